@@ -644,6 +644,10 @@ func (a *ACL) matchingHostVolumeCapabilitySet(name string) (capabilitySet, bool)
 
 var workloadVariablesCapabilitySet = capabilitySet{"read": struct{}{}, "list": struct{}{}}
 
+// workloadLeadershipCapabilitySet allows workloads to hold the locks of the
+// leader elections of their job.
+var workloadLeadershipCapabilitySet = capabilitySet{"read": struct{}{}, "list": struct{}{}, "write": struct{}{}}
+
 // matchingVariablesCapabilitySet looks for a capabilitySet in the following order:
 // - matching the namespace and path from a policy
 // - automatic access based on the claim
@@ -666,6 +670,10 @@ func (a *ACL) matchingVariablesCapabilitySet(ns, path string, claim *ACLClaim) (
 			return workloadVariablesCapabilitySet, true
 		default:
 		}
+		// Workloads can hold the locks of the leader elections of their job
+		if strings.HasPrefix(path, fmt.Sprintf("nomad/jobs/%s/leadership/", claim.Job)) {
+			return workloadLeadershipCapabilitySet, true
+		}
 	}
 
 	// We didn't find a concrete match, so lets try and evaluate globs.
@@ -904,6 +904,46 @@ func TestVariablesMatching(t *testing.T) {
 			claim: &ACLClaim{Namespace: "ns", Job: "example", Group: "foo", Task: "bar"},
 			allow: true,
 		},
+		{
+			name: "claim can write job leadership lock",
+			policy: `namespace "ns" {
+					variables { path "other/*" { capabilities = ["read"] }}}`,
+			ns:    "ns",
+			path:  "nomad/jobs/example/leadership/primary",
+			op:    "write",
+			claim: &ACLClaim{Namespace: "ns", Job: "example", Group: "foo", Task: "bar"},
+			allow: true,
+		},
+		{
+			name: "claim cannot destroy job leadership lock",
+			policy: `namespace "ns" {
+					variables { path "other/*" { capabilities = ["read"] }}}`,
+			ns:    "ns",
+			path:  "nomad/jobs/example/leadership/primary",
+			op:    "destroy",
+			claim: &ACLClaim{Namespace: "ns", Job: "example", Group: "foo", Task: "bar"},
+			allow: false,
+		},
+		{
+			name: "claim cannot write other job leadership lock",
+			policy: `namespace "ns" {
+					variables { path "other/*" { capabilities = ["read"] }}}`,
+			ns:    "ns",
+			path:  "nomad/jobs/other/leadership/primary",
+			op:    "write",
+			claim: &ACLClaim{Namespace: "ns", Job: "example", Group: "foo", Task: "bar"},
+			allow: false,
+		},
+		{
+			name: "claim cannot write job variable",
+			policy: `namespace "ns" {
+					variables { path "other/*" { capabilities = ["read"] }}}`,
+			ns:    "ns",
+			path:  "nomad/jobs/example",
+			op:    "write",
+			claim: &ACLClaim{Namespace: "ns", Job: "example", Group: "foo", Task: "bar"},
+			allow: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -34,6 +34,7 @@ const (
 	NamespaceCapabilityListJobs             = "list-jobs"
 	NamespaceCapabilityParseJob             = "parse-job"
 	NamespaceCapabilityReadJob              = "read-job"
+	NamespaceCapabilityReadJobSourceSecrets = "read-job-source-secrets"
 	NamespaceCapabilitySubmitJob            = "submit-job"
 	NamespaceCapabilityDispatchJob          = "dispatch-job"
 	NamespaceCapabilityReadLogs             = "read-logs"
@@ -56,6 +57,7 @@ const (
 	NamespaceCapabilityReadScalingPolicy    = "read-scaling-policy"
 	NamespaceCapabilityReadJobScaling       = "read-job-scaling"
 	NamespaceCapabilityScaleJob             = "scale-job"
+	NamespaceCapabilityFreezeJob            = "freeze-job"
 	NamespaceCapabilitySubmitRecommendation = "submit-recommendation"
 )
 
@@ -207,12 +209,12 @@ func (p *PluginPolicy) isValid() bool {
 // isNamespaceCapabilityValid ensures the given capability is valid for a namespace policy
 func isNamespaceCapabilityValid(cap string) bool {
 	switch cap {
-	case NamespaceCapabilityDeny, NamespaceCapabilityParseJob, NamespaceCapabilityListJobs, NamespaceCapabilityReadJob,
+	case NamespaceCapabilityDeny, NamespaceCapabilityParseJob, NamespaceCapabilityListJobs, NamespaceCapabilityReadJob, NamespaceCapabilityReadJobSourceSecrets,
 		NamespaceCapabilitySubmitJob, NamespaceCapabilityDispatchJob, NamespaceCapabilityReadLogs,
 		NamespaceCapabilityReadFS, NamespaceCapabilityAllocLifecycle,
 		NamespaceCapabilityAllocExec, NamespaceCapabilityAllocNodeExec,
 		NamespaceCapabilityCSIReadVolume, NamespaceCapabilityCSIWriteVolume, NamespaceCapabilityCSIListVolume, NamespaceCapabilityCSIMountVolume, NamespaceCapabilityCSIRegisterPlugin,
-		NamespaceCapabilityListScalingPolicies, NamespaceCapabilityReadScalingPolicy, NamespaceCapabilityReadJobScaling, NamespaceCapabilityScaleJob, NamespaceCapabilityHostVolumeCreate, NamespaceCapabilityHostVolumeRegister, NamespaceCapabilityHostVolumeWrite, NamespaceCapabilityHostVolumeRead:
+		NamespaceCapabilityListScalingPolicies, NamespaceCapabilityReadScalingPolicy, NamespaceCapabilityReadJobScaling, NamespaceCapabilityScaleJob, NamespaceCapabilityFreezeJob, NamespaceCapabilityHostVolumeCreate, NamespaceCapabilityHostVolumeRegister, NamespaceCapabilityHostVolumeWrite, NamespaceCapabilityHostVolumeRead:
 		return true
 	// Separate the enterprise-only capabilities
 	case NamespaceCapabilitySentinelOverride, NamespaceCapabilitySubmitRecommendation:
@@ -255,6 +257,7 @@ func expandNamespacePolicy(policy string) []string {
 	write = append(write, []string{
 		NamespaceCapabilityScaleJob,
 		NamespaceCapabilitySubmitJob,
+		NamespaceCapabilityReadJobSourceSecrets,
 		NamespaceCapabilityDispatchJob,
 		NamespaceCapabilityReadLogs,
 		NamespaceCapabilityReadFS,
@@ -264,6 +267,7 @@ func expandNamespacePolicy(policy string) []string {
 		NamespaceCapabilityCSIWriteVolume,
 		NamespaceCapabilitySubmitRecommendation,
 		NamespaceCapabilityHostVolumeCreate,
+		NamespaceCapabilityFreezeJob,
 	}...)
 
 	switch policy {
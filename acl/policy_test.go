@@ -136,6 +136,7 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityHostVolumeRead,
 							NamespaceCapabilityScaleJob,
 							NamespaceCapabilitySubmitJob,
+							NamespaceCapabilityReadJobSourceSecrets,
 							NamespaceCapabilityDispatchJob,
 							NamespaceCapabilityReadLogs,
 							NamespaceCapabilityReadFS,
@@ -145,6 +146,7 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityCSIWriteVolume,
 							NamespaceCapabilitySubmitRecommendation,
 							NamespaceCapabilityHostVolumeCreate,
+							NamespaceCapabilityFreezeJob,
 							NamespaceCapabilityHostVolumeRead,
 						},
 					},
@@ -360,6 +362,7 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityHostVolumeRead,
 							NamespaceCapabilityScaleJob,
 							NamespaceCapabilitySubmitJob,
+							NamespaceCapabilityReadJobSourceSecrets,
 							NamespaceCapabilityDispatchJob,
 							NamespaceCapabilityReadLogs,
 							NamespaceCapabilityReadFS,
@@ -369,6 +372,7 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityCSIWriteVolume,
 							NamespaceCapabilitySubmitRecommendation,
 							NamespaceCapabilityHostVolumeCreate,
+							NamespaceCapabilityFreezeJob,
 							NamespaceCapabilityHostVolumeRead,
 						},
 					},
@@ -492,7 +492,7 @@ func TestACLTokens_BootstrapInvalidToken(t *testing.T) {
 	bootkn := "badtoken"
 	// Bootstrap with invalid token
 	_, _, err := at.BootstrapOpts(bootkn, nil)
-	must.EqError(t, err, "Unexpected response code: 400 (invalid acl token)")
+	must.EqError(t, err, "Unexpected response code: 400 (invalid acl token) [NOMAD-E1001]")
 }
 
 func TestACLTokens_BootstrapValidToken(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Agent encapsulates an API client which talks to Nomad's
@@ -64,6 +65,18 @@ func (a *Agent) Self() (*AgentSelf, error) {
 	return out, nil
 }
 
+// EffectiveConfig returns the configuration of the agent after all
+// configuration files, command line flags and environment variables have been
+// merged, with secrets redacted.
+func (a *Agent) EffectiveConfig(q *QueryOptions) (*AgentEffectiveConfig, error) {
+	var resp AgentEffectiveConfig
+	_, err := a.client.query("/v1/agent/config-effective", &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // populateCache is used to insert various pieces of static
 // data into the agent handle. This is used during subsequent
 // lookups for the same data later on to save the round trip.
@@ -394,6 +407,62 @@ func (a *Agent) Trace(opts PprofOptions, q *QueryOptions) ([]byte, error) {
 	return a.pprofRequest("trace", opts, q)
 }
 
+// SchedulerTrace records the timing of the evaluations processed by the
+// scheduler workers of a given server, along with a CPU profile of the
+// server. The trace will run for the amount of seconds passed in or default
+// to 1. Traces can't target nodes.
+//
+// The call blocks until the trace finishes.
+func (a *Agent) SchedulerTrace(opts PprofOptions, q *QueryOptions) (*SchedulerTrace, error) {
+	body, err := a.pprofRequest("scheduler-trace", opts, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var trace SchedulerTrace
+	if err := json.Unmarshal(body, &trace); err != nil {
+		return nil, err
+	}
+	return &trace, nil
+}
+
+// SchedulerTrace is the result of a scheduler trace run on a server.
+type SchedulerTrace struct {
+	ServerID  string
+	StartTime time.Time
+	Duration  time.Duration
+
+	// Profile is the CPU profile captured during the trace. ProfileError is
+	// set if the profile could not be captured.
+	Profile      []byte
+	ProfileError string
+
+	// Evals is the timing of the evaluations processed during the trace.
+	// Dropped is the number of evaluations that were not recorded because
+	// the trace reached its limit.
+	Evals   []*SchedulerEvalTrace
+	Dropped int
+}
+
+// SchedulerEvalTrace is the timing of the processing of an evaluation by a
+// scheduler worker.
+type SchedulerEvalTrace struct {
+	EvalID             string
+	Namespace          string
+	JobID              string
+	Type               string
+	TriggeredBy        string
+	WorkerID           string
+	DequeueTime        time.Time
+	SnapshotDuration   time.Duration
+	ScheduleDuration   time.Duration
+	PlanSubmitDuration time.Duration
+	PlanWaitDuration   time.Duration
+	Plans              int
+	TotalDuration      time.Duration
+	Acked              bool
+}
+
 // Lookup returns a runtime/pprof profile using pprof.Lookup to determine
 // which profile to run. Accepts a client or server ID but not both simultaneously.
 //
@@ -449,6 +518,17 @@ type AgentSelf struct {
 	Stats  map[string]map[string]string `json:"stats"`
 }
 
+// AgentEffectiveConfig is the merged configuration of an agent.
+type AgentEffectiveConfig struct {
+	// Config is the merged configuration, with secrets redacted.
+	Config map[string]interface{}
+
+	// Sources maps the dotted path of the configuration values that are not
+	// left to their defaults to the configuration file, "command line" or
+	// "derived" source that set them.
+	Sources map[string]string
+}
+
 // AgentMember represents a cluster member known to the agent
 type AgentMember struct {
 	Name        string
@@ -518,6 +598,26 @@ type HostDataResponse struct {
 	HostData *HostData `json:",omitempty"`
 }
 
+// ReloadStatus returns the outcome of the last configuration reload of the
+// agent, including the configuration sections that require a restart.
+func (a *Agent) ReloadStatus(q *QueryOptions) (*AgentReloadStatus, error) {
+	var resp AgentReloadStatus
+	_, err := a.client.query("/v1/agent/reload", &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AgentReloadStatus describes the outcome of the last configuration reload of
+// an agent.
+type AgentReloadStatus struct {
+	ReloadTime      time.Time
+	Applied         []string
+	RestartRequired []string
+	Error           string
+}
+
 // GetSchedulerWorkerConfig returns the targeted agent's worker pool configuration
 func (a *Agent) GetSchedulerWorkerConfig(q *QueryOptions) (*SchedulerWorkerPoolArgs, error) {
 	var resp AgentSchedulerWorkerConfigResponse
@@ -5,8 +5,11 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -37,6 +40,12 @@ const (
 	AllocRestartReasonWithinPolicy = "Restart within policy"
 )
 
+const (
+	AllocBatchActionStop    = "stop"
+	AllocBatchActionSignal  = "signal"
+	AllocBatchActionRestart = "restart"
+)
+
 // Allocations is used to query the alloc-related endpoints.
 type Allocations struct {
 	client *Client
@@ -84,6 +93,11 @@ func (a *Allocations) Info(allocID string, q *QueryOptions) (*Allocation, *Query
 //     If tty is true, then streams need to point to a tty that's alive for the whole process
 //   - terminalSizeCh: A channel to send new tty terminal sizes
 //
+// The session output is recorded to the log directory of the allocation if
+// the "record" query parameter is set to true, and shared with read-only
+// viewers attaching with ExecAttach if the "share" query parameter is set to
+// a session name.
+//
 // The call blocks until command terminates (or an error occurs), and returns the exit code.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -112,6 +126,31 @@ func (a *Allocations) Exec(ctx context.Context,
 	return s.run(ctx)
 }
 
+// ExecAttach is used to attach to an exec session shared by another user
+// under the given name, as a read-only viewer. The output of the session is
+// written to stdout and stderr.
+//
+// The call blocks until the session ends (or an error occurs), and returns
+// the exit code of the session.
+func (a *Allocations) ExecAttach(ctx context.Context,
+	alloc *Allocation, task, session string,
+	stdout, stderr io.Writer, q *QueryOptions) (exitCode int, err error) {
+
+	s := &execSession{
+		client: a.client,
+		alloc:  alloc,
+		task:   task,
+		attach: session,
+
+		stdout: stdout,
+		stderr: stderr,
+
+		q: q,
+	}
+
+	return s.run(ctx)
+}
+
 // Stats gets allocation resource usage statistics about an allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -123,6 +162,63 @@ func (a *Allocations) Stats(alloc *Allocation, q *QueryOptions) (*AllocResourceU
 	return &resp, err
 }
 
+// StatsStream streams the resource usage of an allocation at the given
+// interval, raised by the client to its stats collection interval if lower.
+// If task is set only the usage of that task is streamed. The stats channel is
+// closed when the context is canceled or the stream ends, and errors are sent
+// on the error channel.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) StatsStream(ctx context.Context, alloc *Allocation, task string,
+	interval time.Duration, q *QueryOptions) (<-chan *AllocResourceUsage, <-chan error) {
+
+	errCh := make(chan error, 1)
+
+	reqPath := "/v1/client/allocation/" + alloc.ID + "/stats"
+	r, err := queryClientNode(a.client, alloc, reqPath, q.WithContext(ctx),
+		func(q *QueryOptions) {
+			q.Params["stream"] = "true"
+			q.Params["interval"] = interval.String()
+			if task != "" {
+				q.Params["task"] = task
+			}
+		})
+	if err != nil {
+		errCh <- err
+		return nil, errCh
+	}
+
+	stats := make(chan *AllocResourceUsage, 10)
+
+	go func() {
+		defer r.Close()
+
+		dec := json.NewDecoder(r)
+		for {
+			var usage AllocResourceUsage
+			if err := dec.Decode(&usage); err != nil {
+				if ctx.Err() != nil || err == io.EOF || err == io.ErrClosedPipe {
+					close(stats)
+				} else {
+					errCh <- fmt.Errorf("failed to decode allocation stats: %w", err)
+				}
+				return
+			}
+
+			select {
+			case stats <- &usage:
+			case <-ctx.Done():
+				close(stats)
+				return
+			}
+		}
+	}()
+
+	return stats, errCh
+}
+
 // Checks gets status information for nomad service checks that exist in the allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -134,6 +230,30 @@ func (a *Allocations) Checks(allocID string, q *QueryOptions) (AllocCheckStatuse
 	return resp, err
 }
 
+// TaskEnv gets the environment variables and rendered template files a task of
+// the allocation is running with. Values that may hold secrets are redacted
+// unless the token has the alloc-exec capability.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) TaskEnv(allocID, task string, q *QueryOptions) (*AllocTaskEnv, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	q.Params["task"] = task
+
+	var resp AllocTaskEnv
+	_, err := a.client.query("/v1/client/allocation/"+allocID+"/env", &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GC forces a garbage collection of client state for an allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -235,6 +355,81 @@ func (a *Allocations) Signal(alloc *Allocation, q *QueryOptions, task, signal st
 	return err
 }
 
+// Batch stops, signals, or restarts all the allocations matching the filter
+// of the request. The server applies the action with bounded parallelism and
+// returns the outcome for each allocation.
+func (a *Allocations) Batch(req *AllocBatchRequest, w *WriteOptions) (*AllocBatchResponse, *WriteMeta, error) {
+	var resp AllocBatchResponse
+	wm, err := a.client.put("/v1/allocations/batch", req, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// AllocBatchRequest is used to stop, signal, or restart all the allocations
+// matching a filter.
+type AllocBatchRequest struct {
+	// Action is one of "stop", "signal" or "restart".
+	Action string
+
+	// JobID, TaskGroup and NodeID filter the allocations the action is
+	// applied to. At least one of JobID or NodeID must be set.
+	JobID     string `json:",omitempty"`
+	TaskGroup string `json:",omitempty"`
+	NodeID    string `json:",omitempty"`
+
+	// Task is the task to signal or restart.
+	Task string `json:",omitempty"`
+
+	// Signal is the signal to send for the signal action.
+	Signal string `json:",omitempty"`
+
+	// AllTasks restarts all tasks of the allocations for the restart action.
+	AllTasks bool `json:",omitempty"`
+
+	// NoShutdownDelay skips the shutdown delay for the stop action.
+	NoShutdownDelay bool `json:",omitempty"`
+
+	// Parallelism is the number of allocations signaled or restarted
+	// concurrently. Defaults to 8.
+	Parallelism int `json:",omitempty"`
+}
+
+// AllocBatchResult is the result of a batch action for a single allocation.
+type AllocBatchResult struct {
+	AllocID string
+	NodeID  string
+	Error   string
+}
+
+// AllocBatchResponse is the response to a batch allocation request.
+type AllocBatchResponse struct {
+	Results []*AllocBatchResult
+	EvalIDs []string
+	WriteMeta
+}
+
+// AllocTaskEnvRedacted replaces the values of environment variables that may
+// hold secrets.
+const AllocTaskEnvRedacted = "<redacted>"
+
+// AllocTaskEnv is the environment and the rendered templates of a task.
+type AllocTaskEnv struct {
+	Env       map[string]string
+	Templates []*TaskTemplateFile
+}
+
+// TaskTemplateFile is the file rendered by a task template. Redacted is set
+// instead of Contents if the file may hold secrets, and Error is set if the
+// file could not be read.
+type TaskTemplateFile struct {
+	DestPath string
+	Contents string
+	Redacted bool
+	Error    string
+}
+
 // SetPauseState sets the schedule behavior of one task in the allocation.
 func (a *Allocations) SetPauseState(alloc *Allocation, q *QueryOptions, task, state string) error {
 	req := AllocPauseRequest{
@@ -264,42 +459,84 @@ func (a *Allocations) Services(allocID string, q *QueryOptions) ([]*ServiceRegis
 	return resp, qm, err
 }
 
+// RegisterResult is used to register the result of a task of the allocation.
+// The task may be left empty when using the workload identity of the task.
+func (a *Allocations) RegisterResult(allocID, task string, result *TaskResult, q *WriteOptions) (*WriteMeta, error) {
+	endpoint := "/v1/allocation/" + allocID + "/result"
+	if task != "" {
+		endpoint += "?task=" + url.QueryEscape(task)
+	}
+	return a.client.put(endpoint, result, nil, q)
+}
+
+// TaskResult is a small result registered by a task before it exits.
+type TaskResult struct {
+	Summary      string
+	OutputURI    string
+	Meta         map[string]string
+	RegisterTime int64
+}
+
+// AllocTaskResult is the result registered by a task of an allocation.
+type AllocTaskResult struct {
+	AllocID   string
+	AllocName string
+	TaskName  string
+	Result    *TaskResult
+}
+
 // Allocation is used for serialization of allocations.
 type Allocation struct {
-	ID                    string
-	Namespace             string
-	EvalID                string
-	Name                  string
-	NodeID                string
-	NodeName              string
-	JobID                 string
-	Job                   *Job
-	TaskGroup             string
-	Resources             *Resources
-	TaskResources         map[string]*Resources
-	AllocatedResources    *AllocatedResources
-	Services              map[string]string
-	Metrics               *AllocationMetric
-	DesiredStatus         string
-	DesiredDescription    string
-	DesiredTransition     DesiredTransition
-	ClientStatus          string
-	ClientDescription     string
-	TaskStates            map[string]*TaskState
-	DeploymentID          string
-	DeploymentStatus      *AllocDeploymentStatus
-	FollowupEvalID        string
-	PreviousAllocation    string
-	NextAllocation        string
-	RescheduleTracker     *RescheduleTracker
-	NetworkStatus         *AllocNetworkStatus
-	PreemptedAllocations  []string
-	PreemptedByAllocation string
-	CreateIndex           uint64
-	ModifyIndex           uint64
-	AllocModifyIndex      uint64
-	CreateTime            int64
-	ModifyTime            int64
+	ID                      string
+	Namespace               string
+	EvalID                  string
+	Name                    string
+	NodeID                  string
+	NodeName                string
+	JobID                   string
+	Job                     *Job
+	TaskGroup               string
+	Resources               *Resources
+	TaskResources           map[string]*Resources
+	AllocatedResources      *AllocatedResources
+	Services                map[string]string
+	Metrics                 *AllocationMetric
+	DesiredStatus           string
+	DesiredDescription      string
+	DesiredTransition       DesiredTransition
+	ClientStatus            string
+	ClientDescription       string
+	TaskStates              map[string]*TaskState
+	TaskResults             map[string]*TaskResult
+	DeploymentID            string
+	DeploymentStatus        *AllocDeploymentStatus
+	FollowupEvalID          string
+	PreviousAllocation      string
+	NextAllocation          string
+	RescheduleTracker       *RescheduleTracker
+	NetworkStatus           *AllocNetworkStatus
+	PreservedNetworkAddress string
+	PreemptedAllocations    []string
+	PreemptedByAllocation   string
+	PreemptionDeadline      int64
+	CreateIndex             uint64
+	ModifyIndex             uint64
+	AllocModifyIndex        uint64
+	CreateTime              int64
+	ModifyTime              int64
+	Timestamps              *AllocTimestamps
+}
+
+// AllocTimestamps records when an allocation went through the steps of its
+// placement and startup, stored as UnixNano. Steps that were not reached yet
+// are zero.
+type AllocTimestamps struct {
+	EvalCreateTime    int64
+	PlanSubmitTime    int64
+	PlanApplyTime     int64
+	ClientReceiveTime int64
+	TaskStartTime     int64
+	FirstHealthyTime  int64
 }
 
 // AllocationMetric is used to deserialize allocation metrics.
@@ -320,6 +557,8 @@ type AllocationMetric struct {
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+	PlanRejections    map[string]string
+	FailedGang        string
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
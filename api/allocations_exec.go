@@ -32,6 +32,9 @@ type execSession struct {
 	command []string
 	action  string
 
+	// attach is the name of the shared session to view, if set
+	attach string
+
 	stdin  io.Reader
 	stdout io.Writer
 	stderr io.Writer
@@ -97,6 +100,9 @@ func (s *execSession) startConnection() (*websocket.Conn, error) {
 	q.Params["tty"] = strconv.FormatBool(s.tty)
 	q.Params["task"] = s.task
 	q.Params["command"] = string(commandBytes)
+	if s.attach != "" {
+		q.Params["attach"] = s.attach
+	}
 	reqPath := fmt.Sprintf("/v1/client/allocation/%s/exec", s.alloc.ID)
 
 	if s.action != "" {
@@ -140,8 +146,11 @@ func (s *execSession) startTransmit(ctx context.Context, conn *websocket.Conn) <
 
 	errCh := make(chan error, 4)
 
-	// propagate stdin
+	// propagate stdin, unless viewing a shared session
 	go func() {
+		if s.stdin == nil {
+			return
+		}
 
 		bytes := make([]byte, 2048)
 		for {
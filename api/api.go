@@ -1225,6 +1225,11 @@ func decodeBody(resp *http.Response, out interface{}) error {
 		}
 		return errors.New("Got 0 byte response with non-nil decode object")
 	default:
+		// The length of compressed responses is unknown, so an empty body
+		// can only be detected by decoding it
+		if out == nil {
+			return nil
+		}
 		dec := json.NewDecoder(resp.Body)
 		return dec.Decode(out)
 	}
@@ -55,6 +55,17 @@ func (d *Deployments) Allocations(deploymentID string, q *QueryOptions) ([]*Allo
 	return resp, qm, nil
 }
 
+// Multiregion is used to retrieve the deployments paired with a multiregion
+// deployment in each region of its job.
+func (d *Deployments) Multiregion(deploymentID string, q *QueryOptions) ([]*MultiregionDeploymentRegion, *QueryMeta, error) {
+	var resp []*MultiregionDeploymentRegion
+	qm, err := d.client.query("/v1/deployment/"+deploymentID+"/multiregion", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Fail is used to fail the given deployment.
 func (d *Deployments) Fail(deploymentID string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
 	var resp DeploymentUpdateResponse
@@ -110,6 +121,26 @@ func (d *Deployments) PromoteGroups(deploymentID string, groups []string, q *Wri
 	return &resp, wm, nil
 }
 
+// PromoteCanaries is used to promote a subset of the canaries in the passed
+// groups in the given deployment, or in all groups if none are passed. Either
+// count or percent of the placed canaries of each group are promoted, and the
+// groups stay unpromoted until their other canaries are promoted.
+func (d *Deployments) PromoteCanaries(deploymentID string, groups []string, count, percent int, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
+	var resp DeploymentUpdateResponse
+	req := &DeploymentPromoteRequest{
+		DeploymentID:  deploymentID,
+		All:           len(groups) == 0,
+		Groups:        groups,
+		CanaryCount:   count,
+		CanaryPercent: percent,
+	}
+	wm, err := d.client.put("/v1/deployment/promote/"+deploymentID, req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Unblock is used to unblock the given deployment.
 func (d *Deployments) Unblock(deploymentID string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
 	var resp DeploymentUpdateResponse
@@ -199,18 +230,40 @@ type Deployment struct {
 	ModifyTime int64
 }
 
+// MultiregionDeploymentRegion is the state of a multiregion deployment in one
+// region.
+type MultiregionDeploymentRegion struct {
+	// Region is the name of the region.
+	Region string
+
+	// Deployment is the deployment of the job version in the region. It is
+	// nil if the deployment couldn't be found.
+	Deployment *Deployment
+
+	// Blocking is true if the region hasn't finished its part of the
+	// deployment, which keeps the peer regions blocked.
+	Blocking bool
+
+	// Error describes why the deployment couldn't be retrieved.
+	Error string
+}
+
 // DeploymentState tracks the state of a deployment for a given task group.
 type DeploymentState struct {
-	PlacedCanaries    []string
-	AutoRevert        bool
-	ProgressDeadline  time.Duration
-	RequireProgressBy time.Time
-	Promoted          bool
-	DesiredCanaries   int
-	DesiredTotal      int
-	PlacedAllocs      int
-	HealthyAllocs     int
-	UnhealthyAllocs   int
+	PlacedCanaries            []string
+	PromotedCanaries          []string
+	AutoRevert                bool
+	AutoPromote               bool
+	AutoPromoteThreshold      int
+	AutoPromoteMinHealthyTime time.Duration
+	ProgressDeadline          time.Duration
+	RequireProgressBy         time.Time
+	Promoted                  bool
+	DesiredCanaries           int
+	DesiredTotal              int
+	PlacedAllocs              int
+	HealthyAllocs             int
+	UnhealthyAllocs           int
 }
 
 // DeploymentIndexSort is a wrapper to sort deployments by CreateIndex. We
@@ -265,6 +318,14 @@ type DeploymentPromoteRequest struct {
 	// Groups is used to set the promotion status per task group
 	Groups []string
 
+	// CanaryCount is the number of healthy canaries to promote in each task
+	// group, leaving the task groups unpromoted
+	CanaryCount int
+
+	// CanaryPercent is the percentage of the placed canaries to promote in
+	// each task group, as an alternative to CanaryCount
+	CanaryPercent int
+
 	// PromotedAt is the timestamp stored as Unix nano
 	PromotedAt int64
 
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import "errors"
+
+// ErrorCodeHeader is the HTTP response header in which Nomad returns the error
+// code of failed requests.
+const ErrorCodeHeader = "X-Nomad-Error-Code"
+
+// Error codes are stable identifiers for classes of errors returned by the
+// Nomad HTTP API. Unlike error messages, they do not change between releases
+// and can be used to branch on the class of an error.
+const (
+	// Generic error codes, derived from the HTTP status code of the response
+	// when the error doesn't match a more specific error code.
+	ErrCodeInternal         = "NOMAD-E1000"
+	ErrCodeBadRequest       = "NOMAD-E1001"
+	ErrCodePermissionDenied = "NOMAD-E1002"
+	ErrCodeNotFound         = "NOMAD-E1003"
+	ErrCodeConflict         = "NOMAD-E1004"
+	ErrCodeMethodNotAllowed = "NOMAD-E1005"
+	ErrCodeTooManyRequests  = "NOMAD-E1006"
+	ErrCodeUnavailable      = "NOMAD-E1007"
+
+	// Specific error codes.
+	ErrCodeTokenNotFound           = "NOMAD-E2001"
+	ErrCodeTokenExpired            = "NOMAD-E2002"
+	ErrCodeTokenInvalid            = "NOMAD-E2003"
+	ErrCodeNoLeader                = "NOMAD-E2004"
+	ErrCodeNoRegionPath            = "NOMAD-E2005"
+	ErrCodeNoNodeConn              = "NOMAD-E2006"
+	ErrCodeJobRegistrationDisabled = "NOMAD-E2007"
+	ErrCodeIncompatibleFiltering   = "NOMAD-E2008"
+	ErrCodeUnknownJob              = "NOMAD-E2009"
+	ErrCodeUnknownAllocation       = "NOMAD-E2010"
+	ErrCodeUnknownNode             = "NOMAD-E2011"
+	ErrCodeUnknownEvaluation       = "NOMAD-E2012"
+	ErrCodeUnknownDeployment       = "NOMAD-E2013"
+	ErrCodeDeploymentTerminal      = "NOMAD-E2014"
+)
+
+// ErrorCode returns the error code returned by the Nomad HTTP API for err, or
+// an empty string if err is not an API error or the agent did not return an
+// error code.
+func ErrorCode(err error) string {
+	var ure UnexpectedResponseError
+	if errors.As(err, &ure) {
+		return ure.ErrorCode()
+	}
+	return ""
+}
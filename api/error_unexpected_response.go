@@ -20,6 +20,7 @@ type UnexpectedResponseError struct {
 	statusCode int
 	statusText string
 	body       string
+	errorCode  string
 	err        error
 	additional error
 }
@@ -32,6 +33,8 @@ func (e UnexpectedResponseError) HasStatusText() bool       { return e.statusTex
 func (e UnexpectedResponseError) StatusText() string        { return e.statusText }
 func (e UnexpectedResponseError) HasBody() bool             { return e.body != "" }
 func (e UnexpectedResponseError) Body() string              { return e.body }
+func (e UnexpectedResponseError) HasErrorCode() bool        { return e.errorCode != "" }
+func (e UnexpectedResponseError) ErrorCode() string         { return e.errorCode }
 func (e UnexpectedResponseError) HasError() bool            { return e.err != nil }
 func (e UnexpectedResponseError) Unwrap() error             { return e.err }
 func (e UnexpectedResponseError) HasAdditional() bool       { return e.additional != nil }
@@ -73,6 +76,9 @@ func (e UnexpectedResponseError) Error() string {
 	if e.HasBody() {
 		eTxt.WriteString(fmt.Sprintf("(%s)", e.body))
 	}
+	if e.HasErrorCode() {
+		eTxt.WriteString(fmt.Sprintf(" [%s]", e.errorCode))
+	}
 
 	if e.HasAdditional() {
 		eTxt.WriteString(fmt.Sprintf(". Additionally, an error occurred while constructing this error (%s); the body might be truncated or missing.", e.additional.Error()))
@@ -133,6 +139,7 @@ func fromHTTPResponse(resp *http.Response) unexpectedResponseErrorSource {
 			u.statusCode = resp.StatusCode
 			u.statusText = strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode)))
 			u.body = body
+			u.errorCode = resp.Header.Get(ErrorCodeHeader)
 		}
 		return u
 	}
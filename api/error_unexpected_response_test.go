@@ -98,6 +98,28 @@ func TestUnexpectedResponseError(t *testing.T) {
 		must.Eq(t, "Namespace not found", ure.Body())
 	})
 
+	// ErrorCode tests that the error code returned by the agent is available
+	// on the UnexpectedResponseError and included in its message.
+	t.Run("ErrorCode", func(t *testing.T) {
+		testutil.Parallel(t)
+		n, _, err := c.Namespaces().Info("forbidden", nil)
+		must.Nil(t, n)
+		must.Error(t, err)
+
+		ure, ok := err.(api.UnexpectedResponseError)
+		must.True(t, ok)
+
+		must.True(t, ure.HasErrorCode())
+		must.Eq(t, api.ErrCodePermissionDenied, ure.ErrorCode())
+		must.Eq(t, api.ErrCodePermissionDenied, api.ErrorCode(fmt.Errorf("wrapped: %w", err)))
+		must.EqError(t, err, "Unexpected response code: 403 (Permission denied) [NOMAD-E1002]")
+
+		// Errors without an error code
+		_, _, err = c.Namespaces().Info("wat", nil)
+		must.Error(t, err)
+		must.Eq(t, "", api.ErrorCode(err))
+	})
+
 	// EarlyClose tests what happens when an error occurs during the building of
 	// the UnexpectedResponseError using FromHTTPRequest.
 	t.Run("EarlyClose", func(t *testing.T) {
@@ -132,6 +154,7 @@ func mockserver(t *testing.T) string {
 	mux := http.NewServeMux()
 	mux.Handle("/v1/namespace/earlyClose", closingHandler(http.StatusInternalServerError, mockNamespaceBody))
 	mux.Handle("/v1/namespace/badStatus", testHandler(http.StatusAccepted, mockNamespaceBody))
+	mux.Handle("/v1/namespace/forbidden", testErrorCodeHandler(http.StatusForbidden, "Permission denied", api.ErrCodePermissionDenied))
 	mux.Handle("/v1/namespace/default", testHandler(http.StatusOK, mockNamespaceBody))
 	mux.Handle("/v1/namespace/", testNotFoundHandler("Namespace not found"))
 	mux.Handle("/v1/namespace", http.NotFoundHandler())
@@ -177,6 +200,15 @@ func testNotFoundHandler(b string) http.Handler { return testHandler(http.Status
 // testNotFoundHandler creates a testHandler preconfigured with status code 200.
 func testOKHandler(b string) http.Handler { return testHandler(http.StatusOK, b) }
 
+// testErrorCodeHandler creates a testHandler that also sets the error code
+// header.
+func testErrorCodeHandler(sc int, b, code string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.ErrorCodeHeader, code)
+		testHandler(sc, b).ServeHTTP(w, r)
+	})
+}
+
 // testHandler is a helper function that writes a Nomad-like server response
 // with the necessary headers to make the API client happy
 func testHandler(sc int, b string) http.Handler {
@@ -122,12 +122,31 @@ type Evaluation struct {
 	AnnotatePlan         bool
 	QueuedAllocations    map[string]int
 	SnapshotIndex        uint64
+	WorkerID             string
+	DequeueTime          int64
+	SnapshotWaitTime     time.Duration
+	PlanTime             time.Duration
+	RaftApplyTime        time.Duration
+	ForwardedFromRegion  string
+	ForwardedTime        int64
 	CreateIndex          uint64
 	ModifyIndex          uint64
 	CreateTime           int64
 	ModifyTime           int64
 }
 
+// EvaluationTransition describes the change of status of an evaluation that
+// triggered an Evaluation event.
+type EvaluationTransition struct {
+	PreviousStatus    string
+	Status            string
+	StatusDescription string
+	TriggeredBy       string
+	WorkerID          string
+	QueueDuration     time.Duration
+	ProcessDuration   time.Duration
+}
+
 // EvaluationStub is used to serialize parts of an evaluation returned in the
 // RelatedEvals field of an Evaluation.
 type EvaluationStub struct {
@@ -6,7 +6,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -14,6 +16,9 @@ import (
 )
 
 const (
+	TopicACLPolicy  Topic = "ACLPolicy"
+	TopicACLRole    Topic = "ACLRole"
+	TopicACLToken   Topic = "ACLToken"
 	TopicDeployment Topic = "Deployment"
 	TopicEvaluation Topic = "Evaluation"
 	TopicAllocation Topic = "Allocation"
@@ -30,6 +35,11 @@ type Events struct {
 	Index  uint64
 	Events []Event
 	Err    error
+
+	// ResumeToken is set on the events of a durable subscription. It can be
+	// persisted once the events are processed and passed to
+	// EventStream.StreamResumable to resume the subscription after them.
+	ResumeToken string
 }
 
 // Topic is an event Topic
@@ -50,6 +60,37 @@ type Event struct {
 	Payload    map[string]interface{}
 }
 
+// ACLPolicy returns an ACLPolicy struct from a given event payload. If the
+// Event Topic is ACLPolicy this will return a valid ACLPolicy.
+func (e *Event) ACLPolicy() (*ACLPolicy, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.ACLPolicy, nil
+}
+
+// ACLRole returns an ACLRole struct from a given event payload. If the Event
+// Topic is ACLRole this will return a valid ACLRole.
+func (e *Event) ACLRole() (*ACLRole, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.ACLRole, nil
+}
+
+// ACLToken returns an ACLToken struct from a given event payload. If the
+// Event Topic is ACLToken this will return a valid ACLToken. The SecretID of
+// the token is always redacted.
+func (e *Event) ACLToken() (*ACLToken, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.ACLToken, nil
+}
+
 // Deployment returns a Deployment struct from a given event payload. If the
 // Event Topic is Deployment this will return a valid Deployment
 func (e *Event) Deployment() (*Deployment, error) {
@@ -70,6 +111,17 @@ func (e *Event) Evaluation() (*Evaluation, error) {
 	return out.Evaluation, nil
 }
 
+// EvaluationTransition returns the change of status of the evaluation from a
+// given event payload. If the Event Topic is Evaluation this will return a
+// valid EvaluationTransition.
+func (e *Event) EvaluationTransition() (*EvaluationTransition, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Transition, nil
+}
+
 // Allocation returns a Allocation struct from a given event payload. If the
 // Event Topic is Allocation this will return a valid Allocation.
 func (e *Event) Allocation() (*Allocation, error) {
@@ -121,13 +173,17 @@ func (e *Event) Service() (*ServiceRegistration, error) {
 }
 
 type eventPayload struct {
-	Allocation *Allocation          `mapstructure:"Allocation"`
-	Deployment *Deployment          `mapstructure:"Deployment"`
-	Evaluation *Evaluation          `mapstructure:"Evaluation"`
-	Job        *Job                 `mapstructure:"Job"`
-	Node       *Node                `mapstructure:"Node"`
-	NodePool   *NodePool            `mapstructure:"NodePool"`
-	Service    *ServiceRegistration `mapstructure:"Service"`
+	ACLPolicy  *ACLPolicy            `mapstructure:"ACLPolicy"`
+	ACLRole    *ACLRole              `mapstructure:"ACLRole"`
+	ACLToken   *ACLToken             `mapstructure:"ACLToken"`
+	Allocation *Allocation           `mapstructure:"Allocation"`
+	Deployment *Deployment           `mapstructure:"Deployment"`
+	Evaluation *Evaluation           `mapstructure:"Evaluation"`
+	Job        *Job                  `mapstructure:"Job"`
+	Node       *Node                 `mapstructure:"Node"`
+	NodePool   *NodePool             `mapstructure:"NodePool"`
+	Service    *ServiceRegistration  `mapstructure:"Service"`
+	Transition *EvaluationTransition `mapstructure:"Transition"`
 }
 
 func (e *Event) decodePayload() (*eventPayload, error) {
@@ -168,15 +224,117 @@ func (c *Client) EventStream() *EventStream {
 // Stream establishes a new subscription to Nomad's event stream and streams
 // results back to the returned channel.
 func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, index uint64, q *QueryOptions) (<-chan *Events, error) {
+	return e.stream(ctx, topics, map[string]string{
+		"index": strconv.FormatUint(index, 10),
+	}, q)
+}
+
+// StreamResumable establishes a durable subscription to Nomad's event stream
+// and streams results back to the returned channel. Each set of events carries
+// a ResumeToken; a consumer that persists the token of the events it
+// processed can resume the subscription right after them, possibly from
+// another process, by passing it as resumeToken. An empty resumeToken starts
+// a new subscription with the next events.
+//
+// The subscription reconnects automatically after a disconnect and resumes
+// after the last events sent to the channel. The channel receives an error and
+// is closed if the subscription can't be resumed, such as when the events
+// following the token are no longer retained in the event buffer of the
+// servers.
+func (e *EventStream) StreamResumable(ctx context.Context, topics map[Topic][]string, resumeToken string, q *QueryOptions) (<-chan *Events, error) {
+	// Each connection gets its own context so that its stream is released
+	// once the connection is lost.
+	connect := func(token string) (<-chan *Events, context.CancelFunc, error) {
+		params := map[string]string{"durable": "true"}
+		if token != "" {
+			params["resume_token"] = token
+		}
+		connCtx, cancel := context.WithCancel(ctx)
+		streamCh, err := e.stream(connCtx, topics, params, q)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		return streamCh, cancel, nil
+	}
+
+	streamCh, cancel, err := connect(resumeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsCh := make(chan *Events, 10)
+	go func() {
+		defer close(eventsCh)
+
+		var retry time.Duration
+		for {
+			for events := range streamCh {
+				if events.Err != nil {
+					break
+				}
+				retry = 0
+				resumeToken = events.ResumeToken
+
+				select {
+				case <-ctx.Done():
+					cancel()
+					return
+				case eventsCh <- events:
+				}
+			}
+			cancel()
+
+			// The connection was lost, so reconnect and resume after the
+			// last events sent, unless the subscription can't be resumed.
+			for {
+				retry *= 2
+				if retry < time.Second {
+					retry = time.Second
+				} else if retry > 30*time.Second {
+					retry = 30 * time.Second
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(retry):
+				}
+
+				streamCh, cancel, err = connect(resumeToken)
+				if err == nil {
+					break
+				}
+
+				var respErr UnexpectedResponseError
+				if errors.As(err, &respErr) && respErr.StatusCode() < http.StatusInternalServerError {
+					select {
+					case <-ctx.Done():
+					case eventsCh <- &Events{Err: err}:
+					}
+					return
+				}
+			}
+		}
+	}()
+
+	return eventsCh, nil
+}
+
+func (e *EventStream) stream(ctx context.Context, topics map[Topic][]string, params map[string]string, q *QueryOptions) (<-chan *Events, error) {
 	r, err := e.client.newRequest("GET", "/v1/event/stream")
 	if err != nil {
 		return nil, err
 	}
 	q = q.WithContext(ctx)
-	if q.Params == nil {
-		q.Params = map[string]string{}
+	merged := make(map[string]string, len(q.Params)+len(params))
+	for k, v := range q.Params {
+		merged[k] = v
+	}
+	for k, v := range params {
+		merged[k] = v
 	}
-	q.Params["index"] = strconv.FormatUint(index, 10)
+	q.Params = merged
 	r.setQueryOptions(q)
 
 	// Build topic query params
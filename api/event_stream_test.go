@@ -101,6 +101,71 @@ func TestEvent_Stream(t *testing.T) {
 	}
 }
 
+func TestEvent_StreamResumable(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	// register job to generate events
+	jobs := c.Jobs()
+	job := testJob()
+	resp2, _, err := jobs.Register(job, nil)
+	must.NoError(t, err)
+	must.NotNil(t, resp2)
+
+	events := c.EventStream()
+	topics := map[Topic][]string{
+		TopicJob:        {"*"},
+		TopicEvaluation: {"*"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Start a durable subscription at the first events
+	streamCh, err := events.StreamResumable(ctx, topics, "", nil)
+	must.NoError(t, err)
+
+	var first *Events
+	select {
+	case first = <-streamCh:
+		must.NoError(t, first.Err)
+		must.NotEq(t, "", first.ResumeToken)
+	case <-time.After(5 * time.Second):
+		must.Unreachable(t, must.Sprint("failed waiting for event stream event"))
+	}
+	cancel()
+
+	// Resuming after the first events streams the following ones
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	streamCh, err = events.StreamResumable(ctx, topics, first.ResumeToken, nil)
+	must.NoError(t, err)
+
+	select {
+	case event := <-streamCh:
+		must.NoError(t, event.Err)
+		must.Greater(t, first.Index, event.Index)
+	case <-time.After(5 * time.Second):
+		must.Unreachable(t, must.Sprint("failed waiting for event stream event"))
+	}
+}
+
+func TestEvent_StreamResumable_InvalidToken(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := c.EventStream().StreamResumable(ctx, nil, "invalid", nil)
+	must.ErrorContains(t, err, "invalid resume token")
+}
+
 func TestEvent_Stream_Err_InvalidQueryParam(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -234,6 +299,52 @@ func TestEventStream_PayloadValueHelpers(t *testing.T) {
 		err      string
 		expectFn func(t *testing.T, event Event)
 	}{
+		{
+			desc:  "acl_policy",
+			input: []byte(`{"Topic": "ACLPolicy", "Payload": {"ACLPolicy":{"Name":"readonly","Rules":"node { policy = \"read\" }","ModifyIndex":10}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				must.Eq(t, TopicACLPolicy, event.Topic)
+				p, err := event.ACLPolicy()
+				must.NoError(t, err)
+				must.Eq(t, &ACLPolicy{
+					Name:        "readonly",
+					Rules:       `node { policy = "read" }`,
+					ModifyIndex: 10,
+				}, p)
+			},
+		},
+		{
+			desc:  "acl_role",
+			input: []byte(`{"Topic": "ACLRole", "Payload": {"ACLRole":{"ID":"some-id","Name":"ops","Policies":[{"Name":"readonly"}]}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				must.Eq(t, TopicACLRole, event.Topic)
+				r, err := event.ACLRole()
+				must.NoError(t, err)
+				must.Eq(t, &ACLRole{
+					ID:       "some-id",
+					Name:     "ops",
+					Policies: []*ACLRolePolicyLink{{Name: "readonly"}},
+				}, r)
+			},
+		},
+		{
+			desc:  "acl_token",
+			input: []byte(`{"Topic": "ACLToken", "Payload": {"ACLToken":{"AccessorID":"some-id","SecretID":"","Type":"client","Policies":["readonly"],"CreateTime":"2020-11-05T11:52:54.370774000-05:00"}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				createTime, err := time.Parse(time.RFC3339, "2020-11-05T11:52:54.370774000-05:00")
+				must.NoError(t, err)
+				must.Eq(t, TopicACLToken, event.Topic)
+
+				token, err := event.ACLToken()
+				must.NoError(t, err)
+				must.Eq(t, &ACLToken{
+					AccessorID: "some-id",
+					Type:       "client",
+					Policies:   []string{"readonly"},
+					CreateTime: createTime,
+				}, token)
+			},
+		},
 		{
 			desc:  "deployment",
 			input: []byte(`{"Topic": "Deployment", "Payload": {"Deployment":{"ID":"some-id","JobID":"some-job-id", "TaskGroups": {"tg1": {"RequireProgressBy": "2020-11-05T11:52:54.370774000-05:00"}}}}}`),
@@ -268,6 +379,22 @@ func TestEventStream_PayloadValueHelpers(t *testing.T) {
 				}, eval)
 			},
 		},
+		{
+			desc:  "evaluation_transition",
+			input: []byte(`{"Topic": "Evaluation", "Payload": {"Evaluation":{"ID":"some-id"},"Transition":{"PreviousStatus":"pending","Status":"complete","WorkerID":"some-worker-id","QueueDuration":2000000,"ProcessDuration":1000000}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				must.Eq(t, TopicEvaluation, event.Topic)
+				transition, err := event.EvaluationTransition()
+				must.NoError(t, err)
+				must.Eq(t, &EvaluationTransition{
+					PreviousStatus:  "pending",
+					Status:          "complete",
+					WorkerID:        "some-worker-id",
+					QueueDuration:   2 * time.Millisecond,
+					ProcessDuration: time.Millisecond,
+				}, transition)
+			},
+		},
 		{
 			desc:  "allocation",
 			input: []byte(`{"Topic": "Allocation", "Payload": {"Allocation":{"ID":"some-id","Namespace":"some-namespace-id"}}}`),
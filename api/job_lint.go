@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+const (
+	// JobLintSeverityInfo is the severity of findings that are only
+	// informative.
+	JobLintSeverityInfo = "info"
+
+	// JobLintSeverityWarning is the severity of findings that likely make the
+	// job behave unexpectedly.
+	JobLintSeverityWarning = "warning"
+
+	// JobLintSeverityError is the severity of findings that must be fixed.
+	JobLintSeverityError = "error"
+)
+
+// JobLintRule is a user-supplied lint rule. A finding is reported for each
+// object of the rule scope matched by the rule filter.
+type JobLintRule struct {
+	Name        string `hcl:"name,label"`
+	Description string `hcl:"description,optional"`
+
+	// Severity is one of "info", "warning" or "error". Defaults to "warning".
+	Severity string `hcl:"severity,optional"`
+
+	// Scope is the kind of object the filter is evaluated against, one of
+	// "job", "group", "task" or "service". Defaults to "job".
+	Scope string `hcl:"scope,optional"`
+
+	// Filter is the filter expression matching the objects that violate the
+	// rule.
+	Filter  string `hcl:"filter"`
+	Message string `hcl:"message,optional"`
+}
+
+// JobLintFinding is a violation of a lint rule by a job.
+type JobLintFinding struct {
+	Rule     string
+	Severity string
+	Message  string
+	Group    string
+	Task     string
+}
+
+// Lint is used to lint a job with the built-in lint rules and the given
+// rules. The job is validated as well, and the validation result is returned
+// along with the findings.
+func (j *Jobs) Lint(job *Job, rules []*JobLintRule, q *WriteOptions) (*JobValidateResponse, *WriteMeta, error) {
+	var resp JobValidateResponse
+	req := &JobValidateRequest{
+		Job:       job,
+		Lint:      true,
+		LintRules: rules,
+	}
+	if q != nil {
+		req.WriteRequest = WriteRequest{Region: q.Region}
+	}
+	wm, err := j.client.put("/v1/validate/job", req, &resp, q)
+	return &resp, wm, err
+}
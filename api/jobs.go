@@ -212,6 +212,67 @@ func (j *Jobs) Info(jobID string, q *QueryOptions) (*Job, *QueryMeta, error) {
 	return &resp, qm, nil
 }
 
+// Watch follows the status of a job using blocking queries and streams each
+// status transition back to the returned channel. The first change is the
+// status of the job when the watch starts. If a query fails, the error is
+// delivered as the last change before the channel is closed, which also
+// happens once the job is purged. The channel is closed without an error
+// when ctx is canceled.
+func (j *Jobs) Watch(ctx context.Context, jobID string, q *QueryOptions) <-chan *JobStatusChange {
+	q = q.WithContext(ctx)
+	changeCh := make(chan *JobStatusChange, 10)
+
+	go func() {
+		defer close(changeCh)
+
+		var prevStatus string
+		for ctx.Err() == nil {
+			job, qm, err := j.Info(jobID, q)
+			if err != nil {
+				if ctx.Err() == nil {
+					select {
+					case <-ctx.Done():
+					case changeCh <- &JobStatusChange{Err: err}:
+					}
+				}
+				return
+			}
+
+			// Restart the blocking query from scratch if the index went
+			// backwards, such as after a snapshot restore
+			if qm.LastIndex < q.WaitIndex {
+				q.WaitIndex = 0
+			} else {
+				q.WaitIndex = qm.LastIndex
+			}
+
+			var status string
+			if job.Status != nil {
+				status = *job.Status
+			}
+			if status == prevStatus {
+				continue
+			}
+
+			change := &JobStatusChange{
+				PreviousStatus: prevStatus,
+				Status:         status,
+				Job:            job,
+				Index:          qm.LastIndex,
+			}
+			prevStatus = status
+
+			select {
+			case <-ctx.Done():
+				return
+			case changeCh <- change:
+			}
+		}
+	}()
+
+	return changeCh
+}
+
 // Scale is used to scale a job.
 func (j *Jobs) Scale(jobID, group string, count *int, message string, error bool, meta map[string]interface{},
 	q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
@@ -378,6 +439,27 @@ func (j *Jobs) LatestDeployment(jobID string, q *QueryOptions) (*Deployment, *Qu
 	return resp, qm, nil
 }
 
+// Snapshot is used to query a job along with its evaluations, deployments and
+// allocations. All objects are read at the same Raft index, which is returned
+// in the query metadata. If all is true, deployments and allocations of
+// previous instances of the job with the same ID are included.
+func (j *Jobs) Snapshot(jobID string, all bool, q *QueryOptions) (*JobSnapshot, *QueryMeta, error) {
+	var resp JobSnapshot
+	u, err := url.Parse("/v1/job/" + url.PathEscape(jobID) + "/snapshot")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := u.Query()
+	v.Add("all", strconv.FormatBool(all))
+	u.RawQuery = v.Encode()
+	qm, err := j.client.query(u.String(), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Evaluations is used to query the evaluations associated with the given job
 // ID.
 func (j *Jobs) Evaluations(jobID string, q *QueryOptions) ([]*Evaluation, *QueryMeta, error) {
@@ -390,6 +472,17 @@ func (j *Jobs) Evaluations(jobID string, q *QueryOptions) ([]*Evaluation, *Query
 	return resp, qm, nil
 }
 
+// Results is used to retrieve the results registered by the tasks of a job,
+// in the order their allocations were created.
+func (j *Jobs) Results(jobID string, q *QueryOptions) ([]*AllocTaskResult, *QueryMeta, error) {
+	var resp []*AllocTaskResult
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/results", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Deregister is used to remove an existing job. If purge is set to true, the job
 // is deregistered and purged from the system versus still being queryable and
 // eventually GC'ed from the system. Most callers should not specify purge.
@@ -424,6 +517,10 @@ type DeregisterOptions struct {
 	// task shutdown_delay configuration and ignore the delay for any
 	// allocations stopped as a result of this Deregister call.
 	NoShutdownDelay bool
+
+	// If Audit is set to true along with Purge, the purge of the job is
+	// recorded in the job purge history. See Jobs.PurgeHistory.
+	Audit bool
 }
 
 // DeregisterOpts is used to remove an existing job. See DeregisterOptions
@@ -439,6 +536,9 @@ func (j *Jobs) DeregisterOpts(jobID string, opts *DeregisterOptions, q *WriteOpt
 	if opts != nil {
 		endpoint += fmt.Sprintf("?purge=%t&global=%t&eval_priority=%v&no_shutdown_delay=%t",
 			opts.Purge, opts.Global, opts.EvalPriority, opts.NoShutdownDelay)
+		if opts.Audit {
+			endpoint += "&audit=true"
+		}
 	}
 
 	wm, err := j.client.delete(endpoint, nil, &resp, q)
@@ -448,6 +548,23 @@ func (j *Jobs) DeregisterOpts(jobID string, opts *DeregisterOptions, q *WriteOpt
 	return resp.EvalID, wm, nil
 }
 
+// PurgeHistory is used to list the audit records of the jobs purged with
+// DeregisterOptions.Audit set, newest first. If jobID is empty, the records
+// of all the jobs in the namespace are returned.
+func (j *Jobs) PurgeHistory(jobID string, q *QueryOptions) ([]*JobPurgeRecord, *QueryMeta, error) {
+	endpoint := "/v1/jobs/purged"
+	if jobID != "" {
+		endpoint += "?job=" + url.QueryEscape(jobID)
+	}
+
+	var resp []*JobPurgeRecord
+	qm, err := j.client.query(endpoint, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // ForceEvaluate is used to force-evaluate an existing job.
 func (j *Jobs) ForceEvaluate(jobID string, q *WriteOptions) (string, *WriteMeta, error) {
 	var resp JobRegisterResponse
@@ -476,8 +593,29 @@ func (j *Jobs) EvaluateWithOpts(jobID string, opts EvalOptions, q *WriteOptions)
 
 // PeriodicForce spawns a new instance of the periodic job and returns the eval ID
 func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta, error) {
+	return j.PeriodicForceOpts(jobID, nil, q)
+}
+
+// PeriodicForceOptions is used to pass through periodic force parameters
+type PeriodicForceOptions struct {
+	// Meta is merged into the metadata of the spawned job.
+	Meta map[string]string
+
+	// Env is merged into the environment of each task of the spawned job.
+	Env map[string]string
+}
+
+// PeriodicForceOpts spawns a new instance of the periodic job with the given
+// overrides applied and returns the eval ID
+func (j *Jobs) PeriodicForceOpts(jobID string, opts *PeriodicForceOptions, q *WriteOptions) (string, *WriteMeta, error) {
+	req := &periodicForceRequest{}
+	if opts != nil {
+		req.Meta = opts.Meta
+		req.Env = opts.Env
+	}
+
 	var resp periodicForceResponse
-	wm, err := j.client.put("/v1/job/"+url.PathEscape(jobID)+"/periodic/force", nil, &resp, q)
+	wm, err := j.client.put("/v1/job/"+url.PathEscape(jobID)+"/periodic/force", req, &resp, q)
 	if err != nil {
 		return "", nil, err
 	}
@@ -520,6 +658,30 @@ func (j *Jobs) PlanOpts(job *Job, opts *PlanOptions, q *WriteOptions) (*JobPlanR
 	return &resp, wm, nil
 }
 
+// Drift is used to compare a job specification against the registered
+// version of the job. The response contains the job normalized the way it
+// would be registered and the hashes of both specifications, which differ if
+// the job has drifted or is not registered.
+func (j *Jobs) Drift(job *Job, q *WriteOptions) (*JobDriftResponse, *WriteMeta, error) {
+	if job == nil {
+		return nil, nil, errors.New("must pass non-nil job")
+	}
+	if job.ID == nil {
+		return nil, nil, errors.New("job is missing ID")
+	}
+
+	req := &JobDriftRequest{
+		Job: job,
+	}
+
+	var resp JobDriftResponse
+	wm, err := j.client.put("/v1/job/"+url.PathEscape(*job.ID)+"/drift", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta, error) {
 	var resp JobSummary
 	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/summary", &resp, q)
@@ -582,6 +744,46 @@ func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	return &resp, wm, nil
 }
 
+// Freeze is used to freeze or unfreeze the scheduling of a job. While a job
+// is frozen, its running allocations are left as they are, but failed or lost
+// allocations are not replaced and job updates are not rolled out.
+func (j *Jobs) Freeze(jobID string, frozen bool, q *WriteOptions) (*JobFreezeResponse, *WriteMeta, error) {
+	var resp JobFreezeResponse
+	req := &JobFreezeRequest{
+		JobID:  jobID,
+		Frozen: frozen,
+	}
+	wm, err := j.client.put("/v1/job/"+url.PathEscape(jobID)+"/freeze", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Restart is used to start a batched restart of the allocations of a job, to
+// submit a batch to a restart, or to update the status of a restart. The
+// restart is paused by the servers when one of its batches fails, after which
+// new batches are rejected with an error containing
+// JobRestartPausedErrorContent.
+func (j *Jobs) Restart(req *JobRestartRequest, q *WriteOptions) (*JobRestartResponse, *WriteMeta, error) {
+	var resp JobRestartResponse
+	wm, err := j.client.put("/v1/job/"+url.PathEscape(req.JobID)+"/restart", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// RestartInfo is used to query the latest batched restart of a job.
+func (j *Jobs) RestartInfo(jobID string, q *QueryOptions) (*JobRestart, *QueryMeta, error) {
+	var resp JobRestart
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/restart", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Services is used to return a list of service registrations associated to the
 // specified jobID.
 func (j *Jobs) Services(jobID string, q *QueryOptions) ([]*ServiceRegistration, *QueryMeta, error) {
@@ -590,6 +792,12 @@ func (j *Jobs) Services(jobID string, q *QueryOptions) ([]*ServiceRegistration,
 	return resp, qm, err
 }
 
+// periodicForceRequest is used to serialize a force request
+type periodicForceRequest struct {
+	Meta map[string]string `json:",omitempty"`
+	Env  map[string]string `json:",omitempty"`
+}
+
 // periodicForceResponse is used to deserialize a force response
 type periodicForceResponse struct {
 	EvalID string
@@ -597,30 +805,93 @@ type periodicForceResponse struct {
 
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
-	MaxParallel      *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
-	HealthCheck      *string        `mapstructure:"health_check" hcl:"health_check,optional"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
-	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
-	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
-	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Stagger                   *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
+	MaxParallel               *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	HealthCheck               *string        `mapstructure:"health_check" hcl:"health_check,optional"`
+	MinHealthyTime            *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
+	HealthyDeadline           *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	ProgressDeadline          *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
+	Canary                    *int           `mapstructure:"canary" hcl:"canary,optional"`
+	AutoRevert                *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
+	AutoPromote               *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	AutoPromoteThreshold      *int           `mapstructure:"auto_promote_threshold" hcl:"auto_promote_threshold,optional"`
+	AutoPromoteMinHealthyTime *time.Duration `mapstructure:"auto_promote_min_healthy_time" hcl:"auto_promote_min_healthy_time,optional"`
+	RollbackOn                *RollbackOn    `mapstructure:"rollback_on" hcl:"rollback_on,block"`
+}
+
+const (
+	RollbackOnComparisonAbove = "above"
+	RollbackOnComparisonBelow = "below"
+)
+
+// RollbackOn is a metric query that fails and reverts a deployment when it
+// breaches its threshold.
+type RollbackOn struct {
+	Query      *string        `mapstructure:"query" hcl:"query,optional"`
+	Threshold  *float64       `mapstructure:"threshold" hcl:"threshold,optional"`
+	Comparison *string        `mapstructure:"comparison" hcl:"comparison,optional"`
+	Interval   *time.Duration `mapstructure:"interval" hcl:"interval,optional"`
+}
+
+func (r *RollbackOn) Copy() *RollbackOn {
+	if r == nil {
+		return nil
+	}
+
+	copy := new(RollbackOn)
+
+	if r.Query != nil {
+		copy.Query = pointerOf(*r.Query)
+	}
+
+	if r.Threshold != nil {
+		copy.Threshold = pointerOf(*r.Threshold)
+	}
+
+	if r.Comparison != nil {
+		copy.Comparison = pointerOf(*r.Comparison)
+	}
+
+	if r.Interval != nil {
+		copy.Interval = pointerOf(*r.Interval)
+	}
+
+	return copy
+}
+
+func (r *RollbackOn) Canonicalize() {
+	if r.Query == nil {
+		r.Query = pointerOf("")
+	}
+
+	if r.Threshold == nil {
+		r.Threshold = pointerOf(0.0)
+	}
+
+	if r.Comparison == nil {
+		r.Comparison = pointerOf(RollbackOnComparisonAbove)
+	}
+
+	if r.Interval == nil {
+		r.Interval = pointerOf(30 * time.Second)
+	}
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
 // jobs with the old policy or for populating field defaults.
 func DefaultUpdateStrategy() *UpdateStrategy {
 	return &UpdateStrategy{
-		Stagger:          pointerOf(30 * time.Second),
-		MaxParallel:      pointerOf(1),
-		HealthCheck:      pointerOf("checks"),
-		MinHealthyTime:   pointerOf(10 * time.Second),
-		HealthyDeadline:  pointerOf(5 * time.Minute),
-		ProgressDeadline: pointerOf(10 * time.Minute),
-		AutoRevert:       pointerOf(false),
-		Canary:           pointerOf(0),
-		AutoPromote:      pointerOf(false),
+		Stagger:                   pointerOf(30 * time.Second),
+		MaxParallel:               pointerOf(1),
+		HealthCheck:               pointerOf("checks"),
+		MinHealthyTime:            pointerOf(10 * time.Second),
+		HealthyDeadline:           pointerOf(5 * time.Minute),
+		ProgressDeadline:          pointerOf(10 * time.Minute),
+		AutoRevert:                pointerOf(false),
+		Canary:                    pointerOf(0),
+		AutoPromote:               pointerOf(false),
+		AutoPromoteThreshold:      pointerOf(0),
+		AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 	}
 }
 
@@ -667,6 +938,16 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	if u.AutoPromoteThreshold != nil {
+		copy.AutoPromoteThreshold = pointerOf(*u.AutoPromoteThreshold)
+	}
+
+	if u.AutoPromoteMinHealthyTime != nil {
+		copy.AutoPromoteMinHealthyTime = pointerOf(*u.AutoPromoteMinHealthyTime)
+	}
+
+	copy.RollbackOn = u.RollbackOn.Copy()
+
 	return copy
 }
 
@@ -710,6 +991,18 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.AutoPromoteThreshold != nil {
+		u.AutoPromoteThreshold = pointerOf(*o.AutoPromoteThreshold)
+	}
+
+	if o.AutoPromoteMinHealthyTime != nil {
+		u.AutoPromoteMinHealthyTime = pointerOf(*o.AutoPromoteMinHealthyTime)
+	}
+
+	if o.RollbackOn != nil {
+		u.RollbackOn = o.RollbackOn.Copy()
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -750,6 +1043,18 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.AutoPromoteThreshold == nil {
+		u.AutoPromoteThreshold = d.AutoPromoteThreshold
+	}
+
+	if u.AutoPromoteMinHealthyTime == nil {
+		u.AutoPromoteMinHealthyTime = d.AutoPromoteMinHealthyTime
+	}
+
+	if u.RollbackOn != nil {
+		u.RollbackOn.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -790,10 +1095,22 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.AutoPromoteThreshold != nil && *u.AutoPromoteThreshold != 0 {
+		return false
+	}
+
+	if u.AutoPromoteMinHealthyTime != nil && *u.AutoPromoteMinHealthyTime != 0 {
+		return false
+	}
+
 	if u.Canary != nil && *u.Canary != 0 {
 		return false
 	}
 
+	if u.RollbackOn != nil {
+		return false
+	}
+
 	return true
 }
 
@@ -958,6 +1275,22 @@ type ParameterizedJobConfig struct {
 	Payload      string   `hcl:"payload,optional"`
 	MetaRequired []string `mapstructure:"meta_required" hcl:"meta_required,optional"`
 	MetaOptional []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+
+	// MetaSchema declares the type and allowed values of dispatch metadata
+	// keys.
+	MetaSchema []*DispatchMetaSchema `hcl:"meta_schema,block"`
+
+	// PayloadSchema is a JSON Schema document the dispatch payload must
+	// match.
+	PayloadSchema string `mapstructure:"payload_schema" hcl:"payload_schema,optional"`
+}
+
+// DispatchMetaSchema declares the values a dispatch metadata key accepts.
+type DispatchMetaSchema struct {
+	Name    string   `hcl:",label"`
+	Type    string   `hcl:"type,optional"`
+	Pattern string   `hcl:"pattern,optional"`
+	Enum    []string `hcl:"enum,optional"`
 }
 
 // JobSubmission is used to hold information about the original content of a job
@@ -1090,6 +1423,7 @@ type Job struct {
 	AllAtOnce        *bool                   `mapstructure:"all_at_once" hcl:"all_at_once,optional"`
 	Datacenters      []string                `hcl:"datacenters,optional"`
 	NodePool         *string                 `mapstructure:"node_pool" hcl:"node_pool,optional"`
+	MinClientVersion *string                 `mapstructure:"min_client_version" hcl:"min_client_version,optional"`
 	Constraints      []*Constraint           `hcl:"constraint,block"`
 	Affinities       []*Affinity             `hcl:"affinity,block"`
 	TaskGroups       []*TaskGroup            `hcl:"group,block"`
@@ -1117,6 +1451,7 @@ type Job struct {
 	Status                   *string
 	StatusDescription        *string
 	Stable                   *bool
+	Frozen                   *bool
 	Version                  *uint64
 	SubmitTime               *int64
 	CreateIndex              *uint64
@@ -1165,6 +1500,9 @@ func (j *Job) Canonicalize() {
 	if j.NodePool == nil {
 		j.NodePool = pointerOf("")
 	}
+	if j.MinClientVersion == nil {
+		j.MinClientVersion = pointerOf("")
+	}
 	if j.Type == nil {
 		j.Type = pointerOf("service")
 	}
@@ -1242,6 +1580,36 @@ func (j *Job) LookupTaskGroup(name string) *TaskGroup {
 	return nil
 }
 
+// JobSnapshot is a job along with its evaluations, deployments and
+// allocations, all read at the same Raft index.
+type JobSnapshot struct {
+	Job         *Job
+	Evaluations []*Evaluation
+	Deployments []*Deployment
+	Allocations []*AllocationListStub
+}
+
+// JobStatusChange is a transition of the status of a job, as streamed by
+// Jobs.Watch.
+type JobStatusChange struct {
+	// PreviousStatus is the status of the job before the change, or empty
+	// for the first change of the watch.
+	PreviousStatus string
+
+	// Status is the status of the job after the change.
+	Status string
+
+	// Job is the job as of Index.
+	Job *Job
+
+	// Index is the Raft index at which the change was observed.
+	Index uint64
+
+	// Err is set when the watch failed, in which case it is the last change
+	// streamed.
+	Err error
+}
+
 // JobSummary summarizes the state of the allocations of a job
 type JobSummary struct {
 	JobID     string
@@ -1416,6 +1784,12 @@ type WriteRequest struct {
 // JobValidateRequest is used to validate a job
 type JobValidateRequest struct {
 	Job *Job
+
+	// Lint requests the job to be linted with the built-in lint rules and
+	// LintRules in addition to being validated.
+	Lint      bool
+	LintRules []*JobLintRule
+
 	WriteRequest
 }
 
@@ -1434,6 +1808,10 @@ type JobValidateResponse struct {
 	// Warnings contains any warnings about the given job. These may include
 	// deprecation warnings.
 	Warnings string
+
+	// LintFindings are the findings of the lint rules when linting was
+	// requested.
+	LintFindings []*JobLintFinding
 }
 
 // JobRevertRequest is used to revert a job to a prior version.
@@ -1509,6 +1887,33 @@ type JobPlanRequest struct {
 	WriteRequest
 }
 
+// JobDriftRequest is used to compare a job against its registered version.
+type JobDriftRequest struct {
+	Job *Job
+	WriteRequest
+}
+
+// JobDriftResponse is the result of comparing a job against its registered
+// version.
+type JobDriftResponse struct {
+	// Job is the submitted job in the normalized form it would be registered
+	// with, and Hash is the hash of its specification.
+	Job  *Job
+	Hash string
+
+	// RegisteredHash and RegisteredVersion describe the registered version
+	// of the job. Both are empty if the job is not registered.
+	RegisteredHash    string
+	RegisteredVersion uint64
+
+	// Drifted is true if Hash differs from RegisteredHash.
+	Drifted bool
+
+	// Warnings contains any warnings about the given job. These may include
+	// deprecation warnings.
+	Warnings string
+}
+
 type JobPlanResponse struct {
 	JobModifyIndex     uint64
 	CreatedEvals       []*Evaluation
@@ -1564,6 +1969,18 @@ type ObjectDiff struct {
 type PlanAnnotations struct {
 	DesiredTGUpdates map[string]*DesiredUpdates
 	PreemptedAllocs  []*AllocationListStub
+	PortConflicts    map[string]*PortConflicts
+}
+
+// PortConflicts describes the candidate nodes of a task group excluded solely
+// because some of its static ports are already in use.
+type PortConflicts struct {
+	// CandidateNodes is the number of candidate nodes the static ports of the
+	// task group were checked on.
+	CandidateNodes int
+
+	// Nodes maps the ID of each node with a conflict to the conflicting ports.
+	Nodes map[string][]int
 }
 
 type DesiredUpdates struct {
@@ -1622,6 +2039,66 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobFreezeRequest is used to freeze or unfreeze the scheduling of a job.
+type JobFreezeRequest struct {
+	JobID  string
+	Frozen bool
+	WriteRequest
+}
+
+// JobFreezeResponse is the response when freezing or unfreezing a job.
+type JobFreezeResponse struct {
+	EvalID          string
+	EvalCreateIndex uint64
+	JobModifyIndex  uint64
+	WriteMeta
+}
+
+const (
+	JobRestartStatusRunning  = "running"
+	JobRestartStatusPaused   = "paused"
+	JobRestartStatusComplete = "complete"
+
+	// JobRestartPausedErrorContent is the string content of the error
+	// returned when a batch is submitted to a paused job restart.
+	JobRestartPausedErrorContent = "job restart is paused"
+)
+
+// JobRestart is a batched restart of the allocations of a job.
+type JobRestart struct {
+	ID                string
+	Namespace         string
+	JobID             string
+	Status            string
+	StatusDescription string
+	Reschedule        bool
+	Batch             []string
+	BatchStartTime    int64
+	BatchCount        int
+	CreateIndex       uint64
+	ModifyIndex       uint64
+	CreateTime        int64
+	ModifyTime        int64
+}
+
+// JobRestartRequest is used to start a job restart, submit a batch to it, or
+// update its status. A new restart is started if RestartID is empty.
+type JobRestartRequest struct {
+	JobID             string
+	RestartID         string
+	Reschedule        bool
+	Batch             []string
+	Status            string
+	StatusDescription string
+	WriteRequest
+}
+
+// JobRestartResponse is the response to a JobRestartRequest.
+type JobRestartResponse struct {
+	Restart *JobRestart
+	WriteMeta
+}
+
 // JobEvaluateRequest is used when we just need to re-evaluate a target job
 type JobEvaluateRequest struct {
 	JobID       string
@@ -1689,3 +2166,25 @@ func (j *Jobs) TagVersion(jobID string, version uint64, name string, description
 func (j *Jobs) UntagVersion(jobID string, name string, q *WriteOptions) (*WriteMeta, error) {
 	return j.client.delete("/v1/job/"+url.PathEscape(jobID)+"/versions/"+name+"/tag", nil, nil, q)
 }
+
+// JobPurgeRecord is the audit record of a job purged with an audit.
+type JobPurgeRecord struct {
+	ID          string
+	Namespace   string
+	JobID       string
+	Name        string
+	Type        string
+	PurgedBy    string
+	PurgedAt    int64
+	Versions    []*JobPurgeVersionSummary
+	CreateIndex uint64
+}
+
+// JobPurgeVersionSummary is the summary of a version of a purged job.
+type JobPurgeVersionSummary struct {
+	Version    uint64
+	SubmitTime int64
+	Stable     bool
+	Stopped    bool
+	Tag        string
+}
@@ -4,6 +4,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"testing"
@@ -285,6 +286,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf(""),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
@@ -299,15 +301,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ModifyIndex:       pointerOf(uint64(0)),
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(30 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(5 * time.Minute),
-					ProgressDeadline: pointerOf(10 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(30 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(5 * time.Minute),
+					ProgressDeadline:          pointerOf(10 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -339,15 +343,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(30 * time.Second),
-							MaxParallel:      pointerOf(1),
-							HealthCheck:      pointerOf("checks"),
-							MinHealthyTime:   pointerOf(10 * time.Second),
-							HealthyDeadline:  pointerOf(5 * time.Minute),
-							ProgressDeadline: pointerOf(10 * time.Minute),
-							AutoRevert:       pointerOf(false),
-							Canary:           pointerOf(0),
-							AutoPromote:      pointerOf(false),
+							Stagger:                   pointerOf(30 * time.Second),
+							MaxParallel:               pointerOf(1),
+							HealthCheck:               pointerOf("checks"),
+							MinHealthyTime:            pointerOf(10 * time.Second),
+							HealthyDeadline:           pointerOf(5 * time.Minute),
+							ProgressDeadline:          pointerOf(10 * time.Minute),
+							AutoRevert:                pointerOf(false),
+							Canary:                    pointerOf(0),
+							AutoPromote:               pointerOf(false),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -383,6 +389,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf(""),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
@@ -464,6 +471,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
@@ -478,15 +486,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ModifyIndex:       pointerOf(uint64(0)),
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(30 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(5 * time.Minute),
-					ProgressDeadline: pointerOf(10 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(30 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(5 * time.Minute),
+					ProgressDeadline:          pointerOf(10 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -518,15 +528,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(30 * time.Second),
-							MaxParallel:      pointerOf(1),
-							HealthCheck:      pointerOf("checks"),
-							MinHealthyTime:   pointerOf(10 * time.Second),
-							HealthyDeadline:  pointerOf(5 * time.Minute),
-							ProgressDeadline: pointerOf(10 * time.Minute),
-							AutoRevert:       pointerOf(false),
-							Canary:           pointerOf(0),
-							AutoPromote:      pointerOf(false),
+							Stagger:                   pointerOf(30 * time.Second),
+							MaxParallel:               pointerOf(1),
+							HealthCheck:               pointerOf("checks"),
+							MinHealthyTime:            pointerOf(10 * time.Second),
+							HealthyDeadline:           pointerOf(5 * time.Minute),
+							ProgressDeadline:          pointerOf(10 * time.Minute),
+							AutoRevert:                pointerOf(false),
+							Canary:                    pointerOf(0),
+							AutoPromote:               pointerOf(false),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -637,6 +649,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf(""),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				Region:            pointerOf("global"),
 				Type:              pointerOf("service"),
 				AllAtOnce:         pointerOf(false),
@@ -654,15 +667,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Datacenters:       []string{"dc1"},
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(30 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(5 * time.Minute),
-					ProgressDeadline: pointerOf(10 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(true),
+					Stagger:                   pointerOf(30 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(5 * time.Minute),
+					ProgressDeadline:          pointerOf(10 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(true),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -694,15 +709,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(30 * time.Second),
-							MaxParallel:      pointerOf(1),
-							HealthCheck:      pointerOf("checks"),
-							MinHealthyTime:   pointerOf(10 * time.Second),
-							HealthyDeadline:  pointerOf(5 * time.Minute),
-							ProgressDeadline: pointerOf(10 * time.Minute),
-							AutoRevert:       pointerOf(true),
-							Canary:           pointerOf(0),
-							AutoPromote:      pointerOf(true),
+							Stagger:                   pointerOf(30 * time.Second),
+							MaxParallel:               pointerOf(1),
+							HealthCheck:               pointerOf("checks"),
+							MinHealthyTime:            pointerOf(10 * time.Second),
+							HealthyDeadline:           pointerOf(5 * time.Minute),
+							ProgressDeadline:          pointerOf(10 * time.Minute),
+							AutoRevert:                pointerOf(true),
+							Canary:                    pointerOf(0),
+							AutoPromote:               pointerOf(true),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -811,6 +828,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Type:              pointerOf("service"),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
@@ -825,15 +843,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ModifyIndex:       pointerOf(uint64(0)),
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(30 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(5 * time.Minute),
-					ProgressDeadline: pointerOf(10 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(30 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(5 * time.Minute),
+					ProgressDeadline:          pointerOf(10 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				Periodic: &PeriodicConfig{
 					Enabled:         pointerOf(true),
@@ -852,15 +872,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ID:       pointerOf("bar"),
 				ParentID: pointerOf("lol"),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(1 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(6 * time.Minute),
-					ProgressDeadline: pointerOf(7 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(1 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(6 * time.Minute),
+					ProgressDeadline:          pointerOf(7 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -904,6 +926,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
@@ -918,15 +941,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ModifyIndex:       pointerOf(uint64(0)),
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(1 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(6 * time.Minute),
-					ProgressDeadline: pointerOf(7 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(1 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(6 * time.Minute),
+					ProgressDeadline:          pointerOf(7 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -958,15 +983,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(2 * time.Second),
-							MaxParallel:      pointerOf(2),
-							HealthCheck:      pointerOf("manual"),
-							MinHealthyTime:   pointerOf(1 * time.Second),
-							HealthyDeadline:  pointerOf(6 * time.Minute),
-							ProgressDeadline: pointerOf(7 * time.Minute),
-							AutoRevert:       pointerOf(true),
-							Canary:           pointerOf(1),
-							AutoPromote:      pointerOf(true),
+							Stagger:                   pointerOf(2 * time.Second),
+							MaxParallel:               pointerOf(2),
+							HealthCheck:               pointerOf("manual"),
+							MinHealthyTime:            pointerOf(1 * time.Second),
+							HealthyDeadline:           pointerOf(6 * time.Minute),
+							ProgressDeadline:          pointerOf(7 * time.Minute),
+							AutoRevert:                pointerOf(true),
+							Canary:                    pointerOf(1),
+							AutoPromote:               pointerOf(true),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1008,15 +1035,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(1 * time.Second),
-							MaxParallel:      pointerOf(1),
-							HealthCheck:      pointerOf("checks"),
-							MinHealthyTime:   pointerOf(10 * time.Second),
-							HealthyDeadline:  pointerOf(6 * time.Minute),
-							ProgressDeadline: pointerOf(7 * time.Minute),
-							AutoRevert:       pointerOf(false),
-							Canary:           pointerOf(0),
-							AutoPromote:      pointerOf(false),
+							Stagger:                   pointerOf(1 * time.Second),
+							MaxParallel:               pointerOf(1),
+							HealthCheck:               pointerOf("checks"),
+							MinHealthyTime:            pointerOf(10 * time.Second),
+							HealthyDeadline:           pointerOf(6 * time.Minute),
+							ProgressDeadline:          pointerOf(7 * time.Minute),
+							AutoRevert:                pointerOf(false),
+							Canary:                    pointerOf(0),
+							AutoPromote:               pointerOf(false),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1086,6 +1115,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Type:              pointerOf("service"),
 				ParentID:          pointerOf("lol"),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				Priority:          pointerOf(JobDefaultPriority),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
@@ -1101,15 +1131,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ModifyIndex:       pointerOf(uint64(0)),
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(30 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(5 * time.Minute),
-					ProgressDeadline: pointerOf(10 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(30 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(5 * time.Minute),
+					ProgressDeadline:          pointerOf(10 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -1141,15 +1173,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(30 * time.Second),
-							MaxParallel:      pointerOf(1),
-							HealthCheck:      pointerOf("checks"),
-							MinHealthyTime:   pointerOf(10 * time.Second),
-							HealthyDeadline:  pointerOf(5 * time.Minute),
-							ProgressDeadline: pointerOf(10 * time.Minute),
-							AutoRevert:       pointerOf(false),
-							Canary:           pointerOf(0),
-							AutoPromote:      pointerOf(false),
+							Stagger:                   pointerOf(30 * time.Second),
+							MaxParallel:               pointerOf(1),
+							HealthCheck:               pointerOf("checks"),
+							MinHealthyTime:            pointerOf(10 * time.Second),
+							HealthyDeadline:           pointerOf(5 * time.Minute),
+							ProgressDeadline:          pointerOf(10 * time.Minute),
+							AutoRevert:                pointerOf(false),
+							Canary:                    pointerOf(0),
+							AutoPromote:               pointerOf(false),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1197,15 +1231,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Cluster:   "default",
 						},
 						Update: &UpdateStrategy{
-							Stagger:          pointerOf(30 * time.Second),
-							MaxParallel:      pointerOf(1),
-							HealthCheck:      pointerOf("checks"),
-							MinHealthyTime:   pointerOf(10 * time.Second),
-							HealthyDeadline:  pointerOf(5 * time.Minute),
-							ProgressDeadline: pointerOf(10 * time.Minute),
-							AutoRevert:       pointerOf(false),
-							Canary:           pointerOf(0),
-							AutoPromote:      pointerOf(false),
+							Stagger:                   pointerOf(30 * time.Second),
+							MaxParallel:               pointerOf(1),
+							HealthCheck:               pointerOf("checks"),
+							MinHealthyTime:            pointerOf(10 * time.Second),
+							HealthyDeadline:           pointerOf(5 * time.Minute),
+							ProgressDeadline:          pointerOf(10 * time.Minute),
+							AutoRevert:                pointerOf(false),
+							Canary:                    pointerOf(0),
+							AutoPromote:               pointerOf(false),
+							AutoPromoteThreshold:      pointerOf(0),
+							AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1265,6 +1301,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
+				MinClientVersion:  pointerOf(""),
 				AllAtOnce:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
@@ -1279,15 +1316,17 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ModifyIndex:       pointerOf(uint64(0)),
 				JobModifyIndex:    pointerOf(uint64(0)),
 				Update: &UpdateStrategy{
-					Stagger:          pointerOf(30 * time.Second),
-					MaxParallel:      pointerOf(1),
-					HealthCheck:      pointerOf("checks"),
-					MinHealthyTime:   pointerOf(10 * time.Second),
-					HealthyDeadline:  pointerOf(5 * time.Minute),
-					ProgressDeadline: pointerOf(10 * time.Minute),
-					AutoRevert:       pointerOf(false),
-					Canary:           pointerOf(0),
-					AutoPromote:      pointerOf(false),
+					Stagger:                   pointerOf(30 * time.Second),
+					MaxParallel:               pointerOf(1),
+					HealthCheck:               pointerOf("checks"),
+					MinHealthyTime:            pointerOf(10 * time.Second),
+					HealthyDeadline:           pointerOf(5 * time.Minute),
+					ProgressDeadline:          pointerOf(10 * time.Minute),
+					AutoRevert:                pointerOf(false),
+					Canary:                    pointerOf(0),
+					AutoPromote:               pointerOf(false),
+					AutoPromoteThreshold:      pointerOf(0),
+					AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 				},
 			},
 		},
@@ -1407,6 +1446,57 @@ func TestJobs_Info(t *testing.T) {
 	must.Eq(t, *result.ID, *job.ID)
 }
 
+func TestJobs_Watch(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register the job
+	job := testJob()
+	_, _, err := jobs.Register(job, nil)
+	must.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changeCh := jobs.Watch(ctx, *job.ID, nil)
+
+	// The first change is the current status of the job
+	select {
+	case change := <-changeCh:
+		must.NoError(t, change.Err)
+		must.Eq(t, "", change.PreviousStatus)
+		must.Eq(t, "pending", change.Status)
+		must.Eq(t, *job.ID, *change.Job.ID)
+		must.Positive(t, change.Index)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for job status")
+	}
+
+	// Stopping the job is streamed as a transition
+	_, _, err = jobs.Deregister(*job.ID, false, nil)
+	must.NoError(t, err)
+
+	select {
+	case change := <-changeCh:
+		must.NoError(t, change.Err)
+		must.Eq(t, "pending", change.PreviousStatus)
+		must.Eq(t, "dead", change.Status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for job status change")
+	}
+
+	// Canceling the context closes the channel
+	cancel()
+	select {
+	case _, ok := <-changeCh:
+		must.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for watch to stop")
+	}
+}
+
 func TestJobs_ScaleInvalidAction(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -2082,6 +2172,40 @@ func TestJobs_PeriodicForce(t *testing.T) {
 	must.Eq(t, eval.ID, evalID)
 }
 
+func TestJobs_Drift(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Check that passing a nil job fails
+	_, _, err := jobs.Drift(nil, nil)
+	must.Error(t, err)
+
+	// A job that is not registered has drifted
+	job := testJob()
+	driftResp, _, err := jobs.Drift(job, nil)
+	must.NoError(t, err)
+	must.True(t, driftResp.Drifted)
+	must.Eq(t, "", driftResp.RegisteredHash)
+
+	_, _, err = jobs.Register(job, nil)
+	must.NoError(t, err)
+
+	// The registered specification has not drifted
+	driftResp, _, err = jobs.Drift(job, nil)
+	must.NoError(t, err)
+	must.False(t, driftResp.Drifted)
+	must.Eq(t, driftResp.Hash, driftResp.RegisteredHash)
+
+	// A changed specification has drifted
+	job.TaskGroups[0].Count = pointerOf(2)
+	driftResp, _, err = jobs.Drift(job, nil)
+	must.NoError(t, err)
+	must.True(t, driftResp.Drifted)
+}
+
 func TestJobs_Plan(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Keyring is used to access the Variables keyring.
@@ -59,6 +60,59 @@ func (k *Keyring) List(q *QueryOptions) ([]*RootKeyMeta, *QueryMeta, error) {
 	return resp, qm, nil
 }
 
+// KeyringHealth is the health of the keyring of a server
+type KeyringHealth struct {
+	// Ready is true when all the root keys have been decrypted, and
+	// PendingKeys lists the IDs of the root keys waiting to be decrypted.
+	Ready       bool
+	PendingKeys []string
+
+	Providers []*KEKProviderHealth
+}
+
+// KEKProviderHealth is the health of a KEK provider as last observed by a
+// server when wrapping or unwrapping root keys with it.
+type KEKProviderHealth struct {
+	ID        string
+	Provider  string
+	Active    bool
+	Healthy   bool
+	Error     string
+	LastCheck time.Time
+}
+
+// KeyringReady is the readiness of the keyring of a server. Ready is true when
+// the keyring has an active key and all the root keys have been decrypted.
+// Otherwise Error explains what the keyring is waiting for.
+type KeyringReady struct {
+	Ready bool
+	Error string
+}
+
+// Health returns the health of the keyring and of its KEK providers. Set
+// AllowStale to get the health of the keyring of the server answering the
+// request rather than the leader's.
+func (k *Keyring) Health(q *QueryOptions) (*KeyringHealth, *QueryMeta, error) {
+	var resp KeyringHealth
+	qm, err := k.client.query("/v1/operator/keyring/health", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Ready returns whether the keyring can be used to sign and encrypt. Set
+// AllowStale to get the readiness of the server answering the request rather
+// than the leader's.
+func (k *Keyring) Ready(q *QueryOptions) (*KeyringReady, *QueryMeta, error) {
+	var resp KeyringReady
+	qm, err := k.client.query("/v1/operator/keyring/ready", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Delete deletes a specific inactive key from the keyring
 func (k *Keyring) Delete(opts *KeyringDeleteOptions, w *WriteOptions) (*WriteMeta, error) {
 	wm, err := k.client.delete(fmt.Sprintf("/v1/operator/keyring/key/%v?force=%v",
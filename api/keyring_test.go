@@ -50,3 +50,32 @@ func TestKeyring_CRUD(t *testing.T) {
 	must.Eq(t, key.KeyID, keys[0].KeyID)
 	must.Eq(t, RootKeyState(RootKeyStateActive), keys[0].State)
 }
+
+func TestKeyring_Health(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	health, qm, err := c.Keyring().Health(nil)
+	must.NoError(t, err)
+	assertQueryMeta(t, qm)
+	must.True(t, health.Ready)
+	must.SliceEmpty(t, health.PendingKeys)
+	must.Len(t, 1, health.Providers)
+	must.Eq(t, "aead", health.Providers[0].ID)
+	must.True(t, health.Providers[0].Healthy)
+}
+
+func TestKeyring_Ready(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	ready, qm, err := c.Keyring().Ready(&QueryOptions{AllowStale: true})
+	must.NoError(t, err)
+	assertQueryMeta(t, qm)
+	must.True(t, ready.Ready)
+	must.Eq(t, "", ready.Error)
+}
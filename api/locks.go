@@ -83,6 +83,10 @@ type Locks struct {
 	ttl      time.Duration
 	ro       retryOptions
 
+	// steal is set if the next acquire takes the lock regardless of its
+	// current holder.
+	steal bool
+
 	WriteOptions
 }
 
@@ -97,6 +101,15 @@ func LocksOptionWithMaxRetries(maxRetries int64) LocksOption {
 	}
 }
 
+// LocksOptionWithSteal makes the first successful call to Acquire take the
+// lock even if it is held by another caller. The lock of the previous holder
+// is revoked and the lock gets a new fencing token.
+func LocksOptionWithSteal() LocksOption {
+	return func(l *Locks) {
+		l.steal = true
+	}
+}
+
 //	Acquire will make the actual call to acquire the lock over the variable using
 //	the ttl in the Locks to create the VariableLock. It will return the
 //	path of the variable holding the lock.
@@ -106,7 +119,12 @@ func (l *Locks) Acquire(ctx context.Context) (string, error) {
 
 	var out Variable
 
-	_, err := l.c.retryPut(ctx, "/v1/var/"+l.variable.Path+"?lock-acquire", l.variable, &out, &l.WriteOptions)
+	op := "lock-acquire"
+	if l.steal {
+		op = "lock-steal"
+	}
+
+	_, err := l.c.retryPut(ctx, "/v1/var/"+l.variable.Path+"?"+op, l.variable, &out, &l.WriteOptions)
 	if err != nil {
 		callErr, ok := err.(UnexpectedResponseError)
 
@@ -121,10 +139,38 @@ func (l *Locks) Acquire(ctx context.Context) (string, error) {
 	}
 
 	l.variable.Lock = out.Lock
+	l.steal = false
 
 	return l.variable.Path, nil
 }
 
+// Steal makes the call to acquire the lock over the variable even if it is
+// held by another caller, using the ttl in the Locks to create the
+// VariableLock. The lock of the previous holder is revoked and the lock gets
+// a new fencing token.
+//
+// Steal returns the path to the variable holding the lock.
+func (l *Locks) Steal(ctx context.Context) (string, error) {
+	steal := l.steal
+	l.steal = true
+
+	path, err := l.Acquire(ctx)
+	if err != nil {
+		l.steal = steal
+	}
+	return path, err
+}
+
+// FencingToken returns the fencing token of the lock, or zero if the lock is
+// not held. Pass it to the resources protected by the lock so they can reject
+// requests from previous holders.
+func (l *Locks) FencingToken() uint64 {
+	if l.variable.Lock == nil {
+		return 0
+	}
+	return l.variable.Lock.FencingToken
+}
+
 // Release makes the call to release the lock over a variable, even if the ttl
 // has not yet passed.
 // In case of a call to release a non held lock, Release returns ErrLockConflict.
@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"sort"
+	"time"
 )
 
 // Namespaces is used to query the namespace endpoints.
@@ -70,16 +71,18 @@ func (n *Namespaces) Delete(namespace string, q *WriteOptions) (*WriteMeta, erro
 
 // Namespace is used to serialize a namespace.
 type Namespace struct {
-	Name                  string
-	Description           string
-	Quota                 string
-	Capabilities          *NamespaceCapabilities          `hcl:"capabilities,block"`
-	NodePoolConfiguration *NamespaceNodePoolConfiguration `hcl:"node_pool_config,block"`
-	VaultConfiguration    *NamespaceVaultConfiguration    `hcl:"vault,block"`
-	ConsulConfiguration   *NamespaceConsulConfiguration   `hcl:"consul,block"`
-	Meta                  map[string]string
-	CreateIndex           uint64
-	ModifyIndex           uint64
+	Name                    string
+	Description             string
+	Quota                   string
+	Capabilities            *NamespaceCapabilities            `hcl:"capabilities,block"`
+	NodePoolConfiguration   *NamespaceNodePoolConfiguration   `hcl:"node_pool_config,block"`
+	VaultConfiguration      *NamespaceVaultConfiguration      `hcl:"vault,block"`
+	ConsulConfiguration     *NamespaceConsulConfiguration     `hcl:"consul,block"`
+	PreemptionConfiguration *NamespacePreemptionConfiguration `hcl:"preemption,block"`
+	GCConfiguration         *NamespaceGCConfiguration         `hcl:"gc_config,block"`
+	Meta                    map[string]string
+	CreateIndex             uint64
+	ModifyIndex             uint64
 }
 
 // NamespaceCapabilities represents a set of capabilities allowed for this
@@ -143,6 +146,30 @@ type NamespaceConsulConfiguration struct {
 	Denied []string
 }
 
+// NamespacePreemptionConfiguration stores configuration about whether the
+// allocations of a namespace may be preempted to make room for allocations of
+// higher priority jobs.
+type NamespacePreemptionConfiguration struct {
+	// Protected prevents any allocation in this namespace from being
+	// preempted, regardless of the priority of its job.
+	Protected bool `hcl:"protected"`
+
+	// PriorityFloor prevents allocations of jobs with a priority at or above
+	// the floor from being preempted, while allocations of jobs with a lower
+	// priority remain preemptible. Zero disables the floor.
+	PriorityFloor int `hcl:"priority_floor"`
+}
+
+// NamespaceGCConfiguration stores the garbage collection thresholds of a
+// namespace, overriding the thresholds of the server configuration. Zero
+// thresholds use the server configuration.
+type NamespaceGCConfiguration struct {
+	JobGCThreshold        time.Duration `hcl:"job_gc_threshold" mapstructure:"job_gc_threshold"`
+	EvalGCThreshold       time.Duration `hcl:"eval_gc_threshold" mapstructure:"eval_gc_threshold"`
+	BatchEvalGCThreshold  time.Duration `hcl:"batch_eval_gc_threshold" mapstructure:"batch_eval_gc_threshold"`
+	DeploymentGCThreshold time.Duration `hcl:"deployment_gc_threshold" mapstructure:"deployment_gc_threshold"`
+}
+
 // NamespaceIndexSort is a wrapper to sort Namespaces by CreateIndex. We
 // reverse the test so that we get the highest index first.
 type NamespaceIndexSort []*Namespace
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 const (
@@ -17,6 +18,24 @@ const (
 	NodePoolDefault = "default"
 )
 
+const (
+	// Statuses of a node lifecycle run.
+	NodeLifecycleStatusRunning  = "running"
+	NodeLifecycleStatusComplete = "complete"
+	NodeLifecycleStatusFailed   = "failed"
+	NodeLifecycleStatusAborted  = "aborted"
+
+	// Phases a node goes through during a node lifecycle run.
+	NodeLifecyclePhasePending    = "pending"
+	NodeLifecyclePhaseCordon     = "cordon"
+	NodeLifecyclePhaseDrain      = "drain"
+	NodeLifecyclePhaseAction     = "action"
+	NodeLifecyclePhaseReregister = "reregister"
+	NodeLifecyclePhaseUncordon   = "uncordon"
+	NodeLifecyclePhaseComplete   = "complete"
+	NodeLifecyclePhaseFailed     = "failed"
+)
+
 // NodePools is used to access node pools endpoints.
 type NodePools struct {
 	client *Client
@@ -113,12 +132,68 @@ func (n *NodePools) ListNodes(poolName string, q *QueryOptions) ([]*NodeListStub
 	return resp, qm, nil
 }
 
+// StartLifecycle is used to start a node lifecycle run cycling the nodes of a
+// node pool through maintenance. The run is driven by the leader.
+func (n *NodePools) StartLifecycle(poolName string, req *NodeLifecycleStartRequest, w *WriteOptions) (*NodeLifecycleRun, *WriteMeta, error) {
+	if poolName == "" {
+		return nil, nil, errors.New("missing node pool name")
+	}
+	if req == nil {
+		req = &NodeLifecycleStartRequest{}
+	}
+
+	var resp NodeLifecycleRun
+	wm, err := n.client.put(
+		fmt.Sprintf("/v1/node/pool/%s/lifecycle", url.PathEscape(poolName)),
+		req, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// LifecycleStatus is used to fetch the last node lifecycle run of a node pool.
+func (n *NodePools) LifecycleStatus(poolName string, q *QueryOptions) (*NodeLifecycleRun, *QueryMeta, error) {
+	if poolName == "" {
+		return nil, nil, errors.New("missing node pool name")
+	}
+
+	var resp NodeLifecycleRun
+	qm, err := n.client.query(
+		fmt.Sprintf("/v1/node/pool/%s/lifecycle", url.PathEscape(poolName)),
+		&resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// AbortLifecycle is used to abort the running node lifecycle run of a node
+// pool.
+func (n *NodePools) AbortLifecycle(poolName string, w *WriteOptions) (*NodeLifecycleRun, *WriteMeta, error) {
+	if poolName == "" {
+		return nil, nil, errors.New("missing node pool name")
+	}
+
+	var resp NodeLifecycleRun
+	wm, err := n.client.put(
+		fmt.Sprintf("/v1/node/pool/%s/lifecycle/abort", url.PathEscape(poolName)),
+		nil, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // NodePool is used to serialize a node pool.
 type NodePool struct {
 	Name                   string                          `hcl:"name,label"`
 	Description            string                          `hcl:"description,optional"`
 	Meta                   map[string]string               `hcl:"meta,block"`
 	SchedulerConfiguration *NodePoolSchedulerConfiguration `hcl:"scheduler_config,block"`
+	MaxJobFeatureLevel     string                          `hcl:"max_job_feature_level,optional"`
+	ReadinessGates         []*NodePoolReadinessGate        `hcl:"readiness_gate,block"`
+	PortConfiguration      *NodePoolPortConfiguration      `hcl:"port_config,block"`
 	CreateIndex            uint64
 	ModifyIndex            uint64
 }
@@ -129,3 +204,65 @@ type NodePoolSchedulerConfiguration struct {
 	SchedulerAlgorithm            SchedulerAlgorithm `hcl:"scheduler_algorithm,optional"`
 	MemoryOversubscriptionEnabled *bool              `hcl:"memory_oversubscription_enabled,optional"`
 }
+
+// NodePoolPortConfiguration is used to serialize the port configuration
+// applied to the nodes of a node pool.
+type NodePoolPortConfiguration struct {
+	MinDynamicPort int    `hcl:"min_dynamic_port,optional"`
+	MaxDynamicPort int    `hcl:"max_dynamic_port,optional"`
+	ReservedPorts  string `hcl:"reserved_ports,optional"`
+}
+
+// NodePoolReadinessGate is used to serialize a job that must have a healthy
+// allocation on a node of the node pool before allocations of other jobs are
+// placed on the node.
+type NodePoolReadinessGate struct {
+	Namespace string `hcl:"namespace,optional"`
+	JobID     string `hcl:"job_id"`
+}
+
+// NodeLifecycleStartRequest is used to start a node lifecycle run. Unset
+// fields take the defaults of the servers.
+type NodeLifecycleStartRequest struct {
+	// BatchSize is the number of nodes cycled at the same time.
+	BatchSize int
+
+	// DrainSpec is the drain applied to each node.
+	DrainSpec *DrainSpec
+
+	// WebhookURL is the URL of the custom action webhook called for each
+	// drained node.
+	WebhookURL string
+
+	// ReregisterTimeout is the maximum amount of time to wait for a node to
+	// register again after the webhook was called.
+	ReregisterTimeout time.Duration
+}
+
+// NodeLifecycleRun is used to serialize a node lifecycle run.
+type NodeLifecycleRun struct {
+	ID                string
+	NodePool          string
+	BatchSize         int
+	DrainSpec         *DrainSpec
+	WebhookURL        string
+	ReregisterTimeout time.Duration
+	Status            string
+	StatusDescription string
+	Nodes             []*NodeLifecycleNode
+	CreateIndex       uint64
+	ModifyIndex       uint64
+	CreateTime        int64
+	ModifyTime        int64
+}
+
+// NodeLifecycleNode is used to serialize the progress of a node in a node
+// lifecycle run.
+type NodeLifecycleNode struct {
+	NodeID          string
+	NodeName        string
+	NodeCreateIndex uint64
+	Phase           string
+	PhaseStartTime  int64
+	Error           string
+}
@@ -736,6 +736,11 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// BatchGrace is the duration past the deadline during which the remaining
+	// batch allocations on the node are allowed to complete before they are
+	// told to stop.
+	BatchGrace time.Duration
 }
 
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
@@ -752,6 +757,9 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
 	}
+	if d.BatchGrace != o.BatchGrace {
+		return false
+	}
 
 	return true
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -180,6 +181,16 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// PlanEvaluatePoolMin and PlanEvaluatePoolMax bound the number of workers
+	// used by the leader to evaluate plans. Zero values fall back to the
+	// server configuration.
+	PlanEvaluatePoolMin int
+	PlanEvaluatePoolMax int
+
+	// EvalBrokerDequeueMode controls the order in which the eval broker hands
+	// evaluations to the scheduler workers.
+	EvalBrokerDequeueMode EvalBrokerDequeueMode
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -213,6 +224,16 @@ const (
 	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
 )
 
+// EvalBrokerDequeueMode is an enum string that encapsulates the valid options
+// for a SchedulerConfiguration block's EvalBrokerDequeueMode.
+type EvalBrokerDequeueMode string
+
+const (
+	EvalBrokerDequeueModePriority      EvalBrokerDequeueMode = "priority"
+	EvalBrokerDequeueModeNamespaceFair EvalBrokerDequeueMode = "namespace_fair"
+	EvalBrokerDequeueModePriorityFair  EvalBrokerDequeueMode = "priority_fair"
+)
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	SystemSchedulerEnabled   bool
@@ -254,6 +275,232 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 	return &out, wm, nil
 }
 
+// PlanRejectionNode is a node tracked by the plan rejection tracker.
+type PlanRejectionNode struct {
+	NodeID        string
+	Score         int
+	LastRejection time.Time
+}
+
+// PlanRejectionTracker is the state of the plan rejection tracker of the
+// leader.
+type PlanRejectionTracker struct {
+	Enabled   bool
+	Threshold int
+	Window    time.Duration
+	Nodes     []*PlanRejectionNode
+}
+
+// PlanRejectionTrackerResetResponse is the response of a plan rejection
+// tracker reset.
+type PlanRejectionTrackerResetResponse struct {
+	Removed int
+}
+
+// PlanRejectionTracker is used to query the nodes tracked by the plan
+// rejection tracker of the leader.
+func (op *Operator) PlanRejectionTracker(q *QueryOptions) (*PlanRejectionTracker, *QueryMeta, error) {
+	var resp PlanRejectionTracker
+	qm, err := op.c.query("/v1/operator/plan-rejections", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// PlanRejectionTrackerReset is used to clear the plan rejection history of a
+// node, or of all nodes if nodeID is empty. Nodes already marked as
+// ineligible are not modified.
+func (op *Operator) PlanRejectionTrackerReset(nodeID string, q *WriteOptions) (*PlanRejectionTrackerResetResponse, *WriteMeta, error) {
+	endpoint := "/v1/operator/plan-rejections"
+	if nodeID != "" {
+		endpoint += "?node_id=" + url.QueryEscape(nodeID)
+	}
+
+	var resp PlanRejectionTrackerResetResponse
+	wm, err := op.c.delete(endpoint, nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// PendingPlan is a plan waiting in the plan queue of the leader.
+type PendingPlan struct {
+	EvalID            string
+	Namespace         string
+	JobID             string
+	Priority          int
+	EffectivePriority int
+	EnqueueTime       time.Time
+	WaitTime          time.Duration
+}
+
+// PlanQueueWaitTime is the distribution of the time recently dequeued plans
+// spent waiting in the plan queue.
+type PlanQueueWaitTime struct {
+	Samples int
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+}
+
+// PlanQueueStatus is a snapshot of the plan queue of the leader.
+type PlanQueueStatus struct {
+	Enabled         bool
+	Depth           int
+	DepthByPriority map[int]int
+	Pending         []*PendingPlan
+	WaitTime        PlanQueueWaitTime
+}
+
+// PlanQueueStatus is used to query the plans waiting in the plan queue of
+// the leader and how long plans wait before being evaluated.
+func (op *Operator) PlanQueueStatus(q *QueryOptions) (*PlanQueueStatus, *QueryMeta, error) {
+	var resp PlanQueueStatus
+	qm, err := op.c.query("/v1/operator/scheduler/plan-queue", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// ReplicationConflict is a namespace or node pool of the authoritative region
+// that the leader of a federated region can't replicate.
+type ReplicationConflict struct {
+	Type   string
+	Name   string
+	Reason string
+	Since  time.Time
+}
+
+// ReplicationConflicts is used to query the namespaces and node pools the
+// leader can't replicate from the authoritative region.
+func (op *Operator) ReplicationConflicts(q *QueryOptions) ([]*ReplicationConflict, *QueryMeta, error) {
+	var resp []*ReplicationConflict
+	qm, err := op.c.query("/v1/operator/replication/conflicts", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// SchedulerSimulateRequest is used to simulate scheduling a job.
+type SchedulerSimulateRequest struct {
+	Job *Job
+	WriteRequest
+}
+
+// SimulatedAllocation is an allocation placed, stopped or preempted by a
+// scheduler simulation. Metrics are only set for placed allocations.
+type SimulatedAllocation struct {
+	ID        string
+	Name      string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+	Metrics   *AllocationMetric
+}
+
+// SchedulerSimulation is the outcome of running the scheduler for a job and
+// evaluating the resulting plan, without committing it.
+type SchedulerSimulation struct {
+	Placements     []*SimulatedAllocation
+	Stops          []*SimulatedAllocation
+	Preemptions    []*SimulatedAllocation
+	FailedTGAllocs map[string]*AllocationMetric
+	NodeRejections map[string]string
+	Warnings       string
+}
+
+// SchedulerSimulate is used to run the scheduler for the job against the
+// current state of the cluster without committing the results, to find out
+// where its allocations would be placed and which allocations would be
+// preempted.
+func (op *Operator) SchedulerSimulate(job *Job, q *WriteOptions) (*SchedulerSimulation, *WriteMeta, error) {
+	if job == nil {
+		return nil, nil, errors.New("must pass non-nil job")
+	}
+	if job.ID == nil {
+		return nil, nil, errors.New("job is missing ID")
+	}
+
+	req := &SchedulerSimulateRequest{
+		Job: job,
+	}
+
+	var resp SchedulerSimulation
+	wm, err := op.c.put("/v1/operator/scheduler/simulate", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// EvalBrokerSchedulerStatus is the number of evaluations of one scheduler
+// type in each state of the eval broker.
+type EvalBrokerSchedulerStatus struct {
+	Ready            int
+	Unacked          int
+	Pending          int
+	Waiting          int
+	OldestReadyAge   time.Duration
+	OldestPendingAge time.Duration
+}
+
+// EvalBrokerStatus is a snapshot of the eval broker of the leader.
+type EvalBrokerStatus struct {
+	Enabled           bool
+	DequeueMode       EvalBrokerDequeueMode
+	TotalReady        int
+	TotalUnacked      int
+	TotalPending      int
+	TotalWaiting      int
+	TotalCancelable   int
+	ByScheduler       map[string]*EvalBrokerSchedulerStatus
+	OldestReadyAge    time.Duration
+	OldestPendingAge  time.Duration
+	DequeuesPerMinute int
+	NacksPerMinute    int
+	RequeuesPerMinute int
+	TotalDequeues     uint64
+	TotalNacks        uint64
+	TotalRequeues     uint64
+}
+
+// EvalBrokerStatus is used to query the number of evaluations in each state
+// of the eval broker of the leader and its dequeue, Nack, and requeue rates.
+func (op *Operator) EvalBrokerStatus(q *QueryOptions) (*EvalBrokerStatus, *QueryMeta, error) {
+	var resp EvalBrokerStatus
+	qm, err := op.c.query("/v1/operator/scheduler/eval-broker", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// StateTableUsage is the number of objects and their approximate size in a
+// table of the state store.
+type StateTableUsage struct {
+	Name      string
+	Objects   int
+	SizeBytes int64
+}
+
+// StateUsage is used to query the number of objects and their approximate
+// size in each table of the state store.
+func (op *Operator) StateUsage(q *QueryOptions) ([]*StateTableUsage, *QueryMeta, error) {
+	var resp []*StateTableUsage
+	qm, err := op.c.query("/v1/operator/state/usage", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Snapshot is used to capture a snapshot state of a running cluster.
 // The returned reader that must be consumed fully
 func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
@@ -132,6 +132,10 @@ type ServerHealth struct {
 	// Voter is whether this is a voting server.
 	Voter bool
 
+	// ReadReplica is whether this server is a read replica, which is never
+	// promoted to a voter.
+	ReadReplica bool
+
 	// StableSince is the last time this server's Healthy value changed.
 	StableSince time.Time
 }
@@ -173,6 +173,10 @@ type NetworkResource struct {
 	DynamicPorts  []Port     `hcl:"port,block"`
 	Hostname      string     `hcl:"hostname,optional"`
 
+	// PreserveIdentity keeps the bridge address and dynamic ports of a
+	// replaced allocation when its replacement lands on the same node.
+	PreserveIdentity bool `hcl:"preserve_identity,optional"`
+
 	// COMPAT(0.13)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.13 and is only being kept to allow any references to be removed before
@@ -3,6 +3,11 @@
 
 package api
 
+import (
+	"net/url"
+	"time"
+)
+
 const (
 	// ScalingPolicyTypeHorizontal indicates a policy that does horizontal scaling.
 	ScalingPolicyTypeHorizontal = "horizontal"
@@ -36,6 +41,41 @@ func (s *Scaling) GetPolicy(id string, q *QueryOptions) (*ScalingPolicy, *QueryM
 	return &policy, qm, nil
 }
 
+// ScalingEventsOptions are the filters applied when listing scaling events.
+type ScalingEventsOptions struct {
+	// Start and End bound the time of the returned events. A zero value
+	// leaves the range unbounded on that side.
+	Start time.Time
+	End   time.Time
+
+	// Source only returns the events requested by the given source.
+	Source string
+}
+
+// ListEvents returns the scaling events of all jobs in the namespace of the
+// query, newest first.
+func (s *Scaling) ListEvents(opts *ScalingEventsOptions, q *QueryOptions) ([]*ScalingEventListStub, *QueryMeta, error) {
+	qp := url.Values{}
+	if opts != nil {
+		if !opts.Start.IsZero() {
+			qp.Set("start", opts.Start.Format(time.RFC3339))
+		}
+		if !opts.End.IsZero() {
+			qp.Set("end", opts.End.Format(time.RFC3339))
+		}
+		if opts.Source != "" {
+			qp.Set("source", opts.Source)
+		}
+	}
+
+	var resp []*ScalingEventListStub
+	qm, err := s.client.query("/v1/scaling/events?"+qp.Encode(), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 func (p *ScalingPolicy) Canonicalize(taskGroupCount int) {
 	if p.Enabled == nil {
 		p.Enabled = pointerOf(true)
@@ -56,6 +96,13 @@ type ScalingRequest struct {
 	Message string
 	Error   bool
 	Meta    map[string]interface{}
+
+	// Source, MetricSnapshot and DryRun annotate the recorded scaling event.
+	// A dry run records the event without changing the group count.
+	Source         string
+	MetricSnapshot map[string]float64
+	DryRun         bool
+
 	WriteRequest
 
 	// this is effectively a job update, so we need the ability to override policy.
@@ -70,11 +117,13 @@ type ScalingRequest struct {
 type ScalingPolicy struct {
 	/* fields set by user in HCL config */
 
-	Min     *int64                 `hcl:"min,optional"`
-	Max     *int64                 `hcl:"max,optional"`
-	Policy  map[string]interface{} `hcl:"policy,block"`
-	Enabled *bool                  `hcl:"enabled,optional"`
-	Type    string                 `hcl:"type,optional"`
+	Min      *int64                 `hcl:"min,optional"`
+	Max      *int64                 `hcl:"max,optional"`
+	MaxStep  *int64                 `hcl:"max_step,optional"`
+	Cooldown *time.Duration         `hcl:"cooldown,optional"`
+	Policy   map[string]interface{} `hcl:"policy,block"`
+	Enabled  *bool                  `hcl:"enabled,optional"`
+	Type     string                 `hcl:"type,optional"`
 
 	/* fields set by server */
 
@@ -116,12 +165,24 @@ type TaskGroupScaleStatus struct {
 }
 
 type ScalingEvent struct {
-	Count         *int64
-	PreviousCount int64
-	Error         bool
-	Message       string
-	Meta          map[string]interface{}
-	EvalID        *string
-	Time          uint64
-	CreateIndex   uint64
+	Count          *int64
+	PreviousCount  int64
+	Error          bool
+	Message        string
+	Meta           map[string]interface{}
+	Source         string
+	MetricSnapshot map[string]float64
+	DryRun         bool
+	EvalID         *string
+	Time           uint64
+	CreateIndex    uint64
+}
+
+// ScalingEventListStub is a scaling event along with the task group it
+// belongs to.
+type ScalingEventListStub struct {
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Event     *ScalingEvent
 }
@@ -58,6 +58,32 @@ func (s *Search) FuzzySearch(text string, context contexts.Context, q *QueryOpti
 	return &resp, qm, nil
 }
 
+// FuzzySearchOpts returns a set of matches for the given request, which can
+// filter the types of matches and control their ranking.
+func (s *Search) FuzzySearchOpts(req *FuzzySearchRequest, q *QueryOptions) (*FuzzySearchResponse, *QueryMeta, error) {
+	var resp FuzzySearchResponse
+
+	qm, err := s.client.putQuery("/v1/search/fuzzy", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, qm, nil
+}
+
+// FuzzyRanking defines the order of the matches of each Context in a fuzzy
+// search response.
+type FuzzyRanking string
+
+const (
+	// FuzzyRankingScore orders matches by the position of the search text in
+	// the name, then by the length of the name. This is the default.
+	FuzzyRankingScore FuzzyRanking = "score"
+
+	// FuzzyRankingName orders matches alphabetically by name.
+	FuzzyRankingName FuzzyRanking = "name"
+)
+
 // FuzzyMatch is used to describe the ID of an object which may be a machine
 // readable UUID or a human readable Name. If the object is a component of a Job,
 // the Scope is a list of IDs starting from Namespace down to the parent object of
@@ -97,5 +123,15 @@ type FuzzySearchRequest struct {
 	// all Contexts types are queried for matching.
 	Context contexts.Context
 
+	// Contexts restricts the response to matches of the given types, and
+	// takes precedence over Context when set.
+	Contexts []contexts.Context `json:",omitempty"`
+
+	// Ranking controls the order of the matches of each Context.
+	Ranking FuzzyRanking `json:",omitempty"`
+
+	// Limit caps the number of matches returned for each Context.
+	Limit int `json:",omitempty"`
+
 	QueryOptions
 }
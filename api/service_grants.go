@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+)
+
+// ServiceGrantAllServices is the service name of grants that expose all the
+// services of their namespace.
+const ServiceGrantAllServices = "*"
+
+// ServiceGrant allows the workloads of a namespace to look up the Nomad
+// services registered in another namespace. Without a grant, workloads can
+// only look up the services of their own namespace.
+type ServiceGrant struct {
+	// ID is the unique identifier of the grant. It is generated when the
+	// grant is created.
+	ID string
+
+	// Namespace is the namespace of the services exposed by the grant.
+	Namespace string
+
+	// ServiceName is the name of the service exposed by the grant, or "*" to
+	// expose all the services of the namespace.
+	ServiceName string
+
+	// GrantedNamespace is the namespace whose workloads are allowed to look
+	// up the services.
+	GrantedNamespace string
+
+	// Description is the human-friendly description of the grant.
+	Description string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ServiceGrants is used to query the service grant endpoints.
+type ServiceGrants struct {
+	client *Client
+}
+
+// ServiceGrants returns a new handle on the service grant endpoints.
+func (c *Client) ServiceGrants() *ServiceGrants {
+	return &ServiceGrants{client: c}
+}
+
+// List returns the service grants exposing the services of the namespace set
+// in the query options, or of all namespaces when using the "*" namespace.
+func (s *ServiceGrants) List(q *QueryOptions) ([]*ServiceGrant, *QueryMeta, error) {
+	var resp []*ServiceGrant
+	qm, err := s.client.query("/v1/service-grants", &resp, q)
+	if err != nil {
+		return nil, qm, err
+	}
+	return resp, qm, nil
+}
+
+// Upsert creates or updates a service grant and returns it. A grant without
+// an ID that exposes the same services to the same namespace as an existing
+// grant updates it.
+func (s *ServiceGrants) Upsert(grant *ServiceGrant, q *WriteOptions) (*ServiceGrant, *WriteMeta, error) {
+	if grant == nil {
+		return nil, nil, errors.New("missing service grant")
+	}
+
+	var resp ServiceGrant
+	wm, err := s.client.put("/v1/service-grants", grant, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Delete deletes the service grant with the given ID.
+func (s *ServiceGrants) Delete(id string, q *WriteOptions) (*WriteMeta, error) {
+	if id == "" {
+		return nil, errors.New("missing service grant ID")
+	}
+
+	wm, err := s.client.delete("/v1/service-grant/"+url.PathEscape(id), nil, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import "net/url"
+
+// TaskLeadership is the state of an allocation in a leader election held
+// through the Task API.
+type TaskLeadership struct {
+	// Name is the name of the election, unique within the job.
+	Name string
+
+	// Namespace and Path of the variable holding the lock of the election.
+	Namespace string
+	Path      string
+
+	// Campaigning is true while the allocation takes part in the election.
+	Campaigning bool
+
+	// Leader is true while the allocation holds the lock of the election.
+	Leader bool
+
+	// FencingToken is the fencing token of the lock while the allocation is
+	// the leader.
+	FencingToken uint64
+}
+
+// TaskLeaderships is used to take part in leader elections between the
+// allocations of a job. It must be used through the Task API with the workload
+// identity of the task.
+type TaskLeaderships struct {
+	client *Client
+}
+
+// TaskLeaderships returns a handle on the leader elections of the Task API.
+func (c *Client) TaskLeaderships() *TaskLeaderships {
+	return &TaskLeaderships{client: c}
+}
+
+// Status returns the state of the allocation in the named election.
+func (t *TaskLeaderships) Status(name string, q *QueryOptions) (*TaskLeadership, *QueryMeta, error) {
+	var resp TaskLeadership
+	qm, err := t.client.query("/v1/task/leadership/"+url.PathEscape(name), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Campaign makes the allocation take part in the named election. The Nomad
+// client acquires and renews the lock of the election on behalf of the
+// allocation while it is healthy, and releases it when the allocation becomes
+// unhealthy or stops.
+func (t *TaskLeaderships) Campaign(name string, q *WriteOptions) (*TaskLeadership, *WriteMeta, error) {
+	var resp TaskLeadership
+	wm, err := t.client.put("/v1/task/leadership/"+url.PathEscape(name), nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Resign makes the allocation leave the named election, releasing its lock if
+// it is the leader.
+func (t *TaskLeaderships) Resign(name string, q *WriteOptions) (*TaskLeadership, *WriteMeta, error) {
+	var resp TaskLeadership
+	wm, err := t.client.delete("/v1/task/leadership/"+url.PathEscape(name), nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
@@ -154,6 +154,92 @@ func (ds *DisconnectStrategy) Canonicalize() {
 	}
 }
 
+// ScaleWithNodes keeps the count of a task group proportional to the number
+// of nodes matching its selectors.
+type ScaleWithNodes struct {
+	// NodePool restricts the counted nodes to a node pool. Defaults to the
+	// node pool of the job.
+	NodePool *string `mapstructure:"node_pool" hcl:"node_pool,optional"`
+
+	// NodeClass is a glob pattern matched against the class of each node.
+	NodeClass *string `mapstructure:"node_class" hcl:"node_class,optional"`
+
+	// PerNode is the number of allocations desired for each matching node.
+	PerNode *int `mapstructure:"per_node" hcl:"per_node,optional"`
+
+	// Min and Max bound the computed count. Min defaults to 1 so that the
+	// group is only scaled to zero if it is explicitly allowed. A Max of
+	// zero is unbounded.
+	Min *int `mapstructure:"min" hcl:"min,optional"`
+	Max *int `mapstructure:"max" hcl:"max,optional"`
+
+	// ScaleDownDelay is how long the computed count must stay below the
+	// group count before the group is scaled down.
+	ScaleDownDelay *time.Duration `mapstructure:"scale_down_delay" hcl:"scale_down_delay,optional"`
+}
+
+func (s *ScaleWithNodes) Canonicalize() {
+	if s.NodePool == nil {
+		s.NodePool = pointerOf("")
+	}
+	if s.NodeClass == nil {
+		s.NodeClass = pointerOf("")
+	}
+	if s.PerNode == nil {
+		s.PerNode = pointerOf(1)
+	}
+	if s.Min == nil {
+		s.Min = pointerOf(1)
+	}
+	if s.Max == nil {
+		s.Max = pointerOf(0)
+	}
+	if s.ScaleDownDelay == nil {
+		s.ScaleDownDelay = pointerOf(5 * time.Minute)
+	}
+}
+
+// DisruptionBudget is the minimum number of healthy allocations of a service
+// task group that node drains and preemption must leave running.
+type DisruptionBudget struct {
+	// MinHealthy is the minimum number of healthy allocations.
+	MinHealthy *int `mapstructure:"min_healthy" hcl:"min_healthy,optional"`
+
+	// MinHealthyPercent is the minimum number of healthy allocations as a
+	// percentage of the group count.
+	MinHealthyPercent *int `mapstructure:"min_healthy_percent" hcl:"min_healthy_percent,optional"`
+}
+
+func (b *DisruptionBudget) Canonicalize() {
+	if b.MinHealthy == nil {
+		b.MinHealthy = pointerOf(0)
+	}
+	if b.MinHealthyPercent == nil {
+		b.MinHealthyPercent = pointerOf(0)
+	}
+}
+
+// PreemptionNotice configures how the allocations of a task group are notified
+// when they are preempted before they are evicted.
+type PreemptionNotice struct {
+	// Grace is how long a preempted allocation keeps running before its tasks
+	// are killed.
+	Grace *time.Duration `mapstructure:"grace" hcl:"grace,optional"`
+
+	// Signal is sent to the tasks of a preempted allocation at the start of
+	// the grace period.
+	Signal *string `mapstructure:"signal" hcl:"signal,optional"`
+}
+
+func (n *PreemptionNotice) Canonicalize() {
+	if n.Grace == nil {
+		n.Grace = pointerOf(time.Duration(0))
+	}
+	if n.Signal == nil {
+		n.Signal = pointerOf("")
+	}
+}
+
 // Reschedule configures how Tasks are rescheduled  when they crash or fail.
 type ReschedulePolicy struct {
 	// Attempts limits the number of rescheduling attempts that can occur in an interval.
@@ -332,6 +418,7 @@ type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	Topology     []string        `hcl:"topology,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -355,6 +442,15 @@ func NewSpread(attribute string, weight int8, spreadTargets []*SpreadTarget) *Sp
 	}
 }
 
+// NewTopologySpread returns a spread across the nested failure domains given by
+// the topology attributes, ordered from the broadest to the narrowest.
+func NewTopologySpread(weight int8, topology ...string) *Spread {
+	return &Spread{
+		Weight:   pointerOf(weight),
+		Topology: topology,
+	}
+}
+
 func (s *Spread) Canonicalize() {
 	if s.Weight == nil {
 		s.Weight = pointerOf(int8(50))
@@ -515,9 +611,13 @@ type TaskGroup struct {
 	// Deprecated: StopAfterClientDisconnect is deprecated in Nomad 1.8. Use Disconnect.StopOnClientAfter instead.
 	StopAfterClientDisconnect *time.Duration `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.LostAfter
-	MaxClientDisconnect *time.Duration `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
-	Scaling             *ScalingPolicy `hcl:"scaling,block"`
-	Consul              *Consul        `hcl:"consul,block"`
+	MaxClientDisconnect *time.Duration    `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
+	Scaling             *ScalingPolicy    `hcl:"scaling,block"`
+	ScaleWithNodes      *ScaleWithNodes   `hcl:"scale_with_nodes,block"`
+	DisruptionBudget    *DisruptionBudget `hcl:"disruption_budget,block"`
+	PreemptionNotice    *PreemptionNotice `hcl:"preemption_notice,block"`
+	Gang                *string           `hcl:"gang,optional"`
+	Consul              *Consul           `hcl:"consul,block"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.Replace
 	PreventRescheduleOnLost *bool `hcl:"prevent_reschedule_on_lost,optional"`
 }
@@ -647,6 +747,16 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	if g.Disconnect != nil {
 		g.Disconnect.Canonicalize()
 	}
+
+	if g.ScaleWithNodes != nil {
+		g.ScaleWithNodes.Canonicalize()
+	}
+	if g.DisruptionBudget != nil {
+		g.DisruptionBudget.Canonicalize()
+	}
+	if g.PreemptionNotice != nil {
+		g.PreemptionNotice.Canonicalize()
+	}
 }
 
 // These needs to be in sync with DefaultServiceJobRestartPolicy in
@@ -722,13 +832,20 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	Compress       *bool `mapstructure:"compress" hcl:"compress,optional"`
+	MaxTotalSizeMB *int  `mapstructure:"max_total_size" hcl:"max_total_size,optional"`
+	Journald       *bool `mapstructure:"journald" hcl:"journald,optional"`
 }
 
 func DefaultLogConfig() *LogConfig {
 	return &LogConfig{
-		MaxFiles:      pointerOf(10),
-		MaxFileSizeMB: pointerOf(10),
-		Disabled:      pointerOf(false),
+		MaxFiles:       pointerOf(10),
+		MaxFileSizeMB:  pointerOf(10),
+		Disabled:       pointerOf(false),
+		Compress:       pointerOf(false),
+		MaxTotalSizeMB: pointerOf(0),
+		Journald:       pointerOf(false),
 	}
 }
 
@@ -742,6 +859,15 @@ func (l *LogConfig) Canonicalize() {
 	if l.Disabled == nil {
 		l.Disabled = pointerOf(false)
 	}
+	if l.Compress == nil {
+		l.Compress = pointerOf(false)
+	}
+	if l.MaxTotalSizeMB == nil {
+		l.MaxTotalSizeMB = pointerOf(0)
+	}
+	if l.Journald == nil {
+		l.Journald = pointerOf(false)
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -1135,6 +1261,7 @@ const (
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 	TaskClientReconnected      = "Reconnected"
+	TaskStartQueued            = "Start Queued"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -1240,6 +1367,7 @@ type WorkloadIdentity struct {
 	Filepath     string        `hcl:"filepath,optional"`
 	ServiceName  string        `hcl:"service_name,optional"`
 	TTL          time.Duration `mapstructure:"ttl" hcl:"ttl,optional"`
+	MetaClaims   []string      `mapstructure:"meta_claims" hcl:"meta_claims,optional"`
 }
 
 type Action struct {
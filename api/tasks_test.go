@@ -488,15 +488,17 @@ func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	job := &Job{
 		ID: pointerOf("test"),
 		Update: &UpdateStrategy{
-			AutoRevert:       pointerOf(false),
-			AutoPromote:      pointerOf(false),
-			Canary:           pointerOf(0),
-			HealthCheck:      pointerOf(""),
-			HealthyDeadline:  pointerOf(time.Duration(0)),
-			ProgressDeadline: pointerOf(time.Duration(0)),
-			MaxParallel:      pointerOf(0),
-			MinHealthyTime:   pointerOf(time.Duration(0)),
-			Stagger:          pointerOf(time.Duration(0)),
+			AutoRevert:                pointerOf(false),
+			AutoPromote:               pointerOf(false),
+			Canary:                    pointerOf(0),
+			HealthCheck:               pointerOf(""),
+			HealthyDeadline:           pointerOf(time.Duration(0)),
+			ProgressDeadline:          pointerOf(time.Duration(0)),
+			MaxParallel:               pointerOf(0),
+			MinHealthyTime:            pointerOf(time.Duration(0)),
+			Stagger:                   pointerOf(time.Duration(0)),
+			AutoPromoteThreshold:      pointerOf(0),
+			AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
 		},
 	}
 	job.Canonicalize()
@@ -580,20 +582,32 @@ func TestTaskGroup_Merge_Update(t *testing.T) {
 			AutoRevert:  pointerOf(true),
 			Canary:      pointerOf(5),
 			HealthCheck: pointerOf("foo"),
+			RollbackOn: &RollbackOn{
+				Query:      pointerOf("up"),
+				Comparison: pointerOf(RollbackOnComparisonBelow),
+			},
 		},
 	}
 
 	tg.Canonicalize(job)
 	must.Eq(t, &UpdateStrategy{
-		AutoRevert:       pointerOf(true),
-		AutoPromote:      pointerOf(false),
-		Canary:           pointerOf(5),
-		HealthCheck:      pointerOf("foo"),
-		HealthyDeadline:  pointerOf(5 * time.Minute),
-		ProgressDeadline: pointerOf(10 * time.Minute),
-		MaxParallel:      pointerOf(1),
-		MinHealthyTime:   pointerOf(10 * time.Second),
-		Stagger:          pointerOf(30 * time.Second),
+		AutoRevert:                pointerOf(true),
+		AutoPromote:               pointerOf(false),
+		Canary:                    pointerOf(5),
+		HealthCheck:               pointerOf("foo"),
+		HealthyDeadline:           pointerOf(5 * time.Minute),
+		ProgressDeadline:          pointerOf(10 * time.Minute),
+		MaxParallel:               pointerOf(1),
+		MinHealthyTime:            pointerOf(10 * time.Second),
+		Stagger:                   pointerOf(30 * time.Second),
+		AutoPromoteThreshold:      pointerOf(0),
+		AutoPromoteMinHealthyTime: pointerOf(time.Duration(0)),
+		RollbackOn: &RollbackOn{
+			Query:      pointerOf("up"),
+			Threshold:  pointerOf(0.0),
+			Comparison: pointerOf(RollbackOnComparisonBelow),
+			Interval:   pointerOf(30 * time.Second),
+		},
 	}, tg.Update)
 }
 
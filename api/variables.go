@@ -440,6 +440,11 @@ type VariableLock struct {
 	// before another client may acquire the lock. This helps protect against
 	// split-brains. This is a string version of a time.Duration like "2m".
 	LockDelay string
+
+	// FencingToken is set by Nomad and increases every time the lock changes
+	// holder. Resources protected by the lock can reject requests carrying a
+	// lower token than the last one they have seen.
+	FencingToken uint64
 }
 
 // VariableItems are the key/value pairs of a Variable.
@@ -64,6 +64,8 @@ func (a *Agent) Profile(args *structs.AgentPprofRequest, reply *structs.AgentPpr
 		resp, headers, err = pprof.Profile(args.Profile, args.Debug, args.GC)
 	case pprof.TraceReq:
 		resp, headers, err = pprof.Trace(context.TODO(), args.Seconds)
+	default:
+		err = structs.NewErrRPCCoded(404, "Unknown profile request type")
 	}
 
 	if err != nil {
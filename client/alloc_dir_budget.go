@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// allocDirBudget enforces a disk budget for all the allocation directories
+// of the client. Above the high watermark it garbage collects terminal
+// allocations and, if that isn't enough, reports the node as over budget so
+// that the servers mark it ineligible until the usage drops below the low
+// watermark, so that tasks writing many logs or files can't fill the disk of
+// the node.
+type allocDirBudget struct {
+	budget uint64
+	high   uint64
+	low    uint64
+
+	// reported is true once the budget state of the node was reported to the
+	// servers, and exceeded is the state that was last reported. The servers
+	// track whether the node is ineligible because of the budget, so a
+	// restarted client only needs to report the state again.
+	reported bool
+	exceeded bool
+
+	usage     func() (uint64, error)
+	collect   func(reason string, done func() bool) int
+	report    func(exceeded bool) error
+	emitEvent func(*structs.NodeEvent)
+
+	labels []metrics.Label
+	logger hclog.Logger
+}
+
+// watchAllocDirBudget periodically enforces the disk budget of the allocation
+// directories, if one is configured.
+func (c *Client) watchAllocDirBudget() {
+	cfg := c.GetConfig()
+	if cfg.AllocDirDiskBudgetMB <= 0 {
+		return
+	}
+
+	budget := uint64(cfg.AllocDirDiskBudgetMB) * MB
+	b := &allocDirBudget{
+		budget: budget,
+		high:   uint64(float64(budget) * cfg.AllocDirDiskHighWatermark / 100),
+		low:    uint64(float64(budget) * cfg.AllocDirDiskLowWatermark / 100),
+		usage: func() (uint64, error) {
+			return allocdir.DiskUsage(cfg.AllocDir)
+		},
+		collect:   c.garbageCollector.CollectUntil,
+		report:    c.reportAllocDirBudget,
+		emitEvent: c.triggerNodeEvent,
+		labels:    c.baseLabels,
+		logger:    c.logger.Named("alloc_dir_budget"),
+	}
+
+	ticker := time.NewTicker(cfg.GCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.check()
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// reportAllocDirBudget reports to the servers whether the node is over its
+// alloc dir disk budget. The servers mark the node ineligible when it is over
+// budget, and only mark it eligible again if it was made ineligible by the
+// budget.
+func (c *Client) reportAllocDirBudget(exceeded bool) error {
+	req := &structs.NodeAllocDirBudgetRequest{
+		NodeID:   c.NodeID(),
+		Exceeded: exceeded,
+		WriteRequest: structs.WriteRequest{
+			Region: c.Region(), AuthToken: c.secretNodeID()},
+	}
+
+	var resp structs.NodeEligibilityUpdateResponse
+	return c.RPC("Node.UpdateAllocDirBudget", req, &resp)
+}
+
+// check measures the disk usage of the allocation directories and enforces
+// the budget.
+func (b *allocDirBudget) check() {
+	used, err := b.usage()
+	if err != nil {
+		b.logger.Warn("failed to measure disk usage of allocation directories", "error", err)
+		return
+	}
+
+	if used > b.high {
+		reason := fmt.Sprintf("alloc dir usage of %d MB is over budget watermark of %d MB",
+			used/MB, b.high/MB)
+		collected := b.collect(reason, func() bool {
+			if used, err = b.usage(); err != nil {
+				// Stop collecting on errors, the next check will retry
+				return true
+			}
+			return used <= b.high
+		})
+		if err != nil {
+			b.logger.Warn("failed to measure disk usage of allocation directories", "error", err)
+			return
+		}
+		if collected > 0 {
+			metrics.IncrCounterWithLabels([]string{"client", "alloc_dir", "budget", "evictions"}, float32(collected), b.labels)
+		}
+	}
+
+	metrics.SetGaugeWithLabels([]string{"client", "alloc_dir", "budget", "used"}, float32(used), b.labels)
+	metrics.SetGaugeWithLabels([]string{"client", "alloc_dir", "budget", "used_percent"}, float32(used)*100/float32(b.budget), b.labels)
+
+	switch {
+	case used > b.high && (!b.reported || !b.exceeded):
+		if err := b.report(true); err != nil {
+			b.logger.Error("failed to report alloc dir disk budget exceeded", "error", err)
+			return
+		}
+		b.reported, b.exceeded = true, true
+		b.logger.Warn("alloc dir disk budget exceeded, node is ineligible until usage is back under budget",
+			"used_mb", used/MB, "budget_mb", b.budget/MB)
+		metrics.IncrCounterWithLabels([]string{"client", "alloc_dir", "budget", "exceeded"}, 1, b.labels)
+		b.emitEvent(b.event("Alloc dir disk budget exceeded", used))
+
+	case used < b.low && (!b.reported || b.exceeded):
+		if err := b.report(false); err != nil {
+			b.logger.Error("failed to report alloc dir disk usage back under budget", "error", err)
+			return
+		}
+		wasExceeded := b.reported && b.exceeded
+		b.reported, b.exceeded = true, false
+		if wasExceeded {
+			b.logger.Info("alloc dir disk usage back under budget",
+				"used_mb", used/MB, "budget_mb", b.budget/MB)
+			b.emitEvent(b.event("Alloc dir disk usage back under budget", used))
+		}
+	}
+}
+
+func (b *allocDirBudget) event(msg string, used uint64) *structs.NodeEvent {
+	return structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemStorage).
+		SetMessage(msg).
+		AddDetail("used_mb", fmt.Sprint(used/MB)).
+		AddDetail("budget_mb", fmt.Sprint(b.budget/MB))
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocDirBudget_check(t *testing.T) {
+	ci.Parallel(t)
+
+	var used uint64
+	var reports []bool
+	var events []*structs.NodeEvent
+	terminal := 0
+
+	b := &allocDirBudget{
+		budget: 100 * MB,
+		high:   90 * MB,
+		low:    80 * MB,
+		usage:  func() (uint64, error) { return used, nil },
+		collect: func(reason string, done func() bool) int {
+			collected := 0
+			for !done() && terminal > 0 {
+				terminal--
+				used -= 10 * MB
+				collected++
+			}
+			return collected
+		},
+		report: func(exceeded bool) error {
+			reports = append(reports, exceeded)
+			return nil
+		},
+		emitEvent: func(ev *structs.NodeEvent) { events = append(events, ev) },
+		logger:    testlog.HCLogger(t),
+	}
+
+	// Under budget, the state is reported once so that the servers can
+	// restore eligibility removed before the client restarted
+	used = 50 * MB
+	b.check()
+	must.Eq(t, []bool{false}, reports)
+	must.SliceEmpty(t, events)
+
+	b.check()
+	must.Len(t, 1, reports)
+
+	// Over the high watermark, terminal allocations are collected first
+	used = 95 * MB
+	terminal = 2
+	b.check()
+	must.Eq(t, 85*MB, used)
+	must.Eq(t, 1, terminal)
+	must.Len(t, 1, reports)
+
+	// The node is reported over budget once there is nothing left to collect
+	used = 105 * MB
+	b.check()
+	must.Eq(t, 95*MB, used)
+	must.Eq(t, []bool{false, true}, reports)
+	must.Len(t, 1, events)
+	must.Eq(t, structs.NodeEventSubsystemStorage, events[0].Subsystem)
+	must.Eq(t, "95", events[0].Details["used_mb"])
+
+	// Still exceeded, the node is not reported again
+	b.check()
+	must.Len(t, 2, reports)
+
+	// Between the watermarks the node stays over budget
+	used = 85 * MB
+	b.check()
+	must.Len(t, 2, reports)
+
+	// Under the low watermark the node is reported back under budget
+	used = 70 * MB
+	b.check()
+	must.Eq(t, []bool{false, true, false}, reports)
+	must.Len(t, 2, events)
+}
+
+func TestAllocDirBudget_check_restart(t *testing.T) {
+	ci.Parallel(t)
+
+	var reports []bool
+	fail := true
+
+	b := &allocDirBudget{
+		budget:  100 * MB,
+		high:    90 * MB,
+		low:     80 * MB,
+		usage:   func() (uint64, error) { return 95 * MB, nil },
+		collect: func(string, func() bool) int { return 0 },
+		report: func(exceeded bool) error {
+			reports = append(reports, exceeded)
+			if fail {
+				return errors.New("no servers")
+			}
+			return nil
+		},
+		emitEvent: func(*structs.NodeEvent) {},
+		logger:    testlog.HCLogger(t),
+	}
+
+	// A failed report is retried on the next check
+	b.check()
+	must.False(t, b.reported)
+	fail = false
+	b.check()
+	must.Eq(t, []bool{true, true}, reports)
+
+	// A restarted client reports its state again, the servers ignore
+	// reports that don't change anything
+	b = &allocDirBudget{
+		budget:    b.budget,
+		high:      b.high,
+		low:       b.low,
+		usage:     func() (uint64, error) { return 95 * MB, nil },
+		collect:   b.collect,
+		report:    b.report,
+		emitEvent: b.emitEvent,
+		logger:    b.logger,
+	}
+	b.check()
+	must.Eq(t, []bool{true, true, true}, reports)
+}
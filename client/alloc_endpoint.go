@@ -10,11 +10,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client/allocdir"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -26,11 +29,15 @@ import (
 // Allocations endpoint is used for interacting with client allocations
 type Allocations struct {
 	c *Client
+
+	// execSessions are the exec sessions shared with read-only viewers
+	execSessions *execSessions
 }
 
 func NewAllocationsEndpoint(c *Client) *Allocations {
-	a := &Allocations{c: c}
+	a := &Allocations{c: c, execSessions: newExecSessions()}
 	a.c.streamingRpcs.Register("Allocations.Exec", a.exec)
+	a.c.streamingRpcs.Register("Allocations.StatsStream", a.statsStream)
 	return a
 }
 
@@ -46,6 +53,7 @@ func (a *Allocations) GarbageCollectAll(args *nstructs.NodeSpecificRequest, repl
 	}
 
 	a.c.CollectAllAllocs()
+	a.c.CollectOrphanedNetworks()
 	return nil
 }
 
@@ -181,6 +189,99 @@ func (a *Allocations) Stats(args *cstructs.AllocStatsRequest, reply *cstructs.Al
 	return nil
 }
 
+// statsStream is used to stream allocation statistics at a regular interval
+// until the remote side closes the connection.
+func (a *Allocations) statsStream(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "allocations", "stats_stream"}, time.Now())
+	defer conn.Close()
+
+	// Decode the arguments
+	var args cstructs.AllocStatsStreamRequest
+	decoder := codec.NewDecoder(conn, nstructs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, nstructs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, pointer.Of(int64(500)), encoder)
+		return
+	}
+
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		var code *int64
+		if nstructs.IsErrUnknownAllocation(err) {
+			code = pointer.Of(int64(404))
+		}
+		handleStreamResultError(err, code, encoder)
+		return
+	}
+
+	// Check read-job permission.
+	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
+		handleStreamResultError(err, pointer.Of(int64(403)), encoder)
+		return
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob) {
+		handleStreamResultError(nstructs.ErrPermissionDenied, pointer.Of(int64(403)), encoder)
+		return
+	}
+
+	aStats, err := a.c.StatsReporter().GetAllocStats(args.AllocID)
+	if err != nil {
+		handleStreamResultError(err, pointer.Of(int64(404)), encoder)
+		return
+	}
+
+	// Stats are only refreshed at the collection interval, so sending them
+	// more often would only repeat the same sample.
+	interval := max(args.Interval, a.c.GetConfig().StatsCollectionInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// goroutine to detect remote side closing
+	go func() {
+		if _, err := conn.Read(nil); err != nil {
+			// One end of the pipe explicitly closed, exit
+			cancel()
+		}
+	}()
+
+	var buf bytes.Buffer
+	frameCodec := codec.NewEncoder(&buf, nstructs.JsonHandle)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := aStats.LatestAllocStats(args.Task)
+		if err != nil {
+			handleStreamResultError(err, pointer.Of(int64(400)), encoder)
+			return
+		}
+
+		// Each sample is sent as its own line of JSON so it can be written
+		// as is to HTTP responses.
+		if err := frameCodec.Encode(stats); err != nil {
+			handleStreamResultError(err, pointer.Of(int64(500)), encoder)
+			return
+		}
+		buf.WriteByte('\n')
+
+		resp := cstructs.StreamErrWrapper{Payload: buf.Bytes()}
+		err = encoder.Encode(resp)
+		buf.Reset()
+		if err != nil {
+			return
+		}
+		encoder.Reset(conn)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // Checks is used to retrieve nomad service discovery check status information.
 func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.AllocChecksResponse) error {
 	defer metrics.MeasureSince([]string{"client", "allocations", "checks"}, time.Now())
@@ -204,6 +305,37 @@ func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.
 	return nil
 }
 
+// TaskEnv is used to retrieve the environment variables and rendered templates
+// a task is running with. Values that may hold secrets are redacted unless the
+// token is allowed to exec into the allocation, since it could read them from
+// within the task anyway.
+func (a *Allocations) TaskEnv(args *cstructs.AllocTaskEnvRequest, reply *cstructs.AllocTaskEnvResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "task_env"}, time.Now())
+
+	// Get the allocation
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check read-fs permission
+	aclObj, err := a.c.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS) {
+		return nstructs.ErrPermissionDenied
+	}
+	redact := !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocExec)
+
+	env, err := a.c.TaskEnvironment(args.AllocID, args.Task, redact)
+	if err != nil {
+		return err
+	}
+
+	reply.Environment = env
+	return nil
+}
+
 // exec is used to execute command in a running task
 func (a *Allocations) exec(conn io.ReadWriteCloser) {
 	defer metrics.MeasureSince([]string{"client", "allocations", "exec"}, time.Now())
@@ -258,6 +390,9 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 			"command", req.Cmd,
 			"tty", req.Tty,
 			"action", req.Action,
+			"record", req.Record || a.c.GetConfig().RecordRemoteExec,
+			"share", req.Share,
+			"attach", req.Attach,
 		}
 		if ident != nil {
 			if ident.ACLToken != nil {
@@ -312,7 +447,7 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		req.Cmd = append([]string{jobAction.Command}, jobAction.Args...)
 	}
 
-	if len(req.Cmd) == 0 {
+	if len(req.Cmd) == 0 && req.Attach == "" {
 		return pointer.Of(int64(400)), errors.New("command is not present")
 	}
 
@@ -354,6 +489,10 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		return pointer.Of(int64(404)), fmt.Errorf("task %q not started yet.", req.Task)
 	}
 
+	if req.Attach != "" {
+		return a.execAttach(alloc.ID, &req, encoder, decoder)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -362,7 +501,34 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		return pointer.Of(int64(404)), fmt.Errorf("task %q is not running.", req.Task)
 	}
 
-	err = h(ctx, req.Cmd, req.Tty, newExecStream(decoder, encoder))
+	stream := newExecStream(decoder, encoder)
+	if req.Record || req.Share != "" || a.c.GetConfig().RecordRemoteExec {
+		var recording io.Writer
+		if req.Record || a.c.GetConfig().RecordRemoteExec {
+			path := filepath.Join(ar.GetAllocDir().ShareDirPath(), allocdir.LogDirName,
+				fmt.Sprintf("exec-%s-%s.log", req.Task, execID))
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return pointer.Of(int64(500)), fmt.Errorf("failed to create exec session recording: %w", err)
+			}
+			defer f.Close()
+			recording = f
+			a.c.logger.Info("recording task exec session", "exec_id", execID, "path", path)
+		}
+
+		var session *sharedExecSession
+		if req.Share != "" {
+			session, err = a.execSessions.add(alloc.ID, req.Task, req.Share)
+			if err != nil {
+				return pointer.Of(int64(http.StatusConflict)), err
+			}
+			defer a.execSessions.remove(alloc.ID, req.Share)
+		}
+
+		stream = newTeeExecStream(stream, a.c.logger.With("exec_id", execID), recording, session)
+	}
+
+	err = h(ctx, req.Cmd, req.Tty, stream)
 	if err != nil {
 		code := pointer.Of(int64(500))
 		return code, err
@@ -371,6 +537,54 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	return nil, nil
 }
 
+// execAttach streams the output of a shared exec session to a read-only
+// viewer until the session ends or the viewer disconnects.
+func (a *Allocations) execAttach(allocID string, req *cstructs.AllocExecRequest,
+	encoder *codec.Encoder, decoder *codec.Decoder) (*int64, error) {
+
+	session := a.execSessions.get(allocID, req.Attach)
+	if session == nil || session.task != req.Task {
+		return pointer.Of(int64(404)), fmt.Errorf("exec session %q not found", req.Attach)
+	}
+
+	viewer, unsubscribe := session.subscribe()
+	defer unsubscribe()
+
+	// The input of viewers is discarded, it is only read to detect when they
+	// disconnect.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			var input drivers.ExecTaskStreamingRequestMsg
+			if err := decoder.Decode(&input); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case frame, ok := <-viewer.frames:
+			if !ok {
+				if viewer.dropped {
+					return pointer.Of(int64(500)), errors.New("viewer fell behind the exec session output")
+				}
+				return pointer.Of(int64(500)), fmt.Errorf("exec session %q ended", req.Attach)
+			}
+			if err := sendExecFrame(encoder, frame); err != nil {
+				return pointer.Of(int64(500)), err
+			}
+			if frame.exited {
+				return nil, nil
+			}
+		}
+	}
+}
+
 // newExecStream returns a new exec stream as expected by drivers that interpolate with RPC streaming format
 func newExecStream(decoder *codec.Decoder, encoder *codec.Encoder) drivers.ExecTaskStream {
 	buf := new(bytes.Buffer)
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/lib/proclib"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -507,6 +510,74 @@ func TestAllocations_Stats(t *testing.T) {
 	})
 }
 
+func TestAllocations_StatsStream(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.StatsCollectionInterval = 100 * time.Millisecond
+	})
+	defer cleanup()
+
+	a := mock.Alloc()
+	must.NoError(t, client.addAlloc(a, ""))
+
+	handler, err := client.StreamingRpcHandler("Allocations.StatsStream")
+	must.NoError(t, err)
+
+	// stream opens a stream of the stats of the allocation and returns the
+	// first message received.
+	stream := func(allocID string) (*cstructs.StreamErrWrapper, *codec.Decoder, net.Conn) {
+		p1, p2 := net.Pipe()
+		go handler(p2)
+
+		req := &cstructs.AllocStatsStreamRequest{
+			AllocID:  allocID,
+			Interval: 10 * time.Millisecond,
+		}
+		must.NoError(t, codec.NewEncoder(p1, nstructs.MsgpackHandle).Encode(req))
+
+		var msg cstructs.StreamErrWrapper
+		decoder := codec.NewDecoder(p1, nstructs.MsgpackHandle)
+		must.NoError(t, decoder.Decode(&msg))
+		return &msg, decoder, p1
+	}
+
+	// Try with bad alloc
+	msg, _, conn := stream(uuid.Generate())
+	conn.Close()
+	must.NotNil(t, msg.Error)
+	must.Eq(t, 404, *msg.Error.Code)
+
+	// Try with good alloc, once its stats are being collected
+	testutil.WaitForResult(func() (bool, error) {
+		msg, decoder, conn := stream(a.ID)
+		defer conn.Close()
+		if msg.Error != nil {
+			return false, msg.Error
+		}
+
+		var stats cstructs.AllocResourceUsage
+		if err := json.Unmarshal(msg.Payload, &stats); err != nil {
+			return false, err
+		}
+		if stats.ResourceUsage == nil {
+			return false, fmt.Errorf("invalid stats object")
+		}
+
+		// Samples keep coming at the collection interval
+		var next cstructs.StreamErrWrapper
+		if err := decoder.Decode(&next); err != nil {
+			return false, err
+		}
+		if len(next.Payload) == 0 {
+			return false, fmt.Errorf("expected a second sample")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
 func TestAllocations_Stats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -579,6 +650,104 @@ func TestAllocations_Stats_ACL(t *testing.T) {
 	}
 }
 
+func TestAllocations_TaskEnv_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	server, addr, root, cleanupS := testACLServer(t, nil)
+	defer cleanupS()
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+		// Render templates in process rather than in a sandbox subprocess
+		// of the nomad binary
+		c.TemplateConfig.DisableSandbox = true
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	task := job.TaskGroups[0].Tasks[0]
+	task.Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	task.Env = map[string]string{"APP_MODE": "debug"}
+	task.Templates = []*nstructs.Template{
+		{
+			EmbeddedTmpl: "port = 8080",
+			DestPath:     "local/app.conf",
+			ChangeMode:   nstructs.TemplateChangeModeNoop,
+		},
+		{
+			EmbeddedTmpl: "s3cr3t",
+			DestPath:     "secrets/password",
+			ChangeMode:   nstructs.TemplateChangeModeNoop,
+		},
+		{
+			EmbeddedTmpl: "API_KEY=abc123",
+			DestPath:     "local/app.env",
+			ChangeMode:   nstructs.TemplateChangeModeNoop,
+			Envvars:      true,
+		},
+	}
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunningWithToken(t, server.RPC, job, root.SecretID)[0]
+
+	newReq := func(token string) *cstructs.AllocTaskEnvRequest {
+		req := &cstructs.AllocTaskEnvRequest{AllocID: alloc.ID, Task: task.Name}
+		req.AuthToken = token
+		req.Namespace = nstructs.DefaultNamespace
+		return req
+	}
+
+	// Try request without a token and expect failure
+	var resp cstructs.AllocTaskEnvResponse
+	err := client.ClientRPC("Allocations.TaskEnv", newReq(""), &resp)
+	must.EqError(t, err, nstructs.ErrPermissionDenied.Error())
+
+	// Try request with a token that can only read the job
+	token := mock.CreatePolicyAndToken(t, server.State(), 1005, "read-job",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	err = client.ClientRPC("Allocations.TaskEnv", newReq(token.SecretID), &resp)
+	must.EqError(t, err, nstructs.ErrPermissionDenied.Error())
+
+	// Try request with a token that can read the alloc filesystem and expect
+	// secrets to be redacted
+	token = mock.CreatePolicyAndToken(t, server.State(), 1007, "read-fs",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadFS}))
+	resp = cstructs.AllocTaskEnvResponse{}
+	must.NoError(t, client.ClientRPC("Allocations.TaskEnv", newReq(token.SecretID), &resp))
+	must.Eq(t, "debug", resp.Environment.Env["APP_MODE"])
+	must.Eq(t, alloc.ID, resp.Environment.Env["NOMAD_ALLOC_ID"])
+	must.Eq(t, cstructs.TaskEnvRedacted, resp.Environment.Env["API_KEY"])
+	must.Eq(t, []*cstructs.TaskTemplateFile{
+		{DestPath: "local/app.conf", Contents: "port = 8080"},
+		{DestPath: "secrets/password", Redacted: true},
+		{DestPath: "local/app.env", Redacted: true},
+	}, resp.Environment.Templates)
+
+	// Try request with a token that can exec into the alloc and expect the
+	// secrets to be included
+	token = mock.CreatePolicyAndToken(t, server.State(), 1009, "alloc-exec",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "",
+			[]string{acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityAllocExec}))
+	resp = cstructs.AllocTaskEnvResponse{}
+	must.NoError(t, client.ClientRPC("Allocations.TaskEnv", newReq(token.SecretID), &resp))
+	must.Eq(t, "abc123", resp.Environment.Env["API_KEY"])
+	must.Eq(t, []*cstructs.TaskTemplateFile{
+		{DestPath: "local/app.conf", Contents: "port = 8080"},
+		{DestPath: "secrets/password", Contents: "s3cr3t"},
+		{DestPath: "local/app.env", Contents: "API_KEY=abc123"},
+	}, resp.Environment.Templates)
+
+	// Unknown tasks are rejected
+	req := newReq(root.SecretID)
+	req.Task = "nope"
+	err = client.ClientRPC("Allocations.TaskEnv", req, &resp)
+	must.ErrorContains(t, err, "task not found")
+}
+
 func TestAlloc_Checks(t *testing.T) {
 	ci.Parallel(t)
 
@@ -769,6 +938,146 @@ OUTER:
 	}
 }
 
+func TestAlloc_ExecStreaming_RecordAndShare(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+		"exec_command": map[string]interface{}{
+			"run_for":                "2s",
+			"stdout_string":          "output\n",
+			"stdout_repeat":          10,
+			"stdout_repeat_duration": "100ms",
+			"exit_code":              3,
+		},
+	}
+	testutil.WaitForRunning(t, s.RPC, job)
+
+	args := nstructs.AllocListRequest{}
+	args.Region = "global"
+	resp := nstructs.AllocListResponse{}
+	must.NoError(t, s.RPC("Alloc.List", &args, &resp))
+	must.Len(t, 1, resp.Allocations)
+	allocID := resp.Allocations[0].ID
+	task := job.TaskGroups[0].Tasks[0].Name
+
+	handler, err := c.StreamingRpcHandler("Allocations.Exec")
+	must.NoError(t, err)
+
+	// exec runs a session and returns its frames.
+	exec := func(req *cstructs.AllocExecRequest) (<-chan *drivers.ExecTaskStreamingResponseMsg, <-chan error) {
+		p1, p2 := net.Pipe()
+		t.Cleanup(func() {
+			p1.Close()
+			p2.Close()
+		})
+
+		errCh := make(chan error, 1)
+		frames := make(chan *drivers.ExecTaskStreamingResponseMsg, 64)
+		go handler(p2)
+		go decodeFrames(t, p1, frames, errCh)
+
+		encoder := codec.NewEncoder(p1, nstructs.MsgpackHandle)
+		must.NoError(t, encoder.Encode(req))
+		return frames, errCh
+	}
+
+	// Viewers can't attach to unknown sessions
+	frames, errCh := exec(&cstructs.AllocExecRequest{
+		AllocID:      allocID,
+		Task:         task,
+		Attach:       "pair",
+		QueryOptions: nstructs.QueryOptions{Region: "global"},
+	})
+	select {
+	case err := <-errCh:
+		must.ErrorContains(t, err, `exec session "pair" not found`)
+	case f := <-frames:
+		t.Fatalf("unexpected frame: %v", f)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out")
+	}
+
+	// Start a recorded and shared session
+	_, ownerErrCh := exec(&cstructs.AllocExecRequest{
+		AllocID:      allocID,
+		Task:         task,
+		Cmd:          []string{"placeholder command"},
+		Record:       true,
+		Share:        "pair",
+		QueryOptions: nstructs.QueryOptions{Region: "global"},
+	})
+
+	allocs := c.endpoints.Allocations
+	testutil.WaitForResult(func() (bool, error) {
+		return allocs.execSessions.get(allocID, "pair") != nil, nil
+	}, func(err error) {
+		t.Fatal("session was not shared")
+	})
+
+	// A viewer receives the output and the exit code of the session
+	frames, errCh = exec(&cstructs.AllocExecRequest{
+		AllocID:      allocID,
+		Task:         task,
+		Attach:       "pair",
+		QueryOptions: nstructs.QueryOptions{Region: "global"},
+	})
+
+	timeout := time.After(10 * time.Second)
+	viewed := ""
+	exitCode := -1
+	for exitCode == -1 {
+		select {
+		case <-timeout:
+			t.Fatal("timed out")
+		case err := <-ownerErrCh:
+			must.NoError(t, err)
+		case err := <-errCh:
+			must.NoError(t, err)
+		case f := <-frames:
+			switch {
+			case f.Stdout != nil:
+				viewed += string(f.Stdout.Data)
+			case f.Exited && f.Result != nil:
+				exitCode = int(f.Result.ExitCode)
+			}
+		}
+	}
+	must.Eq(t, 3, exitCode)
+	must.StrContains(t, viewed, "output\n")
+
+	// The session is unshared once it ends
+	testutil.WaitForResult(func() (bool, error) {
+		return allocs.execSessions.get(allocID, "pair") == nil, nil
+	}, func(err error) {
+		t.Fatal("session was not unshared")
+	})
+
+	// The output of the session is recorded
+	ar, err := c.getAllocRunner(allocID)
+	must.NoError(t, err)
+	recordings, err := filepath.Glob(filepath.Join(ar.GetAllocDir().ShareDirPath(),
+		allocdir.LogDirName, "exec-"+task+"-*.log"))
+	must.NoError(t, err)
+	must.Len(t, 1, recordings)
+
+	recorded, err := os.ReadFile(recordings[0])
+	must.NoError(t, err)
+	must.Eq(t, strings.Repeat("output\n", 11), string(recorded))
+}
+
 func TestAlloc_ExecStreaming_NoAllocation(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// execViewerBuffer is the number of output frames buffered for each viewer of
+// a shared exec session. Viewers that fall further behind are disconnected so
+// they never slow down the session.
+const execViewerBuffer = 256
+
+// execSessions tracks the shared exec sessions running on the client, so that
+// read-only viewers can attach to them.
+type execSessions struct {
+	lock sync.Mutex
+
+	// sessions are the shared sessions by allocation ID and session name.
+	sessions map[string]map[string]*sharedExecSession
+}
+
+func newExecSessions() *execSessions {
+	return &execSessions{
+		sessions: make(map[string]map[string]*sharedExecSession),
+	}
+}
+
+// add registers a new shared session for the task of the allocation. Session
+// names are unique within an allocation.
+func (e *execSessions) add(allocID, task, name string) (*sharedExecSession, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if _, ok := e.sessions[allocID][name]; ok {
+		return nil, fmt.Errorf("exec session %q already exists", name)
+	}
+
+	if e.sessions[allocID] == nil {
+		e.sessions[allocID] = make(map[string]*sharedExecSession)
+	}
+	s := &sharedExecSession{
+		task:    task,
+		viewers: make(map[*execViewer]struct{}),
+	}
+	e.sessions[allocID][name] = s
+	return s, nil
+}
+
+// get returns the shared session of the allocation with the given name, or
+// nil if there is no such session.
+func (e *execSessions) get(allocID, name string) *sharedExecSession {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.sessions[allocID][name]
+}
+
+// remove unregisters the shared session and disconnects its viewers.
+func (e *execSessions) remove(allocID, name string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	s, ok := e.sessions[allocID][name]
+	if !ok {
+		return
+	}
+	s.close()
+
+	delete(e.sessions[allocID], name)
+	if len(e.sessions[allocID]) == 0 {
+		delete(e.sessions, allocID)
+	}
+}
+
+// sharedExecSession is an exec session whose output is broadcast to
+// read-only viewers.
+type sharedExecSession struct {
+	// task is the task the session runs in.
+	task string
+
+	lock    sync.Mutex
+	viewers map[*execViewer]struct{}
+	closed  bool
+}
+
+// execFrame is an output frame of an exec session, encoded as sent to the
+// user.
+type execFrame struct {
+	payload []byte
+	exited  bool
+}
+
+// execViewer is a read-only viewer of a shared exec session.
+type execViewer struct {
+	// frames receives the output of the session. It is closed when the
+	// session ends or when the viewer falls behind.
+	frames chan *execFrame
+
+	// dropped is set if the viewer was disconnected for falling behind.
+	dropped bool
+}
+
+// subscribe adds a viewer to the session. The returned function must be
+// called once the viewer is done.
+func (s *sharedExecSession) subscribe() (*execViewer, func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v := &execViewer{frames: make(chan *execFrame, execViewerBuffer)}
+	if s.closed {
+		close(v.frames)
+		return v, func() {}
+	}
+
+	s.viewers[v] = struct{}{}
+	return v, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if _, ok := s.viewers[v]; ok {
+			delete(s.viewers, v)
+			close(v.frames)
+		}
+	}
+}
+
+// broadcast sends an output frame to all the viewers, disconnecting the ones
+// whose buffer is full.
+func (s *sharedExecSession) broadcast(f *execFrame) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for v := range s.viewers {
+		select {
+		case v.frames <- f:
+		default:
+			v.dropped = true
+			delete(s.viewers, v)
+			close(v.frames)
+		}
+	}
+}
+
+// close disconnects all the viewers of the session.
+func (s *sharedExecSession) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	for v := range s.viewers {
+		delete(s.viewers, v)
+		close(v.frames)
+	}
+}
+
+// teeExecStream is an exec stream that records the output sent to the user
+// and broadcasts it to the viewers of a shared session. The input received
+// from the user, such as keystrokes, is neither recorded nor broadcast.
+type teeExecStream struct {
+	drivers.ExecTaskStream
+
+	logger hclog.Logger
+
+	// recording receives the output of the session, if recorded.
+	recording io.Writer
+
+	// session is the shared session, if shared.
+	session *sharedExecSession
+
+	buf        *bytes.Buffer
+	frameCodec *codec.Encoder
+}
+
+func newTeeExecStream(stream drivers.ExecTaskStream, logger hclog.Logger,
+	recording io.Writer, session *sharedExecSession) *teeExecStream {
+
+	buf := new(bytes.Buffer)
+	return &teeExecStream{
+		ExecTaskStream: stream,
+		logger:         logger,
+		recording:      recording,
+		session:        session,
+		buf:            buf,
+		frameCodec:     codec.NewEncoder(buf, nstructs.JsonHandle),
+	}
+}
+
+// Send records and broadcasts the output before sending it to the user.
+func (s *teeExecStream) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	if s.recording != nil {
+		var output []byte
+		switch {
+		case m.Stdout != nil:
+			output = m.Stdout.Data
+		case m.Stderr != nil:
+			output = m.Stderr.Data
+		}
+		if len(output) > 0 {
+			if _, err := s.recording.Write(output); err != nil {
+				// Don't interrupt the session, the error is logged
+				// and the recording stopped.
+				s.logger.Warn("failed to record exec session output", "error", err)
+				s.recording = nil
+			}
+		}
+	}
+
+	if s.session != nil {
+		// The message may be reused once sent, so viewers receive a copy
+		// of the encoded frame.
+		s.buf.Reset()
+		s.frameCodec.Reset(s.buf)
+		s.frameCodec.MustEncode(m)
+		s.session.broadcast(&execFrame{
+			payload: bytes.Clone(s.buf.Bytes()),
+			exited:  m.Exited,
+		})
+	}
+
+	return s.ExecTaskStream.Send(m)
+}
+
+// sendExecFrame sends an encoded output frame of an exec session to a viewer.
+func sendExecFrame(encoder *codec.Encoder, f *execFrame) error {
+	return encoder.Encode(cstructs.StreamErrWrapper{
+		Payload: f.payload,
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+	"github.com/shoenig/test/must"
+)
+
+func TestExecSessions(t *testing.T) {
+	ci.Parallel(t)
+
+	sessions := newExecSessions()
+	session, err := sessions.add("alloc", "web", "pair")
+	must.NoError(t, err)
+
+	// Names are unique within an allocation
+	_, err = sessions.add("alloc", "web", "pair")
+	must.EqError(t, err, `exec session "pair" already exists`)
+	_, err = sessions.add("other", "web", "pair")
+	must.NoError(t, err)
+
+	must.Eq(t, session, sessions.get("alloc", "pair"))
+	must.Nil(t, sessions.get("alloc", "other"))
+
+	viewer, unsubscribe := session.subscribe()
+	defer unsubscribe()
+	slow, unsubscribeSlow := session.subscribe()
+	defer unsubscribeSlow()
+
+	// Viewers falling behind are disconnected
+	for i := 0; i < execViewerBuffer; i++ {
+		session.broadcast(&execFrame{payload: []byte("output")})
+		<-viewer.frames
+	}
+	session.broadcast(&execFrame{exited: true})
+	must.True(t, (<-viewer.frames).exited)
+	for range slow.frames {
+	}
+	must.True(t, slow.dropped)
+
+	// Viewers are disconnected once the session is removed
+	sessions.remove("alloc", "pair")
+	_, ok := <-viewer.frames
+	must.False(t, ok)
+	must.False(t, viewer.dropped)
+	must.Nil(t, sessions.get("alloc", "pair"))
+
+	late, _ := session.subscribe()
+	_, ok = <-late.frames
+	must.False(t, ok)
+}
+
+type testExecStream struct {
+	sent []*drivers.ExecTaskStreamingResponseMsg
+}
+
+func (s *testExecStream) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *testExecStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	return &drivers.ExecTaskStreamingRequestMsg{
+		Stdin: &proto.ExecTaskStreamingIOOperation{Data: []byte("secret")},
+	}, nil
+}
+
+func TestTeeExecStream(t *testing.T) {
+	ci.Parallel(t)
+
+	session := &sharedExecSession{viewers: make(map[*execViewer]struct{})}
+	viewer, unsubscribe := session.subscribe()
+	defer unsubscribe()
+
+	var recording bytes.Buffer
+	inner := &testExecStream{}
+	stream := newTeeExecStream(inner, hclog.NewNullLogger(), &recording, session)
+
+	// Input is neither recorded nor broadcast
+	_, err := stream.Recv()
+	must.NoError(t, err)
+
+	msgs := []*drivers.ExecTaskStreamingResponseMsg{
+		{Stdout: &proto.ExecTaskStreamingIOOperation{Data: []byte("out ")}},
+		{Stderr: &proto.ExecTaskStreamingIOOperation{Data: []byte("err")}},
+		{Exited: true, Result: &proto.ExitResult{ExitCode: 1}},
+	}
+	for _, m := range msgs {
+		must.NoError(t, stream.Send(m))
+	}
+
+	must.Eq(t, msgs, inner.sent)
+	must.Eq(t, "out err", recording.String())
+
+	for _, m := range msgs {
+		frame := <-viewer.frames
+		must.Eq(t, m.Exited, frame.exited)
+
+		var decoded drivers.ExecTaskStreamingResponseMsg
+		must.NoError(t, json.Unmarshal(frame.payload, &decoded))
+		must.Eq(t, m.GetStdout().GetData(), decoded.GetStdout().GetData())
+		must.Eq(t, m.GetStderr().GetData(), decoded.GetStderr().GetData())
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// allocStartLimiter limits the number of allocations the client starts at the
+// same time and the rate at which it starts them, so a client restarting or
+// rejoining the cluster with many allocations does not saturate its disk and
+// network pulling images and artifacts. Allocations waiting to start are
+// admitted in the order they were queued.
+type allocStartLimiter struct {
+	// max is the maximum number of allocations starting at the same time.
+	// There is no limit if it is zero.
+	max    int
+	active int
+
+	// interval is the minimum time between two admissions and next is the
+	// earliest time at which the next allocation may be admitted.
+	interval time.Duration
+	next     time.Time
+	timer    *time.Timer
+
+	waiting []chan struct{}
+
+	l sync.Mutex
+}
+
+// newAllocStartLimiter returns a limiter starting at most max allocations at
+// the same time, at most one per interval. A zero max and interval disable the
+// limiter.
+func newAllocStartLimiter(max int, interval time.Duration) *allocStartLimiter {
+	return &allocStartLimiter{
+		max:      max,
+		interval: interval,
+	}
+}
+
+// Acquire blocks until the allocation may start or the context is done. If
+// the allocation has to wait, queued is called with its position in the
+// queue. The returned function must be called to release the slot once the
+// allocation has started and may be called more than once.
+func (l *allocStartLimiter) Acquire(ctx context.Context, queued func(position int)) (func(), error) {
+	l.l.Lock()
+	if l.max <= 0 && l.interval <= 0 {
+		l.l.Unlock()
+		return func() {}, nil
+	}
+
+	now := time.Now()
+	if len(l.waiting) == 0 && l.hasSlotLocked() && !now.Before(l.next) {
+		l.active++
+		l.next = now.Add(l.interval)
+		l.l.Unlock()
+		return l.releaseFunc(), nil
+	}
+
+	w := make(chan struct{})
+	l.waiting = append(l.waiting, w)
+	position := len(l.waiting)
+	l.admitLocked(now)
+	l.l.Unlock()
+
+	if queued != nil {
+		queued(position)
+	}
+
+	select {
+	case <-w:
+		return l.releaseFunc(), nil
+	case <-ctx.Done():
+	}
+
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	// The allocation may have been admitted while the context was done, in
+	// which case its slot is handed over to the next one.
+	select {
+	case <-w:
+		l.active--
+		l.admitLocked(time.Now())
+		return nil, ctx.Err()
+	default:
+	}
+
+	l.waiting = slices.DeleteFunc(l.waiting, func(o chan struct{}) bool { return o == w })
+	return nil, ctx.Err()
+}
+
+// releaseFunc returns a function releasing a slot once.
+func (l *allocStartLimiter) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.l.Lock()
+			defer l.l.Unlock()
+			l.active--
+			l.admitLocked(time.Now())
+		})
+	}
+}
+
+// hasSlotLocked returns true if another allocation may start. The lock must be
+// held by the caller.
+func (l *allocStartLimiter) hasSlotLocked() bool {
+	return l.max <= 0 || l.active < l.max
+}
+
+// admitLocked admits waiting allocations while there are free slots, and
+// schedules the next admission if the interval has not elapsed yet. The lock
+// must be held by the caller.
+func (l *allocStartLimiter) admitLocked(now time.Time) {
+	for len(l.waiting) > 0 && l.hasSlotLocked() {
+		if now.Before(l.next) {
+			if l.timer == nil {
+				l.timer = time.AfterFunc(l.next.Sub(now), l.tick)
+			}
+			return
+		}
+
+		w := l.waiting[0]
+		l.waiting = l.waiting[1:]
+		l.active++
+		l.next = now.Add(l.interval)
+		close(w)
+	}
+}
+
+// tick admits waiting allocations once the interval has elapsed.
+func (l *allocStartLimiter) tick() {
+	l.l.Lock()
+	defer l.l.Unlock()
+	l.timer = nil
+	l.admitLocked(time.Now())
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocStartLimiter_Disabled(t *testing.T) {
+	ci.Parallel(t)
+	l := newAllocStartLimiter(0, 0)
+
+	for i := 0; i < 10; i++ {
+		_, err := l.Acquire(context.Background(), func(int) {
+			t.Fatal("allocation should not be queued")
+		})
+		must.NoError(t, err)
+	}
+}
+
+func TestAllocStartLimiter_MaxConcurrent(t *testing.T) {
+	ci.Parallel(t)
+	l := newAllocStartLimiter(1, 0)
+
+	release, err := l.Acquire(context.Background(), nil)
+	must.NoError(t, err)
+
+	positions := make(chan int, 2)
+	admitted := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			release, err := l.Acquire(context.Background(), func(position int) {
+				positions <- position
+			})
+			must.NoError(t, err)
+			admitted <- i
+			release()
+		}()
+		must.Eq(t, i+1, <-positions)
+	}
+
+	// Allocations are admitted in order once the slot is released, and
+	// releasing the slot more than once has no effect
+	select {
+	case <-admitted:
+		t.Fatal("allocation should be queued")
+	case <-time.After(10 * time.Millisecond):
+	}
+	release()
+	release()
+	must.Eq(t, 0, <-admitted)
+	must.Eq(t, 1, <-admitted)
+}
+
+func TestAllocStartLimiter_Interval(t *testing.T) {
+	ci.Parallel(t)
+	interval := 50 * time.Millisecond
+	l := newAllocStartLimiter(0, interval)
+
+	start := time.Now()
+	var queued int
+	for i := 0; i < 3; i++ {
+		_, err := l.Acquire(context.Background(), func(int) { queued++ })
+		must.NoError(t, err)
+	}
+	must.Eq(t, 2, queued)
+	must.GreaterEq(t, 2*interval, time.Since(start))
+}
+
+func TestAllocStartLimiter_Cancel(t *testing.T) {
+	ci.Parallel(t)
+	l := newAllocStartLimiter(1, 0)
+
+	release, err := l.Acquire(context.Background(), nil)
+	must.NoError(t, err)
+
+	// An allocation that stops waiting is removed from the queue
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, nil)
+	must.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = l.Acquire(context.Background(), func(int) {
+		t.Fatal("allocation should not be queued")
+	})
+	must.NoError(t, err)
+	release()
+}
@@ -109,3 +109,14 @@ func getOwner(fi os.FileInfo) (int, int) {
 	}
 	return int(stat.Uid), int(stat.Gid)
 }
+
+// fileDiskUsage returns the device of the file, its unique ID and the number
+// of bytes it uses on disk.
+func fileDiskUsage(fi os.FileInfo) (uint64, *fileID, uint64) {
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, nil, uint64(fi.Size())
+	}
+	dev := uint64(stat.Dev) //nolint:unconvert // the type varies by platform
+	return dev, &fileID{dev: dev, ino: uint64(stat.Ino)}, uint64(stat.Blocks) * 512
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// fileID identifies a file across the paths it is reachable through.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// DiskUsage returns the number of bytes used on disk by the files under root.
+// Files reachable through several paths, such as the shared alloc directory
+// bind mounted in task directories or hard linked chroot files, are only
+// counted once, and other file systems mounted under root, such as secrets
+// directories, are skipped.
+func DiskUsage(root string) (uint64, error) {
+	rootInfo, err := os.Stat(root)
+	if err != nil {
+		return 0, err
+	}
+	rootDev, _, _ := fileDiskUsage(rootInfo)
+
+	seen := make(map[fileID]struct{})
+	var used uint64
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		// Allocations may be destroyed during the walk, and some task
+		// directories may not be readable, so skip what can't be read
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		dev, id, size := fileDiskUsage(info)
+		if dev != rootDev {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if id != nil {
+			if _, ok := seen[*id]; ok {
+				return nil
+			}
+			seen[*id] = struct{}{}
+		}
+		used += size
+		return nil
+	})
+	return used, err
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocdir
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDiskUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	empty, err := DiskUsage(root)
+	must.NoError(t, err)
+
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "alloc1", "logs"), 0o755))
+	data := make([]byte, 64*1024)
+	must.NoError(t, os.WriteFile(filepath.Join(root, "alloc1", "logs", "web.stdout.0"), data, 0o644))
+
+	used, err := DiskUsage(root)
+	must.NoError(t, err)
+	must.Greater(t, empty+60*1024, used)
+
+	// Hard links are only counted once
+	if runtime.GOOS != "windows" {
+		must.NoError(t, os.Link(
+			filepath.Join(root, "alloc1", "logs", "web.stdout.0"),
+			filepath.Join(root, "alloc1", "link")))
+
+		linked, err := DiskUsage(root)
+		must.NoError(t, err)
+		must.Less(t, used+4096, linked)
+	}
+
+	_, err = DiskUsage(filepath.Join(root, "missing"))
+	must.Error(t, err)
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/client/vaultclient"
 	"github.com/hashicorp/nomad/client/widmgr"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/users/dynamic"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -50,6 +51,10 @@ type allocRunner struct {
 	// id is the ID of the allocation. Can be accessed without a lock
 	id string
 
+	// receiveTime is the time the client received the allocation, or
+	// restored it after a restart. Can be accessed without a lock
+	receiveTime time.Time
+
 	// Logger is the logger for the alloc runner.
 	logger log.Logger
 
@@ -232,6 +237,21 @@ type allocRunner struct {
 
 	// users manages a pool of dynamic workload users
 	users dynamic.Pool
+
+	// startLimiter limits the number of allocations the client starts at the
+	// same time. Allocations start without limit if it is nil.
+	startLimiter cinterfaces.AllocStartLimiter
+
+	// startCtx is cancelled to stop waiting for the start limiter when the
+	// alloc runner is stopped, destroyed or shutdown.
+	startCtx      context.Context
+	startCancelFn context.CancelFunc
+
+	// startRelease releases the start limiter slot held by the allocation
+	// until it leaves the pending state. Must acquire startReleaseLock to
+	// access.
+	startRelease     func()
+	startReleaseLock sync.Mutex
 }
 
 // NewAllocRunner returns a new allocation runner.
@@ -244,6 +264,7 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 
 	ar := &allocRunner{
 		id:                       alloc.ID,
+		receiveTime:              time.Now(),
 		alloc:                    alloc,
 		clientConfig:             config.ClientConfig,
 		clientBaseLabels:         config.BaseLabels,
@@ -278,6 +299,7 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 		hookResources:            cstructs.NewAllocHookResources(),
 		widsigner:                config.WIDSigner,
 		users:                    config.Users,
+		startLimiter:             config.StartLimiter,
 	}
 
 	// Create the logger based on the allocation ID
@@ -302,6 +324,8 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 	ar.shutdownDelayCtx = shutdownDelayCtx
 	ar.shutdownDelayCancelFn = shutdownDelayCancel
 
+	ar.startCtx, ar.startCancelFn = context.WithCancel(context.Background())
+
 	// Create a *taskenv.Builder for the allocation so the WID manager can
 	// interpolate services with the allocation and tasks as needed
 	envBuilder := taskenv.NewBuilder(
@@ -381,6 +405,9 @@ func (ar *allocRunner) Run() {
 	// Close the wait channel on return
 	defer close(ar.waitCh)
 
+	// Release the start limiter slot if tasks exit before leaving pending
+	defer ar.releaseStartSlot()
+
 	// Start the task state update handler
 	go ar.handleTaskStateUpdates()
 
@@ -397,9 +424,12 @@ func (ar *allocRunner) Run() {
 	// When handling (potentially restored) terminal alloc, ensure tasks and post-run hooks are run
 	// to perform any cleanup that's necessary, potentially not done prior to earlier termination
 
-	// Run the prestart hooks if non-terminal
+	// Run the prestart hooks if non-terminal, once the client's allocation
+	// start limits allow it
 	if ar.shouldRun() {
-		if err := ar.prerun(); err != nil {
+		if err := ar.acquireStartSlot(); err != nil {
+			ar.logger.Debug("stopped waiting to start", "error", err)
+		} else if err := ar.prerun(); err != nil {
 			ar.logger.Error("prerun failed", "error", err)
 
 			for _, tr := range ar.tasks {
@@ -423,6 +453,47 @@ func (ar *allocRunner) Run() {
 
 }
 
+// acquireStartSlot blocks until the client's allocation start limits allow
+// the allocation to start, or until the alloc runner is stopped. Allocations
+// with tasks that already started, such as restored ones, are not limited.
+func (ar *allocRunner) acquireStartSlot() error {
+	if ar.startLimiter == nil {
+		return nil
+	}
+	for _, tr := range ar.tasks {
+		if tr.TaskState().State != structs.TaskStatePending {
+			return nil
+		}
+	}
+
+	release, err := ar.startLimiter.Acquire(ar.startCtx, func(position int) {
+		ar.logger.Debug("allocation start queued", "position", position)
+		msg := fmt.Sprintf("Allocation start queued by client at position %d", position)
+		for _, tr := range ar.tasks {
+			tr.EmitEvent(structs.NewTaskEvent(structs.TaskStartQueued).SetMessage(msg))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	ar.startReleaseLock.Lock()
+	ar.startRelease = release
+	ar.startReleaseLock.Unlock()
+	return nil
+}
+
+// releaseStartSlot releases the start limiter slot held by the allocation, if
+// any.
+func (ar *allocRunner) releaseStartSlot() {
+	ar.startReleaseLock.Lock()
+	defer ar.startReleaseLock.Unlock()
+	if ar.startRelease != nil {
+		ar.startRelease()
+		ar.startRelease = nil
+	}
+}
+
 // shouldRun returns true if the alloc is in a state that the alloc runner
 // should run it.
 func (ar *allocRunner) shouldRun() bool {
@@ -697,6 +768,11 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 		// Get the client allocation
 		calloc := ar.clientAlloc(states)
 
+		// Let the next allocation start once this one is not pending anymore
+		if calloc.ClientStatus != structs.AllocClientStatusPending {
+			ar.releaseStartSlot()
+		}
+
 		// Update the server
 		ar.stateUpdater.AllocStateUpdated(calloc)
 
@@ -834,6 +910,9 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 	a := &structs.Allocation{
 		ID:         ar.id,
 		TaskStates: taskStates,
+		Timestamps: &structs.AllocTimestamps{
+			ClientReceiveTime: ar.receiveTime.UnixNano(),
+		},
 	}
 
 	if d := ar.state.DeploymentStatus; d != nil {
@@ -1068,18 +1147,69 @@ func (ar *allocRunner) handleAllocUpdate(update *structs.Allocation) {
 
 	// If alloc is being terminated, kill all tasks, leader first
 	if stopping {
+		ar.startCancelFn()
+		if !ar.preemptionGrace(update) {
+			return
+		}
 		ar.killTasks()
 	}
 
 }
 
+// preemptionGrace notifies the tasks of a preempted allocation with a
+// preemption grace period and blocks until the grace period has passed or all
+// tasks have exited. It returns false if the alloc runner is shutting down and
+// the tasks must not be killed.
+func (ar *allocRunner) preemptionGrace(alloc *structs.Allocation) bool {
+	if alloc.PreemptedByAllocation == "" || alloc.PreemptionDeadline == 0 {
+		return true
+	}
+	grace := time.Until(time.Unix(0, alloc.PreemptionDeadline))
+	if grace <= 0 {
+		return true
+	}
+
+	var signal string
+	if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil && tg.PreemptionNotice != nil {
+		signal = tg.PreemptionNotice.Signal
+	}
+
+	ar.logger.Info("allocation preempted, waiting for grace period before killing tasks",
+		"preempted_by", alloc.PreemptedByAllocation, "grace", grace)
+
+	msg := fmt.Sprintf("Allocation preempted by alloc ID %s, task will be killed in %v",
+		alloc.PreemptedByAllocation, grace.Round(time.Second))
+	for name, tr := range ar.tasks {
+		event := structs.NewTaskEvent(structs.TaskPreemptionNotice).SetMessage(msg)
+		if signal == "" {
+			tr.EmitEvent(event)
+			continue
+		}
+
+		event.SetSignalText(signal)
+		if err := tr.Signal(event, signal); err != nil && err != taskrunner.ErrTaskNotRunning {
+			ar.logger.Warn("error signaling preempted task", "error", err, "task_name", name)
+		}
+	}
+
+	timer, stop := helper.NewSafeTimer(grace)
+	defer stop()
+
+	select {
+	case <-timer.C:
+	case <-ar.waitCh:
+	}
+	return !ar.isShuttingDown()
+}
+
 func (ar *allocRunner) Listener() *cstructs.AllocListener {
 	return ar.allocBroadcaster.Listen()
 }
 
 func (ar *allocRunner) destroyImpl() {
-	// Stop any running tasks and persist states in case the client is
-	// shutdown before Destroy finishes.
+	// Stop waiting to start, any running tasks and persist states in case the
+	// client is shutdown before Destroy finishes.
+	ar.startCancelFn()
 	states := ar.killTasks()
 	calloc := ar.clientAlloc(states)
 	ar.stateUpdater.AllocStateUpdated(calloc)
@@ -1248,6 +1378,9 @@ func (ar *allocRunner) Shutdown() {
 
 	ar.shutdownLaunched = true
 
+	// Stop waiting to start
+	ar.startCancelFn()
+
 	go func() {
 		ar.logger.Trace("shutting down")
 
@@ -1493,6 +1626,17 @@ func (ar *allocRunner) GetTaskDriverCapabilities(taskName string) (*drivers.Capa
 	return tr.DriverCapabilities()
 }
 
+// GetTaskEnvironment returns the environment variables and rendered template
+// files of a task. See TaskRunner.TaskEnvironment for the meaning of redact.
+func (ar *allocRunner) GetTaskEnvironment(taskName string, redact bool) (*cstructs.TaskEnvironment, error) {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	return tr.TaskEnvironment(redact), nil
+}
+
 // AcknowledgeState is called by the client's alloc sync when a given client
 // state has been acknowledged by the server
 func (ar *allocRunner) AcknowledgeState(a *state.State) {
@@ -4,6 +4,7 @@
 package allocrunner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -1083,6 +1084,81 @@ func TestAllocRunner_TaskGroup_ShutdownDelay(t *testing.T) {
 	require.True(t, leaderFinished.Sub(groupRemoveOp.OccurredAt) > shutdownDelay)
 }
 
+// TestAllocRunner_PreemptionGrace asserts that the tasks of a preempted alloc
+// with a preemption grace period are notified and keep running until the
+// preemption deadline.
+func TestAllocRunner_PreemptionGrace(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.PreemptionNotice = &structs.PreemptionNotice{
+		Grace:  time.Second,
+		Signal: "SIGUSR1",
+	}
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if state := last.TaskStates[task.Name]; state == nil || state.State != structs.TaskStateRunning {
+			return false, fmt.Errorf("task is not running yet")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Preempt the alloc
+	preemptedAt := time.Now()
+	update := alloc.Copy()
+	update.DesiredStatus = structs.AllocDesiredStatusEvict
+	update.PreemptedByAllocation = uuid.Generate()
+	update.PreemptionDeadline = preemptedAt.Add(tg.PreemptionNotice.Grace).UnixNano()
+	ar.Update(update)
+
+	// Wait for the task to stop
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if last.TaskStates[task.Name].FinishedAt.IsZero() {
+			return false, fmt.Errorf("task is still running")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	state := upd.Last().TaskStates[task.Name]
+	must.True(t, state.FinishedAt.After(preemptedAt.Add(tg.PreemptionNotice.Grace)))
+
+	var notice *structs.TaskEvent
+	for _, event := range state.Events {
+		if event.Type == structs.TaskPreemptionNotice {
+			notice = event
+		}
+	}
+	must.NotNil(t, notice)
+	must.Eq(t, "SIGUSR1", notice.Details["signal"])
+	must.StrContains(t, notice.DisplayMessage, update.PreemptedByAllocation)
+}
+
 // TestAllocRunner_TaskLeader_StopTG asserts that when stopping an alloc with a
 // leader the leader is stopped before other tasks.
 func TestAllocRunner_TaskLeader_StopTG(t *testing.T) {
@@ -1684,6 +1760,88 @@ func TestAllocRunner_SimpleRun(t *testing.T) {
 
 }
 
+// testStartLimiter is an AllocStartLimiter queueing allocations until unblock
+// is closed.
+type testStartLimiter struct {
+	unblock  chan struct{}
+	released atomic.Bool
+}
+
+func (l *testStartLimiter) Acquire(ctx context.Context, queued func(int)) (func(), error) {
+	queued(3)
+	select {
+	case <-l.unblock:
+		return func() { l.released.Store(true) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TestAllocRunner_StartLimiter asserts that an allocation queued by the start
+// limiter emits an event, stays pending until admitted and releases its slot
+// once started.
+func TestAllocRunner_StartLimiter(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	limiter := &testStartLimiter{unblock: make(chan struct{})}
+	conf.StartLimiter = limiter
+
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	go ar.Run()
+	defer destroy(ar)
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		state := ar.AllocState()
+		if state.ClientStatus != structs.AllocClientStatusPending {
+			return fmt.Errorf("got status %v; want pending", state.ClientStatus)
+		}
+		for _, e := range state.TaskStates[task.Name].Events {
+			if e.Type == structs.TaskStartQueued {
+				must.Eq(t, "Allocation start queued by client at position 3", e.DisplayMessage)
+				return nil
+			}
+		}
+		return fmt.Errorf("no %q event", structs.TaskStartQueued)
+	}), wait.Timeout(10*time.Second), wait.Gap(10*time.Millisecond)))
+	must.False(t, limiter.released.Load())
+
+	close(limiter.unblock)
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		return ar.AllocState().ClientStatus == structs.AllocClientStatusComplete &&
+			limiter.released.Load()
+	}), wait.Timeout(10*time.Second), wait.Gap(10*time.Millisecond)))
+}
+
+// TestAllocRunner_StartLimiter_Destroy asserts that destroying an allocation
+// queued by the start limiter stops it from waiting.
+func TestAllocRunner_StartLimiter_Destroy(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	conf.StartLimiter = &testStartLimiter{unblock: make(chan struct{})}
+
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	go ar.Run()
+
+	ar.Destroy()
+	select {
+	case <-ar.DestroyCh():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for alloc to be destroyed")
+	}
+	must.Eq(t, structs.AllocClientStatusComplete, ar.AllocState().ClientStatus)
+}
+
 // TestAllocRunner_MoveAllocDir asserts that a rescheduled
 // allocation copies ephemeral disk content from previous alloc run
 func TestAllocRunner_MoveAllocDir(t *testing.T) {
@@ -48,6 +48,7 @@ type AllocRunner interface {
 	GetTaskEventHandler(taskName string) drivermanager.EventHandler
 	GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler
 	GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error)
+	GetTaskEnvironment(taskName string, redact bool) (*cstructs.TaskEnvironment, error)
 	StatsReporter() AllocStatsReporter
 	Listener() *cstructs.AllocListener
 	GetAllocDir() allocdir.Interface
@@ -169,6 +169,14 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	// Add NOMAD_* after custom args so it cannot be overridden.
 	addNomadWorkloadCNIArgs(c.logger, alloc, cniArgs)
 
+	// Request the bridge address of the allocation this one replaces, unless
+	// the job sets the IP arg itself. The host-local IPAM plugin honours it.
+	preserveAddress := false
+	if _, ok := cniArgs["IP"]; !ok && alloc.PreservedNetworkAddress != "" {
+		cniArgs["IP"] = alloc.PreservedNetworkAddress
+		preserveAddress = true
+	}
+
 	portMaps := getPortMapping(alloc, c.ignorePortMappingHostIP)
 
 	tproxyArgs, err := c.setupTransparentProxyArgs(alloc, spec, portMaps)
@@ -220,6 +228,18 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 			c.nsOpts.withCapabilityPortMap(portMaps.ports),
 			c.nsOpts.withArgs(cniArgs),
 		); err != nil {
+			// The preserved address may be gone or still held by the previous
+			// allocation, so fall back to a new address without counting an
+			// attempt.
+			if preserveAddress {
+				c.logger.Warn("failed to configure network with preserved address, requesting a new one",
+					"address", cniArgs["IP"], "error", err)
+				delete(cniArgs, "IP")
+				preserveAddress = false
+				attempt--
+				continue
+			}
+
 			c.logger.Warn("failed to configure network", "error", err, "attempt", attempt)
 			switch attempt {
 			case 1:
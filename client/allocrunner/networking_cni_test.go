@@ -211,6 +211,44 @@ func TestSetup(t *testing.T) {
 				"NOMAD_REGION":     "global",
 			},
 		},
+		{
+			name: "with preserved address",
+			modAlloc: func(a *structs.Allocation) {
+				a.PreservedNetworkAddress = "172.26.64.5"
+			},
+			expectResult: &structs.AllocNetworkStatus{
+				InterfaceName: "eth0",
+				Address:       "99.99.99.99",
+			},
+			expectArgs: map[string]string{
+				"IgnoreUnknown":    "true",
+				"IP":               "172.26.64.5",
+				"NOMAD_ALLOC_ID":   "7cd08c6c-86c8-0bfa-f7ca-338466447711",
+				"NOMAD_GROUP_NAME": "web",
+				"NOMAD_JOB_ID":     "mock-service",
+				"NOMAD_NAMESPACE":  "default",
+				"NOMAD_REGION":     "global",
+			},
+		},
+		{
+			name: "preserved address unavailable",
+			modAlloc: func(a *structs.Allocation) {
+				a.PreservedNetworkAddress = "172.26.64.5"
+			},
+			setupErrors: []string{"requested IP address 172.26.64.5 is not available"},
+			expectResult: &structs.AllocNetworkStatus{
+				InterfaceName: "eth0",
+				Address:       "99.99.99.99",
+			},
+			expectArgs: map[string]string{
+				"IgnoreUnknown":    "true",
+				"NOMAD_ALLOC_ID":   "7cd08c6c-86c8-0bfa-f7ca-338466447711",
+				"NOMAD_GROUP_NAME": "web",
+				"NOMAD_JOB_ID":     "mock-service",
+				"NOMAD_NAMESPACE":  "default",
+				"NOMAD_REGION":     "global",
+			},
+		},
 		{
 			name: "with args and tproxy",
 			modAlloc: func(a *structs.Allocation) {
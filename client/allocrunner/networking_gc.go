@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+// NetworkGCStats counts the network resources removed by
+// CollectOrphanedNetworks.
+type NetworkGCStats struct {
+	// Namespaces is the number of network namespaces removed.
+	Namespaces int
+
+	// Veths is the number of veth pairs removed from the network namespaces.
+	Veths int
+
+	// IPTablesChains is the number of iptables chains removed, along with
+	// the rules jumping to them.
+	IPTablesChains int
+}
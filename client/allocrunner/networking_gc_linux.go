@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocrunner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// cniGCChains are the chains of the nat table that hold the per allocation
+// rules created by the bridge and portmap CNI plugins.
+var cniGCChains = []string{"POSTROUTING", "CNI-HOSTPORT-DNAT"}
+
+// cniAllocRuleRe matches a rule created by a CNI plugin for an allocation,
+// capturing the allocation ID and the per allocation chain it jumps to, e.g.
+//
+//	-A POSTROUTING -s 172.26.64.191/32 -m comment --comment "name: \"nomad\" id: \"6b235529-8111-4bbe-520b-d639b1d2a94e\"" -j CNI-50e58ea77dc52e0c731e3799
+//	-A CNI-HOSTPORT-DNAT -p tcp -m comment --comment "dnat name: \"nomad\" id: \"6b235529-8111-4bbe-520b-d639b1d2a94e\"" -m multiport --dports 8080 -j CNI-DN-50e58ea77dc52e0c731e3
+var cniAllocRuleRe = regexp.MustCompile(`^-A \S+ .*--comment "(?:dnat )?name: \\"[^"\\]*\\" id: \\"([[:xdigit:]-]+)\\"".* -j (CNI-[[:alnum:]-]+)$`)
+
+// CollectOrphanedNetworks removes the network namespaces, veth pairs and
+// iptables chains created for allocations for which isLive returns false.
+// These are left behind when an allocation's network can't be torn down, for
+// example if the client or the host crashed while the allocation was running.
+//
+// The resources are listed before isLive is called, so that the network of
+// an allocation that starts concurrently is never considered orphaned.
+func CollectOrphanedNetworks(logger hclog.Logger, isLive func(allocID string) bool) (*NetworkGCStats, error) {
+	var mErr *multierror.Error
+	stats := &NetworkGCStats{}
+
+	namespaces, err := listAllocNetNS()
+	if err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	for _, allocID := range namespaces {
+		if isLive(allocID) {
+			continue
+		}
+
+		nsPath := filepath.Join(nsutil.NetNSRunDir, allocID)
+		veths, err := removeNetNSVeths(nsPath)
+		stats.Veths += veths
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to remove veth pairs of %s: %w", nsPath, err))
+		}
+
+		if err := nsutil.UnmountNS(nsPath); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to remove network namespace %s: %w", nsPath, err))
+			continue
+		}
+		logger.Info("removed orphaned network namespace", "alloc_id", allocID, "veths", veths)
+		stats.Namespaces++
+	}
+
+	for _, family := range []structs.NodeNetworkAF{structs.NodeNetworkAF_IPv4, structs.NodeNetworkAF_IPv6} {
+		ipt, err := newIPTables(family)
+		if err != nil {
+			// ip6tables is commonly missing, only iptables is required
+			if family == structs.NodeNetworkAF_IPv6 {
+				logger.Debug("failed to detect ip6tables", "error", err)
+				continue
+			}
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to detect iptables: %w", err))
+			continue
+		}
+
+		chains, err := collectOrphanedIPTables(logger, ipt, isLive)
+		stats.IPTablesChains += chains
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s: %w", family, err))
+		}
+	}
+
+	return stats, mErr.ErrorOrNil()
+}
+
+// listAllocNetNS returns the allocation IDs of the network namespaces created
+// by the client.
+func listAllocNetNS() ([]string, error) {
+	entries, err := os.ReadDir(nsutil.NetNSRunDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list network namespaces: %w", err)
+	}
+
+	var allocIDs []string
+	for _, entry := range entries {
+		// Namespaces created by the client are named after the allocation,
+		// anything else was created by another tool
+		if helper.IsUUID(entry.Name()) {
+			allocIDs = append(allocIDs, entry.Name())
+		}
+	}
+	return allocIDs, nil
+}
+
+// removeNetNSVeths removes the veth pairs of the network namespace, so they
+// are released even if a leftover process still holds the namespace.
+func removeNetNSVeths(nsPath string) (int, error) {
+	ns, err := netns.GetFromPath(nsPath)
+	if err != nil {
+		return 0, err
+	}
+	defer ns.Close()
+
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return 0, err
+	}
+	defer handle.Close()
+
+	links, err := handle.LinkList()
+	if err != nil {
+		return 0, err
+	}
+
+	var mErr *multierror.Error
+	removed := 0
+	for _, link := range links {
+		if link.Type() != "veth" {
+			continue
+		}
+		if err := handle.LinkDel(link); err != nil {
+			mErr = multierror.Append(mErr, err)
+			continue
+		}
+		removed++
+	}
+	return removed, mErr.ErrorOrNil()
+}
+
+// collectOrphanedIPTables removes the nat rules created by CNI plugins for
+// allocations for which isLive returns false, along with the chains they jump
+// to, and returns the number of chains removed.
+func collectOrphanedIPTables(logger hclog.Logger, ipt IPTables, isLive func(allocID string) bool) (int, error) {
+	const natTable = "nat"
+
+	existing, err := ipt.ListChains(natTable)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list iptables chains: %w", err)
+	}
+
+	var rules []string
+	for _, chain := range cniGCChains {
+		if !slices.Contains(existing, chain) {
+			continue
+		}
+		chainRules, err := ipt.List(natTable, chain)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list iptables rules: %w", err)
+		}
+		rules = append(rules, chainRules...)
+	}
+
+	var mErr *multierror.Error
+	removed := 0
+	for _, rule := range rules {
+		subs := cniAllocRuleRe.FindStringSubmatch(rule)
+		if len(subs) != 3 || !helper.IsUUID(subs[1]) || isLive(subs[1]) {
+			continue
+		}
+		allocID, target := subs[1], subs[2]
+
+		// The rule is listed as "-A <chain> <rulespec>"
+		spec := splitRuleSpec(rule)
+		if err := ipt.Delete(natTable, spec[1], spec[2:]...); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to remove iptables rule for alloc %s: %w", allocID, err))
+			continue
+		}
+		if err := ipt.ClearAndDeleteChain(natTable, target); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to remove iptables chain %s: %w", target, err))
+			continue
+		}
+		logger.Info("removed orphaned iptables chain", "alloc_id", allocID, "chain", target)
+		removed++
+	}
+
+	return removed, mErr.ErrorOrNil()
+}
+
+// splitRuleSpec splits a rule as listed by iptables into its arguments,
+// unquoting the quoted ones.
+func splitRuleSpec(rule string) []string {
+	var args []string
+	var arg strings.Builder
+	inArg, inQuotes := false, false
+
+	for i := 0; i < len(rule); i++ {
+		ch := rule[i]
+		switch {
+		case ch == '\\' && inQuotes && i+1 < len(rule):
+			i++
+			arg.WriteByte(rule[i])
+		case ch == '"':
+			inQuotes = !inQuotes
+			inArg = true
+		case ch == ' ' && !inQuotes:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteByte(ch)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
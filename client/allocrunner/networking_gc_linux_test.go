@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+// mockIPTablesGC records the rules and chains removed by the garbage
+// collection of iptables.
+type mockIPTablesGC struct {
+	mockIPTablesChain
+
+	chains []string
+	rules  map[string][]string
+
+	deleted       [][]string
+	deletedChains []string
+}
+
+func (ipt *mockIPTablesGC) ListChains(table string) ([]string, error) {
+	return ipt.chains, nil
+}
+
+func (ipt *mockIPTablesGC) List(table, chain string) ([]string, error) {
+	return ipt.rules[chain], nil
+}
+
+func (ipt *mockIPTablesGC) Delete(table, chain string, rule ...string) error {
+	ipt.deleted = append(ipt.deleted, append([]string{table, chain}, rule...))
+	return nil
+}
+
+func (ipt *mockIPTablesGC) ClearAndDeleteChain(table, chain string) error {
+	ipt.deletedChains = append(ipt.deletedChains, chain)
+	return nil
+}
+
+func TestCNI_collectOrphanedIPTables(t *testing.T) {
+	ci.Parallel(t)
+
+	const (
+		liveAllocID     = "79e8bf2e-a9c8-70ac-8d4e-fa5c4da99fbf"
+		orphanedAllocID = "2dd71cac-2b1e-ff08-167c-735f7f9f4964"
+	)
+
+	ipt := &mockIPTablesGC{
+		chains: []string{"PREROUTING", "POSTROUTING", "CNI-HOSTPORT-DNAT", "CNI-HOSTPORT-MASQ"},
+		rules: map[string][]string{
+			"POSTROUTING": {
+				`-A POSTROUTING -m comment --comment "CNI portfwd requiring masquerade" -j CNI-HOSTPORT-MASQ`,
+				`-A POSTROUTING -s 172.26.64.216/32 -m comment --comment "name: \"nomad\" id: \"79e8bf2e-a9c8-70ac-8d4e-fa5c4da99fbf\"" -j CNI-f2338c31d4de44472fe99c43`,
+				`-A POSTROUTING -s 172.26.64.217/32 -m comment --comment "name: \"nomad\" id: \"2dd71cac-2b1e-ff08-167c-735f7f9f4964\"" -j CNI-5d36f286cfbb35c5776509ec`,
+				`-A POSTROUTING -s 172.17.0.0/16 ! -o docker0 -j MASQUERADE`,
+			},
+			"CNI-HOSTPORT-DNAT": {
+				`-A CNI-HOSTPORT-DNAT -p tcp -m comment --comment "dnat name: \"nomad\" id: \"2dd71cac-2b1e-ff08-167c-735f7f9f4964\"" -m multiport --dports 8080 -j CNI-DN-5d36f286cfbb35c577650`,
+				`-A CNI-HOSTPORT-DNAT -p tcp -m comment --comment "dnat name: \"nomad\" id: \"79e8bf2e-a9c8-70ac-8d4e-fa5c4da99fbf\"" -m multiport --dports 9090 -j CNI-DN-f2338c31d4de44472fe99`,
+			},
+		},
+	}
+
+	isLive := func(allocID string) bool { return allocID == liveAllocID }
+	removed, err := collectOrphanedIPTables(testlog.HCLogger(t), ipt, isLive)
+	must.NoError(t, err)
+	must.Eq(t, 2, removed)
+
+	must.Eq(t, []string{"CNI-5d36f286cfbb35c5776509ec", "CNI-DN-5d36f286cfbb35c577650"}, ipt.deletedChains)
+	must.Eq(t, [][]string{
+		{"nat", "POSTROUTING", "-s", "172.26.64.217/32", "-m", "comment", "--comment",
+			`name: "nomad" id: "` + orphanedAllocID + `"`, "-j", "CNI-5d36f286cfbb35c5776509ec"},
+		{"nat", "CNI-HOSTPORT-DNAT", "-p", "tcp", "-m", "comment", "--comment",
+			`dnat name: "nomad" id: "` + orphanedAllocID + `"`, "-m", "multiport", "--dports", "8080",
+			"-j", "CNI-DN-5d36f286cfbb35c577650"},
+	}, ipt.deleted)
+}
+
+func TestCNI_splitRuleSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, []string{"-A", "POSTROUTING", "-m", "comment", "--comment", `name: "nomad"`, "-j", "ACCEPT"},
+		splitRuleSpec(`-A POSTROUTING -m comment --comment "name: \"nomad\"" -j ACCEPT`))
+	must.Eq(t, []string{"-A", "FORWARD", "--comment", ""},
+		splitRuleSpec(`-A FORWARD --comment ""`))
+}
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+// +build !linux
+
+package allocrunner
+
+import (
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+// CollectOrphanedNetworks is a no-op on systems that don't support network
+// isolation.
+func CollectOrphanedNetworks(_ hclog.Logger, _ func(allocID string) bool) (*NetworkGCStats, error) {
+	return &NetworkGCStats{}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/consul-template/signals"
@@ -38,6 +39,7 @@ type tokenSetter interface {
 
 type identityHook struct {
 	alloc      *structs.Allocation
+	allocLock  sync.Mutex
 	task       *structs.Task
 	taskDir    *allocdir.TaskDir
 	envBuilder *taskenv.Builder
@@ -46,6 +48,10 @@ type identityHook struct {
 	widmgr     widmgr.IdentityManager
 	logger     log.Logger
 
+	// started is set once the default token has been set by Prestart, after
+	// which re-signed default tokens trigger the identity's change_mode.
+	started bool
+
 	stopCtx context.Context
 	stop    context.CancelFunc
 }
@@ -74,7 +80,11 @@ func (*identityHook) Name() string {
 func (h *identityHook) Prestart(ctx context.Context, _ *interfaces.TaskPrestartRequest, _ *interfaces.TaskPrestartResponse) error {
 
 	// Handle default workload identity
-	if err := h.setDefaultToken(); err != nil {
+	h.allocLock.Lock()
+	err := h.setDefaultToken(h.alloc.SignedIdentities[h.task.Name])
+	h.started = true
+	h.allocLock.Unlock()
+	if err != nil {
 		return err
 	}
 
@@ -155,31 +165,8 @@ func (h *identityHook) watchIdentity(wid *structs.WorkloadIdentity, runCh chan s
 				continue
 			}
 
-			switch wid.ChangeMode {
-			case structs.WIChangeModeRestart:
-				const noFailure = false
-				err := h.lifecycle.Restart(h.stopCtx, structs.NewTaskEvent(structs.TaskRestartSignal).
-					SetDisplayMessage(fmt.Sprintf("Identity[%s]: new token acquired", wid.Name)), noFailure)
-				if err != nil {
-					// Ignore error from kill because if that fails there's really
-					// nothing to be done.
-					_ = h.lifecycle.Kill(h.stopCtx, structs.NewTaskEvent(structs.TaskKilling).
-						SetFailsTask().
-						SetDisplayMessage(fmt.Sprintf("Identity[%s]: failed to restart: %v", wid.Name, err)))
-					return
-				}
-
-			case structs.WIChangeModeSignal:
-				if err := h.signalTask(wid); err != nil {
-					h.logger.Error("failed to send signal", "identity", wid.Name, "signal", wid.ChangeSignal)
-					// Ignore error from kill because if that fails there's really
-					// nothing to be done.
-					_ = h.lifecycle.Kill(h.stopCtx, structs.NewTaskEvent(structs.TaskKilling).
-						SetFailsTask().
-						SetDisplayMessage(fmt.Sprintf("Identity[%s]: failed to send signal: %v", wid.Name, err)))
-					return
-				}
-
+			if err := h.applyChangeMode(wid); err != nil {
+				return
 			}
 
 			// Note: any code added here will not run on first run
@@ -190,6 +177,39 @@ func (h *identityHook) watchIdentity(wid *structs.WorkloadIdentity, runCh chan s
 	}
 }
 
+// applyChangeMode restarts or signals the task as configured by the identity
+// when its token changes. If this fails the task is killed and an error is
+// returned.
+func (h *identityHook) applyChangeMode(wid *structs.WorkloadIdentity) error {
+	switch wid.ChangeMode {
+	case structs.WIChangeModeRestart:
+		const noFailure = false
+		err := h.lifecycle.Restart(h.stopCtx, structs.NewTaskEvent(structs.TaskRestartSignal).
+			SetDisplayMessage(fmt.Sprintf("Identity[%s]: new token acquired", wid.Name)), noFailure)
+		if err != nil {
+			// Ignore error from kill because if that fails there's really
+			// nothing to be done.
+			_ = h.lifecycle.Kill(h.stopCtx, structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Identity[%s]: failed to restart: %v", wid.Name, err)))
+			return err
+		}
+
+	case structs.WIChangeModeSignal:
+		if err := h.signalTask(wid); err != nil {
+			h.logger.Error("failed to send signal", "identity", wid.Name, "signal", wid.ChangeSignal)
+			// Ignore error from kill because if that fails there's really
+			// nothing to be done.
+			_ = h.lifecycle.Kill(h.stopCtx, structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Identity[%s]: failed to send signal: %v", wid.Name, err)))
+			return err
+		}
+	}
+
+	return nil
+}
+
 // signalTask sends the configured signal to a task or returns an error.
 func (h *identityHook) signalTask(wid *structs.WorkloadIdentity) error {
 	s, err := signals.Parse(wid.ChangeSignal)
@@ -205,8 +225,7 @@ func (h *identityHook) signalTask(wid *structs.WorkloadIdentity) error {
 
 // setDefaultToken adds the Nomad token to the task's environment and writes it to a
 // file if requested by the jobsepc.
-func (h *identityHook) setDefaultToken() error {
-	token := h.alloc.SignedIdentities[h.task.Name]
+func (h *identityHook) setDefaultToken(token string) error {
 	if token == "" {
 		return nil
 	}
@@ -249,6 +268,32 @@ func (h *identityHook) setAltToken(widspec *structs.WorkloadIdentity, rawJWT str
 	return nil
 }
 
+// Update implements interfaces.TaskUpdateHook. The servers re-sign the default
+// identity before it expires when it has a TTL, so the new token is set and
+// the identity's change_mode is applied.
+func (h *identityHook) Update(_ context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	h.allocLock.Lock()
+	token := req.Alloc.SignedIdentities[h.task.Name]
+	changed := token != "" && token != h.alloc.SignedIdentities[h.task.Name]
+	started := h.started
+	h.alloc = req.Alloc
+	h.allocLock.Unlock()
+
+	// Prestart sets the latest token if the task hasn't started yet
+	if !changed || !started {
+		return nil
+	}
+
+	h.logger.Trace("receiving renewed identity", "identity", structs.WorkloadIdentityDefaultName)
+	if err := h.setDefaultToken(token); err != nil {
+		return err
+	}
+	if wid := h.task.Identity; wid != nil {
+		return h.applyChangeMode(wid)
+	}
+	return nil
+}
+
 // Stop implements interfaces.TaskStopHook
 func (h *identityHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
 	h.stop()
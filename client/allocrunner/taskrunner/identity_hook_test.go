@@ -26,6 +26,7 @@ import (
 var _ interfaces.TaskPrestartHook = (*identityHook)(nil)
 var _ interfaces.TaskStopHook = (*identityHook)(nil)
 var _ interfaces.ShutdownHook = (*identityHook)(nil)
+var _ interfaces.TaskUpdateHook = (*identityHook)(nil)
 
 // See task_runner_test.go:TestTaskRunner_IdentityHook
 
@@ -301,3 +302,68 @@ func TestIdentityHook_ErrorWriting(t *testing.T) {
 	err := h.Prestart(context.Background(), nil, nil)
 	must.ErrorContains(t, err, "failed to write nomad token")
 }
+
+// TestIdentityHook_UpdateDefault asserts the default identity re-signed by the
+// servers is set when the alloc is updated.
+func TestIdentityHook_UpdateDefault(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.SignedIdentities = map[string]string{"web": "first.token.jwt"}
+	task := alloc.LookupTask("web")
+	task.Identity.File = true
+	task.Identity.TTL = time.Hour
+	task.Identity.ChangeMode = structs.WIChangeModeSignal
+	task.Identity.ChangeSignal = "SIGHUP"
+	node := mock.Node()
+
+	stopCtx, stop := context.WithCancel(context.Background())
+	t.Cleanup(stop)
+
+	mockTaskDir := &allocdir.TaskDir{
+		SecretsDir: t.TempDir(),
+	}
+	mockTR := &MockTokenSetter{}
+	mockLifecycle := trtesting.NewMockTaskHooks()
+
+	h := &identityHook{
+		alloc:      alloc,
+		task:       task,
+		taskDir:    mockTaskDir,
+		envBuilder: taskenv.NewBuilder(node, alloc, task, alloc.Job.Region),
+		ts:         mockTR,
+		lifecycle:  mockLifecycle,
+		logger:     testlog.HCLogger(t),
+		stopCtx:    stopCtx,
+		stop:       stop,
+	}
+
+	updateToken := func(token string) {
+		updated := alloc.Copy()
+		updated.SignedIdentities = map[string]string{"web": token}
+		must.NoError(t, h.Update(context.Background(),
+			&interfaces.TaskUpdateRequest{Alloc: updated}, nil))
+	}
+
+	// Updates before the task starts are set by Prestart
+	updateToken("second.token.jwt")
+	must.Eq(t, "", mockTR.defaultToken)
+	must.FileNotExists(t, filepath.Join(mockTaskDir.SecretsDir, wiTokenFile))
+
+	must.NoError(t, h.Prestart(context.Background(), nil, nil))
+	must.Eq(t, "second.token.jwt", mockTR.defaultToken)
+	must.Eq(t, "second.token.jwt",
+		string(testutil.MustReadFile(t, mockTaskDir.SecretsDir, wiTokenFile)))
+	must.SliceEmpty(t, mockLifecycle.Signals())
+
+	// Updates without a new token are ignored
+	updateToken("second.token.jwt")
+	must.SliceEmpty(t, mockLifecycle.Signals())
+
+	// Re-signed tokens are set and the task is signaled
+	updateToken("third.token.jwt")
+	must.Eq(t, "third.token.jwt", mockTR.defaultToken)
+	must.Eq(t, "third.token.jwt",
+		string(testutil.MustReadFile(t, mockTaskDir.SecretsDir, wiTokenFile)))
+	must.Eq(t, []string{"SIGHUP"}, mockLifecycle.Signals())
+}
@@ -192,15 +192,30 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
-	err := h.logmon.Start(&logmon.LogConfig{
-		LogDir:        h.config.logDir,
-		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
-		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
-		StdoutFifo:    h.config.stdoutFifo,
-		StderrFifo:    h.config.stderrFifo,
-		MaxFiles:      req.Task.LogConfig.MaxFiles,
-		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
-	})
+	cfg := &logmon.LogConfig{
+		LogDir:         h.config.logDir,
+		StdoutLogFile:  fmt.Sprintf("%s.stdout", req.Task.Name),
+		StderrLogFile:  fmt.Sprintf("%s.stderr", req.Task.Name),
+		StdoutFifo:     h.config.stdoutFifo,
+		StderrFifo:     h.config.stderrFifo,
+		MaxFiles:       req.Task.LogConfig.MaxFiles,
+		MaxFileSizeMB:  req.Task.LogConfig.MaxFileSizeMB,
+		Compress:       req.Task.LogConfig.Compress,
+		MaxTotalSizeMB: req.Task.LogConfig.MaxTotalSizeMB,
+		Journald:       req.Task.LogConfig.Journald,
+	}
+	if cfg.Journald {
+		alloc := h.runner.Alloc()
+		cfg.JournaldFields = map[string]string{
+			"SYSLOG_IDENTIFIER":          req.Task.Name,
+			logmon.JournalFieldAllocID:   alloc.ID,
+			logmon.JournalFieldJobID:     alloc.JobID,
+			logmon.JournalFieldNamespace: alloc.Namespace,
+			logmon.JournalFieldTaskName:  req.Task.Name,
+		}
+	}
+
+	err := h.logmon.Start(cfg)
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
 		return err
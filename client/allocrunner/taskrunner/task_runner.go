@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"slices"
 	"strings"
 	"sync"
@@ -37,6 +38,7 @@ import (
 	"github.com/hashicorp/nomad/client/vaultclient"
 	"github.com/hashicorp/nomad/client/widmgr"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclspecutils"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/users/dynamic"
@@ -1692,6 +1694,55 @@ func (tr *TaskRunner) DriverCapabilities() (*drivers.Capabilities, error) {
 	return tr.driver.Capabilities()
 }
 
+// TaskEnvironment returns the current environment of the task and the files
+// rendered by its templates. If redact is true, the values of environment
+// variables that may hold secrets are replaced and the contents of templates
+// rendered into the secrets directory or as environment variables are
+// omitted.
+func (tr *TaskRunner) TaskEnvironment(redact bool) *cstructs.TaskEnvironment {
+	taskEnv := tr.envBuilder.Build()
+
+	env := taskEnv.Map()
+	if redact {
+		for k := range tr.envBuilder.SensitiveKeys() {
+			if _, ok := env[k]; ok {
+				env[k] = cstructs.TaskEnvRedacted
+			}
+		}
+	}
+
+	tmpls := tr.Task().Templates
+	files := make([]*cstructs.TaskTemplateFile, 0, len(tmpls))
+	for _, tmpl := range tmpls {
+		file := &cstructs.TaskTemplateFile{DestPath: tmpl.DestPath}
+		files = append(files, file)
+
+		dest, escapes := taskEnv.ClientPath(tmpl.DestPath, true)
+		if escapes {
+			file.Error = "template destination escapes the task directory"
+			continue
+		}
+
+		secret := tmpl.Envvars || !escapingfs.PathEscapesSandbox(tr.taskDir.SecretsDir, dest)
+		if redact && secret {
+			file.Redacted = true
+			continue
+		}
+
+		contents, err := os.ReadFile(dest)
+		if err != nil {
+			file.Error = err.Error()
+			continue
+		}
+		file.Contents = string(contents)
+	}
+
+	return &cstructs.TaskEnvironment{
+		Env:       env,
+		Templates: files,
+	}
+}
+
 // shutdownDelayCancel is used for testing only and cancels the
 // shutdownDelayCtx
 func (tr *TaskRunner) shutdownDelayCancel() {
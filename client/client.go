@@ -57,6 +57,7 @@ import (
 	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/helper/goruntime"
 	"github.com/hashicorp/nomad/helper/group"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/pool"
 	"github.com/hashicorp/nomad/helper/tlsutil"
@@ -65,6 +66,7 @@ import (
 	"github.com/hashicorp/nomad/lib/lang"
 	"github.com/hashicorp/nomad/nomad/structs"
 	nconfig "github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/csi"
 	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/shirou/gopsutil/v3/host"
@@ -267,6 +269,9 @@ type Client struct {
 	// in the node automatically
 	garbageCollector *AllocGarbageCollector
 
+	// networkGCLock serializes the collections of orphaned networks
+	networkGCLock sync.Mutex
+
 	// clientACLResolver holds the ACL resolution state
 	clientACLResolver
 
@@ -319,8 +324,10 @@ type Client struct {
 	// EnterpriseClient is used to set and check enterprise features for clients
 	EnterpriseClient *EnterpriseClient
 
-	// getter is an interface for retrieving artifacts.
-	getter cinterfaces.ArtifactGetter
+	// getter is an interface for retrieving artifacts. It is replaced when
+	// the artifact configuration is reloaded, so access it via getGetter.
+	getter     cinterfaces.ArtifactGetter
+	getterLock sync.Mutex
 
 	// wranglers is used to keep track of processes and manage their interaction
 	// with drivers and stuff
@@ -338,6 +345,14 @@ type Client struct {
 
 	// users is a pool of dynamic workload users
 	users dynamic.Pool
+
+	// allocStartLimiter limits the rate at which allocations are started
+	allocStartLimiter *allocStartLimiter
+
+	// taskLeaderships are the leader elections allocations take part in
+	// through the Task API, keyed by alloc ID and election name
+	taskLeaderships     map[string]*taskLeadership
+	taskLeadershipsLock sync.Mutex
 }
 
 var (
@@ -478,6 +493,10 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		MaxUGID: cfg.Users.MaxDynamicUser,
 	})
 
+	// Create the limiter of allocation starts
+	c.allocStartLimiter = newAllocStartLimiter(cfg.MaxConcurrentAllocStarts, cfg.AllocStartInterval)
+	c.taskLeaderships = make(map[string]*taskLeadership)
+
 	// Create the cpu core partition manager
 	c.partitions = cgroupslib.GetPartition(c.logger.Named("partitions"),
 		c.topology.UsableCores(),
@@ -648,6 +667,12 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Start collecting stats
 	c.shutdownGroup.Go(c.emitStats)
 
+	// Start removing the networks of allocations lost by the client
+	c.shutdownGroup.Go(c.networkGC)
+
+	// Start enforcing the disk budget of the allocation directories
+	c.shutdownGroup.Go(c.watchAllocDirBudget)
+
 	c.logger.Info("started client", "node_id", c.NodeID())
 	return c, nil
 }
@@ -832,11 +857,123 @@ func (c *Client) Reload(newConfig *config.Config) error {
 		}
 	}
 
+	if err := c.reloadHostVolumes(newConfig.HostVolumes); err != nil {
+		return err
+	}
+
+	if err := c.reloadDriverPlugins(newConfig.PluginConfigs); err != nil {
+		return err
+	}
+
+	c.reloadTaskEnvironment(newConfig)
+
 	c.fingerprintManager.Reload()
 
 	return nil
 }
 
+// reloadDriverPlugins applies the new configurations of the external driver
+// plugins and relaunches the plugins whose configuration changed. Their tasks
+// keep running and are recovered by the new plugin instances.
+func (c *Client) reloadDriverPlugins(configs []*nconfig.PluginConfig) error {
+	reloader, ok := c.GetConfig().PluginLoader.(loader.PluginConfigReloader)
+	if !ok {
+		return nil
+	}
+
+	ids, err := reloader.ReloadConfigs(configs)
+	if err != nil {
+		return fmt.Errorf("failed to reload plugin configuration: %w", err)
+	}
+
+	drivers := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id.PluginType == base.PluginTypeDriver {
+			drivers = append(drivers, id.Name)
+		}
+	}
+	c.drivermanager.Reload(drivers)
+	return nil
+}
+
+// reloadHostVolumes replaces the static host volumes of the node with the
+// given ones. Dynamic host volumes are left untouched. The node is only
+// updated if the set of static host volumes changed.
+func (c *Client) reloadHostVolumes(volumes map[string]*structs.ClientHostVolumeConfig) error {
+	for _, v := range volumes {
+		if _, err := os.Stat(v.Path); err != nil {
+			return fmt.Errorf("failed to validate volume %s, err: %w", v.Name, err)
+		}
+	}
+
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	newConfig := c.config.Copy()
+	if newConfig.Node.HostVolumes == nil {
+		newConfig.Node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig, len(volumes))
+	}
+
+	changed := false
+	for name, vol := range newConfig.Node.HostVolumes {
+		if vol.ID != "" {
+			// dynamic host volumes are managed by the host volume manager
+			continue
+		}
+		if _, ok := volumes[name]; !ok {
+			delete(newConfig.Node.HostVolumes, name)
+			changed = true
+		}
+	}
+	for name, vol := range volumes {
+		if existing, ok := newConfig.Node.HostVolumes[name]; ok && existing.ID != "" {
+			c.logger.Warn("static host volume shadowed by dynamic host volume", "volume", name)
+			continue
+		}
+		if !vol.Equal(newConfig.Node.HostVolumes[name]) {
+			newConfig.Node.HostVolumes[name] = vol.Copy()
+			changed = true
+		}
+	}
+
+	newConfig.HostVolumes = structs.CopyMapStringClientHostVolumeConfig(volumes)
+	c.config = newConfig
+
+	if changed {
+		c.logger.Info("reloaded static host volumes", "volumes", len(volumes))
+		c.updateNode()
+	}
+	return nil
+}
+
+// reloadTaskEnvironment applies the chroot environment and artifact
+// configuration used to build the environment of new tasks. Running tasks
+// keep the configuration they were started with.
+func (c *Client) reloadTaskEnvironment(newConfig *config.Config) {
+	c.UpdateConfig(func(c *config.Config) {
+		c.ChrootEnv = maps.Clone(newConfig.ChrootEnv)
+	})
+
+	if newConfig.Artifact == nil {
+		return
+	}
+
+	c.UpdateConfig(func(c *config.Config) {
+		c.Artifact = newConfig.Artifact.Copy()
+	})
+
+	c.getterLock.Lock()
+	defer c.getterLock.Unlock()
+	c.getter = getter.New(newConfig.Artifact.Copy(), c.logger)
+}
+
+// getGetter returns the artifact getter used by new allocations.
+func (c *Client) getGetter() cinterfaces.ArtifactGetter {
+	c.getterLock.Lock()
+	defer c.getterLock.Unlock()
+	return c.getter
+}
+
 // Leave is used to prepare the client to leave the cluster
 func (c *Client) Leave() error {
 	if c.GetConfig().DevMode {
@@ -1004,6 +1141,28 @@ func (c *Client) GetAlloc(allocID string) (*structs.Allocation, error) {
 	return ar.Alloc(), nil
 }
 
+// LocalAllocs returns a copy of the allocations of this client that have not
+// been destroyed, updated with their latest client-side state. It doesn't
+// require a connection to the servers.
+func (c *Client) LocalAllocs() []*structs.Allocation {
+	runners := c.getAllocRunners()
+	allocs := make([]*structs.Allocation, 0, len(runners))
+	for _, ar := range runners {
+		if ar.IsDestroyed() {
+			continue
+		}
+
+		alloc := ar.Alloc().CopySkipJob()
+		state := ar.AllocState()
+		alloc.ClientStatus = state.ClientStatus
+		alloc.ClientDescription = state.ClientDescription
+		alloc.DeploymentStatus = state.DeploymentStatus
+		alloc.TaskStates = state.TaskStates
+		allocs = append(allocs, alloc)
+	}
+	return allocs
+}
+
 // SignalAllocation sends a signal to the tasks within an allocation.
 // If the provided task is empty, then every allocation will be signalled.
 // If a task is provided, then only an exactly matching task will be signalled.
@@ -1034,6 +1193,16 @@ func (c *Client) GetPauseAllocation(allocID, task string) (structs.TaskScheduleS
 	return ar.GetTaskPauseState(task)
 }
 
+// TaskEnvironment returns the environment variables and rendered template
+// files of a task of the allocation.
+func (c *Client) TaskEnvironment(allocID, task string, redact bool) (*cstructs.TaskEnvironment, error) {
+	ar, err := c.getAllocRunner(allocID)
+	if err != nil {
+		return nil, err
+	}
+	return ar.GetTaskEnvironment(task, redact)
+}
+
 // CollectAllocation garbage collects a single allocation on a node. Returns
 // true if alloc was found and garbage collected; otherwise false.
 func (c *Client) CollectAllocation(allocID string) bool {
@@ -1849,6 +2018,12 @@ func (c *Client) lastHeartbeat() time.Time {
 	return c.heartbeatStop.getLastOk()
 }
 
+// LastHeartbeat returns the time of the last successful registration or
+// heartbeat with the servers, or the zero time if there was none.
+func (c *Client) LastHeartbeat() time.Time {
+	return c.lastHeartbeat()
+}
+
 // getHeartbeatRetryIntv is used to retrieve the time to wait before attempting
 // another heartbeat.
 func (c *Client) getHeartbeatRetryIntv(err error) time.Duration {
@@ -2246,6 +2421,7 @@ func (c *Client) AllocStateUpdated(alloc *structs.Allocation) {
 	stripped.ClientDescription = alloc.ClientDescription
 	stripped.DeploymentStatus = alloc.DeploymentStatus
 	stripped.NetworkStatus = alloc.NetworkStatus
+	stripped.Timestamps = alloc.Timestamps
 
 	c.pendingUpdates.add(stripped)
 }
@@ -2838,7 +3014,7 @@ func (c *Client) newAllocRunnerConfig(
 		DeviceStatsReporter: c,
 		DriverManager:       c.drivermanager,
 		DynamicRegistry:     c.dynamicRegistry,
-		Getter:              c.getter,
+		Getter:              c.getGetter(),
 		Logger:              c.logger,
 		PrevAllocMigrator:   prevAllocMigrator,
 		PrevAllocWatcher:    prevAllocWatcher,
@@ -2851,6 +3027,7 @@ func (c *Client) newAllocRunnerConfig(
 		Wranglers:           c.wranglers,
 		Partitions:          c.partitions,
 		Users:               c.users,
+		StartLimiter:        c.allocStartLimiter,
 	}
 }
 
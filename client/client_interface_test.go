@@ -148,6 +148,9 @@ func (ar *emptyAllocRunner) GetTaskExecHandler(taskName string) drivermanager.Ta
 func (ar *emptyAllocRunner) GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error) {
 	return nil, nil
 }
+func (ar *emptyAllocRunner) GetTaskEnvironment(taskName string, redact bool) (*cstructs.TaskEnvironment, error) {
+	return nil, nil
+}
 
 func (ar *emptyAllocRunner) StatsReporter() interfaces.AllocStatsReporter { return ar }
 func (ar *emptyAllocRunner) Listener() *cstructs.AllocListener            { return nil }
@@ -1234,6 +1234,50 @@ func TestClient_ValidateMigrateToken_ACLDisabled(t *testing.T) {
 	assert.Equal(c.ValidateMigrateToken("", ""), true)
 }
 
+func TestClient_Reload_HostVolumesAndTaskEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	volDir := t.TempDir()
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+			"old": {Name: "old", Path: volDir},
+		}
+	})
+	defer cleanup()
+
+	// simulate a dynamic host volume that must survive the reload
+	c1.updateNodeFromHostVol("dynamic", &structs.ClientHostVolumeConfig{
+		Name: "dynamic", Path: volDir, ID: "dynamic-id",
+	})
+
+	newConfig := c1.GetConfig().Copy()
+	newConfig.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"new": {Name: "new", Path: volDir, ReadOnly: true},
+	}
+	newConfig.ChrootEnv = map[string]string{"/bin": "/bin"}
+	newConfig.Artifact = &config.ArtifactConfig{HTTPReadTimeout: 5 * time.Minute}
+
+	must.NoError(t, c1.Reload(newConfig))
+
+	node := c1.Node()
+	must.MapNotContainsKey(t, node.HostVolumes, "old")
+	must.MapContainsKey(t, node.HostVolumes, "new")
+	must.True(t, node.HostVolumes["new"].ReadOnly)
+	must.MapContainsKey(t, node.HostVolumes, "dynamic")
+
+	conf := c1.GetConfig()
+	must.Eq(t, map[string]string{"/bin": "/bin"}, conf.ChrootEnv)
+	must.Eq(t, 5*time.Minute, conf.Artifact.HTTPReadTimeout)
+
+	// a missing host volume path fails the reload
+	newConfig = c1.GetConfig().Copy()
+	newConfig.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"missing": {Name: "missing", Path: filepath.Join(volDir, "missing")},
+	}
+	must.Error(t, c1.Reload(newConfig))
+	must.MapContainsKey(t, c1.Node().HostVolumes, "new")
+}
+
 func TestClient_ReloadTLS_UpgradePlaintextToTLS(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
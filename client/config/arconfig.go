@@ -129,6 +129,10 @@ type AllocRunnerConfig struct {
 
 	// Users manages a pool of dynamic workload users
 	Users dynamic.Pool
+
+	// StartLimiter limits the number of allocations starting at the same
+	// time. Allocations start without limit if it is nil.
+	StartLimiter interfaces.AllocStartLimiter
 }
 
 // PrevAllocWatcher allows AllocRunners to wait for a previous allocation to
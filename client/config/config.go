@@ -241,6 +241,33 @@ type Config struct {
 	// before garbage collection is triggered.
 	GCMaxAllocs int
 
+	// AllocDirDiskBudgetMB is the total disk space in MB that the allocation
+	// directories of the client may use. Zero disables the budget.
+	AllocDirDiskBudgetMB int
+
+	// AllocDirDiskHighWatermark is the percentage of AllocDirDiskBudgetMB
+	// above which the client garbage collects terminal allocations and, if
+	// that isn't enough, marks itself ineligible for scheduling.
+	AllocDirDiskHighWatermark float64
+
+	// AllocDirDiskLowWatermark is the percentage of AllocDirDiskBudgetMB
+	// below which a client marked ineligible by the budget becomes eligible
+	// for scheduling again.
+	AllocDirDiskLowWatermark float64
+
+	// NetworkGCInterval is the time interval at which the client removes the
+	// network namespaces, veth pairs and iptables chains left behind by
+	// allocations that are not running on the client anymore.
+	NetworkGCInterval time.Duration
+
+	// MaxConcurrentAllocStarts is the maximum number of allocations the
+	// client starts at the same time. Zero disables the limit.
+	MaxConcurrentAllocStarts int
+
+	// AllocStartInterval is the minimum time between the start of two
+	// allocations. Zero disables the ramp.
+	AllocStartInterval time.Duration
+
 	// NoHostUUID disables using the host's UUID and will force generation of a
 	// random UUID.
 	NoHostUUID bool
@@ -261,6 +288,10 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// RecordRemoteExec records the output of all the exec sessions targeting
+	// tasks on this client to the log directory of their allocation
+	RecordRemoteExec bool
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -278,6 +309,10 @@ type Config struct {
 	// instances of the plugins.
 	PluginSingletonLoader loader.PluginCatalog
 
+	// PluginConfigs are the configurations of the plugins. They are reloaded
+	// into PluginLoader for the plugins that support it.
+	PluginConfigs []*structsc.PluginConfig
+
 	// StateDBFactory is used to override stateDB implementations,
 	StateDBFactory state.NewStateDBFunc
 
@@ -882,25 +917,28 @@ func DefaultConfig() *Config {
 			structs.VaultDefaultCluster: structsc.DefaultVaultConfig()},
 		ConsulConfigs: map[string]*structsc.ConsulConfig{
 			structs.ConsulDefaultCluster: structsc.DefaultConsulConfig()},
-		Region:                  "global",
-		StatsCollectionInterval: 1 * time.Second,
-		TLSConfig:               &structsc.TLSConfig{},
-		GCInterval:              1 * time.Minute,
-		GCParallelDestroys:      2,
-		GCDiskUsageThreshold:    80,
-		GCInodeUsageThreshold:   70,
-		GCMaxAllocs:             50,
-		NoHostUUID:              true,
-		DisableRemoteExec:       false,
-		TemplateConfig:          DefaultTemplateConfig(),
-		RPCHoldTimeout:          5 * time.Second,
-		CNIPath:                 "/opt/cni/bin",
-		CNIConfigDir:            "/opt/cni/config",
-		CNIInterfacePrefix:      "eth",
-		HostNetworks:            map[string]*structs.ClientHostNetworkConfig{},
-		CgroupParent:            "nomad.slice", // SETH todo
-		MaxDynamicPort:          structs.DefaultMinDynamicPort,
-		MinDynamicPort:          structs.DefaultMaxDynamicPort,
+		Region:                    "global",
+		StatsCollectionInterval:   1 * time.Second,
+		TLSConfig:                 &structsc.TLSConfig{},
+		GCInterval:                1 * time.Minute,
+		GCParallelDestroys:        2,
+		GCDiskUsageThreshold:      80,
+		GCInodeUsageThreshold:     70,
+		GCMaxAllocs:               50,
+		AllocDirDiskHighWatermark: 90,
+		AllocDirDiskLowWatermark:  80,
+		NetworkGCInterval:         10 * time.Minute,
+		NoHostUUID:                true,
+		DisableRemoteExec:         false,
+		TemplateConfig:            DefaultTemplateConfig(),
+		RPCHoldTimeout:            5 * time.Second,
+		CNIPath:                   "/opt/cni/bin",
+		CNIConfigDir:              "/opt/cni/config",
+		CNIInterfacePrefix:        "eth",
+		HostNetworks:              map[string]*structs.ClientHostNetworkConfig{},
+		CgroupParent:              "nomad.slice", // SETH todo
+		MaxDynamicPort:            structs.DefaultMinDynamicPort,
+		MinDynamicPort:            structs.DefaultMaxDynamicPort,
 		Users: &UsersConfig{
 			MinDynamicUser: 80_000,
 			MaxDynamicUser: 89_999,
@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -458,10 +459,22 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	frames := make(chan *sframer.StreamFrame, streamFramesBuffer)
 	errCh := make(chan error)
 
+	// Tasks logging to journald don't write log files in the alloc dir, so
+	// their logs are read from the journal instead.
+	logsFn := func() error {
+		return f.logsImpl(ctx, req.Follow, req.PlainText,
+			req.Offset, req.Origin, req.Task, req.LogType, fs, frames)
+	}
+	if task := alloc.LookupTask(req.Task); task != nil && task.LogConfig != nil && task.LogConfig.Journald {
+		logsFn = func() error {
+			return f.journalLogsImpl(ctx, req.Follow, req.Offset, req.Origin,
+				alloc.ID, req.Task, req.LogType, frames)
+		}
+	}
+
 	// Start streaming
 	go func() {
-		if err := f.logsImpl(ctx, req.Follow, req.PlainText,
-			req.Offset, req.Origin, req.Task, req.LogType, fs, frames); err != nil {
+		if err := logsFn(); err != nil {
 			select {
 			case errCh <- err:
 			case <-ctx.Done():
@@ -890,6 +903,11 @@ func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexT
 			continue
 		}
 
+		// Compressed rotated files can't be streamed
+		if strings.Contains(idxStr, logging.CompressedSuffix) {
+			continue
+		}
+
 		// Convert to an int
 		idx, err := strconv.Atoi(idxStr)
 		if err != nil {
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon"
+)
+
+// journalctlPath is the command used to read the logs of tasks that log to
+// journald. It is a variable so tests can replace it.
+var journalctlPath = "journalctl"
+
+// journalEntry is an entry of the JSON output of journalctl.
+type journalEntry struct {
+	Cursor  string          `json:"__CURSOR"`
+	Message json.RawMessage `json:"MESSAGE"`
+}
+
+// message returns the message of the entry. journalctl outputs messages
+// that are not valid UTF-8 as an array of bytes, and messages it does not
+// display as null.
+func (e *journalEntry) message() ([]byte, error) {
+	if len(e.Message) == 0 || bytes.Equal(e.Message, []byte("null")) {
+		return nil, nil
+	}
+
+	if e.Message[0] == '[' {
+		var raw []uint16
+		if err := json.Unmarshal(e.Message, &raw); err != nil {
+			return nil, err
+		}
+		msg := make([]byte, len(raw))
+		for i, b := range raw {
+			msg[i] = byte(b)
+		}
+		return msg, nil
+	}
+
+	var msg string
+	if err := json.Unmarshal(e.Message, &msg); err != nil {
+		return nil, err
+	}
+	return []byte(msg), nil
+}
+
+// journalctlArgs returns the journalctl arguments to read the entries of
+// the given task log.
+func journalctlArgs(allocID, task, logType string) []string {
+	return []string{
+		"--no-pager",
+		"--quiet",
+		"--all",
+		"--output=json",
+		"--output-fields=MESSAGE",
+		logmon.JournalFieldAllocID + "=" + allocID,
+		logmon.JournalFieldTaskName + "=" + task,
+		logmon.JournalFieldLogType + "=" + logType,
+	}
+}
+
+// readJournal runs journalctl with the given arguments and calls fn for each
+// entry until journalctl exits, fn returns an error or the context is
+// cancelled.
+func readJournal(ctx context.Context, args []string, fn func(*journalEntry) error) error {
+	cmd := exec.CommandContext(ctx, journalctlPath, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to read journal: %v", err)
+	}
+
+	var readErr error
+	dec := json.NewDecoder(stdout)
+	for {
+		var entry journalEntry
+		if err := dec.Decode(&entry); err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				readErr = fmt.Errorf("failed to decode journal entry: %v", err)
+			}
+			break
+		}
+		if err := fn(&entry); err != nil {
+			readErr = err
+			break
+		}
+	}
+
+	if readErr != nil {
+		cmd.Process.Kill()
+	}
+	waitErr := cmd.Wait()
+
+	switch {
+	case readErr != nil:
+		return readErr
+	case ctx.Err() != nil:
+		return nil
+	case waitErr != nil:
+		return fmt.Errorf("failed to read journal: %v: %s", waitErr, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// journalLogsImpl is used to stream the logs of a task that logs to
+// journald. It behaves like logsImpl, with offsets counted over the
+// concatenation of the messages of the task log, each followed by a newline.
+func (f *FileSystem) journalLogsImpl(ctx context.Context, follow bool, offset int64,
+	origin, allocID, task, logType string, frames chan<- *sframer.StreamFrame) error {
+
+	// Create the framer
+	framer := sframer.NewStreamFramer(frames, streamHeartbeatRate, streamBatchWindow, streamFrameSize)
+	framer.Run()
+	defer framer.Destroy()
+
+	name := fmt.Sprintf("%s.%s", task, logType)
+
+	// pos is the offset of the end of the data read from the journal so far
+	var pos int64
+	send := func(data []byte) error {
+		pos += int64(len(data))
+		if len(data) == 0 {
+			return nil
+		}
+		return parseFramerErr(framer.Send(name, "", data, pos))
+	}
+
+	// When reading from the end, the existing entries are buffered to only
+	// send the last offset bytes.
+	skip := offset
+	buffering := origin == OriginEnd
+	if buffering {
+		skip = 0
+	}
+	var tail []byte
+	var cursor string
+
+	handleEntry := func(entry *journalEntry) error {
+		cursor = entry.Cursor
+		msg, err := entry.message()
+		if err != nil {
+			return fmt.Errorf("failed to decode journal message: %v", err)
+		}
+		line := append(msg, '\n')
+
+		if buffering {
+			pos += int64(len(line))
+			tail = append(tail, line...)
+			if int64(len(tail)) > 2*offset+streamFrameSize {
+				tail = bytes.Clone(tail[int64(len(tail))-offset:])
+			}
+			return nil
+		}
+
+		if skip > 0 {
+			n := min(skip, int64(len(line)))
+			skip -= n
+			pos += n
+			line = line[n:]
+		}
+		return send(line)
+	}
+
+	args := journalctlArgs(allocID, task, logType)
+	if err := readJournal(ctx, args, handleEntry); err != nil {
+		return err
+	}
+
+	if buffering {
+		if int64(len(tail)) > offset {
+			tail = tail[int64(len(tail))-offset:]
+		}
+		pos -= int64(len(tail))
+		buffering = false
+		if err := send(tail); err != nil {
+			return err
+		}
+		tail = nil
+	}
+
+	if !follow {
+		return nil
+	}
+
+	// Follow the new entries, starting after the last one read.
+	args = append(args, "--follow")
+	if cursor != "" {
+		args = append(args, "--after-cursor="+cursor)
+	} else {
+		args = append(args, "--lines=all")
+	}
+	return readJournal(ctx, args, handleEntry)
+}
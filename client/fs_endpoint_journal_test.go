@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/shoenig/test/must"
+)
+
+// fakeJournalctl replaces journalctl with a script that records its
+// arguments and prints the history entries, or the follow entries when
+// called with --follow.
+func fakeJournalctl(t *testing.T, history, follow []string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a shell")
+	}
+
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	historyFile := filepath.Join(dir, "history")
+	followFile := filepath.Join(dir, "follow")
+	must.NoError(t, os.WriteFile(historyFile, []byte(strings.Join(history, "\n")+"\n"), 0o644))
+	must.NoError(t, os.WriteFile(followFile, []byte(strings.Join(follow, "\n")+"\n"), 0o644))
+
+	script := `#!/bin/sh
+echo "$@" >> ` + argsFile + `
+for arg in "$@"; do
+  if [ "$arg" = "--follow" ]; then
+    cat ` + followFile + `
+    exec sleep 60
+  fi
+done
+cat ` + historyFile + `
+`
+	path := filepath.Join(dir, "journalctl")
+	must.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+
+	old := journalctlPath
+	journalctlPath = path
+	t.Cleanup(func() { journalctlPath = old })
+
+	return argsFile
+}
+
+func testJournalEntry(t *testing.T, cursor string, message any) string {
+	b, err := json.Marshal(map[string]any{"__CURSOR": cursor, "MESSAGE": message})
+	must.NoError(t, err)
+	return string(b)
+}
+
+// collectJournalLogs runs journalLogsImpl and returns the streamed data and
+// the offset of the last frame. Note that the offset of a frame is the one of
+// the first data sent in it.
+func collectJournalLogs(t *testing.T, ctx context.Context, follow bool, offset int64, origin string) (string, int64, error) {
+	frames := make(chan *sframer.StreamFrame, streamFramesBuffer)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- (&FileSystem{}).journalLogsImpl(ctx, follow, offset, origin, "alloc", "web", "stdout", frames)
+	}()
+
+	var data strings.Builder
+	var lastOffset int64
+	for frame := range frames {
+		if frame.IsHeartbeat() {
+			continue
+		}
+		data.Write(frame.Data)
+		lastOffset = frame.Offset
+	}
+	return data.String(), lastOffset, <-errCh
+}
+
+func TestFS_journalLogsImpl(t *testing.T) {
+	// Not parallel as journalctl is replaced.
+
+	history := []string{
+		testJournalEntry(t, "c1", "hello"),
+		testJournalEntry(t, "c2", []int{0xff, 'x'}),
+		testJournalEntry(t, "c3", "world"),
+	}
+	argsFile := fakeJournalctl(t, history, []string{
+		testJournalEntry(t, "c4", "again"),
+	})
+
+	ctx := context.Background()
+
+	data, _, err := collectJournalLogs(t, ctx, false, 0, OriginStart)
+	must.NoError(t, err)
+	must.Eq(t, "hello\n\xffx\nworld\n", data)
+
+	data, _, err = collectJournalLogs(t, ctx, false, 7, OriginStart)
+	must.NoError(t, err)
+	must.Eq(t, "x\nworld\n", data)
+
+	data, offset, err := collectJournalLogs(t, ctx, false, 6, OriginEnd)
+	must.NoError(t, err)
+	must.Eq(t, "world\n", data)
+	must.Eq(t, 15, offset)
+
+	data, _, err = collectJournalLogs(t, ctx, false, 100, OriginEnd)
+	must.NoError(t, err)
+	must.Eq(t, "hello\n\xffx\nworld\n", data)
+
+	// Following continues after the last entry read.
+	must.NoError(t, os.Truncate(argsFile, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(time.Second, cancel)
+
+	data, offset, err = collectJournalLogs(t, ctx, true, 0, OriginEnd)
+	must.NoError(t, err)
+	must.Eq(t, "again\n", data)
+	must.Eq(t, 21, offset)
+
+	args, err := os.ReadFile(argsFile)
+	must.NoError(t, err)
+	calls := strings.Split(strings.TrimSpace(string(args)), "\n")
+	must.Len(t, 2, calls)
+	must.StrContains(t, calls[0], "NOMAD_ALLOC_ID=alloc NOMAD_TASK_NAME=web NOMAD_LOG_TYPE=stdout")
+	must.StrContains(t, calls[1], "--follow --after-cursor=c3")
+}
+
+func TestFS_journalLogsImpl_Error(t *testing.T) {
+	// Not parallel as journalctl is replaced.
+
+	old := journalctlPath
+	journalctlPath = filepath.Join(t.TempDir(), "missing")
+	t.Cleanup(func() { journalctlPath = old })
+
+	_, _, err := collectJournalLogs(t, context.Background(), false, 0, OriginStart)
+	must.ErrorContains(t, err, "failed to read journal")
+}
+
+func TestFS_journalEntry_message(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		raw string
+		exp string
+	}{
+		{raw: `{"MESSAGE":"hello"}`, exp: "hello"},
+		{raw: `{"MESSAGE":[104,105,255]}`, exp: "hi\xff"},
+		{raw: `{"MESSAGE":null}`, exp: ""},
+		{raw: `{}`, exp: ""},
+	}
+	for _, tc := range cases {
+		var entry journalEntry
+		must.NoError(t, json.Unmarshal([]byte(tc.raw), &entry))
+		msg, err := entry.message()
+		must.NoError(t, err)
+		must.Eq(t, tc.exp, string(msg))
+	}
+}
@@ -1536,6 +1536,23 @@ OUTER:
 	}
 }
 
+func TestFS_logIndexes_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	entries := []*cstructs.AllocFileInfo{
+		{Name: "foo.stdout.0.gz"},
+		{Name: "foo.stdout.1.gz.tmp"},
+		{Name: "foo.stdout.1"},
+		{Name: "foo.stdout.2"},
+	}
+
+	indexes, err := logIndexes(entries, "foo", "stdout")
+	must.NoError(t, err)
+	must.Len(t, 2, indexes)
+	must.Eq(t, "foo.stdout.1", indexes[0].entry.Name)
+	must.Eq(t, "foo.stdout.2", indexes[1].entry.Name)
+}
+
 func TestFS_findClosest(t *testing.T) {
 	task := "foo"
 	entries := []*cstructs.AllocFileInfo{
@@ -232,6 +232,30 @@ func (a *AllocGarbageCollector) CollectAll() {
 	}
 }
 
+// CollectUntil garbage collects terminal allocations, oldest first, until
+// done returns true or there are no terminal allocations left. Returns the
+// number of allocations collected.
+func (a *AllocGarbageCollector) CollectUntil(reason string, done func() bool) int {
+	collected := 0
+	for !done() {
+		select {
+		case <-a.shutdownCh:
+			return collected
+		default:
+		}
+
+		gcAlloc := a.allocRunners.Pop()
+		if gcAlloc == nil {
+			break
+		}
+
+		// Destroy the alloc runner and wait until it exits
+		a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, reason)
+		collected++
+	}
+	return collected
+}
+
 // MakeRoomFor garbage collects enough number of allocations in the terminal
 // state to make room for new allocations
 func (a *AllocGarbageCollector) MakeRoomFor(allocations []*structs.Allocation) error {
@@ -4,6 +4,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/lib/proclib"
@@ -56,3 +58,12 @@ type CPUPartitions interface {
 	Reserve(*idset.Set[hw.CoreID]) error
 	Release(*idset.Set[hw.CoreID]) error
 }
+
+// AllocStartLimiter limits the rate at which a client starts allocations.
+type AllocStartLimiter interface {
+	// Acquire blocks until the allocation may start or the context is done.
+	// If the allocation has to wait, queued is called with its position in
+	// the queue. The returned function must be called to release the slot
+	// once the allocation has started.
+	Acquire(ctx context.Context, queued func(position int)) (func(), error)
+}
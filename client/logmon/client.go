@@ -27,6 +27,10 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 		StderrFileName: cfg.StderrLogFile,
 		MaxFiles:       uint32(cfg.MaxFiles),
 		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
+		Compress:       cfg.Compress,
+		Journald:       cfg.Journald,
+		JournaldFields: cfg.JournaldFields,
+		MaxTotalSizeMb: uint32(cfg.MaxTotalSizeMB),
 		StdoutFifo:     cfg.StdoutFifo,
 		StderrFifo:     cfg.StderrFifo,
 	}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"sync"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// Journal fields added to the entries of tasks that log to journald. They
+	// are used to read the logs of a task back from the journal.
+	JournalFieldAllocID   = "NOMAD_ALLOC_ID"
+	JournalFieldJobID     = "NOMAD_JOB_ID"
+	JournalFieldNamespace = "NOMAD_NAMESPACE"
+	JournalFieldTaskName  = "NOMAD_TASK_NAME"
+	JournalFieldLogType   = "NOMAD_LOG_TYPE"
+
+	// journalMaxLineSize is the size after which a line without a newline is
+	// sent as its own entry. It matches the default LineMax of journald for
+	// stream clients.
+	journalMaxLineSize = 48 * 1024
+)
+
+var (
+	// journalEnabled and journalSend are variables so tests can run without
+	// a journal.
+	journalEnabled = journal.Enabled
+	journalSend    = journal.Send
+)
+
+// newJournalTaskLogger returns a TaskLogger that sends the output of the
+// task to the journal.
+func newJournalTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
+	if !journalEnabled() {
+		return nil, errors.New("journald logging requested but the journal is not available on this host")
+	}
+
+	tl := &TaskLogger{config: cfg}
+
+	stdout := newJournalWriter(cfg.JournaldFields, "stdout", journal.PriInfo, logger)
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, stdout)
+	if err != nil {
+		return nil, err
+	}
+	tl.lro = wrapperOut
+
+	stderr := newJournalWriter(cfg.JournaldFields, "stderr", journal.PriErr, logger)
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, stderr)
+	if err != nil {
+		return nil, err
+	}
+	tl.lre = wrapperErr
+
+	return tl, nil
+}
+
+// journalWriter is an io.WriteCloser that sends each line written to it as a
+// journal entry.
+type journalWriter struct {
+	fields   map[string]string
+	priority journal.Priority
+	send     func(string, journal.Priority, map[string]string) error
+	logger   hclog.Logger
+
+	// buf holds the data written after the last newline
+	buf []byte
+
+	lock sync.Mutex
+}
+
+func newJournalWriter(fields map[string]string, logType string, priority journal.Priority, logger hclog.Logger) *journalWriter {
+	f := maps.Clone(fields)
+	if f == nil {
+		f = make(map[string]string, 1)
+	}
+	f[JournalFieldLogType] = logType
+
+	return &journalWriter{
+		fields:   f,
+		priority: priority,
+		send:     journalSend,
+		logger:   logger.With("log_type", logType),
+	}
+}
+
+// Write sends every complete line in p to the journal and buffers the rest
+// until the next newline or Close. Failures to send are logged rather than
+// returned so that the task does not block or receive SIGPIPE when the
+// journal is unavailable.
+func (w *journalWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.sendLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+
+	for len(w.buf) >= journalMaxLineSize {
+		w.sendLine(w.buf[:journalMaxLineSize])
+		w.buf = w.buf[journalMaxLineSize:]
+	}
+
+	// Avoid holding on to a large backing array once it has been consumed
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+
+	return len(p), nil
+}
+
+// Close sends any remaining partial line to the journal.
+func (w *journalWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.buf) > 0 {
+		w.sendLine(w.buf)
+		w.buf = nil
+	}
+	return nil
+}
+
+func (w *journalWriter) sendLine(line []byte) {
+	if err := w.send(string(line), w.priority, w.fields); err != nil {
+		w.logger.Warn("failed to send log line to journal", "error", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+type testJournalEntry struct {
+	message  string
+	priority journal.Priority
+	fields   map[string]string
+}
+
+type testJournal struct {
+	entries []testJournalEntry
+	lock    sync.Mutex
+}
+
+func (j *testJournal) send(message string, priority journal.Priority, fields map[string]string) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.entries = append(j.entries, testJournalEntry{message, priority, fields})
+	return nil
+}
+
+func (j *testJournal) messages() []string {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	msgs := make([]string, 0, len(j.entries))
+	for _, e := range j.entries {
+		msgs = append(msgs, e.message)
+	}
+	return msgs
+}
+
+func TestJournalWriter(t *testing.T) {
+	ci.Parallel(t)
+
+	j := &testJournal{}
+	fields := map[string]string{JournalFieldTaskName: "web"}
+	w := newJournalWriter(fields, "stderr", journal.PriErr, testlog.HCLogger(t))
+	w.send = j.send
+
+	_, err := w.Write([]byte("one\ntw"))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("o\n\nthree"))
+	must.NoError(t, err)
+	must.Eq(t, []string{"one", "two", ""}, j.messages())
+
+	// Lines without a newline are split at the max line size.
+	_, err = w.Write([]byte(strings.Repeat("a", journalMaxLineSize)))
+	must.NoError(t, err)
+	must.Len(t, 4, j.messages())
+	must.Eq(t, "three"+strings.Repeat("a", journalMaxLineSize-5), j.messages()[3])
+
+	// Close flushes the partial line.
+	must.NoError(t, w.Close())
+	must.Eq(t, "aaaaa", j.messages()[4])
+
+	e := j.entries[0]
+	must.Eq(t, journal.PriErr, e.priority)
+	must.Eq(t, map[string]string{
+		JournalFieldTaskName: "web",
+		JournalFieldLogType:  "stderr",
+	}, e.fields)
+
+	// The fields passed in are not modified.
+	must.MapLen(t, 1, fields)
+}
+
+func TestLogmon_Start_journald(t *testing.T) {
+	// Not parallel as the journal functions are replaced.
+	if runtime.GOOS == "windows" {
+		t.Skip("journald is not supported on windows")
+	}
+
+	j := &testJournal{}
+	enabled := false
+	oldEnabled, oldSend := journalEnabled, journalSend
+	journalEnabled = func() bool { return enabled }
+	journalSend = j.send
+	t.Cleanup(func() {
+		journalEnabled, journalSend = oldEnabled, oldSend
+	})
+
+	dir := t.TempDir()
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    filepath.Join(dir, "stdout.fifo"),
+		StderrLogFile: "stderr",
+		StderrFifo:    filepath.Join(dir, "stderr.fifo"),
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Journald:      true,
+		JournaldFields: map[string]string{
+			JournalFieldAllocID: "alloc",
+		},
+	}
+
+	// Starting fails if the journal is not available.
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.ErrorContains(t, lm.Start(cfg), "journal is not available")
+
+	enabled = true
+	must.NoError(t, lm.Start(cfg))
+
+	stdout, err := fifo.OpenWriter(cfg.StdoutFifo)
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("hello\nworld\n"))
+	must.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		return len(j.messages()) == 2, nil
+	}, func(error) {
+		t.Fatalf("expected 2 journal entries, got %v", j.messages())
+	})
+	must.Eq(t, []string{"hello", "world"}, j.messages())
+	must.Eq(t, "stdout", j.entries[0].fields[JournalFieldLogType])
+	must.Eq(t, "alloc", j.entries[0].fields[JournalFieldAllocID])
+
+	// No log files are written.
+	files, err := filepath.Glob(filepath.Join(dir, "std*[0-9]"))
+	must.NoError(t, err)
+	must.SliceEmpty(t, files)
+
+	must.NoError(t, stdout.Close())
+	must.NoError(t, lm.Stop())
+}
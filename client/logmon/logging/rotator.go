@@ -6,7 +6,9 @@ package logging
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -33,6 +35,12 @@ const (
 
 	// newLineDelimiter is the delimiter used for new lines.
 	newLineDelimiter = '\n'
+
+	// CompressedSuffix is the suffix of rotated files once compressed.
+	CompressedSuffix = ".gz"
+
+	// compressingSuffix is the suffix of rotated files being compressed.
+	compressingSuffix = CompressedSuffix + ".tmp"
 )
 
 // FileRotator writes bytes to a rotated set of files
@@ -40,11 +48,16 @@ type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	// Compress and MaxTotalSize must be set before the first write.
+	Compress     bool  // Compress enables gzip compression of rotated files
+	MaxTotalSize int64 // MaxTotalSize is the maximum size of all the files in a path, if set
+
 	path         string // path is the path on the file system where the rotated set of files are opened
 	baseFileName string // baseFileName is the base file name of the rotated files
 	logFileIdx   int    // logFileIdx is the current index of the rotated files
 
 	oldestLogFileIdx int // oldestLogFileIdx is the index of the oldest log file in a path
+	activeLogFileIdx int // activeLogFileIdx is logFileIdx for the purge goroutine
 	closed           bool
 	fileLock         sync.Mutex
 
@@ -171,16 +184,22 @@ func (f *FileRotator) nextFile() error {
 				continue
 			}
 		}
+		if _, err := os.Stat(logFileName + CompressedSuffix); err == nil {
+			continue
+		}
 		f.logFileIdx = nextFileIdx
 		if err := f.createFile(); err != nil {
 			return err
 		}
 		break
 	}
-	// Purge old files if we have more files than MaxFiles
+	// Purge old files if we have more files than MaxFiles, and compress or
+	// cap the total size of rotated files on every rotation if enabled
 	f.fileLock.Lock()
 	defer f.fileLock.Unlock()
-	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles && !f.closed {
+	f.activeLogFileIdx = f.logFileIdx
+	purge := f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles || f.Compress || f.MaxTotalSize > 0
+	if purge && !f.closed {
 		select {
 		case f.purgeCh <- struct{}{}:
 		default:
@@ -196,22 +215,27 @@ func (f *FileRotator) lastFile() error {
 		return err
 	}
 
-	prefix := fmt.Sprintf("%s.", f.baseFileName)
+	compressed := false
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
-		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
-			n, err := strconv.Atoi(fileIdx)
-			if err != nil {
-				continue
-			}
-			if n > f.logFileIdx {
-				f.logFileIdx = n
-			}
+		n, gz, ok := f.parseFileName(fi.Name())
+		if !ok {
+			continue
+		}
+		if n > f.logFileIdx || (n == f.logFileIdx && gz) {
+			f.logFileIdx = n
+			compressed = gz
 		}
 	}
+
+	// Never append to a file that was already rotated and compressed
+	if compressed {
+		f.logFileIdx++
+	}
+	f.activeLogFileIdx = f.logFileIdx
+
 	if err := f.createFile(); err != nil {
 		return err
 	}
@@ -270,58 +294,202 @@ func (f *FileRotator) Close() error {
 	return nil
 }
 
-// purgeOldFiles removes older files and keeps only the last N files rotated for
-// a file
+// purgeOldFiles compresses rotated files if enabled, and removes older files
+// to keep only the last N files rotated for a file and cap their total size
 func (f *FileRotator) purgeOldFiles() {
 	for {
 		select {
-		case <-f.purgeCh:
-			var fIndexes []int
-			files, err := os.ReadDir(f.path)
+		case _, ok := <-f.purgeCh:
+			if !ok {
+				return
+			}
+
+			f.fileLock.Lock()
+			activeIdx := f.activeLogFileIdx
+			f.fileLock.Unlock()
+
+			files, err := f.rotatedFiles()
 			if err != nil {
 				f.logger.Error("error getting directory listing", "error", err)
 				return
 			}
-			// Inserting all the rotated files in a slice
-			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "error", err)
-						continue
+			if len(files) == 0 {
+				continue
+			}
+
+			// Deleting the files beyond MaxFiles first, compressing the
+			// remaining rotated files, then deleting the oldest rotated files
+			// until the total size is within MaxTotalSize
+			var deleted int
+			if len(files) > f.MaxFiles {
+				deleted = len(files) - f.MaxFiles
+			}
+
+			if f.Compress {
+				for _, file := range files[deleted:] {
+					if file.idx < activeIdx && !file.compressed {
+						f.compressFile(file)
 					}
-					fIndexes = append(fIndexes, n)
 				}
 			}
 
-			// Not continuing to delete files if the number of files is not more
-			// than MaxFiles
-			if len(fIndexes) <= f.MaxFiles {
-				continue
+			if f.MaxTotalSize > 0 {
+				var total int64
+				for _, file := range files[deleted:] {
+					total += file.size
+				}
+				for ; total > f.MaxTotalSize && deleted < len(files); deleted++ {
+					if files[deleted].idx >= activeIdx {
+						break
+					}
+					total -= files[deleted].size
+				}
 			}
 
-			// Sorting the file indexes so that we can purge the older files and keep
-			// only the number of files as configured by the user
-			sort.Ints(fIndexes)
-			toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
-			for _, fIndex := range toDelete {
-				fname := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, fIndex))
+			for _, file := range files[:deleted] {
+				fname := filepath.Join(f.path, file.name)
 				err := os.RemoveAll(fname)
 				if err != nil {
 					f.logger.Error("error removing file", "filename", fname, "error", err)
 				}
 			}
 
-			f.fileLock.Lock()
-			f.oldestLogFileIdx = fIndexes[0]
-			f.fileLock.Unlock()
+			if deleted < len(files) {
+				f.fileLock.Lock()
+				f.oldestLogFileIdx = files[deleted].idx
+				f.fileLock.Unlock()
+			}
 		case <-f.doneCh:
 			return
 		}
 	}
 }
 
+// rotatedFile is a log file of the rotator.
+type rotatedFile struct {
+	idx        int
+	name       string
+	size       int64
+	compressed bool
+}
+
+// rotatedFiles returns the log files of the rotator sorted by index.
+// Uncompressed copies of compressed files are removed, since they are left
+// when a compression is interrupted after the compressed file is complete.
+func (f *FileRotator) rotatedFiles() ([]*rotatedFile, error) {
+	entries, err := os.ReadDir(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	byIdx := make(map[int]*rotatedFile)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		idx, compressed, ok := f.parseFileName(entry.Name())
+		if !ok {
+			if strings.HasPrefix(entry.Name(), f.baseFileName+".") &&
+				!strings.HasSuffix(entry.Name(), compressingSuffix) {
+				f.logger.Error("error extracting file index", "filename", entry.Name())
+			}
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		file := &rotatedFile{idx: idx, name: entry.Name(), size: info.Size(), compressed: compressed}
+		if prev, ok := byIdx[idx]; ok {
+			if !compressed {
+				file, prev = prev, file
+			}
+			os.Remove(filepath.Join(f.path, prev.name))
+		}
+		byIdx[idx] = file
+	}
+
+	files := make([]*rotatedFile, 0, len(byIdx))
+	for _, file := range byIdx {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].idx < files[j].idx })
+	return files, nil
+}
+
+// parseFileName returns the index of a log file of the rotator and whether it
+// is compressed.
+func (f *FileRotator) parseFileName(name string) (int, bool, bool) {
+	fileIdx, ok := strings.CutPrefix(name, f.baseFileName+".")
+	if !ok {
+		return 0, false, false
+	}
+	fileIdx, compressed := strings.CutSuffix(fileIdx, CompressedSuffix)
+	n, err := strconv.Atoi(fileIdx)
+	if err != nil {
+		return 0, false, false
+	}
+	return n, compressed, true
+}
+
+// compressFile replaces a rotated file with its gzip compressed version and
+// updates file with the compressed file name and size.
+func (f *FileRotator) compressFile(file *rotatedFile) {
+	src := filepath.Join(f.path, file.name)
+	dst := src + CompressedSuffix
+
+	size, err := gzipFile(src, dst+".tmp")
+	if err == nil {
+		err = os.Rename(dst+".tmp", dst)
+	}
+	if err != nil {
+		f.logger.Error("error compressing file", "filename", src, "error", err)
+		os.Remove(dst + ".tmp")
+		return
+	}
+	if err := os.Remove(src); err != nil {
+		f.logger.Error("error removing file", "filename", src, "error", err)
+	}
+
+	file.name += CompressedSuffix
+	file.size = size
+	file.compressed = true
+}
+
+// gzipFile writes the gzip compressed content of src to dst and returns the
+// size of dst.
+func gzipFile(src, dst string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		return 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return 0, err
+	}
+	if err := out.Sync(); err != nil {
+		return 0, err
+	}
+
+	fi, err := out.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
 // flushBuffer flushes the buffer
 func (f *FileRotator) flushBuffer() error {
 	f.bufLock.Lock()
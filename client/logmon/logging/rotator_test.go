@@ -4,7 +4,9 @@
 package logging
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -275,6 +277,107 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_Compress(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 5, testlog.HCLogger(t))
+	must.NoError(t, err)
+	fr.Compress = true
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("abcdefghijklm"))
+	must.NoError(t, err)
+
+	// All but the current file are compressed
+	testutil.WaitForResult(func() (bool, error) {
+		var names []string
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return false, err
+		}
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		expected := []string{"redis.stdout.0.gz", "redis.stdout.1.gz", "redis.stdout.2"}
+		if fmt.Sprint(names) != fmt.Sprint(expected) {
+			return false, fmt.Errorf("expected files %v, got %v", expected, names)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+
+	f, err := os.Open(filepath.Join(path, "redis.stdout.1.gz"))
+	must.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	must.NoError(t, err)
+	content, err := io.ReadAll(gz)
+	must.NoError(t, err)
+	must.Eq(t, "fghij", string(content))
+}
+
+func TestFileRotator_MaxTotalSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 5, testlog.HCLogger(t))
+	must.NoError(t, err)
+	fr.MaxTotalSize = 12
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("abcdefghijklmnopqrstuvw"))
+	must.NoError(t, err)
+
+	// Only the newest files fitting in the total size when the last file was
+	// created are kept
+	testutil.WaitForResult(func() (bool, error) {
+		var names []string
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return false, err
+		}
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		expected := []string{"redis.stdout.2", "redis.stdout.3", "redis.stdout.4"}
+		if fmt.Sprint(names) != fmt.Sprint(expected) {
+			return false, fmt.Errorf("expected files %v, got %v", expected, names)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
+func TestFileRotator_OpenLastFile_Compressed(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	// An interrupted compression leaves both files behind
+	for _, name := range []string{"redis.stdout.0.gz", "redis.stdout.1", "redis.stdout.1.gz"} {
+		must.NoError(t, os.WriteFile(filepath.Join(path, name), []byte("abc"), 0o644))
+	}
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+
+	// Writes never go to a file that was already compressed
+	must.Eq(t, 2, fr.logFileIdx)
+
+	files, err := fr.rotatedFiles()
+	must.NoError(t, err)
+	must.Len(t, 3, files)
+	must.Eq(t, "redis.stdout.1.gz", files[1].name)
+	_, err = os.Stat(filepath.Join(path, "redis.stdout.1"))
+	must.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
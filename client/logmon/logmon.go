@@ -44,6 +44,20 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// Compress enables gzip compression of rotated log files
+	Compress bool
+
+	// MaxTotalSizeMB is the max size in MB of all the log files of each
+	// stream, or 0 for no limit other than MaxFiles
+	MaxTotalSizeMB int
+
+	// Journald sends each line of output to the journal instead of log
+	// files in LogDir
+	Journald bool
+
+	// JournaldFields are the fields added to each journal entry
+	JournaldFields map[string]string
 }
 
 type LogMon interface {
@@ -139,6 +153,10 @@ func (tl *TaskLogger) Close() {
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
+	if cfg.Journald {
+		return newJournalTaskLogger(cfg, logger)
+	}
+
 	tl := &TaskLogger{config: cfg}
 
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
@@ -147,6 +165,8 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
+	lro.Compress = cfg.Compress
+	lro.MaxTotalSize = int64(cfg.MaxTotalSizeMB) * 1024 * 1024
 
 	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
 	if err != nil {
@@ -160,6 +180,8 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
+	lre.Compress = cfg.Compress
+	lre.MaxTotalSize = int64(cfg.MaxTotalSizeMB) * 1024 * 1024
 
 	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
 	if err != nil {
@@ -25,16 +25,20 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir               string            `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName       string            `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName       string            `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles             uint32            `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb        uint32            `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo           string            `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo           string            `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	Compress             bool              `protobuf:"varint,8,opt,name=compress,proto3" json:"compress,omitempty"`
+	MaxTotalSizeMb       uint32            `protobuf:"varint,9,opt,name=max_total_size_mb,json=maxTotalSizeMb,proto3" json:"max_total_size_mb,omitempty"`
+	Journald             bool              `protobuf:"varint,10,opt,name=journald,proto3" json:"journald,omitempty"`
+	JournaldFields       map[string]string `protobuf:"bytes,11,rep,name=journald_fields,json=journaldFields,proto3" json:"journald_fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,6 +115,34 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetCompress() bool {
+	if m != nil {
+		return m.Compress
+	}
+	return false
+}
+
+func (m *StartRequest) GetMaxTotalSizeMb() uint32 {
+	if m != nil {
+		return m.MaxTotalSizeMb
+	}
+	return 0
+}
+
+func (m *StartRequest) GetJournald() bool {
+	if m != nil {
+		return m.Journald
+	}
+	return false
+}
+
+func (m *StartRequest) GetJournaldFields() map[string]string {
+	if m != nil {
+		return m.JournaldFields
+	}
+	return nil
+}
+
 type StartResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -206,6 +238,7 @@ var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest.JournaldFieldsEntry")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
@@ -216,27 +249,35 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 438 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcf, 0x8e, 0xd3, 0x30,
+	0x10, 0x87, 0xc9, 0xf6, 0x5f, 0x3a, 0xdd, 0x76, 0x8b, 0x41, 0x22, 0x0a, 0x07, 0xa2, 0x72, 0x20,
+	0x5c, 0xb2, 0x6c, 0xb9, 0x20, 0x6e, 0x20, 0x76, 0x0f, 0x88, 0xe5, 0x90, 0x72, 0xe2, 0x12, 0xb9,
+	0x8d, 0xd3, 0xcd, 0x62, 0x7b, 0x82, 0xed, 0xa2, 0xee, 0xbe, 0x09, 0x8f, 0xc8, 0x5b, 0xa0, 0x38,
+	0x4e, 0x54, 0x24, 0x0e, 0xdb, 0x53, 0xf2, 0x1b, 0x7f, 0xe3, 0xf9, 0xac, 0x81, 0x68, 0xc3, 0x4b,
+	0x26, 0xcd, 0x39, 0xc7, 0xad, 0x40, 0x79, 0x5e, 0x29, 0x34, 0xe8, 0x42, 0x62, 0x03, 0x79, 0x79,
+	0x43, 0xf5, 0x4d, 0xb9, 0x41, 0x55, 0x25, 0x12, 0x05, 0xcd, 0x93, 0xa6, 0x23, 0x39, 0x84, 0x16,
+	0xbf, 0xfb, 0x70, 0xba, 0x32, 0x54, 0x99, 0x94, 0xfd, 0xdc, 0x31, 0x6d, 0xc8, 0x33, 0x18, 0x71,
+	0xdc, 0x66, 0x79, 0xa9, 0x02, 0x2f, 0xf2, 0xe2, 0x71, 0x3a, 0xe4, 0xb8, 0xfd, 0x54, 0x2a, 0x12,
+	0xc3, 0x5c, 0x9b, 0x1c, 0x77, 0x26, 0x2b, 0x4a, 0xce, 0x32, 0x49, 0x05, 0x0b, 0x4e, 0x2c, 0x31,
+	0x6b, 0xea, 0x57, 0x25, 0x67, 0x5f, 0xa9, 0x60, 0x8e, 0x64, 0x4a, 0x1d, 0x90, 0xbd, 0x8e, 0x64,
+	0x4a, 0x75, 0xe4, 0x73, 0x18, 0x0b, 0xba, 0xb7, 0x98, 0x0e, 0xfa, 0x91, 0x17, 0x4f, 0x53, 0x5f,
+	0xd0, 0x7d, 0x7d, 0xae, 0xc9, 0x2b, 0x98, 0xb7, 0x87, 0x99, 0x2e, 0xef, 0x59, 0x26, 0xd6, 0xc1,
+	0xc0, 0x32, 0x53, 0xc7, 0xac, 0xca, 0x7b, 0x76, 0xbd, 0x26, 0x2f, 0x60, 0xd2, 0x99, 0x15, 0x18,
+	0x0c, 0xed, 0x28, 0x68, 0xa5, 0x0a, 0x74, 0x40, 0x23, 0x54, 0x60, 0x30, 0xea, 0x00, 0xeb, 0x52,
+	0x20, 0x09, 0xc1, 0xdf, 0xa0, 0xa8, 0x14, 0xd3, 0x3a, 0xf0, 0x23, 0x2f, 0xf6, 0xd3, 0x2e, 0x93,
+	0xd7, 0xf0, 0xb8, 0xd6, 0x30, 0x68, 0x28, 0xef, 0x3c, 0xc6, 0xd6, 0x63, 0x26, 0xe8, 0xfe, 0x5b,
+	0x5d, 0x77, 0x22, 0x21, 0xf8, 0xb7, 0xb8, 0x53, 0x92, 0xf2, 0x3c, 0x80, 0xe6, 0x9a, 0x36, 0x13,
+	0x09, 0x67, 0xed, 0x7f, 0x56, 0x94, 0x8c, 0xe7, 0x3a, 0x98, 0x44, 0xbd, 0x78, 0xb2, 0xbc, 0x4c,
+	0x1e, 0xb0, 0xa7, 0xe4, 0x70, 0x47, 0xc9, 0x67, 0x77, 0xd1, 0x95, 0xbd, 0xe7, 0x52, 0x1a, 0x75,
+	0x97, 0xce, 0x6e, 0xff, 0x29, 0x86, 0x1f, 0xe0, 0xc9, 0x7f, 0x30, 0x32, 0x87, 0xde, 0x0f, 0x76,
+	0xe7, 0x56, 0x5b, 0xff, 0x92, 0xa7, 0x30, 0xf8, 0x45, 0xf9, 0xae, 0x5d, 0x66, 0x13, 0xde, 0x9f,
+	0xbc, 0xf3, 0x16, 0x67, 0x30, 0x75, 0x63, 0x75, 0x85, 0x52, 0xb3, 0xc5, 0x14, 0x26, 0x2b, 0x83,
+	0x95, 0xd3, 0x58, 0xcc, 0xe0, 0xb4, 0x89, 0xcd, 0xf1, 0xf2, 0x8f, 0x07, 0xc3, 0x2f, 0xb8, 0xbd,
+	0x46, 0x49, 0x2a, 0x18, 0xd8, 0x56, 0x72, 0x71, 0xf4, 0xeb, 0xc2, 0xe5, 0x31, 0x2d, 0xce, 0xec,
+	0x11, 0x11, 0xd0, 0xaf, 0x65, 0xc8, 0x9b, 0x07, 0x76, 0x77, 0xcf, 0x08, 0x2f, 0x8e, 0xe8, 0x68,
+	0xc7, 0x7d, 0x1c, 0x7d, 0x1f, 0xd8, 0xfa, 0x7a, 0x68, 0x3f, 0x6f, 0xff, 0x0e, 0x00, 0x7b, 0x18,
+	0x1e, 0x04, 0x90, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -17,13 +17,17 @@ type logmonServer struct {
 
 func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*proto.StartResponse, error) {
 	cfg := &LogConfig{
-		LogDir:        req.LogDir,
-		StdoutLogFile: req.StdoutFileName,
-		StderrLogFile: req.StderrFileName,
-		MaxFiles:      int(req.MaxFiles),
-		MaxFileSizeMB: int(req.MaxFileSizeMb),
-		StdoutFifo:    req.StdoutFifo,
-		StderrFifo:    req.StderrFifo,
+		LogDir:         req.LogDir,
+		StdoutLogFile:  req.StdoutFileName,
+		StderrLogFile:  req.StderrFileName,
+		MaxFiles:       int(req.MaxFiles),
+		MaxFileSizeMB:  int(req.MaxFileSizeMb),
+		Compress:       req.Compress,
+		Journald:       req.Journald,
+		JournaldFields: req.JournaldFields,
+		MaxTotalSizeMB: int(req.MaxTotalSizeMb),
+		StdoutFifo:     req.StdoutFifo,
+		StderrFifo:     req.StderrFifo,
 	}
 
 	err := s.impl.Start(cfg)
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/client/allocrunner"
+)
+
+// networkGC periodically collects the networks of allocations that are not
+// running on the client anymore.
+func (c *Client) networkGC() {
+	interval := c.GetConfig().NetworkGCInterval
+	if interval <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			c.CollectOrphanedNetworks()
+			timer.Reset(interval)
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// CollectOrphanedNetworks removes the network namespaces, veth pairs and
+// iptables chains left behind by allocations that are not running on the
+// client anymore, for example because the client crashed before their network
+// was torn down.
+func (c *Client) CollectOrphanedNetworks() {
+	c.networkGCLock.Lock()
+	defer c.networkGCLock.Unlock()
+
+	defer metrics.MeasureSinceWithLabels([]string{"client", "network_gc", "duration"}, time.Now(), c.baseLabels)
+
+	isLive := func(allocID string) bool {
+		_, err := c.getAllocRunner(allocID)
+		return err == nil
+	}
+
+	stats, err := allocrunner.CollectOrphanedNetworks(c.logger, isLive)
+	if err != nil {
+		c.logger.Warn("failed to collect orphaned networks", "error", err)
+		metrics.IncrCounterWithLabels([]string{"client", "network_gc", "errors"}, 1, c.baseLabels)
+	}
+
+	metrics.IncrCounterWithLabels([]string{"client", "network_gc", "netns"}, float32(stats.Namespaces), c.baseLabels)
+	metrics.IncrCounterWithLabels([]string{"client", "network_gc", "veth"}, float32(stats.Veths), c.baseLabels)
+	metrics.IncrCounterWithLabels([]string{"client", "network_gc", "iptables_chains"}, float32(stats.IPTablesChains), c.baseLabels)
+}
@@ -262,6 +262,25 @@ func (i *instanceManager) cleanup() {
 	i.cancel()
 }
 
+// reload kills the running external plugin so that the next dispense launches
+// it with its reloaded configuration. Running tasks are recovered on the new
+// plugin instance as if the plugin had exited. Internal plugins run within the
+// agent and are left untouched.
+func (i *instanceManager) reload() {
+	i.pluginLock.Lock()
+	defer i.pluginLock.Unlock()
+
+	if i.plugin == nil || i.plugin.Internal() || i.plugin.Exited() {
+		return
+	}
+
+	i.logger.Info("relaunching plugin to apply reloaded configuration")
+	i.plugin.Kill()
+	if err := i.storeReattach(nil); err != nil {
+		i.logger.Warn("error clearing plugin reattach config from state store", "error", err)
+	}
+}
+
 // dispenseFingerprintCh dispenses a driver and makes a Fingerprint RPC call
 // to the driver. The fingerprint chan is returned along with the cancel func
 // for the context used in the RPC. This cancel func should always be called
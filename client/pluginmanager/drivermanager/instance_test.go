@@ -124,3 +124,55 @@ func TestInstanceManager_dispense(t *testing.T) {
 	require.Same(plug, plug2)
 
 }
+
+func TestInstanceManager_reload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cat := new(mockedCatalog)
+	cat.Test(t)
+	var stored []*plugin.ReattachConfig
+	i := &instanceManager{
+		logger: testlog.HCLogger(t),
+		ctx:    ctx,
+		cancel: cancel,
+		loader: cat,
+		storeReattach: func(c *plugin.ReattachConfig) error {
+			stored = append(stored, c)
+			return nil
+		},
+		fetchReattach:        func() (*plugin.ReattachConfig, bool) { return nil, false },
+		pluginConfig:         &base.AgentConfig{},
+		id:                   &loader.PluginID{Name: "mock", PluginType: base.PluginTypeDriver},
+		updateNodeFromDriver: noopUpdater,
+		eventHandlerFactory:  noopEventHandlerFactory,
+		firstFingerprintCh:   make(chan struct{}),
+	}
+	require := require.New(t)
+
+	// Reloading before the plugin is dispensed is a no-op
+	i.reload()
+	require.Empty(stored)
+
+	cat.On("Dispense", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plug, err := i.dispense()
+	require.NoError(err)
+	cat.AssertNumberOfCalls(t, "Dispense", 1)
+
+	// Reloading kills the plugin and clears its reattach config, so the next
+	// dispense launches a new plugin
+	i.reload()
+	require.True(i.plugin.Exited())
+	require.Len(stored, 2)
+	require.Nil(stored[1])
+
+	plug2, err := i.dispense()
+	require.NoError(err)
+	cat.AssertNumberOfCalls(t, "Dispense", 2)
+	require.NotSame(plug, plug2)
+
+	// Internal plugins are not killed
+	internal := loader.MockBasicExternalPlugin(&dtu.MockDriver{}, "0.1.0")
+	internal.InternalPlugin = true
+	i.plugin = internal
+	i.reload()
+	require.False(internal.Exited())
+}
@@ -30,6 +30,10 @@ type Manager interface {
 	// Dispense returns a drivers.DriverPlugin for the given driver plugin name
 	// handling reattaching to an existing driver if available
 	Dispense(driver string) (drivers.DriverPlugin, error)
+
+	// Reload relaunches the given external driver plugins so that they use
+	// their reloaded configuration
+	Reload(drivers []string)
 }
 
 // TaskExecHandler is function to be called for executing commands in a task
@@ -385,6 +389,19 @@ func (m *manager) Dispense(d string) (drivers.DriverPlugin, error) {
 	return nil, ErrDriverNotFound
 }
 
+// Reload relaunches the given external driver plugins so that they use their
+// reloaded configuration. Tasks of the drivers are recovered by the new plugin
+// instances as if the plugins had exited.
+func (m *manager) Reload(drivers []string) {
+	m.instancesMu.RLock()
+	defer m.instancesMu.RUnlock()
+	for _, d := range drivers {
+		if instance, ok := m.instances[d]; ok {
+			instance.reload()
+		}
+	}
+}
+
 func (m *manager) isDriverBlocked(name string) bool {
 	// Block drivers that are not in the allowed list if it is set.
 	if _, ok := m.allowedDrivers[name]; len(m.allowedDrivers) > 0 && !ok {
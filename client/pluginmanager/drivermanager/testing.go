@@ -60,5 +60,7 @@ func (m *testManager) Dispense(driver string) (drivers.DriverPlugin, error) {
 	return d, nil
 }
 
+func (m *testManager) Reload(drivers []string) {}
+
 func (m *testManager) RegisterEventHandler(driver, taskID string, handler EventHandler) {}
 func (m *testManager) DeregisterEventHandler(driver, taskID string)                     {}
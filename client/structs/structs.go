@@ -193,6 +193,18 @@ type AllocExecRequest struct {
 	// The name of a predefined command to be executed (optional)
 	Action string
 
+	// Record indicates whether to record the output of the session to the
+	// log directory of the allocation. Input is never recorded.
+	Record bool
+
+	// Share is the name under which the session is shared with read-only
+	// viewers, if set.
+	Share string
+
+	// Attach is the name of the shared session to attach to as a read-only
+	// viewer. When set, no command is executed.
+	Attach string
+
 	structs.QueryOptions
 }
 
@@ -210,6 +222,59 @@ type AllocChecksResponse struct {
 	Results map[structs.CheckID]*structs.CheckQueryResult
 }
 
+// TaskEnvRedacted replaces the values of the environment variables that may
+// hold secrets.
+const TaskEnvRedacted = "<redacted>"
+
+// AllocTaskEnvRequest is used to request the environment variables and
+// rendered templates a task of an allocation is running with.
+type AllocTaskEnvRequest struct {
+	structs.QueryOptions
+
+	// AllocID is the allocation running the task.
+	AllocID string
+
+	// Task is the name of the task.
+	Task string
+}
+
+// AllocTaskEnvResponse is used to return the environment variables and
+// rendered templates of a task.
+type AllocTaskEnvResponse struct {
+	structs.QueryMeta
+	Environment *TaskEnvironment
+}
+
+// TaskEnvironment is the environment and the rendered templates a task is
+// running with.
+type TaskEnvironment struct {
+	// Env is the environment of the task. The values of variables that may
+	// hold secrets are replaced by TaskEnvRedacted unless the request was
+	// allowed to read them.
+	Env map[string]string
+
+	// Templates are the rendered template files of the task, in the order
+	// of the task's templates.
+	Templates []*TaskTemplateFile
+}
+
+// TaskTemplateFile is the rendered file of a task template.
+type TaskTemplateFile struct {
+	// DestPath is the destination of the template as set in the job.
+	DestPath string
+
+	// Contents is the rendered file.
+	Contents string
+
+	// Redacted is true if the contents were omitted because the file may hold
+	// secrets.
+	Redacted bool
+
+	// Error is set if the rendered file could not be read, for example
+	// because the template hasn't been rendered yet.
+	Error string
+}
+
 // AllocStatsRequest is used to request the resource usage of a given
 // allocation, potentially filtering by task
 type AllocStatsRequest struct {
@@ -229,6 +294,22 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+// AllocStatsStreamRequest is used to stream the resource usage of a given
+// allocation at a regular interval, potentially filtering by task.
+type AllocStatsStreamRequest struct {
+	// AllocID is the allocation to stream stats for
+	AllocID string
+
+	// Task is an optional filter to only stream stats for the task.
+	Task string
+
+	// Interval is the interval at which stats are sent. It is raised to the
+	// stats collection interval of the client if lower.
+	Interval time.Duration
+
+	structs.QueryOptions
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
@@ -356,6 +437,27 @@ type HealthCheckIntervalResponse struct {
 	Period   time.Duration
 }
 
+// TaskLeadership is the state of an allocation in a leader election held
+// through the Task API.
+type TaskLeadership struct {
+	// Name is the name of the election, unique within the job.
+	Name string
+
+	// Namespace and Path of the variable holding the lock of the election.
+	Namespace string
+	Path      string
+
+	// Campaigning is true while the allocation takes part in the election.
+	Campaigning bool
+
+	// Leader is true while the allocation holds the lock of the election.
+	Leader bool
+
+	// FencingToken is the fencing token of the lock while the allocation is
+	// the leader.
+	FencingToken uint64
+}
+
 // AddDriverInfo adds information about a driver to the fingerprint response.
 // If the Drivers field has not yet been initialized, it does so here.
 func (h *HealthCheckResponse) AddDriverInfo(name string, driverInfo *structs.DriverInfo) {
@@ -0,0 +1,410 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// taskLeadershipTTL and taskLeadershipLockDelay are the TTL and lock delay
+	// of the locks held on behalf of allocations.
+	taskLeadershipTTL       = 15 * time.Second
+	taskLeadershipLockDelay = 15 * time.Second
+
+	// taskLeadershipRenewInterval is the interval between two renewals of a
+	// held lock.
+	taskLeadershipRenewInterval = taskLeadershipTTL / 3
+
+	// taskLeadershipRetryInterval is the interval between two attempts to
+	// acquire a lock held by another allocation.
+	taskLeadershipRetryInterval = 5 * time.Second
+)
+
+// taskLeadershipNameRe matches valid election names. Names are used as the
+// last segment of the path of the variable holding the lock.
+var taskLeadershipNameRe = regexp.MustCompile("^[a-zA-Z0-9-_~]{1,64}$")
+
+// TaskLeadershipStatus returns the state of the allocation of the workload
+// identity token in the named election.
+func (c *Client) TaskLeadershipStatus(token, name string) (*cstructs.TaskLeadership, error) {
+	claims, _, err := c.taskLeadershipClaims(token, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.taskLeadershipsLock.Lock()
+	tl, ok := c.taskLeaderships[taskLeadershipKey(claims.AllocationID, name)]
+	c.taskLeadershipsLock.Unlock()
+	if !ok {
+		return &cstructs.TaskLeadership{
+			Name:      name,
+			Namespace: claims.Namespace,
+			Path:      taskLeadershipPath(claims.JobID, name),
+		}, nil
+	}
+	return tl.status(), nil
+}
+
+// TaskLeadershipCampaign makes the allocation of the workload identity token
+// take part in the named election. The client acquires and renews the lock of
+// the election on behalf of the allocation while it is healthy, and releases
+// it once the allocation becomes unhealthy or stops. Campaigning again
+// refreshes the token used to hold the lock.
+func (c *Client) TaskLeadershipCampaign(token, name string) (*cstructs.TaskLeadership, error) {
+	claims, ar, err := c.taskLeadershipClaims(token, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.taskLeadershipsLock.Lock()
+	defer c.taskLeadershipsLock.Unlock()
+
+	key := taskLeadershipKey(claims.AllocationID, name)
+	if tl, ok := c.taskLeaderships[key]; ok {
+		tl.setToken(token)
+		return tl.status(), nil
+	}
+
+	tl := newTaskLeadership(c.logger, c.Region(), claims.Namespace, taskLeadershipPath(claims.JobID, name), name, token)
+	tl.rpc = c.RPC
+	tl.healthy = func() bool { return taskLeadershipAllocHealthy(ar) }
+	c.taskLeaderships[key] = tl
+
+	go func() {
+		tl.run(ar.WaitCh(), c.shutdownCh)
+
+		c.taskLeadershipsLock.Lock()
+		defer c.taskLeadershipsLock.Unlock()
+		if c.taskLeaderships[key] == tl {
+			delete(c.taskLeaderships, key)
+		}
+	}()
+
+	return tl.status(), nil
+}
+
+// TaskLeadershipResign makes the allocation of the workload identity token
+// leave the named election, releasing its lock if it is held.
+func (c *Client) TaskLeadershipResign(token, name string) (*cstructs.TaskLeadership, error) {
+	claims, _, err := c.taskLeadershipClaims(token, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.taskLeadershipsLock.Lock()
+	key := taskLeadershipKey(claims.AllocationID, name)
+	tl, ok := c.taskLeaderships[key]
+	delete(c.taskLeaderships, key)
+	c.taskLeadershipsLock.Unlock()
+
+	if ok {
+		tl.setToken(token)
+		tl.stop()
+	}
+
+	return &cstructs.TaskLeadership{
+		Name:      name,
+		Namespace: claims.Namespace,
+		Path:      taskLeadershipPath(claims.JobID, name),
+	}, nil
+}
+
+// taskLeadershipClaims resolves the workload identity token of a Task API
+// request and returns its claims along with the runner of its allocation.
+func (c *Client) taskLeadershipClaims(token, name string) (*structs.IdentityClaims, interfaces.AllocRunner, error) {
+	if !taskLeadershipNameRe.MatchString(name) {
+		return nil, nil, structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("invalid election name %q", name))
+	}
+
+	ident, err := c.resolveTokenValue(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ident == nil || ident.Claims == nil || ident.Claims.AllocationID == "" {
+		return nil, nil, structs.ErrPermissionDenied
+	}
+
+	ar, err := c.getAllocRunner(ident.Claims.AllocationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ident.Claims, ar, nil
+}
+
+// taskLeadershipAllocHealthy returns true if the allocation is running and
+// has not been marked unhealthy by its deployment.
+func taskLeadershipAllocHealthy(ar interfaces.AllocRunner) bool {
+	state := ar.AllocState()
+	if state.ClientStatus != structs.AllocClientStatusRunning {
+		return false
+	}
+	return !state.DeploymentStatus.IsUnhealthy()
+}
+
+func taskLeadershipKey(allocID, name string) string {
+	return allocID + "/" + name
+}
+
+// taskLeadershipPath returns the path of the variable holding the lock of an
+// election. Workload identities are allowed to write variables under the
+// leadership path of their job.
+func taskLeadershipPath(jobID, name string) string {
+	return fmt.Sprintf("nomad/jobs/%s/leadership/%s", jobID, name)
+}
+
+// taskLeadership campaigns for the lock of an election on behalf of an
+// allocation.
+type taskLeadership struct {
+	logger    hclog.Logger
+	region    string
+	name      string
+	namespace string
+	path      string
+
+	// rpc is used to make the variables RPCs and healthy returns true if the
+	// allocation may hold the lock.
+	rpc     func(method string, args, reply any) error
+	healthy func() bool
+
+	renewInterval time.Duration
+	retryInterval time.Duration
+
+	// token is the workload identity used to hold the lock. lockID and
+	// fencingToken are set while the lock is held, and renewed is the last
+	// time the lock was acquired or renewed.
+	token        string
+	lockID       string
+	fencingToken uint64
+	renewed      time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+
+	l sync.Mutex
+}
+
+func newTaskLeadership(logger hclog.Logger, region, namespace, path, name, token string) *taskLeadership {
+	return &taskLeadership{
+		logger:        logger.Named("task_leadership").With("election", name, "path", path),
+		region:        region,
+		name:          name,
+		namespace:     namespace,
+		path:          path,
+		renewInterval: taskLeadershipRenewInterval,
+		retryInterval: taskLeadershipRetryInterval,
+		token:         token,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// status returns the state of the allocation in the election.
+func (tl *taskLeadership) status() *cstructs.TaskLeadership {
+	tl.l.Lock()
+	defer tl.l.Unlock()
+
+	campaigning := true
+	select {
+	case <-tl.stopCh:
+		campaigning = false
+	default:
+	}
+
+	return &cstructs.TaskLeadership{
+		Name:         tl.name,
+		Namespace:    tl.namespace,
+		Path:         tl.path,
+		Campaigning:  campaigning,
+		Leader:       tl.lockID != "",
+		FencingToken: tl.fencingToken,
+	}
+}
+
+func (tl *taskLeadership) setToken(token string) {
+	tl.l.Lock()
+	defer tl.l.Unlock()
+	tl.token = token
+}
+
+// stop ends the campaign and waits for the lock to be released.
+func (tl *taskLeadership) stop() {
+	tl.stopOnce.Do(func() { close(tl.stopCh) })
+	<-tl.doneCh
+}
+
+// run campaigns for the lock until the campaign is stopped or allocCh is
+// closed, and then releases the lock. The lock is not released when the client
+// shuts down, as the allocation keeps running, and expires after its TTL
+// instead.
+func (tl *taskLeadership) run(allocCh, shutdownCh <-chan struct{}) {
+	defer close(tl.doneCh)
+
+	timer, stop := helper.NewSafeTimer(0)
+	defer stop()
+
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case <-allocCh:
+			tl.stopOnce.Do(func() { close(tl.stopCh) })
+			tl.release()
+			return
+		case <-tl.stopCh:
+			tl.release()
+			return
+		case <-timer.C:
+		}
+
+		timer.Reset(tl.campaign())
+	}
+}
+
+// campaign acquires, renews or releases the lock depending on the health of
+// the allocation, and returns the time to wait before campaigning again.
+func (tl *taskLeadership) campaign() time.Duration {
+	tl.l.Lock()
+	held := tl.lockID != ""
+	tl.l.Unlock()
+
+	switch {
+	case held && !tl.healthy():
+		tl.logger.Debug("releasing lock of unhealthy allocation")
+		tl.release()
+		return tl.retryInterval
+	case held:
+		return tl.renew()
+	case tl.healthy():
+		return tl.acquire()
+	default:
+		return tl.retryInterval
+	}
+}
+
+func (tl *taskLeadership) acquire() time.Duration {
+	tl.l.Lock()
+	args := structs.VariablesApplyRequest{
+		Op: structs.VarOpLockAcquire,
+		Var: &structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Namespace: tl.namespace,
+				Path:      tl.path,
+				Lock: &structs.VariableLock{
+					TTL:       taskLeadershipTTL,
+					LockDelay: taskLeadershipLockDelay,
+				},
+			},
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    tl.region,
+			Namespace: tl.namespace,
+			AuthToken: tl.token,
+		},
+	}
+	tl.l.Unlock()
+
+	// A conflict means the lock is held by another allocation.
+	var reply structs.VariablesApplyResponse
+	if err := tl.rpc(structs.VariablesApplyRPCMethod, &args, &reply); err != nil {
+		tl.logger.Warn("failed to acquire lock", "error", err)
+		return tl.retryInterval
+	}
+	if !reply.IsOk() || reply.Output == nil || reply.Output.Lock == nil {
+		return tl.retryInterval
+	}
+
+	tl.l.Lock()
+	defer tl.l.Unlock()
+	tl.lockID = reply.Output.Lock.ID
+	tl.fencingToken = reply.Output.Lock.FencingToken
+	tl.renewed = time.Now()
+	tl.logger.Debug("acquired lock", "fencing_token", tl.fencingToken)
+	return tl.renewInterval
+}
+
+func (tl *taskLeadership) renew() time.Duration {
+	tl.l.Lock()
+	args := structs.VariablesRenewLockRequest{
+		Path:   tl.path,
+		LockID: tl.lockID,
+		WriteRequest: structs.WriteRequest{
+			Region:    tl.region,
+			Namespace: tl.namespace,
+			AuthToken: tl.token,
+		},
+	}
+	tl.l.Unlock()
+
+	var reply structs.VariablesRenewLockResponse
+	err := tl.rpc(structs.VariablesRenewLockRPCMethod, &args, &reply)
+
+	tl.l.Lock()
+	defer tl.l.Unlock()
+
+	if err == nil {
+		tl.renewed = time.Now()
+		return tl.renewInterval
+	}
+
+	// The lock is lost if it was taken over or expired, or if it could not be
+	// renewed for longer than its TTL.
+	code, _, ok := structs.CodeFromRPCCodedErr(err)
+	if (ok && code == http.StatusConflict) || time.Since(tl.renewed) >= taskLeadershipTTL {
+		tl.logger.Warn("lost lock", "error", err)
+		tl.lockID = ""
+		tl.fencingToken = 0
+		return tl.retryInterval
+	}
+
+	tl.logger.Warn("failed to renew lock", "error", err)
+	return tl.renewInterval
+}
+
+// release releases the lock if it is held. Failing to release the lock is not
+// fatal as it expires after its TTL.
+func (tl *taskLeadership) release() {
+	tl.l.Lock()
+	if tl.lockID == "" {
+		tl.l.Unlock()
+		return
+	}
+
+	args := structs.VariablesApplyRequest{
+		Op: structs.VarOpLockRelease,
+		Var: &structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Namespace: tl.namespace,
+				Path:      tl.path,
+				Lock:      &structs.VariableLock{ID: tl.lockID},
+			},
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    tl.region,
+			Namespace: tl.namespace,
+			AuthToken: tl.token,
+		},
+	}
+	tl.lockID = ""
+	tl.fencingToken = 0
+	tl.l.Unlock()
+
+	var reply structs.VariablesApplyResponse
+	if err := tl.rpc(structs.VariablesApplyRPCMethod, &args, &reply); err != nil {
+		tl.logger.Warn("failed to release lock", "error", err)
+	}
+}
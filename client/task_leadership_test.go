@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// fakeTaskLeadershipLock implements the variables lock RPCs used by the task
+// leaderships for a single lock.
+type fakeTaskLeadershipLock struct {
+	holder string
+	index  uint64
+	l      sync.Mutex
+}
+
+func (f *fakeTaskLeadershipLock) RPC(method string, args, reply any) error {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	switch method {
+	case structs.VariablesApplyRPCMethod:
+		args := args.(*structs.VariablesApplyRequest)
+		reply := reply.(*structs.VariablesApplyResponse)
+		switch args.Op {
+		case structs.VarOpLockAcquire:
+			if f.holder != "" {
+				reply.Result = structs.VarOpResultConflict
+				return nil
+			}
+			f.index++
+			f.holder = uuid.Generate()
+			out := args.Var.Copy()
+			out.Lock.ID = f.holder
+			out.Lock.FencingToken = f.index
+			reply.Result = structs.VarOpResultOk
+			reply.Output = &out
+		case structs.VarOpLockRelease:
+			if f.holder != args.Var.Lock.ID {
+				reply.Result = structs.VarOpResultConflict
+				return nil
+			}
+			f.holder = ""
+			reply.Result = structs.VarOpResultOk
+		}
+	case structs.VariablesRenewLockRPCMethod:
+		args := args.(*structs.VariablesRenewLockRequest)
+		if f.holder != args.LockID {
+			return structs.NewErrRPCCoded(http.StatusConflict, "attempting to modify locked variable")
+		}
+	default:
+		return fmt.Errorf("unexpected RPC %q", method)
+	}
+	return nil
+}
+
+func (f *fakeTaskLeadershipLock) setHolder(holder string) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.holder = holder
+}
+
+func (f *fakeTaskLeadershipLock) getHolder() string {
+	f.l.Lock()
+	defer f.l.Unlock()
+	return f.holder
+}
+
+func testTaskLeadership(t *testing.T, lock *fakeTaskLeadershipLock, healthy *atomic.Bool) *taskLeadership {
+	tl := newTaskLeadership(testlog.HCLogger(t), "global", "default",
+		taskLeadershipPath("example", "primary"), "primary", "token")
+	tl.rpc = lock.RPC
+	tl.healthy = healthy.Load
+	tl.renewInterval = 10 * time.Millisecond
+	tl.retryInterval = 10 * time.Millisecond
+	return tl
+}
+
+func waitTaskLeader(t *testing.T, tl *taskLeadership, leader bool) {
+	t.Helper()
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return tl.status().Leader == leader }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
+func TestTaskLeadership_Campaign(t *testing.T) {
+	ci.Parallel(t)
+
+	lock := &fakeTaskLeadershipLock{}
+	var healthy atomic.Bool
+	healthy.Store(true)
+
+	tl := testTaskLeadership(t, lock, &healthy)
+	go tl.run(nil, nil)
+
+	waitTaskLeader(t, tl, true)
+	status := tl.status()
+	must.True(t, status.Campaigning)
+	must.Eq(t, 1, status.FencingToken)
+	must.Eq(t, "nomad/jobs/example/leadership/primary", status.Path)
+
+	// Resigning releases the lock
+	tl.stop()
+	status = tl.status()
+	must.False(t, status.Campaigning)
+	must.False(t, status.Leader)
+	must.Eq(t, "", lock.getHolder())
+}
+
+func TestTaskLeadership_Health(t *testing.T) {
+	ci.Parallel(t)
+
+	lock := &fakeTaskLeadershipLock{}
+	var healthy atomic.Bool
+
+	tl := testTaskLeadership(t, lock, &healthy)
+	go tl.run(nil, nil)
+	defer tl.stop()
+
+	// An unhealthy allocation does not acquire the lock
+	time.Sleep(50 * time.Millisecond)
+	must.False(t, tl.status().Leader)
+	must.Eq(t, "", lock.getHolder())
+
+	healthy.Store(true)
+	waitTaskLeader(t, tl, true)
+
+	// The lock is released once the allocation becomes unhealthy, and
+	// acquired again with a new fencing token once it recovers
+	healthy.Store(false)
+	waitTaskLeader(t, tl, false)
+	must.Eq(t, "", lock.getHolder())
+
+	healthy.Store(true)
+	waitTaskLeader(t, tl, true)
+	must.Eq(t, 2, tl.status().FencingToken)
+}
+
+func TestTaskLeadership_Conflict(t *testing.T) {
+	ci.Parallel(t)
+
+	lock := &fakeTaskLeadershipLock{holder: "other"}
+	var healthy atomic.Bool
+	healthy.Store(true)
+
+	tl := testTaskLeadership(t, lock, &healthy)
+	go tl.run(nil, nil)
+	defer tl.stop()
+
+	// The lock is acquired once released by its holder
+	time.Sleep(50 * time.Millisecond)
+	must.False(t, tl.status().Leader)
+
+	lock.setHolder("")
+	waitTaskLeader(t, tl, true)
+
+	// The lock is lost when taken over by another holder
+	lock.setHolder("other")
+	waitTaskLeader(t, tl, false)
+	must.Eq(t, "other", lock.getHolder())
+}
+
+func TestTaskLeadership_AllocStopped(t *testing.T) {
+	ci.Parallel(t)
+
+	lock := &fakeTaskLeadershipLock{}
+	var healthy atomic.Bool
+	healthy.Store(true)
+
+	tl := testTaskLeadership(t, lock, &healthy)
+	allocCh := make(chan struct{})
+	go tl.run(allocCh, nil)
+
+	waitTaskLeader(t, tl, true)
+
+	// The lock is released once the allocation stops
+	close(allocCh)
+	<-tl.doneCh
+	must.False(t, tl.status().Campaigning)
+	must.Eq(t, "", lock.getHolder())
+}
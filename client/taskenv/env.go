@@ -1071,6 +1071,29 @@ func (b *Builder) SetTemplateEnv(m map[string]string) *Builder {
 	return b
 }
 
+// SensitiveKeys returns the names of the environment variables that may hold
+// secrets: the ones set from templates and the Vault and workload identity
+// tokens.
+func (b *Builder) SensitiveKeys() map[string]struct{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	keys := make(map[string]struct{}, len(b.templateEnv)+len(b.workloadTokens)+2)
+	for k := range b.templateEnv {
+		keys[k] = struct{}{}
+	}
+	if b.injectVaultToken && b.vaultToken != "" {
+		keys[VaultToken] = struct{}{}
+	}
+	if b.workloadTokenDefault != "" {
+		keys[WorkloadToken] = struct{}{}
+	}
+	for name := range b.workloadTokens {
+		keys[WorkloadToken+"_"+name] = struct{}{}
+	}
+	return keys
+}
+
 func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
 	b.mu.Lock()
 	b.vaultToken = token
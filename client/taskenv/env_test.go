@@ -549,6 +549,31 @@ func TestEnvironment_VaultToken(t *testing.T) {
 	}
 }
 
+func TestEnvironment_SensitiveKeys(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	env := NewBuilder(n, a, a.Job.TaskGroups[0].Tasks[0], "global")
+
+	// The Vault token is only sensitive if it is injected
+	env.SetVaultToken("123", "", false)
+	require.Empty(t, env.SensitiveKeys())
+
+	env.SetVaultToken("123", "", true)
+	env.SetDefaultWorkloadToken("abc")
+	env.SetWorkloadToken("consul", "def")
+	env.SetTemplateEnv(map[string]string{"API_KEY": "s3cr3t"})
+
+	exp := map[string]struct{}{
+		VaultToken:                {},
+		WorkloadToken:             {},
+		WorkloadToken + "_consul": {},
+		"API_KEY":                 {},
+	}
+	require.Equal(t, exp, env.SensitiveKeys())
+}
+
 func TestEnvironment_Envvars(t *testing.T) {
 	ci.Parallel(t)
 
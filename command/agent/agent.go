@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -72,6 +73,10 @@ type Agent struct {
 	config     *Config
 	configLock sync.Mutex
 
+	// reloadStatus is the outcome of the last configuration reload. It is
+	// guarded by configLock.
+	reloadStatus *ReloadStatus
+
 	logger     log.InterceptLogger
 	auditor    event.Auditor
 	httpLogger log.Logger
@@ -250,6 +255,14 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.RaftConfig.SnapshotThreshold = uint64(*vPtr)
 	}
 
+	if vPtr := agentConfig.Server.RaftSnapshotPersistRate; vPtr != nil {
+		rate, err := humanize.ParseBytes(*vPtr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse raft_snapshot_persist_rate: %w", err)
+		}
+		conf.RaftSnapshotPersistRate = rate
+	}
+
 	conf.RaftConfig.ElectionTimeout *= time.Duration(raftMultiplier)
 	conf.RaftConfig.HeartbeatTimeout *= time.Duration(raftMultiplier)
 	conf.RaftConfig.LeaderLeaseTimeout *= time.Duration(raftMultiplier)
@@ -258,6 +271,19 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if agentConfig.Server.NumSchedulers != nil {
 		conf.NumSchedulers = *agentConfig.Server.NumSchedulers
 	}
+	if agentConfig.Server.PlanEvaluatePoolMin != nil {
+		conf.PlanEvaluatePoolMin = *agentConfig.Server.PlanEvaluatePoolMin
+	}
+	if agentConfig.Server.PlanEvaluatePoolMax != nil {
+		conf.PlanEvaluatePoolMax = *agentConfig.Server.PlanEvaluatePoolMax
+	}
+	if concurrency := agentConfig.Server.JobRegisterConcurrency; concurrency != nil {
+		if *concurrency < 0 {
+			return nil, fmt.Errorf("job_register_concurrency must not be negative, got %d", *concurrency)
+		}
+		conf.JobRegisterConcurrency = *concurrency
+	}
+	conf.DisableParallelPlanApply = agentConfig.Server.DisableParallelPlanApply
 	if len(agentConfig.Server.EnabledSchedulers) != 0 {
 		// Convert to a set and require the core scheduler
 		set := make(map[string]struct{}, 4)
@@ -292,6 +318,13 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if agentConfig.Server.NonVotingServer {
 		conf.NonVoter = true
 	}
+	if agentConfig.Server.ReadReplica {
+		if agentConfig.Server.BootstrapExpect == 1 {
+			return nil, fmt.Errorf("read_replica cannot be set on a server bootstrapping a single server cluster")
+		}
+		conf.ReadReplica = true
+	}
+	conf.IdentityMetaClaims = slices.Clone(agentConfig.Server.IdentityMetaClaims)
 	if agentConfig.Server.RedundancyZone != "" {
 		conf.RedundancyZone = agentConfig.Server.RedundancyZone
 	}
@@ -506,6 +539,12 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if failoverTTL := agentConfig.Server.FailoverHeartbeatTTL; failoverTTL != 0 {
 		conf.FailoverHeartbeatTTL = failoverTTL
 	}
+	if aging := agentConfig.Server.PlanQueueAgingInterval; aging != 0 {
+		if aging < 0 {
+			return nil, fmt.Errorf("plan_queue_aging_interval must not be negative: %v", aging)
+		}
+		conf.PlanQueueAgingInterval = aging
+	}
 
 	// Add the Consul and Vault configs
 	conf.ConsulConfigs = helper.SliceToMap[map[string]*config.ConsulConfig](
@@ -556,6 +595,20 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.RPCMaxConnsPerClient = limit
 	}
 
+	// Set blocking query limits; nil/0 == unlimited
+	if limit := agentConfig.Limits.BlockingQueriesPerToken; limit != nil {
+		if *limit < 0 {
+			return nil, fmt.Errorf("blocking_queries_per_token must be >= 0; found: %d", *limit)
+		}
+		conf.BlockingQueriesPerToken = *limit
+	}
+	if limit := agentConfig.Limits.BlockingQueriesPerIP; limit != nil {
+		if *limit < 0 {
+			return nil, fmt.Errorf("blocking_queries_per_ip must be >= 0; found: %d", *limit)
+		}
+		conf.BlockingQueriesPerIP = *limit
+	}
+
 	// Set deployment rate limit
 	if rate := agentConfig.Server.DeploymentQueryRateLimit; rate == 0 {
 		conf.DeploymentQueryRateLimit = deploymentwatcher.LimitStateQueriesPerSecond
@@ -565,6 +618,32 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		return nil, fmt.Errorf("deploy_query_rate_limit must be greater than 0")
 	}
 
+	// Set deployment webhooks
+	for _, webhook := range agentConfig.Server.DeploymentWebhooks {
+		if err := webhook.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	conf.DeploymentWebhooks = config.CopyDeploymentWebhooks(agentConfig.Server.DeploymentWebhooks)
+
+	// Set the deployment metric provider
+	if err := agentConfig.Server.DeploymentMetrics.Validate(); err != nil {
+		return nil, err
+	}
+	conf.DeploymentMetrics = agentConfig.Server.DeploymentMetrics.Copy()
+
+	// Set the periodic snapshot upload
+	if err := agentConfig.Server.SnapshotUpload.Validate(); err != nil {
+		return nil, err
+	}
+	conf.SnapshotUpload = agentConfig.Server.SnapshotUpload.Copy()
+
+	// Set the job admission webhook
+	if err := agentConfig.Server.JobAdmissionWebhook.Validate(); err != nil {
+		return nil, err
+	}
+	conf.JobAdmissionWebhook = agentConfig.Server.JobAdmissionWebhook.Copy()
+
 	// Set plan rejection tracker configuration.
 	if planRejectConf := agentConfig.Server.PlanRejectionTracker; planRejectConf != nil {
 		if planRejectConf.Enabled != nil {
@@ -716,6 +795,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 
 	conf.Servers = agentConfig.Client.Servers
 	conf.DevMode = agentConfig.DevMode
+	conf.PluginConfigs = agentConfig.Plugins
 	conf.EnableDebug = agentConfig.EnableDebug
 
 	if agentConfig.Region != "" {
@@ -782,6 +862,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.MaxDynamicPort = agentConfig.Client.MaxDynamicPort
 	conf.MinDynamicPort = agentConfig.Client.MinDynamicPort
 	conf.DisableRemoteExec = agentConfig.Client.DisableRemoteExec
+	conf.RecordRemoteExec = agentConfig.Client.RecordRemoteExec
 
 	if agentConfig.Client.TemplateConfig != nil {
 		conf.TemplateConfig = conf.TemplateConfig.Merge(agentConfig.Client.TemplateConfig)
@@ -886,6 +967,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.GCDiskUsageThreshold = agentConfig.Client.GCDiskUsageThreshold
 	conf.GCInodeUsageThreshold = agentConfig.Client.GCInodeUsageThreshold
 	conf.GCMaxAllocs = agentConfig.Client.GCMaxAllocs
+	conf.NetworkGCInterval = agentConfig.Client.NetworkGCInterval
+	conf.AllocDirDiskBudgetMB = agentConfig.Client.AllocDirDiskBudgetMB
+	conf.AllocDirDiskHighWatermark = agentConfig.Client.AllocDirDiskHighWatermark
+	conf.AllocDirDiskLowWatermark = agentConfig.Client.AllocDirDiskLowWatermark
+	conf.MaxConcurrentAllocStarts = agentConfig.Client.MaxConcurrentAllocStarts
+	conf.AllocStartInterval = agentConfig.Client.AllocStartInterval
 	if agentConfig.Client.NoHostUUID != nil {
 		conf.NoHostUUID = *agentConfig.Client.NoHostUUID
 	} else {
@@ -1424,6 +1511,7 @@ func (a *Agent) Reload(newConfig *Config) error {
 		if err := a.entReloadEventer(newConfig.Audit); err != nil {
 			return err
 		}
+		current.Audit = newConfig.Audit.Copy()
 	}
 	// Allow auditor to call reopen regardless of config changes
 	// This is primarily for enterprise audit logging to allow the underlying
@@ -82,23 +82,44 @@ func (s *HTTPServer) AgentSelfRequest(resp http.ResponseWriter, req *http.Reques
 		Stats:  s.agent.Stats(),
 	}
 
-	self.Config = s.agent.GetConfig().Copy()
+	self.Config = s.agent.GetConfig().Redacted()
 
-	if self.Config != nil && self.Config.ACL != nil && self.Config.ACL.ReplicationToken != "" {
-		self.Config.ACL.ReplicationToken = "<redacted>"
-	}
+	return self, nil
+}
 
-	for _, consulConfig := range self.Config.Consuls {
-		if consulConfig.Token != "" {
-			consulConfig.Token = "<redacted>"
-		}
+// agentEffectiveConfig is the response of the effective configuration
+// endpoint.
+type agentEffectiveConfig struct {
+	// Config is the merged configuration of the agent, with secrets
+	// redacted.
+	Config *Config
+
+	// Sources maps the dotted HCL path of the values that are not left to
+	// their defaults to the configuration source that set them.
+	Sources map[string]string
+}
+
+// AgentEffectiveConfigRequest returns the configuration of the agent after all
+// configuration files, command line flags and environment variables have been
+// merged.
+func (s *HTTPServer) AgentEffectiveConfigRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
 	}
 
-	if self.Config != nil && self.Config.Telemetry != nil && self.Config.Telemetry.CirconusAPIToken != "" {
-		self.Config.Telemetry.CirconusAPIToken = "<redacted>"
+	aclObj, err := s.ResolveToken(req)
+	if err != nil {
+		return nil, err
+	}
+	if !aclObj.AllowAgentRead() {
+		return nil, structs.ErrPermissionDenied
 	}
 
-	return self, nil
+	config := s.agent.GetConfig().Redacted()
+	return agentEffectiveConfig{
+		Config:  config,
+		Sources: config.Sources,
+	}, nil
 }
 
 func (s *HTTPServer) AgentJoinRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -305,6 +326,28 @@ func (s *HTTPServer) AgentMonitor(resp http.ResponseWriter, req *http.Request) (
 	return nil, codedErr
 }
 
+// AgentReloadRequest returns the outcome of the last configuration reload of
+// the agent, including which configuration sections require a restart.
+func (s *HTTPServer) AgentReloadRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	aclObj, err := s.ResolveToken(req)
+	if err != nil {
+		return nil, err
+	}
+	if !aclObj.AllowAgentRead() {
+		return nil, structs.ErrPermissionDenied
+	}
+
+	status := s.agent.ReloadStatus()
+	if status == nil {
+		return nil, CodedError(404, "agent configuration was never reloaded")
+	}
+	return status, nil
+}
+
 func (s *HTTPServer) AgentForceLeaveRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -356,6 +399,8 @@ func (s *HTTPServer) AgentPprofRequest(resp http.ResponseWriter, req *http.Reque
 		return s.agentPprof(pprof.CPUReq, resp, req)
 	case "trace":
 		return s.agentPprof(pprof.TraceReq, resp, req)
+	case "scheduler-trace":
+		return s.agentPprof(pprof.SchedulerTraceReq, resp, req)
 	default:
 		// Add profile to request
 		values := req.URL.Query()
@@ -88,6 +88,59 @@ func TestHTTP_AgentSelf(t *testing.T) {
 	})
 }
 
+func TestHTTP_AgentReload(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/agent/reload", nil)
+		must.NoError(t, err)
+
+		// No reload happened yet
+		_, err = s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "never reloaded")
+
+		s.Agent.recordReload(nil, []string{"log_level"}, []string{"plugin.docker"}, nil)
+
+		obj, err := s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		status := obj.(*ReloadStatus)
+		must.Eq(t, []string{"log_level"}, status.Applied)
+		must.Eq(t, []string{"plugin.docker"}, status.RestartRequired)
+		must.Eq(t, "", status.Error)
+		must.False(t, status.ReloadTime.IsZero())
+	})
+}
+
+func TestHTTP_AgentEffectiveConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		s.Config.Sources = map[string]string{"region": "command line"}
+		s.Config.ACL.ReplicationToken = "badc0deb-adc0-deba-dc0d-ebadc0debadc"
+		s.Config.Consuls[0].Token = "badc0deb-adc0-deba-dc0d-ebadc0debadc"
+		s.Config.Consuls[0].Auth = "user:password"
+		s.Config.Vaults[0].Token = "badc0deb-adc0-deba-dc0d-ebadc0debadc"
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/agent/config-effective", nil)
+		must.NoError(t, err)
+
+		obj, err := s.Server.AgentEffectiveConfigRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		out := obj.(agentEffectiveConfig)
+		must.Eq(t, map[string]string{"region": "command line"}, out.Sources)
+		must.Eq(t, s.Config.Region, out.Config.Region)
+		must.Eq(t, "<redacted>", out.Config.ACL.ReplicationToken)
+		must.Eq(t, "<redacted>", out.Config.Consuls[0].Token)
+		must.Eq(t, "<redacted>", out.Config.Consuls[0].Auth)
+		must.Eq(t, "<redacted>", out.Config.Vaults[0].Token)
+
+		// The running configuration must not be modified
+		must.Eq(t, "user:password", s.Config.Consuls[0].Auth)
+	})
+}
+
 func TestHTTP_AgentSelf_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
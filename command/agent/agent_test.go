@@ -146,6 +146,31 @@ func TestAgent_ServerConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 337*time.Second, out.FailoverHeartbeatTTL)
 
+	conf.Server.JobRegisterConcurrency = pointer.Of(16)
+	out, err = a.serverConfig()
+	require.NoError(t, err)
+	require.Equal(t, 16, out.JobRegisterConcurrency)
+
+	conf.Server.JobRegisterConcurrency = pointer.Of(-1)
+	_, err = a.serverConfig()
+	require.ErrorContains(t, err, "job_register_concurrency")
+	conf.Server.JobRegisterConcurrency = nil
+
+	conf.Server.DisableParallelPlanApply = true
+	out, err = a.serverConfig()
+	require.NoError(t, err)
+	require.True(t, out.DisableParallelPlanApply)
+
+	conf.Server.PlanQueueAgingInterval = 45 * time.Second
+	out, err = a.serverConfig()
+	require.NoError(t, err)
+	require.Equal(t, 45*time.Second, out.PlanQueueAgingInterval)
+
+	conf.Server.PlanQueueAgingInterval = -time.Second
+	_, err = a.serverConfig()
+	require.ErrorContains(t, err, "plan_queue_aging_interval")
+	conf.Server.PlanQueueAgingInterval = 0
+
 	// Defaults to the global bind addr
 	conf.Addresses.RPC = ""
 	conf.Addresses.Serf = ""
@@ -298,6 +323,15 @@ func TestAgent_ServerConfig_Limits_Error(t *testing.T) {
 				RPCMaxConnsPerClient: pointer.Of(config.LimitsNonStreamingConnsPerClient),
 			},
 		},
+		{
+			name:        "Negative Blocking Query Limit",
+			expectedErr: "blocking_queries_per_token must be >= 0; found: -1",
+			limits: config.Limits{
+				RPCHandshakeTimeout:     "5s",
+				RPCMaxConnsPerClient:    pointer.Of(100),
+				BlockingQueriesPerToken: pointer.Of(-1),
+			},
+		},
 	}
 
 	for i := range cases {
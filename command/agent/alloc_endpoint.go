@@ -14,10 +14,12 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/snappy"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/api"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -58,6 +60,11 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 		}
 	}
 
+	projection, err := parseFieldsProjection[*structs.AllocListStub](req)
+	if err != nil {
+		return nil, err
+	}
+
 	var out structs.AllocListResponse
 	if err := s.agent.RPC("Alloc.List", &args, &out); err != nil {
 		return nil, err
@@ -70,7 +77,44 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 	for _, alloc := range out.Allocations {
 		alloc.SetEventDisplayMessages()
 	}
-	return out.Allocations, nil
+	return projectFields(out.Allocations, projection), nil
+}
+
+// AllocsBatchRequest stops, signals, or restarts all the allocations matching
+// a filter. It is callable via the /v1/allocations/batch HTTP API.
+func (s *HTTPServer) AllocsBatchRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if !(req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.AllocBatchRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	batchReq := structs.AllocBatchRequest{
+		Action:          args.Action,
+		JobID:           args.JobID,
+		TaskGroup:       args.TaskGroup,
+		NodeID:          args.NodeID,
+		Task:            args.Task,
+		Signal:          args.Signal,
+		AllTasks:        args.AllTasks,
+		NoShutdownDelay: args.NoShutdownDelay,
+		Parallelism:     args.Parallelism,
+	}
+	s.parseWriteRequest(req, &batchReq.WriteRequest)
+
+	var out structs.AllocBatchResponse
+	if err := s.agent.RPC("Alloc.Batch", &batchReq, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	if out.Results == nil {
+		out.Results = make([]*structs.AllocBatchResult, 0)
+	}
+	return &out, nil
 }
 
 func (s *HTTPServer) AllocSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -93,6 +137,8 @@ func (s *HTTPServer) AllocSpecificRequest(resp http.ResponseWriter, req *http.Re
 		return s.allocChecks(allocID, resp, req)
 	case "stop":
 		return s.allocStop(allocID, resp, req)
+	case "result":
+		return s.allocRegisterResult(allocID, resp, req)
 	case "services":
 		return s.allocServiceRegistrations(resp, req, allocID)
 	}
@@ -176,6 +222,38 @@ func (s *HTTPServer) allocStop(allocID string, resp http.ResponseWriter, req *ht
 	return &out, nil
 }
 
+// allocRegisterResult registers the result of a task of the allocation. It is
+// callable via the /v1/allocation/:alloc_id/result HTTP API, and the task
+// defaults to the task of the workload identity making the request.
+func (s *HTTPServer) allocRegisterResult(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if !(req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var result structs.TaskResult
+	if err := decodeBody(req, &result); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	args := structs.AllocRegisterResultRequest{
+		AllocID:  allocID,
+		TaskName: req.URL.Query().Get("task"),
+		Result:   &result,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Alloc.RegisterResult", &args, &out); err != nil {
+		if structs.IsErrUnknownAllocation(err) {
+			err = CodedError(404, allocNotFoundErr)
+		}
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
 // allocServiceRegistrations returns a list of all service registrations
 // assigned to the job identifier. It is callable via the
 // /v1/allocation/:alloc_id/services HTTP API and uses the
@@ -224,6 +302,8 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 		return s.allocChecks(allocID, resp, req)
 	case "stats":
 		return s.allocStats(allocID, resp, req)
+	case "env":
+		return s.allocTaskEnv(allocID, resp, req)
 	case "exec":
 		return s.allocExec(allocID, resp, req)
 	case "snapshot":
@@ -514,6 +594,14 @@ func (s *HTTPServer) allocSnapshot(allocID string, resp http.ResponseWriter, req
 
 func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
+	stream, err := parseBool(req, "stream")
+	if err != nil {
+		return nil, err
+	}
+	if stream != nil && *stream {
+		return s.allocStatsStream(allocID, resp, req)
+	}
+
 	// Build the request and parse the ACL token
 	task := req.URL.Query().Get("task")
 	args := cstructs.AllocStatsRequest{
@@ -547,6 +635,29 @@ func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *h
 	return reply.Stats, rpcErr
 }
 
+// allocStatsStream streams the resource usage of the allocation as
+// newline-delimited JSON at the interval given by the interval query
+// parameter.
+func (s *HTTPServer) allocStatsStream(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
+	query := req.URL.Query()
+	args := cstructs.AllocStatsStreamRequest{
+		AllocID: allocID,
+		Task:    query.Get("task"),
+	}
+	if raw := query.Get("interval"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Unable to parse interval: %v", err))
+		}
+		args.Interval = interval
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Cache-Control", "no-cache")
+	return s.fsStreamImpl(resp, req, "Allocations.StatsStream", &args, allocID)
+}
+
 func (s *HTTPServer) allocChecks(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
 	// Build the request and parse the ACL token
 	args := cstructs.AllocChecksRequest{
@@ -580,17 +691,66 @@ func (s *HTTPServer) allocChecks(allocID string, resp http.ResponseWriter, req *
 	return reply.Results, rpcErr
 }
 
+func (s *HTTPServer) allocTaskEnv(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	// Build the request and parse the ACL token
+	args := cstructs.AllocTaskEnvRequest{
+		AllocID: allocID,
+		Task:    req.URL.Query().Get("task"),
+	}
+	if args.Task == "" {
+		return nil, CodedError(400, "task must be set")
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+
+	// Make the RPC
+	var reply cstructs.AllocTaskEnvResponse
+	var rpcErr error
+	switch {
+	case useLocalClient:
+		rpcErr = s.agent.Client().ClientRPC("Allocations.TaskEnv", &args, &reply)
+	case useClientRPC:
+		rpcErr = s.agent.Client().RPC("ClientAllocations.TaskEnv", &args, &reply)
+	case useServerRPC:
+		rpcErr = s.agent.Server().RPC("ClientAllocations.TaskEnv", &args, &reply)
+	default:
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+		return nil, rpcErr
+	}
+
+	return reply.Environment, nil
+}
+
 func (s *HTTPServer) allocExec(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	// Build the request and parse the ACL token
 	task := req.URL.Query().Get("task")
+	attach := req.URL.Query().Get("attach")
+
 	cmdJsonStr := req.URL.Query().Get("command")
+
+	// Viewers attaching to a shared session don't run a command
 	var command []string
-	err := json.Unmarshal([]byte(cmdJsonStr), &command)
-	if err != nil {
-		// this shouldn't happen, []string is always be serializable to json
-		return nil, fmt.Errorf("failed to marshal command into json: %v", err)
+	if attach == "" || cmdJsonStr != "" {
+		err := json.Unmarshal([]byte(cmdJsonStr), &command)
+		if err != nil {
+			// this shouldn't happen, []string is always be serializable to json
+			return nil, fmt.Errorf("failed to marshal command into json: %v", err)
+		}
 	}
 
+	var err error
 	ttyB := false
 	if tty := req.URL.Query().Get("tty"); tty != "" {
 		ttyB, err = strconv.ParseBool(tty)
@@ -599,11 +759,22 @@ func (s *HTTPServer) allocExec(allocID string, resp http.ResponseWriter, req *ht
 		}
 	}
 
+	record := false
+	if r := req.URL.Query().Get("record"); r != "" {
+		record, err = strconv.ParseBool(r)
+		if err != nil {
+			return nil, fmt.Errorf("record value is not a boolean: %v", err)
+		}
+	}
+
 	args := cstructs.AllocExecRequest{
 		AllocID: allocID,
 		Task:    task,
 		Cmd:     command,
 		Tty:     ttyB,
+		Record:  record,
+		Share:   req.URL.Query().Get("share"),
+		Attach:  attach,
 	}
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
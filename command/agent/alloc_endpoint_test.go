@@ -6,6 +6,7 @@ package agent
 import (
 	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -443,6 +445,91 @@ func TestHTTP_AllocStop(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocsBatch(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		alloc := mock.Alloc()
+		must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+		// Stop all the allocations of the job
+		args := api.AllocBatchRequest{
+			Action: api.AllocBatchActionStop,
+			JobID:  alloc.JobID,
+		}
+		req, err := http.NewRequest(http.MethodPut, "/v1/allocations/batch", encodeReq(args))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.AllocsBatchRequest(respW, req)
+		must.NoError(t, err)
+
+		out := obj.(*structs.AllocBatchResponse)
+		must.Len(t, 1, out.Results)
+		must.Eq(t, alloc.ID, out.Results[0].AllocID)
+		must.Len(t, 1, out.EvalIDs)
+		must.Positive(t, out.Index)
+		must.Eq(t, strconv.FormatUint(out.Index, 10), respW.Header().Get("X-Nomad-Index"))
+
+		// Invalid requests are rejected
+		args = api.AllocBatchRequest{Action: "bogus", JobID: alloc.JobID}
+		req, err = http.NewRequest(http.MethodPut, "/v1/allocations/batch", encodeReq(args))
+		must.NoError(t, err)
+		_, err = s.Server.AllocsBatchRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "invalid action")
+
+		// Only PUT and POST are allowed
+		req, err = http.NewRequest(http.MethodGet, "/v1/allocations/batch", nil)
+		must.NoError(t, err)
+		_, err = s.Server.AllocsBatchRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
+func TestHTTP_AllocRegisterResult(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		alloc := mock.Alloc()
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+		// Register a result for the task
+		result := &structs.TaskResult{Summary: "done", OutputURI: "s3://bucket/output"}
+		req, err := http.NewRequest(http.MethodPut,
+			"/v1/allocation/"+alloc.ID+"/result?task=web", encodeReq(result))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.AllocSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		// The result is listed for the job
+		req, err = http.NewRequest(http.MethodGet, "/v1/job/"+alloc.JobID+"/results", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		results := obj.([]*structs.AllocTaskResult)
+		must.Len(t, 1, results)
+		must.Eq(t, alloc.ID, results[0].AllocID)
+		must.Eq(t, "web", results[0].TaskName)
+		must.Eq(t, "done", results[0].Result.Summary)
+		must.Eq(t, "s3://bucket/output", results[0].Result.OutputURI)
+
+		// Test that we 404 when the allocid is invalid
+		req, err = http.NewRequest(http.MethodPut,
+			"/v1/allocation/"+uuid.Generate()+"/result?task=web", encodeReq(result))
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.AllocSpecificRequest(respW, req)
+		must.ErrorContains(t, err, allocNotFoundErr)
+	})
+}
+
 func TestHTTP_allocServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 
@@ -556,6 +643,44 @@ func TestHTTP_allocServiceRegistrations(t *testing.T) {
 	}
 }
 
+func TestHTTP_AllocTaskEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		path := fmt.Sprintf("/v1/client/allocation/%s/env", uuid.Generate())
+
+		// The task is required
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		must.NoError(t, err)
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "task must be set")
+
+		// Only GET is allowed
+		req, err = http.NewRequest(http.MethodPut, path+"?task=web", nil)
+		must.NoError(t, err)
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+
+		// Local node, local resp
+		req, err = http.NewRequest(http.MethodGet, path+"?task=web", nil)
+		must.NoError(t, err)
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.True(t, structs.IsErrUnknownAllocation(err))
+		var codedErr HTTPCodedError
+		must.True(t, errors.As(err, &codedErr))
+		must.Eq(t, http.StatusNotFound, codedErr.Code())
+
+		// Local node, server resp
+		srv := s.server
+		s.server = nil
+		req, err = http.NewRequest(http.MethodGet, path+"?task=web", nil)
+		must.NoError(t, err)
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.True(t, structs.IsErrUnknownAllocation(err))
+		s.server = srv
+	})
+}
+
 func TestHTTP_AllocStats(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -620,6 +745,40 @@ func TestHTTP_AllocStats(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocStatsStream(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// Invalid interval
+		req, err := http.NewRequest(http.MethodGet,
+			fmt.Sprintf("/v1/client/allocation/%s/stats?stream=true&interval=soon", uuid.Generate()), nil)
+		must.NoError(t, err)
+
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Unable to parse interval")
+		must.Eq(t, http.StatusBadRequest, err.(HTTPCodedError).Code())
+
+		// Unknown allocation on the local node
+		req, err = http.NewRequest(http.MethodGet,
+			fmt.Sprintf("/v1/client/allocation/%s/stats?stream=true&interval=1s", uuid.Generate()), nil)
+		must.NoError(t, err)
+
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Unknown allocation")
+		must.Eq(t, http.StatusNotFound, err.(HTTPCodedError).Code())
+
+		// Unknown allocation through the server
+		c := s.client
+		s.client = nil
+
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Unknown allocation")
+		must.Eq(t, http.StatusNotFound, err.(HTTPCodedError).Code())
+
+		s.client = c
+	})
+}
+
 func TestHTTP_AllocStats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -0,0 +1,404 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	gsyslog "github.com/hashicorp/go-syslog"
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/ryanuber/go-glob"
+)
+
+const (
+	// auditEventType is the event type of all audit log entries.
+	auditEventType = "audit"
+
+	auditStageReceived = "OperationReceived"
+	auditStageComplete = "OperationComplete"
+
+	auditFilterTypeHTTP = "HTTPEvent"
+
+	auditSinkTypeFile   = "file"
+	auditSinkTypeSyslog = "syslog"
+
+	auditSinkFormatJSON = "json"
+
+	auditDeliveryEnforced   = "enforced"
+	auditDeliveryBestEffort = "best-effort"
+
+	// auditDefaultRotateDuration is the rotation period of file sinks that
+	// don't set rotate_duration.
+	auditDefaultRotateDuration = 24 * time.Hour
+)
+
+// auditLogEntry is the envelope of each line written to an audit sink.
+type auditLogEntry struct {
+	CreatedAt time.Time   `json:"created_at"`
+	EventType string      `json:"event_type"`
+	Payload   *auditEvent `json:"payload"`
+}
+
+// auditEvent records a single stage of an audited HTTP request.
+type auditEvent struct {
+	ID        string         `json:"id"`
+	Stage     string         `json:"stage"`
+	Type      string         `json:"type"`
+	Timestamp time.Time      `json:"timestamp"`
+	Version   int            `json:"version"`
+	Auth      *auditAuth     `json:"auth,omitempty"`
+	Request   *auditRequest  `json:"request"`
+	Response  *auditResponse `json:"response,omitempty"`
+}
+
+// auditAuth is the actor that made an audited request.
+type auditAuth struct {
+	AccessorID   string    `json:"accessor_id,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Policies     []string  `json:"policies,omitempty"`
+	Global       bool      `json:"global,omitempty"`
+	CreateTime   time.Time `json:"create_time"`
+	AllocationID string    `json:"allocation_id,omitempty"`
+	Namespace    string    `json:"namespace,omitempty"`
+	JobID        string    `json:"job_id,omitempty"`
+	TaskName     string    `json:"task,omitempty"`
+}
+
+type auditRequest struct {
+	ID          string             `json:"id"`
+	Operation   string             `json:"operation"`
+	Endpoint    string             `json:"endpoint"`
+	Namespace   *auditNamespace    `json:"namespace"`
+	RequestMeta *auditRequestMeta  `json:"request_meta"`
+	NodeMeta    *auditNodeMeta     `json:"node_meta"`
+	Payload     *auditPayloadStats `json:"payload,omitempty"`
+}
+
+type auditNamespace struct {
+	ID string `json:"id"`
+}
+
+type auditRequestMeta struct {
+	RemoteAddress string `json:"remote_address"`
+	UserAgent     string `json:"user_agent"`
+}
+
+type auditNodeMeta struct {
+	IP string `json:"ip"`
+}
+
+// auditPayloadStats summarizes the request body. The body itself is never
+// recorded since it may hold secrets, such as variable items.
+type auditPayloadStats struct {
+	ContentType   string `json:"content_type,omitempty"`
+	ContentLength int64  `json:"content_length"`
+}
+
+type auditResponse struct {
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+}
+
+// auditSink is a configured destination for audit log entries.
+type auditSink struct {
+	name     string
+	enforced bool
+	w        io.WriteCloser
+}
+
+// auditor writes audit log entries for HTTP requests to its sinks. Its
+// configuration can be reloaded at runtime, which is how audit logging is
+// toggled without restarting the agent.
+type auditor struct {
+	logger  hclog.Logger
+	dataDir string
+
+	l       sync.RWMutex
+	enabled bool
+	sinks   []*auditSink
+	filters []*config.AuditFilter
+}
+
+// Ensure auditor is an Auditor
+var _ event.Auditor = &auditor{}
+
+// newAuditor returns an auditor configured from cfg. Default file sinks are
+// created in the audit directory of dataDir.
+func newAuditor(cfg *config.AuditConfig, dataDir string, logger hclog.Logger) (*auditor, error) {
+	a := &auditor{
+		logger:  logger.Named("audit"),
+		dataDir: dataDir,
+	}
+	if err := a.reload(cfg); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// reload replaces the sinks and filters of the auditor. The previous sinks
+// are only closed once the new configuration has been applied successfully.
+func (a *auditor) reload(cfg *config.AuditConfig) error {
+	enabled := cfg != nil && cfg.Enabled != nil && *cfg.Enabled
+
+	var sinks []*auditSink
+	var filters []*config.AuditFilter
+	if enabled {
+		var err error
+		if sinks, err = a.newSinks(cfg.Sinks); err != nil {
+			return err
+		}
+		if filters, err = validateAuditFilters(cfg.Filters); err != nil {
+			closeAuditSinks(sinks)
+			return err
+		}
+	}
+
+	a.l.Lock()
+	old := a.sinks
+	a.enabled = enabled
+	a.sinks = sinks
+	a.filters = filters
+	a.l.Unlock()
+
+	closeAuditSinks(old)
+	return nil
+}
+
+func (a *auditor) newSinks(cfgs []*config.AuditSink) ([]*auditSink, error) {
+	if len(cfgs) == 0 {
+		if a.dataDir == "" {
+			return nil, errors.New("audit sink path must be set when data_dir is not configured")
+		}
+		cfgs = []*config.AuditSink{{
+			Name:              "audit",
+			Type:              auditSinkTypeFile,
+			DeliveryGuarantee: auditDeliveryEnforced,
+			Format:            auditSinkFormatJSON,
+			Path:              filepath.Join(a.dataDir, "audit", "audit.log"),
+		}}
+	}
+
+	sinks := make([]*auditSink, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		sink, err := a.newSink(cfg)
+		if err != nil {
+			closeAuditSinks(sinks)
+			return nil, fmt.Errorf("invalid audit sink %q: %w", cfg.Name, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+func (a *auditor) newSink(cfg *config.AuditSink) (*auditSink, error) {
+	sink := &auditSink{name: cfg.Name}
+
+	switch cfg.DeliveryGuarantee {
+	case "", auditDeliveryEnforced:
+		sink.enforced = true
+	case auditDeliveryBestEffort:
+	default:
+		return nil, fmt.Errorf("unknown delivery_guarantee %q", cfg.DeliveryGuarantee)
+	}
+
+	if cfg.Format != "" && cfg.Format != auditSinkFormatJSON {
+		return nil, fmt.Errorf("unsupported format %q", cfg.Format)
+	}
+
+	switch cfg.Type {
+	case "", auditSinkTypeFile:
+		path := cfg.Path
+		if path == "" {
+			if a.dataDir == "" {
+				return nil, errors.New("path must be set when data_dir is not configured")
+			}
+			path = filepath.Join(a.dataDir, "audit", "audit.log")
+		}
+
+		mode := os.FileMode(0600)
+		if cfg.Mode != "" {
+			m, err := strconv.ParseUint(cfg.Mode, 8, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mode %q: %v", cfg.Mode, err)
+			}
+			mode = os.FileMode(m)
+		}
+
+		duration := cfg.RotateDuration
+		if duration == 0 {
+			duration = auditDefaultRotateDuration
+		}
+
+		dir := filepath.Dir(path)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create audit log directory: %v", err)
+		}
+
+		sink.w = &logFile{
+			fileName: filepath.Base(path),
+			logPath:  dir,
+			duration: duration,
+			MaxBytes: cfg.RotateBytes,
+			MaxFiles: cfg.RotateMaxFiles,
+			mode:     mode,
+		}
+
+	case auditSinkTypeSyslog:
+		facility := cfg.Facility
+		if facility == "" {
+			facility = "LOCAL0"
+		}
+		tag := cfg.Tag
+		if tag == "" {
+			tag = "nomad-audit"
+		}
+		w, err := gsyslog.NewLogger(gsyslog.LOG_NOTICE, facility, tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to syslog: %v", err)
+		}
+		sink.w = w
+
+	default:
+		return nil, fmt.Errorf("unknown type %q", cfg.Type)
+	}
+
+	return sink, nil
+}
+
+func validateAuditFilters(cfgs []*config.AuditFilter) ([]*config.AuditFilter, error) {
+	filters := make([]*config.AuditFilter, 0, len(cfgs))
+	for _, f := range cfgs {
+		if f.Type != auditFilterTypeHTTP {
+			return nil, fmt.Errorf("invalid audit filter %q: unknown type %q", f.Name, f.Type)
+		}
+		filters = append(filters, f.Copy())
+	}
+	return filters, nil
+}
+
+func closeAuditSinks(sinks []*auditSink) {
+	for _, sink := range sinks {
+		sink.w.Close()
+	}
+}
+
+// Event writes the event to every sink unless it is excluded by a filter.
+// Errors are only returned for sinks with an enforced delivery guarantee.
+func (a *auditor) Event(_ context.Context, eventType string, payload interface{}) error {
+	a.l.RLock()
+	defer a.l.RUnlock()
+
+	if !a.enabled {
+		return nil
+	}
+
+	ev, ok := payload.(*auditEvent)
+	if !ok {
+		return fmt.Errorf("unexpected audit payload %T", payload)
+	}
+	if a.filtered(ev) {
+		return nil
+	}
+
+	buf, err := json.Marshal(&auditLogEntry{
+		CreatedAt: time.Now(),
+		EventType: eventType,
+		Payload:   ev,
+	})
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+
+	var mErr *multierror.Error
+	for _, sink := range a.sinks {
+		if _, err := sink.w.Write(buf); err != nil {
+			if sink.enforced {
+				mErr = multierror.Append(mErr, fmt.Errorf("audit sink %q: %v", sink.name, err))
+				continue
+			}
+			a.logger.Warn("failed to write audit event", "sink", sink.name, "error", err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// filtered returns true if the event matches any of the configured filters.
+// Query parameters are ignored when matching endpoints.
+func (a *auditor) filtered(ev *auditEvent) bool {
+	endpoint, _, _ := strings.Cut(ev.Request.Endpoint, "?")
+	for _, f := range a.filters {
+		if auditFilterMatch(f.Endpoints, endpoint) &&
+			auditFilterMatch(f.Stages, ev.Stage) &&
+			auditFilterMatch(f.Operations, ev.Request.Operation) {
+			return true
+		}
+	}
+	return false
+}
+
+func auditFilterMatch(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if glob.Glob(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *auditor) Enabled() bool {
+	a.l.RLock()
+	defer a.l.RUnlock()
+	return a.enabled
+}
+
+// Reopen closes the audit log files so they are reopened on the next write.
+func (a *auditor) Reopen() error {
+	a.l.RLock()
+	defer a.l.RUnlock()
+
+	var mErr *multierror.Error
+	for _, sink := range a.sinks {
+		if f, ok := sink.w.(*logFile); ok {
+			if err := f.Close(); err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+func (a *auditor) SetEnabled(enabled bool) {
+	a.l.Lock()
+	defer a.l.Unlock()
+	a.enabled = enabled
+}
+
+func (a *auditor) DeliveryEnforced() bool {
+	a.l.RLock()
+	defer a.l.RUnlock()
+
+	for _, sink := range a.sinks {
+		if sink.enforced {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func testAuditEvent(endpoint, method string) *auditEvent {
+	return &auditEvent{
+		ID:      "e1",
+		Stage:   auditStageReceived,
+		Type:    auditEventType,
+		Version: 1,
+		Request: &auditRequest{
+			ID:        "r1",
+			Operation: method,
+			Endpoint:  endpoint,
+			Namespace: &auditNamespace{ID: "default"},
+		},
+	}
+}
+
+func readAuditLog(t *testing.T, path string) []*auditLogEntry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	var entries []*auditLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry auditLogEntry
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, &entry)
+	}
+	must.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestAuditor_FileSink(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "logs", "audit.log")
+	a, err := newAuditor(&config.AuditConfig{
+		Enabled: pointer.Of(true),
+		Sinks: []*config.AuditSink{{
+			Name:              "file",
+			Type:              auditSinkTypeFile,
+			DeliveryGuarantee: auditDeliveryBestEffort,
+			Format:            auditSinkFormatJSON,
+			Path:              path,
+		}},
+		Filters: []*config.AuditFilter{{
+			Name:       "metrics",
+			Type:       auditFilterTypeHTTP,
+			Endpoints:  []string{"/v1/metrics"},
+			Stages:     []string{"*"},
+			Operations: []string{"*"},
+		}, {
+			Name:       "received gets",
+			Type:       auditFilterTypeHTTP,
+			Endpoints:  []string{"/v1/job/*"},
+			Stages:     []string{auditStageReceived},
+			Operations: []string{"GET"},
+		}},
+	}, "", testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.True(t, a.Enabled())
+	must.False(t, a.DeliveryEnforced())
+
+	ctx := context.Background()
+	ev := testAuditEvent("/v1/job/example?namespace=default", "GET")
+	must.NoError(t, a.Event(ctx, auditEventType, ev))
+	must.NoError(t, a.Event(ctx, auditEventType, ev.complete(&auditResponse{StatusCode: 200})))
+	must.NoError(t, a.Event(ctx, auditEventType, testAuditEvent("/v1/metrics?format=prometheus", "GET")))
+
+	info, err := os.Stat(path)
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries := readAuditLog(t, path)
+	must.Len(t, 1, entries)
+	must.Eq(t, auditEventType, entries[0].EventType)
+	must.Eq(t, auditStageComplete, entries[0].Payload.Stage)
+	must.Eq(t, "/v1/job/example?namespace=default", entries[0].Payload.Request.Endpoint)
+	must.Eq(t, 200, entries[0].Payload.Response.StatusCode)
+}
+
+func TestAuditor_DefaultSink(t *testing.T) {
+	ci.Parallel(t)
+
+	dataDir := t.TempDir()
+	a, err := newAuditor(&config.AuditConfig{Enabled: pointer.Of(true)}, dataDir, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.True(t, a.DeliveryEnforced())
+
+	must.NoError(t, a.Event(context.Background(), auditEventType, testAuditEvent("/v1/jobs", "GET")))
+	must.Len(t, 1, readAuditLog(t, filepath.Join(dataDir, "audit", "audit.log")))
+
+	_, err = newAuditor(&config.AuditConfig{Enabled: pointer.Of(true)}, "", testlog.HCLogger(t))
+	must.ErrorContains(t, err, "data_dir")
+}
+
+func TestAuditor_Reload(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := &config.AuditConfig{
+		Enabled: pointer.Of(false),
+		Sinks: []*config.AuditSink{{
+			Name: "file",
+			Type: auditSinkTypeFile,
+			Path: path,
+		}},
+	}
+
+	a, err := newAuditor(cfg, "", testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.False(t, a.Enabled())
+
+	ctx := context.Background()
+	must.NoError(t, a.Event(ctx, auditEventType, testAuditEvent("/v1/jobs", "GET")))
+	_, err = os.Stat(path)
+	must.True(t, os.IsNotExist(err))
+
+	// Enabling audit logging at runtime writes subsequent events
+	cfg.Enabled = pointer.Of(true)
+	must.NoError(t, a.reload(cfg))
+	must.True(t, a.Enabled())
+	must.NoError(t, a.Event(ctx, auditEventType, testAuditEvent("/v1/jobs", "GET")))
+	must.Len(t, 1, readAuditLog(t, path))
+
+	// Reopening the file after it was moved away creates a new one
+	must.NoError(t, os.Rename(path, path+".old"))
+	must.NoError(t, a.Reopen())
+	must.NoError(t, a.Event(ctx, auditEventType, testAuditEvent("/v1/jobs", "GET")))
+	must.Len(t, 1, readAuditLog(t, path))
+
+	// An invalid configuration leaves the current one in place
+	must.Error(t, a.reload(&config.AuditConfig{
+		Enabled: pointer.Of(true),
+		Sinks:   []*config.AuditSink{{Name: "bad", Type: "kafka"}},
+	}))
+	must.True(t, a.Enabled())
+
+	cfg.Enabled = pointer.Of(false)
+	must.NoError(t, a.reload(cfg))
+	must.False(t, a.Enabled())
+	must.NoError(t, a.Event(ctx, auditEventType, testAuditEvent("/v1/jobs", "GET")))
+	must.Len(t, 1, readAuditLog(t, path))
+}
+
+func TestAuditor_InvalidConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	cases := []struct {
+		name   string
+		cfg    *config.AuditConfig
+		expErr string
+	}{
+		{
+			name: "sink type",
+			cfg: &config.AuditConfig{
+				Sinks: []*config.AuditSink{{Name: "s", Type: "kafka"}},
+			},
+			expErr: `unknown type "kafka"`,
+		},
+		{
+			name: "delivery guarantee",
+			cfg: &config.AuditConfig{
+				Sinks: []*config.AuditSink{{Name: "s", Path: filepath.Join(dir, "a.log"), DeliveryGuarantee: "sometimes"}},
+			},
+			expErr: `unknown delivery_guarantee "sometimes"`,
+		},
+		{
+			name: "format",
+			cfg: &config.AuditConfig{
+				Sinks: []*config.AuditSink{{Name: "s", Path: filepath.Join(dir, "a.log"), Format: "xml"}},
+			},
+			expErr: `unsupported format "xml"`,
+		},
+		{
+			name: "mode",
+			cfg: &config.AuditConfig{
+				Sinks: []*config.AuditSink{{Name: "s", Path: filepath.Join(dir, "a.log"), Mode: "rw"}},
+			},
+			expErr: `invalid mode "rw"`,
+		},
+		{
+			name: "filter type",
+			cfg: &config.AuditConfig{
+				Sinks:   []*config.AuditSink{{Name: "s", Path: filepath.Join(dir, "a.log")}},
+				Filters: []*config.AuditFilter{{Name: "f", Type: "RPCEvent"}},
+			},
+			expErr: `unknown type "RPCEvent"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Enabled = pointer.Of(true)
+			_, err := newAuditor(tc.cfg, "", testlog.HCLogger(t))
+			must.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// clientStatusPage is the data rendered by the client status page.
+type clientStatusPage struct {
+	Node          *structs.Node
+	KnownServers  string
+	LastHeartbeat string
+	HeartbeatTTL  string
+	MemoryUsedMB  uint64
+	MemoryTotalMB uint64
+	CPUTicks      float64
+	Uptime        time.Duration
+	Drivers       []clientStatusDriver
+	Allocs        []clientStatusAlloc
+	GeneratedAt   time.Time
+}
+
+type clientStatusDriver struct {
+	Name              string
+	Detected          bool
+	Healthy           bool
+	HealthDescription string
+}
+
+type clientStatusAlloc struct {
+	ID           string
+	Name         string
+	Namespace    string
+	JobID        string
+	ClientStatus string
+	Health       string
+	Tasks        string
+	CPUPercent   float64
+	MemoryMB     uint64
+}
+
+var clientStatusTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>Nomad client {{ .Node.Name }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+.bad { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Nomad client {{ .Node.Name }}</h1>
+<table>
+<tr><th>Node ID</th><td>{{ .Node.ID }}</td></tr>
+<tr><th>Datacenter</th><td>{{ .Node.Datacenter }}</td></tr>
+<tr><th>Node Pool</th><td>{{ .Node.NodePool }}</td></tr>
+<tr><th>Status</th><td>{{ .Node.Status }}</td></tr>
+<tr><th>Known Servers</th><td>{{ .KnownServers }}</td></tr>
+<tr><th>Last Heartbeat</th><td>{{ .LastHeartbeat }} ago</td></tr>
+<tr><th>Heartbeat TTL</th><td>{{ .HeartbeatTTL }}</td></tr>
+<tr><th>Memory</th><td>{{ .MemoryUsedMB }} MiB / {{ .MemoryTotalMB }} MiB</td></tr>
+<tr><th>CPU Ticks Consumed</th><td>{{ printf "%.0f" .CPUTicks }} MHz</td></tr>
+<tr><th>Uptime</th><td>{{ .Uptime }}</td></tr>
+</table>
+
+<h2>Drivers</h2>
+<table>
+<tr><th>Driver</th><th>Detected</th><th>Healthy</th><th>Description</th></tr>
+{{- range .Drivers }}
+<tr><td>{{ .Name }}</td><td>{{ .Detected }}</td><td{{ if not .Healthy }} class="bad"{{ end }}>{{ .Healthy }}</td><td>{{ .HealthDescription }}</td></tr>
+{{- end }}
+</table>
+
+<h2>Allocations</h2>
+<table>
+<tr><th>ID</th><th>Name</th><th>Namespace</th><th>Job ID</th><th>Status</th><th>Health</th><th>Tasks</th><th>CPU</th><th>Memory</th></tr>
+{{- range .Allocs }}
+<tr><td>{{ .ID }}</td><td>{{ .Name }}</td><td>{{ .Namespace }}</td><td>{{ .JobID }}</td><td>{{ .ClientStatus }}</td><td>{{ .Health }}</td><td>{{ .Tasks }}</td><td>{{ printf "%.2f" .CPUPercent }}%</td><td>{{ .MemoryMB }} MiB</td></tr>
+{{- else }}
+<tr><td colspan="9">No allocations</td></tr>
+{{- end }}
+</table>
+
+<p>Generated at {{ .GeneratedAt.Format "2006-01-02T15:04:05Z07:00" }}</p>
+</body>
+</html>
+`))
+
+// ClientStatusPageRequest serves a read-only HTML page with the state of the
+// local client, its drivers, and its allocations. It only uses state held by
+// the client, so it can be used to debug the node while the servers are
+// unreachable.
+func (s *HTTPServer) ClientStatusPageRequest(resp http.ResponseWriter, req *http.Request) ([]byte, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	c := s.agent.Client()
+	if c == nil {
+		return nil, CodedError(http.StatusBadRequest, "client is not enabled on this agent")
+	}
+
+	aclObj, err := s.ResolveToken(req)
+	if err != nil {
+		return nil, err
+	}
+	if !aclObj.AllowNodeRead() {
+		return nil, structs.ErrPermissionDenied
+	}
+
+	var buf bytes.Buffer
+	if err := clientStatusTemplate.Execute(&buf, newClientStatusPage(c, aclObj)); err != nil {
+		return nil, CodedError(http.StatusInternalServerError, err.Error())
+	}
+
+	resp.Header().Set(contentTypeHeader, "text/html; charset=utf-8")
+	resp.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	return buf.Bytes(), nil
+}
+
+// newClientStatusPage collects the status of the client, omitting the
+// allocations in namespaces the token can't read.
+func newClientStatusPage(c *client.Client, aclObj *acl.ACL) *clientStatusPage {
+	stats := c.Stats()["client"]
+	page := &clientStatusPage{
+		Node:          c.Node(),
+		KnownServers:  stats["known_servers"],
+		LastHeartbeat: stats["last_heartbeat"],
+		HeartbeatTTL:  stats["heartbeat_ttl"],
+		GeneratedAt:   time.Now().UTC(),
+	}
+
+	if host := c.LatestHostStats(); host != nil {
+		if host.Memory != nil {
+			page.MemoryUsedMB = host.Memory.Used / 1024 / 1024
+			page.MemoryTotalMB = host.Memory.Total / 1024 / 1024
+		}
+		page.CPUTicks = host.CPUTicksConsumed
+		page.Uptime = time.Duration(host.Uptime) * time.Second
+	}
+
+	for name, driver := range page.Node.Drivers {
+		page.Drivers = append(page.Drivers, clientStatusDriver{
+			Name:              name,
+			Detected:          driver.Detected,
+			Healthy:           driver.Healthy,
+			HealthDescription: driver.HealthDescription,
+		})
+	}
+	sort.Slice(page.Drivers, func(i, j int) bool {
+		return page.Drivers[i].Name < page.Drivers[j].Name
+	})
+
+	for _, alloc := range c.LocalAllocs() {
+		if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob) {
+			continue
+		}
+
+		status := clientStatusAlloc{
+			ID:           alloc.ID,
+			Name:         alloc.Name,
+			Namespace:    alloc.Namespace,
+			JobID:        alloc.JobID,
+			ClientStatus: alloc.ClientStatus,
+			Health:       "-",
+		}
+		if ds := alloc.DeploymentStatus; ds != nil && ds.Healthy != nil {
+			status.Health = "unhealthy"
+			if *ds.Healthy {
+				status.Health = "healthy"
+			}
+		}
+
+		tasks := make([]string, 0, len(alloc.TaskStates))
+		for name, state := range alloc.TaskStates {
+			tasks = append(tasks, name+": "+state.State)
+		}
+		slices.Sort(tasks)
+		status.Tasks = strings.Join(tasks, ", ")
+
+		if reporter, err := c.GetAllocStats(alloc.ID); err == nil {
+			if usage, err := reporter.LatestAllocStats(""); err == nil && usage.ResourceUsage != nil {
+				if cpu := usage.ResourceUsage.CpuStats; cpu != nil {
+					status.CPUPercent = cpu.Percent
+				}
+				if mem := usage.ResourceUsage.MemoryStats; mem != nil {
+					status.MemoryMB = mem.RSS / 1024 / 1024
+				}
+			}
+		}
+
+		page.Allocs = append(page.Allocs, status)
+	}
+	sort.Slice(page.Allocs, func(i, j int) bool {
+		return page.Allocs[i].Name < page.Allocs[j].Name
+	})
+
+	return page
+}
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -251,6 +252,10 @@ func (c *Command) readConfig() *Config {
 	// Merge in the enterprise overlay
 	config = config.Merge(DefaultEntConfig())
 
+	// Track which configuration source set each value, so operators can
+	// find out which one won via the effective configuration API.
+	sources := make(map[string]string)
+
 	for _, path := range configPath {
 		current, err := LoadConfig(path)
 		if err != nil {
@@ -268,7 +273,9 @@ func (c *Command) readConfig() *Config {
 		if config == nil {
 			config = current
 		} else {
+			previous := config.Copy()
 			config = config.Merge(current)
+			recordConfigSources(sources, previous, config, path)
 		}
 	}
 
@@ -282,7 +289,10 @@ func (c *Command) readConfig() *Config {
 	}
 
 	// Merge any CLI options over config file options
+	previous := config.Copy()
 	config = config.Merge(cmdConfig)
+	recordConfigSources(sources, previous, config, configSourceCommandLine)
+	previous = config.Copy()
 
 	// Set the version info
 	config.Version = c.Version
@@ -316,6 +326,9 @@ func (c *Command) readConfig() *Config {
 
 	config.Server.DefaultSchedulerConfig.Canonicalize()
 
+	recordConfigSources(sources, previous, config, configSourceDerived)
+	config.Sources = sources
+
 	if !c.IsValidConfig(config, cmdConfig) {
 		return nil
 	}
@@ -406,6 +419,20 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	poolMin, poolMax := config.Server.PlanEvaluatePoolMin, config.Server.PlanEvaluatePoolMax
+	if poolMin != nil && *poolMin < 0 {
+		c.Ui.Error("server.plan_evaluate_pool_min must be greater than or equal to 0")
+		return false
+	}
+	if poolMax != nil && *poolMax < 0 {
+		c.Ui.Error("server.plan_evaluate_pool_max must be greater than or equal to 0")
+		return false
+	}
+	if poolMin != nil && poolMax != nil && *poolMax > 0 && *poolMin > *poolMax {
+		c.Ui.Error("server.plan_evaluate_pool_min must be less than or equal to server.plan_evaluate_pool_max")
+		return false
+	}
+
 	// Validate node pool name early to prevent agent from starting but the
 	// client failing to register.
 	if pool := config.Client.NodePool; pool != "" {
@@ -437,6 +464,28 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		}
 	}
 
+	if config.Client.AllocDirDiskBudgetMB < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid alloc_dir_disk_budget_mb=%d: must not be negative", config.Client.AllocDirDiskBudgetMB))
+		return false
+	}
+	if high := config.Client.AllocDirDiskHighWatermark; high <= 0 || high > 100 {
+		c.Ui.Error(fmt.Sprintf("Invalid alloc_dir_disk_high_watermark=%v: must be greater than 0 and at most 100", high))
+		return false
+	}
+	if low := config.Client.AllocDirDiskLowWatermark; low <= 0 || low >= config.Client.AllocDirDiskHighWatermark {
+		c.Ui.Error(fmt.Sprintf("Invalid alloc_dir_disk_low_watermark=%v: must be greater than 0 and lower than alloc_dir_disk_high_watermark", low))
+		return false
+	}
+
+	if config.Client.MaxConcurrentAllocStarts < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid max_concurrent_alloc_starts=%d: must not be negative", config.Client.MaxConcurrentAllocStarts))
+		return false
+	}
+	if config.Client.AllocStartInterval < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid alloc_start_interval=%s: must not be negative", config.Client.AllocStartInterval))
+		return false
+	}
+
 	if config.Client.MinDynamicPort < 0 || config.Client.MinDynamicPort > structs.MaxValidPort {
 		c.Ui.Error(fmt.Sprintf("Invalid dynamic port range: min_dynamic_port=%d", config.Client.MinDynamicPort))
 		return false
@@ -991,6 +1040,7 @@ const (
 	sdReloading = "RELOADING=1"
 	sdStopping  = "STOPPING=1"
 	sdMonotonic = "MONOTONIC_USEC=%d"
+	sdWatchdog  = "WATCHDOG=1"
 )
 
 // handleSignals blocks until we get an exit-causing signal
@@ -1008,6 +1058,14 @@ func (c *Command) handleSignals() int {
 	}
 	sdNotify(sdSock, sdReady)
 
+	// Keep the systemd watchdog fed while the agent is healthy, if the unit
+	// has WatchdogSec set
+	if interval := watchdogInterval(); interval > 0 && sdSock != nil {
+		watchdogStopCh := make(chan struct{})
+		defer close(watchdogStopCh)
+		go newAgentWatchdog(c.agent, time.Now()).run(sdSock, interval, watchdogStopCh)
+	}
+
 	// Wait for a signal
 WAIT:
 	var sig os.Signal
@@ -1098,6 +1156,7 @@ func (c *Command) handleReload() {
 	newConf := c.readConfig()
 	if newConf == nil {
 		c.Ui.Error("Failed to reload configs")
+		c.agent.recordReload(nil, nil, nil, errors.New("failed to read configuration"))
 		return
 	}
 
@@ -1113,12 +1172,25 @@ func (c *Command) handleReload() {
 		newConf.LogLevel = c.agent.GetConfig().LogLevel
 	}
 
+	// Record which sections were applied and which need a restart once the
+	// reload is done, so operators can check it via the agent API
+	applied, restartRequired := c.agent.reloadSectionsDiff(newConf)
+	var reloadErr error
+	defer func() {
+		c.agent.recordReload(newConf, applied, restartRequired, reloadErr)
+		if len(restartRequired) > 0 {
+			c.agent.logger.Warn("configuration changes require an agent restart",
+				"sections", restartRequired)
+		}
+	}()
+
 	shouldReloadAgent, shouldReloadHTTP := c.agent.ShouldReload(newConf)
 	if shouldReloadAgent {
 		c.agent.logger.Debug("starting reload of agent config")
 		err := c.agent.Reload(newConf)
 		if err != nil {
 			c.agent.logger.Error("failed to reload the config", "error", err)
+			reloadErr = err
 			return
 		}
 	}
@@ -1128,6 +1200,7 @@ func (c *Command) handleReload() {
 		sconf, err := convertServerConfig(newConf)
 		if err != nil {
 			c.agent.logger.Error("failed to convert server config", "error", err)
+			reloadErr = err
 			return
 		}
 
@@ -1137,6 +1210,7 @@ func (c *Command) handleReload() {
 		// Reload the config
 		if err := s.Reload(sconf); err != nil {
 			c.agent.logger.Error("reloading server config failed", "error", err)
+			reloadErr = err
 			return
 		}
 	}
@@ -1146,17 +1220,20 @@ func (c *Command) handleReload() {
 		clientConfig, err := convertClientConfig(newConf)
 		if err != nil {
 			c.agent.logger.Error("failed to convert client config", "error", err)
+			reloadErr = err
 			return
 		}
 
 		// Finalize the config to get the agent objects injected in
 		if err := c.agent.finalizeClientConfig(clientConfig); err != nil {
 			c.agent.logger.Error("failed to finalize client config", "error", err)
+			reloadErr = err
 			return
 		}
 
 		if err := client.Reload(clientConfig); err != nil {
 			c.agent.logger.Error("reloading client config failed", "error", err)
+			reloadErr = err
 			return
 		}
 	}
@@ -1169,6 +1246,7 @@ func (c *Command) handleReload() {
 		err := c.reloadHTTPServer()
 		if err != nil {
 			c.agent.httpLogger.Error("reloading config failed", "error", err)
+			reloadErr = err
 			return
 		}
 	}
@@ -1286,14 +1364,27 @@ func (c *Command) setupTelemetry(config *Config) (*metrics.InmemSink, error) {
 	}
 
 	// Initialize the global sink
+	var sink metrics.MetricSink = inm
 	if len(fanout) > 0 {
 		fanout = append(fanout, inm)
-		metrics.NewGlobal(metricsConf, fanout)
+		sink = fanout
 	} else {
 		metricsConf.EnableHostname = false
-		metrics.NewGlobal(metricsConf, inm)
 	}
 
+	// Add the global tags after aggregation and cardinality limits are
+	// applied, so they are never removed or limited
+	if global := newGlobalLabelSink(config, sink); global != nil {
+		sink = global
+	}
+
+	// Aggregate series and cap label cardinality for all sinks, including
+	// the in-memory sink that backs the metrics endpoint
+	if guard := newLabelGuardSink(telConfig, sink); guard != nil {
+		sink = guard
+	}
+	metrics.NewGlobal(metricsConf, sink)
+
 	return inm, nil
 }
 
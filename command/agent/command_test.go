@@ -590,6 +590,22 @@ vault {
 				must.Eq(t, "ns-from-cli", c.Vaults[0].Namespace)
 			},
 		},
+		{
+			name: "sources of configuration values",
+			args: []string{
+				"-config", path.Join(configDir, "base.hcl"),
+				"-config", path.Join(configDir, "vault.hcl"),
+				"-region", "east",
+			},
+			checkFn: func(t *testing.T, c *Config) {
+				must.Eq(t, path.Join(configDir, "base.hcl"), c.Sources["data_dir"])
+				must.Eq(t, path.Join(configDir, "base.hcl"), c.Sources["server.enabled"])
+				must.Eq(t, path.Join(configDir, "vault.hcl"), c.Sources["vaults"])
+				must.Eq(t, "command line", c.Sources["region"])
+				must.Eq(t, "derived", c.Sources["plugin_dir"])
+				must.MapNotContainsKey(t, c.Sources, "datacenter")
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -164,6 +164,11 @@ type Config struct {
 	// List of config files that have been loaded (in order)
 	Files []string `hcl:"-"`
 
+	// Sources maps the dotted HCL path of the values that were not left to
+	// their defaults to the configuration file, command line, or derivation
+	// that set them last.
+	Sources map[string]string `hcl:"-" json:"-"`
+
 	// TLSConfig provides TLS related configuration for the Nomad server and
 	// client
 	TLSConfig *config.TLSConfig `hcl:"tls"`
@@ -338,6 +343,39 @@ type ClientConfig struct {
 	// before garbage collection is triggered.
 	GCMaxAllocs int `hcl:"gc_max_allocs"`
 
+	// AllocDirDiskBudgetMB is the total disk space in MB that the allocation
+	// directories of the client may use, regardless of the ephemeral disk of
+	// each allocation. Zero disables the budget.
+	AllocDirDiskBudgetMB int `hcl:"alloc_dir_disk_budget_mb"`
+
+	// AllocDirDiskHighWatermark is the percentage of AllocDirDiskBudgetMB
+	// above which the client garbage collects terminal allocations and, if
+	// that isn't enough, marks itself ineligible for scheduling.
+	AllocDirDiskHighWatermark float64 `hcl:"alloc_dir_disk_high_watermark"`
+
+	// AllocDirDiskLowWatermark is the percentage of AllocDirDiskBudgetMB
+	// below which a client marked ineligible by the budget becomes eligible
+	// for scheduling again.
+	AllocDirDiskLowWatermark float64 `hcl:"alloc_dir_disk_low_watermark"`
+
+	// NetworkGCInterval is the time interval at which the client removes the
+	// network namespaces, veth pairs and iptables chains left behind by
+	// allocations that are not running on the client anymore.
+	NetworkGCInterval    time.Duration
+	NetworkGCIntervalHCL string `hcl:"network_gc_interval" json:"-"`
+
+	// MaxConcurrentAllocStarts is the maximum number of allocations the
+	// client starts at the same time, so a client restarting with many
+	// allocations does not saturate its disk and network. Zero disables the
+	// limit.
+	MaxConcurrentAllocStarts int `hcl:"max_concurrent_alloc_starts"`
+
+	// AllocStartInterval is the minimum time between the start of two
+	// allocations, which ramps up the allocation starts of the client. Zero
+	// disables the ramp.
+	AllocStartInterval    time.Duration
+	AllocStartIntervalHCL string `hcl:"alloc_start_interval" json:"-"`
+
 	// NoHostUUID disables using the host's UUID and will force generation of a
 	// random UUID.
 	NoHostUUID *bool `hcl:"no_host_uuid"`
@@ -345,6 +383,14 @@ type ClientConfig struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool `hcl:"disable_remote_exec"`
 
+	// RecordRemoteExec records the output of all the exec sessions targeting
+	// tasks on this client to the log directory of their allocation
+	RecordRemoteExec bool `hcl:"record_remote_exec"`
+
+	// EnableStatusPage serves a read-only HTML status page of the client for
+	// node-local debugging, which works while the servers are unreachable.
+	EnableStatusPage bool `hcl:"enable_status_page"`
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *client.ClientTemplateConfig `hcl:"template"`
 
@@ -526,6 +572,23 @@ type ServerConfig struct {
 	// from doing any scheduling work.
 	NumSchedulers *int `hcl:"num_schedulers"`
 
+	// PlanEvaluatePoolMin and PlanEvaluatePoolMax bound the number of workers
+	// used by the leader to evaluate plans. The pool is resized between these
+	// values based on the plan queue depth and the number of nodes in each
+	// plan. They can be overridden at runtime by the scheduler configuration.
+	PlanEvaluatePoolMin *int `hcl:"plan_evaluate_pool_min"`
+	PlanEvaluatePoolMax *int `hcl:"plan_evaluate_pool_max"`
+
+	// JobRegisterConcurrency is the maximum number of job registrations the
+	// leader processes at the same time. Registrations over the limit are
+	// queued and admitted fairly across the tokens that submitted them.
+	JobRegisterConcurrency *int `hcl:"job_register_concurrency"`
+
+	// DisableParallelPlanApply forces the leader to commit plans one at a
+	// time, instead of committing plans that modify disjoint sets of nodes,
+	// jobs and deployments in parallel.
+	DisableParallelPlanApply bool `hcl:"disable_parallel_plan_apply"`
+
 	// EnabledSchedulers controls the set of sub-schedulers that are
 	// enabled for this server to handle. This will restrict the evaluations
 	// that the workers dequeue for processing.
@@ -612,6 +675,12 @@ type ServerConfig struct {
 	FailoverHeartbeatTTL    time.Duration
 	FailoverHeartbeatTTLHCL string `hcl:"failover_heartbeat_ttl" json:"-"`
 
+	// PlanQueueAgingInterval is how long a plan can wait in the plan queue
+	// before its effective priority is boosted, to prevent plans of low
+	// priority jobs from being starved. Aging is disabled if unset.
+	PlanQueueAgingInterval    time.Duration
+	PlanQueueAgingIntervalHCL string `hcl:"plan_queue_aging_interval" json:"-"`
+
 	// StartJoin is a list of addresses to attempt to join when the
 	// agent starts. If Serf is unable to communicate with any of these
 	// addresses, then the agent will error and exit.
@@ -645,6 +714,15 @@ type ServerConfig struct {
 	// non-voting member of the cluster to help provide read scalability.
 	NonVotingServer bool `hcl:"non_voting_server"`
 
+	// ReadReplica is whether this server joins the cluster as a permanent
+	// non-voter that serves stale read queries, offloading them from the
+	// voting servers.
+	ReadReplica bool `hcl:"read_replica"`
+
+	// IdentityMetaClaims is the list of job meta keys that workload
+	// identities may request as claims with meta_claims.
+	IdentityMetaClaims []string `hcl:"identity_meta_claims"`
+
 	// (Enterprise-only) RedundancyZone is the redundancy zone to use for this server.
 	RedundancyZone string `hcl:"redundancy_zone"`
 
@@ -697,6 +775,22 @@ type ServerConfig struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64 `hcl:"deploy_query_rate_limit"`
 
+	// DeploymentWebhooks configures URLs that are notified of deployment
+	// status transitions.
+	DeploymentWebhooks []*config.DeploymentWebhookConfig `hcl:"deployment_webhook"`
+
+	// DeploymentMetrics configures the metric provider queried by the
+	// rollback_on block of job update strategies.
+	DeploymentMetrics *config.DeploymentMetricsConfig `hcl:"deployment_metrics"`
+
+	// SnapshotUpload configures the periodic upload of state snapshots to
+	// object storage by the leader.
+	SnapshotUpload *config.SnapshotUploadConfig `hcl:"snapshot_upload"`
+
+	// JobAdmissionWebhook configures an external service called on job
+	// registration to mutate or reject jobs.
+	JobAdmissionWebhook *config.JobAdmissionWebhookConfig `hcl:"job_admission_webhook"`
+
 	// RaftBoltConfig configures boltdb as used by raft.
 	RaftBoltConfig *RaftBoltConfig `hcl:"raft_boltdb"`
 
@@ -719,6 +813,11 @@ type ServerConfig struct {
 	// setting used. This can be tuned during operation using a hot reload.
 	RaftTrailingLogs *int `hcl:"raft_trailing_logs"`
 
+	// RaftSnapshotPersistRate limits the rate at which state snapshots are
+	// written to disk, as a size per second such as "100MB". If unset or
+	// "0", snapshots are written as fast as possible.
+	RaftSnapshotPersistRate *string `hcl:"raft_snapshot_persist_rate"`
+
 	// JobDefaultPriority is the default Job priority if not specified.
 	JobDefaultPriority *int `hcl:"job_default_priority"`
 
@@ -747,7 +846,11 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns := *s
 	ns.RaftMultiplier = pointer.Copy(s.RaftMultiplier)
 	ns.NumSchedulers = pointer.Copy(s.NumSchedulers)
+	ns.PlanEvaluatePoolMin = pointer.Copy(s.PlanEvaluatePoolMin)
+	ns.PlanEvaluatePoolMax = pointer.Copy(s.PlanEvaluatePoolMax)
+	ns.JobRegisterConcurrency = pointer.Copy(s.JobRegisterConcurrency)
 	ns.EnabledSchedulers = slices.Clone(s.EnabledSchedulers)
+	ns.IdentityMetaClaims = slices.Clone(s.IdentityMetaClaims)
 	ns.StartJoin = slices.Clone(s.StartJoin)
 	ns.RetryJoin = slices.Clone(s.RetryJoin)
 	ns.ServerJoin = s.ServerJoin.Copy()
@@ -759,10 +862,15 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.licenseAdditionalPublicKeys = slices.Clone(s.licenseAdditionalPublicKeys)
 	ns.ExtraKeysHCL = slices.Clone(s.ExtraKeysHCL)
 	ns.Search = s.Search.Copy()
+	ns.DeploymentWebhooks = config.CopyDeploymentWebhooks(s.DeploymentWebhooks)
+	ns.DeploymentMetrics = s.DeploymentMetrics.Copy()
+	ns.SnapshotUpload = s.SnapshotUpload.Copy()
+	ns.JobAdmissionWebhook = s.JobAdmissionWebhook.Copy()
 	ns.RaftBoltConfig = s.RaftBoltConfig.Copy()
 	ns.RaftSnapshotInterval = pointer.Copy(s.RaftSnapshotInterval)
 	ns.RaftSnapshotThreshold = pointer.Copy(s.RaftSnapshotThreshold)
 	ns.RaftTrailingLogs = pointer.Copy(s.RaftTrailingLogs)
+	ns.RaftSnapshotPersistRate = pointer.Copy(s.RaftSnapshotPersistRate)
 	ns.JobDefaultPriority = pointer.Copy(s.JobDefaultPriority)
 	ns.JobMaxPriority = pointer.Copy(s.JobMaxPriority)
 	ns.JobTrackedVersions = pointer.Copy(s.JobTrackedVersions)
@@ -1020,6 +1128,27 @@ type Telemetry struct {
 	// metrics.
 	DisableAllocationHookMetrics *bool `hcl:"disable_allocation_hook_metrics"`
 
+	// GlobalTags are labels added to all the metrics emitted by the agent.
+	// Values may reference the agent configuration with ${node.datacenter},
+	// ${node.region}, ${node.name}, ${node.pool}, ${node.class} and
+	// ${meta.<key>}.
+	GlobalTags map[string]string `hcl:"global_tags"`
+
+	// AggregatePrefixes is a list of metric name prefixes whose metrics are
+	// emitted without the labels listed in AggregateLabels, collapsing the
+	// series of each metric. All labels but the host are removed if
+	// AggregateLabels is empty.
+	AggregatePrefixes []string `hcl:"aggregate_prefixes"`
+	AggregateLabels   []string `hcl:"aggregate_labels"`
+
+	// LabelCardinalityLimits caps the number of distinct values tracked for
+	// the given label names, such as alloc_id. Once the limit is reached,
+	// new values are replaced by "_other" until tracked values expire after
+	// LabelCardinalityTTL without updates.
+	LabelCardinalityLimits map[string]int `hcl:"label_cardinality_limits"`
+	LabelCardinalityTTL    string         `hcl:"label_cardinality_ttl"`
+	labelCardinalityTTL    time.Duration  `hcl:"-"`
+
 	// Circonus: see https://github.com/circonus-labs/circonus-gometrics
 	// for more details on the various configuration options.
 	// Valid configuration combinations:
@@ -1104,6 +1233,10 @@ func (t *Telemetry) Copy() *Telemetry {
 	nt.DataDogTags = slices.Clone(t.DataDogTags)
 	nt.PrefixFilter = slices.Clone(t.PrefixFilter)
 	nt.FilterDefault = pointer.Copy(t.FilterDefault)
+	nt.GlobalTags = maps.Clone(t.GlobalTags)
+	nt.AggregatePrefixes = slices.Clone(t.AggregatePrefixes)
+	nt.AggregateLabels = slices.Clone(t.AggregateLabels)
+	nt.LabelCardinalityLimits = maps.Clone(t.LabelCardinalityLimits)
 	nt.ExtraKeysHCL = slices.Clone(t.ExtraKeysHCL)
 	return &nt
 }
@@ -1147,6 +1280,21 @@ func (t *Telemetry) Validate() error {
 		return errors.New("telemetry in-memory collection interval cannot be greater than retention period")
 	}
 
+	for tag, value := range t.GlobalTags {
+		if err := validateTelemetryTag(value); err != nil {
+			return fmt.Errorf("telemetry global tag %q is invalid: %w", tag, err)
+		}
+	}
+
+	for label, limit := range t.LabelCardinalityLimits {
+		if limit <= 0 {
+			return fmt.Errorf("telemetry label cardinality limit for %q must be greater than zero", label)
+		}
+	}
+	if len(t.LabelCardinalityLimits) > 0 && t.labelCardinalityTTL <= 0 {
+		return errors.New("telemetry label cardinality TTL must be greater than zero")
+	}
+
 	return nil
 }
 
@@ -1402,21 +1550,24 @@ func DefaultConfig() *Config {
 		Vaults:         []*config.VaultConfig{config.DefaultVaultConfig()},
 		UI:             config.DefaultUIConfig(),
 		Client: &ClientConfig{
-			Enabled:               false,
-			NodePool:              structs.NodePoolDefault,
-			MaxKillTimeout:        "30s",
-			ClientMinPort:         14000,
-			ClientMaxPort:         14512,
-			MinDynamicPort:        20000,
-			MaxDynamicPort:        32000,
-			Reserved:              &Resources{},
-			GCInterval:            1 * time.Minute,
-			GCParallelDestroys:    2,
-			GCDiskUsageThreshold:  80,
-			GCInodeUsageThreshold: 70,
-			GCMaxAllocs:           50,
-			NoHostUUID:            pointer.Of(true),
-			DisableRemoteExec:     false,
+			Enabled:                   false,
+			NodePool:                  structs.NodePoolDefault,
+			MaxKillTimeout:            "30s",
+			ClientMinPort:             14000,
+			ClientMaxPort:             14512,
+			MinDynamicPort:            20000,
+			MaxDynamicPort:            32000,
+			Reserved:                  &Resources{},
+			GCInterval:                1 * time.Minute,
+			GCParallelDestroys:        2,
+			GCDiskUsageThreshold:      80,
+			GCInodeUsageThreshold:     70,
+			GCMaxAllocs:               50,
+			AllocDirDiskHighWatermark: 90,
+			AllocDirDiskLowWatermark:  80,
+			NetworkGCInterval:         10 * time.Minute,
+			NoHostUUID:                pointer.Of(true),
+			DisableRemoteExec:         false,
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -1471,6 +1622,8 @@ func DefaultConfig() *Config {
 			CollectionInterval:           "1s",
 			collectionInterval:           1 * time.Second,
 			DisableAllocationHookMetrics: pointer.Of(false),
+			LabelCardinalityTTL:          "10m",
+			labelCardinalityTTL:          10 * time.Minute,
 		},
 		TLSConfig:          &config.TLSConfig{},
 		Sentinel:           &config.SentinelConfig{},
@@ -1813,6 +1966,52 @@ func mergeKEKProviderConfigs(left, right []*structs.KEKProviderConfig) []*struct
 	return results
 }
 
+// Redacted returns a copy of the configuration with secrets replaced by
+// "<redacted>", suitable for returning via the agent API.
+func (c *Config) Redacted() *Config {
+	if c == nil {
+		return nil
+	}
+
+	nc := c.Copy()
+	if nc.ACL != nil && nc.ACL.ReplicationToken != "" {
+		nc.ACL.ReplicationToken = "<redacted>"
+	}
+
+	for _, consulConfig := range nc.Consuls {
+		if consulConfig.Token != "" {
+			consulConfig.Token = "<redacted>"
+		}
+		if consulConfig.Auth != "" {
+			consulConfig.Auth = "<redacted>"
+		}
+	}
+
+	for _, vaultConfig := range nc.Vaults {
+		if vaultConfig.Token != "" {
+			vaultConfig.Token = "<redacted>"
+		}
+	}
+
+	if nc.Telemetry != nil && nc.Telemetry.CirconusAPIToken != "" {
+		nc.Telemetry.CirconusAPIToken = "<redacted>"
+	}
+
+	if nc.Server != nil && nc.Server.LicenseEnv != "" {
+		nc.Server.LicenseEnv = "<redacted>"
+	}
+
+	if nc.Server != nil {
+		for _, webhook := range nc.Server.DeploymentWebhooks {
+			if webhook.Secret != "" {
+				webhook.Secret = "<redacted>"
+			}
+		}
+	}
+
+	return nc
+}
+
 // Copy returns a deep copy safe for mutation.
 func (c *Config) Copy() *Config {
 	if c == nil {
@@ -1838,6 +2037,7 @@ func (c *Config) Copy() *Config {
 
 	nc.Version = c.Version.Copy()
 	nc.Files = slices.Clone(c.Files)
+	nc.Sources = maps.Clone(c.Sources)
 	nc.TLSConfig = c.TLSConfig.Copy()
 	nc.HTTPAPIResponseHeaders = maps.Clone(c.HTTPAPIResponseHeaders)
 	nc.Sentinel = c.Sentinel.Copy()
@@ -2135,6 +2335,18 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.NumSchedulers != nil {
 		result.NumSchedulers = pointer.Of(*b.NumSchedulers)
 	}
+	if b.PlanEvaluatePoolMin != nil {
+		result.PlanEvaluatePoolMin = pointer.Of(*b.PlanEvaluatePoolMin)
+	}
+	if b.PlanEvaluatePoolMax != nil {
+		result.PlanEvaluatePoolMax = pointer.Of(*b.PlanEvaluatePoolMax)
+	}
+	if b.JobRegisterConcurrency != nil {
+		result.JobRegisterConcurrency = pointer.Of(*b.JobRegisterConcurrency)
+	}
+	if b.DisableParallelPlanApply {
+		result.DisableParallelPlanApply = true
+	}
 	if b.NodeGCThreshold != "" {
 		result.NodeGCThreshold = b.NodeGCThreshold
 	}
@@ -2201,6 +2413,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.FailoverHeartbeatTTLHCL != "" {
 		result.FailoverHeartbeatTTLHCL = b.FailoverHeartbeatTTLHCL
 	}
+	if b.PlanQueueAgingInterval != 0 {
+		result.PlanQueueAgingInterval = b.PlanQueueAgingInterval
+	}
+	if b.PlanQueueAgingIntervalHCL != "" {
+		result.PlanQueueAgingIntervalHCL = b.PlanQueueAgingIntervalHCL
+	}
 	if b.RetryMaxAttempts != 0 {
 		result.RetryMaxAttempts = b.RetryMaxAttempts
 	}
@@ -2216,6 +2434,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.NonVotingServer {
 		result.NonVotingServer = true
 	}
+	if b.ReadReplica {
+		result.ReadReplica = true
+	}
+	if len(b.IdentityMetaClaims) != 0 {
+		result.IdentityMetaClaims = append(result.IdentityMetaClaims, b.IdentityMetaClaims...)
+	}
 	if b.RedundancyZone != "" {
 		result.RedundancyZone = b.RedundancyZone
 	}
@@ -2255,6 +2479,22 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.DeploymentQueryRateLimit = b.DeploymentQueryRateLimit
 	}
 
+	if len(b.DeploymentWebhooks) != 0 {
+		result.DeploymentWebhooks = config.MergeDeploymentWebhooks(s.DeploymentWebhooks, b.DeploymentWebhooks)
+	}
+
+	if b.DeploymentMetrics != nil {
+		result.DeploymentMetrics = s.DeploymentMetrics.Merge(b.DeploymentMetrics)
+	}
+
+	if b.SnapshotUpload != nil {
+		result.SnapshotUpload = s.SnapshotUpload.Merge(b.SnapshotUpload)
+	}
+
+	if b.JobAdmissionWebhook != nil {
+		result.JobAdmissionWebhook = s.JobAdmissionWebhook.Merge(b.JobAdmissionWebhook)
+	}
+
 	if b.Search != nil {
 		result.Search = &Search{FuzzyEnabled: b.Search.FuzzyEnabled}
 		if b.Search.LimitQuery > 0 {
@@ -2286,6 +2526,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.RaftTrailingLogs = pointer.Of(*b.RaftTrailingLogs)
 	}
 
+	if b.RaftSnapshotPersistRate != nil {
+		result.RaftSnapshotPersistRate = pointer.Of(*b.RaftSnapshotPersistRate)
+	}
+
 	if b.JobTrackedVersions != nil {
 		result.JobTrackedVersions = b.JobTrackedVersions
 	}
@@ -2406,6 +2650,30 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.GCMaxAllocs != 0 {
 		result.GCMaxAllocs = b.GCMaxAllocs
 	}
+	if b.AllocDirDiskBudgetMB != 0 {
+		result.AllocDirDiskBudgetMB = b.AllocDirDiskBudgetMB
+	}
+	if b.AllocDirDiskHighWatermark != 0 {
+		result.AllocDirDiskHighWatermark = b.AllocDirDiskHighWatermark
+	}
+	if b.AllocDirDiskLowWatermark != 0 {
+		result.AllocDirDiskLowWatermark = b.AllocDirDiskLowWatermark
+	}
+	if b.NetworkGCInterval != 0 {
+		result.NetworkGCInterval = b.NetworkGCInterval
+	}
+	if b.NetworkGCIntervalHCL != "" {
+		result.NetworkGCIntervalHCL = b.NetworkGCIntervalHCL
+	}
+	if b.MaxConcurrentAllocStarts != 0 {
+		result.MaxConcurrentAllocStarts = b.MaxConcurrentAllocStarts
+	}
+	if b.AllocStartInterval != 0 {
+		result.AllocStartInterval = b.AllocStartInterval
+	}
+	if b.AllocStartIntervalHCL != "" {
+		result.AllocStartIntervalHCL = b.AllocStartIntervalHCL
+	}
 	// NoHostUUID defaults to true, merge if false
 	if b.NoHostUUID != nil {
 		result.NoHostUUID = b.NoHostUUID
@@ -2415,6 +2683,14 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.DisableRemoteExec = b.DisableRemoteExec
 	}
 
+	if b.RecordRemoteExec {
+		result.RecordRemoteExec = b.RecordRemoteExec
+	}
+
+	if b.EnableStatusPage {
+		result.EnableStatusPage = b.EnableStatusPage
+	}
+
 	if b.TemplateConfig != nil {
 		result.TemplateConfig = result.TemplateConfig.Merge(b.TemplateConfig)
 	}
@@ -2616,6 +2892,24 @@ func (t *Telemetry) Merge(b *Telemetry) *Telemetry {
 	if b.DisableAllocationHookMetrics != nil {
 		result.DisableAllocationHookMetrics = b.DisableAllocationHookMetrics
 	}
+	if b.GlobalTags != nil {
+		result.GlobalTags = b.GlobalTags
+	}
+	if b.AggregatePrefixes != nil {
+		result.AggregatePrefixes = b.AggregatePrefixes
+	}
+	if b.AggregateLabels != nil {
+		result.AggregateLabels = b.AggregateLabels
+	}
+	if b.LabelCardinalityLimits != nil {
+		result.LabelCardinalityLimits = b.LabelCardinalityLimits
+	}
+	if b.LabelCardinalityTTL != "" {
+		result.LabelCardinalityTTL = b.LabelCardinalityTTL
+	}
+	if b.labelCardinalityTTL != 0 {
+		result.labelCardinalityTTL = b.labelCardinalityTTL
+	}
 
 	return &result
 }
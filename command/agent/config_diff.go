@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// ConfigChange describes a difference between two agent configurations.
+type ConfigChange struct {
+	// Path is the dotted HCL path of the changed value, such as
+	// client.host_volume.
+	Path string
+
+	// Old and New are the JSON encoded values.
+	Old string
+	New string
+
+	// Reloadable is true if the change is applied when the agent reloads its
+	// configuration, false if it requires a restart.
+	Reloadable bool
+}
+
+// configDiffIgnoredPaths are the paths that describe the running agent rather
+// than its configuration.
+var configDiffIgnoredPaths = map[string]bool{
+	"devmode":       true,
+	"files":         true,
+	"version":       true,
+	"tls.keyloader": true,
+	"tls.checksum":  true,
+}
+
+// DiffConfig returns the differences between the current and candidate
+// configurations, in the order of the configuration fields. Only the fields
+// that are encoded to JSON are compared, so both configurations may come from
+// the agent API.
+func DiffConfig(current, candidate *Config) []*ConfigChange {
+	var changes []*ConfigChange
+	diffConfigValue(reflect.ValueOf(current), reflect.ValueOf(candidate), "", &changes)
+	return changes
+}
+
+func diffConfigValue(a, b reflect.Value, path string, changes *[]*ConfigChange) {
+	for a.Kind() == reflect.Pointer || b.Kind() == reflect.Pointer {
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*changes = append(*changes, newConfigChange(path, a, b))
+			}
+			return
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+
+	if a.Kind() != reflect.Struct {
+		if configValueEmpty(a) && configValueEmpty(b) {
+			return
+		}
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, newConfigChange(path, a, b))
+		}
+		return
+	}
+
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		name := configFieldName(field)
+		if path != "" {
+			name = path + "." + name
+		}
+		if configDiffIgnoredPaths[name] {
+			continue
+		}
+		diffConfigValue(a.Field(i), b.Field(i), name, changes)
+	}
+}
+
+// configValueEmpty returns true for nil and empty maps and slices, which
+// are equivalent in the agent configuration.
+func configValueEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+// configFieldName returns the HCL name of a field, falling back to its
+// lower cased Go name for fields that are not decoded from HCL directly.
+func configFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("hcl"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func newConfigChange(path string, a, b reflect.Value) *ConfigChange {
+	encode := func(v reflect.Value) string {
+		buf, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+		return string(buf)
+	}
+
+	return &ConfigChange{
+		Path:       path,
+		Old:        encode(a),
+		New:        encode(b),
+		Reloadable: ConfigPathReloadable(path),
+	}
+}
+
+// ConfigPathReloadable returns true if changes to the configuration value
+// at the given dotted HCL path are applied when the agent reloads its
+// configuration.
+func ConfigPathReloadable(path string) bool {
+	for _, section := range reloadSections {
+		if !section.reloadable {
+			continue
+		}
+		if path == section.name || strings.HasPrefix(path, section.name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// configSourceCommandLine is the source of values set by command line
+	// flags or environment variables read by the agent command.
+	configSourceCommandLine = "command line"
+
+	// configSourceDerived is the source of values computed from other values
+	// once all configuration sources are merged, such as advertise
+	// addresses.
+	configSourceDerived = "derived"
+)
+
+// recordConfigSources records source as the origin of every value that
+// differs between the previous and next configuration.
+func recordConfigSources(sources map[string]string, previous, next *Config, source string) {
+	for _, change := range DiffConfig(previous, next) {
+		sources[change.Path] = source
+	}
+}
+
+// LoadConfigFiles loads and merges the configuration files and directories
+// at the given paths over the default configuration, the same way the agent
+// does on startup, excluding command line flags.
+func LoadConfigFiles(paths []string) (*Config, error) {
+	var mErr multierror.Error
+
+	config := DefaultConfig().Merge(DefaultEntConfig())
+	for _, path := range paths {
+		current, err := LoadConfig(path)
+		if err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf(
+				"Error loading configuration from %s: %s", path, err))
+			continue
+		}
+		config = config.Merge(current)
+	}
+	if err := mErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	if config.Client == nil {
+		config.Client = &ClientConfig{}
+	}
+	if config.Server == nil {
+		config.Server = &ServerConfig{}
+	}
+
+	if err := config.normalizeAddrs(); err != nil {
+		return nil, err
+	}
+
+	if config.PluginDir == "" && config.DataDir != "" {
+		config.PluginDir = filepath.Join(config.DataDir, "plugins")
+	}
+
+	config.Server.LicenseEnv = os.Getenv("NOMAD_LICENSE")
+	if config.Server.LicensePath == "" {
+		config.Server.LicensePath = os.Getenv("NOMAD_LICENSE_PATH")
+	}
+
+	config.Server.DefaultSchedulerConfig.Canonicalize()
+
+	return config, nil
+}
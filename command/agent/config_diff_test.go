@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestDiffConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	current := DevConfig(nil)
+	must.SliceEmpty(t, DiffConfig(current, current.Copy()))
+
+	// The running configuration is read via the agent API, so the diff must
+	// be stable across a JSON round trip.
+	buf, err := json.Marshal(current)
+	must.NoError(t, err)
+	var decoded Config
+	must.NoError(t, json.Unmarshal(buf, &decoded))
+	must.SliceEmpty(t, DiffConfig(current, &decoded))
+
+	candidate := current.Copy()
+	candidate.Client.HostVolumes = []*structs.ClientHostVolumeConfig{
+		{Name: "data", Path: "/srv/data"},
+	}
+	candidate.Server.NumSchedulers = pointer.Of(4)
+	candidate.Files = []string{"new.hcl"}
+
+	changes := DiffConfig(current, candidate)
+	must.Len(t, 2, changes)
+
+	must.Eq(t, "client.host_volume", changes[0].Path)
+	must.True(t, changes[0].Reloadable)
+	must.Eq(t, "null", changes[0].Old)
+	must.StrContains(t, changes[0].New, "/srv/data")
+
+	must.Eq(t, "server.num_schedulers", changes[1].Path)
+	must.False(t, changes[1].Reloadable)
+}
+
+func TestConfigPathReloadable(t *testing.T) {
+	ci.Parallel(t)
+
+	must.True(t, ConfigPathReloadable("log_level"))
+	must.True(t, ConfigPathReloadable("client.artifact.http_read_timeout"))
+	must.False(t, ConfigPathReloadable("client.artifactory"))
+	must.False(t, ConfigPathReloadable("client.node_pool"))
+	must.False(t, ConfigPathReloadable("datacenter"))
+}
+
+func TestLoadConfigFiles(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent.hcl")
+	must.NoError(t, os.WriteFile(path, []byte(`
+datacenter = "east"
+client {
+  enabled = true
+}
+`), 0o644))
+
+	config, err := LoadConfigFiles([]string{path})
+	must.NoError(t, err)
+	must.Eq(t, "east", config.Datacenter)
+	must.True(t, config.Client.Enabled)
+	must.NotNil(t, config.Addresses)
+
+	_, err = LoadConfigFiles([]string{filepath.Join(dir, "missing.hcl")})
+	must.ErrorContains(t, err, "missing.hcl")
+}
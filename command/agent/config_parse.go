@@ -103,6 +103,8 @@ func ParseConfigFile(path string) (*Config, error) {
 	// convert strings to time.Durations
 	tds := []durationConversionMap{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL, nil},
+		{"network_gc_interval", &c.Client.NetworkGCInterval, &c.Client.NetworkGCIntervalHCL, nil},
+		{"alloc_start_interval", &c.Client.AllocStartInterval, &c.Client.AllocStartIntervalHCL, nil},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.RoleTTL, &c.ACL.RoleTTLHCL, nil},
@@ -112,6 +114,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"server.heartbeat_grace", &c.Server.HeartbeatGrace, &c.Server.HeartbeatGraceHCL, nil},
 		{"server.min_heartbeat_ttl", &c.Server.MinHeartbeatTTL, &c.Server.MinHeartbeatTTLHCL, nil},
 		{"server.failover_heartbeat_ttl", &c.Server.FailoverHeartbeatTTL, &c.Server.FailoverHeartbeatTTLHCL, nil},
+		{"server.plan_queue_aging_interval", &c.Server.PlanQueueAgingInterval, &c.Server.PlanQueueAgingIntervalHCL, nil},
 		{"server.plan_rejection_tracker.node_window", &c.Server.PlanRejectionTracker.NodeWindow, &c.Server.PlanRejectionTracker.NodeWindowHCL, nil},
 		{"server.retry_interval", &c.Server.RetryInterval, &c.Server.RetryIntervalHCL, nil},
 		{"server.server_join.retry_interval", &c.Server.ServerJoin.RetryInterval, &c.Server.ServerJoin.RetryIntervalHCL, nil},
@@ -120,6 +123,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"telemetry.in_memory_collection_interval", &c.Telemetry.inMemoryCollectionInterval, &c.Telemetry.InMemoryCollectionInterval, nil},
 		{"telemetry.in_memory_retention_period", &c.Telemetry.inMemoryRetentionPeriod, &c.Telemetry.InMemoryRetentionPeriod, nil},
 		{"telemetry.collection_interval", &c.Telemetry.collectionInterval, &c.Telemetry.CollectionInterval, nil},
+		{"telemetry.label_cardinality_ttl", &c.Telemetry.labelCardinalityTTL, &c.Telemetry.LabelCardinalityTTL, nil},
 		{"client.template.block_query_wait", nil, &c.Client.TemplateConfig.BlockQueryWaitTimeHCL,
 			func(d *time.Duration) {
 				c.Client.TemplateConfig.BlockQueryWaitTime = d
@@ -183,6 +187,20 @@ func ParseConfigFile(path string) (*Config, error) {
 			&c.Reporting.ExportInterval, &c.Reporting.ExportIntervalHCL, nil},
 	}
 
+	if c.Server.SnapshotUpload != nil {
+		tds = append(tds, durationConversionMap{
+			"server.snapshot_upload.interval", &c.Server.SnapshotUpload.Interval,
+			&c.Server.SnapshotUpload.IntervalHCL, nil,
+		})
+	}
+
+	if c.Server.JobAdmissionWebhook != nil {
+		tds = append(tds, durationConversionMap{
+			"server.job_admission_webhook.timeout", &c.Server.JobAdmissionWebhook.Timeout,
+			&c.Server.JobAdmissionWebhook.TimeoutHCL, nil,
+		})
+	}
+
 	// Parse durations for Consul and Vault config blocks if provided.
 	for _, consulConfig := range c.Consuls {
 		// Capture consulConfig inside the loop so the parse duration function
@@ -341,6 +359,33 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
+	// Remove DeploymentWebhook extra keys
+	for _, w := range c.Server.DeploymentWebhooks {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, w.Name)
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "deployment_webhook")
+	}
+
+	// Remove DeploymentMetrics extra keys
+	if c.Server.DeploymentMetrics != nil {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "deployment_metrics")
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "deployment_metrics")
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
+	}
+
+	// Remove SnapshotUpload extra keys
+	if c.Server.SnapshotUpload != nil {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "snapshot_upload")
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "snapshot_upload")
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
+	}
+
+	// Remove JobAdmissionWebhook extra keys
+	if c.Server.JobAdmissionWebhook != nil {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "job_admission_webhook")
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "job_admission_webhook")
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "telemetry")
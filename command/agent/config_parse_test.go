@@ -89,6 +89,7 @@ var basicConfig = &Config{
 		GCMaxAllocs:           50,
 		NoHostUUID:            pointer.Of(false),
 		DisableRemoteExec:     true,
+		EnableStatusPage:      true,
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
@@ -122,6 +123,8 @@ var basicConfig = &Config{
 		MaxHeartbeatsPerSecond:    11.0,
 		FailoverHeartbeatTTL:      330 * time.Second,
 		FailoverHeartbeatTTLHCL:   "330s",
+		PlanQueueAgingInterval:    45 * time.Second,
+		PlanQueueAgingIntervalHCL: "45s",
 		RetryJoin:                 []string{"1.1.1.1", "2.2.2.2"},
 		StartJoin:                 []string{"1.1.1.1", "2.2.2.2"},
 		RetryInterval:             15 * time.Second,
@@ -129,6 +132,8 @@ var basicConfig = &Config{
 		RejoinAfterLeave:          true,
 		RetryMaxAttempts:          3,
 		NonVotingServer:           true,
+		ReadReplica:               true,
+		IdentityMetaClaims:        []string{"owner"},
 		RedundancyZone:            "foo",
 		UpgradeVersion:            "0.8.0",
 		EncryptKey:                "abc",
@@ -1182,3 +1187,100 @@ func TestConfig_Template(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_DeploymentWebhooks(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			cfg := DefaultConfig()
+			fc, err := LoadConfig("testdata/deployment_webhooks." + suffix)
+			must.NoError(t, err)
+			must.SliceEmpty(t, fc.Server.ExtraKeysHCL)
+			cfg = cfg.Merge(fc)
+
+			must.Eq(t, []*config.DeploymentWebhookConfig{
+				{
+					Name:   "ci",
+					URL:    "https://ci.example.com/hooks/nomad",
+					Secret: "s3cr3t",
+					Events: []string{"failed", "successful"},
+				},
+				{
+					Name: "chat",
+					URL:  "http://chat.example.com",
+				},
+			}, cfg.Server.DeploymentWebhooks)
+		})
+	}
+}
+
+func TestConfig_DeploymentMetrics(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			cfg := DefaultConfig()
+			fc, err := LoadConfig("testdata/deployment_metrics." + suffix)
+			must.NoError(t, err)
+			must.SliceEmpty(t, fc.Server.ExtraKeysHCL)
+			cfg = cfg.Merge(fc)
+
+			must.Eq(t, &config.DeploymentMetricsConfig{
+				PrometheusAddress: "http://prometheus.example.com:9090",
+			}, cfg.Server.DeploymentMetrics)
+		})
+	}
+}
+
+func TestConfig_SnapshotUpload(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			cfg := DefaultConfig()
+			fc, err := LoadConfig("testdata/snapshot_upload." + suffix)
+			must.NoError(t, err)
+			must.SliceEmpty(t, fc.Server.ExtraKeysHCL)
+			cfg = cfg.Merge(fc)
+
+			must.Eq(t, &config.SnapshotUploadConfig{
+				Storage:     "s3://backups/nomad",
+				Interval:    time.Hour,
+				IntervalHCL: "1h",
+				Retain:      24,
+				Region:      "us-east-1",
+				SSE:         "aws:kms",
+				KMSKeyID:    "alias/nomad",
+			}, cfg.Server.SnapshotUpload)
+			must.NoError(t, cfg.Server.SnapshotUpload.Validate())
+		})
+	}
+}
+
+func TestConfig_JobAdmissionWebhook(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			cfg := DefaultConfig()
+			fc, err := LoadConfig("testdata/job_admission_webhook." + suffix)
+			must.NoError(t, err)
+			must.SliceEmpty(t, fc.Server.ExtraKeysHCL)
+			cfg = cfg.Merge(fc)
+
+			must.Eq(t, &config.JobAdmissionWebhookConfig{
+				URL: "https://admission.example.com/jobs",
+				TLS: &config.JobAdmissionWebhookTLSConfig{
+					CAFile:   "/etc/nomad/admission-ca.pem",
+					CertFile: "/etc/nomad/admission-cert.pem",
+					KeyFile:  "/etc/nomad/admission-key.pem",
+				},
+				Timeout:    5 * time.Second,
+				TimeoutHCL: "5s",
+				FailPolicy: "ignore",
+			}, cfg.Server.JobAdmissionWebhook)
+			must.NoError(t, cfg.Server.JobAdmissionWebhook.Validate())
+		})
+	}
+}
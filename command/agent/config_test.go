@@ -323,6 +323,7 @@ func TestConfig_Merge(t *testing.T) {
 			MemoryMB:          105,
 			MaxKillTimeout:    "50s",
 			DisableRemoteExec: false,
+			RecordRemoteExec:  true,
 			TemplateConfig: &client.ClientTemplateConfig{
 				FunctionDenylist:   client.DefaultTemplateFunctionDenylist,
 				DisableSandbox:     false,
@@ -349,32 +350,35 @@ func TestConfig_Merge(t *testing.T) {
 			NomadServiceDiscovery: pointer.Of(false),
 		},
 		Server: &ServerConfig{
-			Enabled:                true,
-			AuthoritativeRegion:    "global2",
-			BootstrapExpect:        2,
-			DataDir:                "/tmp/data2",
-			ProtocolVersion:        2,
-			RaftProtocol:           2,
-			RaftMultiplier:         pointer.Of(6),
-			RaftSnapshotThreshold:  pointer.Of(100),
-			RaftSnapshotInterval:   pointer.Of("30m"),
-			RaftTrailingLogs:       pointer.Of(200),
-			NumSchedulers:          pointer.Of(2),
-			EnabledSchedulers:      []string{structs.JobTypeBatch},
-			NodeGCThreshold:        "12h",
-			BatchEvalGCThreshold:   "4h",
-			HeartbeatGrace:         2 * time.Minute,
-			MinHeartbeatTTL:        2 * time.Minute,
-			MaxHeartbeatsPerSecond: 200.0,
-			RejoinAfterLeave:       true,
-			StartJoin:              []string{"1.1.1.1"},
-			RetryJoin:              []string{"1.1.1.1"},
-			RetryInterval:          time.Second * 10,
-			NonVotingServer:        true,
-			RedundancyZone:         "bar",
-			UpgradeVersion:         "bar",
-			EnableEventBroker:      pointer.Of(true),
-			EventBufferSize:        pointer.Of(100),
+			Enabled:                 true,
+			AuthoritativeRegion:     "global2",
+			BootstrapExpect:         2,
+			DataDir:                 "/tmp/data2",
+			ProtocolVersion:         2,
+			RaftProtocol:            2,
+			RaftMultiplier:          pointer.Of(6),
+			RaftSnapshotThreshold:   pointer.Of(100),
+			RaftSnapshotInterval:    pointer.Of("30m"),
+			RaftTrailingLogs:        pointer.Of(200),
+			RaftSnapshotPersistRate: pointer.Of("100MB"),
+			NumSchedulers:           pointer.Of(2),
+			EnabledSchedulers:       []string{structs.JobTypeBatch},
+			NodeGCThreshold:         "12h",
+			BatchEvalGCThreshold:    "4h",
+			HeartbeatGrace:          2 * time.Minute,
+			MinHeartbeatTTL:         2 * time.Minute,
+			MaxHeartbeatsPerSecond:  200.0,
+			RejoinAfterLeave:        true,
+			StartJoin:               []string{"1.1.1.1"},
+			RetryJoin:               []string{"1.1.1.1"},
+			RetryInterval:           time.Second * 10,
+			NonVotingServer:         true,
+			ReadReplica:             true,
+			IdentityMetaClaims:      []string{"owner"},
+			RedundancyZone:          "bar",
+			UpgradeVersion:          "bar",
+			EnableEventBroker:       pointer.Of(true),
+			EventBufferSize:         pointer.Of(100),
 			PlanRejectionTracker: &PlanRejectionTracker{
 				Enabled:       pointer.Of(true),
 				NodeThreshold: 100,
@@ -1846,3 +1850,24 @@ func Test_mergeKEKProviderConfigs(t *testing.T) {
 		},
 	}, result)
 }
+
+func TestConfig_Redacted_DeploymentWebhooks(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &Config{
+		Server: &ServerConfig{
+			DeploymentWebhooks: []*config.DeploymentWebhookConfig{
+				{Name: "signed", URL: "https://example.com/hook", Secret: "hunter2"},
+				{Name: "unsigned", URL: "https://example.com/other"},
+			},
+		},
+	}
+
+	redacted := c.Redacted()
+	must.Eq(t, "<redacted>", redacted.Server.DeploymentWebhooks[0].Secret)
+	must.Eq(t, "", redacted.Server.DeploymentWebhooks[1].Secret)
+	must.Eq(t, "https://example.com/hook", redacted.Server.DeploymentWebhooks[0].URL)
+
+	// The original config is not modified
+	must.Eq(t, "hunter2", c.Server.DeploymentWebhooks[0].Secret)
+}
@@ -53,6 +53,9 @@ func (s *HTTPServer) DeploymentSpecificRequest(resp http.ResponseWriter, req *ht
 	case strings.HasPrefix(path, "unblock/"):
 		deploymentID := strings.TrimPrefix(path, "unblock/")
 		return s.deploymentUnblock(resp, req, deploymentID)
+	case strings.HasSuffix(path, "/multiregion"):
+		deploymentID := strings.TrimSuffix(path, "/multiregion")
+		return s.deploymentMultiregion(resp, req, deploymentID)
 	default:
 		return s.deploymentQuery(resp, req, path)
 	}
@@ -203,6 +206,30 @@ func (s *HTTPServer) deploymentAllocations(resp http.ResponseWriter, req *http.R
 	return out.Allocations, nil
 }
 
+func (s *HTTPServer) deploymentMultiregion(resp http.ResponseWriter, req *http.Request, deploymentID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.DeploymentSpecificRequest{
+		DeploymentID: deploymentID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.DeploymentMultiregionResponse
+	if err := s.agent.RPC("Deployment.Multiregion", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Regions == nil {
+		out.Regions = make([]*structs.MultiregionDeploymentRegion, 0)
+	}
+	return out.Regions, nil
+}
+
 func (s *HTTPServer) deploymentQuery(resp http.ResponseWriter, req *http.Request, deploymentID string) (interface{}, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
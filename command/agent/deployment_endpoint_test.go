@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -169,6 +170,39 @@ func TestHTTP_DeploymentQuery(t *testing.T) {
 	})
 }
 
+func TestHTTP_DeploymentMultiregion(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		j := mock.MultiregionJob()
+		j.Multiregion.Regions = []*structs.MultiregionRegion{{Name: "global"}}
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, j))
+
+		d := mock.Deployment()
+		d.JobID = j.ID
+		d.JobVersion = 0
+		d.IsMultiregion = true
+		d.Status = structs.DeploymentStatusBlocked
+		must.NoError(t, state.UpsertDeployment(1000, d))
+
+		// Make the HTTP request
+		req, err := http.NewRequest(http.MethodGet, "/v1/deployment/"+d.ID+"/multiregion", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.DeploymentSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, "1000", respW.Result().Header.Get("X-Nomad-Index"))
+
+		out := obj.([]*structs.MultiregionDeploymentRegion)
+		must.Len(t, 1, out)
+		must.Eq(t, "global", out[0].Region)
+		must.Eq(t, d.ID, out[0].Deployment.ID)
+		must.False(t, out[0].Blocking)
+	})
+}
+
 func TestHTTP_DeploymentPause(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
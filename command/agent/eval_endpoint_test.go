@@ -63,6 +63,17 @@ func TestHTTP_EvalList(t *testing.T) {
 		// check response body
 		require.Len(t, obj.([]*structs.Evaluation), 1, "expected 1 eval")
 
+		// projected list request
+		req, err = http.NewRequest(http.MethodGet,
+			fmt.Sprintf("/v1/evaluations?job=%s&fields=ID,JobID", eval2.JobID), nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.EvalsRequest(respW, req)
+		require.NoError(t, err)
+
+		// check response body
+		must.Eq(t, []map[string]any{{"ID": eval2.ID, "JobID": eval2.JobID}},
+			obj.([]map[string]any))
 	})
 }
 
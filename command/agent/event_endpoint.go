@@ -41,9 +41,24 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 		return nil, CodedError(400, fmt.Sprintf("Invalid topic query: %v", err))
 	}
 
+	var durable bool
+	if durableStr := query.Get("durable"); durableStr != "" {
+		durable, err = strconv.ParseBool(durableStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Unable to parse durable: %v", err))
+		}
+	}
+
+	resumeToken := query.Get("resume_token")
+	if resumeToken != "" && index != 0 {
+		return nil, CodedError(400, "index and resume_token are mutually exclusive")
+	}
+
 	args := &structs.EventStreamRequest{
-		Topics: topics,
-		Index:  index,
+		Topics:      topics,
+		Index:       index,
+		Durable:     durable,
+		ResumeToken: resumeToken,
 	}
 	resp.Header().Set("Content-Type", "application/json")
 	resp.Header().Set("Cache-Control", "no-cache")
@@ -20,7 +20,6 @@ import (
 	"time"
 
 	assetfs "github.com/elazarl/go-bindata-assetfs"
-	"github.com/gorilla/handlers"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-connlimit"
 	log "github.com/hashicorp/go-hclog"
@@ -93,6 +92,7 @@ type RPCer interface {
 	Stats() map[string]map[string]string
 	GetConfig() *Config
 	GetMetricsSink() *metrics.InmemSink
+	ReloadStatus() *ReloadStatus
 }
 
 // HTTPServer is used to wrap an Agent and expose it over an HTTP interface
@@ -184,7 +184,7 @@ func NewHTTPServers(agent *Agent, config *Config) ([]*HTTPServer, error) {
 		// Create HTTP server with timeouts
 		httpServer := http.Server{
 			Addr:      srv.Addr,
-			Handler:   handlers.CompressHandler(srv.mux),
+			Handler:   newCompressHandler(srv.mux),
 			ConnState: makeConnState(config.TLSConfig.EnableHTTP, handshakeTimeout, maxConns, srv.logger),
 			ErrorLog:  newHTTPServerLogger(srv.logger),
 		}
@@ -386,6 +386,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/jobs", s.wrap(s.JobsRequest))
 	s.mux.HandleFunc("/v1/jobs/parse", s.wrap(s.JobsParseRequest))
 	s.mux.HandleFunc("/v1/jobs/statuses", s.wrap(s.JobStatusesRequest))
+	s.mux.HandleFunc("/v1/jobs/purged", s.wrap(s.JobsPurgedRequest))
 	s.mux.HandleFunc("/v1/job/", s.wrap(s.JobSpecificRequest))
 
 	s.mux.HandleFunc("/v1/nodes", s.wrap(s.NodesRequest))
@@ -395,6 +396,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/node/pool/", s.wrap(s.NodePoolSpecificRequest))
 
 	s.mux.HandleFunc("/v1/allocations", s.wrap(s.AllocsRequest))
+	s.mux.HandleFunc("/v1/allocations/batch", s.wrap(s.AllocsBatchRequest))
 	s.mux.HandleFunc("/v1/allocation/", s.wrap(s.AllocSpecificRequest))
 
 	s.mux.HandleFunc("/v1/evaluations", s.wrap(s.EvalsRequest))
@@ -450,8 +452,10 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
 	s.mux.Handle("/v1/client/allocation/", wrapCORS(s.wrap(s.ClientAllocRequest)))
 	s.mux.Handle("/v1/client/metadata", wrapCORS(s.wrap(s.NodeMetaRequest)))
+	s.mux.HandleFunc("/v1/task/leadership/", s.wrap(s.TaskLeadershipRequest))
 
 	s.mux.HandleFunc("/v1/agent/self", s.wrap(s.AgentSelfRequest))
+	s.mux.HandleFunc("/v1/agent/config-effective", s.wrap(s.AgentEffectiveConfigRequest))
 	s.mux.HandleFunc("/v1/agent/join", s.wrap(s.AgentJoinRequest))
 	s.mux.HandleFunc("/v1/agent/members", s.wrap(s.AgentMembersRequest))
 	s.mux.HandleFunc("/v1/agent/force-leave", s.wrap(s.AgentForceLeaveRequest))
@@ -461,10 +465,13 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/agent/keyring/", s.wrap(s.KeyringOperationRequest))
 	s.mux.HandleFunc("/v1/agent/health", s.wrap(s.HealthRequest))
 	s.mux.HandleFunc("/v1/agent/host", s.wrap(s.AgentHostRequest))
+	s.mux.HandleFunc("/v1/agent/reload", s.wrap(s.AgentReloadRequest))
 
 	// Register our service registration handlers.
 	s.mux.HandleFunc("/v1/services", s.wrap(s.ServiceRegistrationListRequest))
 	s.mux.HandleFunc("/v1/service/", s.wrap(s.ServiceRegistrationRequest))
+	s.mux.HandleFunc("/v1/service-grants", s.wrap(s.ServiceGrantsRequest))
+	s.mux.HandleFunc("/v1/service-grant/", s.wrap(s.ServiceGrantSpecificRequest))
 
 	// Monitor is *not* an untrusted endpoint despite the log contents
 	// potentially containing unsanitized user input. Monitor, like
@@ -483,6 +490,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 
 	s.mux.HandleFunc("/v1/scaling/policies", s.wrap(s.ScalingPoliciesRequest))
 	s.mux.HandleFunc("/v1/scaling/policy/", s.wrap(s.ScalingPolicySpecificRequest))
+	s.mux.HandleFunc("/v1/scaling/events", s.wrap(s.ScalingEventsRequest))
 
 	s.mux.HandleFunc("/v1/status/leader", s.wrap(s.StatusLeaderRequest))
 	s.mux.HandleFunc("/v1/status/peers", s.wrap(s.StatusPeersRequest))
@@ -501,6 +509,12 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/plan-queue", s.wrap(s.OperatorPlanQueue))
+	s.mux.HandleFunc("/v1/operator/scheduler/eval-broker", s.wrap(s.OperatorEvalBroker))
+	s.mux.HandleFunc("/v1/operator/scheduler/simulate", s.wrap(s.OperatorSchedulerSimulate))
+	s.mux.HandleFunc("/v1/operator/plan-rejections", s.wrap(s.OperatorPlanRejections))
+	s.mux.HandleFunc("/v1/operator/state/usage", s.wrap(s.OperatorStateUsage))
+	s.mux.HandleFunc("/v1/operator/replication/conflicts", s.wrap(s.OperatorReplicationConflicts))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
 
@@ -534,6 +548,10 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	}
 	s.mux.Handle("/", s.handleRootFallthrough())
 
+	if agentConfig.Client != nil && agentConfig.Client.Enabled && agentConfig.Client.EnableStatusPage {
+		s.mux.HandleFunc("/v1/client/status-page", s.wrapNonJSON(s.ClientStatusPageRequest))
+	}
+
 	if enableDebug {
 		if !agentConfig.DevMode {
 			s.logger.Warn("enable_debug is set to true. This is insecure and should not be enabled in production")
@@ -751,6 +769,8 @@ func (s *HTTPServer) wrap(handler func(resp http.ResponseWriter, req *http.Reque
 			}
 
 			resp.Header().Set(contentTypeHeader, plainContentType)
+			resp.Header().Set(structs.ErrorCodeHeader, structs.ErrorCode(code, err))
+			setRetryAfter(resp, code, errMsg)
 			resp.WriteHeader(code)
 			resp.Write([]byte(errMsg))
 			if isAPIClientError(code) {
@@ -810,6 +830,8 @@ func (s *HTTPServer) wrapNonJSON(handler func(resp http.ResponseWriter, req *htt
 		if err != nil {
 			code, errMsg := errCodeFromHandler(err)
 			resp.Header().Set(contentTypeHeader, plainContentType)
+			resp.Header().Set(structs.ErrorCodeHeader, structs.ErrorCode(code, err))
+			setRetryAfter(resp, code, errMsg)
 			resp.WriteHeader(code)
 			resp.Write([]byte(errMsg))
 			if isAPIClientError(code) {
@@ -832,6 +854,16 @@ func isAPIClientError(code int) bool {
 	return 400 <= code && code <= 499
 }
 
+// setRetryAfter sets the Retry-After header of responses to requests rejected
+// because the caller has too many concurrent blocking queries.
+func setRetryAfter(resp http.ResponseWriter, code int, errMsg string) {
+	if code == http.StatusServiceUnavailable &&
+		strings.Contains(errMsg, structs.ErrTooManyBlockingQueries.Error()) {
+		resp.Header().Set("Retry-After",
+			strconv.Itoa(int(structs.BlockingQueryRetryAfter.Seconds())))
+	}
+}
+
 // decodeBody is used to decode a JSON request body
 func decodeBody(req *http.Request, out interface{}) error {
 
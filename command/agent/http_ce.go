@@ -8,6 +8,11 @@ package agent
 
 import (
 	"net/http"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 // registerEnterpriseHandlers is a no-op for the oss release
@@ -32,15 +37,193 @@ func (s *HTTPServer) entOnly(resp http.ResponseWriter, req *http.Request) (inter
 
 // auditHandler wraps the passed handlerFn
 func (s *HTTPServer) auditHandler(h handlerFn) handlerFn {
-	return h
+	return func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		if s.eventAuditor == nil || !s.eventAuditor.Enabled() {
+			return h(resp, req)
+		}
+
+		ev := s.newAuditEvent(req)
+		if err := s.auditEvent(req, ev); err != nil {
+			return nil, err
+		}
+
+		obj, rspErr := h(resp, req)
+
+		if err := s.auditEvent(req, ev.complete(auditResponseFromErr(rspErr))); err != nil {
+			return nil, err
+		}
+		return obj, rspErr
+	}
 }
 
-// auditHTTPHandler wraps  the passed handlerByteFn
+// auditNonJSONHandler wraps the passed handlerByteFn
 func (s *HTTPServer) auditNonJSONHandler(h handlerByteFn) handlerByteFn {
-	return h
+	return func(resp http.ResponseWriter, req *http.Request) ([]byte, error) {
+		if s.eventAuditor == nil || !s.eventAuditor.Enabled() {
+			return h(resp, req)
+		}
+
+		ev := s.newAuditEvent(req)
+		if err := s.auditEvent(req, ev); err != nil {
+			return nil, err
+		}
+
+		obj, rspErr := h(resp, req)
+
+		if err := s.auditEvent(req, ev.complete(auditResponseFromErr(rspErr))); err != nil {
+			return nil, err
+		}
+		return obj, rspErr
+	}
 }
 
 // auditHTTPHandler wraps the passed http.Handler
 func (s *HTTPServer) auditHTTPHandler(h http.Handler) http.Handler {
-	return h
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if s.eventAuditor == nil || !s.eventAuditor.Enabled() {
+			h.ServeHTTP(resp, req)
+			return
+		}
+
+		ev := s.newAuditEvent(req)
+		if err := s.auditEvent(req, ev); err != nil {
+			http.Error(resp, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw := &auditResponseWriter{ResponseWriter: resp, status: http.StatusOK}
+		h.ServeHTTP(rw, req)
+
+		// The response has already been written, so a failure to record its
+		// completion can only be logged.
+		if err := s.auditEvent(req, ev.complete(&auditResponse{StatusCode: rw.status})); err != nil {
+			s.logger.Error("failed to write audit event", "error", err)
+		}
+	})
+}
+
+// newAuditEvent returns the OperationReceived event for the request.
+func (s *HTTPServer) newAuditEvent(req *http.Request) *auditEvent {
+	var namespace string
+	parseNamespace(req, &namespace)
+
+	ev := &auditEvent{
+		ID:        uuid.Generate(),
+		Stage:     auditStageReceived,
+		Type:      auditEventType,
+		Timestamp: time.Now(),
+		Version:   1,
+		Auth:      s.auditAuth(req),
+		Request: &auditRequest{
+			ID:        uuid.Generate(),
+			Operation: req.Method,
+			Endpoint:  req.URL.RequestURI(),
+			Namespace: &auditNamespace{ID: namespace},
+			RequestMeta: &auditRequestMeta{
+				RemoteAddress: req.RemoteAddr,
+				UserAgent:     req.UserAgent(),
+			},
+			NodeMeta: &auditNodeMeta{IP: s.Addr},
+		},
+	}
+	if req.ContentLength > 0 {
+		ev.Request.Payload = &auditPayloadStats{
+			ContentType:   req.Header.Get(contentTypeHeader),
+			ContentLength: req.ContentLength,
+		}
+	}
+	return ev
+}
+
+// complete returns a copy of the event for the OperationComplete stage.
+func (ev *auditEvent) complete(rsp *auditResponse) *auditEvent {
+	c := *ev
+	c.Stage = auditStageComplete
+	c.Response = rsp
+	return &c
+}
+
+func auditResponseFromErr(err error) *auditResponse {
+	if err == nil {
+		return &auditResponse{StatusCode: http.StatusOK}
+	}
+	code, msg := errCodeFromHandler(err)
+	return &auditResponse{StatusCode: code, Error: msg}
+}
+
+// auditEvent sends the event to the auditor. An error is only returned if the
+// event could not be written to a sink with an enforced delivery guarantee.
+func (s *HTTPServer) auditEvent(req *http.Request, ev *auditEvent) error {
+	if err := s.eventAuditor.Event(req.Context(), auditEventType, ev); err != nil {
+		s.logger.Error("failed to write audit event", "error", err)
+		return CodedError(http.StatusInternalServerError, "failed to write audit log")
+	}
+	return nil
+}
+
+// auditAuth resolves the actor of the request. Requests whose token can't be
+// resolved are recorded without an actor, as they are rejected by the
+// endpoint.
+func (s *HTTPServer) auditAuth(req *http.Request) *auditAuth {
+	if acl := s.agent.GetConfig().ACL; acl == nil || !acl.Enabled {
+		return nil
+	}
+
+	var secret string
+	s.parseToken(req, &secret)
+
+	var ident *structs.AuthenticatedIdentity
+	if srv := s.agent.Server(); srv != nil {
+		switch {
+		case secret == "":
+			ident = &structs.AuthenticatedIdentity{ACLToken: structs.AnonymousACLToken}
+		case helper.IsUUID(secret):
+			token, err := srv.State().ACLTokenBySecretID(nil, secret)
+			if err != nil || token == nil {
+				return nil
+			}
+			ident = &structs.AuthenticatedIdentity{ACLToken: token}
+		default:
+			claims, err := srv.VerifyClaim(secret)
+			if err != nil {
+				return nil
+			}
+			ident = &structs.AuthenticatedIdentity{Claims: claims}
+		}
+	} else if c := s.agent.Client(); c != nil {
+		var err error
+		if ident, err = c.ResolveIdentity(secret); err != nil {
+			return nil
+		}
+	}
+
+	if token := ident.GetACLToken(); token != nil {
+		return &auditAuth{
+			AccessorID: token.AccessorID,
+			Name:       token.Name,
+			Policies:   token.Policies,
+			Global:     token.Global,
+			CreateTime: token.CreateTime,
+		}
+	}
+	if claims := ident.GetClaims(); claims != nil {
+		return &auditAuth{
+			AllocationID: claims.AllocationID,
+			Namespace:    claims.Namespace,
+			JobID:        claims.JobID,
+			TaskName:     claims.TaskName,
+		}
+	}
+	return nil
+}
+
+// auditResponseWriter records the status code written by an http.Handler.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
 }
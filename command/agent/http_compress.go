@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/handlers"
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdEncoders pools the zstd encoders used to compress responses, since
+// creating them is expensive.
+var zstdEncoders = sync.Pool{
+	New: func() any {
+		enc, _ := zstd.NewWriter(nil,
+			zstd.WithEncoderLevel(zstd.SpeedFastest),
+			zstd.WithEncoderConcurrency(1))
+		return enc
+	},
+}
+
+// newCompressHandler returns a handler compressing the responses of h with
+// zstd if the client accepts it, or with gzip or deflate otherwise.
+func newCompressHandler(h http.Handler) http.Handler {
+	fallback := handlers.CompressHandler(h)
+
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		// Upgraded connections, such as websockets, are never compressed
+		if req.Header.Get("Upgrade") != "" || !acceptsEncoding(req, "zstd") {
+			fallback.ServeHTTP(resp, req)
+			return
+		}
+
+		resp.Header().Add("Vary", "Accept-Encoding")
+		zw := &zstdResponseWriter{ResponseWriter: resp}
+		defer zw.Close()
+		h.ServeHTTP(zw, req)
+	})
+}
+
+// acceptsEncoding returns true if the Accept-Encoding header of the request
+// lists the encoding with a non-zero quality.
+func acceptsEncoding(req *http.Request, encoding string) bool {
+	for _, header := range req.Header.Values("Accept-Encoding") {
+		for _, part := range strings.Split(header, ",") {
+			name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+				continue
+			}
+
+			q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+			if !ok {
+				return true
+			}
+			quality, err := strconv.ParseFloat(q, 64)
+			return err == nil && quality > 0
+		}
+	}
+	return false
+}
+
+// zstdResponseWriter is an http.ResponseWriter compressing the response body
+// with zstd. The body is only compressed if the handler didn't encode it
+// already and the status code allows a body.
+type zstdResponseWriter struct {
+	http.ResponseWriter
+
+	enc         *zstd.Encoder
+	wroteHeader bool
+}
+
+func (w *zstdResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	h := w.Header()
+	if code != http.StatusNoContent && code != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" {
+		h.Set("Content-Encoding", "zstd")
+		h.Del("Content-Length")
+
+		w.enc = zstdEncoders.Get().(*zstd.Encoder)
+		w.enc.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *zstdResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.enc == nil {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.enc.Write(p)
+}
+
+// Flush writes the data compressed so far to the client, so that streaming
+// endpoints keep working with compression.
+func (w *zstdResponseWriter) Flush() {
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close writes the end of the compressed body and releases the encoder.
+func (w *zstdResponseWriter) Close() error {
+	if w.enc == nil {
+		return nil
+	}
+	err := w.enc.Close()
+	w.enc.Reset(nil)
+	zstdEncoders.Put(w.enc)
+	w.enc = nil
+	return err
+}
+
+// Unwrap returns the underlying http.ResponseWriter for
+// http.ResponseController.
+func (w *zstdResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
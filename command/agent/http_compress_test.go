@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/klauspost/compress/zstd"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_CompressHandler(t *testing.T) {
+	ci.Parallel(t)
+
+	// Large enough for the gzip handler to compress it
+	body := strings.Repeat(`{"ID":"example"}`, 1024)
+	handler := newCompressHandler(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.Header().Set("Content-Type", "application/json")
+		io.WriteString(resp, body)
+	}))
+
+	decode := map[string]func(t *testing.T, r io.Reader) io.Reader{
+		"": func(t *testing.T, r io.Reader) io.Reader {
+			return r
+		},
+		"gzip": func(t *testing.T, r io.Reader) io.Reader {
+			gr, err := gzip.NewReader(r)
+			must.NoError(t, err)
+			return gr
+		},
+		"zstd": func(t *testing.T, r io.Reader) io.Reader {
+			zr, err := zstd.NewReader(r)
+			must.NoError(t, err)
+			t.Cleanup(zr.Close)
+			return zr
+		},
+	}
+
+	cases := []struct {
+		name           string
+		acceptEncoding string
+		expected       string
+	}{
+		{name: "none", acceptEncoding: "", expected: ""},
+		{name: "gzip", acceptEncoding: "gzip", expected: "gzip"},
+		{name: "zstd", acceptEncoding: "zstd", expected: "zstd"},
+		{name: "zstd preferred", acceptEncoding: "gzip, deflate, zstd", expected: "zstd"},
+		{name: "zstd refused", acceptEncoding: "gzip, zstd;q=0", expected: "gzip"},
+		{name: "unsupported", acceptEncoding: "br", expected: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/allocations", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			respW := httptest.NewRecorder()
+			handler.ServeHTTP(respW, req)
+
+			resp := respW.Result()
+			must.Eq(t, http.StatusOK, resp.StatusCode)
+			must.Eq(t, tc.expected, resp.Header.Get("Content-Encoding"))
+			must.Eq(t, "application/json", resp.Header.Get("Content-Type"))
+
+			out, err := io.ReadAll(decode[tc.expected](t, resp.Body))
+			must.NoError(t, err)
+			must.Eq(t, body, string(out))
+		})
+	}
+}
+
+func TestHTTP_CompressHandler_NoBody(t *testing.T) {
+	ci.Parallel(t)
+
+	handler := newCompressHandler(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/var/example", nil)
+	req.Header.Set("Accept-Encoding", "zstd")
+	respW := httptest.NewRecorder()
+	handler.ServeHTTP(respW, req)
+
+	resp := respW.Result()
+	must.Eq(t, http.StatusNoContent, resp.StatusCode)
+	must.Eq(t, "", resp.Header.Get("Content-Encoding"))
+	must.Eq(t, 0, respW.Body.Len())
+}
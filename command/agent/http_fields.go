@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// fieldsProjection is the set of top-level fields of the objects of a list
+// response requested with the fields query parameter.
+type fieldsProjection struct {
+	// names maps the requested field names to the index of the field in
+	// the struct type of the list objects.
+	names map[string]int
+}
+
+// parseFieldsProjection parses the fields query parameter, a comma separated
+// list of the fields of the objects of type T to include in a list response.
+// It returns nil if the parameter is not set, and an error if a field is not
+// a field of T.
+func parseFieldsProjection[T any](req *http.Request) (*fieldsProjection, error) {
+	raw := req.URL.Query().Get("fields")
+	if raw == "" {
+		return nil, nil
+	}
+
+	available := encodedFields(reflect.TypeFor[T]())
+	p := &fieldsProjection{names: make(map[string]int)}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		idx, ok := available[name]
+		if !ok {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("unknown field %q", name))
+		}
+		p.names[name] = idx
+	}
+	if len(p.names) == 0 {
+		return nil, CodedError(http.StatusBadRequest, "fields must list at least one field")
+	}
+	return p, nil
+}
+
+// encodedFields returns the index of the fields of the struct type t, or of
+// the struct t points to, by the name they are encoded with in responses.
+func encodedFields(t reflect.Type) map[string]int {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		for _, key := range []string{"codec", "json"} {
+			if tag, ok := f.Tag.Lookup(key); ok {
+				name, _, _ = strings.Cut(tag, ",")
+				break
+			}
+		}
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = i
+	}
+	return fields
+}
+
+// projectFields returns the objects of list with only the fields of the
+// projection. The list is returned unmodified if the projection is nil.
+func projectFields[T any](list []T, p *fieldsProjection) any {
+	if p == nil {
+		return list
+	}
+
+	out := make([]map[string]any, 0, len(list))
+	for _, obj := range list {
+		v := reflect.Indirect(reflect.ValueOf(obj))
+		if !v.IsValid() {
+			out = append(out, nil)
+			continue
+		}
+
+		projected := make(map[string]any, len(p.names))
+		for name, idx := range p.names {
+			projected[name] = v.Field(idx).Interface()
+		}
+		out = append(out, projected)
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_FieldsProjection(t *testing.T) {
+	ci.Parallel(t)
+
+	evals := []*structs.Evaluation{mock.Eval(), mock.Eval()}
+
+	t.Run("unset", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/evaluations", nil)
+		p, err := parseFieldsProjection[*structs.Evaluation](req)
+		must.NoError(t, err)
+		must.Nil(t, p)
+		must.Eq[any](t, evals, projectFields(evals, p))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/evaluations?fields=ID,%20Status", nil)
+		p, err := parseFieldsProjection[*structs.Evaluation](req)
+		must.NoError(t, err)
+
+		out := projectFields(evals, p).([]map[string]any)
+		must.Len(t, 2, out)
+		for i, eval := range evals {
+			must.Eq(t, map[string]any{
+				"ID":     eval.ID,
+				"Status": eval.Status,
+			}, out[i])
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/evaluations?fields=ID,Unknown", nil)
+		_, err := parseFieldsProjection[*structs.Evaluation](req)
+		must.ErrorContains(t, err, `unknown field "Unknown"`)
+		codedErr, ok := err.(HTTPCodedError)
+		must.True(t, ok)
+		must.Eq(t, http.StatusBadRequest, codedErr.Code())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/evaluations?fields=,", nil)
+		_, err := parseFieldsProjection[*structs.Evaluation](req)
+		must.ErrorContains(t, err, "at least one field")
+	})
+}
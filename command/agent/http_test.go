@@ -302,6 +302,7 @@ func TestWrapNonJSON_Error(t *testing.T) {
 		must.Eq(t, []byte("not found"), respBody)
 		must.Eq(t, 404, resp.Code)
 		must.Eq(t, resp.Header().Get(contentTypeHeader), plainContentType)
+		must.Eq(t, structs.ErrCodeNotFound, resp.Header().Get(structs.ErrorCodeHeader))
 	}
 
 	// CodedError
@@ -313,10 +314,29 @@ func TestWrapNonJSON_Error(t *testing.T) {
 		must.Eq(t, []byte("unprocessable"), respBody)
 		must.Eq(t, 422, resp.Code)
 		must.Eq(t, resp.Header().Get(contentTypeHeader), plainContentType)
+		must.Eq(t, structs.ErrCodeBadRequest, resp.Header().Get(structs.ErrorCodeHeader))
 	}
 
 }
 
+func TestWrap_TooManyBlockingQueries(t *testing.T) {
+	ci.Parallel(t)
+	s := makeHTTPServer(t, nil)
+	defer s.Shutdown()
+
+	handler := func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		return nil, structs.NewErrRPCCodedf(503, "%s for IP 127.0.0.1: limit is 1",
+			structs.ErrTooManyBlockingQueries)
+	}
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/jobs?index=10", nil)
+	s.Server.wrap(handler)(resp, req)
+	must.Eq(t, 503, resp.Code)
+	must.Eq(t, "5", resp.Header().Get("Retry-After"))
+	must.Eq(t, structs.ErrCodeUnavailable, resp.Header().Get(structs.ErrorCodeHeader))
+}
+
 func TestPrettyPrint(t *testing.T) {
 	ci.Parallel(t)
 	testPrettyPrint("pretty=1", true, t)
@@ -386,6 +406,7 @@ func TestPermissionDenied(t *testing.T) {
 		s.Server.wrap(handler)(resp, req)
 		must.Eq(t, resp.Code, 403)
 		must.Eq(t, resp.Header().Get(contentTypeHeader), plainContentType)
+		must.Eq(t, structs.ErrCodePermissionDenied, resp.Header().Get(structs.ErrorCodeHeader))
 	}
 
 	// When remote RPC is used the errors have "rpc error: " prependend
@@ -399,6 +420,7 @@ func TestPermissionDenied(t *testing.T) {
 		s.Server.wrap(handler)(resp, req)
 		must.Eq(t, resp.Code, 403)
 		must.Eq(t, resp.Header().Get(contentTypeHeader), plainContentType)
+		must.Eq(t, structs.ErrCodePermissionDenied, resp.Header().Get(structs.ErrorCodeHeader))
 	}
 }
 
@@ -418,6 +440,7 @@ func TestTokenNotFound(t *testing.T) {
 	s.Server.wrap(handler)(resp, req)
 	must.Eq(t, resp.Code, 403)
 	must.Eq(t, resp.Header().Get(contentTypeHeader), plainContentType)
+	must.Eq(t, structs.ErrCodeTokenNotFound, resp.Header().Get(structs.ErrorCodeHeader))
 }
 
 func TestParseWait(t *testing.T) {
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"maps"
@@ -78,12 +79,18 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/evaluations"):
 		jobID := strings.TrimSuffix(path, "/evaluations")
 		return s.jobEvaluations(resp, req, jobID)
+	case strings.HasSuffix(path, "/results"):
+		jobID := strings.TrimSuffix(path, "/results")
+		return s.jobResults(resp, req, jobID)
 	case strings.HasSuffix(path, "/periodic/force"):
 		jobID := strings.TrimSuffix(path, "/periodic/force")
 		return s.periodicForceRequest(resp, req, jobID)
 	case strings.HasSuffix(path, "/plan"):
 		jobID := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobID)
+	case strings.HasSuffix(path, "/drift"):
+		jobID := strings.TrimSuffix(path, "/drift")
+		return s.jobDrift(resp, req, jobID)
 	case strings.HasSuffix(path, "/summary"):
 		jobID := strings.TrimSuffix(path, "/summary")
 		return s.jobSummaryRequest(resp, req, jobID)
@@ -105,9 +112,18 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/deployment"):
 		jobID := strings.TrimSuffix(path, "/deployment")
 		return s.jobLatestDeployment(resp, req, jobID)
+	case strings.HasSuffix(path, "/snapshot"):
+		jobID := strings.TrimSuffix(path, "/snapshot")
+		return s.jobSnapshot(resp, req, jobID)
 	case strings.HasSuffix(path, "/stable"):
 		jobID := strings.TrimSuffix(path, "/stable")
 		return s.jobStable(resp, req, jobID)
+	case strings.HasSuffix(path, "/freeze"):
+		jobID := strings.TrimSuffix(path, "/freeze")
+		return s.jobFreeze(resp, req, jobID)
+	case strings.HasSuffix(path, "/restart"):
+		jobID := strings.TrimSuffix(path, "/restart")
+		return s.jobRestart(resp, req, jobID)
 	case strings.HasSuffix(path, "/scale"):
 		jobID := strings.TrimSuffix(path, "/scale")
 		return s.jobScale(resp, req, jobID)
@@ -206,6 +222,40 @@ func (s *HTTPServer) jobPlan(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobDrift(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.JobDriftRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.Job == nil {
+		return nil, CodedError(400, "Job must be specified")
+	}
+	if args.Job.ID == nil {
+		return nil, CodedError(400, "Job must have a valid ID")
+	}
+	if jobName != "" && *args.Job.ID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	sJob, writeReq := s.apiJobAndRequestToStructs(args.Job, req, args.WriteRequest)
+	driftReq := structs.JobDriftRequest{
+		Job:          sJob,
+		WriteRequest: *writeReq,
+	}
+
+	var out structs.JobDriftResponse
+	if err := s.agent.RPC("Job.Drift", &driftReq, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) ValidateJobRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	// Ensure request method is POST or PUT
 	if !(req.Method == http.MethodPost || req.Method == http.MethodPut) {
@@ -222,7 +272,9 @@ func (s *HTTPServer) ValidateJobRequest(resp http.ResponseWriter, req *http.Requ
 
 	job := ApiJobToStructJob(validateRequest.Job)
 	args := structs.JobValidateRequest{
-		Job: job,
+		Job:       job,
+		Lint:      validateRequest.Lint,
+		LintRules: ApiJobLintRulesToStructs(validateRequest.LintRules),
 		WriteRequest: structs.WriteRequest{
 			Region: validateRequest.Region,
 		},
@@ -238,15 +290,43 @@ func (s *HTTPServer) ValidateJobRequest(resp http.ResponseWriter, req *http.Requ
 	return out, nil
 }
 
+// ApiJobLintRulesToStructs converts user-supplied lint rules from the API to
+// their structs equivalent.
+func ApiJobLintRulesToStructs(in []*api.JobLintRule) []*structs.JobLintRule {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]*structs.JobLintRule, len(in))
+	for i, rule := range in {
+		out[i] = &structs.JobLintRule{
+			Name:        rule.Name,
+			Description: rule.Description,
+			Severity:    rule.Severity,
+			Scope:       rule.Scope,
+			Filter:      rule.Filter,
+			Message:     rule.Message,
+		}
+	}
+	return out
+}
+
 func (s *HTTPServer) periodicForceRequest(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
 
-	args := structs.PeriodicForceRequest{
-		JobID: jobName,
+	// The meta and env overrides are optional, so the payload may be empty.
+	// The length of the payload isn't always known, so an empty chunked
+	// payload is accepted as well.
+	var args structs.PeriodicForceRequest
+	if req.Body != nil && req.Body != http.NoBody {
+		if err := decodeBody(req, &args); err != nil && !errors.Is(err, io.EOF) {
+			return nil, CodedError(400, err.Error())
+		}
 	}
+	args.JobID = jobName
 	s.parseWriteRequest(req, &args.WriteRequest)
 
 	var out structs.PeriodicForceResponse
@@ -309,6 +389,29 @@ func (s *HTTPServer) jobEvaluations(resp http.ResponseWriter, req *http.Request,
 	return out.Evaluations, nil
 }
 
+func (s *HTTPServer) jobResults(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.JobSpecificRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobResultsResponse
+	if err := s.agent.RPC("Job.Results", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Results == nil {
+		out.Results = make([]*structs.AllocTaskResult, 0)
+	}
+	return out.Results, nil
+}
+
 func (s *HTTPServer) jobDeployments(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -354,6 +457,37 @@ func (s *HTTPServer) jobLatestDeployment(resp http.ResponseWriter, req *http.Req
 	return out.Deployment, nil
 }
 
+func (s *HTTPServer) jobSnapshot(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	all, _ := strconv.ParseBool(req.URL.Query().Get("all"))
+	args := structs.JobSpecificRequest{
+		JobID: jobID,
+		All:   all,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobSnapshotResponse
+	if err := s.agent.RPC("Job.Snapshot", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Snapshot == nil || out.Snapshot.Job == nil {
+		return nil, CodedError(404, "job not found")
+	}
+	if out.Snapshot.Evaluations == nil {
+		out.Snapshot.Evaluations = make([]*structs.Evaluation, 0)
+	}
+	if out.Snapshot.Deployments == nil {
+		out.Snapshot.Deployments = make([]*structs.Deployment, 0)
+	}
+	return out.Snapshot, nil
+}
+
 func (s *HTTPServer) jobActions(resp http.ResponseWriter, req *http.Request, jobID string) (any, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
@@ -667,6 +801,21 @@ func (s *HTTPServer) jobDelete(resp http.ResponseWriter, req *http.Request, jobI
 	}
 	args.NoShutdownDelay = noShutdownDelay
 
+	// Identify the audit query param and parse. Auditing only applies to
+	// purges, so reject requests that would silently not be audited.
+	auditStr := req.URL.Query().Get("audit")
+	var audit bool
+	if auditStr != "" {
+		var err error
+		audit, err = strconv.ParseBool(auditStr)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse value of %q (%v) as a bool: %v", "audit", auditStr, err)
+		}
+	}
+	if audit && !args.Purge {
+		return nil, CodedError(http.StatusBadRequest, "audit can only be used to purge jobs")
+	}
+
 	// Validate the evaluation priority if the user supplied a non-default
 	// value. It's more efficient to do it here, within the agent rather than
 	// sending a bad request for the server to reject.
@@ -679,8 +828,13 @@ func (s *HTTPServer) jobDelete(resp http.ResponseWriter, req *http.Request, jobI
 
 	s.parseWriteRequest(req, &args.WriteRequest)
 
+	method := "Job.Deregister"
+	if audit {
+		method = "Job.PurgeWithAudit"
+	}
+
 	var out structs.JobDeregisterResponse
-	if err := s.agent.RPC("Job.Deregister", &args, &out); err != nil {
+	if err := s.agent.RPC(method, &args, &out); err != nil {
 		return nil, err
 	}
 	setIndex(resp, out.Index)
@@ -745,6 +899,9 @@ func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
 		Message:        args.Message,
 		Error:          args.Error,
 		Meta:           args.Meta,
+		Source:         args.Source,
+		MetricSnapshot: args.MetricSnapshot,
+		DryRun:         args.DryRun,
 		JobModifyIndex: args.JobModifyIndex,
 	}
 	// parseWriteRequest overrides Namespace, Region and AuthToken
@@ -863,6 +1020,100 @@ func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request, jobI
 	return out, nil
 }
 
+func (s *HTTPServer) jobFreeze(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.JobFreezeRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.JobID == "" {
+		return nil, CodedError(400, "JobID must be specified")
+	}
+	if args.JobID != jobID {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	freezeRequest := structs.JobFreezeRequest{
+		JobID:  args.JobID,
+		Frozen: args.Frozen,
+	}
+	s.parseWriteRequest(req, &freezeRequest.WriteRequest)
+
+	var out structs.JobFreezeResponse
+	if err := s.agent.RPC("Job.Freeze", &freezeRequest, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) jobRestart(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.jobRestartQuery(resp, req, jobID)
+	case http.MethodPut, http.MethodPost:
+		return s.jobRestartUpdate(resp, req, jobID)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) jobRestartQuery(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	args := structs.JobSpecificRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleJobRestartResponse
+	if err := s.agent.RPC("Job.GetRestart", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Restart == nil {
+		return nil, CodedError(404, "job restart not found")
+	}
+	return out.Restart, nil
+}
+
+func (s *HTTPServer) jobRestartUpdate(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	var args api.JobRestartRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.JobID == "" {
+		return nil, CodedError(400, "JobID must be specified")
+	}
+	if args.JobID != jobID {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	restartRequest := structs.JobRestartRequest{
+		JobID:             args.JobID,
+		RestartID:         args.RestartID,
+		Reschedule:        args.Reschedule,
+		Batch:             args.Batch,
+		Status:            args.Status,
+		StatusDescription: args.StatusDescription,
+	}
+	s.parseWriteRequest(req, &restartRequest.WriteRequest)
+
+	var out structs.JobRestartResponse
+	if err := s.agent.RPC("Job.Restart", &restartRequest, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	args := structs.JobSummaryRequest{
 		JobID: jobID,
@@ -933,6 +1184,32 @@ func (s *HTTPServer) jobDispatchPayloadRequest(resp http.ResponseWriter, req *ht
 	return out, nil
 }
 
+// JobsPurgedRequest lists the audit records of the jobs purged with an audit,
+// optionally restricted to a single job ID.
+func (s *HTTPServer) JobsPurgedRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobPurgeHistoryRequest{
+		JobID: req.URL.Query().Get("job"),
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobPurgeHistoryResponse
+	if err := s.agent.RPC("Job.PurgeHistory", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Records == nil {
+		out.Records = make([]*structs.JobPurgeRecord, 0)
+	}
+	return out.Records, nil
+}
+
 // JobsParseRequest parses a hcl jobspec and returns a api.Job
 func (s *HTTPServer) JobsParseRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
@@ -1135,24 +1412,25 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 	job.Canonicalize()
 
 	j := &structs.Job{
-		Stop:           *job.Stop,
-		Region:         *job.Region,
-		Namespace:      *job.Namespace,
-		ID:             *job.ID,
-		Name:           *job.Name,
-		Type:           *job.Type,
-		Priority:       *job.Priority,
-		AllAtOnce:      *job.AllAtOnce,
-		Datacenters:    job.Datacenters,
-		NodePool:       *job.NodePool,
-		Payload:        job.Payload,
-		Meta:           job.Meta,
-		ConsulToken:    *job.ConsulToken,
-		VaultNamespace: *job.VaultNamespace,
-		Constraints:    ApiConstraintsToStructs(job.Constraints),
-		Affinities:     ApiAffinitiesToStructs(job.Affinities),
-		UI:             ApiJobUIConfigToStructs(job.UI),
-		VersionTag:     ApiJobVersionTagToStructs(job.VersionTag),
+		Stop:             *job.Stop,
+		Region:           *job.Region,
+		Namespace:        *job.Namespace,
+		ID:               *job.ID,
+		Name:             *job.Name,
+		Type:             *job.Type,
+		Priority:         *job.Priority,
+		AllAtOnce:        *job.AllAtOnce,
+		Datacenters:      job.Datacenters,
+		NodePool:         *job.NodePool,
+		Payload:          job.Payload,
+		MinClientVersion: *job.MinClientVersion,
+		Meta:             job.Meta,
+		ConsulToken:      *job.ConsulToken,
+		VaultNamespace:   *job.VaultNamespace,
+		Constraints:      ApiConstraintsToStructs(job.Constraints),
+		Affinities:       ApiAffinitiesToStructs(job.Affinities),
+		UI:               ApiJobUIConfigToStructs(job.UI),
+		VersionTag:       ApiJobVersionTagToStructs(job.VersionTag),
 	}
 
 	// Update has been pushed into the task groups. stagger and max_parallel are
@@ -1195,9 +1473,18 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 
 	if job.ParameterizedJob != nil {
 		j.ParameterizedJob = &structs.ParameterizedJobConfig{
-			Payload:      job.ParameterizedJob.Payload,
-			MetaRequired: job.ParameterizedJob.MetaRequired,
-			MetaOptional: job.ParameterizedJob.MetaOptional,
+			Payload:       job.ParameterizedJob.Payload,
+			MetaRequired:  job.ParameterizedJob.MetaRequired,
+			MetaOptional:  job.ParameterizedJob.MetaOptional,
+			PayloadSchema: job.ParameterizedJob.PayloadSchema,
+		}
+		for _, schema := range job.ParameterizedJob.MetaSchema {
+			j.ParameterizedJob.MetaSchema = append(j.ParameterizedJob.MetaSchema, &structs.DispatchMetaSchema{
+				Name:    schema.Name,
+				Type:    schema.Type,
+				Pattern: schema.Pattern,
+				Enum:    schema.Enum,
+			})
 		}
 	}
 
@@ -1293,6 +1580,35 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if taskGroup.ScaleWithNodes != nil {
+		tg.ScaleWithNodes = &structs.ScaleWithNodes{
+			NodePool:       *taskGroup.ScaleWithNodes.NodePool,
+			NodeClass:      *taskGroup.ScaleWithNodes.NodeClass,
+			PerNode:        *taskGroup.ScaleWithNodes.PerNode,
+			Min:            *taskGroup.ScaleWithNodes.Min,
+			Max:            *taskGroup.ScaleWithNodes.Max,
+			ScaleDownDelay: *taskGroup.ScaleWithNodes.ScaleDownDelay,
+		}
+	}
+
+	if taskGroup.DisruptionBudget != nil {
+		tg.DisruptionBudget = &structs.DisruptionBudget{
+			MinHealthy:        *taskGroup.DisruptionBudget.MinHealthy,
+			MinHealthyPercent: *taskGroup.DisruptionBudget.MinHealthyPercent,
+		}
+	}
+
+	if taskGroup.PreemptionNotice != nil {
+		tg.PreemptionNotice = &structs.PreemptionNotice{
+			Grace:  *taskGroup.PreemptionNotice.Grace,
+			Signal: *taskGroup.PreemptionNotice.Signal,
+		}
+	}
+
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
+
 	if taskGroup.Migrate != nil {
 		tg.Migrate = &structs.MigrateStrategy{
 			MaxParallel:     *taskGroup.Migrate.MaxParallel,
@@ -1370,6 +1686,23 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if taskGroup.Update.AutoPromoteThreshold != nil {
+			tg.Update.AutoPromoteThreshold = *taskGroup.Update.AutoPromoteThreshold
+		}
+
+		if taskGroup.Update.AutoPromoteMinHealthyTime != nil {
+			tg.Update.AutoPromoteMinHealthyTime = *taskGroup.Update.AutoPromoteMinHealthyTime
+		}
+
+		if r := taskGroup.Update.RollbackOn; r != nil {
+			tg.Update.RollbackOn = &structs.RollbackOn{
+				Query:      *r.Query,
+				Threshold:  *r.Threshold,
+				Comparison: *r.Comparison,
+				Interval:   *r.Interval,
+			}
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1662,6 +1995,8 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 			IP:       nw.IP,
 			Hostname: nw.Hostname,
 			MBits:    nw.Megabits(),
+
+			PreserveIdentity: nw.PreserveIdentity,
 		}
 
 		if nw.DNS != nil {
@@ -1807,6 +2142,7 @@ func apiWorkloadIdentityToStructs(in *api.WorkloadIdentity) *structs.WorkloadIde
 		Filepath:     in.Filepath,
 		ServiceName:  in.ServiceName,
 		TTL:          in.TTL,
+		MetaClaims:   slices.Clone(in.MetaClaims),
 	}
 }
 
@@ -2189,9 +2525,12 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 	}
 
 	return &structs.LogConfig{
-		Disabled:      dereferenceBool(in.Disabled),
-		MaxFiles:      dereferenceInt(in.MaxFiles),
-		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
+		Disabled:       dereferenceBool(in.Disabled),
+		MaxFiles:       dereferenceInt(in.MaxFiles),
+		MaxFileSizeMB:  dereferenceInt(in.MaxFileSizeMB),
+		Compress:       dereferenceBool(in.Compress),
+		MaxTotalSizeMB: dereferenceInt(in.MaxTotalSizeMB),
+		Journald:       dereferenceBool(in.Journald),
 	}
 }
 
@@ -2296,6 +2635,7 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	ret.Topology = slices.Clone(a1.Topology)
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
@@ -1627,6 +1627,47 @@ func TestHTTP_JobDeployments(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobSnapshot(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		j := mock.Job()
+		args := structs.JobRegisterRequest{
+			Job: j,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		must.NoError(t, s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest(http.MethodGet, "/v1/job/"+j.ID+"/snapshot", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		// Check the response contains the job and the registration eval
+		snap := obj.(*structs.JobSnapshot)
+		must.Eq(t, j.ID, snap.Job.ID)
+		must.Len(t, 1, snap.Evaluations)
+		must.Eq(t, resp.EvalID, snap.Evaluations[0].ID)
+		must.NotNil(t, snap.Deployments)
+		must.NotNil(t, snap.Allocations)
+		must.NotEq(t, "", respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Check a missing job returns a 404
+		req, err = http.NewRequest(http.MethodGet, "/v1/job/missing/snapshot", nil)
+		must.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		must.EqError(t, err, "job not found")
+		must.Eq(t, http.StatusNotFound, err.(HTTPCodedError).Code())
+	})
+}
+
 func TestHTTP_JobDeployment(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
@@ -1834,6 +1875,60 @@ func TestHTTP_PeriodicForce(t *testing.T) {
 	})
 }
 
+func TestHTTP_PeriodicForce_Overrides(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create and register a periodic job.
+		job := mock.PeriodicJob()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		must.NoError(t, s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Make the HTTP request with meta and env overrides, with a body of
+		// unknown length as sent with chunked encoding
+		buf := encodeReq(map[string]map[string]string{
+			"Meta": {"date": "2024-01-01"},
+			"Env":  {"DRY_RUN": "true"},
+		})
+		req, err := http.NewRequest(http.MethodPost, "/v1/job/"+job.ID+"/periodic/force", buf)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+		r := obj.(structs.PeriodicForceResponse)
+		must.NotEq(t, "", r.EvalID)
+
+		// The overrides are recorded on the child job
+		evalArgs := structs.EvalSpecificRequest{
+			EvalID:       r.EvalID,
+			QueryOptions: structs.QueryOptions{Region: "global"},
+		}
+		var evalResp structs.SingleEvalResponse
+		must.NoError(t, s.Agent.RPC("Eval.GetEval", &evalArgs, &evalResp))
+		must.NotNil(t, evalResp.Eval)
+
+		jobArgs := structs.JobSpecificRequest{
+			JobID: evalResp.Eval.JobID,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var jobResp structs.SingleJobResponse
+		must.NoError(t, s.Agent.RPC("Job.GetJob", &jobArgs, &jobResp))
+		must.NotNil(t, jobResp.Job)
+		must.Eq(t, "2024-01-01", jobResp.Job.Meta["date"])
+		must.Eq(t, "true", jobResp.Job.TaskGroups[0].Tasks[0].Env["DRY_RUN"])
+	})
+}
+
 func TestHTTP_JobPlan(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1874,6 +1969,40 @@ func TestHTTP_JobPlan(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobDrift(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := MockJob()
+		args := api.JobDriftRequest{
+			Job: job,
+			WriteRequest: api.WriteRequest{
+				Region:    "global",
+				Namespace: api.DefaultNamespace,
+			},
+		}
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/job/"+*job.ID+"/drift", encodeReq(args))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		// The job is not registered yet
+		drift := obj.(structs.JobDriftResponse)
+		must.True(t, drift.Drifted)
+		must.NotEq(t, "", drift.Hash)
+		must.Eq(t, "", drift.RegisteredHash)
+		must.Eq(t, *job.ID, drift.Job.ID)
+
+		// A mismatched job ID is rejected
+		req, err = http.NewRequest(http.MethodPut, "/v1/job/other/drift", encodeReq(args))
+		must.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Job ID does not match")
+	})
+}
+
 func TestHTTP_JobPlanRegion(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2160,6 +2289,45 @@ func TestHTTP_JobStable(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobFreeze(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		must.NoError(t, s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Freeze the job
+		buf := encodeReq(api.JobFreezeRequest{JobID: job.ID, Frozen: true})
+		req, err := http.NewRequest(http.MethodPut, "/v1/job/"+job.ID+"/freeze", buf)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+		freezeResp := obj.(structs.JobFreezeResponse)
+		must.Positive(t, freezeResp.Index)
+		must.Eq(t, "", freezeResp.EvalID)
+
+		out, err := s.Agent.server.State().JobByID(nil, job.Namespace, job.ID)
+		must.NoError(t, err)
+		must.True(t, out.Frozen)
+
+		// Job IDs must match
+		buf = encodeReq(api.JobFreezeRequest{JobID: "other", Frozen: false})
+		req, err = http.NewRequest(http.MethodPut, "/v1/job/"+job.ID+"/freeze", buf)
+		must.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Job ID does not match")
+	})
+}
+
 func TestJobs_ParsingWriteRequest(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2645,6 +2813,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			Payload:      "payload",
 			MetaRequired: []string{"a", "b"},
 			MetaOptional: []string{"c", "d"},
+			MetaSchema: []*api.DispatchMetaSchema{
+				{Name: "a", Type: "integer", Enum: []string{"1", "2"}},
+			},
+			PayloadSchema: `{"type": "object"}`,
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -2727,6 +2899,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					HealthyDeadline:  pointer.Of(5 * time.Minute),
 					ProgressDeadline: pointer.Of(5 * time.Minute),
 					AutoRevert:       pointer.Of(true),
+					RollbackOn: &api.RollbackOn{
+						Query:     pointer.Of("up"),
+						Threshold: pointer.Of(1.0),
+					},
 				},
 				Meta: map[string]string{
 					"key": "value",
@@ -3083,6 +3259,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			Payload:      "payload",
 			MetaRequired: []string{"a", "b"},
 			MetaOptional: []string{"c", "d"},
+			MetaSchema: []*structs.DispatchMetaSchema{
+				{Name: "a", Type: "integer", Enum: []string{"1", "2"}},
+			},
+			PayloadSchema: `{"type": "object"}`,
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -3170,6 +3350,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					AutoRevert:       true,
 					AutoPromote:      false,
 					Canary:           1,
+					RollbackOn: &structs.RollbackOn{
+						Query:      "up",
+						Threshold:  1,
+						Comparison: structs.RollbackOnComparisonAbove,
+						Interval:   30 * time.Second,
+					},
 				},
 				Meta: map[string]string{
 					"key": "value",
@@ -109,6 +109,16 @@ func (s *HTTPServer) KeyringRequest(resp http.ResponseWriter, req *http.Request)
 
 	path := strings.TrimPrefix(req.URL.Path, "/v1/operator/keyring/")
 	switch {
+	case strings.HasPrefix(path, "health"):
+		if req.Method != http.MethodGet {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringHealthRequest(resp, req)
+	case strings.HasPrefix(path, "ready"):
+		if req.Method != http.MethodGet {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringReadyRequest(resp, req)
 	case strings.HasPrefix(path, "keys"):
 		if req.Method != http.MethodGet {
 			return nil, CodedError(405, ErrInvalidMethod)
@@ -164,6 +174,44 @@ func (s *HTTPServer) keyringListRequest(resp http.ResponseWriter, req *http.Requ
 	return out.Keys, nil
 }
 
+func (s *HTTPServer) keyringHealthRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	args := structs.GenericRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringHealthResponse
+	if err := s.agent.RPC("Keyring.Health", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.PendingKeys == nil {
+		out.PendingKeys = make([]string, 0)
+	}
+	if out.Providers == nil {
+		out.Providers = make([]*structs.KEKProviderHealth, 0)
+	}
+	return out, nil
+}
+
+func (s *HTTPServer) keyringReadyRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	args := structs.GenericRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringReadyResponse
+	if err := s.agent.RPC("Keyring.Ready", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out, nil
+}
+
 func (s *HTTPServer) keyringRotateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
 	args := structs.KeyringRotateRootKeyRequest{}
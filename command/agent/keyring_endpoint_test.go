@@ -123,6 +123,52 @@ func TestHTTP_Keyring_CRUD(t *testing.T) {
 	})
 }
 
+func TestHTTP_Keyring_Health(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		respW := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/operator/keyring/health", nil)
+		must.NoError(t, err)
+		obj, err := s.Server.KeyringRequest(respW, req)
+		must.NoError(t, err)
+
+		health := obj.(structs.KeyringHealthResponse)
+		must.True(t, health.Ready)
+		must.NotNil(t, health.PendingKeys)
+		must.Len(t, 1, health.Providers)
+		must.True(t, health.Providers[0].Healthy)
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/operator/keyring/health", nil)
+		must.NoError(t, err)
+		_, err = s.Server.KeyringRequest(respW, req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
+func TestHTTP_Keyring_Ready(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		respW := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/operator/keyring/ready?stale=true", nil)
+		must.NoError(t, err)
+		obj, err := s.Server.KeyringRequest(respW, req)
+		must.NoError(t, err)
+
+		ready := obj.(structs.KeyringReadyResponse)
+		must.True(t, ready.Ready)
+		must.Eq(t, "", ready.Error)
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/operator/keyring/ready", nil)
+		must.NoError(t, err)
+		_, err = s.Server.KeyringRequest(respW, req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
 // TestHTTP_Keyring_JWKS asserts the JWKS endpoint is enabled by default and
 // caches relative to the key rotation threshold.
 func TestHTTP_Keyring_JWKS(t *testing.T) {
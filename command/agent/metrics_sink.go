@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// labelOverflowValue replaces the values of labels that exceed their
+	// cardinality limit.
+	labelOverflowValue = "_other"
+
+	// labelHostName is the label added by go-metrics to identify the agent,
+	// which is never removed by aggregation.
+	labelHostName = "host"
+)
+
+// labelOverflowKey is the metric used to report label values replaced because
+// their label reached its cardinality limit.
+var labelOverflowKey = []string{"nomad", "agent", "telemetry", "label_overflow"}
+
+// precisionGaugeSink is implemented by sinks that support 64 bit gauges.
+type precisionGaugeSink interface {
+	SetPrecisionGauge(key []string, val float64)
+	SetPrecisionGaugeWithLabels(key []string, val float64, labels []metrics.Label)
+}
+
+// labelGuardSink wraps a metrics sink to aggregate the series of selected
+// metric prefixes and to cap the number of distinct values of labels, so
+// that high churn labels such as allocation IDs don't grow the number of
+// series without bounds.
+type labelGuardSink struct {
+	sink metrics.MetricSink
+
+	aggregatePrefixes []string
+	aggregateLabels   map[string]struct{}
+
+	limits map[string]int
+	ttl    time.Duration
+
+	// values tracks the last time each value of a limited label was seen.
+	values    map[string]map[string]time.Time
+	lastPrune time.Time
+	lock      sync.Mutex
+
+	// now is used to retrieve the current time and can be replaced in tests.
+	now func() time.Time
+}
+
+// newLabelGuardSink returns a sink that applies the aggregation and label
+// cardinality settings of the telemetry configuration to sink, or nil if none
+// are configured.
+func newLabelGuardSink(t *Telemetry, sink metrics.MetricSink) *labelGuardSink {
+	if len(t.AggregatePrefixes) == 0 && len(t.LabelCardinalityLimits) == 0 {
+		return nil
+	}
+
+	g := &labelGuardSink{
+		sink:              sink,
+		aggregatePrefixes: t.AggregatePrefixes,
+		aggregateLabels:   make(map[string]struct{}, len(t.AggregateLabels)),
+		limits:            t.LabelCardinalityLimits,
+		ttl:               t.labelCardinalityTTL,
+		values:            make(map[string]map[string]time.Time, len(t.LabelCardinalityLimits)),
+		now:               time.Now,
+	}
+	for _, label := range t.AggregateLabels {
+		g.aggregateLabels[label] = struct{}{}
+	}
+	return g
+}
+
+func (g *labelGuardSink) SetGauge(key []string, val float32) {
+	g.sink.SetGauge(key, val)
+}
+
+func (g *labelGuardSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	g.sink.SetGaugeWithLabels(key, val, g.guard(key, labels))
+}
+
+func (g *labelGuardSink) SetPrecisionGauge(key []string, val float64) {
+	if sink, ok := g.sink.(precisionGaugeSink); ok {
+		sink.SetPrecisionGauge(key, val)
+	}
+}
+
+func (g *labelGuardSink) SetPrecisionGaugeWithLabels(key []string, val float64, labels []metrics.Label) {
+	if sink, ok := g.sink.(precisionGaugeSink); ok {
+		sink.SetPrecisionGaugeWithLabels(key, val, g.guard(key, labels))
+	}
+}
+
+func (g *labelGuardSink) EmitKey(key []string, val float32) {
+	g.sink.EmitKey(key, val)
+}
+
+func (g *labelGuardSink) IncrCounter(key []string, val float32) {
+	g.sink.IncrCounter(key, val)
+}
+
+func (g *labelGuardSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	g.sink.IncrCounterWithLabels(key, val, g.guard(key, labels))
+}
+
+func (g *labelGuardSink) AddSample(key []string, val float32) {
+	g.sink.AddSample(key, val)
+}
+
+func (g *labelGuardSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	g.sink.AddSampleWithLabels(key, val, g.guard(key, labels))
+}
+
+func (g *labelGuardSink) Shutdown() {
+	if sink, ok := g.sink.(metrics.ShutdownSink); ok {
+		sink.Shutdown()
+	}
+}
+
+// guard returns the labels to emit for the metric. The passed labels are
+// never modified since they may be shared by the caller.
+func (g *labelGuardSink) guard(key []string, labels []metrics.Label) []metrics.Label {
+	if len(labels) == 0 {
+		return labels
+	}
+
+	aggregate := g.aggregated(key)
+	var out []metrics.Label
+	for _, label := range labels {
+		if aggregate && g.aggregatedLabel(label.Name) {
+			continue
+		}
+		if limit, ok := g.limits[label.Name]; ok && !g.track(label, limit) {
+			label.Value = labelOverflowValue
+			g.sink.IncrCounterWithLabels(labelOverflowKey, 1,
+				[]metrics.Label{{Name: "label", Value: label.Name}})
+		}
+		out = append(out, label)
+	}
+	return out
+}
+
+// aggregated returns true if the metric matches one of the aggregated
+// prefixes.
+func (g *labelGuardSink) aggregated(key []string) bool {
+	if len(g.aggregatePrefixes) == 0 {
+		return false
+	}
+
+	name := strings.Join(key, ".")
+	for _, prefix := range g.aggregatePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregatedLabel returns true if the label must be removed from aggregated
+// metrics.
+func (g *labelGuardSink) aggregatedLabel(name string) bool {
+	if len(g.aggregateLabels) == 0 {
+		return name != labelHostName
+	}
+	_, ok := g.aggregateLabels[name]
+	return ok
+}
+
+// track records that the value of the label was seen and returns false if
+// the value is new and the label already reached its limit.
+func (g *labelGuardSink) track(label metrics.Label, limit int) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	now := g.now()
+	values, ok := g.values[label.Name]
+	if !ok {
+		values = make(map[string]time.Time)
+		g.values[label.Name] = values
+	}
+
+	if _, ok := values[label.Value]; ok || len(values) < limit {
+		values[label.Value] = now
+		return true
+	}
+
+	// Expired values are only pruned once the limit is reached, and at most
+	// once per TTL fraction, to keep the cost of a flood of new values low.
+	if now.Sub(g.lastPrune) >= g.ttl/10 {
+		g.lastPrune = now
+		for _, values := range g.values {
+			for value, seen := range values {
+				if now.Sub(seen) >= g.ttl {
+					delete(values, value)
+				}
+			}
+		}
+		if len(values) < limit {
+			values[label.Value] = now
+			return true
+		}
+	}
+
+	return false
+}
+
+// telemetryTagVariableRe matches the variables of telemetry tag templates.
+var telemetryTagVariableRe = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// telemetryTagVariables are the variables available to telemetry tag
+// templates, in addition to ${meta.<key>}.
+var telemetryTagVariables = map[string]func(*Config) string{
+	"node.datacenter": func(c *Config) string { return c.Datacenter },
+	"node.region":     func(c *Config) string { return c.Region },
+	"node.name": func(c *Config) string {
+		if c.NodeName != "" {
+			return c.NodeName
+		}
+		name, _ := os.Hostname()
+		return name
+	},
+	"node.pool": func(c *Config) string {
+		if c.Client == nil || c.Client.NodePool == "" {
+			return structs.NodePoolDefault
+		}
+		return c.Client.NodePool
+	},
+	"node.class": func(c *Config) string {
+		if c.Client == nil {
+			return ""
+		}
+		return c.Client.NodeClass
+	},
+}
+
+// validateTelemetryTag returns an error if the tag template references an
+// unknown variable.
+func validateTelemetryTag(value string) error {
+	for _, match := range telemetryTagVariableRe.FindAllStringSubmatch(value, -1) {
+		name := match[1]
+		if _, ok := telemetryTagVariables[name]; ok {
+			continue
+		}
+		if key, ok := strings.CutPrefix(name, "meta."); ok && key != "" {
+			continue
+		}
+		return fmt.Errorf("unknown variable ${%s}", name)
+	}
+	return nil
+}
+
+// telemetryGlobalLabels interpolates the global tags of the telemetry
+// configuration and returns them as labels sorted by name.
+func telemetryGlobalLabels(config *Config) []metrics.Label {
+	tags := config.Telemetry.GlobalTags
+	labels := make([]metrics.Label, 0, len(tags))
+	for name, value := range tags {
+		value = telemetryTagVariableRe.ReplaceAllStringFunc(value, func(match string) string {
+			variable := match[2 : len(match)-1]
+			if fn, ok := telemetryTagVariables[variable]; ok {
+				return fn(config)
+			}
+			if key, ok := strings.CutPrefix(variable, "meta."); ok && config.Client != nil {
+				return config.Client.Meta[key]
+			}
+			return ""
+		})
+		labels = append(labels, metrics.Label{Name: name, Value: value})
+	}
+
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels
+}
+
+// globalLabelSink wraps a metrics sink to add the same labels to all metrics.
+type globalLabelSink struct {
+	sink   metrics.MetricSink
+	labels []metrics.Label
+}
+
+// newGlobalLabelSink returns a sink that adds the global tags of the telemetry
+// configuration to all the metrics emitted to sink, or nil if there are none.
+func newGlobalLabelSink(config *Config, sink metrics.MetricSink) *globalLabelSink {
+	if config.Telemetry == nil || len(config.Telemetry.GlobalTags) == 0 {
+		return nil
+	}
+	return &globalLabelSink{
+		sink:   sink,
+		labels: telemetryGlobalLabels(config),
+	}
+}
+
+func (g *globalLabelSink) SetGauge(key []string, val float32) {
+	g.sink.SetGaugeWithLabels(key, val, g.labels)
+}
+
+func (g *globalLabelSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	g.sink.SetGaugeWithLabels(key, val, g.with(labels))
+}
+
+func (g *globalLabelSink) SetPrecisionGauge(key []string, val float64) {
+	if sink, ok := g.sink.(precisionGaugeSink); ok {
+		sink.SetPrecisionGaugeWithLabels(key, val, g.labels)
+	}
+}
+
+func (g *globalLabelSink) SetPrecisionGaugeWithLabels(key []string, val float64, labels []metrics.Label) {
+	if sink, ok := g.sink.(precisionGaugeSink); ok {
+		sink.SetPrecisionGaugeWithLabels(key, val, g.with(labels))
+	}
+}
+
+func (g *globalLabelSink) EmitKey(key []string, val float32) {
+	g.sink.EmitKey(key, val)
+}
+
+func (g *globalLabelSink) IncrCounter(key []string, val float32) {
+	g.sink.IncrCounterWithLabels(key, val, g.labels)
+}
+
+func (g *globalLabelSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	g.sink.IncrCounterWithLabels(key, val, g.with(labels))
+}
+
+func (g *globalLabelSink) AddSample(key []string, val float32) {
+	g.sink.AddSampleWithLabels(key, val, g.labels)
+}
+
+func (g *globalLabelSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	g.sink.AddSampleWithLabels(key, val, g.with(labels))
+}
+
+func (g *globalLabelSink) Shutdown() {
+	if sink, ok := g.sink.(metrics.ShutdownSink); ok {
+		sink.Shutdown()
+	}
+}
+
+// with returns the labels followed by the global labels. Labels set by the
+// metric take precedence over global labels with the same name.
+func (g *globalLabelSink) with(labels []metrics.Label) []metrics.Label {
+	out := make([]metrics.Label, len(labels), len(labels)+len(g.labels))
+	copy(out, labels)
+
+OUTER:
+	for _, global := range g.labels {
+		for _, label := range labels {
+			if label.Name == global.Name {
+				continue OUTER
+			}
+		}
+		out = append(out, global)
+	}
+	return out
+}
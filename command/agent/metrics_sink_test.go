@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestLabelGuardSink_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	inm := metrics.NewInmemSink(time.Minute, time.Minute)
+	must.Nil(t, newLabelGuardSink(DefaultConfig().Telemetry, inm))
+}
+
+func TestLabelGuardSink_Aggregate(t *testing.T) {
+	ci.Parallel(t)
+
+	inm := metrics.NewInmemSink(time.Minute, time.Minute)
+	telemetry := &Telemetry{
+		AggregatePrefixes: []string{"nomad.client.allocs"},
+	}
+	sink := newLabelGuardSink(telemetry, inm)
+	must.NotNil(t, sink)
+
+	labels := []metrics.Label{
+		{Name: "alloc_id", Value: "a1"},
+		{Name: "task", Value: "web"},
+		{Name: "host", Value: "node1"},
+	}
+	sink.IncrCounterWithLabels([]string{"nomad", "client", "allocs", "restart"}, 1, labels)
+	sink.IncrCounterWithLabels([]string{"nomad", "client", "allocs", "restart"}, 1,
+		[]metrics.Label{{Name: "alloc_id", Value: "a2"}, {Name: "host", Value: "node1"}})
+	sink.IncrCounterWithLabels([]string{"nomad", "client", "uptime"}, 1, labels)
+
+	// The labels of the caller must not be modified
+	must.Eq(t, "a1", labels[0].Value)
+
+	counters := inm.Data()[0].Counters
+	must.MapContainsKey(t, counters, "nomad.client.allocs.restart;host=node1")
+	must.Eq(t, 2, counters["nomad.client.allocs.restart;host=node1"].Count)
+	must.MapContainsKey(t, counters, "nomad.client.uptime;alloc_id=a1;task=web;host=node1")
+
+	// Only the listed labels are removed if set
+	inm = metrics.NewInmemSink(time.Minute, time.Minute)
+	telemetry.AggregateLabels = []string{"alloc_id"}
+	sink = newLabelGuardSink(telemetry, inm)
+	sink.IncrCounterWithLabels([]string{"nomad", "client", "allocs", "restart"}, 1, labels)
+
+	counters = inm.Data()[0].Counters
+	must.MapContainsKey(t, counters, "nomad.client.allocs.restart;task=web;host=node1")
+}
+
+func TestLabelGuardSink_CardinalityLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	inm := metrics.NewInmemSink(time.Minute, time.Minute)
+	telemetry := &Telemetry{
+		LabelCardinalityLimits: map[string]int{"alloc_id": 2},
+		labelCardinalityTTL:    10 * time.Minute,
+	}
+	sink := newLabelGuardSink(telemetry, inm)
+
+	now := time.Now()
+	sink.now = func() time.Time { return now }
+
+	emit := func(allocID string) {
+		sink.SetGaugeWithLabels([]string{"nomad", "client", "allocs", "memory"}, 1,
+			[]metrics.Label{{Name: "alloc_id", Value: allocID}})
+	}
+
+	emit("a1")
+	emit("a2")
+	emit("a3")
+	emit("a1")
+
+	data := inm.Data()[0]
+	must.MapContainsKey(t, data.Gauges, "nomad.client.allocs.memory;alloc_id=a1")
+	must.MapContainsKey(t, data.Gauges, "nomad.client.allocs.memory;alloc_id=a2")
+	must.MapNotContainsKey(t, data.Gauges, "nomad.client.allocs.memory;alloc_id=a3")
+	must.MapContainsKey(t, data.Gauges, "nomad.client.allocs.memory;alloc_id=_other")
+	must.Eq(t, 1, data.Counters["nomad.agent.telemetry.label_overflow;label=alloc_id"].Count)
+
+	// Values that were not updated within the TTL make room for new ones
+	now = now.Add(5 * time.Minute)
+	emit("a1")
+	now = now.Add(6 * time.Minute)
+	emit("a3")
+
+	data = inm.Data()[0]
+	must.MapContainsKey(t, data.Gauges, "nomad.client.allocs.memory;alloc_id=a3")
+	must.Eq(t, 1, data.Counters["nomad.agent.telemetry.label_overflow;label=alloc_id"].Count)
+}
+
+func TestGlobalLabelSink(t *testing.T) {
+	ci.Parallel(t)
+
+	config := DefaultConfig()
+	must.Nil(t, newGlobalLabelSink(config, metrics.NewInmemSink(time.Minute, time.Minute)))
+
+	config.Datacenter = "dc2"
+	config.NodeName = "node1"
+	config.Client.NodePool = "gpu"
+	config.Client.Meta = map[string]string{"team": "ml"}
+	config.Telemetry.GlobalTags = map[string]string{
+		"node_pool": "${node.pool}",
+		"location":  "${node.region}/${node.datacenter}",
+		"team":      "${meta.team}",
+		"node":      "${node.name}",
+	}
+
+	must.Eq(t, []metrics.Label{
+		{Name: "location", Value: "global/dc2"},
+		{Name: "node", Value: "node1"},
+		{Name: "node_pool", Value: "gpu"},
+		{Name: "team", Value: "ml"},
+	}, telemetryGlobalLabels(config))
+
+	inm := metrics.NewInmemSink(time.Minute, time.Minute)
+	config.Telemetry.GlobalTags = map[string]string{
+		"node_pool": "${node.pool}",
+		"team":      "${meta.team}",
+	}
+	sink := newGlobalLabelSink(config, inm)
+	must.NotNil(t, sink)
+
+	labels := []metrics.Label{{Name: "team", Value: "infra"}}
+	sink.IncrCounter([]string{"nomad", "runtime", "gc"}, 1)
+	sink.SetGaugeWithLabels([]string{"nomad", "client", "allocs"}, 1, labels)
+
+	// The labels of the caller must not be modified
+	must.Len(t, 1, labels)
+
+	data := inm.Data()[0]
+	must.MapContainsKey(t, data.Counters, "nomad.runtime.gc;node_pool=gpu;team=ml")
+	must.MapContainsKey(t, data.Gauges, "nomad.client.allocs;team=infra;node_pool=gpu")
+}
+
+func TestValidateTelemetryTag(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, validateTelemetryTag("static"))
+	must.NoError(t, validateTelemetryTag("${node.pool}-${meta.rack}"))
+	must.ErrorContains(t, validateTelemetryTag("${node.id}"), "unknown variable ${node.id}")
+	must.ErrorContains(t, validateTelemetryTag("${meta.}"), "unknown variable ${meta.}")
+}
@@ -29,6 +29,11 @@ func (s *HTTPServer) NodesRequest(resp http.ResponseWriter, req *http.Request) (
 	}
 	args.Fields = fields
 
+	projection, err := parseFieldsProjection[*structs.NodeListStub](req)
+	if err != nil {
+		return nil, err
+	}
+
 	var out structs.NodeListResponse
 	if err := s.agent.RPC("Node.List", &args, &out); err != nil {
 		return nil, err
@@ -39,7 +44,7 @@ func (s *HTTPServer) NodesRequest(resp http.ResponseWriter, req *http.Request) (
 		out.Nodes = make([]*structs.NodeListStub, 0)
 	}
 
-	return out.Nodes, nil
+	return projectFields(out.Nodes, projection), nil
 }
 
 func (s *HTTPServer) NodeSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -134,6 +139,7 @@ func (s *HTTPServer) nodeToggleDrain(resp http.ResponseWriter, req *http.Request
 			DrainSpec: structs.DrainSpec{
 				Deadline:         drainRequest.DrainSpec.Deadline,
 				IgnoreSystemJobs: drainRequest.DrainSpec.IgnoreSystemJobs,
+				BatchGrace:       drainRequest.DrainSpec.BatchGrace,
 			},
 		}
 	}
@@ -31,6 +31,12 @@ func (s *HTTPServer) NodePoolSpecificRequest(resp http.ResponseWriter, req *http
 	case strings.HasSuffix(path, "/jobs"):
 		poolName := strings.TrimSuffix(path, "/jobs")
 		return s.nodePoolJobList(resp, req, poolName)
+	case strings.HasSuffix(path, "/lifecycle/abort"):
+		poolName := strings.TrimSuffix(path, "/lifecycle/abort")
+		return s.nodePoolLifecycleAbort(resp, req, poolName)
+	case strings.HasSuffix(path, "/lifecycle"):
+		poolName := strings.TrimSuffix(path, "/lifecycle")
+		return s.nodePoolLifecycle(resp, req, poolName)
 	default:
 		return s.nodePoolCRUD(resp, req, path)
 	}
@@ -205,3 +211,73 @@ func (s *HTTPServer) nodePoolJobList(resp http.ResponseWriter, req *http.Request
 	}
 	return out.Jobs, nil
 }
+
+func (s *HTTPServer) nodePoolLifecycle(resp http.ResponseWriter, req *http.Request, poolName string) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.nodePoolLifecycleQuery(resp, req, poolName)
+	case http.MethodPut, http.MethodPost:
+		return s.nodePoolLifecycleStart(resp, req, poolName)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) nodePoolLifecycleQuery(resp http.ResponseWriter, req *http.Request, poolName string) (any, error) {
+	args := structs.NodeLifecycleRunSpecificRequest{
+		NodePool: poolName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleNodeLifecycleRunResponse
+	if err := s.agent.RPC("NodePool.GetNodeLifecycle", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Run == nil {
+		return nil, CodedError(http.StatusNotFound, "node lifecycle run not found")
+	}
+	return out.Run, nil
+}
+
+func (s *HTTPServer) nodePoolLifecycleStart(resp http.ResponseWriter, req *http.Request, poolName string) (any, error) {
+	var args structs.NodeLifecycleStartRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if args.NodePool != "" && args.NodePool != poolName {
+		return nil, CodedError(http.StatusBadRequest, "Node pool name does not match request path")
+	}
+	args.NodePool = poolName
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.NodeLifecycleRunResponse
+	if err := s.agent.RPC("NodePool.StartNodeLifecycle", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out.Run, nil
+}
+
+func (s *HTTPServer) nodePoolLifecycleAbort(resp http.ResponseWriter, req *http.Request, poolName string) (any, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.NodeLifecycleAbortRequest{
+		NodePool: poolName,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.NodeLifecycleRunResponse
+	if err := s.agent.RPC("NodePool.AbortNodeLifecycle", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out.Run, nil
+}
@@ -481,3 +481,81 @@ func TestHTTP_NodePool_JobsList(t *testing.T) {
 
 	})
 }
+
+func TestHTTP_NodePool_Lifecycle(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t,
+		func(c *Config) {
+			c.Client.Enabled = false
+		},
+		func(s *TestAgent) {
+			// The webhook never gets the node to register again, so the run
+			// stays running until it's aborted.
+			webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer webhook.Close()
+
+			// Populate state with test data.
+			pool := mock.NodePool()
+			args := structs.NodePoolUpsertRequest{
+				NodePools: []*structs.NodePool{pool},
+			}
+			var resp structs.GenericResponse
+			err := s.Agent.RPC("NodePool.UpsertNodePools", &args, &resp)
+			must.NoError(t, err)
+
+			node := mock.Node()
+			node.NodePool = pool.Name
+			nodeReq := structs.NodeRegisterRequest{
+				Node:         node,
+				WriteRequest: structs.WriteRequest{Region: "global"},
+			}
+			var nodeResp structs.NodeUpdateResponse
+			err = s.Agent.RPC("Node.Register", &nodeReq, &nodeResp)
+			must.NoError(t, err)
+
+			path := fmt.Sprintf("/v1/node/pool/%s/lifecycle", pool.Name)
+
+			// The node pool has no run yet.
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			must.NoError(t, err)
+			_, err = s.Server.NodePoolSpecificRequest(httptest.NewRecorder(), req)
+			must.ErrorContains(t, err, "not found")
+
+			// Start a run.
+			start := structs.NodeLifecycleStartRequest{
+				BatchSize:  1,
+				WebhookURL: webhook.URL,
+			}
+			req, err = http.NewRequest(http.MethodPut, path, encodeReq(start))
+			must.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.NodePoolSpecificRequest(respW, req)
+			must.NoError(t, err)
+			must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+			run := obj.(*structs.NodeLifecycleRun)
+			must.Eq(t, pool.Name, run.NodePool)
+			must.Eq(t, structs.NodeLifecycleStatusRunning, run.Status)
+			must.Eq(t, webhook.URL, run.WebhookURL)
+
+			// Read the run.
+			req, err = http.NewRequest(http.MethodGet, path, nil)
+			must.NoError(t, err)
+			obj, err = s.Server.NodePoolSpecificRequest(httptest.NewRecorder(), req)
+			must.NoError(t, err)
+			must.Eq(t, run.ID, obj.(*structs.NodeLifecycleRun).ID)
+
+			// Abort the run.
+			req, err = http.NewRequest(http.MethodPut, path+"/abort", nil)
+			must.NoError(t, err)
+			obj, err = s.Server.NodePoolSpecificRequest(httptest.NewRecorder(), req)
+			must.NoError(t, err)
+			must.Eq(t, structs.NodeLifecycleStatusAborted, obj.(*structs.NodeLifecycleRun).Status)
+
+			// Only PUT and POST can abort a run.
+			req, err = http.NewRequest(http.MethodGet, path+"/abort", nil)
+			must.NoError(t, err)
+			_, err = s.Server.NodePoolSpecificRequest(httptest.NewRecorder(), req)
+			must.ErrorContains(t, err, ErrInvalidMethod)
+		})
+}
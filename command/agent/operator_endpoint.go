@@ -293,6 +293,165 @@ func (s *HTTPServer) OperatorSchedulerConfiguration(resp http.ResponseWriter, re
 	}
 }
 
+// OperatorPlanQueue is used to inspect the plan queue of the leader.
+func (s *HTTPServer) OperatorPlanQueue(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.PlanQueueStatusResponse
+	if err := s.agent.RPC("Operator.PlanQueueStatus", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply.PlanQueue, nil
+}
+
+// OperatorReplicationConflicts is used to inspect the namespaces and node
+// pools the leader can't replicate from the authoritative region.
+func (s *HTTPServer) OperatorReplicationConflicts(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.ReplicationConflictsResponse
+	if err := s.agent.RPC("Operator.ReplicationConflicts", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply.Conflicts, nil
+}
+
+// OperatorSchedulerSimulate is used to simulate scheduling a job without
+// committing the results.
+func (s *HTTPServer) OperatorSchedulerSimulate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.SchedulerSimulateRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.Job == nil {
+		return nil, CodedError(400, "Job must be specified")
+	}
+	if args.Job.ID == nil {
+		return nil, CodedError(400, "Job must have a valid ID")
+	}
+
+	sJob, writeReq := s.apiJobAndRequestToStructs(args.Job, req, args.WriteRequest)
+	simulateReq := structs.SchedulerSimulateRequest{
+		Job:          sJob,
+		WriteRequest: *writeReq,
+	}
+
+	var reply structs.SchedulerSimulateResponse
+	if err := s.agent.RPC("Operator.SchedulerSimulate", &simulateReq, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+
+	return reply.Simulation, nil
+}
+
+// OperatorEvalBroker is used to inspect the eval broker of the leader.
+func (s *HTTPServer) OperatorEvalBroker(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.EvalBrokerStatusResponse
+	if err := s.agent.RPC("Operator.EvalBrokerStatus", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply.EvalBroker, nil
+}
+
+// OperatorStateUsage is used to inspect the usage of the state store tables.
+func (s *HTTPServer) OperatorStateUsage(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.StateUsageResponse
+	if err := s.agent.RPC("Operator.StateUsage", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply.Tables, nil
+}
+
+// OperatorPlanRejections is used to inspect and reset the plan rejection
+// tracker of the leader.
+func (s *HTTPServer) OperatorPlanRejections(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.planRejectionsGet(resp, req)
+
+	case http.MethodDelete:
+		return s.planRejectionsReset(resp, req)
+
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) planRejectionsGet(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.PlanRejectionTrackerResponse
+	if err := s.agent.RPC("Operator.PlanRejectionTrackerGet", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
+func (s *HTTPServer) planRejectionsReset(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.PlanRejectionTrackerResetRequest{
+		NodeID: req.URL.Query().Get("node_id"),
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.PlanRejectionTrackerResetResponse
+	if err := s.agent.RPC("Operator.PlanRejectionTrackerReset", &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+
+	return reply, nil
+}
+
 func (s *HTTPServer) schedulerGetConfig(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.GenericRequest
 	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
@@ -322,6 +481,9 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 		MemoryOversubscriptionEnabled: conf.MemoryOversubscriptionEnabled,
 		RejectJobRegistration:         conf.RejectJobRegistration,
 		PauseEvalBroker:               conf.PauseEvalBroker,
+		PlanEvaluatePoolMin:           conf.PlanEvaluatePoolMin,
+		PlanEvaluatePoolMax:           conf.PlanEvaluatePoolMax,
+		EvalBrokerDequeueMode:         structs.EvalBrokerDequeueMode(conf.EvalBrokerDequeueMode),
 		PreemptionConfig: structs.PreemptionConfig{
 			SystemSchedulerEnabled:   conf.PreemptionConfig.SystemSchedulerEnabled,
 			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
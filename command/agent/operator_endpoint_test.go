@@ -508,6 +508,128 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	})
 }
 
+func TestOperator_PlanRejections(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/operator/plan-rejections", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorPlanRejections(resp, req)
+		require.NoError(t, err)
+		out, ok := obj.(structs.PlanRejectionTrackerResponse)
+		require.True(t, ok)
+		require.Empty(t, out.Nodes)
+
+		req, _ = http.NewRequest(http.MethodDelete, "/v1/operator/plan-rejections?node_id=foo", nil)
+		resp = httptest.NewRecorder()
+		obj, err = s.Server.OperatorPlanRejections(resp, req)
+		require.NoError(t, err)
+		reset, ok := obj.(structs.PlanRejectionTrackerResetResponse)
+		require.True(t, ok)
+		require.Zero(t, reset.Removed)
+
+		req, _ = http.NewRequest(http.MethodPut, "/v1/operator/plan-rejections", nil)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorPlanRejections(resp, req)
+		require.Error(t, err)
+	})
+}
+
+func TestOperator_PlanQueue(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/operator/scheduler/plan-queue", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorPlanQueue(resp, req)
+		require.NoError(t, err)
+		out, ok := obj.(*structs.PlanQueueStatus)
+		require.True(t, ok)
+		require.True(t, out.Enabled)
+		require.Empty(t, out.Pending)
+		require.NotEmpty(t, resp.Header().Get("X-Nomad-Index"))
+
+		req, _ = http.NewRequest(http.MethodPut, "/v1/operator/scheduler/plan-queue", nil)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorPlanQueue(resp, req)
+		require.Error(t, err)
+	})
+}
+
+func TestOperator_ReplicationConflicts(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/operator/replication/conflicts", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorReplicationConflicts(resp, req)
+		require.NoError(t, err)
+		out, ok := obj.([]*structs.ReplicationConflict)
+		require.True(t, ok)
+		require.Empty(t, out)
+		require.NotEmpty(t, resp.Header().Get("X-Nomad-Index"))
+
+		req, _ = http.NewRequest(http.MethodPut, "/v1/operator/replication/conflicts", nil)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorReplicationConflicts(resp, req)
+		require.Error(t, err)
+	})
+}
+
+func TestOperator_EvalBroker(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/operator/scheduler/eval-broker", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorEvalBroker(resp, req)
+		must.NoError(t, err)
+		out, ok := obj.(*structs.EvalBrokerStatus)
+		must.True(t, ok)
+		must.True(t, out.Enabled)
+		must.NotEq(t, "", resp.Header().Get("X-Nomad-Index"))
+
+		req, _ = http.NewRequest(http.MethodPut, "/v1/operator/scheduler/eval-broker", nil)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorEvalBroker(resp, req)
+		must.Error(t, err)
+	})
+}
+
+func TestOperator_SchedulerSimulate(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := MockJob()
+		args := api.SchedulerSimulateRequest{
+			Job: job,
+			WriteRequest: api.WriteRequest{
+				Region:    "global",
+				Namespace: api.DefaultNamespace,
+			},
+		}
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/operator/scheduler/simulate", encodeReq(args))
+		must.NoError(t, err)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerSimulate(resp, req)
+		must.NoError(t, err)
+		out, ok := obj.(*structs.SchedulerSimulation)
+		must.True(t, ok)
+		must.NotNil(t, out)
+		must.NotEq(t, "", resp.Header().Get("X-Nomad-Index"))
+
+		// The job is not registered
+		var jobResp structs.SingleJobResponse
+		err = s.Agent.RPC("Job.GetJob", &structs.JobSpecificRequest{
+			JobID:        *job.ID,
+			QueryOptions: structs.QueryOptions{Region: "global", Namespace: api.DefaultNamespace},
+		}, &jobResp)
+		must.NoError(t, err)
+		must.Nil(t, jobResp.Job)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/scheduler/simulate", nil)
+		must.NoError(t, err)
+		_, err = s.Server.OperatorSchedulerSimulate(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -28,6 +28,10 @@ const (
 	TraceReq  ReqType = "trace"
 	LookupReq ReqType = "lookup"
 
+	// SchedulerTraceReq is only supported by servers. The payload is a JSON
+	// encoded structs.SchedulerTrace.
+	SchedulerTraceReq ReqType = "scheduler-trace"
+
 	ErrProfileNotFoundPrefix = "Pprof profile not found profile:"
 )
 
@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// ReloadStatus describes the outcome of the last configuration reload of the
+// agent.
+type ReloadStatus struct {
+	// ReloadTime is the time of the last reload.
+	ReloadTime time.Time
+
+	// Applied lists the configuration sections that changed and were applied
+	// by the reload.
+	Applied []string
+
+	// RestartRequired lists the configuration sections that changed but
+	// only take effect once the agent is restarted.
+	RestartRequired []string
+
+	// Error is set if the reload failed. Sections listed in Applied may have
+	// been applied partially.
+	Error string
+}
+
+// reloadSection describes a configuration section whose changes are tracked
+// on reload.
+type reloadSection struct {
+	// name is the name of the section as reported to operators.
+	name string
+
+	// reloadable is true if changes to the section are applied on reload.
+	reloadable bool
+
+	// clientOnly is true if the section only matters on client agents.
+	clientOnly bool
+
+	// value returns the value of the section to compare.
+	value func(*Config) any
+}
+
+// clientReloadSection returns a reloadSection for a section of the client
+// block.
+func clientReloadSection(name string, reloadable bool, value func(*ClientConfig) any) reloadSection {
+	return reloadSection{
+		name:       "client." + name,
+		reloadable: reloadable,
+		clientOnly: true,
+		value: func(c *Config) any {
+			if c.Client == nil {
+				return nil
+			}
+			return value(c.Client)
+		},
+	}
+}
+
+// reloadSections are the configuration sections reported on reload.
+var reloadSections = []reloadSection{
+	{name: "log_level", reloadable: true, value: func(c *Config) any { return c.LogLevel }},
+	{name: "tls", reloadable: true, value: tlsReloadValue},
+	{name: "audit", reloadable: true, value: func(c *Config) any { return c.Audit }},
+	{name: "client.enabled", value: func(c *Config) any { return c.Client != nil && c.Client.Enabled }},
+
+	clientReloadSection("host_volume", true, func(c *ClientConfig) any { return c.HostVolumes }),
+	clientReloadSection("chroot_env", true, func(c *ClientConfig) any { return c.ChrootEnv }),
+	clientReloadSection("artifact", true, func(c *ClientConfig) any { return c.Artifact }),
+
+	clientReloadSection("servers", false, func(c *ClientConfig) any { return c.Servers }),
+	clientReloadSection("server_join", false, func(c *ClientConfig) any { return c.ServerJoin }),
+	clientReloadSection("node_class", false, func(c *ClientConfig) any { return c.NodeClass }),
+	clientReloadSection("node_pool", false, func(c *ClientConfig) any { return c.NodePool }),
+	clientReloadSection("options", false, func(c *ClientConfig) any { return c.Options }),
+	clientReloadSection("meta", false, func(c *ClientConfig) any { return c.Meta }),
+	clientReloadSection("reserved", false, func(c *ClientConfig) any { return c.Reserved }),
+	clientReloadSection("host_network", false, func(c *ClientConfig) any { return c.HostNetworks }),
+	clientReloadSection("cni_path", false, func(c *ClientConfig) any { return c.CNIPath }),
+	clientReloadSection("cni_config_dir", false, func(c *ClientConfig) any { return c.CNIConfigDir }),
+	clientReloadSection("template", false, func(c *ClientConfig) any { return c.TemplateConfig }),
+	clientReloadSection("drain_on_shutdown", false, func(c *ClientConfig) any { return c.Drain }),
+}
+
+// tlsReloadValue returns the TLS settings compared on reload. Unlike the
+// rest of the TLS configuration, the key loader and checksum are runtime
+// state and must not be compared.
+func tlsReloadValue(c *Config) any {
+	if c.TLSConfig == nil {
+		return nil
+	}
+	tls := c.TLSConfig
+	return [...]any{
+		tls.EnableHTTP, tls.EnableRPC, tls.RPCUpgradeMode,
+		tls.VerifyServerHostname, tls.VerifyHTTPSClient,
+		tls.CAFile, tls.CertFile, tls.KeyFile,
+	}
+}
+
+// reloadSectionsDiff returns the names of the sections that differ between
+// the current and new configuration, split between the ones applied on
+// reload and the ones that require a restart. Client sections are ignored if
+// the agent does not run a client. Each plugin block is its own section named
+// after the plugin, which is applied on reload if pluginReloadable returns
+// true for the plugin.
+func reloadSectionsDiff(current, newConfig *Config, client bool, pluginReloadable func(string) bool) (applied, restartRequired []string) {
+	for _, section := range reloadSections {
+		if section.clientOnly && !client {
+			continue
+		}
+		if reflect.DeepEqual(section.value(current), section.value(newConfig)) {
+			continue
+		}
+
+		if section.reloadable {
+			applied = append(applied, section.name)
+		} else {
+			restartRequired = append(restartRequired, section.name)
+		}
+	}
+
+	// Removed plugin blocks only unload the plugin once the agent restarts
+	newPlugins := pluginsByName(newConfig.Plugins)
+	for _, name := range pluginsDiff(current.Plugins, newConfig.Plugins) {
+		if _, ok := newPlugins[name]; ok && client && pluginReloadable(name) {
+			applied = append(applied, "plugin."+name)
+		} else {
+			restartRequired = append(restartRequired, "plugin."+name)
+		}
+	}
+	return applied, restartRequired
+}
+
+// pluginsDiff returns the sorted names of the plugins whose configuration
+// differs between current and newPlugins.
+func pluginsDiff(current, newPlugins []*config.PluginConfig) []string {
+	currentByName := pluginsByName(current)
+	newByName := pluginsByName(newPlugins)
+
+	var names []string
+	for name, c := range currentByName {
+		if n, ok := newByName[name]; !ok || !reflect.DeepEqual(c, n) {
+			names = append(names, name)
+		}
+	}
+	for name := range newByName {
+		if _, ok := currentByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func pluginsByName(plugins []*config.PluginConfig) map[string]*config.PluginConfig {
+	byName := make(map[string]*config.PluginConfig, len(plugins))
+	for _, p := range plugins {
+		byName[p.Name] = p
+	}
+	return byName
+}
+
+// reloadSectionsDiff returns the configuration sections that differ between
+// the running configuration of the agent and newConfig.
+func (a *Agent) reloadSectionsDiff(newConfig *Config) (applied, restartRequired []string) {
+	return reloadSectionsDiff(a.GetConfig(), newConfig, a.Client() != nil, a.pluginReloadable)
+}
+
+// pluginReloadable returns whether changes to the configuration of the plugin
+// with the given name are applied on reload. Only external driver plugins are
+// reloaded, by relaunching them while their tasks keep running.
+func (a *Agent) pluginReloadable(name string) bool {
+	reloader, ok := a.pluginLoader.(loader.PluginConfigReloader)
+	return ok && reloader.CanReloadConfig(name)
+}
+
+// recordReload stores the outcome of a reload. If the reload succeeded, the
+// client sections and plugins applied by the client are copied into the agent
+// configuration so that they are not reported as changed again.
+func (a *Agent) recordReload(newConfig *Config, applied, restartRequired []string, err error) {
+	a.configLock.Lock()
+	defer a.configLock.Unlock()
+
+	status := &ReloadStatus{
+		ReloadTime:      time.Now().UTC(),
+		Applied:         applied,
+		RestartRequired: restartRequired,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	a.reloadStatus = status
+
+	if err != nil || newConfig == nil || newConfig.Client == nil || a.client == nil {
+		return
+	}
+
+	current := a.config.Copy()
+	if current.Client == nil {
+		current.Client = &ClientConfig{}
+	}
+	current.Client.HostVolumes = structs.CopySliceClientHostVolumeConfig(newConfig.Client.HostVolumes)
+	current.Client.ChrootEnv = maps.Clone(newConfig.Client.ChrootEnv)
+	current.Client.Artifact = newConfig.Client.Artifact.Copy()
+	current.Plugins = reloadedPlugins(current.Plugins, newConfig.Plugins, applied)
+	a.config = current
+}
+
+// reloadedPlugins returns the plugin configurations of the agent once the
+// plugin sections in applied were reloaded from newPlugins.
+func reloadedPlugins(current, newPlugins []*config.PluginConfig, applied []string) []*config.PluginConfig {
+	newByName := pluginsByName(newPlugins)
+	reloaded := make(map[string]bool)
+	for _, section := range applied {
+		if name, ok := strings.CutPrefix(section, "plugin."); ok {
+			reloaded[name] = true
+		}
+	}
+
+	var plugins []*config.PluginConfig
+	for _, p := range current {
+		if !reloaded[p.Name] {
+			plugins = append(plugins, p)
+		} else if n, ok := newByName[p.Name]; ok {
+			plugins = append(plugins, n.Copy())
+		}
+		delete(reloaded, p.Name)
+	}
+	for _, p := range newPlugins {
+		if reloaded[p.Name] {
+			plugins = append(plugins, p.Copy())
+		}
+	}
+	return plugins
+}
+
+// ReloadStatus returns the outcome of the last configuration reload, or nil
+// if the configuration was never reloaded.
+func (a *Agent) ReloadStatus() *ReloadStatus {
+	a.configLock.Lock()
+	defer a.configLock.Unlock()
+	return a.reloadStatus
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestReloadSectionsDiff(t *testing.T) {
+	ci.Parallel(t)
+
+	current := DevConfig(nil)
+	current.Client.HostVolumes = []*structs.ClientHostVolumeConfig{
+		{Name: "data", Path: "/srv/data"},
+	}
+	current.Plugins = []*config.PluginConfig{
+		{Name: "mock-driver", Config: map[string]any{"foo": "1"}},
+		{Name: "other-driver"},
+	}
+	pluginReloadable := func(name string) bool { return name != "docker" }
+
+	cases := []struct {
+		name            string
+		client          bool
+		modify          func(*Config)
+		applied         []string
+		restartRequired []string
+	}{
+		{
+			name:   "no changes",
+			client: true,
+			modify: func(*Config) {},
+		},
+		{
+			name:   "reloadable client sections",
+			client: true,
+			modify: func(c *Config) {
+				c.Client.HostVolumes = append(c.Client.HostVolumes,
+					&structs.ClientHostVolumeConfig{Name: "logs", Path: "/srv/logs"})
+				c.Client.ChrootEnv = map[string]string{"/bin": "/bin"}
+				c.Client.Artifact.HTTPReadTimeout = pointer.Of("5m")
+			},
+			applied: []string{"client.host_volume", "client.chroot_env", "client.artifact"},
+		},
+		{
+			name:   "restart required",
+			client: true,
+			modify: func(c *Config) {
+				c.LogLevel = "TRACE"
+				c.Plugins = append(c.Plugins, &config.PluginConfig{Name: "docker"})
+				c.Client.NodePool = "gpu"
+				c.Client.Meta = map[string]string{"rack": "r1"}
+			},
+			applied:         []string{"log_level"},
+			restartRequired: []string{"client.node_pool", "client.meta", "plugin.docker"},
+		},
+		{
+			name:   "reloadable plugins",
+			client: true,
+			modify: func(c *Config) {
+				c.Plugins = []*config.PluginConfig{
+					{Name: "mock-driver", Config: map[string]any{"foo": "2"}},
+				}
+			},
+			applied:         []string{"plugin.mock-driver"},
+			restartRequired: []string{"plugin.other-driver"},
+		},
+		{
+			name:   "plugins require restart without client",
+			client: false,
+			modify: func(c *Config) {
+				c.Plugins[0] = &config.PluginConfig{Name: "mock-driver"}
+			},
+			restartRequired: []string{"plugin.mock-driver"},
+		},
+		{
+			name:   "client sections ignored without client",
+			client: false,
+			modify: func(c *Config) {
+				c.Client.ChrootEnv = map[string]string{"/bin": "/bin"}
+				c.Client.NodePool = "gpu"
+			},
+		},
+		{
+			name:   "tls",
+			client: false,
+			modify: func(c *Config) {
+				c.TLSConfig.EnableHTTP = true
+			},
+			applied: []string{"tls"},
+		},
+		{
+			name:   "audit",
+			client: false,
+			modify: func(c *Config) {
+				c.Audit.Enabled = pointer.Of(true)
+			},
+			applied: []string{"audit"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			newConfig := current.Copy()
+			tc.modify(newConfig)
+
+			applied, restartRequired := reloadSectionsDiff(current, newConfig, tc.client, pluginReloadable)
+			must.Eq(t, tc.applied, applied)
+			must.Eq(t, tc.restartRequired, restartRequired)
+		})
+	}
+}
+
+func TestReloadedPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	current := []*config.PluginConfig{
+		{Name: "docker", Config: map[string]any{"foo": "1"}},
+		{Name: "mock-driver", Config: map[string]any{"foo": "1"}},
+	}
+	newPlugins := []*config.PluginConfig{
+		{Name: "docker", Config: map[string]any{"foo": "2"}},
+		{Name: "mock-driver", Config: map[string]any{"foo": "2"}},
+		{Name: "other-driver", Args: []string{"-v"}},
+	}
+
+	// Only the applied plugins are updated
+	plugins := reloadedPlugins(current, newPlugins, []string{"log_level", "plugin.mock-driver", "plugin.other-driver"})
+	must.Eq(t, []*config.PluginConfig{current[0], newPlugins[1], newPlugins[2]}, plugins)
+}
@@ -4,8 +4,10 @@
 package agent
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -44,6 +46,40 @@ func (s *HTTPServer) scalingPoliciesListRequest(resp http.ResponseWriter, req *h
 	return out.Policies, nil
 }
 
+func (s *HTTPServer) ScalingEventsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.ScalingEventListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	query := req.URL.Query()
+	for param, dst := range map[string]*int64{"start": &args.Start, "end": &args.End} {
+		if raw := query.Get(param); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return nil, CodedError(400, fmt.Sprintf("Failed to parse %s: %v", param, err))
+			}
+			*dst = t.UnixNano()
+		}
+	}
+	args.Source = query.Get("source")
+
+	var out structs.ScalingEventListResponse
+	if err := s.agent.RPC("Scaling.ListEvents", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Events == nil {
+		out.Events = make([]*structs.ScalingEventListStub, 0)
+	}
+	return out.Events, nil
+}
+
 func (s *HTTPServer) ScalingPolicySpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/scaling/policy/")
 	return s.scalingPolicyCRUD(resp, req, path)
@@ -103,6 +139,12 @@ func ApiScalingPolicyToStructs(job *structs.Job, tg *structs.TaskGroup, task *st
 	} else {
 		p.Min = int64(count)
 	}
+	if ap.MaxStep != nil {
+		p.MaxStep = *ap.MaxStep
+	}
+	if ap.Cooldown != nil {
+		p.Cooldown = *ap.Cooldown
+	}
 
 	// COMPAT(1.12.0) - canonicalization is done in Job.Register as of 1.9,
 	// remove this canonicalization in 1.12.0 LTS
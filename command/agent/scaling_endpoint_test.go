@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -137,3 +138,54 @@ func TestHTTP_ScalingPolicyGet(t *testing.T) {
 		require.Equal(p.ID, obj.(*structs.ScalingPolicy).ID)
 	})
 }
+
+func TestHTTP_ScalingEventsList(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		must.NoError(t, s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		for _, source := range []string{"autoscaler", "operator"} {
+			scaleReq := structs.JobScaleRequest{
+				JobID: job.ID,
+				Target: map[string]string{
+					structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+				},
+				Message: "annotated",
+				Source:  source,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: structs.DefaultNamespace,
+				},
+			}
+			var scaleResp structs.JobRegisterResponse
+			must.NoError(t, s.Agent.RPC("Job.Scale", &scaleReq, &scaleResp))
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/scaling/events?source=operator", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.ScalingEventsRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		events := obj.([]*structs.ScalingEventListStub)
+		must.Len(t, 1, events)
+		must.Eq(t, job.ID, events[0].JobID)
+		must.Eq(t, "operator", events[0].Event.Source)
+
+		// Invalid time ranges are rejected
+		req, err = http.NewRequest(http.MethodGet, "/v1/scaling/events?start=yesterday", nil)
+		must.NoError(t, err)
+		_, err = s.Server.ScalingEventsRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Failed to parse start")
+	})
+}
@@ -7,6 +7,7 @@ package agent
 
 import (
 	"io"
+	"time"
 )
 
 func openNotify() (io.WriteCloser, error) {
@@ -14,3 +15,7 @@ func openNotify() (io.WriteCloser, error) {
 }
 
 func sdNotify(_ io.Writer, _ string) {}
+
+func watchdogInterval() time.Duration {
+	return 0
+}
@@ -9,10 +9,15 @@ import (
 	"io"
 	"net"
 	"os"
+	"strconv"
 	"time"
 )
 
-const sdNotifySocketEnvVar = "NOTIFY_SOCKET"
+const (
+	sdNotifySocketEnvVar = "NOTIFY_SOCKET"
+	sdWatchdogUSecEnvVar = "WATCHDOG_USEC"
+	sdWatchdogPIDEnvVar  = "WATCHDOG_PID"
+)
 
 // openNotify opens the systemd notify socket only if the expected env var has
 // been set, because the systemd unit file is Type=notify or Type=notify-reload
@@ -38,3 +43,21 @@ func sdNotify(w io.Writer, msg string) {
 	}
 	w.Write([]byte(msg))
 }
+
+// watchdogInterval returns the watchdog timeout systemd expects keep-alive
+// pings within, or zero if the unit doesn't set WatchdogSec or the watchdog is
+// meant for another process. Like openNotify, it unsets the env vars so that
+// child processes can't inherit them.
+func watchdogInterval() time.Duration {
+	defer os.Unsetenv(sdWatchdogUSecEnvVar)
+	defer os.Unsetenv(sdWatchdogPIDEnvVar)
+
+	usec, err := strconv.ParseInt(os.Getenv(sdWatchdogUSecEnvVar), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if pid := os.Getenv(sdWatchdogPIDEnvVar); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
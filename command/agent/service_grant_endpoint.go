@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ServiceGrantsRequest is callable via the /v1/service-grants HTTP API and
+// handles listing and creating service grants.
+func (s *HTTPServer) ServiceGrantsRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.serviceGrantList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.serviceGrantUpsert(resp, req)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+// ServiceGrantSpecificRequest is callable via the /v1/service-grant/ HTTP API
+// and handles service grant deletions.
+func (s *HTTPServer) ServiceGrantSpecificRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	id := strings.TrimPrefix(req.URL.Path, "/v1/service-grant/")
+	if id == "" {
+		return nil, CodedError(http.StatusBadRequest, "missing service grant ID")
+	}
+
+	switch req.Method {
+	case http.MethodDelete:
+		return s.serviceGrantDelete(resp, req, id)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) serviceGrantList(resp http.ResponseWriter, req *http.Request) (any, error) {
+	args := structs.ServiceGrantListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var reply structs.ServiceGrantListResponse
+	if err := s.agent.RPC(structs.ServiceGrantListRPCMethod, &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	if reply.Grants == nil {
+		reply.Grants = make([]*structs.ServiceGrant, 0)
+	}
+	return reply.Grants, nil
+}
+
+func (s *HTTPServer) serviceGrantUpsert(resp http.ResponseWriter, req *http.Request) (any, error) {
+	var grant structs.ServiceGrant
+	if err := decodeBody(req, &grant); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	args := structs.ServiceGrantUpsertRequest{
+		Grants: []*structs.ServiceGrant{&grant},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.ServiceGrantUpsertResponse
+	if err := s.agent.RPC(structs.ServiceGrantUpsertRPCMethod, &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+
+	if len(reply.Grants) != 1 {
+		return nil, nil
+	}
+	return reply.Grants[0], nil
+}
+
+func (s *HTTPServer) serviceGrantDelete(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.ServiceGrantDeleteRequest{
+		IDs: []string{id},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.ServiceGrantDeleteResponse
+	if err := s.agent.RPC(structs.ServiceGrantDeleteRPCMethod, &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+	return nil, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TaskLeadershipRequest handles the leader elections tasks take part in
+// through the Task API. It is callable via the /v1/task/leadership/:name HTTP
+// API with the workload identity of the task.
+func (s *HTTPServer) TaskLeadershipRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	client := s.agent.Client()
+	if client == nil {
+		return nil, clientNotRunning
+	}
+
+	name := strings.TrimPrefix(req.URL.Path, "/v1/task/leadership/")
+	if name == "" {
+		return nil, CodedError(400, "missing election name")
+	}
+
+	var secret string
+	s.parseToken(req, &secret)
+
+	var out *cstructs.TaskLeadership
+	var err error
+	switch req.Method {
+	case http.MethodGet:
+		out, err = client.TaskLeadershipStatus(secret, name)
+	case http.MethodPut, http.MethodPost:
+		out, err = client.TaskLeadershipCampaign(secret, name)
+	case http.MethodDelete:
+		out, err = client.TaskLeadershipResign(secret, name)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	if err != nil {
+		if structs.IsErrUnknownAllocation(err) {
+			return nil, CodedError(404, err.Error())
+		}
+		return nil, err
+	}
+	return out, nil
+}
@@ -97,6 +97,7 @@ client {
   gc_max_allocs            = 50
   no_host_uuid             = false
   disable_remote_exec      = true
+  enable_status_page       = true
 
   host_volume "tmp" {
     path = "/tmp"
@@ -129,12 +130,15 @@ server {
   min_heartbeat_ttl             = "33s"
   max_heartbeats_per_second     = 11.0
   failover_heartbeat_ttl        = "330s"
+  plan_queue_aging_interval     = "45s"
   retry_join                    = ["1.1.1.1", "2.2.2.2"]
   start_join                    = ["1.1.1.1", "2.2.2.2"]
   retry_max                     = 3
   retry_interval                = "15s"
   rejoin_after_leave            = true
   non_voting_server             = true
+  read_replica                  = true
+  identity_meta_claims          = ["owner"]
   redundancy_zone               = "foo"
   upgrade_version               = "0.8.0"
   encrypt                       = "abc"
@@ -0,0 +1,8 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+server {
+  deployment_metrics {
+    prometheus_address = "http://prometheus.example.com:9090"
+  }
+}
@@ -0,0 +1,14 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+server {
+  deployment_webhook "ci" {
+    url    = "https://ci.example.com/hooks/nomad"
+    secret = "s3cr3t"
+    events = ["failed", "successful"]
+  }
+
+  deployment_webhook "chat" {
+    url = "http://chat.example.com"
+  }
+}
@@ -0,0 +1,16 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+server {
+  job_admission_webhook {
+    url         = "https://admission.example.com/jobs"
+    timeout     = "5s"
+    fail_policy = "ignore"
+
+    tls {
+      ca_file   = "/etc/nomad/admission-ca.pem"
+      cert_file = "/etc/nomad/admission-cert.pem"
+      key_file  = "/etc/nomad/admission-key.pem"
+    }
+  }
+}
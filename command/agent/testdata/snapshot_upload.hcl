@@ -0,0 +1,13 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+server {
+  snapshot_upload {
+    storage    = "s3://backups/nomad"
+    interval   = "1h"
+    retain     = 24
+    region     = "us-east-1"
+    sse        = "aws:kms"
+    kms_key_id = "alias/nomad"
+  }
+}
@@ -19,6 +19,7 @@ var (
 
 	acquireLockQueryParam = string(structs.VarOpLockAcquire)
 	releaseLockQueryParam = string(structs.VarOpLockRelease)
+	stealLockQueryParam   = string(structs.VarOpLockSteal)
 )
 
 func (s *HTTPServer) VariablesListRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -115,8 +116,8 @@ func (s *HTTPServer) variableLockOperation(resp http.ResponseWriter, req *http.R
 		return nil, CodedError(http.StatusBadRequest, err.Error())
 	}
 
-	// At this point, the operation can be either acquire or release, and they are
-	// both handled by the VariablesApplyRPCMethod.
+	// At this point, the operation can be either acquire, release, or steal,
+	// and they are all handled by the VariablesApplyRPCMethod.
 	args := structs.VariablesApplyRequest{
 		Op:  structs.VarOp(operation),
 		Var: &Variable,
@@ -279,33 +280,24 @@ func parseCAS(req *http.Request) (bool, uint64, error) {
 	return false, 0, nil
 }
 
-func isOneAndOnlyOneSet(a, b, c bool) bool {
-	return (a || b || c) && !a != !b != !c != !(a && b && c)
-}
-
 // getLockOperation returns the lock operation to be performed in case there is
 // one. It returns error if more than one is set.
 func getLockOperation(queryParams url.Values) (string, error) {
-	_, renewLock := queryParams[renewLockQueryParam]
-	_, acquireLock := queryParams[acquireLockQueryParam]
-	_, releaseLock := queryParams[releaseLockQueryParam]
-
-	if !renewLock && !acquireLock && !releaseLock {
-		return "", nil
-	}
-
-	if !isOneAndOnlyOneSet(renewLock, acquireLock, releaseLock) {
-		return "", errors.New("multiple lock operations")
+	var operation string
+	for _, param := range []string{
+		renewLockQueryParam,
+		acquireLockQueryParam,
+		releaseLockQueryParam,
+		stealLockQueryParam,
+	} {
+		if _, ok := queryParams[param]; !ok {
+			continue
+		}
+		if operation != "" {
+			return "", errors.New("multiple lock operations")
+		}
+		operation = param
 	}
 
-	switch {
-	case renewLock:
-		return renewLockQueryParam, nil
-	case acquireLock:
-		return acquireLockQueryParam, nil
-	case releaseLock:
-		return releaseLockQueryParam, nil
-	default:
-		return "", errors.New("unspecified lock operation")
-	}
+	return operation, nil
 }
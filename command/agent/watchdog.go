@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// watchdogRaftStallTimeout is how long a server may hold committed raft
+	// log entries without applying any of them before it is unhealthy.
+	watchdogRaftStallTimeout = 2 * time.Minute
+
+	// watchdogHeartbeatTimeout is how long a client may go without a
+	// successful heartbeat before it is unhealthy.
+	watchdogHeartbeatTimeout = 5 * time.Minute
+)
+
+// raftIndexer is the part of the server checked by the watchdog.
+type raftIndexer interface {
+	RaftIndexes() (commit, applied uint64)
+}
+
+// heartbeater is the part of the client checked by the watchdog.
+type heartbeater interface {
+	LastHeartbeat() time.Time
+}
+
+// agentWatchdog pings the systemd watchdog as long as the agent is healthy,
+// so that systemd restarts agents which are wedged rather than crashed.
+type agentWatchdog struct {
+	logger log.Logger
+
+	server raftIndexer
+	client heartbeater
+
+	// started is when the watchdog started, and is used in place of the last
+	// heartbeat until the client heartbeats for the first time.
+	started time.Time
+
+	// lastApplied is the applied raft index seen by the previous check, and
+	// lastProgress when it last changed or caught up with the commit index.
+	lastApplied  uint64
+	lastProgress time.Time
+}
+
+func newAgentWatchdog(a *Agent, now time.Time) *agentWatchdog {
+	w := &agentWatchdog{
+		logger:       a.logger.Named("watchdog"),
+		started:      now,
+		lastProgress: now,
+	}
+	// Avoid storing typed nil pointers in the interfaces
+	if srv := a.Server(); srv != nil {
+		w.server = srv
+	}
+	if c := a.Client(); c != nil {
+		w.client = c
+	}
+	return w
+}
+
+// check returns an error if the agent is unhealthy.
+func (w *agentWatchdog) check(now time.Time) error {
+	if w.server != nil {
+		commit, applied := w.server.RaftIndexes()
+		if applied != w.lastApplied || applied >= commit {
+			w.lastApplied = applied
+			w.lastProgress = now
+		} else if stalled := now.Sub(w.lastProgress); stalled > watchdogRaftStallTimeout {
+			return fmt.Errorf("raft has not applied committed entries for %v (commit index %d, applied index %d)",
+				stalled.Round(time.Second), commit, applied)
+		}
+	}
+
+	if w.client != nil {
+		last := w.client.LastHeartbeat()
+		if last.IsZero() || last.Before(w.started) {
+			last = w.started
+		}
+		if since := now.Sub(last); since > watchdogHeartbeatTimeout {
+			return fmt.Errorf("no successful heartbeat for %v", since.Round(time.Second))
+		}
+	}
+
+	return nil
+}
+
+// run pings the watchdog at half the watchdog interval until shutdownCh is
+// closed. Pings are withheld while the agent is unhealthy, which lets systemd
+// restart it once the interval expires.
+func (w *agentWatchdog) run(sdSock io.Writer, interval time.Duration, shutdownCh <-chan struct{}) {
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+
+	for {
+		if err := w.check(time.Now()); err != nil {
+			w.logger.Error("agent is unhealthy, withholding watchdog ping", "error", err)
+		} else {
+			sdNotify(sdSock, sdWatchdog)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-shutdownCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+type mockRaftIndexer struct {
+	commit, applied uint64
+}
+
+func (m *mockRaftIndexer) RaftIndexes() (uint64, uint64) {
+	return m.commit, m.applied
+}
+
+type mockHeartbeater struct {
+	last time.Time
+}
+
+func (m *mockHeartbeater) LastHeartbeat() time.Time {
+	return m.last
+}
+
+func TestAgentWatchdog_Server(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Now()
+	raft := &mockRaftIndexer{commit: 10, applied: 10}
+	w := &agentWatchdog{
+		logger:       testlog.HCLogger(t),
+		server:       raft,
+		started:      start,
+		lastProgress: start,
+	}
+
+	// Caught up with the commit index, even without new entries
+	must.NoError(t, w.check(start.Add(time.Minute)))
+	must.NoError(t, w.check(start.Add(10*time.Minute)))
+
+	// Behind but still applying entries
+	raft.commit, raft.applied = 20, 12
+	must.NoError(t, w.check(start.Add(11*time.Minute)))
+	raft.applied = 15
+	must.NoError(t, w.check(start.Add(14*time.Minute)))
+
+	// Behind without progress for longer than the stall timeout
+	must.NoError(t, w.check(start.Add(15*time.Minute)))
+	must.ErrorContains(t, w.check(start.Add(17*time.Minute)), "raft has not applied")
+
+	// Recovers once entries are applied again
+	raft.applied = 16
+	must.NoError(t, w.check(start.Add(18*time.Minute)))
+}
+
+func TestAgentWatchdog_Client(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Now()
+	client := &mockHeartbeater{}
+	w := &agentWatchdog{
+		logger:       testlog.HCLogger(t),
+		client:       client,
+		started:      start,
+		lastProgress: start,
+	}
+
+	// Clients that have not heartbeated yet are measured from startup
+	must.NoError(t, w.check(start.Add(time.Minute)))
+	must.ErrorContains(t, w.check(start.Add(6*time.Minute)), "no successful heartbeat")
+
+	client.last = start.Add(5 * time.Minute)
+	must.NoError(t, w.check(start.Add(6*time.Minute)))
+	must.ErrorContains(t, w.check(start.Add(11*time.Minute)), "no successful heartbeat")
+}
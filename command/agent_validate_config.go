@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type AgentValidateConfigCommand struct {
+	Meta
+}
+
+func (c *AgentValidateConfigCommand) Help() string {
+	helpText := `
+Usage: nomad agent validate-config [options]
+
+  Parse and validate a candidate agent configuration. If the -against flag is
+  set, the candidate configuration is also compared with the configuration of
+  a running agent, including default values, and each change is reported as
+  either applied on configuration reload or requiring an agent restart.
+
+  The candidate configuration is merged over the default configuration the
+  same way the agent does on startup. Command line flags the running agent
+  was started with are not taken into account and may show up as changes.
+
+  When ACLs are enabled and the -against flag is set, this command requires a
+  token with the 'agent:read' capability.
+
+  Returns 0 if the configuration is valid, or 1 if there are problems.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Validate Config Options:
+
+  -file <path>
+    Path to a configuration file or directory of the candidate configuration.
+    This option may be specified multiple times, in which case later files
+    are merged over earlier ones. Required.
+
+  -against <address>
+    HTTP address of the running agent to compare the candidate configuration
+    with, for example "https://10.0.0.5:4646". The TLS options of the command
+    apply to this address. If not set, the candidate configuration is only
+    validated.
+
+  -json
+    Output the configuration changes in JSON format.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AgentValidateConfigCommand) Synopsis() string {
+	return "Validate and diff an agent configuration"
+}
+
+func (c *AgentValidateConfigCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-file":    complete.PredictFiles("*"),
+			"-against": complete.PredictAnything,
+			"-json":    complete.PredictNothing,
+		})
+}
+
+func (c *AgentValidateConfigCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *AgentValidateConfigCommand) Name() string { return "agent validate-config" }
+
+func (c *AgentValidateConfigCommand) Run(args []string) int {
+	var files flaghelper.StringFlag
+	var against string
+	var jsonOutput bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var(&files, "file", "")
+	flags.StringVar(&against, "against", "", "")
+	flags.BoolVar(&jsonOutput, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing flags: %s", err))
+		return 1
+	}
+
+	if len(flags.Args()) > 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if len(files) == 0 {
+		c.Ui.Error("Must specify at least one configuration file with -file")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	candidate, err := agent.LoadConfigFiles(files)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	cmd := agent.Command{Ui: c.Ui}
+	if !cmd.IsValidConfig(candidate, agent.DefaultConfig()) {
+		c.Ui.Error("Configuration is invalid")
+		return 1
+	}
+
+	if against == "" {
+		c.Ui.Output("Configuration is valid!")
+		return 0
+	}
+	c.Meta.flagAddress = against
+
+	changes, err := c.diffAgentConfig(candidate)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	if jsonOutput {
+		out, err := Format(true, "", changes)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output("Configuration is valid!")
+	if len(changes) == 0 {
+		c.Ui.Output("\nNo changes compared to the running agent")
+		return 0
+	}
+
+	restartRequired := 0
+	rows := make([]string, len(changes)+1)
+	rows[0] = "Path|Running|Candidate|On Reload"
+	for i, change := range changes {
+		action := "applied"
+		if !change.Reloadable {
+			action = "restart required"
+			restartRequired++
+		}
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s", change.Path, change.Old, change.New, action)
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Changes[reset]"))
+	c.Ui.Output(formatList(rows))
+
+	if restartRequired > 0 {
+		c.Ui.Warn(fmt.Sprintf("\n%d of %d changes require an agent restart",
+			restartRequired, len(changes)))
+	}
+	return 0
+}
+
+// diffAgentConfig compares the candidate configuration with the configuration
+// of the agent the command is pointed at.
+func (c *AgentValidateConfigCommand) diffAgentConfig(candidate *agent.Config) ([]*agent.ConfigChange, error) {
+	client, err := c.Meta.Client()
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing client: %s", err)
+	}
+
+	self, err := client.Agent().Self()
+	if err != nil {
+		return nil, fmt.Errorf("Error querying agent info: %s", err)
+	}
+
+	// The running configuration is returned as a generic map, so decode it
+	// into the agent configuration and put the candidate through the same
+	// encoding to compare like with like.
+	var running agent.Config
+	if err := decodeAgentConfig(self.Config, &running); err != nil {
+		return nil, fmt.Errorf("Error decoding agent configuration: %s", err)
+	}
+
+	var redacted agent.Config
+	if err := decodeAgentConfig(candidate.Redacted(), &redacted); err != nil {
+		return nil, fmt.Errorf("Error encoding candidate configuration: %s", err)
+	}
+
+	return agent.DiffConfig(&running, &redacted), nil
+}
+
+// decodeAgentConfig converts in to an agent configuration via its JSON
+// encoding.
+func decodeAgentConfig(in any, out *agent.Config) error {
+	buf, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, out)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/shoenig/test/must"
+)
+
+func TestAgentValidateConfigCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &AgentValidateConfigCommand{}
+}
+
+func TestAgentValidateConfigCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+
+	ui := cli.NewMockUi()
+	cmd := &AgentValidateConfigCommand{Meta: Meta{Ui: ui}}
+
+	// No files
+	code := cmd.Run(nil)
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Must specify at least one configuration file")
+	ui.ErrorWriter.Reset()
+
+	// Invalid configuration, neither server nor client enabled
+	path := filepath.Join(dir, "invalid.hcl")
+	must.NoError(t, os.WriteFile(path, []byte(`datacenter = "dc1"`), 0o644))
+
+	code = cmd.Run([]string{"-file", path})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Configuration is invalid")
+}
+
+func TestAgentValidateConfigCommand_Against(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent.hcl")
+	must.NoError(t, os.WriteFile(path, []byte(`
+data_dir  = "/tmp/nomad"
+log_level = "INFO"
+server {
+  enabled        = true
+  num_schedulers = 1
+}
+`), 0o644))
+
+	// Without -against the configuration is only validated
+	ui := cli.NewMockUi()
+	cmd := &AgentValidateConfigCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-file", path})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "Configuration is valid!")
+	must.StrNotContains(t, ui.OutputWriter.String(), "Changes")
+
+	ui = cli.NewMockUi()
+	cmd = &AgentValidateConfigCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-file", path, "-against", url})
+	must.Zero(t, code)
+
+	out := ui.OutputWriter.String()
+	must.RegexMatch(t, regexp.MustCompile(`log_level\s+"\w+"\s+"INFO"\s+applied`), out)
+	must.RegexMatch(t, regexp.MustCompile(`server.num_schedulers\s+\S+\s+1\s+restart required`), out)
+	must.StrContains(t, ui.ErrorWriter.String(), "changes require an agent restart")
+
+	ui = cli.NewMockUi()
+	cmd = &AgentValidateConfigCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-file", path, "-against", url, "-json"})
+	must.Zero(t, code)
+
+	var changes []*agent.ConfigChange
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &changes))
+
+	paths := map[string]bool{}
+	for _, change := range changes {
+		paths[change.Path] = change.Reloadable
+	}
+	must.MapContainsKey(t, paths, "log_level")
+	must.True(t, paths["log_level"])
+	must.MapContainsKey(t, paths, "server.num_schedulers")
+	must.False(t, paths["server.num_schedulers"])
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
 	"github.com/hashicorp/nomad/helper/escapingio"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/moby/term"
 	"github.com/posener/complete"
 )
@@ -32,9 +33,14 @@ type AllocExecCommand struct {
 func (l *AllocExecCommand) Help() string {
 	helpText := `
 Usage: nomad alloc exec [options] <allocation> <command>
+       nomad alloc exec [options] -attach <session> <allocation>
 
   Run command inside the environment of the given allocation and task.
 
+  With the -attach flag, attach to an exec session shared with -share as a
+  read-only viewer instead: the output of the session is displayed until it
+  ends, and no input is sent to it.
+
   When ACLs are enabled, this command requires a token with the 'alloc-exec',
   'read-job', and 'list-jobs' capabilities for the allocation's namespace. If
   the task driver does not have file system isolation (as with 'raw_exec'),
@@ -65,6 +71,17 @@ Exec Specific Options:
     character is only recognized at the beginning of a line.  The escape character
     followed by a dot ('.') closes the connection.  Setting the character to
     'none' disables any escapes and makes the session fully transparent.
+
+  -record
+    Record the output of the session to the log directory of the allocation.
+    The input of the session, such as keystrokes, is not recorded.
+
+  -share
+    Share the session with read-only viewers. The name of the session and the
+    command viewers can attach with are displayed before the session starts.
+
+  -attach <session>
+    Attach to the shared session with the given name as a read-only viewer.
   `
 	return strings.TrimSpace(helpText)
 }
@@ -76,11 +93,14 @@ func (l *AllocExecCommand) Synopsis() string {
 func (l *AllocExecCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(l.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"--task": complete.PredictAnything,
-			"-job":   complete.PredictAnything,
-			"-i":     complete.PredictNothing,
-			"-t":     complete.PredictNothing,
-			"-e":     complete.PredictSet("none", "~"),
+			"--task":  complete.PredictAnything,
+			"-job":    complete.PredictAnything,
+			"-i":      complete.PredictNothing,
+			"-t":      complete.PredictNothing,
+			"-e":      complete.PredictSet("none", "~"),
+			"-record": complete.PredictNothing,
+			"-share":  complete.PredictNothing,
+			"-attach": complete.PredictAnything,
 		})
 }
 
@@ -102,8 +122,8 @@ func (l *AllocExecCommand) AutocompleteArgs() complete.Predictor {
 func (l *AllocExecCommand) Name() string { return "alloc exec" }
 
 func (l *AllocExecCommand) Run(args []string) int {
-	var job, stdinOpt, ttyOpt bool
-	var task, escapeChar string
+	var job, stdinOpt, ttyOpt, record, share bool
+	var task, escapeChar, attach string
 
 	flags := l.Meta.FlagSet(l.Name(), FlagSetClient)
 	flags.Usage = func() { l.Ui.Output(l.Help()) }
@@ -112,6 +132,9 @@ func (l *AllocExecCommand) Run(args []string) int {
 	flags.BoolVar(&ttyOpt, "t", isTty(), "")
 	flags.StringVar(&escapeChar, "e", "~", "")
 	flags.StringVar(&task, "task", "", "")
+	flags.BoolVar(&record, "record", false, "")
+	flags.BoolVar(&share, "share", false, "")
+	flags.StringVar(&attach, "attach", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -134,13 +157,23 @@ func (l *AllocExecCommand) Run(args []string) int {
 		return 1
 	}
 
-	if len(args) < 2 {
+	if attach != "" {
+		if len(args) > 1 {
+			l.Ui.Error("A command can't be used with -attach")
+			l.Ui.Error(commandErrorText(l))
+			return 1
+		}
+		if record || share {
+			l.Ui.Error("-record and -share can't be used with -attach")
+			return 1
+		}
+	} else if len(args) < 2 {
 		l.Ui.Error("A command is required")
 		l.Ui.Error(commandErrorText(l))
 		return 1
 	}
 
-	if ttyOpt && !stdinOpt {
+	if attach == "" && ttyOpt && !stdinOpt {
 		l.Ui.Error("-i must be enabled if running with tty")
 		return 1
 	}
@@ -212,6 +245,23 @@ func (l *AllocExecCommand) Run(args []string) int {
 		return 1
 	}
 
+	if l.Stdout == nil {
+		l.Stdout = os.Stdout
+	}
+
+	if l.Stderr == nil {
+		l.Stderr = os.Stderr
+	}
+
+	if attach != "" {
+		code, err := l.attachImpl(client, alloc, task, attach, l.Stdout, l.Stderr)
+		if err != nil {
+			l.Ui.Error(fmt.Sprintf("failed to attach to exec session: %v", err))
+			return 1
+		}
+		return code
+	}
+
 	if !stdinOpt {
 		l.Stdin = bytes.NewReader(nil)
 	}
@@ -220,15 +270,18 @@ func (l *AllocExecCommand) Run(args []string) int {
 		l.Stdin = os.Stdin
 	}
 
-	if l.Stdout == nil {
-		l.Stdout = os.Stdout
+	q.Params = make(map[string]string)
+	if record {
+		q.Params["record"] = "true"
 	}
-
-	if l.Stderr == nil {
-		l.Stderr = os.Stderr
+	if share {
+		session := uuid.Short()
+		q.Params["share"] = session
+		fmt.Fprintf(l.Stderr, "Sharing exec session %q, read-only viewers can attach with:\n"+
+			"  nomad alloc exec -attach=%s -task=%s %s\n\n", session, session, task, alloc.ID)
 	}
 
-	code, err := l.execImpl(client, alloc, task, ttyOpt, args[1:], escapeChar, l.Stdin, l.Stdout, l.Stderr)
+	code, err := l.execImpl(client, alloc, task, ttyOpt, args[1:], escapeChar, l.Stdin, l.Stdout, l.Stderr, q)
 	if err != nil {
 		l.Ui.Error(fmt.Sprintf("failed to exec into task: %v", err))
 		return 1
@@ -239,7 +292,7 @@ func (l *AllocExecCommand) Run(args []string) int {
 
 // execImpl invokes the Alloc Exec api call, it also prepares and restores terminal states as necessary.
 func (l *AllocExecCommand) execImpl(client *api.Client, alloc *api.Allocation, task string, tty bool,
-	command []string, escapeChar string, stdin io.Reader, stdout, stderr io.WriteCloser) (int, error) {
+	command []string, escapeChar string, stdin io.Reader, stdout, stderr io.WriteCloser, q *api.QueryOptions) (int, error) {
 
 	sizeCh := make(chan api.TerminalSize, 1)
 
@@ -298,7 +351,29 @@ func (l *AllocExecCommand) execImpl(client *api.Client, alloc *api.Allocation, t
 	}()
 
 	return client.Allocations().Exec(ctx,
-		alloc, task, tty, command, stdin, stdout, stderr, sizeCh, nil)
+		alloc, task, tty, command, stdin, stdout, stderr, sizeCh, q)
+}
+
+// attachImpl invokes the Alloc ExecAttach api call to view a shared session
+// until it ends or the command is interrupted.
+func (l *AllocExecCommand) attachImpl(client *api.Client, alloc *api.Allocation, task, session string,
+	stdout, stderr io.WriteCloser) (int, error) {
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			cancelFn()
+		case <-ctx.Done():
+		}
+	}()
+
+	return client.Allocations().ExecAttach(ctx, alloc, task, session, stdout, stderr, nil)
 }
 
 // setRawTerminal sets the stream terminal in raw mode, so process captures
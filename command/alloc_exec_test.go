@@ -72,6 +72,16 @@ func TestAllocExecCommand_Fails(t *testing.T) {
 			[]string{"-address=nope", "26470238-5CF2-438F-8772-DC67CFB0705C", "/bin/bash"},
 			`Error querying allocation`,
 		},
+		{
+			"command with attach",
+			[]string{"-address=" + url, "-attach=pair", "26470238-5CF2-438F-8772-DC67CFB0705C", "/bin/bash"},
+			`A command can't be used with -attach`,
+		},
+		{
+			"share with attach",
+			[]string{"-address=" + url, "-attach=pair", "-share", "26470238-5CF2-438F-8772-DC67CFB0705C"},
+			`-record and -share can't be used with -attach`,
+		},
 		{
 			"escape char too long",
 			[]string{"-address=" + url, "-e", "es", "26470238-5CF2-438F-8772-DC67CFB0705C", "/bin/bash"},
@@ -331,11 +331,38 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 			fmt.Sprintf("Exhausted Nodes|%d", alloc.Metrics.NodesExhausted),
 			fmt.Sprintf("Allocation Time|%s", alloc.Metrics.AllocationTime),
 			fmt.Sprintf("Failures|%d", alloc.Metrics.CoalescedFailures))
+		basic = append(basic, formatAllocTimestamps(alloc.Timestamps)...)
 	}
 
 	return formatKV(basic), nil
 }
 
+// formatAllocTimestamps returns the rows of the placement and startup
+// timestamps of an allocation that were reached.
+func formatAllocTimestamps(ts *api.AllocTimestamps) []string {
+	if ts == nil {
+		return nil
+	}
+
+	var rows []string
+	for _, step := range []struct {
+		name string
+		time int64
+	}{
+		{"Eval Created", ts.EvalCreateTime},
+		{"Plan Submitted", ts.PlanSubmitTime},
+		{"Plan Applied", ts.PlanApplyTime},
+		{"Client Received", ts.ClientReceiveTime},
+		{"Task Started", ts.TaskStartTime},
+		{"First Healthy", ts.FirstHealthyTime},
+	} {
+		if step.time != 0 {
+			rows = append(rows, fmt.Sprintf("%s|%s", step.name, formatUnixNanoTime(step.time)))
+		}
+	}
+	return rows
+}
+
 func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	nw := alloc.AllocatedResources.Shared.Networks[0]
 	addrs := []string{"Label|Dynamic|Address"}
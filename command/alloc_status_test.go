@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -284,6 +285,22 @@ func TestAllocStatusCommand_ScoreMetrics(t *testing.T) {
 	must.StrContains(t, out, "final score")
 }
 
+func TestAllocStatusCommand_FormatTimestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, formatAllocTimestamps(nil))
+
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	ts := &api.AllocTimestamps{
+		EvalCreateTime: created.UnixNano(),
+		PlanApplyTime:  created.Add(time.Second).UnixNano(),
+	}
+	must.Eq(t, []string{
+		"Eval Created|" + formatUnixNanoTime(ts.EvalCreateTime),
+		"Plan Applied|" + formatUnixNanoTime(ts.PlanApplyTime),
+	}, formatAllocTimestamps(ts))
+}
+
 func TestAllocStatusCommand_AutocompleteArgs(t *testing.T) {
 	ci.Parallel(t)
 
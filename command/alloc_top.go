@@ -0,0 +1,352 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+// clearScreen moves the cursor to the top left corner of the terminal and
+// clears it, so that each refresh of the table replaces the previous one.
+const clearScreen = "\033[H\033[2J"
+
+type AllocTopCommand struct {
+	Meta
+}
+
+func (c *AllocTopCommand) Help() string {
+	helpText := `
+Usage: nomad alloc top [options] <allocation>
+
+  Streams the CPU and memory usage of the tasks of an allocation and displays
+  it in a table refreshed at each interval, until interrupted. With the -job
+  flag, the argument is a job ID and the usage of all the running allocations
+  of the job is displayed.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Top Specific Options:
+
+  -job
+    Use a job ID instead of an allocation ID as the argument, and display the
+    usage of the allocations of the job running when the command starts.
+
+  -task <task>
+    Only display the usage of the given task.
+
+  -interval <duration>
+    Interval at which the usage is refreshed. Intervals lower than the stats
+    collection interval of the client are raised to it. Defaults to 1s.
+
+  -verbose
+    Show full allocation IDs.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocTopCommand) Synopsis() string {
+	return "Display live resource usage of an allocation"
+}
+
+func (c *AllocTopCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-job":      complete.PredictNothing,
+			"-task":     complete.PredictAnything,
+			"-interval": complete.PredictAnything,
+			"-verbose":  complete.PredictNothing,
+		})
+}
+
+func (c *AllocTopCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocTopCommand) Name() string { return "alloc top" }
+
+func (c *AllocTopCommand) Run(args []string) int {
+	var job, verbose bool
+	var task string
+	var interval time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&job, "job", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&task, "task", "", "")
+	flags.DurationVar(&interval, "interval", time.Second, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <allocation>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if interval <= 0 {
+		c.Ui.Error("Interval must be greater than zero")
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	var allocs []*api.Allocation
+	if job {
+		allocs, err = c.jobAllocs(client, args[0])
+	} else {
+		allocs, err = c.alloc(client, args[0], length)
+	}
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	// Stream the usage of each allocation, and collect the latest sample of
+	// each of them to refresh the table.
+	updates := make(chan *allocTopUpdate)
+	for _, alloc := range allocs {
+		go streamAllocTopUpdates(ctx, client, alloc, task, interval, updates)
+	}
+
+	usage := make(map[string]*api.AllocResourceUsage, len(allocs))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for streams := len(allocs); streams > 0; {
+		select {
+		case <-signalCh:
+			return 0
+		case update := <-updates:
+			switch {
+			case update.err != nil:
+				streams--
+				delete(usage, update.alloc.ID)
+				c.Ui.Error(fmt.Sprintf("Error streaming stats of allocation %q: %s",
+					limit(update.alloc.ID, length), update.err))
+				if !job {
+					return 1
+				}
+			case update.usage == nil:
+				streams--
+				delete(usage, update.alloc.ID)
+			default:
+				usage[update.alloc.ID] = update.usage
+			}
+		case <-ticker.C:
+			out := formatAllocTop(allocs, usage, length)
+			if isTty() {
+				out = clearScreen + out
+			}
+			c.Ui.Output(out)
+		}
+	}
+
+	return 0
+}
+
+// alloc returns the allocation matching the ID prefix.
+func (c *AllocTopCommand) alloc(client *api.Client, allocID string, length int) ([]*api.Allocation, error) {
+	if len(allocID) == 1 {
+		return nil, errors.New("Alloc ID must contain at least two characters")
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		return nil, fmt.Errorf("Error querying allocation: %v", err)
+	}
+	if len(allocs) == 0 {
+		return nil, fmt.Errorf("No allocation(s) with prefix or id %q found", allocID)
+	}
+	if len(allocs) > 1 {
+		out := formatAllocListStubs(allocs, length == fullId, length)
+		return nil, fmt.Errorf("Prefix matched multiple allocations\n\n%s", out)
+	}
+
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		return nil, fmt.Errorf("Error querying allocation: %s", err)
+	}
+	return []*api.Allocation{alloc}, nil
+}
+
+// jobAllocs returns the running allocations of the job matching the ID
+// prefix.
+func (c *AllocTopCommand) jobAllocs(client *api.Client, jobID string) ([]*api.Allocation, error) {
+	jobID, namespace, err := c.JobIDByPrefix(client, jobID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &api.QueryOptions{Namespace: namespace}
+	stubs, _, err := client.Jobs().Allocations(jobID, false, q)
+	if err != nil {
+		return nil, fmt.Errorf("Error querying job allocations: %s", err)
+	}
+
+	var allocs []*api.Allocation
+	for _, stub := range stubs {
+		if stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		alloc, _, err := client.Allocations().Info(stub.ID, q)
+		if err != nil {
+			return nil, fmt.Errorf("Error querying allocation: %s", err)
+		}
+		allocs = append(allocs, alloc)
+	}
+	if len(allocs) == 0 {
+		return nil, fmt.Errorf("Job %q has no running allocations", jobID)
+	}
+
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].Name < allocs[j].Name })
+	return allocs, nil
+}
+
+// allocTopUpdate is a sample of the usage of an allocation, or the end of its
+// stream if usage is nil.
+type allocTopUpdate struct {
+	alloc *api.Allocation
+	usage *api.AllocResourceUsage
+	err   error
+}
+
+// streamAllocTopUpdates sends the samples of the usage of the allocation to
+// updates until the stream ends or the context is canceled.
+func streamAllocTopUpdates(ctx context.Context, client *api.Client, alloc *api.Allocation,
+	task string, interval time.Duration, updates chan<- *allocTopUpdate) {
+
+	q := &api.QueryOptions{Namespace: alloc.Namespace}
+	statsCh, errCh := client.Allocations().StatsStream(ctx, alloc, task, interval, q)
+
+	send := func(update *allocTopUpdate) bool {
+		select {
+		case updates <- update:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case err := <-errCh:
+			send(&allocTopUpdate{alloc: alloc, err: err})
+			return
+		case usage, ok := <-statsCh:
+			if !ok {
+				send(&allocTopUpdate{alloc: alloc})
+				return
+			}
+			if !send(&allocTopUpdate{alloc: alloc, usage: usage}) {
+				return
+			}
+		}
+	}
+}
+
+// formatAllocTop returns a table of the latest usage of the tasks of each
+// allocation.
+func formatAllocTop(allocs []*api.Allocation, usage map[string]*api.AllocResourceUsage, length int) string {
+	rows := []string{"Alloc ID|Name|Task|CPU|CPU %|Throttled|Memory|Max Memory"}
+	for _, alloc := range allocs {
+		stats, ok := usage[alloc.ID]
+		if !ok {
+			continue
+		}
+
+		tasks := make([]string, 0, len(stats.Tasks))
+		for task := range stats.Tasks {
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+
+		for _, task := range tasks {
+			ru := stats.Tasks[task].ResourceUsage
+			if ru == nil {
+				continue
+			}
+
+			cpu, percent, throttled := "-", "-", "-"
+			if cs := ru.CpuStats; cs != nil {
+				cpu = fmt.Sprintf("%v MHz", math.Floor(cs.TotalTicks))
+				percent = strconv.FormatFloat(cs.Percent, 'f', 2, 64)
+				throttled = time.Duration(cs.ThrottledTime).String()
+			}
+
+			mem, maxMem := "-", "-"
+			if ms := ru.MemoryStats; ms != nil {
+				// RSS is not measured with cgroups v2, so fall back to the
+				// usage as in alloc status.
+				memUsage := ms.RSS
+				if memUsage == 0 && !slices.Contains(ms.Measured, "RSS") {
+					memUsage = ms.Usage
+				}
+				mem = humanize.IBytes(memUsage)
+				maxMem = humanize.IBytes(ms.MaxUsage)
+			}
+
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s",
+				limit(alloc.ID, length), alloc.Name, task,
+				cpu, percent, throttled, mem, maxMem))
+		}
+	}
+
+	return formatList(rows)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocTopCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = (*AllocTopCommand)(nil)
+}
+
+func TestAllocTopCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &AllocTopCommand{Meta: Meta{Ui: ui}}
+
+	// fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// fails on invalid interval
+	code = cmd.Run([]string{"-address=" + url, "-interval=0s", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Interval must be greater than zero")
+	ui.ErrorWriter.Reset()
+
+	// fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying allocation")
+	ui.ErrorWriter.Reset()
+
+	// fails on missing allocation
+	code = cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+	ui.ErrorWriter.Reset()
+
+	// fails on prefix with too few characters
+	code = cmd.Run([]string{"-address=" + url, "2"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "must contain at least two characters")
+	ui.ErrorWriter.Reset()
+
+	// fails on missing job
+	code = cmd.Run([]string{"-address=" + url, "-job", "example"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `No job(s) with prefix or ID "example" found`)
+}
+
+func TestAllocTopCommand_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	allocs := []*api.Allocation{
+		{ID: "a0c1e2b4-2c6e-4b1f-9e0f-0b6f3d7d1a01", Name: "example.web[0]"},
+		{ID: "f6a9d1c2-7f3b-4b7e-8a4c-2d9e5f0b3c02", Name: "example.web[1]"},
+	}
+	usage := map[string]*api.AllocResourceUsage{
+		allocs[0].ID: {
+			Tasks: map[string]*api.TaskResourceUsage{
+				"web": {
+					ResourceUsage: &api.ResourceUsage{
+						CpuStats: &api.CpuStats{
+							TotalTicks:    250.7,
+							Percent:       12.5,
+							ThrottledTime: 2000000,
+						},
+						MemoryStats: &api.MemoryStats{
+							RSS:      64 * 1024 * 1024,
+							MaxUsage: 128 * 1024 * 1024,
+							Measured: []string{"RSS", "Max Usage"},
+						},
+					},
+				},
+				"sidecar": {
+					ResourceUsage: &api.ResourceUsage{
+						MemoryStats: &api.MemoryStats{
+							Usage:    32 * 1024 * 1024,
+							Measured: []string{"Usage"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out := formatAllocTop(allocs, usage, shortId)
+	expected := formatList([]string{
+		"Alloc ID|Name|Task|CPU|CPU %|Throttled|Memory|Max Memory",
+		"a0c1e2b4|example.web[0]|sidecar|-|-|-|32 MiB|0 B",
+		"a0c1e2b4|example.web[0]|web|250 MHz|12.50|2ms|64 MiB|128 MiB",
+	})
+	must.Eq(t, expected, out)
+}
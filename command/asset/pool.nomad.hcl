@@ -9,6 +9,28 @@ node_pool "example" {
     owner       = "sre"
   }
 
+  # max_job_feature_level is the most recent Nomad version whose job
+  # specification features may be required by the jobs in this node pool. Jobs
+  # with a more recent min_client_version are rejected.
+  # max_job_feature_level = "1.9.0"
+
+  # readiness_gate blocks define the jobs, usually system jobs installing
+  # node-level dependencies such as CNI plugins, that must have a healthy
+  # allocation on a node of this node pool before allocations of other jobs
+  # are placed on the node. namespace defaults to "default".
+  # readiness_gate {
+  #   namespace = "default"
+  #   job_id    = "cni-installer"
+  # }
+
+  # port_config overrides the dynamic port range and reserved ports of all the
+  # nodes in this node pool. Nodes pick up changes when they next register.
+  # port_config {
+  #   min_dynamic_port = 20000
+  #   max_dynamic_port = 32000
+  #   reserved_ports   = "22,80,443"
+  # }
+
   # The scheduler configuration options specific to this node pool. This block
   # supports a subset of the fields supported in the global scheduler
   # configuration as described at:
@@ -19,8 +41,6 @@ node_pool "example" {
   #
   # * memory_oversubscription_enabled specifies whether memory oversubscription
   #   is enabled. If not defined, the global cluster configuration is used.
-  #
-  # Available only in Nomad Enterprise.
 
   # scheduler_config {
   #   scheduler_algorithm             = "spread"
@@ -290,6 +290,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc top": func() (cli.Command, error) {
+			return &AllocTopCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc-status": func() (cli.Command, error) {
 			return &AllocStatusCommand{
 				Meta: meta,
@@ -302,6 +307,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				ShutdownCh: make(chan struct{}),
 			}, nil
 		},
+		"agent validate-config": func() (cli.Command, error) {
+			return &AgentValidateConfigCommand{
+				Meta: meta,
+			}, nil
+		},
 		"agent-info": func() (cli.Command, error) {
 			return &AgentInfoCommand{
 				Meta: meta,
@@ -368,6 +378,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"deployment watch": func() (cli.Command, error) {
+			return &DeploymentWatchCommand{
+				Meta: meta,
+			}, nil
+		},
 		"eval": func() (cli.Command, error) {
 			return &EvalCommand{
 				Meta: meta,
@@ -446,6 +461,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job diff": func() (cli.Command, error) {
+			return &JobDiffCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job dispatch": func() (cli.Command, error) {
 			return &JobDispatchCommand{
 				Meta: meta,
@@ -456,6 +476,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job freeze": func() (cli.Command, error) {
+			return &JobFreezeCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job history": func() (cli.Command, error) {
 			return &JobHistoryCommand{
 				Meta: meta,
@@ -471,6 +496,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job lint": func() (cli.Command, error) {
+			return &JobLintCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job periodic": func() (cli.Command, error) {
 			return &JobPeriodicCommand{
 				Meta: meta,
@@ -536,6 +566,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job unfreeze": func() (cli.Command, error) {
+			return &JobUnfreezeCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job validate": func() (cli.Command, error) {
 			return &JobValidateCommand{
 				Meta: meta,
@@ -621,6 +656,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node lifecycle": func() (cli.Command, error) {
+			return &NodeLifecycleCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node lifecycle abort": func() (cli.Command, error) {
+			return &NodeLifecycleAbortCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node lifecycle run": func() (cli.Command, error) {
+			return &NodeLifecycleRunCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node lifecycle status": func() (cli.Command, error) {
+			return &NodeLifecycleStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node meta": func() (cli.Command, error) {
 			return &NodeMetaCommand{
 				Meta: meta,
@@ -772,6 +827,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator eval-broker": func() (cli.Command, error) {
+			return &OperatorEvalBrokerCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator eval-broker status": func() (cli.Command, error) {
+			return &OperatorEvalBrokerStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator plan-queue": func() (cli.Command, error) {
+			return &OperatorPlanQueueCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator plan-queue status": func() (cli.Command, error) {
+			return &OperatorPlanQueueStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator raft": func() (cli.Command, error) {
 			return &OperatorRaftCommand{
 				Meta: meta,
@@ -824,6 +899,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler simulate": func() (cli.Command, error) {
+			return &OperatorSchedulerSimulateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator root": func() (cli.Command, error) {
 			return &OperatorRootCommand{
 				Meta: meta,
@@ -879,6 +959,16 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator state": func() (cli.Command, error) {
+			return &OperatorStateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator state usage": func() (cli.Command, error) {
+			return &OperatorStateUsageCommand{
+				Meta: meta,
+			}, nil
+		},
 
 		"plan": func() (cli.Command, error) {
 			return &JobPlanCommand{
@@ -1076,6 +1166,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"service grant": func() (cli.Command, error) {
+			return &ServiceGrantCommand{
+				Meta: meta,
+			}, nil
+		},
+		"service grant create": func() (cli.Command, error) {
+			return &ServiceGrantCreateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"service grant list": func() (cli.Command, error) {
+			return &ServiceGrantListCommand{
+				Meta: meta,
+			}, nil
+		},
+		"service grant delete": func() (cli.Command, error) {
+			return &ServiceGrantDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
 		"setup": func() (cli.Command, error) {
 			return &SetupCommand{
 				Meta: meta,
@@ -1209,6 +1319,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Ui:      meta.Ui,
 			}, nil
 		},
+		"version check": func() (cli.Command, error) {
+			return &VersionCheckCommand{
+				Meta: meta,
+			}, nil
+		},
 		"volume": func() (cli.Command, error) {
 			return &VolumeCommand{
 				Meta: meta,
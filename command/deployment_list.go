@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -36,6 +37,12 @@ List Options:
   -filter
     Specifies an expression used to filter query results.
 
+  -per-page
+    How many results to show per page.
+
+  -page-token
+    Where to start pagination.
+
   -t
     Format and display the deployments using a Go template.
 
@@ -48,10 +55,12 @@ List Options:
 func (c *DeploymentListCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-json":    complete.PredictNothing,
-			"-filter":  complete.PredictAnything,
-			"-t":       complete.PredictAnything,
-			"-verbose": complete.PredictNothing,
+			"-json":       complete.PredictNothing,
+			"-filter":     complete.PredictAnything,
+			"-per-page":   complete.PredictAnything,
+			"-page-token": complete.PredictAnything,
+			"-t":          complete.PredictAnything,
+			"-verbose":    complete.PredictNothing,
 		})
 }
 
@@ -67,7 +76,8 @@ func (c *DeploymentListCommand) Name() string { return "deployment list" }
 
 func (c *DeploymentListCommand) Run(args []string) int {
 	var json, verbose bool
-	var filter, tmpl string
+	var perPage int
+	var filter, tmpl, pageToken string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -75,6 +85,8 @@ func (c *DeploymentListCommand) Run(args []string) int {
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&filter, "filter", "", "")
 	flags.StringVar(&tmpl, "t", "", "")
+	flags.IntVar(&perPage, "per-page", 0, "")
+	flags.StringVar(&pageToken, "page-token", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -102,9 +114,11 @@ func (c *DeploymentListCommand) Run(args []string) int {
 	}
 
 	opts := &api.QueryOptions{
-		Filter: filter,
+		Filter:    filter,
+		PerPage:   int32(perPage),
+		NextToken: pageToken,
 	}
-	deploys, _, err := client.Deployments().List(opts)
+	deploys, qm, err := client.Deployments().List(opts)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error retrieving deployments: %s", err))
 		return 1
@@ -122,6 +136,14 @@ func (c *DeploymentListCommand) Run(args []string) int {
 	}
 
 	c.Ui.Output(formatDeployments(deploys, length))
+
+	if qm.NextToken != "" {
+		c.Ui.Output(fmt.Sprintf(`
+Results have been paginated. To get the next page run:
+
+%s -page-token %s`, argsWithoutPageToken(os.Args), qm.NextToken))
+	}
+
 	return 0
 }
 
@@ -51,6 +51,10 @@ Status Options:
   -monitor
     Enter monitor mode to poll for updates to the deployment status.
 
+  -multiregion
+    Display the status of a multiregion deployment in each region of the job,
+    including which regions are blocking the deployment from being unblocked.
+
   -wait
     How long to wait before polling an update, used in conjunction with monitor
     mode. Defaults to 2s.
@@ -68,10 +72,11 @@ func (c *DeploymentStatusCommand) Synopsis() string {
 func (c *DeploymentStatusCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-verbose": complete.PredictNothing,
-			"-json":    complete.PredictNothing,
-			"-monitor": complete.PredictNothing,
-			"-t":       complete.PredictAnything,
+			"-verbose":     complete.PredictNothing,
+			"-json":        complete.PredictNothing,
+			"-monitor":     complete.PredictNothing,
+			"-multiregion": complete.PredictNothing,
+			"-t":           complete.PredictAnything,
 		})
 }
 
@@ -93,7 +98,7 @@ func (c *DeploymentStatusCommand) AutocompleteArgs() complete.Predictor {
 func (c *DeploymentStatusCommand) Name() string { return "deployment status" }
 
 func (c *DeploymentStatusCommand) Run(args []string) int {
-	var json, verbose, monitor bool
+	var json, verbose, monitor, multiregion bool
 	var wait time.Duration
 	var tmpl string
 
@@ -102,6 +107,7 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.BoolVar(&monitor, "monitor", false, "")
+	flags.BoolVar(&multiregion, "multiregion", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.DurationVar(&wait, "wait", 2*time.Second, "")
 
@@ -114,6 +120,10 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 		c.Ui.Error("The monitor flag cannot be used with the '-json' or '-t' flags")
 		return 1
 	}
+	if monitor && multiregion {
+		c.Ui.Error("The monitor flag cannot be used with the '-multiregion' flag")
+		return 1
+	}
 
 	// Check that we got exactly one argument
 	args = flags.Args()
@@ -161,6 +171,10 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 		return 1
 	}
 
+	if multiregion {
+		return c.multiregionStatus(client, deploy, json, tmpl, length)
+	}
+
 	if json || len(tmpl) > 0 {
 		out, err := Format(json, tmpl, deploy)
 		if err != nil {
@@ -189,6 +203,35 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 	return 0
 }
 
+// multiregionStatus outputs the status of the deployment in each region of
+// its multiregion job.
+func (c *DeploymentStatusCommand) multiregionStatus(client *api.Client, deploy *api.Deployment, json bool, tmpl string, length int) int {
+	if !deploy.IsMultiregion {
+		c.Ui.Error(fmt.Sprintf("Deployment %q is not part of a multiregion deployment", limit(deploy.ID, length)))
+		return 1
+	}
+
+	regions, _, err := client.Deployments().Multiregion(deploy.ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving multiregion deployment: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, regions)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color(formatMultiregionStatus(deploy, regions, length)))
+	return 0
+}
+
 func (c *DeploymentStatusCommand) monitor(client *api.Client, deployID string, index uint64, wait time.Duration, verbose bool) (status string, err error) {
 	if isStdoutTerminal() {
 		return c.ttyMonitor(client, deployID, index, wait, verbose)
@@ -599,6 +642,41 @@ func formatMultiregionDeployment(regions map[string]*api.Deployment, uuidLength
 	return formatList(rows)
 }
 
+func formatMultiregionStatus(d *api.Deployment, regions []*api.MultiregionDeploymentRegion, uuidLength int) string {
+	high := []string{
+		fmt.Sprintf("Job ID|%s", d.JobID),
+		fmt.Sprintf("Job Version|%d", d.JobVersion),
+	}
+
+	var blocking []string
+	rows := make([]string, len(regions)+1)
+	rows[0] = "Region|ID|Status|Blocking|Description"
+	for i, r := range regions {
+		if r.Blocking {
+			blocking = append(blocking, r.Region)
+		}
+
+		id, status, desc := "<none>", "unknown", r.Error
+		if r.Deployment != nil {
+			id = limit(r.Deployment.ID, uuidLength)
+			status = r.Deployment.Status
+			desc = r.Deployment.StatusDescription
+		}
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%v|%s", r.Region, id, status, r.Blocking, desc)
+	}
+
+	if len(blocking) == 0 {
+		high = append(high, "Blocking Regions|<none>")
+	} else {
+		high = append(high, fmt.Sprintf("Blocking Regions|%s", strings.Join(blocking, ", ")))
+	}
+
+	base := formatKV(high)
+	base += "\n\n[bold]Regions[reset]\n"
+	base += formatList(rows)
+	return base
+}
+
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
 	var canaries, autorevert, progressDeadline bool
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/posener/complete"
 	"github.com/shoenig/test/must"
 )
@@ -51,6 +52,51 @@ func TestDeploymentStatusCommand_Fails(t *testing.T) {
 		must.StrContains(t, out, "The monitor flag cannot be used with the '-json' or '-t' flags")
 		ui.ErrorWriter.Reset()
 	}
+
+	code = cmd.Run([]string{"-monitor", "-multiregion", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "The monitor flag cannot be used with the '-multiregion' flag")
+}
+
+func TestDeploymentStatusCommand_Multiregion(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	j := mock.MultiregionJob()
+	j.Multiregion.Regions = []*structs.MultiregionRegion{{Name: "global"}, {Name: "east"}}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, j))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobVersion = 0
+	d.IsMultiregion = true
+	d.Status = structs.DeploymentStatusBlocked
+	d.StatusDescription = structs.DeploymentStatusDescriptionBlocked
+	must.NoError(t, state.UpsertDeployment(1000, d))
+
+	single := mock.Deployment()
+	must.NoError(t, state.UpsertDeployment(1001, single))
+
+	ui := cli.NewMockUi()
+	cmd := &DeploymentStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Single region deployments don't have a multiregion status
+	must.One(t, cmd.Run([]string{"-address=" + url, "-multiregion", single.ID}))
+	must.StrContains(t, ui.ErrorWriter.String(), "is not part of a multiregion deployment")
+
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-multiregion", d.ID}))
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Blocking Regions = east")
+	must.StrContains(t, out, "global  "+d.ID[:8])
+	must.StrContains(t, out, structs.DeploymentStatusDescriptionBlocked)
+	must.StrContains(t, out, "No path to region")
+	ui.OutputWriter.Reset()
+
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-multiregion", "-json", d.ID}))
+	must.StrContains(t, ui.OutputWriter.String(), `"Region": "east"`)
 }
 
 func TestDeploymentStatusCommand_AutocompleteArgs(t *testing.T) {
@@ -0,0 +1,497 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+const (
+	deploymentWatchEventStatus    = "status"
+	deploymentWatchEventPlacement = "placement"
+	deploymentWatchEventHealth    = "health"
+	deploymentWatchEventPromotion = "promotion"
+	deploymentWatchEventProgress  = "progress"
+)
+
+type DeploymentWatchCommand struct {
+	Meta
+}
+
+func (c *DeploymentWatchCommand) Help() string {
+	helpText := `
+Usage: nomad deployment watch [options] <deployment id>
+
+  Watch attaches to a deployment and streams its placement, health and
+  promotion events until the deployment reaches a terminal state. The command
+  exits 0 if the deployment is successful and 1 if it fails, is cancelled or
+  the stream can't be read, which makes it suitable for CI pipelines.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the deployment's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Watch Options:
+
+  -json
+    Output each event as a line of JSON.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *DeploymentWatchCommand) Synopsis() string {
+	return "Stream the events of a deployment until it completes"
+}
+
+func (c *DeploymentWatchCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *DeploymentWatchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Deployments, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Deployments]
+	})
+}
+
+func (c *DeploymentWatchCommand) Name() string { return "deployment watch" }
+
+func (c *DeploymentWatchCommand) Run(args []string) int {
+	var jsonOutput, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&jsonOutput, "json", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <deployment id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Do a prefix lookup
+	deploy, possible, err := getDeployment(client.Deployments(), args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment: %s", err))
+		return 1
+	}
+
+	if len(possible) != 0 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple deployments\n\n%s", formatDeployments(possible, length)))
+		return 1
+	}
+
+	q := &api.QueryOptions{Namespace: deploy.Namespace}
+
+	// Refetch the deployment so the stream starts from an index that
+	// includes its current state
+	deploy, meta, err := client.Deployments().Info(deploy.ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Deployments().Allocations(deploy.ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment allocations: %s", err))
+		return 1
+	}
+
+	w := newDeploymentEventWatcher(deploy, allocs, length)
+	return streamDeploymentEvents(c.Ui, client, w, meta.LastIndex, jsonOutput)
+}
+
+// streamDeploymentEvents outputs the events of the watched deployment from the
+// given index until the deployment reaches a terminal state, and returns the
+// exit code of the deployment.
+func streamDeploymentEvents(ui cli.Ui, client *api.Client, w *deploymentEventWatcher, index uint64, jsonOutput bool) int {
+	outputDeploymentEvents(ui, w.initial(index), jsonOutput)
+	if w.done() {
+		return w.exitCode()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	topics := map[api.Topic][]string{
+		api.TopicDeployment: {w.deploy.ID},
+		api.TopicAllocation: {w.deploy.ID},
+	}
+	q := &api.QueryOptions{Namespace: w.deploy.Namespace}
+	eventCh, err := client.EventStream().Stream(ctx, topics, index, q)
+	if err != nil {
+		ui.Error(fmt.Sprintf("Error streaming events: %s", err))
+		return 1
+	}
+
+	for {
+		select {
+		case <-signalCh:
+			return 1
+		case events, ok := <-eventCh:
+			if !ok {
+				ui.Error("Event stream closed before the deployment completed")
+				return 1
+			}
+			if events.Err != nil {
+				ui.Error(fmt.Sprintf("Error reading event stream: %s", events.Err))
+				return 1
+			}
+			if events.IsHeartbeat() {
+				continue
+			}
+
+			for _, event := range events.Events {
+				out, err := w.handle(&event)
+				if err != nil {
+					ui.Error(fmt.Sprintf("Error decoding event: %s", err))
+					return 1
+				}
+				outputDeploymentEvents(ui, out, jsonOutput)
+			}
+
+			if w.done() {
+				return w.exitCode()
+			}
+		}
+	}
+}
+
+func outputDeploymentEvents(ui cli.Ui, events []*deploymentWatchEvent, jsonOutput bool) {
+	for _, e := range events {
+		if jsonOutput {
+			b, err := json.Marshal(e)
+			if err != nil {
+				ui.Error(fmt.Sprintf("Error encoding event: %s", err))
+				continue
+			}
+			ui.Output(string(b))
+			continue
+		}
+		ui.Output(fmt.Sprintf("%s: %s", formatTime(e.Time), e.Message))
+	}
+}
+
+// deploymentWatchEvent is a single event output by the watch command.
+type deploymentWatchEvent struct {
+	Time              time.Time
+	Index             uint64
+	Type              string
+	DeploymentID      string
+	Status            string `json:",omitempty"`
+	StatusDescription string `json:",omitempty"`
+	TaskGroup         string `json:",omitempty"`
+	AllocID           string `json:",omitempty"`
+	NodeID            string `json:",omitempty"`
+	Canary            bool   `json:",omitempty"`
+	Healthy           *bool  `json:",omitempty"`
+	HealthyAllocs     int    `json:",omitempty"`
+	DesiredTotal      int    `json:",omitempty"`
+	Message           string
+}
+
+// deploymentEventWatcher turns the deployment and allocation events of a
+// deployment into watch events, suppressing events that don't change what
+// has already been reported.
+type deploymentEventWatcher struct {
+	deploy *api.Deployment
+	length int
+
+	// progress enables the events reporting the healthy allocations of each
+	// task group whenever they change
+	progress bool
+
+	// allocs tracks the health of each placed allocation, which is nil until
+	// the allocation's health is known
+	allocs map[string]*bool
+}
+
+func newDeploymentEventWatcher(deploy *api.Deployment, allocs []*api.AllocationListStub, length int) *deploymentEventWatcher {
+	w := &deploymentEventWatcher{
+		deploy: deploy,
+		length: length,
+		allocs: make(map[string]*bool, len(allocs)),
+	}
+	for _, alloc := range allocs {
+		w.allocs[alloc.ID] = allocHealth(alloc.DeploymentStatus)
+	}
+	return w
+}
+
+// initial returns the event describing the deployment when the watch starts.
+func (w *deploymentEventWatcher) initial(index uint64) []*deploymentWatchEvent {
+	out := []*deploymentWatchEvent{w.statusEvent(index)}
+	if w.progress {
+		out = append(out, w.progressEvents(index, nil)...)
+	}
+	return out
+}
+
+func (w *deploymentEventWatcher) done() bool {
+	switch w.deploy.Status {
+	case api.DeploymentStatusSuccessful, api.DeploymentStatusFailed, api.DeploymentStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (w *deploymentEventWatcher) exitCode() int {
+	if w.deploy.Status == api.DeploymentStatusSuccessful {
+		return 0
+	}
+	return 1
+}
+
+// handle returns the watch events for an event stream event.
+func (w *deploymentEventWatcher) handle(event *api.Event) ([]*deploymentWatchEvent, error) {
+	switch event.Topic {
+	case api.TopicDeployment:
+		d, err := event.Deployment()
+		if err != nil {
+			return nil, err
+		}
+		if d == nil || d.ID != w.deploy.ID {
+			return nil, nil
+		}
+		return w.handleDeployment(event.Index, d), nil
+
+	case api.TopicAllocation:
+		alloc, err := event.Allocation()
+		if err != nil {
+			return nil, err
+		}
+		if alloc == nil || alloc.DeploymentID != w.deploy.ID {
+			return nil, nil
+		}
+		return w.handleAlloc(event.Index, alloc), nil
+	}
+
+	return nil, nil
+}
+
+func (w *deploymentEventWatcher) handleDeployment(index uint64, d *api.Deployment) []*deploymentWatchEvent {
+	prev := w.deploy
+	w.deploy = d
+
+	var out []*deploymentWatchEvent
+	for name, state := range d.TaskGroups {
+		var prevState *api.DeploymentState
+		if prev.TaskGroups != nil {
+			prevState = prev.TaskGroups[name]
+		}
+		if prevState == nil {
+			prevState = &api.DeploymentState{}
+		}
+
+		for _, id := range state.PromotedCanaries {
+			if slices.Contains(prevState.PromotedCanaries, id) {
+				continue
+			}
+			out = append(out, &deploymentWatchEvent{
+				Time:         time.Now(),
+				Index:        index,
+				Type:         deploymentWatchEventPromotion,
+				DeploymentID: d.ID,
+				TaskGroup:    name,
+				AllocID:      id,
+				Canary:       true,
+				Message: fmt.Sprintf("Promoted canary %q in group %q",
+					limit(id, w.length), name),
+			})
+		}
+
+		if state.Promoted && !prevState.Promoted {
+			out = append(out, &deploymentWatchEvent{
+				Time:         time.Now(),
+				Index:        index,
+				Type:         deploymentWatchEventPromotion,
+				DeploymentID: d.ID,
+				TaskGroup:    name,
+				Message:      fmt.Sprintf("Promoted group %q", name),
+			})
+		}
+	}
+
+	// Sort the promotions so output is stable across task groups
+	slices.SortFunc(out, func(a, b *deploymentWatchEvent) int {
+		return strings.Compare(a.TaskGroup+a.AllocID, b.TaskGroup+b.AllocID)
+	})
+
+	if w.progress {
+		out = append(out, w.progressEvents(index, prev)...)
+	}
+
+	if d.Status != prev.Status || d.StatusDescription != prev.StatusDescription {
+		out = append(out, w.statusEvent(index))
+	}
+	return out
+}
+
+// progressEvents returns an event for each task group whose healthy or
+// desired allocations or promotion changed since the previous version of the deployment.
+func (w *deploymentEventWatcher) progressEvents(index uint64, prev *api.Deployment) []*deploymentWatchEvent {
+	names := make([]string, 0, len(w.deploy.TaskGroups))
+	for name := range w.deploy.TaskGroups {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var out []*deploymentWatchEvent
+	for _, name := range names {
+		state := w.deploy.TaskGroups[name]
+		if prev != nil && prev.TaskGroups != nil {
+			if p := prev.TaskGroups[name]; p != nil &&
+				p.HealthyAllocs == state.HealthyAllocs && p.DesiredTotal == state.DesiredTotal &&
+				p.Promoted == state.Promoted {
+				continue
+			}
+		}
+
+		// Until the group is promoted, only its canaries are being placed
+		desired, kind := state.DesiredTotal, "allocations"
+		if state.DesiredCanaries > 0 && !state.Promoted {
+			desired, kind = state.DesiredCanaries, "canaries"
+		}
+		out = append(out, &deploymentWatchEvent{
+			Time:          time.Now(),
+			Index:         index,
+			Type:          deploymentWatchEventProgress,
+			DeploymentID:  w.deploy.ID,
+			TaskGroup:     name,
+			HealthyAllocs: state.HealthyAllocs,
+			DesiredTotal:  state.DesiredTotal,
+			Message: fmt.Sprintf("Group %q has %d of %d %s healthy",
+				name, state.HealthyAllocs, desired, kind),
+		})
+	}
+	return out
+}
+
+func (w *deploymentEventWatcher) handleAlloc(index uint64, alloc *api.Allocation) []*deploymentWatchEvent {
+	var out []*deploymentWatchEvent
+
+	canary := alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Canary
+	prevHealth, seen := w.allocs[alloc.ID]
+	if !seen {
+		kind := "allocation"
+		if canary {
+			kind = "canary allocation"
+		}
+		out = append(out, &deploymentWatchEvent{
+			Time:         time.Now(),
+			Index:        index,
+			Type:         deploymentWatchEventPlacement,
+			DeploymentID: w.deploy.ID,
+			TaskGroup:    alloc.TaskGroup,
+			AllocID:      alloc.ID,
+			NodeID:       alloc.NodeID,
+			Canary:       canary,
+			Message: fmt.Sprintf("Placed %s %q for group %q on node %q",
+				kind, limit(alloc.ID, w.length), alloc.TaskGroup, limit(alloc.NodeID, w.length)),
+		})
+	}
+
+	health := allocHealth(alloc.DeploymentStatus)
+	w.allocs[alloc.ID] = health
+	if health != nil && (prevHealth == nil || *prevHealth != *health) {
+		status := "unhealthy"
+		if *health {
+			status = "healthy"
+		}
+		out = append(out, &deploymentWatchEvent{
+			Time:         time.Now(),
+			Index:        index,
+			Type:         deploymentWatchEventHealth,
+			DeploymentID: w.deploy.ID,
+			TaskGroup:    alloc.TaskGroup,
+			AllocID:      alloc.ID,
+			NodeID:       alloc.NodeID,
+			Canary:       canary,
+			Healthy:      health,
+			Message: fmt.Sprintf("Allocation %q for group %q is %s",
+				limit(alloc.ID, w.length), alloc.TaskGroup, status),
+		})
+	}
+
+	return out
+}
+
+func (w *deploymentEventWatcher) statusEvent(index uint64) *deploymentWatchEvent {
+	msg := fmt.Sprintf("Deployment %q is %s", limit(w.deploy.ID, w.length), w.deploy.Status)
+	if w.deploy.StatusDescription != "" {
+		msg = fmt.Sprintf("%s: %s", msg, w.deploy.StatusDescription)
+	}
+	return &deploymentWatchEvent{
+		Time:              time.Now(),
+		Index:             index,
+		Type:              deploymentWatchEventStatus,
+		DeploymentID:      w.deploy.ID,
+		Status:            w.deploy.Status,
+		StatusDescription: w.deploy.StatusDescription,
+		Message:           msg,
+	}
+}
+
+func allocHealth(status *api.AllocDeploymentStatus) *bool {
+	if status == nil || status.Healthy == nil {
+		return nil
+	}
+	healthy := *status.Healthy
+	return &healthy
+}
@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestDeploymentWatchCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &DeploymentWatchCommand{}
+}
+
+func TestDeploymentWatchCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &DeploymentWatchCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error retrieving deployment")
+}
+
+func TestDeploymentWatchCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	d := mock.Deployment()
+	must.NoError(t, state.UpsertDeployment(1000, d))
+
+	ui := cli.NewMockUi()
+	cmd := &DeploymentWatchCommand{Meta: Meta{Ui: ui}}
+
+	codeCh := make(chan int, 1)
+	go func() {
+		codeCh <- cmd.Run([]string{"-address=" + url, "-json", d.ID})
+	}()
+
+	// Wait for the watch to start before completing the deployment
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return strings.Contains(ui.OutputWriter.String(), `"Status":"running"`)
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+
+	must.NoError(t, state.UpdateDeploymentStatus(structs.DeploymentStatusUpdateRequestType, 1001,
+		&structs.DeploymentStatusUpdateRequest{
+			DeploymentUpdate: &structs.DeploymentStatusUpdate{
+				DeploymentID:      d.ID,
+				Status:            structs.DeploymentStatusSuccessful,
+				StatusDescription: structs.DeploymentStatusDescriptionSuccessful,
+			},
+		}))
+
+	select {
+	case code := <-codeCh:
+		must.Zero(t, code)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the deployment watch to exit")
+	}
+
+	lines := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+	must.Len(t, 2, lines)
+
+	var last deploymentWatchEvent
+	must.NoError(t, json.Unmarshal([]byte(lines[1]), &last))
+	must.Eq(t, deploymentWatchEventStatus, last.Type)
+	must.Eq(t, structs.DeploymentStatusSuccessful, last.Status)
+	must.Eq(t, d.ID, last.DeploymentID)
+}
+
+func TestDeploymentWatchCommand_Terminal(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	d := mock.Deployment()
+	d.Status = structs.DeploymentStatusFailed
+	d.StatusDescription = structs.DeploymentStatusDescriptionFailedAllocations
+	must.NoError(t, state.UpsertDeployment(1000, d))
+
+	ui := cli.NewMockUi()
+	cmd := &DeploymentWatchCommand{Meta: Meta{Ui: ui}}
+
+	// A terminal deployment exits immediately with its status
+	must.One(t, cmd.Run([]string{"-address=" + url, d.ID[:8]}))
+	must.StrContains(t, ui.OutputWriter.String(),
+		"is failed: "+structs.DeploymentStatusDescriptionFailedAllocations)
+}
+
+func TestDeploymentEventWatcher_handle(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &api.Deployment{
+		ID:     "d1",
+		Status: api.DeploymentStatusRunning,
+		TaskGroups: map[string]*api.DeploymentState{
+			"web": {DesiredCanaries: 2},
+		},
+	}
+	existing := &api.AllocationListStub{ID: "a0", TaskGroup: "web"}
+	w := newDeploymentEventWatcher(d, []*api.AllocationListStub{existing}, fullId)
+
+	// Placing a canary
+	alloc := &api.Allocation{
+		ID:               "a1",
+		NodeID:           "n1",
+		TaskGroup:        "web",
+		DeploymentID:     "d1",
+		DeploymentStatus: &api.AllocDeploymentStatus{Canary: true},
+	}
+	out := w.handleAlloc(10, alloc)
+	must.Len(t, 1, out)
+	must.Eq(t, deploymentWatchEventPlacement, out[0].Type)
+	must.True(t, out[0].Canary)
+	must.Eq(t, `Placed canary allocation "a1" for group "web" on node "n1"`, out[0].Message)
+
+	// Updates that don't change the health aren't reported
+	must.SliceEmpty(t, w.handleAlloc(11, alloc))
+
+	// The canary becomes healthy
+	alloc.DeploymentStatus.Healthy = pointer.Of(true)
+	out = w.handleAlloc(12, alloc)
+	must.Len(t, 1, out)
+	must.Eq(t, deploymentWatchEventHealth, out[0].Type)
+	must.Eq(t, pointer.Of(true), out[0].Healthy)
+
+	// Existing allocations are not reported as placed
+	out = w.handleAlloc(13, &api.Allocation{ID: "a0", TaskGroup: "web", DeploymentID: "d1",
+		DeploymentStatus: &api.AllocDeploymentStatus{Healthy: pointer.Of(false)}})
+	must.Len(t, 1, out)
+	must.Eq(t, `Allocation "a0" for group "web" is unhealthy`, out[0].Message)
+
+	// Partial then full promotion, followed by a status change
+	promoted := *d
+	promoted.TaskGroups = map[string]*api.DeploymentState{
+		"web": {DesiredCanaries: 2, PromotedCanaries: []string{"a1"}},
+	}
+	out = w.handleDeployment(14, &promoted)
+	must.Len(t, 1, out)
+	must.Eq(t, `Promoted canary "a1" in group "web"`, out[0].Message)
+
+	complete := promoted
+	complete.Status = api.DeploymentStatusSuccessful
+	complete.TaskGroups = map[string]*api.DeploymentState{
+		"web": {DesiredCanaries: 2, Promoted: true, PromotedCanaries: []string{"a1"}},
+	}
+	out = w.handleDeployment(15, &complete)
+	must.Len(t, 2, out)
+	must.Eq(t, `Promoted group "web"`, out[0].Message)
+	must.Eq(t, deploymentWatchEventStatus, out[1].Type)
+	must.True(t, w.done())
+	must.Zero(t, w.exitCode())
+}
+
+func TestDeploymentEventWatcher_progress(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &api.Deployment{
+		ID:     "d1",
+		Status: api.DeploymentStatusRunning,
+		TaskGroups: map[string]*api.DeploymentState{
+			"web": {DesiredCanaries: 1, DesiredTotal: 3},
+			"api": {DesiredTotal: 2, HealthyAllocs: 1},
+		},
+	}
+	w := newDeploymentEventWatcher(d, nil, fullId)
+	w.progress = true
+
+	out := w.initial(10)
+	must.Len(t, 3, out)
+	must.Eq(t, `Group "api" has 1 of 2 allocations healthy`, out[1].Message)
+	must.Eq(t, `Group "web" has 0 of 1 canaries healthy`, out[2].Message)
+
+	// Only the groups that changed are reported
+	healthy := *d
+	healthy.TaskGroups = map[string]*api.DeploymentState{
+		"web": {DesiredCanaries: 1, DesiredTotal: 3, HealthyAllocs: 1},
+		"api": {DesiredTotal: 2, HealthyAllocs: 1},
+	}
+	out = w.handleDeployment(11, &healthy)
+	must.Len(t, 1, out)
+	must.Eq(t, deploymentWatchEventProgress, out[0].Type)
+	must.Eq(t, 1, out[0].HealthyAllocs)
+	must.Eq(t, `Group "web" has 1 of 1 canaries healthy`, out[0].Message)
+
+	// Once promoted, the group reports all of its allocations
+	promoted := healthy
+	promoted.TaskGroups = map[string]*api.DeploymentState{
+		"web": {DesiredCanaries: 1, DesiredTotal: 3, HealthyAllocs: 1, Promoted: true},
+		"api": {DesiredTotal: 2, HealthyAllocs: 1},
+	}
+	out = w.handleDeployment(12, &promoted)
+	must.Len(t, 2, out)
+	must.Eq(t, `Promoted group "web"`, out[0].Message)
+	must.Eq(t, `Group "web" has 1 of 3 allocations healthy`, out[1].Message)
+}
@@ -223,6 +223,21 @@ func (c *EvalStatusCommand) Run(args []string) int {
 			fmt.Sprintf("Previous Eval|%s", eval.PreviousEval),
 			fmt.Sprintf("Next Eval|%s", eval.NextEval),
 			fmt.Sprintf("Blocked Eval|%s", eval.BlockedEval))
+
+		if eval.WorkerID != "" {
+			basic = append(basic,
+				fmt.Sprintf("Worker ID|%s", eval.WorkerID),
+				fmt.Sprintf("Dequeue Time|%s", formatUnixNanoTime(eval.DequeueTime)),
+				fmt.Sprintf("Snapshot Wait Time|%s", eval.SnapshotWaitTime),
+				fmt.Sprintf("Plan Time|%s", eval.PlanTime),
+				fmt.Sprintf("Raft Apply Time|%s", eval.RaftApplyTime))
+		}
+
+		if eval.ForwardedFromRegion != "" {
+			basic = append(basic,
+				fmt.Sprintf("Forwarded From|%s", eval.ForwardedFromRegion),
+				fmt.Sprintf("Forwarded Time|%s", formatUnixNanoTime(eval.ForwardedTime)))
+		}
 	}
 	c.Ui.Output(formatKV(basic))
 
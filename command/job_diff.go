@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type JobDiffCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *JobDiffCommand) Help() string {
+	helpText := `
+Usage: nomad job diff [options] <job>
+
+  Diff displays the differences between two versions of a job, or between the
+  registered job and a job file. The differences are computed by the servers
+  the same way as by "nomad job plan", and include the changes to each task
+  group and task.
+
+  With two -version flags, the first version is compared to the second. With
+  a single -version flag, the version is compared to the latest version of the
+  job. Without -version or -file flags, the latest version is compared to the
+  version before it.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the job's namespace. The 'submit-job' capability is also
+  required with the -file flag, since the job file is planned to compute the
+  diff. The 'list-jobs' capability is required to run the command with a job
+  prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Diff Options:
+
+  -version <job version>
+    Specifies a version of the job to compare. May be specified twice to
+    compare two versions. Mutually exclusive with -file.
+
+  -file <path>
+    Specifies a job file to compare against the registered job. The job in the
+    file must have the same ID as the job argument. Files with a ".json"
+    extension are parsed as JSON jobs. Mutually exclusive with -version.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times. Only used with -file.
+
+  -var-file=path
+    Path to HCL2 file containing user variables. Only used with -file.
+
+  -verbose
+    Display the fields that did not change along with the differences.
+
+  -json
+    Output the job diff in a JSON format.
+
+  -t
+    Format and display the job diff using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobDiffCommand) Synopsis() string {
+	return "Display the differences between job versions or a job file"
+}
+
+func (c *JobDiffCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-version":  complete.PredictAnything,
+			"-file":     complete.PredictOr(complete.PredictFiles("*.nomad"), complete.PredictFiles("*.hcl"), complete.PredictFiles("*.json")),
+			"-var":      complete.PredictAnything,
+			"-var-file": complete.PredictFiles("*.var"),
+			"-verbose":  complete.PredictNothing,
+			"-json":     complete.PredictNothing,
+			"-t":        complete.PredictAnything,
+		})
+}
+
+func (c *JobDiffCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobDiffCommand) Name() string { return "job diff" }
+
+func (c *JobDiffCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl, file string
+	var versionFlags flaghelper.StringFlag
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var(&versionFlags, "version", "")
+	flags.StringVar(&file, "file", "", "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if len(versionFlags) > 2 {
+		c.Ui.Error("-version can be specified at most twice")
+		return 1
+	}
+	if file != "" && len(versionFlags) > 0 {
+		c.Ui.Error("-file and -version are mutually exclusive")
+		return 1
+	}
+	if file == "" && (len(c.JobGetter.Vars) > 0 || len(c.JobGetter.VarFiles) > 0) {
+		c.Ui.Error("-var and -var-file can only be used with -file")
+		return 1
+	}
+
+	versions := make([]uint64, 0, len(versionFlags))
+	for _, v := range versionFlags {
+		version, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing -version value %q: %v", v, err))
+			return 1
+		}
+		versions = append(versions, version)
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	var diff *api.JobDiff
+	if file != "" {
+		diff, err = c.fileDiff(client, jobID, namespace, file)
+	} else {
+		diff, err = versionsDiff(client, jobID, namespace, versions)
+	}
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, diff)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if diff.Type == "None" && !verbose {
+		c.Ui.Output(fmt.Sprintf("No changes to job %q", jobID))
+		return 0
+	}
+	c.Ui.Output(c.Colorize().Color(strings.TrimSpace(formatJobDiff(diff, verbose))))
+	return 0
+}
+
+// fileDiff returns the diff between the registered job and the job file by
+// planning the job file.
+func (c *JobDiffCommand) fileDiff(client *api.Client, jobID, namespace, path string) (*api.JobDiff, error) {
+	c.JobGetter.JSON = strings.HasSuffix(path, ".json")
+	if err := c.JobGetter.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid job options: %s", err)
+	}
+
+	_, job, err := c.JobGetter.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting job struct: %s", err)
+	}
+	if job.ID == nil || *job.ID != jobID {
+		return nil, fmt.Errorf("Job file %q does not define job %q", path, jobID)
+	}
+	if job.Namespace == nil {
+		job.Namespace = &namespace
+	} else if *job.Namespace != namespace {
+		return nil, fmt.Errorf("Job file %q defines job %q in namespace %q, not %q",
+			path, jobID, *job.Namespace, namespace)
+	}
+
+	resp, _, err := client.Jobs().Plan(job, true, &api.WriteOptions{Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("Error planning job file: %s", err)
+	}
+	return resp.Diff, nil
+}
+
+// versionsDiff returns the diff between two versions of the job. The first
+// version is compared to the second one, which defaults to the latest version.
+// Without versions, the latest version is compared to the version before it.
+func versionsDiff(client *api.Client, jobID, namespace string, versions []uint64) (*api.JobDiff, error) {
+	opts := &api.VersionsOptions{Diffs: true}
+	if len(versions) > 0 {
+		opts.DiffVersion = &versions[0]
+	}
+
+	jobs, diffs, _, err := client.Jobs().VersionsOpts(jobID, opts, &api.QueryOptions{Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving job versions: %s", err)
+	}
+
+	// Find the version compared against, which is the latest one unless
+	// given.
+	target := -1
+	for i, job := range jobs {
+		if len(versions) == 2 {
+			if *job.Version == versions[1] {
+				target = i
+			}
+		} else if target == -1 || *job.Version > *jobs[target].Version {
+			target = i
+		}
+	}
+	if target == -1 {
+		return nil, fmt.Errorf("Job %q has no version %d", jobID, versions[1])
+	}
+
+	// Without a version to compare against, each version is diffed against
+	// the version before it and the first version has no diff.
+	if target >= len(diffs) {
+		return nil, fmt.Errorf("Job %q has a single version", jobID)
+	}
+	return diffs[target], nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobDiffCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobDiffCommand{}
+}
+
+func TestJobDiffCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "no job",
+			args:        []string{},
+			expectedErr: commandErrorText(&JobDiffCommand{}),
+		},
+		{
+			name:        "too many versions",
+			args:        []string{"-version=0", "-version=1", "-version=2", "foo"},
+			expectedErr: "-version can be specified at most twice",
+		},
+		{
+			name:        "file and version",
+			args:        []string{"-version=0", "-file=job.nomad.hcl", "foo"},
+			expectedErr: "-file and -version are mutually exclusive",
+		},
+		{
+			name:        "var without file",
+			args:        []string{"-var=foo=bar", "foo"},
+			expectedErr: "-var and -var-file can only be used with -file",
+		},
+		{
+			name:        "invalid version",
+			args:        []string{"-version=latest", "foo"},
+			expectedErr: `Error parsing -version value "latest"`,
+		},
+		{
+			name:        "bad address",
+			args:        []string{"-address=nope", "foo"},
+			expectedErr: "Error querying job prefix",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+
+			must.One(t, cmd.Run(tc.args))
+			must.StrContains(t, ui.ErrorWriter.String(), tc.expectedErr)
+		})
+	}
+}
+
+func TestJobDiffCommand_Versions(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+	state := srv.Agent.Server().State()
+
+	v0 := mock.Job()
+	v0.TaskGroups[0].Count = 1
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, v0))
+
+	v1 := v0.Copy()
+	v1.TaskGroups[0].Count = 2
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, v1))
+
+	v2 := v1.Copy()
+	v2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/true"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, v2))
+
+	testCases := []struct {
+		name       string
+		args       []string
+		countDiff  string
+		configDiff bool
+	}{
+		{
+			name:       "latest",
+			args:       []string{},
+			configDiff: true,
+		},
+		{
+			name:       "one version",
+			args:       []string{"-version=0"},
+			countDiff:  `"1" => "2"`,
+			configDiff: true,
+		},
+		{
+			name:      "two versions",
+			args:      []string{"-version=0", "-version=1"},
+			countDiff: `"1" => "2"`,
+		},
+		{
+			name:      "reverse",
+			args:      []string{"-version=1", "-version=0"},
+			countDiff: `"2" => "1"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+
+			args := append([]string{"-address", url}, tc.args...)
+			must.Zero(t, cmd.Run(append(args, v0.ID)))
+
+			out := ui.OutputWriter.String()
+			must.StrContains(t, out, `Task Group: "web"`)
+			if tc.countDiff != "" {
+				must.StrContains(t, out, "Count: "+tc.countDiff)
+			} else {
+				must.StrNotContains(t, out, "Count:")
+			}
+			if tc.configDiff {
+				must.StrContains(t, out, `Task: "web"`)
+				must.StrContains(t, out, "/bin/true")
+			} else {
+				must.StrNotContains(t, out, "/bin/true")
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+
+		must.Zero(t, cmd.Run([]string{"-address", url, "-json", "-version=0", "-version=1", v0.ID}))
+
+		var diff api.JobDiff
+		must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &diff))
+		must.Eq(t, "Edited", diff.Type)
+		must.Len(t, 1, diff.TaskGroups)
+		must.Eq(t, "Edited", diff.TaskGroups[0].Type)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+
+		must.One(t, cmd.Run([]string{"-address", url, "-version=0", "-version=5", v0.ID}))
+		must.StrContains(t, ui.ErrorWriter.String(), "has no version 5")
+	})
+}
+
+func TestJobDiffCommand_File(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	job := testJob("job_diff")
+	_, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+
+	job.TaskGroups[0].Count = pointer.Of(3)
+	body, err := json.Marshal(map[string]*api.Job{"Job": job})
+	must.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "job.json")
+	must.NoError(t, os.WriteFile(path, body, 0o644))
+
+	ui := cli.NewMockUi()
+	cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+	must.Zero(t, cmd.Run([]string{"-address", url, "-file", path, "job_diff"}))
+	must.StrContains(t, ui.OutputWriter.String(), `Count: "1" => "3"`)
+
+	// The job file must define the job being compared
+	ui = cli.NewMockUi()
+	cmd = &JobDiffCommand{Meta: Meta{Ui: ui}}
+	must.One(t, cmd.Run([]string{"-address", url, "-file", path, "other"}))
+}
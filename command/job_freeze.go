@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobFreezeCommand struct {
+	Meta
+}
+
+func (c *JobFreezeCommand) Help() string {
+	helpText := `
+Usage: nomad job freeze [options] <job_id>
+
+  Freeze the scheduling of a job. While a job is frozen, its running
+  allocations are left as they are, but the scheduler does not reschedule
+  failed allocations, replace lost allocations, migrate allocations off
+  draining nodes or roll out job updates. Unlike stopping a job, freezing it
+  does not stop any allocations. Use the "nomad job unfreeze" command to
+  resume scheduling.
+
+  When ACLs are enabled, this command requires a token with the 'freeze-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobFreezeCommand) Synopsis() string {
+	return "Suspend scheduling of a job"
+}
+
+func (c *JobFreezeCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *JobFreezeCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobFreezeCommand) Name() string { return "job freeze" }
+
+func (c *JobFreezeCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	w := &api.WriteOptions{Namespace: namespace}
+	if _, _, err := client.Jobs().Freeze(jobID, true, w); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error freezing job: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Job %q frozen", jobID))
+	return 0
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobFreezeCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobFreezeCommand{}
+	var _ cli.Command = &JobUnfreezeCommand{}
+}
+
+func TestJobFreezeCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobFreezeCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foo"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying job prefix")
+	ui.ErrorWriter.Reset()
+
+	ucmd := &JobUnfreezeCommand{Meta: Meta{Ui: ui}}
+
+	// Fails when job ID is not specified
+	code = ucmd.Run([]string{})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+}
+
+func TestJobFreezeCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Freeze the job
+	ui := cli.NewMockUi()
+	cmd := &JobFreezeCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, job.ID})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "frozen")
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.True(t, out.Frozen)
+
+	// Unfreeze the job without monitoring the evaluation
+	ui = cli.NewMockUi()
+	ucmd := &JobUnfreezeCommand{Meta: Meta{Ui: ui}}
+	code = ucmd.Run([]string{"-address=" + url, "-detach", job.ID})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "unfrozen")
+	must.StrContains(t, ui.OutputWriter.String(), "Evaluation ID: ")
+
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.False(t, out.Frozen)
+}
@@ -23,6 +23,7 @@ type JobHistoryCommand struct {
 func (c *JobHistoryCommand) Help() string {
 	helpText := `
 Usage: nomad job history [options] <job>
+       nomad job history -purged [options] [<job>]
 
   History is used to display the known versions of a particular job. The command
   can display the diff between job versions and can be useful for understanding
@@ -33,6 +34,10 @@ Usage: nomad job history [options] <job>
   capability for the job's namespace. The 'list-jobs' capability is required to
   run the command with a job prefix instead of the exact job ID.
 
+  With the -purged flag, the command lists the audit records of the jobs that
+  were purged with "nomad job stop -purge -audit". The job argument is then
+  optional and must be an exact job ID, since the job no longer exists.
+
 General Options:
 
   ` + generalOptionsUsage(usageOptsDefault) + `
@@ -59,6 +64,10 @@ History Options:
   -version <job version>
     Display only the history for the given job version.
 
+  -purged
+    List the audit records of purged jobs instead of the versions of a job.
+    Cannot be used with -p, -full or -version.
+
   -json
     Output the job versions in a JSON format.
 
@@ -82,6 +91,7 @@ func (c *JobHistoryCommand) AutocompleteFlags() complete.Flags {
 			"-t":            complete.PredictAnything,
 			"-diff-tag":     complete.PredictNothing,
 			"-diff-version": complete.PredictNothing,
+			"-purged":       complete.PredictNothing,
 		})
 }
 
@@ -103,7 +113,7 @@ func (c *JobHistoryCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobHistoryCommand) Name() string { return "job history" }
 
 func (c *JobHistoryCommand) Run(args []string) int {
-	var json, diff, full bool
+	var json, diff, full, purged bool
 	var tmpl, versionStr, diffTag, diffVersionFlag string
 	var diffVersion *uint64
 
@@ -112,6 +122,7 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	flags.BoolVar(&diff, "p", false, "")
 	flags.BoolVar(&full, "full", false, "")
 	flags.BoolVar(&json, "json", false, "")
+	flags.BoolVar(&purged, "purged", false, "")
 	flags.StringVar(&versionStr, "version", "", "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.StringVar(&diffTag, "diff-tag", "", "")
@@ -121,8 +132,27 @@ func (c *JobHistoryCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Check that we got exactly one node
 	args = flags.Args()
+
+	if purged {
+		if len(args) > 1 {
+			c.Ui.Error("This command takes at most one argument with -purged: [<job>]")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		if diff || full || versionStr != "" {
+			c.Ui.Error("-purged is exclusive with -p, -full and -version")
+			return 1
+		}
+
+		var jobID string
+		if len(args) == 1 {
+			jobID = strings.TrimSpace(args[0])
+		}
+		return c.purgeHistory(jobID, json, tmpl)
+	}
+
+	// Check that we got exactly one node
 	if l := len(args); l < 1 || l > 2 {
 		c.Ui.Error("This command takes one argument: <job>")
 		c.Ui.Error(commandErrorText(c))
@@ -246,6 +276,56 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	return 0
 }
 
+// purgeHistory lists the job purge records, optionally restricted to the
+// given job ID.
+func (c *JobHistoryCommand) purgeHistory(jobID string, json bool, tmpl string) int {
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	records, _, err := client.Jobs().PurgeHistory(jobID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving job purge history: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, records)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(records) == 0 {
+		c.Ui.Output("No purged jobs found")
+		return 0
+	}
+
+	c.Ui.Output(formatJobPurgeRecords(records))
+	return 0
+}
+
+func formatJobPurgeRecords(records []*api.JobPurgeRecord) string {
+	rows := make([]string, len(records)+1)
+	rows[0] = "Job ID|Namespace|Type|Purged At|Purged By|Versions"
+	for i, r := range records {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%d",
+			r.JobID,
+			r.Namespace,
+			r.Type,
+			formatTime(time.Unix(0, r.PurgedAt)),
+			r.PurgedBy,
+			len(r.Versions))
+	}
+	return formatList(rows)
+}
+
 // parseVersion parses the version flag and returns the index, whether it
 // was set and potentially an error during parsing.
 func parseVersion(input string) (uint64, bool, error) {
@@ -43,6 +43,22 @@ func TestJobHistoryCommand_Fails(t *testing.T) {
 		t.Fatalf("expected failed query error, got: %s", out)
 	}
 	ui.ErrorWriter.Reset()
+
+	// Fails on -purged misuse
+	code := cmd.Run([]string{"-purged", "foo", "bar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "at most one argument with -purged")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-purged", "-full", "foo"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-purged is exclusive with -p, -full and -version")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "-purged"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error retrieving job purge history")
+	ui.ErrorWriter.Reset()
 }
 
 func TestJobHistoryCommand_AutocompleteArgs(t *testing.T) {
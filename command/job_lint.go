@@ -0,0 +1,313 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/posener/complete"
+)
+
+type JobLintCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *JobLintCommand) Help() string {
+	helpText := `
+Usage: nomad job lint [options] <path>
+
+  Checks a job specification for common mistakes that do not make the job
+  invalid, such as services without health checks or tasks without resources.
+
+  The job is linted with the built-in rules and the rules of the bundles given
+  with -rules. A rule bundle is an HCL or JSON file of rule blocks, each
+  matching the job, groups, tasks or services that violate the rule with a
+  filter expression:
+
+    rule "no-privileged" {
+      description = "Tasks must not run privileged containers"
+      severity    = "error"
+      scope       = "task"
+      filter      = "Config.privileged == true"
+    }
+
+  The job is linted by the Nomad servers through the validate endpoint, or
+  locally if no agent can be reached or -local is set. The command exits with
+  status 2 if any finding is at least as severe as -fail-on.
+
+  If the supplied path is "-", the jobfile is read from stdin. Otherwise
+  it is read from the file at the supplied path or downloaded and
+  read from URL specified.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Lint Options:
+
+  -rules=<path>
+    Path to an HCL or JSON rule bundle. Can be used multiple times.
+
+  -skip=<rule>
+    Name of a rule whose findings are not reported. Can be used multiple
+    times.
+
+  -fail-on=<severity>
+    The minimum severity of the findings that make the command fail, one of
+    "info", "warning" or "error". Defaults to "error".
+
+  -local
+    Lint the job locally instead of on the Nomad servers.
+
+  -format=<format>
+    The output format of the findings, either "table" or "json". Defaults to
+    "table".
+
+  -json
+    Parses the job file as JSON. If the outer object has a Job field, such as
+    from "nomad job inspect" or "nomad run -output", the value of the field is
+    used as the job.
+
+  -hcl2-strict
+    Whether an error should be produced from the HCL2 parser where a variable
+    has been supplied which is not defined within the root variables. Defaults
+    to true.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobLintCommand) Synopsis() string {
+	return "Checks a job specification for common mistakes"
+}
+
+func (c *JobLintCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-rules": complete.PredictOr(
+				complete.PredictFiles("*.hcl"),
+				complete.PredictFiles("*.json"),
+			),
+			"-skip": complete.PredictAnything,
+			"-fail-on": complete.PredictSet(
+				api.JobLintSeverityInfo,
+				api.JobLintSeverityWarning,
+				api.JobLintSeverityError,
+			),
+			"-local":       complete.PredictNothing,
+			"-format":      complete.PredictSet("table", "json"),
+			"-json":        complete.PredictNothing,
+			"-hcl2-strict": complete.PredictNothing,
+			"-var":         complete.PredictAnything,
+			"-var-file":    complete.PredictFiles("*.var"),
+		})
+}
+
+func (c *JobLintCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *JobLintCommand) Name() string { return "job lint" }
+
+func (c *JobLintCommand) Run(args []string) int {
+	var ruleFiles, skip flaghelper.StringFlag
+	var failOn, format string
+	var local bool
+
+	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
+	flagSet.Var(&ruleFiles, "rules", "")
+	flagSet.Var(&skip, "skip", "")
+	flagSet.StringVar(&failOn, "fail-on", api.JobLintSeverityError, "")
+	flagSet.BoolVar(&local, "local", false, "")
+	flagSet.StringVar(&format, "format", "table", "")
+	flagSet.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flagSet.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
+	flagSet.Var(&c.JobGetter.Vars, "var", "")
+	flagSet.Var(&c.JobGetter.VarFiles, "var-file", "")
+
+	if err := flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flagSet.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if structs.JobLintSeverityRank(failOn) < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid -fail-on severity %q", failOn))
+		return 1
+	}
+	if format != "table" && format != "json" {
+		c.Ui.Error(fmt.Sprintf("Invalid -format %q, must be \"table\" or \"json\"", format))
+		return 1
+	}
+
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	var rules []*api.JobLintRule
+	for _, path := range ruleFiles {
+		fileRules, err := parseJobLintRules(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing rule bundle %q: %s", path, err))
+			return 1
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	_, job, err := c.JobGetter.Get(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+
+	var findings []*api.JobLintFinding
+	if local {
+		findings, err = c.lintLocal(job, rules)
+	} else {
+		findings, err = c.lintRemote(job, rules)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error linting job: %s", err))
+		return 1
+	}
+
+	findings = slices.DeleteFunc(findings, func(f *api.JobLintFinding) bool {
+		return slices.Contains(skip, f.Rule)
+	})
+
+	if format == "json" {
+		if findings == nil {
+			findings = []*api.JobLintFinding{}
+		}
+		out, err := Format(true, "", findings)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+	} else if len(findings) == 0 {
+		c.Ui.Output(c.Colorize().Color("[bold][green]No lint findings[reset]"))
+	} else {
+		c.Ui.Output(formatJobLintFindings(findings))
+	}
+
+	for _, finding := range findings {
+		if structs.JobLintSeverityRank(finding.Severity) >= structs.JobLintSeverityRank(failOn) {
+			return 2
+		}
+	}
+	return 0
+}
+
+// lintRemote lints the job through the validate endpoint of the agent, and
+// falls back to linting locally if the agent cannot be reached.
+func (c *JobLintCommand) lintRemote(job *api.Job, rules []*api.JobLintRule) ([]*api.JobLintFinding, error) {
+	client, err := c.Meta.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	if r := job.Region; r != nil {
+		client.SetRegion(*r)
+	}
+
+	resp, _, err := client.Jobs().Lint(job, rules, nil)
+	if err != nil {
+		// Errors returned by the agent, such as invalid rules, are not
+		// resolved by linting locally
+		var urErr api.UnexpectedResponseError
+		if errors.As(err, &urErr) {
+			return nil, err
+		}
+		c.Ui.Warn(c.Colorize().Color(
+			"[bold][yellow]Linting locally since connection to Nomad agent couldn't be established.[reset]\n"))
+		return c.lintLocal(job, rules)
+	}
+	return resp.LintFindings, nil
+}
+
+// lintLocal lints the job without contacting the Nomad agent.
+func (c *JobLintCommand) lintLocal(aj *api.Job, rules []*api.JobLintRule) ([]*api.JobLintFinding, error) {
+	job := agent.ApiJobToStructJob(aj)
+	job.Canonicalize()
+
+	findings, err := job.Lint(agent.ApiJobLintRulesToStructs(rules))
+	if err != nil {
+		return nil, err
+	}
+	return apiJobLintFindings(findings), nil
+}
+
+func apiJobLintFindings(findings []*structs.JobLintFinding) []*api.JobLintFinding {
+	out := make([]*api.JobLintFinding, len(findings))
+	for i, finding := range findings {
+		out[i] = &api.JobLintFinding{
+			Rule:     finding.Rule,
+			Severity: finding.Severity,
+			Message:  finding.Message,
+			Group:    finding.Group,
+			Task:     finding.Task,
+		}
+	}
+	return out
+}
+
+// jobLintRuleBundle is the content of a rule bundle file.
+type jobLintRuleBundle struct {
+	Rules []*api.JobLintRule `hcl:"rule,block"`
+}
+
+// parseJobLintRules parses the rules of an HCL or JSON rule bundle file.
+func parseJobLintRules(path string) ([]*api.JobLintRule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle jobLintRuleBundle
+	if err := hclsimple.Decode(path, content, nil, &bundle); err != nil {
+		return nil, err
+	}
+	return bundle.Rules, nil
+}
+
+func formatJobLintFindings(findings []*api.JobLintFinding) string {
+	rows := make([]string, len(findings)+1)
+	rows[0] = "Severity|Rule|Group|Task|Message"
+	for i, f := range findings {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			f.Severity, f.Rule, f.Group, f.Task, f.Message)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobLintCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobLintCommand{}
+}
+
+func TestJobLintCommand_Local(t *testing.T) {
+	ci.Parallel(t)
+
+	rules := filepath.Join(t.TempDir(), "rules.hcl")
+	must.NoError(t, os.WriteFile(rules, []byte(`
+rule "no-exec" {
+  severity = "error"
+  scope    = "task"
+  filter   = "Driver == \"exec\""
+  message  = "tasks must run in containers"
+}
+`), 0o644))
+
+	t.Run("builtin", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobLintCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-local", "testdata/example-basic.nomad"})
+		must.Zero(t, code)
+		must.StrContains(t, ui.OutputWriter.String(), "no-restart-policy")
+	})
+
+	t.Run("fail on", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobLintCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-local", "-fail-on", "info", "testdata/example-basic.nomad"})
+		must.Eq(t, 2, code)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobLintCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-local", "-fail-on", "info", "-skip", "no-restart-policy", "testdata/example-basic.nomad"})
+		must.Zero(t, code)
+		must.StrContains(t, ui.OutputWriter.String(), "No lint findings")
+	})
+
+	t.Run("rules json", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobLintCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-local", "-rules", rules, "-format", "json", "testdata/example-basic.nomad"})
+		must.Eq(t, 2, code)
+
+		var findings []*api.JobLintFinding
+		must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &findings))
+		must.Len(t, 2, findings)
+		must.Eq(t, &api.JobLintFinding{
+			Rule:     "no-exec",
+			Severity: api.JobLintSeverityError,
+			Message:  "tasks must run in containers",
+			Group:    "group1",
+			Task:     "task1",
+		}, findings[1])
+	})
+}
+
+func TestJobLintCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &JobLintCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid severity
+	code = cmd.Run([]string{"-fail-on", "fatal", "testdata/example-basic.nomad"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Invalid -fail-on severity")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing rule bundle
+	code = cmd.Run([]string{"-rules", "/unicorns/rules.hcl", "testdata/example-basic.nomad"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error parsing rule bundle")
+}
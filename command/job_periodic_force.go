@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	"github.com/posener/complete"
 )
 
@@ -22,7 +23,9 @@ Usage: nomad job periodic force <job id>
 
   This command is used to force the creation of a new instance of a periodic job.
   This is used to immediately run a periodic job, even if it violates the job's
-  prohibit_overlap setting.
+  prohibit_overlap setting. Metadata and environment variables can be overridden
+  for the new instance only, without editing the periodic job, by using the
+  meta and env flags.
 
   When ACLs are enabled, this command requires a token with the 'submit-job'
   capability for the job's namespace. The 'list-jobs' capability is required to
@@ -41,6 +44,16 @@ Periodic Force Options:
     the evaluation ID will be printed to the screen, which can be used to
     examine the evaluation using the eval-status command.
 
+  -env <key>=<value>
+    Env takes a key/value pair separated by "=". The environment variable will
+    be merged into the environment of every task of the new instance. This
+    flag can be provided more than once to set multiple variables.
+
+  -meta <key>=<value>
+    Meta takes a key/value pair separated by "=". The metadata key will be
+    merged into the metadata of the new instance. This flag can be provided
+    more than once to set multiple metadata key/value pairs.
+
   -verbose
     Display full information.
 `
@@ -56,6 +69,8 @@ func (c *JobPeriodicForceCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-detach":  complete.PredictNothing,
+			"-env":     complete.PredictAnything,
+			"-meta":    complete.PredictAnything,
 			"-verbose": complete.PredictNothing,
 		})
 }
@@ -87,11 +102,14 @@ func (c *JobPeriodicForceCommand) Name() string { return "job periodic force" }
 
 func (c *JobPeriodicForceCommand) Run(args []string) int {
 	var detach, verbose bool
+	var meta, env []string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
+	flags.Var((*flaghelper.StringFlag)(&env), "env", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -105,6 +123,31 @@ func (c *JobPeriodicForceCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Build the overrides
+	opts := &api.PeriodicForceOptions{}
+	for _, m := range meta {
+		k, v, found := strings.Cut(m, "=")
+		if !found || k == "" {
+			c.Ui.Error(fmt.Sprintf("Error parsing meta value: %v", m))
+			return 1
+		}
+		if opts.Meta == nil {
+			opts.Meta = make(map[string]string, len(meta))
+		}
+		opts.Meta[k] = v
+	}
+	for _, e := range env {
+		k, v, found := strings.Cut(e, "=")
+		if !found || k == "" {
+			c.Ui.Error(fmt.Sprintf("Error parsing env value: %v", e))
+			return 1
+		}
+		if opts.Env == nil {
+			opts.Env = make(map[string]string, len(env))
+		}
+		opts.Env[k] = v
+	}
+
 	// Truncate the id unless full length is requested
 	length := shortId
 	if verbose {
@@ -134,7 +177,7 @@ func (c *JobPeriodicForceCommand) Run(args []string) int {
 	q := &api.WriteOptions{Namespace: namespace}
 
 	// force the evaluation
-	evalID, _, err := client.Jobs().PeriodicForce(jobID, q)
+	evalID, _, err := client.Jobs().PeriodicForceOpts(jobID, opts, q)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error forcing periodic job %q: %s", jobID, err))
 		return 1
@@ -40,6 +40,17 @@ func TestJobPeriodicForceCommand_Fails(t *testing.T) {
 	must.One(t, code)
 	out = ui.ErrorWriter.String()
 	must.StrContains(t, out, "Error querying job prefix")
+	ui.ErrorWriter.Reset()
+
+	// Fails on malformed overrides
+	code = cmd.Run([]string{"-address=nope", "-meta=novalue", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error parsing meta value")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "-env==value", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error parsing env value")
 }
 
 func TestJobPeriodicForceCommand_AutocompleteArgs(t *testing.T) {
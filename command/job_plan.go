@@ -5,11 +5,15 @@ package command
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/scheduler"
 	"github.com/posener/complete"
@@ -89,6 +93,13 @@ Plan Options:
     has been supplied which is not defined within the root variables. Defaults
     to true.
 
+  -policy=<path>
+    Path to an HCL or JSON rule bundle, a directory of rule bundles, or a URL
+    to download them from. The rules, in the format of "nomad job lint -rules",
+    are evaluated locally against the job and violations are reported before
+    the job is planned. Rules with the "error" severity prevent the job from
+    being planned. Can be used multiple times.
+
   -policy-override
     Sets the flag to force override any soft mandatory Sentinel policies.
 
@@ -116,6 +127,7 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-diff":            complete.PredictNothing,
+			"-policy":          complete.PredictAnything,
 			"-policy-override": complete.PredictNothing,
 			"-verbose":         complete.PredictNothing,
 			"-json":            complete.PredictNothing,
@@ -138,10 +150,12 @@ func (c *JobPlanCommand) Name() string { return "job plan" }
 func (c *JobPlanCommand) Run(args []string) int {
 	var diff, policyOverride, verbose bool
 	var vaultNamespace string
+	var policies flaghelper.StringFlag
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
 	flagSet.BoolVar(&diff, "diff", true, "")
+	flagSet.Var(&policies, "policy", "")
 	flagSet.BoolVar(&policyOverride, "policy-override", false, "")
 	flagSet.BoolVar(&verbose, "verbose", false, "")
 	flagSet.BoolVar(&c.JobGetter.JSON, "json", false, "")
@@ -197,6 +211,10 @@ func (c *JobPlanCommand) Run(args []string) int {
 		job.VaultNamespace = pointer.Of(vaultNamespace)
 	}
 
+	if !checkJobPolicies(&c.Meta, job, policies) {
+		return 255
+	}
+
 	// Setup the options
 	opts := &api.PlanOptions{
 		// Always request the diff so we can tell if there are changes.
@@ -292,9 +310,42 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 		c.addPreemptions(resp)
 	}
 
+	// Print static port conflicts if there are any
+	if resp.Annotations != nil && len(resp.Annotations.PortConflicts) > 0 {
+		c.Ui.Output(c.Colorize().Color("[bold][yellow]Static Port Conflicts:[reset]"))
+		c.Ui.Output(c.Colorize().Color(formatPortConflicts(resp.Annotations.PortConflicts, verbose)))
+	}
+
 	return getExitCode(resp)
 }
 
+// formatPortConflicts lists the nodes excluded because of static port
+// conflicts for each task group.
+func formatPortConflicts(conflicts map[string]*api.PortConflicts, verbose bool) string {
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	var out string
+	for _, tg := range slices.Sorted(maps.Keys(conflicts)) {
+		tgConflicts := conflicts[tg]
+		out += fmt.Sprintf("[yellow]Task Group %q (%d of %d candidate nodes excluded):\n[reset]",
+			tg, len(tgConflicts.Nodes), tgConflicts.CandidateNodes)
+
+		rows := []string{"Node ID|Ports"}
+		for _, nodeID := range slices.Sorted(maps.Keys(tgConflicts.Nodes)) {
+			ports := make([]string, 0, len(tgConflicts.Nodes[nodeID]))
+			for _, port := range tgConflicts.Nodes[nodeID] {
+				ports = append(ports, strconv.Itoa(port))
+			}
+			rows = append(rows, fmt.Sprintf("%s|%s", limit(nodeID, length), strings.Join(ports, ",")))
+		}
+		out += formatList(rows) + "\n\n"
+	}
+	return strings.TrimSuffix(out, "\n")
+}
+
 // addPreemptions shows details about preempted allocations
 func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
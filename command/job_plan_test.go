@@ -5,6 +5,7 @@ package command
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -116,6 +117,21 @@ job "job1" {
 	}
 }
 
+func TestPlanCommand_Policy(t *testing.T) {
+	ci.Parallel(t)
+
+	rules := filepath.Join(t.TempDir(), "rules.hcl")
+	must.NoError(t, os.WriteFile(rules, []byte(testJobPolicyRules), 0o644))
+
+	// Violations are reported before the job is submitted
+	ui := cli.NewMockUi()
+	cmd := &JobPlanCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=http://127.0.0.1:1", "-policy", rules, "testdata/example-basic.nomad"})
+	must.Eq(t, 255, code)
+	must.StrContains(t, ui.OutputWriter.String(), "tasks must run in containers")
+	must.StrNotContains(t, ui.ErrorWriter.String(), "Error during plan")
+}
+
 func TestPlanCommand_From_STDIN(t *testing.T) {
 	_, _, addr := testServer(t, false, nil)
 
@@ -291,6 +307,28 @@ func TestPlanCommand_Preemptions(t *testing.T) {
 	must.StrContains(t, out, "service")
 }
 
+func TestPlanCommand_PortConflicts(t *testing.T) {
+	ci.Parallel(t)
+
+	conflicts := map[string]*api.PortConflicts{
+		"web": {
+			CandidateNodes: 3,
+			Nodes: map[string][]int{
+				"5a8f1a9c-7d5b-4b64-a8b5-3f0d6d1b1f01": {80, 443},
+			},
+		},
+	}
+
+	out := formatPortConflicts(conflicts, false)
+	must.StrContains(t, out, `Task Group "web" (1 of 3 candidate nodes excluded)`)
+	must.StrContains(t, out, "Node ID")
+	must.StrContains(t, out, "5a8f1a9c  80,443")
+	must.StrNotContains(t, out, "5a8f1a9c-7d5b")
+
+	out = formatPortConflicts(conflicts, true)
+	must.StrContains(t, out, "5a8f1a9c-7d5b-4b64-a8b5-3f0d6d1b1f01")
+}
+
 func TestPlanCommand_JSON(t *testing.T) {
 	ui := cli.NewMockUi()
 	cmd := &JobPlanCommand{
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gg "github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// loadJobPolicies loads the rules of the job policies at the given sources.
+// A source is a rule bundle file, a directory of rule bundle files, or a URL
+// they are downloaded from.
+func loadJobPolicies(sources []string) ([]*api.JobLintRule, error) {
+	var rules []*api.JobLintRule
+	for _, src := range sources {
+		srcRules, err := loadJobPolicy(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policy %q: %w", src, err)
+		}
+		rules = append(rules, srcRules...)
+	}
+	return rules, nil
+}
+
+func loadJobPolicy(src string) ([]*api.JobLintRule, error) {
+	path := src
+	if _, err := os.Stat(src); err != nil {
+		dir, err := os.MkdirTemp("", "job-policy")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+
+		pwd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+
+		client := &gg.Client{
+			Src:  src,
+			Pwd:  pwd,
+			Dst:  dir,
+			Mode: gg.ClientModeAny,
+
+			// This will prevent copying or writing files through symlinks
+			DisableSymlinks: true,
+		}
+		if err := client.Get(); err != nil {
+			return nil, err
+		}
+		path = dir
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return parseJobLintRules(path)
+	}
+
+	// Only the bundles at the top of a directory are loaded, in lexical order
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*api.JobLintRule
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".hcl" && ext != ".json") {
+			continue
+		}
+		fileRules, err := parseJobLintRules(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		rules = append(rules, fileRules...)
+	}
+	return rules, nil
+}
+
+// checkJobPolicies evaluates the rules of the job policies at the given
+// sources against the job, without contacting the Nomad agent, and outputs
+// the violations. It returns false if the policies can't be evaluated or if a
+// violation has the "error" severity.
+func checkJobPolicies(m *Meta, aj *api.Job, sources []string) bool {
+	if len(sources) == 0 {
+		return true
+	}
+
+	rules, err := loadJobPolicies(sources)
+	if err != nil {
+		m.Ui.Error(fmt.Sprintf("Error loading job policies: %s", err))
+		return false
+	}
+
+	job := agent.ApiJobToStructJob(aj)
+	job.Canonicalize()
+
+	findings, err := job.LintRules(agent.ApiJobLintRulesToStructs(rules))
+	if err != nil {
+		m.Ui.Error(fmt.Sprintf("Error evaluating job policies: %s", err))
+		return false
+	}
+	if len(findings) == 0 {
+		return true
+	}
+
+	violated := false
+	for _, finding := range findings {
+		if finding.Severity == structs.JobLintSeverityError {
+			violated = true
+		}
+	}
+
+	header := "[bold][yellow]Job policy warnings:[reset]"
+	if violated {
+		header = "[bold][red]Job policy violations:[reset]"
+	}
+	m.Ui.Output(m.Colorize().Color(header))
+	m.Ui.Output(formatJobLintFindings(apiJobLintFindings(findings)) + "\n")
+	return !violated
+}
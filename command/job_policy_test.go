@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+const testJobPolicyRules = `
+rule "no-exec" {
+  severity = "error"
+  scope    = "task"
+  filter   = "Driver == \"exec\""
+  message  = "tasks must run in containers"
+}
+`
+
+func TestLoadJobPolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "a.hcl"), []byte(testJobPolicyRules), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"),
+		[]byte(`{"rule": {"owner": {"filter": "Meta.owner is empty"}}}`), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a bundle"), 0o644))
+
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer srv.Close()
+
+	t.Run("file", func(t *testing.T) {
+		rules, err := loadJobPolicies([]string{filepath.Join(dir, "a.hcl")})
+		must.NoError(t, err)
+		must.Len(t, 1, rules)
+		must.Eq(t, "no-exec", rules[0].Name)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		rules, err := loadJobPolicies([]string{dir})
+		must.NoError(t, err)
+		must.Len(t, 2, rules)
+		must.Eq(t, "no-exec", rules[0].Name)
+		must.Eq(t, "owner", rules[1].Name)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		rules, err := loadJobPolicies([]string{srv.URL + "/a.hcl"})
+		must.NoError(t, err)
+		must.Len(t, 1, rules)
+		must.Eq(t, "no-exec", rules[0].Name)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := loadJobPolicies([]string{filepath.Join(dir, "missing.hcl")})
+		must.ErrorContains(t, err, "failed to load policy")
+	})
+}
@@ -5,6 +5,8 @@ package command
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -45,11 +47,28 @@ Promote Options:
     Group may be specified many times and is used to promote that particular
     group. If no specific groups are specified, all groups are promoted.
 
+  -canary-count
+    Promote only this many healthy canaries of each group, starting with the
+    canaries that have been healthy the longest. The groups stay unpromoted and
+    their other canaries continue to be observed until they are promoted.
+
+  -canary-percent
+    Promote only this percentage of the placed canaries of each group, rounded
+    up. Can't be combined with -canary-count.
+
   -detach
     Return immediately instead of entering monitor mode. After deployment
     resume, the evaluation ID will be printed to the screen, which can be used
     to examine the evaluation using the eval-status command.
 
+  -watch
+    After the promotion is evaluated, keep streaming the deployment until it
+    completes. The healthy allocations of each group are displayed as the
+    canaries are promoted and the remaining allocations are replaced, followed
+    by the stable job version, or the version the job was reverted to if the
+    deployment fails and auto-revert is enabled. The command exits 1 if the
+    deployment doesn't succeed. Can't be combined with -detach.
+
   -verbose
     Display full information.
 `
@@ -63,9 +82,12 @@ func (c *JobPromoteCommand) Synopsis() string {
 func (c *JobPromoteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-group":   complete.PredictAnything,
-			"-detach":  complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
+			"-group":          complete.PredictAnything,
+			"-canary-count":   complete.PredictAnything,
+			"-canary-percent": complete.PredictAnything,
+			"-detach":         complete.PredictNothing,
+			"-watch":          complete.PredictNothing,
+			"-verbose":        complete.PredictNothing,
 		})
 }
 
@@ -87,19 +109,40 @@ func (c *JobPromoteCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobPromoteCommand) Name() string { return "job promote" }
 
 func (c *JobPromoteCommand) Run(args []string) int {
-	var detach, verbose bool
+	var detach, watch, verbose bool
+	var canaryCount, canaryPercent int
 	var groups []string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&watch, "watch", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.Var((*flaghelper.StringFlag)(&groups), "group", "")
+	flags.IntVar(&canaryCount, "canary-count", 0, "")
+	flags.IntVar(&canaryPercent, "canary-percent", 0, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
+	if canaryCount < 0 {
+		c.Ui.Error("-canary-count must not be negative")
+		return 1
+	}
+	if canaryPercent < 0 || canaryPercent > 100 {
+		c.Ui.Error("-canary-percent must be between 0 and 100")
+		return 1
+	}
+	if canaryCount > 0 && canaryPercent > 0 {
+		c.Ui.Error("-canary-count can't be combined with -canary-percent")
+		return 1
+	}
+	if detach && watch {
+		c.Ui.Error("-watch can't be combined with -detach")
+		return 1
+	}
+
 	// Check that we got exactly one argument
 	args = flags.Args()
 	if l := len(args); l != 1 {
@@ -144,9 +187,12 @@ func (c *JobPromoteCommand) Run(args []string) int {
 
 	wq := &api.WriteOptions{Namespace: namespace}
 	var u *api.DeploymentUpdateResponse
-	if len(groups) == 0 {
+	switch {
+	case canaryCount > 0 || canaryPercent > 0:
+		u, _, err = client.Deployments().PromoteCanaries(deploy.ID, groups, canaryCount, canaryPercent, wq)
+	case len(groups) == 0:
 		u, _, err = client.Deployments().PromoteAll(deploy.ID, wq)
-	} else {
+	default:
 		u, _, err = client.Deployments().PromoteGroups(deploy.ID, groups, wq)
 	}
 
@@ -157,16 +203,72 @@ func (c *JobPromoteCommand) Run(args []string) int {
 
 	evalCreated := u.EvalID != ""
 
-	// Nothing to do
-	if !evalCreated {
+	if detach {
+		if evalCreated {
+			c.Ui.Output("Evaluation ID: " + u.EvalID)
+		}
 		return 0
 	}
 
-	if detach {
-		c.Ui.Output("Evaluation ID: " + u.EvalID)
+	if evalCreated {
+		mon := newMonitor(c.Ui, client, length)
+		if code := mon.monitor(u.EvalID); !watch || code == 1 {
+			return code
+		}
+	}
+
+	if !watch {
 		return 0
 	}
+	return c.watch(client, jobID, deploy.ID, q, length)
+}
+
+// watch streams the deployment after the promotion until it completes, and
+// reports the job version that is running once it does.
+func (c *JobPromoteCommand) watch(client *api.Client, jobID, deployID string, q *api.QueryOptions, length int) int {
+	deploy, meta, err := client.Deployments().Info(deployID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment: %s", err))
+		return 1
+	}
 
-	mon := newMonitor(c.Ui, client, length)
-	return mon.monitor(u.EvalID)
+	allocs, _, err := client.Deployments().Allocations(deployID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment allocations: %s", err))
+		return 1
+	}
+
+	c.Ui.Output("")
+	w := newDeploymentEventWatcher(deploy, allocs, length)
+	w.progress = true
+	code := streamDeploymentEvents(c.Ui, client, w, meta.LastIndex, false)
+	if !w.done() {
+		return code
+	}
+
+	switch {
+	case w.deploy.Status == api.DeploymentStatusSuccessful:
+		c.Ui.Output(fmt.Sprintf("Job %q version %d is now stable", jobID, w.deploy.JobVersion))
+	case w.deploy.Status == api.DeploymentStatusFailed:
+		if version, ok := deploymentRollbackVersion(w.deploy.StatusDescription); ok {
+			c.Ui.Output(fmt.Sprintf("Job %q was auto-reverted to version %d", jobID, version))
+		}
+	}
+	return code
+}
+
+var deploymentRollbackRe = regexp.MustCompile(`rolling back to job version (\d+)$`)
+
+// deploymentRollbackVersion returns the job version a failed deployment
+// rolled back to, based on its status description.
+func deploymentRollbackVersion(desc string) (uint64, bool) {
+	m := deploymentRollbackRe.FindStringSubmatch(desc)
+	if m == nil {
+		return 0, false
+	}
+	version, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
 }
@@ -43,6 +43,43 @@ func TestJobPromoteCommand_Fails(t *testing.T) {
 		t.Fatalf("expected failed to promote error, got: %s", out)
 	}
 	ui.ErrorWriter.Reset()
+
+	// Fails on invalid partial promotions
+	code := cmd.Run([]string{"-canary-count=-1", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-canary-count must not be negative")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-canary-percent=101", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-canary-percent must be between 0 and 100")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-canary-count=1", "-canary-percent=50", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-canary-count can't be combined with -canary-percent")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-watch", "-detach", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-watch can't be combined with -detach")
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobPromoteCommand_deploymentRollbackVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	version, ok := deploymentRollbackVersion(structs.DeploymentStatusDescriptionRollback(
+		structs.DeploymentStatusDescriptionFailedAllocations, 3))
+	must.True(t, ok)
+	must.Eq(t, 3, version)
+
+	_, ok = deploymentRollbackVersion(structs.DeploymentStatusDescriptionRollbackNoop(
+		structs.DeploymentStatusDescriptionFailedAllocations, 3))
+	must.False(t, ok)
+
+	_, ok = deploymentRollbackVersion(structs.DeploymentStatusDescriptionFailedAllocations)
+	must.False(t, ok)
 }
 
 func TestJobPromoteCommand_AutocompleteArgs(t *testing.T) {
@@ -34,6 +34,16 @@ const (
 	// command should ask user for confirmation between batches.
 	jobRestartBatchWaitAsk = "ask"
 
+	// jobRestartBatchWaitAuto is the special token used to indicate that the
+	// command should wait for the allocations of each batch to be healthy, as
+	// defined by the update block of their group, before proceeding.
+	jobRestartBatchWaitAuto = "auto"
+
+	// jobRestartHealthWaitTime is the maximum time a blocking query waits
+	// for changes to an allocation while the command waits for it to be
+	// healthy, so that the min_healthy_time is checked regularly.
+	jobRestartHealthWaitTime = 2 * time.Second
+
 	// jobRestartOnErrorFail is the special token used to indicate that the
 	// command should exit when a batch has errors.
 	jobRestartOnErrorFail = "fail"
@@ -41,6 +51,11 @@ const (
 	// jobRestartOnErrorAks is the special token used to indicate that the
 	// command should ask user for confirmation when a batch has errors.
 	jobRestartOnErrorAsk = "ask"
+
+	// jobRestartOnErrorPause is the special token used to indicate that the
+	// restart should be tracked by the servers, which pause it when a batch
+	// has errors even if the command is no longer running.
+	jobRestartOnErrorPause = "pause"
 )
 
 var (
@@ -87,6 +102,7 @@ type JobRestartCommand struct {
 	batchSizePercent bool
 	batchWait        time.Duration
 	batchWaitAsk     bool
+	batchWaitAuto    bool
 	groups           *set.Set[string]
 	jobID            string
 	noShutdownDelay  bool
@@ -100,6 +116,12 @@ type JobRestartCommand struct {
 	// the questions.
 	canceled bool
 
+	// restartID is the ID of the restart tracked by the servers when
+	// -on-error is 'pause', and paused is set to true once the servers
+	// rejected a batch because the restart was paused.
+	restartID string
+	paused    bool
+
 	// sigsCh is used to subscribe to signals from the operating system.
 	sigsCh chan os.Signal
 }
@@ -122,7 +144,8 @@ Usage: nomad job restart [options] <job>
   This command can operate in batches and it waits until all restarted or
   rescheduled allocations are running again before proceeding to the next
   batch. It is also possible to specify additional time to wait between
-  batches.
+  batches, or to wait for the allocations of each batch to be healthy as
+  defined by the 'update' block of their group.
 
   You may restart in-place or migrated allocations. When restarting in-place,
   the command may target specific tasks in the allocations, restart only tasks
@@ -134,8 +157,8 @@ Usage: nomad job restart [options] <job>
   When migrating, Nomad stops the current allocations, triggering the Nomad
   scheduler to create new allocations that may be placed in different
   clients. The command waits until the new allocations have client status
-  'ready' before proceeding with the remaining batches. The command does not
-  consider service health checks.
+  'ready' before proceeding with the remaining batches. Service health checks
+  are only considered when '-batch-wait' is 'auto'.
 
   By default the command restarts all running tasks in-place with one
   allocation per batch.
@@ -161,10 +184,15 @@ Restart Options:
     value of the current number of running allocations. Percentage values are
     rounded up to increase parallelism. Defaults to 1.
 
-  -batch-wait=<duration|'ask'>
+  -batch-wait=<duration|'ask'|'auto'>
     Time to wait between restart batches. If set to 'ask' the command halts
     between batches and waits for user input on how to proceed. If the answer
-    is a time duration all remaining batches will use this new value. Defaults
+    is a time duration all remaining batches will use this new value. If set
+    to 'auto' the command waits for the allocations of each batch to be
+    healthy, as defined by the 'health_check', 'min_healthy_time', and
+    'healthy_deadline' values of the 'update' block of their group. Only the
+    checks of services using the Nomad provider are considered. Allocations
+    that are not healthy by the healthy deadline are batch errors. Defaults
     to 0.
 
   -group=<group-name>
@@ -177,10 +205,14 @@ Restart Options:
     that using this flag will result in failed network connections to the
     allocation being restarted.
 
-  -on-error=<'ask'|'fail'>
+  -on-error=<'ask'|'fail'|'pause'>
     Determines what action to take when an error happens during a restart
     batch. If 'ask' the command stops and waits for user confirmation on how to
-    proceed. If 'fail' the command exits immediately. Defaults to 'ask'.
+    proceed. If 'fail' the command exits immediately. If 'pause' the restart is
+    recorded by the servers, which pause it when an allocation of the current
+    batch fails or is not running by the healthy deadline of its group, even
+    if the command is no longer running. New batches are rejected once the
+    restart is paused, and the command exits. Defaults to 'ask'.
 
   -reschedule
     If set, allocations are stopped and migrated instead of restarted
@@ -222,7 +254,7 @@ func (c *JobRestartCommand) AutocompleteFlags() complete.Flags {
 			"-batch-size":        complete.PredictAnything,
 			"-batch-wait":        complete.PredictAnything,
 			"-no-shutdown-delay": complete.PredictNothing,
-			"-on-error":          complete.PredictSet(jobRestartOnErrorAsk, jobRestartOnErrorFail),
+			"-on-error":          complete.PredictSet(jobRestartOnErrorAsk, jobRestartOnErrorFail, jobRestartOnErrorPause),
 			"-reschedule":        complete.PredictNothing,
 			"-task":              complete.PredictAnything,
 			"-yes":               complete.PredictNothing,
@@ -346,6 +378,24 @@ func (c *JobRestartCommand) Run(args []string) int {
 		c.batchSize = int(math.Ceil(float64(len(restartAllocs)*c.batchSize) / 100))
 	}
 
+	if c.batchWaitAuto {
+		c.warnUnobservedChecks(restartAllocs)
+	}
+
+	// Register the restart with the servers so it's paused when a batch
+	// fails, even if this command stops running.
+	if c.onError == jobRestartOnErrorPause {
+		resp, _, err := c.client.Jobs().Restart(&api.JobRestartRequest{
+			JobID:      c.jobID,
+			Reschedule: c.reschedule,
+		}, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error starting restart of job %q: %v", c.jobID, err))
+			return 1
+		}
+		c.restartID = resp.Restart.ID
+	}
+
 	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 		"[bold]==> %s: Restarting %s[reset]",
 		formatTime(time.Now()),
@@ -385,6 +435,16 @@ func (c *JobRestartCommand) Run(args []string) int {
 			)))
 		}
 
+		// Submit new batches to the servers, which reject them once the
+		// restart is paused.
+		if restartCount%c.batchSize == 0 && c.restartID != "" {
+			end := min(restartCount+c.batchSize, len(restartAllocs))
+			if err := c.submitBatch(restartAllocs[restartCount:end]); err != nil {
+				restartErr = multierror.Append(restartErr, err)
+				break
+			}
+		}
+
 		// Restart allocation. Wrap the callback function to capture the
 		// allocID loop variable and prevent it from changing inside the
 		// goroutine at each iteration.
@@ -422,12 +482,20 @@ func (c *JobRestartCommand) Run(args []string) int {
 
 			// Handle errors that happened in this batch.
 			if batchErr != nil {
-				// Exit early if -on-error is 'fail'.
-				if c.onError == jobRestartOnErrorFail {
+				// Exit early if -on-error is 'fail' or 'pause'.
+				switch c.onError {
+				case jobRestartOnErrorFail:
 					c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 						"[bold]==> %s: Stopping job restart due to error[reset]",
 						formatTime(time.Now()),
 					)))
+				case jobRestartOnErrorPause:
+					c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+						"[bold]==> %s: Pausing job restart due to error[reset]",
+						formatTime(time.Now()),
+					)))
+				}
+				if c.onError != jobRestartOnErrorAsk {
 					break
 				}
 
@@ -480,6 +548,11 @@ func (c *JobRestartCommand) Run(args []string) int {
 		}
 	}
 
+	// Record the outcome of the restart with the servers.
+	if c.restartID != "" {
+		c.finishRestart(restartErr)
+	}
+
 	if restartErr != nil && len(restartErr.Errors) > 0 {
 		if !c.canceled {
 			c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
@@ -574,7 +647,8 @@ func (c *JobRestartCommand) parseAndValidate(args []string) (int, error) {
 	}
 
 	// Parse and validate -batch-wait.
-	if strings.ToLower(batchWaitStr) == jobRestartBatchWaitAsk {
+	switch strings.ToLower(batchWaitStr) {
+	case jobRestartBatchWaitAsk:
 		if !isTty() && !c.autoYes {
 			return 1, fmt.Errorf(
 				"Invalid -batch-wait value %[1]q: %[1]q cannot be used when terminal is not interactive",
@@ -582,7 +656,9 @@ func (c *JobRestartCommand) parseAndValidate(args []string) (int, error) {
 			)
 		}
 		c.batchWaitAsk = true
-	} else {
+	case jobRestartBatchWaitAuto:
+		c.batchWaitAuto = true
+	default:
 		c.batchWait, err = time.ParseDuration(batchWaitStr)
 		if err != nil {
 			return 1, fmt.Errorf("Invalid -batch-wait value %q: %w", batchWaitStr, err)
@@ -598,13 +674,14 @@ func (c *JobRestartCommand) parseAndValidate(args []string) (int, error) {
 				jobRestartOnErrorAsk,
 			)
 		}
-	case jobRestartOnErrorFail:
+	case jobRestartOnErrorFail, jobRestartOnErrorPause:
 	default:
 		return 1, fmt.Errorf(
-			"Invalid -on-error value %q: valid options are %q and %q",
+			"Invalid -on-error value %q: valid options are %q, %q, and %q",
 			c.onError,
 			jobRestartOnErrorAsk,
 			jobRestartOnErrorFail,
+			jobRestartOnErrorPause,
 		)
 	}
 
@@ -861,8 +938,11 @@ func (c *JobRestartCommand) askQuestion(question string, onError bool, cb func(s
 }
 
 // handleAlloc stops or restarts an allocation in-place. Blocks until the
-// allocation  is done restarting or the rescheduled allocation is running.
+// allocation  is done restarting or the rescheduled allocation is running,
+// and until it's healthy if -batch-wait is 'auto'.
 func (c *JobRestartCommand) handleAlloc(alloc AllocationListStubWithJob) error {
+	start := time.Now()
+
 	var err error
 	if c.reschedule {
 		// Stopping an allocation triggers a reschedule.
@@ -870,6 +950,9 @@ func (c *JobRestartCommand) handleAlloc(alloc AllocationListStubWithJob) error {
 	} else {
 		err = c.restartAlloc(alloc)
 	}
+	if err == nil && c.batchWaitAuto {
+		err = c.waitAllocHealthy(alloc, start)
+	}
 	if err != nil {
 		msg := fmt.Sprintf("Error restarting allocation %q:", limit(alloc.ID, c.length))
 		if mErr, ok := err.(*multierror.Error); ok {
@@ -1133,6 +1216,269 @@ func (c *JobRestartCommand) monitorReplacementAlloc(
 	}
 }
 
+// waitAllocHealthy blocks until the restarted allocation, or its replacement,
+// is healthy as defined by the update block of its group. Returns an error if
+// the allocation fails or is not healthy by the group's healthy deadline,
+// measured from start.
+func (c *JobRestartCommand) waitAllocHealthy(stub AllocationListStubWithJob, start time.Time) error {
+	update := jobRestartUpdateStrategy(stub.Job, stub.TaskGroup)
+	minHealthyTime := *update.MinHealthyTime
+	healthyDeadline := *update.HealthyDeadline
+	useChecks := *update.HealthCheck == "checks"
+
+	c.Ui.Output(fmt.Sprintf(
+		"    %s: Waiting for allocation %q to be healthy",
+		formatTime(time.Now()),
+		limit(stub.ID, c.length),
+	))
+
+	currentAllocID := stub.ID
+	var healthySince time.Time
+	q := &api.QueryOptions{WaitIndex: 1, WaitTime: jobRestartHealthWaitTime}
+	for {
+		if time.Since(start) > healthyDeadline {
+			return fmt.Errorf("Allocation %q is not healthy after %s",
+				limit(currentAllocID, c.length), healthyDeadline)
+		}
+
+		alloc, qm, err := c.client.Allocations().Info(currentAllocID, q)
+		if err != nil {
+			return fmt.Errorf("Failed to retrieve allocation %q: %w", limit(currentAllocID, c.length), err)
+		}
+
+		// Follow replacement allocations, since the health of the
+		// replacement is what matters.
+		if alloc.NextAllocation != "" {
+			currentAllocID = alloc.NextAllocation
+			healthySince = time.Time{}
+			q.WaitIndex = 1
+			continue
+		}
+
+		healthy, err := c.allocHealthy(stub, alloc, useChecks)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		switch {
+		case !healthy:
+			healthySince = time.Time{}
+		case healthySince.IsZero():
+			healthySince = now
+		}
+		if !healthySince.IsZero() && now.Sub(healthySince) >= minHealthyTime {
+			c.Ui.Output(fmt.Sprintf(
+				"    %s: Allocation %q is healthy",
+				formatTime(now),
+				limit(alloc.ID, c.length),
+			))
+			return nil
+		}
+
+		q.WaitIndex = qm.LastIndex
+	}
+}
+
+// allocHealthy returns true if all the tasks of the allocation are running,
+// and the restarted tasks have been started again since the restart, and if
+// useChecks is set, all the Nomad service checks of the allocation are
+// passing. Returns an error if the allocation or any of its tasks failed.
+func (c *JobRestartCommand) allocHealthy(stub AllocationListStubWithJob, alloc *api.Allocation, useChecks bool) (bool, error) {
+	switch alloc.ClientStatus {
+	case api.AllocClientStatusRunning:
+	case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+		return false, fmt.Errorf("Allocation %q is %q", limit(alloc.ID, c.length), alloc.ClientStatus)
+	default:
+		return false, nil
+	}
+
+	for name, state := range alloc.TaskStates {
+		switch state.State {
+		case "running":
+		case "dead":
+			if state.Failed {
+				return false, fmt.Errorf("Task %q in allocation %q failed", name, limit(alloc.ID, c.length))
+			}
+			// Lifecycle tasks that completed are not expected to run.
+			continue
+		default:
+			return false, nil
+		}
+
+		// Tasks restarted in-place must have started after the restart. The
+		// tasks of replacement allocations are all new.
+		if alloc.ID != stub.ID || !c.shouldRestartTask(stub, name) {
+			continue
+		}
+		if prev := stub.TaskStates[name]; prev != nil && !state.StartedAt.After(prev.StartedAt) {
+			return false, nil
+		}
+	}
+
+	if !useChecks || !jobRestartHasNomadChecks(stub.Job, alloc.TaskGroup) {
+		return true, nil
+	}
+
+	checks, err := c.client.Allocations().Checks(alloc.ID, nil)
+	if err != nil {
+		return false, fmt.Errorf("Failed to retrieve checks of allocation %q: %w", limit(alloc.ID, c.length), err)
+	}
+	if len(checks) == 0 {
+		return false, nil
+	}
+	for _, check := range checks {
+		// Readiness checks don't affect the health of allocations.
+		if check.Mode == "readiness" {
+			continue
+		}
+		if check.Status != "success" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// shouldRestartTask returns true if the task of the allocation is restarted
+// in-place by this command.
+func (c *JobRestartCommand) shouldRestartTask(stub AllocationListStubWithJob, task string) bool {
+	switch {
+	case c.allTasks:
+		return true
+	case c.tasks.Size() > 0:
+		return c.tasks.Contains(task)
+	default:
+		state := stub.TaskStates[task]
+		return state != nil && state.State == "running"
+	}
+}
+
+// warnUnobservedChecks warns the user if the health of the allocations
+// depends on Consul checks, which the command can't observe, so only the task
+// states are considered instead.
+func (c *JobRestartCommand) warnUnobservedChecks(allocs []AllocationListStubWithJob) {
+	groups := set.New[string](0)
+	for _, alloc := range allocs {
+		if alloc.Job == nil || !groups.Insert(alloc.TaskGroup) {
+			continue
+		}
+
+		update := jobRestartUpdateStrategy(alloc.Job, alloc.TaskGroup)
+		if *update.HealthCheck != "checks" {
+			continue
+		}
+		if jobRestartHasChecks(alloc.Job, alloc.TaskGroup, api.ServiceProviderConsul) {
+			c.Ui.Warn(fmt.Sprintf(
+				"Consul checks of group %q are not considered by -batch-wait=auto, only the task states are",
+				alloc.TaskGroup,
+			))
+		}
+	}
+}
+
+// submitBatch submits the allocations of a new batch to the restart tracked
+// by the servers. Returns an error if the servers reject the batch, such as
+// when the restart is paused.
+func (c *JobRestartCommand) submitBatch(allocs []AllocationListStubWithJob) error {
+	batch := make([]string, 0, len(allocs))
+	for _, alloc := range allocs {
+		batch = append(batch, alloc.ID)
+	}
+
+	_, _, err := c.client.Jobs().Restart(&api.JobRestartRequest{
+		JobID:     c.jobID,
+		RestartID: c.restartID,
+		Batch:     batch,
+	}, nil)
+	if err == nil {
+		return nil
+	}
+
+	if strings.Contains(err.Error(), api.JobRestartPausedErrorContent) {
+		c.paused = true
+		c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+			"[bold]==> %s: Stopping job restart because it was paused[reset]",
+			formatTime(time.Now()),
+		)))
+	}
+	return fmt.Errorf("Failed to submit batch: %w", err)
+}
+
+// finishRestart records the outcome of the restart with the servers. The
+// restart is paused if there were errors, and complete otherwise.
+func (c *JobRestartCommand) finishRestart(restartErr *multierror.Error) {
+	// The servers already paused the restart.
+	if c.paused {
+		return
+	}
+
+	req := &api.JobRestartRequest{
+		JobID:     c.jobID,
+		RestartID: c.restartID,
+		Status:    api.JobRestartStatusComplete,
+	}
+	switch {
+	case restartErr != nil && len(restartErr.Errors) > 0:
+		// Only keep the first line of the first error, since the status
+		// description is meant to be short.
+		desc, _, _ := strings.Cut(strings.TrimSpace(restartErr.Errors[0].Error()), "\n")
+		req.Status = api.JobRestartStatusPaused
+		req.StatusDescription = desc
+	case c.canceled:
+		req.StatusDescription = "Restart canceled"
+	}
+
+	if _, _, err := c.client.Jobs().Restart(req, nil); err != nil {
+		c.Ui.Warn(fmt.Sprintf("Failed to update the status of the restart of job %q: %v", c.jobID, err))
+	}
+}
+
+// jobRestartUpdateStrategy returns the update block of the group, with
+// default values for the ones not set.
+func jobRestartUpdateStrategy(job *api.Job, group string) *api.UpdateStrategy {
+	update := api.DefaultUpdateStrategy()
+	if job == nil {
+		return update
+	}
+	if tg := job.LookupTaskGroup(group); tg != nil {
+		update.Merge(tg.Update)
+	}
+	return update
+}
+
+// jobRestartHasNomadChecks returns true if the group has services using the
+// Nomad provider with checks.
+func jobRestartHasNomadChecks(job *api.Job, group string) bool {
+	return jobRestartHasChecks(job, group, "nomad")
+}
+
+// jobRestartHasChecks returns true if the group has services with checks
+// using the given provider.
+func jobRestartHasChecks(job *api.Job, group, provider string) bool {
+	if job == nil {
+		return false
+	}
+	tg := job.LookupTaskGroup(group)
+	if tg == nil {
+		return false
+	}
+
+	services := tg.Services
+	for _, task := range tg.Tasks {
+		services = append(services, task.Services...)
+	}
+	for _, service := range services {
+		p := service.Provider
+		if p == "" {
+			p = api.ServiceProviderConsul
+		}
+		if p == provider && len(service.Checks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // handleSignal receives input signals and blocks the activeCh until the user
 // confirms how to proceed.
 //
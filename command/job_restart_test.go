@@ -142,6 +142,15 @@ func TestJobRestartCommand_parseAndValidate(t *testing.T) {
 				batchWait: 10 * time.Second,
 			},
 		},
+		{
+			name: "batch wait auto",
+			args: []string{"-batch-wait", "auto", "my-job"},
+			expectedCmd: &JobRestartCommand{
+				jobID:         "my-job",
+				batchSize:     1,
+				batchWaitAuto: true,
+			},
+		},
 		{
 			name:        "batch wait invalid",
 			args:        []string{"-batch-wait", "10", "my-job"},
@@ -156,6 +165,15 @@ func TestJobRestartCommand_parseAndValidate(t *testing.T) {
 				onError:   jobRestartOnErrorFail,
 			},
 		},
+		{
+			name: "on error pause",
+			args: []string{"-on-error", "pause", "my-job"},
+			expectedCmd: &JobRestartCommand{
+				jobID:     "my-job",
+				batchSize: 1,
+				onError:   jobRestartOnErrorPause,
+			},
+		},
 		{
 			name:        "on error invalid",
 			args:        []string{"-on-error", "invalid", "my-job"},
@@ -1592,6 +1610,135 @@ func TestJobRestartCommand_filterAllocs(t *testing.T) {
 	}
 }
 
+func TestJobRestartCommand_allocHealthy(t *testing.T) {
+	ci.Parallel(t)
+
+	before := time.Now().Add(-time.Minute)
+	after := time.Now()
+
+	stub := AllocationListStubWithJob{
+		AllocationListStub: &api.AllocationListStub{
+			ID:           "alloc-1",
+			ClientStatus: api.AllocClientStatusRunning,
+			TaskStates: map[string]*api.TaskState{
+				"main":    {State: "running", StartedAt: before},
+				"sidecar": {State: "running", StartedAt: before},
+				"init":    {State: "dead", StartedAt: before},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		cmd         *JobRestartCommand
+		alloc       *api.Allocation
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name: "running tasks restarted",
+			cmd:  &JobRestartCommand{tasks: set.New[string](0)},
+			alloc: &api.Allocation{
+				ID:           "alloc-1",
+				ClientStatus: api.AllocClientStatusRunning,
+				TaskStates: map[string]*api.TaskState{
+					"main":    {State: "running", StartedAt: after},
+					"sidecar": {State: "running", StartedAt: after},
+					"init":    {State: "dead", StartedAt: before},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "running task not restarted yet",
+			cmd:  &JobRestartCommand{tasks: set.New[string](0)},
+			alloc: &api.Allocation{
+				ID:           "alloc-1",
+				ClientStatus: api.AllocClientStatusRunning,
+				TaskStates: map[string]*api.TaskState{
+					"main":    {State: "running", StartedAt: after},
+					"sidecar": {State: "running", StartedAt: before},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "only requested task restarted",
+			cmd:  &JobRestartCommand{tasks: set.From([]string{"main"})},
+			alloc: &api.Allocation{
+				ID:           "alloc-1",
+				ClientStatus: api.AllocClientStatusRunning,
+				TaskStates: map[string]*api.TaskState{
+					"main":    {State: "running", StartedAt: after},
+					"sidecar": {State: "running", StartedAt: before},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "task pending",
+			cmd:  &JobRestartCommand{tasks: set.New[string](0)},
+			alloc: &api.Allocation{
+				ID:           "alloc-1",
+				ClientStatus: api.AllocClientStatusRunning,
+				TaskStates: map[string]*api.TaskState{
+					"main":    {State: "pending", StartedAt: before},
+					"sidecar": {State: "running", StartedAt: after},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "replacement allocation",
+			cmd:  &JobRestartCommand{tasks: set.New[string](0)},
+			alloc: &api.Allocation{
+				ID:           "alloc-2",
+				ClientStatus: api.AllocClientStatusRunning,
+				TaskStates: map[string]*api.TaskState{
+					"main":    {State: "running", StartedAt: before},
+					"sidecar": {State: "running", StartedAt: before},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "task failed",
+			cmd:  &JobRestartCommand{tasks: set.New[string](0)},
+			alloc: &api.Allocation{
+				ID:           "alloc-1",
+				ClientStatus: api.AllocClientStatusRunning,
+				TaskStates: map[string]*api.TaskState{
+					"main":    {State: "dead", Failed: true},
+					"sidecar": {State: "running", StartedAt: after},
+				},
+			},
+			expectedErr: `Task "main" in allocation "alloc-1" failed`,
+		},
+		{
+			name: "allocation lost",
+			cmd:  &JobRestartCommand{tasks: set.New[string](0)},
+			alloc: &api.Allocation{
+				ID:           "alloc-1",
+				ClientStatus: api.AllocClientStatusLost,
+			},
+			expectedErr: `Allocation "alloc-1" is "lost"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cmd.length = fullId
+			healthy, err := tc.cmd.allocHealthy(stub, tc.alloc, false)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, healthy)
+		})
+	}
+}
+
 func TestJobRestartCommand_onErrorFail(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
@@ -19,7 +20,7 @@ type JobRevertCommand struct {
 
 func (c *JobRevertCommand) Help() string {
 	helpText := `
-Usage: nomad job revert [options] <job> <version|tag>
+Usage: nomad job revert [options] <job> [<version|tag>]
 
   Revert is used to revert a job to a prior version of the job. The available
   versions to revert to can be found using "nomad job history" command.
@@ -34,6 +35,12 @@ Usage: nomad job revert [options] <job> <version|tag>
   version number. If a version tag is specified, the job will be reverted to
   the version with the given tag.
 
+  If no version or tag is specified and the command is run in a terminal, the
+  prior versions of the job are listed along with their differences from the
+  current version, and the version to revert to is picked interactively. The
+  revert is only applied once confirmed, and fails if the job was updated in
+  the meantime. Listing the versions requires the 'read-job' capability.
+
 General Options:
 
   ` + generalOptionsUsage(usageOptsDefault) + `
@@ -45,6 +52,11 @@ Revert Options:
     the evaluation ID will be printed to the screen, which can be used to
     examine the evaluation using the eval-status command.
 
+  -preview
+    Display the differences between the current version of the job and the
+    version to revert to, without reverting the job. Requires the 'read-job'
+    capability.
+
   -consul-token
    The Consul token used to verify that the caller has access to the Service
    Identity policies associated in the targeted version of the job.
@@ -63,6 +75,7 @@ func (c *JobRevertCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-detach":  complete.PredictNothing,
+			"-preview": complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 		})
 }
@@ -85,12 +98,13 @@ func (c *JobRevertCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobRevertCommand) Name() string { return "job revert" }
 
 func (c *JobRevertCommand) Run(args []string) int {
-	var detach, verbose bool
+	var detach, preview, verbose bool
 	var consulToken string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&preview, "preview", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.StringVar(&consulToken, "consul-token", "", "")
 
@@ -104,10 +118,18 @@ func (c *JobRevertCommand) Run(args []string) int {
 		length = fullId
 	}
 
-	// Check that we got two args
+	// Check that we got one or two args
 	args = flags.Args()
-	if l := len(args); l != 2 {
-		c.Ui.Error("This command takes two arguments: <job> <version|tag>")
+	if l := len(args); l != 1 && l != 2 {
+		c.Ui.Error("This command takes one or two arguments: <job> [<version|tag>]")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// The version can only be picked interactively in a terminal
+	interactive := len(args) == 1
+	if interactive && !isTty() {
+		c.Ui.Error("A version or tag is required when not running in a terminal")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
@@ -125,32 +147,78 @@ func (c *JobRevertCommand) Run(args []string) int {
 		consulToken = os.Getenv("CONSUL_HTTP_TOKEN")
 	}
 
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
 	// Parse the job version or version tag
 	var revertVersion uint64
+	if !interactive {
+		parsedVersion, ok, err := parseVersion(args[1])
+		if ok && err == nil {
+			revertVersion = parsedVersion
+		} else {
+			foundTaggedVersion, _, err := client.Jobs().VersionByTag(jobID, args[1],
+				&api.QueryOptions{Namespace: namespace})
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
+				return 1
+			}
+			revertVersion = *foundTaggedVersion.Version
+		}
+	}
 
-	parsedVersion, ok, err := parseVersion(args[1])
-	if ok && err == nil {
-		revertVersion = parsedVersion
-	} else {
-		foundTaggedVersion, _, err := client.Jobs().VersionByTag(args[0], args[1], nil)
+	// Compare the versions against the current version when previewing the
+	// revert or picking the version.
+	var enforcePriorVersion *uint64
+	if preview || interactive {
+		versions, err := revertVersions(client, jobID, namespace)
 		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
+			c.Ui.Error(err.Error())
 			return 1
 		}
-		revertVersion = *foundTaggedVersion.Version
-	}
 
-	// Check if the job exists
-	jobIDPrefix := strings.TrimSpace(args[0])
-	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
-	if err != nil {
-		c.Ui.Error(err.Error())
-		return 1
+		var target *priorJobVersion
+		if interactive {
+			target = c.selectVersion(jobID, versions)
+			if target == nil {
+				return 1
+			}
+		} else if revertVersion == versions.current {
+			c.Ui.Error(fmt.Sprintf("Job %q is already at version %d", jobID, revertVersion))
+			return 1
+		} else {
+			target = versions.find(revertVersion)
+			if target == nil {
+				c.Ui.Error(fmt.Sprintf("Job %q has no version %d", jobID, revertVersion))
+				return 1
+			}
+		}
+		revertVersion = *target.job.Version
+
+		c.Ui.Output(fmt.Sprintf("Changes when reverting job %q from version %d to version %d:\n",
+			jobID, versions.current, revertVersion))
+		c.Ui.Output(c.Colorize().Color(formatRevertDiff(target.diff)))
+
+		if preview {
+			return 0
+		}
+		if !c.askQuestion(fmt.Sprintf("Revert job %q to version %d? [Y/n]", jobID, revertVersion)) {
+			c.Ui.Output("Cancelling job revert")
+			return 0
+		}
+
+		// Make sure the job wasn't updated since the diff was displayed
+		enforcePriorVersion = &versions.current
 	}
 
 	// Prefix lookup matched a single job
 	q := &api.WriteOptions{Namespace: namespace}
-	resp, _, err := client.Jobs().Revert(jobID, revertVersion, nil, q, consulToken, "")
+	resp, _, err := client.Jobs().Revert(jobID, revertVersion, enforcePriorVersion, q, consulToken, "")
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
 		return 1
@@ -171,3 +239,113 @@ func (c *JobRevertCommand) Run(args []string) int {
 	mon := newMonitor(c.Ui, client, length)
 	return mon.monitor(resp.EvalID)
 }
+
+// priorJobVersion is a prior version of a job along with the changes
+// reverting to it makes to the current version.
+type priorJobVersion struct {
+	job  *api.Job
+	diff *api.JobDiff
+}
+
+// jobRevertVersions are the versions a job can be reverted to.
+type jobRevertVersions struct {
+	current  uint64
+	versions []*priorJobVersion
+}
+
+// find returns the version with the given version number, or nil if the job
+// can't be reverted to it.
+func (v *jobRevertVersions) find(version uint64) *priorJobVersion {
+	for _, r := range v.versions {
+		if *r.job.Version == version {
+			return r
+		}
+	}
+	return nil
+}
+
+// revertVersions returns the prior versions of the job, most recent first,
+// each diffed against the current version of the job.
+func revertVersions(client *api.Client, jobID, namespace string) (*jobRevertVersions, error) {
+	q := &api.QueryOptions{Namespace: namespace}
+	job, _, err := client.Jobs().Info(jobID, q)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving job: %s", err)
+	}
+
+	current := *job.Version
+	jobs, diffs, _, err := client.Jobs().VersionsOpts(jobID,
+		&api.VersionsOptions{Diffs: true, DiffVersion: &current}, q)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving job versions: %s", err)
+	}
+
+	out := &jobRevertVersions{current: current}
+	for i, job := range jobs {
+		if *job.Version == current || i >= len(diffs) {
+			continue
+		}
+		out.versions = append(out.versions, &priorJobVersion{job: job, diff: diffs[i]})
+	}
+	return out, nil
+}
+
+// selectVersion lists the versions the job can be reverted to along with
+// their changes, and asks the user to pick one by version number or tag.
+func (c *JobRevertCommand) selectVersion(jobID string, versions *jobRevertVersions) *priorJobVersion {
+	if len(versions.versions) == 0 {
+		c.Ui.Error(fmt.Sprintf("Job %q has no prior version to revert to", jobID))
+		return nil
+	}
+
+	c.Ui.Output(fmt.Sprintf("Job %q is at version %d. Prior versions:", jobID, versions.current))
+	for _, v := range versions.versions {
+		c.Ui.Output("")
+		c.Ui.Output(c.Colorize().Color(formatRevertVersion(v)))
+	}
+
+	answer, err := c.Ui.Ask("\nSelect a version or tag to revert to:")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse answer: %v", err))
+		return nil
+	}
+	answer = strings.TrimSpace(answer)
+
+	for _, v := range versions.versions {
+		if v.job.VersionTag != nil && v.job.VersionTag.Name == answer {
+			return v
+		}
+	}
+	if version, ok, err := parseVersion(answer); ok && err == nil {
+		if v := versions.find(version); v != nil {
+			return v
+		}
+	}
+
+	c.Ui.Error(fmt.Sprintf("Invalid selection %q", answer))
+	return nil
+}
+
+// formatRevertVersion formats a version of a job with a summary of its
+// changes from the current version.
+func formatRevertVersion(v *priorJobVersion) string {
+	header := fmt.Sprintf("[bold]Version %d[reset] (submitted %s",
+		*v.job.Version, formatTime(time.Unix(0, *v.job.SubmitTime)))
+	if v.job.Stable != nil && *v.job.Stable {
+		header += ", stable"
+	}
+	if v.job.VersionTag != nil {
+		header += fmt.Sprintf(", tag %q", v.job.VersionTag.Name)
+	}
+	header += ")"
+
+	return header + "\n" + formatRevertDiff(v.diff)
+}
+
+// formatRevertDiff formats the changes reverting to a version makes.
+func formatRevertDiff(diff *api.JobDiff) string {
+	if diff == nil || diff.Type == "None" {
+		return "No changes from the current version"
+	}
+	return strings.TrimSpace(formatJobDiff(diff, false))
+}
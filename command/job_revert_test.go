@@ -258,11 +258,11 @@ func TestJobRevertCommand_VersionTag(t *testing.T) {
 
 		code := cmd.Run([]string{"-address", url, "test-job-revert", "v1-tag", "0"})
 		must.One(t, code)
-		must.StrContains(t, ui.ErrorWriter.String(), "This command takes two arguments")
+		must.StrContains(t, ui.ErrorWriter.String(), "This command takes one or two arguments")
 
 		code2 := cmd.Run([]string{"-address", url, "test-job-revert"})
 		must.One(t, code2)
-		must.StrContains(t, ui.ErrorWriter.String(), "This command takes two arguments")
+		must.StrContains(t, ui.ErrorWriter.String(), "A version or tag is required when not running in a terminal")
 	})
 
 	t.Run("Revert to tagged version doesn't duplicate tag", func(t *testing.T) {
@@ -296,3 +296,60 @@ func TestJobRevertCommand_VersionTag(t *testing.T) {
 		must.StrContains(t, output, "Tag Description = Version 1 tag")
 	})
 }
+
+func TestJobRevertCommand_Preview(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	state := srv.Agent.Server().State()
+
+	// Create a job with multiple versions
+	v0 := mock.Job()
+	v0.TaskGroups[0].Count = 1
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, v0))
+
+	v1 := v0.Copy()
+	v1.TaskGroups[0].Count = 2
+	v1.VersionTag = &structs.JobVersionTag{Name: "v1-tag"}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, v1))
+
+	v2 := v0.Copy()
+	v2.TaskGroups[0].Count = 3
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, v2))
+
+	t.Run("preview version", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobRevertCommand{Meta: Meta{Ui: ui}}
+
+		code := cmd.Run([]string{"-address", url, "-preview", v0.ID, "0"})
+		must.Zero(t, code)
+
+		out := ui.OutputWriter.String()
+		must.StrContains(t, out, "from version 2 to version 0")
+		must.StrContains(t, out, `Count: "3" => "1"`)
+	})
+
+	t.Run("preview tag", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobRevertCommand{Meta: Meta{Ui: ui}}
+
+		code := cmd.Run([]string{"-address", url, "-preview", v0.ID, "v1-tag"})
+		must.Zero(t, code)
+		must.StrContains(t, ui.OutputWriter.String(), `Count: "3" => "2"`)
+	})
+
+	t.Run("preview current version", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobRevertCommand{Meta: Meta{Ui: ui}}
+
+		code := cmd.Run([]string{"-address", url, "-preview", v0.ID, "2"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "is already at version 2")
+	})
+
+	// The job was not reverted
+	job, _, err := client.Jobs().Info(v0.ID, nil)
+	must.NoError(t, err)
+	must.Eq(t, 2, *job.Version)
+}
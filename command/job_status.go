@@ -250,6 +250,10 @@ func (c *JobStatusCommand) Run(args []string) int {
 		basic = append(basic, fmt.Sprintf("Idempotency Token|%v", *job.DispatchIdempotencyToken))
 	}
 
+	if job.Frozen != nil && *job.Frozen {
+		basic = append(basic, "Frozen|true")
+	}
+
 	if periodic && !parameterized {
 		if *job.Stop {
 			basic = append(basic, "Next Periodic Launch|none (job stopped)")
@@ -63,6 +63,10 @@ Stop Options:
     Purge is used to stop the job and purge it from the system. If not set, the
     job will still be queryable and will be purged by the garbage collector.
 
+  -audit
+    Record the purge of the job in the job purge history, which can be listed
+    with "nomad job history -purged". Requires the -purge flag.
+
   -yes
     Automatic yes to prompts.
 
@@ -82,6 +86,7 @@ func (c *JobStopCommand) AutocompleteFlags() complete.Flags {
 			"-detach":            complete.PredictNothing,
 			"-eval-priority":     complete.PredictNothing,
 			"-purge":             complete.PredictNothing,
+			"-audit":             complete.PredictNothing,
 			"-global":            complete.PredictNothing,
 			"-no-shutdown-delay": complete.PredictNothing,
 			"-yes":               complete.PredictNothing,
@@ -107,7 +112,7 @@ func (c *JobStopCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobStopCommand) Name() string { return "job stop" }
 
 func (c *JobStopCommand) Run(args []string) int {
-	var detach, purge, verbose, global, autoYes, noShutdownDelay bool
+	var detach, purge, audit, verbose, global, autoYes, noShutdownDelay bool
 	var evalPriority int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -118,6 +123,7 @@ func (c *JobStopCommand) Run(args []string) int {
 	flags.BoolVar(&noShutdownDelay, "no-shutdown-delay", false, "")
 	flags.BoolVar(&autoYes, "yes", false, "")
 	flags.BoolVar(&purge, "purge", false, "")
+	flags.BoolVar(&audit, "audit", false, "")
 	flags.IntVar(&evalPriority, "eval-priority", 0, "")
 
 	if err := flags.Parse(args); err != nil {
@@ -132,6 +138,12 @@ func (c *JobStopCommand) Run(args []string) int {
 		return 1
 	}
 
+	if audit && !purge {
+		c.Ui.Error("The -audit flag can only be used with -purge")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
 	var jobIDs []string
 	for _, jobID := range flags.Args() {
 		jobIDs = append(jobIDs, strings.TrimSpace(jobID))
@@ -216,7 +228,7 @@ func (c *JobStopCommand) Run(args []string) int {
 			}
 
 			// Invoke the stop
-			opts := &api.DeregisterOptions{Purge: purge, Global: global, EvalPriority: evalPriority, NoShutdownDelay: noShutdownDelay}
+			opts := &api.DeregisterOptions{Purge: purge, Global: global, EvalPriority: evalPriority, NoShutdownDelay: noShutdownDelay, Audit: audit}
 			wq := &api.WriteOptions{Namespace: *job.Namespace}
 			evalID, _, err := client.Jobs().DeregisterOpts(*job.ID, opts, wq)
 			if err != nil {
@@ -113,6 +113,15 @@ func TestStopCommand_Fails(t *testing.T) {
 
 	ui.ErrorWriter.Reset()
 
+	// Fails on -audit without -purge
+	code = cmd.Run([]string{"-audit", "nope"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "The -audit flag can only be used with -purge")
+
+	ui.ErrorWriter.Reset()
+
 	// Fails on nonexistent job ID
 	code = cmd.Run([]string{"-address=" + url, "nope"})
 	must.One(t, code)
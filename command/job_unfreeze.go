@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobUnfreezeCommand struct {
+	Meta
+}
+
+func (c *JobUnfreezeCommand) Help() string {
+	helpText := `
+Usage: nomad job unfreeze [options] <job_id>
+
+  Resume scheduling of a job frozen with the "nomad job freeze" command. An
+  evaluation is created so that any placements or updates held back while the
+  job was frozen are made. Upon successful unfreeze, an interactive monitor
+  session will start to display log lines as the evaluation is processed.
+
+  When ACLs are enabled, this command requires a token with the 'freeze-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID. The 'read-job'
+  capability is required to monitor the resulting evaluation when -detach is
+  not used.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Unfreeze Options:
+
+  -detach
+    Return immediately instead of entering monitor mode. The ID
+    of the evaluation created will be printed to the screen, which can be
+    used to examine the evaluation using the eval-status command.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobUnfreezeCommand) Synopsis() string {
+	return "Resume scheduling of a frozen job"
+}
+
+func (c *JobUnfreezeCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-detach":  complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *JobUnfreezeCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobUnfreezeCommand) Name() string { return "job unfreeze" }
+
+func (c *JobUnfreezeCommand) Run(args []string) int {
+	var detach, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	w := &api.WriteOptions{Namespace: namespace}
+	resp, _, err := client.Jobs().Freeze(jobID, false, w)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error unfreezing job: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Job %q unfrozen", jobID))
+
+	// Nothing to monitor if the job wasn't frozen or is stopped
+	if resp.EvalID == "" {
+		return 0
+	}
+
+	if detach {
+		c.Ui.Output("Evaluation ID: " + resp.EvalID)
+		return 0
+	}
+
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(resp.EvalID)
+}
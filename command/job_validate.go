@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/hashicorp/nomad/helper"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/posener/complete"
 )
@@ -41,6 +42,13 @@ General Options:
 
 Validate Options:
 
+  -policy=<path>
+    Path to an HCL or JSON rule bundle, a directory of rule bundles, or a URL
+    to download them from. The rules, in the format of "nomad job lint -rules",
+    are evaluated locally against the job and violations are reported. Rules
+    with the "error" severity make the validation fail. Can be used multiple
+    times.
+
   -json
     Parses the job file as JSON. If the outer object has a Job field, such as
     from "nomad job inspect" or "nomad run -output", the value of the field is
@@ -71,6 +79,7 @@ func (c *JobValidateCommand) Synopsis() string {
 func (c *JobValidateCommand) AutocompleteFlags() complete.Flags {
 	return complete.Flags{
 		"-hcl2-strict":     complete.PredictNothing,
+		"-policy":          complete.PredictAnything,
 		"-vault-namespace": complete.PredictAnything,
 		"-var":             complete.PredictAnything,
 		"-var-file":        complete.PredictFiles("*.var"),
@@ -89,12 +98,14 @@ func (c *JobValidateCommand) Name() string { return "job validate" }
 
 func (c *JobValidateCommand) Run(args []string) int {
 	var vaultNamespace string
+	var policies flaghelper.StringFlag
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
 	flagSet.BoolVar(&c.JobGetter.JSON, "json", false, "")
 	flagSet.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
 	flagSet.StringVar(&vaultNamespace, "vault-namespace", "", "")
+	flagSet.Var(&policies, "policy", "")
 	flagSet.Var(&c.JobGetter.Vars, "var", "")
 	flagSet.Var(&c.JobGetter.VarFiles, "var-file", "")
 
@@ -165,6 +176,10 @@ func (c *JobValidateCommand) Run(args []string) int {
 		c.Ui.Output(c.FormatWarnings("Job", jr.Warnings))
 	}
 
+	if !checkJobPolicies(&c.Meta, job, policies) {
+		return 1
+	}
+
 	// Done!
 	c.Ui.Output(
 		c.Colorize().Color("[bold][green]Job validation successful[reset]"))
@@ -5,6 +5,7 @@ package command
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -110,6 +111,51 @@ func TestValidateCommand_Fails(t *testing.T) {
 	ui.ErrorWriter.Reset()
 }
 
+func TestValidateCommand_Policy(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	errorRules := filepath.Join(dir, "error.hcl")
+	must.NoError(t, os.WriteFile(errorRules, []byte(testJobPolicyRules), 0o644))
+	warningRules := filepath.Join(dir, "warning.hcl")
+	must.NoError(t, os.WriteFile(warningRules, []byte(`
+rule "owner" {
+  filter  = "Meta.owner is empty"
+  message = "jobs should set an owner"
+}
+`), 0o644))
+
+	t.Run("warning", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobValidateCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=http://127.0.0.1:1", "-policy", warningRules, "testdata/example-basic.nomad"})
+		must.Zero(t, code)
+		out := ui.OutputWriter.String()
+		must.StrContains(t, out, "Job policy warnings")
+		must.StrContains(t, out, "jobs should set an owner")
+		must.StrContains(t, out, "Job validation successful")
+	})
+
+	t.Run("violation", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobValidateCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=http://127.0.0.1:1", "-policy", dir, "testdata/example-basic.nomad"})
+		must.One(t, code)
+		out := ui.OutputWriter.String()
+		must.StrContains(t, out, "Job policy violations")
+		must.StrContains(t, out, "tasks must run in containers")
+		must.StrNotContains(t, out, "Job validation successful")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobValidateCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=http://127.0.0.1:1", "-policy", filepath.Join(dir, "missing.hcl"), "testdata/example-basic.nomad"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "Error loading job policies")
+	})
+}
+
 func TestValidateCommand_From_STDIN(t *testing.T) {
 	ci.Parallel(t)
 	stdinR, stdinW, err := os.Pipe()
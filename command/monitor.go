@@ -323,10 +323,16 @@ func (m *monitor) monitor(evalID string) int {
 func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix string) string {
 	// Print a helpful message if we have an eligibility problem
 	var out string
-	if metrics.NodesEvaluated == 0 {
+	if metrics.NodesEvaluated == 0 && len(metrics.PlanRejections) == 0 && metrics.FailedGang == "" {
 		out += fmt.Sprintf("%s* No nodes were eligible for evaluation\n", prefix)
 	}
 
+	// Print a helpful message if the placements were dropped because other
+	// task groups of the gang failed to place
+	if metrics.FailedGang != "" {
+		out += fmt.Sprintf("%s* Task groups of gang %q could not all be placed\n", prefix, metrics.FailedGang)
+	}
+
 	// Print a helpful message if the user has asked for a DC that has no
 	// available nodes.
 	for dc, available := range metrics.NodesAvailable {
@@ -359,6 +365,16 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print the nodes the plan applier rejected placements for
+	nodeIDs := make([]string, 0, len(metrics.PlanRejections))
+	for nodeID := range metrics.PlanRejections {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	for _, nodeID := range nodeIDs {
+		out += fmt.Sprintf("%s* Plan rejected on node %q: %s\n", prefix, nodeID, metrics.PlanRejections[nodeID])
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
@@ -371,6 +371,28 @@ Node    score-1  score-2  score-3  score-4  final score
 node-1  1        2        0        0        1
 node-2  1        0        3        0        2
 node-3  0        0        0        4        3
+`,
+		},
+		{
+			Name: "display plan rejections",
+			Metrics: &api.AllocationMetric{
+				PlanRejections: map[string]string{
+					"node-2": "node is draining",
+					"node-1": "memory",
+				},
+			},
+			Expected: `
+* Plan rejected on node "node-1": memory
+* Plan rejected on node "node-2": node is draining
+`,
+		},
+		{
+			Name: "display failed gang",
+			Metrics: &api.AllocationMetric{
+				FailedGang: "app",
+			},
+			Expected: `
+* Task groups of gang "app" could not all be placed
 `,
 		},
 	}
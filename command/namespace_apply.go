@@ -229,6 +229,8 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 	delete(m, "node_pool_config")
 	delete(m, "vault")
 	delete(m, "consul")
+	delete(m, "preemption")
+	delete(m, "gc_config")
 
 	// Decode the rest
 	if err := mapstructure.WeakDecode(m, result); err != nil {
@@ -299,6 +301,50 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 		}
 	}
 
+	pObj := list.Filter("preemption")
+	if len(pObj.Items) > 0 {
+		for _, o := range pObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var pConfig *api.NamespacePreemptionConfiguration
+			if err := hcl.DecodeObject(&pConfig, ot.List); err != nil {
+				return err
+			}
+			result.PreemptionConfiguration = pConfig
+			break
+		}
+	}
+
+	gcObj := list.Filter("gc_config")
+	if len(gcObj.Items) > 0 {
+		for _, o := range gcObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, ot.List); err != nil {
+				return err
+			}
+			var gcConfig api.NamespaceGCConfiguration
+			dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+				DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+				WeaklyTypedInput: true,
+				Result:           &gcConfig,
+			})
+			if err != nil {
+				return err
+			}
+			if err := dec.Decode(m); err != nil {
+				return err
+			}
+			result.GCConfiguration = &gcConfig
+			break
+		}
+	}
+
 	if metaO := list.Filter("meta"); len(metaO.Items) > 0 {
 		for _, o := range metaO.Elem().Items {
 			var m map[string]interface{}
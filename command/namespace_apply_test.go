@@ -6,6 +6,7 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/api"
@@ -97,6 +98,16 @@ consul {
   allowed = ["prod", "apps*"]
 }
 
+preemption {
+  protected      = false
+  priority_floor = 70
+}
+
+gc_config {
+  job_gc_threshold  = "1h"
+  eval_gc_threshold = "30m"
+}
+
 meta {
   dept = "eng"
 }`,
@@ -120,6 +131,13 @@ meta {
 					Default: "prod",
 					Allowed: []string{"prod", "apps*"},
 				},
+				PreemptionConfiguration: &api.NamespacePreemptionConfiguration{
+					PriorityFloor: 70,
+				},
+				GCConfiguration: &api.NamespaceGCConfiguration{
+					JobGCThreshold:  time.Hour,
+					EvalGCThreshold: 30 * time.Minute,
+				},
 				Meta: map[string]string{
 					"dept": "eng",
 				},
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
@@ -194,9 +195,40 @@ func (c *NamespaceStatusCommand) Run(args []string) int {
 		c.Ui.Output(formatKV(cConfigOut))
 	}
 
+	if ns.PreemptionConfiguration != nil {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Preemption Configuration[reset]"))
+		pConfig := ns.PreemptionConfiguration
+		pConfigOut := []string{
+			fmt.Sprintf("Protected|%t", pConfig.Protected),
+			fmt.Sprintf("Priority Floor|%d", pConfig.PriorityFloor),
+		}
+		c.Ui.Output(formatKV(pConfigOut))
+	}
+
+	if ns.GCConfiguration != nil {
+		c.Ui.Output(c.Colorize().Color("\n[bold]GC Configuration[reset]"))
+		gcConfig := ns.GCConfiguration
+		gcConfigOut := []string{
+			fmt.Sprintf("Job GC Threshold|%s", formatNamespaceGCThreshold(gcConfig.JobGCThreshold)),
+			fmt.Sprintf("Eval GC Threshold|%s", formatNamespaceGCThreshold(gcConfig.EvalGCThreshold)),
+			fmt.Sprintf("Batch Eval GC Threshold|%s", formatNamespaceGCThreshold(gcConfig.BatchEvalGCThreshold)),
+			fmt.Sprintf("Deployment GC Threshold|%s", formatNamespaceGCThreshold(gcConfig.DeploymentGCThreshold)),
+		}
+		c.Ui.Output(formatKV(gcConfigOut))
+	}
+
 	return 0
 }
 
+// formatNamespaceGCThreshold formats a GC threshold of a namespace, which uses
+// the server configuration when zero.
+func formatNamespaceGCThreshold(threshold time.Duration) string {
+	if threshold == 0 {
+		return "<server default>"
+	}
+	return threshold.String()
+}
+
 // formatNamespaceBasics formats the basic information of the namespace
 func formatNamespaceBasics(ns *api.Namespace) string {
 	enabled_drivers := "*"
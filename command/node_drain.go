@@ -71,6 +71,12 @@ Node Drain Options:
     Ignore system allows the drain to complete without stopping system job
     allocations. By default system jobs are stopped last.
 
+  -batch-grace <duration>
+    Allow batch job allocations to complete for up to this duration past the
+    deadline before they are force stopped. Batch allocations that are nearly
+    finished complete instead of being stopped and rescheduled. Cannot be used
+    with -force or -no-deadline.
+
   -keep-ineligible
     Keep ineligible will maintain the node's scheduling ineligibility even if
     the drain is being disabled. This is useful when an existing drain is being
@@ -106,6 +112,7 @@ func (c *NodeDrainCommand) AutocompleteFlags() complete.Flags {
 			"-force":           complete.PredictNothing,
 			"-no-deadline":     complete.PredictNothing,
 			"-ignore-system":   complete.PredictNothing,
+			"-batch-grace":     complete.PredictAnything,
 			"-keep-ineligible": complete.PredictNothing,
 			"-m":               complete.PredictNothing,
 			"-meta":            complete.PredictNothing,
@@ -135,7 +142,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	var enable, disable, detach, force,
 		noDeadline, ignoreSystem, keepIneligible,
 		self, autoYes, monitor bool
-	var deadline, message string
+	var deadline, batchGrace, message string
 	var metaVars flaghelper.StringFlag
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -147,6 +154,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	flags.BoolVar(&force, "force", false, "Force immediate drain")
 	flags.BoolVar(&noDeadline, "no-deadline", false, "Drain node with no deadline")
 	flags.BoolVar(&ignoreSystem, "ignore-system", false, "Do not drain system job allocations from the node")
+	flags.StringVar(&batchGrace, "batch-grace", "", "Time batch allocations are allowed to complete past the deadline")
 	flags.BoolVar(&keepIneligible, "keep-ineligible", false, "Do not update the nodes scheduling eligibility")
 	flags.BoolVar(&self, "self", false, "")
 	flags.BoolVar(&autoYes, "yes", false, "Automatic yes to prompts.")
@@ -181,7 +189,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	}
 
 	// Validate a compatible set of flags were set
-	if disable && (deadline != "" || force || noDeadline || ignoreSystem) {
+	if disable && (deadline != "" || force || noDeadline || ignoreSystem || batchGrace != "") {
 		c.Ui.Error("-disable can't be combined with flags configuring drain strategy")
 		c.Ui.Error(commandErrorText(c))
 		return 1
@@ -196,6 +204,11 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
+	if batchGrace != "" && (force || noDeadline) {
+		c.Ui.Error("-batch-grace can't be combined with -force or -no-deadline")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
 
 	// Parse the duration
 	var d time.Duration
@@ -219,6 +232,21 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		d = defaultDrainDuration
 	}
 
+	var grace time.Duration
+	if batchGrace != "" {
+		dur, err := time.ParseDuration(batchGrace)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse batch grace %q: %v", batchGrace, err))
+			return 1
+		}
+		if dur <= 0 {
+			c.Ui.Error("A positive batch grace duration must be given")
+			return 1
+		}
+
+		grace = dur
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -311,6 +339,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		spec = &api.DrainSpec{
 			Deadline:         d,
 			IgnoreSystemJobs: ignoreSystem,
+			BatchGrace:       grace,
 		}
 	}
 
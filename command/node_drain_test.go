@@ -412,6 +412,22 @@ func TestNodeDrainCommand_Fails(t *testing.T) {
 		}
 		ui.ErrorWriter.Reset()
 	}
+
+	// Fail on setting a batch grace without a deadline
+	for _, flag := range []string{"-force", "-no-deadline"} {
+		code := cmd.Run([]string{"-address=" + url, "-enable", "-batch-grace=10m", flag, "12345678-abcd-efab-cdef-123456789abc"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "-batch-grace can't be combined with")
+		ui.ErrorWriter.Reset()
+	}
+
+	// Fail on setting a bad batch grace
+	for _, flag := range []string{"-batch-grace=0s", "-batch-grace=-1s"} {
+		code := cmd.Run([]string{"-address=" + url, "-enable", flag, "12345678-abcd-efab-cdef-123456789abc"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "positive batch grace")
+		ui.ErrorWriter.Reset()
+	}
 }
 
 func TestNodeDrainCommand_AutocompleteArgs(t *testing.T) {
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+)
+
+type NodeLifecycleCommand struct {
+	Meta
+}
+
+func (c *NodeLifecycleCommand) Name() string {
+	return "node lifecycle"
+}
+
+func (c *NodeLifecycleCommand) Synopsis() string {
+	return "Cycle the nodes of a node pool through maintenance"
+}
+
+func (c *NodeLifecycleCommand) Help() string {
+	helpText := `
+Usage: nomad node lifecycle <subcommand> [options] [args]
+
+  This command groups subcommands for cycling the nodes of a node pool through
+  maintenance, such as an OS upgrade. Nodes are processed in batches and go
+  through the following phases: cordon, drain, custom action, reregister and
+  uncordon.
+
+  Runs are driven by the cluster leader and their progress is stored in the
+  cluster state, so they continue if the command that started them exits or
+  if leadership changes. Each node pool has at most one running run.
+
+  Cycle the nodes of a node pool:
+
+    $ nomad node lifecycle run -webhook https://reimage.example.com <node-pool>
+
+  Display the progress of a run:
+
+    $ nomad node lifecycle status <node-pool>
+
+  Abort a run:
+
+    $ nomad node lifecycle abort <node-pool>
+
+  Please refer to individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeLifecycleCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// formatNodeLifecycleRun formats a node lifecycle run and the phase of each
+// of its nodes, truncating node IDs to the given length.
+func formatNodeLifecycleRun(run *api.NodeLifecycleRun, length int) string {
+	basic := []string{
+		fmt.Sprintf("ID|%s", run.ID),
+		fmt.Sprintf("Node Pool|%s", run.NodePool),
+		fmt.Sprintf("Status|%s", run.Status),
+		fmt.Sprintf("Batch Size|%d", run.BatchSize),
+		fmt.Sprintf("Created|%s", formatUnixNanoTime(run.CreateTime)),
+		fmt.Sprintf("Modified|%s", formatUnixNanoTime(run.ModifyTime)),
+	}
+	if run.WebhookURL != "" {
+		basic = append(basic, fmt.Sprintf("Webhook|%s", run.WebhookURL))
+	}
+	if run.StatusDescription != "" {
+		basic = append(basic, fmt.Sprintf("Description|%s", run.StatusDescription))
+	}
+
+	nodes := make([]string, len(run.Nodes)+1)
+	nodes[0] = "Node ID|Node Name|Phase|Since|Error"
+	for i, n := range run.Nodes {
+		nodes[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			limit(n.NodeID, length), n.NodeName, n.Phase,
+			formatUnixNanoTime(n.PhaseStartTime), n.Error)
+	}
+
+	var out strings.Builder
+	out.WriteString(formatKV(basic))
+	out.WriteString("\n\n")
+	out.WriteString(formatList(nodes))
+	return out.String()
+}
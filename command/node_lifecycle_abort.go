@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type NodeLifecycleAbortCommand struct {
+	Meta
+}
+
+func (c *NodeLifecycleAbortCommand) Name() string {
+	return "node lifecycle abort"
+}
+
+func (c *NodeLifecycleAbortCommand) Synopsis() string {
+	return "Abort a node lifecycle run"
+}
+
+func (c *NodeLifecycleAbortCommand) Help() string {
+	helpText := `
+Usage: nomad node lifecycle abort [options] <node-pool>
+
+  Abort stops the running node lifecycle run of a node pool. No further nodes
+  are cycled. Drains that are already in progress are not cancelled, and nodes
+  left ineligible by the run are not modified.
+
+  If ACLs are enabled, this command requires a token with the 'node:write'
+  capability and the 'read' capability for the node pool.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeLifecycleAbortCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *NodeLifecycleAbortCommand) AutocompleteArgs() complete.Predictor {
+	return nodePoolPredictor(c.Client, nil)
+}
+
+func (c *NodeLifecycleAbortCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <node-pool>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	pool := args[0]
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	run, _, err := client.NodePools().AbortLifecycle(pool, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error aborting node lifecycle run: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Node lifecycle run %q for node pool %q aborted",
+		limit(run.ID, shortId), pool))
+	return 0
+}
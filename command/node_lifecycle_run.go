@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type NodeLifecycleRunCommand struct {
+	Meta
+}
+
+func (c *NodeLifecycleRunCommand) Name() string {
+	return "node lifecycle run"
+}
+
+func (c *NodeLifecycleRunCommand) Synopsis() string {
+	return "Cycle the nodes of a node pool through maintenance"
+}
+
+func (c *NodeLifecycleRunCommand) Help() string {
+	helpText := `
+Usage: nomad node lifecycle run [options] <node-pool>
+
+  Run starts a node lifecycle run that cycles the nodes of a node pool through
+  maintenance in batches. Each node in a batch is marked as ineligible,
+  drained, handed over to the custom action webhook, waited on until it
+  registers again and finally marked as eligible. The next batch starts once
+  all nodes of the current batch are complete. The run fails once the nodes of
+  a batch are done if any of them failed.
+
+  The run is driven by the cluster leader. By default, this command monitors
+  the run until it's done; interrupting the command stops monitoring but not
+  the run. Use "nomad node lifecycle status" to display the progress of the
+  run and "nomad node lifecycle abort" to stop it.
+
+  The webhook is called by the leader with a POST request whose JSON body
+  contains the RunID, NodeID, NodeName, NodePool, Datacenter and Address of
+  the node, and must respond with a 2xx status code once the action has been
+  started. The webhook may be called more than once for a node if leadership
+  changes while it's being called. Nodes that register again with a new node
+  ID but the same name, for example after being reimaged, are recognized. If
+  no webhook is given, nodes are marked as eligible again right after being
+  drained.
+
+  If ACLs are enabled, this command requires a token with the 'node:write'
+  capability and the 'read' capability for the node pool. Using a webhook also
+  requires the 'operator:write' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Run Options:
+
+  -batch-size <n>
+    Number of nodes to cycle at the same time. Defaults to 1.
+
+  -deadline <duration>
+    Deadline by which all allocations must be moved off a node being drained.
+    Defaults to 1h.
+
+  -ignore-system
+    Do not stop system job allocations when draining nodes.
+
+  -webhook <url>
+    URL of the custom action webhook to call for each drained node.
+
+  -reregister-timeout <duration>
+    Maximum amount of time to wait for a node to register again after the
+    custom action webhook was called. Defaults to 30m.
+
+  -detach
+    Return immediately instead of monitoring the run.
+
+  -verbose
+    Display full node and run IDs.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeLifecycleRunCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-batch-size":         complete.PredictAnything,
+			"-deadline":           complete.PredictAnything,
+			"-ignore-system":      complete.PredictNothing,
+			"-webhook":            complete.PredictAnything,
+			"-reregister-timeout": complete.PredictAnything,
+			"-detach":             complete.PredictNothing,
+			"-verbose":            complete.PredictNothing,
+		})
+}
+
+func (c *NodeLifecycleRunCommand) AutocompleteArgs() complete.Predictor {
+	return nodePoolPredictor(c.Client, nil)
+}
+
+func (c *NodeLifecycleRunCommand) Run(args []string) int {
+	var batchSize int
+	var deadline, reregisterTimeout time.Duration
+	var ignoreSystem, detach, verbose bool
+	var webhook string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.IntVar(&batchSize, "batch-size", 1, "")
+	flags.DurationVar(&deadline, "deadline", defaultDrainDuration, "")
+	flags.BoolVar(&ignoreSystem, "ignore-system", false, "")
+	flags.StringVar(&webhook, "webhook", "", "")
+	flags.DurationVar(&reregisterTimeout, "reregister-timeout", 30*time.Minute, "")
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <node-pool>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	pool := args[0]
+
+	if batchSize < 1 {
+		c.Ui.Error("The -batch-size flag must be greater than zero")
+		return 1
+	}
+	if deadline <= 0 {
+		c.Ui.Error("The -deadline flag must be a positive duration")
+		return 1
+	}
+	if reregisterTimeout <= 0 {
+		c.Ui.Error("The -reregister-timeout flag must be a positive duration")
+		return 1
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	run, _, err := client.NodePools().StartLifecycle(pool, &api.NodeLifecycleStartRequest{
+		BatchSize: batchSize,
+		DrainSpec: &api.DrainSpec{
+			Deadline:         deadline,
+			IgnoreSystemJobs: ignoreSystem,
+		},
+		WebhookURL:        webhook,
+		ReregisterTimeout: reregisterTimeout,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error starting node lifecycle run: %s", err))
+		return 1
+	}
+
+	if detach {
+		c.Ui.Output(fmt.Sprintf("Started node lifecycle run %q for %d nodes in node pool %q",
+			limit(run.ID, length), len(run.Nodes), pool))
+		return 0
+	}
+
+	c.output(fmt.Sprintf("Cycling %d nodes in node pool %q in batches of %d",
+		len(run.Nodes), pool, batchSize))
+	return c.monitor(client, run, length)
+}
+
+// monitor outputs the phase changes of the nodes of the run until it's done.
+// Interrupting the monitor leaves the run running.
+func (c *NodeLifecycleRunCommand) monitor(client *api.Client, run *api.NodeLifecycleRun, length int) int {
+	sigsCh := make(chan os.Signal, 1)
+	signal.Notify(sigsCh, os.Interrupt)
+	defer signal.Stop(sigsCh)
+
+	type result struct {
+		run *api.NodeLifecycleRun
+		err error
+	}
+	resultCh := make(chan result, 1)
+
+	phases := make(map[string]string, len(run.Nodes))
+	q := &api.QueryOptions{WaitIndex: run.ModifyIndex}
+	for {
+		for _, n := range run.Nodes {
+			if phases[n.NodeName] == n.Phase {
+				continue
+			}
+			phases[n.NodeName] = n.Phase
+			msg := fmt.Sprintf("Node %q (%s): %s", n.NodeName, limit(n.NodeID, length), n.Phase)
+			if n.Error != "" {
+				msg += ": " + n.Error
+			}
+			c.output(msg)
+		}
+
+		switch run.Status {
+		case api.NodeLifecycleStatusComplete:
+			c.output(fmt.Sprintf("Node lifecycle run for node pool %q complete", run.NodePool))
+			return 0
+		case api.NodeLifecycleStatusFailed:
+			c.Ui.Error(fmt.Sprintf("Node lifecycle run for node pool %q failed: %s",
+				run.NodePool, run.StatusDescription))
+			return 1
+		case api.NodeLifecycleStatusAborted:
+			c.Ui.Warn(fmt.Sprintf("Node lifecycle run for node pool %q aborted", run.NodePool))
+			return 1
+		}
+
+		go func() {
+			out, qm, err := client.NodePools().LifecycleStatus(run.NodePool, q)
+			if err == nil {
+				q.WaitIndex = qm.LastIndex
+			}
+			resultCh <- result{run: out, err: err}
+		}()
+
+		select {
+		case <-sigsCh:
+			c.Ui.Output(fmt.Sprintf("\nStopped monitoring node lifecycle run %q, which continues running",
+				limit(run.ID, length)))
+			return 0
+		case res := <-resultCh:
+			if res.err != nil {
+				c.Ui.Error(fmt.Sprintf("Error reading node lifecycle run: %s", res.err))
+				return 1
+			}
+			if res.run.ID != run.ID {
+				c.Ui.Error(fmt.Sprintf("Node lifecycle run %q was replaced by run %q",
+					limit(run.ID, length), limit(res.run.ID, length)))
+				return 1
+			}
+			run = res.run
+		}
+	}
+}
+
+func (c *NodeLifecycleRunCommand) output(msg string) {
+	c.Ui.Output(fmt.Sprintf("==> %s: %s", formatTime(time.Now()), msg))
+}
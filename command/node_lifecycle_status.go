@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type NodeLifecycleStatusCommand struct {
+	Meta
+}
+
+func (c *NodeLifecycleStatusCommand) Name() string {
+	return "node lifecycle status"
+}
+
+func (c *NodeLifecycleStatusCommand) Synopsis() string {
+	return "Display the progress of a node lifecycle run"
+}
+
+func (c *NodeLifecycleStatusCommand) Help() string {
+	helpText := `
+Usage: nomad node lifecycle status [options] <node-pool>
+
+  Status displays the status of the last node lifecycle run of a node pool and
+  the phase of each of its nodes.
+
+  If ACLs are enabled, this command requires a token with the 'node:read'
+  capability and the 'read' capability for the node pool.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Status Options:
+
+  -json
+    Output the run in its JSON format.
+
+  -t
+    Format and display the run using a Go template.
+
+  -verbose
+    Display full node IDs.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeLifecycleStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *NodeLifecycleStatusCommand) AutocompleteArgs() complete.Predictor {
+	return nodePoolPredictor(c.Client, nil)
+}
+
+func (c *NodeLifecycleStatusCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <node-pool>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	pool := args[0]
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	run, _, err := client.NodePools().LifecycleStatus(pool, nil)
+	if err != nil {
+		var urErr api.UnexpectedResponseError
+		if errors.As(err, &urErr) && urErr.StatusCode() == http.StatusNotFound {
+			c.Ui.Error(fmt.Sprintf("No node lifecycle run found for node pool %q", pool))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error reading node lifecycle run: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, run)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+	c.Ui.Output(formatNodeLifecycleRun(run, length))
+	return 0
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeLifecycleCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeLifecycleCommand{}
+	var _ cli.Command = &NodeLifecycleRunCommand{}
+	var _ cli.Command = &NodeLifecycleStatusCommand{}
+	var _ cli.Command = &NodeLifecycleAbortCommand{}
+}
+
+func TestNodeLifecycleRunCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &NodeLifecycleRunCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-batch-size", "0", "default"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-batch-size flag must be greater than zero")
+}
+
+func TestNodeLifecycleRunCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	waitForNodes(t, client)
+
+	ui := cli.NewMockUi()
+	cmd := &NodeLifecycleRunCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address", url, "default"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "complete")
+
+	nodes, _, err := client.Nodes().List(nil)
+	must.NoError(t, err)
+	for _, node := range nodes {
+		must.Eq(t, api.NodeSchedulingEligible, node.SchedulingEligibility)
+		must.False(t, node.Drain)
+	}
+
+	ui = cli.NewMockUi()
+	statusCmd := &NodeLifecycleStatusCommand{Meta: Meta{Ui: ui}}
+	code = statusCmd.Run([]string{"-address", url, "default"})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.RegexMatch(t, regexp.MustCompile(`Status\s+= complete`), out)
+	must.StrContains(t, out, nodes[0].Name)
+}
+
+func TestNodeLifecycleRunCommand_WebhookFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	waitForNodes(t, client)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer webhook.Close()
+
+	ui := cli.NewMockUi()
+	cmd := &NodeLifecycleRunCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address", url, "-webhook", webhook.URL, "default"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "unexpected status code 500")
+
+	run, _, err := client.NodePools().LifecycleStatus("default", nil)
+	must.NoError(t, err)
+	must.Eq(t, api.NodeLifecycleStatusFailed, run.Status)
+}
+
+func TestNodeLifecycleAbortCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	waitForNodes(t, client)
+
+	// Aborting without a run fails.
+	ui := cli.NewMockUi()
+	cmd := &NodeLifecycleAbortCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address", url, "default"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "no running node lifecycle run")
+
+	// Start a run whose node never registers again after the webhook is
+	// called, so it keeps running until it's aborted.
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer webhook.Close()
+
+	ui = cli.NewMockUi()
+	runCmd := &NodeLifecycleRunCommand{Meta: Meta{Ui: ui}}
+	code = runCmd.Run([]string{"-address", url, "-webhook", webhook.URL, "-detach", "default"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "Started node lifecycle run")
+
+	// Starting another run while it's running fails.
+	ui = cli.NewMockUi()
+	runCmd = &NodeLifecycleRunCommand{Meta: Meta{Ui: ui}}
+	code = runCmd.Run([]string{"-address", url, "-detach", "default"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "already has running node lifecycle run")
+
+	ui = cli.NewMockUi()
+	cmd = &NodeLifecycleAbortCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address", url, "default"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "aborted")
+
+	run, _, err := client.NodePools().LifecycleStatus("default", nil)
+	must.NoError(t, err)
+	must.Eq(t, api.NodeLifecycleStatusAborted, run.Status)
+
+	ui = cli.NewMockUi()
+	statusCmd := &NodeLifecycleStatusCommand{Meta: Meta{Ui: ui}}
+	code = statusCmd.Run([]string{"-address", url, "default"})
+	must.Zero(t, code)
+	must.RegexMatch(t, regexp.MustCompile(`Status\s+= aborted`), ui.OutputWriter.String())
+}
+
+func TestNodeLifecycleStatusCommand_NotFound(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NodeLifecycleStatusCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address", url, "default"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No node lifecycle run found")
+}
@@ -6,8 +6,10 @@ package command
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
 
@@ -115,6 +117,9 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 		fmt.Sprintf("Name|%s", pool.Name),
 		fmt.Sprintf("Description|%s", pool.Description),
 	}
+	if pool.MaxJobFeatureLevel != "" {
+		basic = append(basic, fmt.Sprintf("Max Job Feature Level|%s", pool.MaxJobFeatureLevel))
+	}
 	c.Ui.Output(formatKV(basic))
 
 	c.Ui.Output(c.Colorize().Color("\n[bold]Metadata[reset]"))
@@ -144,5 +149,38 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 		c.Ui.Output("No scheduler configuration")
 	}
 
+	if len(pool.ReadinessGates) > 0 {
+		gates := make([]string, len(pool.ReadinessGates)+1)
+		gates[0] = "Namespace|Job ID"
+		for i, gate := range pool.ReadinessGates {
+			namespace := gate.Namespace
+			if namespace == "" {
+				namespace = api.DefaultNamespace
+			}
+			gates[i+1] = fmt.Sprintf("%s|%s", namespace, gate.JobID)
+		}
+		c.Ui.Output(c.Colorize().Color("\n[bold]Readiness Gates[reset]"))
+		c.Ui.Output(formatList(gates))
+	}
+
+	if portConfig := pool.PortConfiguration; portConfig != nil {
+		portConfigOut := []string{
+			fmt.Sprintf("Min Dynamic Port|%s", formatNodePoolPort(portConfig.MinDynamicPort)),
+			fmt.Sprintf("Max Dynamic Port|%s", formatNodePoolPort(portConfig.MaxDynamicPort)),
+			fmt.Sprintf("Reserved Ports|%s", portConfig.ReservedPorts),
+		}
+		c.Ui.Output(c.Colorize().Color("\n[bold]Port Configuration[reset]"))
+		c.Ui.Output(formatKV(portConfigOut))
+	}
+
 	return 0
 }
+
+// formatNodePoolPort formats a port of the node pool port configuration,
+// which keeps the client configuration of the nodes when zero.
+func formatNodePoolPort(port int) string {
+	if port == 0 {
+		return "<client default>"
+	}
+	return strconv.Itoa(port)
+}
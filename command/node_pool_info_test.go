@@ -52,10 +52,13 @@ No scheduler configuration`
 	dev1JsonOutput := `
 {
     "Description": "Test pool",
+    "MaxJobFeatureLevel": "",
     "Meta": {
         "env": "test"
     },
     "Name": "dev-1",
+    "PortConfiguration": null,
+    "ReadinessGates": null,
     "SchedulerConfiguration": null
 }`
 
@@ -104,8 +104,11 @@ prod-1  <none>`,
 [
     {
         "Description": "",
+        "MaxJobFeatureLevel": "",
         "Meta": null,
         "Name": "prod-1",
+        "PortConfiguration": null,
+        "ReadinessGates": null,
         "SchedulerConfiguration": null
     }
 ]`,
@@ -22,6 +22,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/hashicorp/nomad/api/contexts"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/escapingfs"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	"github.com/hashicorp/nomad/version"
 	"github.com/posener/complete"
 )
@@ -45,6 +47,8 @@ type OperatorDebugCommand struct {
 	interval           time.Duration
 	pprofInterval      time.Duration
 	pprofDuration      time.Duration
+	schedulerTrace     bool
+	schedulerTraceDur  time.Duration
 	logLevel           string
 	logIncludeLocation bool
 	maxNodes           int
@@ -56,6 +60,7 @@ type OperatorDebugCommand struct {
 	consul             *external
 	vault              *external
 	manifest           []string
+	redactor           *redactor
 	ctx                context.Context
 	cancel             context.CancelFunc
 	opts               *api.QueryOptions
@@ -203,6 +208,18 @@ Debug Options:
     duration to capture a single snapshot. Defaults to 30s or
     -pprof-duration, whichever is more.
 
+  -scheduler-trace
+    Run a scheduler trace on each selected server. The trace records the time
+    each evaluation processed by the scheduler workers spent acquiring a state
+    snapshot, running the scheduler, submitting plans, and waiting for plan
+    results, along with a CPU profile of the server labeled by evaluation.
+    Periodic CPU profiles captured while the trace runs may fail. Defaults to
+    false.
+
+  -scheduler-trace-duration=<duration>
+    Duration of the scheduler trace. Defaults to 30s or -duration, whichever
+    is less. The maximum is 5m.
+
   -server-id=<server1>,<server2>
     Comma separated list of Nomad server names to monitor for logs, API
     outputs, and pprof profiles. Accepts server names, "leader", or "all".
@@ -213,6 +230,21 @@ Debug Options:
     the cluster is in an outage unable to establish leadership, it may be
     necessary to get the configuration from a non-leader server.
 
+  -redact=<profile|rules>
+    Redaction profile, or comma separated list of redaction rules, applied to
+    the API outputs written to the archive. The profiles are "none",
+    "default" (tokens) and "strict" (tokens, env, templates and meta). The
+    rules are "tokens" for secret IDs, tokens and passwords, "env" for
+    environment variable values, "templates" for embedded template contents
+    and "meta" for meta values. The values redacted are listed in
+    redactions.json. Logs and pprof profiles are not redacted. Defaults to
+    "default".
+
+  -redact-meta-key=<regexp>
+    Only redact the meta values whose key matches the regular expression.
+    Enables the "meta" redaction rule. Can be specified multiple times.
+    Defaults to all meta keys when the "meta" rule is enabled.
+
   -output=<path>
     Path to the parent directory of the output directory. If specified, no
     archive is built. Defaults to the current directory.
@@ -230,21 +262,25 @@ func (c *OperatorDebugCommand) Synopsis() string {
 func (c *OperatorDebugCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-duration":             complete.PredictAnything,
-			"-event-index":          complete.PredictAnything,
-			"-event-topic":          complete.PredictAnything,
-			"-interval":             complete.PredictAnything,
-			"-log-level":            complete.PredictSet("TRACE", "DEBUG", "INFO", "WARN", "ERROR"),
-			"-log-include-location": complete.PredictAnything,
-			"-max-nodes":            complete.PredictAnything,
-			"-node-class":           NodeClassPredictor(c.Client),
-			"-node-id":              NodePredictor(c.Client),
-			"-server-id":            ServerPredictor(c.Client),
-			"-output":               complete.PredictDirs("*"),
-			"-pprof-duration":       complete.PredictAnything,
-			"-consul-token":         complete.PredictAnything,
-			"-vault-token":          complete.PredictAnything,
-			"-verbose":              complete.PredictAnything,
+			"-duration":                 complete.PredictAnything,
+			"-event-index":              complete.PredictAnything,
+			"-event-topic":              complete.PredictAnything,
+			"-interval":                 complete.PredictAnything,
+			"-log-level":                complete.PredictSet("TRACE", "DEBUG", "INFO", "WARN", "ERROR"),
+			"-log-include-location":     complete.PredictAnything,
+			"-max-nodes":                complete.PredictAnything,
+			"-node-class":               NodeClassPredictor(c.Client),
+			"-node-id":                  NodePredictor(c.Client),
+			"-server-id":                ServerPredictor(c.Client),
+			"-output":                   complete.PredictDirs("*"),
+			"-pprof-duration":           complete.PredictAnything,
+			"-scheduler-trace":          complete.PredictNothing,
+			"-scheduler-trace-duration": complete.PredictAnything,
+			"-redact":                   complete.PredictSet("none", "default", "strict", "tokens", "env", "templates", "meta"),
+			"-redact-meta-key":          complete.PredictAnything,
+			"-consul-token":             complete.PredictAnything,
+			"-vault-token":              complete.PredictAnything,
+			"-verbose":                  complete.PredictAnything,
 		})
 }
 
@@ -355,8 +391,10 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 
 	var duration, interval, pprofInterval, output, pprofDuration, eventTopic string
+	var schedulerTraceDuration string
 	var eventIndex int64
-	var nodeIDs, serverIDs string
+	var nodeIDs, serverIDs, redact string
+	var redactMetaKeys flaghelper.StringFlag
 	var allowStale bool
 
 	flags.StringVar(&duration, "duration", "5m", "")
@@ -373,6 +411,10 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	flags.StringVar(&output, "output", "", "")
 	flags.StringVar(&pprofDuration, "pprof-duration", "1s", "")
 	flags.StringVar(&pprofInterval, "pprof-interval", "30s", "")
+	flags.BoolVar(&c.schedulerTrace, "scheduler-trace", false, "")
+	flags.StringVar(&schedulerTraceDuration, "scheduler-trace-duration", "30s", "")
+	flags.StringVar(&redact, "redact", "default", "")
+	flags.Var(&redactMetaKeys, "redact-meta-key", "")
 	flags.BoolVar(&c.verbose, "verbose", false, "")
 
 	c.consul = &external{tls: &api.TLSConfig{}}
@@ -444,6 +486,17 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	}
 	c.pprofInterval = pi
 
+	// Parse and clamp the scheduler trace duration
+	std, err := time.ParseDuration(schedulerTraceDuration)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing scheduler trace duration: %s: %s", schedulerTraceDuration, err.Error()))
+		return 1
+	}
+	if std.Seconds() > d.Seconds() {
+		std = d
+	}
+	c.schedulerTraceDur = std
+
 	// Parse event stream topic filter
 	t, err := topicsFromString(eventTopic)
 	if err != nil {
@@ -459,6 +512,13 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	}
 	c.index = uint64(eventIndex)
 
+	// Parse the redaction profile
+	c.redactor, err = newRedactor(redact, redactMetaKeys)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing redaction profile: %v", err))
+		return 1
+	}
+
 	// Verify there are no extra arguments
 	args = flags.Args()
 	if l := len(args); l != 0 {
@@ -657,9 +717,17 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	if c.pprofDuration.Seconds() != 1 {
 		c.Ui.Output(fmt.Sprintf("   pprof Duration: %s", c.pprofDuration))
 	}
+	if c.schedulerTrace {
+		c.Ui.Output(fmt.Sprintf("  Scheduler Trace: %s", c.schedulerTraceDur))
+	}
 	if c.topics != nil {
 		c.Ui.Output(fmt.Sprintf("     Event topics: %+v", c.topics))
 	}
+	if rules := c.redactor.rules(); len(rules) > 0 {
+		c.Ui.Output(fmt.Sprintf("        Redaction: %s", strings.Join(rules, ", ")))
+	} else {
+		c.Ui.Output("        Redaction: none")
+	}
 	c.Ui.Output("")
 	c.Ui.Output("Capturing cluster data...")
 
@@ -670,7 +738,8 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 		return 2
 	}
 
-	// Write index json/html manifest files
+	// Write the list of redacted values and the index json/html manifest files
+	c.writeRedactions()
 	c.writeManifest()
 
 	// Exit before archive if output directory was specified
@@ -726,8 +795,10 @@ func (c *OperatorDebugCommand) collect(client *api.Client) error {
 
 	c.collectAgentHosts(client)
 	c.collectPeriodicPprofs(client)
+	writeSchedulerTraces := c.startSchedulerTraces(client)
 
 	c.collectPeriodic(client)
+	writeSchedulerTraces()
 
 	return nil
 }
@@ -879,6 +950,7 @@ func (c *OperatorDebugCommand) captureEventStream(client *api.Client) error {
 					errCount++
 					mErrs = multierror.Append(mErrs, fmt.Errorf("failed to marshal json from Topic: %s, Type: %s, Err: %w", e.Topic, e.Type, err))
 				}
+				bytes = c.redactor.redactJSON(filepath.Join(path, "eventstream.json"), bytes)
 
 				n, err := fh.Write(bytes)
 				if err != nil {
@@ -1094,6 +1166,69 @@ func retrievePprofProfile(profile string, opts api.PprofOptions, client *api.Cli
 	return bs, err
 }
 
+// startSchedulerTraces runs a scheduler trace on each server in the
+// background. It returns a function that waits for the traces to finish and
+// writes them to the archive.
+func (c *OperatorDebugCommand) startSchedulerTraces(client *api.Client) func() {
+	if !c.schedulerTrace || len(c.serverIDs) == 0 {
+		return func() {}
+	}
+
+	c.Ui.Output(fmt.Sprintf("    Capture scheduler traces (%s)", c.schedulerTraceDur))
+
+	traces := make([]*api.SchedulerTrace, len(c.serverIDs))
+	errs := make([]error, len(c.serverIDs))
+	var wg sync.WaitGroup
+	for i, id := range c.serverIDs {
+		opts := api.PprofOptions{
+			ServerID: id,
+			Seconds:  int(c.schedulerTraceDur.Seconds()),
+		}
+		qopts := c.queryOpts()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			traces[i], errs[i] = client.Agent().SchedulerTrace(opts, qopts)
+		}()
+	}
+
+	return func() {
+		wg.Wait()
+		for i, id := range c.serverIDs {
+			c.writeSchedulerTrace(id, traces[i], errs[i])
+		}
+	}
+}
+
+// writeSchedulerTrace writes the evaluations and CPU profile of the scheduler
+// trace of a server to the archive
+func (c *OperatorDebugCommand) writeSchedulerTrace(id string, trace *api.SchedulerTrace, err error) {
+	path := filepath.Join(serverDir, id)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("%s: Failed to retrieve scheduler trace, err: %v", path, err))
+		if strings.Contains(err.Error(), api.PermissionDeniedErrorContent) {
+			c.Ui.Warn("Scheduler trace requires agent:write ACL or enable_debug=true.  See https://developer.hashicorp.com/nomad/api-docs/agent#agent-runtime-profiles for more information.")
+		}
+		c.reportErr(writeResponseOrErrorToFile(nil, err, c.newFile(path, "scheduler-trace.json")))
+		return
+	}
+
+	// The profile is written to its own file so it can be read by `go tool
+	// pprof`
+	profile := trace.Profile
+	trace.Profile = nil
+	c.reportErr(writeResponseOrErrorToFile(trace, nil, c.newFile(path, "scheduler-trace.json")))
+
+	if trace.ProfileError != "" {
+		c.Ui.Warn(fmt.Sprintf("%s: Failed to capture scheduler trace CPU profile, err: %s", path, trace.ProfileError))
+		return
+	}
+	if err := c.writeBytes(path, "scheduler-trace.prof", profile); err != nil {
+		c.Ui.Error(err.Error())
+	}
+}
+
 // collectPeriodic runs for duration, capturing the cluster state
 // every interval. It flushes and stops the monitor requests
 func (c *OperatorDebugCommand) collectPeriodic(client *api.Client) {
@@ -1364,14 +1499,30 @@ func (c *OperatorDebugCommand) newFile(dir, file string) writerGetter {
 			return nil, err
 		}
 
-		writer, err := os.Create(filePath)
+		fh, err := os.Create(filePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create file %q: %w", filePath, err)
 		}
-		return writer, nil
+		return &debugFile{
+			File:     fh,
+			name:     filepath.Join(dir, helper.CleanFilename(file, "_")),
+			redactor: c.redactor,
+		}, nil
 	}
 }
 
+// debugFile is a file of the archive that redacts the JSON documents written
+// with writeJSON.
+type debugFile struct {
+	*os.File
+	name     string
+	redactor *redactor
+}
+
+func (f *debugFile) redactJSON(buf []byte) []byte {
+	return f.redactor.redactJSON(f.name, buf)
+}
+
 // writeResponseToFile writes a response object to a file. It returns an error
 // that the caller should report to the UI.
 func writeResponseToFile(obj any, getWriterFn writerGetter) error {
@@ -1444,6 +1595,9 @@ func writeJSON(obj any, writer io.Writer) error {
 			return fmt.Errorf("could not serialize our own error: %v", err)
 		}
 	}
+	if f, ok := writer.(*debugFile); ok {
+		buf = f.redactJSON(buf)
+	}
 	n, err := writer.Write(buf)
 	if err != nil {
 		return fmt.Errorf("write error, wrote %d bytes of %d: %v", n, len(buf), err)
@@ -1501,6 +1655,7 @@ func (c *OperatorDebugCommand) writeBody(dir, file string, resp *http.Response,
 		return
 	}
 
+	body = c.redactor.redactJSON(filepath.Join(dir, helper.CleanFilename(file, "_")), body)
 	if err := c.writeBytes(dir, file, body); err != nil {
 		c.Ui.Error(err.Error())
 	}
@@ -1526,25 +1681,37 @@ func (c *OperatorDebugCommand) writeFlags(flags *flag.FlagSet) {
 	f.Actual = make(map[string]*flag.Flag)
 	f.Effective = make(map[string]*flag.Flag)
 	f.Args = flags.Args()
-	f.OsArgs = os.Args
+
+	// Tokens are redacted from the flags here rather than by the file writer
+	// as flag.Flag values do not have a field name to match.
+	file := filepath.Join(clusterDir, "cli-flags.json")
+	f.OsArgs = c.redactor.redactArgs(file, "OsArgs", os.Args)
 
 	// Formal flags (all flags)
 	flags.VisitAll(func(flagA *flag.Flag) {
-		f.Formal[flagA.Name] = flagA
+		f.Formal[flagA.Name] = c.redactor.redactFlag(file, "Formal", flagA)
 
 		// Determine which of thees are "effective" flags by comparing to empty string
 		if flagA.Value.String() != "" {
-			f.Effective[flagA.Name] = flagA
+			f.Effective[flagA.Name] = c.redactor.redactFlag(file, "Effective", flagA)
 		}
 	})
 	// Actual flags (everything passed on cmdline)
 	flags.Visit(func(flag *flag.Flag) {
-		f.Actual[flag.Name] = flag
+		f.Actual[flag.Name] = c.redactor.redactFlag(file, "Actual", flag)
 	})
 
 	c.reportErr(writeResponseToFile(f, c.newFile(clusterDir, "cli-flags.json")))
 }
 
+// writeRedactions writes the list of values redacted from the archive
+func (c *OperatorDebugCommand) writeRedactions() {
+	if c.redactor == nil {
+		return
+	}
+	c.reportErr(writeResponseToFile(c.redactor.manifest(), c.newFile("", redactionsFile)))
+}
+
 func (c *OperatorDebugCommand) reportErr(err error) {
 	if err != nil {
 		c.Ui.Error(err.Error())
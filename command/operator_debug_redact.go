@@ -0,0 +1,373 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// redactedValue replaces the values removed from the debug bundle
+	redactedValue = "<redacted>"
+
+	// Redaction rules that can be enabled with the -redact flag
+	redactTokens    = "tokens"
+	redactEnv       = "env"
+	redactTemplates = "templates"
+	redactMeta      = "meta"
+
+	// redactionsFile is the file listing what was redacted from the bundle
+	redactionsFile = "redactions.json"
+)
+
+var (
+	// redactProfiles are the named sets of redaction rules
+	redactProfiles = map[string][]string{
+		"none":    {},
+		"default": {redactTokens},
+		"strict":  {redactTokens, redactEnv, redactTemplates, redactMeta},
+	}
+
+	// redactTokenSuffixes are the suffixes of the normalized object keys
+	// whose values are redacted by the tokens rule
+	redactTokenSuffixes = []string{"token", "secretid", "secret", "password", "passwd", "privatekey"}
+)
+
+// redactor removes sensitive values from the JSON documents written to the
+// debug bundle, and records what was removed.
+type redactor struct {
+	tokens    bool
+	env       bool
+	templates bool
+	meta      bool
+	metaKeys  []*regexp.Regexp
+
+	lock       sync.Mutex
+	redactions map[redactionRecordKey]int
+}
+
+type redactionRecordKey struct {
+	File string
+	Path string
+	Rule string
+}
+
+// redactionRecord is an entry of the redactions file. Array indexes are
+// collapsed to [*] in Path, and Count is the number of values redacted at
+// that path.
+type redactionRecord struct {
+	File  string
+	Path  string
+	Rule  string
+	Count int
+}
+
+// redactionManifest is the content of the redactions file
+type redactionManifest struct {
+	Rules           []string
+	MetaKeyPatterns []string
+	Redactions      []redactionRecord
+}
+
+// newRedactor returns a redactor for the given comma separated list of
+// profile names and rules. Meta values are only redacted for keys matching
+// one of metaKeys, or all keys if metaKeys is empty. Passing metaKeys
+// enables the meta rule.
+func newRedactor(rules string, metaKeys []string) (*redactor, error) {
+	r := &redactor{
+		redactions: make(map[redactionRecordKey]int),
+	}
+
+	for _, rule := range stringToSlice(rules) {
+		expanded, ok := redactProfiles[rule]
+		if !ok {
+			expanded = []string{rule}
+		}
+		for _, rule := range expanded {
+			switch rule {
+			case redactTokens:
+				r.tokens = true
+			case redactEnv:
+				r.env = true
+			case redactTemplates:
+				r.templates = true
+			case redactMeta:
+				r.meta = true
+			default:
+				return nil, fmt.Errorf("unknown redaction profile or rule %q", rule)
+			}
+		}
+	}
+
+	for _, key := range metaKeys {
+		re, err := regexp.Compile(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid meta key pattern %q: %v", key, err)
+		}
+		r.metaKeys = append(r.metaKeys, re)
+		r.meta = true
+	}
+
+	return r, nil
+}
+
+// rules returns the names of the enabled redaction rules
+func (r *redactor) rules() []string {
+	rules := []string{}
+	if r == nil {
+		return rules
+	}
+	if r.tokens {
+		rules = append(rules, redactTokens)
+	}
+	if r.env {
+		rules = append(rules, redactEnv)
+	}
+	if r.templates {
+		rules = append(rules, redactTemplates)
+	}
+	if r.meta {
+		rules = append(rules, redactMeta)
+	}
+	return rules
+}
+
+// redactJSON returns the JSON document buf with the sensitive values of the
+// given bundle file replaced. Documents that cannot be parsed are returned
+// unmodified.
+func (r *redactor) redactJSON(file string, buf []byte) []byte {
+	if len(r.rules()) == 0 {
+		return buf
+	}
+
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return buf
+	}
+
+	if !r.redactValue(file, "", doc) {
+		return buf
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return buf
+	}
+	return out
+}
+
+// redactValue redacts the children of v in place and returns true if
+// anything was redacted.
+func (r *redactor) redactValue(file, path string, v any) bool {
+	redacted := false
+
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			switch {
+			case r.tokens && isTokenKey(key) && isNonEmptyString(child):
+				v[key] = redactedValue
+				r.record(file, childPath, redactTokens)
+				redacted = true
+
+			case r.templates && key == "EmbeddedTmpl" && isNonEmptyString(child):
+				v[key] = redactedValue
+				r.record(file, childPath, redactTemplates)
+				redacted = true
+
+			case r.env && (key == "Env" || key == "Environment"):
+				redacted = r.redactMap(file, childPath, redactEnv, child, nil) || redacted
+
+			case r.meta && (key == "Meta" || strings.HasSuffix(key, "Meta")):
+				redacted = r.redactMap(file, childPath, redactMeta, child, r.metaKeys) || redacted
+
+			default:
+				redacted = r.redactValue(file, childPath, child) || redacted
+			}
+		}
+
+	case []any:
+		for _, child := range v {
+			redacted = r.redactValue(file, path+"[*]", child) || redacted
+		}
+	}
+
+	return redacted
+}
+
+// redactMap redacts the string values of v, if it is an object, for the keys
+// matching one of patterns, or all keys if there are no patterns. Tokens are
+// still redacted from the values that are kept.
+func (r *redactor) redactMap(file, path, rule string, v any, patterns []*regexp.Regexp) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return r.redactValue(file, path, v)
+	}
+
+	redacted := false
+	for key, child := range m {
+		if !isNonEmptyString(child) {
+			continue
+		}
+
+		switch {
+		case matchesAny(key, patterns):
+			r.record(file, path+"."+key, rule)
+		case r.tokens && isTokenKey(key):
+			r.record(file, path+"."+key, redactTokens)
+		default:
+			continue
+		}
+		m[key] = redactedValue
+		redacted = true
+	}
+	return redacted
+}
+
+// matchesAny returns true if key matches one of patterns, or if there are no
+// patterns.
+func matchesAny(key string, patterns []*regexp.Regexp) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, re := range patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) record(file, path, rule string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.redactions[redactionRecordKey{File: file, Path: path, Rule: rule}]++
+}
+
+// manifest returns the list of redactions, sorted by file and path
+func (r *redactor) manifest() *redactionManifest {
+	m := &redactionManifest{
+		Rules:           r.rules(),
+		MetaKeyPatterns: []string{},
+		Redactions:      []redactionRecord{},
+	}
+	if r == nil {
+		return m
+	}
+
+	for _, re := range r.metaKeys {
+		m.MetaKeyPatterns = append(m.MetaKeyPatterns, re.String())
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, count := range r.redactions {
+		m.Redactions = append(m.Redactions, redactionRecord{
+			File:  k.File,
+			Path:  k.Path,
+			Rule:  k.Rule,
+			Count: count,
+		})
+	}
+	slices.SortFunc(m.Redactions, func(a, b redactionRecord) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(a.Rule, b.Rule),
+		)
+	})
+	return m
+}
+
+// redactFlag returns a copy of f with its values redacted if it holds a
+// token or credentials.
+func (r *redactor) redactFlag(file, path string, f *flag.Flag) *flag.Flag {
+	if r == nil || !r.tokens || !isSensitiveFlag(f.Name) || (f.Value.String() == "" && f.DefValue == "") {
+		return f
+	}
+
+	// The default value of some flags is read from the environment
+	redacted := &flag.Flag{
+		Name:     f.Name,
+		Usage:    f.Usage,
+		Value:    redactedFlagValue(f.Value.String()),
+		DefValue: f.DefValue,
+	}
+	if redacted.Value.String() != "" {
+		redacted.Value = redactedFlagValue(redactedValue)
+	}
+	if redacted.DefValue != "" {
+		redacted.DefValue = redactedValue
+	}
+	r.record(file, path+"."+f.Name, redactTokens)
+	return redacted
+}
+
+// redactArgs returns a copy of the command line arguments with the values of
+// sensitive flags redacted.
+func (r *redactor) redactArgs(file, path string, args []string) []string {
+	if r == nil || !r.tokens {
+		return args
+	}
+
+	out := slices.Clone(args)
+	for i := 0; i < len(out); i++ {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(out[i], "-"), "=")
+		if !strings.HasPrefix(out[i], "-") || !isSensitiveFlag(name) {
+			continue
+		}
+
+		if hasValue {
+			out[i] = out[i][:strings.Index(out[i], "=")+1] + redactedValue
+		} else if i+1 < len(out) {
+			i++
+			out[i] = redactedValue
+		}
+		r.record(file, path, redactTokens)
+	}
+	return out
+}
+
+// redactedFlagValue is the flag.Value of redacted flags
+type redactedFlagValue string
+
+func (v redactedFlagValue) String() string   { return string(v) }
+func (v redactedFlagValue) Set(string) error { return nil }
+
+// isTokenKey returns true if the values of the key hold tokens or passwords
+func isTokenKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, suffix := range redactTokenSuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitiveFlag returns true if the CLI flag holds a token or credentials
+func isSensitiveFlag(name string) bool {
+	return name == "consul-auth" || strings.HasSuffix(name, "token")
+}
+
+func isNonEmptyString(v any) bool {
+	s, ok := v.(string)
+	return ok && s != ""
+}
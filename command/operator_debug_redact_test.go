@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebug_Redactor_Rules(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		redact   string
+		metaKeys []string
+		expected []string
+		err      string
+	}{
+		{redact: "none", expected: []string{}},
+		{redact: "", expected: []string{}},
+		{redact: "default", expected: []string{"tokens"}},
+		{redact: "strict", expected: []string{"tokens", "env", "templates", "meta"}},
+		{redact: "env, templates", expected: []string{"env", "templates"}},
+		{redact: "none", metaKeys: []string{"^secret_"}, expected: []string{"meta"}},
+		{redact: "default,foo", err: `unknown redaction profile or rule "foo"`},
+		{redact: "default", metaKeys: []string{"("}, err: `invalid meta key pattern "("`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.redact, func(t *testing.T) {
+			r, err := newRedactor(tc.redact, tc.metaKeys)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, r.rules())
+		})
+	}
+}
+
+func TestDebug_Redactor_RedactJSON(t *testing.T) {
+	ci.Parallel(t)
+
+	doc := `{
+  "ID": "job",
+  "Meta": {"owner": "team", "secret_key": "hunter2"},
+  "VaultToken": "s.1234",
+  "Index": 12345678901234567890,
+  "TaskGroups": [{
+    "Tasks": [{
+      "Env": {"DB_PASSWORD": "p4ss", "PORT": "8080"},
+      "Meta": {"deploy_token": "abc"},
+      "Templates": [{"EmbeddedTmpl": "{{ key \"secret\" }}", "DestPath": "local/config"}]
+    }, {
+      "Env": {"USER": "nobody"},
+      "Templates": [{"EmbeddedTmpl": ""}]
+    }]
+  }],
+  "Token": {"SecretID": "d2f1", "AccessorID": "a1b2"},
+  "Empty": {"SecretID": ""}
+}`
+
+	cases := []struct {
+		name       string
+		redact     string
+		metaKeys   []string
+		expected   map[string]string
+		redactions []redactionRecord
+	}{
+		{
+			name:   "none",
+			redact: "none",
+		},
+		{
+			name:   "default",
+			redact: "default",
+			expected: map[string]string{
+				"VaultToken":                             redactedValue,
+				"Token.SecretID":                         redactedValue,
+				"Token.AccessorID":                       "a1b2",
+				"Meta.secret_key":                        "hunter2",
+				"TaskGroups.0.Tasks.0.Env.DB_PASSWORD":   redactedValue,
+				"TaskGroups.0.Tasks.0.Env.PORT":          "8080",
+				"TaskGroups.0.Tasks.0.Meta.deploy_token": redactedValue,
+			},
+			redactions: []redactionRecord{
+				{File: "test.json", Path: "TaskGroups[*].Tasks[*].Env.DB_PASSWORD", Rule: "tokens", Count: 1},
+				{File: "test.json", Path: "TaskGroups[*].Tasks[*].Meta.deploy_token", Rule: "tokens", Count: 1},
+				{File: "test.json", Path: "Token.SecretID", Rule: "tokens", Count: 1},
+				{File: "test.json", Path: "VaultToken", Rule: "tokens", Count: 1},
+			},
+		},
+		{
+			name:     "meta keys",
+			redact:   "env",
+			metaKeys: []string{"^secret_"},
+			expected: map[string]string{
+				"VaultToken":                             "s.1234",
+				"Meta.owner":                             "team",
+				"Meta.secret_key":                        redactedValue,
+				"TaskGroups.0.Tasks.0.Env.DB_PASSWORD":   redactedValue,
+				"TaskGroups.0.Tasks.0.Env.PORT":          redactedValue,
+				"TaskGroups.0.Tasks.1.Env.USER":          redactedValue,
+				"TaskGroups.0.Tasks.0.Meta.deploy_token": "abc",
+			},
+			redactions: []redactionRecord{
+				{File: "test.json", Path: "Meta.secret_key", Rule: "meta", Count: 1},
+				{File: "test.json", Path: "TaskGroups[*].Tasks[*].Env.DB_PASSWORD", Rule: "env", Count: 1},
+				{File: "test.json", Path: "TaskGroups[*].Tasks[*].Env.PORT", Rule: "env", Count: 1},
+				{File: "test.json", Path: "TaskGroups[*].Tasks[*].Env.USER", Rule: "env", Count: 1},
+			},
+		},
+		{
+			name:   "strict",
+			redact: "strict",
+			expected: map[string]string{
+				"Meta.owner":      redactedValue,
+				"Meta.secret_key": redactedValue,
+				"TaskGroups.0.Tasks.0.Templates.0.EmbeddedTmpl": redactedValue,
+				"TaskGroups.0.Tasks.0.Templates.0.DestPath":     "local/config",
+				"TaskGroups.0.Tasks.1.Templates.0.EmbeddedTmpl": "",
+				"Empty.SecretID": "",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := newRedactor(tc.redact, tc.metaKeys)
+			require.NoError(t, err)
+
+			out := r.redactJSON("test.json", []byte(doc))
+			if len(tc.expected) == 0 {
+				require.Equal(t, doc, string(out))
+				require.Empty(t, r.manifest().Redactions)
+				return
+			}
+
+			var result map[string]any
+			require.NoError(t, json.Unmarshal(out, &result))
+			for path, expected := range tc.expected {
+				require.Equal(t, expected, lookupJSONPath(t, result, path), path)
+			}
+
+			// Large numbers are preserved
+			require.Contains(t, string(out), `"Index":12345678901234567890`)
+
+			if tc.redactions != nil {
+				require.Equal(t, tc.redactions, r.manifest().Redactions)
+			}
+		})
+	}
+}
+
+func TestDebug_Redactor_RedactJSON_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	r, err := newRedactor("strict", nil)
+	require.NoError(t, err)
+
+	buf := []byte("not json")
+	require.Equal(t, buf, r.redactJSON("test.txt", buf))
+
+	// A nil redactor does not redact anything
+	var nilRedactor *redactor
+	buf = []byte(`{"SecretID":"d2f1"}`)
+	require.Equal(t, buf, nilRedactor.redactJSON("test.json", buf))
+	require.Empty(t, nilRedactor.manifest().Redactions)
+}
+
+func TestDebug_Redactor_Manifest(t *testing.T) {
+	ci.Parallel(t)
+
+	r, err := newRedactor("default", []string{"^aws_"})
+	require.NoError(t, err)
+
+	r.redactJSON("b.json", []byte(`[{"SecretID":"1"},{"SecretID":"2"}]`))
+	r.redactJSON("a.json", []byte(`{"Meta":{"aws_key":"k"}}`))
+	r.redactJSON("b.json", []byte(`[{"SecretID":"3"}]`))
+
+	m := r.manifest()
+	require.Equal(t, []string{"tokens", "meta"}, m.Rules)
+	require.Equal(t, []string{"^aws_"}, m.MetaKeyPatterns)
+	require.Equal(t, []redactionRecord{
+		{File: "a.json", Path: "Meta.aws_key", Rule: "meta", Count: 1},
+		{File: "b.json", Path: "[*].SecretID", Rule: "tokens", Count: 3},
+	}, m.Redactions)
+}
+
+func TestDebug_Redactor_Flags(t *testing.T) {
+	ci.Parallel(t)
+
+	r, err := newRedactor("default", nil)
+	require.NoError(t, err)
+
+	flags := flag.NewFlagSet("debug", flag.ContinueOnError)
+	var token, consulToken, consulTokenFile, address string
+	flags.StringVar(&token, "token", "", "")
+	flags.StringVar(&consulToken, "consul-token", "from-env", "")
+	flags.StringVar(&consulTokenFile, "consul-token-file", "", "")
+	flags.StringVar(&address, "address", "", "")
+	require.NoError(t, flags.Parse([]string{"-token=secret", "-consul-token-file", "/tmp/token", "-address", "http://localhost"}))
+
+	redacted := map[string]*flag.Flag{}
+	flags.VisitAll(func(f *flag.Flag) {
+		redacted[f.Name] = r.redactFlag("cli-flags.json", "Formal", f)
+	})
+	require.Equal(t, redactedValue, redacted["token"].Value.String())
+	require.Equal(t, "", redacted["token"].DefValue)
+	require.Equal(t, redactedValue, redacted["consul-token"].Value.String())
+	require.Equal(t, redactedValue, redacted["consul-token"].DefValue)
+	require.Equal(t, "/tmp/token", redacted["consul-token-file"].Value.String())
+	require.Equal(t, "http://localhost", redacted["address"].Value.String())
+
+	// The flags themselves are not modified
+	require.Equal(t, "secret", flags.Lookup("token").Value.String())
+
+	args := r.redactArgs("cli-flags.json", "OsArgs", []string{
+		"nomad", "operator", "debug", "-token=secret", "--consul-token", "secret",
+		"-consul-token-file", "/tmp/token", "-vault-token",
+	})
+	require.Equal(t, []string{
+		"nomad", "operator", "debug", "-token=" + redactedValue, "--consul-token", redactedValue,
+		"-consul-token-file", "/tmp/token", "-vault-token",
+	}, args)
+}
+
+func TestDebug_Redact(t *testing.T) {
+	srv, _, url := testServer(t, false, nil)
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorDebugCommand{Meta: Meta{Ui: ui}}
+	testDir := t.TempDir()
+
+	code := cmd.Run([]string{
+		"-address", url,
+		"-output", testDir,
+		"-duration", "250ms",
+		"-interval", "250ms",
+		"-server-id", "all",
+		"-node-id", "",
+		"-token", "d2f1a8a5-0ed2-4f54-9a4f-1a8e2e3b5f7a",
+		"-redact", "strict",
+	})
+	require.Empty(t, ui.ErrorWriter.String())
+	require.Equal(t, 0, code)
+	require.Contains(t, ui.OutputWriter.String(), "Redaction: tokens, env, templates, meta")
+
+	flags, err := os.ReadFile(cmd.path(clusterDir, "cli-flags.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(flags), "d2f1a8a5-0ed2-4f54-9a4f-1a8e2e3b5f7a")
+
+	buf, err := os.ReadFile(filepath.Join(cmd.collectDir, redactionsFile))
+	require.NoError(t, err)
+
+	var m redactionManifest
+	require.NoError(t, json.Unmarshal(buf, &m))
+	require.Equal(t, []string{"tokens", "env", "templates", "meta"}, m.Rules)
+	require.Contains(t, m.Redactions, redactionRecord{
+		File:  filepath.Join(clusterDir, "cli-flags.json"),
+		Path:  "Actual.token",
+		Rule:  "tokens",
+		Count: 1,
+	})
+
+	index, err := os.ReadFile(filepath.Join(cmd.collectDir, "index.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(index), redactionsFile)
+}
+
+// lookupJSONPath returns the value at the dot separated path of a decoded
+// JSON document, where array elements are selected by index.
+func lookupJSONPath(t *testing.T, doc any, path string) any {
+	t.Helper()
+
+	for _, key := range strings.Split(path, ".") {
+		switch v := doc.(type) {
+		case map[string]any:
+			doc = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			require.NoError(t, err, path)
+			require.Less(t, i, len(v), path)
+			doc = v[i]
+		default:
+			t.Fatalf("path %q not found", path)
+		}
+	}
+	return doc
+}
@@ -369,6 +369,18 @@ func TestDebug_Failures(t *testing.T) {
 			expectedCode:  1,
 			expectedError: "Error parsing pprof-interval: bar: time: invalid duration \"bar\"",
 		},
+		{
+			name:          "Fails bad redaction profile",
+			args:          []string{"-redact", "tokens,foo"},
+			expectedCode:  1,
+			expectedError: "Error parsing redaction profile: unknown redaction profile or rule \"foo\"",
+		},
+		{
+			name:          "Fails bad redaction meta key",
+			args:          []string{"-redact-meta-key", "("},
+			expectedCode:  1,
+			expectedError: "Error parsing redaction profile: invalid meta key pattern \"(\"",
+		},
 		{
 			name:          "Fails bad address",
 			args:          []string{"-address", url + "bogus"},
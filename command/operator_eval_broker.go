@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/hashicorp/cli"
+)
+
+// Ensure OperatorEvalBrokerCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorEvalBrokerCommand{}
+
+type OperatorEvalBrokerCommand struct {
+	Meta
+}
+
+func (o *OperatorEvalBrokerCommand) Help() string {
+	helpText := `
+Usage: nomad operator eval-broker <subcommand> [options]
+
+  This command groups subcommands for inspecting the eval broker of the
+  leader. The eval broker queues evaluations and hands them to the scheduler
+  workers of the servers.
+
+  Display the number of queued evaluations and the broker rates:
+
+      $ nomad operator eval-broker status
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorEvalBrokerCommand) Synopsis() string {
+	return "Provides access to the eval broker"
+}
+
+func (o *OperatorEvalBrokerCommand) Name() string { return "operator eval-broker" }
+
+func (o *OperatorEvalBrokerCommand) Run(_ []string) int { return cli.RunResultHelp }
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorEvalBrokerStatusCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorEvalBrokerStatusCommand{}
+
+type OperatorEvalBrokerStatusCommand struct {
+	Meta
+
+	json bool
+	tmpl string
+}
+
+func (o *OperatorEvalBrokerStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorEvalBrokerStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorEvalBrokerStatusCommand) Name() string { return "operator eval-broker status" }
+
+func (o *OperatorEvalBrokerStatusCommand) Run(args []string) int {
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		o.Ui.Error("This command takes no arguments")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	status, _, err := client.Operator().EvalBrokerStatus(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error querying eval broker: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, status)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	o.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Enabled|%v", status.Enabled),
+		fmt.Sprintf("Dequeue Mode|%s", status.DequeueMode),
+		fmt.Sprintf("Ready|%d", status.TotalReady),
+		fmt.Sprintf("Unacked|%d", status.TotalUnacked),
+		fmt.Sprintf("Pending|%d", status.TotalPending),
+		fmt.Sprintf("Waiting|%d", status.TotalWaiting),
+		fmt.Sprintf("Cancelable|%d", status.TotalCancelable),
+		fmt.Sprintf("Oldest Ready|%s", status.OldestReadyAge.Round(time.Millisecond)),
+		fmt.Sprintf("Oldest Pending|%s", status.OldestPendingAge.Round(time.Millisecond)),
+	}))
+
+	o.Ui.Output(o.Colorize().Color("\n[bold]Rates[reset]"))
+	o.Ui.Output(formatList([]string{
+		"Event|Last Minute|Total",
+		fmt.Sprintf("Dequeues|%d|%d", status.DequeuesPerMinute, status.TotalDequeues),
+		fmt.Sprintf("Nacks|%d|%d", status.NacksPerMinute, status.TotalNacks),
+		fmt.Sprintf("Requeues|%d|%d", status.RequeuesPerMinute, status.TotalRequeues),
+	}))
+
+	if len(status.ByScheduler) == 0 {
+		return 0
+	}
+
+	schedulers := make([]string, 0, len(status.ByScheduler))
+	for sched := range status.ByScheduler {
+		schedulers = append(schedulers, sched)
+	}
+	slices.Sort(schedulers)
+
+	rows := make([]string, 1, len(schedulers)+1)
+	rows[0] = "Scheduler|Ready|Unacked|Pending|Waiting|Oldest Ready|Oldest Pending"
+	for _, sched := range schedulers {
+		s := status.ByScheduler[sched]
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%d|%d|%s|%s",
+			sched,
+			s.Ready,
+			s.Unacked,
+			s.Pending,
+			s.Waiting,
+			s.OldestReadyAge.Round(time.Millisecond),
+			s.OldestPendingAge.Round(time.Millisecond),
+		))
+	}
+	o.Ui.Output(o.Colorize().Color("\n[bold]Evaluations by Scheduler[reset]"))
+	o.Ui.Output(formatList(rows))
+
+	return 0
+}
+
+func (o *OperatorEvalBrokerStatusCommand) Synopsis() string {
+	return "Display the status of the eval broker"
+}
+
+func (o *OperatorEvalBrokerStatusCommand) Help() string {
+	helpText := `
+Usage: nomad operator eval-broker status [options]
+
+  Displays the number of evaluations in each state of the eval broker of the
+  leader, by scheduler type, along with the age of the oldest evaluations and
+  the rate at which evaluations are dequeued, Nacked, and requeued. Ready
+  evaluations wait for a scheduler worker, unacked evaluations are being
+  processed, pending evaluations wait for another evaluation of the same job
+  to complete, and waiting evaluations wait for a delay to pass. A growing
+  number of ready evaluations means the scheduler workers cannot keep up,
+  while a high Nack rate means schedulers fail to process evaluations.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Eval Broker Status Options:
+
+  -json
+    Output the eval broker status in its JSON format.
+
+  -t
+    Format and display the eval broker status using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/hashicorp/cli"
+)
+
+// Ensure OperatorPlanQueueCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorPlanQueueCommand{}
+
+type OperatorPlanQueueCommand struct {
+	Meta
+}
+
+func (o *OperatorPlanQueueCommand) Help() string {
+	helpText := `
+Usage: nomad operator plan-queue <subcommand> [options]
+
+  This command groups subcommands for inspecting the plan queue of the
+  leader. Schedulers submit their plans to this queue, and the leader
+  evaluates and commits them one at a time.
+
+  Display the plans waiting in the queue and the queue wait times:
+
+      $ nomad operator plan-queue status
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorPlanQueueCommand) Synopsis() string {
+	return "Provides access to the plan queue"
+}
+
+func (o *OperatorPlanQueueCommand) Name() string { return "operator plan-queue" }
+
+func (o *OperatorPlanQueueCommand) Run(_ []string) int { return cli.RunResultHelp }
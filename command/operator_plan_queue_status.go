@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorPlanQueueStatusCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorPlanQueueStatusCommand{}
+
+type OperatorPlanQueueStatusCommand struct {
+	Meta
+
+	json bool
+	tmpl string
+}
+
+func (o *OperatorPlanQueueStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		},
+	)
+}
+
+func (o *OperatorPlanQueueStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorPlanQueueStatusCommand) Name() string { return "operator plan-queue status" }
+
+func (o *OperatorPlanQueueStatusCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		o.Ui.Error("This command takes no arguments")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	status, _, err := client.Operator().PlanQueueStatus(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error querying plan queue: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, status)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	o.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Enabled|%v", status.Enabled),
+		fmt.Sprintf("Pending Plans|%d", status.Depth),
+	}))
+
+	wait := status.WaitTime
+	o.Ui.Output(o.Colorize().Color("\n[bold]Queue Wait Time[reset]"))
+	o.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Samples|%d", wait.Samples),
+		fmt.Sprintf("P50|%s", wait.P50),
+		fmt.Sprintf("P90|%s", wait.P90),
+		fmt.Sprintf("P99|%s", wait.P99),
+		fmt.Sprintf("Max|%s", wait.Max),
+	}))
+
+	if len(status.DepthByPriority) == 0 {
+		return 0
+	}
+
+	priorities := make([]int, 0, len(status.DepthByPriority))
+	for priority := range status.DepthByPriority {
+		priorities = append(priorities, priority)
+	}
+	slices.Sort(priorities)
+	slices.Reverse(priorities)
+
+	depths := make([]string, 1, len(priorities)+1)
+	depths[0] = "Priority|Pending"
+	for _, priority := range priorities {
+		depths = append(depths, fmt.Sprintf("%d|%d", priority, status.DepthByPriority[priority]))
+	}
+	o.Ui.Output(o.Colorize().Color("\n[bold]Pending Plans by Priority[reset]"))
+	o.Ui.Output(formatList(depths))
+
+	pending := make([]string, 1, len(status.Pending)+1)
+	pending[0] = "Eval ID|Namespace|Job ID|Priority|Effective Priority|Enqueued|Waiting"
+	for _, plan := range status.Pending {
+		pending = append(pending, fmt.Sprintf("%s|%s|%s|%d|%d|%s|%s",
+			limit(plan.EvalID, length),
+			plan.Namespace,
+			plan.JobID,
+			plan.Priority,
+			plan.EffectivePriority,
+			formatTime(plan.EnqueueTime),
+			plan.WaitTime.Round(time.Millisecond),
+		))
+	}
+	o.Ui.Output(o.Colorize().Color("\n[bold]Pending Plans[reset]"))
+	o.Ui.Output(formatList(pending))
+
+	return 0
+}
+
+func (o *OperatorPlanQueueStatusCommand) Synopsis() string {
+	return "Display the status of the plan queue"
+}
+
+func (o *OperatorPlanQueueStatusCommand) Help() string {
+	helpText := `
+Usage: nomad operator plan-queue status [options]
+
+  Displays the plans waiting in the plan queue of the leader, the number of
+  pending plans per job priority, and the distribution of the time recently
+  evaluated plans spent waiting in the queue. A high queue wait time means
+  scheduling latency comes from plans waiting to be evaluated rather than
+  from the evaluation or the Raft commit of each plan.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Plan Queue Status Options:
+
+  -json
+    Output the plan queue status in its JSON format.
+
+  -t
+    Format and display the plan queue status using a Go template.
+
+  -verbose
+    Display full evaluation IDs.
+`
+
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorPlanQueueStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorPlanQueueStatusCommand{}
+}
+
+func TestOperatorPlanQueueStatusCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	ui := cli.NewMockUi()
+	c := &OperatorPlanQueueStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Run the command, so we get the default output and test this.
+	must.Zero(t, c.Run([]string{"-address=" + addr}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "Enabled       = true")
+	must.StrContains(t, s, "Pending Plans = 0")
+	must.StrContains(t, s, "Queue Wait Time")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Request JSON output and test.
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-json"}))
+	var js api.PlanQueueStatus
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &js))
+	must.True(t, js.Enabled)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Request a template output and test.
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-t={{.Depth}}"}))
+	must.StrContains(t, ui.OutputWriter.String(), "0")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Test an unexpected argument.
+	must.One(t, c.Run([]string{"-address=" + addr, "foo"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
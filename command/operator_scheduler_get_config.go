@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
 
@@ -75,6 +76,10 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 	}
 
 	schedConfig := resp.SchedulerConfig
+	dequeueMode := schedConfig.EvalBrokerDequeueMode
+	if dequeueMode == "" {
+		dequeueMode = api.EvalBrokerDequeueModePriority
+	}
 
 	// Output the information.
 	o.Ui.Output(formatKV([]string{
@@ -86,6 +91,9 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Plan Evaluate Pool Min|%v", schedConfig.PlanEvaluatePoolMin),
+		fmt.Sprintf("Plan Evaluate Pool Max|%v", schedConfig.PlanEvaluatePoolMax),
+		fmt.Sprintf("Eval Broker Dequeue Mode|%s", dequeueMode),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
 	return 0
@@ -31,6 +31,9 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	planEvaluatePoolMin      int
+	planEvaluatePoolMax      int
+	evalBrokerDequeueMode    string
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -48,6 +51,13 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-plan-evaluate-pool-min":     complete.PredictAnything,
+			"-plan-evaluate-pool-max":     complete.PredictAnything,
+			"-eval-broker-dequeue-mode": complete.PredictSet(
+				string(api.EvalBrokerDequeueModePriority),
+				string(api.EvalBrokerDequeueModeNamespaceFair),
+				string(api.EvalBrokerDequeueModePriorityFair),
+			),
 		},
 	)
 }
@@ -72,6 +82,9 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.IntVar(&o.planEvaluatePoolMin, "plan-evaluate-pool-min", -1, "")
+	flags.IntVar(&o.planEvaluatePoolMax, "plan-evaluate-pool-max", -1, "")
+	flags.StringVar(&o.evalBrokerDequeueMode, "eval-broker-dequeue-mode", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -134,6 +147,15 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	if o.planEvaluatePoolMin >= 0 {
+		schedulerConfig.PlanEvaluatePoolMin = o.planEvaluatePoolMin
+	}
+	if o.planEvaluatePoolMax >= 0 {
+		schedulerConfig.PlanEvaluatePoolMax = o.planEvaluatePoolMax
+	}
+	if o.evalBrokerDequeueMode != "" {
+		schedulerConfig.EvalBrokerDequeueMode = api.EvalBrokerDequeueMode(o.evalBrokerDequeueMode)
+	}
 
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
@@ -208,6 +230,23 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -plan-evaluate-pool-min=<count>
+    Specifies the minimum number of workers used by the leader to evaluate
+    plans. A value of 0 uses the plan_evaluate_pool_min server option.
+
+  -plan-evaluate-pool-max=<count>
+    Specifies the maximum number of workers used by the leader to evaluate
+    plans. The pool grows up to this size with the plan queue depth and the
+    number of nodes in each plan. A value of 0 uses the plan_evaluate_pool_max
+    server option.
+
+  -eval-broker-dequeue-mode=["priority"|"namespace_fair"|"priority_fair"]
+    Specifies the order in which the eval broker hands evaluations to the
+    scheduler workers. The "priority" mode dequeues the highest priority
+    evaluations first. The "namespace_fair" mode shares the workers evenly
+    between namespaces, and the "priority_fair" mode shares the workers
+    between job priorities in proportion to the priority.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -51,6 +51,9 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-preempt-service-scheduler=true",
 		"-preempt-sysbatch-scheduler=true",
 		"-preempt-system-scheduler=false",
+		"-plan-evaluate-pool-min=2",
+		"-plan-evaluate-pool-max=8",
+		"-eval-broker-dequeue-mode=namespace_fair",
 	}
 	must.Zero(t, c.Run(modifyingArgs))
 	s := ui.OutputWriter.String()
@@ -69,6 +72,9 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		MemoryOversubscriptionEnabled: true,
 		RejectJobRegistration:         true,
 		PauseEvalBroker:               true,
+		PlanEvaluatePoolMin:           2,
+		PlanEvaluatePoolMax:           8,
+		EvalBrokerDequeueMode:         api.EvalBrokerDequeueModeNamespaceFair,
 	}, modifiedConfig.SchedulerConfig)
 
 	ui.ErrorWriter.Reset()
@@ -108,4 +114,7 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	must.Eq(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	must.Eq(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	must.Eq(t, expected.PreemptionConfig, actual.PreemptionConfig)
+	must.Eq(t, expected.PlanEvaluatePoolMin, actual.PlanEvaluatePoolMin)
+	must.Eq(t, expected.PlanEvaluatePoolMax, actual.PlanEvaluatePoolMax)
+	must.Eq(t, expected.EvalBrokerDequeueMode, actual.EvalBrokerDequeueMode)
 }
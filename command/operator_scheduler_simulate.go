@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] <path>
+
+  Runs the scheduler for a job end-to-end, including the evaluation of the
+  resulting plan by the plan applier, without committing anything. The
+  simulation reports where allocations would be placed, which nodes were
+  filtered and why, and which allocations of other jobs would be preempted.
+
+  By default the simulation runs against the live state of the cluster. With
+  the -snapshot option it runs locally against the state of a snapshot file
+  instead, without contacting a Nomad agent. Local simulations do not apply
+  the server-side job mutations, such as implicit identities and Vault or
+  Consul constraints.
+
+  If the supplied path is "-", the jobfile is read from stdin. Otherwise
+  it is read from the file at the supplied path or downloaded and
+  read from URL specified.
+
+  When ACLs are enabled, simulating against the live state requires a token
+  with the 'operator:read' capability and the 'submit-job' capability for the
+  job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Scheduler Simulate Options:
+
+  -snapshot=<file>
+    Path to a snapshot file, as saved by "nomad operator snapshot save", to
+    run the simulation against instead of the live state.
+
+  -json
+    Parses the job file as JSON. If the outer object has a Job field, such as
+    from "nomad job inspect" or "nomad run -output", the value of the field is
+    used as the job.
+
+  -hcl2-strict
+    Whether an error should be produced from the HCL2 parser where a variable
+    has been supplied which is not defined within the root variables. Defaults
+    to true.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+  -verbose
+    Display the nodes filtered and the scores of the nodes evaluated for each
+    placement.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate scheduling a job without committing the results"
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-snapshot":    complete.PredictFiles("*.snap"),
+			"-json":        complete.PredictNothing,
+			"-hcl2-strict": complete.PredictNothing,
+			"-var":         complete.PredictAnything,
+			"-var-file":    complete.PredictFiles("*.var"),
+			"-verbose":     complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var snapshotPath string
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&snapshotPath, "snapshot", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flags.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	_, job, err := c.JobGetter.Get(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+
+	var simulation *api.SchedulerSimulation
+	if snapshotPath != "" {
+		simulation, err = c.simulateLocal(snapshotPath, job)
+	} else {
+		simulation, err = c.simulateRemote(job)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error running simulation: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(formatSchedulerSimulation(simulation, job, verbose)))
+	return 0
+}
+
+// simulateRemote runs the simulation on the servers against the live state.
+func (c *OperatorSchedulerSimulateCommand) simulateRemote(job *api.Job) (*api.SchedulerSimulation, error) {
+	client, err := c.Meta.Client()
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing client: %w", err)
+	}
+
+	// Force the region and namespace to be that of the job.
+	if r := job.Region; r != nil {
+		client.SetRegion(*r)
+	}
+	if n := job.Namespace; n != nil {
+		client.SetNamespace(*n)
+	}
+
+	simulation, _, err := client.Operator().SchedulerSimulate(job, nil)
+	return simulation, err
+}
+
+// simulateLocal runs the simulation against the state of a snapshot file
+// without talking to a Nomad agent.
+func (c *OperatorSchedulerSimulateCommand) simulateLocal(path string, aj *api.Job) (*api.SchedulerSimulation, error) {
+	job := agent.ApiJobToStructJob(aj)
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		return nil, fmt.Errorf("Job validation errors: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	_, state, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read archive file: %w", err)
+	}
+	snap, err := state.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	simulation, err := nomad.SimulateJob(hclog.NewNullLogger(), snap, job)
+	if err != nil {
+		return nil, err
+	}
+	simulation.Warnings = helper.MergeMultierrorWarnings(job.Warnings())
+
+	// Convert the simulation to its API representation, which has the same
+	// fields, so both modes share the same output.
+	buf, err := json.Marshal(simulation)
+	if err != nil {
+		return nil, err
+	}
+	var out api.SchedulerSimulation
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// formatSchedulerSimulation produces a string describing the outcome of a
+// scheduler simulation.
+func formatSchedulerSimulation(simulation *api.SchedulerSimulation, job *api.Job, verbose bool) string {
+	var out strings.Builder
+
+	if simulation.Warnings != "" {
+		out.WriteString(fmt.Sprintf("[bold][yellow]Job Warnings:\n%s[reset]\n\n", simulation.Warnings))
+	}
+
+	out.WriteString("[bold]Placements[reset]\n")
+	if len(simulation.Placements) == 0 {
+		out.WriteString("No allocations would be placed\n")
+	} else {
+		placements := make([]string, len(simulation.Placements)+1)
+		placements[0] = "Name|Task Group|Node ID|Node Name"
+		for i, alloc := range simulation.Placements {
+			placements[i+1] = fmt.Sprintf("%s|%s|%s|%s",
+				alloc.Name, alloc.TaskGroup, limit(alloc.NodeID, shortId), alloc.NodeName)
+		}
+		out.WriteString(formatList(placements) + "\n")
+
+		if verbose {
+			for _, alloc := range simulation.Placements {
+				if alloc.Metrics == nil {
+					continue
+				}
+				out.WriteString(fmt.Sprintf("\n[bold]Placement Metrics for %q[reset]\n", alloc.Name))
+				out.WriteString(strings.TrimSuffix(formatAllocMetrics(alloc.Metrics, true, "  "), "\n") + "\n")
+			}
+		}
+	}
+
+	if len(simulation.Stops) > 0 {
+		out.WriteString("\n[bold]Stops[reset]\n")
+		out.WriteString(formatSimulatedAllocations(simulation.Stops) + "\n")
+	}
+
+	if len(simulation.Preemptions) > 0 {
+		out.WriteString("\n[bold][yellow]Preemptions[reset]\n")
+		out.WriteString(formatSimulatedAllocations(simulation.Preemptions) + "\n")
+	}
+
+	if len(simulation.NodeRejections) > 0 {
+		nodeIDs := make([]string, 0, len(simulation.NodeRejections))
+		for nodeID := range simulation.NodeRejections {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		sort.Strings(nodeIDs)
+
+		rejections := make([]string, len(nodeIDs)+1)
+		rejections[0] = "Node ID|Reason"
+		for i, nodeID := range nodeIDs {
+			rejections[i+1] = fmt.Sprintf("%s|%s", limit(nodeID, shortId), simulation.NodeRejections[nodeID])
+		}
+		out.WriteString("\n[bold][yellow]Plan Rejections[reset]\n")
+		out.WriteString(formatList(rejections) + "\n")
+	}
+
+	out.WriteString("\n")
+	out.WriteString(formatDryRun(&api.JobPlanResponse{FailedTGAllocs: simulation.FailedTGAllocs}, job))
+	return out.String()
+}
+
+// formatSimulatedAllocations formats stopped or preempted allocations.
+func formatSimulatedAllocations(allocs []*api.SimulatedAllocation) string {
+	rows := make([]string, len(allocs)+1)
+	rows[0] = "Alloc ID|Namespace|Job ID|Task Group|Node ID|Node Name"
+	for i, alloc := range allocs {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			limit(alloc.ID, shortId), alloc.Namespace, alloc.JobID,
+			alloc.TaskGroup, limit(alloc.NodeID, shortId), alloc.NodeName)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{"some", "bad", "args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails when the job file does not exist
+	must.One(t, cmd.Run([]string{"/unicorns/leprechauns"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error getting job struct")
+	ui.ErrorWriter.Reset()
+
+	// Fails when the snapshot file does not exist
+	must.One(t, cmd.Run([]string{"-snapshot=/unicorns/leprechauns.snap", "testdata/example-basic.nomad"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error opening snapshot file")
+}
+
+func TestFormatSchedulerSimulation(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &api.Job{Type: pointer.Of("service")}
+	simulation := &api.SchedulerSimulation{
+		Placements: []*api.SimulatedAllocation{{
+			Name:      "example.web[0]",
+			TaskGroup: "web",
+			NodeID:    "f1e2d3c4-0000-0000-0000-000000000000",
+			NodeName:  "node-1",
+			Metrics:   &api.AllocationMetric{NodesEvaluated: 2, NodesFiltered: 1},
+		}},
+		Preemptions: []*api.SimulatedAllocation{{
+			ID:        "a1b2c3d4-0000-0000-0000-000000000000",
+			Namespace: "default",
+			JobID:     "batch",
+			TaskGroup: "worker",
+			NodeID:    "f1e2d3c4-0000-0000-0000-000000000000",
+			NodeName:  "node-1",
+		}},
+		FailedTGAllocs: map[string]*api.AllocationMetric{
+			"db": {
+				NodesEvaluated:     2,
+				NodesFiltered:      2,
+				ConstraintFiltered: map[string]int{"${attr.kernel.name} = windows": 2},
+			},
+		},
+		NodeRejections: map[string]string{
+			"f1e2d3c4-0000-0000-0000-000000000000": "resources exhausted",
+		},
+	}
+
+	out := formatSchedulerSimulation(simulation, job, false)
+	must.StrContains(t, out, "example.web[0]")
+	must.StrContains(t, out, "node-1")
+	must.StrContains(t, out, "Preemptions")
+	must.StrContains(t, out, "a1b2c3d4")
+	must.StrContains(t, out, "resources exhausted")
+	must.StrContains(t, out, `Task Group "db" (failed to place 1 allocation)`)
+	must.StrContains(t, out, `Constraint "${attr.kernel.name} = windows": 2 nodes excluded by filter`)
+	must.StrNotContains(t, out, "Placement Metrics")
+
+	out = formatSchedulerSimulation(simulation, job, true)
+	must.StrContains(t, out, `Placement Metrics for "example.web[0]"`)
+}
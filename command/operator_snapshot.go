@@ -4,9 +4,12 @@
 package command
 
 import (
+	"flag"
 	"strings"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/posener/complete"
 )
 
 type OperatorSnapshotCommand struct {
@@ -28,6 +31,10 @@ Usage: nomad operator snapshot <subcommand> [options]
 
       $ nomad operator snapshot save backup.snap
 
+  Create a snapshot and upload it to an S3 bucket:
+
+      $ nomad operator snapshot save -storage s3://bucket/path backup.snap
+
   Inspect a snapshot:
 
       $ nomad operator snapshot inspect backup.snap
@@ -51,3 +58,36 @@ func (f *OperatorSnapshotCommand) Name() string { return "operator snapshot" }
 func (f *OperatorSnapshotCommand) Run(args []string) int {
 	return cli.RunResultHelp
 }
+
+// snapshotStorageOptionsUsage documents the flags registered by
+// snapshotStorageFlags.
+const snapshotStorageOptionsUsage = `
+  -storage-region
+    The region of the S3 bucket used with -storage. Defaults to the region of
+    the AWS environment or shared configuration.
+
+  -storage-endpoint
+    The address of an S3 compatible storage service used with -storage instead
+    of AWS S3.`
+
+// snapshotStorageFlags are the flags configuring the object storage used to
+// save and restore snapshots. AWS credentials are loaded from the default
+// credential chain.
+type snapshotStorageFlags struct {
+	url  string
+	opts snapshot.StorageOptions
+}
+
+func (s *snapshotStorageFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&s.url, "storage", "", "")
+	flags.StringVar(&s.opts.Region, "storage-region", "", "")
+	flags.StringVar(&s.opts.Endpoint, "storage-endpoint", "", "")
+}
+
+func (s *snapshotStorageFlags) autocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-storage":          complete.PredictAnything,
+		"-storage-region":   complete.PredictAnything,
+		"-storage-endpoint": complete.PredictAnything,
+	}
+}
@@ -4,11 +4,14 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/posener/complete"
 )
 
@@ -18,7 +21,7 @@ type OperatorSnapshotRestoreCommand struct {
 
 func (c *OperatorSnapshotRestoreCommand) Help() string {
 	helpText := `
-Usage: nomad operator snapshot restore [options] <file>
+Usage: nomad operator snapshot restore [options] [<file>]
 
   Restores an atomic, point-in-time snapshot of the state of the Nomad servers
   which includes jobs, nodes, allocations, periodic jobs, and ACLs.
@@ -35,14 +38,27 @@ Usage: nomad operator snapshot restore [options] <file>
 
     $ nomad operator snapshot restore backup.snap
 
+  To restore the snapshot "nomad/backup.snap" from the "backups" S3 bucket:
+
+    $ nomad operator snapshot restore -storage s3://backups/nomad/backup.snap
+
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Snapshot Restore Options:
+
+  -storage
+    The s3://bucket/path URL of the snapshot to restore instead of a local
+    file.
+` + snapshotStorageOptionsUsage
 	return strings.TrimSpace(helpText)
 }
 
 func (c *OperatorSnapshotRestoreCommand) AutocompleteFlags() complete.Flags {
-	return c.Meta.AutocompleteFlags(FlagSetClient)
+	var storage snapshotStorageFlags
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		storage.autocompleteFlags())
 }
 
 func (c *OperatorSnapshotRestoreCommand) AutocompleteArgs() complete.Predictor {
@@ -56,8 +72,11 @@ func (c *OperatorSnapshotRestoreCommand) Synopsis() string {
 func (c *OperatorSnapshotRestoreCommand) Name() string { return "operator snapshot restore" }
 
 func (c *OperatorSnapshotRestoreCommand) Run(args []string) int {
+	var storageFlags snapshotStorageFlags
+
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	storageFlags.register(flags)
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
@@ -66,16 +85,37 @@ func (c *OperatorSnapshotRestoreCommand) Run(args []string) int {
 
 	// Check for misuse
 	args = flags.Args()
-	if len(args) != 1 {
+	if storageFlags.url != "" {
+		if len(args) != 0 {
+			c.Ui.Error("This command takes no arguments when used with -storage")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+	} else if len(args) != 1 {
 		c.Ui.Error("This command takes one argument: <filename>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
 
-	snap, err := os.Open(args[0])
-	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %q", err))
-		return 1
+	var snap io.ReadCloser
+	if storageFlags.url != "" {
+		storage, err := snapshot.NewStorage(storageFlags.url, &storageFlags.opts)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error initializing snapshot storage: %v", err))
+			return 1
+		}
+		snap, err = storage.Download(context.Background())
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error downloading snapshot file: %v", err))
+			return 1
+		}
+	} else {
+		var err error
+		snap, err = os.Open(args[0])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %q", err))
+			return 1
+		}
 	}
 	defer snap.Close()
 
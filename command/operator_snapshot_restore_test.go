@@ -93,3 +93,21 @@ func TestOperatorSnapshotRestore_Fails(t *testing.T) {
 	must.One(t, code)
 	must.StrContains(t, ui.ErrorWriter.String(), "no such file")
 }
+
+func TestOperatorSnapshotRestore_Storage_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}}
+
+	// Fails when used with both a file and a storage URL
+	code := cmd.Run([]string{"-storage", "s3://backups/nomad/backup.snap", "backup.snap"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "no arguments when used with -storage")
+	ui.ErrorWriter.Reset()
+
+	// Fails on an unsupported storage URL
+	code = cmd.Run([]string{"-storage", "gs://backups/nomad/backup.snap"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "unsupported snapshot storage URL scheme")
+}
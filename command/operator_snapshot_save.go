@@ -4,14 +4,17 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/posener/complete"
 )
 
@@ -21,7 +24,7 @@ type OperatorSnapshotSaveCommand struct {
 
 func (c *OperatorSnapshotSaveCommand) Help() string {
 	helpText := `
-Usage: nomad operator snapshot save [options] <file>
+Usage: nomad operator snapshot save [options] [<file>]
 
   Retrieves an atomic, point-in-time snapshot of the state of the Nomad servers
   which includes jobs, nodes, allocations, periodic jobs, and ACLs.
@@ -42,6 +45,12 @@ Usage: nomad operator snapshot save [options] <file>
   leader is available. To target a specific server for a snapshot, you can run
   the 'nomad operator snapshot save' command on that specific server.
 
+  To create a snapshot and upload it to the "backups" S3 bucket as
+  "nomad/backup.snap", encrypted with a KMS key:
+
+    $ nomad operator snapshot save -storage s3://backups/nomad \
+        -storage-sse aws:kms -storage-kms-key-id alias/nomad backup.snap
+
 
 General Options:
 
@@ -59,15 +68,32 @@ Snapshot Save Options:
     The -stale option defaults to "false" which means the leader provides the
     result. If the cluster is in an outage state without a leader, you may need
     to set -stale to "true" to get the configuration from a non-leader server.
+
+  -storage
+    The s3://bucket/path URL to upload the snapshot to instead of writing it to
+    a local file. The snapshot is uploaded under the path with the file name.
+` + snapshotStorageOptionsUsage + `
+
+  -storage-sse
+    The server-side encryption of the uploaded snapshot, either "AES256" or
+    "aws:kms". Defaults to the default encryption of the bucket.
+
+  -storage-kms-key-id
+    The ID of the KMS key used to encrypt the uploaded snapshot with the
+    "aws:kms" server-side encryption. Defaults to the AWS managed key.
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *OperatorSnapshotSaveCommand) AutocompleteFlags() complete.Flags {
+	var storage snapshotStorageFlags
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-stale": complete.PredictAnything,
-		})
+			"-stale":              complete.PredictAnything,
+			"-redact":             complete.PredictNothing,
+			"-storage-sse":        complete.PredictSet(snapshot.StorageSSEAES256, snapshot.StorageSSEKMS),
+			"-storage-kms-key-id": complete.PredictAnything,
+		}, storage.autocompleteFlags())
 }
 
 func (c *OperatorSnapshotSaveCommand) AutocompleteArgs() complete.Predictor {
@@ -82,12 +108,16 @@ func (c *OperatorSnapshotSaveCommand) Name() string { return "operator snapshot
 
 func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 	var stale, redact bool
+	var storageFlags snapshotStorageFlags
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 
 	flags.BoolVar(&stale, "stale", false, "")
 	flags.BoolVar(&redact, "redact", false, "")
+	storageFlags.register(flags)
+	flags.StringVar(&storageFlags.opts.SSE, "storage-sse", "", "")
+	flags.StringVar(&storageFlags.opts.KMSKeyID, "storage-kms-key-id", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
@@ -110,7 +140,15 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		filename = args[0]
 	}
 
-	if _, err := os.Lstat(filename); err == nil {
+	var storage *snapshot.Storage
+	if storageFlags.url != "" {
+		var err error
+		storage, err = snapshot.NewStorage(storageFlags.url, &storageFlags.opts)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error initializing snapshot storage: %v", err))
+			return 1
+		}
+	} else if _, err := os.Lstat(filename); err == nil {
 		c.Ui.Error(fmt.Sprintf("Destination file already exists: %q", filename))
 		c.Ui.Error(commandErrorText(c))
 		return 1
@@ -126,7 +164,15 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		return 1
 	}
 
-	tmpFile, err := os.Create(filename + ".tmp")
+	var tmpFile *os.File
+	if storage != nil {
+		tmpFile, err = os.CreateTemp("", "nomad-snapshot-*.tmp")
+		if err == nil {
+			defer os.Remove(tmpFile.Name())
+		}
+	} else {
+		tmpFile, err = os.Create(filename + ".tmp")
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to create file: %v", err))
 		return 1
@@ -159,6 +205,22 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		}
 	}
 
+	if storage != nil {
+		if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read snapshot file: %v", err))
+			return 1
+		}
+
+		key := storage.Key(filepath.Base(filename))
+		if err := storage.Upload(context.Background(), key, tmpFile); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to upload snapshot file: %v", err))
+			return 1
+		}
+
+		c.Ui.Output(fmt.Sprintf("State file uploaded to %v", storage.URL(key)))
+		return 0
+	}
+
 	err = os.Rename(tmpFile.Name(), filename)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to finalize snapshot file: %v", err))
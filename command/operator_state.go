@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/hashicorp/cli"
+)
+
+// Ensure OperatorStateCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorStateCommand{}
+
+type OperatorStateCommand struct {
+	Meta
+}
+
+func (o *OperatorStateCommand) Help() string {
+	helpText := `
+Usage: nomad operator state <subcommand> [options]
+
+  This command groups subcommands for inspecting the state store of the
+  servers, which holds the jobs, allocations, evaluations, and other objects
+  of the cluster in memory.
+
+  Display the number of objects and their size in each table:
+
+      $ nomad operator state usage
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorStateCommand) Synopsis() string {
+	return "Provides access to the state store"
+}
+
+func (o *OperatorStateCommand) Name() string { return "operator state" }
+
+func (o *OperatorStateCommand) Run(_ []string) int { return cli.RunResultHelp }
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorStateUsageCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorStateUsageCommand{}
+
+type OperatorStateUsageCommand struct {
+	Meta
+
+	json bool
+	tmpl string
+}
+
+func (o *OperatorStateUsageCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorStateUsageCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorStateUsageCommand) Name() string { return "operator state usage" }
+
+func (o *OperatorStateUsageCommand) Run(args []string) int {
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		o.Ui.Error("This command takes no arguments")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	tables, _, err := client.Operator().StateUsage(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error querying state usage: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, tables)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	// Show the largest tables first, and skip empty ones.
+	slices.SortStableFunc(tables, func(a, b *api.StateTableUsage) int {
+		return cmp.Compare(b.SizeBytes, a.SizeBytes)
+	})
+
+	var totalObjects int
+	var totalSize int64
+	rows := []string{"Table|Objects|Size"}
+	for _, table := range tables {
+		totalObjects += table.Objects
+		totalSize += table.SizeBytes
+		if table.Objects == 0 {
+			continue
+		}
+		rows = append(rows, fmt.Sprintf("%s|%d|%s",
+			table.Name, table.Objects, humanize.IBytes(uint64(table.SizeBytes))))
+	}
+
+	o.Ui.Output(formatList(rows))
+	o.Ui.Output(fmt.Sprintf("\nTotal: %d objects, %s",
+		totalObjects, humanize.IBytes(uint64(totalSize))))
+
+	return 0
+}
+
+func (o *OperatorStateUsageCommand) Synopsis() string {
+	return "Display the number of objects and their size in each state table"
+}
+
+func (o *OperatorStateUsageCommand) Help() string {
+	helpText := `
+Usage: nomad operator state usage [options]
+
+  Displays the number of objects and their approximate size in each table of
+  the state store, largest first, to find which tables grow the memory usage
+  of the servers. Sizes are the size of the objects once encoded, and do not
+  account for the indexes of the tables. Empty tables are not displayed.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+State Usage Options:
+
+  -json
+    Output the state usage in its JSON format.
+
+  -t
+    Format and display the state usage using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorStateUsageCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorStateUsageCommand{}
+}
+
+func TestOperatorStateUsageCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	ui := cli.NewMockUi()
+	c := &OperatorStateUsageCommand{Meta: Meta{Ui: ui}}
+
+	// Run the command, so we get the default output and test this.
+	must.Zero(t, c.Run([]string{"-address=" + addr}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "Table")
+	must.StrContains(t, s, "namespaces")
+	must.StrContains(t, s, "Total:")
+	must.StrNotContains(t, s, "allocs")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Request JSON output and test.
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-json"}))
+	var js []*api.StateTableUsage
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &js))
+	must.SliceNotEmpty(t, js)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Request a template output and test.
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-t={{range .}}{{.Name}} {{end}}"}))
+	must.StrContains(t, ui.OutputWriter.String(), "allocs")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Test an unexpected argument.
+	must.One(t, c.Run([]string{"-address=" + addr, "foo"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
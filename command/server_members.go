@@ -37,7 +37,8 @@ Server Members Options:
 
   -verbose
     Show detailed information about each member. This dumps a raw set of tags
-    which shows more information than the default output format.
+    which shows more information than the default output format, and reports
+    whether the keyring of each server is ready.
 
  -json
     Output the latest information about each member in a JSON format.
@@ -178,7 +179,7 @@ func standardOutput(mem []*api.AgentMember, leaders map[string]string) []string
 func verboseOutput(mem []*api.AgentMember, leaders map[string]string) []string {
 	// Format the members list
 	members := make([]string, len(mem)+1)
-	members[0] = "Name|Address|Port|Status|Leader|Protocol|Raft Version|Build|Datacenter|Region|Tags"
+	members[0] = "Name|Address|Port|Status|Leader|Protocol|Raft Version|Build|Datacenter|Region|Keyring Ready|Tags"
 	for i, member := range mem {
 		// Format the tags
 		tagPairs := make([]string, 0, len(member.Tags))
@@ -187,7 +188,13 @@ func verboseOutput(mem []*api.AgentMember, leaders map[string]string) []string {
 		}
 		tags := strings.Join(tagPairs, ",")
 
-		members[i+1] = fmt.Sprintf("%s|%s|%d|%s|%t|%d|%s|%s|%s|%s|%s",
+		// Servers older than the keyring_ready tag don't report it
+		keyringReady := member.Tags["keyring_ready"]
+		if keyringReady == "" {
+			keyringReady = "<unknown>"
+		}
+
+		members[i+1] = fmt.Sprintf("%s|%s|%d|%s|%t|%d|%s|%s|%s|%s|%s|%s",
 			member.Name,
 			member.Addr,
 			member.Port,
@@ -198,6 +205,7 @@ func verboseOutput(mem []*api.AgentMember, leaders map[string]string) []string {
 			member.Tags["build"],
 			member.Tags["dc"],
 			member.Tags["region"],
+			keyringReady,
 			tags,
 		)
 	}
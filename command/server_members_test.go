@@ -50,6 +50,7 @@ func TestServerMembersCommand_Run(t *testing.T) {
 	must.Zero(t, code)
 
 	must.StrContains(t, ui.OutputWriter.String(), "Tags")
+	must.StrContains(t, ui.OutputWriter.String(), "Keyring Ready")
 
 	ui.OutputWriter.Reset()
 
@@ -31,6 +31,10 @@ Usage: nomad service <subcommand> [options]
 
       $ nomad service delete <service_name> <service_id>
 
+  Allow the workloads of a namespace to look up services of another namespace:
+
+      $ nomad service grant create -namespace <namespace> <service_name> <granted_namespace>
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+)
+
+type ServiceGrantCommand struct {
+	Meta
+}
+
+func (c *ServiceGrantCommand) Help() string {
+	helpText := `
+Usage: nomad service grant <subcommand> [options]
+
+  This command groups subcommands for interacting with service grants. Service
+  grants allow the workloads of a namespace to look up the Nomad services
+  registered in another namespace.
+
+  Allow the workloads of the "web" namespace to look up the "db" service of
+  the "data" namespace:
+
+      $ nomad service grant create -namespace data db web
+
+  List the service grants of the "data" namespace:
+
+      $ nomad service grant list -namespace data
+
+  Delete a service grant:
+
+      $ nomad service grant delete <grant_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceGrantCommand) Name() string { return "service grant" }
+
+func (c *ServiceGrantCommand) Synopsis() string { return "Interact with service grants" }
+
+func (c *ServiceGrantCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+func formatServiceGrants(grants []*api.ServiceGrant) string {
+	rows := make([]string, len(grants)+1)
+	rows[0] = "ID|Namespace|Service Name|Granted Namespace|Description"
+	for i, grant := range grants {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			grant.ID,
+			grant.Namespace,
+			grant.ServiceName,
+			grant.GrantedNamespace,
+			grant.Description,
+		)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ServiceGrantCreateCommand struct {
+	Meta
+}
+
+func (c *ServiceGrantCreateCommand) Help() string {
+	helpText := `
+Usage: nomad service grant create [options] <service_name> <granted_namespace>
+
+  Create is used to allow the workloads of the granted namespace to look up the
+  Nomad service registered in the namespace of the command. Use "*" as the
+  service name to expose all the services of the namespace. Creating a grant
+  that already exists updates its description.
+
+  When ACLs are enabled, this command requires a management token or a token
+  with the 'operator:write' policy.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Service Grant Create Options:
+
+  -description
+    Sets a human-friendly description for the service grant.
+
+  -json
+    Output the service grant in its JSON format.
+
+  -t
+    Format and display the service grant using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceGrantCreateCommand) Name() string { return "service grant create" }
+
+func (c *ServiceGrantCreateCommand) Synopsis() string {
+	return "Allow a namespace to look up services of another namespace"
+}
+
+func (c *ServiceGrantCreateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-description": complete.PredictAnything,
+			"-json":        complete.PredictNothing,
+			"-t":           complete.PredictAnything,
+		})
+}
+
+func (c *ServiceGrantCreateCommand) Run(args []string) int {
+	var (
+		json              bool
+		tmpl, description string
+	)
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&description, "description", "", "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if args = flags.Args(); len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <service_name> and <granted_namespace>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	namespace := c.Meta.namespace
+	if namespace == "" {
+		namespace = api.DefaultNamespace
+	}
+
+	grant, _, err := client.ServiceGrants().Upsert(&api.ServiceGrant{
+		Namespace:        namespace,
+		ServiceName:      args[0],
+		GrantedNamespace: args[1],
+		Description:      description,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating service grant: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, grant)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully created service grant %q", grant.ID))
+	return 0
+}
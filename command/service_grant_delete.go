@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ServiceGrantDeleteCommand struct {
+	Meta
+}
+
+func (c *ServiceGrantDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad service grant delete [options] <grant_id>
+
+  Delete is used to delete a service grant. Workloads of the granted namespace
+  can no longer look up the services exposed by the grant.
+
+  When ACLs are enabled, this command requires a management token or a token
+  with the 'operator:write' policy.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceGrantDeleteCommand) Name() string { return "service grant delete" }
+
+func (c *ServiceGrantDeleteCommand) Synopsis() string { return "Delete a service grant" }
+
+func (c *ServiceGrantDeleteCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{})
+}
+
+func (c *ServiceGrantDeleteCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if args = flags.Args(); len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <grant_id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.ServiceGrants().Delete(args[0], nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting service grant: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted service grant %q", args[0]))
+	return 0
+}
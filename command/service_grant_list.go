@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ServiceGrantListCommand struct {
+	Meta
+}
+
+func (c *ServiceGrantListCommand) Help() string {
+	helpText := `
+Usage: nomad service grant list [options]
+
+  List is used to list the service grants exposing the services of the
+  namespace of the command. Use the "*" namespace to list the service grants of
+  all namespaces.
+
+  When ACLs are enabled, this command requires a management token or a token
+  with the 'operator:read' policy.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Service Grant List Options:
+
+  -json
+    Output the service grants in their JSON format.
+
+  -t
+    Format and display the service grants using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ServiceGrantListCommand) Name() string { return "service grant list" }
+
+func (c *ServiceGrantListCommand) Synopsis() string { return "List service grants" }
+
+func (c *ServiceGrantListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *ServiceGrantListCommand) Run(args []string) int {
+	var (
+		json bool
+		tmpl string
+	)
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if args = flags.Args(); len(args) > 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	grants, _, err := client.ServiceGrants().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing service grants: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, grants)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(grants) == 0 {
+		c.Ui.Output("No service grants found")
+		return 0
+	}
+
+	c.Ui.Output(formatServiceGrants(grants))
+	return 0
+}
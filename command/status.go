@@ -5,9 +5,14 @@ package command
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
 	"github.com/posener/complete"
 )
@@ -123,37 +128,50 @@ func (c *StatusCommand) Run(args []string) int {
 		return 1
 	}
 
-	var match contexts.Context
+	var matches []statusMatch
 	exactMatches := 0
-	for ctx, vers := range res.Matches {
-		if len(vers) > 0 && vers[0] == id {
-			match = ctx
+	ctxs := slices.Sorted(maps.Keys(res.Matches))
+	for _, ctx := range ctxs {
+		if vers := res.Matches[ctx]; len(vers) > 0 && vers[0] == id {
+			matches = append(matches, statusMatch{context: ctx, id: id, name: id})
 			exactMatches++
 		}
 	}
 
-	if exactMatches > 1 {
-		c.logMultiMatchError(id, res.Matches)
-		return 1
-	} else if exactMatches == 0 {
-		matchCount := 0
-		for ctx, vers := range res.Matches {
-			l := len(vers)
-			if l == 1 {
-				match = ctx
-				matchCount++
+	if exactMatches == 0 {
+		for _, ctx := range ctxs {
+			for _, ver := range res.Matches[ctx] {
+				matches = append(matches, statusMatch{context: ctx, id: ver, name: ver})
 			}
+		}
+	}
 
-			// Only a single result should return, as this is a match against a full id
-			if matchCount > 1 || l > 1 {
-				c.logMultiMatchError(id, res.Matches)
-				return 1
-			}
+	// Resolve names across namespaces when the identifier isn't the prefix
+	// of any ID
+	if len(matches) == 0 {
+		matches, err = c.fuzzyMatches(client, id)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Unable to resolve ID: %q", id))
+			return 1
+		}
+	}
+
+	var match statusMatch
+	switch len(matches) {
+	case 0:
+		c.Ui.Error(fmt.Sprintf("Unable to resolve ID: %q", id))
+		return 1
+	case 1:
+		match = matches[0]
+	default:
+		var ok bool
+		if match, ok = c.selectMatch(id, matches); !ok {
+			return 1
 		}
 	}
 
 	var cmd cli.Command
-	switch match {
+	switch match.context {
 	case contexts.Evals:
 		cmd = &EvalStatusCommand{Meta: c.Meta}
 	case contexts.Nodes:
@@ -177,19 +195,125 @@ func (c *StatusCommand) Run(args []string) int {
 		return 1
 	}
 
-	return cmd.Run(argsCopy)
+	// Route to the status command with the resolved identifier, in the
+	// namespace it was found in
+	routedArgs := make([]string, 0, len(argsCopy)+1)
+	if match.namespace != "" {
+		routedArgs = append(routedArgs, "-namespace="+match.namespace)
+	}
+	routedArgs = append(routedArgs, argsCopy[:len(argsCopy)-1]...)
+	routedArgs = append(routedArgs, match.id)
+	return cmd.Run(routedArgs)
+}
+
+// statusMatch is a resource matching the identifier given to the status
+// command.
+type statusMatch struct {
+	context   contexts.Context
+	id        string // identifier passed to the routed status command
+	name      string // name displayed when disambiguating matches
+	namespace string // namespace of the resource, if found by name
+}
+
+// fuzzyStatusContexts are the types of resources that the status command
+// resolves by name.
+var fuzzyStatusContexts = []contexts.Context{
+	contexts.Jobs,
+	contexts.Allocs,
+	contexts.Nodes,
+	contexts.Deployments,
+}
+
+// fuzzyMatches resolves id by name using the fuzzy search API, across all the
+// namespaces the token can read unless a namespace was given.
+func (c *StatusCommand) fuzzyMatches(client *api.Client, id string) ([]statusMatch, error) {
+	q := &api.QueryOptions{Namespace: c.namespace}
+	if q.Namespace == "" && os.Getenv("NOMAD_NAMESPACE") == "" {
+		q.Namespace = "*"
+	}
+
+	res, _, err := client.Search().FuzzySearchOpts(&api.FuzzySearchRequest{
+		Text:     id,
+		Contexts: fuzzyStatusContexts,
+		Ranking:  api.FuzzyRankingScore,
+	}, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []statusMatch
+	for _, ctx := range fuzzyStatusContexts {
+		for _, m := range res.Matches[ctx] {
+			match := statusMatch{context: ctx, id: m.ID, name: m.ID}
+			switch ctx {
+			case contexts.Jobs, contexts.Allocs:
+				// scope is the namespace followed by the ID
+				if len(m.Scope) != 2 {
+					continue
+				}
+				match.namespace, match.id = m.Scope[0], m.Scope[1]
+			case contexts.Nodes:
+				if len(m.Scope) != 1 {
+					continue
+				}
+				match.id = m.Scope[0]
+			}
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// selectMatch asks the user to pick one of multiple matches when running in
+// a terminal, and otherwise logs the matches as an error.
+func (c *StatusCommand) selectMatch(id string, matches []statusMatch) (statusMatch, bool) {
+	if !isTty() {
+		c.logMultiMatchError(id, matches)
+		return statusMatch{}, false
+	}
+
+	c.Ui.Output(fmt.Sprintf("Multiple matches found for id %q:\n", id))
+	rows := make([]string, len(matches)+1)
+	rows[0] = "#|Type|Name|Namespace|ID"
+	for i, m := range matches {
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%s|%s",
+			i+1, m.context, m.name, m.namespace, limit(m.id, shortId))
+	}
+	c.Ui.Output(formatList(rows))
+
+	answer, err := c.Ui.Ask(fmt.Sprintf("\nSelect a match [1-%d]:", len(matches)))
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse answer: %v", err))
+		return statusMatch{}, false
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || i < 1 || i > len(matches) {
+		c.Ui.Error(fmt.Sprintf("Invalid selection %q", answer))
+		return statusMatch{}, false
+	}
+	return matches[i-1], true
 }
 
 // logMultiMatchError is used to log an error message when multiple matches are
 // found. The error message logged displays the matched IDs per context.
-func (c *StatusCommand) logMultiMatchError(id string, matches map[contexts.Context][]string) {
+func (c *StatusCommand) logMultiMatchError(id string, matches []statusMatch) {
 	c.Ui.Error(fmt.Sprintf("Multiple matches found for id %q", id))
-	for ctx, vers := range matches {
-		if len(vers) == 0 {
-			continue
+
+	byContext := make(map[contexts.Context][]string)
+	var ctxs []contexts.Context
+	for _, m := range matches {
+		if _, ok := byContext[m.context]; !ok {
+			ctxs = append(ctxs, m.context)
 		}
+		name := m.name
+		if m.namespace != "" {
+			name = fmt.Sprintf("%s (%s)", m.name, m.namespace)
+		}
+		byContext[m.context] = append(byContext[m.context], name)
+	}
 
+	for _, ctx := range ctxs {
 		c.Ui.Error(fmt.Sprintf("\n%s:", strings.Title(string(ctx))))
-		c.Ui.Error(strings.Join(vers, ", "))
+		c.Ui.Error(strings.Join(byContext[ctx], ", "))
 	}
 }
@@ -69,6 +69,41 @@ func TestStatusCommand_Run_JobStatus_MultiMatch(t *testing.T) {
 	ui.OutputWriter.Reset()
 }
 
+func TestStatusCommand_Run_JobStatus_ByName(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &StatusCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Create a job in another namespace whose ID doesn't share the prefix
+	state := srv.Agent.Server().State()
+	must.NoError(t, state.UpsertNamespaces(999, []*structs.Namespace{{Name: "team"}}))
+	j := mock.Job()
+	j.Namespace = "team"
+	j.Name = "billing-api"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, j))
+
+	code := cmd.Run([]string{"-address=" + url, "billing"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), j.ID)
+
+	// Ambiguous names are listed when not running in a terminal
+	j2 := mock.Job()
+	j2.Name = "billing-worker"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, j2))
+
+	ui.OutputWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, "billing"})
+	must.One(t, code)
+	out := ui.ErrorWriter.String()
+	must.StrContains(t, out, "Multiple matches found")
+	must.StrContains(t, out, "billing-api (team)")
+	must.StrContains(t, out, "billing-worker (default)")
+}
+
 func TestStatusCommand_Run_EvalStatus(t *testing.T) {
 	ci.Parallel(t)
 
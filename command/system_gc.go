@@ -20,11 +20,24 @@ Usage: nomad system gc [options]
 
   Initializes a garbage collection of jobs, evaluations, allocations, and nodes.
 
-  If ACLs are enabled, this option requires a management token.
+  If the -node flag is set, the garbage collection runs on the client node
+  instead, and removes the terminal allocations and the network namespaces,
+  veth pairs and iptables chains left behind by allocations that are not
+  running on the node anymore.
+
+  If ACLs are enabled, this option requires a management token, or a token
+  with the 'node:write' capability if the -node flag is set.
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+GC Options:
+
+  -node <node-id>
+    Run the garbage collection on the client node with the given ID or ID
+    prefix.
+`
 	return strings.TrimSpace(helpText)
 }
 
@@ -33,7 +46,10 @@ func (c *SystemGCCommand) Synopsis() string {
 }
 
 func (c *SystemGCCommand) AutocompleteFlags() complete.Flags {
-	return c.Meta.AutocompleteFlags(FlagSetClient)
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node": complete.PredictAnything,
+		})
 }
 
 func (c *SystemGCCommand) AutocompleteArgs() complete.Predictor {
@@ -43,8 +59,11 @@ func (c *SystemGCCommand) AutocompleteArgs() complete.Predictor {
 func (c *SystemGCCommand) Name() string { return "system gc" }
 
 func (c *SystemGCCommand) Run(args []string) int {
+	var nodeID string
+
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&nodeID, "node", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -53,6 +72,7 @@ func (c *SystemGCCommand) Run(args []string) int {
 	if args = flags.Args(); len(args) > 0 {
 		c.Ui.Error("This command takes no arguments")
 		c.Ui.Error(commandErrorText(c))
+		return 1
 	}
 
 	// Get the HTTP client
@@ -62,6 +82,20 @@ func (c *SystemGCCommand) Run(args []string) int {
 		return 1
 	}
 
+	if nodeID != "" {
+		nodeID, err = lookupNodeID(client.Nodes(), nodeID)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		if err := client.Nodes().GC(nodeID, nil); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error running node garbage-collection: %s", err))
+			return 1
+		}
+		return 0
+	}
+
 	if err := client.System().GarbageCollect(); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error running system garbage-collection: %s", err))
 		return 1
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
 )
 
 func TestSystemGCCommand_Implements(t *testing.T) {
@@ -29,3 +30,26 @@ func TestSystemGCCommand_Good(t *testing.T) {
 		t.Fatalf("expected exit 0, got: %d; %v", code, ui.ErrorWriter.String())
 	}
 }
+
+func TestSystemGCCommand_Node(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	waitForNodes(t, client)
+
+	nodes, _, err := client.Nodes().List(nil)
+	must.NoError(t, err)
+	must.Len(t, 1, nodes)
+
+	ui := cli.NewMockUi()
+	cmd := &SystemGCCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-node", nodes[0].ID[:8]})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	ui.ErrorWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, "-node", "12345678"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No node(s) with prefix")
+}
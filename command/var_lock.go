@@ -17,7 +17,13 @@ import (
 	"github.com/posener/complete"
 )
 
-const defaultMaxClientRetries = 5
+const (
+	defaultMaxClientRetries = 5
+
+	// lockFencingTokenEnv is the environment variable holding the fencing
+	// token of the lock in the child command.
+	lockFencingTokenEnv = "NOMAD_LOCK_FENCING_TOKEN"
+)
 
 type VarLockCommand struct {
 	shell     bool
@@ -85,6 +91,17 @@ Var lock Options:
   -shell
 	Optional, use a shell to run the command (can set a custom shell via		
 	the SHELL environment variable). The default value is true.
+
+  -steal
+	Optional, take the lock even if it is held by another process. The lock
+	of the current holder is revoked, and its child command is stopped the
+	next time it fails to renew the lock. Requires the 'variables:destroy'
+	capability in addition to 'variables:write'. Defaults to false.
+
+  The fencing token of the lock is passed to the child command in the
+  NOMAD_LOCK_FENCING_TOKEN environment variable. The token increases every
+  time the lock changes holder, so resources protected by the lock can reject
+  requests from previous holders.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -111,6 +128,7 @@ func (c *VarLockCommand) Run(args []string) int {
 	var path string
 	var maxRetry int64
 	var earlyReturn bool
+	var steal bool
 	var backoff time.Duration
 
 	flags := c.varPutCommand.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -123,6 +141,7 @@ func (c *VarLockCommand) Run(args []string) int {
 	flags.BoolVar(&earlyReturn, "early-return", false, "")
 	flags.Int64Var(&maxRetry, "max-retry", 5, "")
 	flags.DurationVar(&backoff, "backoff", 0, "")
+	flags.BoolVar(&steal, "steal", false, "")
 
 	if fileInfo, _ := os.Stdout.Stat(); (fileInfo.Mode() & os.ModeCharDevice) != 0 {
 		flags.StringVar(&c.varPutCommand.outFmt, "out", "none", "")
@@ -215,6 +234,11 @@ func (c *VarLockCommand) Run(args []string) int {
 		lo = append(lo, api.LocksOptionWithMaxRetries(maxRetry))
 	}
 
+	if steal {
+		c.varPutCommand.verbose("Stealing the lock")
+		lo = append(lo, api.LocksOptionWithSteal())
+	}
+
 	l, err := client.Locks(api.WriteOptions{}, *sv, lo...)
 	if err != nil {
 		c.varPutCommand.Ui.Error(fmt.Sprintf("Error initializing lock handler: %s", err))
@@ -259,6 +283,8 @@ func (c *VarLockCommand) Run(args []string) int {
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(),
+			fmt.Sprintf("%s=%d", lockFencingTokenEnv, l.FencingToken()))
 
 		signalCh := make(chan os.Signal, 10)
 		signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
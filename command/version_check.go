@@ -0,0 +1,462 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// versionCheckMaxMinorSkew is the number of minor releases agents can be
+	// apart and still work together, as described in the upgrade guide.
+	versionCheckMaxMinorSkew = 2
+
+	// versionCheckFeedTimeout is the timeout for fetching a remote feed.
+	versionCheckFeedTimeout = 10 * time.Second
+
+	versionCheckStatusOK          = "ok"
+	versionCheckStatusMixed       = "mixed"
+	versionCheckStatusUnsupported = "unsupported"
+)
+
+type VersionCheckCommand struct {
+	Meta
+}
+
+func (c *VersionCheckCommand) Help() string {
+	helpText := `
+Usage: nomad version check [options]
+
+  Compare the versions of the servers and clients of the cluster. The check
+  reports agents whose versions are too far apart to be supported, clients
+  running a newer version than the servers of their region, and optionally
+  agents running versions listed in a feed of known bad versions.
+
+  The command exits with 0 when no issues were found, 1 on error, and 2 when
+  issues were found.
+
+  If ACLs are enabled, this command requires a token with the 'node:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Version Check Options:
+
+  -feed=<path|url>
+    Path or HTTP(S) URL of a JSON feed of known bad versions. Each entry of
+    the "KnownBad" list has a "Versions" constraint, such as ">= 1.7.0, < 1.7.3",
+    and a "Reason" reported for the agents matching it.
+
+  -json
+    Output the result of the check in JSON format.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VersionCheckCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-feed": complete.PredictFiles("*.json"),
+			"-json": complete.PredictNothing,
+		})
+}
+
+func (c *VersionCheckCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VersionCheckCommand) Synopsis() string {
+	return "Check the versions of the agents of the cluster"
+}
+
+func (c *VersionCheckCommand) Name() string { return "version check" }
+
+func (c *VersionCheckCommand) Run(args []string) int {
+	var feedPath string
+	var jsonOutput bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&feedPath, "feed", "", "")
+	flags.BoolVar(&jsonOutput, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check for extra arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var feed *versionCheckFeed
+	if feedPath != "" {
+		var err error
+		feed, err = loadVersionCheckFeed(feedPath)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error loading feed: %s", err))
+			return 1
+		}
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	region := c.Meta.region
+	if region == "" {
+		region, err = client.Agent().Region()
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying agent region: %s", err))
+			return 1
+		}
+	}
+
+	srvMembers, err := client.Agent().Members()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying servers: %s", err))
+		return 1
+	}
+
+	nodes, _, err := client.Nodes().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying clients: %s", err))
+		return 1
+	}
+
+	report := checkVersions(srvMembers.Members, nodes, region, feed)
+
+	if jsonOutput {
+		out, err := Format(true, "", report)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+	} else {
+		c.Ui.Output(c.Colorize().Color(formatVersionCheckReport(report)))
+	}
+
+	if len(report.Issues) > 0 {
+		return 2
+	}
+	return 0
+}
+
+// versionCheckFeed is the format of the feed of known bad versions.
+type versionCheckFeed struct {
+	KnownBad []*versionCheckFeedEntry
+}
+
+// versionCheckFeedEntry is a range of known bad versions.
+type versionCheckFeedEntry struct {
+	// Versions is a version constraint matching the bad versions.
+	Versions string
+
+	// Reason is reported for the agents running a matching version.
+	Reason string
+
+	constraints version.Constraints
+}
+
+// loadVersionCheckFeed reads and parses the feed of known bad versions from
+// a local file or HTTP(S) URL.
+func loadVersionCheckFeed(path string) (*versionCheckFeed, error) {
+	var raw []byte
+	var err error
+
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		httpClient := defaultHttpClient()
+		httpClient.Timeout = versionCheckFeedTimeout
+
+		resp, err := httpClient.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected response code %d", resp.StatusCode)
+		}
+		raw, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		raw, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var feed versionCheckFeed
+	if err := json.Unmarshal(raw, &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+	for i, entry := range feed.KnownBad {
+		entry.constraints, err = version.NewConstraint(entry.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid versions for entry %d: %w", i, err)
+		}
+	}
+	return &feed, nil
+}
+
+// versionCheckReport is the result of the version check.
+type versionCheckReport struct {
+	// Status is "ok" when all agents run the same version, "mixed" when they
+	// run different but compatible versions, and "unsupported" when issues
+	// were found.
+	Status string
+
+	Region  string
+	Servers []*versionCheckAgent
+	Clients []*versionCheckAgent
+	Issues  []string
+}
+
+// versionCheckAgent is the version of a server or client.
+type versionCheckAgent struct {
+	Name    string
+	Region  string
+	Version string
+
+	version *version.Version
+}
+
+// checkVersions compares the versions of the servers of all the regions, and
+// of the clients of the given region against its servers.
+func checkVersions(members []*api.AgentMember, nodes []*api.NodeListStub,
+	region string, feed *versionCheckFeed) *versionCheckReport {
+
+	report := &versionCheckReport{Region: region}
+	issue := func(format string, a ...any) {
+		report.Issues = append(report.Issues, fmt.Sprintf(format, a...))
+	}
+	parse := func(kind, name, raw string) *version.Version {
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			issue("%s %q has an invalid version %q", kind, name, raw)
+			return nil
+		}
+		return v
+	}
+
+	regions := map[string][]*versionCheckAgent{}
+	for _, member := range members {
+		if member.Status == "left" {
+			continue
+		}
+		agent := &versionCheckAgent{
+			Name:    member.Name,
+			Region:  member.Tags["region"],
+			Version: member.Tags["build"],
+		}
+		agent.version = parse("server", agent.Name, agent.Version)
+		report.Servers = append(report.Servers, agent)
+		if agent.version != nil {
+			regions[agent.Region] = append(regions[agent.Region], agent)
+		}
+	}
+
+	for _, node := range nodes {
+		if node.Status == api.NodeStatusDown {
+			continue
+		}
+		agent := &versionCheckAgent{
+			Name:    node.Name,
+			Region:  region,
+			Version: node.Version,
+		}
+		agent.version = parse("client", agent.Name, agent.Version)
+		report.Clients = append(report.Clients, agent)
+	}
+
+	sortAgents := func(agents []*versionCheckAgent) {
+		sort.Slice(agents, func(i, j int) bool {
+			if agents[i].Region != agents[j].Region {
+				return agents[i].Region < agents[j].Region
+			}
+			return agents[i].Name < agents[j].Name
+		})
+	}
+	sortAgents(report.Servers)
+	sortAgents(report.Clients)
+
+	regionNames := make([]string, 0, len(regions))
+	for name := range regions {
+		regionNames = append(regionNames, name)
+	}
+	sort.Strings(regionNames)
+
+	// The servers of a region must be within the supported window of each
+	// other
+	for _, name := range regionNames {
+		oldest, newest := versionRange(regions[name])
+		if minorReleasesApart(oldest.version, newest.version) > versionCheckMaxMinorSkew {
+			issue("servers in region %q run versions %s and %s, which are more than %d minor releases apart",
+				name, oldest.Version, newest.Version, versionCheckMaxMinorSkew)
+		}
+	}
+
+	// Clients must not run a newer version than the servers of their region,
+	// and must be within the supported window of them
+	if servers := regions[region]; len(servers) > 0 {
+		oldest, newest := versionRange(servers)
+		for _, agent := range report.Clients {
+			if agent.version == nil {
+				continue
+			}
+			if agent.version.Core().GreaterThan(oldest.version.Core()) {
+				issue("client %q runs version %s, which is newer than server %q running %s",
+					agent.Name, agent.Version, oldest.Name, oldest.Version)
+			}
+			if minorReleasesApart(agent.version, newest.version) > versionCheckMaxMinorSkew {
+				issue("client %q runs version %s, which is more than %d minor releases behind server %q running %s",
+					agent.Name, agent.Version, versionCheckMaxMinorSkew, newest.Name, newest.Version)
+			}
+		}
+	}
+
+	if feed != nil {
+		check := func(kind string, agents []*versionCheckAgent) {
+			for _, agent := range agents {
+				if agent.version == nil {
+					continue
+				}
+				for _, entry := range feed.KnownBad {
+					if entry.constraints.Check(agent.version) {
+						issue("%s %q runs known bad version %s: %s",
+							kind, agent.Name, agent.Version, entry.Reason)
+					}
+				}
+			}
+		}
+		check("server", report.Servers)
+		check("client", report.Clients)
+	}
+
+	versions := map[string]struct{}{}
+	for _, agent := range slices.Concat(report.Servers, report.Clients) {
+		versions[agent.Version] = struct{}{}
+	}
+
+	switch {
+	case len(report.Issues) > 0:
+		report.Status = versionCheckStatusUnsupported
+	case len(versions) > 1:
+		report.Status = versionCheckStatusMixed
+	default:
+		report.Status = versionCheckStatusOK
+	}
+	return report
+}
+
+// versionRange returns the agents running the oldest and newest versions.
+func versionRange(agents []*versionCheckAgent) (oldest, newest *versionCheckAgent) {
+	for _, agent := range agents {
+		if oldest == nil || agent.version.LessThan(oldest.version) {
+			oldest = agent
+		}
+		if newest == nil || agent.version.GreaterThan(newest.version) {
+			newest = agent
+		}
+	}
+	return oldest, newest
+}
+
+// minorReleasesApart returns the number of minor releases between the two
+// versions. Versions with different major versions are never compatible.
+func minorReleasesApart(a, b *version.Version) int {
+	aSegments, bSegments := a.Segments(), b.Segments()
+	if aSegments[0] != bSegments[0] {
+		return versionCheckMaxMinorSkew + 1
+	}
+	diff := aSegments[1] - bSegments[1]
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff
+}
+
+func formatVersionCheckReport(report *versionCheckReport) string {
+	var out strings.Builder
+
+	out.WriteString(formatKV([]string{
+		fmt.Sprintf("Status|%s", report.Status),
+		fmt.Sprintf("Servers|%d (%s)", len(report.Servers), agentVersions(report.Servers)),
+		fmt.Sprintf("Clients|%d (%s)", len(report.Clients), agentVersions(report.Clients)),
+	}))
+
+	out.WriteString("\n\n")
+	out.WriteString("[bold]Servers[reset]")
+	servers := []string{"Name|Region|Version"}
+	for _, agent := range report.Servers {
+		servers = append(servers, fmt.Sprintf("%s|%s|%s", agent.Name, agent.Region, agent.Version))
+	}
+	out.WriteString("\n")
+	out.WriteString(formatList(servers))
+
+	if len(report.Clients) > 0 {
+		counts := map[string]int{}
+		for _, agent := range report.Clients {
+			counts[agent.Version]++
+		}
+		versions := make([]string, 0, len(counts))
+		for v := range counts {
+			versions = append(versions, v)
+		}
+		sort.Strings(versions)
+
+		clients := []string{"Version|Clients"}
+		for _, v := range versions {
+			clients = append(clients, fmt.Sprintf("%s|%d", v, counts[v]))
+		}
+		out.WriteString("\n\n")
+		out.WriteString(fmt.Sprintf("[bold]Clients in region %q[reset]", report.Region))
+		out.WriteString("\n")
+		out.WriteString(formatList(clients))
+	}
+
+	if len(report.Issues) > 0 {
+		out.WriteString("\n\n")
+		out.WriteString("[bold]Issues[reset]")
+		for _, issue := range report.Issues {
+			out.WriteString("\n  * ")
+			out.WriteString(issue)
+		}
+	}
+
+	return out.String()
+}
+
+// agentVersions returns the sorted list of distinct versions of the agents.
+func agentVersions(agents []*versionCheckAgent) string {
+	var versions []string
+	for _, agent := range agents {
+		if !slices.Contains(versions, agent.Version) {
+			versions = append(versions, agent.Version)
+		}
+	}
+	sort.Strings(versions)
+	return strings.Join(versions, ", ")
+}
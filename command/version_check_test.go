@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestVersionCheckCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VersionCheckCommand{}
+}
+
+func TestVersionCheckCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VersionCheckCommand{Meta: Meta{Ui: ui}}
+
+	// A single server always passes the check
+	code := cmd.Run([]string{"-address=" + url})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Status  = ok")
+	must.StrContains(t, out, srv.Agent.Server().LocalMember().Name)
+	ui.OutputWriter.Reset()
+
+	// Flag the version of the server as bad
+	build := srv.Agent.Server().LocalMember().Tags["build"]
+	feed := filepath.Join(t.TempDir(), "feed.json")
+	must.NoError(t, os.WriteFile(feed, []byte(`{
+  "KnownBad": [{"Versions": "= `+build+`", "Reason": "bad release"}]
+}`), 0o644))
+
+	code = cmd.Run([]string{"-address=" + url, "-json", "-feed=" + feed})
+	must.Eq(t, 2, code)
+
+	var report versionCheckReport
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &report))
+	must.Eq(t, versionCheckStatusUnsupported, report.Status)
+	must.Len(t, 1, report.Issues)
+	must.StrContains(t, report.Issues[0], "bad release")
+}
+
+func TestVersionCheckCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VersionCheckCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid feeds
+	feed := filepath.Join(t.TempDir(), "feed.json")
+	must.NoError(t, os.WriteFile(feed, []byte(`{"KnownBad": [{"Versions": "nope"}]}`), 0o644))
+	code = cmd.Run([]string{"-feed=" + feed})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "invalid versions for entry 0")
+}
+
+func TestVersionCheck_checkVersions(t *testing.T) {
+	ci.Parallel(t)
+
+	server := func(name, region, version string) *api.AgentMember {
+		return &api.AgentMember{
+			Name:   name,
+			Status: "alive",
+			Tags:   map[string]string{"region": region, "build": version},
+		}
+	}
+	client := func(name, version string) *api.NodeListStub {
+		return &api.NodeListStub{Name: name, Status: api.NodeStatusReady, Version: version}
+	}
+
+	testCases := []struct {
+		name         string
+		members      []*api.AgentMember
+		nodes        []*api.NodeListStub
+		feed         *versionCheckFeed
+		expectStatus string
+		expectIssues []string
+	}{
+		{
+			name: "same versions",
+			members: []*api.AgentMember{
+				server("s1", "global", "1.9.7"),
+				server("s2", "global", "1.9.7"),
+			},
+			nodes:        []*api.NodeListStub{client("c1", "1.9.7")},
+			expectStatus: versionCheckStatusOK,
+		},
+		{
+			name: "supported skew",
+			members: []*api.AgentMember{
+				server("s1", "global", "1.9.7"),
+				server("s2", "global", "1.8.2"),
+				server("s3", "west", "1.7.0"),
+			},
+			nodes: []*api.NodeListStub{
+				client("c1", "1.7.5"),
+				client("c2", "1.8.2+ent"),
+			},
+			expectStatus: versionCheckStatusMixed,
+		},
+		{
+			name: "left servers and down clients are ignored",
+			members: []*api.AgentMember{
+				server("s1", "global", "1.9.7"),
+				{Name: "s2", Status: "left", Tags: map[string]string{"region": "global", "build": "1.5.0"}},
+			},
+			nodes: []*api.NodeListStub{
+				client("c1", "1.9.7"),
+				{Name: "c2", Status: api.NodeStatusDown, Version: "1.5.0"},
+			},
+			expectStatus: versionCheckStatusOK,
+		},
+		{
+			name: "unsupported skew",
+			members: []*api.AgentMember{
+				server("s1", "global", "1.9.7"),
+				server("s2", "global", "1.6.0"),
+				server("s3", "west", "2.0.0"),
+				server("s4", "west", "1.9.0"),
+			},
+			nodes: []*api.NodeListStub{
+				client("c1", "1.9.7"),
+				client("c2", "1.5.3"),
+			},
+			expectStatus: versionCheckStatusUnsupported,
+			expectIssues: []string{
+				`servers in region "global" run versions 1.6.0 and 1.9.7, which are more than 2 minor releases apart`,
+				`servers in region "west" run versions 1.9.0 and 2.0.0, which are more than 2 minor releases apart`,
+				`client "c1" runs version 1.9.7, which is newer than server "s2" running 1.6.0`,
+				`client "c2" runs version 1.5.3, which is more than 2 minor releases behind server "s1" running 1.9.7`,
+			},
+		},
+		{
+			name:         "invalid version",
+			members:      []*api.AgentMember{server("s1", "global", "1.9.7")},
+			nodes:        []*api.NodeListStub{client("c1", "unknown")},
+			expectStatus: versionCheckStatusUnsupported,
+			expectIssues: []string{
+				`client "c1" has an invalid version "unknown"`,
+			},
+		},
+		{
+			name: "known bad versions",
+			members: []*api.AgentMember{
+				server("s1", "global", "1.9.7"),
+			},
+			nodes: []*api.NodeListStub{
+				client("c1", "1.9.7"),
+				client("c2", "1.9.2"),
+			},
+			feed: &versionCheckFeed{
+				KnownBad: []*versionCheckFeedEntry{
+					{Versions: "= 1.9.7", Reason: "regression in scheduler"},
+					{Versions: ">= 1.8.0, < 1.9.0", Reason: "security issue"},
+				},
+			},
+			expectStatus: versionCheckStatusUnsupported,
+			expectIssues: []string{
+				`server "s1" runs known bad version 1.9.7: regression in scheduler`,
+				`client "c1" runs known bad version 1.9.7: regression in scheduler`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.feed != nil {
+				raw, err := json.Marshal(tc.feed)
+				must.NoError(t, err)
+				path := filepath.Join(t.TempDir(), "feed.json")
+				must.NoError(t, os.WriteFile(path, raw, 0o644))
+				tc.feed, err = loadVersionCheckFeed(path)
+				must.NoError(t, err)
+			}
+
+			report := checkVersions(tc.members, tc.nodes, "global", tc.feed)
+			must.Eq(t, tc.expectStatus, report.Status)
+			must.Eq(t, tc.expectIssues, report.Issues)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package jsonschema implements validation of JSON documents against the
+// subset of JSON Schema needed to describe the inputs of dispatched jobs. The
+// supported keywords are type, properties, required, additionalProperties,
+// items, enum, minimum, maximum, minLength, maxLength, pattern, minItems and
+// maxItems. Other keywords are ignored.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+var validTypes = []string{
+	TypeObject, TypeArray, TypeString, TypeNumber, TypeInteger, TypeBoolean, TypeNull,
+}
+
+// Schema is a parsed JSON Schema.
+type Schema struct {
+	Type                 types              `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Pattern              string             `json:"pattern"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+
+	// additional is the schema for properties not listed in Properties. It
+	// is nil if additional properties are forbidden.
+	additional *Schema
+
+	pattern *regexp.Regexp
+}
+
+// types is the value of the type keyword, which may be a single type or a
+// list of types.
+type types []string
+
+func (t *types) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = types{single}
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(b, &multi); err != nil {
+		return errors.New("type must be a string or an array of strings")
+	}
+	*t = multi
+	return nil
+}
+
+// ValidationError describes a value that doesn't match its schema.
+type ValidationError struct {
+	// Path is the location of the value within the document, such as
+	// "$.items[2].name".
+	Path string
+
+	// Message describes why the value doesn't match.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Parse parses and checks the schema document.
+func Parse(doc []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(doc, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if err := s.compile("$"); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Schema) compile(path string) error {
+	for _, t := range s.Type {
+		if !slices.Contains(validTypes, t) {
+			return fmt.Errorf("%s: unknown type %q", path, t)
+		}
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+		s.pattern = re
+	}
+
+	for name, prop := range s.Properties {
+		if prop == nil {
+			return fmt.Errorf("%s: property %q has no schema", path, name)
+		}
+		if err := prop.compile(path + "." + name); err != nil {
+			return err
+		}
+	}
+
+	if s.Items != nil {
+		if err := s.Items.compile(path + "[]"); err != nil {
+			return err
+		}
+	}
+
+	// additionalProperties may be a boolean or a schema, and defaults to
+	// allowing any value
+	s.additional = &Schema{}
+	if len(s.AdditionalProperties) != 0 {
+		var allowed bool
+		if err := json.Unmarshal(s.AdditionalProperties, &allowed); err == nil {
+			if !allowed {
+				s.additional = nil
+			}
+		} else {
+			var additional Schema
+			if err := json.Unmarshal(s.AdditionalProperties, &additional); err != nil {
+				return fmt.Errorf("%s: additionalProperties must be a boolean or a schema", path)
+			}
+			if err := additional.compile(path + ".*"); err != nil {
+				return err
+			}
+			s.additional = &additional
+		}
+	}
+
+	return nil
+}
+
+// ValidateJSON decodes the document and validates it against the schema.
+func (s *Schema) ValidateJSON(doc []byte) []*ValidationError {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return []*ValidationError{{Path: "$", Message: fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+	if dec.More() {
+		return []*ValidationError{{Path: "$", Message: "invalid JSON: unexpected data after top-level value"}}
+	}
+
+	return s.Validate(v)
+}
+
+// Validate validates a decoded JSON value against the schema. Numbers may be
+// float64 or json.Number.
+func (s *Schema) Validate(v any) []*ValidationError {
+	var errs []*ValidationError
+	s.validate("$", v, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, v any, errs *[]*ValidationError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) != 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return isType(v, t) }) {
+		fail("expected %s, got %s", strings.Join(s.Type, " or "), typeOf(v))
+		return
+	}
+
+	if len(s.Enum) != 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return equal(e, v) }) {
+		fail("value is not one of the allowed values")
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+
+		names := make([]string, 0, len(val))
+		for name := range val {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				prop = s.additional
+			}
+			if prop == nil {
+				fail("unexpected property %q", name)
+				continue
+			}
+			prop.validate(path+"."+name, val[name], errs)
+		}
+
+	case []any:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			fail("expected at least %d items, got %d", *s.MinItems, len(val))
+		}
+		if s.MaxItems != nil && len(val) > *s.MaxItems {
+			fail("expected at most %d items, got %d", *s.MaxItems, len(val))
+		}
+		if s.Items != nil {
+			for i, item := range val {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+
+	case string:
+		n := utf8.RuneCountInString(val)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("expected at least %d characters, got %d", *s.MinLength, n)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("expected at most %d characters, got %d", *s.MaxLength, n)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(val) {
+			fail("value does not match pattern %q", s.Pattern)
+		}
+
+	default:
+		if f, ok := toFloat(v); ok {
+			if s.Minimum != nil && f < *s.Minimum {
+				fail("value must be at least %v", *s.Minimum)
+			}
+			if s.Maximum != nil && f > *s.Maximum {
+				fail("value must be at most %v", *s.Maximum)
+			}
+		}
+	}
+}
+
+func isType(v any, t string) bool {
+	switch t {
+	case TypeObject:
+		_, ok := v.(map[string]any)
+		return ok
+	case TypeArray:
+		_, ok := v.([]any)
+		return ok
+	case TypeString:
+		_, ok := v.(string)
+		return ok
+	case TypeNumber:
+		_, ok := toFloat(v)
+		return ok
+	case TypeInteger:
+		f, ok := toFloat(v)
+		return ok && f == math.Trunc(f)
+	case TypeBoolean:
+		_, ok := v.(bool)
+		return ok
+	case TypeNull:
+		return v == nil
+	}
+	return false
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return TypeObject
+	case []any:
+		return TypeArray
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case nil:
+		return TypeNull
+	}
+	if _, ok := toFloat(v); ok {
+		return TypeNumber
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// equal compares JSON values, treating numbers of different representations
+// as equal.
+func equal(a, b any) bool {
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok || bok {
+		return aok && bok && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
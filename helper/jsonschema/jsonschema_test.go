@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParse(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		schema string
+		expErr string
+	}{
+		{
+			name:   "valid",
+			schema: `{"type": ["object", "null"], "additionalProperties": {"type": "string"}}`,
+		},
+		{
+			name:   "invalid json",
+			schema: `{"type": `,
+			expErr: "failed to parse schema",
+		},
+		{
+			name:   "unknown type",
+			schema: `{"properties": {"a": {"type": "text"}}}`,
+			expErr: `$.a: unknown type "text"`,
+		},
+		{
+			name:   "invalid pattern",
+			schema: `{"items": {"pattern": "("}}`,
+			expErr: "$[]: invalid pattern",
+		},
+		{
+			name:   "invalid additional properties",
+			schema: `{"additionalProperties": 1}`,
+			expErr: "additionalProperties must be a boolean or a schema",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.schema))
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateJSON(t *testing.T) {
+	ci.Parallel(t)
+
+	schema, err := Parse([]byte(`{
+  "type": "object",
+  "required": ["name", "count"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "minLength": 2, "pattern": "^[a-z]+$"},
+    "count": {"type": "integer", "minimum": 1, "maximum": 10},
+    "mode": {"enum": ["fast", "slow"]},
+    "tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+  }
+}`))
+	must.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		doc     string
+		expErrs []string
+	}{
+		{
+			name: "valid",
+			doc:  `{"name": "web", "count": 3, "mode": "fast", "tags": ["a"]}`,
+		},
+		{
+			name:    "invalid json",
+			doc:     `{"name": `,
+			expErrs: []string{"$: invalid JSON: unexpected EOF"},
+		},
+		{
+			name:    "trailing data",
+			doc:     `{} {}`,
+			expErrs: []string{"$: invalid JSON: unexpected data after top-level value"},
+		},
+		{
+			name:    "wrong type",
+			doc:     `["web"]`,
+			expErrs: []string{"$: expected object, got array"},
+		},
+		{
+			name: "invalid properties",
+			doc:  `{"name": "W", "count": 2.5, "mode": "medium", "tags": ["a", 1, "c"], "other": true}`,
+			expErrs: []string{
+				"$.count: expected integer, got number",
+				"$.mode: value is not one of the allowed values",
+				"$.name: expected at least 2 characters, got 1",
+				`$.name: value does not match pattern "^[a-z]+$"`,
+				`$: unexpected property "other"`,
+				"$.tags: expected at most 2 items, got 3",
+				"$.tags[1]: expected string, got number",
+			},
+		},
+		{
+			name: "missing and out of range",
+			doc:  `{"count": 11}`,
+			expErrs: []string{
+				`$: missing required property "name"`,
+				"$.count: value must be at most 10",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, err := range schema.ValidateJSON([]byte(tc.doc)) {
+				got = append(got, err.Error())
+			}
+			must.Eq(t, tc.expErrs, got)
+		})
+	}
+}
@@ -490,7 +490,7 @@ func (l *PluginLoader) validatePluginConfig(id PluginID, info *pluginInfo) error
 	info.msgpackConfig = cdata
 
 	// Dispense the plugin and set its config and ensure it is error free
-	instance, err := l.Dispense(id.Name, id.PluginType, nil, l.logger)
+	instance, err := l.dispense(id, info, nil, l.logger)
 	if err != nil {
 		return fmt.Errorf("failed to dispense plugin: %v", err)
 	}
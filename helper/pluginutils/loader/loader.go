@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"sync"
 
 	log "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
@@ -91,6 +92,10 @@ type PluginLoader struct {
 
 	// plugins maps a plugin to information required to launch it
 	plugins map[PluginID]*pluginInfo
+
+	// configLock guards the configuration of the plugins, which can be
+	// reloaded for external driver plugins
+	configLock sync.RWMutex
 }
 
 // pluginInfo captures the necessary information to launch and configure a
@@ -154,6 +159,16 @@ func (l *PluginLoader) Dispense(name, pluginType string, config *base.AgentConfi
 		return nil, fmt.Errorf("unknown plugin with name %q and type %q", name, pluginType)
 	}
 
+	l.configLock.RLock()
+	info := *pinfo
+	l.configLock.RUnlock()
+
+	return l.dispense(id, &info, config, logger)
+}
+
+// dispense returns a plugin instance launched and configured with the given
+// plugin information.
+func (l *PluginLoader) dispense(id PluginID, pinfo *pluginInfo, config *base.AgentConfig, logger log.Logger) (PluginInstance, error) {
 	// If the plugin is internal, launch via the factory
 	var instance PluginInstance
 	if pinfo.factory != nil {
@@ -15,6 +15,7 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/device"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
 )
 
@@ -61,7 +62,7 @@ func pluginMain(name, pluginType, version string, apiVersions []string, config b
 	}
 
 	switch pluginType {
-	case base.PluginTypeDevice:
+	case base.PluginTypeDevice, base.PluginTypeDriver:
 	default:
 		return fmt.Errorf("unsupported plugin type %q", pluginType)
 	}
@@ -82,6 +83,8 @@ func pluginMain(name, pluginType, version string, apiVersions []string, config b
 	switch pluginType {
 	case base.PluginTypeDevice:
 		pmap[base.PluginTypeDevice] = &device.PluginDevice{Impl: m}
+	case base.PluginTypeDriver:
+		pmap[base.PluginTypeDriver] = drivers.NewDriverPlugin(&mockDriver{plugin: m}, log.NewNullLogger())
 	}
 
 	// Serve the plugin
@@ -196,3 +199,22 @@ func (m *mockPlugin) Reserve(deviceIDs []string) (*device.ContainerReservation,
 func (m *mockPlugin) Stats(ctx context.Context, interval time.Duration) (<-chan *device.StatsResponse, error) {
 	return make(chan *device.StatsResponse), nil
 }
+
+// mockDriver serves the mock plugin as a driver plugin. Only the base plugin
+// methods are implemented.
+type mockDriver struct {
+	drivers.DriverPlugin
+	plugin *mockPlugin
+}
+
+func (m *mockDriver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return m.plugin.PluginInfo()
+}
+
+func (m *mockDriver) ConfigSchema() (*hclspec.Spec, error) {
+	return m.plugin.ConfigSchema()
+}
+
+func (m *mockDriver) SetConfig(c *base.Config) error {
+	return m.plugin.SetConfig(c)
+}
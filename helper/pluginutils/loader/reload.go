@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// PluginConfigReloader is implemented by plugin catalogs that can apply new
+// plugin configurations after they are loaded.
+type PluginConfigReloader interface {
+	// CanReloadConfig returns whether a new configuration of the plugin with
+	// the given name can be applied by ReloadConfigs.
+	CanReloadConfig(name string) bool
+
+	// ReloadConfigs applies the new plugin configurations to the plugins
+	// dispensed afterwards and returns the plugins whose configuration
+	// changed.
+	ReloadConfigs(configs []*config.PluginConfig) ([]PluginID, error)
+}
+
+// CanReloadConfig returns whether a new configuration of the plugin with the
+// given name can be applied by ReloadConfigs. Only the configuration of
+// external driver plugins can be reloaded, as their running instances can be
+// relaunched while the tasks they started keep running and are recovered.
+// Internal plugins run within the agent and must be restarted with it.
+func (l *PluginLoader) CanReloadConfig(name string) bool {
+	for id, info := range l.plugins {
+		if reloadable(id, info) && info.configName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReloadConfigs applies the new configurations of the plugins for which
+// CanReloadConfig is true to the instances dispensed afterwards. The running
+// instances of the plugins are not affected and must be relaunched by the
+// caller. Plugins without a configuration are left untouched, since they are
+// only unloaded once the agent restarts. It returns the plugins whose
+// configuration changed. Every changed configuration is validated before any
+// is applied, so none is applied if any is invalid.
+func (l *PluginLoader) ReloadConfigs(configs []*config.PluginConfig) ([]PluginID, error) {
+	configMap := configMap(configs)
+
+	l.configLock.RLock()
+	changed := make(map[PluginID]*pluginInfo)
+	for id, info := range l.plugins {
+		if !reloadable(id, info) {
+			continue
+		}
+		c, ok := configMap[info.configName()]
+		if !ok {
+			continue
+		}
+
+		updated := *info
+		updated.args, updated.config, updated.msgpackConfig = c.Args, c.Config, nil
+		if argsEqual(info.args, updated.args) && configEqual(info.config, updated.config) {
+			continue
+		}
+		changed[id] = &updated
+	}
+	l.configLock.RUnlock()
+
+	var mErr multierror.Error
+	for id, info := range changed {
+		if err := l.validatePluginConfig(id, info); err != nil {
+			wrapped := multierror.Prefix(err, fmt.Sprintf("plugin %s:", id))
+			_ = multierror.Append(&mErr, wrapped)
+		}
+	}
+	if err := mErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	l.configLock.Lock()
+	defer l.configLock.Unlock()
+
+	ids := make([]PluginID, 0, len(changed))
+	for id, updated := range changed {
+		info := l.plugins[id]
+		info.args = updated.args
+		info.config = updated.config
+		info.msgpackConfig = updated.msgpackConfig
+		ids = append(ids, id)
+
+		l.logger.Info("reloaded plugin configuration", "plugin", id)
+	}
+
+	return ids, nil
+}
+
+// reloadable returns whether the configuration of the plugin can be reloaded.
+func reloadable(id PluginID, info *pluginInfo) bool {
+	return id.PluginType == base.PluginTypeDriver && info.factory == nil
+}
+
+// configName returns the name of the plugin block configuring the external
+// plugin, which is the name of its executable.
+func (i *pluginInfo) configName() string {
+	return cleanPluginExecutable(filepath.Base(i.exePath))
+}
+
+// argsEqual returns whether two sets of plugin arguments are equal.
+func argsEqual(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// configEqual returns whether two plugin configurations are equal, treating
+// a missing configuration as empty since validation defaults it to an empty
+// one.
+func configEqual(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
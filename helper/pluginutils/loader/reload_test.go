@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package loader
+
+import (
+	"testing"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/device"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestPluginLoader_ReloadConfigs(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create an external driver and device plugin
+	driver, dev := "mock-driver", "mock-device"
+	h := newHarness(t, []string{driver, dev})
+
+	driverArgs := []string{"-plugin", "-name", driver,
+		"-type", base.PluginTypeDriver, "-version", "v0.0.1", "-api-version", drivers.ApiVersion010}
+	deviceArgs := []string{"-plugin", "-name", dev,
+		"-type", base.PluginTypeDevice, "-version", "v0.0.1", "-api-version", device.ApiVersion010}
+	configs := func(driverConfig, deviceConfig map[string]interface{}) []*config.PluginConfig {
+		return []*config.PluginConfig{
+			{Name: driver, Args: driverArgs, Config: driverConfig},
+			{Name: dev, Args: deviceArgs, Config: deviceConfig},
+		}
+	}
+
+	logger := testlog.HCLogger(t)
+	logger.SetLevel(log.Trace)
+	l, err := NewPluginLoader(&PluginLoaderConfig{
+		Logger:    logger,
+		PluginDir: h.pluginDir(),
+		SupportedVersions: map[string][]string{
+			base.PluginTypeDevice: {device.ApiVersion010},
+			base.PluginTypeDriver: {drivers.ApiVersion010},
+		},
+		Configs: configs(map[string]interface{}{"foo": "1"}, nil),
+	})
+	must.NoError(t, err)
+
+	// Only the external driver plugin can be reloaded
+	must.True(t, l.CanReloadConfig(driver))
+	must.False(t, l.CanReloadConfig(dev))
+	must.False(t, l.CanReloadConfig("unknown"))
+
+	driverID := PluginID{Name: driver, PluginType: base.PluginTypeDriver}
+	deviceID := PluginID{Name: dev, PluginType: base.PluginTypeDevice}
+
+	// Nothing is reloaded if the configuration is unchanged
+	ids, err := l.ReloadConfigs(configs(map[string]interface{}{"foo": "1"}, nil))
+	must.NoError(t, err)
+	must.SliceEmpty(t, ids)
+
+	// Invalid configurations are not applied
+	_, err = l.ReloadConfigs(configs(map[string]interface{}{"baz": "1"}, nil))
+	must.ErrorContains(t, err, "failed to parse config")
+	must.Eq(t, map[string]interface{}{"foo": "1"}, l.plugins[driverID].config)
+
+	// Changed driver configurations are applied and dispensed, while changes
+	// to the device plugin are ignored
+	msgpackConfig := l.plugins[driverID].msgpackConfig
+	ids, err = l.ReloadConfigs(configs(
+		map[string]interface{}{"foo": "2"},
+		map[string]interface{}{"foo": "3"}))
+	must.NoError(t, err)
+	must.Eq(t, []PluginID{driverID}, ids)
+	must.Eq(t, map[string]interface{}{"foo": "2"}, l.plugins[driverID].config)
+	must.NotEq(t, msgpackConfig, l.plugins[driverID].msgpackConfig)
+	must.MapEmpty(t, l.plugins[deviceID].config)
+
+	p, err := l.Dispense(driver, base.PluginTypeDriver, nil, logger)
+	must.NoError(t, err)
+	p.Kill()
+}
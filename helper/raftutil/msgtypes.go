@@ -71,4 +71,5 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.HostVolumeRegisterRequestType:                "HostVolumeRegisterRequestType",
 	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
 	structs.TaskGroupHostVolumeClaimDeleteRequestType:    "TaskGroupHostVolumeClaimDeleteRequestType",
+	structs.JobFreezeRequestType:                         "JobFreezeRequestType",
 }
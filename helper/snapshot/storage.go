@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// StorageSSEAES256 encrypts snapshots with S3 managed keys.
+	StorageSSEAES256 = "AES256"
+
+	// StorageSSEKMS encrypts snapshots with AWS KMS keys.
+	StorageSSEKMS = "aws:kms"
+)
+
+// StorageOptions configures the object storage used to save and restore
+// snapshots. Credentials are loaded from the default AWS credential chain.
+type StorageOptions struct {
+	// Region is the region of the bucket. Defaults to the region of the AWS
+	// environment or shared configuration.
+	Region string
+
+	// Endpoint is the address of an S3 compatible storage service, used
+	// instead of AWS S3.
+	Endpoint string
+
+	// SSE is the server-side encryption used for uploaded snapshots, either
+	// "AES256" or "aws:kms". Uploaded snapshots use the default encryption of
+	// the bucket when empty.
+	SSE string
+
+	// KMSKeyID is the ID of the KMS key used to encrypt uploaded snapshots
+	// when SSE is "aws:kms". Uploaded snapshots use the AWS managed key when
+	// empty.
+	KMSKeyID string
+}
+
+// Validate returns an error if the storage options are invalid.
+func (o *StorageOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	switch o.SSE {
+	case "", StorageSSEAES256, StorageSSEKMS:
+	default:
+		return fmt.Errorf("unsupported server-side encryption %q, must be %q or %q",
+			o.SSE, StorageSSEAES256, StorageSSEKMS)
+	}
+	if o.KMSKeyID != "" && o.SSE != StorageSSEKMS {
+		return fmt.Errorf("a KMS key ID requires %q server-side encryption", StorageSSEKMS)
+	}
+	return nil
+}
+
+// ParseStorageURL parses a snapshot storage URL in the form
+// s3://bucket/path and returns the bucket and path.
+func ParseStorageURL(raw string) (string, string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid snapshot storage URL: %w", err)
+	}
+	if u.Scheme != "s3" {
+		return "", "", fmt.Errorf("unsupported snapshot storage URL scheme %q, must be \"s3\"", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", "", errors.New("snapshot storage URL is missing a bucket")
+	}
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// Storage saves and restores snapshots in an S3 bucket.
+type Storage struct {
+	bucket string
+	path   string
+	opts   StorageOptions
+
+	client   *s3.Client
+	uploader *manager.Uploader
+}
+
+// NewStorage returns a Storage for the s3://bucket/path storage URL.
+func NewStorage(rawURL string, opts *StorageOptions) (*Storage, error) {
+	bucket, p, err := ParseStorageURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &StorageOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	var loadOpts []func(*config.LoadOptions) error
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+	awsConfig, err := config.LoadDefaultConfig(context.Background(), loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure snapshot storage: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &Storage{
+		bucket:   bucket,
+		path:     p,
+		opts:     *opts,
+		client:   client,
+		uploader: manager.NewUploader(client),
+	}, nil
+}
+
+// URL returns the storage URL of the object with the given key.
+func (s *Storage) URL(key string) string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, key)
+}
+
+// Key returns the key of the object with the given name under the path of the
+// storage URL.
+func (s *Storage) Key(name string) string {
+	return path.Join(s.path, name)
+}
+
+// Upload uploads a snapshot to the object with the given key.
+func (s *Storage) Upload(ctx context.Context, key string, r io.Reader) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	}
+	if s.opts.SSE != "" {
+		input.ServerSideEncryption = types.ServerSideEncryption(s.opts.SSE)
+	}
+	if s.opts.KMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.opts.KMSKeyID)
+	}
+
+	if _, err := s.uploader.Upload(ctx, input); err != nil {
+		return fmt.Errorf("failed to upload snapshot to %s: %w", s.URL(key), err)
+	}
+	return nil
+}
+
+// Download returns the content of the object at the path of the storage URL.
+// The caller must close the returned reader.
+func (s *Storage) Download(ctx context.Context) (io.ReadCloser, error) {
+	if s.path == "" {
+		return nil, errors.New("snapshot storage URL is missing the snapshot path")
+	}
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download snapshot from %s: %w", s.URL(s.path), err)
+	}
+	return out.Body, nil
+}
+
+// Prune deletes the oldest snapshots under the path of the storage URL whose
+// name starts with namePrefix, keeping the retain most recent ones. It returns
+// the keys of the deleted snapshots.
+func (s *Storage) Prune(ctx context.Context, namePrefix string, retain int) ([]string, error) {
+	var objects []types.Object
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.Key(namePrefix)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots in %s: %w", s.URL(s.path), err)
+		}
+		objects = append(objects, page.Contents...)
+	}
+
+	var deleted []string
+	for _, key := range snapshotsToPrune(objects, retain) {
+		if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(key),
+		}); err != nil {
+			return deleted, fmt.Errorf("failed to delete snapshot %s: %w", s.URL(key), err)
+		}
+		deleted = append(deleted, key)
+	}
+	return deleted, nil
+}
+
+// snapshotsToPrune returns the keys of the objects to delete to keep the
+// retain most recently modified ones.
+func snapshotsToPrune(objects []types.Object, retain int) []string {
+	if retain <= 0 || len(objects) <= retain {
+		return nil
+	}
+
+	sorted := slices.Clone(objects)
+	sort.Slice(sorted, func(i, j int) bool {
+		ti, tj := aws.ToTime(sorted[i].LastModified), aws.ToTime(sorted[j].LastModified)
+		if ti.Equal(tj) {
+			return aws.ToString(sorted[i].Key) > aws.ToString(sorted[j].Key)
+		}
+		return ti.After(tj)
+	})
+
+	keys := make([]string, 0, len(sorted)-retain)
+	for _, obj := range sorted[retain:] {
+		keys = append(keys, aws.ToString(obj.Key))
+	}
+	return keys
+}
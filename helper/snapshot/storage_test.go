@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package snapshot
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestStorage_ParseStorageURL(t *testing.T) {
+	ci.Parallel(t)
+
+	bucket, p, err := ParseStorageURL("s3://backups/nomad/prod/")
+	must.NoError(t, err)
+	must.Eq(t, "backups", bucket)
+	must.Eq(t, "nomad/prod", p)
+
+	bucket, p, err = ParseStorageURL("s3://backups")
+	must.NoError(t, err)
+	must.Eq(t, "backups", bucket)
+	must.Eq(t, "", p)
+
+	_, _, err = ParseStorageURL("gs://backups/nomad")
+	must.ErrorContains(t, err, "unsupported snapshot storage URL scheme")
+
+	_, _, err = ParseStorageURL("s3:///nomad")
+	must.ErrorContains(t, err, "missing a bucket")
+}
+
+func TestStorage_Options_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (*StorageOptions)(nil).Validate())
+	must.NoError(t, (&StorageOptions{SSE: StorageSSEAES256}).Validate())
+	must.NoError(t, (&StorageOptions{SSE: StorageSSEKMS, KMSKeyID: "alias/nomad"}).Validate())
+	must.ErrorContains(t, (&StorageOptions{SSE: "rot13"}).Validate(), "unsupported server-side encryption")
+	must.ErrorContains(t, (&StorageOptions{KMSKeyID: "alias/nomad"}).Validate(), "requires")
+}
+
+func TestStorage_snapshotsToPrune(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	objects := []types.Object{
+		{Key: aws.String("b"), LastModified: aws.Time(now.Add(-2 * time.Hour))},
+		{Key: aws.String("a"), LastModified: aws.Time(now.Add(-3 * time.Hour))},
+		{Key: aws.String("d"), LastModified: aws.Time(now)},
+		{Key: aws.String("c"), LastModified: aws.Time(now.Add(-1 * time.Hour))},
+	}
+
+	must.Eq(t, []string{"b", "a"}, snapshotsToPrune(objects, 2))
+	must.SliceEmpty(t, snapshotsToPrune(objects, 4))
+	must.SliceEmpty(t, snapshotsToPrune(objects, 0))
+}
+
+func TestStorage_UploadDownloadPrune(t *testing.T) {
+	// Not parallel because the AWS credentials are set in the environment
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	fake := newFakeS3()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	ctx := context.Background()
+	storage, err := NewStorage("s3://backups/nomad", &StorageOptions{
+		Region:   "us-east-1",
+		Endpoint: srv.URL,
+		SSE:      StorageSSEKMS,
+		KMSKeyID: "alias/nomad",
+	})
+	must.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		key := storage.Key(fmt.Sprintf("nomad-snapshot-%d.snap", i))
+		must.NoError(t, storage.Upload(ctx, key, strings.NewReader(fmt.Sprintf("snapshot %d", i))))
+	}
+	must.Eq(t, "aws:kms", fake.objects["backups/nomad/nomad-snapshot-0.snap"].sse)
+	must.Eq(t, "alias/nomad", fake.objects["backups/nomad/nomad-snapshot-0.snap"].kmsKeyID)
+
+	deleted, err := storage.Prune(ctx, "nomad-snapshot-", 2)
+	must.NoError(t, err)
+	must.Eq(t, []string{"nomad/nomad-snapshot-0.snap"}, deleted)
+	must.MapNotContainsKey(t, fake.objects, "backups/nomad/nomad-snapshot-0.snap")
+
+	restore, err := NewStorage("s3://backups/nomad/nomad-snapshot-2.snap", &StorageOptions{
+		Region:   "us-east-1",
+		Endpoint: srv.URL,
+	})
+	must.NoError(t, err)
+	body, err := restore.Download(ctx)
+	must.NoError(t, err)
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	must.NoError(t, err)
+	must.Eq(t, "snapshot 2", string(content))
+}
+
+type fakeS3Object struct {
+	body         []byte
+	sse          string
+	kmsKeyID     string
+	lastModified time.Time
+}
+
+// fakeS3 implements the subset of the S3 API used by Storage with path-style
+// addressing.
+type fakeS3 struct {
+	lock    sync.Mutex
+	objects map[string]*fakeS3Object
+	clock   time.Time
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: map[string]*fakeS3Object{},
+		clock:   time.Now().Add(-time.Hour),
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.clock = f.clock.Add(time.Minute)
+		f.objects[path] = &fakeS3Object{
+			body:         body,
+			sse:          r.Header.Get("X-Amz-Server-Side-Encryption"),
+			kmsKeyID:     r.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"),
+			lastModified: f.clock,
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		f.list(w, path, r.URL.Query().Get("prefix"))
+	case r.Method == http.MethodGet:
+		obj, ok := f.objects[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(obj.body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, bucket, prefix string) {
+	type content struct {
+		Key          string
+		LastModified time.Time
+		Size         int
+	}
+	result := struct {
+		XMLName  xml.Name `xml:"ListBucketResult"`
+		Name     string
+		Prefix   string
+		KeyCount int
+		Contents []content
+	}{Name: bucket, Prefix: prefix}
+
+	for path, obj := range f.objects {
+		key, ok := strings.CutPrefix(path, bucket+"/")
+		if ok && strings.HasPrefix(key, prefix) {
+			result.Contents = append(result.Contents, content{key, obj.lastModified, len(obj.body)})
+		}
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
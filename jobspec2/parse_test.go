@@ -1098,6 +1098,98 @@ func TestParseServiceCheck(t *testing.T) {
 	require.Equal(t, expectedJob, parsedJob)
 }
 
+func TestParseScaleWithNodes(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  group "web" {
+    scale_with_nodes {
+      node_class = "web*"
+      per_node   = 2
+      max        = 10
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	expected := &api.ScaleWithNodes{
+		NodeClass: pointerOf("web*"),
+		PerNode:   pointerOf(2),
+		Max:       pointerOf(10),
+	}
+	require.Equal(t, expected, parsedJob.TaskGroups[0].ScaleWithNodes)
+}
+
+func TestParseDisruptionBudget(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  group "web" {
+    disruption_budget {
+      min_healthy = 2
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	expected := &api.DisruptionBudget{
+		MinHealthy: pointerOf(2),
+	}
+	require.Equal(t, expected, parsedJob.TaskGroups[0].DisruptionBudget)
+}
+
+func TestParsePreemptionNotice(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  group "web" {
+    preemption_notice {
+      grace  = "45s"
+      signal = "SIGUSR1"
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	expected := &api.PreemptionNotice{
+		Grace:  pointerOf(45 * time.Second),
+		Signal: pointerOf("SIGUSR1"),
+	}
+	require.Equal(t, expected, parsedJob.TaskGroups[0].PreemptionNotice)
+}
+
+func TestParseMinClientVersion(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  min_client_version = "1.9.0"
+
+  group "web" {}
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+	require.Equal(t, pointerOf("1.9.0"), parsedJob.MinClientVersion)
+}
+
 func TestWaitConfig(t *testing.T) {
 	t.Parallel()
 
@@ -1180,3 +1272,70 @@ func TestIdentity(t *testing.T) {
 	must.Eq(t, "sighup", altID.ChangeSignal)
 	must.Eq(t, 2*time.Hour, altID.TTL)
 }
+
+func TestParseParameterizedSchema(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  parameterized {
+    payload       = "required"
+    meta_required = ["count"]
+    meta_optional = ["mode"]
+
+    meta_schema "count" {
+      type = "integer"
+    }
+
+    meta_schema "mode" {
+      enum = ["fast", "slow"]
+    }
+
+    payload_schema = jsonencode({
+      type     = "object"
+      required = ["name"]
+    })
+  }
+
+  group "web" {}
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []*api.DispatchMetaSchema{
+		{Name: "count", Type: "integer"},
+		{Name: "mode", Enum: []string{"fast", "slow"}},
+	}, parsedJob.ParameterizedJob.MetaSchema)
+	must.Eq(t, `{"required":["name"],"type":"object"}`, parsedJob.ParameterizedJob.PayloadSchema)
+}
+
+func TestParseUpdateRollbackOn(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  group "web" {
+    update {
+      rollback_on {
+        query      = "sum(rate(http_requests_errors_total[1m]))"
+        threshold  = 0.5
+        comparison = "above"
+        interval   = "15s"
+      }
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &api.RollbackOn{
+		Query:      pointerOf("sum(rate(http_requests_errors_total[1m]))"),
+		Threshold:  pointerOf(0.5),
+		Comparison: pointerOf("above"),
+		Interval:   pointerOf(15 * time.Second),
+	}, parsedJob.TaskGroups[0].Update.RollbackOn)
+}
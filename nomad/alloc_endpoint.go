@@ -6,6 +6,8 @@ package nomad
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -18,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/sync/errgroup"
 )
 
 // Alloc endpoint is used for manipulating allocations
@@ -301,6 +304,14 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 		return structs.ErrPermissionDenied
 	}
 
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	if err := checkDisruptionBudgets(snap, []*structs.Allocation{alloc}); err != nil {
+		return err
+	}
+
 	now := time.Now().UTC().UnixNano()
 	eval := &structs.Evaluation{
 		ID:             uuid.Generate(),
@@ -338,6 +349,275 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 	return nil
 }
 
+// Batch is used to stop, signal, or restart all the allocations matching a
+// filter. Stops are committed in a single Raft apply, while signals and
+// restarts are forwarded to the clients with bounded parallelism. The outcome
+// for each allocation is returned in the reply.
+func (a *Alloc) Batch(args *structs.AllocBatchRequest, reply *structs.AllocBatchResponse) error {
+
+	authErr := a.srv.Authenticate(a.ctx, args)
+	if done, err := a.srv.forward("Alloc.Batch", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("alloc", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "batch"}, time.Now())
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	// Check for namespace alloc-lifecycle permissions. When querying all
+	// namespaces, allocations in namespaces the token can't act on are
+	// skipped instead.
+	namespace := args.RequestNamespace()
+	if namespace == structs.AllNamespacesSentinel && args.NodeID == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			"namespace must be set when filtering by job only")
+	}
+
+	allowNsOp := acl.NamespaceValidator(acl.NamespaceCapabilityAllocLifecycle)
+	aclObj, err := a.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if namespace != structs.AllNamespacesSentinel && !allowNsOp(aclObj, namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	allocs, err := batchAllocs(snap, args, func(ns string) bool {
+		return (namespace == structs.AllNamespacesSentinel || ns == namespace) && allowNsOp(aclObj, ns)
+	})
+	if err != nil {
+		return err
+	}
+
+	reply.Results = make([]*structs.AllocBatchResult, len(allocs))
+	for i, alloc := range allocs {
+		reply.Results[i] = &structs.AllocBatchResult{AllocID: alloc.ID, NodeID: alloc.NodeID}
+	}
+	if len(allocs) == 0 {
+		reply.Index, _ = snap.Index("allocs")
+		return nil
+	}
+
+	switch args.Action {
+	case structs.AllocBatchActionStop:
+		if err := checkDisruptionBudgets(snap, allocs); err != nil {
+			return err
+		}
+		return a.batchStop(args, allocs, reply)
+	default:
+		a.batchClientAction(snap, args, allocs, reply)
+		reply.Index, _ = snap.Index("allocs")
+		return nil
+	}
+}
+
+// batchAllocs returns the non-terminal allocations matching the filter of
+// the batch request, sorted by ID.
+func batchAllocs(snap *state.StateSnapshot, args *structs.AllocBatchRequest, allowNs func(string) bool) ([]*structs.Allocation, error) {
+	var allocs []*structs.Allocation
+	var err error
+	if args.NodeID != "" {
+		allocs, err = snap.AllocsByNode(nil, args.NodeID)
+	} else {
+		allocs, err = snap.AllocsByJob(nil, args.RequestNamespace(), args.JobID, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]*structs.Allocation, 0, len(allocs))
+	for _, alloc := range allocs {
+		switch {
+		case alloc.TerminalStatus():
+		case !allowNs(alloc.Namespace):
+		case args.JobID != "" && alloc.JobID != args.JobID:
+		case args.TaskGroup != "" && alloc.TaskGroup != args.TaskGroup:
+		default:
+			matching = append(matching, alloc)
+		}
+	}
+
+	slices.SortFunc(matching, func(a, b *structs.Allocation) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return matching, nil
+}
+
+// checkDisruptionBudgets returns an error if stopping and rescheduling the
+// allocations would take any of their task groups below the minimum number of
+// healthy allocations of its disruption budget.
+func checkDisruptionBudgets(snap *state.StateSnapshot, allocs []*structs.Allocation) error {
+	type groupID struct {
+		job   structs.NamespacedID
+		group string
+	}
+
+	stopping := make(map[groupID]int)
+	for _, alloc := range allocs {
+		if alloc.DisruptionBudgetHealthy() && !alloc.DesiredTransition.ShouldMigrate() {
+			stopping[groupID{alloc.JobNamespacedID(), alloc.TaskGroup}]++
+		}
+	}
+
+	for id, count := range stopping {
+		job, err := snap.JobByID(nil, id.job.Namespace, id.job.ID)
+		if err != nil {
+			return err
+		}
+		tg := job.LookupTaskGroup(id.group)
+		if tg == nil || tg.DisruptionBudget == nil {
+			continue
+		}
+
+		jobAllocs, err := snap.AllocsByJob(nil, id.job.Namespace, id.job.ID, false)
+		if err != nil {
+			return err
+		}
+		healthy := 0
+		for _, alloc := range jobAllocs {
+			if alloc.TaskGroup == id.group && alloc.DisruptionBudgetHealthy() &&
+				!alloc.DesiredTransition.ShouldMigrate() {
+				healthy++
+			}
+		}
+
+		if allowed := tg.DisruptionBudget.Allowed(tg.Count, healthy); count > allowed {
+			return structs.NewErrRPCCoded(http.StatusConflict, fmt.Sprintf(
+				"stopping %d allocations of task group %q of job %q would violate its disruption budget: %d healthy allocations, %d required",
+				count, id.group, id.job.ID, healthy, tg.DisruptionBudget.MinHealthyCount(tg.Count)))
+		}
+	}
+	return nil
+}
+
+// batchStop stops the allocations of a batch request in a single Raft apply,
+// creating an evaluation for each affected job.
+func (a *Alloc) batchStop(args *structs.AllocBatchRequest, allocs []*structs.Allocation, reply *structs.AllocBatchResponse) error {
+	now := time.Now().UTC().UnixNano()
+	transitionReq := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: make(map[string]*structs.DesiredTransition, len(allocs)),
+	}
+
+	jobs := make(map[structs.NamespacedID]struct{})
+	for _, alloc := range allocs {
+		transitionReq.Allocs[alloc.ID] = &structs.DesiredTransition{
+			Migrate:         pointer.Of(true),
+			NoShutdownDelay: pointer.Of(args.NoShutdownDelay),
+		}
+
+		jobID := structs.NamespacedID{ID: alloc.JobID, Namespace: alloc.Namespace}
+		if _, ok := jobs[jobID]; ok {
+			continue
+		}
+		jobs[jobID] = struct{}{}
+
+		eval := &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      alloc.Namespace,
+			Priority:       alloc.Job.Priority,
+			Type:           alloc.Job.Type,
+			TriggeredBy:    structs.EvalTriggerAllocStop,
+			JobID:          alloc.Job.ID,
+			JobModifyIndex: alloc.Job.ModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		}
+		transitionReq.Evals = append(transitionReq.Evals, eval)
+		reply.EvalIDs = append(reply.EvalIDs, eval.ID)
+	}
+
+	// Commit this update via Raft
+	_, index, err := a.srv.raftApply(structs.AllocUpdateDesiredTransitionRequestType, transitionReq)
+	if err != nil {
+		a.logger.Error("AllocUpdateDesiredTransitionRequest failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// batchClientAction signals or restarts the allocations of a batch request,
+// recording the outcome for each allocation in the reply.
+func (a *Alloc) batchClientAction(snap *state.StateSnapshot, args *structs.AllocBatchRequest, allocs []*structs.Allocation, reply *structs.AllocBatchResponse) {
+	parallelism := args.Parallelism
+	if parallelism == 0 {
+		parallelism = structs.AllocBatchDefaultParallelism
+	}
+
+	var g errgroup.Group
+	g.SetLimit(parallelism)
+
+	for i, alloc := range allocs {
+		g.Go(func() error {
+			// Forward the token so the clients and any server the request is
+			// forwarded to can check it.
+			opts := structs.QueryOptions{
+				Region:     args.Region,
+				Namespace:  alloc.Namespace,
+				AuthToken:  args.AuthToken,
+				AllowStale: true,
+			}
+
+			var err error
+			var resp structs.GenericResponse
+			switch args.Action {
+			case structs.AllocBatchActionSignal:
+				req := &structs.AllocSignalRequest{
+					AllocID:      alloc.ID,
+					Task:         args.Task,
+					Signal:       args.Signal,
+					QueryOptions: opts,
+				}
+				err = a.clientAllocRPC(snap, alloc.NodeID, "Signal", req, &resp)
+			case structs.AllocBatchActionRestart:
+				req := &structs.AllocRestartRequest{
+					AllocID:      alloc.ID,
+					TaskName:     args.Task,
+					AllTasks:     args.AllTasks,
+					QueryOptions: opts,
+				}
+				err = a.clientAllocRPC(snap, alloc.NodeID, "Restart", req, &resp)
+			}
+
+			if err != nil {
+				reply.Results[i].Error = err.Error()
+			}
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+}
+
+// clientAllocRPC makes an allocation RPC on the client running the allocation,
+// forwarding it to the server connected to the client if needed.
+func (a *Alloc) clientAllocRPC(snap *state.StateSnapshot, nodeID, method string, args, reply interface{}) error {
+	// Make sure Node is valid and new enough to support RPC
+	if _, err := getNodeForRpc(snap, nodeID); err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := a.srv.getNodeConn(nodeID)
+	if !ok {
+		return findNodeConnAndForward(a.srv, nodeID, "ClientAllocations."+method, args, reply)
+	}
+
+	return NodeRpc(state.Session, "Allocations."+method, args, reply)
+}
+
 // UpdateDesiredTransition is used to update the desired transitions of an
 // allocation.
 func (a *Alloc) UpdateDesiredTransition(args *structs.AllocUpdateDesiredTransitionRequest, reply *structs.GenericResponse) error {
@@ -376,6 +656,69 @@ func (a *Alloc) UpdateDesiredTransition(args *structs.AllocUpdateDesiredTransiti
 	return nil
 }
 
+// RegisterResult is used by a task to register its result on the allocation.
+// Tasks may register their own result with their workload identity, otherwise
+// the submit-job capability is required.
+func (a *Alloc) RegisterResult(args *structs.AllocRegisterResultRequest, reply *structs.GenericResponse) error {
+	authErr := a.srv.Authenticate(a.ctx, args)
+	if done, err := a.srv.forward("Alloc.RegisterResult", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("alloc", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "register_result"}, time.Now())
+
+	alloc, err := getAlloc(a.srv.State(), args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	if claims := args.GetIdentity().GetClaims(); claims != nil {
+		if claims.AllocationID != alloc.ID || claims.TaskName == "" ||
+			(args.TaskName != "" && args.TaskName != claims.TaskName) {
+			return structs.ErrPermissionDenied
+		}
+		args.TaskName = claims.TaskName
+	} else {
+		aclObj, err := a.srv.ResolveACL(args)
+		if err != nil {
+			return err
+		} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	if !ServersMeetMinimumVersion(a.srv.Members(), a.srv.Region(), minTaskResultVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to register task results",
+			minTaskResultVersion)
+	}
+
+	if args.TaskName == "" {
+		return fmt.Errorf("missing task name")
+	}
+	if alloc.LookupTask(args.TaskName) == nil {
+		return fmt.Errorf("task %q not found in allocation", args.TaskName)
+	}
+	if err := args.Result.Validate(); err != nil {
+		return err
+	}
+	args.Result.RegisterTime = time.Now().UTC().UnixNano()
+
+	// Commit this update via Raft
+	_, index, err := a.srv.raftApply(structs.AllocTaskResultRegisterRequestType, args)
+	if err != nil {
+		a.logger.Error("AllocRegisterResultRequest failed", "error", err)
+		return err
+	}
+
+	// Setup the response
+	reply.Index = index
+	return nil
+}
+
 // GetServiceRegistrations returns a list of service registrations which belong
 // to the passed allocation ID.
 func (a *Alloc) GetServiceRegistrations(
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -1187,6 +1188,404 @@ func TestAllocEndpoint_Stop_ACL(t *testing.T) {
 	require.True(*out2.DesiredTransition.Migrate)
 }
 
+func TestAllocEndpoint_Batch_Stop(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	tg := job.TaskGroups[0].Copy()
+	tg.Name = "other"
+	job.TaskGroups = append(job.TaskGroups, tg)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 4; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		allocs = append(allocs, alloc)
+	}
+
+	// One allocation is already stopped, one is in another group, and one
+	// belongs to another job
+	allocs[2].DesiredStatus = structs.AllocDesiredStatusStop
+	allocs[3].TaskGroup = "other"
+	other := mock.Alloc()
+	allocs = append(allocs, other)
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	req := &structs.AllocBatchRequest{
+		Action:    structs.AllocBatchActionStop,
+		JobID:     job.ID,
+		TaskGroup: "web",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.AllocBatchResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp))
+	must.Positive(t, resp.Index)
+	must.Len(t, 1, resp.EvalIDs)
+
+	expected := []string{allocs[0].ID, allocs[1].ID}
+	slices.Sort(expected)
+	must.Len(t, 2, resp.Results)
+	for i, result := range resp.Results {
+		must.Eq(t, expected[i], result.AllocID)
+		must.Eq(t, "", result.Error)
+
+		out, err := state.AllocByID(nil, result.AllocID)
+		must.NoError(t, err)
+		must.True(t, out.DesiredTransition.ShouldMigrate())
+	}
+
+	eval, err := state.EvalByID(nil, resp.EvalIDs[0])
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+	must.Eq(t, structs.EvalTriggerAllocStop, eval.TriggeredBy)
+	must.Eq(t, job.ID, eval.JobID)
+
+	// Allocations not matching the filter were left untouched
+	for _, id := range []string{allocs[3].ID, other.ID} {
+		out, err := state.AllocByID(nil, id)
+		must.NoError(t, err)
+		must.False(t, out.DesiredTransition.ShouldMigrate())
+	}
+}
+
+func TestAllocEndpoint_Stop_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MinHealthy: 2}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	stop := func(allocID string) error {
+		req := &structs.AllocStopRequest{
+			AllocID: allocID,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.AllocStopResponse
+		return msgpackrpc.CallWithCodec(codec, "Alloc.Stop", req, &resp)
+	}
+
+	// The first allocation can be stopped but stopping another one would
+	// leave a single healthy allocation
+	must.NoError(t, stop(allocs[0].ID))
+	must.ErrorContains(t, stop(allocs[1].ID), "would violate its disruption budget")
+
+	// Stopping all the allocations at once is rejected as well
+	req := &structs.AllocBatchRequest{
+		Action: structs.AllocBatchActionStop,
+		JobID:  job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.AllocBatchResponse
+	must.ErrorContains(t, msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp),
+		"would violate its disruption budget")
+}
+
+func TestAllocEndpoint_Batch_Signal(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Place allocations on a node that isn't connected to any server
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 998, node))
+
+	alloc1 := mock.Alloc()
+	alloc1.NodeID = node.ID
+	alloc2 := mock.Alloc()
+	alloc2.NodeID = node.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+	// Invalid requests are rejected
+	req := &structs.AllocBatchRequest{
+		Action: structs.AllocBatchActionSignal,
+		Signal: "SIGHUP",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.AllocBatchResponse
+	err := msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp)
+	must.ErrorContains(t, err, "must filter by job or node")
+
+	// Each allocation reports its own failure
+	req.NodeID = node.ID
+	req.Parallelism = 1
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp))
+	must.Len(t, 2, resp.Results)
+	must.Len(t, 0, resp.EvalIDs)
+	for _, result := range resp.Results {
+		must.Eq(t, node.ID, result.NodeID)
+		must.NotEq(t, "", result.Error)
+	}
+}
+
+func TestAllocEndpoint_Batch_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, state.UpsertNamespaces(997, []*structs.Namespace{ns}))
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 998, node))
+
+	alloc1 := mock.Alloc()
+	alloc1.NodeID = node.ID
+	alloc2 := mock.Alloc()
+	alloc2.NodeID = node.ID
+	alloc2.Namespace = ns.Name
+	alloc2.Job.Namespace = ns.Name
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+	// Signal the allocations so that they're still running for each of the
+	// ACL checks, the effect of stops is covered by TestAllocEndpoint_Batch_Stop
+	req := &structs.AllocBatchRequest{
+		Action: structs.AllocBatchActionSignal,
+		Signal: "SIGHUP",
+		NodeID: node.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns.Name,
+		},
+	}
+
+	// Try without permissions
+	var resp structs.AllocBatchResponse
+	err := msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with a token for the default namespace only
+	validToken := mock.CreatePolicyAndToken(t, state, 1002, "valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocLifecycle}))
+	req.AuthToken = validToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Querying all namespaces skips the allocations the token can't act on
+	req.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp))
+	must.Len(t, 1, resp.Results)
+	must.Eq(t, alloc1.ID, resp.Results[0].AllocID)
+
+	// A management token can act on all namespaces
+	req.AuthToken = root.SecretID
+	var resp2 structs.AllocBatchResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp2))
+	must.Len(t, 2, resp2.Results)
+	must.SliceContainsFunc(t, resp2.Results, alloc2.ID,
+		func(r *structs.AllocBatchResult, id string) bool { return r.AllocID == id })
+}
+
+func TestAllocEndpoint_RegisterResult(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+	state := s1.fsm.State()
+
+	alloc := mock.Alloc()
+	other := mock.Alloc()
+	other.Job = alloc.Job
+	other.JobID = alloc.JobID
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{alloc, other}))
+
+	sign := func(alloc *structs.Allocation) string {
+		task := alloc.LookupTask("web")
+		claims := structs.NewIdentityClaimsBuilder(alloc.Job, alloc, wiHandle, task.Identity).
+			WithTask(task).
+			Build(time.Now())
+		token, _, err := s1.encrypter.SignClaims(claims)
+		must.NoError(t, err)
+		return token
+	}
+	submitToken := mock.CreatePolicyAndToken(t, state, 1001, "submit",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	readToken := mock.CreatePolicyAndToken(t, state, 1002, "read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	testCases := []struct {
+		name      string
+		token     string
+		taskName  string
+		result    *structs.TaskResult
+		expectErr string
+	}{
+		{
+			name:      "no token",
+			result:    &structs.TaskResult{Summary: "done"},
+			expectErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:      "insufficient permissions",
+			token:     readToken.SecretID,
+			taskName:  "web",
+			result:    &structs.TaskResult{Summary: "done"},
+			expectErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:      "identity of another alloc",
+			token:     sign(other),
+			result:    &structs.TaskResult{Summary: "done"},
+			expectErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:      "identity of another task",
+			token:     sign(alloc),
+			taskName:  "sidecar",
+			result:    &structs.TaskResult{Summary: "done"},
+			expectErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:      "missing task name",
+			token:     submitToken.SecretID,
+			result:    &structs.TaskResult{Summary: "done"},
+			expectErr: "missing task name",
+		},
+		{
+			name:      "unknown task",
+			token:     root.SecretID,
+			taskName:  "unknown",
+			result:    &structs.TaskResult{Summary: "done"},
+			expectErr: `task "unknown" not found in allocation`,
+		},
+		{
+			name:      "invalid result",
+			token:     sign(alloc),
+			result:    &structs.TaskResult{OutputURI: "no-scheme"},
+			expectErr: "missing scheme",
+		},
+		{
+			name:     "submit-job token",
+			token:    submitToken.SecretID,
+			taskName: "web",
+			result:   &structs.TaskResult{Summary: "first"},
+		},
+		{
+			name:  "workload identity",
+			token: sign(alloc),
+			result: &structs.TaskResult{
+				Summary:   "done",
+				OutputURI: "s3://bucket/output",
+				Meta:      map[string]string{"rows": "42"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &structs.AllocRegisterResultRequest{
+				AllocID:  alloc.ID,
+				TaskName: tc.taskName,
+				Result:   tc.result,
+				WriteRequest: structs.WriteRequest{
+					Region:    s1.Region(),
+					Namespace: structs.DefaultNamespace,
+					AuthToken: tc.token,
+				},
+			}
+			var resp structs.GenericResponse
+			err := msgpackrpc.CallWithCodec(codec, "Alloc.RegisterResult", req, &resp)
+			if tc.expectErr != "" {
+				must.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Positive(t, resp.Index)
+
+			out, err := state.AllocByID(nil, alloc.ID)
+			must.NoError(t, err)
+			must.Eq(t, resp.Index, out.ModifyIndex)
+			must.Eq(t, 1000, out.AllocModifyIndex)
+
+			result := out.TaskResults["web"]
+			must.NotNil(t, result)
+			must.Eq(t, tc.result.Summary, result.Summary)
+			must.Eq(t, tc.result.OutputURI, result.OutputURI)
+			must.Eq(t, tc.result.Meta, result.Meta)
+			must.Positive(t, result.RegisterTime)
+		})
+	}
+}
+
+func TestAllocEndpoint_RegisterResult_MinVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.Build = "1.9.6"
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	alloc := mock.Alloc()
+	must.NoError(t, s1.fsm.State().UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{alloc}))
+
+	req := &structs.AllocRegisterResultRequest{
+		AllocID:  alloc.ID,
+		TaskName: "web",
+		Result:   &structs.TaskResult{Summary: "done"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: alloc.Namespace,
+		},
+	}
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Alloc.RegisterResult", req, &resp)
+	must.ErrorContains(t, err, "all servers must be running version 1.9.7 or later")
+}
+
 func TestAllocEndpoint_List_AllNamespaces_ACL_OSS(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1240,6 +1639,7 @@ func TestAllocEndpoint_List_AllNamespaces_ACL_OSS(t *testing.T) {
 		acl.NamespaceCapabilityReadScalingPolicy,
 		acl.NamespaceCapabilityReadJobScaling,
 		acl.NamespaceCapabilityScaleJob,
+		acl.NamespaceCapabilityFreezeJob,
 		acl.NamespaceCapabilitySubmitRecommendation,
 	}
 
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// allocIdentityRenewInterval is the interval at which the leader looks for
+	// default workload identities that are about to expire.
+	allocIdentityRenewInterval = 30 * time.Second
+
+	// allocIdentityRenewBatchSize is the maximum number of allocations whose
+	// identities are updated in a single raft entry.
+	allocIdentityRenewBatchSize = 512
+)
+
+// renewAllocIdentities is a long lived function run on the leader that
+// re-signs the default workload identities of allocations before they expire.
+// The identities are updated on the allocations, which pushes them to the
+// clients. It stops once stopCh is closed.
+func (s *Server) renewAllocIdentities(stopCh chan struct{}) {
+	timer, stop := helper.NewSafeTimer(allocIdentityRenewInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+		}
+
+		if err := s.renewExpiringAllocIdentities(time.Now()); err != nil {
+			s.logger.Error("failed to renew workload identities", "error", err)
+		}
+		timer.Reset(allocIdentityRenewInterval)
+	}
+}
+
+// renewExpiringAllocIdentities re-signs the default workload identities that
+// need to be renewed at the given time, and applies them to the allocations.
+func (s *Server) renewExpiringAllocIdentities(now time.Time) error {
+	defer metrics.MeasureSince([]string{"nomad", "leader", "renew_alloc_identities"}, now)
+
+	// Identities can't be renewed until all servers can apply the update
+	if !ServersMeetMinimumVersion(s.Members(), s.Region(), minAllocIdentityRenewVersion, true) {
+		s.logger.Debug("skipping workload identity renewal until all servers are upgraded",
+			"min_version", minAllocIdentityRenewVersion)
+		return nil
+	}
+
+	iter, err := s.State().Allocs(nil, state.SortDefault)
+	if err != nil {
+		return err
+	}
+
+	var batch []*structs.AllocSignedIdentities
+	var renewed int
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		req := &structs.AllocIdentitiesUpdateRequest{
+			Allocs:       batch,
+			WriteRequest: structs.WriteRequest{Region: s.Region()},
+		}
+		if _, _, err := s.raftApply(structs.AllocIdentitiesUpdateRequestType, req); err != nil {
+			return err
+		}
+		renewed += len(batch)
+		batch = nil
+		return nil
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		update, err := s.resignAllocIdentities(alloc, now)
+		if err != nil {
+			return fmt.Errorf("failed to renew identities of alloc %s: %w", alloc.ID, err)
+		}
+		if update == nil {
+			continue
+		}
+
+		batch = append(batch, update)
+		if len(batch) >= allocIdentityRenewBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if renewed > 0 {
+		s.logger.Debug("renewed workload identities", "allocs", renewed)
+		metrics.IncrCounter([]string{"nomad", "leader", "renewed_alloc_identities"}, float32(renewed))
+	}
+	return nil
+}
+
+// resignAllocIdentities re-signs the default workload identities of the tasks
+// of the allocation that need to be renewed. It returns nil if there are none.
+func (s *Server) resignAllocIdentities(alloc *structs.Allocation, now time.Time) (*structs.AllocSignedIdentities, error) {
+	if alloc.TerminalStatus() || alloc.Job == nil || len(alloc.SignedIdentities) == 0 {
+		return nil, nil
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil, nil
+	}
+
+	var update *structs.AllocSignedIdentities
+	for _, task := range tg.Tasks {
+		if task.Identity == nil || task.Identity.TTL == 0 {
+			continue
+		}
+		token, ok := alloc.SignedIdentities[task.Name]
+		if !ok || !identityNeedsRenewal(token, now) {
+			continue
+		}
+
+		defaultWI := &structs.WorkloadIdentity{Name: "default"}
+		claims := structs.NewIdentityClaimsBuilder(
+			alloc.Job, alloc, task.IdentityHandle(defaultWI), task.Identity).
+			WithTask(task).
+			Build(now)
+
+		// The identities are signed one at a time instead of in a batch, as
+		// the batch signing cache would return the identity being renewed
+		signed, keyID, err := s.encrypter.SignClaims(claims)
+		if err != nil {
+			return nil, err
+		}
+
+		if update == nil {
+			update = &structs.AllocSignedIdentities{
+				AllocID:          alloc.ID,
+				SignedIdentities: map[string]string{},
+			}
+		}
+		update.SignedIdentities[task.Name] = signed
+		update.SigningKeyID = keyID
+	}
+
+	return update, nil
+}
+
+// identityNeedsRenewal returns true if less than a third of the lifetime of
+// the signed identity remains at the given time, or if it expires before the
+// next renewal pass. Identities that can't be parsed are renewed.
+func identityNeedsRenewal(token string, now time.Time) bool {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return true
+	}
+
+	// The identity was signed by this cluster and is only inspected to find
+	// its expiration, so the signature doesn't need to be verified
+	var claims jwt.Claims
+	if err := parsed.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return true
+	}
+	if claims.Expiry == nil {
+		return false
+	}
+
+	expiry := claims.Expiry.Time()
+	margin := 2 * allocIdentityRenewInterval
+	if claims.IssuedAt != nil {
+		margin = max(margin, expiry.Sub(claims.IssuedAt.Time())/3)
+	}
+	return !now.Before(expiry.Add(-margin))
+}
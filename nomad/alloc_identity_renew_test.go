@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocIdentityRenew_identityNeedsRenewal(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+
+	alloc := mock.Alloc()
+	task := alloc.LookupTask("web")
+	now := time.Now()
+
+	sign := func(ttl time.Duration) string {
+		wid := task.Identity.Copy()
+		wid.TTL = ttl
+		claims := structs.NewIdentityClaimsBuilder(alloc.Job, alloc, wiHandle, wid).
+			WithTask(task).
+			Build(now)
+		token, _, err := s1.encrypter.SignClaims(claims)
+		must.NoError(t, err)
+		return token
+	}
+
+	// Identities without a TTL never need to be renewed
+	must.False(t, identityNeedsRenewal(sign(0), now.Add(24*time.Hour)))
+
+	// Identities are renewed once less than a third of their TTL remains
+	daily := sign(24 * time.Hour)
+	must.False(t, identityNeedsRenewal(daily, now))
+	must.False(t, identityNeedsRenewal(daily, now.Add(15*time.Hour)))
+	must.True(t, identityNeedsRenewal(daily, now.Add(17*time.Hour)))
+	must.True(t, identityNeedsRenewal(daily, now.Add(25*time.Hour)))
+
+	// Identities expiring before the next pass are renewed
+	must.True(t, identityNeedsRenewal(sign(time.Minute), now))
+
+	// Invalid identities are renewed
+	must.True(t, identityNeedsRenewal("not-a-jwt", now))
+}
+
+func TestAllocIdentityRenew_renewExpiringAllocIdentities(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+	store := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Identity = &structs.WorkloadIdentity{
+		Name:     structs.WorkloadIdentityDefaultName,
+		Audience: []string{structs.WorkloadIdentityDefaultAud},
+		TTL:      time.Hour,
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Sign the identities in the past, so the first one needs to be renewed
+	signedAt := time.Now().Add(-50 * time.Minute)
+	expiring := mock.AllocForNode(mock.Node())
+	expiring.Job = job
+	expiring.JobID = job.ID
+	fresh := expiring.Copy()
+	fresh.ID = uuid.Generate()
+	fresh.SignedIdentities = nil
+	stopped := expiring.Copy()
+	stopped.ID = uuid.Generate()
+	stopped.SignedIdentities = nil
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+
+	must.NoError(t, signAllocIdentities(s1.encrypter, job,
+		[]*structs.Allocation{expiring, stopped}, signedAt))
+	must.NoError(t, signAllocIdentities(s1.encrypter, job,
+		[]*structs.Allocation{fresh}, time.Now()))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Allocation{expiring, fresh, stopped}))
+
+	must.NoError(t, s1.renewExpiringAllocIdentities(time.Now()))
+
+	out, err := store.AllocByID(nil, expiring.ID)
+	must.NoError(t, err)
+	must.NotEq(t, 1001, out.AllocModifyIndex)
+	token := out.SignedIdentities["web"]
+	must.NotEq(t, expiring.SignedIdentities["web"], token)
+
+	claims, err := s1.encrypter.VerifyClaim(token)
+	must.NoError(t, err)
+	must.Eq(t, expiring.ID, claims.AllocationID)
+	must.Eq(t, "web", claims.TaskName)
+	must.True(t, claims.Expiry.Time().After(time.Now().Add(50*time.Minute)))
+	must.False(t, identityNeedsRenewal(token, time.Now()))
+
+	// Identities that don't need to be renewed and terminal allocs are not
+	// updated
+	for _, alloc := range []*structs.Allocation{fresh, stopped} {
+		out, err = store.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.Eq(t, 1001, out.AllocModifyIndex)
+		must.Eq(t, alloc.SignedIdentities["web"], out.SignedIdentities["web"])
+	}
+}
+
+func TestAllocIdentityRenew_MinVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Build = "1.9.6"
+	})
+	defer cleanupS1()
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+	store := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Identity = &structs.WorkloadIdentity{
+		Name:     structs.WorkloadIdentityDefaultName,
+		Audience: []string{structs.WorkloadIdentityDefaultAud},
+		TTL:      time.Hour,
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	expiring := mock.AllocForNode(mock.Node())
+	expiring.Job = job
+	expiring.JobID = job.ID
+	must.NoError(t, signAllocIdentities(s1.encrypter, job,
+		[]*structs.Allocation{expiring}, time.Now().Add(-50*time.Minute)))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Allocation{expiring}))
+
+	// Identities are not renewed until all servers can apply the update
+	must.NoError(t, s1.renewExpiringAllocIdentities(time.Now()))
+
+	out, err := store.AllocByID(nil, expiring.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1001, out.AllocModifyIndex)
+	must.Eq(t, expiring.SignedIdentities["web"], out.SignedIdentities["web"])
+}
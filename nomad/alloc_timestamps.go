@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// measureAllocTimestamps emits the latency of each step of the placement and
+// startup of an allocation newly reached between the old and updated
+// timestamps. Latencies are measured from the creation of the evaluation that
+// placed the allocation and labeled by namespace.
+func measureAllocTimestamps(namespace string, old, updated *structs.AllocTimestamps) {
+	if updated == nil || updated.EvalCreateTime == 0 {
+		return
+	}
+	if old == nil {
+		old = &structs.AllocTimestamps{}
+	}
+
+	labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+	measure := func(name string, previous, current int64) {
+		if previous != 0 || current == 0 {
+			return
+		}
+		latency := time.Duration(current - updated.EvalCreateTime)
+		metrics.AddSampleWithLabels([]string{"nomad", "alloc", name}, float32(latency.Milliseconds()), labels)
+	}
+
+	measure("placement_latency", old.PlanApplyTime, updated.PlanApplyTime)
+	measure("client_receive_latency", old.ClientReceiveTime, updated.ClientReceiveTime)
+	measure("task_start_latency", old.TaskStartTime, updated.TaskStartTime)
+	measure("healthy_latency", old.FirstHealthyTime, updated.FirstHealthyTime)
+}
@@ -6,6 +6,7 @@ package nomad
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	metrics "github.com/hashicorp/go-metrics/compat"
@@ -24,8 +25,41 @@ const (
 	// AutopilotRZTag is the Serf tag to use for the custom version value
 	// when passing the server metadata to Autopilot.
 	AutopilotVersionTag = "ap_version"
+
+	// readReplicaTag is the Serf tag set by read replica servers.
+	readReplicaTag = "read_replica"
 )
 
+// readReplicaPromoter wraps an autopilot promoter to keep read replicas as
+// non-voters: they are never promoted, and are demoted if they somehow became
+// voters, such as when an existing server is restarted as a read replica.
+type readReplicaPromoter struct {
+	autopilot.Promoter
+}
+
+func (p *readReplicaPromoter) CalculatePromotionsAndDemotions(c *autopilot.Config, s *autopilot.State) autopilot.RaftChanges {
+	changes := p.Promoter.CalculatePromotionsAndDemotions(c, s)
+
+	changes.Promotions = slices.DeleteFunc(changes.Promotions, func(id raft.ServerID) bool {
+		return isReadReplica(s.Servers[id])
+	})
+	for id, srv := range s.Servers {
+		if srv.State == autopilot.RaftVoter && isReadReplica(srv) && !slices.Contains(changes.Demotions, id) {
+			changes.Demotions = append(changes.Demotions, id)
+		}
+	}
+	return changes
+}
+
+// isReadReplica returns true if the autopilot server is a read replica.
+func isReadReplica(srv *autopilot.ServerState) bool {
+	if srv == nil {
+		return false
+	}
+	_, ok := srv.Server.Meta[readReplicaTag]
+	return ok
+}
+
 // AutopilotDelegate is a Nomad delegate for autopilot operations. It implements
 // the autopilot.ApplicationIntegration interface, and the methods required for
 // that interface have been documented as such below.
@@ -146,6 +180,7 @@ func autopilotToServerHealth(srv *autopilot.ServerState) structs.ServerHealth {
 		Version:     srv.Server.Version,
 		Leader:      srv.State == autopilot.RaftLeader,
 		Voter:       srv.State == autopilot.RaftLeader || srv.State == autopilot.RaftVoter,
+		ReadReplica: isReadReplica(srv),
 		LastContact: srv.Stats.LastContact,
 		LastTerm:    srv.Stats.LastTerm,
 		LastIndex:   srv.Stats.LastIndex,
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/hashicorp/raft"
 	autopilot "github.com/hashicorp/raft-autopilot"
 	"github.com/hashicorp/serf/serf"
@@ -309,6 +310,102 @@ func TestAutopilot_PromoteNonVoter(t *testing.T) {
 	}, func(err error) { must.NoError(t, err) })
 }
 
+func TestAutopilot_ReadReplicaStaysNonVoter(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // reduces test log noise
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // reduces test log noise
+		c.BootstrapExpect = 0
+		c.ReadReplica = true
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+
+	// Wait for the read replica to be healthy for longer than the server
+	// stabilization time, after which a regular server would be promoted.
+	testutil.WaitForResultUntil(10*time.Second, func() (bool, error) {
+		health := s1.GetClusterHealth()
+		if health == nil || len(health.Servers) != 2 {
+			return false, fmt.Errorf("expected 2 servers, got: %#v", health)
+		}
+		for _, srv := range health.Servers {
+			if srv.ID != s2.config.NodeID {
+				continue
+			}
+			if !srv.ReadReplica {
+				return false, fmt.Errorf("expected server to be a read replica: %#v", srv)
+			}
+			if !srv.Healthy || time.Since(srv.StableSince) < time.Second {
+				return false, fmt.Errorf("expected server to be stable: %#v", srv)
+			}
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+
+	future := s1.raft.GetConfiguration()
+	must.NoError(t, future.Error())
+	for _, srv := range future.Configuration().Servers {
+		if srv.ID == raft.ServerID(s2.config.NodeID) {
+			must.Eq(t, raft.Nonvoter, srv.Suffrage)
+		}
+	}
+
+	// The read replica serves stale reads from its own state
+	codec := rpcClient(t, s2)
+	defer codec.Close()
+	req := &structs.JobListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     "global",
+			Namespace:  structs.DefaultNamespace,
+			AllowStale: true,
+		},
+	}
+	var resp structs.JobListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.List", req, &resp))
+	must.True(t, resp.KnownLeader)
+}
+
+func TestAutopilot_readReplicaPromoter(t *testing.T) {
+	ci.Parallel(t)
+
+	stable := autopilot.ServerHealth{Healthy: true, StableSince: time.Now().Add(-time.Hour)}
+	state := &autopilot.State{
+		Servers: map[raft.ServerID]*autopilot.ServerState{
+			"leader": {
+				Server: autopilot.Server{ID: "leader"},
+				State:  autopilot.RaftLeader,
+				Health: stable,
+			},
+			"server": {
+				Server: autopilot.Server{ID: "server"},
+				State:  autopilot.RaftNonVoter,
+				Health: stable,
+			},
+			"replica": {
+				Server: autopilot.Server{ID: "replica", Meta: map[string]string{readReplicaTag: "1"}},
+				State:  autopilot.RaftNonVoter,
+				Health: stable,
+			},
+			"voting-replica": {
+				Server: autopilot.Server{ID: "voting-replica", Meta: map[string]string{readReplicaTag: "1"}},
+				State:  autopilot.RaftVoter,
+				Health: stable,
+			},
+		},
+	}
+
+	promoter := &readReplicaPromoter{autopilot.DefaultPromoter()}
+	changes := promoter.CalculatePromotionsAndDemotions(&autopilot.Config{}, state)
+	must.Eq(t, []raft.ServerID{"server"}, changes.Promotions)
+	must.Eq(t, []raft.ServerID{"voting-replica"}, changes.Demotions)
+}
+
 func TestAutopilot_ReturnAutopilotHealth(t *testing.T) {
 	ci.Parallel(t)
 	s1, cleanupS1 := TestServer(t, func(c *Config) {
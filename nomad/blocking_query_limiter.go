@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net/http"
+	"sync"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// blockingQueryLimiter limits the number of concurrent blocking queries of
+// each caller, so a single misbehaving watcher can't tie up the goroutines
+// and memory of the server at the expense of the other callers. Callers are
+// limited both by their token and by their IP address.
+type blockingQueryLimiter struct {
+	perToken int
+	perIP    int
+
+	l      sync.Mutex
+	tokens map[string]int
+	ips    map[string]int
+}
+
+func newBlockingQueryLimiter(perToken, perIP int) *blockingQueryLimiter {
+	return &blockingQueryLimiter{
+		perToken: perToken,
+		perIP:    perIP,
+		tokens:   make(map[string]int),
+		ips:      make(map[string]int),
+	}
+}
+
+// acquire reserves a blocking query for the identity. It returns a function
+// that must be called once the query completes, or a coded error if the
+// identity has reached one of the limits. Forwarded queries are only limited
+// by token, since their IP address is the one of the forwarding server.
+func (b *blockingQueryLimiter) acquire(identity *structs.AuthenticatedIdentity, forwarded bool) (func(), error) {
+	token, ip := blockingQueryKeys(identity)
+	if b.perToken <= 0 {
+		token = ""
+	}
+	if b.perIP <= 0 || forwarded {
+		ip = ""
+	}
+	if token == "" && ip == "" {
+		return func() {}, nil
+	}
+
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if token != "" && b.tokens[token] >= b.perToken {
+		metrics.IncrCounterWithLabels([]string{"nomad", "rpc", "blocking_query", "rejected"}, 1,
+			[]metrics.Label{{Name: "limit", Value: "token"}})
+		return nil, structs.NewErrRPCCodedf(http.StatusServiceUnavailable,
+			"%s for %s: limit is %d", structs.ErrTooManyBlockingQueries, token, b.perToken)
+	}
+	if ip != "" && b.ips[ip] >= b.perIP {
+		metrics.IncrCounterWithLabels([]string{"nomad", "rpc", "blocking_query", "rejected"}, 1,
+			[]metrics.Label{{Name: "limit", Value: "ip"}})
+		return nil, structs.NewErrRPCCodedf(http.StatusServiceUnavailable,
+			"%s for IP %s: limit is %d", structs.ErrTooManyBlockingQueries, ip, b.perIP)
+	}
+
+	if token != "" {
+		b.tokens[token]++
+	}
+	if ip != "" {
+		b.ips[ip]++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { b.release(token, ip) })
+	}, nil
+}
+
+func (b *blockingQueryLimiter) release(token, ip string) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if token != "" {
+		if b.tokens[token] <= 1 {
+			delete(b.tokens, token)
+		} else {
+			b.tokens[token]--
+		}
+	}
+	if ip != "" {
+		if b.ips[ip] <= 1 {
+			delete(b.ips, ip)
+		} else {
+			b.ips[ip]--
+		}
+	}
+}
+
+// blockingQueryKeys returns the keys the blocking queries of the identity are
+// counted against. Anonymous callers share a token so they are only limited
+// by IP address.
+func blockingQueryKeys(identity *structs.AuthenticatedIdentity) (token, ip string) {
+	if identity == nil {
+		return "", ""
+	}
+	if identity.RemoteIP != nil {
+		ip = identity.RemoteIP.String()
+	}
+
+	aclToken := identity.GetACLToken()
+	if (aclToken != nil && aclToken != structs.AnonymousACLToken) ||
+		identity.GetClaims() != nil || identity.ClientID != "" {
+		token = identity.String()
+	}
+	return token, ip
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestBlockingQueryLimiter_acquire(t *testing.T) {
+	ci.Parallel(t)
+
+	token := mock.ACLToken()
+	ip := net.ParseIP("10.0.0.1")
+	tokenIdentity := &structs.AuthenticatedIdentity{ACLToken: token, RemoteIP: ip}
+	anonymous := &structs.AuthenticatedIdentity{ACLToken: structs.AnonymousACLToken, RemoteIP: ip}
+	otherIP := &structs.AuthenticatedIdentity{
+		ACLToken: structs.AnonymousACLToken, RemoteIP: net.ParseIP("10.0.0.2")}
+
+	b := newBlockingQueryLimiter(1, 2)
+
+	release, err := b.acquire(tokenIdentity, false)
+	must.NoError(t, err)
+
+	// The token is at its limit, even from another IP
+	_, err = b.acquire(&structs.AuthenticatedIdentity{
+		ACLToken: token, RemoteIP: net.ParseIP("10.0.0.3")}, false)
+	must.ErrorContains(t, err, structs.ErrTooManyBlockingQueries.Error())
+	must.ErrorContains(t, err, token.AccessorID)
+
+	// Anonymous callers are only limited by IP
+	releaseAnon, err := b.acquire(anonymous, false)
+	must.NoError(t, err)
+	_, err = b.acquire(anonymous, false)
+	must.ErrorContains(t, err, "for IP 10.0.0.1")
+
+	// Other callers are not affected
+	releaseOther, err := b.acquire(otherIP, false)
+	must.NoError(t, err)
+
+	// Forwarded queries are not limited by the IP of the forwarding server
+	releaseForwarded, err := b.acquire(anonymous, true)
+	must.NoError(t, err)
+	releaseForwarded()
+
+	// Releasing frees up the limits, and releasing twice is safe
+	release()
+	release()
+	releaseAnon()
+	releaseOther()
+	must.MapEmpty(t, b.tokens)
+	must.MapEmpty(t, b.ips)
+
+	release, err = b.acquire(tokenIdentity, false)
+	must.NoError(t, err)
+	release()
+
+	// A disabled limiter doesn't track queries
+	b = newBlockingQueryLimiter(0, 0)
+	for i := 0; i < 10; i++ {
+		_, err := b.acquire(tokenIdentity, false)
+		must.NoError(t, err)
+	}
+	must.MapEmpty(t, b.ips)
+}
+
+func TestBlockingQueryLimiter_RPC(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.BlockingQueriesPerIP = 1
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	req := &structs.JobSpecificRequest{
+		JobID: uuid.Generate(),
+		QueryOptions: structs.QueryOptions{
+			Region:        "global",
+			Namespace:     structs.DefaultNamespace,
+			MinQueryIndex: 1000,
+			MaxQueryTime:  time.Second,
+		},
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var resp structs.SingleJobResponse
+		errCh <- msgpackrpc.CallWithCodec(rpcClient(t, s1), "Job.GetJob", req, &resp)
+	}()
+
+	// Wait for the first query to block
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			s1.blockingQueries.l.Lock()
+			defer s1.blockingQueries.l.Unlock()
+			return len(s1.blockingQueries.ips) == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// A second blocking query from the same IP is rejected
+	var resp structs.SingleJobResponse
+	err := msgpackrpc.CallWithCodec(rpcClient(t, s1), "Job.GetJob", req, &resp)
+	must.ErrorContains(t, err, structs.ErrTooManyBlockingQueries.Error())
+
+	// Non-blocking queries are not limited
+	nonBlocking := *req
+	nonBlocking.MinQueryIndex = 0
+	must.NoError(t, msgpackrpc.CallWithCodec(rpcClient(t, s1), "Job.GetJob", &nonBlocking, &resp))
+
+	must.NoError(t, <-errCh)
+}
@@ -6,6 +6,7 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -109,6 +110,8 @@ func (a *Agent) Profile(args *structs.AgentPprofRequest, reply *structs.AgentPpr
 		resp, headers, err = pprof.Profile(args.Profile, args.Debug, args.GC)
 	case pprof.TraceReq:
 		resp, headers, err = pprof.Trace(a.srv.shutdownCtx, args.Seconds)
+	case pprof.SchedulerTraceReq:
+		resp, headers, err = a.schedulerTrace(args.Seconds)
 	default:
 		err = structs.NewErrRPCCoded(404, "Unknown profile request type")
 	}
@@ -117,6 +120,9 @@ func (a *Agent) Profile(args *structs.AgentPprofRequest, reply *structs.AgentPpr
 		if pprof.IsErrProfileNotFound(err) {
 			return structs.NewErrRPCCoded(404, err.Error())
 		}
+		if errors.Is(err, errSchedulerTraceRunning) {
+			return structs.NewErrRPCCoded(409, err.Error())
+		}
 		return structs.NewErrRPCCoded(500, err.Error())
 	}
 
@@ -296,6 +302,24 @@ OUTER:
 	}
 }
 
+// schedulerTrace runs a scheduler trace on this server and returns it JSON
+// encoded.
+func (a *Agent) schedulerTrace(seconds int) ([]byte, map[string]string, error) {
+	trace, err := a.srv.schedulerTrace(a.srv.shutdownCtx, seconds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf, err := json.Marshal(trace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buf, map[string]string{
+		"Content-Type": "application/json",
+	}, nil
+}
+
 // forwardFor returns a serverParts for a request to be forwarded to.
 // A response of nil, nil indicates that the current server is equal to the
 // serverID and region so the request should not be forwarded.
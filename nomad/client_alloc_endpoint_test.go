@@ -657,6 +657,90 @@ func TestClientAllocations_Stats_Local(t *testing.T) {
 	require.NotNil(resp2.Stats)
 }
 
+func TestClientAllocations_StatsStream_Local(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a clients")
+	})
+
+	// Force an allocation onto the node
+	a := mock.BatchAlloc()
+	a.NodeID = c.NodeID()
+	a.Job.TaskGroups[0].Count = 1
+	a.Job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+
+	state := s.State()
+	must.NoError(t, state.UpsertJob(nstructs.MsgTypeTestSetup, 999, nil, a.Job))
+	must.NoError(t, state.UpsertAllocs(nstructs.MsgTypeTestSetup, 1003, []*nstructs.Allocation{a}))
+
+	// Wait for the client to run the allocation
+	testutil.WaitForResult(func() (bool, error) {
+		alloc, err := state.AllocByID(nil, a.ID)
+		if err != nil {
+			return false, err
+		}
+		if alloc == nil {
+			return false, fmt.Errorf("unknown alloc")
+		}
+		if alloc.ClientStatus != nstructs.AllocClientStatusRunning {
+			return false, fmt.Errorf("alloc client status: %v", alloc.ClientStatus)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("Alloc on node %q not running: %v", c.NodeID(), err)
+	})
+
+	handler, err := s.StreamingRpcHandler("Allocations.StatsStream")
+	must.NoError(t, err)
+
+	// stream opens a stream of the stats of the allocation and returns the
+	// first message received.
+	stream := func(allocID string) *cstructs.StreamErrWrapper {
+		p1, p2 := net.Pipe()
+		defer p1.Close()
+		go handler(p2)
+
+		req := &cstructs.AllocStatsStreamRequest{
+			AllocID:      allocID,
+			QueryOptions: nstructs.QueryOptions{Region: "global"},
+		}
+		must.NoError(t, codec.NewEncoder(p1, nstructs.MsgpackHandle).Encode(req))
+
+		var msg cstructs.StreamErrWrapper
+		must.NoError(t, codec.NewDecoder(p1, nstructs.MsgpackHandle).Decode(&msg))
+		return &msg
+	}
+
+	// Make the request without having an alloc id
+	msg := stream("")
+	must.NotNil(t, msg.Error)
+	must.Eq(t, "missing AllocID", msg.Error.Message)
+
+	// Make the request with the alloc id
+	msg = stream(a.ID)
+	must.Nil(t, msg.Error)
+
+	var stats cstructs.AllocResourceUsage
+	must.NoError(t, json.Unmarshal(msg.Payload, &stats))
+	must.MapContainsKey(t, stats.Tasks, a.Job.TaskGroups[0].Tasks[0].Name)
+}
+
 func TestClientAllocations_Stats_Local_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -814,6 +898,69 @@ func TestClientAllocations_Stats_Remote(t *testing.T) {
 	require.NotNil(resp.Stats)
 }
 
+func TestClientAllocations_TaskEnv_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Reading the job isn't enough to read the task environment
+	policyBad := mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadFS})
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid", policyGood)
+
+	// Upsert the allocation
+	state := s.State()
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(nstructs.MsgTypeTestSetup, 1010, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(nstructs.MsgTypeTestSetup, 1011, []*nstructs.Allocation{alloc}))
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: nstructs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenGood.SecretID,
+			ExpectedError: nstructs.ErrUnknownNodePrefix,
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: nstructs.ErrUnknownNodePrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := &cstructs.AllocTaskEnvRequest{
+				AllocID: alloc.ID,
+				Task:    "web",
+				QueryOptions: nstructs.QueryOptions{
+					AuthToken: c.Token,
+					Region:    "global",
+					Namespace: nstructs.DefaultNamespace,
+				},
+			}
+
+			var resp cstructs.AllocTaskEnvResponse
+			err := msgpackrpc.CallWithCodec(codec, "ClientAllocations.TaskEnv", req, &resp)
+			must.ErrorContains(t, err, c.ExpectedError)
+		})
+	}
+}
+
 func TestClientAllocations_Restart_Local(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -920,6 +1067,99 @@ func TestClientAllocations_Restart_Local(t *testing.T) {
 	})
 }
 
+func TestClientAllocations_Batch_Restart_Local(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a client")
+	})
+
+	// Force an allocation onto the node
+	a := mock.Alloc()
+	a.Job.Type = nstructs.JobTypeService
+	a.NodeID = c.NodeID()
+	a.Job.TaskGroups[0].Count = 1
+	a.Job.TaskGroups[0].Tasks[0] = &nstructs.Task{
+		Name:   "web",
+		Driver: "mock_driver",
+		Config: map[string]interface{}{
+			"run_for": "10s",
+		},
+		LogConfig: nstructs.DefaultLogConfig(),
+		Resources: &nstructs.Resources{
+			CPU:      500,
+			MemoryMB: 256,
+		},
+	}
+
+	state := s.State()
+	must.NoError(t, state.UpsertJob(nstructs.MsgTypeTestSetup, 999, nil, a.Job))
+	must.NoError(t, state.UpsertAllocs(nstructs.MsgTypeTestSetup, 1003, []*nstructs.Allocation{a}))
+
+	// Wait for the client to run the allocation
+	testutil.WaitForResult(func() (bool, error) {
+		alloc, err := state.AllocByID(nil, a.ID)
+		if err != nil {
+			return false, err
+		}
+		if alloc == nil {
+			return false, fmt.Errorf("unknown alloc")
+		}
+		if alloc.ClientStatus != nstructs.AllocClientStatusRunning {
+			return false, fmt.Errorf("alloc client status: %v", alloc.ClientStatus)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("Alloc on node %q not running: %v", c.NodeID(), err)
+	})
+
+	// Restart all the allocations of the job
+	req := &nstructs.AllocBatchRequest{
+		Action: nstructs.AllocBatchActionRestart,
+		JobID:  a.JobID,
+		WriteRequest: nstructs.WriteRequest{
+			Region:    "global",
+			Namespace: a.Namespace,
+		},
+	}
+	var resp nstructs.AllocBatchResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Batch", req, &resp))
+	must.Len(t, 1, resp.Results)
+	must.Eq(t, a.ID, resp.Results[0].AllocID)
+	must.Eq(t, "", resp.Results[0].Error)
+
+	testutil.WaitForResult(func() (bool, error) {
+		alloc, err := state.AllocByID(nil, a.ID)
+		if err != nil {
+			return false, err
+		}
+		taskState := alloc.TaskStates["web"]
+		if taskState == nil {
+			return false, fmt.Errorf("could not find task state")
+		}
+		if taskState.Restarts != 1 {
+			return false, fmt.Errorf("expected task 'web' to have 1 restart, got: %d", taskState.Restarts)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("Alloc on node %q not restarted: %v", c.NodeID(), err)
+	})
+}
+
 func TestClientAllocations_Restart_Remote(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -102,10 +102,23 @@ type Config struct {
 	// RaftTimeout is applied to any network traffic for raft. Defaults to 10s.
 	RaftTimeout time.Duration
 
+	// RaftSnapshotPersistRate is the maximum number of bytes per second
+	// written when persisting a state snapshot. Zero means unlimited.
+	RaftSnapshotPersistRate uint64
+
 	// (Enterprise-only) NonVoter is used to prevent this server from being added
 	// as a voting member of the Raft cluster.
 	NonVoter bool
 
+	// ReadReplica makes this server join the Raft cluster as a permanent
+	// non-voter that serves stale reads. Autopilot never promotes read
+	// replicas to voters.
+	ReadReplica bool
+
+	// IdentityMetaClaims is the list of job meta keys that workload
+	// identities are allowed to request as claims.
+	IdentityMetaClaims []string
+
 	// (Enterprise-only) RedundancyZone is the redundancy zone to use for this server.
 	RedundancyZone string
 
@@ -145,6 +158,24 @@ type Config struct {
 	// from doing any scheduling work.
 	NumSchedulers int
 
+	// PlanEvaluatePoolMin and PlanEvaluatePoolMax bound the number of workers
+	// used by the plan applier to evaluate plans. Zero values use a default
+	// based on the number of CPUs. They are overridden by the values of the
+	// scheduler configuration if set.
+	PlanEvaluatePoolMin int
+	PlanEvaluatePoolMax int
+
+	// JobRegisterConcurrency is the maximum number of job registrations the
+	// leader processes at the same time. Registrations over the limit wait in
+	// a queue which admits them round robin across the submitting identities.
+	// Zero disables the limit.
+	JobRegisterConcurrency int
+
+	// DisableParallelPlanApply forces the plan applier to wait for each plan
+	// to be committed before applying the next one, even if the plans modify
+	// disjoint sets of nodes, jobs and deployments.
+	DisableParallelPlanApply bool
+
 	// EnabledSchedulers controls the set of sub-schedulers that are
 	// enabled for this server to handle. This will restrict the evaluations
 	// that the workers dequeue for processing.
@@ -308,6 +339,12 @@ type Config struct {
 	// of all the heartbeats.
 	FailoverHeartbeatTTL time.Duration
 
+	// PlanQueueAgingInterval is how long a plan can wait in the plan queue
+	// before its effective priority is boosted, so that plans of low priority
+	// jobs are not starved by plans of higher priority jobs. Each further
+	// interval boosts it again, up to JobMaxPriority. Zero disables aging.
+	PlanQueueAgingInterval time.Duration
+
 	// ConsulConfigs is a map of Consul configurations, here to support features
 	// in Nomad Enterprise. The default Consul config pointer above will be
 	// found in this map under the name "default"
@@ -403,6 +440,15 @@ type Config struct {
 	// connections from a single IP address. nil/0 means no limit.
 	RPCMaxConnsPerClient int
 
+	// BlockingQueriesPerToken is the maximum number of concurrent blocking
+	// queries served for a single ACL token, workload identity or client
+	// node. 0 means no limit.
+	BlockingQueriesPerToken int
+
+	// BlockingQueriesPerIP is the maximum number of concurrent blocking
+	// queries served for a single IP address. 0 means no limit.
+	BlockingQueriesPerIP int
+
 	// LicenseConfig stores information about the Enterprise license loaded for the server.
 	LicenseConfig *LicenseConfig
 
@@ -420,6 +466,22 @@ type Config struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
 
+	// DeploymentWebhooks are the webhooks the leader notifies of deployment
+	// status transitions.
+	DeploymentWebhooks []*config.DeploymentWebhookConfig
+
+	// DeploymentMetrics configures the metric provider used to evaluate the
+	// rollback_on block of job update strategies.
+	DeploymentMetrics *config.DeploymentMetricsConfig
+
+	// SnapshotUpload configures the periodic upload of state snapshots to
+	// object storage by the leader.
+	SnapshotUpload *config.SnapshotUploadConfig
+
+	// JobAdmissionWebhook configures an external service called on job
+	// registration to mutate or reject jobs.
+	JobAdmissionWebhook *config.JobAdmissionWebhookConfig
+
 	// JobDefaultPriority is the default Job priority if not specified.
 	JobDefaultPriority int
 
@@ -459,6 +521,7 @@ func (c *Config) Copy() *Config {
 	nc.RaftConfig = pointer.Copy(c.RaftConfig)
 	nc.SerfConfig = pointer.Copy(c.SerfConfig)
 	nc.EnabledSchedulers = slices.Clone(c.EnabledSchedulers)
+	nc.IdentityMetaClaims = slices.Clone(c.IdentityMetaClaims)
 	nc.ConsulConfigs = helper.DeepCopyMap(c.ConsulConfigs)
 	nc.VaultConfigs = helper.DeepCopyMap(c.VaultConfigs)
 	nc.TLSConfig = c.TLSConfig.Copy()
@@ -467,6 +530,10 @@ func (c *Config) Copy() *Config {
 	nc.LicenseConfig = c.LicenseConfig.Copy()
 	nc.SearchConfig = c.SearchConfig.Copy()
 	nc.KEKProviderConfigs = helper.CopySlice(c.KEKProviderConfigs)
+	nc.DeploymentWebhooks = config.CopyDeploymentWebhooks(c.DeploymentWebhooks)
+	nc.DeploymentMetrics = c.DeploymentMetrics.Copy()
+	nc.SnapshotUpload = c.SnapshotUpload.Copy()
+	nc.JobAdmissionWebhook = c.JobAdmissionWebhook.Copy()
 
 	return &nc
 }
@@ -131,16 +131,12 @@ func (c *CoreScheduler) jobGC(eval *structs.Evaluation, customThreshold *time.Du
 		return err
 	}
 
-	var threshold time.Duration
-	threshold = c.srv.config.JobGCThreshold
-
-	// custom threshold override
-	if customThreshold != nil {
-		threshold = *customThreshold
+	cutoffTimeFor, err := c.namespaceCutoffTimes(ws, c.srv.config.JobGCThreshold, customThreshold,
+		func(gc *structs.NamespaceGCConfiguration) time.Duration { return gc.JobGCThreshold })
+	if err != nil {
+		return err
 	}
 
-	cutoffTime := c.getCutoffTime(threshold)
-
 	// Collect the allocations, evaluations and jobs to GC
 	var gcAlloc, gcEval []string
 	var gcJob []*structs.Job
@@ -148,6 +144,7 @@ func (c *CoreScheduler) jobGC(eval *structs.Evaluation, customThreshold *time.Du
 OUTER:
 	for i := iter.Next(); i != nil; i = iter.Next() {
 		job := i.(*structs.Job)
+		cutoffTime := cutoffTimeFor(job.Namespace)
 
 		// Ignore new jobs.
 		st := time.Unix(0, job.SubmitTime)
@@ -275,27 +272,25 @@ func (c *CoreScheduler) evalGC(customThreshold *time.Duration) error {
 		return err
 	}
 
-	var threshold, batchThreshold time.Duration
-	threshold = c.srv.config.EvalGCThreshold
-	batchThreshold = c.srv.config.BatchEvalGCThreshold
-
-	// custom threshold override
-	if customThreshold != nil {
-		threshold = *customThreshold
-		batchThreshold = *customThreshold
+	cutoffTimeFor, err := c.namespaceCutoffTimes(ws, c.srv.config.EvalGCThreshold, customThreshold,
+		func(gc *structs.NamespaceGCConfiguration) time.Duration { return gc.EvalGCThreshold })
+	if err != nil {
+		return err
+	}
+	batchCutoffTimeFor, err := c.namespaceCutoffTimes(ws, c.srv.config.BatchEvalGCThreshold, customThreshold,
+		func(gc *structs.NamespaceGCConfiguration) time.Duration { return gc.BatchEvalGCThreshold })
+	if err != nil {
+		return err
 	}
-
-	cutoffTime := c.getCutoffTime(threshold)
-	batchCutoffTime := c.getCutoffTime(batchThreshold)
 
 	// Collect the allocations and evaluations to GC
 	var gcAlloc, gcEval []string
 	for raw := iter.Next(); raw != nil; raw = iter.Next() {
 		eval := raw.(*structs.Evaluation)
 
-		gcCutoffTime := cutoffTime
+		gcCutoffTime := cutoffTimeFor(eval.Namespace)
 		if eval.Type == structs.JobTypeBatch {
-			gcCutoffTime = batchCutoffTime
+			gcCutoffTime = batchCutoffTimeFor(eval.Namespace)
 		}
 
 		gc, allocs, err := c.gcEval(eval, gcCutoffTime, false)
@@ -575,14 +570,11 @@ func (c *CoreScheduler) deploymentGC(customThreshold *time.Duration) error {
 		return err
 	}
 
-	var threshold time.Duration
-	threshold = c.srv.config.DeploymentGCThreshold
-
-	// custom threshold override
-	if customThreshold != nil {
-		threshold = *customThreshold
+	cutoffTimeFor, err := c.namespaceCutoffTimes(ws, c.srv.config.DeploymentGCThreshold, customThreshold,
+		func(gc *structs.NamespaceGCConfiguration) time.Duration { return gc.DeploymentGCThreshold })
+	if err != nil {
+		return err
 	}
-	cutoffTime := c.getCutoffTime(threshold)
 
 	// Collect the deployments to GC
 	var gcDeployment []string
@@ -597,7 +589,7 @@ OUTER:
 
 		// Ignore non-terminal and new deployments
 		mt := time.Unix(0, deploy.ModifyTime)
-		if deploy.Active() || mt.After(cutoffTime) {
+		if deploy.Active() || mt.After(cutoffTimeFor(deploy.Namespace)) {
 			continue
 		}
 
@@ -1357,3 +1349,41 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 func (c *CoreScheduler) getCutoffTime(configThreshold time.Duration) time.Time {
 	return time.Now().UTC().Add(-1 * configThreshold)
 }
+
+// namespaceCutoffTimes returns a function that looks up the GC cutoff time for
+// objects in a given namespace. Namespaces may override the server's
+// threshold in their GC configuration, as selected by nsThreshold. A custom
+// threshold overrides both the server and namespace thresholds.
+func (c *CoreScheduler) namespaceCutoffTimes(ws memdb.WatchSet, threshold time.Duration,
+	customThreshold *time.Duration,
+	nsThreshold func(*structs.NamespaceGCConfiguration) time.Duration) (func(string) time.Time, error) {
+
+	if customThreshold != nil {
+		cutoffTime := c.getCutoffTime(*customThreshold)
+		return func(string) time.Time { return cutoffTime }, nil
+	}
+
+	iter, err := c.snap.Namespaces(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]time.Time)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+		if ns.GCConfiguration == nil {
+			continue
+		}
+		if nsThresh := nsThreshold(ns.GCConfiguration); nsThresh > 0 {
+			overrides[ns.Name] = c.getCutoffTime(nsThresh)
+		}
+	}
+
+	cutoffTime := c.getCutoffTime(threshold)
+	return func(namespace string) time.Time {
+		if t, ok := overrides[namespace]; ok {
+			return t
+		}
+		return cutoffTime
+	}, nil
+}
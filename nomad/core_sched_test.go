@@ -268,6 +268,58 @@ func TestCoreScheduler_EvalGC_StoppedJob_Reschedulable(t *testing.T) {
 	must.Nil(t, outA)
 }
 
+// TestCoreScheduler_EvalGC_NamespaceThreshold asserts that a namespace GC
+// configuration overrides the server eval GC threshold for its evals only.
+func TestCoreScheduler_EvalGC_NamespaceThreshold(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EvalGCThreshold = time.Hour
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	ns := mock.Namespace()
+	ns.GCConfiguration = &structs.NamespaceGCConfiguration{
+		EvalGCThreshold: time.Minute,
+	}
+	must.NoError(t, store.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	// Insert "dead" evals in both namespaces, older than the namespace
+	// threshold but newer than the server threshold
+	newEval := func(namespace string) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.CreateTime = time.Now().UTC().Add(-20 * time.Minute).UnixNano()
+		eval.ModifyTime = time.Now().UTC().Add(-10 * time.Minute).UnixNano()
+		eval.Status = structs.EvalStatusFailed
+		return eval
+	}
+	defaultEval := newEval(structs.DefaultNamespace)
+	nsEval := newEval(ns.Name)
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Evaluation{defaultEval, nsEval}))
+
+	// Create a core scheduler
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	// Attempt the GC
+	gc := s1.coreJobEval(structs.CoreJobEvalGC, 2000)
+	must.NoError(t, core.Process(gc))
+
+	ws := memdb.NewWatchSet()
+	out, err := store.EvalByID(ws, nsEval.ID)
+	must.NoError(t, err)
+	must.Nil(t, out, must.Sprint("expected namespace eval to be GC'd"))
+
+	out, err = store.EvalByID(ws, defaultEval.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out, must.Sprint("expected default namespace eval to be kept"))
+}
+
 // An EvalGC should never reap a batch job that has not been stopped
 func TestCoreScheduler_EvalGC_Batch(t *testing.T) {
 	ci.Parallel(t)
@@ -6,6 +6,7 @@ package nomad
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -210,6 +211,15 @@ func (d *Deployment) Promote(args *structs.DeploymentPromoteRequest, reply *stru
 	if args.DeploymentID == "" {
 		return fmt.Errorf("missing deployment ID")
 	}
+	if args.CanaryCount < 0 {
+		return fmt.Errorf("canary count must not be negative")
+	}
+	if args.CanaryPercent < 0 || args.CanaryPercent > 100 {
+		return fmt.Errorf("canary percent must be between 0 and 100")
+	}
+	if args.CanaryCount > 0 && args.CanaryPercent > 0 {
+		return fmt.Errorf("canary count and canary percent can't both be set")
+	}
 
 	// Lookup the deployment
 	snap, err := d.srv.fsm.State().Snapshot()
@@ -609,6 +619,135 @@ func (d *Deployment) Allocations(args *structs.DeploymentSpecificRequest, reply
 	return d.srv.blockingRPC(&opts)
 }
 
+// Multiregion returns the deployments paired with a multiregion deployment in
+// each region of its job, so operators can see which regions are blocking the
+// deployment without querying each region.
+func (d *Deployment) Multiregion(args *structs.DeploymentSpecificRequest,
+	reply *structs.DeploymentMultiregionResponse) error {
+
+	authErr := d.srv.Authenticate(d.ctx, args)
+	if done, err := d.srv.forward("Deployment.Multiregion", args, args, reply); done {
+		return err
+	}
+	d.srv.MeasureRPCRate("deployment", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "deployment", "multiregion"}, time.Now())
+
+	// Validate the arguments
+	if args.DeploymentID == "" {
+		return fmt.Errorf("missing deployment ID")
+	}
+
+	// Lookup the deployment
+	snap, err := d.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	deploy, err := snap.DeploymentByID(ws, args.DeploymentID)
+	if err != nil {
+		return err
+	}
+	if deploy == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, "deployment not found")
+	}
+
+	// Check namespace read-job permissions
+	if aclObj, err := d.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(deploy.Namespace, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if !deploy.IsMultiregion {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "deployment is not part of a multiregion deployment")
+	}
+
+	job, err := snap.JobByIDAndVersion(ws, deploy.Namespace, deploy.JobID, deploy.JobVersion)
+	if err != nil {
+		return err
+	}
+	if job == nil || !job.IsMultiregion() {
+		return structs.NewErrRPCCodedf(http.StatusNotFound,
+			"job %q at version %d not found", deploy.JobID, deploy.JobVersion)
+	}
+
+	// Fetch the paired deployments from the peer regions concurrently
+	regions := make([]*structs.MultiregionDeploymentRegion, len(job.Multiregion.Regions))
+	var wg sync.WaitGroup
+	for i, region := range job.Multiregion.Regions {
+		if region.Name == d.srv.Region() {
+			regions[i] = newMultiregionDeploymentRegion(region.Name, deploy, nil)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			peer, err := d.peerDeployment(region.Name, deploy, args.AuthToken)
+			regions[i] = newMultiregionDeploymentRegion(region.Name, peer, err)
+		}()
+	}
+	wg.Wait()
+
+	reply.Regions = regions
+	reply.Index = deploy.ModifyIndex
+	d.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// peerDeployment returns the deployment of the same job version in the peer
+// region.
+func (d *Deployment) peerDeployment(region string, deploy *structs.Deployment, authToken string) (*structs.Deployment, error) {
+	req := &structs.JobSpecificRequest{
+		JobID: deploy.JobID,
+		All:   true,
+		QueryOptions: structs.QueryOptions{
+			Region:    region,
+			Namespace: deploy.Namespace,
+			AuthToken: authToken,
+		},
+	}
+
+	var resp structs.DeploymentListResponse
+	if err := d.srv.forwardRegion(region, "Job.Deployments", req, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, peer := range resp.Deployments {
+		if peer.JobVersion == deploy.JobVersion {
+			return peer, nil
+		}
+	}
+	return nil, fmt.Errorf("no deployment found for job version %d", deploy.JobVersion)
+}
+
+// newMultiregionDeploymentRegion returns the state of a multiregion deployment
+// in the region. A region is blocking until its deployment is blocked waiting
+// for its peers or has completed.
+func newMultiregionDeploymentRegion(region string, deploy *structs.Deployment, err error) *structs.MultiregionDeploymentRegion {
+	r := &structs.MultiregionDeploymentRegion{
+		Region:     region,
+		Deployment: deploy,
+		Blocking:   true,
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+
+	switch deploy.Status {
+	case structs.DeploymentStatusBlocked,
+		structs.DeploymentStatusUnblocking,
+		structs.DeploymentStatusSuccessful:
+		r.Blocking = false
+	}
+	return r
+}
+
 // Reap is used to cleanup terminal deployments
 func (d *Deployment) Reap(args *structs.DeploymentDeleteRequest,
 	reply *structs.GenericResponse) error {
@@ -538,6 +538,72 @@ func TestDeploymentEndpoint_Promote(t *testing.T) {
 	assert.True(dout.TaskGroups["web"].Promoted, "web group should be promoted")
 }
 
+func TestDeploymentEndpoint_Promote_Partial(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the deployment, job and two healthy canaries
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.MaxParallel = 2
+	j.TaskGroups[0].Update.Canary = 2
+	d := mock.Deployment()
+	d.TaskGroups["web"].DesiredCanaries = 2
+	d.JobID = j.ID
+	d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	var canaries []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		a := mock.Alloc()
+		a.DeploymentID = d.ID
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy:   pointer.Of(true),
+			Canary:    true,
+			Timestamp: time.Now().Add(time.Duration(i) * time.Minute),
+		}
+		d.TaskGroups["web"].PlacedCanaries = append(d.TaskGroups["web"].PlacedCanaries, a.ID)
+		canaries = append(canaries, a)
+	}
+
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, j))
+	must.NoError(t, state.UpsertDeployment(1000, d))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, canaries))
+
+	req := &structs.DeploymentPromoteRequest{
+		DeploymentID: d.ID,
+		All:          true,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.DeploymentUpdateResponse
+
+	// Invalid partial promotions are rejected
+	req.CanaryCount, req.CanaryPercent = 1, 50
+	err := msgpackrpc.CallWithCodec(codec, "Deployment.Promote", req, &resp)
+	must.ErrorContains(t, err, "canary count and canary percent can't both be set")
+
+	req.CanaryCount, req.CanaryPercent = 0, 150
+	err = msgpackrpc.CallWithCodec(codec, "Deployment.Promote", req, &resp)
+	must.ErrorContains(t, err, "canary percent must be between 0 and 100")
+
+	// Promote half the canaries
+	req.CanaryCount, req.CanaryPercent = 0, 50
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Deployment.Promote", req, &resp))
+	must.NotEq(t, "", resp.EvalID)
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusDescriptionRunningNeedsPromotion, dout.StatusDescription)
+	must.False(t, dout.TaskGroups["web"].Promoted)
+	must.Eq(t, []string{canaries[0].ID}, dout.TaskGroups["web"].PromotedCanaries)
+	must.Eq(t, []string{canaries[1].ID}, dout.TaskGroups["web"].PlacedCanaries)
+}
+
 func TestDeploymentEndpoint_Promote_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1759,3 +1825,84 @@ func TestDeploymentEndpoint_Reap(t *testing.T) {
 	assert.Nil(err, "DeploymentByID")
 	assert.Nil(outD, "Deleted Deployment")
 }
+
+func TestDeploymentEndpoint_Multiregion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.Region = "west"
+	})
+	defer cleanupS1()
+
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.Region = "east"
+	})
+	defer cleanupS2()
+
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+	codec := rpcClient(t, s1)
+
+	// Register the same multiregion job in both regions, with the region
+	// "north" missing from the cluster
+	j := mock.MultiregionJob()
+	j.Multiregion.Regions = []*structs.MultiregionRegion{
+		{Name: "west"}, {Name: "east"}, {Name: "north"},
+	}
+	must.NoError(t, s1.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, j.Copy()))
+	must.NoError(t, s2.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, j.Copy()))
+
+	// The west deployment is still running while the east deployment is
+	// blocked waiting for its peers
+	west := mock.Deployment()
+	west.JobID = j.ID
+	west.JobVersion = 0
+	west.IsMultiregion = true
+	must.NoError(t, s1.fsm.State().UpsertDeployment(1001, west))
+
+	east := mock.Deployment()
+	east.JobID = j.ID
+	east.JobVersion = 0
+	east.IsMultiregion = true
+	east.Status = structs.DeploymentStatusBlocked
+	east.StatusDescription = structs.DeploymentStatusDescriptionBlocked
+	must.NoError(t, s2.fsm.State().UpsertDeployment(1001, east))
+
+	get := &structs.DeploymentSpecificRequest{
+		DeploymentID: west.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "west",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.DeploymentMultiregionResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Deployment.Multiregion", get, &resp))
+	must.Len(t, 3, resp.Regions)
+
+	must.Eq(t, "west", resp.Regions[0].Region)
+	must.Eq(t, west.ID, resp.Regions[0].Deployment.ID)
+	must.True(t, resp.Regions[0].Blocking)
+
+	must.Eq(t, "east", resp.Regions[1].Region)
+	must.Eq(t, east.ID, resp.Regions[1].Deployment.ID)
+	must.False(t, resp.Regions[1].Blocking)
+
+	must.Eq(t, "north", resp.Regions[2].Region)
+	must.Nil(t, resp.Regions[2].Deployment)
+	must.True(t, resp.Regions[2].Blocking)
+	must.StrContains(t, resp.Regions[2].Error, "No path to region")
+
+	// Single region deployments are rejected
+	single := mock.Deployment()
+	must.NoError(t, s1.fsm.State().UpsertDeployment(1002, single))
+	get.DeploymentID = single.ID
+	err := msgpackrpc.CallWithCodec(codec, "Deployment.Multiregion", get, &resp)
+	must.ErrorContains(t, err, "not part of a multiregion deployment")
+
+	get.DeploymentID = uuid.Generate()
+	err = msgpackrpc.CallWithCodec(codec, "Deployment.Multiregion", get, &resp)
+	must.ErrorContains(t, err, "deployment not found")
+}
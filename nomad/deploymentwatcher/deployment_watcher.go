@@ -6,11 +6,13 @@ package deploymentwatcher
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -22,6 +24,15 @@ const (
 	// perJobEvalBatchPeriod is the batching length before creating an evaluation to
 	// trigger the scheduler when allocations are marked as healthy.
 	perJobEvalBatchPeriod = 1 * time.Second
+
+	// autoPromoteRetryInterval is the time to wait before retrying a failed
+	// auto promotion.
+	autoPromoteRetryInterval = 5 * time.Second
+
+	// failDeploymentBackoffBase and failDeploymentBackoffLimit bound the
+	// backoff between attempts to apply a pending failure.
+	failDeploymentBackoffBase  = 1 * time.Second
+	failDeploymentBackoffLimit = 30 * time.Second
 )
 
 var (
@@ -101,6 +112,10 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// metrics evaluates the rollback_on queries of the job's task groups. It
+	// is nil if no metric provider is configured.
+	metrics metricQuerier
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -112,7 +127,7 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC, metrics metricQuerier) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -125,6 +140,7 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		metrics:            metrics,
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
@@ -279,15 +295,24 @@ func (w *deploymentWatcher) PromoteDeployment(
 	return nil
 }
 
-// autoPromoteDeployment creates a synthetic promotion request, and upserts it for processing
-func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStub) error {
-	d := w.getDeployment()
+// autoPromoteDeployment creates a synthetic promotion request, and upserts it
+// for processing. If the canaries meet the auto promote threshold but haven't
+// been healthy for the minimum healthy time yet, it returns the time at which
+// the deployment can be promoted.
+func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStub, now time.Time) (time.Time, error) {
+	// Read the deployment from the state store rather than using the tracked
+	// one, which may not reflect a promotion that was just applied yet.
+	d, err := w.state.DeploymentByID(nil, w.deploymentID)
+	if err != nil || d == nil {
+		return time.Time{}, err
+	}
 	if !d.HasPlacedCanaries() || !d.RequiresPromotion() {
-		return nil
+		return time.Time{}, nil
 	}
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
+	var promoteAt time.Time
 	for _, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
@@ -296,30 +321,67 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			continue
 		}
 
-		if !dstate.AutoPromote || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
-			return nil
+		placed := len(dstate.PlacedCanaries) + len(dstate.PromotedCanaries)
+		if !dstate.AutoPromote || placed < dstate.DesiredCanaries {
+			return time.Time{}, nil
 		}
 
-		healthyCanaries := 0
-		// Find the health status of each canary
+		// Find the time at which each healthy canary was marked healthy
+		var healthyAt []time.Time
 		for _, c := range dstate.PlacedCanaries {
 			for _, a := range allocs {
 				if c == a.ID && a.DeploymentStatus.IsHealthy() {
-					healthyCanaries += 1
+					healthyAt = append(healthyAt, a.DeploymentStatus.Timestamp)
 				}
 			}
 		}
-		if healthyCanaries != dstate.DesiredCanaries {
-			return nil
+
+		// Canaries that were already promoted count towards the required
+		// canaries
+		required := dstate.HealthyCanariesRequired(true)
+		if required == 0 {
+			continue
+		}
+		if len(healthyAt) < required {
+			return time.Time{}, nil
+		}
+
+		// The threshold was met when the last of the required canaries was
+		// marked healthy
+		slices.SortFunc(healthyAt, time.Time.Compare)
+		groupPromoteAt := healthyAt[required-1].Add(dstate.AutoPromoteMinHealthyTime)
+		if groupPromoteAt.After(promoteAt) {
+			promoteAt = groupPromoteAt
 		}
 	}
 
+	if promoteAt.After(now) {
+		return promoteAt, nil
+	}
+
 	// Send the request
-	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
+	_, err = w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
 		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true},
+		AutoPromote:              true,
 		Eval:                     w.getEval(),
 	})
-	return err
+	return time.Time{}, err
+}
+
+// autoPromote automatically promotes the deployment if permitted, or resets
+// the timer to the time at which it can be promoted. A failed promotion is
+// retried by the timer rather than waiting for the next allocation update.
+func (w *deploymentWatcher) autoPromote(allocs []*structs.AllocListStub, timer *time.Timer) {
+	promoteAt, err := w.autoPromoteDeployment(allocs, time.Now())
+	if err != nil {
+		w.logger.Error("failed to auto promote deployment", "error", err)
+		timer.Reset(autoPromoteRetryInterval)
+		return
+	}
+	if !promoteAt.IsZero() {
+		w.logger.Trace("waiting to auto promote deployment", "promote_at", promoteAt)
+		timer.Reset(time.Until(promoteAt))
+	}
 }
 
 func (w *deploymentWatcher) PauseDeployment(
@@ -414,6 +476,14 @@ func (w *deploymentWatcher) StopWatch() {
 // failed and potentially rolling back the job. Progress can be made when an
 // allocation transitions to healthy, so we create an eval.
 func (w *deploymentWatcher) watch() {
+	// A previous leader may have decided to fail the deployment without
+	// applying the failure, in which case it is completed as decided.
+	if failure := w.getDeployment().PendingFailure; failure != nil {
+		w.logger.Debug("resuming pending deployment failure", "description", failure.StatusDescription)
+		w.applyFailure(failure)
+		return
+	}
+
 	// Get the deadline. This is likely a zero time to begin with but we need to
 	// handle the case that the deployment has already progressed and we are now
 	// just starting to watch it. This must likely would occur if there was a
@@ -429,11 +499,26 @@ func (w *deploymentWatcher) watch() {
 		deadlineTimer = time.NewTimer(time.Until(currentDeadline))
 	}
 
+	// The auto promote timer fires once the canaries have met the auto
+	// promote threshold for the minimum healthy time.
+	autoPromoteTimer, autoPromoteTimerStop := helper.NewStoppedTimer()
+	defer autoPromoteTimerStop()
+
+	// The metric ticker fires to evaluate the rollback_on queries of the
+	// job's task groups.
+	var metricCh <-chan time.Time
+	metricChecks := make(map[string]time.Time)
+	if interval := w.rollbackMetricInterval(); interval > 0 {
+		metricTicker := time.NewTicker(interval)
+		defer metricTicker.Stop()
+		metricCh = metricTicker.C
+	}
+
 	allocIndex := uint64(1)
 	allocsCh := w.getAllocsCh(allocIndex)
 	var updates *allocUpdates
 
-	rollback, deadlineHit := false, false
+	rollback, deadlineHit, metricBreach := false, false, false
 
 FAIL:
 	for {
@@ -466,6 +551,23 @@ FAIL:
 				w.logger.Error("multiregion deployment error", "error", err)
 			}
 			break FAIL
+		case <-autoPromoteTimer.C:
+			if updates == nil {
+				continue
+			}
+			w.autoPromote(updates.allocs, autoPromoteTimer)
+		case now := <-metricCh:
+			if !w.rollbackMetricBreached(now, metricChecks) {
+				continue
+			}
+
+			// A breached rollback metric always reverts the job
+			metricBreach, rollback = true, true
+			err := w.nextRegion(structs.DeploymentStatusFailed)
+			if err != nil {
+				w.logger.Error("multiregion deployment error", "error", err)
+			}
+			break FAIL
 		case <-w.deploymentUpdateCh:
 			// Get the updated deployment and check if we should change the
 			// deadline timer
@@ -537,10 +639,7 @@ FAIL:
 			}
 
 			// If permitted, automatically promote this canary deployment
-			err = w.autoPromoteDeployment(updates.allocs)
-			if err != nil {
-				w.logger.Error("failed to auto promote deployment", "error", err)
-			}
+			w.autoPromote(updates.allocs, autoPromoteTimer)
 
 			// Create an eval to push the deployment along
 			if res.createEval || len(res.allowReplacements) != 0 {
@@ -552,17 +651,35 @@ FAIL:
 		}
 	}
 
-	// Change the deployments status to failed
+	// Record the decision to fail the deployment before applying it, so a
+	// new leader completes it if this one stops before the failure is
+	// applied.
+	failure := w.decideFailure(rollback, metricBreach, deadlineHit)
+	d := w.getDeployment()
+	u := w.getDeploymentStatusUpdate(d.Status, d.StatusDescription)
+	u.PendingFailure = failure
+	if _, err := w.upsertDeploymentStatusUpdate(u, nil, nil); err != nil {
+		w.logger.Warn("failed to record pending deployment failure", "error", err)
+	}
+
+	w.applyFailure(failure)
+}
+
+// decideFailure returns the failure of the deployment, including the job
+// version to roll back to if the job should be rolled back.
+func (w *deploymentWatcher) decideFailure(rollback, metricBreach, deadlineHit bool) *structs.DeploymentFailure {
 	desc := structs.DeploymentStatusDescriptionFailedAllocations
-	if deadlineHit {
+	if metricBreach {
+		desc = structs.DeploymentStatusDescriptionRollbackMetric
+	} else if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
 	}
 
+	failure := &structs.DeploymentFailure{DecidedAt: time.Now().UTC().UnixNano()}
+
 	// Rollback to the old job if necessary
-	var j *structs.Job
 	if rollback {
-		var err error
-		j, err = w.latestStableJob()
+		j, err := w.latestStableJob()
 		if err != nil {
 			w.logger.Error("failed to lookup latest stable job", "error", err)
 		}
@@ -574,16 +691,143 @@ FAIL:
 		} else {
 			desc = structs.DeploymentStatusDescriptionNoRollbackTarget(desc)
 		}
+		if j != nil {
+			failure.RollbackJobVersion = pointer.Of(j.Version)
+		}
 	}
 
-	// Update the status of the deployment to failed and create an evaluation.
-	e := w.getEval()
-	u := w.getDeploymentStatusUpdate(structs.DeploymentStatusFailed, desc)
-	if _, err := w.upsertDeploymentStatusUpdate(u, e, j); err != nil {
+	failure.StatusDescription = desc
+	return failure
+}
+
+// applyFailure fails the deployment as decided by the failure, retrying until
+// it succeeds, the deployment is no longer active, or the watcher is stopped.
+func (w *deploymentWatcher) applyFailure(failure *structs.DeploymentFailure) {
+	for attempt := uint64(0); ; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-w.ctx.Done():
+				return
+			case <-time.After(helper.Backoff(failDeploymentBackoffBase, failDeploymentBackoffLimit, attempt)):
+			}
+		}
+
+		err := w.failDeployment(failure)
+		if err == nil {
+			return
+		}
 		w.logger.Error("failed to update deployment status", "error", err)
 	}
 }
 
+// failDeployment updates the status of the deployment to failed, rolls back
+// the job if the failure requires it, and creates an evaluation. It is a no-op
+// if the deployment is no longer active.
+func (w *deploymentWatcher) failDeployment(failure *structs.DeploymentFailure) error {
+	snap, err := w.state.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	d, err := snap.DeploymentByID(nil, w.deploymentID)
+	if err != nil {
+		return err
+	}
+	if d == nil || !d.Active() {
+		return nil
+	}
+
+	var j *structs.Job
+	if version := failure.RollbackJobVersion; version != nil {
+		j, err = snap.JobByIDAndVersion(nil, w.j.Namespace, w.j.ID, *version)
+		if err != nil {
+			return err
+		}
+		if j == nil {
+			w.logger.Warn("job version to roll back to no longer exists", "version", *version)
+		}
+	}
+
+	e := w.getEval()
+	u := w.getDeploymentStatusUpdate(structs.DeploymentStatusFailed, failure.StatusDescription)
+	_, err = w.upsertDeploymentStatusUpdate(u, e, j)
+	return err
+}
+
+// rollbackMetricInterval returns the interval at which the rollback_on
+// queries must be checked, which is the shortest interval of the job's task
+// groups. Zero is returned if there is nothing to check.
+func (w *deploymentWatcher) rollbackMetricInterval() time.Duration {
+	var interval time.Duration
+	for _, tg := range w.j.TaskGroups {
+		if tg.Update == nil || tg.Update.RollbackOn == nil {
+			continue
+		}
+		if w.metrics == nil {
+			w.logger.Warn("ignoring rollback_on because no deployment metric provider is configured",
+				"task_group", tg.Name)
+			return 0
+		}
+		if interval == 0 || tg.Update.RollbackOn.Interval < interval {
+			interval = tg.Update.RollbackOn.Interval
+		}
+	}
+	return interval
+}
+
+// rollbackMetricBreached evaluates the rollback_on queries of the task groups
+// that are due for a check and returns whether any of them breached its
+// threshold. Queries are only evaluated while the deployment is running and
+// the group is still within its progress deadline. The lastChecked map tracks
+// when each group was last evaluated.
+func (w *deploymentWatcher) rollbackMetricBreached(now time.Time, lastChecked map[string]time.Time) bool {
+	d := w.getDeployment()
+	if d.Status != structs.DeploymentStatusRunning {
+		return false
+	}
+
+	doneTGs := w.doneGroups(d)
+	for _, tg := range w.j.TaskGroups {
+		if tg.Update == nil || tg.Update.RollbackOn == nil {
+			continue
+		}
+		rollbackOn := tg.Update.RollbackOn
+
+		dstate, ok := d.TaskGroups[tg.Name]
+		if !ok || dstate.PlacedAllocs == 0 || doneTGs[tg.Name] {
+			continue
+		}
+		if !dstate.RequireProgressBy.IsZero() && now.After(dstate.RequireProgressBy) {
+			continue
+		}
+		if last, ok := lastChecked[tg.Name]; ok && now.Sub(last) < rollbackOn.Interval {
+			continue
+		}
+		lastChecked[tg.Name] = now
+
+		values, err := w.queryRollbackMetric(rollbackOn.Query)
+		if err != nil {
+			w.logger.Warn("failed to query rollback metric", "task_group", tg.Name, "error", err)
+			continue
+		}
+		for _, value := range values {
+			if rollbackOn.Breached(value) {
+				w.logger.Debug("rollback metric breached", "task_group", tg.Name,
+					"value", value, "threshold", rollbackOn.Threshold, "comparison", rollbackOn.Comparison)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (w *deploymentWatcher) queryRollbackMetric(query string) ([]float64, error) {
+	ctx, cancel := context.WithTimeout(w.ctx, metricQueryTimeout)
+	defer cancel()
+	return w.metrics.query(ctx, query)
+}
+
 // allocUpdateResult is used to return the desired actions given the newest set
 // of allocations for the deployment.
 type allocUpdateResult struct {
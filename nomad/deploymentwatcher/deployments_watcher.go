@@ -16,6 +16,7 @@ import (
 
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 const (
@@ -91,6 +92,14 @@ type Watcher struct {
 	// allocation desired transition updates
 	allocUpdateBatcher *AllocUpdateBatcher
 
+	// webhooks delivers deployment status transitions to the configured
+	// webhooks. It is nil if no webhooks are configured.
+	webhooks *webhookNotifier
+
+	// metrics evaluates the rollback_on queries of deployments. It is nil
+	// if no metric provider is configured.
+	metrics metricQuerier
+
 	// ctx and exitFn are used to cancel the watcher
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -105,9 +114,11 @@ func NewDeploymentsWatcher(logger log.Logger,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
+	webhooks []*config.DeploymentWebhookConfig,
+	metrics *config.DeploymentMetricsConfig,
 ) *Watcher {
 
-	return &Watcher{
+	w := &Watcher{
 		raft:                raft,
 		deploymentRPC:       deploymentRPC,
 		jobRPC:              jobRPC,
@@ -115,6 +126,13 @@ func NewDeploymentsWatcher(logger log.Logger,
 		updateBatchDuration: updateBatchDuration,
 		logger:              logger.Named("deployments_watcher"),
 	}
+	if len(webhooks) > 0 {
+		w.webhooks = newWebhookNotifier(w.logger, webhooks)
+	}
+	if metrics != nil && metrics.PrometheusAddress != "" {
+		w.metrics = newPrometheusQuerier(metrics.PrometheusAddress)
+	}
+	return w
 }
 
 // SetEnabled is used to control if the watcher is enabled. The watcher
@@ -157,6 +175,9 @@ func (w *Watcher) flush(enabled bool) {
 
 	if enabled {
 		w.allocUpdateBatcher = NewAllocUpdateBatcher(w.ctx, w.updateBatchDuration, w.raft)
+		if w.webhooks != nil {
+			go w.webhooks.run(w.ctx)
+		}
 	} else {
 		w.allocUpdateBatcher = nil
 	}
@@ -166,6 +187,12 @@ func (w *Watcher) flush(enabled bool) {
 // add and remove watchers on.
 func (w *Watcher) watchDeployments(ctx context.Context) {
 	dindex := uint64(1)
+
+	// events tracks the last webhook event seen for each deployment. It is
+	// nil until the first set of deployments has been seen, so transitions
+	// that happened under a previous leader aren't sent again.
+	var events map[string]string
+
 	for {
 		// Block getting all deployments using the last deployment index.
 		deployments, idx, err := w.getDeploys(ctx, dindex)
@@ -193,7 +220,31 @@ func (w *Watcher) watchDeployments(ctx context.Context) {
 
 		// Ensure we've removed deployments for purged jobs
 		w.removeDeletedDeployments(deployments)
+
+		if w.webhooks != nil && err == nil {
+			events = w.notifyTransitions(events, deployments)
+		}
+	}
+}
+
+// notifyTransitions sends a webhook notification for every deployment whose
+// event differs from the previously seen one and returns the updated events.
+func (w *Watcher) notifyTransitions(events map[string]string, deployments []*structs.Deployment) map[string]string {
+	seen := make(map[string]string, len(deployments))
+	for _, d := range deployments {
+		event := webhookEvent(d)
+		seen[d.ID] = event
+
+		if events == nil || event == "" {
+			continue
+		}
+		if prev, ok := events[d.ID]; ok && prev == event {
+			continue
+		}
+		w.webhooks.notify(event, d)
 	}
+
+	return seen
 }
 
 // getDeploys retrieves all deployments blocking at the given index.
@@ -301,7 +352,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.metrics)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
@@ -25,7 +25,7 @@ import (
 
 func testDeploymentWatcher(t *testing.T, qps float64, batchDur time.Duration) (*Watcher, *mockBackend) {
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur)
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur, nil, nil)
 	return w, m
 }
 
@@ -837,6 +837,126 @@ func TestWatcher_AutoPromoteDeployment_UnhealthyCanaries(t *testing.T) {
 	require.False(t, b1.DeploymentStatus.Canary)
 }
 
+func TestWatcher_AutoPromoteDeployment_Threshold(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+	now := time.Now()
+
+	// Create 1 UpdateStrategy, 1 job, 4 canaries, and 1 deployment. Half the
+	// canaries must be healthy for a second to auto promote.
+	canaryUpd := structs.DefaultUpdateStrategy.Copy()
+	canaryUpd.AutoPromote = true
+	canaryUpd.AutoPromoteThreshold = 50
+	canaryUpd.AutoPromoteMinHealthyTime = time.Second
+	canaryUpd.MaxParallel = 4
+	canaryUpd.Canary = 4
+	canaryUpd.ProgressDeadline = 5 * time.Second
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = canaryUpd
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			AutoPromote:               canaryUpd.AutoPromote,
+			AutoPromoteThreshold:      canaryUpd.AutoPromoteThreshold,
+			AutoPromoteMinHealthyTime: canaryUpd.AutoPromoteMinHealthyTime,
+			ProgressDeadline:          canaryUpd.ProgressDeadline,
+			DesiredCanaries:           4,
+			DesiredTotal:              4,
+		},
+	}
+
+	var canaries []*structs.Allocation
+	for range 4 {
+		a := mock.Alloc()
+		a.DeploymentID = d.ID
+		a.CreateTime = now.UnixNano()
+		a.ModifyTime = now.UnixNano()
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Canary: true,
+		}
+		canaries = append(canaries, a)
+		d.TaskGroups["web"].PlacedCanaries = append(d.TaskGroups["web"].PlacedCanaries, a.ID)
+	}
+
+	require.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j), "UpsertJob")
+	require.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+	require.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), canaries), "UpsertAllocs")
+
+	// clear UpdateDeploymentStatus default expectation
+	m.Mock.ExpectedCalls = nil
+
+	matchConfig0 := &matchDeploymentAllocHealthRequestConfig{
+		DeploymentID: d.ID,
+		Healthy:      []string{canaries[0].ID, canaries[1].ID},
+		Eval:         true,
+	}
+	matcher0 := matchDeploymentAllocHealthRequest(matchConfig0)
+	m.On("UpdateDeploymentAllocHealth", mocker.MatchedBy(matcher0)).Return(nil)
+
+	matchConfig1 := &matchDeploymentPromoteRequestConfig{
+		Promotion: &structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+		Eval: true,
+	}
+	matcher1 := matchDeploymentPromoteRequest(matchConfig1)
+	m.On("UpdateDeploymentPromotion", mocker.MatchedBy(matcher1)).Return(nil)
+
+	// Start the deployment
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) {
+		return 1 == watchersCount(w), nil
+	},
+		func(err error) {
+			require.Equal(t, 1, watchersCount(w), "Should have 1 deployment")
+		},
+	)
+
+	// Mark half the canaries as healthy
+	req := &structs.DeploymentAllocHealthRequest{
+		DeploymentID:         d.ID,
+		HealthyAllocationIDs: []string{canaries[0].ID, canaries[1].ID},
+	}
+	var resp structs.DeploymentUpdateResponse
+	require.NoError(t, w.SetAllocHealth(req, &resp))
+
+	ws := memdb.NewWatchSet()
+	testutil.WaitForResult(
+		func() (bool, error) {
+			ds, _ := m.state.DeploymentsByJobID(ws, j.Namespace, j.ID, true)
+			d = ds[0]
+			return 2 == d.TaskGroups["web"].HealthyAllocs, nil
+		},
+		func(err error) { require.NoError(t, err) },
+	)
+
+	// The threshold is met, but the canaries haven't been healthy long enough
+	require.False(t, d.TaskGroups["web"].Promoted)
+	m.AssertNotCalled(t, "UpdateDeploymentPromotion", mocker.MatchedBy(matcher1))
+
+	// The deployment is promoted once the canaries have been healthy for the
+	// minimum healthy time
+	testutil.WaitForResult(
+		func() (bool, error) {
+			ds, _ := m.state.DeploymentsByJobID(ws, j.Namespace, j.ID, true)
+			d = ds[0]
+			return d.TaskGroups["web"].Promoted, nil
+		},
+		func(err error) { require.NoError(t, err) },
+	)
+	m.AssertCalled(t, "UpdateDeploymentPromotion", mocker.MatchedBy(matcher1))
+
+	// Only the healthy canaries are promoted
+	for i, canary := range canaries {
+		a, _ := m.state.AllocByID(ws, canary.ID)
+		require.Equal(t, i >= 2, a.DeploymentStatus.Canary)
+	}
+}
+
 // Test pausing a deployment that is running
 func TestWatcher_PauseDeployment_Pause_Running(t *testing.T) {
 	ci.Parallel(t)
@@ -1088,6 +1208,10 @@ func TestDeploymentWatcher_Watch_NoProgressDeadline(t *testing.T) {
 	m2 := matchDeploymentStatusUpdateRequest(c)
 	m.On("UpdateDeploymentStatus", mocker.MatchedBy(m2)).Return(nil)
 
+	// require that the failure is recorded before it's applied
+	mp := matchPendingFailure(d.ID, structs.DeploymentStatusDescriptionRollback(structs.DeploymentStatusDescriptionFailedAllocations, 0))
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(mp)).Return(nil)
+
 	w.SetEnabled(true, m.state)
 	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
 		func(err error) { require.Equal(1, watchersCount(w), "Should have 1 deployment") })
@@ -1160,6 +1284,7 @@ func TestDeploymentWatcher_Watch_NoProgressDeadline(t *testing.T) {
 	}
 	m3 := matchDeploymentStatusUpdateRequest(c2)
 	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(m3))
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(mp))
 	testutil.WaitForResult(func() (bool, error) { return 0 == watchersCount(w), nil },
 		func(err error) { require.Equal(0, watchersCount(w), "Should have no deployment") })
 }
@@ -1197,6 +1322,10 @@ func TestDeploymentWatcher_Watch_ProgressDeadline(t *testing.T) {
 	m2 := matchDeploymentStatusUpdateRequest(c)
 	m.On("UpdateDeploymentStatus", mocker.MatchedBy(m2)).Return(nil)
 
+	// require that the failure is recorded before it's applied
+	mp := matchPendingFailure(d.ID, structs.DeploymentStatusDescriptionProgressDeadline)
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(mp)).Return(nil)
+
 	w.SetEnabled(true, m.state)
 	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
 		func(err error) { require.Equal(1, watchersCount(w), "Should have 1 deployment") })
@@ -1220,6 +1349,7 @@ func TestDeploymentWatcher_Watch_ProgressDeadline(t *testing.T) {
 	}, func(err error) {
 		t.Fatal(err)
 	})
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(mp))
 
 	// require there are is only one evaluation
 	testutil.WaitForResult(func() (bool, error) {
@@ -1824,6 +1954,10 @@ func TestDeploymentWatcher_Watch_FailEarly(t *testing.T) {
 	m2 := matchDeploymentStatusUpdateRequest(c)
 	m.On("UpdateDeploymentStatus", mocker.MatchedBy(m2)).Return(nil)
 
+	// require that the failure is recorded before it's applied
+	mp := matchPendingFailure(d.ID, structs.DeploymentStatusDescriptionFailedAllocations)
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(mp)).Return(nil)
+
 	w.SetEnabled(true, m.state)
 	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
 		func(err error) { must.Eq(t, 1, watchersCount(w), must.Sprint("Should have 1 deployment")) })
@@ -1852,6 +1986,14 @@ func TestDeploymentWatcher_Watch_FailEarly(t *testing.T) {
 		t.Fatal(err)
 	})
 
+	// require the failure was recorded before it was applied
+	out, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out.PendingFailure)
+	must.Eq(t, structs.DeploymentStatusDescriptionFailedAllocations, out.PendingFailure.StatusDescription)
+	must.Nil(t, out.PendingFailure.RollbackJobVersion)
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(mp))
+
 	// require there are is only one evaluation
 	testutil.WaitForResult(func() (bool, error) {
 		ws := memdb.NewWatchSet()
@@ -1870,6 +2012,80 @@ func TestDeploymentWatcher_Watch_FailEarly(t *testing.T) {
 	})
 }
 
+// Tests that a watcher started on a deployment with a pending failure, such as
+// after a leader election, applies the failure as recorded
+func TestDeploymentWatcher_Watch_PendingFailure(t *testing.T) {
+	ci.Parallel(t)
+	w, m := testDeploymentWatcher(t, 1000.0, 1*time.Millisecond)
+
+	// Create a stable job and a newer version of it with a deployment
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.AutoRevert = true
+	j.Stable = true
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+
+	j2 := j.Copy()
+	j2.Stable = false
+	j2.Meta["version"] = "2"
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j2))
+
+	// The deployment has healthy allocations, so it would not be failed if
+	// the failure was decided again
+	desc := structs.DeploymentStatusDescriptionRollback(structs.DeploymentStatusDescriptionRollbackMetric, 0)
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobVersion = 1
+	d.TaskGroups["web"].AutoRevert = true
+	d.PendingFailure = &structs.DeploymentFailure{
+		StatusDescription:  desc,
+		RollbackJobVersion: pointer.Of(uint64(0)),
+		DecidedAt:          time.Now().UnixNano(),
+	}
+	a := mock.Alloc()
+	a.DeploymentID = d.ID
+	a.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}))
+
+	// require that we get a call to UpsertDeploymentStatusUpdate rolling back
+	// to the recorded version
+	c := &matchDeploymentStatusUpdateConfig{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusFailed,
+		StatusDescription: desc,
+		JobVersion:        pointer.Of(uint64(0)),
+		Eval:              true,
+	}
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(c))).Return(nil)
+
+	w.SetEnabled(true, m.state)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			out, err := m.state.DeploymentByID(nil, d.ID)
+			if err != nil {
+				return err
+			}
+			if out.Status != structs.DeploymentStatusFailed {
+				return fmt.Errorf("bad status %q", out.Status)
+			}
+			if out.StatusDescription != desc {
+				return fmt.Errorf("bad status description %q", out.StatusDescription)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// The job was rolled back to a new version
+	out, err := m.state.JobByID(nil, j.Namespace, j.ID)
+	must.NoError(t, err)
+	must.Eq(t, 2, out.Version)
+	must.MapNotContainsKey(t, out.Meta, "version")
+}
+
 // Tests that the watcher fails rollback when the spec hasn't changed
 func TestDeploymentWatcher_RollbackFailed(t *testing.T) {
 	ci.Parallel(t)
@@ -1914,6 +2130,10 @@ func TestDeploymentWatcher_RollbackFailed(t *testing.T) {
 	m2 := matchDeploymentStatusUpdateRequest(c)
 	m.On("UpdateDeploymentStatus", mocker.MatchedBy(m2)).Return(nil)
 
+	// require that the failure is recorded before it's applied
+	mp := matchPendingFailure(d.ID, structs.DeploymentStatusDescriptionRollbackNoop(structs.DeploymentStatusDescriptionFailedAllocations, 0))
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(mp)).Return(nil)
+
 	w.SetEnabled(true, m.state)
 	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
 		func(err error) { require.Equal(1, watchersCount(w), "Should have 1 deployment") })
@@ -1974,6 +2194,7 @@ func TestDeploymentWatcher_RollbackFailed(t *testing.T) {
 	})
 
 	m.AssertCalled(t, "UpdateAllocDesiredTransition", mocker.MatchedBy(m1))
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(mp))
 
 	// verify that the job version hasn't changed after upsert
 	m.state.JobByID(nil, structs.DefaultNamespace, j.ID)
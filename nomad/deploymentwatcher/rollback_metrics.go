@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/helper/useragent"
+)
+
+const (
+	// metricQueryTimeout is the timeout for a single rollback metric query.
+	metricQueryTimeout = 10 * time.Second
+)
+
+// metricQuerier evaluates the rollback_on queries of a deployment.
+type metricQuerier interface {
+	// query returns the sample values of the query result.
+	query(ctx context.Context, query string) ([]float64, error)
+}
+
+// prometheusQuerier evaluates queries using the Prometheus HTTP API.
+type prometheusQuerier struct {
+	addr   string
+	client *http.Client
+}
+
+func newPrometheusQuerier(addr string) *prometheusQuerier {
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = metricQueryTimeout
+
+	return &prometheusQuerier{
+		addr:   strings.TrimSuffix(addr, "/"),
+		client: client,
+	}
+}
+
+// prometheusResponse is the envelope of a Prometheus instant query response.
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusSample is a single sample of a vector result.
+type prometheusSample struct {
+	Value []any `json:"value"`
+}
+
+func (p *prometheusQuerier) query(ctx context.Context, query string) ([]float64, error) {
+	u := p.addr + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", useragent.String())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode response with status %d: %w", resp.StatusCode, err)
+	}
+	if out.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s: %s", out.ErrorType, out.Error)
+	}
+
+	switch out.Data.ResultType {
+	case "scalar":
+		var value []any
+		if err := json.Unmarshal(out.Data.Result, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode scalar result: %w", err)
+		}
+		v, err := parsePrometheusValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(out.Data.Result, &samples); err != nil {
+			return nil, fmt.Errorf("failed to decode vector result: %w", err)
+		}
+		values := make([]float64, 0, len(samples))
+		for _, sample := range samples {
+			v, err := parsePrometheusValue(sample.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", out.Data.ResultType)
+	}
+}
+
+// parsePrometheusValue parses a [timestamp, "value"] pair.
+func parsePrometheusValue(value []any) (float64, error) {
+	if len(value) != 2 {
+		return 0, errors.New("malformed sample")
+	}
+	s, ok := value[1].(string)
+	if !ok {
+		return 0, errors.New("malformed sample value")
+	}
+	return strconv.ParseFloat(s, 64)
+}
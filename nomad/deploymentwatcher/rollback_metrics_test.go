@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	mocker "github.com/stretchr/testify/mock"
+)
+
+// testPrometheus returns a Prometheus API server that answers every query
+// with the given response body.
+func testPrometheus(t *testing.T, body string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPrometheusQuerier_query(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		body   string
+		exp    []float64
+		expErr string
+	}{
+		{
+			name: "vector",
+			body: `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"job":"web"},"value":[1700000000.1,"0.25"]},
+				{"metric":{"job":"api"},"value":[1700000000.1,"2"]}]}}`,
+			exp: []float64{0.25, 2},
+		},
+		{
+			name: "empty vector",
+			body: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			exp:  []float64{},
+		},
+		{
+			name: "scalar",
+			body: `{"status":"success","data":{"resultType":"scalar","result":[1700000000.1,"7.5"]}}`,
+			exp:  []float64{7.5},
+		},
+		{
+			name:   "matrix",
+			body:   `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			expErr: `unsupported result type "matrix"`,
+		},
+		{
+			name:   "error",
+			body:   `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			expErr: "query failed: bad_data: parse error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := testPrometheus(t, tc.body)
+			values, err := newPrometheusQuerier(srv.URL+"/").query(context.Background(), "up")
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, values)
+		})
+	}
+}
+
+// Test that a deployment is failed and rolled back when its rollback metric
+// breaches the threshold
+func TestDeploymentWatcher_RollbackMetric(t *testing.T) {
+	ci.Parallel(t)
+	w, m := testDeploymentWatcher(t, 1000.0, 1*time.Millisecond)
+
+	srv := testPrometheus(t, `{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{},"value":[1700000000.1,"0.9"]}]}}`)
+	w.metrics = newPrometheusQuerier(srv.URL)
+
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(func(args *structs.DeploymentStatusUpdateRequest) bool {
+		return true
+	})).Return(nil).Maybe()
+
+	// Create a job, alloc, and a deployment
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.MaxParallel = 2
+	j.TaskGroups[0].Update.RollbackOn = &structs.RollbackOn{
+		Query:      `sum(rate(http_errors_total[1m]))`,
+		Threshold:  0.5,
+		Comparison: structs.RollbackOnComparisonAbove,
+		Interval:   50 * time.Millisecond,
+	}
+	j.Stable = true
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].PlacedAllocs = 1
+	a := mock.Alloc()
+	a.DeploymentID = d.ID
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}))
+
+	// Upsert the job again to get a new version
+	j2 := j.Copy()
+	j2.Stable = false
+	j2.Meta["foo"] = "bar"
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j2))
+
+	// require that the deployment is failed and the job rolled back
+	c := &matchDeploymentStatusUpdateConfig{
+		DeploymentID: d.ID,
+		Status:       structs.DeploymentStatusFailed,
+		StatusDescription: structs.DeploymentStatusDescriptionRollback(
+			structs.DeploymentStatusDescriptionRollbackMetric, 0),
+		JobVersion: pointer.Of(uint64(0)),
+		Eval:       true,
+	}
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(c))).Return(nil)
+
+	w.SetEnabled(true, m.state)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			d, err := m.state.DeploymentByID(nil, d.ID)
+			if err != nil {
+				return err
+			}
+			if d.Status != structs.DeploymentStatusFailed {
+				return fmt.Errorf("bad status %q", d.Status)
+			}
+			if d.StatusDescription != c.StatusDescription {
+				return fmt.Errorf("bad status description %q", d.StatusDescription)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(20*time.Millisecond),
+	))
+}
+
+func TestDeploymentWatcher_rollbackMetricBreached(t *testing.T) {
+	ci.Parallel(t)
+
+	var queries int
+	querier := metricQuerierFunc(func(_ context.Context, _ string) ([]float64, error) {
+		queries++
+		return []float64{3}, nil
+	})
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.RollbackOn = &structs.RollbackOn{
+		Query:      "up",
+		Threshold:  1,
+		Comparison: structs.RollbackOnComparisonBelow,
+		Interval:   time.Minute,
+	}
+	d := mock.Deployment()
+	d.TaskGroups["web"].PlacedAllocs = 1
+
+	w := &deploymentWatcher{
+		ctx:     context.Background(),
+		state:   state.TestStateStore(t),
+		logger:  testlog.HCLogger(t),
+		d:       d,
+		j:       j,
+		metrics: querier,
+	}
+	now := time.Now()
+	checks := map[string]time.Time{}
+
+	// A value above the threshold doesn't breach a "below" comparison
+	must.False(t, w.rollbackMetricBreached(now, checks))
+	must.Eq(t, 1, queries)
+
+	// The group isn't checked again until its interval has passed
+	must.False(t, w.rollbackMetricBreached(now.Add(time.Second), checks))
+	must.Eq(t, 1, queries)
+
+	j.TaskGroups[0].Update.RollbackOn.Threshold = 5
+	must.True(t, w.rollbackMetricBreached(now.Add(time.Minute), checks))
+	must.Eq(t, 2, queries)
+
+	// Groups past their progress deadline and deployments that aren't
+	// running aren't checked
+	d.TaskGroups["web"].RequireProgressBy = now
+	must.False(t, w.rollbackMetricBreached(now.Add(2*time.Minute), checks))
+	d.TaskGroups["web"].RequireProgressBy = time.Time{}
+	d.Status = structs.DeploymentStatusPaused
+	must.False(t, w.rollbackMetricBreached(now.Add(3*time.Minute), checks))
+	must.Eq(t, 2, queries)
+}
+
+type metricQuerierFunc func(ctx context.Context, query string) ([]float64, error)
+
+func (f metricQuerierFunc) query(ctx context.Context, query string) ([]float64, error) {
+	return f(ctx, query)
+}
@@ -149,6 +149,23 @@ func matchDeploymentStatusUpdateRequest(c *matchDeploymentStatusUpdateConfig) fu
 	}
 }
 
+// matchPendingFailure is used to match the update recording the decision to
+// fail a deployment, which precedes the update failing it.
+func matchPendingFailure(deploymentID, statusDescription string) func(args *structs.DeploymentStatusUpdateRequest) bool {
+	return func(args *structs.DeploymentStatusUpdateRequest) bool {
+		u := args.DeploymentUpdate
+		if u.DeploymentID != deploymentID || u.Status == structs.DeploymentStatusFailed {
+			return false
+		}
+
+		if u.PendingFailure == nil || u.PendingFailure.StatusDescription != statusDescription {
+			return false
+		}
+
+		return args.Eval == nil && args.Job == nil
+	}
+}
+
 func (m *mockBackend) UpdateDeploymentPromotion(req *structs.ApplyDeploymentPromoteRequest) (uint64, error) {
 	m.Called(req)
 	i := m.nextIndex()
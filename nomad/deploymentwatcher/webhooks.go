@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/useragent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// webhookQueueSize is the number of notifications that can be waiting
+	// for delivery before new notifications are dropped.
+	webhookQueueSize = 256
+
+	// webhookTimeout is the timeout for a single webhook request.
+	webhookTimeout = 10 * time.Second
+
+	// webhookAttempts is the number of times delivery of a notification is
+	// attempted before it is dropped.
+	webhookAttempts = 3
+
+	// webhookRetryBackoff is the base backoff between delivery attempts.
+	webhookRetryBackoff = time.Second
+
+	// WebhookSignatureHeader is the header holding the hex encoded
+	// HMAC-SHA256 signature of the request body, prefixed by "sha256=".
+	WebhookSignatureHeader = "X-Nomad-Signature"
+
+	// WebhookEventHeader is the header holding the name of the event.
+	WebhookEventHeader = "X-Nomad-Event"
+)
+
+// WebhookPayload is the body POSTed to deployment webhooks.
+type WebhookPayload struct {
+	// Event is the name of the status transition, one of
+	// config.DeploymentWebhookEvents.
+	Event string
+
+	// Timestamp is the time the transition was observed by the leader.
+	Timestamp time.Time
+
+	// Deployment is the deployment as of the transition.
+	Deployment *structs.Deployment
+}
+
+// webhookNotification is a payload queued for delivery to one webhook.
+type webhookNotification struct {
+	webhook *config.DeploymentWebhookConfig
+	event   string
+	body    []byte
+}
+
+// webhookNotifier delivers deployment status transitions to the configured
+// webhooks. Deliveries happen in the background so slow endpoints don't block
+// the watcher.
+type webhookNotifier struct {
+	logger   log.Logger
+	client   *http.Client
+	webhooks []*config.DeploymentWebhookConfig
+	queue    chan *webhookNotification
+
+	// backoff is the base backoff between delivery attempts and can be
+	// shortened in tests.
+	backoff time.Duration
+}
+
+func newWebhookNotifier(logger log.Logger, webhooks []*config.DeploymentWebhookConfig) *webhookNotifier {
+	client := cleanhttp.DefaultClient()
+	client.Timeout = webhookTimeout
+
+	return &webhookNotifier{
+		logger:   logger.Named("webhooks"),
+		client:   client,
+		webhooks: webhooks,
+		queue:    make(chan *webhookNotification, webhookQueueSize),
+		backoff:  webhookRetryBackoff,
+	}
+}
+
+// run delivers queued notifications until the context is canceled.
+func (n *webhookNotifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-n.queue:
+			n.deliver(ctx, notification)
+		}
+	}
+}
+
+// notify queues the event for the webhooks subscribed to it.
+func (n *webhookNotifier) notify(event string, d *structs.Deployment) {
+	body, err := json.Marshal(&WebhookPayload{
+		Event:      event,
+		Timestamp:  time.Now().UTC(),
+		Deployment: d,
+	})
+	if err != nil {
+		n.logger.Error("failed to encode webhook payload", "deployment_id", d.ID, "error", err)
+		return
+	}
+
+	for _, webhook := range n.webhooks {
+		if !webhook.Wants(event) {
+			continue
+		}
+
+		select {
+		case n.queue <- &webhookNotification{webhook: webhook, event: event, body: body}:
+		default:
+			n.logger.Warn("webhook queue is full, dropping notification",
+				"webhook", webhook.Name, "deployment_id", d.ID, "event", event)
+		}
+	}
+}
+
+// deliver POSTs the notification, retrying on failure.
+func (n *webhookNotifier) deliver(ctx context.Context, notification *webhookNotification) {
+	var err error
+	for attempt := 0; attempt < webhookAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(n.backoff << (attempt - 1)):
+			}
+		}
+
+		if err = n.post(ctx, notification); err == nil {
+			return
+		}
+	}
+
+	n.logger.Warn("failed to deliver webhook notification",
+		"webhook", notification.webhook.Name, "event", notification.event, "error", err)
+}
+
+func (n *webhookNotifier) post(ctx context.Context, notification *webhookNotification) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		notification.webhook.URL, bytes.NewReader(notification.body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", useragent.String())
+	req.Header.Set(WebhookEventHeader, notification.event)
+	if secret := notification.webhook.Secret; secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(secret, notification.body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of the body using the
+// secret, which receivers can compare against the X-Nomad-Signature header.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookEvent returns the webhook event for the deployment's current status,
+// or an empty string if the status has no event.
+func webhookEvent(d *structs.Deployment) string {
+	switch d.Status {
+	case structs.DeploymentStatusRunning:
+		if d.StatusDescription == structs.DeploymentStatusDescriptionRunningNeedsPromotion {
+			return config.DeploymentWebhookEventPromotionRequired
+		}
+		return config.DeploymentWebhookEventRunning
+	case structs.DeploymentStatusFailed:
+		return config.DeploymentWebhookEventFailed
+	case structs.DeploymentStatusSuccessful:
+		return config.DeploymentWebhookEventSuccessful
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+type webhookRequest struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func TestWatcher_notifyTransitions(t *testing.T) {
+	ci.Parallel(t)
+
+	requests := make(chan *webhookRequest, 10)
+	var fails atomic.Int32
+	fails.Store(1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request to exercise the retries
+		if fails.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests <- &webhookRequest{
+			event:     r.Header.Get(WebhookEventHeader),
+			signature: r.Header.Get(WebhookSignatureHeader),
+			body:      body,
+		}
+	}))
+	defer srv.Close()
+
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), nil, nil, nil,
+		LimitStateQueriesPerSecond, CrossDeploymentUpdateBatchDuration,
+		[]*config.DeploymentWebhookConfig{{
+			Name:   "ci",
+			URL:    srv.URL,
+			Secret: "s3cr3t",
+			Events: []string{config.DeploymentWebhookEventFailed},
+		}}, nil)
+	w.webhooks.backoff = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.webhooks.run(ctx)
+
+	d1, d2 := mock.Deployment(), mock.Deployment()
+	d2.Status = structs.DeploymentStatusFailed
+
+	// The first set of deployments only seeds the events
+	events := w.notifyTransitions(nil, []*structs.Deployment{d1, d2})
+	must.MapLen(t, 2, events)
+
+	// Unchanged deployments and events the webhook isn't subscribed to are
+	// not sent
+	d1 = d1.Copy()
+	d1.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	events = w.notifyTransitions(events, []*structs.Deployment{d1, d2})
+
+	// The failed deployment is sent
+	d1 = d1.Copy()
+	d1.Status = structs.DeploymentStatusFailed
+	d1.StatusDescription = structs.DeploymentStatusDescriptionFailedAllocations
+	w.notifyTransitions(events, []*structs.Deployment{d1, d2})
+
+	var req *webhookRequest
+	select {
+	case req = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook")
+	}
+
+	must.Eq(t, config.DeploymentWebhookEventFailed, req.event)
+	must.Eq(t, "sha256="+SignWebhookPayload("s3cr3t", req.body), req.signature)
+
+	var payload WebhookPayload
+	must.NoError(t, json.Unmarshal(req.body, &payload))
+	must.Eq(t, config.DeploymentWebhookEventFailed, payload.Event)
+	must.Eq(t, d1.ID, payload.Deployment.ID)
+	must.Eq(t, structs.DeploymentStatusFailed, payload.Deployment.Status)
+
+	select {
+	case req = <-requests:
+		t.Fatalf("unexpected webhook %q", req.event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
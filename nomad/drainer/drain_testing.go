@@ -23,6 +23,7 @@ import (
 type MockJobWatcher struct {
 	drainCh    chan *DrainRequest
 	migratedCh chan []*structs.Allocation
+	blockedCh  chan map[string][]*structs.NodeEvent
 	jobs       map[structs.NamespacedID]struct{}
 	sync.Mutex
 }
@@ -50,6 +51,13 @@ func (m *MockJobWatcher) Migrated() <-chan []*structs.Allocation {
 	return m.migratedCh
 }
 
+// Blocked returns the channel of blocked drain node events. Tests can send on
+// this channel to simulate steps through the NodeDrainer watch loop. (Sending
+// on this channel will block anywhere else.)
+func (m *MockJobWatcher) Blocked() <-chan map[string][]*structs.NodeEvent {
+	return m.blockedCh
+}
+
 type MockDeadlineNotifier struct {
 	expiredCh <-chan []string
 	nodes     map[string]struct{}
@@ -123,6 +131,17 @@ func (m *MockRaftApplierShim) NodesDrainComplete(
 	return index, err
 }
 
+// NodesEmitEvents mocks a write to raft as a state store update
+func (m *MockRaftApplierShim) NodesEmitEvents(events map[string][]*structs.NodeEvent) (uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, _ := m.state.LatestIndex()
+	index++
+	err := m.state.UpsertNodeEvents(structs.MsgTypeTestSetup, index, events)
+	return index, err
+}
+
 func testNodeDrainWatcher(t *testing.T) (*nodeDrainWatcher, *state.StateStore, *NodeDrainer) {
 	t.Helper()
 	store := state.TestStateStore(t)
@@ -49,6 +49,7 @@ const (
 type RaftApplier interface {
 	AllocUpdateDesiredTransition(allocs map[string]*structs.DesiredTransition, evals []*structs.Evaluation) (uint64, error)
 	NodesDrainComplete(nodes []string, event *structs.NodeEvent) (uint64, error)
+	NodesEmitEvents(events map[string][]*structs.NodeEvent) (uint64, error)
 }
 
 // NodeTracker is the interface to notify an object that is tracking draining
@@ -236,16 +237,23 @@ func (n *NodeDrainer) run(ctx context.Context) {
 			n.handleJobAllocDrain(req)
 		case allocs := <-n.jobWatcher.Migrated():
 			n.handleMigratedAllocs(allocs)
+		case events := <-n.jobWatcher.Blocked():
+			n.handleBlockedDrains(events)
 		}
 	}
 }
 
 // handleDeadlinedNodes handles a set of nodes reaching their drain deadline.
 // The handler detects the remaining allocations on the nodes and immediately
-// marks them for migration.
+// marks them for migration. Batch allocations on nodes with a batch grace
+// period are allowed to complete until the end of the period, at which point
+// the node is deadlined again.
 func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
+	now := time.Now()
+
 	// Retrieve the set of allocations that will be force stopped.
 	var forceStop []*structs.Allocation
+	var deadlined []string
 	n.l.RLock()
 	for _, node := range nodes {
 		draining, ok := n.nodes[node]
@@ -260,12 +268,35 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 			continue
 		}
 
+		batchDeadline := draining.BatchDeadlineTime()
+		inGrace := now.Before(batchDeadline)
+		remainingBatch := 0
+		for _, alloc := range allocs {
+			if inGrace && alloc.Job.Type == structs.JobTypeBatch {
+				remainingBatch++
+				continue
+			}
+			forceStop = append(forceStop, alloc)
+		}
+
+		if remainingBatch > 0 {
+			n.logger.Debug("node deadlined with batch allocs allowed to complete",
+				"node_id", node, "num_allocs", len(allocs)-remainingBatch,
+				"num_batch_allocs", remainingBatch, "batch_deadline", batchDeadline)
+			n.deadlineNotifier.Watch(node, batchDeadline)
+			continue
+		}
+
 		n.logger.Debug("node deadlined causing allocs to be force stopped", "node_id", node, "num_allocs", len(allocs))
-		forceStop = append(forceStop, allocs...)
+		deadlined = append(deadlined, node)
 	}
 	n.l.RUnlock()
 	n.batchDrainAllocs(forceStop)
 
+	if len(deadlined) == 0 {
+		return
+	}
+
 	// Create the node event
 	event := structs.NewNodeEvent().
 		SetSubsystem(structs.NodeEventSubsystemDrain).
@@ -274,7 +305,7 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 
 	// Submit the node transitions in a sharded form to ensure a reasonable
 	// Raft transaction size.
-	for _, nodes := range partitionIds(defaultMaxIdsPerTxn, nodes) {
+	for _, nodes := range partitionIds(defaultMaxIdsPerTxn, deadlined) {
 		if _, err := n.raft.NodesDrainComplete(nodes, event); err != nil {
 			n.logger.Error("failed to unset drain for nodes", "error", err)
 		}
@@ -355,6 +386,15 @@ func (n *NodeDrainer) handleMigratedAllocs(allocs []*structs.Allocation) {
 	}
 }
 
+// handleBlockedDrains emits node events on the nodes whose drain is blocked by
+// the disruption budget of a task group, so operators can tell why the drain
+// isn't making progress.
+func (n *NodeDrainer) handleBlockedDrains(events map[string][]*structs.NodeEvent) {
+	if _, err := n.raft.NodesEmitEvents(events); err != nil {
+		n.logger.Error("failed to emit blocked drain events", "error", err)
+	}
+}
+
 // batchDrainAllocs is used to batch the draining of allocations. It will block
 // until the batch is complete.
 func (n *NodeDrainer) batchDrainAllocs(allocs []*structs.Allocation) (uint64, error) {
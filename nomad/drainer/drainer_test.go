@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TestNodeDrainer_handleDeadlinedNodes_BatchGrace tests that batch allocs are
+// allowed to complete past the deadline until the end of the batch grace
+// period, while all other allocs are force stopped.
+func TestNodeDrainer_handleDeadlinedNodes_BatchGrace(t *testing.T) {
+	ci.Parallel(t)
+	_, store, tracker := testNodeDrainWatcher(t)
+	notifier := tracker.deadlineNotifier.(*MockDeadlineNotifier)
+
+	node := mock.Node()
+	node.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline:   time.Hour,
+			BatchGrace: time.Hour,
+		},
+		ForceDeadline: time.Now().Add(-time.Minute),
+	}
+
+	service := mock.Alloc()
+	service.NodeID = node.ID
+	batch := mock.BatchAlloc()
+	batch.NodeID = node.ID
+
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, service.Job))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, batch.Job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 102,
+		[]*structs.Allocation{service, batch}))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 103, node))
+	assertTrackerSettled(t, tracker, []string{node.ID})
+
+	// The service alloc is force stopped, but the batch alloc is left to
+	// complete and the node is watched until the end of the batch grace
+	notifier.Remove(node.ID)
+	tracker.handleDeadlinedNodes([]string{node.ID})
+
+	out, err := store.AllocByID(nil, service.ID)
+	must.NoError(t, err)
+	must.True(t, out.DesiredTransition.ShouldMigrate())
+	out, err = store.AllocByID(nil, batch.ID)
+	must.NoError(t, err)
+	must.False(t, out.DesiredTransition.ShouldMigrate())
+
+	must.MapContainsKey(t, notifier.nodes, node.ID)
+	outNode, err := store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.NotNil(t, outNode.DrainStrategy)
+
+	// Once the batch grace has passed, the batch alloc is force stopped and
+	// the drain is complete
+	expired := node.Copy()
+	expired.DrainStrategy.ForceDeadline = time.Now().Add(-2 * time.Hour)
+	tracker.nodes[node.ID].Update(expired)
+	tracker.handleDeadlinedNodes([]string{node.ID})
+
+	out, err = store.AllocByID(nil, batch.ID)
+	must.NoError(t, err)
+	must.True(t, out.DesiredTransition.ShouldMigrate())
+
+	outNode, err = store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Nil(t, outNode.DrainStrategy)
+}
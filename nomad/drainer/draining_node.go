@@ -50,6 +50,20 @@ func (n *drainingNode) DeadlineTime() (bool, time.Time) {
 	return n.node.DrainStrategy.DeadlineTime()
 }
 
+// BatchDeadlineTime returns the time until which batch allocs are allowed to
+// complete past the deadline, or the zero time if there is no batch grace.
+func (n *drainingNode) BatchDeadlineTime() time.Time {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	// Should never happen
+	if n.node == nil || n.node.DrainStrategy == nil {
+		return time.Time{}
+	}
+
+	return n.node.DrainStrategy.BatchDeadlineTime()
+}
+
 // IsDone returns if the node is done draining batch and service allocs. System
 // allocs must be stopped before marking drain complete unless they're being
 // ignored.
@@ -6,6 +6,7 @@ package drainer
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	log "github.com/hashicorp/go-hclog"
@@ -40,6 +41,11 @@ type DrainingJobWatcher interface {
 	// Migrated is allocations for draining jobs that have transitioned to
 	// stop. There is no guarantee that duplicates won't be published.
 	Migrated() <-chan []*structs.Allocation
+
+	// Blocked is the node events to emit on draining nodes whose drain is
+	// blocked by the disruption budget of a task group. An event is only
+	// published once for as long as the drain remains blocked.
+	Blocked() <-chan map[string][]*structs.NodeEvent
 }
 
 // drainingJobWatcher is used to watch draining jobs and emit events when
@@ -65,6 +71,11 @@ type drainingJobWatcher struct {
 	drainCh    chan *DrainRequest
 	migratedCh chan []*structs.Allocation
 
+	// blockedCh is used to emit node events for drains blocked by disruption
+	// budgets, and blocked tracks the events already emitted per node.
+	blockedCh chan map[string][]*structs.NodeEvent
+	blocked   map[string]map[string]struct{}
+
 	l sync.RWMutex
 }
 
@@ -86,6 +97,8 @@ func NewDrainingJobWatcher(ctx context.Context, limiter *rate.Limiter, state *st
 		jobs:        make(map[structs.NamespacedID]struct{}, 64),
 		drainCh:     make(chan *DrainRequest),
 		migratedCh:  make(chan []*structs.Allocation),
+		blockedCh:   make(chan map[string][]*structs.NodeEvent),
+		blocked:     make(map[string]map[string]struct{}),
 	}
 
 	go w.watch()
@@ -128,6 +141,12 @@ func (w *drainingJobWatcher) Migrated() <-chan []*structs.Allocation {
 	return w.migratedCh
 }
 
+// Blocked returns the channel that emits node events for drains blocked by
+// disruption budgets.
+func (w *drainingJobWatcher) Blocked() <-chan map[string][]*structs.NodeEvent {
+	return w.blockedCh
+}
+
 // deregisterJob removes the job from being watched.
 func (w *drainingJobWatcher) deregisterJob(jobID, namespace string) {
 	w.l.Lock()
@@ -191,6 +210,7 @@ func (w *drainingJobWatcher) watch() {
 
 		currentJobs := w.drainingJobs()
 		var allDrain, allMigrated []*structs.Allocation
+		allBlocked := make(map[string]map[string]struct{})
 		for jns, allocs := range jobAllocs {
 			// Check if the job is still registered
 			if _, ok := currentJobs[jns]; !ok {
@@ -230,6 +250,14 @@ func (w *drainingJobWatcher) watch() {
 
 			allDrain = append(allDrain, result.drain...)
 			allMigrated = append(allMigrated, result.migrated...)
+			for nodeID, msgs := range result.blocked {
+				if allBlocked[nodeID] == nil {
+					allBlocked[nodeID] = make(map[string]struct{})
+				}
+				for _, msg := range msgs {
+					allBlocked[nodeID][msg] = struct{}{}
+				}
+			}
 
 			// Stop tracking this job
 			if result.done {
@@ -277,7 +305,38 @@ func (w *drainingJobWatcher) watch() {
 				return
 			}
 		}
+
+		if events := w.newBlockedEvents(allBlocked); len(events) != 0 {
+			w.logger.Trace("sending blocked drain events for nodes", "num_nodes", len(events))
+			select {
+			case w.blockedCh <- events:
+			case <-w.ctx.Done():
+				w.logger.Trace("shutting down")
+				return
+			}
+		}
+	}
+}
+
+// newBlockedEvents returns the node events for the drains blocked by
+// disruption budgets that have not been emitted yet, and tracks the blocked
+// drains so an event is emitted again once a drain that was unblocked becomes
+// blocked again.
+func (w *drainingJobWatcher) newBlockedEvents(blocked map[string]map[string]struct{}) map[string][]*structs.NodeEvent {
+	events := make(map[string][]*structs.NodeEvent)
+	for nodeID, msgs := range blocked {
+		for msg := range msgs {
+			if _, ok := w.blocked[nodeID][msg]; ok {
+				continue
+			}
+			w.logger.Info("node drain blocked by disruption budget", "node_id", nodeID, "reason", msg)
+			events[nodeID] = append(events[nodeID], structs.NewNodeEvent().
+				SetSubsystem(structs.NodeEventSubsystemDrain).
+				SetMessage(msg))
+		}
 	}
+	w.blocked = blocked
+	return events
 }
 
 // jobResult is the set of actions to take for a draining job given its current
@@ -289,6 +348,10 @@ type jobResult struct {
 	// migrated is the set of allocations to emit as migrated
 	migrated []*structs.Allocation
 
+	// blocked is the set of reasons the drain of a node is blocked by the
+	// disruption budget of a task group, keyed by node ID.
+	blocked map[string][]string
+
 	// done marks whether the job has been fully drained.
 	done bool
 }
@@ -348,6 +411,7 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 	// Determine how many allocations can be drained
 	drainingNodes := make(map[string]bool, 4)
 	healthy := 0
+	budgetHealthy := 0
 	remainingDrainingAlloc := false
 	var drainable []*structs.Allocation
 
@@ -383,6 +447,12 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 			healthy++
 		}
 
+		// Allocations already marked for migration are about to be stopped
+		// so they don't count towards the disruption budget.
+		if alloc.DisruptionBudgetHealthy() && !alloc.DesiredTransition.ShouldMigrate() {
+			budgetHealthy++
+		}
+
 		// An alloc can't be considered for migration if:
 		// - It isn't on a draining node
 		// - It is already terminal on the client
@@ -419,6 +489,28 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 		return nil
 	}
 
+	// Never take the group below the minimum number of healthy allocations of
+	// its disruption budget, and report the nodes whose drain is blocked.
+	if budget := tg.DisruptionBudget; budget != nil {
+		allowed := budget.Allowed(tg.Count, budgetHealthy)
+		if allowed < numToDrain {
+			msg := fmt.Sprintf("Drain blocked by disruption budget of task group %q of job %q requiring %d healthy allocations",
+				tg.Name, drainable[0].JobID, budget.MinHealthyCount(tg.Count))
+			for _, alloc := range drainable[allowed:numToDrain] {
+				if result.blocked == nil {
+					result.blocked = make(map[string][]string)
+				}
+				if !slices.Contains(result.blocked[alloc.NodeID], msg) {
+					result.blocked[alloc.NodeID] = append(result.blocked[alloc.NodeID], msg)
+				}
+			}
+			numToDrain = allowed
+		}
+		if numToDrain <= 0 {
+			return nil
+		}
+	}
+
 	result.drain = append(result.drain, drainable[0:numToDrain]...)
 	return nil
 }
@@ -635,6 +635,106 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 	}
 }
 
+func TestHandleTaskGroup_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name          string
+		minHealthy    int
+		expectDrained int
+		expectBlocked bool
+	}{
+		{
+			name:          "budget-allows-all",
+			minHealthy:    2,
+			expectDrained: 2,
+		},
+		{
+			name:          "budget-allows-one",
+			minHealthy:    3,
+			expectDrained: 1,
+			expectBlocked: true,
+		},
+		{
+			name:          "budget-allows-none",
+			minHealthy:    4,
+			expectDrained: 0,
+			expectBlocked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := state.TestStateStore(t)
+			drainingNode, runningNode := testNodes(t, store)
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = 4
+			job.TaskGroups[0].Migrate.MaxParallel = 4
+			job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{
+				MinHealthy: tc.minHealthy,
+			}
+			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
+
+			// Two healthy allocs on the draining node and two on the running
+			// node
+			var allocs []*structs.Allocation
+			for i := 0; i < 4; i++ {
+				a := mock.Alloc()
+				a.JobID = job.ID
+				a.Job = job
+				a.TaskGroup = job.TaskGroups[0].Name
+				a.ClientStatus = structs.AllocClientStatusRunning
+				a.DeploymentStatus = &structs.AllocDeploymentStatus{
+					Healthy: pointer.Of(true),
+				}
+				a.NodeID = drainingNode.ID
+				if i >= 2 {
+					a.NodeID = runningNode.ID
+				}
+				allocs = append(allocs, a)
+			}
+			must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, allocs))
+			snap, err := store.Snapshot()
+			must.NoError(t, err)
+
+			res := newJobResult()
+			must.NoError(t, handleTaskGroup(snap, false, job.TaskGroups[0], allocs, 102, res))
+			must.Len(t, tc.expectDrained, res.drain)
+			if tc.expectBlocked {
+				must.MapLen(t, 1, res.blocked)
+				must.Len(t, 1, res.blocked[drainingNode.ID])
+				must.StrContains(t, res.blocked[drainingNode.ID][0], "disruption budget")
+			} else {
+				must.MapEmpty(t, res.blocked)
+			}
+		})
+	}
+}
+
+func TestDrainingJobWatcher_newBlockedEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	w := &drainingJobWatcher{
+		logger:  testlog.HCLogger(t),
+		blocked: make(map[string]map[string]struct{}),
+	}
+
+	blocked := map[string]map[string]struct{}{
+		"node1": {"blocked": {}},
+	}
+
+	// Events are only emitted once while the drain remains blocked
+	events := w.newBlockedEvents(blocked)
+	must.MapLen(t, 1, events)
+	must.Eq(t, "blocked", events["node1"][0].Message)
+	must.MapEmpty(t, w.newBlockedEvents(blocked))
+
+	// And are emitted again once the drain is blocked again
+	must.MapEmpty(t, w.newBlockedEvents(nil))
+	must.MapLen(t, 1, w.newBlockedEvents(blocked))
+}
+
 func TestHandleTaskGroup_Migrations(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
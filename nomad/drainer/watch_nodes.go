@@ -5,6 +5,7 @@ package drainer
 
 import (
 	"context"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
@@ -61,6 +62,12 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 	}
 
 	if inf, deadline := node.DrainStrategy.DeadlineTime(); !inf {
+		// Once the deadline has passed, batch allocs may still be completing
+		// until the end of the batch grace period
+		batchDeadline := node.DrainStrategy.BatchDeadlineTime()
+		if !batchDeadline.IsZero() && time.Now().After(deadline) {
+			deadline = batchDeadline
+		}
 		n.deadlineNotifier.Watch(node.ID, deadline)
 	} else {
 		// There is an infinite deadline so it shouldn't be tracked for
@@ -35,6 +35,15 @@ func (d drainerShim) NodesDrainComplete(nodes []string, event *structs.NodeEvent
 	return index, err
 }
 
+func (d drainerShim) NodesEmitEvents(events map[string][]*structs.NodeEvent) (uint64, error) {
+	args := &structs.EmitNodeEventsRequest{
+		NodeEvents:   events,
+		WriteRequest: structs.WriteRequest{Region: d.s.config.Region},
+	}
+	_, index, err := d.s.raftApply(structs.UpsertNodeEventsType, args)
+	return index, err
+}
+
 func (d drainerShim) AllocUpdateDesiredTransition(allocs map[string]*structs.DesiredTransition, evals []*structs.Evaluation) (uint64, error) {
 	args := &structs.AllocUpdateDesiredTransitionRequest{
 		Allocs:       allocs,
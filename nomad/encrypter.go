@@ -14,8 +14,10 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -29,7 +31,9 @@ import (
 	"github.com/hashicorp/go-kms-wrapping/wrappers/azurekeyvault/v2"
 	"github.com/hashicorp/go-kms-wrapping/wrappers/gcpckms/v2"
 	"github.com/hashicorp/go-kms-wrapping/wrappers/transit/v2"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/go-multierror"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/crypto"
 	"github.com/hashicorp/nomad/helper/joseutil"
@@ -41,8 +45,13 @@ import (
 
 const nomadKeystoreExtension = ".nks.json"
 
+// identityCacheSize is the number of signed workload identities cached by the
+// encrypter, so that allocations planned again aren't signed again.
+const identityCacheSize = 8192
+
 type claimSigner interface {
 	SignClaims(*structs.IdentityClaims) (string, string, error)
+	SignClaimsBatch([]*structs.IdentityClaims) ([]string, string, error)
 }
 
 var _ claimSigner = &Encrypter{}
@@ -61,6 +70,29 @@ type Encrypter struct {
 	keyring      map[string]*cipherSet
 	decryptTasks map[string]context.CancelFunc
 	lock         sync.RWMutex
+
+	// providerHealth is the health of each KEK provider, updated every time
+	// a root key is wrapped or unwrapped with the provider. It has its own
+	// lock so it can be updated while the keyring is locked.
+	providerHealth     map[string]*structs.KEKProviderHealth
+	providerHealthLock sync.RWMutex
+
+	// identityCache caches the workload identities signed by SignClaimsBatch.
+	identityCache *lru.Cache[signedIdentityKey, signedIdentity]
+}
+
+// signedIdentityKey identifies a signed workload identity in the identity
+// cache. The subject includes the task and the identity name.
+type signedIdentityKey struct {
+	allocID string
+	subject string
+	keyID   string
+}
+
+// signedIdentity is a signed workload identity in the identity cache.
+type signedIdentity struct {
+	token  string
+	expiry *jwt.NumericDate
 }
 
 // cipherSet contains the key material for variable encryption and workload
@@ -87,13 +119,28 @@ func NewEncrypter(srv *Server, keystorePath string) (*Encrypter, error) {
 		issuer:          srv.GetConfig().OIDCIssuer,
 		providerConfigs: map[string]*structs.KEKProviderConfig{},
 		decryptTasks:    map[string]context.CancelFunc{},
+		providerHealth:  map[string]*structs.KEKProviderHealth{},
+	}
+
+	identityCache, err := lru.New[signedIdentityKey, signedIdentity](identityCacheSize)
+	if err != nil {
+		return nil, err
 	}
+	encrypter.identityCache = identityCache
 
 	providerConfigs, err := getProviderConfigs(srv)
 	if err != nil {
 		return nil, err
 	}
 	encrypter.providerConfigs = providerConfigs
+	for id, provider := range providerConfigs {
+		encrypter.providerHealth[id] = &structs.KEKProviderHealth{
+			ID:       id,
+			Provider: provider.Provider,
+			Active:   provider.Active,
+			Healthy:  true,
+		}
+	}
 
 	err = encrypter.loadKeystore()
 	if err != nil {
@@ -195,15 +242,7 @@ func (e *Encrypter) IsReady(ctx context.Context) error {
 	err := helper.WithBackoffFunc(ctx, time.Millisecond*100, time.Second, func() error {
 		e.lock.RLock()
 		defer e.lock.RUnlock()
-		if len(e.decryptTasks) != 0 {
-			keyIDs := []string{}
-			for keyID := range e.decryptTasks {
-				keyIDs = append(keyIDs, keyID)
-			}
-			return fmt.Errorf("keyring is not ready - waiting for keys %s",
-				strings.Join(keyIDs, ", "))
-		}
-		return nil
+		return e.pendingKeysErrorLocked()
 	})
 	if err != nil {
 		return err
@@ -211,6 +250,115 @@ func (e *Encrypter) IsReady(ctx context.Context) error {
 	return nil
 }
 
+// Ready returns an error if the keyring has no active key yet, or if root keys
+// are still being decrypted. Unlike IsReady it does not block, so it can be
+// used to check whether the server can sign and encrypt.
+func (e *Encrypter) Ready() error {
+	key, err := e.srv.fsm.State().GetActiveRootKey(nil)
+	if err != nil {
+		return err
+	}
+
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	if err := e.pendingKeysErrorLocked(); err != nil {
+		return err
+	}
+	if key == nil {
+		if healthErr := e.providerHealthError(); healthErr != nil {
+			return fmt.Errorf("keyring has not been initialized yet: %w", healthErr)
+		}
+		return fmt.Errorf("keyring has not been initialized yet")
+	}
+	if _, ok := e.keyring[key.KeyID]; !ok {
+		return fmt.Errorf("keyring is not ready - waiting for active key %s", key.KeyID)
+	}
+	return nil
+}
+
+// pendingKeysErrorLocked returns an error listing the keys waiting to be
+// decrypted, if any. The caller must read-lock the keyring
+func (e *Encrypter) pendingKeysErrorLocked() error {
+	if len(e.decryptTasks) == 0 {
+		return nil
+	}
+
+	keyIDs := slices.Sorted(maps.Keys(e.decryptTasks))
+	err := fmt.Errorf("keyring is not ready - waiting for keys %s",
+		strings.Join(keyIDs, ", "))
+	if healthErr := e.providerHealthError(); healthErr != nil {
+		err = fmt.Errorf("%w: %w", err, healthErr)
+	}
+	return err
+}
+
+// PendingKeys returns the IDs of the root keys waiting to be decrypted
+func (e *Encrypter) PendingKeys() []string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	keyIDs := slices.Collect(maps.Keys(e.decryptTasks))
+	slices.Sort(keyIDs)
+	return keyIDs
+}
+
+// ProviderHealth returns the health of each KEK provider, sorted by ID
+func (e *Encrypter) ProviderHealth() []*structs.KEKProviderHealth {
+	e.providerHealthLock.RLock()
+	defer e.providerHealthLock.RUnlock()
+
+	health := make([]*structs.KEKProviderHealth, 0, len(e.providerHealth))
+	for _, h := range e.providerHealth {
+		health = append(health, h.Copy())
+	}
+	slices.SortFunc(health, func(a, b *structs.KEKProviderHealth) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return health
+}
+
+// setProviderHealth records the result of an operation with the KEK provider
+func (e *Encrypter) setProviderHealth(provider *structs.KEKProviderConfig, err error) {
+	e.providerHealthLock.Lock()
+	defer e.providerHealthLock.Unlock()
+
+	h, ok := e.providerHealth[provider.ID()]
+	if !ok {
+		h = &structs.KEKProviderHealth{
+			ID:       provider.ID(),
+			Provider: provider.Provider,
+			Active:   provider.Active,
+		}
+		e.providerHealth[provider.ID()] = h
+	}
+
+	if err == nil && !h.Healthy && !h.LastCheck.IsZero() {
+		e.log.Info("KEK provider has recovered", "provider", h.ID)
+	}
+
+	h.LastCheck = time.Now().UTC()
+	h.Healthy = err == nil
+	h.Error = ""
+	if err != nil {
+		h.Error = err.Error()
+	}
+}
+
+// providerHealthError returns an error naming the KEK providers that failed
+// the last time they were used, or nil if there are none.
+func (e *Encrypter) providerHealthError() error {
+	var failing []string
+	for _, h := range e.ProviderHealth() {
+		if !h.Healthy {
+			failing = append(failing, fmt.Sprintf("KEK provider %q is failing: %s", h.ID, h.Error))
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(failing, "; "))
+}
+
 // Encrypt encrypts the clear data with the cipher for the active root key, and
 // returns the cipher text (including the nonce), and the key ID used to encrypt
 // it
@@ -281,21 +429,9 @@ func (e *Encrypter) SignClaims(claims *structs.IdentityClaims) (string, string,
 		claims.Issuer = e.issuer
 	}
 
-	opts := (&jose.SignerOptions{}).WithHeader("kid", cs.rootKey.Meta.KeyID).WithType("JWT")
-
-	var sig jose.Signer
-	if cs.rsaPrivateKey != nil {
-		// If an RSA key has been created prefer it as it is more widely compatible
-		sig, err = jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: cs.rsaPrivateKey}, opts)
-		if err != nil {
-			return "", "", err
-		}
-	} else {
-		// No RSA key has been created, fallback to ed25519 which always exists
-		sig, err = jose.NewSigner(jose.SigningKey{Algorithm: jose.EdDSA, Key: cs.eddsaPrivateKey}, opts)
-		if err != nil {
-			return "", "", err
-		}
+	sig, err := newClaimsSigner(cs)
+	if err != nil {
+		return "", "", err
 	}
 
 	raw, err := jwt.Signed(sig).Claims(claims).CompactSerialize()
@@ -306,6 +442,82 @@ func (e *Encrypter) SignClaims(claims *structs.IdentityClaims) (string, string,
 	return raw, cs.rootKey.Meta.KeyID, nil
 }
 
+// SignClaimsBatch signs the claims with the active root key, and returns the
+// signed tokens in the same order as the claims along with the ID of the key
+// used. The tokens are cached by allocation, subject, and key ID, and claims
+// that have already been signed with the active key return the cached token
+// as long as it hasn't expired.
+func (e *Encrypter) SignClaimsBatch(claims []*structs.IdentityClaims) ([]string, string, error) {
+	if len(claims) == 0 {
+		return nil, "", nil
+	}
+
+	cs, err := e.activeCipherSet()
+	if err != nil {
+		return nil, "", err
+	}
+	keyID := cs.rootKey.Meta.KeyID
+
+	sig, err := newClaimsSigner(cs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var hits, misses float32
+	defer func() {
+		metrics.IncrCounter([]string{"nomad", "keyring", "identity_cache", "hit"}, hits)
+		metrics.IncrCounter([]string{"nomad", "keyring", "identity_cache", "miss"}, misses)
+	}()
+
+	now := time.Now()
+	tokens := make([]string, len(claims))
+	for i, c := range claims {
+		if c == nil {
+			return nil, "", errors.New("cannot sign empty claims")
+		}
+
+		cacheKey := signedIdentityKey{
+			allocID: c.AllocationID,
+			subject: c.Subject,
+			keyID:   keyID,
+		}
+		if cached, ok := e.identityCache.Get(cacheKey); ok &&
+			(cached.expiry == nil || now.Before(cached.expiry.Time())) {
+			tokens[i] = cached.token
+			hits++
+			continue
+		}
+		misses++
+
+		// Add Issuer claim from server configuration
+		if e.issuer != "" {
+			c.Issuer = e.issuer
+		}
+
+		raw, err := jwt.Signed(sig).Claims(c).CompactSerialize()
+		if err != nil {
+			return nil, "", err
+		}
+		e.identityCache.Add(cacheKey, signedIdentity{token: raw, expiry: c.Expiry})
+		tokens[i] = raw
+	}
+
+	return tokens, keyID, nil
+}
+
+// newClaimsSigner returns a JWT signer for the cipherSet.
+func newClaimsSigner(cs *cipherSet) (jose.Signer, error) {
+	opts := (&jose.SignerOptions{}).WithHeader("kid", cs.rootKey.Meta.KeyID).WithType("JWT")
+
+	if cs.rsaPrivateKey != nil {
+		// If an RSA key has been created prefer it as it is more widely compatible
+		return jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: cs.rsaPrivateKey}, opts)
+	}
+
+	// No RSA key has been created, fallback to ed25519 which always exists
+	return jose.NewSigner(jose.SigningKey{Algorithm: jose.EdDSA, Key: cs.eddsaPrivateKey}, opts)
+}
+
 // VerifyClaim accepts a previously-signed encoded claim and validates
 // it before returning the claim
 func (e *Encrypter) VerifyClaim(tokenString string) (*structs.IdentityClaims, error) {
@@ -414,6 +626,8 @@ func (e *Encrypter) AddWrappedKey(ctx context.Context, wrappedKeys *structs.Root
 
 		wrapper, err := e.newKMSWrapper(provider, wrappedKeys.KeyID, wrappedKey.KeyEncryptionKey)
 		if err != nil {
+			e.setProviderHealth(provider, err)
+
 			// the errors that bubble up from this library can be a bit opaque, so
 			// make sure we wrap them with as much context as possible
 			err := fmt.Errorf("unable to create KMS wrapper for provider %q: %w", providerID, err)
@@ -460,9 +674,10 @@ func (e *Encrypter) decryptWrappedKeyTask(ctx context.Context, cancel context.Ca
 		wrappedDEK := wrappedKey.WrappedDataEncryptionKey
 		var err error
 		key, err = wrapper.Decrypt(e.srv.shutdownCtx, wrappedDEK)
+		e.setProviderHealth(provider, err)
 		if err != nil {
 			err := fmt.Errorf("%w (root key): %w", ErrDecryptFailed, err)
-			e.log.Error(err.Error(), "key_id", meta.KeyID)
+			e.log.Error(err.Error(), "key_id", meta.KeyID, "provider", provider.ID())
 			return err
 		}
 		return nil
@@ -618,6 +833,12 @@ func (e *Encrypter) activeCipherSet() (*cipherSet, error) {
 		return nil, err
 	}
 	if key == nil {
+		// the leader can fail to initialize the keyring because the KEK
+		// provider is failing, so report it instead of leaving the operator
+		// guessing
+		if healthErr := e.providerHealthError(); healthErr != nil {
+			return nil, fmt.Errorf("keyring has not been initialized yet: %w", healthErr)
+		}
 		return nil, fmt.Errorf("keyring has not been initialized yet")
 	}
 
@@ -631,6 +852,11 @@ func (e *Encrypter) activeCipherSet() (*cipherSet, error) {
 func (e *Encrypter) cipherSetByIDLocked(keyID string) (*cipherSet, error) {
 	cipherSet, ok := e.keyring[keyID]
 	if !ok {
+		if _, pending := e.decryptTasks[keyID]; pending {
+			if healthErr := e.providerHealthError(); healthErr != nil {
+				return nil, fmt.Errorf("no such key %q in keyring: %w", keyID, healthErr)
+			}
+		}
 		return nil, fmt.Errorf("no such key %q in keyring", keyID)
 	}
 	return cipherSet, nil
@@ -700,12 +926,14 @@ func (e *Encrypter) encryptDEK(rootKey *structs.UnwrappedRootKey, provider *stru
 	}
 	wrapper, err := e.newKMSWrapper(provider, rootKey.Meta.KeyID, kek)
 	if err != nil {
+		e.setProviderHealth(provider, err)
 		return nil, fmt.Errorf("unable to create key wrapper: %w", err)
 	}
 
 	rootBlob, err := wrapper.Encrypt(e.srv.shutdownCtx, rootKey.Key)
+	e.setProviderHealth(provider, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt root key: %w", err)
+		return nil, fmt.Errorf("failed to encrypt root key with KEK provider %q: %w", provider.ID(), err)
 	}
 
 	kekWrapper := &structs.WrappedKey{
@@ -887,26 +1115,23 @@ func (e *Encrypter) GetPublicKey(keyID string) (*structs.KeyringPublicKey, error
 	return pubKey, nil
 }
 
+// kekProviderWrappers are the constructors of the go-kms-wrapping wrappers for
+// the external KEK providers.
+//
+// note: adding support for another provider from go-kms-wrapping is a matter
+// of adding the dependency and another entry here, but the remaining
+// third-party providers add significantly to binary size
+var kekProviderWrappers = map[string]func() kms.Wrapper{
+	structs.KEKProviderAWSKMS:        func() kms.Wrapper { return awskms.NewWrapper() },
+	structs.KEKProviderAzureKeyVault: func() kms.Wrapper { return azurekeyvault.NewWrapper() },
+	structs.KEKProviderGCPCloudKMS:   func() kms.Wrapper { return gcpckms.NewWrapper() },
+	structs.KEKProviderVaultTransit:  func() kms.Wrapper { return transit.NewWrapper() },
+}
+
 // newKMSWrapper returns a go-kms-wrapping interface the caller can use to
 // encrypt the RootKey with a key encryption key (KEK).
 func (e *Encrypter) newKMSWrapper(provider *structs.KEKProviderConfig, keyID string, kek []byte) (kms.Wrapper, error) {
-	var wrapper kms.Wrapper
-
-	// note: adding support for another provider from go-kms-wrapping is a
-	// matter of adding the dependency and another case here, but the remaining
-	// third-party providers add significantly to binary size
-
-	switch provider.Provider {
-	case structs.KEKProviderAWSKMS:
-		wrapper = awskms.NewWrapper()
-	case structs.KEKProviderAzureKeyVault:
-		wrapper = azurekeyvault.NewWrapper()
-	case structs.KEKProviderGCPCloudKMS:
-		wrapper = gcpckms.NewWrapper()
-	case structs.KEKProviderVaultTransit:
-		wrapper = transit.NewWrapper()
-
-	default: // "aead"
+	if provider.Provider == string(structs.KEKProviderAEAD) || provider.Provider == "" {
 		wrapper := aead.NewWrapper()
 		wrapper.SetConfig(context.Background(),
 			aead.WithAeadType(kms.AeadTypeAesGcm),
@@ -920,6 +1145,12 @@ func (e *Encrypter) newKMSWrapper(provider *structs.KEKProviderConfig, keyID str
 		return wrapper, nil
 	}
 
+	newWrapper, ok := kekProviderWrappers[provider.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown KEK provider %q", provider.Provider)
+	}
+	wrapper := newWrapper()
+
 	config, ok := e.providerConfigs[provider.ID()]
 	if ok {
 		_, err := wrapper.SetConfig(context.Background(), kms.WithConfigMap(config.Config))
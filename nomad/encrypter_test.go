@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"net/rpc"
@@ -20,12 +21,14 @@ import (
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/crypto"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/shoenig/test"
 	"github.com/shoenig/test/must"
@@ -52,6 +55,17 @@ func (s *mockSigner) SignClaims(c *structs.IdentityClaims) (token, keyID string,
 	return s.nextToken, s.nextKeyID, s.nextErr
 }
 
+func (s *mockSigner) SignClaimsBatch(claims []*structs.IdentityClaims) (tokens []string, keyID string, err error) {
+	s.calls = append(s.calls, claims...)
+	if s.nextErr != nil {
+		return nil, "", s.nextErr
+	}
+	for range claims {
+		tokens = append(tokens, s.nextToken)
+	}
+	return tokens, s.nextKeyID, nil
+}
+
 // TestEncrypter_LoadSave exercises round-tripping keys to disk
 func TestEncrypter_LoadSave(t *testing.T) {
 	ci.Parallel(t)
@@ -553,6 +567,79 @@ func TestEncrypter_SignVerify(t *testing.T) {
 	must.Eq(t, "", got.Issuer)
 }
 
+// TestEncrypter_SignClaimsBatch asserts that batches of claims are signed with
+// the active key and that identities already signed with it are cached.
+func TestEncrypter_SignClaimsBatch(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+
+	e := srv.encrypter
+	now := time.Now()
+
+	buildClaims := func(alloc *structs.Allocation, ttl time.Duration) *structs.IdentityClaims {
+		task := alloc.LookupTask("web")
+		wid := task.Identity.Copy()
+		wid.TTL = ttl
+		return structs.NewIdentityClaimsBuilder(alloc.Job, alloc, wiHandle, wid).
+			WithTask(task).
+			Build(now)
+	}
+
+	tokens, keyID, err := e.SignClaimsBatch(nil)
+	must.NoError(t, err)
+	must.Nil(t, tokens)
+	must.Eq(t, "", keyID)
+
+	alloc1, alloc2 := mock.Alloc(), mock.Alloc()
+	tokens, keyID, err = e.SignClaimsBatch([]*structs.IdentityClaims{
+		buildClaims(alloc1, 0), buildClaims(alloc2, 0)})
+	must.NoError(t, err)
+	must.Len(t, 2, tokens)
+	must.NotEq(t, "", keyID)
+
+	for i, alloc := range []*structs.Allocation{alloc1, alloc2} {
+		got, err := e.VerifyClaim(tokens[i])
+		must.NoError(t, err)
+		must.Eq(t, alloc.ID, got.AllocationID)
+		must.Eq(t, "web", got.TaskName)
+	}
+
+	// Signing the same identities again returns the cached tokens
+	cached, cachedKeyID, err := e.SignClaimsBatch([]*structs.IdentityClaims{
+		buildClaims(alloc2, 0), buildClaims(alloc1, 0)})
+	must.NoError(t, err)
+	must.Eq(t, keyID, cachedKeyID)
+	must.Eq(t, []string{tokens[1], tokens[0]}, cached)
+
+	// Expired tokens are not returned from the cache
+	alloc3 := mock.Alloc()
+	expired, _, err := e.SignClaimsBatch([]*structs.IdentityClaims{
+		buildClaims(alloc3, time.Nanosecond)})
+	must.NoError(t, err)
+	resigned, _, err := e.SignClaimsBatch([]*structs.IdentityClaims{
+		buildClaims(alloc3, time.Nanosecond)})
+	must.NoError(t, err)
+	must.NotEq(t, expired[0], resigned[0])
+
+	// Rotating the key invalidates the cache
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	must.NoError(t, srv.RPC("Keyring.Rotate", rotateReq, &rotateResp))
+
+	rotated, rotatedKeyID, err := e.SignClaimsBatch([]*structs.IdentityClaims{
+		buildClaims(alloc1, 0)})
+	must.NoError(t, err)
+	must.Eq(t, rotateResp.Key.KeyID, rotatedKeyID)
+	must.NotEq(t, tokens[0], rotated[0])
+}
+
 // TestEncrypter_SignVerify_Issuer asserts that the signer adds an issuer if it
 // is configured.
 func TestEncrypter_SignVerify_Issuer(t *testing.T) {
@@ -874,3 +961,199 @@ func TestEncrypter_decryptWrappedKeyTask(t *testing.T) {
 	err = encrypter.decryptWrappedKeyTask(ctx, cancel, KMSWrapper, provider, key.Meta, wrappedKey)
 	must.NoError(t, err)
 }
+
+func TestEncrypter_ProviderHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := &Server{
+		logger: testlog.HCLogger(t),
+		config: &Config{},
+	}
+
+	encrypter, err := NewEncrypter(srv, t.TempDir())
+	must.NoError(t, err)
+
+	key, err := structs.NewUnwrappedRootKey(structs.EncryptionAlgorithmAES256GCM)
+	must.NoError(t, err)
+
+	provider := encrypter.providerConfigs[string(structs.KEKProviderAEAD)]
+	must.NotNil(t, provider)
+
+	// Providers are healthy until they fail
+	health := encrypter.ProviderHealth()
+	must.Len(t, 1, health)
+	must.Eq(t, "aead", health[0].ID)
+	must.True(t, health[0].Healthy)
+	must.True(t, health[0].Active)
+	must.True(t, health[0].LastCheck.IsZero())
+	must.NoError(t, encrypter.providerHealthError())
+
+	wrappedKey, err := encrypter.encryptDEK(key, provider)
+	must.NoError(t, err)
+	health = encrypter.ProviderHealth()
+	must.True(t, health[0].Healthy)
+	must.False(t, health[0].LastCheck.IsZero())
+
+	// Decrypting with the wrong KEK fails and marks the provider unhealthy
+	wrongKEK, err := crypto.Bytes(32)
+	must.NoError(t, err)
+	wrapper, err := encrypter.newKMSWrapper(provider, key.Meta.KeyID, wrongKEK)
+	must.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err = encrypter.decryptWrappedKeyTask(ctx, cancel, wrapper, provider, key.Meta, wrappedKey)
+	must.ErrorIs(t, err, ErrDecryptFailed)
+
+	health = encrypter.ProviderHealth()
+	must.False(t, health[0].Healthy)
+	must.StrContains(t, health[0].Error, "message authentication failed")
+	must.ErrorContains(t, encrypter.providerHealthError(), `KEK provider "aead" is failing`)
+
+	// Succeeding again marks the provider healthy
+	wrapper, err = encrypter.newKMSWrapper(provider, key.Meta.KeyID, wrappedKey.KeyEncryptionKey)
+	must.NoError(t, err)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = encrypter.decryptWrappedKeyTask(ctx, cancel, wrapper, provider, key.Meta, wrappedKey)
+	must.NoError(t, err)
+
+	health = encrypter.ProviderHealth()
+	must.True(t, health[0].Healthy)
+	must.Eq(t, "", health[0].Error)
+	must.NoError(t, encrypter.providerHealthError())
+}
+
+func TestEncrypter_ProviderHealth_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	t.Cleanup(shutdown)
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+
+	encrypter := srv.encrypter
+	activeKey, err := srv.State().GetActiveRootKey(nil)
+	must.NoError(t, err)
+
+	provider := &structs.KEKProviderConfig{Provider: "awskms", Name: "prod", Active: true}
+	encrypter.setProviderHealth(provider, errors.New("AccessDeniedException"))
+
+	health := encrypter.ProviderHealth()
+	must.Len(t, 2, health)
+	must.Eq(t, "aead", health[0].ID)
+	must.Eq(t, "awskms.prod", health[1].ID)
+	must.False(t, health[1].Healthy)
+
+	// Keys that are decrypted are still available
+	_, err = encrypter.GetKey(activeKey.KeyID)
+	must.NoError(t, err)
+
+	// Keys pending decryption report the failing provider
+	encrypter.lock.Lock()
+	encrypter.decryptTasks["pending"] = func() {}
+	encrypter.lock.Unlock()
+	t.Cleanup(func() {
+		encrypter.lock.Lock()
+		delete(encrypter.decryptTasks, "pending")
+		encrypter.lock.Unlock()
+	})
+
+	_, err = encrypter.GetKey("pending")
+	must.EqError(t, err,
+		`no such key "pending" in keyring: KEK provider "awskms.prod" is failing: AccessDeniedException`)
+	_, err = encrypter.GetKey("missing")
+	must.EqError(t, err, `no such key "missing" in keyring`)
+	must.Eq(t, []string{"pending"}, encrypter.PendingKeys())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	must.ErrorContains(t, encrypter.IsReady(ctx), `KEK provider "awskms.prod" is failing`)
+}
+
+func TestEncrypter_Ready(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	t.Cleanup(shutdown)
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+
+	encrypter := srv.encrypter
+	must.NoError(t, encrypter.Ready())
+
+	// Servers advertise their keyring readiness
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return srv.serf.LocalMember().Tags[keyringReadyTag] == "true"
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	encrypter.lock.Lock()
+	encrypter.decryptTasks["pending"] = func() {}
+	encrypter.lock.Unlock()
+	t.Cleanup(func() {
+		encrypter.lock.Lock()
+		delete(encrypter.decryptTasks, "pending")
+		encrypter.lock.Unlock()
+	})
+	must.EqError(t, encrypter.Ready(), "keyring is not ready - waiting for keys pending")
+}
+
+func TestEncrypter_Ready_NotInitialized(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.KEKProviderConfigs = []*structs.KEKProviderConfig{{
+			Provider: "no-such-provider",
+			Active:   true,
+		}}
+	})
+	t.Cleanup(shutdown)
+	testutil.WaitForLeader(t, srv.RPC) // don't WaitForKeyring
+
+	// wait for the leader to fail to initialize the keyring
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return srv.encrypter.providerHealthError() != nil }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	must.EqError(t, srv.encrypter.Ready(),
+		`keyring has not been initialized yet: KEK provider "no-such-provider" is failing: unknown KEK provider "no-such-provider"`)
+
+	must.Eq(t, "false", srv.serf.LocalMember().Tags[keyringReadyTag])
+
+	// Evaluations are held in the eval broker, so schedulers don't submit
+	// plans that can't be applied
+	eval := mock.Eval()
+	srv.evalBroker.Enqueue(eval)
+
+	req := &structs.EvalDequeueRequest{
+		Schedulers:       defaultSched,
+		Timeout:          100 * time.Millisecond,
+		SchedulerVersion: scheduler.SchedulerVersion,
+		WriteRequest:     structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.EvalDequeueResponse
+	must.NoError(t, srv.RPC("Eval.Dequeue", req, &resp))
+	must.Nil(t, resp.Eval)
+	must.Eq(t, 1, srv.evalBroker.Stats().TotalReady)
+}
+
+func TestEncrypter_UnknownProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	encrypter := &Encrypter{providerConfigs: map[string]*structs.KEKProviderConfig{}}
+	_, err := encrypter.newKMSWrapper(&structs.KEKProviderConfig{Provider: "hsm"}, "", nil)
+	must.EqError(t, err, `unknown KEK provider "hsm"`)
+
+	for _, provider := range []string{"awskms", "azurekeyvault", "gcpckms", "transit"} {
+		_, err := encrypter.newKMSWrapper(&structs.KEKProviderConfig{Provider: provider}, "", nil)
+		must.NoError(t, err, must.Sprint(provider))
+	}
+}
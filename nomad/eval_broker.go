@@ -118,6 +118,25 @@ type EvalBroker struct {
 	enqueuedTime map[string]time.Time
 	dequeuedTime map[string]time.Time
 
+	// dequeueMode controls the order in which ready evaluations are dequeued.
+	// In the fair-share modes, the ready evaluations are held in fairReady
+	// rather than ready, fairTags tracks the virtual time at which each
+	// namespace or priority is next due to be served, fairExpiry orders those
+	// times so they can be dropped once passed, and fairClock is the virtual
+	// time of the last dequeue.
+	dequeueMode structs.EvalBrokerDequeueMode
+	fairReady   map[string]*fairReadyQueue
+	fairTags    map[string]float64
+	fairExpiry  fairTagHeap
+	fairClock   float64
+
+	// dequeues, nacks, and requeues count the evaluations dequeued, Nacked,
+	// and re-enqueued after being reblocked by a scheduler, and are used to
+	// report the rate of each over the last minute
+	dequeues brokerRate
+	nacks    brokerRate
+	requeues brokerRate
+
 	l sync.RWMutex
 }
 
@@ -168,6 +187,8 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		dequeuedTime:         make(map[string]time.Time),
 		delayHeap:            delayheap.NewDelayHeap(),
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
+		fairReady:            make(map[string]*fairReadyQueue),
+		fairTags:             make(map[string]float64),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
@@ -210,6 +231,38 @@ func (b *EvalBroker) SetEnabled(enabled bool) {
 	b.enabledNotifier.Notify("eval broker enabled status changed to " + strconv.FormatBool(enabled))
 }
 
+// SetDequeueMode is used to set the order in which ready evaluations are
+// dequeued. Changing the mode resets the fair-share accounting and moves the
+// ready evaluations to the ready queues of the new mode.
+func (b *EvalBroker) SetDequeueMode(mode structs.EvalBrokerDequeueMode) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if mode == structs.EvalBrokerDequeueModePriority {
+		mode = ""
+	}
+	if mode == b.dequeueMode {
+		return
+	}
+
+	ready := make(map[string][]*structs.Evaluation)
+	for sched, readyQueue := range b.ready {
+		ready[sched] = append(ready[sched], readyQueue...)
+	}
+	for sched, q := range b.fairReady {
+		ready[sched] = append(ready[sched], q.evals()...)
+	}
+
+	b.dequeueMode = mode
+	b.ready = make(map[string]ReadyEvaluations)
+	b.resetFairLocked()
+	for sched, evals := range ready {
+		for _, eval := range evals {
+			b.pushReadyLocked(sched, eval)
+		}
+	}
+}
+
 // Enqueue is used to enqueue a new evaluation
 func (b *EvalBroker) Enqueue(eval *structs.Evaluation) {
 	b.l.Lock()
@@ -283,6 +336,7 @@ func (b *EvalBroker) processEnqueue(eval *structs.Evaluation, token string, trac
 	if !eval.WaitUntil.IsZero() {
 		b.delayHeap.Push(&evalWrapper{eval}, eval.WaitUntil)
 		b.stats.TotalWaiting += 1
+		b.schedulerStatsLocked(eval.Type).Waiting += 1
 		b.stats.DelayedEvals[eval.ID] = eval
 		// Signal an update.
 		select {
@@ -303,6 +357,7 @@ func (b *EvalBroker) processWaitingEnqueue(eval *structs.Evaluation, trackTime b
 	})
 	b.timeWait[eval.ID] = timer
 	b.stats.TotalWaiting += 1
+	b.schedulerStatsLocked(eval.Type).Waiting += 1
 }
 
 // enqueueWaiting is used to enqueue a waiting evaluation
@@ -312,6 +367,7 @@ func (b *EvalBroker) enqueueWaiting(eval *structs.Evaluation, trackTime bool) {
 
 	delete(b.timeWait, eval.ID)
 	b.stats.TotalWaiting -= 1
+	b.schedulerStatsLocked(eval.Type).Waiting -= 1
 
 	b.enqueueLocked(eval, eval.Type, trackTime)
 }
@@ -348,27 +404,16 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		return
 	}
 
-	// Find the next ready eval by scheduler class
-	readyQueue, ok := b.ready[sched]
-	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
-		if _, ok := b.waiting[sched]; !ok {
-			b.waiting[sched] = make(chan struct{}, 1)
-		}
+	if _, ok := b.waiting[sched]; !ok {
+		b.waiting[sched] = make(chan struct{}, 1)
 	}
 
-	// Push onto the heap
-	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	// Push onto the ready queue of the scheduler
+	b.pushReadyLocked(sched, eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
-	bySched, ok := b.stats.ByScheduler[sched]
-	if !ok {
-		bySched = &SchedulerStats{}
-		b.stats.ByScheduler[sched] = bySched
-	}
-	bySched.Ready += 1
+	b.schedulerStatsLocked(sched).Ready += 1
 
 	// Unblock any pending dequeues
 	select {
@@ -377,6 +422,25 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 	}
 }
 
+// pushReadyLocked pushes an evaluation onto the ready queue of a scheduler for
+// the current dequeue mode. This assumes locks are held.
+func (b *EvalBroker) pushReadyLocked(sched string, eval *structs.Evaluation) {
+	if b.dequeueMode != "" {
+		b.pushFairLocked(sched, eval)
+		return
+	}
+
+	// Find the next ready eval by scheduler class
+	readyQueue, ok := b.ready[sched]
+	if !ok {
+		readyQueue = make([]*structs.Evaluation, 0, 16)
+	}
+
+	// Push onto the heap
+	heap.Push(&readyQueue, eval)
+	b.ready[sched] = readyQueue
+}
+
 // Dequeue is used to perform a blocking dequeue. The next available evaluation
 // is returned as well as a unique token identifier for this dequeue. The token
 // changes on leadership election to ensure a Dequeue prior to a leadership
@@ -441,6 +505,10 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		return nil, "", fmt.Errorf("eval broker disabled")
 	}
 
+	if b.dequeueMode != "" {
+		return b.scanFairLocked(schedulers)
+	}
+
 	// Scan for eligible work
 	var eligibleSched []string
 	var eligiblePriority int
@@ -496,6 +564,12 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	b.ready[sched] = readyQueue
 	eval := raw.(*structs.Evaluation)
 
+	return b.dequeueEvalLocked(sched, eval)
+}
+
+// dequeueEvalLocked tracks an evaluation removed from the ready queue of a
+// scheduler until it is acknowledged. This assumes locks are held.
+func (b *EvalBroker) dequeueEvalLocked(sched string, eval *structs.Evaluation) (*structs.Evaluation, string, error) {
 	// Generate a UUID for the token
 	token := uuid.Generate()
 
@@ -513,6 +587,7 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 
 	// Increment the dequeue count
 	b.evals[eval.ID] += 1
+	b.dequeues.inc(time.Now())
 
 	// Update the stats
 	b.stats.TotalReady -= 1
@@ -668,6 +743,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 
 	// Re-enqueue the evaluation.
 	if eval, ok := b.requeue[token]; ok {
+		b.requeues.inc(time.Now())
 		b.processEnqueue(eval, "", true)
 	}
 
@@ -695,6 +771,7 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 
 	// Stop the timer, doesn't matter if we've missed it
 	unack.NackTimer.Stop()
+	b.nacks.inc(time.Now())
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -849,6 +926,21 @@ func (b *EvalBroker) flush() {
 	b.delayHeap = delayheap.NewDelayHeap()
 	b.enqueuedTime = make(map[string]time.Time)
 	b.dequeuedTime = make(map[string]time.Time)
+	b.resetFairLocked()
+	b.dequeues = brokerRate{}
+	b.nacks = brokerRate{}
+	b.requeues = brokerRate{}
+}
+
+// schedulerStatsLocked returns the stats of the given scheduler, creating them
+// if needed. It must be called with the lock held.
+func (b *EvalBroker) schedulerStatsLocked(sched string) *SchedulerStats {
+	bySched, ok := b.stats.ByScheduler[sched]
+	if !ok {
+		bySched = &SchedulerStats{}
+		b.stats.ByScheduler[sched] = bySched
+	}
+	return bySched
 }
 
 // evalWrapper satisfies the HeapNode interface
@@ -895,6 +987,7 @@ func (b *EvalBroker) runDelayedEvalsWatcher(ctx context.Context, updateCh <-chan
 			b.l.Lock()
 			b.delayHeap.Remove(&evalWrapper{eval})
 			b.stats.TotalWaiting -= 1
+			b.schedulerStatsLocked(eval.Type).Waiting -= 1
 			delete(b.stats.DelayedEvals, eval.ID)
 			b.enqueueLocked(eval, eval.Type, true)
 			b.l.Unlock()
@@ -949,6 +1042,87 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	return stats
 }
 
+// Status is used to return a snapshot of the broker for operators: the number
+// of evaluations in each state by scheduler, the age of the oldest ready and
+// pending evaluations, and the dequeue, Nack, and requeue rates.
+func (b *EvalBroker) Status() *structs.EvalBrokerStatus {
+	now := time.Now()
+
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	status := &structs.EvalBrokerStatus{
+		Enabled:           b.enabled,
+		DequeueMode:       b.dequeueMode,
+		TotalReady:        b.stats.TotalReady,
+		TotalUnacked:      b.stats.TotalUnacked,
+		TotalPending:      b.stats.TotalPending,
+		TotalWaiting:      b.stats.TotalWaiting,
+		TotalCancelable:   b.stats.TotalCancelable,
+		ByScheduler:       make(map[string]*structs.EvalBrokerSchedulerStatus),
+		DequeuesPerMinute: b.dequeues.lastMinute(now),
+		NacksPerMinute:    b.nacks.lastMinute(now),
+		RequeuesPerMinute: b.requeues.lastMinute(now),
+		TotalDequeues:     b.dequeues.total,
+		TotalNacks:        b.nacks.total,
+		TotalRequeues:     b.requeues.total,
+	}
+	if status.DequeueMode == "" {
+		status.DequeueMode = structs.EvalBrokerDequeueModePriority
+	}
+
+	schedStatus := func(sched string) *structs.EvalBrokerSchedulerStatus {
+		s, ok := status.ByScheduler[sched]
+		if !ok {
+			s = &structs.EvalBrokerSchedulerStatus{}
+			status.ByScheduler[sched] = s
+		}
+		return s
+	}
+
+	for sched, stats := range b.stats.ByScheduler {
+		s := schedStatus(sched)
+		s.Ready = stats.Ready
+		s.Unacked = stats.Unacked
+		s.Waiting = stats.Waiting
+	}
+
+	readyAge := func(sched string, ready []*structs.Evaluation) {
+		s := schedStatus(sched)
+		for _, eval := range ready {
+			age := evalAge(eval, now)
+			s.OldestReadyAge = max(s.OldestReadyAge, age)
+			status.OldestReadyAge = max(status.OldestReadyAge, age)
+		}
+	}
+	for sched, ready := range b.ready {
+		readyAge(sched, ready)
+	}
+	for sched, q := range b.fairReady {
+		readyAge(sched, q.evals())
+	}
+
+	for _, pending := range b.pending {
+		for _, eval := range pending {
+			age := evalAge(eval, now)
+			s := schedStatus(eval.Type)
+			s.Pending++
+			s.OldestPendingAge = max(s.OldestPendingAge, age)
+			status.OldestPendingAge = max(status.OldestPendingAge, age)
+		}
+	}
+
+	return status
+}
+
+// evalAge returns the time since the evaluation was created.
+func evalAge(eval *structs.Evaluation, now time.Time) time.Duration {
+	if eval.CreateTime == 0 {
+		return 0
+	}
+	return max(now.Sub(time.Unix(0, eval.CreateTime)), 0)
+}
+
 // Cancelable retrieves a batch of previously-pending evaluations that are now
 // stale and ready to mark for canceling. The eval RPC will call this with a
 // batch size set to avoid sending overly large raft messages.
@@ -1018,6 +1192,46 @@ type BrokerStats struct {
 type SchedulerStats struct {
 	Ready   int
 	Unacked int
+	Waiting int
+}
+
+// brokerRate counts events over a sliding window of one minute, using one
+// bucket per second.
+type brokerRate struct {
+	buckets [60]brokerRateBucket
+
+	// total is the number of events since the broker was last flushed
+	total uint64
+}
+
+type brokerRateBucket struct {
+	second int64
+	count  int
+}
+
+// inc counts an event at the given time.
+func (r *brokerRate) inc(now time.Time) {
+	second := now.Unix()
+	bucket := &r.buckets[second%int64(len(r.buckets))]
+	if bucket.second != second {
+		bucket.second = second
+		bucket.count = 0
+	}
+	bucket.count++
+	r.total++
+}
+
+// lastMinute returns the number of events in the minute before the given
+// time.
+func (r *brokerRate) lastMinute(now time.Time) int {
+	second := now.Unix()
+	var count int
+	for _, bucket := range r.buckets {
+		if age := second - bucket.second; age >= 0 && age < int64(len(r.buckets)) {
+			count += bucket.count
+		}
+	}
+	return count
 }
 
 // Len is for the sorting interface
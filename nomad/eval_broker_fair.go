@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"container/heap"
+	"strconv"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// fairReadyQueue holds the ready evaluations of a scheduler in one of the
+// fair-share dequeue modes. The evaluations are grouped by namespace or by
+// priority. The groups due at the current virtual time are ordered by their
+// next evaluation and the others by the virtual time at which they are due,
+// so the next evaluation is found and dequeued in logarithmic time.
+type fairReadyQueue struct {
+	groups  map[string]*fairGroup
+	due     fairGroups
+	waiting fairGroups
+}
+
+// fairGroup holds the ready evaluations of a scheduler for a namespace or a
+// priority.
+type fairGroup struct {
+	key   string
+	ready ReadyEvaluations
+
+	// waiting is set when the group is due at the virtual time tag rather
+	// than at the current virtual time
+	waiting bool
+	tag     float64
+
+	// index is the index of the group in the due or waiting heap
+	index int
+}
+
+func newFairReadyQueue() *fairReadyQueue {
+	return &fairReadyQueue{
+		groups:  make(map[string]*fairGroup),
+		waiting: fairGroups{byTag: true},
+	}
+}
+
+// push adds an evaluation to the group of the key, creating the group if
+// needed. A new group is due at the virtual time tag if waiting is set.
+func (q *fairReadyQueue) push(key string, eval *structs.Evaluation, tag float64, waiting bool) {
+	if g, ok := q.groups[key]; ok {
+		heap.Push(&g.ready, eval)
+		heap.Fix(q.heapOf(g), g.index)
+		return
+	}
+
+	g := &fairGroup{
+		key:     key,
+		ready:   ReadyEvaluations{eval},
+		waiting: waiting,
+		tag:     tag,
+	}
+	q.groups[key] = g
+	heap.Push(q.heapOf(g), g)
+}
+
+// peek returns the group whose next evaluation is dequeued first, or nil if
+// there are no ready evaluations.
+func (q *fairReadyQueue) peek() *fairGroup {
+	if q.due.Len() > 0 {
+		return q.due.groups[0]
+	}
+	if q.waiting.Len() > 0 {
+		return q.waiting.groups[0]
+	}
+	return nil
+}
+
+// pop removes and returns the next evaluation of the group, removing the
+// group once it's empty.
+func (q *fairReadyQueue) pop(g *fairGroup) *structs.Evaluation {
+	eval := heap.Pop(&g.ready).(*structs.Evaluation)
+	if g.ready.Len() == 0 {
+		heap.Remove(q.heapOf(g), g.index)
+		delete(q.groups, g.key)
+	} else {
+		heap.Fix(q.heapOf(g), g.index)
+	}
+	return eval
+}
+
+// wait makes the group of the key due at the virtual time tag.
+func (q *fairReadyQueue) wait(key string, tag float64) {
+	g, ok := q.groups[key]
+	if !ok {
+		return
+	}
+
+	if g.waiting {
+		g.tag = tag
+		heap.Fix(&q.waiting, g.index)
+		return
+	}
+
+	heap.Remove(&q.due, g.index)
+	g.waiting, g.tag = true, tag
+	heap.Push(&q.waiting, g)
+}
+
+// release makes the waiting groups due at the virtual time clock.
+func (q *fairReadyQueue) release(clock float64) {
+	for q.waiting.Len() > 0 && q.waiting.groups[0].tag <= clock {
+		g := heap.Pop(&q.waiting).(*fairGroup)
+		g.waiting = false
+		heap.Push(&q.due, g)
+	}
+}
+
+// evals returns all the ready evaluations of the queue.
+func (q *fairReadyQueue) evals() []*structs.Evaluation {
+	var evals []*structs.Evaluation
+	for _, g := range q.groups {
+		evals = append(evals, g.ready...)
+	}
+	return evals
+}
+
+func (q *fairReadyQueue) heapOf(g *fairGroup) *fairGroups {
+	if g.waiting {
+		return &q.waiting
+	}
+	return &q.due
+}
+
+// fairGroups is a heap of groups of ready evaluations ordered by their next
+// evaluation, and first by the virtual time at which they are due if byTag is
+// set.
+type fairGroups struct {
+	groups []*fairGroup
+	byTag  bool
+}
+
+func (h fairGroups) Len() int {
+	return len(h.groups)
+}
+
+func (h fairGroups) Less(i, j int) bool {
+	a, b := h.groups[i], h.groups[j]
+	if h.byTag && a.tag != b.tag {
+		return a.tag < b.tag
+	}
+	return fairBefore(a.ready[0], b.ready[0])
+}
+
+func (h fairGroups) Swap(i, j int) {
+	h.groups[i], h.groups[j] = h.groups[j], h.groups[i]
+	h.groups[i].index = i
+	h.groups[j].index = j
+}
+
+func (h *fairGroups) Push(e interface{}) {
+	g := e.(*fairGroup)
+	g.index = len(h.groups)
+	h.groups = append(h.groups, g)
+}
+
+func (h *fairGroups) Pop() interface{} {
+	n := len(h.groups)
+	g := h.groups[n-1]
+	h.groups[n-1] = nil
+	h.groups = h.groups[:n-1]
+	g.index = -1
+	return g
+}
+
+// fairTag is the virtual time at which a namespace or priority is next due
+type fairTag struct {
+	key string
+	tag float64
+}
+
+// fairTagHeap is a heap of virtual times ordered from the earliest
+type fairTagHeap []fairTag
+
+func (h fairTagHeap) Len() int {
+	return len(h)
+}
+
+func (h fairTagHeap) Less(i, j int) bool {
+	return h[i].tag < h[j].tag
+}
+
+func (h fairTagHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *fairTagHeap) Push(e interface{}) {
+	*h = append(*h, e.(fairTag))
+}
+
+func (h *fairTagHeap) Pop() interface{} {
+	n := len(*h)
+	t := (*h)[n-1]
+	*h = (*h)[:n-1]
+	return t
+}
+
+// pushFairLocked pushes an evaluation onto the fair-share ready queue of a
+// scheduler. This assumes locks are held.
+func (b *EvalBroker) pushFairLocked(sched string, eval *structs.Evaluation) {
+	q, ok := b.fairReady[sched]
+	if !ok {
+		q = newFairReadyQueue()
+		b.fairReady[sched] = q
+	}
+
+	key := b.fairKey(eval)
+	tag, ok := b.fairTags[key]
+	q.push(key, eval, tag, ok && tag > b.fairClock)
+}
+
+// scanFairLocked scans for work on any of the schedulers in one of the
+// fair-share dequeue modes. Ready evaluations are grouped by namespace or by
+// priority, and each group is served in turn in proportion to its weight so
+// that a single namespace or priority with many evaluations cannot monopolize
+// the scheduler workers. Within a group, the highest priority work is dequeued
+// first. This assumes locks are held.
+func (b *EvalBroker) scanFairLocked(schedulers []string) (*structs.Evaluation, string, error) {
+	var best *fairGroup
+	var bestSched string
+	for _, sched := range schedulers {
+		q, ok := b.fairReady[sched]
+		if !ok {
+			continue
+		}
+		if g := q.peek(); g != nil && (best == nil || b.fairGroupBefore(g, best)) {
+			best, bestSched = g, sched
+		}
+	}
+	if best == nil {
+		return nil, "", nil
+	}
+
+	// Groups that had no work since they were last served are due at the
+	// current virtual time, so they cannot build up credit. Otherwise the
+	// virtual time advances to when the group is due.
+	if best.waiting {
+		b.advanceFairClockLocked(best.tag)
+	}
+
+	eval := b.fairReady[bestSched].pop(best)
+	b.setFairTagLocked(best.key, b.fairClock+1/b.fairWeight(eval))
+
+	return b.dequeueEvalLocked(bestSched, eval)
+}
+
+// fairGroupBefore returns whether the next evaluation of group a should be
+// dequeued before the next evaluation of group c.
+func (b *EvalBroker) fairGroupBefore(a, c *fairGroup) bool {
+	aTag, cTag := b.fairClock, b.fairClock
+	if a.waiting {
+		aTag = a.tag
+	}
+	if c.waiting {
+		cTag = c.tag
+	}
+	if aTag != cTag {
+		return aTag < cTag
+	}
+	return fairBefore(a.ready[0], c.ready[0])
+}
+
+// advanceFairClockLocked advances the virtual time, making the groups of all
+// schedulers that are due by then ready to be served and dropping the virtual
+// times that have passed. This assumes locks are held.
+func (b *EvalBroker) advanceFairClockLocked(clock float64) {
+	b.fairClock = clock
+	for _, q := range b.fairReady {
+		q.release(clock)
+	}
+
+	for b.fairExpiry.Len() > 0 && b.fairExpiry[0].tag <= clock {
+		t := heap.Pop(&b.fairExpiry).(fairTag)
+		if b.fairTags[t.key] == t.tag {
+			delete(b.fairTags, t.key)
+		}
+	}
+}
+
+// setFairTagLocked sets the virtual time at which the namespace or priority
+// is next due to be served. This assumes locks are held.
+func (b *EvalBroker) setFairTagLocked(key string, tag float64) {
+	b.fairTags[key] = tag
+	heap.Push(&b.fairExpiry, fairTag{key: key, tag: tag})
+	for _, q := range b.fairReady {
+		q.wait(key, tag)
+	}
+}
+
+// resetFairLocked drops the fair-share ready queues and accounting. This
+// assumes locks are held.
+func (b *EvalBroker) resetFairLocked() {
+	b.fairReady = make(map[string]*fairReadyQueue)
+	b.fairTags = make(map[string]float64)
+	b.fairExpiry = nil
+	b.fairClock = 0
+}
+
+// fairKey returns the fair-share group of the evaluation
+func (b *EvalBroker) fairKey(eval *structs.Evaluation) string {
+	if b.dequeueMode == structs.EvalBrokerDequeueModePriorityFair {
+		return strconv.Itoa(eval.Priority)
+	}
+	return eval.Namespace
+}
+
+// fairWeight returns the share of the scheduler workers given to the
+// fair-share group of the evaluation relative to the other groups
+func (b *EvalBroker) fairWeight(eval *structs.Evaluation) float64 {
+	if b.dequeueMode == structs.EvalBrokerDequeueModePriorityFair {
+		return float64(max(eval.Priority, 1))
+	}
+	return 1
+}
+
+// fairBefore returns whether evaluation a should be dequeued before evaluation
+// b when their fair-share groups are due at the same time.
+func fairBefore(a, b *structs.Evaluation) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.CreateIndex < b.CreateIndex
+}
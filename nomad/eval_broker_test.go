@@ -752,6 +752,189 @@ func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	}
 }
 
+// Ensure namespaces share the workers in the namespace fair dequeue mode
+func TestEvalBroker_Dequeue_NamespaceFair(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetDequeueMode(structs.EvalBrokerDequeueModeNamespaceFair)
+
+	enqueue := func(namespace string, priority int, index uint64) {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.Priority = priority
+		eval.CreateIndex = index
+		b.Enqueue(eval)
+	}
+	for i := 1; i <= 4; i++ {
+		enqueue("busy", 70, uint64(i))
+	}
+	enqueue("quiet", 50, 5)
+	enqueue("quiet", 50, 6)
+
+	expected := []string{"busy", "quiet", "busy", "quiet", "busy", "busy"}
+	var got []string
+	for range expected {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.NotNil(t, out)
+		got = append(got, out.Namespace)
+	}
+	must.Eq(t, expected, got)
+
+	// Switching back to the priority mode dequeues by priority only
+	b.SetDequeueMode(structs.EvalBrokerDequeueModePriority)
+	enqueue("busy", 70, 7)
+	enqueue("busy", 70, 8)
+	enqueue("quiet", 50, 9)
+
+	for _, namespace := range []string{"busy", "busy", "quiet"} {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.Eq(t, namespace, out.Namespace)
+	}
+}
+
+// Ensure priorities share the workers in proportion to their priority in the
+// priority fair dequeue mode
+func TestEvalBroker_Dequeue_PriorityFair(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetDequeueMode(structs.EvalBrokerDequeueModePriorityFair)
+
+	for i := 0; i < 6; i++ {
+		for _, priority := range []int{100, 50} {
+			eval := mock.Eval()
+			eval.Priority = priority
+			b.Enqueue(eval)
+		}
+	}
+
+	counts := map[int]int{}
+	for i := 0; i < 6; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		counts[out.Priority]++
+	}
+	must.Eq(t, map[int]int{100: 4, 50: 2}, counts)
+}
+
+// Ensure the namespaces share the workers across schedulers and that ready
+// evaluations are kept when the dequeue mode changes
+func TestEvalBroker_Dequeue_NamespaceFair_Schedulers(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	enqueue := func(namespace, sched string, priority int, index uint64) {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.Type = sched
+		eval.Priority = priority
+		eval.CreateIndex = index
+		b.Enqueue(eval)
+	}
+	for i := 1; i <= 3; i++ {
+		enqueue("busy", structs.JobTypeService, 70, uint64(i))
+		enqueue("busy", structs.JobTypeBatch, 70, uint64(i+3))
+	}
+	enqueue("quiet", structs.JobTypeBatch, 50, 7)
+	enqueue("quiet", structs.JobTypeService, 50, 8)
+
+	// The evaluations enqueued in the priority mode are dequeued in the fair
+	// mode
+	b.SetDequeueMode(structs.EvalBrokerDequeueModeNamespaceFair)
+	must.Eq(t, 8, b.Stats().TotalReady)
+
+	// A worker for the service scheduler only doesn't take the turn of the
+	// quiet namespace on the batch scheduler
+	out, _, err := b.Dequeue([]string{structs.JobTypeService}, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, "busy", out.Namespace)
+	must.Eq(t, 1, out.CreateIndex)
+
+	expected := []uint64{7, 2, 8, 3, 4, 5, 6}
+	var got []uint64
+	for range expected {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.NotNil(t, out)
+		got = append(got, out.CreateIndex)
+	}
+	must.Eq(t, expected, got)
+	must.Eq(t, 0, b.Stats().TotalReady)
+	must.Eq(t, 0, len(b.fairReady[structs.JobTypeService].groups))
+	must.Eq(t, 0, len(b.fairReady[structs.JobTypeBatch].groups))
+
+	// The evaluations enqueued in the fair mode are dequeued in the priority
+	// mode
+	enqueue("quiet", structs.JobTypeService, 50, 9)
+	enqueue("busy", structs.JobTypeService, 70, 10)
+	b.SetDequeueMode(structs.EvalBrokerDequeueModePriority)
+	for _, index := range []uint64{10, 9} {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.Eq(t, index, out.CreateIndex)
+	}
+}
+
+func TestEvalBroker_Status(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Two evaluations for the same job, so the second one is pending
+	eval := mock.Eval()
+	eval.CreateTime = time.Now().Add(-time.Minute).UnixNano()
+	b.Enqueue(eval)
+	eval2 := mock.Eval()
+	eval2.JobID = eval.JobID
+	eval2.CreateIndex = eval.CreateIndex + 1
+	eval2.CreateTime = time.Now().Add(-time.Second).UnixNano()
+	b.Enqueue(eval2)
+
+	status := b.Status()
+	must.True(t, status.Enabled)
+	must.Eq(t, structs.EvalBrokerDequeueModePriority, status.DequeueMode)
+	must.Eq(t, 1, status.TotalReady)
+	must.Eq(t, 1, status.TotalPending)
+	must.GreaterEq(t, time.Minute, status.OldestReadyAge)
+	must.GreaterEq(t, time.Second, status.OldestPendingAge)
+	must.Less(t, time.Minute, status.OldestPendingAge)
+
+	sched := status.ByScheduler[eval.Type]
+	must.NotNil(t, sched)
+	must.Eq(t, 1, sched.Ready)
+	must.Eq(t, 1, sched.Pending)
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval, out)
+	must.NoError(t, b.Nack(eval.ID, token))
+
+	status = b.Status()
+	must.Eq(t, 1, status.DequeuesPerMinute)
+	must.Eq(t, 1, status.NacksPerMinute)
+	must.Eq(t, uint64(1), status.TotalDequeues)
+	must.Eq(t, uint64(1), status.TotalNacks)
+}
+
+func TestBrokerRate(t *testing.T) {
+	ci.Parallel(t)
+
+	var r brokerRate
+	now := time.Now()
+	r.inc(now.Add(-2 * time.Minute))
+	r.inc(now.Add(-30 * time.Second))
+	r.inc(now)
+	r.inc(now)
+
+	must.Eq(t, 3, r.lastMinute(now))
+	must.Eq(t, 2, r.lastMinute(now.Add(45*time.Second)))
+	must.Eq(t, uint64(4), r.total)
+}
+
 // Ensure we get unblocked
 func TestEvalBroker_Dequeue_Blocked(t *testing.T) {
 	ci.Parallel(t)
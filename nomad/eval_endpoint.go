@@ -164,6 +164,17 @@ func (e *Eval) Dequeue(args *structs.EvalDequeueRequest,
 		return nil
 	}
 
+	// Plans can't be applied until the keyring is ready, so hold the evals in
+	// the broker rather than having the schedulers do work that would be
+	// thrown away. The leader notifies the waiting calls once it is ready.
+	if err := e.srv.encrypter.Ready(); err != nil {
+		message := e.srv.evalBroker.enabledNotifier.WaitForChange(args.Timeout)
+		e.logger.Trace("eval broker wait for keyring", "message", message, "reason", err)
+		if e.srv.encrypter.Ready() != nil || !e.srv.evalBroker.Enabled() {
+			return nil
+		}
+	}
+
 	// Attempt the dequeue
 	eval, token, err := e.srv.evalBroker.Dequeue(args.Schedulers, args.Timeout)
 	if err != nil {
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 
@@ -69,11 +70,23 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 		return
 	}
 
+	// Resuming a subscription implies it is durable
+	durable := args.Durable || args.ResumeToken != ""
+	var resumeIndex uint64
+	if args.ResumeToken != "" {
+		resumeIndex, err = structs.ParseEventResumeToken(args.ResumeToken)
+		if err != nil {
+			handleJsonResultError(err, pointer.Of(int64(400)), encoder)
+			return
+		}
+	}
+
 	// Generate the subscription request
 	subReq := &stream.SubscribeRequest{
-		Token:  args.AuthToken,
-		Topics: args.Topics,
-		Index:  uint64(args.Index),
+		Token:       args.AuthToken,
+		Topics:      args.Topics,
+		Index:       uint64(args.Index),
+		ResumeIndex: resumeIndex,
 		// Namespaces is set once, in the event a users ACL is updated to include
 		// more NSes, the current event stream will not include the new NSes.
 		Namespaces: validatedNses,
@@ -102,7 +115,10 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 	var subErr error
 
 	subscription, subErr = publisher.Subscribe(subReq)
-	if subErr != nil {
+	if errors.Is(subErr, stream.ErrResumeIndexNotInBuffer) {
+		handleJsonResultError(subErr, pointer.Of(int64(410)), encoder)
+		return
+	} else if subErr != nil {
 		handleJsonResultError(subErr, pointer.Of(int64(500)), encoder)
 		return
 	}
@@ -153,7 +169,16 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 				continue
 			}
 
-			if err := jsonStream.Send(events); err != nil {
+			var out interface{} = events
+			if durable {
+				out = &structs.DurableEvents{
+					Index:       events.Index,
+					Events:      events.Events,
+					ResumeToken: structs.NewEventResumeToken(events.Index),
+				}
+			}
+
+			if err := jsonStream.Send(out); err != nil {
 				select {
 				case errCh <- err:
 				case <-ctx.Done():
@@ -132,6 +132,85 @@ OUTER:
 
 // TestEventStream_StreamErr asserts an error is returned when an event publisher
 // closes its subscriptions
+func TestEventStream_Resume(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+
+	publisher, err := s1.State().EventBroker()
+	must.NoError(t, err)
+
+	// Use indexes above the ones of the server setup events
+	for i := uint64(1001); i <= 1003; i++ {
+		publisher.Publish(&structs.Events{Index: i, Events: []structs.Event{{Topic: "test", Index: i}}})
+	}
+
+	handler, err := s1.StreamingRpcHandler("Event.Stream")
+	must.NoError(t, err)
+
+	openStream := func(req structs.EventStreamRequest) (*codec.Decoder, func()) {
+		p1, p2 := net.Pipe()
+		go handler(p2)
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		must.NoError(t, encoder.Encode(req))
+		return codec.NewDecoder(p1, structs.MsgpackHandle), func() {
+			p1.Close()
+			p2.Close()
+		}
+	}
+	nextEvents := func(decoder *codec.Decoder) *structs.DurableEvents {
+		for {
+			var msg structs.EventStreamWrapper
+			must.NoError(t, decoder.Decode(&msg))
+			must.Nil(t, msg.Error)
+			if bytes.Equal(msg.Event.Data, stream.JsonHeartbeat.Data) {
+				continue
+			}
+
+			var events structs.DurableEvents
+			must.NoError(t, json.Unmarshal(msg.Event.Data, &events))
+			return &events
+		}
+	}
+
+	// Resume after the first events
+	decoder, closeFn := openStream(structs.EventStreamRequest{
+		Topics:      map[structs.Topic][]string{"test": {"*"}},
+		ResumeToken: structs.NewEventResumeToken(1001),
+		QueryOptions: structs.QueryOptions{
+			Region: s1.Region(),
+		},
+	})
+	defer closeFn()
+
+	events := nextEvents(decoder)
+	must.Eq(t, 1002, events.Index)
+	must.Eq(t, structs.NewEventResumeToken(1002), events.ResumeToken)
+
+	events = nextEvents(decoder)
+	must.Eq(t, 1003, events.Index)
+	must.Eq(t, structs.NewEventResumeToken(1003), events.ResumeToken)
+
+	// An invalid token is rejected
+	decoder, closeFn = openStream(structs.EventStreamRequest{
+		Topics:      map[structs.Topic][]string{"test": {"*"}},
+		ResumeToken: "invalid",
+		QueryOptions: structs.QueryOptions{
+			Region: s1.Region(),
+		},
+	})
+	defer closeFn()
+
+	var msg structs.EventStreamWrapper
+	must.NoError(t, decoder.Decode(&msg))
+	must.NotNil(t, msg.Error)
+	must.Eq(t, 400, *msg.Error.Code)
+	must.StrContains(t, msg.Error.Error(), "invalid resume token")
+}
+
 func TestEventStream_StreamErr(t *testing.T) {
 	ci.Parallel(t)
 
@@ -8,6 +8,7 @@ import (
 	"io"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-bexpr"
@@ -57,6 +58,11 @@ const (
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
 	HostVolumeSnapshot                   SnapshotType = 31
+	ServiceGrantSnapshot                 SnapshotType = 32
+	JobPurgeRecordSnapshot               SnapshotType = 33
+	JobRestartSnapshot                   SnapshotType = 34
+	AppliedPlanSnapshot                  SnapshotType = 35
+	NodeLifecycleRunSnapshot             SnapshotType = 36
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -108,6 +114,11 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
 	HostVolumeSnapshot:                   "HostVolumeSnapshot",
+	ServiceGrantSnapshot:                 "ServiceGrant",
+	JobPurgeRecordSnapshot:               "JobPurgeRecord",
+	JobRestartSnapshot:                   "JobRestart",
+	AppliedPlanSnapshot:                  "AppliedPlan",
+	NodeLifecycleRunSnapshot:             "NodeLifecycleRun",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -150,6 +161,10 @@ type nomadFSM struct {
 	// new state store). Everything internal here is synchronized by the
 	// Raft side, so doesn't need to lock this.
 	stateLock sync.RWMutex
+
+	// unthrottledSnapshots is the number of callers waiting for a snapshot
+	// that must not be throttled, see disablePersistRate.
+	unthrottledSnapshots atomic.Int32
 }
 
 // nomadSnapshot is used to provide a snapshot of the current
@@ -157,6 +172,10 @@ type nomadFSM struct {
 // that may modify the live state.
 type nomadSnapshot struct {
 	snap *state.StateSnapshot
+
+	// persistRate is the maximum number of bytes per second written by
+	// Persist, or zero for unlimited.
+	persistRate uint64
 }
 
 // SnapshotHeader is the first entry in our snapshot
@@ -194,6 +213,10 @@ type FSMConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// SnapshotPersistRate is the maximum number of bytes per second written
+	// when persisting a snapshot. Zero means unlimited.
+	SnapshotPersistRate uint64
 }
 
 // NewFSM is used to construct a new FSM with a blank state.
@@ -395,6 +418,20 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyHostVolumeDelete(msgType, buf[1:], log.Index)
 	case structs.TaskGroupHostVolumeClaimDeleteRequestType:
 		return n.applyTaskGroupHostVolumeClaimDelete(buf[1:], log.Index)
+	case structs.AllocIdentitiesUpdateRequestType:
+		return n.applyAllocIdentitiesUpdate(msgType, buf[1:], log.Index)
+	case structs.AllocTaskResultRegisterRequestType:
+		return n.applyAllocTaskResultRegister(msgType, buf[1:], log.Index)
+	case structs.JobFreezeRequestType:
+		return n.applyJobFreeze(msgType, buf[1:], log.Index)
+	case structs.ServiceGrantUpsertRequestType:
+		return n.applyServiceGrantUpsert(msgType, buf[1:], log.Index)
+	case structs.ServiceGrantDeleteRequestType:
+		return n.applyServiceGrantDelete(msgType, buf[1:], log.Index)
+	case structs.JobRestartUpsertRequestType:
+		return n.applyJobRestartUpsert(msgType, buf[1:], log.Index)
+	case structs.NodeLifecycleRunUpsertRequestType:
+		return n.applyNodeLifecycleRunUpsert(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -561,7 +598,12 @@ func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf [
 		return err
 	}
 
-	if err := n.state.UpdateNodeEligibility(msgType, index, req.NodeID, req.Eligibility, req.UpdatedAt, req.NodeEvent); err != nil {
+	if req.AllocDirBudget {
+		err = n.state.UpdateNodeAllocDirBudgetEligibility(msgType, index, req.NodeID, req.Eligibility, req.UpdatedAt, req.NodeEvent)
+	} else {
+		err = n.state.UpdateNodeEligibility(msgType, index, req.NodeID, req.Eligibility, req.UpdatedAt, req.NodeEvent)
+	}
+	if err != nil {
 		n.logger.Error("UpdateNodeEligibility failed", "error", err)
 		return err
 	}
@@ -607,6 +649,66 @@ func (n *nomadFSM) applyNodePoolDelete(msgType structs.MessageType, buf []byte,
 	return nil
 }
 
+func (n *nomadFSM) applyServiceGrantUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_service_grant_upsert"}, time.Now())
+	var req structs.ServiceGrantUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertServiceGrants(msgType, index, req.Grants); err != nil {
+		n.logger.Error("UpsertServiceGrants failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyJobRestartUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_restart_upsert"}, time.Now())
+	var req structs.JobRestartUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertJobRestart(msgType, index, req.Restart); err != nil {
+		n.logger.Error("UpsertJobRestart failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyNodeLifecycleRunUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_lifecycle_run_upsert"}, time.Now())
+	var req structs.NodeLifecycleRunUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertNodeLifecycleRun(msgType, index, req.Run); err != nil {
+		n.logger.Error("UpsertNodeLifecycleRun failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyServiceGrantDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_service_grant_delete"}, time.Now())
+	var req structs.ServiceGrantDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteServiceGrants(msgType, index, req.IDs); err != nil {
+		n.logger.Error("DeleteServiceGrants failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyUpsertJob(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "register_job"}, time.Now())
 	var req structs.JobRegisterRequest
@@ -756,6 +858,14 @@ func (n *nomadFSM) applyDeregisterJob(msgType structs.MessageType, buf []byte, i
 			return err
 		}
 
+		if req.Purge && req.PurgeRecord != nil {
+			if err := n.state.InsertJobPurgeRecordTxn(index, req.PurgeRecord, tx); err != nil {
+				n.logger.Error("recording job purge failed",
+					"error", err, "job", req.JobID, "namespace", req.Namespace)
+				return err
+			}
+		}
+
 		return nil
 	})
 
@@ -946,11 +1056,20 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 	// Create a watch set
 	ws := memdb.NewWatchSet()
 
-	// Updating the allocs with the job id and task group name
+	// Updating the allocs with the job id and task group name, and find the
+	// nodes on which a readiness gate allocation becomes healthy
+	var gatedNodes []*structs.Node
 	for _, alloc := range req.Alloc {
 		if existing, _ := n.state.AllocByID(ws, alloc.ID); existing != nil {
 			alloc.JobID = existing.JobID
 			alloc.TaskGroup = existing.TaskGroup
+
+			node, _, err := readinessGateOpened(n.state, existing, alloc)
+			if err != nil {
+				n.logger.Error("looking up readiness gates failed", "alloc_id", alloc.ID, "error", err)
+			} else if node != nil {
+				gatedNodes = append(gatedNodes, node)
+			}
 		}
 	}
 
@@ -993,6 +1112,12 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 		}
 	}
 
+	// Unblock evals for the nodes whose readiness gates may have opened
+	for _, node := range gatedNodes {
+		n.blockedEvals.Unblock(node.ComputedClass, index)
+		n.blockedEvals.UnblockNode(node.ID, index)
+	}
+
 	return nil
 }
 
@@ -1014,6 +1139,38 @@ func (n *nomadFSM) applyAllocUpdateDesiredTransition(msgType structs.MessageType
 	return nil
 }
 
+// applyAllocIdentitiesUpdate is used to update the default workload identities
+// of allocations re-signed by the leader
+func (n *nomadFSM) applyAllocIdentitiesUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "alloc_identities_update"}, time.Now())
+	var req structs.AllocIdentitiesUpdateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateAllocsSignedIdentities(msgType, index, req.Allocs); err != nil {
+		n.logger.Error("UpdateAllocsSignedIdentities failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyAllocTaskResultRegister is used to register the result of a task of an
+// allocation
+func (n *nomadFSM) applyAllocTaskResultRegister(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "alloc_task_result_register"}, time.Now())
+	var req structs.AllocRegisterResultRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertAllocTaskResult(msgType, index, req.AllocID, req.TaskName, req.Result); err != nil {
+		n.logger.Error("UpsertAllocTaskResult failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 // applyReconcileSummaries reconciles summaries for all the jobs
 func (n *nomadFSM) applyReconcileSummaries(buf []byte, index uint64) interface{} {
 	if err := n.state.ReconcileJobSummaries(index); err != nil {
@@ -1076,6 +1233,23 @@ func (n *nomadFSM) applyPlanResults(msgType structs.MessageType, buf []byte, ind
 		panic(fmt.Errorf("failed to decode request: %v", err))
 	}
 
+	// A plan resubmitted after an error that left its outcome unknown may
+	// have been applied already, in which case applying it again would
+	// repeat its deployment updates and preemptions.
+	if req.PlanID != "" {
+		applied, err := n.state.AppliedPlanByID(nil, req.PlanID)
+		if err != nil {
+			n.logger.Error("ApplyPlan failed", "error", err)
+			return err
+		}
+		if applied != nil {
+			n.logger.Warn("ignoring plan that was already applied",
+				"plan_id", req.PlanID, "eval_id", req.EvalID, "applied_index", applied.AppliedIndex)
+			metrics.IncrCounter([]string{"nomad", "fsm", "apply_plan_results", "duplicate"}, 1)
+			return nil
+		}
+	}
+
 	if err := n.state.UpsertPlanResults(msgType, index, &req); err != nil {
 		n.logger.Error("ApplyPlan failed", "error", err)
 		return err
@@ -1187,6 +1361,29 @@ func (n *nomadFSM) applyJobStability(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyJobFreeze is used to set the freeze state of a job
+func (n *nomadFSM) applyJobFreeze(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_freeze"}, time.Now())
+	var req structs.JobFreezeRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateJobFreeze(msgType, index, req.Namespace, req.JobID, req.Frozen); err != nil {
+		n.logger.Error("UpdateJobFreeze failed", "error", err)
+		return err
+	}
+
+	if req.Eval != nil {
+		req.Eval.JobModifyIndex = index
+		if err := n.upsertEvals(msgType, index, []*structs.Evaluation{req.Eval}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // applyACLPolicyUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLPolicyUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_policy_upsert"}, time.Now())
@@ -1483,11 +1680,23 @@ func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	}
 
 	ns := &nomadSnapshot{
-		snap: snap,
+		snap:        snap,
+		persistRate: n.config.SnapshotPersistRate,
+	}
+	if n.unthrottledSnapshots.Load() > 0 {
+		ns.persistRate = 0
 	}
 	return ns, nil
 }
 
+// disablePersistRate disables the persist rate of the snapshots taken until
+// the returned function is called. It is used for snapshots that an operator
+// is waiting for, which should be written as fast as possible.
+func (n *nomadFSM) disablePersistRate() func() {
+	n.unthrottledSnapshots.Add(1)
+	return func() { n.unthrottledSnapshots.Add(-1) }
+}
+
 // Restore implements the raft.FSM interface, which doesn't support a
 // filtering parameter
 func (n *nomadFSM) Restore(old io.ReadCloser) error {
@@ -1923,6 +2132,61 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				}
 			}
 
+		case ServiceGrantSnapshot:
+			grant := new(structs.ServiceGrant)
+			if err := dec.Decode(grant); err != nil {
+				return err
+			}
+			if filter.Include(grant) {
+				if err := restore.ServiceGrantRestore(grant); err != nil {
+					return err
+				}
+			}
+
+		case JobPurgeRecordSnapshot:
+			record := new(structs.JobPurgeRecord)
+			if err := dec.Decode(record); err != nil {
+				return err
+			}
+			if filter.Include(record) {
+				if err := restore.JobPurgeRecordRestore(record); err != nil {
+					return err
+				}
+			}
+
+		case JobRestartSnapshot:
+			restart := new(structs.JobRestart)
+			if err := dec.Decode(restart); err != nil {
+				return err
+			}
+			if filter.Include(restart) {
+				if err := restore.JobRestartRestore(restart); err != nil {
+					return err
+				}
+			}
+
+		case AppliedPlanSnapshot:
+			record := new(structs.AppliedPlan)
+			if err := dec.Decode(record); err != nil {
+				return err
+			}
+			if filter.Include(record) {
+				if err := restore.AppliedPlanRestore(record); err != nil {
+					return err
+				}
+			}
+
+		case NodeLifecycleRunSnapshot:
+			run := new(structs.NodeLifecycleRun)
+			if err := dec.Decode(run); err != nil {
+				return err
+			}
+			if filter.Include(run) {
+				if err := restore.NodeLifecycleRunRestore(run); err != nil {
+					return err
+				}
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2319,6 +2583,8 @@ func (n *nomadFSM) applyVariableOperation(msgType structs.MessageType, buf []byt
 		return n.state.VarLockAcquire(index, &req)
 	case structs.VarOpLockRelease:
 		return n.state.VarLockRelease(index, &req)
+	case structs.VarOpLockSteal:
+		return n.state.VarLockSteal(index, &req)
 	default:
 		err := fmt.Errorf("Invalid variable operation '%s'", req.Op)
 		n.logger.Warn("Invalid variable operation", "operation", req.Op)
@@ -2436,10 +2702,60 @@ func (n *nomadFSM) applyTaskGroupHostVolumeClaimDelete(buf []byte, index uint64)
 	return nil
 }
 
+// snapshotTable is a table of the state store written by Persist.
+type snapshotTable struct {
+	name    string
+	persist func(raft.SnapshotSink, *codec.Encoder) error
+}
+
+// tables returns the tables written by Persist, in order.
+func (s *nomadSnapshot) tables() []snapshotTable {
+	return []snapshotTable{
+		{"indexes", s.persistIndexes},
+		{"nodes", s.persistNodes},
+		{"node_pools", s.persistNodePools},
+		{"jobs", s.persistJobs},
+		{"evals", s.persistEvals},
+		{"allocs", s.persistAllocs},
+		{"periodic_launches", s.persistPeriodicLaunches},
+		{"job_summaries", s.persistJobSummaries},
+		{"si_token_accessors", s.persistSITokenAccessors},
+		{"job_versions", s.persistJobVersions},
+		{"deployments", s.persistDeployments},
+		{"scaling_policies", s.persistScalingPolicies},
+		{"scaling_events", s.persistScalingEvents},
+		{"csi_plugins", s.persistCSIPlugins},
+		{"csi_volumes", s.persistCSIVolumes},
+		{"acl_policies", s.persistACLPolicies},
+		{"acl_tokens", s.persistACLTokens},
+		{"namespaces", s.persistNamespaces},
+		{"enterprise_tables", s.persistEnterpriseTables},
+		{"scheduler_config", s.persistSchedulerConfig},
+		{"cluster_metadata", s.persistClusterMetadata},
+		{"service_registrations", s.persistServiceRegistrations},
+		{"variables", s.persistVariables},
+		{"variables_quotas", s.persistVariablesQuotas},
+		{"wrapped_root_keys", s.persistWrappedRootKeys},
+		{"acl_roles", s.persistACLRoles},
+		{"acl_auth_methods", s.persistACLAuthMethods},
+		{"acl_binding_rules", s.persistACLBindingRules},
+		{"job_submissions", s.persistJobSubmissions},
+		{"host_volumes", s.persistHostVolumes},
+		{"service_grants", s.persistServiceGrants},
+		{"job_purge_history", s.persistJobPurgeHistory},
+		{"job_restarts", s.persistJobRestarts},
+		{"applied_plans", s.persistAppliedPlans},
+		{"node_lifecycle_runs", s.persistNodeLifecycleRuns},
+	}
+}
+
 func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "persist"}, time.Now())
-	// Register the nodes
-	encoder := codec.NewEncoder(sink, structs.MsgpackHandle)
+
+	// Throttle the snapshot writes to the configured rate, so that persisting
+	// a large state store does not saturate the disk
+	psink := newPersistSink(sink, s.persistRate)
+	encoder := codec.NewEncoder(psink, structs.MsgpackHandle)
 
 	// Write the header
 	header := SnapshotHeader{}
@@ -2448,127 +2764,27 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		return err
 	}
 
-	// Write all the data out
-	if err := s.persistIndexes(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistNodes(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistNodePools(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistJobs(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistEvals(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistAllocs(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistPeriodicLaunches(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistJobSummaries(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistSITokenAccessors(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistJobVersions(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistDeployments(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistScalingPolicies(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistScalingEvents(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistCSIPlugins(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistCSIVolumes(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistACLPolicies(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistACLTokens(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistNamespaces(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistEnterpriseTables(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistSchedulerConfig(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistClusterMetadata(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistServiceRegistrations(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistVariables(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistVariablesQuotas(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistWrappedRootKeys(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistACLRoles(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistACLAuthMethods(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistACLBindingRules(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
-	}
-	if err := s.persistJobSubmissions(sink, encoder); err != nil {
-		sink.Cancel()
-		return err
+	// Write all the data out, table by table
+	for _, table := range s.tables() {
+		start := time.Now()
+		written := psink.written
+
+		if err := table.persist(psink, encoder); err != nil {
+			sink.Cancel()
+			return fmt.Errorf("failed to persist %s: %w", table.name, err)
+		}
+
+		labels := []metrics.Label{{Name: "table", Value: table.name}}
+		metrics.MeasureSinceWithLabels([]string{"nomad", "fsm", "persist", "table"}, start, labels)
+		metrics.SetGaugeWithLabels([]string{"nomad", "fsm", "persist", "table_size"},
+			float32(psink.written-written), labels)
 	}
-	if err := s.persistHostVolumes(sink, encoder); err != nil {
+
+	if err := psink.Flush(); err != nil {
 		sink.Cancel()
 		return err
 	}
+	metrics.SetGauge([]string{"nomad", "fsm", "persist", "size"}, float32(psink.written))
 	return nil
 }
 
@@ -3297,6 +3513,86 @@ func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *code
 	return nil
 }
 
+func (s *nomadSnapshot) persistServiceGrants(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.ServiceGrants(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		grant := raw.(*structs.ServiceGrant)
+
+		sink.Write([]byte{byte(ServiceGrantSnapshot)})
+		if err := encoder.Encode(grant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistJobPurgeHistory(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.JobPurgeRecords(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		record := raw.(*structs.JobPurgeRecord)
+
+		sink.Write([]byte{byte(JobPurgeRecordSnapshot)})
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistJobRestarts(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.JobRestarts(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		restart := raw.(*structs.JobRestart)
+
+		sink.Write([]byte{byte(JobRestartSnapshot)})
+		if err := encoder.Encode(restart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistAppliedPlans(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.AppliedPlans(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		record := raw.(*structs.AppliedPlan)
+
+		sink.Write([]byte{byte(AppliedPlanSnapshot)})
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistNodeLifecycleRuns(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.NodeLifecycleRuns(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		run := raw.(*structs.NodeLifecycleRun)
+
+		sink.Write([]byte{byte(NodeLifecycleRunSnapshot)})
+		if err := encoder.Encode(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
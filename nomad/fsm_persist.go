@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/hashicorp/raft"
+	"golang.org/x/time/rate"
+)
+
+// persistBurstSize is the largest write made to a throttled snapshot sink at
+// once, and the size of the buffer in front of it.
+const persistBurstSize = 256 * 1024
+
+// persistSink wraps the raft.SnapshotSink written by nomadSnapshot.Persist.
+// It counts the bytes written for the snapshot metrics and, if a persist rate
+// is set, throttles the writes so that persisting a large state store does not
+// starve Raft and the other users of the disk. The snapshot itself is still
+// encoded in full from a single state store snapshot.
+type persistSink struct {
+	raft.SnapshotSink
+
+	buf *bufio.Writer
+
+	// written is the number of bytes written to the sink so far, including
+	// bytes still buffered.
+	written int64
+}
+
+// newPersistSink returns a persistSink writing to sink at most bytesPerSecond
+// bytes per second, or as fast as possible if bytesPerSecond is zero.
+func newPersistSink(sink raft.SnapshotSink, bytesPerSecond uint64) *persistSink {
+	var w io.Writer = sink
+	if bytesPerSecond > 0 {
+		w = &throttledWriter{
+			w:       sink,
+			limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), persistBurstSize),
+		}
+	}
+	return &persistSink{
+		SnapshotSink: sink,
+		buf:          bufio.NewWriterSize(w, persistBurstSize),
+	}
+}
+
+// Write implements io.Writer, buffering p.
+func (s *persistSink) Write(p []byte) (int, error) {
+	n, err := s.buf.Write(p)
+	s.written += int64(n)
+	return n, err
+}
+
+// Flush writes the buffered bytes to the sink. It must be called before
+// the sink is closed.
+func (s *persistSink) Flush() error {
+	return s.buf.Flush()
+}
+
+// throttledWriter is an io.Writer that writes at most persistBurstSize bytes
+// at a time, waiting on a rate limiter before each write.
+type throttledWriter struct {
+	w       io.Writer
+	limiter *rate.Limiter
+}
+
+func (t *throttledWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		burst := p[:min(len(p), persistBurstSize)]
+		if err := t.limiter.WaitN(context.Background(), len(burst)); err != nil {
+			return written, err
+		}
+		n, err := t.w.Write(burst)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(burst):]
+	}
+	return written, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestPersistSink(t *testing.T) {
+	ci.Parallel(t)
+
+	data := bytes.Repeat([]byte("nomad"), persistBurstSize/2)
+
+	t.Run("unlimited", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		sink := newPersistSink(&MockSink{buf, false}, 0)
+
+		n, err := sink.Write(data)
+		must.NoError(t, err)
+		must.Eq(t, len(data), n)
+		must.NoError(t, sink.Flush())
+		must.Eq(t, int64(len(data)), sink.written)
+		must.Eq(t, data, buf.Bytes())
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+
+		// The first burst is written immediately, and the remaining bytes
+		// wait for the limiter
+		rate := uint64(len(data) * 4)
+		sink := newPersistSink(&MockSink{buf, false}, rate)
+
+		start := time.Now()
+		for range 2 {
+			_, err := sink.Write(data)
+			must.NoError(t, err)
+		}
+		must.NoError(t, sink.Flush())
+
+		expected := time.Duration(float64(2*len(data)-persistBurstSize) / float64(rate) * float64(time.Second))
+		must.Greater(t, expected-50*time.Millisecond, time.Since(start))
+		must.Eq(t, 2*len(data), buf.Len())
+	})
+}
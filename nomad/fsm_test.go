@@ -972,6 +972,124 @@ func TestFSM_DeregisterJob_Purge(t *testing.T) {
 	}
 }
 
+func TestFSM_DeregisterJob_PurgeRecord(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	must.NoError(t, fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	record := structs.NewJobPurgeRecord(job, []*structs.Job{job}, "token", time.Now().UnixNano())
+	req := structs.JobDeregisterRequest{
+		JobID:       job.ID,
+		Purge:       true,
+		PurgeRecord: record,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobDeregisterRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// The job is gone but the purge record is kept
+	ws := memdb.NewWatchSet()
+	jobOut, err := fsm.State().JobByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, jobOut)
+
+	iter, err := fsm.State().JobPurgeRecordsByJob(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	raw := iter.Next()
+	must.NotNil(t, raw)
+	out := raw.(*structs.JobPurgeRecord)
+	must.Eq(t, record.ID, out.ID)
+	must.Nil(t, iter.Next())
+
+	// The record survives a snapshot and restore
+	fsm2 := testSnapshotRestore(t, fsm)
+	iter, err = fsm2.State().JobPurgeRecords(ws)
+	must.NoError(t, err)
+	raw = iter.Next()
+	must.NotNil(t, raw)
+	must.Eq(t, out, raw.(*structs.JobPurgeRecord))
+}
+
+func TestFSM_JobRestartUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	must.NoError(t, fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	restart := &structs.JobRestart{
+		ID:                uuid.Generate(),
+		Namespace:         job.Namespace,
+		JobID:             job.ID,
+		Status:            structs.JobRestartStatusPaused,
+		StatusDescription: "Allocation failed",
+		Batch:             []string{uuid.Generate()},
+		BatchStartTime:    time.Now().UnixNano(),
+		BatchCount:        1,
+	}
+	req := structs.JobRestartUpsertRequest{Restart: restart}
+	buf, err := structs.Encode(structs.JobRestartUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().JobRestartByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, restart.ID, out.ID)
+	must.Eq(t, structs.JobRestartStatusPaused, out.Status)
+	must.Eq(t, restart.Batch, out.Batch)
+
+	// The restart survives a snapshot and restore
+	fsm2 := testSnapshotRestore(t, fsm)
+	out2, err := fsm2.State().JobRestartByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, out, out2)
+}
+
+func TestFSM_NodeLifecycleRunUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	pool := mock.NodePool()
+	must.NoError(t, fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	run := &structs.NodeLifecycleRun{
+		ID:                uuid.Generate(),
+		NodePool:          pool.Name,
+		BatchSize:         2,
+		DrainSpec:         &structs.DrainSpec{Deadline: time.Hour},
+		ReregisterTimeout: 30 * time.Minute,
+		Status:            structs.NodeLifecycleStatusRunning,
+		Nodes: []*structs.NodeLifecycleNode{{
+			NodeID:         uuid.Generate(),
+			NodeName:       "node-1",
+			Phase:          structs.NodeLifecyclePhaseDrain,
+			PhaseStartTime: time.Now().UnixNano(),
+		}},
+	}
+	req := structs.NodeLifecycleRunUpsertRequest{Run: run}
+	buf, err := structs.Encode(structs.NodeLifecycleRunUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().NodeLifecycleRunByPool(nil, pool.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, run.ID, out.ID)
+	must.Eq(t, run.Nodes, out.Nodes)
+
+	// The run survives a snapshot and restore
+	fsm2 := testSnapshotRestore(t, fsm)
+	out2, err := fsm2.State().NodeLifecycleRunByPool(nil, pool.Name)
+	must.NoError(t, err)
+	must.Eq(t, out, out2)
+}
+
 func TestFSM_DeregisterJob_NoPurge(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -1760,6 +1878,79 @@ func TestFSM_ApplyPlanResults(t *testing.T) {
 
 }
 
+func TestFSM_ApplyPlanResults_Duplicate(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	fsm.evalBroker.SetEnabled(true)
+	store := fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1, nil, job))
+
+	d := mock.Deployment()
+	d.JobID = job.ID
+	must.NoError(t, store.UpsertDeployment(2, d))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 3, []*structs.Evaluation{eval}))
+
+	// applyPlan builds follow up evals with new IDs on every submission, so
+	// a resubmitted plan carries different preemption evals
+	newRequest := func() structs.ApplyPlanResultsRequest {
+		preemptionEval := mock.Eval()
+		return structs.ApplyPlanResultsRequest{
+			AllocUpdateRequest: structs.AllocUpdateRequest{Job: job},
+			DeploymentUpdates: []*structs.DeploymentStatusUpdate{{
+				DeploymentID:      d.ID,
+				Status:            structs.DeploymentStatusCancelled,
+				StatusDescription: structs.DeploymentStatusDescriptionNewerJob,
+			}},
+			EvalID:          eval.ID,
+			PlanID:          "7cbd5a1e-3c6a-4ab6-a8ff-0d64b2b4c0f7",
+			PreemptionEvals: []*structs.Evaluation{preemptionEval},
+		}
+	}
+
+	req := newRequest()
+	buf, err := structs.Encode(structs.ApplyPlanResultsRequestType, req)
+	must.NoError(t, err)
+	log := makeLog(buf)
+	log.Index = 10
+	must.Nil(t, fsm.Apply(log))
+
+	// Applying the resubmitted plan is a no-op
+	dup := newRequest()
+	buf, err = structs.Encode(structs.ApplyPlanResultsRequestType, dup)
+	must.NoError(t, err)
+	log = makeLog(buf)
+	log.Index = 11
+	must.Nil(t, fsm.Apply(log))
+
+	dout, err := store.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusCancelled, dout.Status)
+	must.Eq(t, 10, dout.ModifyIndex)
+
+	e, err := store.EvalByID(nil, req.PreemptionEvals[0].ID)
+	must.NoError(t, err)
+	must.NotNil(t, e)
+	e, err = store.EvalByID(nil, dup.PreemptionEvals[0].ID)
+	must.NoError(t, err)
+	must.Nil(t, e)
+
+	applied, err := store.AppliedPlanByID(nil, req.PlanID)
+	must.NoError(t, err)
+	must.NotNil(t, applied)
+	must.Eq(t, 10, applied.AppliedIndex)
+
+	// The record survives a snapshot and restore
+	fsm2 := testSnapshotRestore(t, fsm)
+	applied2, err := fsm2.State().AppliedPlanByID(nil, req.PlanID)
+	must.NoError(t, err)
+	must.Eq(t, applied, applied2)
+}
+
 func TestFSM_DeploymentStatusUpdate(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -2322,6 +2513,50 @@ func TestFSM_SnapshotRestore_Nodes(t *testing.T) {
 	must.Eq(t, node, out)
 }
 
+func TestFSM_SnapshotRestore_PersistRate(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state to a FSM persisting throttled snapshots
+	fsm := testFSM(t)
+	fsm.config.SnapshotPersistRate = 1024 * 1024
+	state := fsm.State()
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	outJob, err := state2.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, job, outJob)
+	outNode, err := state2.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, node, outNode)
+}
+
+func TestFSM_Snapshot_DisablePersistRate(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	fsm.config.SnapshotPersistRate = 1024 * 1024
+
+	persistRate := func() uint64 {
+		snap, err := fsm.Snapshot()
+		must.NoError(t, err)
+		defer snap.Release()
+		return snap.(*nomadSnapshot).persistRate
+	}
+	must.Eq(t, 1024*1024, persistRate())
+
+	// Snapshots taken for an operator are not throttled
+	restore := fsm.disablePersistRate()
+	must.Eq(t, 0, persistRate())
+	restore()
+	must.Eq(t, 1024*1024, persistRate())
+}
+
 func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	log "github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/helper/useragent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// maxJobAdmissionResponseSize is the maximum size of a response of the job
+// admission webhook.
+const maxJobAdmissionResponseSize = 16 * 1024 * 1024
+
+// jobAdmissionWebhook calls an external service on job registration that can
+// mutate or reject the job before it's written to state, similar to the
+// admission webhooks of Kubernetes.
+type jobAdmissionWebhook struct {
+	config *config.JobAdmissionWebhookConfig
+	client *http.Client
+	logger log.Logger
+}
+
+// newJobAdmissionWebhook returns the job admission webhook of the server, or
+// nil if none is configured.
+func newJobAdmissionWebhook(conf *config.JobAdmissionWebhookConfig, logger log.Logger) (*jobAdmissionWebhook, error) {
+	if conf == nil {
+		return nil, nil
+	}
+	conf = conf.Copy()
+	conf.Canonicalize()
+
+	transport := cleanhttp.DefaultPooledTransport()
+	if conf.TLS != nil {
+		tlsConf, err := jobAdmissionWebhookTLSConfig(conf.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure job admission webhook tls: %w", err)
+		}
+		transport.TLSClientConfig = tlsConf
+	}
+
+	return &jobAdmissionWebhook{
+		config: conf,
+		client: &http.Client{Transport: transport, Timeout: conf.Timeout},
+		logger: logger.Named("job_admission_webhook"),
+	}, nil
+}
+
+func jobAdmissionWebhookTLSConfig(conf *config.JobAdmissionWebhookTLSConfig) (*tls.Config, error) {
+	tlsConf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: conf.InsecureSkipVerify,
+	}
+
+	if conf.CAFile != "" {
+		pem, err := os.ReadFile(conf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", conf.CAFile)
+		}
+		tlsConf.RootCAs = pool
+	}
+
+	if conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConf, nil
+}
+
+// admit sends the job to the webhook and returns the job to register, which
+// is the mutated job if the webhook returned one, along with the warnings of
+// the webhook. An error is returned if the webhook rejects the job, or if it
+// fails and the fail policy is "fail".
+func (w *jobAdmissionWebhook) admit(ctx context.Context, region string, job *structs.Job) (*structs.Job, []error, error) {
+	start := time.Now()
+	resp, err := w.call(ctx, region, job)
+	if err != nil {
+		w.measure(start, "error")
+		if w.config.FailPolicy == config.JobAdmissionWebhookFailPolicyIgnore {
+			w.logger.Warn("job admission webhook failed, admitting job unchanged",
+				"job_id", job.ID, "namespace", job.Namespace, "error", err)
+			return job, nil, nil
+		}
+		return nil, nil, fmt.Errorf("job admission webhook failed: %w", err)
+	}
+
+	if !resp.Allowed {
+		w.measure(start, "rejected")
+		message := resp.Message
+		if message == "" {
+			message = "no reason given"
+		}
+		return nil, nil, fmt.Errorf("job rejected by admission webhook: %s", message)
+	}
+	w.measure(start, "allowed")
+
+	var warnings []error
+	for _, warning := range resp.Warnings {
+		warnings = append(warnings, fmt.Errorf("admission webhook: %s", warning))
+	}
+
+	if resp.Job != nil {
+		return resp.Job, warnings, nil
+	}
+	return job, warnings, nil
+}
+
+func (w *jobAdmissionWebhook) call(ctx context.Context, region string, job *structs.Job) (*structs.JobAdmissionResponse, error) {
+	var body []byte
+	err := codec.NewEncoderBytes(&body, structs.JsonHandle).Encode(&structs.JobAdmissionRequest{
+		Region:    region,
+		Namespace: job.Namespace,
+		Job:       job,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", useragent.String())
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+
+	var out structs.JobAdmissionResponse
+	dec := codec.NewDecoder(io.LimitReader(resp.Body, maxJobAdmissionResponseSize), structs.JsonHandle)
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	// The webhook can mutate the job but not register another one
+	if out.Job != nil && (out.Job.ID != job.ID || out.Job.Namespace != job.Namespace) {
+		return nil, fmt.Errorf("invalid response: the job ID and namespace must not be changed")
+	}
+
+	return &out, nil
+}
+
+func (w *jobAdmissionWebhook) measure(start time.Time, result string) {
+	metrics.MeasureSinceWithLabels([]string{"nomad", "job", "admission_webhook"}, start,
+		[]metrics.Label{{Name: "result", Value: result}})
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobEndpoint_Register_AdmissionWebhook(t *testing.T) {
+	ci.Parallel(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req structs.JobAdmissionRequest
+		if err := codec.NewDecoder(r.Body, structs.JsonHandle).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resp := &structs.JobAdmissionResponse{Allowed: true}
+		switch req.Job.Meta["admission"] {
+		case "reject":
+			resp = &structs.JobAdmissionResponse{Message: "jobs must have an owner"}
+		case "mutate":
+			req.Job.Meta["owner"] = "platform"
+			resp.Job = req.Job
+			resp.Warnings = []string{"owner defaulted to platform"}
+		case "rename":
+			req.Job.ID = "other"
+			resp.Job = req.Job
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = codec.NewEncoder(w, structs.JsonHandle).Encode(resp)
+	}))
+	defer ts.Close()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.JobAdmissionWebhook = &config.JobAdmissionWebhookConfig{URL: ts.URL}
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	rpc := rpcClient(t, s1)
+
+	register := func(admission string) (*structs.JobRegisterResponse, *structs.Job, error) {
+		job := mock.Job()
+		job.Meta = map[string]string{"admission": admission}
+		var resp structs.JobRegisterResponse
+		err := msgpackrpc.CallWithCodec(rpc, "Job.Register", &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}, &resp)
+		return &resp, job, err
+	}
+
+	// Allowed jobs are registered unchanged
+	_, job, err := register("allow")
+	must.NoError(t, err)
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.MapNotContainsKey(t, out.Meta, "owner")
+
+	// Mutated jobs are registered with the changes of the webhook
+	resp, job, err := register("mutate")
+	must.NoError(t, err)
+	must.StrContains(t, resp.Warnings, "owner defaulted to platform")
+	out, err = s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, "platform", out.Meta["owner"])
+
+	// Rejected jobs are not registered
+	_, job, err = register("reject")
+	must.ErrorContains(t, err, "job rejected by admission webhook: jobs must have an owner")
+	out, err = s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// The webhook can't register another job
+	_, _, err = register("rename")
+	must.ErrorContains(t, err, "job ID and namespace must not be changed")
+
+	// Jobs are rejected when the webhook fails with the default fail policy
+	_, _, err = register("error")
+	must.ErrorContains(t, err, "job admission webhook failed: unexpected response code 500")
+
+	// Jobs are admitted unchanged when the webhook fails with the ignore
+	// fail policy
+	s1.jobAdmissionWebhook.config.FailPolicy = config.JobAdmissionWebhookFailPolicyIgnore
+	_, job, err = register("error")
+	must.NoError(t, err)
+	out, err = s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/jsonschema"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -85,6 +87,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			jobConsulHook{srv: s},
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobNodePoolValidatingHook{srv: s},
+			jobFeatureGateHook{srv: s},
 			&jobValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 			jobNumaHook{},
@@ -96,7 +99,19 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 // Register is used to upsert a job for scheduling
 func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
 	authErr := j.srv.Authenticate(j.ctx, args)
+
+	// Registrations forwarded to the region of the job record the region they
+	// were forwarded from on their evaluation.
+	forwarded := args.Region != "" && args.Region != j.srv.Region()
+	if forwarded {
+		args.ForwardedFromRegion = j.srv.Region()
+		args.ForwardedTime = time.Now().UnixNano()
+	}
 	if done, err := j.srv.forward("Job.Register", args, args, reply); done {
+		if forwarded {
+			j.logger.Debug("forwarded job registration", "region", args.Region,
+				"eval_id", reply.EvalID, "rtt", time.Since(time.Unix(0, args.ForwardedTime)), "error", err)
+		}
 		return err
 	}
 	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
@@ -124,12 +139,32 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return fmt.Errorf("mismatched request namespace in request: %q, %q", args.RequestNamespace(), args.Job.Namespace)
 	}
 
+	// Wait for our turn if too many registrations are being processed
+	release, err := j.srv.jobRegisterQueue.Acquire(j.srv.shutdownCtx, args.GetIdentity().String())
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Call the admission webhook before the admission controllers, so that
+	// the mutated job is canonicalized, validated and authorized
+	var warnings []error
+	if webhook := j.srv.jobAdmissionWebhook; webhook != nil {
+		job, webhookWarnings, err := webhook.admit(j.srv.shutdownCtx, j.srv.Region(), args.Job)
+		if err != nil {
+			return err
+		}
+		args.Job = job
+		warnings = webhookWarnings
+	}
+
 	// Run admission controllers
-	job, warnings, err := j.admissionControllers(args.Job)
+	job, controllerWarnings, err := j.admissionControllers(args.Job)
 	if err != nil {
 		return err
 	}
 	args.Job = job
+	warnings = append(warnings, controllerWarnings...)
 
 	// Run the submission controller
 	warnings = append(warnings, j.submissionController(args))
@@ -368,6 +403,12 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			CreateTime:  now,
 			ModifyTime:  now,
 		}
+		if args.ForwardedFromRegion != "" && slices.Contains(j.srv.Regions(), args.ForwardedFromRegion) {
+			eval.ForwardedFromRegion = args.ForwardedFromRegion
+			eval.ForwardedTime = args.ForwardedTime
+			metrics.IncrCounterWithLabels([]string{"nomad", "job", "register", "forwarded"}, 1,
+				[]metrics.Label{{Name: "from_region", Value: args.ForwardedFromRegion}})
+		}
 		reply.EvalID = eval.ID
 	}
 
@@ -593,6 +634,14 @@ func (j *Job) Validate(args *structs.JobValidateRequest, reply *structs.JobValid
 
 	validateWarnings = append(validateWarnings, mutateWarnings...)
 
+	if args.Lint {
+		findings, err := args.Job.Lint(args.LintRules)
+		if err != nil {
+			return err
+		}
+		reply.LintFindings = findings
+	}
+
 	// Set the warning message
 	reply.Warnings = helper.MergeMultierrorWarnings(validateWarnings...)
 	reply.DriverConfigValidated = true
@@ -729,6 +778,99 @@ func (j *Job) Stable(args *structs.JobStabilityRequest, reply *structs.JobStabil
 	return nil
 }
 
+// Freeze is used to freeze or unfreeze the scheduling of a job. A frozen job
+// keeps its running allocations, but the scheduler makes no placements for it
+// until it is unfrozen.
+func (j *Job) Freeze(args *structs.JobFreezeRequest, reply *structs.JobFreezeResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Freeze", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "freeze"}, time.Now())
+
+	// Check for freeze-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityFreezeJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(j.srv.Members(), j.srv.Region(), minJobFreezeVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to freeze jobs",
+			minJobFreezeVersion)
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for freeze")
+	}
+
+	// Lookup the job
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	ws := memdb.NewWatchSet()
+	job, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound,
+			"job %q in namespace %q not found", args.JobID, args.RequestNamespace())
+	}
+	if job.IsPeriodic() || job.IsParameterized() {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			"can't freeze periodic or parameterized job")
+	}
+
+	// Nothing to do if the job is already in the desired state
+	if job.Frozen == args.Frozen {
+		reply.JobModifyIndex = job.JobModifyIndex
+		reply.Index = job.ModifyIndex
+		return nil
+	}
+
+	// Unfreezing a job needs an evaluation so that any placements held back
+	// while it was frozen are made.
+	args.Eval = nil
+	if !args.Frozen && !job.Stopped() {
+		now := time.Now().UnixNano()
+		args.Eval = &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      args.RequestNamespace(),
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobUnfreeze,
+			JobID:          job.ID,
+			JobModifyIndex: job.ModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		}
+	}
+
+	// Commit this freeze request via Raft
+	_, index, err := j.srv.raftApply(structs.JobFreezeRequestType, args)
+	if err != nil {
+		j.logger.Error("submitting job freeze request failed", "error", err)
+		return err
+	}
+
+	// Setup the reply
+	if args.Eval != nil {
+		reply.EvalID = args.Eval.ID
+		reply.EvalCreateIndex = index
+	}
+	reply.JobModifyIndex = job.JobModifyIndex
+	reply.Index = index
+	return nil
+}
+
 // Evaluate is used to force a job for re-evaluation
 func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegisterResponse) error {
 	authErr := j.srv.Authenticate(j.ctx, args)
@@ -849,6 +991,38 @@ func (j *Job) Deregister(args *structs.JobDeregisterRequest, reply *structs.JobD
 		return structs.ErrPermissionDenied
 	}
 
+	// Purge records can only be written by PurgeWithAudit
+	args.PurgeRecord = nil
+	return j.deregister(args, reply, false)
+}
+
+// PurgeWithAudit is used to purge a job, recording who purged it, when, and a
+// summary of its versions in the job purge history.
+func (j *Job) PurgeWithAudit(args *structs.JobDeregisterRequest, reply *structs.JobDeregisterResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.PurgeWithAudit", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "purge_with_audit"}, time.Now())
+
+	// Check for submit-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	args.Purge = true
+	return j.deregister(args, reply, true)
+}
+
+// deregister stops or purges a job once the request has been authorized. If
+// audit is set, the purge of the job is recorded in the job purge history.
+func (j *Job) deregister(args *structs.JobDeregisterRequest, reply *structs.JobDeregisterResponse, audit bool) error {
 	// Validate the arguments
 	if args.JobID == "" {
 		return fmt.Errorf("missing job ID for deregistering")
@@ -908,6 +1082,14 @@ func (j *Job) Deregister(args *structs.JobDeregisterRequest, reply *structs.JobD
 	args.SubmitTime = now
 	args.Eval = eval
 
+	if audit {
+		versions, err := snap.JobVersionsByID(ws, job.Namespace, job.ID)
+		if err != nil {
+			return err
+		}
+		args.PurgeRecord = structs.NewJobPurgeRecord(job, versions, args.GetIdentity().String(), now)
+	}
+
 	// Commit the job update via Raft
 	_, index, err := j.srv.raftApply(structs.JobDeregisterRequestType, args)
 	if err != nil {
@@ -1059,16 +1241,20 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		JobID:     job.ID,
 		TaskGroup: groupName,
 		ScalingEvent: &structs.ScalingEvent{
-			Time:          now,
-			PreviousCount: prevCount,
-			Count:         args.Count,
-			Message:       args.Message,
-			Error:         args.Error,
-			Meta:          args.Meta,
+			Time:           now,
+			PreviousCount:  prevCount,
+			Count:          args.Count,
+			Message:        args.Message,
+			Error:          args.Error,
+			Meta:           args.Meta,
+			Source:         args.Source,
+			MetricSnapshot: args.MetricSnapshot,
+			DryRun:         args.DryRun,
 		},
 	}
 
-	if args.Count != nil {
+	// Dry runs only record the scaling event, leaving the job untouched
+	if args.Count != nil && !args.DryRun {
 		// Further validation for count-based scaling event
 		if group.Scaling != nil {
 			if *args.Count < group.Scaling.Min {
@@ -1081,6 +1267,9 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 					fmt.Sprintf("group count was greater than scaling policy maximum: %d > %d",
 						*args.Count, group.Scaling.Max))
 			}
+			if err := j.checkScalingRate(snap, ws, job, group, prevCount, *args.Count, now); err != nil {
+				return err
+			}
 		}
 
 		// Update group count
@@ -1171,6 +1360,54 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 	return nil
 }
 
+// checkScalingRate enforces the max step and cooldown of the scaling policy of
+// the group on a request changing its count from prevCount to count. These
+// guards apply to every caller, so that a misbehaving autoscaler cannot scale
+// a job up and down faster than its policy allows.
+func (j *Job) checkScalingRate(snap *state.StateSnapshot, ws memdb.WatchSet,
+	job *structs.Job, group *structs.TaskGroup, prevCount, count, now int64) error {
+
+	policy := group.Scaling
+	if count == prevCount {
+		return nil
+	}
+
+	step := count - prevCount
+	if step < 0 {
+		step = -step
+	}
+	if policy.MaxStep > 0 && step > policy.MaxStep {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("group count change was greater than scaling policy max step: %d > %d",
+				step, policy.MaxStep))
+	}
+
+	if policy.Cooldown <= 0 {
+		return nil
+	}
+
+	events, _, err := snap.ScalingEventsByJob(ws, job.Namespace, job.ID)
+	if err != nil {
+		j.logger.Error("unable to lookup scaling events", "error", err)
+		return err
+	}
+
+	// Events are sorted from newest to oldest, and only the events that
+	// changed the count start a cooldown.
+	for _, event := range events[group.Name] {
+		if event.Error || event.DryRun || event.Count == nil || *event.Count == event.PreviousCount {
+			continue
+		}
+		if remaining := policy.Cooldown - time.Duration(now-event.Time); remaining > 0 {
+			return structs.NewErrRPCCoded(400,
+				fmt.Sprintf("job scaling blocked by scaling policy cooldown for another %s",
+					remaining.Round(time.Second)))
+		}
+		break
+	}
+	return nil
+}
+
 func (j *Job) GetJobSubmission(args *structs.JobSubmissionRequest, reply *structs.JobSubmissionResponse) error {
 	authErr := j.srv.Authenticate(j.ctx, args)
 	if done, err := j.srv.forward("Job.GetJobSubmission", args, args, reply); done {
@@ -1183,12 +1420,16 @@ func (j *Job) GetJobSubmission(args *structs.JobSubmissionRequest, reply *struct
 	defer metrics.MeasureSince([]string{"nomad", "job", "get_job_submission"}, time.Now())
 
 	// Check for read-job permissions
-	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+	aclObj, err := j.srv.ResolveACL(args)
+	if err != nil {
 		return err
 	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
 		return structs.ErrPermissionDenied
 	}
 
+	// Variable values are only served to users allowed to read them
+	redact := !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJobSourceSecrets)
+
 	// Setup the blocking query
 	opts := blockingOptions{
 		queryOpts: &args.QueryOptions,
@@ -1202,6 +1443,9 @@ func (j *Job) GetJobSubmission(args *structs.JobSubmissionRequest, reply *struct
 
 			// Setup the output
 			reply.Submission = out
+			if redact {
+				reply.Submission = out.Redact()
+			}
 			if out != nil {
 				// associate with the index of the job this submission originates from
 				reply.Index = out.JobModifyIndex
@@ -1268,6 +1512,277 @@ func (j *Job) GetJob(args *structs.JobSpecificRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// Restart is used to start a batched restart of the allocations of a job,
+// submit batches to it, and update its status. Restarts are driven by the
+// CLI, but are persisted so that a failed batch pauses the restart even if
+// the CLI disconnects. Batches submitted to a paused restart are rejected.
+func (j *Job) Restart(args *structs.JobRestartRequest, reply *structs.JobRestartResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Restart", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "restart"}, time.Now())
+
+	// Check for alloc-lifecycle and read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityAllocLifecycle) ||
+		!aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(j.srv.Members(), j.srv.Region(), minJobRestartVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to track job restarts",
+			minJobRestartVersion)
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for restart")
+	}
+	switch args.Status {
+	case "", structs.JobRestartStatusPaused, structs.JobRestartStatusComplete:
+	default:
+		return fmt.Errorf("invalid job restart status %q", args.Status)
+	}
+	if args.Status != "" && len(args.Batch) > 0 {
+		return fmt.Errorf("job restart status and batch cannot be updated together")
+	}
+
+	store := j.srv.State()
+	namespace := args.RequestNamespace()
+	job, err := store.JobByID(nil, namespace, args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrUnknownJob(args.JobID)
+	}
+
+	now := time.Now()
+	existing, err := store.JobRestartByJob(nil, namespace, args.JobID)
+	if err != nil {
+		return err
+	}
+
+	var restart *structs.JobRestart
+	var rejectErr error
+	switch {
+	case args.RestartID == "":
+		if args.Status != "" || len(args.Batch) > 0 {
+			return fmt.Errorf("missing restart ID to update")
+		}
+		restart = &structs.JobRestart{
+			ID:         uuid.Generate(),
+			Namespace:  namespace,
+			JobID:      args.JobID,
+			Status:     structs.JobRestartStatusRunning,
+			Reschedule: args.Reschedule,
+			CreateTime: now.UnixNano(),
+		}
+
+	case existing == nil || existing.ID != args.RestartID:
+		return fmt.Errorf("restart %q of job %q not found", args.RestartID, args.JobID)
+
+	case existing.Terminal():
+		return fmt.Errorf("restart %q of job %q is %s", args.RestartID, args.JobID, existing.Status)
+
+	case args.Status != "":
+		restart = existing.Copy()
+		restart.Status = args.Status
+		restart.StatusDescription = args.StatusDescription
+
+	case existing.Status == structs.JobRestartStatusPaused:
+		return fmt.Errorf("%s: %s", structs.JobRestartPausedError, existing.StatusDescription)
+
+	default:
+		for _, allocID := range args.Batch {
+			alloc, err := store.AllocByID(nil, allocID)
+			if err != nil {
+				return err
+			}
+			if alloc == nil || alloc.Namespace != namespace || alloc.JobID != args.JobID {
+				return fmt.Errorf("allocation %q does not belong to job %q", allocID, args.JobID)
+			}
+		}
+
+		// Check the previous batch before accepting a new one, in case it
+		// failed since the leader last checked it.
+		reason, err := jobRestartBatchFailure(store, existing, now)
+		if err != nil {
+			return err
+		}
+
+		restart = existing.Copy()
+		if reason != "" {
+			restart.Status = structs.JobRestartStatusPaused
+			restart.StatusDescription = reason
+			rejectErr = fmt.Errorf("%s: %s", structs.JobRestartPausedError, reason)
+		} else {
+			restart.Batch = args.Batch
+			restart.BatchStartTime = now.UnixNano()
+			restart.BatchCount++
+		}
+	}
+	restart.ModifyTime = now.UnixNano()
+
+	// Commit the restart via Raft
+	req := &structs.JobRestartUpsertRequest{
+		Restart:      restart,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := j.srv.raftApply(structs.JobRestartUpsertRequestType, req)
+	if err != nil {
+		j.logger.Error("job restart update failed", "error", err)
+		return err
+	}
+	if rejectErr != nil {
+		return rejectErr
+	}
+
+	reply.Restart, err = j.srv.State().JobRestartByJob(nil, namespace, args.JobID)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// GetRestart is used to request the restart of a job.
+func (j *Job) GetRestart(args *structs.JobSpecificRequest, reply *structs.SingleJobRestartResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.GetRestart", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "get_restart"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			out, err := state.JobRestartByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			reply.Restart = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the job restarts table
+				index, err := state.Index("job_restarts")
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// PurgeHistory is used to list the audit records of the jobs purged with
+// PurgeWithAudit, newest first.
+func (j *Job) PurgeHistory(args *structs.JobPurgeHistoryRequest, reply *structs.JobPurgeHistoryResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.PurgeHistory", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "purge_history"}, time.Now())
+
+	aclObj, err := j.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	allow := func(ns string) bool {
+		return aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob)
+	}
+	namespace := args.RequestNamespace()
+	if namespace != structs.AllNamespacesSentinel && !allow(namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			var allowed map[string]bool
+			var iter memdb.ResultIterator
+			var err error
+			switch {
+			case namespace == structs.AllNamespacesSentinel:
+				allowed, err = allowedNSes(aclObj, state, allow)
+				if err == structs.ErrPermissionDenied {
+					// return empty if token isn't authorized for any namespace
+					reply.Records = []*structs.JobPurgeRecord{}
+					return nil
+				} else if err != nil {
+					return err
+				}
+				iter, err = state.JobPurgeRecords(ws)
+			case args.JobID != "":
+				iter, err = state.JobPurgeRecordsByJob(ws, namespace, args.JobID)
+			default:
+				iter, err = state.JobPurgeRecordsByNamespace(ws, namespace)
+			}
+			if err != nil {
+				return err
+			}
+
+			var records []*structs.JobPurgeRecord
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				record := raw.(*structs.JobPurgeRecord)
+				if allowed != nil && !allowed[record.Namespace] {
+					continue
+				}
+				if args.JobID != "" && record.JobID != args.JobID {
+					continue
+				}
+				records = append(records, record)
+			}
+			sort.Slice(records, func(i, j int) bool {
+				return records[i].PurgedAt > records[j].PurgedAt
+			})
+			reply.Records = records
+
+			// Use the last index that affected the job purge history table
+			index, err := state.Index("job_purge_history")
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // GetJobVersions is used to retrieve all tracked versions of a job.
 func (j *Job) GetJobVersions(args *structs.JobVersionsRequest,
 	reply *structs.JobVersionsResponse) error {
@@ -1601,6 +2116,78 @@ func (j *Job) Allocations(args *structs.JobSpecificRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// Results is used to list the results registered by the tasks of a job
+func (j *Job) Results(args *structs.JobSpecificRequest,
+	reply *structs.JobResultsResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Results", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "results"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Capture the results of the allocations of all job versions
+			allocs, err := state.AllocsByJob(ws, args.RequestNamespace(), args.JobID, true)
+			if err != nil {
+				return err
+			}
+
+			// Return the results in the order they were created
+			sort.Slice(allocs, func(i, j int) bool {
+				return allocs[i].CreateIndex < allocs[j].CreateIndex
+			})
+
+			reply.Results = nil
+			for _, alloc := range allocs {
+				tasks := make([]string, 0, len(alloc.TaskResults))
+				for task := range alloc.TaskResults {
+					tasks = append(tasks, task)
+				}
+				sort.Strings(tasks)
+
+				for _, task := range tasks {
+					reply.Results = append(reply.Results, &structs.AllocTaskResult{
+						AllocID:   alloc.ID,
+						AllocName: alloc.Name,
+						TaskName:  task,
+						Result:    alloc.TaskResults[task],
+					})
+				}
+			}
+
+			// Use the last index that affected the allocs table
+			index, err := state.Index("allocs")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // Evaluations is used to list the evaluations for a job
 func (j *Job) Evaluations(args *structs.JobSpecificRequest,
 	reply *structs.JobEvaluationsResponse) error {
@@ -1695,6 +2282,87 @@ func (j *Job) Deployments(args *structs.JobSpecificRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// Snapshot is used to retrieve a job along with its evaluations, deployments
+// and allocations. All objects are read from the same state snapshot so the
+// response never mixes objects from different Raft indexes.
+func (j *Job) Snapshot(args *structs.JobSpecificRequest,
+	reply *structs.JobSnapshotResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Snapshot", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "snapshot"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			// Read everything from a single snapshot so that writes applied
+			// between the reads below can't be observed
+			snap, err := store.Snapshot()
+			if err != nil {
+				return err
+			}
+			namespace := args.RequestNamespace()
+
+			job, err := snap.JobByID(ws, namespace, args.JobID)
+			if err != nil {
+				return err
+			}
+			evals, err := snap.EvalsByJob(ws, namespace, args.JobID)
+			if err != nil {
+				return err
+			}
+			deploys, err := snap.DeploymentsByJobID(ws, namespace, args.JobID, args.All)
+			if err != nil {
+				return err
+			}
+			allocs, err := snap.AllocsByJob(ws, namespace, args.JobID, args.All)
+			if err != nil {
+				return err
+			}
+
+			reply.Snapshot = &structs.JobSnapshot{
+				Job:         job,
+				Evaluations: evals,
+				Deployments: deploys,
+				Allocations: make([]*structs.AllocListStub, 0, len(allocs)),
+			}
+			for _, alloc := range allocs {
+				reply.Snapshot.Allocations = append(reply.Snapshot.Allocations, alloc.Stub(nil))
+			}
+
+			// Use the index of the snapshot, which all objects are
+			// consistent with
+			index, err := snap.LatestIndex()
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // LatestDeployment is used to retrieve the latest deployment for a job
 func (j *Job) LatestDeployment(args *structs.JobSpecificRequest,
 	reply *structs.SingleDeploymentResponse) error {
@@ -1955,6 +2623,9 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		return fmt.Errorf("scheduler resulted in an unexpected number of plans: %v", plans)
 	}
 	annotations := planner.Plans[0].Annotations
+	if err := scheduler.AnnotatePortConflicts(snap, args.Job, annotations); err != nil {
+		return fmt.Errorf("failed to check static port conflicts: %v", err)
+	}
 	if args.Diff {
 		jobDiff, err := existingJob.Diff(args.Job, true)
 		if err != nil {
@@ -1989,6 +2660,81 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	return nil
 }
 
+// Drift is used to compare a job specification against the registered version
+// of the job. The job is normalized the same way it would be on registration
+// and the hashes of both specifications are returned, so callers can detect
+// drift without diffing the full specifications.
+func (j *Job) Drift(args *structs.JobDriftRequest, reply *structs.JobDriftResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Drift", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "drift"}, time.Now())
+
+	// Validate the arguments
+	if args.Job == nil {
+		return fmt.Errorf("Job required for drift")
+	}
+
+	// defensive check; http layer and RPC requester should ensure namespaces are set consistently
+	if args.RequestNamespace() != args.Job.Namespace {
+		return fmt.Errorf("mismatched request namespace in request: %q, %q", args.RequestNamespace(), args.Job.Namespace)
+	}
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Run admission controllers so the job is in the form it would be
+	// registered with
+	job, warnings, err := j.admissionControllers(args.Job)
+	if err != nil {
+		return err
+	}
+	reply.Warnings = helper.MergeMultierrorWarnings(warnings...)
+
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	existingJob, err := snap.JobByID(nil, args.RequestNamespace(), job.ID)
+	if err != nil {
+		return err
+	}
+
+	// Registration clears the Consul token, so it must not be returned
+	job.ConsulToken = ""
+
+	reply.Job = job
+	reply.Hash, err = job.SpecHash()
+	if err != nil {
+		return err
+	}
+
+	if existingJob != nil {
+		reply.RegisteredHash, err = existingJob.SpecHash()
+		if err != nil {
+			return err
+		}
+		reply.RegisteredVersion = existingJob.Version
+	}
+	reply.Drifted = reply.Hash != reply.RegisteredHash
+
+	index, err := snap.Index("jobs")
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
 // validateJobUpdate ensures updates to a job are valid.
 func validateJobUpdate(old, new *structs.Job) error {
 	// Validate Dispatch not set on new Jobs
@@ -2258,6 +3004,34 @@ func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job)
 		return fmt.Errorf("Dispatch did not provide required meta keys: %v", flat)
 	}
 
+	// Check the metadata values and payload match their schemas
+	var mErr multierror.Error
+	for _, schema := range job.ParameterizedJob.MetaSchema {
+		v, ok := req.Meta[schema.Name]
+		if !ok {
+			continue
+		}
+		if err := schema.ValidateValue(v); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("meta.%s: %v", schema.Name, err))
+		}
+	}
+
+	if job.ParameterizedJob.PayloadSchema != "" && hasInputData {
+		schema, err := jsonschema.Parse([]byte(job.ParameterizedJob.PayloadSchema))
+		if err != nil {
+			return fmt.Errorf("Parameterized job has an invalid payload schema: %v", err)
+		}
+		for _, err := range schema.ValidateJSON(req.Payload) {
+			_ = multierror.Append(&mErr, fmt.Errorf("payload%s: %s",
+				strings.TrimPrefix(err.Path, "$"), err.Message))
+		}
+	}
+
+	if err := mErr.ErrorOrNil(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("Dispatch input does not match the parameterized job's schema: %v", err))
+	}
+
 	return nil
 }
 
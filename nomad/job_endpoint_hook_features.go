@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/serf/serf"
+)
+
+// jobFeature is a job specification feature that requires all the servers in
+// the region to be running a minimum version of Nomad. Servers running an
+// older version don't know about the feature, so a job using it could be
+// silently altered or misinterpreted if leadership fails over to one of them.
+type jobFeature struct {
+	// name is the user facing name of the feature, used in error messages.
+	name string
+
+	// minVersion is the minimum server version that supports the feature.
+	minVersion *version.Version
+
+	// used returns true if the job uses the feature.
+	used func(*structs.Job) bool
+}
+
+// jobFeatures is the registry of job features gated on the version of the
+// servers. New job specification fields or blocks that older servers can't
+// handle should be added here.
+var jobFeatures = []jobFeature{
+	{
+		name:       "task actions",
+		minVersion: version.Must(version.NewVersion("1.7.0")),
+		used: func(job *structs.Job) bool {
+			return anyTask(job, func(t *structs.Task) bool {
+				return len(t.Actions) > 0
+			})
+		},
+	},
+	{
+		name:       "a numa block",
+		minVersion: version.Must(version.NewVersion("1.7.0")),
+		used: func(job *structs.Job) bool {
+			return anyTask(job, func(t *structs.Task) bool {
+				return t.Resources != nil && t.Resources.NUMA.Requested()
+			})
+		},
+	},
+	{
+		name:       "a ui block",
+		minVersion: version.Must(version.NewVersion("1.8.0")),
+		used: func(job *structs.Job) bool {
+			return job.UI != nil
+		},
+	},
+	{
+		name:       "a disconnect block",
+		minVersion: version.Must(version.NewVersion("1.8.0")),
+		used: func(job *structs.Job) bool {
+			for _, tg := range job.TaskGroups {
+				if tg.Disconnect != nil {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		name:       "a task schedule block",
+		minVersion: version.Must(version.NewVersion("1.8.0")),
+		used: func(job *structs.Job) bool {
+			return anyTask(job, func(t *structs.Task) bool {
+				return t.Schedule != nil
+			})
+		},
+	},
+	{
+		name:       "min_client_version",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return job.MinClientVersion != ""
+		},
+	},
+	{
+		name:       "a scale_with_nodes block",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return anyGroup(job, func(tg *structs.TaskGroup) bool {
+				return tg.ScaleWithNodes != nil
+			})
+		},
+	},
+	{
+		name:       "a default identity ttl",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return anyTask(job, func(t *structs.Task) bool {
+				return t.Identity != nil && t.Identity.TTL > 0
+			})
+		},
+	},
+	{
+		name:       "auto_promote_threshold",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return anyUpdate(job, func(u *structs.UpdateStrategy) bool {
+				return u.AutoPromoteThreshold != 0 || u.AutoPromoteMinHealthyTime != 0
+			})
+		},
+	},
+	{
+		name:       "dispatch meta or payload schemas",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			p := job.ParameterizedJob
+			return p != nil && (len(p.MetaSchema) > 0 || p.PayloadSchema != "")
+		},
+	},
+	{
+		name:       "a rollback_on block",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return anyUpdate(job, func(u *structs.UpdateStrategy) bool {
+				return u.RollbackOn != nil
+			})
+		},
+	},
+	{
+		name:       "gang scheduling",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return anyGroup(job, func(tg *structs.TaskGroup) bool {
+				return tg.Gang != ""
+			})
+		},
+	},
+	{
+		name:       "topology spreads",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			hasTopology := func(spreads []*structs.Spread) bool {
+				for _, spread := range spreads {
+					if len(spread.Topology) > 0 {
+						return true
+					}
+				}
+				return false
+			}
+			return hasTopology(job.Spreads) || anyGroup(job, func(tg *structs.TaskGroup) bool {
+				return hasTopology(tg.Spreads)
+			})
+		},
+	},
+	{
+		name:       "a disruption_budget block",
+		minVersion: version.Must(version.NewVersion("1.9.7")),
+		used: func(job *structs.Job) bool {
+			return anyGroup(job, func(tg *structs.TaskGroup) bool {
+				return tg.DisruptionBudget != nil
+			})
+		},
+	},
+}
+
+// anyGroup returns true if fn returns true for any task group of the job.
+func anyGroup(job *structs.Job, fn func(*structs.TaskGroup) bool) bool {
+	for _, tg := range job.TaskGroups {
+		if fn(tg) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyUpdate returns true if fn returns true for the update block of the job
+// or of any of its task groups.
+func anyUpdate(job *structs.Job, fn func(*structs.UpdateStrategy) bool) bool {
+	if fn(&job.Update) {
+		return true
+	}
+	return anyGroup(job, func(tg *structs.TaskGroup) bool {
+		return tg.Update != nil && fn(tg.Update)
+	})
+}
+
+// anyTask returns true if fn returns true for any task of the job.
+func anyTask(job *structs.Job, fn func(*structs.Task) bool) bool {
+	for _, tg := range job.TaskGroups {
+		for _, t := range tg.Tasks {
+			if fn(t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jobFeatureGateHook is a job validating admission controller that rejects
+// jobs using features that are not supported by all the servers in the region,
+// as can happen in the middle of an upgrade.
+type jobFeatureGateHook struct {
+	srv *Server
+}
+
+func (jobFeatureGateHook) Name() string {
+	return "feature_gate"
+}
+
+func (h jobFeatureGateHook) Validate(job *structs.Job) ([]error, error) {
+	if h.srv == nil || h.srv.serf == nil {
+		return nil, nil // handle tests w/o real servers safely
+	}
+	return nil, unsupportedJobFeatures(job, h.srv.Members(), h.srv.Region())
+}
+
+// unsupportedJobFeatures returns an error for each feature used by the job
+// that is not supported by all the servers of the region.
+func unsupportedJobFeatures(job *structs.Job, members []serf.Member, region string) error {
+	var mErr *multierror.Error
+	for _, feature := range jobFeatures {
+		if !feature.used(job) {
+			continue
+		}
+		if !ServersMeetMinimumVersion(members, region, feature.minVersion, true) {
+			mErr = multierror.Append(mErr, fmt.Errorf(
+				"job uses %s, which is not supported until all servers are upgraded to %s or later",
+				feature.name, feature.minVersion))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/serf/serf"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobFeatureGateHook_unsupportedJobFeatures(t *testing.T) {
+	ci.Parallel(t)
+
+	upgraded := []serf.Member{
+		makeMember("1.9.7", serf.StatusAlive),
+		makeMember("1.9.7", serf.StatusAlive),
+	}
+	upgrading := []serf.Member{
+		makeMember("1.9.7", serf.StatusAlive),
+		makeMember("1.7.2", serf.StatusAlive),
+	}
+
+	testCases := []struct {
+		name      string
+		members   []serf.Member
+		mutateJob func(*structs.Job)
+		expectErr []string
+	}{
+		{
+			name:      "no gated features",
+			members:   upgrading,
+			mutateJob: func(*structs.Job) {},
+		},
+		{
+			name:    "gated features on upgraded servers",
+			members: upgraded,
+			mutateJob: func(job *structs.Job) {
+				job.UI = &structs.JobUIConfig{Description: "example"}
+				job.MinClientVersion = "1.9.7"
+				job.TaskGroups[0].Disconnect = &structs.DisconnectStrategy{}
+			},
+		},
+		{
+			name:    "features supported by old servers",
+			members: upgrading,
+			mutateJob: func(job *structs.Job) {
+				job.TaskGroups[0].Tasks[0].Actions = []*structs.Action{{Name: "date", Command: "date"}}
+				job.TaskGroups[0].Tasks[0].Resources.NUMA = &structs.NUMA{Affinity: structs.RequireNUMA}
+			},
+		},
+		{
+			name:    "numa affinity none is not a feature use",
+			members: []serf.Member{makeMember("1.6.0", serf.StatusAlive)},
+			mutateJob: func(job *structs.Job) {
+				job.TaskGroups[0].Tasks[0].Actions = nil
+				job.TaskGroups[0].Tasks[0].Resources.NUMA = &structs.NUMA{Affinity: structs.NoneNUMA}
+			},
+		},
+		{
+			name:    "features not supported by old servers",
+			members: upgrading,
+			mutateJob: func(job *structs.Job) {
+				job.UI = &structs.JobUIConfig{Description: "example"}
+				job.MinClientVersion = "1.9.7"
+				job.TaskGroups[0].Disconnect = &structs.DisconnectStrategy{}
+				job.TaskGroups[0].Tasks[0].Schedule = &structs.TaskSchedule{}
+			},
+			expectErr: []string{
+				"job uses a ui block, which is not supported until all servers are upgraded to 1.8.0 or later",
+				"job uses a disconnect block, which is not supported until all servers are upgraded to 1.8.0 or later",
+				"job uses a task schedule block, which is not supported until all servers are upgraded to 1.8.0 or later",
+				"job uses min_client_version, which is not supported until all servers are upgraded to 1.9.7 or later",
+			},
+		},
+		{
+			name:    "features added in 1.9.7",
+			members: upgrading,
+			mutateJob: func(job *structs.Job) {
+				tg := job.TaskGroups[0]
+				tg.ScaleWithNodes = &structs.ScaleWithNodes{}
+				tg.Gang = "web"
+				tg.DisruptionBudget = &structs.DisruptionBudget{MinHealthy: 1}
+				tg.Spreads = []*structs.Spread{{Topology: []string{"${node.datacenter}"}}}
+				tg.Update = &structs.UpdateStrategy{
+					AutoPromoteThreshold: 50,
+					RollbackOn:           &structs.RollbackOn{},
+				}
+				tg.Tasks[0].Identity = &structs.WorkloadIdentity{TTL: time.Hour}
+				job.ParameterizedJob = &structs.ParameterizedJobConfig{PayloadSchema: "{}"}
+			},
+			expectErr: []string{
+				"job uses a scale_with_nodes block",
+				"job uses a default identity ttl",
+				"job uses auto_promote_threshold",
+				"job uses dispatch meta or payload schemas",
+				"job uses a rollback_on block",
+				"job uses gang scheduling",
+				"job uses topology spreads",
+				"job uses a disruption_budget block",
+			},
+		},
+		{
+			name: "failed servers are checked",
+			members: []serf.Member{
+				makeMember("1.9.7", serf.StatusAlive),
+				makeMember("1.7.2", serf.StatusFailed),
+			},
+			mutateJob: func(job *structs.Job) {
+				job.UI = &structs.JobUIConfig{Description: "example"}
+			},
+			expectErr: []string{
+				"job uses a ui block, which is not supported until all servers are upgraded to 1.8.0 or later",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := mock.Job()
+			tc.mutateJob(job)
+
+			err := unsupportedJobFeatures(job, tc.members, "aws")
+			if len(tc.expectErr) == 0 {
+				must.NoError(t, err)
+				return
+			}
+			must.Error(t, err)
+			for _, expectErr := range tc.expectErr {
+				must.ErrorContains(t, err, expectErr)
+			}
+		})
+	}
+}
+
+func TestJobFeatureGateHook_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+
+	hook := jobFeatureGateHook{srv: s1}
+	job := mock.Job()
+	job.UI = &structs.JobUIConfig{Description: "example"}
+	job.MinClientVersion = "1.9.7"
+
+	// The test server runs the current version so all features are supported
+	warnings, err := hook.Validate(job)
+	must.NoError(t, err)
+	must.Nil(t, warnings)
+}
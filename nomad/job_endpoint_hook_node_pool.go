@@ -30,5 +30,9 @@ func (j jobNodePoolValidatingHook) Validate(job *structs.Job) ([]error, error) {
 		return nil, fmt.Errorf("job %q is in nonexistent node pool %q", job.ID, poolName)
 	}
 
+	if err := pool.AllowsJobFeatureLevel(job.MinClientVersion); err != nil {
+		return nil, fmt.Errorf("job %q: %v", job.ID, err)
+	}
+
 	return j.enterpriseValidation(job, pool)
 }
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -286,7 +287,7 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		nativeServiceDisco.Empty() && len(consulServiceDisco) == 0 &&
 		numaTaskGroups.Empty() && bridgeNetworkingTaskGroups.Empty() &&
 		transparentProxyTaskGroups.Empty() &&
-		taskScheduleTaskGroups.Empty() {
+		taskScheduleTaskGroups.Empty() && j.MinClientVersion == "" {
 		return j, nil, nil
 	}
 
@@ -360,6 +361,11 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		if taskScheduleTaskGroups.Contains(tg.Name) {
 			mutateConstraint(constraintMatcherLeft, tg, taskScheduleConstraint)
 		}
+
+		// If the job requires a minimum client version, run the mutator.
+		if j.MinClientVersion != "" {
+			mutateConstraint(constraintMatcherFull, tg, minClientVersionConstraintFn(j.MinClientVersion))
+		}
 	}
 
 	return j, nil, nil
@@ -382,6 +388,16 @@ func vaultConstraintFn(vault *structs.Vault) *structs.Constraint {
 	return vaultConstraint
 }
 
+// minClientVersionConstraintFn returns the constraint that excludes the
+// clients older than the min_client_version of a job.
+func minClientVersionConstraintFn(minVersion string) *structs.Constraint {
+	return &structs.Constraint{
+		LTarget: attrNomadVersion,
+		RTarget: ">= " + minVersion,
+		Operand: structs.ConstraintSemver,
+	}
+}
+
 // consulConstraintFn returns a service discovery constraint that matches the
 // fingerprint of the requested Consul cluster. This is to support Nomad
 // Enterprise but neither the fingerprint or non-default cluster are allowed
@@ -506,6 +522,7 @@ func (v *jobValidate) Validate(job *structs.Job) (warnings []error, err error) {
 			serviceErrs := v.validateServiceIdentity(
 				s, fmt.Sprintf("task group %s", tg.Name), okForIdentity)
 			multierror.Append(validationErrors, serviceErrs)
+			multierror.Append(validationErrors, v.validateMetaClaims(s.Identity))
 		}
 
 		for _, t := range tg.Tasks {
@@ -516,6 +533,11 @@ func (v *jobValidate) Validate(job *structs.Job) (warnings []error, err error) {
 				serviceErrs := v.validateServiceIdentity(
 					s, fmt.Sprintf("task %s", t.Name), okForIdentity)
 				multierror.Append(validationErrors, serviceErrs)
+				multierror.Append(validationErrors, v.validateMetaClaims(s.Identity))
+			}
+			multierror.Append(validationErrors, v.validateMetaClaims(t.Identity))
+			for _, wid := range t.Identities {
+				multierror.Append(validationErrors, v.validateMetaClaims(wid))
 			}
 
 			vaultWarns, vaultErrs := v.validateVaultIdentity(t, okForIdentity)
@@ -547,6 +569,24 @@ func (v *jobValidate) validateServiceIdentity(s *structs.Service, parent string,
 	return nil
 }
 
+// validateMetaClaims validates that the meta claims requested by an identity
+// are allowed by the server identity_meta_claims configuration.
+func (v *jobValidate) validateMetaClaims(wid *structs.WorkloadIdentity) error {
+	if wid == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	for _, key := range wid.MetaClaims {
+		if !slices.Contains(v.srv.config.IdentityMetaClaims, key) {
+			mErr = multierror.Append(mErr, fmt.Errorf(
+				"Identity %s requests meta claim %q which is not allowed by the server identity_meta_claims configuration",
+				wid.Name, key))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // validateVaultIdentity validates that a task is properly configured to access
 // a Vault cluster.
 //
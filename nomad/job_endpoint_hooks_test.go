@@ -1280,6 +1280,57 @@ func Test_jobImpliedConstraints_Mutate(t *testing.T) {
 			expectedOutputWarnings: nil,
 			expectedOutputError:    nil,
 		},
+		{
+			name: "job with min client version",
+			inputJob: &structs.Job{
+				Name:             "example",
+				MinClientVersion: "1.9.0",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-1",
+						Constraints: []*structs.Constraint{{
+							LTarget: attrNomadVersion,
+							RTarget: ">= 1.8.0",
+							Operand: structs.ConstraintSemver,
+						}},
+					},
+					{
+						Name: "group-2",
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name:             "example",
+				MinClientVersion: "1.9.0",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-1",
+						Constraints: []*structs.Constraint{
+							{
+								LTarget: attrNomadVersion,
+								RTarget: ">= 1.8.0",
+								Operand: structs.ConstraintSemver,
+							},
+							{
+								LTarget: attrNomadVersion,
+								RTarget: ">= 1.9.0",
+								Operand: structs.ConstraintSemver,
+							},
+						},
+					},
+					{
+						Name: "group-2",
+						Constraints: []*structs.Constraint{{
+							LTarget: attrNomadVersion,
+							RTarget: ">= 1.9.0",
+							Operand: structs.ConstraintSemver,
+						}},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -26,6 +27,7 @@ import (
 	"github.com/hashicorp/raft"
 	"github.com/kr/pretty"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -388,6 +390,59 @@ func TestJobEndpoint_Register_EvalPriority(t *testing.T) {
 	requireAssert.Equal(99, out[0].Priority)
 }
 
+func TestJobEndpoint_Register_ForwardedRegion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.Region = "two"
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+	codec := rpcClient(t, s1)
+
+	// Register the job of the other region through the first one
+	job := mock.Job()
+	job.Region = "two"
+	before := time.Now().UnixNano()
+
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "two",
+			Namespace: job.Namespace,
+		},
+	}, &resp))
+
+	// The evaluation records the region the registration was forwarded from
+	eval, err := s2.fsm.State().EvalByID(nil, resp.EvalID)
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+	must.Eq(t, "global", eval.ForwardedFromRegion)
+	must.GreaterEq(t, before, eval.ForwardedTime)
+	must.LessEq(t, eval.CreateTime, eval.ForwardedTime)
+
+	// Registrations in the local region are not annotated
+	job2 := mock.Job()
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", &structs.JobRegisterRequest{
+		Job: job2,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job2.Namespace,
+		},
+	}, &resp))
+	eval, err = s1.fsm.State().EvalByID(nil, resp.EvalID)
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+	must.Eq(t, "", eval.ForwardedFromRegion)
+	must.Zero(t, eval.ForwardedTime)
+}
+
 func TestJobEndpoint_Register_Connect(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1578,6 +1633,41 @@ func TestJobEndpoint_Register_Vault_Disabled(t *testing.T) {
 	}
 }
 
+// TestJobEndpoint_Register_IdentityMetaClaims asserts that identities may only
+// request the meta claims allowed by the server configuration.
+func TestJobEndpoint_Register_IdentityMetaClaims(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.IdentityMetaClaims = []string{"owner"}
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Identities = []*structs.WorkloadIdentity{{
+		Name:       "example",
+		Audience:   []string{"example.com"},
+		MetaClaims: []string{"owner", "cost-center"},
+	}}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	must.ErrorContains(t, err, `requests meta claim "cost-center" which is not allowed`)
+
+	job.TaskGroups[0].Tasks[0].Identities[0].MetaClaims = []string{"owner"}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+}
+
 // TestJobEndpoint_Register_Vault_OverrideConstraint asserts that job
 // submitters can specify their own Vault constraint to override the
 // automatically injected one.
@@ -2030,6 +2120,57 @@ func TestJobEndpoint_Register_ValidateMemoryMax_NodePool(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Register_MinClientVersion_NodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	pool := mock.NodePool()
+	pool.MaxJobFeatureLevel = "1.8.0"
+	must.NoError(t, s.State().UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	register := func(minClientVersion string) error {
+		job := mock.Job()
+		job.NodePool = pool.Name
+		job.MinClientVersion = minClientVersion
+
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		if err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp); err != nil {
+			return err
+		}
+
+		// The job is constrained to the clients of the min client version
+		stored, err := s.State().JobByID(nil, job.Namespace, job.ID)
+		must.NoError(t, err)
+		if minClientVersion != "" {
+			must.SliceContains(t, stored.TaskGroups[0].Constraints, &structs.Constraint{
+				LTarget: attrNomadVersion,
+				RTarget: ">= " + minClientVersion,
+				Operand: structs.ConstraintSemver,
+			})
+		}
+		return nil
+	}
+
+	must.NoError(t, register(""))
+	must.NoError(t, register("1.8.0"))
+
+	err := register("1.9.0")
+	must.ErrorContains(t, err, "more recent than the max job feature level 1.8.0")
+}
+
 // evalUpdateFromRaft searches the raft logs for the eval update pertaining to the eval
 func evalUpdateFromRaft(t *testing.T, s *Server, evalID string) *structs.Evaluation {
 	var store raft.LogStore = s.raftInmem
@@ -2689,6 +2830,144 @@ func TestJobEndpoint_Stable_ACL(t *testing.T) {
 	require.Equal(true, out.Stable)
 }
 
+func TestJobEndpoint_Freeze(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	req := &structs.JobFreezeRequest{
+		JobID:  job.ID,
+		Frozen: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Freezing the job doesn't create an evaluation
+	var resp structs.JobFreezeResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp))
+	must.Positive(t, resp.Index)
+	must.Eq(t, "", resp.EvalID)
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.True(t, out.Frozen)
+	must.Eq(t, job.Version, out.Version)
+
+	// Unfreezing the job creates an evaluation
+	req.Frozen = false
+	var resp2 structs.JobFreezeResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp2))
+	must.NotEq(t, "", resp2.EvalID)
+
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.False(t, out.Frozen)
+
+	eval, err := state.EvalByID(nil, resp2.EvalID)
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+	must.Eq(t, resp2.EvalCreateIndex, eval.CreateIndex)
+	must.Eq(t, structs.EvalTriggerJobUnfreeze, eval.TriggeredBy)
+	must.Eq(t, job.ID, eval.JobID)
+
+	// Unfreezing a job that isn't frozen is a no-op
+	var resp3 structs.JobFreezeResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp3))
+	must.Eq(t, "", resp3.EvalID)
+
+	// Freezing a missing job fails
+	req.JobID = "missing"
+	var resp4 structs.JobFreezeResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp4)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestJobEndpoint_Freeze_MinVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Build = "1.9.6"
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	must.NoError(t, s1.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	req := &structs.JobFreezeRequest{
+		JobID:  job.ID,
+		Frozen: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobFreezeResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp)
+	must.ErrorContains(t, err, "all servers must be running version 1.9.7 or later")
+}
+
+func TestJobEndpoint_Freeze_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	req := &structs.JobFreezeRequest{
+		JobID:  job.ID,
+		Frozen: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Attempt without a token
+	var resp structs.JobFreezeResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Attempt with a token that can only submit jobs
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	req.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Freeze with a token that has the freeze-job capability
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityFreezeJob}))
+	req.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp))
+
+	// Unfreeze with a management token
+	req.AuthToken = root.SecretID
+	req.Frozen = false
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Freeze", req, &resp))
+	must.NotEq(t, "", resp.EvalID)
+}
+
 func TestJobEndpoint_Evaluate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3448,18 +3727,313 @@ func TestJobEndpoint_Deregister_EvalCreation(t *testing.T) {
 		require.NoError(t, err)
 		require.NotZero(t, resp.Index)
 
-		dereg := &structs.JobDeregisterRequest{
-			JobID: job.ID,
-			WriteRequest: structs.WriteRequest{
-				Region:    "global",
-				Namespace: job.Namespace,
-			},
-		}
-		var resp2 structs.JobDeregisterResponse
-		err = msgpackrpc.CallWithCodec(codec, "Job.Deregister", dereg, &resp2)
-		require.NoError(t, err)
-		require.Empty(t, resp2.EvalID)
-	})
+		dereg := &structs.JobDeregisterRequest{
+			JobID: job.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp2 structs.JobDeregisterResponse
+		err = msgpackrpc.CallWithCodec(codec, "Job.Deregister", dereg, &resp2)
+		require.NoError(t, err)
+		require.Empty(t, resp2.EvalID)
+	})
+}
+
+func TestJobEndpoint_PurgeWithAudit(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	// A record forged through a plain deregister is ignored
+	forged := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 101, nil, forged))
+	req := &structs.JobDeregisterRequest{
+		JobID:       forged.ID,
+		Purge:       true,
+		PurgeRecord: structs.NewJobPurgeRecord(forged, nil, "someone-else", 1),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: forged.Namespace,
+		},
+	}
+	var resp structs.JobDeregisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Deregister", req, &resp))
+
+	iter, err := state.JobPurgeRecords(nil)
+	must.NoError(t, err)
+	must.Nil(t, iter.Next())
+
+	// Purge the job with an audit record
+	req = &structs.JobDeregisterRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.PurgeWithAudit", req, &resp))
+	must.NotEq(t, 0, resp.Index)
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	histReq := &structs.JobPurgeHistoryRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var histResp structs.JobPurgeHistoryResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.PurgeHistory", histReq, &histResp))
+	must.Len(t, 1, histResp.Records)
+	record := histResp.Records[0]
+	must.Eq(t, job.ID, record.JobID)
+	must.Eq(t, job.Type, record.Type)
+	must.NotEq(t, "", record.PurgedBy)
+	must.Positive(t, record.PurgedAt)
+	must.Len(t, 1, record.Versions)
+	must.Eq(t, job.Version, record.Versions[0].Version)
+	must.Eq(t, resp.JobModifyIndex, record.CreateIndex)
+}
+
+func TestJobEndpoint_PurgeHistory_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, state.UpsertNamespaces(90, []*structs.Namespace{ns}))
+
+	job1 := mock.Job()
+	job2 := mock.Job()
+	job2.Namespace = ns.Name
+	for i, job := range []*structs.Job{job1, job2} {
+		index := uint64(100 + i)
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, index, nil, job))
+
+		req := &structs.JobDeregisterRequest{
+			JobID: job.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+				AuthToken: root.SecretID,
+			},
+		}
+		var resp structs.JobDeregisterResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.PurgeWithAudit", req, &resp))
+	}
+
+	// Purging requires the submit-job capability
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "test-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req := &structs.JobDeregisterRequest{
+		JobID: "missing",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var resp structs.JobDeregisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.PurgeWithAudit", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	histReq := &structs.JobPurgeHistoryRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: ns.Name,
+			AuthToken: readToken.SecretID,
+		},
+	}
+
+	// Listing another namespace is denied
+	var histResp structs.JobPurgeHistoryResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.PurgeHistory", histReq, &histResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Listing all the namespaces only returns the allowed ones
+	histReq.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.PurgeHistory", histReq, &histResp))
+	must.Len(t, 1, histResp.Records)
+	must.Eq(t, job1.ID, histResp.Records[0].JobID)
+
+	// A management token sees everything
+	histReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.PurgeHistory", histReq, &histResp))
+	must.Len(t, 2, histResp.Records)
+}
+
+func TestJobEndpoint_Restart(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	alloc1 := mock.Alloc()
+	alloc1.Job = job
+	alloc1.JobID = job.ID
+	alloc1.ClientStatus = structs.AllocClientStatusRunning
+	alloc2 := alloc1.Copy()
+	alloc2.ID = uuid.Generate()
+	other := mock.Alloc()
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 101,
+		[]*structs.Allocation{alloc1, alloc2, other}))
+
+	restartReq := func(restartID string, batch []string, status string) *structs.JobRestartRequest {
+		return &structs.JobRestartRequest{
+			JobID:     job.ID,
+			RestartID: restartID,
+			Batch:     batch,
+			Status:    status,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+	}
+
+	// Start a restart
+	var resp structs.JobRestartResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq("", nil, ""), &resp))
+	must.NotNil(t, resp.Restart)
+	must.Eq(t, structs.JobRestartStatusRunning, resp.Restart.Status)
+	restartID := resp.Restart.ID
+
+	// Allocations of other jobs are rejected
+	err := msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq(restartID, []string{other.ID}, ""), &resp)
+	must.ErrorContains(t, err, "does not belong to job")
+
+	// Submit the first batch
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq(restartID, []string{alloc1.ID}, ""), &resp))
+	must.Eq(t, []string{alloc1.ID}, resp.Restart.Batch)
+	must.Eq(t, 1, resp.Restart.BatchCount)
+	must.Positive(t, resp.Restart.BatchStartTime)
+
+	// The next batch is rejected once the first one failed, and the restart
+	// is paused
+	failed := alloc1.Copy()
+	failed.ClientStatus = structs.AllocClientStatusFailed
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 102, []*structs.Allocation{failed}))
+
+	err = msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq(restartID, []string{alloc2.ID}, ""), &resp)
+	must.ErrorContains(t, err, structs.JobRestartPausedError)
+
+	getReq := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var getResp structs.SingleJobRestartResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.GetRestart", getReq, &getResp))
+	must.Eq(t, structs.JobRestartStatusPaused, getResp.Restart.Status)
+	must.StrContains(t, getResp.Restart.StatusDescription, alloc1.ID)
+	must.Eq(t, []string{alloc1.ID}, getResp.Restart.Batch)
+
+	err = msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq(restartID, []string{alloc2.ID}, ""), &resp)
+	must.ErrorContains(t, err, structs.JobRestartPausedError)
+
+	// Complete restarts reject any update
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Restart",
+		restartReq(restartID, nil, structs.JobRestartStatusComplete), &resp))
+	must.Eq(t, structs.JobRestartStatusComplete, resp.Restart.Status)
+
+	err = msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq(restartID, []string{alloc2.ID}, ""), &resp)
+	must.ErrorContains(t, err, "is complete")
+
+	// A new restart replaces the previous one
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq("", nil, ""), &resp))
+	must.NotEq(t, restartID, resp.Restart.ID)
+	err = msgpackrpc.CallWithCodec(codec, "Job.Restart", restartReq(restartID, nil, structs.JobRestartStatusPaused), &resp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestJobEndpoint_Restart_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "test-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	lifecycleToken := mock.CreatePolicyAndToken(t, state, 1002, "test-lifecycle",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{
+			acl.NamespaceCapabilityReadJob,
+			acl.NamespaceCapabilityAllocLifecycle,
+		}))
+
+	req := &structs.JobRestartRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRestartResponse
+
+	// Restarting requires the alloc-lifecycle and read-job capabilities
+	for _, token := range []string{"", readToken.SecretID} {
+		req.AuthToken = token
+		err := msgpackrpc.CallWithCodec(codec, "Job.Restart", req, &resp)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	}
+
+	req.AuthToken = lifecycleToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Restart", req, &resp))
+
+	// Reading the restart requires the read-job capability
+	getReq := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var getResp structs.SingleJobRestartResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.GetRestart", getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	for _, token := range []string{readToken.SecretID, root.SecretID} {
+		getReq.AuthToken = token
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.GetRestart", getReq, &getResp))
+		must.Eq(t, resp.Restart.ID, getResp.Restart.ID)
+	}
 }
 
 func TestJobEndpoint_Deregister_NoShutdownDelay(t *testing.T) {
@@ -4221,6 +4795,31 @@ func TestJobEndpoint_GetJobSubmission_ACL(t *testing.T) {
 	var submissionResponse2 structs.JobSubmissionResponse
 	err = msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", submissionRequest, &submissionResponse2)
 	must.NoError(t, err)
+	must.Eq(t, map[string]string{"one": "1"}, submissionResponse2.Submission.VariableFlags)
+	must.Eq(t, "two = 2", submissionResponse2.Submission.Variables)
+
+	// make request with a read-job token, variables are redacted
+	readToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "read-job",
+		mock.NamespacePolicy(job.Namespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	submissionRequest.QueryOptions.AuthToken = readToken.SecretID
+	var submissionResponse3 structs.JobSubmissionResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", submissionRequest, &submissionResponse3)
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"one": structs.JobSubmissionRedacted}, submissionResponse3.Submission.VariableFlags)
+	must.Eq(t, `two = "<redacted>"`, submissionResponse3.Submission.Variables)
+
+	// make request with a token allowed to read the variables
+	secretsToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1003, "read-job-source-secrets",
+		mock.NamespacePolicy(job.Namespace, "", []string{
+			acl.NamespaceCapabilityReadJob,
+			acl.NamespaceCapabilityReadJobSourceSecrets,
+		}))
+	submissionRequest.QueryOptions.AuthToken = secretsToken.SecretID
+	var submissionResponse4 structs.JobSubmissionResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", submissionRequest, &submissionResponse4)
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"one": "1"}, submissionResponse4.Submission.VariableFlags)
+	must.Eq(t, "two = 2", submissionResponse4.Submission.Variables)
 }
 
 func TestJobEndpoint_GetJobVersions_ACL(t *testing.T) {
@@ -5333,6 +5932,55 @@ func TestJobEndpoint_Allocations_NoJobID(t *testing.T) {
 	require.Contains(t, err.Error(), "missing job ID")
 }
 
+func TestJobEndpoint_Results(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.BatchJob()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+
+	alloc1 := mock.BatchAlloc()
+	alloc1.Job = job
+	alloc1.JobID = job.ID
+	alloc2 := alloc1.Copy()
+	alloc2.ID = uuid.Generate()
+	noResult := alloc1.Copy()
+	noResult.ID = uuid.Generate()
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{alloc1, noResult}))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Allocation{alloc2}))
+
+	result1 := &structs.TaskResult{Summary: "first"}
+	result2 := &structs.TaskResult{OutputURI: "s3://bucket/output"}
+	must.NoError(t, state.UpsertAllocTaskResult(structs.MsgTypeTestSetup, 1002,
+		alloc2.ID, "web", result2))
+	must.NoError(t, state.UpsertAllocTaskResult(structs.MsgTypeTestSetup, 1003,
+		alloc1.ID, "web", result1))
+
+	get := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobResultsResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Results", get, &resp))
+	must.Eq(t, 1003, resp.Index)
+
+	// The results are returned in the order the allocs were created
+	must.Eq(t, []*structs.AllocTaskResult{
+		{AllocID: alloc1.ID, AllocName: alloc1.Name, TaskName: "web", Result: result1},
+		{AllocID: alloc2.ID, AllocName: alloc2.Name, TaskName: "web", Result: result2},
+	}, resp.Results)
+}
+
 func TestJobEndpoint_Evaluations(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5589,6 +6237,120 @@ func TestJobEndpoint_Deployments_ACL(t *testing.T) {
 	require.Len(validResp2.Deployments, 2, "deployments for job")
 }
 
+func TestJobEndpoint_Snapshot(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a job along with an eval, a deployment and an alloc
+	j := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, j))
+
+	eval := mock.Eval()
+	eval.JobID = j.ID
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1001, []*structs.Evaluation{eval}))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobCreateIndex = j.CreateIndex
+	must.NoError(t, state.UpsertDeployment(1002, d))
+
+	alloc := mock.Alloc()
+	alloc.JobID = j.ID
+	alloc.Job = j
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{alloc}))
+
+	// Objects of other jobs are not returned
+	other := mock.Alloc()
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1004, []*structs.Allocation{other}))
+
+	get := &structs.JobSpecificRequest{
+		JobID: j.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: j.Namespace,
+		},
+	}
+
+	// Lookup with no token should fail
+	var resp structs.JobSnapshotResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Snapshot", get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Lookup with a token that can only list jobs should fail
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1005, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Snapshot", get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Lookup with a valid token should succeed
+	validToken := mock.CreatePolicyAndToken(t, state, 1006, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	get.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Snapshot", get, &resp))
+
+	must.Eq(t, 1007, resp.Index)
+	must.NotNil(t, resp.Snapshot)
+	must.Eq(t, j.ID, resp.Snapshot.Job.ID)
+	must.Len(t, 1, resp.Snapshot.Evaluations)
+	must.Eq(t, eval.ID, resp.Snapshot.Evaluations[0].ID)
+	must.Len(t, 1, resp.Snapshot.Deployments)
+	must.Eq(t, d.ID, resp.Snapshot.Deployments[0].ID)
+	must.Len(t, 1, resp.Snapshot.Allocations)
+	must.Eq(t, alloc.ID, resp.Snapshot.Allocations[0].ID)
+
+	// A missing job returns no job
+	get.JobID = uuid.Generate()
+	get.AuthToken = root.SecretID
+	var missingResp structs.JobSnapshotResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Snapshot", get, &missingResp))
+	must.Nil(t, missingResp.Snapshot.Job)
+	must.SliceEmpty(t, missingResp.Snapshot.Allocations)
+}
+
+func TestJobEndpoint_Snapshot_Blocking(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	j := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, j))
+
+	alloc := mock.Alloc()
+	alloc.JobID = j.ID
+	alloc.Job = j
+	time.AfterFunc(100*time.Millisecond, func() {
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+			[]*structs.Allocation{alloc}))
+	})
+
+	get := &structs.JobSpecificRequest{
+		JobID: j.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:        "global",
+			Namespace:     j.Namespace,
+			MinQueryIndex: 1000,
+		},
+	}
+	var resp structs.JobSnapshotResponse
+	start := time.Now()
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Snapshot", get, &resp))
+	must.GreaterEq(t, 100*time.Millisecond, time.Since(start))
+
+	must.Eq(t, 1001, resp.Index)
+	must.Len(t, 1, resp.Snapshot.Allocations)
+	must.Eq(t, alloc.ID, resp.Snapshot.Allocations[0].ID)
+}
+
 func TestJobEndpoint_Deployments_Blocking(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6022,6 +6784,108 @@ func TestJobEndpoint_Plan_ACL(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Register_Queued(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.JobRegisterConcurrency = 1
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Hold the only registration slot
+	release, err := s1.jobRegisterQueue.Acquire(context.Background(), "other")
+	must.NoError(t, err)
+
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		var resp structs.JobRegisterResponse
+		errCh <- msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	}()
+
+	// The registration waits for the slot to be released
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		return s1.jobRegisterQueue.Stats().Depth == 1
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	release()
+	must.NoError(t, <-errCh)
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, JobRegisterQueueStats{}, s1.jobRegisterQueue.Stats())
+}
+
+func TestJobEndpoint_Drift(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	driftReq := &structs.JobDriftRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// A job that is not registered has always drifted
+	var driftResp structs.JobDriftResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Drift", driftReq, &driftResp))
+	must.True(t, driftResp.Drifted)
+	must.NotEq(t, "", driftResp.Hash)
+	must.Eq(t, "", driftResp.RegisteredHash)
+	must.NotNil(t, driftResp.Job)
+	unregisteredHash := driftResp.Hash
+
+	// Register the job
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// The same specification has not drifted from the registered job
+	driftResp = structs.JobDriftResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Drift", driftReq, &driftResp))
+	must.False(t, driftResp.Drifted)
+	must.Eq(t, unregisteredHash, driftResp.Hash)
+	must.Eq(t, driftResp.Hash, driftResp.RegisteredHash)
+	must.Eq(t, 0, driftResp.RegisteredVersion)
+	must.Positive(t, driftResp.Index)
+
+	// A changed specification has drifted
+	changed := job.Copy()
+	changed.TaskGroups[0].Count++
+	driftReq.Job = changed
+	driftResp = structs.JobDriftResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Drift", driftReq, &driftResp))
+	must.True(t, driftResp.Drifted)
+	must.NotEq(t, driftResp.Hash, driftResp.RegisteredHash)
+	must.Eq(t, unregisteredHash, driftResp.RegisteredHash)
+}
+
 func TestJobEndpoint_Plan_WithDiff(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6232,6 +7096,54 @@ func TestJobEndpoint_ImplicitConstraints_Vault(t *testing.T) {
 	})
 }
 
+func TestJobEndpoint_Validate_Lint(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	req := &structs.JobValidateRequest{
+		Job:  job,
+		Lint: true,
+		LintRules: []*structs.JobLintRule{{
+			Name:     "owner",
+			Severity: structs.JobLintSeverityError,
+			Filter:   `Meta.owner != "ops"`,
+			Message:  "job must be owned by ops",
+		}},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.JobValidateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Validate", req, &resp))
+	must.Eq(t, "", resp.Error)
+	must.Len(t, 2, resp.LintFindings)
+	must.Eq(t, "missing-health-checks", resp.LintFindings[0].Rule)
+	must.Eq(t, &structs.JobLintFinding{
+		Rule:     "owner",
+		Severity: structs.JobLintSeverityError,
+		Message:  "job must be owned by ops",
+	}, resp.LintFindings[1])
+
+	// Findings are only returned when linting is requested
+	req.Lint = false
+	resp = structs.JobValidateResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Validate", req, &resp))
+	must.SliceEmpty(t, resp.LintFindings)
+
+	// Invalid rules are rejected
+	req.Lint = true
+	req.LintRules[0].Filter = "Meta.owner =="
+	err := msgpackrpc.CallWithCodec(codec, "Job.Validate", req, &resp)
+	must.ErrorContains(t, err, `lint rule "owner" is invalid`)
+}
+
 func TestJobEndpoint_ValidateJob_ConsulConnect(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6637,6 +7549,18 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 	d7.ParameterizedJob = &structs.ParameterizedJobConfig{}
 	d7.Stop = true
 
+	// Schema for meta and input data
+	d8 := mock.BatchJob()
+	d8.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MetaRequired: []string{"foo"},
+		MetaOptional: []string{"bar"},
+		MetaSchema: []*structs.DispatchMetaSchema{
+			{Name: "foo", Type: structs.DispatchMetaTypeInteger},
+			{Name: "bar", Type: structs.DispatchMetaTypeString, Enum: []string{"f1", "f2"}},
+		},
+		PayloadSchema: `{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}`,
+	}
+
 	reqNoInputNoMeta := &structs.JobDispatchRequest{}
 	reqInputDataNoMeta := &structs.JobDispatchRequest{
 		Payload: []byte("hello world"),
@@ -6662,6 +7586,20 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 			"baz": "f3",
 		},
 	}
+	reqSchemaMatch := &structs.JobDispatchRequest{
+		Payload: []byte(`{"name": "hello world"}`),
+		Meta: map[string]string{
+			"foo": "1",
+			"bar": "f2",
+		},
+	}
+	reqSchemaMismatch := &structs.JobDispatchRequest{
+		Payload: []byte(`{"name": 1}`),
+		Meta: map[string]string{
+			"foo": "f1",
+			"bar": "f3",
+		},
+	}
 	reqInputDataTooLarge := &structs.JobDispatchRequest{
 		Payload: make([]byte, DispatchPayloadSizeLimit+100),
 	}
@@ -6758,6 +7696,26 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 			err:              true,
 			errStr:           "Payload exceeds maximum size",
 		},
+		{
+			name:             "schema w/ matching input",
+			parameterizedJob: d8,
+			dispatchReq:      reqSchemaMatch,
+			err:              false,
+		},
+		{
+			name:             "schema w/ mismatched input",
+			parameterizedJob: d8,
+			dispatchReq:      reqSchemaMismatch,
+			err:              true,
+			errStr:           "payload.name: expected string, got number",
+		},
+		{
+			name:             "schema w/ non-JSON input",
+			parameterizedJob: d8,
+			dispatchReq:      reqInputDataMeta,
+			err:              true,
+			errStr:           "payload: invalid JSON",
+		},
 		{
 			name:             "periodic job dispatched, ensure no eval",
 			parameterizedJob: d6,
@@ -7662,6 +8620,108 @@ func TestJobEndpoint_Scale_OutOfBounds(t *testing.T) {
 	require.Contains(err.Error(), "group count was less than scaling policy minimum: 2 < 3")
 }
 
+func TestJobEndpoint_Scale_RateGuards(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job, pol := mock.JobWithScalingPolicy()
+	pol.Min = 1
+	pol.Max = 20
+	pol.MaxStep = 2
+	pol.Cooldown = time.Hour
+	job.TaskGroups[0].Count = 5
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	var resp structs.JobRegisterResponse
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: pointer.Of(int64(8)),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Changes larger than the max step are rejected in both directions
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	must.ErrorContains(t, err, "group count change was greater than scaling policy max step: 3 > 2")
+	scale.Count = pointer.Of(int64(2))
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	must.ErrorContains(t, err, "group count change was greater than scaling policy max step: 3 > 2")
+
+	scale.Count = pointer.Of(int64(7))
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+
+	// The next change is blocked until the cooldown passed, but requests
+	// that do not change the count are not
+	scale.Count = pointer.Of(int64(6))
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	must.ErrorContains(t, err, "job scaling blocked by scaling policy cooldown")
+
+	scale.Count = pointer.Of(int64(7))
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+
+	scale.Count = nil
+	scale.Message = "informational"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+}
+
+func TestJobEndpoint_Scale_DryRun(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	originalCount := job.TaskGroups[0].Count
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count:          pointer.Of(int64(originalCount + 1)),
+		Source:         "autoscaler-1",
+		MetricSnapshot: map[string]float64{"cpu": 92.5},
+		DryRun:         true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	must.Eq(t, "", resp.EvalID)
+
+	// The job is left untouched
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, originalCount, out.TaskGroups[0].Count)
+	must.Eq(t, job.ModifyIndex, out.ModifyIndex)
+
+	// The event is recorded with its annotations
+	events, _, err := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	groupEvents := events[job.TaskGroups[0].Name]
+	must.Len(t, 1, groupEvents)
+	must.True(t, groupEvents[0].DryRun)
+	must.Eq(t, "autoscaler-1", groupEvents[0].Source)
+	must.Eq(t, map[string]float64{"cpu": 92.5}, groupEvents[0].MetricSnapshot)
+	must.Nil(t, groupEvents[0].EvalID)
+}
+
 func TestJobEndpoint_Scale_NoEval(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
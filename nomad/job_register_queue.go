@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper"
+)
+
+// JobRegisterQueue limits the number of job registrations the leader
+// processes concurrently, so bursts of registrations, such as CI pipelines
+// submitting hundreds of jobs at once, do not contend with scheduling.
+// Registrations waiting for a slot are admitted round robin across the
+// identities that submitted them, so a burst from one token does not delay
+// the registrations of others.
+type JobRegisterQueue struct {
+	// concurrency is the maximum number of registrations processed at the
+	// same time. The queue is disabled if it is zero.
+	concurrency int
+	active      int
+
+	// waiting holds the registrations waiting for a slot per identity, in
+	// submission order, and order is the round robin order of the
+	// identities with waiting registrations.
+	waiting map[string][]*jobRegisterWaiter
+	order   []string
+	depth   int
+
+	l sync.Mutex
+}
+
+// jobRegisterWaiter is a registration waiting for a slot. ready is closed
+// once the registration is admitted.
+type jobRegisterWaiter struct {
+	ready chan struct{}
+}
+
+// JobRegisterQueueStats are the stats of the job registration queue.
+type JobRegisterQueueStats struct {
+	Active int
+	Depth  int
+}
+
+// NewJobRegisterQueue returns a job registration queue processing at most
+// concurrency registrations at the same time. A concurrency of zero disables
+// the queue.
+func NewJobRegisterQueue(concurrency int) *JobRegisterQueue {
+	return &JobRegisterQueue{
+		concurrency: concurrency,
+		waiting:     make(map[string][]*jobRegisterWaiter),
+	}
+}
+
+// Acquire blocks until a registration submitted by the given identity is
+// admitted or the context is done. The returned function must be called once
+// the registration has been processed to release its slot.
+func (q *JobRegisterQueue) Acquire(ctx context.Context, identity string) (func(), error) {
+	q.l.Lock()
+	if q.concurrency <= 0 {
+		q.l.Unlock()
+		return func() {}, nil
+	}
+	if q.depth == 0 && q.active < q.concurrency {
+		q.active++
+		q.l.Unlock()
+		return q.release, nil
+	}
+
+	w := &jobRegisterWaiter{ready: make(chan struct{})}
+	if _, ok := q.waiting[identity]; !ok {
+		q.order = append(q.order, identity)
+	}
+	q.waiting[identity] = append(q.waiting[identity], w)
+	q.depth++
+	q.l.Unlock()
+
+	start := time.Now()
+	select {
+	case <-w.ready:
+		metrics.MeasureSince([]string{"nomad", "job", "register_queue", "wait"}, start)
+		return q.release, nil
+	case <-ctx.Done():
+	}
+
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	// The registration may have been admitted while the context was done,
+	// in which case its slot is handed over to the next one.
+	select {
+	case <-w.ready:
+		q.active--
+		q.admitLocked()
+		return nil, ctx.Err()
+	default:
+	}
+
+	q.waiting[identity] = slices.DeleteFunc(q.waiting[identity], func(o *jobRegisterWaiter) bool {
+		return o == w
+	})
+	if len(q.waiting[identity]) == 0 {
+		delete(q.waiting, identity)
+		q.order = slices.DeleteFunc(q.order, func(o string) bool { return o == identity })
+	}
+	q.depth--
+	return nil, ctx.Err()
+}
+
+// release frees the slot of a registration and admits the next ones.
+func (q *JobRegisterQueue) release() {
+	q.l.Lock()
+	defer q.l.Unlock()
+	q.active--
+	q.admitLocked()
+}
+
+// admitLocked admits waiting registrations while there are free slots, taking
+// the oldest registration of each identity in turn. The lock must be held by
+// the caller.
+func (q *JobRegisterQueue) admitLocked() {
+	for q.active < q.concurrency && len(q.order) > 0 {
+		identity := q.order[0]
+		q.order = q.order[1:]
+
+		waiters := q.waiting[identity]
+		w := waiters[0]
+		if len(waiters) > 1 {
+			q.waiting[identity] = waiters[1:]
+			q.order = append(q.order, identity)
+		} else {
+			delete(q.waiting, identity)
+		}
+
+		q.depth--
+		q.active++
+		close(w.ready)
+	}
+}
+
+// Stats returns the number of registrations being processed and waiting.
+func (q *JobRegisterQueue) Stats() JobRegisterQueueStats {
+	q.l.Lock()
+	defer q.l.Unlock()
+	return JobRegisterQueueStats{
+		Active: q.active,
+		Depth:  q.depth,
+	}
+}
+
+// EmitStats is used to export metrics about the queue
+func (q *JobRegisterQueue) EmitStats(period time.Duration, stopCh <-chan struct{}) {
+	timer, stop := helper.NewSafeTimer(period)
+	defer stop()
+
+	for {
+		timer.Reset(period)
+
+		select {
+		case <-timer.C:
+			stats := q.Stats()
+			metrics.SetGauge([]string{"nomad", "job", "register_queue", "active"}, float32(stats.Active))
+			metrics.SetGauge([]string{"nomad", "job", "register_queue", "depth"}, float32(stats.Depth))
+		case <-stopCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestJobRegisterQueue_Disabled(t *testing.T) {
+	ci.Parallel(t)
+	q := NewJobRegisterQueue(0)
+
+	for i := 0; i < 10; i++ {
+		_, err := q.Acquire(context.Background(), "a")
+		must.NoError(t, err)
+	}
+	must.Eq(t, JobRegisterQueueStats{}, q.Stats())
+}
+
+func TestJobRegisterQueue_Fairness(t *testing.T) {
+	ci.Parallel(t)
+	q := NewJobRegisterQueue(1)
+
+	release, err := q.Acquire(context.Background(), "a")
+	must.NoError(t, err)
+
+	// Queue a burst from one identity before a single registration from
+	// another, waiting for each to be queued so the order is deterministic.
+	admitted := make(chan string, 4)
+	enqueue := func(identity string) {
+		depth := q.Stats().Depth
+		go func() {
+			release, err := q.Acquire(context.Background(), identity)
+			must.NoError(t, err)
+			admitted <- identity
+			release()
+		}()
+		must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+			return q.Stats().Depth == depth+1
+		}), wait.Timeout(5*time.Second), wait.Gap(time.Millisecond)))
+	}
+	enqueue("a")
+	enqueue("a")
+	enqueue("a")
+	enqueue("b")
+	must.Eq(t, JobRegisterQueueStats{Active: 1, Depth: 4}, q.Stats())
+
+	// The other identity is admitted right after the first registration of
+	// the burst instead of waiting for the whole burst.
+	release()
+	order := make([]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		order = append(order, <-admitted)
+	}
+	must.Eq(t, []string{"a", "b", "a", "a"}, order)
+	must.Eq(t, JobRegisterQueueStats{}, q.Stats())
+}
+
+func TestJobRegisterQueue_Cancel(t *testing.T) {
+	ci.Parallel(t)
+	q := NewJobRegisterQueue(1)
+
+	release, err := q.Acquire(context.Background(), "a")
+	must.NoError(t, err)
+
+	// A registration that gives up waiting is removed from the queue
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = q.Acquire(ctx, "b")
+	must.ErrorIs(t, err, context.DeadlineExceeded)
+	must.Eq(t, JobRegisterQueueStats{Active: 1}, q.Stats())
+
+	release()
+	must.Eq(t, JobRegisterQueueStats{}, q.Stats())
+
+	release, err = q.Acquire(context.Background(), "b")
+	must.NoError(t, err)
+	release()
+}
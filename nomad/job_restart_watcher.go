@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobRestartWatchInterval is the interval at which the leader checks the
+// batches of running job restarts.
+const jobRestartWatchInterval = 10 * time.Second
+
+// watchJobRestarts is a long lived function run on the leader that pauses
+// the job restarts whose current batch failed, so that a failed batch halts
+// the restart even if the CLI driving it is no longer connected. It stops
+// once stopCh is closed.
+func (s *Server) watchJobRestarts(stopCh chan struct{}) {
+	timer, stop := helper.NewSafeTimer(jobRestartWatchInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+		}
+
+		if err := s.pauseFailedJobRestarts(time.Now()); err != nil {
+			s.logger.Error("failed to check job restarts", "error", err)
+		}
+		timer.Reset(jobRestartWatchInterval)
+	}
+}
+
+// pauseFailedJobRestarts pauses the running job restarts whose current batch
+// failed at the given time.
+func (s *Server) pauseFailedJobRestarts(now time.Time) error {
+	defer metrics.MeasureSince([]string{"nomad", "leader", "pause_failed_job_restarts"}, now)
+
+	// Restarts can't be paused until all servers can apply the update, in
+	// case a server was downgraded since the restart started.
+	if !ServersMeetMinimumVersion(s.Members(), s.Region(), minJobRestartVersion, true) {
+		return nil
+	}
+
+	store := s.State()
+	iter, err := store.JobRestarts(nil)
+	if err != nil {
+		return err
+	}
+
+	var paused []*structs.JobRestart
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		restart := raw.(*structs.JobRestart)
+		if restart.Status != structs.JobRestartStatusRunning {
+			continue
+		}
+
+		reason, err := jobRestartBatchFailure(store, restart, now)
+		if err != nil {
+			return fmt.Errorf("failed to check restart of job %q in namespace %q: %w",
+				restart.JobID, restart.Namespace, err)
+		}
+		if reason == "" {
+			continue
+		}
+
+		restart = restart.Copy()
+		restart.Status = structs.JobRestartStatusPaused
+		restart.StatusDescription = reason
+		restart.ModifyTime = now.UnixNano()
+		paused = append(paused, restart)
+	}
+
+	for _, restart := range paused {
+		req := &structs.JobRestartUpsertRequest{
+			Restart:      restart,
+			WriteRequest: structs.WriteRequest{Region: s.Region()},
+		}
+		if _, _, err := s.raftApply(structs.JobRestartUpsertRequestType, req); err != nil {
+			return err
+		}
+		s.logger.Info("paused job restart", "job_id", restart.JobID,
+			"namespace", restart.Namespace, "reason", restart.StatusDescription)
+	}
+	return nil
+}
+
+// jobRestartBatchFailure returns the reason the current batch of the restart
+// failed at the given time, or an empty string if it has not failed.
+//
+// A batch fails as soon as one of its allocations, or their replacements,
+// fails or is lost, or has a failed task. It also fails if the allocations
+// are not running all their tasks once the healthy_deadline of their group's
+// update block has passed since the batch was submitted. Checking the health
+// of the tasks in more detail is left to the CLI, since it involves comparing
+// timestamps recorded by the clients.
+func jobRestartBatchFailure(store *state.StateStore, restart *structs.JobRestart, now time.Time) (string, error) {
+	for _, allocID := range restart.Batch {
+		alloc, err := store.AllocByID(nil, allocID)
+		if err != nil {
+			return "", err
+		}
+
+		// Follow the replacements of the allocation, since it is expected to
+		// be replaced if the restart reschedules allocations.
+		for alloc != nil && alloc.NextAllocation != "" {
+			next, err := store.AllocByID(nil, alloc.NextAllocation)
+			if err != nil {
+				return "", err
+			}
+			if next == nil {
+				break
+			}
+			alloc = next
+		}
+		if alloc == nil {
+			// The allocation was garbage collected, so there is nothing left
+			// to check.
+			continue
+		}
+
+		switch alloc.ClientStatus {
+		case structs.AllocClientStatusFailed, structs.AllocClientStatusLost:
+			return fmt.Sprintf("Allocation %q is %s", alloc.ID, alloc.ClientStatus), nil
+		}
+		for name, ts := range alloc.TaskStates {
+			if ts.Failed && ts.State == structs.TaskStateDead {
+				return fmt.Sprintf("Task %q in allocation %q failed", name, alloc.ID), nil
+			}
+		}
+
+		deadline := structs.DefaultUpdateStrategy.HealthyDeadline
+		if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil && tg.Update != nil {
+			deadline = tg.Update.HealthyDeadline
+		}
+		if now.Before(time.Unix(0, restart.BatchStartTime).Add(deadline)) {
+			continue
+		}
+
+		if restart.Reschedule && alloc.ID == allocID {
+			return fmt.Sprintf("Allocation %q was not replaced within %v", allocID, deadline), nil
+		}
+		if alloc.ClientStatus != structs.AllocClientStatusRunning {
+			return fmt.Sprintf("Allocation %q is not running after %v", alloc.ID, deadline), nil
+		}
+		for name, ts := range alloc.TaskStates {
+			// Lifecycle tasks that already ran successfully are not expected
+			// to be running.
+			if ts.State != structs.TaskStateRunning && !(ts.State == structs.TaskStateDead && !ts.Failed) {
+				return fmt.Sprintf("Task %q in allocation %q is not running after %v", name, alloc.ID, deadline), nil
+			}
+		}
+	}
+	return "", nil
+}
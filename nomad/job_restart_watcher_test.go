@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobRestartWatcher_jobRestartBatchFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	now := time.Now()
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.HealthyDeadline = time.Minute
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	newAlloc := func(clientStatus string, ts *structs.TaskState) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = clientStatus
+		alloc.TaskStates = map[string]*structs.TaskState{"web": ts}
+		return alloc
+	}
+
+	running := newAlloc(structs.AllocClientStatusRunning,
+		&structs.TaskState{State: structs.TaskStateRunning})
+	pending := newAlloc(structs.AllocClientStatusRunning,
+		&structs.TaskState{State: structs.TaskStatePending})
+	taskFailed := newAlloc(structs.AllocClientStatusRunning,
+		&structs.TaskState{State: structs.TaskStateDead, Failed: true})
+	lost := newAlloc(structs.AllocClientStatusLost,
+		&structs.TaskState{State: structs.TaskStateRunning})
+
+	// Rescheduled allocations are followed to their replacement
+	stopped := newAlloc(structs.AllocClientStatusComplete,
+		&structs.TaskState{State: structs.TaskStateDead})
+	replacement := newAlloc(structs.AllocClientStatusFailed,
+		&structs.TaskState{State: structs.TaskStateDead, Failed: true})
+	stopped.NextAllocation = replacement.ID
+	notReplaced := newAlloc(structs.AllocClientStatusComplete,
+		&structs.TaskState{State: structs.TaskStateDead})
+
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{
+		running, pending, taskFailed, lost, stopped, replacement, notReplaced,
+	}))
+
+	testCases := []struct {
+		name       string
+		batch      []string
+		reschedule bool
+		elapsed    time.Duration
+		expected   string
+	}{
+		{
+			name:    "running",
+			batch:   []string{running.ID},
+			elapsed: 2 * time.Minute,
+		},
+		{
+			name:    "pending before deadline",
+			batch:   []string{running.ID, pending.ID},
+			elapsed: 30 * time.Second,
+		},
+		{
+			name:     "pending after deadline",
+			batch:    []string{running.ID, pending.ID},
+			elapsed:  2 * time.Minute,
+			expected: "is not running after 1m0s",
+		},
+		{
+			name:     "task failed",
+			batch:    []string{taskFailed.ID},
+			expected: "failed",
+		},
+		{
+			name:     "allocation lost",
+			batch:    []string{lost.ID},
+			expected: "is lost",
+		},
+		{
+			name:       "replacement failed",
+			batch:      []string{stopped.ID},
+			reschedule: true,
+			expected:   replacement.ID,
+		},
+		{
+			name:       "not replaced before deadline",
+			batch:      []string{notReplaced.ID},
+			reschedule: true,
+		},
+		{
+			name:       "not replaced after deadline",
+			batch:      []string{notReplaced.ID},
+			reschedule: true,
+			elapsed:    2 * time.Minute,
+			expected:   "was not replaced within 1m0s",
+		},
+		{
+			name:  "garbage collected",
+			batch: []string{uuid.Generate()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restart := &structs.JobRestart{
+				Namespace:      job.Namespace,
+				JobID:          job.ID,
+				Status:         structs.JobRestartStatusRunning,
+				Reschedule:     tc.reschedule,
+				Batch:          tc.batch,
+				BatchStartTime: now.Add(-tc.elapsed).UnixNano(),
+			}
+			reason, err := jobRestartBatchFailure(store, restart, now)
+			must.NoError(t, err)
+			if tc.expected == "" {
+				must.Eq(t, "", reason)
+			} else {
+				must.StrContains(t, reason, tc.expected)
+			}
+		})
+	}
+}
+
+func TestJobRestartWatcher_pauseFailedJobRestarts(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	failedJob := mock.Job()
+	healthyJob := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, failedJob))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, healthyJob))
+
+	failed := mock.Alloc()
+	failed.Job = failedJob
+	failed.JobID = failedJob.ID
+	failed.ClientStatus = structs.AllocClientStatusFailed
+	healthy := mock.Alloc()
+	healthy.Job = healthyJob
+	healthy.JobID = healthyJob.ID
+	healthy.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Allocation{failed, healthy}))
+
+	for i, alloc := range []*structs.Allocation{failed, healthy} {
+		restart := &structs.JobRestart{
+			ID:             uuid.Generate(),
+			Namespace:      alloc.Namespace,
+			JobID:          alloc.JobID,
+			Status:         structs.JobRestartStatusRunning,
+			Batch:          []string{alloc.ID},
+			BatchStartTime: time.Now().UnixNano(),
+		}
+		must.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, uint64(1003+i), restart))
+	}
+
+	must.NoError(t, s1.pauseFailedJobRestarts(time.Now()))
+
+	out, err := store.JobRestartByJob(nil, failedJob.Namespace, failedJob.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobRestartStatusPaused, out.Status)
+	must.StrContains(t, out.StatusDescription, failed.ID)
+
+	out, err = store.JobRestartByJob(nil, healthyJob.Namespace, healthyJob.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobRestartStatusRunning, out.Status)
+	must.Eq(t, 1004, out.ModifyIndex)
+}
+
+func TestJobRestartWatcher_MinVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Build = "1.9.6"
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.ClientStatus = structs.AllocClientStatusFailed
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	// Restarts can't be started until all servers support them
+	req := &structs.JobRestartRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRestartResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Restart", req, &resp)
+	must.ErrorContains(t, err, "all servers must be running version 1.9.7 or later")
+
+	// Restarts that were already running are left alone
+	restart := &structs.JobRestart{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		JobID:          job.ID,
+		Status:         structs.JobRestartStatusRunning,
+		Batch:          []string{alloc.ID},
+		BatchStartTime: time.Now().UnixNano(),
+	}
+	must.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 1002, restart))
+	must.NoError(t, s1.pauseFailedJobRestarts(time.Now()))
+
+	out, err := store.JobRestartByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobRestartStatusRunning, out.Status)
+}
@@ -431,6 +431,66 @@ func (k *Keyring) ListPublic(args *structs.GenericRequest, reply *structs.Keyrin
 	return k.srv.blockingRPC(&opts)
 }
 
+// Health returns whether the keyring of the server is ready and the health of
+// the KEK providers it uses. Stale requests are answered by the server that
+// receives them, as each server decrypts the root keys on its own.
+func (k *Keyring) Health(args *structs.GenericRequest, reply *structs.KeyringHealthResponse) error {
+
+	authErr := k.srv.Authenticate(k.ctx, args)
+	if done, err := k.srv.forward("Keyring.Health", args, args, reply); done {
+		return err
+	}
+	k.srv.MeasureRPCRate("keyring", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "health"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.PendingKeys = k.encrypter.PendingKeys()
+	reply.Ready = len(reply.PendingKeys) == 0
+	reply.Providers = k.encrypter.ProviderHealth()
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// Ready reports whether the keyring can be used to sign and encrypt. Stale
+// requests are answered by the local server, so they can be used as a
+// readiness check for each server.
+func (k *Keyring) Ready(args *structs.GenericRequest, reply *structs.KeyringReadyResponse) error {
+
+	authErr := k.srv.Authenticate(k.ctx, args)
+	if done, err := k.srv.forward("Keyring.Ready", args, args, reply); done {
+		return err
+	}
+	k.srv.MeasureRPCRate("keyring", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "ready"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := k.encrypter.Ready(); err != nil {
+		reply.Error = err.Error()
+	} else {
+		reply.Ready = true
+	}
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // GetConfig for workload identities. This RPC is used to back an OIDC
 // Discovery endpoint.
 //
@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -400,6 +401,103 @@ func TestKeyringEndpoint_ListPublic(t *testing.T) {
 	must.True(t, found, must.Sprint("original public key missing after rotation"))
 }
 
+func TestKeyringEndpoint_Health(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+
+	// Requires a management token
+	var resp structs.KeyringHealthResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Health", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	policy := mock.NamespacePolicy("default", "", []string{"list-jobs"})
+	token := mock.CreatePolicyAndToken(t, srv.fsm.State(), 1000, "not-management", policy)
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Health", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = rootToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Health", req, &resp))
+	must.True(t, resp.Ready)
+	must.SliceEmpty(t, resp.PendingKeys)
+	must.Len(t, 1, resp.Providers)
+	must.Eq(t, "aead", resp.Providers[0].ID)
+	must.True(t, resp.Providers[0].Healthy)
+
+	// Failures are reported
+	srv.encrypter.setProviderHealth(
+		&structs.KEKProviderConfig{Provider: "gcpckms", Active: true},
+		errors.New("permission denied on resource"))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Health", req, &resp))
+	must.Len(t, 2, resp.Providers)
+	must.Eq(t, "gcpckms", resp.Providers[1].ID)
+	must.False(t, resp.Providers[1].Healthy)
+	must.Eq(t, "permission denied on resource", resp.Providers[1].Error)
+}
+
+func TestKeyringEndpoint_Ready(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     "global",
+			AllowStale: true,
+		},
+	}
+
+	// Requires operator:read
+	var resp structs.KeyringReadyResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Ready", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	policy := mock.NamespacePolicy("default", "", []string{"list-jobs"})
+	token := mock.CreatePolicyAndToken(t, srv.fsm.State(), 1000, "not-operator", policy)
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Ready", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	token = mock.CreatePolicyAndToken(t, srv.fsm.State(), 1001, "operator-read", `operator { policy = "read" }`)
+	req.AuthToken = token.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Ready", req, &resp))
+	must.True(t, resp.Ready)
+	must.Eq(t, "", resp.Error)
+
+	// Pending keys are reported
+	srv.encrypter.lock.Lock()
+	srv.encrypter.decryptTasks["pending"] = func() {}
+	srv.encrypter.lock.Unlock()
+	t.Cleanup(func() {
+		srv.encrypter.lock.Lock()
+		delete(srv.encrypter.decryptTasks, "pending")
+		srv.encrypter.lock.Unlock()
+	})
+
+	req.AuthToken = rootToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Ready", req, &resp))
+	must.False(t, resp.Ready)
+	must.Eq(t, "keyring is not ready - waiting for keys pending", resp.Error)
+}
+
 // TestKeyringEndpoint_GetConfig_Issuer asserts that GetConfig returns OIDC
 // Discovery Configuration if an issuer is configured.
 func TestKeyringEndpoint_GetConfig_Issuer(t *testing.T) {
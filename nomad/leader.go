@@ -40,6 +40,10 @@ const (
 	// possible loss of leadership event if we are unable to get a barrier
 	// while leader.
 	barrierWriteTimeout = 2 * time.Minute
+
+	// keyringReadyInterval is the interval at which the leader checks whether
+	// the keyring is ready, while evaluations are held waiting for it.
+	keyringReadyInterval = 100 * time.Millisecond
 )
 
 var minAutopilotVersion = version.Must(version.NewVersion("0.8.0"))
@@ -84,6 +88,28 @@ var minNodePoolsVersion = version.Must(version.NewVersion("1.6.0"))
 // automatically added to jobs that need access to Consul or Vault
 var minVersionMultiIdentities = version.Must(version.NewVersion("1.7.0"))
 
+// minJobFreezeVersion is the Nomad version in which jobs can be frozen. Older
+// servers can't apply the freeze Raft log.
+var minJobFreezeVersion = version.Must(version.NewVersion("1.9.7"))
+
+// minJobRestartVersion is the Nomad version in which the servers track job
+// restarts. Older servers can't apply the job restart Raft log.
+var minJobRestartVersion = version.Must(version.NewVersion("1.9.7"))
+
+// minNodeLifecycleVersion is the Nomad version in which the leader cycles the
+// nodes of node pools. Older servers can't apply the node lifecycle run Raft
+// log.
+var minNodeLifecycleVersion = version.Must(version.NewVersion("1.9.7"))
+
+// minTaskResultVersion is the Nomad version in which tasks can register their
+// result. Older servers can't apply the task result Raft log.
+var minTaskResultVersion = version.Must(version.NewVersion("1.9.7"))
+
+// minAllocIdentityRenewVersion is the Nomad version in which the leader renews
+// the workload identities of allocations. Older servers can't apply the
+// identity update Raft log.
+var minAllocIdentityRenewVersion = version.Must(version.NewVersion("1.9.7"))
+
 // monitorLeadership is used to monitor if we acquire or lose our role
 // as the leader in the Raft cluster. There is some work the leader is
 // expected to do, so we must react to changes
@@ -418,6 +444,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Create the first root key if it doesn't already exist
 	go s.initializeKeyring(stopCh)
 
+	// Wake up the schedulers once the keyring is ready, if it isn't already
+	go s.waitForKeyringReady(stopCh)
+
 	// Restore the periodic dispatcher state
 	if err := s.restorePeriodicDispatcher(); err != nil {
 		return err
@@ -445,6 +474,21 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Keep the count of scale_with_nodes groups in line with the nodes
+	go s.scaleGroupsWithNodes(stopCh)
+
+	// Re-sign default workload identities before they expire
+	go s.renewAllocIdentities(stopCh)
+
+	// Periodically upload snapshots of the state to object storage
+	go s.uploadSnapshots(stopCh)
+
+	// Pause job restarts whose current batch failed
+	go s.watchJobRestarts(stopCh)
+
+	// Cycle the nodes of running node lifecycle runs
+	go s.runNodeLifecycles(stopCh)
+
 	// Populate the variable lock TTL timers, so we can start tracking renewals
 	// and expirations.
 	if err := s.restoreLockTTLTimers(); err != nil {
@@ -487,11 +531,17 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 			go s.replicateACLRoles(stopCh)
 			go s.replicateACLAuthMethods(stopCh)
 			go s.replicateACLBindingRules(stopCh)
-			go s.replicateNamespaces(stopCh)
-			go s.replicateNodePools(stopCh)
 		}
 	}
 
+	// Namespaces and node pools are replicated from the authoritative region
+	// whether ACLs are enabled or not, so that tenancy configuration only has
+	// to be applied once in a federated cluster.
+	if s.config.AuthoritativeRegion != "" && s.config.AuthoritativeRegion != s.config.Region {
+		go s.replicateNamespaces(stopCh)
+		go s.replicateNodePools(stopCh)
+	}
+
 	// Setup any enterprise systems required.
 	if err := s.establishEnterpriseLeadership(stopCh, clusterMetadata); err != nil {
 		return err
@@ -540,6 +590,15 @@ START:
 		// Perform a two-way diff
 		delete, update := diffNamespaces(s.State(), req.MinQueryIndex, resp.Namespaces)
 
+		// Namespaces still in use in this region can't be deleted, so they
+		// are reported as conflicts until they are no longer used.
+		delete, conflicts := replicationDeletes(structs.ReplicationConflictNamespace,
+			delete, s.State().NamespaceInUse)
+		for _, c := range conflicts {
+			s.logger.Warn("unable to replicate namespace deletion", "namespace", c.Name, "reason", c.Reason)
+		}
+		s.replicationConflicts.set(structs.ReplicationConflictNamespace, conflicts)
+
 		// Delete namespaces that should not exist
 		if len(delete) > 0 {
 			args := &structs.NamespaceDeleteRequest{
@@ -587,7 +646,13 @@ START:
 		}
 
 		// Update the minimum query index, blocks until there is a change.
+		// While there are conflicts, only block for the replication backoff
+		// so the deletions are retried once the namespaces are unused.
 		req.MinQueryIndex = resp.Index
+		req.MaxQueryTime = 0
+		if len(conflicts) > 0 {
+			req.MaxQueryTime = s.config.ReplicationBackoff
+		}
 	}
 
 ERR_WAIT:
@@ -703,6 +768,15 @@ func (s *Server) replicateNodePools(stopCh chan struct{}) {
 		// Perform a two-way diff
 		delete, update := diffNodePools(s.State(), req.MinQueryIndex, resp.NodePools)
 
+		// Node pools still in use in this region can't be deleted, so they
+		// are reported as conflicts until they are no longer used.
+		delete, conflicts := replicationDeletes(structs.ReplicationConflictNodePool,
+			delete, s.State().NodePoolInUse)
+		for _, c := range conflicts {
+			s.logger.Warn("unable to replicate node pool deletion", "node_pool", c.Name, "reason", c.Reason)
+		}
+		s.replicationConflicts.set(structs.ReplicationConflictNodePool, conflicts)
+
 		// A significant amount of time could pass between the last check
 		// on whether we should stop the replication process. Therefore, do
 		// a check here, before calling Raft.
@@ -745,7 +819,13 @@ func (s *Server) replicateNodePools(stopCh chan struct{}) {
 		}
 
 		// Update the minimum query index, blocks until there is a change.
+		// While there are conflicts, only block for the replication backoff
+		// so the deletions are retried once the node pools are unused.
 		req.MinQueryIndex = resp.Index
+		req.MaxQueryTime = 0
+		if len(conflicts) > 0 {
+			req.MaxQueryTime = s.config.ReplicationBackoff
+		}
 	}
 }
 
@@ -937,7 +1017,7 @@ func (s *Server) restorePeriodicDispatcher() error {
 			continue
 		}
 
-		if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID); err != nil {
+		if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID, nil, nil); err != nil {
 			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
 			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
 		}
@@ -1457,6 +1537,9 @@ func (s *Server) revokeLeadership() error {
 	// Disable the volume watcher
 	s.volumeWatcher.SetEnabled(false, nil, "")
 
+	// Clear the replication conflicts, the next leader detects them again
+	s.replicationConflicts.reset()
+
 	// Disable any enterprise systems required.
 	if err := s.revokeEnterpriseLeadership(); err != nil {
 		return err
@@ -1598,6 +1681,13 @@ func (s *Server) addRaftPeer(m serf.Member, parts *serverParts) error {
 		}
 	}
 
+	// Read replicas must never be added as voters
+	if parts.ReadReplica && (minRaftProtocol < 3 || parts.RaftVersion < 3) {
+		s.logger.Error("skipping adding read replica as Raft peer because it requires Raft protocol 3",
+			"peer", m.Name)
+		return nil
+	}
+
 	// Attempt to add as a peer
 	switch {
 	case minRaftProtocol >= 3:
@@ -2749,6 +2839,34 @@ func (s *Server) generateClusterMetadata() (structs.ClusterMetadata, error) {
 	return newMeta, nil
 }
 
+// waitForKeyringReady waits for the keyring to be ready, and then wakes up the
+// schedulers waiting in Eval.Dequeue. Evaluations are not handed out to the
+// schedulers until the keyring is ready, as their plans could not be applied.
+func (s *Server) waitForKeyringReady(stopCh chan struct{}) {
+	err := s.encrypter.Ready()
+	if err == nil {
+		return
+	}
+
+	logger := s.logger.Named("keyring")
+	logger.Info("evaluations are held in the eval broker until the keyring is ready", "reason", err)
+
+	timer, stop := helper.NewSafeTimer(keyringReadyInterval)
+	defer stop()
+
+	for s.encrypter.Ready() != nil {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			timer.Reset(keyringReadyInterval)
+		}
+	}
+
+	logger.Info("keyring is ready")
+	s.evalBroker.enabledNotifier.Notify("keyring is ready")
+}
+
 // handleEvalBrokerStateChange handles changing the evalBroker and blockedEvals
 // enabled status based on the passed scheduler configuration. The boolean
 // response indicates whether the caller needs to call restoreEvals() due to
@@ -2788,12 +2906,14 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	// The scheduler config can only be persisted to Raft once quorum has been
 	// established. If this is a fresh cluster, we need to use the default
 	// scheduler config, otherwise we can use the persisted object.
-	switch schedConfig {
-	case nil:
-		enableBrokers = !s.config.DefaultSchedulerConfig.PauseEvalBroker
-	default:
-		enableBrokers = !schedConfig.PauseEvalBroker
+	if schedConfig == nil {
+		schedConfig = &s.config.DefaultSchedulerConfig
 	}
+	enableBrokers = !schedConfig.PauseEvalBroker
+
+	// Apply the dequeue mode before the broker is enabled so restored
+	// evaluations are dequeued according to it.
+	s.evalBroker.SetDequeueMode(schedConfig.EvalBrokerDequeueMode)
 
 	// If the evalBroker status is changing, set the new state.
 	if enableBrokers != s.evalBroker.Enabled() {
@@ -1638,6 +1638,95 @@ func TestLeader_ReplicateNamespaces(t *testing.T) {
 	})
 }
 
+func TestLeader_ReplicateNamespaces_Conflicts(t *testing.T) {
+	ci.Parallel(t)
+
+	// Namespaces are replicated without ACLs
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+	})
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ReplicationBackoff = 20 * time.Millisecond
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write namespaces to the authoritative region
+	ns1, ns2 := mock.Namespace(), mock.Namespace()
+	must.NoError(t, s1.State().UpsertNamespaces(100, []*structs.Namespace{ns1, ns2}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().Namespaces(nil)
+		if err != nil {
+			return false, err
+		}
+		count := 0
+		for raw := out.Next(); raw != nil; raw = out.Next() {
+			count++
+		}
+		return count == 3, fmt.Errorf("expected 3 namespaces, found %d", count)
+	}, func(err error) {
+		t.Fatalf("should replicate namespaces: %v", err)
+	})
+
+	// Run a job in the first namespace of the federated region
+	job := mock.Job()
+	job.Namespace = ns1.Name
+	must.NoError(t, s2.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Delete both namespaces at the authoritative region
+	must.NoError(t, s1.State().DeleteNamespaces(200, []string{ns1.Name, ns2.Name}))
+
+	// The unused namespace is deleted and the other one is reported
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().NamespaceByName(nil, ns2.Name)
+		if err != nil || out != nil {
+			return false, fmt.Errorf("namespace %q not deleted", ns2.Name)
+		}
+		conflicts := s2.replicationConflicts.list()
+		if len(conflicts) != 1 {
+			return false, fmt.Errorf("expected 1 conflict, found %d", len(conflicts))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("should replicate namespace deletion: %v", err)
+	})
+
+	conflict := s2.replicationConflicts.list()[0]
+	must.Eq(t, structs.ReplicationConflictNamespace, conflict.Type)
+	must.Eq(t, ns1.Name, conflict.Name)
+	must.StrContains(t, conflict.Reason, job.ID)
+
+	out, err := s2.State().NamespaceByName(nil, ns1.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+
+	// Once the job is stopped the deletion is replicated
+	job = job.Copy()
+	job.Stop = true
+	job.Status = structs.JobStatusDead
+	must.NoError(t, s2.State().UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job))
+
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().NamespaceByName(nil, ns1.Name)
+		if err != nil || out != nil {
+			return false, fmt.Errorf("namespace %q not deleted", ns1.Name)
+		}
+		if conflicts := s2.replicationConflicts.list(); len(conflicts) != 0 {
+			return false, fmt.Errorf("expected no conflict, found %d", len(conflicts))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("should resolve namespace conflict: %v", err)
+	})
+}
+
 func TestLeader_DiffNamespaces(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1719,6 +1808,77 @@ func TestLeader_ReplicateNodePools(t *testing.T) {
 	})
 }
 
+func TestLeader_ReplicateNodePools_Conflicts(t *testing.T) {
+	ci.Parallel(t)
+
+	// Node pools are replicated without ACLs
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+	})
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ReplicationBackoff = 20 * time.Millisecond
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write a node pool to the authoritative region
+	np1 := mock.NodePool()
+	must.NoError(t, s1.State().UpsertNodePools(
+		structs.MsgTypeTestSetup, 100, []*structs.NodePool{np1}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().NodePoolByName(nil, np1.Name)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate node pool")
+	})
+
+	// Register a node in the node pool of the federated region
+	node := mock.Node()
+	node.NodePool = np1.Name
+	must.NoError(t, s2.State().UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Delete the node pool at the authoritative region
+	must.NoError(t, s1.State().DeleteNodePools(structs.MsgTypeTestSetup, 200, []string{np1.Name}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		conflicts := s2.replicationConflicts.list()
+		if len(conflicts) != 1 {
+			return false, fmt.Errorf("expected 1 conflict, found %d", len(conflicts))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("should report node pool conflict: %v", err)
+	})
+
+	conflict := s2.replicationConflicts.list()[0]
+	must.Eq(t, structs.ReplicationConflictNodePool, conflict.Type)
+	must.Eq(t, np1.Name, conflict.Name)
+	must.StrContains(t, conflict.Reason, node.ID)
+
+	// Once the node is removed the deletion is replicated
+	must.NoError(t, s2.State().DeleteNode(structs.MsgTypeTestSetup, 1001, []string{node.ID}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().NodePoolByName(nil, np1.Name)
+		if err != nil || out != nil {
+			return false, fmt.Errorf("node pool %q not deleted", np1.Name)
+		}
+		if conflicts := s2.replicationConflicts.list(); len(conflicts) != 0 {
+			return false, fmt.Errorf("expected no conflict, found %d", len(conflicts))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("should resolve node pool conflict: %v", err)
+	})
+}
+
 func TestLeader_DiffNodePools(t *testing.T) {
 	ci.Parallel(t)
 
@@ -81,8 +81,9 @@ func (s *Server) invalidateVariableLock(variable structs.VariableEncrypted) {
 	s.logger.Debug("locks: lock delay expired, removing lock",
 		"namespace", variable.Namespace, "path", variable.Path)
 
-	// Remove the lock from the variable
-	variable.VariableMetadata.Lock = nil
+	// Remove the lock from the variable. The lock ID is kept in the request,
+	// so a lock stolen in the meantime is not removed.
+	variable.VariableMetadata.Lock = &structs.VariableLock{ID: lockID}
 
 	args := structs.VarApplyStateRequest{
 		Op: structs.VarOpLockRelease,
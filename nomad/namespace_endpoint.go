@@ -30,11 +30,9 @@ func (n *Namespace) UpsertNamespaces(args *structs.NamespaceUpsertRequest,
 	reply *structs.GenericResponse) error {
 
 	authErr := n.srv.Authenticate(n.ctx, args)
-	if n.srv.config.ACLEnabled || args.Region == "" {
-		// only forward to the authoritative region if ACLs are enabled,
-		// otherwise we silently write to the local region
-		args.Region = n.srv.config.AuthoritativeRegion
-	}
+	// Namespaces are replicated from the authoritative region, so writes are
+	// always forwarded to it
+	args.Region = n.srv.config.AuthoritativeRegion
 	if done, err := n.srv.forward("Namespace.UpsertNamespaces", args, args, reply); done {
 		return err
 	}
@@ -81,11 +79,9 @@ func (n *Namespace) UpsertNamespaces(args *structs.NamespaceUpsertRequest,
 func (n *Namespace) DeleteNamespaces(args *structs.NamespaceDeleteRequest, reply *structs.GenericResponse) error {
 
 	authErr := n.srv.Authenticate(n.ctx, args)
-	if n.srv.config.ACLEnabled || args.Region == "" {
-		// only forward to the authoritative region if ACLs are enabled,
-		// otherwise we silently write to the local region
-		args.Region = n.srv.config.AuthoritativeRegion
-	}
+	// Namespaces are replicated from the authoritative region, so writes are
+	// always forwarded to it
+	args.Region = n.srv.config.AuthoritativeRegion
 	if done, err := n.srv.forward("Namespace.DeleteNamespaces", args, args, reply); done {
 		return err
 	}
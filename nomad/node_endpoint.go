@@ -55,6 +55,15 @@ const (
 	// NodeWaitingForNodePool is the message used when the node is waiting for
 	// its node pool to be created.
 	NodeWaitingForNodePool = "Node registered but waiting for node pool to be created"
+
+	// NodeEligibilityEventAllocDirBudgetExceeded is used when the node is
+	// marked ineligible because its client exceeded the alloc dir disk budget
+	NodeEligibilityEventAllocDirBudgetExceeded = "Node marked as ineligible for scheduling, alloc dir disk budget exceeded"
+
+	// NodeEligibilityEventAllocDirBudgetRestored is used when the node is
+	// marked eligible again because its client is back under the alloc dir
+	// disk budget
+	NodeEligibilityEventAllocDirBudgetRestored = "Node marked as eligible for scheduling, alloc dir disk usage back under budget"
 )
 
 // Node endpoint is used for client interactions
@@ -199,6 +208,16 @@ func (n *Node) Register(args *structs.NodeRegisterRequest, reply *structs.NodeUp
 		}
 	}
 
+	// Override the port configuration of the node with the one of its node
+	// pool, if any.
+	pool, err := snap.NodePoolByName(ws, args.Node.NodePool)
+	if err != nil {
+		return err
+	}
+	if err := pool.ApplyPortConfiguration(args.Node); err != nil {
+		return err
+	}
+
 	// We have a valid node connection, so add the mapping to cache the
 	// connection and allow the server to send RPCs to the client. We only cache
 	// the connection if it is not being forwarded from another server.
@@ -735,6 +754,14 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	if args.NodeEvent != nil {
 		return fmt.Errorf("node event must not be set")
 	}
+	if args.DrainStrategy != nil && args.DrainStrategy.BatchGrace != 0 {
+		if args.DrainStrategy.BatchGrace < 0 {
+			return fmt.Errorf("batch grace must not be negative")
+		}
+		if args.DrainStrategy.Deadline <= 0 {
+			return fmt.Errorf("batch grace requires a drain deadline")
+		}
+	}
 
 	// The AuthenticatedIdentity is unexported so won't be written via
 	// Raft. Record the identity string so it can be written to LastDrain
@@ -838,6 +865,9 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	if args.NodeEvent != nil {
 		return fmt.Errorf("node event must not be set")
 	}
+	if args.AllocDirBudget {
+		return fmt.Errorf("alloc dir budget must not be set")
+	}
 
 	// Check that only allowed types are set
 	switch args.Eligibility {
@@ -1326,6 +1356,7 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 	// Update modified timestamp for client initiated allocation updates
 	now := time.Now()
 	var evals []*structs.Evaluation
+	gateOpened := false
 
 	for _, allocToUpdate := range args.Alloc {
 		evalTriggerBy := ""
@@ -1335,6 +1366,23 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 		if alloc == nil {
 			continue
 		}
+		measureAllocTimestamps(alloc.Namespace, alloc.Timestamps, alloc.ClientUpdatedTimestamps(allocToUpdate))
+
+		// System jobs are not placed on the node until the readiness gates
+		// of its node pool are healthy, so evaluate them once a gate opens.
+		if !gateOpened {
+			_, pool, err := readinessGateOpened(n.srv.State(), alloc, allocToUpdate)
+			if err != nil {
+				n.logger.Debug("UpdateAlloc unable to look up readiness gates", "alloc", alloc.ID, "error", err)
+			} else if pool != nil {
+				gateOpened = true
+				gateEvals, err := n.readinessGateEvals(node, pool, now)
+				if err != nil {
+					n.logger.Debug("UpdateAlloc unable to create readiness gate evals", "node_id", nodeID, "error", err)
+				}
+				evals = append(evals, gateEvals...)
+			}
+		}
 
 		if !allocToUpdate.TerminalStatus() && alloc.ClientStatus != structs.AllocClientStatusUnknown {
 			continue
@@ -1606,6 +1654,69 @@ func (n *Node) List(args *structs.NodeListRequest,
 	return n.srv.blockingRPC(&opts)
 }
 
+// readinessGateOpened returns the node of the allocation and its node pool if
+// the client update makes the allocation a healthy allocation of one of the
+// readiness gate jobs of the node pool.
+func readinessGateOpened(store *state.StateStore, existing, update *structs.Allocation) (*structs.Node, *structs.NodePool, error) {
+	if update.ClientStatus != structs.AllocClientStatusRunning {
+		return nil, nil, nil
+	}
+
+	node, err := store.NodeByID(nil, existing.NodeID)
+	if err != nil || node == nil {
+		return nil, nil, err
+	}
+	pool, err := store.NodePoolByName(nil, node.NodePool)
+	if err != nil || pool == nil {
+		return nil, nil, err
+	}
+
+	gate := pool.ReadinessGate(existing.Namespace, existing.JobID)
+	if gate == nil || gate.Ready([]*structs.Allocation{existing}) {
+		return nil, nil, nil
+	}
+
+	updated := existing.CopySkipJob()
+	updated.ClientStatus = update.ClientStatus
+	updated.DeploymentStatus = update.DeploymentStatus
+	if !gate.Ready([]*structs.Allocation{updated}) {
+		return nil, nil, nil
+	}
+	return node, pool, nil
+}
+
+// readinessGateEvals returns evaluations for the system jobs that may run on
+// the node, other than the readiness gate jobs of its node pool.
+func (n *Node) readinessGateEvals(node *structs.Node, pool *structs.NodePool, now time.Time) ([]*structs.Evaluation, error) {
+	iter, err := n.srv.State().JobsByScheduler(nil, structs.JobTypeSystem)
+	if err != nil {
+		return nil, err
+	}
+
+	var evals []*structs.Evaluation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.Stopped() || !node.IsInPool(job.NodePool) || !node.IsInAnyDC(job.Datacenters) ||
+			pool.ReadinessGate(job.Namespace, job.ID) != nil {
+			continue
+		}
+
+		evals = append(evals, &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerNodeUpdate,
+			JobID:       job.ID,
+			NodeID:      node.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now.UTC().UnixNano(),
+			ModifyTime:  now.UTC().UnixNano(),
+		})
+	}
+	return evals, nil
+}
+
 // createNodeEvals is used to create evaluations for each alloc on a node.
 // Each Eval is scoped to a job, so we need to potentially trigger many evals.
 func (n *Node) createNodeEvals(node *structs.Node, nodeIndex uint64) ([]string, uint64, error) {
@@ -1975,6 +2086,101 @@ func taskUsesConnect(task *structs.Task) bool {
 	return task.UsesConnect()
 }
 
+// UpdateAllocDirBudget is used by clients to report that the disk usage of
+// their allocation directories crossed the budget watermarks. Nodes over
+// budget are marked ineligible, and only nodes marked ineligible because of
+// the budget are marked eligible again once back under budget, so that the
+// eligibility set by operators or drains is never overridden.
+func (n *Node) UpdateAllocDirBudget(args *structs.NodeAllocDirBudgetRequest,
+	reply *structs.NodeEligibilityUpdateResponse) error {
+
+	aclObj, err := n.srv.AuthenticateClientOnly(n.ctx, args)
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if err != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := n.srv.forward("Node.UpdateAllocDirBudget", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "update_alloc_dir_budget"}, time.Now())
+
+	// Clients may only update their own node
+	if !aclObj.AllowClientOp() || args.GetIdentity().ClientID != args.NodeID {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID for alloc dir budget update")
+	}
+
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	req := &structs.NodeUpdateEligibilityRequest{
+		NodeID:         args.NodeID,
+		AllocDirBudget: true,
+		UpdatedAt:      time.Now().Unix(),
+		WriteRequest:   args.WriteRequest,
+	}
+	if args.Exceeded {
+		// Nodes that are already ineligible are left alone, since their
+		// eligibility was not removed by the budget.
+		if node.SchedulingEligibility != structs.NodeSchedulingEligible {
+			return nil
+		}
+		n.logger.Info("node transitioning to ineligible state, alloc dir disk budget exceeded", "node_id", node.ID)
+		req.Eligibility = structs.NodeSchedulingIneligible
+		req.NodeEvent = structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemStorage).
+			SetMessage(NodeEligibilityEventAllocDirBudgetExceeded)
+	} else {
+		if !node.AllocDirBudgetExceeded || node.DrainStrategy != nil {
+			return nil
+		}
+		n.logger.Info("node transitioning to eligible state, alloc dir disk usage back under budget", "node_id", node.ID)
+		req.Eligibility = structs.NodeSchedulingEligible
+		req.NodeEvent = structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemStorage).
+			SetMessage(NodeEligibilityEventAllocDirBudgetRestored)
+	}
+
+	outErr, index, err := n.srv.raftApply(structs.NodeUpdateEligibilityRequestType, req)
+	if err != nil {
+		n.logger.Error("eligibility update failed", "error", err)
+		return err
+	}
+	if err, ok := outErr.(error); ok && err != nil {
+		n.logger.Error("eligibility update failed", "error", err)
+		return err
+	}
+
+	// Create node evaluations since there may be system jobs that should be
+	// placed on the node now that it is eligible again.
+	if req.Eligibility == structs.NodeSchedulingEligible {
+		evalIDs, evalIndex, err := n.createNodeEvals(node, index)
+		if err != nil {
+			n.logger.Error("eval creation failed", "error", err)
+			return err
+		}
+		reply.EvalIDs = evalIDs
+		reply.EvalCreateIndex = evalIndex
+	}
+
+	reply.NodeModifyIndex = index
+	reply.Index = index
+	return nil
+}
+
 func (n *Node) EmitEvents(args *structs.EmitNodeEventsRequest, reply *structs.EmitNodeEventsResponse) error {
 	aclObj, err := n.srv.AuthenticateClientOnly(n.ctx, args)
 	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
@@ -301,6 +301,51 @@ func TestClientEndpoint_Register_NodePool(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_Register_NodePoolPortConfiguration(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	state := s.fsm.State()
+
+	pool := mock.NodePool()
+	pool.PortConfiguration = &structs.NodePoolPortConfiguration{
+		MinDynamicPort: 25000,
+		MaxDynamicPort: 26000,
+		ReservedPorts:  "22,80",
+	}
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	// The node pool configuration overrides the client configuration
+	node := mock.Node()
+	node.NodePool = pool.Name
+	req := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", req, &resp))
+
+	got, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, 25000, got.NodeResources.MinDynamicPort)
+	must.Eq(t, 26000, got.NodeResources.MaxDynamicPort)
+	must.Eq(t, "22,80", got.ReservedResources.Networks.ReservedHostPorts)
+
+	// Nodes whose port configuration becomes invalid are rejected
+	pool = pool.Copy()
+	pool.PortConfiguration = &structs.NodePoolPortConfiguration{MaxDynamicPort: 1000}
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 1001, []*structs.NodePool{pool}))
+
+	node = mock.Node()
+	node.NodePool = pool.Name
+	req.Node = node
+	err = msgpackrpc.CallWithCodec(codec, "Node.Register", req, &resp)
+	must.ErrorContains(t, err, "invalid dynamic port range")
+}
+
 func TestClientEndpoint_Register_NodePool_Multiregion(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1933,6 +1978,15 @@ func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
 	}
 
+	// Try with the secret of the node itself
+	dereg.AuthToken = node.SecretID
+	{
+		var resp structs.NodeEligibilityUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", dereg, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
 	// Try with a root token
 	dereg.AuthToken = root.SecretID
 	{
@@ -1941,6 +1995,76 @@ func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_UpdateAllocDirBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1, node))
+
+	report := func(exceeded bool, token string) error {
+		req := &structs.NodeAllocDirBudgetRequest{
+			NodeID:   node.ID,
+			Exceeded: exceeded,
+			WriteRequest: structs.WriteRequest{
+				Region: "global", AuthToken: token},
+		}
+		var resp structs.NodeEligibilityUpdateResponse
+		return msgpackrpc.CallWithCodec(codec, "Node.UpdateAllocDirBudget", req, &resp)
+	}
+	eligibility := func() (string, bool) {
+		out, err := state.NodeByID(nil, node.ID)
+		must.NoError(t, err)
+		return out.SchedulingEligibility, out.AllocDirBudgetExceeded
+	}
+
+	// Only the node itself may report its budget
+	otherNode := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 2, otherNode))
+	must.EqError(t, report(true, otherNode.SecretID), structs.ErrPermissionDenied.Error())
+	must.EqError(t, report(true, root.SecretID), structs.ErrPermissionDenied.Error())
+
+	// Exceeding the budget makes the node ineligible
+	must.NoError(t, report(true, node.SecretID))
+	elig, exceeded := eligibility()
+	must.Eq(t, structs.NodeSchedulingIneligible, elig)
+	must.True(t, exceeded)
+
+	// Coming back under budget restores the eligibility
+	must.NoError(t, report(false, node.SecretID))
+	elig, exceeded = eligibility()
+	must.Eq(t, structs.NodeSchedulingEligible, elig)
+	must.False(t, exceeded)
+
+	// A node made ineligible by an operator is not restored
+	elig = structs.NodeSchedulingIneligible
+	must.NoError(t, state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 10, node.ID, elig, 0, nil))
+	must.NoError(t, report(true, node.SecretID))
+	_, exceeded = eligibility()
+	must.False(t, exceeded)
+	must.NoError(t, report(false, node.SecretID))
+	elig, _ = eligibility()
+	must.Eq(t, structs.NodeSchedulingIneligible, elig)
+
+	// An operator changing the eligibility clears the budget marker, so the
+	// budget doesn't override the operator
+	must.NoError(t, state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 20, node.ID, structs.NodeSchedulingEligible, 0, nil))
+	must.NoError(t, report(true, node.SecretID))
+	_, exceeded = eligibility()
+	must.True(t, exceeded)
+	must.NoError(t, state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 30, node.ID, structs.NodeSchedulingIneligible, 0, nil))
+	_, exceeded = eligibility()
+	must.False(t, exceeded)
+	must.NoError(t, report(false, node.SecretID))
+	elig, _ = eligibility()
+	must.Eq(t, structs.NodeSchedulingIneligible, elig)
+}
+
 func TestClientEndpoint_GetNode(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4257,6 +4381,89 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 
 }
 
+// TestClientEndpoint_UpdateAlloc_Evals_ReadinessGate asserts that the system
+// jobs of a node pool are evaluated on a node once one of the readiness gates
+// of the node pool becomes healthy on the node.
+func TestClientEndpoint_UpdateAlloc_Evals_ReadinessGate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	pool := mock.NodePool()
+	pool.ReadinessGates = []*structs.NodePoolReadinessGate{{JobID: "cni"}}
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	node := mock.Node()
+	node.NodePool = pool.Name
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var nodeResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &nodeResp))
+
+	gateJob := mock.SystemJob()
+	gateJob.ID = "cni"
+	gateJob.NodePool = pool.Name
+	gatedJob := mock.SystemJob()
+	gatedJob.NodePool = pool.Name
+	otherPoolJob := mock.SystemJob()
+	for _, job := range []*structs.Job{gateJob, gatedJob, otherPoolJob} {
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job))
+	}
+
+	alloc := mock.AllocForNode(node)
+	alloc.Job = gateJob
+	alloc.JobID = gateJob.ID
+	alloc.TaskGroup = gateJob.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusPending
+	must.NoError(t, store.UpsertJobSummary(102, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, []*structs.Allocation{alloc}))
+
+	updateAlloc := func(clientStatus string) {
+		clientAlloc := alloc.Copy()
+		clientAlloc.ClientStatus = clientStatus
+		req := &structs.AllocUpdateRequest{
+			Alloc: []*structs.Allocation{clientAlloc},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: node.SecretID,
+			},
+		}
+		var resp structs.NodeAllocsResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", req, &resp))
+	}
+
+	// Only the gated system job of the node pool is evaluated once the
+	// readiness gate becomes healthy, and only once
+	updateAlloc(structs.AllocClientStatusRunning)
+	updateAlloc(structs.AllocClientStatusRunning)
+
+	for _, job := range []*structs.Job{gateJob, gatedJob, otherPoolJob} {
+		evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+		must.NoError(t, err)
+
+		var gateEvals []*structs.Evaluation
+		for _, eval := range evals {
+			if eval.TriggeredBy == structs.EvalTriggerNodeUpdate && eval.NodeModifyIndex == 0 {
+				gateEvals = append(gateEvals, eval)
+			}
+		}
+		if job != gatedJob {
+			must.SliceEmpty(t, gateEvals, must.Sprintf("job %s", job.ID))
+			continue
+		}
+		must.Len(t, 1, gateEvals)
+		must.Eq(t, node.ID, gateEvals[0].NodeID)
+	}
+}
+
 // TestNode_List_PaginationFiltering asserts that API pagination and filtering
 // works against the Node.List RPC.
 func TestNode_List_PaginationFiltering(t *testing.T) {
@@ -0,0 +1,399 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/helper/useragent"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	// nodeLifecycleCoalesce is the amount of time to wait after a change to
+	// the node lifecycle runs or nodes tables before reconciling the runs, so
+	// that a burst of node updates only results in a single pass.
+	nodeLifecycleCoalesce = 1 * time.Second
+
+	// nodeLifecycleResync is the maximum amount of time between two passes
+	// of the controller, so that reregister timeouts are noticed.
+	nodeLifecycleResync = 30 * time.Second
+
+	// nodeLifecycleWebhookTimeout is the timeout of a single call to the
+	// custom action webhook of a node lifecycle run.
+	nodeLifecycleWebhookTimeout = 1 * time.Minute
+)
+
+// runNodeLifecycles is a long lived function run on the leader that cycles
+// the nodes of the running node lifecycle runs. The progress of each node is
+// written to the run through Raft, so a new leader resumes the runs where the
+// previous one stopped. It stops once stopCh is closed.
+func (s *Server) runNodeLifecycles(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(s.shutdownCtx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		ws := memdb.NewWatchSet()
+		if err := s.reconcileNodeLifecycles(ctx, ws, time.Now()); err != nil {
+			s.logger.Error("failed to reconcile node lifecycle runs", "error", err)
+		}
+
+		// Block until the node lifecycle runs or the nodes change, or the
+		// resync interval is reached.
+		watchCtx, watchCancel := context.WithTimeout(ctx, nodeLifecycleResync)
+		ws.WatchCtx(watchCtx)
+		watchCancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(nodeLifecycleCoalesce):
+		}
+	}
+}
+
+// reconcileNodeLifecycles advances the nodes of every running node lifecycle
+// run. The node lifecycle runs table, and the nodes table if any run is
+// running, are added to the passed watch set.
+func (s *Server) reconcileNodeLifecycles(ctx context.Context, ws memdb.WatchSet, now time.Time) error {
+	defer metrics.MeasureSince([]string{"nomad", "node_lifecycle", "reconcile"}, time.Now())
+
+	// Runs can't be updated until all servers can apply the update, in case
+	// a server was downgraded since the run started.
+	if !ServersMeetMinimumVersion(s.Members(), s.Region(), minNodeLifecycleVersion, true) {
+		return nil
+	}
+
+	store := s.State()
+	iter, err := store.NodeLifecycleRuns(ws)
+	if err != nil {
+		return err
+	}
+
+	var runs []*structs.NodeLifecycleRun
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if run := raw.(*structs.NodeLifecycleRun); !run.Terminal() {
+			runs = append(runs, run)
+		}
+	}
+	if len(runs) == 0 {
+		return nil
+	}
+
+	if _, err := store.Nodes(ws); err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if err := s.reconcileNodeLifecycleRun(ctx, run, now); err != nil {
+			s.logger.Error("failed to reconcile node lifecycle run",
+				"node_pool", run.NodePool, "run_id", run.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// reconcileNodeLifecycleRun advances the nodes of the current batch of a run
+// as far as possible, starts the next batch once the current one is complete,
+// and writes the run back if it changed. A run whose node failed stops once
+// the other nodes of the batch are done.
+func (s *Server) reconcileNodeLifecycleRun(ctx context.Context, existing *structs.NodeLifecycleRun, now time.Time) error {
+	run := existing.Copy()
+	changed := false
+
+	active := 0
+	for _, node := range run.Nodes {
+		if !node.Active() {
+			continue
+		}
+		if s.advanceNodeLifecycleNode(ctx, run, node) {
+			changed = true
+		}
+		if node.Active() {
+			active++
+		}
+	}
+
+	var failed *structs.NodeLifecycleNode
+	pending := 0
+	for _, node := range run.Nodes {
+		switch node.Phase {
+		case structs.NodeLifecyclePhaseFailed:
+			if failed == nil {
+				failed = node
+			}
+		case structs.NodeLifecyclePhasePending:
+			pending++
+		}
+	}
+
+	switch {
+	case active > 0:
+	case failed != nil:
+		run.Status = structs.NodeLifecycleStatusFailed
+		run.StatusDescription = fmt.Sprintf("Node %q failed: %s", failed.NodeName, failed.Error)
+		changed = true
+	case pending == 0:
+		run.Status = structs.NodeLifecycleStatusComplete
+		run.StatusDescription = ""
+		changed = true
+	default:
+		// Start the next batch and advance its nodes right away.
+		started := 0
+		for _, node := range run.Nodes {
+			if started == run.BatchSize {
+				break
+			}
+			if node.Phase != structs.NodeLifecyclePhasePending {
+				continue
+			}
+			setNodeLifecyclePhase(node, structs.NodeLifecyclePhaseCordon)
+			s.advanceNodeLifecycleNode(ctx, run, node)
+			started++
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	run.ModifyTime = now.UnixNano()
+	req := &structs.NodeLifecycleRunUpsertRequest{
+		Run:          run,
+		WriteRequest: structs.WriteRequest{Region: s.Region()},
+	}
+	if _, _, err := s.raftApply(structs.NodeLifecycleRunUpsertRequestType, req); err != nil {
+		// The run may have been aborted while its nodes were being advanced.
+		if current, _ := s.State().NodeLifecycleRunByPool(nil, run.NodePool); current != nil &&
+			current.ID == run.ID && current.Terminal() {
+			return nil
+		}
+		return err
+	}
+
+	if run.Terminal() {
+		s.logger.Info("node lifecycle run finished", "node_pool", run.NodePool,
+			"run_id", run.ID, "status", run.Status, "description", run.StatusDescription)
+	}
+	return nil
+}
+
+// advanceNodeLifecycleNode moves a node of the run through its phases until
+// it has to wait for its drain or for it to register again, or until it is
+// complete or failed. Each phase performs its action before moving on, so
+// a new leader repeats the action of the phase a node was last recorded in.
+// It returns true if the node changed.
+func (s *Server) advanceNodeLifecycleNode(ctx context.Context, run *structs.NodeLifecycleRun, n *structs.NodeLifecycleNode) bool {
+	changed := false
+	fail := func(format string, a ...any) bool {
+		n.Error = fmt.Sprintf(format, a...)
+		setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseFailed)
+		s.logger.Warn("node lifecycle run node failed", "node_pool", run.NodePool,
+			"run_id", run.ID, "node_id", n.NodeID, "error", n.Error)
+		return true
+	}
+
+	for {
+		node, err := s.State().NodeByID(nil, n.NodeID)
+		if err != nil {
+			s.logger.Error("failed to read node", "node_id", n.NodeID, "error", err)
+			return changed
+		}
+		if node == nil && n.Phase != structs.NodeLifecyclePhaseReregister {
+			return fail("node not found")
+		}
+
+		switch n.Phase {
+		case structs.NodeLifecyclePhaseCordon:
+			if err := s.setNodeLifecycleEligibility(n.NodeID, structs.NodeSchedulingIneligible); err != nil {
+				return fail("failed to mark node as ineligible: %v", err)
+			}
+			if err := s.drainNodeLifecycleNode(run, n.NodeID); err != nil {
+				return fail("failed to drain node: %v", err)
+			}
+			setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseDrain)
+
+		case structs.NodeLifecyclePhaseDrain:
+			if node.DrainStrategy != nil {
+				return changed
+			}
+			if node.LastDrain != nil && node.LastDrain.Status == structs.DrainStatusCanceled {
+				return fail("drain was canceled")
+			}
+			if run.WebhookURL != "" {
+				setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseAction)
+			} else {
+				setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseUncordon)
+			}
+
+		case structs.NodeLifecyclePhaseAction:
+			if err := s.callNodeLifecycleWebhook(ctx, run, node); err != nil {
+				// Leave the node to the next leader if leadership was lost.
+				if ctx.Err() != nil {
+					return changed
+				}
+				return fail("failed to call webhook: %v", err)
+			}
+			setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseReregister)
+
+		case structs.NodeLifecyclePhaseReregister:
+			since := time.Unix(0, n.PhaseStartTime)
+			reregistered, err := nodeLifecycleReregistered(s.State(), run.NodePool, n, since)
+			if err != nil {
+				s.logger.Error("failed to look for reregistered node", "node_name", n.NodeName, "error", err)
+				return changed
+			}
+			if reregistered == nil {
+				if time.Since(since) > run.ReregisterTimeout {
+					return fail("timed out waiting for node to register again")
+				}
+				return changed
+			}
+			n.NodeID = reregistered.ID
+			n.NodeCreateIndex = reregistered.CreateIndex
+			setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseUncordon)
+
+		case structs.NodeLifecyclePhaseUncordon:
+			if err := s.setNodeLifecycleEligibility(n.NodeID, structs.NodeSchedulingEligible); err != nil {
+				return fail("failed to mark node as eligible: %v", err)
+			}
+			setNodeLifecyclePhase(n, structs.NodeLifecyclePhaseComplete)
+
+		default:
+			return changed
+		}
+		changed = true
+	}
+}
+
+// setNodeLifecyclePhase moves the node to the given phase.
+func setNodeLifecyclePhase(n *structs.NodeLifecycleNode, phase string) {
+	n.Phase = phase
+	n.PhaseStartTime = time.Now().UnixNano()
+}
+
+// setNodeLifecycleEligibility updates the scheduling eligibility of a node
+// through the RPC used by operators, so that the update goes through the same
+// checks and creates the same evaluations.
+func (s *Server) setNodeLifecycleEligibility(nodeID, eligibility string) error {
+	req := &structs.NodeUpdateEligibilityRequest{
+		NodeID:      nodeID,
+		Eligibility: eligibility,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp structs.NodeEligibilityUpdateResponse
+	return s.RPC("Node.UpdateEligibility", req, &resp)
+}
+
+// drainNodeLifecycleNode drains a node with the drain spec of the run.
+func (s *Server) drainNodeLifecycleNode(run *structs.NodeLifecycleRun, nodeID string) error {
+	spec := structs.DrainSpec{Deadline: structs.NodeLifecycleDefaultDrainDeadline}
+	if run.DrainSpec != nil {
+		spec = *run.DrainSpec
+	}
+	req := &structs.NodeUpdateDrainRequest{
+		NodeID:        nodeID,
+		DrainStrategy: &structs.DrainStrategy{DrainSpec: spec},
+		Meta: map[string]string{
+			"message":               "node lifecycle run",
+			"node_lifecycle_run_id": run.ID,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp structs.NodeDrainUpdateResponse
+	return s.RPC("Node.UpdateDrain", req, &resp)
+}
+
+// callNodeLifecycleWebhook hands a drained node over to the custom action
+// webhook of the run. The webhook may be called more than once for the same
+// node if leadership changes while it's being called.
+func (s *Server) callNodeLifecycleWebhook(ctx context.Context, run *structs.NodeLifecycleRun, node *structs.Node) error {
+	defer metrics.MeasureSince([]string{"nomad", "node_lifecycle", "webhook"}, time.Now())
+
+	var body []byte
+	err := codec.NewEncoderBytes(&body, structs.JsonHandle).Encode(&structs.NodeLifecycleWebhookRequest{
+		RunID:      run.ID,
+		NodeID:     node.ID,
+		NodeName:   node.Name,
+		NodePool:   node.NodePool,
+		Datacenter: node.Datacenter,
+		Address:    node.Attributes["unique.network.ip-address"],
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nodeLifecycleWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, run.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", useragent.String())
+
+	resp, err := cleanhttp.DefaultClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// nodeLifecycleReregistered returns the node of the run once it registered
+// again after the given time, or nil if it has not.
+// A node that registers again with a new ID, for example after being
+// reimaged, is recognized by its name.
+func nodeLifecycleReregistered(store *state.StateStore, pool string, n *structs.NodeLifecycleNode, since time.Time) (*structs.Node, error) {
+	iter, err := store.NodesByNodePool(nil, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.Name != n.NodeName || node.Status != structs.NodeStatusReady {
+			continue
+		}
+		if node.ID != n.NodeID {
+			if node.CreateIndex > n.NodeCreateIndex {
+				return node, nil
+			}
+			continue
+		}
+		if node.StatusUpdatedAt > since.Unix() {
+			return node, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/rpc"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// waitForNodeLifecycleRun waits until the node lifecycle run of the node pool
+// satisfies the condition and returns it.
+func waitForNodeLifecycleRun(t *testing.T, s *Server, pool string, cond func(*structs.NodeLifecycleRun) bool) *structs.NodeLifecycleRun {
+	t.Helper()
+
+	var run *structs.NodeLifecycleRun
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			var err error
+			run, err = s.State().NodeLifecycleRunByPool(nil, pool)
+			must.NoError(t, err)
+			return run != nil && cond(run)
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+	return run
+}
+
+// registerNodeLifecycleNode registers the node through the RPC used by
+// clients, so that the node drainer notices its drain.
+func registerNodeLifecycleNode(t *testing.T, codec rpc.ClientCodec, node *structs.Node) {
+	t.Helper()
+
+	req := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", req, &resp))
+}
+
+func TestNodeLifecycle_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.State()
+
+	pool := mock.NodePool()
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	var nodes []*structs.Node
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		node.Name = fmt.Sprintf("node-%d", i)
+		node.NodePool = pool.Name
+		registerNodeLifecycleNode(t, codec, node)
+		nodes = append(nodes, node)
+	}
+
+	req := &structs.NodeLifecycleStartRequest{
+		NodePool:     pool.Name,
+		BatchSize:    2,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeLifecycleRunResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodePool.StartNodeLifecycle", req, &resp))
+
+	// Without a webhook, nodes are uncordoned once drained.
+	run := waitForNodeLifecycleRun(t, s, pool.Name, (*structs.NodeLifecycleRun).Terminal)
+	must.Eq(t, structs.NodeLifecycleStatusComplete, run.Status)
+	for _, n := range run.Nodes {
+		must.Eq(t, structs.NodeLifecyclePhaseComplete, n.Phase)
+	}
+
+	for _, node := range nodes {
+		out, err := store.NodeByID(nil, node.ID)
+		must.NoError(t, err)
+		must.Nil(t, out.DrainStrategy)
+		must.NotNil(t, out.LastDrain)
+		must.Eq(t, structs.DrainStatusComplete, out.LastDrain.Status)
+		must.Eq(t, run.ID, out.LastDrain.Meta["node_lifecycle_run_id"])
+		must.Eq(t, structs.NodeSchedulingEligible, out.SchedulingEligibility)
+	}
+}
+
+func TestNodeLifecycle_Run_Webhook(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.State()
+
+	var lock sync.Mutex
+	var calls []*structs.NodeLifecycleWebhookRequest
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req structs.NodeLifecycleWebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		calls = append(calls, &req)
+	}))
+	defer webhook.Close()
+
+	pool := mock.NodePool()
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	reimaged, rebooted := mock.Node(), mock.Node()
+	reimaged.Name, rebooted.Name = "node-a", "node-b"
+	reimaged.NodePool, rebooted.NodePool = pool.Name, pool.Name
+	registerNodeLifecycleNode(t, codec, reimaged)
+	registerNodeLifecycleNode(t, codec, rebooted)
+
+	req := &structs.NodeLifecycleStartRequest{
+		NodePool:     pool.Name,
+		WebhookURL:   webhook.URL,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeLifecycleRunResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodePool.StartNodeLifecycle", req, &resp))
+
+	reregistering := func(node *structs.Node) func(*structs.NodeLifecycleRun) bool {
+		return func(run *structs.NodeLifecycleRun) bool {
+			for _, n := range run.Nodes {
+				if n.NodeName == node.Name {
+					return n.Phase == structs.NodeLifecyclePhaseReregister
+				}
+			}
+			return false
+		}
+	}
+
+	// The first node comes back with a new ID after being reimaged.
+	waitForNodeLifecycleRun(t, s, pool.Name, reregistering(reimaged))
+
+	lock.Lock()
+	must.Len(t, 1, calls)
+	must.Eq(t, resp.Run.ID, calls[0].RunID)
+	must.Eq(t, reimaged.ID, calls[0].NodeID)
+	must.Eq(t, reimaged.Name, calls[0].NodeName)
+	must.Eq(t, pool.Name, calls[0].NodePool)
+	lock.Unlock()
+
+	replacement := mock.Node()
+	replacement.Name = reimaged.Name
+	replacement.NodePool = pool.Name
+	registerNodeLifecycleNode(t, codec, replacement)
+
+	// The second node comes back with the same ID after being rebooted. The
+	// status of nodes is updated with a precision of one second, so it must
+	// register again at least a second after the webhook was called.
+	run := waitForNodeLifecycleRun(t, s, pool.Name, reregistering(rebooted))
+	since := time.Unix(0, run.Nodes[1].PhaseStartTime)
+	time.Sleep(time.Until(time.Unix(since.Unix()+1, 0)))
+	registerNodeLifecycleNode(t, codec, rebooted.Copy())
+
+	run = waitForNodeLifecycleRun(t, s, pool.Name, (*structs.NodeLifecycleRun).Terminal)
+	must.Eq(t, structs.NodeLifecycleStatusComplete, run.Status)
+	must.Eq(t, replacement.ID, run.Nodes[0].NodeID)
+	must.Eq(t, rebooted.ID, run.Nodes[1].NodeID)
+
+	for _, id := range []string{replacement.ID, rebooted.ID} {
+		out, err := store.NodeByID(nil, id)
+		must.NoError(t, err)
+		must.Eq(t, structs.NodeSchedulingEligible, out.SchedulingEligibility)
+	}
+}
+
+func TestNodeLifecycle_Run_Failures(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.State()
+
+	var succeed atomic.Bool
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !succeed.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer webhook.Close()
+
+	pool := mock.NodePool()
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Name, node2.Name = "node-a", "node-b"
+	node1.NodePool, node2.NodePool = pool.Name, pool.Name
+	registerNodeLifecycleNode(t, codec, node1)
+	registerNodeLifecycleNode(t, codec, node2)
+
+	req := &structs.NodeLifecycleStartRequest{
+		NodePool:     pool.Name,
+		WebhookURL:   webhook.URL,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeLifecycleRunResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodePool.StartNodeLifecycle", req, &resp))
+
+	// A failed webhook call fails the run without cycling the next batch, and
+	// leaves the node ineligible.
+	run := waitForNodeLifecycleRun(t, s, pool.Name, (*structs.NodeLifecycleRun).Terminal)
+	must.Eq(t, structs.NodeLifecycleStatusFailed, run.Status)
+	must.StrContains(t, run.StatusDescription, "unexpected status code 500")
+	must.Eq(t, structs.NodeLifecyclePhaseFailed, run.Nodes[0].Phase)
+	must.Eq(t, structs.NodeLifecyclePhasePending, run.Nodes[1].Phase)
+
+	out, err := store.NodeByID(nil, node1.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeSchedulingIneligible, out.SchedulingEligibility)
+
+	// A node that doesn't register again in time fails the run.
+	succeed.Store(true)
+	req.ReregisterTimeout = time.Nanosecond
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodePool.StartNodeLifecycle", req, &resp))
+
+	run = waitForNodeLifecycleRun(t, s, pool.Name, func(run *structs.NodeLifecycleRun) bool {
+		return run.ID == resp.Run.ID && run.Terminal()
+	})
+	must.Eq(t, structs.NodeLifecycleStatusFailed, run.Status)
+	must.StrContains(t, run.StatusDescription, "timed out waiting for node to register again")
+}
+
+func TestNodeLifecycle_nodeLifecycleReregistered(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.State()
+
+	since := time.Now().Add(-time.Minute)
+
+	node := mock.Node()
+	node.StatusUpdatedAt = since.Add(-time.Minute).Unix()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	n := &structs.NodeLifecycleNode{
+		NodeID:          node.ID,
+		NodeName:        node.Name,
+		NodeCreateIndex: 1000,
+	}
+
+	// The node has not registered again yet.
+	out, err := nodeLifecycleReregistered(store, node.NodePool, n, since)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// Nodes with another name or that are not ready are ignored.
+	other := mock.Node()
+	other.Name = "other"
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1001, other))
+	down := mock.Node()
+	down.Name = node.Name
+	down.Status = structs.NodeStatusDown
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1002, down))
+
+	out, err = nodeLifecycleReregistered(store, node.NodePool, n, since)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// A node with the same name and a new ID has registered again.
+	replacement := mock.Node()
+	replacement.ID = uuid.Generate()
+	replacement.Name = node.Name
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1003, replacement))
+
+	out, err = nodeLifecycleReregistered(store, node.NodePool, n, since)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, replacement.ID, out.ID)
+}
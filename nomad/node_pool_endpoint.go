@@ -7,12 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/go-memdb"
 	metrics "github.com/hashicorp/go-metrics/compat"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -170,11 +172,9 @@ func (n *NodePool) GetNodePool(args *structs.NodePoolSpecificRequest, reply *str
 // cannot be updated.
 func (n *NodePool) UpsertNodePools(args *structs.NodePoolUpsertRequest, reply *structs.GenericResponse) error {
 	authErr := n.srv.Authenticate(n.ctx, args)
-	if n.srv.config.ACLEnabled || args.Region == "" {
-		// only forward to the authoritative region if ACLs are enabled,
-		// otherwise we silently write to the local region
-		args.Region = n.srv.config.AuthoritativeRegion
-	}
+	// Node pools are replicated from the authoritative region, so writes are
+	// always forwarded to it
+	args.Region = n.srv.config.AuthoritativeRegion
 	if done, err := n.srv.forward("NodePool.UpsertNodePools", args, args, reply); done {
 		return err
 	}
@@ -235,11 +235,9 @@ func (n *NodePool) UpsertNodePools(args *structs.NodePoolUpsertRequest, reply *s
 // deleted.
 func (n *NodePool) DeleteNodePools(args *structs.NodePoolDeleteRequest, reply *structs.GenericResponse) error {
 	authErr := n.srv.Authenticate(n.ctx, args)
-	if n.srv.config.ACLEnabled || args.Region == "" {
-		// only forward to the authoritative region if ACLs are enabled,
-		// otherwise we silently write to the local region
-		args.Region = n.srv.config.AuthoritativeRegion
-	}
+	// Node pools are replicated from the authoritative region, so writes are
+	// always forwarded to it
+	args.Region = n.srv.config.AuthoritativeRegion
 	if done, err := n.srv.forward("NodePool.DeleteNodePools", args, args, reply); done {
 		return err
 	}
@@ -608,3 +606,221 @@ func (n *NodePool) ListNodes(args *structs.NodePoolNodesRequest, reply *structs.
 		}}
 	return n.srv.blockingRPC(&opts)
 }
+
+// StartNodeLifecycle starts a node lifecycle run cycling the nodes of a node
+// pool through maintenance. The run is driven by the leader, so it continues
+// after the caller disconnects. A node pool can have only one running run.
+func (n *NodePool) StartNodeLifecycle(args *structs.NodeLifecycleStartRequest, reply *structs.NodeLifecycleRunResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodePool.StartNodeLifecycle", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_pool", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_pool", "start_node_lifecycle"}, time.Now())
+
+	// Resolve ACL token and verify it has write capability for nodes and read
+	// capability for the node pool. Calling a webhook from the servers
+	// requires operator write capability.
+	aclObj, err := n.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	allowed := aclObj.AllowNodeWrite() &&
+		aclObj.AllowNodePoolOperation(args.NodePool, acl.NodePoolCapabilityRead)
+	if !allowed || (args.WebhookURL != "" && !aclObj.AllowOperatorWrite()) {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(n.srv.Members(), n.srv.Region(), minNodeLifecycleVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to start node lifecycle runs",
+			minNodeLifecycleVersion)
+	}
+
+	// Validate request.
+	args.Canonicalize()
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid node lifecycle run: %v", err)
+	}
+
+	store := n.srv.State()
+	pool, err := store.NodePoolByName(nil, args.NodePool)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound, "node pool %q not found", args.NodePool)
+	}
+
+	existing, err := store.NodeLifecycleRunByPool(nil, args.NodePool)
+	if err != nil {
+		return err
+	}
+	if existing != nil && !existing.Terminal() {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"node pool %q already has running node lifecycle run %s", args.NodePool, existing.ID)
+	}
+
+	iter, err := store.NodesByNodePool(nil, args.NodePool)
+	if err != nil {
+		return err
+	}
+	var nodes []*structs.NodeLifecycleNode
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		nodes = append(nodes, &structs.NodeLifecycleNode{
+			NodeID:          node.ID,
+			NodeName:        node.Name,
+			NodeCreateIndex: node.CreateIndex,
+			Phase:           structs.NodeLifecyclePhasePending,
+		})
+	}
+	if len(nodes) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "node pool %q has no nodes", args.NodePool)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].NodeName < nodes[j].NodeName })
+
+	now := time.Now().UnixNano()
+	run := &structs.NodeLifecycleRun{
+		ID:                uuid.Generate(),
+		NodePool:          args.NodePool,
+		BatchSize:         args.BatchSize,
+		DrainSpec:         args.DrainSpec,
+		WebhookURL:        args.WebhookURL,
+		ReregisterTimeout: args.ReregisterTimeout,
+		Status:            structs.NodeLifecycleStatusRunning,
+		Nodes:             nodes,
+		CreateTime:        now,
+		ModifyTime:        now,
+	}
+
+	// Update via Raft.
+	req := &structs.NodeLifecycleRunUpsertRequest{
+		Run:          run,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := n.srv.raftApply(structs.NodeLifecycleRunUpsertRequestType, req)
+	if err != nil {
+		return err
+	}
+
+	reply.Run = run
+	reply.Index = index
+	return nil
+}
+
+// AbortNodeLifecycle aborts the running node lifecycle run of a node pool. No
+// further nodes are cycled, but drains in progress are not cancelled and
+// nodes left ineligible by the run are not modified.
+func (n *NodePool) AbortNodeLifecycle(args *structs.NodeLifecycleAbortRequest, reply *structs.NodeLifecycleRunResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodePool.AbortNodeLifecycle", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_pool", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_pool", "abort_node_lifecycle"}, time.Now())
+
+	// Resolve ACL token and verify it has write capability for nodes and read
+	// capability for the node pool.
+	aclObj, err := n.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	allowed := aclObj.AllowNodeWrite() &&
+		aclObj.AllowNodePoolOperation(args.NodePool, acl.NodePoolCapabilityRead)
+	if !allowed {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(n.srv.Members(), n.srv.Region(), minNodeLifecycleVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to abort node lifecycle runs",
+			minNodeLifecycleVersion)
+	}
+
+	existing, err := n.srv.State().NodeLifecycleRunByPool(nil, args.NodePool)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.Terminal() {
+		return structs.NewErrRPCCodedf(http.StatusNotFound,
+			"no running node lifecycle run found for node pool %q", args.NodePool)
+	}
+
+	run := existing.Copy()
+	run.Status = structs.NodeLifecycleStatusAborted
+	run.StatusDescription = "Aborted by operator"
+	run.ModifyTime = time.Now().UnixNano()
+
+	// Update via Raft.
+	req := &structs.NodeLifecycleRunUpsertRequest{
+		Run:          run,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := n.srv.raftApply(structs.NodeLifecycleRunUpsertRequestType, req)
+	if err != nil {
+		return err
+	}
+
+	reply.Run = run
+	reply.Index = index
+	return nil
+}
+
+// GetNodeLifecycle returns the last node lifecycle run of a node pool or nil
+// if the node pool has none.
+func (n *NodePool) GetNodeLifecycle(args *structs.NodeLifecycleRunSpecificRequest, reply *structs.SingleNodeLifecycleRunResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodePool.GetNodeLifecycle", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_pool", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_pool", "get_node_lifecycle"}, time.Now())
+
+	// Resolve ACL token and verify it has read capability for nodes and the
+	// node pool.
+	aclObj, err := n.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	allowed := aclObj.AllowNodeRead() &&
+		aclObj.AllowNodePoolOperation(args.NodePool, acl.NodePoolCapabilityRead)
+	if !allowed {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query.
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			run, err := store.NodeLifecycleRunByPool(ws, args.NodePool)
+			if err != nil {
+				return err
+			}
+
+			reply.Run = run
+			if run != nil {
+				reply.Index = run.ModifyIndex
+			} else {
+				// Return the last index that affected the node lifecycle runs
+				// table if the node pool has no run.
+				index, err := store.Index(state.TableNodeLifecycleRuns)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+
+			n.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return n.srv.blockingRPC(&opts)
+}
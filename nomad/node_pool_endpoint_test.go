@@ -5,6 +5,8 @@ package nomad
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -662,6 +665,30 @@ func TestNodePoolEndpoint_UpsertNodePools(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "insert pool with scheduler configuration",
+			pools: []*structs.NodePool{
+				{
+					Name: "spread",
+					SchedulerConfiguration: &structs.NodePoolSchedulerConfiguration{
+						SchedulerAlgorithm:            structs.SchedulerAlgorithmSpread,
+						MemoryOversubscriptionEnabled: pointer.Of(true),
+					},
+				},
+			},
+		},
+		{
+			name: "invalid scheduler algorithm",
+			pools: []*structs.NodePool{
+				{
+					Name: "invalid-algorithm",
+					SchedulerConfiguration: &structs.NodePoolSchedulerConfiguration{
+						SchedulerAlgorithm: "random",
+					},
+				},
+			},
+			expectedErr: "invalid scheduler algorithm",
+		},
 		{
 			name: "invalid pool name",
 			pools: []*structs.NodePool{
@@ -1963,3 +1990,209 @@ func TestNodePoolEndpoint_ListNodes_BlockingQuery(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, 1002, resp.Index)
 }
+
+func TestNodePoolEndpoint_NodeLifecycle(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// The webhook never gets the nodes to register again, so the run stays
+	// running until it's aborted.
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer webhook.Close()
+
+	// Populate state.
+	pool := mock.NodePool()
+	empty := mock.NodePool()
+	err := s.fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool, empty})
+	must.NoError(t, err)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Name, node2.Name = "node-b", "node-a"
+	node1.NodePool, node2.NodePool = pool.Name, pool.Name
+	must.NoError(t, s.fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1001, node1))
+	must.NoError(t, s.fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1002, node2))
+
+	start := func(req *structs.NodeLifecycleStartRequest) (*structs.NodeLifecycleRunResponse, error) {
+		req.WriteRequest = structs.WriteRequest{Region: "global"}
+		var resp structs.NodeLifecycleRunResponse
+		err := msgpackrpc.CallWithCodec(codec, "NodePool.StartNodeLifecycle", req, &resp)
+		return &resp, err
+	}
+
+	// Invalid requests are rejected.
+	_, err = start(&structs.NodeLifecycleStartRequest{NodePool: structs.NodePoolAll})
+	must.ErrorContains(t, err, "can't be cycled")
+	_, err = start(&structs.NodeLifecycleStartRequest{NodePool: pool.Name, BatchSize: -1})
+	must.ErrorContains(t, err, "batch size must be greater than zero")
+	_, err = start(&structs.NodeLifecycleStartRequest{NodePool: pool.Name, WebhookURL: "ftp://example.com"})
+	must.ErrorContains(t, err, "webhook URL must use http or https")
+	_, err = start(&structs.NodeLifecycleStartRequest{NodePool: "unknown"})
+	must.ErrorContains(t, err, "not found")
+	_, err = start(&structs.NodeLifecycleStartRequest{NodePool: empty.Name})
+	must.ErrorContains(t, err, "has no nodes")
+
+	// Start a run with the default settings.
+	resp, err := start(&structs.NodeLifecycleStartRequest{
+		NodePool:   pool.Name,
+		WebhookURL: webhook.URL,
+	})
+	must.NoError(t, err)
+	must.NotNil(t, resp.Run)
+	must.Eq(t, 1, resp.Run.BatchSize)
+	must.Eq(t, structs.NodeLifecycleDefaultDrainDeadline, resp.Run.DrainSpec.Deadline)
+	must.Eq(t, structs.NodeLifecycleDefaultReregisterTimeout, resp.Run.ReregisterTimeout)
+	must.Eq(t, structs.NodeLifecycleStatusRunning, resp.Run.Status)
+
+	// Nodes are cycled in the order of their name.
+	must.Len(t, 2, resp.Run.Nodes)
+	must.Eq(t, node2.ID, resp.Run.Nodes[0].NodeID)
+	must.Eq(t, node1.ID, resp.Run.Nodes[1].NodeID)
+
+	// A second run can't be started while the first one is running.
+	_, err = start(&structs.NodeLifecycleStartRequest{NodePool: pool.Name})
+	must.ErrorContains(t, err, "already has running node lifecycle run")
+
+	// Read the run.
+	getReq := &structs.NodeLifecycleRunSpecificRequest{
+		NodePool:     pool.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleNodeLifecycleRunResponse
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.GetNodeLifecycle", getReq, &getResp)
+	must.NoError(t, err)
+	must.NotNil(t, getResp.Run)
+	must.Eq(t, resp.Run.ID, getResp.Run.ID)
+
+	// Abort the run.
+	abortReq := &structs.NodeLifecycleAbortRequest{
+		NodePool:     pool.Name,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var abortResp structs.NodeLifecycleRunResponse
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.AbortNodeLifecycle", abortReq, &abortResp)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeLifecycleStatusAborted, abortResp.Run.Status)
+
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.GetNodeLifecycle", getReq, &getResp)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeLifecycleStatusAborted, getResp.Run.Status)
+
+	// Aborting a run that is not running fails.
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.AbortNodeLifecycle", abortReq, &abortResp)
+	must.ErrorContains(t, err, "no running node lifecycle run")
+
+	// A node pool without a run returns nothing.
+	getReq.NodePool = empty.Name
+	getResp = structs.SingleNodeLifecycleRunResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.GetNodeLifecycle", getReq, &getResp)
+	must.NoError(t, err)
+	must.Nil(t, getResp.Run)
+}
+
+func TestNodePoolEndpoint_NodeLifecycle_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Populate state.
+	pool := mock.NodePool()
+	pool.Name = "dev-1"
+	err := s.fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool})
+	must.NoError(t, err)
+
+	node := mock.Node()
+	node.NodePool = pool.Name
+	err = s.fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1001, node)
+	must.NoError(t, err)
+
+	// Create test ACL tokens.
+	validToken := mock.CreatePolicyAndToken(t, s.fsm.State(), 1002, "valid",
+		fmt.Sprintf("%s\n%s", mock.NodePoolPolicy("dev-*", "read", nil), mock.NodePolicy("write")),
+	)
+	readToken := mock.CreatePolicyAndToken(t, s.fsm.State(), 1004, "read",
+		fmt.Sprintf("%s\n%s", mock.NodePoolPolicy("dev-*", "read", nil), mock.NodePolicy("read")),
+	)
+	nodeOnlyToken := mock.CreatePolicyAndToken(t, s.fsm.State(), 1006, "node-only",
+		mock.NodePolicy("write"),
+	)
+
+	testCases := []struct {
+		name        string
+		token       string
+		webhook     string
+		expectedErr string
+	}{
+		{
+			name:        "read token not allowed",
+			token:       readToken.SecretID,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:        "node only not enough",
+			token:       nodeOnlyToken.SecretID,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:        "webhook requires operator write",
+			token:       validToken.SecretID,
+			webhook:     "http://127.0.0.1:0",
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:  "valid token is allowed",
+			token: validToken.SecretID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &structs.NodeLifecycleStartRequest{
+				NodePool:   pool.Name,
+				WebhookURL: tc.webhook,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					AuthToken: tc.token,
+				},
+			}
+			var resp structs.NodeLifecycleRunResponse
+			err := msgpackrpc.CallWithCodec(codec, "NodePool.StartNodeLifecycle", req, &resp)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+				must.NotNil(t, resp.Run)
+			}
+		})
+	}
+
+	// Reading the run requires node read capability.
+	getReq := &structs.NodeLifecycleRunSpecificRequest{
+		NodePool: pool.Name,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var getResp structs.SingleNodeLifecycleRunResponse
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.GetNodeLifecycle", getReq, &getResp)
+	must.NoError(t, err)
+	must.NotNil(t, getResp.Run)
+
+	getReq.AuthToken = nodeOnlyToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.GetNodeLifecycle", getReq, &getResp)
+	must.ErrorContains(t, err, structs.ErrPermissionDenied.Error())
+
+	// The management token can do anything.
+	getReq.AuthToken = root.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "NodePool.GetNodeLifecycle", getReq, &getResp)
+	must.NoError(t, err)
+}
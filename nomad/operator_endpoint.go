@@ -13,12 +13,15 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
 
+	"github.com/hashicorp/nomad/acl"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -553,6 +556,254 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// PlanRejectionTrackerGet is used to retrieve the nodes tracked by the plan
+// rejection tracker of the leader.
+func (op *Operator) PlanRejectionTrackerGet(args *structs.GenericRequest, reply *structs.PlanRejectionTrackerResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	// The tracker only exists in memory on the leader, so stale reads are
+	// not supported.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.PlanRejectionTrackerGet", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.Enabled = op.srv.config.NodePlanRejectionEnabled
+	reply.Threshold = op.srv.config.NodePlanRejectionThreshold
+	reply.Window = op.srv.config.NodePlanRejectionWindow
+	reply.Nodes = op.srv.planner.badNodeTracker.Nodes()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// ReplicationConflicts is used to retrieve the namespaces and node pools of
+// the authoritative region that the leader can't replicate.
+func (op *Operator) ReplicationConflicts(args *structs.GenericRequest, reply *structs.ReplicationConflictsResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	// The conflicts are only tracked in memory by the leader, so stale reads
+	// are not supported.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.ReplicationConflicts", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.Conflicts = op.srv.replicationConflicts.list()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// PlanQueueStatus is used to retrieve the plans waiting in the plan queue of
+// the leader and how long plans wait before being evaluated.
+func (op *Operator) PlanQueueStatus(args *structs.GenericRequest, reply *structs.PlanQueueStatusResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	// The plan queue only exists in memory on the leader, so stale reads are
+	// not supported.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.PlanQueueStatus", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.PlanQueue = op.srv.planQueue.Status()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// SchedulerSimulate is used to run the scheduler for a job against the current
+// state and evaluate the resulting plan without committing it, to report where
+// allocations would be placed, why nodes were filtered and which allocations
+// would be preempted.
+func (op *Operator) SchedulerSimulate(args *structs.SchedulerSimulateRequest, reply *structs.SchedulerSimulateResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerSimulate", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "operator", "scheduler_simulate"}, time.Now())
+
+	if args.Job == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "Job required for simulation")
+	}
+
+	// This action requires operator read access, since the simulation
+	// exposes nodes and the allocations of other jobs, as well as job
+	// submission permissions, which we assume is the same as for a plan.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() ||
+		!aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Run admission controllers so the job is scheduled as registered
+	job, warnings, err := NewJobEndpoints(op.srv, op.ctx).admissionControllers(args.Job)
+	if err != nil {
+		return err
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	simulation, err := SimulateJob(op.logger, snap, job)
+	if err != nil {
+		return err
+	}
+	simulation.Warnings = helper.MergeMultierrorWarnings(warnings...)
+
+	reply.Simulation = simulation
+	reply.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// EvalBrokerStatus is used to retrieve the number of evaluations in each
+// state of the eval broker of the leader, along with its dequeue, Nack, and
+// requeue rates.
+func (op *Operator) EvalBrokerStatus(args *structs.GenericRequest, reply *structs.EvalBrokerStatusResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	// The eval broker only exists in memory on the leader, so stale reads are
+	// not supported.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.EvalBrokerStatus", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.EvalBroker = op.srv.evalBroker.Status()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// StateUsage is used to retrieve the number of objects and their approximate
+// size in each table of the state store, to find which tables grow the memory
+// usage of the servers.
+func (op *Operator) StateUsage(args *structs.GenericRequest, reply *structs.StateUsageResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.StateUsage", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	state := op.srv.fsm.State()
+	tables, err := state.TableUsage()
+	if err != nil {
+		return err
+	}
+	index, err := state.LatestIndex()
+	if err != nil {
+		return err
+	}
+	reply.Tables = tables
+	reply.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// PlanRejectionTrackerReset is used to clear the plan rejection history of a
+// node, or of all nodes, in the plan rejection tracker of the leader. Nodes
+// already marked as ineligible are not modified.
+func (op *Operator) PlanRejectionTrackerReset(args *structs.PlanRejectionTrackerResetRequest, reply *structs.PlanRejectionTrackerResetResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.PlanRejectionTrackerReset", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator write access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.Removed = op.srv.planner.badNodeTracker.Reset(args.NodeID)
+	reply.Index, _ = op.srv.State().LatestIndex()
+
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -646,8 +897,11 @@ func (op *Operator) snapshotSave(conn io.ReadWriteCloser) {
 
 	op.srv.setQueryMeta(&reply.QueryMeta)
 
-	// Take the snapshot and capture the index.
+	// Take the snapshot and capture the index. The operator is waiting for
+	// it, so it is not throttled.
+	restoreRate := op.srv.fsm.disablePersistRate()
 	snap, err := snapshot.New(op.logger.Named("snapshot"), op.srv.raft)
+	restoreRate()
 	reply.SnapshotChecksum = snap.Checksum()
 	reply.Index = snap.Index()
 	if err != nil {
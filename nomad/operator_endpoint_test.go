@@ -790,6 +790,68 @@ func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
 
 }
 
+func TestOperator_PlanRejectionTracker(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NodePlanRejectionEnabled = true
+		c.NodePlanRejectionThreshold = 10
+		c.NodePlanRejectionWindow = time.Minute
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+
+	s1.planner.badNodeTracker.Add("node-1")
+	s1.planner.badNodeTracker.Add("node-1")
+	s1.planner.badNodeTracker.Add("node-2")
+
+	getReq := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: s1.config.Region},
+	}
+	var getResp structs.PlanRejectionTrackerResponse
+
+	// No token
+	err := msgpackrpc.CallWithCodec(codec, "Operator.PlanRejectionTrackerGet", getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	getReq.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.PlanRejectionTrackerGet", getReq, &getResp)
+	require.NoError(t, err)
+	require.True(t, getResp.Enabled)
+	require.Equal(t, 10, getResp.Threshold)
+	require.Equal(t, time.Minute, getResp.Window)
+	require.Len(t, getResp.Nodes, 2)
+	require.Equal(t, "node-1", getResp.Nodes[0].NodeID)
+	require.Equal(t, 2, getResp.Nodes[0].Score)
+
+	resetReq := &structs.PlanRejectionTrackerResetRequest{
+		NodeID: "node-1",
+		WriteRequest: structs.WriteRequest{
+			Region:    s1.config.Region,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var resetResp structs.PlanRejectionTrackerResetResponse
+
+	// Reset requires operator write
+	err = msgpackrpc.CallWithCodec(codec, "Operator.PlanRejectionTrackerReset", resetReq, &resetResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	resetReq.AuthToken = root.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.PlanRejectionTrackerReset", resetReq, &resetResp)
+	require.NoError(t, err)
+	require.Equal(t, 1, resetResp.Removed)
+
+	err = msgpackrpc.CallWithCodec(codec, "Operator.PlanRejectionTrackerGet", getReq, &getResp)
+	require.NoError(t, err)
+	require.Len(t, getResp.Nodes, 1)
+	require.Equal(t, "node-2", getResp.Nodes[0].NodeID)
+}
+
 func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1280,3 +1342,204 @@ func TestOperator_SnapshotRestore_ACL(t *testing.T) {
 		})
 	}
 }
+
+func TestOperator_PlanQueueStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+	nodeToken := mock.CreatePolicyAndToken(t, state, 1002, "node-read", `node { policy = "read" }`)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: s1.config.Region},
+	}
+	var resp structs.PlanQueueStatusResponse
+
+	// No token
+	err := msgpackrpc.CallWithCodec(codec, "Operator.PlanQueueStatus", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Token without operator read
+	req.AuthToken = nodeToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.PlanQueueStatus", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.PlanQueueStatus", req, &resp)
+	require.NoError(t, err)
+	require.NotNil(t, resp.PlanQueue)
+	require.True(t, resp.PlanQueue.Enabled)
+	require.Zero(t, resp.PlanQueue.Depth)
+}
+
+func TestOperator_ReplicationConflicts(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+	nodeToken := mock.CreatePolicyAndToken(t, state, 1002, "node-read", `node { policy = "read" }`)
+
+	s1.replicationConflicts.set(structs.ReplicationConflictNamespace, []*structs.ReplicationConflict{{
+		Type:   structs.ReplicationConflictNamespace,
+		Name:   "prod",
+		Reason: "in use",
+		Since:  time.Now(),
+	}})
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: s1.config.Region},
+	}
+	var resp structs.ReplicationConflictsResponse
+
+	// No token
+	err := msgpackrpc.CallWithCodec(codec, "Operator.ReplicationConflicts", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Token without operator read
+	req.AuthToken = nodeToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.ReplicationConflicts", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.ReplicationConflicts", req, &resp)
+	must.NoError(t, err)
+	must.Len(t, 1, resp.Conflicts)
+	must.Eq(t, "prod", resp.Conflicts[0].Name)
+}
+
+func TestOperator_EvalBrokerStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+	nodeToken := mock.CreatePolicyAndToken(t, state, 1002, "node-read", `node { policy = "read" }`)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: s1.config.Region},
+	}
+	var resp structs.EvalBrokerStatusResponse
+
+	// No token
+	err := msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerStatus", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Token without operator read
+	req.AuthToken = nodeToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerStatus", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerStatus", req, &resp)
+	must.NoError(t, err)
+	must.NotNil(t, resp.EvalBroker)
+	must.True(t, resp.EvalBroker.Enabled)
+	must.Eq(t, structs.EvalBrokerDequeueModePriority, resp.EvalBroker.DequeueMode)
+}
+
+func TestOperator_StateUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+	nodeToken := mock.CreatePolicyAndToken(t, state, 1002, "node-read", `node { policy = "read" }`)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, mock.Job()))
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: s1.config.Region},
+	}
+	var resp structs.StateUsageResponse
+
+	// No token
+	err := msgpackrpc.CallWithCodec(codec, "Operator.StateUsage", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Token without operator read
+	req.AuthToken = nodeToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.StateUsage", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.StateUsage", req, &resp)
+	must.NoError(t, err)
+	must.NotEq(t, 0, resp.Index)
+
+	var jobs *structs.StateTableUsage
+	for _, table := range resp.Tables {
+		if table.Name == "jobs" {
+			jobs = table
+		}
+	}
+	must.NotNil(t, jobs)
+	must.Eq(t, 1, jobs.Objects)
+	must.Positive(t, jobs.SizeBytes)
+}
+
+func TestOperator_SchedulerSimulate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+	submitToken := mock.CreatePolicyAndToken(t, state, 1002, "submit-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	token := mock.CreatePolicyAndToken(t, state, 1003, "simulate",
+		`operator { policy = "read" }`+
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	req := &structs.SchedulerSimulateRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    s1.config.Region,
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.SchedulerSimulateResponse
+
+	// Both operator read and submit-job are required
+	for _, secretID := range []string{"", readToken.SecretID, submitToken.SecretID} {
+		req.AuthToken = secretID
+		err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", req, &resp)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	}
+
+	req.AuthToken = token.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", req, &resp))
+	must.NotNil(t, resp.Simulation)
+	must.Len(t, 1, resp.Simulation.Placements)
+	must.Eq(t, node.ID, resp.Simulation.Placements[0].NodeID)
+	must.MapEmpty(t, resp.Simulation.FailedTGAllocs)
+
+	// The job is not registered
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
@@ -7,6 +7,7 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 	"sync"
@@ -279,8 +280,9 @@ func (p *PeriodicDispatch) removeLocked(jobID structs.NamespacedID) error {
 }
 
 // ForceEval causes the periodic job to be evaluated immediately and returns the
-// subsequent eval.
-func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluation, error) {
+// subsequent eval. The meta and env overrides, if any, are applied to the
+// launched child job.
+func (p *PeriodicDispatch) ForceEval(namespace, jobID string, meta, env map[string]string) (*structs.Evaluation, error) {
 	p.l.Lock()
 
 	// Do nothing if not enabled
@@ -300,7 +302,45 @@ func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluati
 	}
 
 	p.l.Unlock()
-	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
+	launch := time.Now().In(job.Periodic.GetLocation())
+	if len(meta) == 0 && len(env) == 0 {
+		return p.createEval(job, launch)
+	}
+
+	derived, err := p.deriveJob(job, launch)
+	if err != nil {
+		return nil, err
+	}
+	applyPeriodicForceOverrides(derived, meta, env)
+
+	eval, err := p.dispatcher.DispatchJob(derived)
+	if err != nil {
+		p.logger.Error("failed to dispatch job", "job", job.NamespacedID(), "error", err)
+		return nil, err
+	}
+	return eval, nil
+}
+
+// applyPeriodicForceOverrides merges the meta overrides into the metadata of
+// the derived job and the env overrides into the environment of all its tasks.
+func applyPeriodicForceOverrides(derived *structs.Job, meta, env map[string]string) {
+	if len(meta) > 0 {
+		if derived.Meta == nil {
+			derived.Meta = make(map[string]string, len(meta))
+		}
+		maps.Copy(derived.Meta, meta)
+	}
+
+	if len(env) > 0 {
+		for _, tg := range derived.TaskGroups {
+			for _, task := range tg.Tasks {
+				if task.Env == nil {
+					task.Env = make(map[string]string, len(env))
+				}
+				maps.Copy(task.Env, env)
+			}
+		}
+	}
 }
 
 // shouldRun returns whether the long lived run function should run.
@@ -50,6 +50,16 @@ func (p *Periodic) Force(args *structs.PeriodicForceRequest, reply *structs.Peri
 	if args.JobID == "" {
 		return fmt.Errorf("missing job ID for evaluation")
 	}
+	for k := range args.Meta {
+		if k == "" {
+			return fmt.Errorf("meta override keys must not be empty")
+		}
+	}
+	for k := range args.Env {
+		if k == "" {
+			return fmt.Errorf("env override keys must not be empty")
+		}
+	}
 
 	// Lookup the job
 	snap, err := p.srv.fsm.State().Snapshot()
@@ -71,7 +81,7 @@ func (p *Periodic) Force(args *structs.PeriodicForceRequest, reply *structs.Peri
 	}
 
 	// Force run the job.
-	eval, err := p.srv.periodicDispatcher.ForceEval(args.RequestNamespace(), job.ID)
+	eval, err := p.srv.periodicDispatcher.ForceEval(args.RequestNamespace(), job.ID, args.Meta, args.Env)
 	if err != nil {
 		return fmt.Errorf("force launch for job %q failed: %v", job.ID, err)
 	}
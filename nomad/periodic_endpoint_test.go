@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -67,6 +68,52 @@ func TestPeriodicEndpoint_Force(t *testing.T) {
 	}
 }
 
+func TestPeriodicEndpoint_Force_Overrides(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create and insert a periodic job.
+	job := mock.PeriodicJob()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+	s1.periodicDispatcher.Add(job)
+
+	// Empty override keys are rejected
+	req := &structs.PeriodicForceRequest{
+		JobID: job.ID,
+		Meta:  map[string]string{"": "value"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.PeriodicForceResponse
+	err := msgpackrpc.CallWithCodec(codec, "Periodic.Force", req, &resp)
+	must.ErrorContains(t, err, "meta override keys must not be empty")
+
+	// Force launch it with overrides.
+	req.Meta = map[string]string{"date": "2024-01-01"}
+	req.Env = map[string]string{"DRY_RUN": "true"}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Periodic.Force", req, &resp))
+
+	// The overrides are recorded on the child job
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+	child, err := state.JobByID(nil, job.Namespace, eval.JobID)
+	must.NoError(t, err)
+	must.NotNil(t, child)
+	must.Eq(t, job.ID, child.ParentID)
+	must.Eq(t, "2024-01-01", child.Meta["date"])
+	must.Eq(t, "true", child.TaskGroups[0].Tasks[0].Env["DRY_RUN"])
+}
+
 func TestPeriodicEndpoint_Force_ACL(t *testing.T) {
 	ci.Parallel(t)
 
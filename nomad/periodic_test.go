@@ -364,7 +364,7 @@ func TestPeriodicDispatch_ForceEval_Untracked(t *testing.T) {
 	ci.Parallel(t)
 	p, _ := testPeriodicDispatcher(t)
 
-	if _, err := p.ForceEval("ns", "foo"); err == nil {
+	if _, err := p.ForceEval("ns", "foo", nil, nil); err == nil {
 		t.Fatal("ForceEval of untracked job should fail")
 	}
 }
@@ -382,7 +382,7 @@ func TestPeriodicDispatch_ForceEval_Tracked(t *testing.T) {
 	}
 
 	// ForceEval the job
-	if _, err := p.ForceEval(job.Namespace, job.ID); err != nil {
+	if _, err := p.ForceEval(job.Namespace, job.ID, nil, nil); err != nil {
 		t.Fatalf("ForceEval failed %v", err)
 	}
 
@@ -398,6 +398,36 @@ func TestPeriodicDispatch_ForceEval_Tracked(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_ForceEval_Overrides(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that won't be evaluated for a while.
+	job := testPeriodicJob(time.Now().Add(10 * time.Second))
+	job.Meta = map[string]string{"date": "today", "owner": "ops"}
+	must.NoError(t, p.Add(job))
+
+	meta := map[string]string{"date": "yesterday"}
+	env := map[string]string{"DRY_RUN": "true"}
+	_, err := p.ForceEval(job.Namespace, job.ID, meta, env)
+	must.NoError(t, err)
+
+	must.MapLen(t, 1, m.Jobs)
+	for _, child := range m.Jobs {
+		must.Eq(t, job.ID, child.ParentID)
+		must.Eq(t, map[string]string{"date": "yesterday", "owner": "ops"}, child.Meta)
+		for _, tg := range child.TaskGroups {
+			for _, task := range tg.Tasks {
+				must.Eq(t, "true", task.Env["DRY_RUN"])
+			}
+		}
+	}
+
+	// The periodic job itself is not modified
+	must.Eq(t, "today", job.Meta["date"])
+	must.MapNotContainsKey(t, job.TaskGroups[0].Tasks[0].Env, "DRY_RUN")
+}
+
 func TestPeriodicDispatch_Run_DisallowOverlaps(t *testing.T) {
 	ci.Parallel(t)
 	p, m := testPeriodicDispatcher(t)
@@ -6,7 +6,7 @@ package nomad
 import (
 	"context"
 	"fmt"
-	"runtime"
+	"strings"
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
@@ -44,6 +44,7 @@ func newPlanner(s *Server) (*planner, error) {
 	if err != nil {
 		return nil, err
 	}
+	planQueue.SetAging(s.config.PlanQueueAgingInterval, s.config.JobMaxPriority)
 
 	// Create the bad node tracker.
 	var badNodeTracker BadNodeTracker
@@ -93,11 +94,17 @@ func newPlanner(s *Server) (*planner, error) {
 // in anticipation of this case we cannot respond to the plan until
 // the Raft log is updated. This means our schedulers will stall,
 // but there are many of those and only a single plan verifier.
+//
+// Plan N+1 normally waits for plan N to be committed before being applied.
+// If plan N+1 modifies none of the nodes, jobs and deployments modified by
+// the outstanding plans, its result does not depend on theirs, so it is
+// applied without waiting and several Raft transactions are in flight at
+// the same time.
 func (p *planner) planApply() {
-	// planIndexCh is used to track an outstanding application and receive
-	// its committed index while snap holds an optimistic state which
-	// includes that plan application.
-	var planIndexCh chan uint64
+	// inflight tracks the outstanding applications to receive their
+	// committed index while snap holds an optimistic state which includes
+	// those plan applications.
+	var inflight []*inflightPlan
 	var snap *state.StateSnapshot
 
 	// prevPlanResultIndex is the index when the last PlanResult was
@@ -110,13 +117,12 @@ func (p *planner) planApply() {
 	// against an index older than the previous plan was committed at.
 	var prevPlanResultIndex uint64
 
-	// Setup a worker pool with half the cores, with at least 1
-	poolSize := runtime.NumCPU() / 2
-	if poolSize == 0 {
-		poolSize = 1
-	}
-	pool := NewEvaluatePool(poolSize, workerPoolBufferSize)
+	// Setup a worker pool that starts at its minimum size and is resized
+	// for each plan.
+	poolMin, _ := planEvaluatePoolBounds(p.srv.config, nil)
+	pool := NewEvaluatePool(poolMin, workerPoolBufferSize)
 	defer pool.Shutdown()
+	var sizer evaluatePoolSizer
 
 	for {
 		// Pull the next pending plan, exit if we are no longer leader
@@ -125,16 +131,20 @@ func (p *planner) planApply() {
 			return
 		}
 
-		// If last plan has completed get a new snapshot
-		select {
-		case idx := <-planIndexCh:
-			// Previous plan committed. Discard snapshot and ensure
-			// future snapshots include this plan. idx may be 0 if
-			// plan failed to apply, so use max(prev, idx)
-			prevPlanResultIndex = max(prevPlanResultIndex, idx)
-			planIndexCh = nil
+		// If the outstanding plans have completed get a new snapshot.
+		// Ensure future snapshots include those plans. Their index may be
+		// 0 if they failed to apply, so use max(prev, idx)
+		var failed bool
+		inflight, prevPlanResultIndex, failed = reapCommittedPlans(inflight, prevPlanResultIndex)
+		if failed {
+			// The snapshot includes the optimistic result of a plan that
+			// was never committed. Wait for the other plans in flight so
+			// the next snapshot is taken from the state store only.
+			prevPlanResultIndex, _ = waitCommittedPlans(inflight, prevPlanResultIndex)
+			inflight = inflight[:0]
+		}
+		if len(inflight) == 0 {
 			snap = nil
-		default:
 		}
 
 		if snap != nil {
@@ -149,10 +159,10 @@ func (p *planner) planApply() {
 
 		// Snapshot the state so that we have a consistent view of the world
 		// if no snapshot is available.
-		//  - planIndexCh will be nil if the previous plan result applied
+		//  - inflight will be empty if the previous plan results applied
 		//    during Dequeue
 		//  - snap will be nil if its index < max(prevIndex, curIndex)
-		if planIndexCh == nil || snap == nil {
+		if len(inflight) == 0 || snap == nil {
 			snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
 			if err != nil {
 				p.srv.logger.Error("failed to snapshot state", "error", err)
@@ -161,6 +171,8 @@ func (p *planner) planApply() {
 			}
 		}
 
+		p.resizeEvaluatePool(pool, &sizer, snap, pending.plan)
+
 		// Evaluate the plan
 		result, err := evaluatePlan(pool, snap, pending.plan, p.srv.logger)
 		if err != nil {
@@ -182,12 +194,13 @@ func (p *planner) planApply() {
 			continue
 		}
 
-		// Ensure any parallel apply is complete before starting the next one.
-		// This also limits how out of date our snapshot can be.
-		if planIndexCh != nil {
-			idx := <-planIndexCh
-			planIndexCh = nil
-			prevPlanResultIndex = max(prevPlanResultIndex, idx)
+		// Ensure any parallel apply is complete before starting the next one,
+		// unless the plan is disjoint from all of them. This also limits how
+		// out of date our snapshot can be.
+		footprint := newPlanFootprint(pending.plan, result)
+		if len(inflight) > 0 && !p.canApplyInParallel(inflight, footprint) {
+			prevPlanResultIndex, _ = waitCommittedPlans(inflight, prevPlanResultIndex)
+			inflight = inflight[:0]
 			snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
 			if err != nil {
 				p.srv.logger.Error("failed to update snapshot state", "error", err)
@@ -205,9 +218,34 @@ func (p *planner) planApply() {
 		}
 
 		// Respond to the plan in async; receive plan's committed index via chan
-		planIndexCh = make(chan uint64, 1)
+		planIndexCh := make(chan uint64, 1)
 		go p.asyncPlanWait(planIndexCh, future, result, pending)
+
+		inflight = append(inflight, &inflightPlan{indexCh: planIndexCh, footprint: footprint})
+		if len(inflight) > 1 {
+			metrics.IncrCounter([]string{"nomad", "plan", "apply_parallel"}, 1)
+		}
+	}
+}
+
+// resizeEvaluatePool adjusts the size of the evaluate pool to the plan queue
+// depth and the number of nodes in the plan, within the bounds set by the
+// server and scheduler configurations.
+func (p *planner) resizeEvaluatePool(pool *EvaluatePool, sizer *evaluatePoolSizer, snap *state.StateSnapshot, plan *structs.Plan) {
+	// Errors are ignored to fall back to the server configuration.
+	_, schedConfig, _ := snap.SchedulerConfig()
+	poolMin, poolMax := planEvaluatePoolBounds(p.srv.config, schedConfig)
+
+	depth := p.planQueue.Stats().Depth
+	planNodes := len(plan.NodeUpdate) + len(plan.NodeAllocation)
+
+	size := sizer.size(time.Now(), pool.Size(), poolMin, poolMax, depth, planNodes)
+	if size != pool.Size() {
+		p.srv.logger.Trace("resizing plan evaluate pool", "from", pool.Size(), "to", size,
+			"queue_depth", depth, "plan_nodes", planNodes)
+		pool.SetSize(size)
 	}
+	metrics.SetGauge([]string{"nomad", "plan", "evaluate_pool_size"}, float32(size))
 }
 
 // snapshotMinIndex wraps SnapshotAfter with a 10s timeout and converts timeout
@@ -251,10 +289,12 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 		DeploymentUpdates: result.DeploymentUpdates,
 		IneligibleNodes:   result.IneligibleNodes,
 		EvalID:            plan.EvalID,
+		PlanID:            plan.ID,
 		UpdatedAt:         unixNow,
 	}
 
 	preemptedJobIDs := make(map[structs.NamespacedID]struct{})
+	var placed []*structs.Allocation
 
 	if ServersMeetMinimumVersion(p.srv.Members(), p.srv.Region(), MinVersionPlanNormalization, true) {
 		// Initialize the allocs request using the new optimized log entry format.
@@ -276,7 +316,7 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 
 		// Set the time the alloc was applied for the first time. This can be used
 		// to approximate the scheduling time.
-		updateAllocTimestamps(req.AllocsUpdated, unixNow)
+		placed = updateAllocTimestamps(req.AllocsUpdated, plan, unixNow)
 
 		err := signAllocIdentities(p.srv.encrypter, plan.Job, req.AllocsUpdated, now)
 		if err != nil {
@@ -317,12 +357,13 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 
 		// Set the time the alloc was applied for the first time. This can be used
 		// to approximate the scheduling time.
-		updateAllocTimestamps(req.Alloc, unixNow)
+		placed = updateAllocTimestamps(req.Alloc, plan, unixNow)
 
 		// Set modify time for preempted allocs if any
 		// Also gather jobids to create follow up evals
 		for _, alloc := range req.NodePreemptions {
 			alloc.ModifyTime = unixNow
+			alloc.PreemptionDeadline = alloc.PreemptionDeadlineAt(unixNow)
 			appendNamespacedJobID(preemptedJobIDs, alloc)
 		}
 	}
@@ -353,6 +394,10 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 		return nil, err
 	}
 
+	for _, alloc := range placed {
+		measureAllocTimestamps(alloc.Namespace, nil, alloc.Timestamps)
+	}
+
 	// Optimistically apply to our state view
 	if snap != nil {
 		nextIdx := p.srv.raft.AppliedIndex() + 1
@@ -372,6 +417,7 @@ func normalizePreemptedAlloc(preemptedAlloc *structs.Allocation, now int64) *str
 		ID:                    preemptedAlloc.ID,
 		PreemptedByAllocation: preemptedAlloc.PreemptedByAllocation,
 		ModifyTime:            now,
+		PreemptionDeadline:    preemptedAlloc.PreemptionDeadlineAt(now),
 	}
 }
 
@@ -398,17 +444,37 @@ func appendNamespacedJobID(jobIDs map[structs.NamespacedID]struct{}, alloc *stru
 }
 
 // updateAllocTimestamps sets the CreateTime and ModifyTime for the allocations
-// to the timestamp provided
-func updateAllocTimestamps(allocations []*structs.Allocation, timestamp int64) {
+// to the timestamp provided, and records the placement timestamps of the plan
+// on the new allocations, which are returned.
+func updateAllocTimestamps(allocations []*structs.Allocation, plan *structs.Plan, timestamp int64) []*structs.Allocation {
+	var placed []*structs.Allocation
 	for _, alloc := range allocations {
 		if alloc.CreateTime == 0 {
 			alloc.CreateTime = timestamp
+			alloc.Timestamps = &structs.AllocTimestamps{
+				EvalCreateTime: plan.EvalCreateTime,
+				PlanSubmitTime: plan.SubmitTime,
+				PlanApplyTime:  timestamp,
+			}
+			placed = append(placed, alloc)
 		}
 		alloc.ModifyTime = timestamp
 	}
+	return placed
 }
 
+// signAllocIdentities signs the default identity of every task of the
+// allocations that doesn't have one yet. All the identities are signed in a
+// single batch to avoid the cost of signing each one separately on large plans.
 func signAllocIdentities(signer claimSigner, job *structs.Job, allocations []*structs.Allocation, now time.Time) error {
+	type signedTask struct {
+		alloc    *structs.Allocation
+		taskName string
+	}
+
+	var claims []*structs.IdentityClaims
+	var tasks []signedTask
+
 	for _, alloc := range allocations {
 		if alloc.SignedIdentities == nil {
 			alloc.SignedIdentities = map[string]string{}
@@ -421,19 +487,26 @@ func signAllocIdentities(signer claimSigner, job *structs.Job, allocations []*st
 			}
 			defaultWI := &structs.WorkloadIdentity{Name: "default"}
 
-			claims := structs.NewIdentityClaimsBuilder(
+			claims = append(claims, structs.NewIdentityClaimsBuilder(
 				job, alloc, task.IdentityHandle(defaultWI), task.Identity).
 				WithTask(task).
-				Build(now)
-
-			token, keyID, err := signer.SignClaims(claims)
-			if err != nil {
-				return err
-			}
-			alloc.SignedIdentities[task.Name] = token
-			alloc.SigningKeyID = keyID
+				Build(now))
+			tasks = append(tasks, signedTask{alloc: alloc, taskName: task.Name})
 		}
 	}
+
+	if len(claims) == 0 {
+		return nil
+	}
+
+	tokens, keyID, err := signer.SignClaimsBatch(claims)
+	if err != nil {
+		return err
+	}
+	for i, task := range tasks {
+		task.alloc.SignedIdentities[task.taskName] = tokens[i]
+		task.alloc.SigningKeyID = keyID
+	}
 	return nil
 }
 
@@ -442,7 +515,8 @@ func signAllocIdentities(signer claimSigner, job *structs.Job, allocations []*st
 // closed.
 func (p *planner) asyncPlanWait(indexCh chan<- uint64, future raft.ApplyFuture,
 	result *structs.PlanResult, pending *pendingPlan) {
-	defer metrics.MeasureSince([]string{"nomad", "plan", "apply"}, time.Now())
+	start := time.Now()
+	defer metrics.MeasureSince([]string{"nomad", "plan", "apply"}, start)
 	defer close(indexCh)
 
 	// Wait for the plan to apply
@@ -451,6 +525,7 @@ func (p *planner) asyncPlanWait(indexCh chan<- uint64, future raft.ApplyFuture,
 		pending.respond(nil, err)
 		return
 	}
+	result.RaftApplyTime = time.Since(start)
 
 	// Respond to the plan
 	index := future.Index()
@@ -549,7 +624,10 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 			return true
 		}
 		if !fit {
-			metrics.IncrCounterWithLabels([]string{"nomad", "plan", "node_rejected"}, 1, []metrics.Label{{Name: "node_id", Value: nodeID}})
+			metrics.IncrCounterWithLabels([]string{"nomad", "plan", "node_rejected"}, 1, []metrics.Label{
+				{Name: "node_id", Value: nodeID},
+				{Name: "reason", Value: planRejectionReason(reason)},
+			})
 
 			// Log the reason why the node's allocations could not be made
 			if reason != "" {
@@ -565,9 +643,14 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 			}
 			// Set that this is a partial commit and store the node that was
 			// rejected so the plan applier can detect repeated plan rejections
-			// for the same node.
+			// for the same node. The reason is passed back to the scheduler so
+			// that it can be surfaced in the evaluation's failed placements.
 			partialCommit = true
 			rejectedNodes[nodeID] = struct{}{}
+			if result.NodeRejections == nil {
+				result.NodeRejections = make(map[string]string)
+			}
+			result.NodeRejections[nodeID] = reason
 
 			// If we require all-at-once scheduling, there is no point
 			// to continue the evaluation, as we've already failed.
@@ -656,6 +739,8 @@ OUTER:
 	// a minimum refresh index to force the scheduler to work on a more
 	// up-to-date state to avoid the failures.
 	if partialCommit {
+		rejectPartialGangs(plan, result, rejectedNodes)
+
 		index, err := refreshIndex(snap)
 		if err != nil {
 			mErr.Errors = append(mErr.Errors, err)
@@ -679,6 +764,41 @@ OUTER:
 	return result, mErr.ErrorOrNil()
 }
 
+// rejectPartialGangs rejects the placements of every node holding
+// allocations of a gang that had allocations on a rejected node, so that the
+// task groups of a gang are committed together or not at all.
+func rejectPartialGangs(plan *structs.Plan, result *structs.PlanResult, rejectedNodes map[string]struct{}) {
+	if plan.Job == nil || len(result.NodeAllocation) == 0 {
+		return
+	}
+
+	failedGangs := make(map[string]struct{})
+	for nodeID := range rejectedNodes {
+		for _, alloc := range plan.NodeAllocation[nodeID] {
+			if gang := plan.Job.LookupGang(alloc.TaskGroup); gang != "" {
+				failedGangs[gang] = struct{}{}
+			}
+		}
+	}
+	if len(failedGangs) == 0 {
+		return
+	}
+
+	for nodeID, allocs := range result.NodeAllocation {
+		for _, alloc := range allocs {
+			gang := plan.Job.LookupGang(alloc.TaskGroup)
+			if _, ok := failedGangs[gang]; !ok {
+				continue
+			}
+
+			delete(result.NodeAllocation, nodeID)
+			delete(result.NodePreemptions, nodeID)
+			result.NodeRejections[nodeID] = fmt.Sprintf("gang %q was rejected on another node", gang)
+			break
+		}
+	}
+}
+
 // correctDeploymentCanaries ensures that the deployment object doesn't list any
 // canaries as placed if they didn't actually get placed. This could happen if
 // the plan had a partial commit.
@@ -720,6 +840,9 @@ func correctDeploymentCanaries(result *structs.PlanResult) {
 // evaluateNodePlan is used to evaluate the plan for a single node,
 // returning if the plan is valid or if an error is encountered
 func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID string) (bool, string, error) {
+	defer metrics.MeasureSinceWithLabels([]string{"nomad", "plan", "evaluate_node"}, time.Now(),
+		[]metrics.Label{{Name: "node_id", Value: nodeID}})
+
 	// If this is an evict-only plan, it always 'fits' since we are removing things.
 	if len(plan.NodeAllocation[nodeID]) == 0 {
 		return true, "", nil
@@ -763,6 +886,9 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 		return true, "", nil
 	}
 	if node.SchedulingEligibility == structs.NodeSchedulingIneligible {
+		if node.DrainStrategy != nil {
+			return false, "node is draining", nil
+		}
 		return false, "node is not eligible", nil
 	}
 
@@ -773,8 +899,12 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 		remove = append(remove, update...)
 	}
 
-	// Remove any preempted allocs
+	// Remove any preempted allocs, as long as their namespace still allows
+	// them to be preempted
 	if preempted := plan.NodePreemptions[nodeID]; len(preempted) > 0 {
+		if reason, err := evaluateNodePreemptions(snap, preempted); err != nil || reason != "" {
+			return false, reason, err
+		}
 		remove = append(remove, preempted...)
 	}
 
@@ -786,9 +916,143 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 
 	// Check if these allocations fit
 	fit, reason, _, err := structs.AllocsFit(node, proposed, nil, true)
+	if !fit && reason == "device oversubscribed" {
+		reason = deviceOversubscribedReason(node, proposed, plan.NodeAllocation[nodeID])
+	}
 	return fit, reason, err
 }
 
+// deviceOversubscribedMaxCollisions is the maximum number of conflicting
+// device instances described in the reason for rejecting a plan, to bound the
+// size of the reason stored in the evaluation.
+const deviceOversubscribedMaxCollisions = 3
+
+// deviceOversubscribedReason returns the reason for rejecting the plan of a
+// node whose devices are oversubscribed. It lists the device instances
+// assigned to more than one allocation, the planned allocations requesting
+// them and the existing allocations holding them, so the conflicting
+// allocations can be identified from the evaluation.
+func deviceOversubscribedReason(node *structs.Node, proposed, planned []*structs.Allocation) string {
+	collisions := structs.NewDeviceAccounter(node).Collisions(proposed)
+	if len(collisions) == 0 {
+		return "device oversubscribed"
+	}
+
+	plannedIDs := make(map[string]struct{}, len(planned))
+	for _, alloc := range planned {
+		plannedIDs[alloc.ID] = struct{}{}
+	}
+
+	details := make([]string, 0, deviceOversubscribedMaxCollisions+1)
+	for _, collision := range collisions[:min(len(collisions), deviceOversubscribedMaxCollisions)] {
+		var requested, held []string
+		for _, alloc := range collision.Allocs {
+			desc := fmt.Sprintf("%s (%s)", alloc.ID, alloc.Name)
+			if _, ok := plannedIDs[alloc.ID]; ok {
+				requested = append(requested, desc)
+			} else {
+				held = append(held, desc)
+			}
+		}
+
+		detail := fmt.Sprintf("instance %q of %s", collision.InstanceID, collision.Device.String())
+		switch {
+		case len(held) == 0:
+			detail += " requested by multiple allocations " + strings.Join(requested, ", ")
+		case len(requested) == 0:
+			detail += " held by multiple allocations " + strings.Join(held, ", ")
+		default:
+			detail += fmt.Sprintf(" requested by %s is held by %s", strings.Join(requested, ", "), strings.Join(held, ", "))
+		}
+		details = append(details, detail)
+	}
+	if more := len(collisions) - deviceOversubscribedMaxCollisions; more > 0 {
+		details = append(details, fmt.Sprintf("%d more conflicting instances", more))
+	}
+
+	return "device oversubscribed: " + strings.Join(details, "; ")
+}
+
+// evaluateNodePreemptions checks that the namespace of each preempted
+// allocation allows it to be preempted, returning the reason if it doesn't.
+// The scheduler only preempts allowed allocations, but the namespace may have
+// been updated since the plan was created.
+func evaluateNodePreemptions(snap *state.StateSnapshot, preempted []*structs.Allocation) (string, error) {
+	namespaces := make(map[string]*structs.Namespace)
+	for _, alloc := range preempted {
+		if alloc.Job == nil {
+			continue
+		}
+
+		ns, ok := namespaces[alloc.Namespace]
+		if !ok {
+			var err error
+			ns, err = snap.NamespaceByName(nil, alloc.Namespace)
+			if err != nil {
+				return "", fmt.Errorf("failed to get namespace %q: %v", alloc.Namespace, err)
+			}
+			namespaces[alloc.Namespace] = ns
+		}
+		if ns != nil && !ns.PreemptionConfiguration.AllowsPreemption(alloc.Job.Priority) {
+			return fmt.Sprintf("preemption of allocation %s denied by namespace %q", alloc.ID, alloc.Namespace), nil
+		}
+	}
+	return "", nil
+}
+
+// Reasons for rejecting the plan of a node, used as the reason label of the
+// nomad.plan.node_rejected metric. The reasons returned by evaluateNodePlan
+// may include details such as port numbers, so they are mapped to this small
+// set of values to keep the number of series low.
+const (
+	planRejectionNodeMissing          = "node_missing"
+	planRejectionDisconnected         = "disconnected"
+	planRejectionDown                 = "down"
+	planRejectionNotReady             = "not_ready"
+	planRejectionDrain                = "drain"
+	planRejectionIneligible           = "ineligible"
+	planRejectionNodeFull             = "node_full"
+	planRejectionBandwidth            = "bandwidth_exceeded"
+	planRejectionPortCollision        = "port_collision"
+	planRejectionDeviceOversubscribed = "device_oversubscribed"
+	planRejectionHostVolume           = "host_volume_conflict"
+	planRejectionPreemptionDenied     = "preemption_denied"
+	planRejectionOther                = "other"
+)
+
+// planRejectionReason maps the reason returned by evaluateNodePlan for a node
+// that doesn't fit to one of the plan rejection reasons.
+func planRejectionReason(reason string) string {
+	switch {
+	case reason == "node does not exist":
+		return planRejectionNodeMissing
+	case strings.HasPrefix(reason, "node is disconnected"):
+		return planRejectionDisconnected
+	case strings.HasPrefix(reason, "node is down"):
+		return planRejectionDown
+	case reason == "node is not ready for placements":
+		return planRejectionNotReady
+	case reason == "node is draining":
+		return planRejectionDrain
+	case reason == "node is not eligible":
+		return planRejectionIneligible
+	case reason == "cpu", reason == "cores", reason == "memory", reason == "disk":
+		return planRejectionNodeFull
+	case reason == "bandwidth exceeded":
+		return planRejectionBandwidth
+	case strings.Contains(reason, "port collision"):
+		return planRejectionPortCollision
+	case strings.HasPrefix(reason, "device oversubscribed"):
+		return planRejectionDeviceOversubscribed
+	case strings.Contains(reason, "host volume"):
+		return planRejectionHostVolume
+	case strings.HasPrefix(reason, "preemption of allocation"):
+		return planRejectionPreemptionDenied
+	default:
+		return planRejectionOther
+	}
+}
+
 // The plan is only valid for disconnected nodes if it only contains
 // updates to mark allocations as unknown.
 func isValidForDisconnectedNode(plan *structs.Plan, nodeID string) bool {
@@ -5,18 +5,28 @@ package nomad
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	metrics "github.com/hashicorp/go-metrics/compat"
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"golang.org/x/time/rate"
 )
 
 type BadNodeTracker interface {
 	Add(string) bool
 	EmitStats(time.Duration, <-chan struct{})
+
+	// Nodes returns the nodes being tracked, sorted by node ID.
+	Nodes() []*structs.PlanRejectionNode
+
+	// Reset removes the rejection history of a node, or of all nodes if
+	// nodeID is empty, and returns the number of nodes removed.
+	Reset(nodeID string) int
 }
 
 // NoopBadNodeTracker is a no-op implementation of bad node tracker that is
@@ -27,6 +37,12 @@ func (n *NoopBadNodeTracker) EmitStats(time.Duration, <-chan struct{}) {}
 func (n *NoopBadNodeTracker) Add(string) bool {
 	return false
 }
+func (n *NoopBadNodeTracker) Nodes() []*structs.PlanRejectionNode {
+	return nil
+}
+func (n *NoopBadNodeTracker) Reset(string) int {
+	return 0
+}
 
 // CachedBadNodeTracker keeps a record of nodes marked as bad by the plan
 // applier in a LRU cache.
@@ -43,6 +59,10 @@ type CachedBadNodeTracker struct {
 	limiter   *rate.Limiter
 	window    time.Duration
 	threshold int
+
+	// lock protects the node stats, which are accessed by the plan applier,
+	// the metrics emitter and the operator API.
+	lock sync.Mutex
 }
 
 type CachedBadNodeTrackerConfig struct {
@@ -96,6 +116,9 @@ func NewCachedBadNodeTracker(logger hclog.Logger, config CachedBadNodeTrackerCon
 // cache. If the cache is full the least recently updated or accessed node is
 // evicted.
 func (c *CachedBadNodeTracker) Add(nodeID string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	stats, ok := c.cache.Get(nodeID)
 	if !ok {
 		stats = newBadNodeStats(nodeID, c.window)
@@ -147,7 +170,59 @@ func (c *CachedBadNodeTracker) isBad(t time.Time, stats *badNodeStats) bool {
 	return false
 }
 
+// Nodes returns the nodes being tracked with their current score. Nodes
+// without rejections within the time window are included with a score of 0
+// until they are evicted from the cache.
+func (c *CachedBadNodeTracker) Nodes() []*structs.PlanRejectionNode {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	nodes := make([]*structs.PlanRejectionNode, 0, c.cache.Len())
+	for _, nodeID := range c.cache.Keys() {
+		// Use Peek to avoid refreshing the entries in the cache.
+		stats, ok := c.cache.Peek(nodeID)
+		if !ok {
+			continue
+		}
+
+		node := &structs.PlanRejectionNode{
+			NodeID: nodeID,
+			Score:  stats.score(now),
+		}
+		if len(stats.history) > 0 {
+			node.LastRejection = stats.history[len(stats.history)-1]
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].NodeID < nodes[j].NodeID })
+	return nodes
+}
+
+// Reset removes the rejection history of a node, or of all nodes if nodeID is
+// empty, and returns the number of nodes removed.
+func (c *CachedBadNodeTracker) Reset(nodeID string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if nodeID == "" {
+		n := c.cache.Len()
+		c.cache.Purge()
+		return n
+	}
+
+	if c.cache.Contains(nodeID) {
+		c.cache.Remove(nodeID)
+		return 1
+	}
+	return 0
+}
+
 func (c *CachedBadNodeTracker) emitStats() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	now := time.Now()
 	for _, nodeID := range c.cache.Keys() {
 		stats, _ := c.cache.Get(nodeID)
@@ -33,6 +33,37 @@ func TestCachedtBadNodeTracker(t *testing.T) {
 	require.ElementsMatch(t, expected, tracker.cache.Keys())
 }
 
+func TestCachedBadNodeTracker_NodesReset(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker, err := NewCachedBadNodeTracker(hclog.NewNullLogger(), DefaultCachedBadNodeTrackerConfig())
+	require.NoError(t, err)
+
+	require.Empty(t, tracker.Nodes())
+
+	tracker.Add("node-2")
+	tracker.Add("node-1")
+	tracker.Add("node-2")
+	tracker.Add("node-3")
+
+	nodes := tracker.Nodes()
+	require.Len(t, nodes, 3)
+	require.Equal(t, "node-1", nodes[0].NodeID)
+	require.Equal(t, 1, nodes[0].Score)
+	require.Equal(t, "node-2", nodes[1].NodeID)
+	require.Equal(t, 2, nodes[1].Score)
+	require.False(t, nodes[1].LastRejection.IsZero())
+
+	// Reset a single node.
+	require.Equal(t, 1, tracker.Reset("node-2"))
+	require.Equal(t, 0, tracker.Reset("node-2"))
+	require.ElementsMatch(t, []string{"node-1", "node-3"}, tracker.cache.Keys())
+
+	// Reset all nodes.
+	require.Equal(t, 2, tracker.Reset(""))
+	require.Empty(t, tracker.Nodes())
+}
+
 func TestCachedBadNodeTracker_isBad(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"slices"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// planApplyMaxInflight is the maximum number of plan results that can be
+// waiting to be committed to Raft at the same time. It bounds how far the
+// optimistic snapshot used to evaluate plans can get ahead of the state store.
+const planApplyMaxInflight = 8
+
+// planFootprint is the set of nodes, jobs and deployments modified by applying
+// a plan result. Plan results with disjoint footprints do not depend on each
+// other, so they can be committed in parallel.
+type planFootprint struct {
+	nodes       map[string]struct{}
+	jobs        map[structs.NamespacedID]struct{}
+	deployments map[string]struct{}
+}
+
+// newPlanFootprint returns the footprint of applying the result of the plan.
+func newPlanFootprint(plan *structs.Plan, result *structs.PlanResult) *planFootprint {
+	f := &planFootprint{
+		nodes:       make(map[string]struct{}),
+		jobs:        make(map[structs.NamespacedID]struct{}),
+		deployments: make(map[string]struct{}),
+	}
+
+	if plan.Job != nil {
+		f.jobs[plan.Job.NamespacedID()] = struct{}{}
+	}
+
+	for _, nodeAllocs := range []map[string][]*structs.Allocation{
+		result.NodeUpdate, result.NodeAllocation, result.NodePreemptions,
+	} {
+		for nodeID, allocs := range nodeAllocs {
+			f.nodes[nodeID] = struct{}{}
+			for _, alloc := range allocs {
+				appendNamespacedJobID(f.jobs, alloc)
+				if alloc.DeploymentID != "" {
+					f.deployments[alloc.DeploymentID] = struct{}{}
+				}
+			}
+		}
+	}
+	for _, nodeID := range result.IneligibleNodes {
+		f.nodes[nodeID] = struct{}{}
+	}
+
+	if result.Deployment != nil {
+		f.deployments[result.Deployment.ID] = struct{}{}
+	}
+	for _, update := range result.DeploymentUpdates {
+		f.deployments[update.DeploymentID] = struct{}{}
+	}
+	return f
+}
+
+// disjoint returns true if the footprints have no node, job or deployment in
+// common.
+func (f *planFootprint) disjoint(o *planFootprint) bool {
+	return disjointSets(f.nodes, o.nodes) &&
+		disjointSets(f.jobs, o.jobs) &&
+		disjointSets(f.deployments, o.deployments)
+}
+
+func disjointSets[K comparable](a, b map[K]struct{}) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for k := range a {
+		if _, ok := b[k]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// inflightPlan is a plan result submitted to Raft that has not been committed
+// yet. Its committed index is sent on indexCh, which is closed if the plan
+// failed to apply.
+type inflightPlan struct {
+	indexCh   chan uint64
+	footprint *planFootprint
+}
+
+// canApplyInParallel returns true if a plan result with the given footprint
+// can be submitted to Raft without waiting for the in-flight plans to be
+// committed.
+func (p *planner) canApplyInParallel(inflight []*inflightPlan, footprint *planFootprint) bool {
+	if p.srv.config.DisableParallelPlanApply || len(inflight) >= planApplyMaxInflight {
+		return false
+	}
+	for _, other := range inflight {
+		if !footprint.disjoint(other.footprint) {
+			return false
+		}
+	}
+	return true
+}
+
+// reapCommittedPlans removes the plans that have been committed from the
+// in-flight plans without blocking. It returns the plans still in flight, the
+// maximum of the given index and the committed plan indexes, and whether any
+// of the removed plans failed to apply.
+func reapCommittedPlans(inflight []*inflightPlan, index uint64) ([]*inflightPlan, uint64, bool) {
+	failed := false
+	inflight = slices.DeleteFunc(inflight, func(f *inflightPlan) bool {
+		select {
+		case idx := <-f.indexCh:
+			index = max(index, idx)
+			failed = failed || idx == 0
+			return true
+		default:
+			return false
+		}
+	})
+	return inflight, index, failed
+}
+
+// waitCommittedPlans blocks until all the in-flight plans are committed. It
+// returns the maximum of the given index and the committed plan indexes, and
+// whether any of the plans failed to apply.
+func waitCommittedPlans(inflight []*inflightPlan, index uint64) (uint64, bool) {
+	failed := false
+	for _, f := range inflight {
+		idx := <-f.indexCh
+		index = max(index, idx)
+		failed = failed || idx == 0
+	}
+	return index, failed
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/raft"
+	"github.com/shoenig/test/must"
+)
+
+func TestPlanFootprint(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.DeploymentID = "d1"
+	preempted := mock.Alloc()
+
+	plan := &structs.Plan{Job: alloc.Job}
+	result := &structs.PlanResult{
+		NodeAllocation:  map[string][]*structs.Allocation{"n1": {alloc}},
+		NodePreemptions: map[string][]*structs.Allocation{"n2": {preempted}},
+		IneligibleNodes: []string{"n3"},
+		DeploymentUpdates: []*structs.DeploymentStatusUpdate{
+			{DeploymentID: "d2"},
+		},
+	}
+
+	f := newPlanFootprint(plan, result)
+	must.MapContainsKeys(t, f.nodes, []string{"n1", "n2", "n3"})
+	must.MapContainsKeys(t, f.jobs, []structs.NamespacedID{
+		alloc.Job.NamespacedID(),
+		{Namespace: preempted.Namespace, ID: preempted.JobID},
+	})
+	must.MapContainsKeys(t, f.deployments, []string{"d1", "d2"})
+
+	// A plan for another job on other nodes is disjoint
+	other := mock.Alloc()
+	otherFootprint := newPlanFootprint(&structs.Plan{Job: other.Job}, &structs.PlanResult{
+		NodeAllocation: map[string][]*structs.Allocation{"n4": {other}},
+	})
+	must.True(t, f.disjoint(otherFootprint))
+	must.True(t, otherFootprint.disjoint(f))
+
+	// Sharing a node, a job or a deployment is not
+	otherFootprint.nodes["n3"] = struct{}{}
+	must.False(t, f.disjoint(otherFootprint))
+	delete(otherFootprint.nodes, "n3")
+
+	otherFootprint.jobs[alloc.Job.NamespacedID()] = struct{}{}
+	must.False(t, f.disjoint(otherFootprint))
+	delete(otherFootprint.jobs, alloc.Job.NamespacedID())
+
+	otherFootprint.deployments["d2"] = struct{}{}
+	must.False(t, f.disjoint(otherFootprint))
+}
+
+func TestPlanner_CanApplyInParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &planner{srv: &Server{config: DefaultConfig()}}
+
+	footprint := func(nodeID string) *planFootprint {
+		alloc := mock.Alloc()
+		return newPlanFootprint(&structs.Plan{Job: alloc.Job}, &structs.PlanResult{
+			NodeAllocation: map[string][]*structs.Allocation{nodeID: {alloc}},
+		})
+	}
+
+	inflight := []*inflightPlan{{footprint: footprint("n1")}}
+	must.True(t, p.canApplyInParallel(inflight, footprint("n2")))
+	must.False(t, p.canApplyInParallel(inflight, footprint("n1")))
+
+	// The number of plans in flight is bounded
+	for len(inflight) < planApplyMaxInflight {
+		inflight = append(inflight, &inflightPlan{footprint: footprint("n1")})
+	}
+	must.False(t, p.canApplyInParallel(inflight[:planApplyMaxInflight], footprint("n2")))
+
+	// Parallel application can be disabled
+	p.srv.config.DisableParallelPlanApply = true
+	must.False(t, p.canApplyInParallel(inflight[:1], footprint("n2")))
+}
+
+func TestPlanApply_CommittedPlans(t *testing.T) {
+	ci.Parallel(t)
+
+	committed := &inflightPlan{indexCh: make(chan uint64, 1)}
+	committed.indexCh <- 20
+	pending := &inflightPlan{indexCh: make(chan uint64, 1)}
+
+	// Only the plans that completed are removed
+	inflight, index, failed := reapCommittedPlans([]*inflightPlan{committed, pending}, 10)
+	must.Eq(t, []*inflightPlan{pending}, inflight)
+	must.Eq(t, 20, index)
+	must.False(t, failed)
+
+	pending.indexCh <- 30
+	index, failed = waitCommittedPlans(inflight, index)
+	must.Eq(t, 30, index)
+	must.False(t, failed)
+}
+
+// failedApplyFuture is a raft.ApplyFuture for a plan that failed to apply.
+type failedApplyFuture struct{ err error }
+
+func (f *failedApplyFuture) Error() error          { return f.err }
+func (f *failedApplyFuture) Index() uint64         { return 0 }
+func (f *failedApplyFuture) Response() interface{} { return nil }
+
+func TestPlanApply_CommittedPlans_Failed(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &planner{srv: &Server{logger: testlog.HCLogger(t)}}
+
+	// Fill the in-flight plans, with one failing to apply while the
+	// others are still waiting to be committed
+	var inflight []*inflightPlan
+	for len(inflight) < planApplyMaxInflight {
+		inflight = append(inflight, &inflightPlan{indexCh: make(chan uint64, 1)})
+	}
+
+	failedPlan := &pendingPlan{errCh: make(chan error, 1)}
+	failedCh := make(chan uint64, 1)
+	p.asyncPlanWait(failedCh, &failedApplyFuture{err: raft.ErrNotLeader},
+		&structs.PlanResult{}, failedPlan)
+	inflight[3].indexCh = failedCh
+
+	_, err := failedPlan.Wait()
+	must.ErrorIs(t, err, raft.ErrNotLeader)
+
+	// The failure is reported as soon as the failed plan is reaped, so the
+	// optimistic snapshot can be discarded
+	remaining, index, failed := reapCommittedPlans(inflight, 10)
+	must.True(t, failed)
+	must.Eq(t, 10, index)
+	must.Len(t, planApplyMaxInflight-1, remaining)
+
+	// It is also reported when waiting for the in-flight plans
+	inflight = append(remaining, &inflightPlan{indexCh: make(chan uint64)})
+	close(inflight[len(inflight)-1].indexCh)
+	for i, f := range inflight[:len(inflight)-1] {
+		f.indexCh <- uint64(20 + i)
+	}
+	index, failed = waitCommittedPlans(inflight, index)
+	must.True(t, failed)
+	must.Eq(t, uint64(20+planApplyMaxInflight-2), index)
+}
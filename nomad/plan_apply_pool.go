@@ -4,6 +4,9 @@
 package nomad
 
 import (
+	"runtime"
+	"time"
+
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -13,6 +16,15 @@ const (
 	// request to the workers and to collect the responses. It should
 	// be large enough just to keep things busy
 	workerPoolBufferSize = 64
+
+	// planEvaluateNodesPerWorker is the number of nodes in a plan for which
+	// an additional worker is added to the pool.
+	planEvaluateNodesPerWorker = 16
+
+	// planEvaluatePoolScaleDownDelay is how long the pool must be larger than
+	// needed before it is scaled down, to avoid resizing it back and forth
+	// under bursty load.
+	planEvaluatePoolScaleDownDelay = 30 * time.Second
 )
 
 // EvaluatePool is used to have a pool of workers that are evaluating
@@ -114,3 +126,55 @@ func (p *EvaluatePool) run(stopCh chan struct{}) {
 		}
 	}
 }
+
+// planEvaluatePoolBounds returns the minimum and maximum size of the evaluate
+// pool. Values of the scheduler configuration take precedence over the server
+// configuration, and unset values default to half and all of the CPUs.
+func planEvaluatePoolBounds(config *Config, schedConfig *structs.SchedulerConfiguration) (int, int) {
+	poolMin, poolMax := config.PlanEvaluatePoolMin, config.PlanEvaluatePoolMax
+	if schedConfig != nil {
+		if schedConfig.PlanEvaluatePoolMin > 0 {
+			poolMin = schedConfig.PlanEvaluatePoolMin
+		}
+		if schedConfig.PlanEvaluatePoolMax > 0 {
+			poolMax = schedConfig.PlanEvaluatePoolMax
+		}
+	}
+
+	if poolMin <= 0 {
+		poolMin = max(runtime.NumCPU()/2, 1)
+	}
+	if poolMax <= 0 {
+		poolMax = runtime.NumCPU()
+	}
+	return poolMin, max(poolMin, poolMax)
+}
+
+// evaluatePoolSizer computes the size of the evaluate pool based on the plan
+// queue depth and the number of nodes in the plan being evaluated.
+type evaluatePoolSizer struct {
+	// lastNeeded is the last time the current pool size was needed.
+	lastNeeded time.Time
+}
+
+// size returns the pool size to use for a plan with planNodes nodes while
+// queueDepth other plans are waiting. The pool is scaled up immediately, but
+// only scaled down once the current size hasn't been needed for
+// planEvaluatePoolScaleDownDelay.
+func (s *evaluatePoolSizer) size(now time.Time, current, poolMin, poolMax, queueDepth, planNodes int) int {
+	desired := (planNodes+planEvaluateNodesPerWorker-1)/planEvaluateNodesPerWorker + queueDepth
+	desired = min(max(desired, poolMin), poolMax)
+
+	switch {
+	case current < poolMin || current > poolMax:
+		// The bounds changed, so resize right away.
+	case desired >= current:
+		s.lastNeeded = now
+		return desired
+	case now.Sub(s.lastNeeded) < planEvaluatePoolScaleDownDelay:
+		return current
+	}
+
+	s.lastNeeded = now
+	return desired
+}
@@ -4,11 +4,14 @@
 package nomad
 
 import (
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 )
 
 func TestEvaluatePool(t *testing.T) {
@@ -64,3 +67,57 @@ func TestEvaluatePool_Resize(t *testing.T) {
 		t.Fatalf("bad: %d", n)
 	}
 }
+
+func TestPlanEvaluatePoolBounds(t *testing.T) {
+	ci.Parallel(t)
+
+	config := DefaultConfig()
+	poolMin, poolMax := planEvaluatePoolBounds(config, nil)
+	must.Eq(t, max(runtime.NumCPU()/2, 1), poolMin)
+	must.Eq(t, max(poolMin, runtime.NumCPU()), poolMax)
+
+	config.PlanEvaluatePoolMin = 2
+	config.PlanEvaluatePoolMax = 4
+	poolMin, poolMax = planEvaluatePoolBounds(config, &structs.SchedulerConfiguration{})
+	must.Eq(t, 2, poolMin)
+	must.Eq(t, 4, poolMax)
+
+	// The scheduler configuration takes precedence.
+	poolMin, poolMax = planEvaluatePoolBounds(config, &structs.SchedulerConfiguration{
+		PlanEvaluatePoolMax: 16,
+	})
+	must.Eq(t, 2, poolMin)
+	must.Eq(t, 16, poolMax)
+
+	// The maximum is never lower than the minimum.
+	poolMin, poolMax = planEvaluatePoolBounds(config, &structs.SchedulerConfiguration{
+		PlanEvaluatePoolMin: 8,
+	})
+	must.Eq(t, 8, poolMin)
+	must.Eq(t, 8, poolMax)
+}
+
+func TestEvaluatePoolSizer(t *testing.T) {
+	ci.Parallel(t)
+
+	var sizer evaluatePoolSizer
+	now := time.Now()
+
+	// Small plans with an empty queue use the minimum.
+	must.Eq(t, 2, sizer.size(now, 2, 2, 8, 0, 3))
+
+	// Scale up with the number of nodes and the queue depth.
+	must.Eq(t, 4, sizer.size(now, 2, 2, 8, 0, 4*planEvaluateNodesPerWorker))
+	must.Eq(t, 6, sizer.size(now, 4, 2, 8, 2, 4*planEvaluateNodesPerWorker))
+	must.Eq(t, 8, sizer.size(now, 6, 2, 8, 20, 1))
+
+	// Scaling down is delayed.
+	now = now.Add(planEvaluatePoolScaleDownDelay / 2)
+	must.Eq(t, 8, sizer.size(now, 8, 2, 8, 0, 1))
+	now = now.Add(planEvaluatePoolScaleDownDelay)
+	must.Eq(t, 2, sizer.size(now, 8, 2, 8, 0, 1))
+
+	// Changing the bounds resizes right away.
+	must.Eq(t, 4, sizer.size(now, 2, 4, 8, 0, 1))
+	must.Eq(t, 3, sizer.size(now, 4, 1, 3, 0, 1000))
+}
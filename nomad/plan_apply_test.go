@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -260,9 +262,14 @@ func TestPlanApply_applyPlanWithNormalizedAllocs(t *testing.T) {
 		ClientStatus:       structs.AllocClientStatusLost,
 	}
 	preemptedAlloc := mock.Alloc()
+	preemptedAlloc.Job.TaskGroups[0].PreemptionNotice = &structs.PreemptionNotice{
+		Grace: time.Minute,
+	}
 	preemptedAllocDiff := &structs.Allocation{
 		ID:                    preemptedAlloc.ID,
 		PreemptedByAllocation: alloc.ID,
+		TaskGroup:             preemptedAlloc.TaskGroup,
+		Job:                   preemptedAlloc.Job,
 	}
 	must.NoError(t, s1.State().UpsertJobSummary(1000, mock.JobSummary(alloc.JobID)))
 	must.NoError(t, s1.State().UpsertAllocs(structs.MsgTypeTestSetup, 1100, []*structs.Allocation{stoppedAlloc, preemptedAlloc}))
@@ -346,6 +353,8 @@ func TestPlanApply_applyPlanWithNormalizedAllocs(t *testing.T) {
 	must.Eq(t, updatedPreemptedAlloc.DesiredDescription,
 		"Preempted by alloc ID "+preemptedAllocDiff.PreemptedByAllocation)
 	must.Eq(t, updatedPreemptedAlloc.DesiredStatus, structs.AllocDesiredStatusEvict)
+	must.Eq(t, updatedPreemptedAlloc.ModifyTime+time.Minute.Nanoseconds(),
+		updatedPreemptedAlloc.PreemptionDeadline)
 
 	// Lookup the new deployment
 	dout, err := fsmState.DeploymentByID(ws, plan.Deployment.ID)
@@ -366,6 +375,32 @@ func TestPlanApply_applyPlanWithNormalizedAllocs(t *testing.T) {
 	must.Eq(t, index, evalOut.ModifyIndex)
 }
 
+func TestPlanApply_updateAllocTimestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	existing := mock.Alloc()
+	existing.CreateTime = 10
+	existing.Timestamps = &structs.AllocTimestamps{EvalCreateTime: 1}
+	placed := mock.Alloc()
+
+	plan := &structs.Plan{EvalCreateTime: 20, SubmitTime: 30}
+	out := updateAllocTimestamps([]*structs.Allocation{existing, placed}, plan, 40)
+	must.Eq(t, []*structs.Allocation{placed}, out)
+
+	// Only the new allocations record the placement timestamps
+	must.Eq(t, 10, existing.CreateTime)
+	must.Eq(t, 40, existing.ModifyTime)
+	must.Eq(t, &structs.AllocTimestamps{EvalCreateTime: 1}, existing.Timestamps)
+
+	must.Eq(t, 40, placed.CreateTime)
+	must.Eq(t, 40, placed.ModifyTime)
+	must.Eq(t, &structs.AllocTimestamps{
+		EvalCreateTime: 20,
+		PlanSubmitTime: 30,
+		PlanApplyTime:  40,
+	}, placed.Timestamps)
+}
+
 func TestPlanApply_signAllocIdentities(t *testing.T) {
 	// note: this is mutated by the method under test
 	alloc := mockAlloc()
@@ -452,6 +487,13 @@ func TestPlanApply_KeyringNotReady(t *testing.T) {
 	defer cleanup()
 	testutil.WaitForLeader(t, srv.RPC) // don't WaitForKeyring
 
+	// wait for the leader to fail to initialize the keyring
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return srv.encrypter.providerHealthError() != nil }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
 	node := mock.Node()
 	alloc := mock.Alloc()
 	deploy := mock.Deployment()
@@ -483,7 +525,7 @@ func TestPlanApply_KeyringNotReady(t *testing.T) {
 	snap, _ := srv.State().Snapshot()
 
 	_, err := srv.applyPlan(plan, planRes, snap)
-	must.EqError(t, err, "keyring has not been initialized yet")
+	must.EqError(t, err, `keyring has not been initialized yet: KEK provider "no-such-provider" is failing: unknown KEK provider "no-such-provider"`)
 }
 
 func TestPlanApply_EvalPlan_Simple(t *testing.T) {
@@ -692,6 +734,10 @@ func TestPlanApply_EvalPlan_Partial(t *testing.T) {
 		t.Fatalf("should not allow alloc2")
 	}
 
+	// Check the reason the node was rejected is returned
+	must.MapLen(t, 1, result.NodeRejections)
+	must.NotEq(t, "", result.NodeRejections[node2.ID])
+
 	// Check the deployment was updated
 	if result.Deployment == nil || len(result.Deployment.TaskGroups) == 0 {
 		t.Fatalf("bad: %v", result.Deployment)
@@ -706,6 +752,63 @@ func TestPlanApply_EvalPlan_Partial(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Partial_Gang(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1000, node)
+	node2 := mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2)
+	node3 := mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1002, node3)
+	snap, _ := state.Snapshot()
+
+	// The "web" and "db" groups form a gang and the "db" alloc does not fit
+	job := mock.Job()
+	job.TaskGroups[0].Gang = "app"
+	db := job.TaskGroups[0].Copy()
+	db.Name = "db"
+	cache := job.TaskGroups[0].Copy()
+	cache.Name = "cache"
+	cache.Gang = ""
+	job.TaskGroups = append(job.TaskGroups, db, cache)
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc2 := mock.Alloc()
+	alloc2.Job = job
+	alloc2.TaskGroup = "db"
+	alloc2.AllocatedResources = structs.NodeResourcesToAllocatedResources(node2.NodeResources)
+	alloc3 := mock.Alloc()
+	alloc3.Job = job
+	alloc3.TaskGroup = "cache"
+
+	plan := &structs.Plan{
+		Job: job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID:  {alloc},
+			node2.ID: {alloc2},
+			node3.ID: {alloc3},
+		},
+	}
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.NotNil(t, result)
+
+	// Only the alloc outside of the gang is committed
+	must.MapLen(t, 1, result.NodeAllocation)
+	must.MapContainsKey(t, result.NodeAllocation, node3.ID)
+
+	must.MapLen(t, 2, result.NodeRejections)
+	must.StrContains(t, result.NodeRejections[node.ID], `gang "app"`)
+	must.SliceContainsAll(t, []string{node2.ID}, result.RejectedNodes)
+	must.Positive(t, result.RefreshIndex)
+}
+
 func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
@@ -952,7 +1055,9 @@ func TestPlanApply_EvalNodePlan_NodeFull_Device(t *testing.T) {
 	fit, reason, err := evaluateNodePlan(snap, plan, node.ID)
 	require.NoError(err)
 	require.False(fit)
-	require.Equal("device oversubscribed", reason)
+	require.Equal(fmt.Sprintf(
+		"device oversubscribed: instance %q of nvidia/gpu/1080ti requested by %s (%s) is held by %s (%s)",
+		nvidia0, alloc2.ID, alloc2.Name, alloc.ID, alloc.Name), reason)
 }
 
 func TestPlanApply_EvalNodePlan_UpdateExisting(t *testing.T) {
@@ -1091,6 +1196,61 @@ func TestPlanApply_EvalNodePlan_NodeFull_AllocEvict(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalNodePlan_PreemptionDenied(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+
+	ns := mock.Namespace()
+	ns.PreemptionConfiguration = &structs.NamespacePreemptionConfiguration{
+		Protected: true,
+	}
+	must.NoError(t, state.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	node := mock.Node()
+	node.ReservedResources = nil
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.Job.Namespace = ns.Name
+	alloc.NodeID = node.ID
+	alloc.AllocatedResources = structs.NodeResourcesToAllocatedResources(node.NodeResources)
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	alloc2 := mock.Alloc()
+	plan := &structs.Plan{
+		Job: alloc2.Job,
+		NodePreemptions: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc2},
+		},
+	}
+
+	// The namespace of the preempted alloc is protected
+	snap, err := state.Snapshot()
+	must.NoError(t, err)
+	fit, reason, err := evaluateNodePlan(snap, plan, node.ID)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, fmt.Sprintf("preemption of allocation %s denied by namespace %q", alloc.ID, ns.Name), reason)
+	must.Eq(t, planRejectionPreemptionDenied, planRejectionReason(reason))
+
+	// Allow the preemption of jobs below the priority of the preempted alloc
+	ns = ns.Copy()
+	ns.PreemptionConfiguration = &structs.NamespacePreemptionConfiguration{
+		PriorityFloor: alloc.Job.Priority + 1,
+	}
+	must.NoError(t, state.UpsertNamespaces(1002, []*structs.Namespace{ns}))
+
+	snap, err = state.Snapshot()
+	must.NoError(t, err)
+	fit, reason, err = evaluateNodePlan(snap, plan, node.ID)
+	must.NoError(t, err)
+	must.True(t, fit)
+	must.Eq(t, "", reason)
+}
+
 func TestPlanApply_EvalNodePlan_NodeDown_EvictOnly(t *testing.T) {
 	ci.Parallel(t)
 	alloc := mock.Alloc()
@@ -1201,3 +1361,51 @@ func TestPlanApply_EvalNodePlan_Node_Disconnected(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanApply_EvalNodePlan_Draining(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.DrainNode()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	snap, _ := state.Snapshot()
+
+	alloc := mock.Alloc()
+	plan := &structs.Plan{
+		Job: alloc.Job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+
+	fit, reason, err := evaluateNodePlan(snap, plan, node.ID)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "node is draining", reason)
+	must.Eq(t, planRejectionDrain, planRejectionReason(reason))
+}
+
+func TestPlanApply_planRejectionReason(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := map[string]string{
+		"node does not exist":                               planRejectionNodeMissing,
+		"node is disconnected and contains invalid updates": planRejectionDisconnected,
+		"node is down and contains invalid updates":         planRejectionDown,
+		"node is not ready for placements":                  planRejectionNotReady,
+		"node is draining":                                  planRejectionDrain,
+		"node is not eligible":                              planRejectionIneligible,
+		"memory":                                            planRejectionNodeFull,
+		"cores":                                             planRejectionNodeFull,
+		"bandwidth exceeded":                                planRejectionBandwidth,
+		"reserved alloc port collision: collision when reserving":    planRejectionPortCollision,
+		"device oversubscribed":                                      planRejectionDeviceOversubscribed,
+		"device oversubscribed: instance \"0\" of a/b/c":             planRejectionDeviceOversubscribed,
+		"conflicting claims for host volume with single-writer":      planRejectionHostVolume,
+		"preemption of allocation 1234 denied by namespace \"prod\"": planRejectionPreemptionDenied,
+		"something new": planRejectionOther,
+	}
+	for reason, expected := range cases {
+		must.Eq(t, expected, planRejectionReason(reason), must.Sprint(reason))
+	}
+}
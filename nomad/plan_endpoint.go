@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -67,6 +68,62 @@ func (p *Plan) Submit(args *structs.PlanRequest, reply *structs.PlanResponse) er
 
 	// Package the result
 	reply.Result = result
+	reply.PlanQueueDepth = p.srv.planQueue.Stats().Depth
 	reply.Index = result.AllocIndex
 	return nil
 }
+
+// DryRun is used to evaluate a plan against the latest state snapshot without
+// committing it. It returns the plan result that would have been applied and
+// the reason the plan was rejected for each node that didn't fit, which is
+// useful to debug plan rejections.
+func (p *Plan) DryRun(args *structs.PlanDryRunRequest, reply *structs.PlanDryRunResponse) error {
+
+	authErr := p.srv.Authenticate(p.ctx, args)
+	if done, err := p.srv.forward("Plan.DryRun", args, args, reply); done {
+		return err
+	}
+	p.srv.MeasureRPCRate("plan", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "plan", "dry_run"}, time.Now())
+
+	// This action requires operator read access.
+	aclObj, err := p.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Plan == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "cannot evaluate nil plan")
+	}
+	if args.Plan.Job == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "plan must include a job")
+	}
+
+	snap, err := p.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	pool := NewEvaluatePool(1, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	result, err := evaluatePlan(pool, snap, args.Plan, p.logger)
+	if err != nil {
+		return err
+	}
+
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	reply.Result = result
+	reply.NodeRejections = result.NodeRejections
+	reply.Index = index
+	return nil
+}
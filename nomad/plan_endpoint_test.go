@@ -9,10 +9,13 @@ import (
 	"time"
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -199,3 +202,99 @@ func TestPlanEndpoint_ApplyConcurrent(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestPlanEndpoint_DryRun(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Place one allocation on a node that exists and one on a node that
+	// doesn't
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	missingAlloc := mock.Alloc()
+	missingAlloc.NodeID = uuid.Generate()
+	plan := mock.Plan()
+	plan.Job = alloc.Job
+	plan.NodeAllocation = map[string][]*structs.Allocation{
+		node.ID:             {alloc},
+		missingAlloc.NodeID: {missingAlloc},
+	}
+
+	req := &structs.PlanDryRunRequest{
+		Plan:         plan,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.PlanDryRunResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Plan.DryRun", req, &resp))
+	must.NotNil(t, resp.Result)
+	must.Len(t, 1, resp.Result.NodeAllocation[node.ID])
+	must.MapNotContainsKey(t, resp.Result.NodeAllocation, missingAlloc.NodeID)
+	must.Eq(t, []string{missingAlloc.NodeID}, resp.Result.RejectedNodes)
+	must.Eq(t, map[string]string{missingAlloc.NodeID: "node does not exist"}, resp.NodeRejections)
+	must.Positive(t, resp.Result.RefreshIndex)
+	must.Positive(t, resp.Index)
+
+	// Nothing was committed
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// Plans without a job are rejected
+	req.Plan = &structs.Plan{}
+	err = msgpackrpc.CallWithCodec(codec, "Plan.DryRun", req, &resp)
+	must.ErrorContains(t, err, "plan must include a job")
+}
+
+func TestPlanEndpoint_DryRun_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NodePolicy(acl.PolicyWrite))
+	operatorToken := mock.CreatePolicyAndToken(t, state, 1003, "test-valid",
+		`operator { policy = "read" }`)
+
+	plan := mock.Plan()
+	plan.Job = mock.Job()
+	req := &structs.PlanDryRunRequest{
+		Plan:         plan,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Try without a token
+	var resp structs.PlanDryRunResponse
+	err := msgpackrpc.CallWithCodec(codec, "Plan.DryRun", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with a token without operator read
+	req.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Plan.DryRun", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an operator token
+	req.AuthToken = operatorToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Plan.DryRun", req, &resp))
+	must.NotNil(t, resp.Result)
+
+	// Try with the root token
+	req.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Plan.DryRun", req, &resp))
+	must.NotNil(t, resp.Result)
+}
@@ -6,6 +6,8 @@ package nomad
 import (
 	"container/heap"
 	"fmt"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
 
@@ -20,6 +22,16 @@ var (
 	planQueueFlushed = fmt.Errorf("plan queue flushed")
 )
 
+const (
+	// planQueueWaitSamples is the number of most recent enqueue-to-dequeue
+	// wait times kept to compute the wait time percentiles.
+	planQueueWaitSamples = 1024
+
+	// planQueueAgingBoost is the amount the effective priority of a plan is
+	// increased by for every aging interval it has been waiting.
+	planQueueAgingBoost = 10
+)
+
 // PlanFuture is used to return a future for an enqueue
 type PlanFuture interface {
 	Wait() (*structs.PlanResult, error)
@@ -37,16 +49,30 @@ type PlanQueue struct {
 	ready  PendingPlans
 	waitCh chan struct{}
 
+	// waitTimes is a ring buffer of the most recent enqueue-to-dequeue wait
+	// times and waitTimesIdx is the next position to write to.
+	waitTimes    []time.Duration
+	waitTimesIdx int
+
+	// agingInterval is how long a plan has to wait before its effective
+	// priority is boosted, so that low priority plans are not starved by a
+	// steady stream of higher priority ones. Aging is disabled if zero.
+	// maxPriority caps the effective priority of aged plans.
+	agingInterval time.Duration
+	maxPriority   int
+
 	l sync.RWMutex
 }
 
 // NewPlanQueue is used to construct and return a new plan queue
 func NewPlanQueue() (*PlanQueue, error) {
 	q := &PlanQueue{
-		enabled: false,
-		stats:   new(QueueStats),
-		ready:   make([]*pendingPlan, 0, 16),
-		waitCh:  make(chan struct{}, 1),
+		enabled:     false,
+		stats:       new(QueueStats),
+		ready:       make([]*pendingPlan, 0, 16),
+		waitCh:      make(chan struct{}, 1),
+		waitTimes:   make([]time.Duration, 0, planQueueWaitSamples),
+		maxPriority: structs.JobDefaultMaxPriority,
 	}
 	return q, nil
 }
@@ -58,6 +84,10 @@ type pendingPlan struct {
 	enqueueTime time.Time
 	result      *structs.PlanResult
 	errCh       chan error
+
+	// effectivePriority is the priority used to order the plan in the queue.
+	// It starts at the plan priority and increases as the plan ages.
+	effectivePriority int
 }
 
 // Wait is used to block for the plan result or potential error
@@ -107,9 +137,10 @@ func (q *PlanQueue) Enqueue(plan *structs.Plan) (PlanFuture, error) {
 
 	// Wrap the pending plan
 	pending := &pendingPlan{
-		plan:        plan,
-		enqueueTime: time.Now(),
-		errCh:       make(chan error, 1),
+		plan:              plan,
+		enqueueTime:       time.Now(),
+		errCh:             make(chan error, 1),
+		effectivePriority: plan.Priority,
 	}
 
 	// Push onto the heap
@@ -139,10 +170,20 @@ SCAN:
 
 	// Look for available work
 	if len(q.ready) > 0 {
+		now := time.Now()
+		q.age(now)
+
 		raw := heap.Pop(&q.ready)
 		pending := raw.(*pendingPlan)
 		q.stats.Depth -= 1
+		q.recordWaitTime(now.Sub(pending.enqueueTime))
 		q.l.Unlock()
+
+		labels := []metrics.Label{{Name: "priority", Value: strconv.Itoa(pending.plan.Priority)}}
+		metrics.MeasureSinceWithLabels([]string{"nomad", "plan", "queue_wait"}, pending.enqueueTime, labels)
+		if pending.effectivePriority > pending.plan.Priority {
+			metrics.IncrCounterWithLabels([]string{"nomad", "plan", "queue_aged"}, 1, labels)
+		}
 		return pending, nil
 	}
 	q.l.Unlock()
@@ -177,6 +218,8 @@ func (q *PlanQueue) Flush() {
 	// Reset the broker
 	q.stats.Depth = 0
 	q.ready = make([]*pendingPlan, 0, 16)
+	q.waitTimes = make([]time.Duration, 0, planQueueWaitSamples)
+	q.waitTimesIdx = 0
 
 	// Unblock any waiters
 	select {
@@ -198,6 +241,137 @@ func (q *PlanQueue) Stats() *QueueStats {
 	return stats
 }
 
+// SetAging configures the interval after which waiting plans have their
+// effective priority boosted, and the priority the boost is capped at. An
+// interval of zero disables aging.
+func (q *PlanQueue) SetAging(interval time.Duration, maxPriority int) {
+	q.l.Lock()
+	defer q.l.Unlock()
+	q.agingInterval = interval
+	q.maxPriority = maxPriority
+}
+
+// effectivePriority returns the priority of the plan boosted by
+// planQueueAgingBoost for every full aging interval it has been waiting at
+// the given time. The lock must be held by the caller.
+func (q *PlanQueue) effectivePriority(pending *pendingPlan, now time.Time) int {
+	priority := pending.plan.Priority
+	if q.agingInterval <= 0 || priority >= q.maxPriority {
+		return priority
+	}
+
+	intervals := int(now.Sub(pending.enqueueTime) / q.agingInterval)
+	if intervals <= 0 {
+		return priority
+	}
+	if intervals > (q.maxPriority-priority)/planQueueAgingBoost {
+		return q.maxPriority
+	}
+	return priority + intervals*planQueueAgingBoost
+}
+
+// age updates the effective priority of all the ready plans and restores the
+// heap ordering if any of them changed. The lock must be held by the caller.
+func (q *PlanQueue) age(now time.Time) {
+	if q.agingInterval <= 0 {
+		return
+	}
+
+	changed := false
+	for _, pending := range q.ready {
+		priority := q.effectivePriority(pending, now)
+		if priority != pending.effectivePriority {
+			pending.effectivePriority = priority
+			changed = true
+		}
+	}
+	if changed {
+		heap.Init(&q.ready)
+	}
+}
+
+// recordWaitTime stores the time a plan spent in the queue before being
+// dequeued. The lock must be held by the caller.
+func (q *PlanQueue) recordWaitTime(d time.Duration) {
+	if len(q.waitTimes) < planQueueWaitSamples {
+		q.waitTimes = append(q.waitTimes, d)
+	} else {
+		q.waitTimes[q.waitTimesIdx] = d
+	}
+	q.waitTimesIdx = (q.waitTimesIdx + 1) % planQueueWaitSamples
+}
+
+// Status is used to return a snapshot of the plans waiting in the queue,
+// ordered by dequeue order, along with the depth of the queue per priority
+// and the distribution of the most recent enqueue-to-dequeue wait times.
+func (q *PlanQueue) Status() *structs.PlanQueueStatus {
+	now := time.Now()
+
+	q.l.RLock()
+	defer q.l.RUnlock()
+
+	status := &structs.PlanQueueStatus{
+		Enabled:         q.enabled,
+		Depth:           q.stats.Depth,
+		DepthByPriority: make(map[int]int),
+		Pending:         make([]*structs.PendingPlanStub, 0, len(q.ready)),
+	}
+
+	for _, pending := range q.ready {
+		plan := pending.plan
+		stub := &structs.PendingPlanStub{
+			EvalID:            plan.EvalID,
+			Priority:          plan.Priority,
+			EffectivePriority: q.effectivePriority(pending, now),
+			EnqueueTime:       pending.enqueueTime,
+			WaitTime:          now.Sub(pending.enqueueTime),
+		}
+		if plan.Job != nil {
+			stub.Namespace = plan.Job.Namespace
+			stub.JobID = plan.Job.ID
+		}
+		status.Pending = append(status.Pending, stub)
+		status.DepthByPriority[plan.Priority]++
+	}
+
+	// The heap is only partially ordered, so sort the copy the same way
+	// plans are dequeued.
+	slices.SortFunc(status.Pending, func(a, b *structs.PendingPlanStub) int {
+		if a.EffectivePriority != b.EffectivePriority {
+			return b.EffectivePriority - a.EffectivePriority
+		}
+		return a.EnqueueTime.Compare(b.EnqueueTime)
+	})
+
+	status.WaitTime = planQueueWaitPercentiles(q.waitTimes)
+	return status
+}
+
+// planQueueWaitPercentiles computes the distribution of the given wait times
+// without modifying them.
+func planQueueWaitPercentiles(samples []time.Duration) structs.PlanQueueWaitTime {
+	if len(samples) == 0 {
+		return structs.PlanQueueWaitTime{}
+	}
+
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+
+	// Use the nearest-rank method so each percentile is an observed value.
+	percentile := func(p int) time.Duration {
+		rank := (p*len(sorted) + 99) / 100
+		return sorted[max(rank, 1)-1]
+	}
+
+	return structs.PlanQueueWaitTime{
+		Samples: len(sorted),
+		P50:     percentile(50),
+		P90:     percentile(90),
+		P99:     percentile(99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
 // EmitStats is used to export metrics about the broker while enabled
 func (q *PlanQueue) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 	timer, stop := helper.NewSafeTimer(period)
@@ -228,11 +402,11 @@ func (p PendingPlans) Len() int {
 
 // Less is for the sorting interface. We flip the check
 // so that the "min" in the min-heap is the element with the
-// highest priority. For the same priority, we use the enqueue
-// time of the evaluation to give a FIFO ordering.
+// highest effective priority. For the same priority, we use the
+// enqueue time of the evaluation to give a FIFO ordering.
 func (p PendingPlans) Less(i, j int) bool {
-	if p[i].plan.Priority != p[j].plan.Priority {
-		return !(p[i].plan.Priority < p[j].plan.Priority)
+	if p[i].effectivePriority != p[j].effectivePriority {
+		return !(p[i].effectivePriority < p[j].effectivePriority)
 	}
 	return p[i].enqueueTime.Before(p[j].enqueueTime)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 )
 
 func testPlanQueue(t *testing.T) *PlanQueue {
@@ -200,3 +201,181 @@ func TestPlanQueue_Dequeue_FIFO(t *testing.T) {
 		prev = out
 	}
 }
+
+func TestPlanQueue_Dequeue_Aging(t *testing.T) {
+	ci.Parallel(t)
+	pq := testPlanQueue(t)
+	pq.SetAging(time.Minute, structs.JobDefaultMaxPriority)
+	pq.SetEnabled(true)
+
+	plan1 := mock.Plan()
+	plan1.Priority = 30
+	future, err := pq.Enqueue(plan1)
+	must.NoError(t, err)
+
+	plan2 := mock.Plan()
+	plan2.Priority = 50
+	_, err = pq.Enqueue(plan2)
+	must.NoError(t, err)
+
+	// The low priority plan has waited long enough to be boosted above the
+	// high priority one.
+	future.(*pendingPlan).enqueueTime = time.Now().Add(-3 * time.Minute)
+
+	status := pq.Status()
+	must.Eq(t, plan1.EvalID, status.Pending[0].EvalID)
+	must.Eq(t, 30, status.Pending[0].Priority)
+	must.Eq(t, 60, status.Pending[0].EffectivePriority)
+	must.Eq(t, 50, status.Pending[1].EffectivePriority)
+
+	out, err := pq.Dequeue(time.Second)
+	must.NoError(t, err)
+	must.Eq(t, plan1, out.plan)
+	must.Eq(t, 60, out.effectivePriority)
+
+	out, err = pq.Dequeue(time.Second)
+	must.NoError(t, err)
+	must.Eq(t, plan2, out.plan)
+}
+
+func TestPlanQueue_EffectivePriority(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	cases := []struct {
+		name     string
+		interval time.Duration
+		priority int
+		waited   time.Duration
+		expected int
+	}{
+		{
+			name:     "aging disabled",
+			priority: 50,
+			waited:   time.Hour,
+			expected: 50,
+		},
+		{
+			name:     "within first interval",
+			interval: time.Minute,
+			priority: 50,
+			waited:   59 * time.Second,
+			expected: 50,
+		},
+		{
+			name:     "boosted per interval",
+			interval: time.Minute,
+			priority: 50,
+			waited:   2*time.Minute + time.Second,
+			expected: 70,
+		},
+		{
+			name:     "capped at max priority",
+			interval: time.Minute,
+			priority: 50,
+			waited:   time.Hour,
+			expected: 100,
+		},
+		{
+			name:     "above max priority",
+			interval: time.Minute,
+			priority: 100,
+			waited:   time.Hour,
+			expected: 100,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq := testPlanQueue(t)
+			pq.SetAging(tc.interval, structs.JobDefaultMaxPriority)
+
+			plan := mock.Plan()
+			plan.Priority = tc.priority
+			pending := &pendingPlan{plan: plan, enqueueTime: now.Add(-tc.waited)}
+			must.Eq(t, tc.expected, pq.effectivePriority(pending, now))
+		})
+	}
+}
+
+func TestPlanQueue_Status(t *testing.T) {
+	ci.Parallel(t)
+	pq := testPlanQueue(t)
+	pq.SetEnabled(true)
+
+	plan1 := mock.Plan()
+	plan1.Priority = 50
+	plan1.Job = mock.Job()
+	plan2 := mock.Plan()
+	plan2.Priority = 100
+	plan3 := mock.Plan()
+	plan3.Priority = 50
+
+	for _, plan := range []*structs.Plan{plan1, plan2, plan3} {
+		_, err := pq.Enqueue(plan)
+		must.NoError(t, err)
+	}
+
+	status := pq.Status()
+	must.True(t, status.Enabled)
+	must.Eq(t, 3, status.Depth)
+	must.Eq(t, map[int]int{50: 2, 100: 1}, status.DepthByPriority)
+	must.Len(t, 3, status.Pending)
+
+	// Pending plans are listed in dequeue order.
+	must.Eq(t, plan2.EvalID, status.Pending[0].EvalID)
+	must.Eq(t, plan1.EvalID, status.Pending[1].EvalID)
+	must.Eq(t, plan3.EvalID, status.Pending[2].EvalID)
+	must.Eq(t, plan1.Job.ID, status.Pending[1].JobID)
+	must.Zero(t, status.WaitTime.Samples)
+
+	for i := 0; i < 3; i++ {
+		pending, err := pq.Dequeue(time.Second)
+		must.NoError(t, err)
+		must.Eq(t, status.Pending[i].EvalID, pending.plan.EvalID)
+	}
+
+	status = pq.Status()
+	must.Zero(t, status.Depth)
+	must.MapEmpty(t, status.DepthByPriority)
+	must.SliceEmpty(t, status.Pending)
+	must.Eq(t, 3, status.WaitTime.Samples)
+	must.Positive(t, status.WaitTime.Max)
+
+	// Flushing the queue resets the wait times.
+	pq.Flush()
+	must.Zero(t, pq.Status().WaitTime.Samples)
+}
+
+func TestPlanQueue_WaitPercentiles(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, structs.PlanQueueWaitTime{}, planQueueWaitPercentiles(nil))
+
+	samples := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	wait := planQueueWaitPercentiles(samples)
+	must.Eq(t, 100, wait.Samples)
+	must.Eq(t, 50*time.Millisecond, wait.P50)
+	must.Eq(t, 90*time.Millisecond, wait.P90)
+	must.Eq(t, 99*time.Millisecond, wait.P99)
+	must.Eq(t, 100*time.Millisecond, wait.Max)
+
+	// The samples are not reordered.
+	must.Eq(t, 100*time.Millisecond, samples[0])
+}
+
+func TestPlanQueue_RecordWaitTime(t *testing.T) {
+	ci.Parallel(t)
+	pq := testPlanQueue(t)
+
+	for i := 0; i < planQueueWaitSamples+10; i++ {
+		pq.recordWaitTime(time.Duration(i))
+	}
+	must.Len(t, planQueueWaitSamples, pq.waitTimes)
+	must.Eq(t, 10, pq.waitTimesIdx)
+	must.Eq(t, time.Duration(planQueueWaitSamples), pq.waitTimes[0])
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// replicationConflicts tracks the objects of the authoritative region that the
+// leader of a federated region can't replicate. Conflicts are only tracked in
+// memory by the leader and are detected again by a new leader.
+type replicationConflicts struct {
+	lock sync.RWMutex
+
+	// conflicts are the current conflicts by object type and name.
+	conflicts map[string]map[string]*structs.ReplicationConflict
+}
+
+func newReplicationConflicts() *replicationConflicts {
+	return &replicationConflicts{
+		conflicts: make(map[string]map[string]*structs.ReplicationConflict),
+	}
+}
+
+// set replaces the conflicts of the object type with the given ones, keeping
+// the time conflicts that are still present were first detected.
+func (r *replicationConflicts) set(typ string, conflicts []*structs.ReplicationConflict) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	previous := r.conflicts[typ]
+	current := make(map[string]*structs.ReplicationConflict, len(conflicts))
+	for _, c := range conflicts {
+		if prev, ok := previous[c.Name]; ok {
+			c.Since = prev.Since
+		}
+		current[c.Name] = c
+	}
+	r.conflicts[typ] = current
+
+	metrics.SetGaugeWithLabels([]string{"nomad", "replication", "conflicts"},
+		float32(len(current)), []metrics.Label{{Name: "type", Value: typ}})
+}
+
+// list returns the current conflicts sorted by object type and name.
+func (r *replicationConflicts) list() []*structs.ReplicationConflict {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	out := []*structs.ReplicationConflict{}
+	for _, conflicts := range r.conflicts {
+		for _, c := range conflicts {
+			copied := *c
+			out = append(out, &copied)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// reset removes all the conflicts, when leadership is lost.
+func (r *replicationConflicts) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for typ := range r.conflicts {
+		metrics.SetGaugeWithLabels([]string{"nomad", "replication", "conflicts"},
+			0, []metrics.Label{{Name: "type", Value: typ}})
+	}
+	r.conflicts = make(map[string]map[string]*structs.ReplicationConflict)
+}
+
+// replicationDeletes splits the objects that replication should delete
+// between the ones that can be deleted and the ones still in use in this
+// region, which are returned as conflicts.
+func replicationDeletes(typ string, names []string, inUse func(string) error) ([]string, []*structs.ReplicationConflict) {
+	var deletes []string
+	var conflicts []*structs.ReplicationConflict
+
+	now := time.Now().UTC()
+	for _, name := range names {
+		if err := inUse(name); err != nil {
+			conflicts = append(conflicts, &structs.ReplicationConflict{
+				Type:   typ,
+				Name:   name,
+				Reason: err.Error(),
+				Since:  now,
+			})
+			continue
+		}
+		deletes = append(deletes, name)
+	}
+	return deletes, conflicts
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestReplicationConflicts(t *testing.T) {
+	ci.Parallel(t)
+
+	inUse := func(name string) error {
+		if name == "prod" || name == "dev" {
+			return errors.New("in use")
+		}
+		return nil
+	}
+
+	r := newReplicationConflicts()
+	deletes, conflicts := replicationDeletes(structs.ReplicationConflictNamespace,
+		[]string{"prod", "old"}, inUse)
+	must.Eq(t, []string{"old"}, deletes)
+	must.Len(t, 1, conflicts)
+	r.set(structs.ReplicationConflictNamespace, conflicts)
+
+	since := r.list()[0].Since
+	time.Sleep(time.Millisecond)
+
+	// Conflicts detected again keep the time they were first detected
+	_, conflicts = replicationDeletes(structs.ReplicationConflictNamespace,
+		[]string{"prod", "dev"}, inUse)
+	r.set(structs.ReplicationConflictNamespace, conflicts)
+	_, conflicts = replicationDeletes(structs.ReplicationConflictNodePool,
+		[]string{"prod"}, inUse)
+	r.set(structs.ReplicationConflictNodePool, conflicts)
+
+	list := r.list()
+	must.Len(t, 3, list)
+	must.Eq(t, "dev", list[0].Name)
+	must.Eq(t, "prod", list[1].Name)
+	must.Eq(t, since, list[1].Since)
+	must.Eq(t, structs.ReplicationConflictNodePool, list[2].Type)
+
+	// Resolved conflicts are removed
+	r.set(structs.ReplicationConflictNamespace, nil)
+	must.Len(t, 1, r.list())
+
+	r.reset()
+	must.Len(t, 0, r.list())
+}
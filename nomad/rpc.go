@@ -60,6 +60,9 @@ type rpcHandler struct {
 	streamLimiter *connlimit.Limiter
 	streamLimit   int
 
+	// blockingQueries limits the concurrent blocking queries of each caller
+	blockingQueries *blockingQueryLimiter
+
 	logger   log.Logger
 	gologger *golog.Logger
 }
@@ -70,8 +73,10 @@ func newRpcHandler(s *Server) *rpcHandler {
 	r := rpcHandler{
 		srv:       s,
 		connLimit: s.config.RPCMaxConnsPerClient,
-		logger:    logger,
-		gologger:  logger.StandardLoggerIntercept(&log.StandardLoggerOptions{InferLevels: true}),
+		blockingQueries: newBlockingQueryLimiter(
+			s.config.BlockingQueriesPerToken, s.config.BlockingQueriesPerIP),
+		logger:   logger,
+		gologger: logger.StandardLoggerIntercept(&log.StandardLoggerOptions{InferLevels: true}),
 	}
 
 	// Setup connection limits
@@ -716,7 +721,20 @@ func (r *rpcHandler) forwardRegion(region, method string, args interface{}, repl
 
 	// Forward to remote Nomad
 	metrics.IncrCounter([]string{"nomad", "rpc", "cross-region", region}, 1)
-	return r.srv.connPool.RPC(region, server.Addr, method, args, reply)
+	start := time.Now()
+	err = r.srv.connPool.RPC(region, server.Addr, method, args, reply)
+
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	metrics.MeasureSinceWithLabels([]string{"nomad", "rpc", "cross_region", "rtt"}, start,
+		[]metrics.Label{
+			{Name: "region", Value: region},
+			{Name: "method", Value: method},
+			{Name: "result", Value: result},
+		})
+	return err
 }
 
 func (r *rpcHandler) getServer(region, serverID string) (*serverParts, error) {
@@ -854,6 +872,16 @@ func (r *rpcHandler) blockingRPC(opts *blockingOptions) error {
 	var cancel context.CancelFunc
 	var state *state.StateStore
 
+	// Limit the concurrent blocking queries of each caller
+	if opts.queryOpts.MinQueryIndex > 0 {
+		release, err := r.blockingQueries.acquire(
+			opts.queryOpts.GetIdentity(), opts.queryOpts.IsForwarded())
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
 	// Fast path non-blocking
 	if opts.queryOpts.MinQueryIndex == 0 {
 		goto RUN_QUERY
@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	// scaleWithNodesCoalesce is the amount of time to wait after a change to
+	// the node or job tables before recomputing group counts, so that a burst
+	// of node registrations only results in a single scaling action.
+	scaleWithNodesCoalesce = 5 * time.Second
+
+	// scaleWithNodesResync is the maximum amount of time between two passes
+	// of the controller. This allows retrying groups whose scaling was
+	// blocked, for example by an active deployment.
+	scaleWithNodesResync = 1 * time.Minute
+)
+
+// scaleGroupsWithNodes is a long lived function run on the leader that keeps
+// the count of task groups with a scale_with_nodes block proportional to the
+// number of matching nodes. It stops once stopCh is closed.
+func (s *Server) scaleGroupsWithNodes(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(s.shutdownCtx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	groups := make(scaleWithNodesGroups)
+	for {
+		ws := memdb.NewWatchSet()
+		if err := s.reconcileScaleWithNodes(ws, groups, time.Now()); err != nil {
+			s.logger.Error("failed to reconcile scale_with_nodes groups", "error", err)
+		}
+
+		// Block until the node or job tables change, or the resync
+		// interval is reached.
+		watchCtx, watchCancel := context.WithTimeout(ctx, scaleWithNodesResync)
+		ws.WatchCtx(watchCtx)
+		watchCancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(scaleWithNodesCoalesce):
+		}
+	}
+}
+
+// scaleWithNodesKey identifies a task group with a scale_with_nodes block.
+type scaleWithNodesKey struct {
+	job   structs.NamespacedID
+	group string
+}
+
+// scaleWithNodesGroup is what the leader remembers about a task group with a
+// scale_with_nodes block between two reconciliations.
+type scaleWithNodesGroup struct {
+	// version is the job version seen by the last reconciliation.
+	version uint64
+
+	// belowSince is when the desired count of the group first dropped
+	// below its current count, or zero if it is not below.
+	belowSince time.Time
+}
+
+// scaleWithNodesGroups tracks the task groups with a scale_with_nodes block
+// across reconciliations.
+type scaleWithNodesGroups map[scaleWithNodesKey]*scaleWithNodesGroup
+
+// reconcileScaleWithNodes computes the desired count of every task group
+// with a scale_with_nodes block and scales the groups whose count differs.
+// Groups whose job version changed since the last reconciliation are skipped
+// until the next one, and groups are only scaled down once their desired
+// count has stayed lower for the scale down delay. The node and job tables
+// are added to the passed watch set.
+func (s *Server) reconcileScaleWithNodes(ws memdb.WatchSet, groups scaleWithNodesGroups, now time.Time) error {
+	defer metrics.MeasureSince([]string{"nomad", "scale_with_nodes", "reconcile"}, time.Now())
+
+	snap, err := s.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	nodeIter, err := snap.Nodes(ws)
+	if err != nil {
+		return err
+	}
+	var nodes []*structs.Node
+	for raw := nodeIter.Next(); raw != nil; raw = nodeIter.Next() {
+		nodes = append(nodes, raw.(*structs.Node))
+	}
+
+	jobIter, err := snap.Jobs(ws, state.SortDefault)
+	if err != nil {
+		return err
+	}
+	seen := make(map[scaleWithNodesKey]struct{})
+	for raw := jobIter.Next(); raw != nil; raw = jobIter.Next() {
+		job := raw.(*structs.Job)
+		if job.Stopped() || job.Type != structs.JobTypeService {
+			continue
+		}
+
+		for _, tg := range job.TaskGroups {
+			if tg.ScaleWithNodes == nil {
+				continue
+			}
+
+			key := scaleWithNodesKey{job: job.NamespacedID(), group: tg.Name}
+			seen[key] = struct{}{}
+
+			// Don't act on a job version seen for the first time, the
+			// operator or a previous scaling may have just changed it.
+			group, ok := groups[key]
+			if !ok || group.version != job.Version {
+				groups[key] = &scaleWithNodesGroup{version: job.Version}
+				continue
+			}
+
+			matching := 0
+			for _, node := range nodes {
+				if tg.ScaleWithNodes.Matches(job, node) {
+					matching++
+				}
+			}
+
+			desired := tg.ScaleWithNodes.DesiredCount(matching)
+			if desired >= tg.Count {
+				group.belowSince = time.Time{}
+				if desired == tg.Count {
+					continue
+				}
+			} else {
+				if group.belowSince.IsZero() {
+					group.belowSince = now
+				}
+				if now.Sub(group.belowSince) < tg.ScaleWithNodes.ScaleDownDelay {
+					continue
+				}
+			}
+
+			if err := s.scaleGroupWithNodes(job, tg.Name, desired, matching); err != nil {
+				s.logger.Debug("failed to scale group with node population",
+					"namespace", job.Namespace, "job", job.ID, "group", tg.Name, "error", err)
+				continue
+			}
+			group.belowSince = time.Time{}
+		}
+	}
+
+	// Forget the groups that were removed or stopped
+	for key := range groups {
+		if _, ok := seen[key]; !ok {
+			delete(groups, key)
+		}
+	}
+
+	return nil
+}
+
+// scaleGroupWithNodes submits a scaling request for the given group so that
+// it goes through the same validation, deployment checks and scaling event
+// bookkeeping as a scaling request made by an operator.
+func (s *Server) scaleGroupWithNodes(job *structs.Job, group string, count, nodes int) error {
+	req := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetNamespace: job.Namespace,
+			structs.ScalingTargetJob:       job.ID,
+			structs.ScalingTargetGroup:     group,
+		},
+		Count:          pointer.Of(int64(count)),
+		Message:        fmt.Sprintf("scaled with node population: %d matching nodes", nodes),
+		JobModifyIndex: job.JobModifyIndex,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			Namespace: job.Namespace,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+
+	var resp structs.JobRegisterResponse
+	if err := s.RPC("Job.Scale", req, &resp); err != nil {
+		return err
+	}
+
+	metrics.IncrCounterWithLabels([]string{"nomad", "scale_with_nodes", "scaled"}, 1,
+		[]metrics.Label{
+			{Name: "namespace", Value: job.Namespace},
+			{Name: "job", Value: job.ID},
+			{Name: "task_group", Value: group},
+		})
+
+	s.logger.Info("scaled group with node population",
+		"namespace", job.Namespace, "job", job.ID, "group", group,
+		"count", count, "nodes", nodes)
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestServer_ReconcileScaleWithNodes(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		node.NodeClass = "web"
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+	}
+
+	// Nodes of another class are not counted.
+	other := mock.Node()
+	other.NodeClass = "db"
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 110, other))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].ScaleWithNodes = &structs.ScaleWithNodes{
+		NodeClass: "web*",
+		PerNode:   2,
+		Max:       5,
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 120, nil, job))
+
+	// The first reconciliation only records the job version
+	groups := make(scaleWithNodesGroups)
+	now := time.Now()
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now))
+
+	out, err := store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1, out.TaskGroups[0].Count)
+
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now))
+
+	out, err = store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 5, out.TaskGroups[0].Count)
+
+	events, _, err := store.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, events[job.TaskGroups[0].Name])
+	must.StrContains(t, events[job.TaskGroups[0].Name][0].Message, "3 matching nodes")
+}
+
+func TestServer_ReconcileScaleWithNodes_ScaleDown(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].ScaleWithNodes = &structs.ScaleWithNodes{
+		PerNode:        1,
+		ScaleDownDelay: time.Minute,
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 110, nil, job))
+
+	count := func() int {
+		out, err := store.JobByID(nil, job.Namespace, job.ID)
+		must.NoError(t, err)
+		return out.TaskGroups[0].Count
+	}
+
+	groups := make(scaleWithNodesGroups)
+	now := time.Now()
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now))
+
+	// The group is not scaled down before the delay
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now))
+	must.Eq(t, 3, count())
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(30*time.Second)))
+	must.Eq(t, 3, count())
+
+	// A job update restarts the delay
+	job = job.Copy()
+	job.Meta = map[string]string{"version": "2"}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 120, nil, job))
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(2*time.Minute)))
+	must.Eq(t, 3, count())
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(2*time.Minute)))
+	must.Eq(t, 3, count())
+
+	// The group is scaled down once the delay elapsed
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(3*time.Minute)))
+	must.Eq(t, 1, count())
+
+	// Groups are scaled to zero only if min is set to zero
+	must.NoError(t, store.DeleteNode(structs.MsgTypeTestSetup, 130, []string{node.ID}))
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(4*time.Minute)))
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(5*time.Minute)))
+	must.NoError(t, s1.reconcileScaleWithNodes(memdb.NewWatchSet(), groups, now.Add(7*time.Minute)))
+	must.Eq(t, 0, count())
+}
@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"sort"
 	"strings"
 	"time"
 
@@ -218,3 +219,107 @@ func (p *Scaling) listAllNamespaces(args *structs.ScalingPolicyListRequest, repl
 		}}
 	return p.srv.blockingRPC(&opts)
 }
+
+// ListEvents is used to list the scaling events of all jobs, optionally
+// filtered by time range and source. Events are returned newest first.
+func (p *Scaling) ListEvents(args *structs.ScalingEventListRequest, reply *structs.ScalingEventListResponse) error {
+
+	authErr := p.srv.Authenticate(p.ctx, args)
+	if done, err := p.srv.forward("Scaling.ListEvents", args, args, reply); done {
+		return err
+	}
+	p.srv.MeasureRPCRate("scaling", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "list_events"}, time.Now())
+
+	aclObj, err := p.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	allow := func(ns string) bool {
+		return aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) ||
+			aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJobScaling)
+	}
+	namespace := args.RequestNamespace()
+	if namespace != structs.AllNamespacesSentinel && !allow(namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			var allowed map[string]bool
+			if namespace == structs.AllNamespacesSentinel {
+				allowed, err = allowedNSes(aclObj, state, allow)
+				if err == structs.ErrPermissionDenied {
+					// return empty if token isn't authorized for any namespace
+					reply.Events = []*structs.ScalingEventListStub{}
+					return nil
+				} else if err != nil {
+					return err
+				}
+			}
+
+			iter, err := state.ScalingEvents(ws)
+			if err != nil {
+				return err
+			}
+
+			var events []*structs.ScalingEventListStub
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				jobEvents := raw.(*structs.JobScalingEvents)
+				if namespace != structs.AllNamespacesSentinel && jobEvents.Namespace != namespace {
+					continue
+				}
+				if allowed != nil && !allowed[jobEvents.Namespace] {
+					// not permitted to this name namespace
+					continue
+				}
+				for group, groupEvents := range jobEvents.ScalingEvents {
+					for _, event := range groupEvents {
+						if !scalingEventMatches(args, event) {
+							continue
+						}
+						events = append(events, &structs.ScalingEventListStub{
+							Namespace: jobEvents.Namespace,
+							JobID:     jobEvents.JobID,
+							TaskGroup: group,
+							Event:     event,
+						})
+					}
+				}
+			}
+			sort.SliceStable(events, func(i, j int) bool {
+				return events[i].Event.Time > events[j].Event.Time
+			})
+			reply.Events = events
+
+			// Use the last index that affected the scaling events table
+			index, err := state.Index("scaling_event")
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+
+			// Set the query response
+			p.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// scalingEventMatches returns whether the event passes the time range and
+// source filters of the request.
+func scalingEventMatches(args *structs.ScalingEventListRequest, event *structs.ScalingEvent) bool {
+	if args.Start != 0 && event.Time < args.Start {
+		return false
+	}
+	if args.End != 0 && event.Time > args.End {
+		return false
+	}
+	return args.Source == "" || event.Source == args.Source
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -356,3 +357,128 @@ func TestScalingEndpoint_ListPolicies_Blocking(t *testing.T) {
 	require.Len(resp.Policies, 2)
 	require.ElementsMatch([]string{p1.ID, p2.ID}, []string{resp.Policies[0].ID, resp.Policies[1].ID})
 }
+
+func TestScalingEndpoint_ListEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, state.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	now := time.Now()
+	upsertEvent := func(index uint64, namespace, source string, ago time.Duration) {
+		must.NoError(t, state.UpsertScalingEvent(index, &structs.ScalingEventRequest{
+			Namespace: namespace,
+			JobID:     uuid.Generate(),
+			TaskGroup: "web",
+			ScalingEvent: &structs.ScalingEvent{
+				Time:    now.Add(-ago).UnixNano(),
+				Message: "scaled",
+				Source:  source,
+			},
+		}))
+	}
+	upsertEvent(1000, structs.DefaultNamespace, "autoscaler", 3*time.Hour)
+	upsertEvent(1001, structs.DefaultNamespace, "autoscaler", time.Hour)
+	upsertEvent(1002, structs.DefaultNamespace, "operator", 2*time.Hour)
+	upsertEvent(1003, ns.Name, "autoscaler", 30*time.Minute)
+
+	list := func(namespace string, start, end time.Time, source string) []*structs.ScalingEventListStub {
+		req := &structs.ScalingEventListRequest{
+			Source: source,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: namespace,
+			},
+		}
+		if !start.IsZero() {
+			req.Start = start.UnixNano()
+		}
+		if !end.IsZero() {
+			req.End = end.UnixNano()
+		}
+		var resp structs.ScalingEventListResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Scaling.ListEvents", req, &resp))
+		must.Eq(t, 1003, resp.Index)
+		return resp.Events
+	}
+
+	// All events of the namespace, newest first
+	events := list(structs.DefaultNamespace, time.Time{}, time.Time{}, "")
+	must.Len(t, 3, events)
+	must.Eq(t, now.Add(-time.Hour).UnixNano(), events[0].Event.Time)
+	must.Eq(t, now.Add(-3*time.Hour).UnixNano(), events[2].Event.Time)
+	must.Eq(t, "web", events[0].TaskGroup)
+
+	// Filtered by source and time range
+	must.Len(t, 2, list(structs.DefaultNamespace, time.Time{}, time.Time{}, "autoscaler"))
+	must.Len(t, 2, list(structs.DefaultNamespace, now.Add(-150*time.Minute), time.Time{}, ""))
+	must.Len(t, 1, list(structs.DefaultNamespace, now.Add(-150*time.Minute), now.Add(-90*time.Minute), ""))
+
+	// Across all namespaces
+	events = list(structs.AllNamespacesSentinel, time.Time{}, time.Time{}, "autoscaler")
+	must.Len(t, 3, events)
+	must.Eq(t, ns.Name, events[0].Namespace)
+}
+
+func TestScalingEndpoint_ListEvents_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	must.NoError(t, state.UpsertNamespaces(999, []*structs.Namespace{ns}))
+	for i, namespace := range []string{structs.DefaultNamespace, ns.Name} {
+		must.NoError(t, state.UpsertScalingEvent(uint64(1000+i), &structs.ScalingEventRequest{
+			Namespace:    namespace,
+			JobID:        uuid.Generate(),
+			TaskGroup:    "web",
+			ScalingEvent: &structs.ScalingEvent{Time: time.Now().UnixNano()},
+		}))
+	}
+
+	req := &structs.ScalingEventListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// Lookup without a token fails
+	var resp structs.ScalingEventListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Scaling.ListEvents", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// A token with read-job-scaling on the default namespace succeeds
+	token := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJobScaling}))
+	req.AuthToken = token.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Scaling.ListEvents", req, &resp))
+	must.Len(t, 1, resp.Events)
+
+	// Listing all namespaces only returns the permitted ones
+	req.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Scaling.ListEvents", req, &resp))
+	must.Len(t, 1, resp.Events)
+	must.Eq(t, structs.DefaultNamespace, resp.Events[0].Namespace)
+
+	// But it's denied for the other namespace
+	req.Namespace = ns.Name
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.ListEvents", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Management tokens see everything
+	req.AuthToken = root.SecretID
+	req.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Scaling.ListEvents", req, &resp))
+	must.Len(t, 2, resp.Events)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// SimulateJob runs the scheduler for the job against the state snapshot and
+// evaluates the resulting plan the same way the plan applier would, without
+// committing anything. The job is inserted into the snapshot, so the snapshot
+// must not be used for anything else afterwards.
+func SimulateJob(logger log.Logger, snap *state.StateSnapshot, job *structs.Job) (*structs.SchedulerSimulation, error) {
+	existingJob, err := snap.JobByID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure that all scaling policies have an appropriate ID
+	if err := propagateScalingPolicyIDs(existingJob, job); err != nil {
+		return nil, err
+	}
+
+	latest, err := snap.LatestIndex()
+	if err != nil {
+		return nil, err
+	}
+	index := latest + 1
+
+	// Only insert the job if it has changed, so existing allocations are
+	// updated in place and deployments are reused as they would be on
+	// registration.
+	var jobModifyIndex uint64
+	if existingJob == nil || existingJob.SpecChanged(job) {
+		jobModifyIndex = index
+		if err := snap.UpsertJob(structs.IgnoreUnknownTypeFlag, index, nil, job); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: jobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		// Timestamps are added for consistency but this eval is never persisted
+		CreateTime: now,
+		ModifyTime: now,
+	}
+	if err := snap.UpsertEvals(structs.IgnoreUnknownTypeFlag, index, []*structs.Evaluation{eval}); err != nil {
+		return nil, err
+	}
+
+	pool := NewEvaluatePool(1, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	planner := &simulatePlanner{
+		snap:   snap,
+		pool:   pool,
+		logger: logger,
+	}
+	sched, err := scheduler.NewScheduler(eval.Type, logger, nil, snap, planner)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, err
+	}
+
+	out := &structs.SchedulerSimulation{}
+	if planner.eval != nil {
+		out.FailedTGAllocs = planner.eval.FailedTGAllocs
+	}
+	if planner.result != nil {
+		out.Placements = simulatedAllocations(planner.result.NodeAllocation, true)
+		out.Stops = simulatedAllocations(planner.result.NodeUpdate, false)
+		out.Preemptions = simulatedAllocations(planner.result.NodePreemptions, false)
+		out.NodeRejections = planner.result.NodeRejections
+	}
+	return out, nil
+}
+
+// simulatedAllocations flattens the allocations of a plan result, sorted by
+// name and node.
+func simulatedAllocations(nodeAllocs map[string][]*structs.Allocation, withMetrics bool) []*structs.SimulatedAllocation {
+	var out []*structs.SimulatedAllocation
+	for nodeID, allocs := range nodeAllocs {
+		for _, alloc := range allocs {
+			sim := &structs.SimulatedAllocation{
+				ID:        alloc.ID,
+				Name:      alloc.Name,
+				Namespace: alloc.Namespace,
+				JobID:     alloc.JobID,
+				TaskGroup: alloc.TaskGroup,
+				NodeID:    nodeID,
+				NodeName:  alloc.NodeName,
+			}
+			if withMetrics {
+				sim.Metrics = alloc.Metrics
+			}
+			out = append(out, sim)
+		}
+	}
+	slices.SortFunc(out, func(a, b *structs.SimulatedAllocation) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.NodeID, b.NodeID))
+	})
+	return out
+}
+
+// simulatePlanner is a scheduler.Planner that evaluates submitted plans
+// against a state snapshot instead of applying them. It keeps the result of
+// the last plan and the last evaluation update.
+type simulatePlanner struct {
+	snap   *state.StateSnapshot
+	pool   *EvaluatePool
+	logger log.Logger
+
+	result *structs.PlanResult
+	eval   *structs.Evaluation
+}
+
+// SubmitPlan evaluates the plan without committing it. Since the state is
+// never refreshed, a partially rejected plan is retried by the scheduler
+// against the same state until it gives up, and the last result is kept.
+func (p *simulatePlanner) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.State, error) {
+	result, err := evaluatePlan(p.pool, p.snap, plan, p.logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.result = result
+	return result, nil, nil
+}
+
+func (p *simulatePlanner) UpdateEval(eval *structs.Evaluation) error {
+	p.eval = eval
+	return nil
+}
+
+func (p *simulatePlanner) CreateEval(*structs.Evaluation) error {
+	return nil
+}
+
+func (p *simulatePlanner) ReblockEval(*structs.Evaluation) error {
+	return nil
+}
+
+func (p *simulatePlanner) ServersMeetMinimumVersion(*version.Version, bool) bool {
+	return true
+}
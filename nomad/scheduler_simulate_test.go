@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSimulateJob(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+
+	// A task group that can't be placed on any node
+	failing := job.TaskGroups[0].Copy()
+	failing.Name = "failing"
+	failing.Constraints = []*structs.Constraint{{
+		LTarget: "${attr.kernel.name}",
+		RTarget: "windows",
+		Operand: "=",
+	}}
+	job.TaskGroups = append(job.TaskGroups, failing)
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	simulation, err := SimulateJob(testlog.HCLogger(t), snap, job)
+	must.NoError(t, err)
+
+	must.Len(t, 2, simulation.Placements)
+	for _, alloc := range simulation.Placements {
+		must.Eq(t, "web", alloc.TaskGroup)
+		must.Eq(t, node.ID, alloc.NodeID)
+		must.NotNil(t, alloc.Metrics)
+		must.Eq(t, 1, alloc.Metrics.NodesEvaluated)
+	}
+	must.Eq(t, job.ID+".web[0]", simulation.Placements[0].Name)
+	must.Len(t, 0, simulation.Preemptions)
+	must.MapEmpty(t, simulation.NodeRejections)
+
+	must.MapContainsKey(t, simulation.FailedTGAllocs, "failing")
+	must.Eq(t, 1, simulation.FailedTGAllocs["failing"].NodesFiltered)
+	must.MapContainsKey(t, simulation.FailedTGAllocs["failing"].ClassFiltered, node.NodeClass)
+
+	// Nothing is committed to the state
+	out, err := store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+	allocs, err := store.AllocsByNode(nil, node.ID)
+	must.NoError(t, err)
+	must.Len(t, 0, allocs)
+}
+
+func TestSimulateJob_Preemption(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// A low priority allocation using most of the node
+	lowPrio := mock.Job()
+	lowPrio.Priority = 20
+	alloc := mock.Alloc()
+	alloc.Job = lowPrio
+	alloc.JobID = lowPrio.ID
+	alloc.NodeID = node.ID
+	alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = 13000
+	alloc.AllocatedResources.Tasks["web"].Networks = nil
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, lowPrio))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc}))
+
+	job := mock.SystemJob()
+	job.Priority = 100
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 1000
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	simulation, err := SimulateJob(testlog.HCLogger(t), snap, job)
+	must.NoError(t, err)
+
+	must.Len(t, 1, simulation.Placements)
+	must.Len(t, 1, simulation.Preemptions)
+	must.Eq(t, alloc.ID, simulation.Preemptions[0].ID)
+	must.Eq(t, lowPrio.ID, simulation.Preemptions[0].JobID)
+	must.Eq(t, node.ID, simulation.Preemptions[0].NodeID)
+
+	// The preempted allocation is left untouched
+	out, err := store.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.AllocDesiredStatusRun, out.DesiredStatus)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/command/agent/pprof"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// maxSchedulerTraceDuration is the maximum duration of a scheduler trace.
+	maxSchedulerTraceDuration = 5 * time.Minute
+
+	// maxSchedulerTraceEvals is the maximum number of evaluations recorded by
+	// a scheduler trace.
+	maxSchedulerTraceEvals = 10_000
+)
+
+// errSchedulerTraceRunning is returned when a scheduler trace is requested
+// while another one is running on the server.
+var errSchedulerTraceRunning = errors.New("scheduler trace already running")
+
+// schedulerTracer records the timing of the evaluations processed by the
+// scheduler workers of the server while a scheduler trace is running.
+type schedulerTracer struct {
+	running bool
+	evals   []*structs.SchedulerEvalTrace
+	dropped int
+
+	l sync.Mutex
+}
+
+// start starts recording evaluations. It returns an error if a trace is
+// already running.
+func (t *schedulerTracer) start() error {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if t.running {
+		return errSchedulerTraceRunning
+	}
+	t.running = true
+	t.evals = nil
+	t.dropped = 0
+	return nil
+}
+
+// stop stops recording evaluations and returns the evaluations recorded
+// along with the number of evaluations dropped.
+func (t *schedulerTracer) stop() ([]*structs.SchedulerEvalTrace, int) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	evals, dropped := t.evals, t.dropped
+	t.running = false
+	t.evals = nil
+	t.dropped = 0
+	return evals, dropped
+}
+
+// evalTrace returns the trace of an evaluation dequeued by a worker, or nil if
+// no trace is running.
+func (t *schedulerTracer) evalTrace(eval *structs.Evaluation, workerID string, dequeueTime time.Time) *structs.SchedulerEvalTrace {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if !t.running {
+		return nil
+	}
+	return &structs.SchedulerEvalTrace{
+		EvalID:      eval.ID,
+		Namespace:   eval.Namespace,
+		JobID:       eval.JobID,
+		Type:        eval.Type,
+		TriggeredBy: eval.TriggeredBy,
+		WorkerID:    workerID,
+		DequeueTime: dequeueTime,
+	}
+}
+
+// record records the trace of an evaluation once it has been processed.
+// Traces of evaluations still being processed when the trace stops are
+// dropped.
+func (t *schedulerTracer) record(trace *structs.SchedulerEvalTrace) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if !t.running {
+		return
+	}
+	if len(t.evals) >= maxSchedulerTraceEvals {
+		t.dropped++
+		return
+	}
+	t.evals = append(t.evals, trace)
+}
+
+// schedulerTrace records the timing of the evaluations processed by the
+// scheduler workers of the server for the given number of seconds, along
+// with a CPU profile of the server. The duration is capped at
+// maxSchedulerTraceDuration.
+func (s *Server) schedulerTrace(ctx context.Context, seconds int) (*structs.SchedulerTrace, error) {
+	d := time.Duration(seconds) * time.Second
+	if d <= 0 {
+		d = time.Second
+	} else if d > maxSchedulerTraceDuration {
+		d = maxSchedulerTraceDuration
+	}
+
+	if err := s.schedulerTracer.start(); err != nil {
+		return nil, err
+	}
+
+	trace := &structs.SchedulerTrace{
+		ServerID:  s.LocalMember().Name,
+		StartTime: time.Now().UTC(),
+	}
+
+	// The trace still records the evaluations if the CPU profile can't be
+	// captured, for example because another profile is running.
+	profile, _, err := pprof.CPUProfile(ctx, int(d.Seconds()))
+	if err != nil {
+		trace.ProfileError = err.Error()
+
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	trace.Profile = profile
+	trace.Duration = time.Since(trace.StartTime)
+	trace.Evals, trace.Dropped = s.schedulerTracer.stop()
+	return trace, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent/pprof"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestSchedulerTracer(t *testing.T) {
+	ci.Parallel(t)
+
+	var tracer schedulerTracer
+	eval := mock.Eval()
+
+	// Evaluations are not traced until the trace starts
+	must.Nil(t, tracer.evalTrace(eval, "worker", time.Now()))
+
+	must.NoError(t, tracer.start())
+	must.ErrorIs(t, tracer.start(), errSchedulerTraceRunning)
+
+	trace := tracer.evalTrace(eval, "worker", time.Now())
+	must.NotNil(t, trace)
+	must.Eq(t, eval.ID, trace.EvalID)
+	must.Eq(t, eval.JobID, trace.JobID)
+	must.Eq(t, "worker", trace.WorkerID)
+
+	// Evaluations past the limit are dropped
+	for i := 0; i < maxSchedulerTraceEvals+1; i++ {
+		tracer.record(trace)
+	}
+
+	evals, dropped := tracer.stop()
+	must.Len(t, maxSchedulerTraceEvals, evals)
+	must.Eq(t, 1, dropped)
+
+	// Evaluations still being processed when the trace stops are dropped,
+	// and a new trace can be started
+	tracer.record(trace)
+	must.NoError(t, tracer.start())
+	evals, dropped = tracer.stop()
+	must.Len(t, 0, evals)
+	must.Eq(t, 0, dropped)
+}
+
+func TestServer_SchedulerTrace(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 1
+		c.EnableDebug = true
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+
+	node := mock.Node()
+	testRegisterNode(t, s1, node)
+
+	// Start the trace through the Agent.Profile RPC
+	req := structs.AgentPprofRequest{
+		ReqType:      pprof.SchedulerTraceReq,
+		Seconds:      2,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var reply structs.AgentPprofResponse
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s1.RPC("Agent.Profile", &req, &reply)
+	}()
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return s1.schedulerTracer.evalTrace(mock.Eval(), "", time.Now()) != nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Only one trace runs at a time
+	var conflict structs.AgentPprofResponse
+	err := s1.RPC("Agent.Profile", &req, &conflict)
+	must.ErrorContains(t, err, errSchedulerTraceRunning.Error())
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	must.NoError(t, s1.RPC("Job.Register", regReq, &regResp))
+
+	select {
+	case err := <-errCh:
+		must.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for scheduler trace")
+	}
+	must.Eq(t, "application/json", reply.HTTPHeaders["Content-Type"])
+
+	var trace structs.SchedulerTrace
+	must.NoError(t, json.Unmarshal(reply.Payload, &trace))
+	must.Eq(t, s1.LocalMember().Name, trace.ServerID)
+	must.GreaterEq(t, 2*time.Second, trace.Duration)
+	if trace.ProfileError == "" {
+		must.NotEq(t, 0, len(trace.Profile))
+	}
+
+	var evalTrace *structs.SchedulerEvalTrace
+	for _, et := range trace.Evals {
+		if et.EvalID == regResp.EvalID {
+			evalTrace = et
+		}
+	}
+	must.NotNil(t, evalTrace)
+	must.Eq(t, job.ID, evalTrace.JobID)
+	must.Eq(t, structs.JobTypeService, evalTrace.Type)
+	must.Eq(t, structs.EvalTriggerJobRegister, evalTrace.TriggeredBy)
+	must.Eq(t, 1, evalTrace.Plans)
+	must.True(t, evalTrace.Acked)
+	must.Positive(t, evalTrace.PlanSubmitDuration)
+	must.GreaterEq(t, evalTrace.SnapshotDuration+evalTrace.ScheduleDuration+
+		evalTrace.PlanSubmitDuration, evalTrace.TotalDuration)
+
+	// Evaluations are no longer traced once the trace finishes
+	must.Nil(t, s1.schedulerTracer.evalTrace(mock.Eval(), "", time.Now()))
+}
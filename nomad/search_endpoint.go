@@ -5,6 +5,8 @@ package nomad
 
 import (
 	"fmt"
+	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -524,6 +526,26 @@ func nsCapFilter(aclObj *acl.ACL) memdb.FilterFunc {
 		case *structs.CSIPlugin:
 			return !aclObj.AllowPluginRead()
 
+		case *structs.Evaluation:
+			return !aclObj.AllowNsOp(t.Namespace, acl.NamespaceCapabilityReadJob)
+
+		case *structs.Deployment:
+			return !aclObj.AllowNsOp(t.Namespace, acl.NamespaceCapabilityReadJob)
+
+		case *structs.ScalingPolicy:
+			ns := t.Target[structs.ScalingTargetNamespace]
+			return !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityListScalingPolicies) &&
+				!aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob)
+
+		case *structs.CSIVolume:
+			return !acl.NamespaceValidator(acl.NamespaceCapabilityCSIListVolume,
+				acl.NamespaceCapabilityCSIReadVolume,
+				acl.NamespaceCapabilityListJobs,
+				acl.NamespaceCapabilityReadJob)(aclObj, t.Namespace)
+
+		case *structs.HostVolume:
+			return !acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)(aclObj, t.Namespace)
+
 		default:
 			return false
 		}
@@ -741,12 +763,25 @@ func (s *Search) FuzzySearch(args *structs.FuzzySearchRequest, reply *structs.Fu
 	}
 
 	namespace := args.RequestNamespace()
-	context := args.Context
+	requested := args.Contexts
+	if len(requested) == 0 {
+		requested = []structs.Context{args.Context}
+	}
+	scanned := fuzzyScanContexts(requested)
 
-	if !sufficientFuzzySearchPerms(aclObj, namespace, context) {
+	if !slices.ContainsFunc(scanned, func(ctx structs.Context) bool {
+		return sufficientFuzzySearchPerms(aclObj, namespace, ctx)
+	}) {
 		return structs.ErrPermissionDenied
 	}
 
+	switch args.Ranking {
+	case "", structs.FuzzyRankingScore, structs.FuzzyRankingName:
+	default:
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"invalid fuzzy search ranking %q", args.Ranking)
+	}
+
 	// check that fuzzy search API is enabled
 	if !s.srv.config.SearchConfig.FuzzyEnabled {
 		return fmt.Errorf("fuzzy search is not enabled")
@@ -774,8 +809,18 @@ func (s *Search) FuzzySearch(args *structs.FuzzySearchRequest, reply *structs.Fu
 			fuzzyIters := make(map[structs.Context]memdb.ResultIterator)
 			prefixIters := make(map[structs.Context]memdb.ResultIterator)
 
-			prefixContexts := filteredSearchContexts(aclObj, namespace, context)
-			fuzzyContexts := filteredFuzzySearchContexts(aclObj, namespace, context)
+			var prefixContexts, fuzzyContexts []structs.Context
+			for _, ctx := range scanned {
+				prefixContexts = append(prefixContexts, filteredSearchContexts(aclObj, namespace, ctx)...)
+				fuzzyContexts = append(fuzzyContexts, filteredFuzzySearchContexts(aclObj, namespace, ctx)...)
+			}
+
+			// Without an explicit prefix, only walk the objects whose ID
+			// starts with the search text
+			prefix := args.Prefix
+			if prefix == "" {
+				prefix = args.Text
+			}
 
 			// Gather the iterators used for prefix searching from those allowable contexts
 			for _, ctx := range prefixContexts {
@@ -783,7 +828,10 @@ func (s *Search) FuzzySearch(args *structs.FuzzySearchRequest, reply *structs.Fu
 				// only apply on the types that use UUID prefix searching
 				case structs.Evals, structs.Deployments, structs.ScalingPolicies,
 					structs.Volumes, structs.HostVolumes, structs.Quotas, structs.Recommendations:
-					iter, err := getResourceIter(ctx, aclObj, namespace, roundUUIDDownIfOdd(args.Prefix, args.Context), ws, state)
+					iter, err := getResourceIter(ctx, aclObj, namespace, roundUUIDDownIfOdd(prefix, ctx), ws, state)
+					if err == nil && wildcard(namespace) {
+						iter, err = nsCapIterFilter(iter, err, aclObj)
+					}
 					if err != nil {
 						if !s.silenceError(err) {
 							return err
@@ -840,6 +888,20 @@ func (s *Search) FuzzySearch(args *structs.FuzzySearchRequest, reply *structs.Fu
 				}
 			}
 
+			if len(args.Contexts) > 0 && !slices.Contains(args.Contexts, structs.All) {
+				for ctx := range reply.Matches {
+					if !slices.Contains(args.Contexts, ctx) {
+						delete(reply.Matches, ctx)
+					}
+				}
+				for ctx := range reply.Truncations {
+					if !slices.Contains(args.Contexts, ctx) {
+						delete(reply.Truncations, ctx)
+					}
+				}
+			}
+			rankFuzzyMatches(reply, args.Ranking, args.Limit)
+
 			// Set the index for the context. If the context has been specified,
 			// it will be used as the index of the response. Otherwise, the maximum
 			// index from all the resources will be used.
@@ -861,6 +923,46 @@ func (s *Search) FuzzySearch(args *structs.FuzzySearchRequest, reply *structs.Fu
 	return s.srv.blockingRPC(&opts)
 }
 
+// fuzzyScanContexts returns the contexts that have to be searched to find
+// matches of the requested contexts. The subtypes of jobs are found by
+// searching jobs.
+func fuzzyScanContexts(requested []structs.Context) []structs.Context {
+	var scanned []structs.Context
+	for _, context := range requested {
+		switch context {
+		case structs.Groups, structs.Services, structs.Tasks,
+			structs.Images, structs.Commands, structs.Classes:
+			context = structs.Jobs
+		}
+		for _, ctx := range expandContext(context) {
+			if !slices.Contains(scanned, ctx) {
+				scanned = append(scanned, ctx)
+			}
+		}
+	}
+	return scanned
+}
+
+// rankFuzzyMatches orders the matches of each context by the given ranking
+// and truncates them to limit, if set. Matches are already ordered by score.
+func rankFuzzyMatches(reply *structs.FuzzySearchResponse, ranking structs.FuzzyRanking, limit int) {
+	for ctx, matches := range reply.Matches {
+		if ranking == structs.FuzzyRankingName {
+			sort.SliceStable(matches, func(a, b int) bool {
+				A, B := matches[a], matches[b]
+				if A.ID != B.ID {
+					return A.ID < B.ID
+				}
+				return strings.Join(A.Scope, "/") < strings.Join(B.Scope, "/")
+			})
+		}
+		if limit > 0 && len(matches) > limit {
+			reply.Matches[ctx] = matches[:limit]
+			reply.Truncations[ctx] = true
+		}
+	}
+}
+
 // expandContext returns either allContexts if context is 'all', or a one
 // element slice with context by itself.
 func expandContext(context structs.Context) []structs.Context {
@@ -2677,6 +2677,152 @@ func TestSearch_FuzzySearch_Job(t *testing.T) {
 	})
 }
 
+func TestSearch_FuzzySearch_Contexts(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	job := mock.Job()
+	job.Name = "web-job"
+	job.TaskGroups[0].Name = "web-group"
+	must.NoError(t, s.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	node := mock.Node()
+	node.Name = "web-node"
+	must.NoError(t, s.fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1001, node))
+
+	search := func(contexts ...structs.Context) *structs.FuzzySearchResponse {
+		req := &structs.FuzzySearchRequest{
+			Text:     "web",
+			Contexts: contexts,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.FuzzySearchResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+		return &resp
+	}
+
+	// only the requested types are returned
+	resp := search(structs.Jobs, structs.Nodes)
+	must.MapLen(t, 2, resp.Matches)
+	must.Eq(t, "web-job", resp.Matches[structs.Jobs][0].ID)
+	must.Eq(t, "web-node", resp.Matches[structs.Nodes][0].ID)
+	must.MapNotContainsKey(t, resp.Truncations, structs.Groups)
+
+	// job subtypes are found without returning the jobs
+	resp = search(structs.Groups)
+	must.MapLen(t, 1, resp.Matches)
+	must.Eq(t, "web-group", resp.Matches[structs.Groups][0].ID)
+
+	// all keeps every type
+	resp = search(structs.All, structs.Nodes)
+	must.MapContainsKeys(t, resp.Matches, []structs.Context{structs.Jobs, structs.Groups, structs.Nodes})
+}
+
+func TestSearch_FuzzySearch_Ranking(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	for i, name := range []string{"zapi", "api", "b--api"} {
+		node := mock.Node()
+		node.Name = name
+		must.NoError(t, s.fsm.State().UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), node))
+	}
+
+	search := func(ranking structs.FuzzyRanking, limit int) (*structs.FuzzySearchResponse, error) {
+		req := &structs.FuzzySearchRequest{
+			Text:     "api",
+			Contexts: []structs.Context{structs.Nodes},
+			Ranking:  ranking,
+			Limit:    limit,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.FuzzySearchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp)
+		return &resp, err
+	}
+	names := func(matches []structs.FuzzyMatch) []string {
+		var out []string
+		for _, m := range matches {
+			out = append(out, m.ID)
+		}
+		return out
+	}
+
+	resp, err := search("", 0)
+	must.NoError(t, err)
+	must.Eq(t, []string{"api", "zapi", "b--api"}, names(resp.Matches[structs.Nodes]))
+
+	resp, err = search(structs.FuzzyRankingName, 0)
+	must.NoError(t, err)
+	must.Eq(t, []string{"api", "b--api", "zapi"}, names(resp.Matches[structs.Nodes]))
+	must.False(t, resp.Truncations[structs.Nodes])
+
+	resp, err = search(structs.FuzzyRankingScore, 2)
+	must.NoError(t, err)
+	must.Eq(t, []string{"api", "zapi"}, names(resp.Matches[structs.Nodes]))
+	must.True(t, resp.Truncations[structs.Nodes])
+
+	_, err = search("random", 0)
+	must.ErrorContains(t, err, "invalid fuzzy search ranking")
+}
+
+func TestSearch_FuzzySearch_Wildcard_PrefixACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanupS := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.fsm.State()
+
+	must.NoError(t, store.UpsertNamespaces(500, []*structs.Namespace{{Name: "teamA"}, {Name: "teamB"}}))
+
+	d1 := mock.Deployment()
+	d1.ID = "abcd1111-3350-4b4b-d185-0e1992ed43e9"
+	d1.Namespace = "teamA"
+	d2 := mock.Deployment()
+	d2.ID = "abcd2222-3350-4b4b-d185-0e1992ed43e9"
+	d2.Namespace = "teamB"
+	must.NoError(t, store.UpsertDeployment(1000, d1))
+	must.NoError(t, store.UpsertDeployment(1001, d2))
+
+	policy := mock.NamespacePolicy("teamA", "", []string{acl.NamespaceCapabilityReadJob})
+	token := mock.CreatePolicyAndToken(t, store, 1002, "teamA", policy)
+
+	req := &structs.FuzzySearchRequest{
+		Text:     "abcd",
+		Contexts: []structs.Context{structs.Deployments},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.AllNamespacesSentinel,
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.FuzzySearchResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+	must.Eq(t, []structs.FuzzyMatch{{ID: d1.ID}}, resp.Matches[structs.Deployments])
+}
+
 func TestSearch_FuzzySearch_fuzzyIndex(t *testing.T) {
 	ci.Parallel(t)
 
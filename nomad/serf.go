@@ -4,11 +4,14 @@
 package nomad
 
 import (
+	"maps"
+	"strconv"
 	"strings"
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
 
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -24,6 +27,14 @@ const (
 
 	// peerRetryBase is a baseline retry time
 	peerRetryBase = 1 * time.Second
+
+	// keyringReadyTag is the serf tag reporting whether the keyring of a
+	// server is ready
+	keyringReadyTag = "keyring_ready"
+
+	// keyringReadyTagInterval is the interval at which servers update their
+	// keyring_ready serf tag
+	keyringReadyTagInterval = 1 * time.Second
 )
 
 // serfEventHandler is used to handle events from the serf cluster
@@ -296,3 +307,30 @@ func (s *Server) localMemberEvent(me serf.MemberEvent) {
 		}
 	}
 }
+
+// updateKeyringReadyTag periodically updates the keyring_ready serf tag of the
+// server, so the readiness of each server's keyring is visible to the
+// operator and to the other servers.
+func (s *Server) updateKeyringReadyTag(stopCh <-chan struct{}) {
+	timer, stop := helper.NewSafeTimer(0)
+	defer stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			timer.Reset(keyringReadyTagInterval)
+		}
+
+		ready := strconv.FormatBool(s.encrypter.Ready() == nil)
+		tags := maps.Clone(s.serf.LocalMember().Tags)
+		if tags[keyringReadyTag] == ready {
+			continue
+		}
+		tags[keyringReadyTag] = ready
+		if err := s.serf.SetTags(tags); err != nil {
+			s.logger.Warn("failed to update keyring_ready serf tag", "error", err)
+		}
+	}
+}
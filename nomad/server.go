@@ -202,6 +202,10 @@ type Server struct {
 	// that are waiting to be brokered to a sub-scheduler
 	evalBroker *EvalBroker
 
+	// schedulerTracer records the timing of the evaluations processed by the
+	// workers of this server while a scheduler trace is running
+	schedulerTracer schedulerTracer
+
 	// brokerLock is used to synchronise the alteration of the blockedEvals and
 	// evalBroker enabled state. These two subsystems change state when
 	// leadership changes or when the user modifies the setting via the
@@ -245,6 +249,18 @@ type Server struct {
 	// to be accessed by the leader
 	*planner
 
+	// jobRegisterQueue limits the number of job registrations processed
+	// concurrently by the leader
+	jobRegisterQueue *JobRegisterQueue
+
+	// replicationConflicts tracks the namespaces and node pools of the
+	// authoritative region that the leader can't replicate
+	replicationConflicts *replicationConflicts
+
+	// jobAdmissionWebhook is called on job registration to mutate or reject
+	// jobs, if configured
+	jobAdmissionWebhook *jobAdmissionWebhook
+
 	// nodeHeartbeater is used to track expiration times of node heartbeats. If it
 	// detects an expired node, the node status is updated to be 'down'.
 	*nodeHeartbeater
@@ -390,6 +406,18 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	}
 	s.planner = planner
 
+	// Create the job registration queue
+	s.jobRegisterQueue = NewJobRegisterQueue(s.config.JobRegisterConcurrency)
+
+	// Create the tracker of replication conflicts
+	s.replicationConflicts = newReplicationConflicts()
+
+	// Create the job admission webhook
+	s.jobAdmissionWebhook, err = newJobAdmissionWebhook(s.config.JobAdmissionWebhook, s.logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the node heartbeater
 	s.nodeHeartbeater = newNodeHeartbeater(s)
 
@@ -519,6 +547,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	// Emit metrics for the plan queue
 	go s.planQueue.EmitStats(time.Second, s.shutdownCh)
 
+	// Emit metrics for the job registration queue
+	go s.jobRegisterQueue.EmitStats(time.Second, s.shutdownCh)
+
 	// Emit metrics for the planner's bad node tracker.
 	go s.planner.badNodeTracker.EmitStats(time.Second, s.shutdownCh)
 
@@ -539,6 +570,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	// exist before it can start.
 	s.keyringReplicator = NewKeyringReplicator(s, encrypter)
 
+	// Advertise the keyring readiness to the other members
+	go s.updateKeyringReadyTag(s.shutdownCh)
+
 	// Block until keys are decrypted
 	s.encrypter.IsReady(s.shutdownCtx)
 
@@ -1130,6 +1164,8 @@ func (s *Server) setupDeploymentWatcher() error {
 		NewJobEndpoints(s, nil),
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
+		s.config.DeploymentWebhooks,
+		s.config.DeploymentMetrics,
 	)
 
 	return nil
@@ -1288,6 +1324,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewNamespaceEndpoint(s, ctx))
 	_ = server.Register(NewNodeEndpoint(s, ctx))
 	_ = server.Register(NewNodePoolEndpoint(s, ctx))
+	_ = server.Register(NewServiceGrantEndpoint(s, ctx))
 	_ = server.Register(NewPeriodicEndpoint(s, ctx))
 	_ = server.Register(NewPlanEndpoint(s, ctx))
 	_ = server.Register(NewRegionEndpoint(s, ctx))
@@ -1321,15 +1358,16 @@ func (s *Server) setupRaft() error {
 
 	// Create the FSM
 	fsmConfig := &FSMConfig{
-		EvalBroker:         s.evalBroker,
-		Periodic:           s.periodicDispatcher,
-		Blocked:            s.blockedEvals,
-		Encrypter:          s.encrypter,
-		Logger:             s.logger,
-		Region:             s.Region(),
-		EnableEventBroker:  s.config.EnableEventBroker,
-		EventBufferSize:    s.config.EventBufferSize,
-		JobTrackedVersions: s.config.JobTrackedVersions,
+		EvalBroker:          s.evalBroker,
+		Periodic:            s.periodicDispatcher,
+		Blocked:             s.blockedEvals,
+		Encrypter:           s.encrypter,
+		Logger:              s.logger,
+		Region:              s.Region(),
+		EnableEventBroker:   s.config.EnableEventBroker,
+		EventBufferSize:     s.config.EventBufferSize,
+		JobTrackedVersions:  s.config.JobTrackedVersions,
+		SnapshotPersistRate: s.config.RaftSnapshotPersistRate,
 	}
 
 	var err error
@@ -1560,6 +1598,7 @@ func (s *Server) setupSerf(conf *serf.Config, ch chan serf.Event, path string) (
 	conf.Tags["id"] = s.config.NodeID
 	conf.Tags["rpc_addr"] = s.clientRpcAdvertise.(*net.TCPAddr).IP.String()         // Address that clients will use to RPC to servers
 	conf.Tags["port"] = fmt.Sprintf("%d", s.serverRpcAdvertise.(*net.TCPAddr).Port) // Port servers use to RPC to one and another
+	conf.Tags[keyringReadyTag] = "false"
 	if s.isSingleServerCluster() {
 		conf.Tags["bootstrap"] = "1"
 	}
@@ -1567,9 +1606,12 @@ func (s *Server) setupSerf(conf *serf.Config, ch chan serf.Event, path string) (
 	if bootstrapExpect != 0 {
 		conf.Tags["expect"] = fmt.Sprintf("%d", bootstrapExpect)
 	}
-	if s.config.NonVoter {
+	if s.config.NonVoter || s.config.ReadReplica {
 		conf.Tags["nonvoter"] = "1"
 	}
+	if s.config.ReadReplica {
+		conf.Tags[readReplicaTag] = "1"
+	}
 	if s.config.RedundancyZone != "" {
 		conf.Tags[AutopilotRZTag] = s.config.RedundancyZone
 	}
@@ -1928,6 +1970,12 @@ func (s *Server) State() *state.StateStore {
 	return s.fsm.State()
 }
 
+// RaftIndexes returns the last committed and the last applied raft log
+// indexes of the server.
+func (s *Server) RaftIndexes() (commit, applied uint64) {
+	return s.raft.CommitIndex(), s.raft.AppliedIndex()
+}
+
 // setLeaderAcl stores the given ACL token as the current leader's ACL token.
 func (s *Server) setLeaderAcl(token string) {
 	s.leaderAclLock.Lock()
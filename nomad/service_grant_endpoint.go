@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// minServiceGrantVersion is the Nomad version in which service grants were
+// introduced.
+var minServiceGrantVersion = version.Must(version.NewVersion("1.9.7"))
+
+// ServiceGrant endpoint is used to manage the grants allowing workloads to
+// look up the Nomad services registered in other namespaces.
+type ServiceGrant struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewServiceGrantEndpoint(srv *Server, ctx *RPCContext) *ServiceGrant {
+	return &ServiceGrant{srv: srv, ctx: ctx}
+}
+
+// Upsert creates or updates service grants. Grants without an ID that expose
+// the same services to the same namespace as an existing grant update it.
+func (s *ServiceGrant) Upsert(args *structs.ServiceGrantUpsertRequest, reply *structs.ServiceGrantUpsertResponse) error {
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if done, err := s.srv.forward(structs.ServiceGrantUpsertRPCMethod, args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("service_grant", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "service_grant", "upsert"}, time.Now())
+
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(s.srv.Members(), s.srv.Region(), minServiceGrantVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert service grants",
+			minServiceGrantVersion)
+	}
+
+	if len(args.Grants) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify at least one service grant")
+	}
+
+	stateSnapshot, err := s.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range args.Grants {
+		if err := grant.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid service grant: %v", err)
+		}
+		for _, ns := range []string{grant.Namespace, grant.GrantedNamespace} {
+			if existing, err := stateSnapshot.NamespaceByName(nil, ns); err != nil {
+				return err
+			} else if existing == nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "namespace %q does not exist", ns)
+			}
+		}
+		if err := s.setGrantID(stateSnapshot, grant); err != nil {
+			return err
+		}
+	}
+
+	_, index, err := s.srv.raftApply(structs.ServiceGrantUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	reply.Grants = args.Grants
+	reply.Index = index
+	return nil
+}
+
+// setGrantID sets the ID of a grant being upserted. Grants without an ID
+// reuse the ID of the existing grant with the same target, if any.
+func (s *ServiceGrant) setGrantID(snap *state.StateSnapshot, grant *structs.ServiceGrant) error {
+	if grant.ID != "" {
+		existing, err := snap.ServiceGrantByID(nil, grant.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return structs.NewErrRPCCodedf(http.StatusNotFound, "service grant %s not found", grant.ID)
+		}
+		return nil
+	}
+
+	iter, err := snap.ServiceGrantsByNamespace(nil, grant.Namespace)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if existing := raw.(*structs.ServiceGrant); existing.SameTarget(grant) {
+			grant.ID = existing.ID
+			return nil
+		}
+	}
+
+	grant.ID = uuid.Generate()
+	return nil
+}
+
+// Delete deletes service grants by their ID.
+func (s *ServiceGrant) Delete(args *structs.ServiceGrantDeleteRequest, reply *structs.ServiceGrantDeleteResponse) error {
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if done, err := s.srv.forward(structs.ServiceGrantDeleteRPCMethod, args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("service_grant", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "service_grant", "delete"}, time.Now())
+
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(s.srv.Members(), s.srv.Region(), minServiceGrantVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete service grants",
+			minServiceGrantVersion)
+	}
+
+	if len(args.IDs) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify at least one service grant")
+	}
+
+	stateSnapshot, err := s.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, id := range args.IDs {
+		if existing, err := stateSnapshot.ServiceGrantByID(nil, id); err != nil {
+			return err
+		} else if existing == nil {
+			return structs.NewErrRPCCodedf(http.StatusNotFound, "service grant %s not found", id)
+		}
+	}
+
+	_, index, err := s.srv.raftApply(structs.ServiceGrantDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// List lists the service grants exposing the services of the request
+// namespace, or of all namespaces when using the wildcard namespace.
+func (s *ServiceGrant) List(args *structs.ServiceGrantListRequest, reply *structs.ServiceGrantListResponse) error {
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if done, err := s.srv.forward(structs.ServiceGrantListRPCMethod, args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("service_grant", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "service_grant", "list"}, time.Now())
+
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	return s.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var iter memdb.ResultIterator
+			var err error
+			if ns := args.RequestNamespace(); ns == structs.AllNamespacesSentinel {
+				iter, err = store.ServiceGrants(ws)
+			} else {
+				iter, err = store.ServiceGrantsByNamespace(ws, ns)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Grants = []*structs.ServiceGrant{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Grants = append(reply.Grants, raw.(*structs.ServiceGrant))
+			}
+
+			return s.srv.setReplyQueryMeta(store, state.TableServiceGrants, &reply.QueryMeta)
+		},
+	})
+}
+
+// allowServiceLookup returns true if the caller of a service registration
+// read is allowed to look up the service in the namespace. Workloads can
+// look up the services of their own namespace, the services of namespaces
+// where the policies attached to their job allow reading jobs, and the
+// services of other namespaces exposed to theirs by a service grant. Other
+// callers are authorized by their ACL.
+func allowServiceLookup(store *state.StateStore, ws memdb.WatchSet,
+	claims *structs.IdentityClaims, aclObj *acl.ACL, namespace, service string) (bool, error) {
+
+	if claims == nil || claims.Namespace == namespace {
+		return true, nil
+	}
+	if aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) {
+		return true, nil
+	}
+	return store.ServiceGrantAllows(ws, namespace, service, claims.Namespace)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceGrant_UpsertListDelete(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	ns := mock.Namespace()
+	must.NoError(t, s.State().UpsertNamespaces(10, []*structs.Namespace{ns}))
+
+	grant := &structs.ServiceGrant{
+		Namespace:        ns.Name,
+		ServiceName:      "db",
+		GrantedNamespace: structs.DefaultNamespace,
+	}
+
+	// Management of grants requires operator write
+	upsertReq := &structs.ServiceGrantUpsertRequest{
+		Grants:       []*structs.ServiceGrant{grant.Copy()},
+		WriteRequest: structs.WriteRequest{Region: s.Region()},
+	}
+	var upsertResp structs.ServiceGrantUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ServiceGrantUpsertRPCMethod, upsertReq, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantUpsertRPCMethod, upsertReq, &upsertResp))
+	must.Len(t, 1, upsertResp.Grants)
+	grantID := upsertResp.Grants[0].ID
+	must.UUIDv4(t, grantID)
+
+	// Upserting a grant with the same target updates it
+	updated := grant.Copy()
+	updated.Description = "db for the default namespace"
+	upsertReq.Grants = []*structs.ServiceGrant{updated}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantUpsertRPCMethod, upsertReq, &upsertResp))
+	must.Eq(t, grantID, upsertResp.Grants[0].ID)
+
+	// Grants must reference existing namespaces
+	invalid := grant.Copy()
+	invalid.GrantedNamespace = "missing"
+	upsertReq.Grants = []*structs.ServiceGrant{invalid}
+	err = msgpackrpc.CallWithCodec(codec, structs.ServiceGrantUpsertRPCMethod, upsertReq, &upsertResp)
+	must.ErrorContains(t, err, `namespace "missing" does not exist`)
+
+	listReq := &structs.ServiceGrantListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    s.Region(),
+			Namespace: ns.Name,
+			AuthToken: root.SecretID,
+		},
+	}
+	var listResp structs.ServiceGrantListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantListRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Grants)
+	must.Eq(t, "db for the default namespace", listResp.Grants[0].Description)
+
+	listReq.Namespace = structs.DefaultNamespace
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantListRPCMethod, listReq, &listResp))
+	must.Len(t, 0, listResp.Grants)
+
+	listReq.Namespace = structs.AllNamespacesSentinel
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantListRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Grants)
+
+	deleteReq := &structs.ServiceGrantDeleteRequest{
+		IDs: []string{grantID},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.Region(),
+			AuthToken: root.SecretID,
+		},
+	}
+	var deleteResp structs.ServiceGrantDeleteResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantDeleteRPCMethod, deleteReq, &deleteResp))
+
+	err = msgpackrpc.CallWithCodec(codec, structs.ServiceGrantDeleteRPCMethod, deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "not found")
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceGrantListRPCMethod, listReq, &listResp))
+	must.Len(t, 0, listResp.Grants)
+}
+
+func TestServiceGrant_MinVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.Build = "1.9.6"
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Grants are rejected until all servers can apply them
+	upsertReq := &structs.ServiceGrantUpsertRequest{
+		Grants: []*structs.ServiceGrant{{
+			Namespace:        structs.DefaultNamespace,
+			ServiceName:      "db",
+			GrantedNamespace: structs.DefaultNamespace,
+		}},
+		WriteRequest: structs.WriteRequest{Region: s.Region()},
+	}
+	var upsertResp structs.ServiceGrantUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ServiceGrantUpsertRPCMethod, upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "all servers must be running version 1.9.7 or later")
+
+	deleteReq := &structs.ServiceGrantDeleteRequest{
+		IDs:          []string{"a"},
+		WriteRequest: structs.WriteRequest{Region: s.Region()},
+	}
+	var deleteResp structs.ServiceGrantDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ServiceGrantDeleteRPCMethod, deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "all servers must be running version 1.9.7 or later")
+}
+
+func TestServiceGrant_WorkloadLookup(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	testutil.WaitForKeyring(t, s.RPC, s.Region())
+
+	ns := mock.Namespace()
+	must.NoError(t, s.State().UpsertNamespaces(10, []*structs.Namespace{ns}))
+
+	service := mock.ServiceRegistrations()[0]
+	service.Namespace = ns.Name
+	must.NoError(t, s.State().UpsertServiceRegistrations(
+		structs.MsgTypeTestSetup, 20, []*structs.ServiceRegistration{service}))
+
+	// Generate an allocation with a signed identity in the default namespace
+	allocs := []*structs.Allocation{mock.Alloc()}
+	job := allocs[0].Job
+	must.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, 30, nil, job))
+	must.NoError(t, signAllocIdentities(s.encrypter, job, allocs, time.Now()))
+	must.NoError(t, s.State().UpsertAllocs(structs.MsgTypeTestSetup, 40, allocs))
+	signedToken := allocs[0].SignedIdentities["web"]
+
+	getReq := &structs.ServiceRegistrationByNameRequest{
+		ServiceName: service.ServiceName,
+		QueryOptions: structs.QueryOptions{
+			Namespace: ns.Name,
+			Region:    s.Region(),
+			AuthToken: signedToken,
+		},
+	}
+	listReq := &structs.ServiceRegistrationListRequest{
+		QueryOptions: structs.QueryOptions{
+			Namespace: ns.Name,
+			Region:    s.Region(),
+			AuthToken: signedToken,
+		},
+	}
+
+	// Without a grant, the workload cannot look up the service
+	var getResp structs.ServiceRegistrationByNameResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	var listResp structs.ServiceRegistrationListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationListRPCMethod, listReq, &listResp))
+	must.Len(t, 0, listResp.Services)
+
+	// Grants for other services or namespaces do not apply
+	must.NoError(t, s.State().UpsertServiceGrants(structs.MsgTypeTestSetup, 50, []*structs.ServiceGrant{
+		{ID: "grant-other-service", Namespace: ns.Name, ServiceName: "other", GrantedNamespace: structs.DefaultNamespace},
+		{ID: "grant-other-namespace", Namespace: ns.Name, ServiceName: service.ServiceName, GrantedNamespace: "other"},
+	}))
+	err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// With a grant, the workload can look up the service
+	must.NoError(t, s.State().UpsertServiceGrants(structs.MsgTypeTestSetup, 60, []*structs.ServiceGrant{
+		{ID: "grant", Namespace: ns.Name, ServiceName: service.ServiceName, GrantedNamespace: structs.DefaultNamespace},
+	}))
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp))
+	must.Len(t, 1, getResp.Services)
+	must.Eq(t, service.ID, getResp.Services[0].ID)
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationListRPCMethod, listReq, &listResp))
+	must.Len(t, 1, listResp.Services)
+	must.Len(t, 1, listResp.Services[0].Services)
+	must.Eq(t, service.ServiceName, listResp.Services[0].Services[0].ServiceName)
+
+	// Removing the grant revokes the access
+	must.NoError(t, s.State().DeleteServiceGrants(structs.MsgTypeTestSetup, 70, []string{"grant"}))
+	err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Workloads whose job policies allow reading jobs in the namespace do not
+	// need a grant
+	policy := &structs.ACLPolicy{
+		Name:  "read-" + ns.Name,
+		Rules: mock.NamespacePolicy(ns.Name, "", []string{acl.NamespaceCapabilityReadJob}),
+		JobACL: &structs.JobACL{
+			Namespace: job.Namespace,
+			JobID:     job.ID,
+		},
+	}
+	policy.SetHash()
+	must.NoError(t, s.State().UpsertACLPolicies(structs.MsgTypeTestSetup, 80, []*structs.ACLPolicy{policy}))
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, getReq, &getResp))
+	must.Len(t, 1, getResp.Services)
+}
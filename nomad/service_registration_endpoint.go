@@ -196,12 +196,27 @@ func (s *ServiceRegistration) List(
 				return err
 			}
 
-			// Accumulate the set of tags associated with a particular service name.
+			// Accumulate the set of tags associated with a particular service
+			// name, skipping the services the caller is not allowed to look
+			// up.
 			tagSet := make(serviceTagSet)
+			allowed := make(map[string]bool)
 
 			for raw := iter.Next(); raw != nil; raw = iter.Next() {
 				serviceReg := raw.(*structs.ServiceRegistration)
-				tagSet.add(serviceReg.ServiceName, serviceReg.Tags)
+
+				allow, ok := allowed[serviceReg.ServiceName]
+				if !ok {
+					allow, err = allowServiceLookup(stateStore, ws, args.GetIdentity().Claims,
+						aclObj, args.RequestNamespace(), serviceReg.ServiceName)
+					if err != nil {
+						return err
+					}
+					allowed[serviceReg.ServiceName] = allow
+				}
+				if allow {
+					tagSet.add(serviceReg.ServiceName, serviceReg.Tags)
+				}
 			}
 
 			// Set the output result with the accumulated set of tags for each service.
@@ -355,6 +370,17 @@ func (s *ServiceRegistration) GetService(
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
 
+			// Workloads can only look up the services of other namespaces
+			// exposed to theirs by a service grant.
+			allowed, err := allowServiceLookup(stateStore, ws, args.GetIdentity().Claims,
+				aclObj, args.RequestNamespace(), args.ServiceName)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return structs.ErrPermissionDenied
+			}
+
 			// Perform the state query to get an iterator.
 			iter, err := stateStore.GetServiceRegistrationByName(ws, args.RequestNamespace(), args.ServiceName)
 			if err != nil {
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/snapshot"
+)
+
+// snapshotUploadNamePrefix is the prefix of the name of the snapshots
+// uploaded by the leader. Only snapshots with this prefix are pruned.
+const snapshotUploadNamePrefix = "nomad-snapshot-"
+
+// snapshotUploadStorage is the object storage the leader uploads snapshots
+// to.
+type snapshotUploadStorage interface {
+	Key(name string) string
+	URL(key string) string
+	Upload(ctx context.Context, key string, r io.Reader) error
+	Prune(ctx context.Context, namePrefix string, retain int) ([]string, error)
+}
+
+// uploadSnapshots is a long lived function run on the leader that
+// periodically uploads a snapshot of the state to object storage and deletes
+// the snapshots past the retention count. It stops once stopCh is closed.
+func (s *Server) uploadSnapshots(stopCh chan struct{}) {
+	conf := s.config.SnapshotUpload
+	if conf == nil {
+		return
+	}
+
+	storage, err := snapshot.NewStorage(conf.Storage, conf.StorageOptions())
+	if err != nil {
+		s.logger.Error("failed to configure snapshot upload", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	timer, stop := helper.NewSafeTimer(conf.Interval)
+	defer stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+		}
+
+		if err := s.uploadSnapshot(ctx, storage, conf.Retain, time.Now()); err != nil {
+			s.logger.Error("failed to upload snapshot", "error", err)
+		}
+		timer.Reset(conf.Interval)
+	}
+}
+
+// uploadSnapshot takes a snapshot of the state, uploads it to the storage and
+// deletes the oldest uploaded snapshots to keep the retain most recent ones.
+func (s *Server) uploadSnapshot(ctx context.Context, storage snapshotUploadStorage, retain int, now time.Time) error {
+	defer metrics.MeasureSince([]string{"nomad", "leader", "snapshot_upload"}, now)
+
+	snap, err := snapshot.New(s.logger.Named("snapshot"), s.raft)
+	if err != nil {
+		return fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	defer snap.Close()
+
+	name := fmt.Sprintf("%s%s-%d.snap", snapshotUploadNamePrefix,
+		now.UTC().Format("20060102T150405Z"), snap.Index())
+	key := storage.Key(name)
+	if err := storage.Upload(ctx, key, snap); err != nil {
+		return err
+	}
+	s.logger.Info("uploaded snapshot", "url", storage.URL(key), "index", snap.Index())
+
+	deleted, err := storage.Prune(ctx, snapshotUploadNamePrefix, retain)
+	for _, key := range deleted {
+		s.logger.Debug("deleted snapshot", "url", storage.URL(key))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete old snapshots: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+type fakeSnapshotUploadStorage struct {
+	uploads map[string][]byte
+	retain  int
+}
+
+func (f *fakeSnapshotUploadStorage) Key(name string) string { return path.Join("nomad", name) }
+func (f *fakeSnapshotUploadStorage) URL(key string) string  { return "s3://backups/" + key }
+
+func (f *fakeSnapshotUploadStorage) Upload(_ context.Context, key string, r io.Reader) error {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.uploads[key] = buf
+	return nil
+}
+
+func (f *fakeSnapshotUploadStorage) Prune(_ context.Context, namePrefix string, retain int) ([]string, error) {
+	f.retain = retain
+	return nil, nil
+}
+
+func TestServer_uploadSnapshot(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.DevMode = false
+		c.DataDir = t.TempDir()
+	})
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	storage := &fakeSnapshotUploadStorage{uploads: map[string][]byte{}}
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	must.NoError(t, s.uploadSnapshot(context.Background(), storage, 24, now))
+	must.Eq(t, 24, storage.retain)
+	must.MapLen(t, 1, storage.uploads)
+
+	for key, buf := range storage.uploads {
+		must.True(t, strings.HasPrefix(key, "nomad/nomad-snapshot-20261016T123000Z-"), must.Sprint(key))
+		must.True(t, strings.HasSuffix(key, ".snap"))
+
+		// The uploaded snapshot is a valid snapshot archive
+		meta, err := snapshot.Verify(bytes.NewReader(buf))
+		must.NoError(t, err)
+		must.Positive(t, meta.Index)
+	}
+}
@@ -20,7 +20,10 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.NodeUpdateStatusRequestType:                  structs.TypeNodeEvent,
 	structs.JobDeregisterRequestType:                     structs.TypeJobDeregistered,
 	structs.JobBatchDeregisterRequestType:                structs.TypeJobBatchDeregistered,
+	structs.JobFreezeRequestType:                         structs.TypeJobFreezeUpdated,
 	structs.AllocUpdateDesiredTransitionRequestType:      structs.TypeAllocationUpdateDesiredStatus,
+	structs.AllocIdentitiesUpdateRequestType:             structs.TypeAllocationUpdated,
+	structs.AllocTaskResultRegisterRequestType:           structs.TypeAllocationUpdated,
 	structs.NodeUpdateEligibilityRequestType:             structs.TypeNodeDrain,
 	structs.NodeUpdateDrainRequestType:                   structs.TypeNodeDrain,
 	structs.BatchNodeUpdateDrainRequestType:              structs.TypeNodeDrain,
@@ -308,6 +311,7 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 		if !ok {
 			return structs.Event{}, false
 		}
+		before, _ := change.Before.(*structs.Evaluation)
 		return structs.Event{
 			Topic: structs.TopicEvaluation,
 			Key:   after.ID,
@@ -316,9 +320,7 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				after.DeploymentID,
 			},
 			Namespace: after.Namespace,
-			Payload: &structs.EvaluationEvent{
-				Evaluation: after,
-			},
+			Payload:   structs.NewEvaluationEvent(before, after),
 		}, true
 	case "allocs":
 		after, ok := change.After.(*structs.Allocation)
@@ -478,6 +478,49 @@ func TestEventsFromChanges_EvalUpdateRequestType(t *testing.T) {
 	require.Contains(t, e.FilterKeys, e2.DeploymentID)
 	event := e.Payload.(*structs.EvaluationEvent)
 	require.Equal(t, "blocked", event.Evaluation.Status)
+	require.Equal(t, e1.Status, event.Transition.PreviousStatus)
+	require.Equal(t, "blocked", event.Transition.Status)
+}
+
+func TestNewEvaluationEvent(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	before := mock.Eval()
+	before.CreateTime = now.Add(-3 * time.Second).UnixNano()
+
+	after := before.Copy()
+	after.Status = structs.EvalStatusComplete
+	after.WorkerID = "worker-1"
+	after.DequeueTime = now.Add(-time.Second).UnixNano()
+	after.ModifyTime = now.UnixNano()
+
+	// Processing the evaluation reports the worker and the durations
+	event := structs.NewEvaluationEvent(before, after)
+	must.Eq(t, after, event.Evaluation)
+	must.Eq(t, &structs.EvaluationTransition{
+		PreviousStatus:  structs.EvalStatusPending,
+		Status:          structs.EvalStatusComplete,
+		TriggeredBy:     after.TriggeredBy,
+		WorkerID:        "worker-1",
+		QueueDuration:   2 * time.Second,
+		ProcessDuration: time.Second,
+	}, event.Transition)
+
+	// Later updates of the evaluation do not
+	canceled := after.Copy()
+	canceled.Status = structs.EvalStatusCancelled
+	event = structs.NewEvaluationEvent(after, canceled)
+	must.Eq(t, &structs.EvaluationTransition{
+		PreviousStatus: structs.EvalStatusComplete,
+		Status:         structs.EvalStatusCancelled,
+		TriggeredBy:    after.TriggeredBy,
+	}, event.Transition)
+
+	// New evaluations have no previous status
+	event = structs.NewEvaluationEvent(nil, before)
+	must.Eq(t, "", event.Transition.PreviousStatus)
+	must.Eq(t, structs.EvalStatusPending, event.Transition.Status)
 }
 
 func TestEventsFromChanges_ApplyPlanResultsRequestType(t *testing.T) {
@@ -699,6 +742,29 @@ func TestEventsFromChanges_AllocClientUpdateRequestType(t *testing.T) {
 	t.SkipNow()
 }
 
+func TestEventsFromChanges_JobFreezeRequestType(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	job := mock.Job()
+	must.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	must.NoError(t, s.UpdateJobFreeze(structs.JobFreezeRequestType, 1001, job.Namespace, job.ID, true))
+
+	// Wait and verify freeze event.
+	events := WaitForEvents(t, s, 1001, 1, 1*time.Second)
+	must.Len(t, 1, events)
+
+	e := events[0]
+	must.Eq(t, structs.TopicJob, e.Topic)
+	must.Eq(t, structs.TypeJobFreezeUpdated, e.Type)
+	must.Eq(t, job.ID, e.Key)
+
+	payload := e.Payload.(*structs.JobEvent)
+	must.True(t, payload.Job.Frozen)
+}
+
 func TestEventsFromChanges_JobDeregisterRequestType(t *testing.T) {
 	t.SkipNow()
 }
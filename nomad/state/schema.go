@@ -18,6 +18,11 @@ const (
 	TableNamespaces               = "namespaces"
 	TableNodePools                = "node_pools"
 	TableServiceRegistrations     = "service_registrations"
+	TableServiceGrants            = "service_grants"
+	TableJobPurgeHistory          = "job_purge_history"
+	TableJobRestarts              = "job_restarts"
+	TableAppliedPlans             = "applied_plans"
+	TableNodeLifecycleRuns        = "node_lifecycle_runs"
 	TableVariables                = "variables"
 	TableVariablesQuotas          = "variables_quota"
 	TableRootKeys                 = "root_keys"
@@ -105,6 +110,11 @@ func init() {
 		bindingRulesTableSchema,
 		hostVolumeTableSchema,
 		taskGroupHostVolumeClaimSchema,
+		serviceGrantsTableSchema,
+		jobPurgeHistoryTableSchema,
+		jobRestartsTableSchema,
+		appliedPlansTableSchema,
+		nodeLifecycleRunsTableSchema,
 	}...)
 }
 
@@ -1462,6 +1472,134 @@ func serviceRegistrationsTableSchema() *memdb.TableSchema {
 	}
 }
 
+// serviceGrantsTableSchema returns the MemDB schema for the service grants
+// table, which stores the grants allowing workloads to look up the services
+// of other namespaces.
+func serviceGrantsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableServiceGrants,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			// The namespace index is used to look up the grants exposing the
+			// services of a namespace when enforcing them.
+			"namespace": {
+				Name:         "namespace",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Namespace",
+				},
+			},
+		},
+	}
+}
+
+// jobPurgeHistoryTableSchema returns the MemDB schema for the job purge history
+// table, which stores the audit records of the jobs purged with an audit.
+func jobPurgeHistoryTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableJobPurgeHistory,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			"namespace": {
+				Name:         "namespace",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Namespace",
+				},
+			},
+			// A job may be purged several times if it is registered again,
+			// so the job index is not unique.
+			indexJob: {
+				Name:         indexJob,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// jobRestartsTableSchema returns the MemDB schema for the job restarts table,
+// which stores the batched restarts started with the pause on error option.
+func jobRestartsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableJobRestarts,
+		Indexes: map[string]*memdb.IndexSchema{
+			// There is at most one restart per job, so it is indexed by
+			// namespace and job ID.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// appliedPlansTableSchema returns the MemDB schema for the applied plans
+// table, which records the plans whose results were applied so that they
+// aren't applied twice.
+func appliedPlansTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableAppliedPlans,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "PlanID",
+				},
+			},
+			// Records are deleted along with the evaluation that submitted
+			// the plan.
+			"eval": {
+				Name:         "eval",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "EvalID",
+				},
+			},
+		},
+	}
+}
+
 // variablesTableSchema returns the MemDB schema for Nomad variables.
 func variablesTableSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -1752,3 +1890,23 @@ func taskGroupHostVolumeClaimSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// nodeLifecycleRunsTableSchema returns the MemDB schema for the node lifecycle
+// runs table, which stores the runs cycling the nodes of node pools.
+func nodeLifecycleRunsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableNodeLifecycleRuns,
+		Indexes: map[string]*memdb.IndexSchema{
+			// There is at most one run per node pool, so it is indexed by
+			// node pool.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "NodePool",
+				},
+			},
+		},
+	}
+}
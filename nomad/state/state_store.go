@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"sort"
@@ -392,7 +393,7 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 			SetMessage(NodeEligibilityEventPlanRejectThreshold)
 
 		err := s.updateNodeEligibilityImpl(index, nodeID,
-			structs.NodeSchedulingIneligible, results.UpdatedAt, nodeEvent, txn)
+			structs.NodeSchedulingIneligible, results.UpdatedAt, nodeEvent, false, txn)
 		if err != nil {
 			return err
 		}
@@ -460,6 +461,13 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 		}
 	}
 
+	// Record the plan so that it isn't applied again if it was resubmitted
+	if results.PlanID != "" {
+		if err := s.insertAppliedPlanTxn(txn, index, results.PlanID, results.EvalID); err != nil {
+			return err
+		}
+	}
+
 	return txn.Commit()
 }
 
@@ -1009,6 +1017,7 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 		node.SchedulingEligibility = exist.SchedulingEligibility // Retain the eligibility
 		node.DrainStrategy = exist.DrainStrategy                 // Retain the drain strategy
 		node.LastDrain = exist.LastDrain                         // Retain the drain metadata
+		node.AllocDirBudgetExceeded = exist.AllocDirBudgetExceeded
 
 		// Retain the last index the node missed a heartbeat.
 		if node.LastMissedHeartbeatIndex < exist.LastMissedHeartbeatIndex {
@@ -1211,8 +1220,10 @@ func (s *StateStore) updateNodeDrainImpl(txn *txn, index uint64, nodeID string,
 	updatedNode.DrainStrategy = drain
 	if drain != nil {
 		updatedNode.SchedulingEligibility = structs.NodeSchedulingIneligible
+		updatedNode.AllocDirBudgetExceeded = false
 	} else if markEligible {
 		updatedNode.SchedulingEligibility = structs.NodeSchedulingEligible
+		updatedNode.AllocDirBudgetExceeded = false
 	}
 
 	// Update LastDrain
@@ -1279,13 +1290,25 @@ func (s *StateStore) updateNodeDrainImpl(txn *txn, index uint64, nodeID string,
 func (s *StateStore) UpdateNodeEligibility(msgType structs.MessageType, index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent) error {
 	txn := s.db.WriteTxnMsgT(msgType, index)
 	defer txn.Abort()
-	if err := s.updateNodeEligibilityImpl(index, nodeID, eligibility, updatedAt, event, txn); err != nil {
+	if err := s.updateNodeEligibilityImpl(index, nodeID, eligibility, updatedAt, event, false, txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// UpdateNodeAllocDirBudgetEligibility is used to update the scheduling
+// eligibility of a node on behalf of the alloc dir disk budget of its client.
+// The node is flagged while it is ineligible because of the budget.
+func (s *StateStore) UpdateNodeAllocDirBudgetEligibility(msgType structs.MessageType, index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+	if err := s.updateNodeEligibilityImpl(index, nodeID, eligibility, updatedAt, event, true, txn); err != nil {
 		return err
 	}
 	return txn.Commit()
 }
 
-func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent, txn *txn) error {
+func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent, allocDirBudget bool, txn *txn) error {
 	// Lookup the node
 	existing, err := txn.First("nodes", "id", nodeID)
 	if err != nil {
@@ -1312,6 +1335,7 @@ func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, elig
 
 	// Update the eligibility in the copy
 	copyNode.SchedulingEligibility = eligibility
+	copyNode.AllocDirBudgetExceeded = allocDirBudget && eligibility == structs.NodeSchedulingIneligible
 	copyNode.ModifyIndex = index
 
 	// Insert the node
@@ -1783,6 +1807,10 @@ func (s *StateStore) upsertJobImpl(index uint64, sub *structs.JobSubmission, job
 
 		existingJob = existing.(*structs.Job)
 
+		// The freeze state belongs to the job rather than a version of it,
+		// so carry it over from the existing job.
+		job.Frozen = existingJob.Frozen
+
 		// Bump the version unless asked to keep it. This should only be done
 		// when changing an internal field such as Stable. A spec change should
 		// always come with a version bump
@@ -1956,6 +1984,11 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return err
 	}
 
+	// Delete the job restart
+	if err := s.deleteJobRestartTxn(index, namespace, job.ID, txn); err != nil {
+		return err
+	}
+
 	// Mark all "pending" evals for this job as "complete"
 	evals, err := s.EvalsByJob(nil, namespace, job.ID)
 	if err != nil {
@@ -3553,6 +3586,9 @@ func (s *StateStore) DeleteEvalsByFilter(index uint64, filterExpr string, pageTo
 		if err := txn.Delete("evals", eval); err != nil {
 			return fmt.Errorf("eval delete failed: %v", err)
 		}
+		if err := s.deleteAppliedPlansByEvalTxn(txn, index, eval.ID); err != nil {
+			return err
+		}
 		pageCount++
 	}
 
@@ -3676,6 +3712,9 @@ func (s *StateStore) DeleteEval(index uint64, evals, allocs []string, userInitia
 		if err := txn.Delete("evals", existing); err != nil {
 			return fmt.Errorf("eval delete failed: %v", err)
 		}
+		if err := s.deleteAppliedPlansByEvalTxn(txn, index, eval); err != nil {
+			return err
+		}
 
 		// Mark that we have made a successful modification to the evals
 		// table.
@@ -3990,6 +4029,7 @@ func (s *StateStore) nestedUpdateAllocFromClient(txn *txn, index uint64, alloc *
 	copyAlloc.ClientDescription = alloc.ClientDescription
 	copyAlloc.TaskStates = alloc.TaskStates
 	copyAlloc.NetworkStatus = alloc.NetworkStatus
+	copyAlloc.Timestamps = exist.ClientUpdatedTimestamps(alloc)
 
 	// The client can only set its deployment health and timestamp, so just take
 	// those
@@ -4187,6 +4227,9 @@ func (s *StateStore) upsertAllocsImpl(index uint64, allocs []*structs.Allocation
 			// Keep the clients task states
 			alloc.TaskStates = exist.TaskStates
 
+			// Keep the results registered by the tasks
+			alloc.TaskResults = exist.TaskResults
+
 			// If the scheduler is marking this allocation as lost or unknown we do not
 			// want to reuse the status of the existing allocation.
 			if alloc.ClientStatus != structs.AllocClientStatusLost &&
@@ -4332,6 +4375,88 @@ func (s *StateStore) UpdateAllocDesiredTransitionTxn(
 	return nil
 }
 
+// UpdateAllocsSignedIdentities is used to replace the default workload
+// identities of allocations with the identities re-signed by the leader.
+// Allocations that no longer exist or are terminal are skipped.
+func (s *StateStore) UpdateAllocsSignedIdentities(msgType structs.MessageType, index uint64,
+	allocs []*structs.AllocSignedIdentities) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, update := range allocs {
+		existing, err := txn.First("allocs", "id", update.AllocID)
+		if err != nil {
+			return fmt.Errorf("alloc lookup failed: %v", err)
+		}
+		if existing == nil {
+			continue
+		}
+		exist := existing.(*structs.Allocation)
+		if exist.TerminalStatus() {
+			continue
+		}
+
+		// Copy everything from the existing allocation and replace the
+		// re-signed identities. The signed identities are not deep copied.
+		copyAlloc := exist.Copy()
+		copyAlloc.SignedIdentities = make(map[string]string, len(exist.SignedIdentities))
+		maps.Copy(copyAlloc.SignedIdentities, exist.SignedIdentities)
+		maps.Copy(copyAlloc.SignedIdentities, update.SignedIdentities)
+		copyAlloc.SigningKeyID = update.SigningKeyID
+
+		// Update the modify indexes so the clients pick up the new identities
+		copyAlloc.ModifyIndex = index
+		copyAlloc.AllocModifyIndex = index
+
+		if err := txn.Insert("allocs", copyAlloc); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// UpsertAllocTaskResult is used to register the result of a task of an
+// allocation, replacing any result previously registered by the task.
+func (s *StateStore) UpsertAllocTaskResult(msgType structs.MessageType, index uint64,
+	allocID, taskName string, result *structs.TaskResult) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First("allocs", "id", allocID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("alloc %s not found", allocID)
+	}
+	exist := existing.(*structs.Allocation)
+
+	// Copy everything from the existing allocation and add the result. The
+	// AllocModifyIndex isn't updated, as the clients don't use the results.
+	copyAlloc := exist.Copy()
+	if copyAlloc.TaskResults == nil {
+		copyAlloc.TaskResults = make(map[string]*structs.TaskResult, 1)
+	}
+	copyAlloc.TaskResults[taskName] = result
+	copyAlloc.ModifyIndex = index
+
+	if err := txn.Insert("allocs", copyAlloc); err != nil {
+		return fmt.Errorf("alloc insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // AllocByID is used to lookup an allocation by its ID
 func (s *StateStore) AllocByID(ws memdb.WatchSet, id string) (*structs.Allocation, error) {
 	txn := s.db.ReadTxn()
@@ -4793,6 +4918,9 @@ func (s *StateStore) updateDeploymentStatusImpl(index uint64, u *structs.Deploym
 	copy.StatusDescription = u.StatusDescription
 	copy.ModifyIndex = index
 	copy.ModifyTime = u.UpdatedAt
+	if u.PendingFailure != nil {
+		copy.PendingFailure = u.PendingFailure
+	}
 
 	// Insert the deployment
 	if err := txn.Insert("deployment", copy); err != nil {
@@ -4850,6 +4978,40 @@ func (s *StateStore) updateJobStabilityImpl(index uint64, namespace, jobID strin
 	return s.upsertJobImpl(index, nil, copy, true, txn)
 }
 
+// UpdateJobFreeze sets the freeze state of the given job. Freezing a job does
+// not create a new job version.
+func (s *StateStore) UpdateJobFreeze(msgType structs.MessageType, index uint64, namespace, jobID string, frozen bool) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First("jobs", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("job %q in namespace %q not found", jobID, namespace)
+	}
+
+	// If the job already has the desired freeze state, nothing to do
+	job := existing.(*structs.Job)
+	if job.Frozen == frozen {
+		return nil
+	}
+
+	copy := job.Copy()
+	copy.Frozen = frozen
+	copy.ModifyIndex = index
+
+	if err := txn.Insert("jobs", copy); err != nil {
+		return fmt.Errorf("job insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 func (s *StateStore) UpdateJobVersionTag(index uint64, namespace string, req *structs.JobApplyTagRequest) error {
 	jobID := req.JobID
 	jobVersion := req.Version
@@ -5010,6 +5172,10 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		promotable = append(promotable, alloc)
 	}
 
+	// partial is a mapping of the groups that are partially promoted to the
+	// number of canaries to promote
+	partial := make(map[string]int)
+
 	// Determine if we have enough healthy allocations
 	var unhealthyErr multierror.Error
 	for tg, dstate := range deployment.TaskGroups {
@@ -5017,7 +5183,20 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 			continue
 		}
 
-		need := dstate.DesiredCanaries
+		need := dstate.HealthyCanariesRequired(req.AutoPromote)
+		if req.IsPartial() && dstate.DesiredCanaries > 0 {
+			placed := len(dstate.PlacedCanaries)
+			need = req.CanariesToPromote(placed)
+			if need == 0 {
+				multierror.Append(&unhealthyErr, fmt.Errorf("Task group %q has no placed canaries to promote", tg))
+				continue
+			}
+
+			// The group is promoted once all its canaries are promoted
+			if need < placed || placed+len(dstate.PromotedCanaries) < dstate.DesiredCanaries {
+				partial[tg] = need
+			}
+		}
 		if need == 0 {
 			continue
 		}
@@ -5031,6 +5210,27 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		return err
 	}
 
+	// Only promote the canaries of partially promoted groups that have been
+	// healthy the longest
+	if len(partial) != 0 {
+		sort.SliceStable(promotable, func(i, j int) bool {
+			return promotable[i].DeploymentStatus.Timestamp.Before(promotable[j].DeploymentStatus.Timestamp)
+		})
+
+		promoted := make(map[string]int, len(partial))
+		filtered := promotable[:0]
+		for _, alloc := range promotable {
+			if n, ok := partial[alloc.TaskGroup]; ok {
+				if promoted[alloc.TaskGroup] >= n {
+					continue
+				}
+				promoted[alloc.TaskGroup]++
+			}
+			filtered = append(filtered, alloc)
+		}
+		promotable = filtered
+	}
+
 	// Update deployment
 	copy := deployment.Copy()
 	copy.ModifyIndex = index
@@ -5044,6 +5244,20 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		if status.ProgressDeadline > 0 && !status.RequireProgressBy.IsZero() {
 			status.RequireProgressBy = time.Now().Add(status.ProgressDeadline)
 		}
+
+		// Move the partially promoted canaries out of the placed canaries,
+		// so the group stays unpromoted while its other canaries are observed
+		if _, ok := partial[tg]; ok {
+			for _, alloc := range promotable {
+				if alloc.TaskGroup != tg {
+					continue
+				}
+				status.PlacedCanaries = slices.DeleteFunc(status.PlacedCanaries,
+					func(id string) bool { return id == alloc.ID })
+				status.PromotedCanaries = append(status.PromotedCanaries, alloc.ID)
+			}
+			continue
+		}
 		status.Promoted = true
 	}
 
@@ -7022,46 +7236,9 @@ func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
 			return fmt.Errorf("default namespace can not be deleted")
 		}
 
-		// Ensure that the namespace doesn't have any non-terminal jobs
-		iter, err := s.jobsByNamespaceImpl(nil, name, txn, SortDefault)
-		if err != nil {
-			return err
-		}
-
-		for {
-			raw := iter.Next()
-			if raw == nil {
-				break
-			}
-			job := raw.(*structs.Job)
-
-			if job.Status != structs.JobStatusDead {
-				return fmt.Errorf("namespace %q contains at least one non-terminal job %q. "+
-					"All jobs must be terminal in namespace before it can be deleted", name, job.ID)
-			}
-		}
-
-		vIter, err := s.csiVolumesByNamespaceImpl(txn, nil, name, "")
-		if err != nil {
+		if err := s.namespaceInUseTxn(txn, name); err != nil {
 			return err
 		}
-		rawVol := vIter.Next()
-		if rawVol != nil {
-			vol := rawVol.(*structs.CSIVolume)
-			return fmt.Errorf("namespace %q contains at least one CSI volume %q. "+
-				"All CSI volumes in namespace must be deleted before it can be deleted", name, vol.ID)
-		}
-
-		varIter, err := s.getVariablesByNamespaceImpl(txn, nil, name)
-		if err != nil {
-			return err
-		}
-		if varIter.Next() != nil {
-			// unlike job/volume, don't show the path here because the user may
-			// not have List permissions on the vars in this namespace
-			return fmt.Errorf("namespace %q contains at least one variable. "+
-				"All variables in namespace must be deleted before it can be deleted", name)
-		}
 
 		// Delete the namespace
 		if err := txn.Delete(TableNamespaces, existing); err != nil {
@@ -7076,6 +7253,58 @@ func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
 	return txn.Commit()
 }
 
+// NamespaceInUse returns an error describing why the namespace can not be
+// deleted if it still contains non-terminal jobs, CSI volumes, or variables.
+func (s *StateStore) NamespaceInUse(name string) error {
+	txn := s.db.ReadTxn()
+	return s.namespaceInUseTxn(txn, name)
+}
+
+func (s *StateStore) namespaceInUseTxn(txn *txn, name string) error {
+	// Ensure that the namespace doesn't have any non-terminal jobs
+	iter, err := s.jobsByNamespaceImpl(nil, name, txn, SortDefault)
+	if err != nil {
+		return err
+	}
+
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		job := raw.(*structs.Job)
+
+		if job.Status != structs.JobStatusDead {
+			return fmt.Errorf("namespace %q contains at least one non-terminal job %q. "+
+				"All jobs must be terminal in namespace before it can be deleted", name, job.ID)
+		}
+	}
+
+	vIter, err := s.csiVolumesByNamespaceImpl(txn, nil, name, "")
+	if err != nil {
+		return err
+	}
+	rawVol := vIter.Next()
+	if rawVol != nil {
+		vol := rawVol.(*structs.CSIVolume)
+		return fmt.Errorf("namespace %q contains at least one CSI volume %q. "+
+			"All CSI volumes in namespace must be deleted before it can be deleted", name, vol.ID)
+	}
+
+	varIter, err := s.getVariablesByNamespaceImpl(txn, nil, name)
+	if err != nil {
+		return err
+	}
+	if varIter.Next() != nil {
+		// unlike job/volume, don't show the path here because the user may
+		// not have List permissions on the vars in this namespace
+		return fmt.Errorf("namespace %q contains at least one variable. "+
+			"All variables in namespace must be deleted before it can be deleted", name)
+	}
+
+	return nil
+}
+
 func (s *StateStore) DeleteScalingPolicies(index uint64, ids []string) error {
 	txn := s.db.WriteTxn(index)
 	defer txn.Abort()
@@ -7372,6 +7601,9 @@ func (s *StateSnapshot) DenormalizeAllocationDiffSlice(allocDiffs []*structs.All
 		if allocDiff.ModifyTime != 0 {
 			allocCopy.ModifyTime = allocDiff.ModifyTime
 		}
+		if allocDiff.PreemptionDeadline != 0 {
+			allocCopy.PreemptionDeadline = allocDiff.PreemptionDeadline
+		}
 
 		// Update the allocDiff in the slice to equal the denormalized alloc
 		denormalizedAllocs[j] = allocCopy
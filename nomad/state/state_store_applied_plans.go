@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// AppliedPlans returns an iterator over all the applied plan records.
+func (s *StateStore) AppliedPlans(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableAppliedPlans, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("applied plans lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// AppliedPlanByID returns the record of the applied plan with the given ID or
+// nil if the plan was not applied.
+func (s *StateStore) AppliedPlanByID(ws memdb.WatchSet, planID string) (*structs.AppliedPlan, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableAppliedPlans, indexID, planID)
+	if err != nil {
+		return nil, fmt.Errorf("applied plan lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.AppliedPlan), nil
+}
+
+// insertAppliedPlanTxn records that the plan with the given ID was applied.
+func (s *StateStore) insertAppliedPlanTxn(txn Txn, index uint64, planID, evalID string) error {
+	record := &structs.AppliedPlan{
+		PlanID:       planID,
+		EvalID:       evalID,
+		AppliedIndex: index,
+	}
+	if err := txn.Insert(TableAppliedPlans, record); err != nil {
+		return fmt.Errorf("applied plan insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableAppliedPlans, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// deleteAppliedPlansByEvalTxn deletes the records of the plans submitted by
+// the evaluation, which can't be resubmitted once the evaluation is deleted.
+func (s *StateStore) deleteAppliedPlansByEvalTxn(txn Txn, index uint64, evalID string) error {
+	num, err := txn.DeleteAll(TableAppliedPlans, "eval", evalID)
+	if err != nil {
+		return fmt.Errorf("deleting applied plans failed: %w", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableAppliedPlans, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
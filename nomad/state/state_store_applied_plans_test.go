@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_AppliedPlans(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 11, []*structs.Evaluation{eval}))
+
+	// Applying plan results records the plan
+	planID := uuid.Generate()
+	must.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 12, &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{Job: job},
+		EvalID:             eval.ID,
+		PlanID:             planID,
+	}))
+
+	out, err := state.AppliedPlanByID(nil, planID)
+	must.NoError(t, err)
+	must.Eq(t, &structs.AppliedPlan{PlanID: planID, EvalID: eval.ID, AppliedIndex: 12}, out)
+
+	// Plans without an ID aren't recorded
+	must.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 13, &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{Job: job},
+		EvalID:             eval.ID,
+	}))
+	iter, err := state.AppliedPlans(nil)
+	must.NoError(t, err)
+	must.NotNil(t, iter.Next())
+	must.Nil(t, iter.Next())
+
+	// Deleting the eval deletes the records of its plans
+	must.NoError(t, state.DeleteEval(14, []string{eval.ID}, nil, false))
+	out, err = state.AppliedPlanByID(nil, planID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	index, err := state.Index(TableAppliedPlans)
+	must.NoError(t, err)
+	must.Eq(t, 14, index)
+}
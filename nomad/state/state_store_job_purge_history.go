@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// JobPurgeRecords returns an iterator over all the job purge records.
+func (s *StateStore) JobPurgeRecords(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableJobPurgeHistory, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("job purge history lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// JobPurgeRecordsByNamespace returns an iterator over the purge records of
+// the jobs of the namespace.
+func (s *StateStore) JobPurgeRecordsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableJobPurgeHistory, "namespace", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("job purge history lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// JobPurgeRecordsByJob returns an iterator over the purge records of the job.
+func (s *StateStore) JobPurgeRecordsByJob(ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableJobPurgeHistory, indexJob, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("job purge history lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// InsertJobPurgeRecordTxn inserts the audit record of a purged job. It is
+// applied in the same transaction as the purge of the job.
+func (s *StateStore) InsertJobPurgeRecordTxn(index uint64, record *structs.JobPurgeRecord, txn Txn) error {
+	record.CreateIndex = index
+
+	if err := txn.Insert(TableJobPurgeHistory, record); err != nil {
+		return fmt.Errorf("job purge record insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobPurgeHistory, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_JobPurgeRecords(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job1 := mock.Job()
+	job2 := mock.Job()
+	job3 := mock.Job()
+	job3.Namespace = "other"
+
+	records := []*structs.JobPurgeRecord{
+		structs.NewJobPurgeRecord(job1, []*structs.Job{job1}, "alice", 1),
+		structs.NewJobPurgeRecord(job1, []*structs.Job{job1}, "bob", 2),
+		structs.NewJobPurgeRecord(job2, []*structs.Job{job2}, "alice", 3),
+		structs.NewJobPurgeRecord(job3, []*structs.Job{job3}, "alice", 4),
+	}
+	for i, record := range records {
+		index := uint64(10 + i)
+		txn := state.db.WriteTxn(index)
+		must.NoError(t, state.InsertJobPurgeRecordTxn(index, record, txn))
+		must.NoError(t, txn.Commit())
+	}
+
+	count := func(iter memdb.ResultIterator) int {
+		n := 0
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			n++
+		}
+		return n
+	}
+
+	ws := memdb.NewWatchSet()
+	iter, err := state.JobPurgeRecords(ws)
+	must.NoError(t, err)
+	must.Eq(t, 4, count(iter))
+
+	iter, err = state.JobPurgeRecordsByNamespace(ws, structs.DefaultNamespace)
+	must.NoError(t, err)
+	must.Eq(t, 3, count(iter))
+
+	iter, err = state.JobPurgeRecordsByJob(ws, job1.Namespace, job1.ID)
+	must.NoError(t, err)
+	must.Eq(t, 2, count(iter))
+
+	iter, err = state.JobPurgeRecordsByJob(ws, "other", job1.ID)
+	must.NoError(t, err)
+	must.Eq(t, 0, count(iter))
+
+	index, err := state.Index(TableJobPurgeHistory)
+	must.NoError(t, err)
+	must.Eq(t, 13, index)
+}
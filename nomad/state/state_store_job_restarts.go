@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// JobRestarts returns an iterator over all the job restarts.
+func (s *StateStore) JobRestarts(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableJobRestarts, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("job restarts lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// JobRestartByJob returns the restart of the job or nil if it does not exist.
+func (s *StateStore) JobRestartByJob(ws memdb.WatchSet, namespace, jobID string) (*structs.JobRestart, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableJobRestarts, indexID, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("job restart lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.JobRestart), nil
+}
+
+// UpsertJobRestart inserts or updates the restart of a job. A restart with a
+// different ID replaces the previous restart of the job.
+func (s *StateStore) UpsertJobRestart(msgType structs.MessageType, index uint64, restart *structs.JobRestart) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableJobRestarts, indexID, restart.Namespace, restart.JobID)
+	if err != nil {
+		return fmt.Errorf("job restart lookup failed: %w", err)
+	}
+
+	if existing != nil && existing.(*structs.JobRestart).ID == restart.ID {
+		restart.CreateIndex = existing.(*structs.JobRestart).CreateIndex
+	} else {
+		restart.CreateIndex = index
+	}
+	restart.ModifyIndex = index
+
+	if err := txn.Insert(TableJobRestarts, restart); err != nil {
+		return fmt.Errorf("job restart insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobRestarts, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// deleteJobRestartTxn removes the restart of a purged job, if any.
+func (s *StateStore) deleteJobRestartTxn(index uint64, namespace, jobID string, txn Txn) error {
+	num, err := txn.DeleteAll(TableJobRestarts, indexID, namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("deleting job restart failed: %w", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobRestarts, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
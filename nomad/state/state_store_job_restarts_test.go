@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_JobRestarts(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	restart := &structs.JobRestart{
+		ID:        uuid.Generate(),
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Status:    structs.JobRestartStatusRunning,
+	}
+	must.NoError(t, state.UpsertJobRestart(structs.MsgTypeTestSetup, 11, restart))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.JobRestartByJob(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, 11, out.CreateIndex)
+	must.Eq(t, 11, out.ModifyIndex)
+
+	// Updating the restart keeps its create index
+	update := out.Copy()
+	update.Batch = []string{uuid.Generate()}
+	must.NoError(t, state.UpsertJobRestart(structs.MsgTypeTestSetup, 12, update))
+	must.True(t, watchFired(ws))
+
+	out, err = state.JobRestartByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 11, out.CreateIndex)
+	must.Eq(t, 12, out.ModifyIndex)
+	must.Eq(t, update.Batch, out.Batch)
+
+	// A new restart replaces the previous one
+	replacement := &structs.JobRestart{
+		ID:        uuid.Generate(),
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Status:    structs.JobRestartStatusRunning,
+	}
+	must.NoError(t, state.UpsertJobRestart(structs.MsgTypeTestSetup, 13, replacement))
+
+	iter, err := state.JobRestarts(nil)
+	must.NoError(t, err)
+	raw := iter.Next()
+	must.NotNil(t, raw)
+	must.Eq(t, replacement.ID, raw.(*structs.JobRestart).ID)
+	must.Eq(t, 13, raw.(*structs.JobRestart).CreateIndex)
+	must.Nil(t, iter.Next())
+
+	// Purging the job deletes its restart
+	must.NoError(t, state.DeleteJob(14, job.Namespace, job.ID))
+	out, err = state.JobRestartByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	index, err := state.Index(TableJobRestarts)
+	must.NoError(t, err)
+	must.Eq(t, 14, index)
+}
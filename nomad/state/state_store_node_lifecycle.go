@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeLifecycleRuns returns an iterator over all the node lifecycle runs.
+func (s *StateStore) NodeLifecycleRuns(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodeLifecycleRuns, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("node lifecycle runs lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// NodeLifecycleRunByPool returns the node lifecycle run of the node pool or
+// nil if it does not exist.
+func (s *StateStore) NodeLifecycleRunByPool(ws memdb.WatchSet, pool string) (*structs.NodeLifecycleRun, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableNodeLifecycleRuns, indexID, pool)
+	if err != nil {
+		return nil, fmt.Errorf("node lifecycle run lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.NodeLifecycleRun), nil
+}
+
+// UpsertNodeLifecycleRun inserts or updates the node lifecycle run of a node
+// pool. A run with a different ID replaces the previous run of the node pool
+// only once the previous run is terminal, and a terminal run is never updated
+// so that the leader can't resume a run that was aborted meanwhile.
+func (s *StateStore) UpsertNodeLifecycleRun(msgType structs.MessageType, index uint64, run *structs.NodeLifecycleRun) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	raw, err := txn.First(TableNodeLifecycleRuns, indexID, run.NodePool)
+	if err != nil {
+		return fmt.Errorf("node lifecycle run lookup failed: %w", err)
+	}
+
+	run.CreateIndex = index
+	if raw != nil {
+		existing := raw.(*structs.NodeLifecycleRun)
+		switch {
+		case existing.ID == run.ID && existing.Terminal():
+			return fmt.Errorf("node lifecycle run %s of node pool %q is already %s",
+				existing.ID, existing.NodePool, existing.Status)
+		case existing.ID != run.ID && !existing.Terminal():
+			return fmt.Errorf("node pool %q already has running node lifecycle run %s",
+				existing.NodePool, existing.ID)
+		case existing.ID == run.ID:
+			run.CreateIndex = existing.CreateIndex
+		}
+	}
+	run.ModifyIndex = index
+
+	if err := txn.Insert(TableNodeLifecycleRuns, run); err != nil {
+		return fmt.Errorf("node lifecycle run insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodeLifecycleRuns, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// deleteNodeLifecycleRunTxn removes the node lifecycle run of a deleted node
+// pool, if any.
+func (s *StateStore) deleteNodeLifecycleRunTxn(index uint64, pool string, txn Txn) error {
+	num, err := txn.DeleteAll(TableNodeLifecycleRuns, indexID, pool)
+	if err != nil {
+		return fmt.Errorf("deleting node lifecycle run failed: %w", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodeLifecycleRuns, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
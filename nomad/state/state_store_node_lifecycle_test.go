@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_NodeLifecycleRuns(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	pool := mock.NodePool()
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool}))
+
+	run := &structs.NodeLifecycleRun{
+		ID:       uuid.Generate(),
+		NodePool: pool.Name,
+		Status:   structs.NodeLifecycleStatusRunning,
+		Nodes: []*structs.NodeLifecycleNode{
+			{NodeID: uuid.Generate(), Phase: structs.NodeLifecyclePhasePending},
+		},
+	}
+	must.NoError(t, state.UpsertNodeLifecycleRun(structs.MsgTypeTestSetup, 11, run))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.NodeLifecycleRunByPool(ws, pool.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, 11, out.CreateIndex)
+	must.Eq(t, 11, out.ModifyIndex)
+
+	// Updating the run keeps its create index
+	update := out.Copy()
+	update.Nodes[0].Phase = structs.NodeLifecyclePhaseCordon
+	must.NoError(t, state.UpsertNodeLifecycleRun(structs.MsgTypeTestSetup, 12, update))
+	must.True(t, watchFired(ws))
+
+	out, err = state.NodeLifecycleRunByPool(nil, pool.Name)
+	must.NoError(t, err)
+	must.Eq(t, 11, out.CreateIndex)
+	must.Eq(t, 12, out.ModifyIndex)
+	must.Eq(t, structs.NodeLifecyclePhaseCordon, out.Nodes[0].Phase)
+
+	// A new run can't replace a running one
+	replacement := &structs.NodeLifecycleRun{
+		ID:       uuid.Generate(),
+		NodePool: pool.Name,
+		Status:   structs.NodeLifecycleStatusRunning,
+	}
+	err = state.UpsertNodeLifecycleRun(structs.MsgTypeTestSetup, 13, replacement)
+	must.ErrorContains(t, err, "already has running node lifecycle run")
+
+	// A terminal run can't be updated
+	aborted := out.Copy()
+	aborted.Status = structs.NodeLifecycleStatusAborted
+	must.NoError(t, state.UpsertNodeLifecycleRun(structs.MsgTypeTestSetup, 14, aborted))
+
+	update = out.Copy()
+	update.Nodes[0].Phase = structs.NodeLifecyclePhaseDrain
+	err = state.UpsertNodeLifecycleRun(structs.MsgTypeTestSetup, 15, update)
+	must.ErrorContains(t, err, "is already aborted")
+
+	// A new run replaces a terminal one
+	must.NoError(t, state.UpsertNodeLifecycleRun(structs.MsgTypeTestSetup, 16, replacement))
+
+	iter, err := state.NodeLifecycleRuns(nil)
+	must.NoError(t, err)
+	raw := iter.Next()
+	must.NotNil(t, raw)
+	must.Eq(t, replacement.ID, raw.(*structs.NodeLifecycleRun).ID)
+	must.Eq(t, 16, raw.(*structs.NodeLifecycleRun).CreateIndex)
+	must.Nil(t, iter.Next())
+
+	// Deleting the node pool deletes its run
+	must.NoError(t, state.DeleteNodePools(structs.MsgTypeTestSetup, 17, []string{pool.Name}))
+	out, err = state.NodeLifecycleRunByPool(nil, pool.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	index, err := state.Index(TableNodeLifecycleRuns)
+	must.NoError(t, err)
+	must.Eq(t, 17, index)
+}
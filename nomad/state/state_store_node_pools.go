@@ -209,5 +209,33 @@ func (s *StateStore) deleteNodePoolTxn(txn *txn, index uint64, name string) erro
 		return fmt.Errorf("node pool deletion failed: %w", err)
 	}
 
+	return s.deleteNodeLifecycleRunTxn(index, name, txn)
+}
+
+// NodePoolInUse returns an error describing why the node pool can not be
+// deleted if it still has nodes or non-terminal jobs. Unlike the node pool
+// delete RPC, it only checks the local region.
+func (s *StateStore) NodePoolInUse(name string) error {
+	txn := s.db.ReadTxn()
+
+	nodes, err := txn.Get("nodes", "node_pool", name)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %w", err)
+	}
+	if raw := nodes.Next(); raw != nil {
+		return fmt.Errorf("node pool %q has at least one node %q", name, raw.(*structs.Node).ID)
+	}
+
+	jobs, err := txn.Get("jobs", "pool", name)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %w", err)
+	}
+	for raw := jobs.Next(); raw != nil; raw = jobs.Next() {
+		job := raw.(*structs.Job)
+		if job.Status != structs.JobStatusDead {
+			return fmt.Errorf("node pool %q has at least one non-terminal job %q", name, job.ID)
+		}
+	}
+
 	return nil
 }
@@ -214,6 +214,15 @@ func (r *StateRestore) ServiceRegistrationRestore(service *structs.ServiceRegist
 	return nil
 }
 
+// ServiceGrantRestore is used to restore a single service grant into the
+// service_grants table.
+func (r *StateRestore) ServiceGrantRestore(grant *structs.ServiceGrant) error {
+	if err := r.txn.Insert(TableServiceGrants, grant); err != nil {
+		return fmt.Errorf("service grant insert failed: %v", err)
+	}
+	return nil
+}
+
 // VariablesRestore is used to restore a single variable into the variables
 // table.
 func (r *StateRestore) VariablesRestore(variable *structs.VariableEncrypted) error {
@@ -284,6 +293,33 @@ func (r *StateRestore) JobSubmissionRestore(jobSubmission *structs.JobSubmission
 	return nil
 }
 
+// JobPurgeRecordRestore is used to restore a single job purge record into the
+// job_purge_history table.
+func (r *StateRestore) JobPurgeRecordRestore(record *structs.JobPurgeRecord) error {
+	if err := r.txn.Insert(TableJobPurgeHistory, record); err != nil {
+		return fmt.Errorf("job purge record insert failed: %v", err)
+	}
+	return nil
+}
+
+// JobRestartRestore is used to restore a single job restart into the
+// job_restarts table.
+func (r *StateRestore) JobRestartRestore(restart *structs.JobRestart) error {
+	if err := r.txn.Insert(TableJobRestarts, restart); err != nil {
+		return fmt.Errorf("job restart insert failed: %v", err)
+	}
+	return nil
+}
+
+// AppliedPlanRestore is used to restore a single applied plan record into the
+// applied_plans table.
+func (r *StateRestore) AppliedPlanRestore(record *structs.AppliedPlan) error {
+	if err := r.txn.Insert(TableAppliedPlans, record); err != nil {
+		return fmt.Errorf("applied plan insert failed: %v", err)
+	}
+	return nil
+}
+
 // HostVolumeRestore restores a single host volume into the host_volumes table
 func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
 	if err := r.txn.Insert(TableHostVolumes, vol); err != nil {
@@ -291,3 +327,12 @@ func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
 	}
 	return nil
 }
+
+// NodeLifecycleRunRestore is used to restore a single node lifecycle run into
+// the node_lifecycle_runs table.
+func (r *StateRestore) NodeLifecycleRunRestore(run *structs.NodeLifecycleRun) error {
+	if err := r.txn.Insert(TableNodeLifecycleRuns, run); err != nil {
+		return fmt.Errorf("node lifecycle run insert failed: %v", err)
+	}
+	return nil
+}
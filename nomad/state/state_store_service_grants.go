@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ServiceGrants returns an iterator over all the service grants.
+func (s *StateStore) ServiceGrants(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableServiceGrants, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("service grants lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ServiceGrantsByNamespace returns an iterator over the service grants
+// exposing the services of the namespace.
+func (s *StateStore) ServiceGrantsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+	return s.serviceGrantsByNamespaceTxn(txn, ws, namespace)
+}
+
+func (s *StateStore) serviceGrantsByNamespaceTxn(txn ReadTxn, ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	iter, err := txn.Get(TableServiceGrants, "namespace", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("service grants lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ServiceGrantByID returns the service grant with the given ID or nil if it
+// does not exist.
+func (s *StateStore) ServiceGrantByID(ws memdb.WatchSet, id string) (*structs.ServiceGrant, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableServiceGrants, indexID, id)
+	if err != nil {
+		return nil, fmt.Errorf("service grant lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.ServiceGrant), nil
+}
+
+// ServiceGrantAllows returns true if a service grant allows the workloads of
+// grantedNamespace to look up the service registered in namespace.
+func (s *StateStore) ServiceGrantAllows(ws memdb.WatchSet, namespace, service, grantedNamespace string) (bool, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := s.serviceGrantsByNamespaceTxn(txn, ws, namespace)
+	if err != nil {
+		return false, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if raw.(*structs.ServiceGrant).Matches(service, grantedNamespace) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// UpsertServiceGrants inserts or updates the given set of service grants.
+func (s *StateStore) UpsertServiceGrants(msgType structs.MessageType, index uint64, grants []*structs.ServiceGrant) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, grant := range grants {
+		if grant == nil {
+			continue
+		}
+
+		existing, err := txn.First(TableServiceGrants, indexID, grant.ID)
+		if err != nil {
+			return fmt.Errorf("service grant lookup failed: %w", err)
+		}
+
+		if existing != nil {
+			grant.CreateIndex = existing.(*structs.ServiceGrant).CreateIndex
+		} else {
+			grant.CreateIndex = index
+		}
+		grant.ModifyIndex = index
+
+		if err := txn.Insert(TableServiceGrants, grant); err != nil {
+			return fmt.Errorf("service grant insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableServiceGrants, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteServiceGrants removes the service grants with the given IDs.
+func (s *StateStore) DeleteServiceGrants(msgType structs.MessageType, index uint64, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableServiceGrants, indexID, id)
+		if err != nil {
+			return fmt.Errorf("service grant lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("service grant %s not found", id)
+		}
+		if err := txn.Delete(TableServiceGrants, existing); err != nil {
+			return fmt.Errorf("service grant delete failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableServiceGrants, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
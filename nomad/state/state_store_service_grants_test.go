@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_ServiceGrants(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	grants := []*structs.ServiceGrant{
+		{ID: "a", Namespace: "data", ServiceName: "db", GrantedNamespace: "web"},
+		{ID: "b", Namespace: "data", ServiceName: structs.ServiceGrantAllServices, GrantedNamespace: "api"},
+		{ID: "c", Namespace: "api", ServiceName: "api", GrantedNamespace: "web"},
+	}
+	must.NoError(t, state.UpsertServiceGrants(structs.MsgTypeTestSetup, 10, grants))
+
+	ws := memdb.NewWatchSet()
+	iter, err := state.ServiceGrantsByNamespace(ws, "data")
+	must.NoError(t, err)
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.ServiceGrant).ID)
+	}
+	must.SliceContainsAll(t, []string{"a", "b"}, ids)
+
+	for _, tc := range []struct {
+		namespace, service, granted string
+		allowed                     bool
+	}{
+		{"data", "db", "web", true},
+		{"data", "cache", "web", false},
+		{"data", "cache", "api", true},
+		{"api", "api", "web", true},
+		{"api", "api", "data", false},
+		{"web", "db", "data", false},
+	} {
+		allowed, err := state.ServiceGrantAllows(nil, tc.namespace, tc.service, tc.granted)
+		must.NoError(t, err)
+		must.Eq(t, tc.allowed, allowed, must.Sprintf("%+v", tc))
+	}
+
+	// Updating a grant keeps its create index and fires the watch set
+	updated := grants[0].Copy()
+	updated.Description = "db"
+	must.NoError(t, state.UpsertServiceGrants(structs.MsgTypeTestSetup, 20, []*structs.ServiceGrant{updated}))
+	must.True(t, watchFired(ws))
+
+	got, err := state.ServiceGrantByID(nil, "a")
+	must.NoError(t, err)
+	must.Eq(t, "db", got.Description)
+	must.Eq(t, 10, got.CreateIndex)
+	must.Eq(t, 20, got.ModifyIndex)
+
+	// Deleting grants removes their access
+	must.NoError(t, state.DeleteServiceGrants(structs.MsgTypeTestSetup, 30, []string{"a"}))
+	must.Error(t, state.DeleteServiceGrants(structs.MsgTypeTestSetup, 40, []string{"a"}))
+
+	got, err = state.ServiceGrantByID(nil, "a")
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	allowed, err := state.ServiceGrantAllows(nil, "data", "db", "web")
+	must.NoError(t, err)
+	must.False(t, allowed)
+
+	index, err := state.Index(TableServiceGrants)
+	must.NoError(t, err)
+	must.Eq(t, 30, index)
+}
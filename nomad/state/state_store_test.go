@@ -6075,6 +6075,45 @@ func TestStateStore_UpdateAllocsFromClient_Deployment(t *testing.T) {
 	must.True(t, healthy.Add(pdeadline).Equal(dstate.RequireProgressBy))
 }
 
+func TestStateStore_UpdateAllocsFromClient_Timestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	alloc := mock.Alloc()
+	alloc.Timestamps = &structs.AllocTimestamps{
+		EvalCreateTime: 1,
+		PlanSubmitTime: 2,
+		PlanApplyTime:  3,
+	}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	started := time.Now()
+	update := &structs.Allocation{
+		ID:           alloc.ID,
+		NodeID:       alloc.NodeID,
+		ClientStatus: structs.AllocClientStatusRunning,
+		JobID:        alloc.JobID,
+		TaskGroup:    alloc.TaskGroup,
+		TaskStates: map[string]*structs.TaskState{
+			"web": {State: structs.TaskStateRunning, StartedAt: started},
+		},
+		Timestamps: &structs.AllocTimestamps{ClientReceiveTime: 4},
+	}
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{update}))
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, &structs.AllocTimestamps{
+		EvalCreateTime:    1,
+		PlanSubmitTime:    2,
+		PlanApplyTime:     3,
+		ClientReceiveTime: 4,
+		TaskStartTime:     started.UnixNano(),
+	}, out.Timestamps)
+}
+
 // This tests that the deployment state is merged correctly
 func TestStateStore_UpdateAllocsFromClient_DeploymentStateMerges(t *testing.T) {
 	ci.Parallel(t)
@@ -6837,6 +6876,100 @@ func TestStateStore_UpdateAllocDesiredTransition(t *testing.T) {
 	require.Nil(state.UpdateAllocsDesiredTransitions(structs.MsgTypeTestSetup, 1003, m, evals))
 }
 
+func TestStateStore_UpdateAllocsSignedIdentities(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	alloc := mock.Alloc()
+	alloc.SignedIdentities = map[string]string{"web": "old-web", "sidecar": "old-sidecar"}
+	alloc.SigningKeyID = "old-key"
+	stopped := mock.Alloc()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.SignedIdentities = map[string]string{"web": "old-web"}
+
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{alloc, stopped}))
+
+	must.NoError(t, state.UpdateAllocsSignedIdentities(structs.MsgTypeTestSetup, 1001,
+		[]*structs.AllocSignedIdentities{
+			{
+				AllocID:          alloc.ID,
+				SignedIdentities: map[string]string{"web": "new-web"},
+				SigningKeyID:     "new-key",
+			},
+			{
+				AllocID:          stopped.ID,
+				SignedIdentities: map[string]string{"web": "new-web"},
+				SigningKeyID:     "new-key",
+			},
+			{
+				AllocID:          uuid.Generate(),
+				SignedIdentities: map[string]string{"web": "new-web"},
+			},
+		}))
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"web": "new-web", "sidecar": "old-sidecar"}, out.SignedIdentities)
+	must.Eq(t, "new-key", out.SigningKeyID)
+	must.Eq(t, 1000, out.CreateIndex)
+	must.Eq(t, 1001, out.ModifyIndex)
+	must.Eq(t, 1001, out.AllocModifyIndex)
+
+	// The existing alloc is not modified
+	must.Eq(t, "old-web", alloc.SignedIdentities["web"])
+
+	// Terminal allocs are not updated
+	out, err = state.AllocByID(nil, stopped.ID)
+	must.NoError(t, err)
+	must.Eq(t, "old-web", out.SignedIdentities["web"])
+	must.Eq(t, 1000, out.ModifyIndex)
+
+	index, err := state.Index("allocs")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+}
+
+func TestStateStore_UpsertAllocTaskResult(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{alloc}))
+
+	result := &structs.TaskResult{Summary: "done", Meta: map[string]string{"rows": "42"}}
+	must.NoError(t, state.UpsertAllocTaskResult(structs.MsgTypeTestSetup, 1001,
+		alloc.ID, "web", result))
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, map[string]*structs.TaskResult{"web": result}, out.TaskResults)
+	must.Eq(t, 1001, out.ModifyIndex)
+	must.Eq(t, 1000, out.AllocModifyIndex)
+	must.Nil(t, alloc.TaskResults)
+
+	index, err := state.Index("allocs")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// Updates from the scheduler keep the results
+	update := alloc.Copy()
+	update.DesiredStatus = structs.AllocDesiredStatusStop
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Allocation{update}))
+
+	out, err = state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, map[string]*structs.TaskResult{"web": result}, out.TaskResults)
+
+	// Registering a result for an unknown alloc fails
+	must.ErrorContains(t, state.UpsertAllocTaskResult(structs.MsgTypeTestSetup, 1003,
+		uuid.Generate(), "web", result), "not found")
+}
+
 func TestStateStore_JobSummary(t *testing.T) {
 	ci.Parallel(t)
 
@@ -8438,6 +8571,45 @@ func TestStateStore_UpdateJobStability(t *testing.T) {
 	require.False(t, jout.Stable)
 }
 
+func TestStateStore_UpdateJobFreeze(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, nil, job))
+
+	// Freezing a missing job is an error
+	must.Error(t, state.UpdateJobFreeze(structs.MsgTypeTestSetup, 2, job.Namespace, "missing", true))
+
+	// Freeze the job
+	must.NoError(t, state.UpdateJobFreeze(structs.MsgTypeTestSetup, 3, job.Namespace, job.ID, true))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.JobByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.True(t, out.Frozen)
+	must.Eq(t, 0, out.Version)
+	must.Eq(t, 3, out.ModifyIndex)
+	must.Eq(t, 1, out.JobModifyIndex)
+
+	// Registering a new version of the job keeps it frozen
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 4, nil, job.Copy()))
+
+	out, err = state.JobByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.True(t, out.Frozen)
+	must.Eq(t, 1, out.Version)
+
+	// Unfreeze the job
+	must.NoError(t, state.UpdateJobFreeze(structs.MsgTypeTestSetup, 5, job.Namespace, job.ID, false))
+
+	out, err = state.JobByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.False(t, out.Frozen)
+	must.Eq(t, 5, out.ModifyIndex)
+}
+
 // Test that nonexistent deployment can't be promoted
 func TestStateStore_UpsertDeploymentPromotion_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -8533,6 +8705,53 @@ func TestStateStore_UpsertDeploymentPromotion_Unhealthy(t *testing.T) {
 	require.Contains(err.Error(), `Task group "web" has 0/2 healthy allocations`)
 }
 
+// Test that the auto promote threshold only applies to auto-promotions
+func TestStateStore_UpsertDeploymentPromotion_Threshold(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	j := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, nil, j))
+
+	healthy := mock.Alloc()
+	healthy.JobID = j.ID
+	healthy.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+	unhealthy := mock.Alloc()
+	unhealthy.JobID = j.ID
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].DesiredCanaries = 2
+	d.TaskGroups["web"].AutoPromote = true
+	d.TaskGroups["web"].AutoPromoteThreshold = 50
+	d.TaskGroups["web"].PlacedCanaries = []string{healthy.ID, unhealthy.ID}
+	must.NoError(t, state.UpsertDeployment(2, d))
+
+	healthy.DeploymentID = d.ID
+	unhealthy.DeploymentID = d.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 3,
+		[]*structs.Allocation{healthy, unhealthy}))
+
+	// Manual promotions require all the desired canaries to be healthy
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+	}
+	err := state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 4, req)
+	must.ErrorContains(t, err, `Task group "web" has 1/2 healthy allocations`)
+
+	// Auto-promotions only require the threshold to be met
+	req.AutoPromote = true
+	must.NoError(t, state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 5, req))
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.True(t, out.TaskGroups["web"].Promoted)
+}
+
 // Test promoting a deployment with no canaries
 func TestStateStore_UpsertDeploymentPromotion_NoCanaries(t *testing.T) {
 	ci.Parallel(t)
@@ -8764,6 +8983,96 @@ func TestStateStore_UpsertDeploymentPromotion_Subset(t *testing.T) {
 	require.True(aout3.DeploymentStatus.Canary)
 }
 
+// Test promoting a subset of the canaries of a task group.
+func TestStateStore_UpsertDeploymentPromotion_Partial(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	j := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, nil, j))
+
+	// Create three canaries, of which the first has been healthy the longest
+	// and the last isn't healthy yet
+	now := time.Now()
+	canary := func(healthyAt time.Time) *structs.Allocation {
+		c := mock.Alloc()
+		c.JobID = j.ID
+		c.Job = j
+		c.DeploymentStatus = &structs.AllocDeploymentStatus{Canary: true}
+		if !healthyAt.IsZero() {
+			c.DeploymentStatus.Healthy = pointer.Of(true)
+			c.DeploymentStatus.Timestamp = healthyAt
+		}
+		return c
+	}
+	c1 := canary(now.Add(-2 * time.Minute))
+	c2 := canary(now.Add(-time.Minute))
+	c3 := canary(time.Time{})
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    10,
+			DesiredCanaries: 3,
+			PlacedCanaries:  []string{c2.ID, c1.ID, c3.ID},
+		},
+	}
+	must.NoError(t, state.UpsertDeployment(2, d))
+	for _, c := range []*structs.Allocation{c1, c2, c3} {
+		c.DeploymentID = d.ID
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 3,
+		[]*structs.Allocation{c1, c2, c3}))
+
+	promote := func(index uint64, req structs.DeploymentPromoteRequest) error {
+		req.DeploymentID = d.ID
+		req.All = true
+		return state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, index,
+			&structs.ApplyDeploymentPromoteRequest{DeploymentPromoteRequest: req})
+	}
+	isCanary := func(alloc *structs.Allocation) bool {
+		out, err := state.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		return out.DeploymentStatus.Canary
+	}
+
+	// Promote the canary that has been healthy the longest
+	must.NoError(t, promote(4, structs.DeploymentPromoteRequest{CanaryCount: 1}))
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	dstate := dout.TaskGroups["web"]
+	must.False(t, dstate.Promoted)
+	must.Eq(t, []string{c2.ID, c3.ID}, dstate.PlacedCanaries)
+	must.Eq(t, []string{c1.ID}, dstate.PromotedCanaries)
+	must.True(t, dout.RequiresPromotion())
+	must.False(t, isCanary(c1))
+	must.True(t, isCanary(c2))
+	must.True(t, isCanary(c3))
+
+	// Promoting all the remaining canaries requires them to be healthy
+	err = promote(5, structs.DeploymentPromoteRequest{CanaryPercent: 100})
+	must.ErrorContains(t, err, `Task group "web" has 1/2 healthy allocations`)
+
+	// Once they are healthy, promoting the remaining canaries promotes the
+	// task group
+	c3 = c3.Copy()
+	c3.DeploymentStatus.Healthy = pointer.Of(true)
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 6, []*structs.Allocation{c3}))
+	must.NoError(t, promote(7, structs.DeploymentPromoteRequest{CanaryPercent: 100}))
+
+	dout, err = state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	dstate = dout.TaskGroups["web"]
+	must.True(t, dstate.Promoted)
+	must.Eq(t, []string{c2.ID, c3.ID}, dstate.PlacedCanaries)
+	must.Eq(t, []string{c1.ID}, dstate.PromotedCanaries)
+	must.False(t, dout.RequiresPromotion())
+	must.False(t, isCanary(c2))
+	must.False(t, isCanary(c3))
+}
+
 // Test that allocation health can't be set against a nonexistent deployment
 func TestStateStore_UpsertDeploymentAllocHealth_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
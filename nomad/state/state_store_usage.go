@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TableUsage returns the number of objects and their approximate size for
+// each table of the state store, sorted by table name. The size of an object
+// is the size of its msgpack encoding, which approximates the memory it uses
+// without accounting for pointers shared between objects or for indexes.
+func (s *StateStore) TableUsage() ([]*structs.StateTableUsage, error) {
+	txn := s.db.ReadTxn()
+
+	var counter byteCounter
+	encoder := codec.NewEncoder(&counter, structs.MsgpackHandle)
+
+	tables := s.db.memdb.DBSchema().Tables
+	usage := make([]*structs.StateTableUsage, 0, len(tables))
+	for name := range tables {
+		iter, err := txn.Get(name, indexID)
+		if err != nil {
+			return nil, fmt.Errorf("%s lookup failed: %w", name, err)
+		}
+
+		table := &structs.StateTableUsage{Name: name}
+		start := counter
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			if err := encoder.Encode(raw); err != nil {
+				return nil, fmt.Errorf("failed to encode %s object: %w", name, err)
+			}
+			table.Objects++
+		}
+		table.SizeBytes = int64(counter - start)
+		usage = append(usage, table)
+	}
+
+	slices.SortFunc(usage, func(a, b *structs.StateTableUsage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return usage, nil
+}
+
+// byteCounter is an io.Writer counting the bytes written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_TableUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 10, nil, mock.Job()))
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 11, nil, mock.Job()))
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 12, mock.Node()))
+
+	usage, err := state.TableUsage()
+	must.NoError(t, err)
+
+	tables := map[string]*structs.StateTableUsage{}
+	for i, table := range usage {
+		if i > 0 {
+			must.Less(t, table.Name, usage[i-1].Name)
+		}
+		tables[table.Name] = table
+	}
+
+	must.Eq(t, 2, tables["jobs"].Objects)
+	must.Positive(t, tables["jobs"].SizeBytes)
+	must.Eq(t, 1, tables["nodes"].Objects)
+	must.Positive(t, tables["nodes"].SizeBytes)
+	must.Zero(t, tables["allocs"].Objects)
+	must.Zero(t, tables["allocs"].SizeBytes)
+}
@@ -229,6 +229,12 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 		sv.CreateIndex = existing.CreateIndex
 		sv.CreateTime = existing.CreateTime
 
+		// The holder of a lock keeps its fencing token when updating the
+		// variable.
+		if existing.Lock != nil && sv.Lock != nil {
+			sv.Lock.FencingToken = existing.Lock.FencingToken
+		}
+
 		if existing.Equal(*sv) {
 			// Skip further writing in the state store if the entry is not actually
 			// changed. Nevertheless, the input's ModifyIndex should be reset
@@ -509,6 +515,51 @@ func (s *StateStore) VarLockAcquire(idx uint64,
 		}
 	}
 
+	// A new holder of the lock gets a new fencing token, while the current
+	// holder acquiring the lock again keeps its own.
+	if !ok || sv.Lock == nil {
+		req.Var.Lock.FencingToken = idx
+	}
+
+	resp := s.varSetTxn(tx, idx, req)
+	if resp.IsError() {
+		return resp
+	}
+
+	if err := tx.Commit(); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+
+	return resp
+}
+
+// VarLockSteal is the method used to append a lock to a variable regardless
+// of its current holder, if the variable doesn't exists, it is created. The
+// lock always gets a new fencing token.
+// IMPORTANT: this method overwrites the variable, data included.
+func (s *StateStore) VarLockSteal(idx uint64,
+	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	// Try to fetch the variable.
+	raw, err := tx.First(TableVariables, indexID, req.Var.Namespace, req.Var.Path)
+	if err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("variable lookup failed: %v", err))
+	}
+
+	// Remove the lock of the current holder, so the variable can be written
+	// with the new lock.
+	if sv, ok := raw.(*structs.VariableEncrypted); ok && sv.Lock != nil {
+		unlocked := sv.Copy()
+		unlocked.Lock = nil
+		if err := tx.Insert(TableVariables, &unlocked); err != nil {
+			return req.ErrorResponse(idx, fmt.Errorf("failed lock steal: %v", err))
+		}
+	}
+
+	req.Var.Lock.FencingToken = idx
+
 	resp := s.varSetTxn(tx, idx, req)
 	if resp.IsError() {
 		return resp
@@ -1007,6 +1007,91 @@ func TestStateStore_AcquireAndReleaseLock(t *testing.T) {
 	})
 }
 
+func TestStateStore_StealLock(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+	ws := memdb.NewWatchSet()
+
+	mv := mock.VariableEncrypted()
+	mv.Path = "thePath"
+	mv.Lock = &structs.VariableLock{
+		ID: "theLockID",
+	}
+
+	resp := testState.VarLockAcquire(20, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpLockAcquire,
+		Var: mv,
+	})
+	must.NoError(t, resp.Error)
+
+	// The holder gets the index at which the lock was acquired as its fencing
+	// token.
+	sve, err := testState.GetVariable(ws, mv.Namespace, mv.Path)
+	must.NoError(t, err)
+	must.Eq(t, 20, sve.Lock.FencingToken)
+
+	// Writing the variable while holding the lock keeps the fencing token.
+	update := sve.Copy()
+	update.Lock = &structs.VariableLock{ID: "theLockID"}
+	resp = testState.VarSet(30, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpSet,
+		Var: &update,
+	})
+	must.NoError(t, resp.Error)
+
+	sve, err = testState.GetVariable(ws, mv.Namespace, mv.Path)
+	must.NoError(t, err)
+	must.Eq(t, "theLockID", sve.Lock.ID)
+	must.Eq(t, 20, sve.Lock.FencingToken)
+
+	// Stealing the lock replaces the holder and issues a new fencing token.
+	stolen := sve.Copy()
+	stolen.Lock = &structs.VariableLock{ID: "aDifferentLockID"}
+	resp = testState.VarLockSteal(40, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpLockSteal,
+		Var: &stolen,
+	})
+	must.NoError(t, resp.Error)
+	must.Eq(t, structs.VarOpResultOk, resp.Result)
+
+	sve, err = testState.GetVariable(ws, mv.Namespace, mv.Path)
+	must.NoError(t, err)
+	must.Eq(t, "aDifferentLockID", sve.Lock.ID)
+	must.Eq(t, 40, sve.Lock.FencingToken)
+	must.Eq(t, 20, sve.CreateIndex)
+	must.Eq(t, 40, sve.ModifyIndex)
+	must.Eq(t, mv.Data, sve.Data)
+
+	// The previous holder can no longer release the lock.
+	released := sve.Copy()
+	released.Lock = &structs.VariableLock{ID: "theLockID"}
+	resp = testState.VarLockRelease(50, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpLockRelease,
+		Var: &released,
+	})
+	must.NoError(t, resp.Error)
+	must.Eq(t, structs.VarOpResultConflict, resp.Result)
+
+	sve, err = testState.GetVariable(ws, mv.Namespace, mv.Path)
+	must.NoError(t, err)
+	must.Eq(t, "aDifferentLockID", sve.Lock.ID)
+	must.Eq(t, 40, sve.Lock.FencingToken)
+
+	// Stealing a lock on a missing variable creates it.
+	missing := mock.VariableEncrypted()
+	missing.Lock = &structs.VariableLock{ID: "theLockID"}
+	resp = testState.VarLockSteal(60, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpLockSteal,
+		Var: missing,
+	})
+	must.NoError(t, resp.Error)
+
+	sve, err = testState.GetVariable(ws, missing.Namespace, missing.Path)
+	must.NoError(t, err)
+	must.NotNil(t, sve)
+	must.Eq(t, 60, sve.Lock.FencingToken)
+}
+
 func TestStateStore_ReleaseLock(t *testing.T) {
 	ci.Parallel(t)
 	testState := testStateStore(t)
@@ -107,6 +107,10 @@ func (e *EventBroker) Publish(events *structs.Events) {
 // set and the index is no longer in the buffer or not yet in the buffer an error
 // will be returned.
 //
+// A Subscription resuming after ResumeIndex starts with the events published
+// after that index. ErrResumeIndexNotInBuffer is returned if some of these
+// events may have been dropped from the buffer already.
+//
 // When a caller is finished with the subscription it must call Subscription.Unsubscribe
 // to free ACL tracking resources.
 func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
@@ -115,9 +119,19 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 
 	var head *bufferItem
 	var offset int
-	if req.Index != 0 {
+	switch {
+	case req.ResumeIndex != 0:
+		head, _ = e.eventBuf.StartAtClosest(req.ResumeIndex)
+
+		// Events are dropped from the buffer concurrently, so only check once
+		// the starting point has been found that none of the events after the
+		// resume index were dropped before it.
+		if e.eventBuf.DroppedIndex() > req.ResumeIndex {
+			return nil, ErrResumeIndexNotInBuffer
+		}
+	case req.Index != 0:
 		head, offset = e.eventBuf.StartAtClosest(req.Index)
-	} else {
+	default:
 		head = e.eventBuf.Head()
 	}
 	if offset > 0 && req.StartExactlyAtIndex {
@@ -68,6 +68,77 @@ func TestEventBroker_PublishChangesAndSubscribe(t *testing.T) {
 	require.Equal(t, expected, result.Events)
 }
 
+func TestEventBroker_Subscribe_Resume(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	publisher, err := NewEventBroker(ctx, EventBrokerCfg{EventBufferSize: 3})
+	require.NoError(t, err)
+
+	publish := func(index uint64) {
+		publisher.Publish(&structs.Events{Index: index, Events: []structs.Event{{
+			Index: index,
+			Topic: "Test",
+			Key:   "sub-key",
+		}}})
+	}
+	allTopics := map[structs.Topic][]string{structs.TopicAll: {"*"}}
+
+	for _, index := range []uint64{2, 4, 6} {
+		publish(index)
+	}
+	require.Eventually(t, func() bool {
+		return publisher.Len() == 3
+	}, time.Second, 10*time.Millisecond)
+
+	// Resuming after an index in the buffer starts with the following events
+	sub, err := publisher.Subscribe(&SubscribeRequest{Topics: allTopics, ResumeIndex: 4})
+	require.NoError(t, err)
+	next, err := sub.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), next.Index)
+	sub.Unsubscribe()
+
+	// Resuming after an index that was never published is fine as long as
+	// nothing was dropped from the buffer after it
+	sub, err = publisher.Subscribe(&SubscribeRequest{Topics: allTopics, ResumeIndex: 3})
+	require.NoError(t, err)
+	next, err = sub.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), next.Index)
+	sub.Unsubscribe()
+
+	// Resuming after the latest index waits for new events
+	sub, err = publisher.Subscribe(&SubscribeRequest{Topics: allTopics, ResumeIndex: 6})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	eventCh := consumeSubscription(ctx, sub)
+	assertNoResult(t, eventCh)
+
+	publish(8)
+	result := nextResult(t, eventCh)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(8), result.Events[0].Index)
+
+	// Dropping the events at index 2 only fails resuming before them
+	publish(10)
+	result = nextResult(t, eventCh)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(10), result.Events[0].Index)
+
+	sub2, err := publisher.Subscribe(&SubscribeRequest{Topics: allTopics, ResumeIndex: 2})
+	require.NoError(t, err)
+	next, err = sub2.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), next.Index)
+	sub2.Unsubscribe()
+
+	_, err = publisher.Subscribe(&SubscribeRequest{Topics: allTopics, ResumeIndex: 1})
+	require.ErrorIs(t, err, ErrResumeIndexNotInBuffer)
+}
+
 func TestEventBroker_ShutdownClosesSubscriptions(t *testing.T) {
 	ci.Parallel(t)
 
@@ -48,6 +48,10 @@ import (
 type eventBuffer struct {
 	size *int64
 
+	// droppedIndex is the index of the most recent events dropped from the
+	// buffer, or 0 if no events have been dropped yet.
+	droppedIndex *uint64
+
 	head atomic.Value
 	tail atomic.Value
 
@@ -58,8 +62,9 @@ type eventBuffer struct {
 func newEventBuffer(size int64) *eventBuffer {
 	zero := int64(0)
 	b := &eventBuffer{
-		maxSize: size,
-		size:    &zero,
+		maxSize:      size,
+		size:         &zero,
+		droppedIndex: new(uint64),
 	}
 
 	item := newBufferItem(&structs.Events{Index: 0, Events: nil})
@@ -118,6 +123,9 @@ func (b *eventBuffer) advanceHead() {
 
 	// notify readers that old is being dropped
 	close(old.link.droppedCh)
+	if old.Events != nil && old.Events.Index > 0 {
+		atomic.StoreUint64(b.droppedIndex, old.Events.Index)
+	}
 
 	// store the next value to head
 	b.head.Store(next)
@@ -181,6 +189,12 @@ func (b *eventBuffer) StartAtClosest(index uint64) (*bufferItem, int) {
 	}
 }
 
+// DroppedIndex returns the index of the most recent events dropped from the
+// buffer, or 0 if the buffer never dropped any events.
+func (b *eventBuffer) DroppedIndex() uint64 {
+	return atomic.LoadUint64(b.droppedIndex)
+}
+
 // Len returns the current length of the buffer
 func (b *eventBuffer) Len() int {
 	return int(atomic.LoadInt64(b.size))
@@ -27,6 +27,11 @@ const (
 // closed. The client should Unsubscribe, then re-Subscribe.
 var ErrSubscriptionClosed = errors.New("subscription closed by server, client should resubscribe")
 
+// ErrResumeIndexNotInBuffer is the error returned when resuming a subscription
+// after an index whose following events have been dropped from the buffer, so
+// that the subscription can't be resumed without missing events.
+var ErrResumeIndexNotInBuffer = errors.New("events after the resume index are no longer in the buffer")
+
 type Subscription struct {
 	// state must be accessed atomically 0 means open, 1 means closed with reload
 	state uint32
@@ -61,6 +66,11 @@ type SubscribeRequest struct {
 	// an exact match
 	StartExactlyAtIndex bool
 
+	// ResumeIndex is the index of the last events delivered to a subscriber
+	// resuming a previous subscription. If set, the subscription starts with
+	// the events published after this index and Index is ignored.
+	ResumeIndex uint64
+
 	// Authenticate is a callback that authenticates the token
 	// associated with the SubscribeRequest has not expired and
 	// has the correct permissions
@@ -91,6 +101,11 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 		}
 		s.currentItem = next
 
+		// Skip the events already delivered before resuming
+		if next.Events.Index <= s.req.ResumeIndex {
+			continue
+		}
+
 		events := filter(s.req, next.Events.Events)
 		if len(events) == 0 {
 			continue
@@ -111,6 +126,11 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 		}
 		s.currentItem = next
 
+		// Skip the events already delivered before resuming
+		if next.Events.Index <= s.req.ResumeIndex {
+			continue
+		}
+
 		events := filter(s.req, next.Events.Events)
 		if len(events) == 0 {
 			continue
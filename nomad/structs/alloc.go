@@ -107,3 +107,76 @@ type AllocInfo struct {
 	// nomad namespace caused this registration.
 	Namespace string
 }
+
+// AllocTimestamps records when an allocation went through the steps of its
+// placement and startup, so that placement and startup latencies can be
+// measured. The times are stored as UnixNano and are zero until the step is
+// reached.
+type AllocTimestamps struct {
+	// EvalCreateTime is the time the evaluation that placed the allocation
+	// was created.
+	EvalCreateTime int64
+
+	// PlanSubmitTime is the time the plan placing the allocation was
+	// submitted by the scheduler.
+	PlanSubmitTime int64
+
+	// PlanApplyTime is the time the plan placing the allocation was applied
+	// by the leader.
+	PlanApplyTime int64
+
+	// ClientReceiveTime is the time the client received the allocation.
+	ClientReceiveTime int64
+
+	// TaskStartTime is the time the first task of the allocation started.
+	TaskStartTime int64
+
+	// FirstHealthyTime is the time the allocation was first reported
+	// healthy. It is only set for allocations whose health is tracked by a
+	// deployment.
+	FirstHealthyTime int64
+}
+
+func (t *AllocTimestamps) Copy() *AllocTimestamps {
+	if t == nil {
+		return nil
+	}
+	nt := *t
+	return &nt
+}
+
+// ClientUpdatedTimestamps returns the timestamps of the allocation once
+// updated by the client with the given allocation, or nil if none is known.
+// The timestamps already set are kept.
+func (a *Allocation) ClientUpdatedTimestamps(update *Allocation) *AllocTimestamps {
+	ts := a.Timestamps.Copy()
+	if ts == nil {
+		ts = &AllocTimestamps{}
+	}
+
+	if ts.ClientReceiveTime == 0 && update.Timestamps != nil {
+		ts.ClientReceiveTime = update.Timestamps.ClientReceiveTime
+	}
+
+	if ts.TaskStartTime == 0 {
+		for _, state := range update.TaskStates {
+			if state == nil || state.StartedAt.IsZero() {
+				continue
+			}
+			started := state.StartedAt.UnixNano()
+			if ts.TaskStartTime == 0 || started < ts.TaskStartTime {
+				ts.TaskStartTime = started
+			}
+		}
+	}
+
+	if ts.FirstHealthyTime == 0 && update.DeploymentStatus.IsHealthy() &&
+		!update.DeploymentStatus.Timestamp.IsZero() {
+		ts.FirstHealthyTime = update.DeploymentStatus.Timestamp.UnixNano()
+	}
+
+	if *ts == (AllocTimestamps{}) {
+		return nil
+	}
+	return ts
+}
@@ -640,3 +640,62 @@ func TestAllocation_Expired_Disconnected(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocation_ClientUpdatedTimestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	alloc := &Allocation{
+		Timestamps: &AllocTimestamps{EvalCreateTime: 1, PlanApplyTime: 2},
+	}
+
+	// Timestamps are derived from the client update
+	update := &Allocation{
+		Timestamps: &AllocTimestamps{ClientReceiveTime: 3},
+		TaskStates: map[string]*TaskState{
+			"sidecar": {State: TaskStatePending},
+			"web":     {State: TaskStateRunning, StartedAt: now.Add(time.Second)},
+			"init":    {State: TaskStateDead, StartedAt: now},
+		},
+		DeploymentStatus: &AllocDeploymentStatus{
+			Healthy:   pointer.Of(true),
+			Timestamp: now.Add(2 * time.Second),
+		},
+	}
+	ts := alloc.ClientUpdatedTimestamps(update)
+	must.Eq(t, &AllocTimestamps{
+		EvalCreateTime:    1,
+		PlanApplyTime:     2,
+		ClientReceiveTime: 3,
+		TaskStartTime:     now.UnixNano(),
+		FirstHealthyTime:  now.Add(2 * time.Second).UnixNano(),
+	}, ts)
+
+	// The allocation is not modified
+	must.Eq(t, &AllocTimestamps{EvalCreateTime: 1, PlanApplyTime: 2}, alloc.Timestamps)
+
+	// Timestamps already set are kept
+	alloc.Timestamps = ts
+	update = &Allocation{
+		Timestamps: &AllocTimestamps{ClientReceiveTime: 4},
+		TaskStates: map[string]*TaskState{
+			"web": {State: TaskStateRunning, StartedAt: now.Add(time.Hour)},
+		},
+		DeploymentStatus: &AllocDeploymentStatus{
+			Healthy:   pointer.Of(true),
+			Timestamp: now.Add(time.Hour),
+		},
+	}
+	must.Eq(t, ts, alloc.ClientUpdatedTimestamps(update))
+
+	// Allocations without timestamps, such as allocations placed by older
+	// servers, only get the timestamps known by the client
+	alloc.Timestamps = nil
+	update = &Allocation{
+		DeploymentStatus: &AllocDeploymentStatus{Healthy: pointer.Of(false), Timestamp: now},
+	}
+	must.Nil(t, alloc.ClientUpdatedTimestamps(update))
+
+	update.Timestamps = &AllocTimestamps{ClientReceiveTime: 3}
+	must.Eq(t, &AllocTimestamps{ClientReceiveTime: 3}, alloc.ClientUpdatedTimestamps(update))
+}
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+// AppliedPlan records that the results of a plan were applied. A plan that is
+// resubmitted after an error that left its outcome unknown, such as a Raft
+// timeout, may be written to Raft twice. The FSM ignores the results of plans
+// that were already applied so that deployment status updates, preemptions,
+// and follow up evaluations are only applied once.
+type AppliedPlan struct {
+	// PlanID is the ID of the applied plan.
+	PlanID string
+
+	// EvalID is the ID of the evaluation that submitted the plan. The record
+	// is deleted along with the evaluation.
+	EvalID string
+
+	// AppliedIndex is the Raft index at which the plan was applied.
+	AppliedIndex uint64
+}
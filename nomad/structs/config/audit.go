@@ -34,7 +34,7 @@ type AuditSink struct {
 	// be met in order to successfully make requests
 	DeliveryGuarantee string `hcl:"delivery_guarantee"`
 
-	// Type is the sink type to configure. (file)
+	// Type is the sink type to configure. (file, syslog)
 	Type string `hcl:"type"`
 
 	// Format is the sink output format. (json)
@@ -56,6 +56,14 @@ type AuditSink struct {
 
 	// Mode is the octal formatted permissions for the audit log files.
 	Mode string `hcl:"mode"`
+
+	// Facility is the syslog facility audit logs are written to when the
+	// sink type is syslog.
+	Facility string `hcl:"facility"`
+
+	// Tag is the syslog tag audit logs are written with when the sink type
+	// is syslog.
+	Tag string `hcl:"tag"`
 }
 
 // AuditFilter is the configuration for a Audit Log Filter
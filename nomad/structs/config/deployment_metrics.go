@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// DeploymentMetricsConfig configures the metric provider the leader queries
+// to evaluate the rollback_on block of a job's update strategy.
+type DeploymentMetricsConfig struct {
+	// PrometheusAddress is the http or https address of the Prometheus
+	// server that rollback queries are sent to.
+	PrometheusAddress string `hcl:"prometheus_address"`
+}
+
+// Copy returns a new copy of a DeploymentMetricsConfig
+func (d *DeploymentMetricsConfig) Copy() *DeploymentMetricsConfig {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(DeploymentMetricsConfig)
+	*nd = *d
+	return nd
+}
+
+// Merge returns a new DeploymentMetricsConfig with the values of o taking
+// precedence over those of d.
+func (d *DeploymentMetricsConfig) Merge(o *DeploymentMetricsConfig) *DeploymentMetricsConfig {
+	switch {
+	case d == nil:
+		return o.Copy()
+	case o == nil:
+		return d.Copy()
+	default:
+		nd := d.Copy()
+		if o.PrometheusAddress != "" {
+			nd.PrometheusAddress = o.PrometheusAddress
+		}
+		return nd
+	}
+}
+
+// Validate returns an error if the metric provider is misconfigured.
+func (d *DeploymentMetricsConfig) Validate() error {
+	if d == nil || d.PrometheusAddress == "" {
+		return nil
+	}
+
+	u, err := url.Parse(d.PrometheusAddress)
+	if err != nil {
+		return fmt.Errorf("deployment metrics prometheus_address is invalid: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("deployment metrics prometheus_address must use the http or https scheme")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDeploymentMetricsConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *DeploymentMetricsConfig
+	a := &DeploymentMetricsConfig{PrometheusAddress: "http://a:9090"}
+	b := &DeploymentMetricsConfig{PrometheusAddress: "http://b:9090"}
+
+	must.Eq(t, a, nilConfig.Merge(a))
+	must.Eq(t, a, a.Merge(nilConfig))
+	must.Eq(t, b, a.Merge(b))
+	must.Eq(t, a, a.Merge(&DeploymentMetricsConfig{}))
+}
+
+func TestDeploymentMetricsConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *DeploymentMetricsConfig
+	must.NoError(t, nilConfig.Validate())
+	must.NoError(t, (&DeploymentMetricsConfig{}).Validate())
+	must.NoError(t, (&DeploymentMetricsConfig{PrometheusAddress: "https://prometheus:9090"}).Validate())
+	must.ErrorContains(t, (&DeploymentMetricsConfig{PrometheusAddress: "prometheus:9090"}).Validate(),
+		"must use the http or https scheme")
+}
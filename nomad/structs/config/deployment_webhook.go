@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+const (
+	// DeploymentWebhookEventRunning is sent when a deployment starts running
+	// or resumes running after being paused or promoted.
+	DeploymentWebhookEventRunning = "running"
+
+	// DeploymentWebhookEventPromotionRequired is sent when a deployment is
+	// running but is blocked waiting for its canaries to be promoted.
+	DeploymentWebhookEventPromotionRequired = "promotion_required"
+
+	// DeploymentWebhookEventFailed is sent when a deployment fails.
+	DeploymentWebhookEventFailed = "failed"
+
+	// DeploymentWebhookEventSuccessful is sent when a deployment completes
+	// successfully.
+	DeploymentWebhookEventSuccessful = "successful"
+)
+
+// DeploymentWebhookEvents is the set of events a deployment webhook can
+// subscribe to.
+var DeploymentWebhookEvents = []string{
+	DeploymentWebhookEventRunning,
+	DeploymentWebhookEventPromotionRequired,
+	DeploymentWebhookEventFailed,
+	DeploymentWebhookEventSuccessful,
+}
+
+// DeploymentWebhookConfig configures a URL the leader notifies of deployment
+// status transitions.
+type DeploymentWebhookConfig struct {
+	// Name is a unique name given to the webhook
+	Name string `hcl:",key"`
+
+	// URL is the http or https endpoint the notifications are POSTed to.
+	URL string `hcl:"url"`
+
+	// Secret is used to sign the notification body with HMAC-SHA256. The
+	// signature is sent in the X-Nomad-Signature header. If empty, the
+	// notifications are not signed.
+	Secret string `hcl:"secret"`
+
+	// Events is the list of events to send to the webhook. If empty, all
+	// events are sent.
+	Events []string `hcl:"events"`
+}
+
+// Copy returns a new copy of a DeploymentWebhookConfig
+func (d *DeploymentWebhookConfig) Copy() *DeploymentWebhookConfig {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(DeploymentWebhookConfig)
+	*nd = *d
+	nd.Events = slices.Clone(d.Events)
+	return nd
+}
+
+// Validate returns an error if the webhook is misconfigured.
+func (d *DeploymentWebhookConfig) Validate() error {
+	if d.Name == "" {
+		return errors.New("deployment webhook name must not be empty")
+	}
+
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return fmt.Errorf("deployment webhook %q has an invalid url: %w", d.Name, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("deployment webhook %q url must use the http or https scheme", d.Name)
+	}
+
+	for _, event := range d.Events {
+		if !slices.Contains(DeploymentWebhookEvents, event) {
+			return fmt.Errorf("deployment webhook %q has unknown event %q", d.Name, event)
+		}
+	}
+
+	return nil
+}
+
+// Wants returns whether the webhook is subscribed to the given event.
+func (d *DeploymentWebhookConfig) Wants(event string) bool {
+	return len(d.Events) == 0 || slices.Contains(d.Events, event)
+}
+
+// CopyDeploymentWebhooks returns a deep copy of the webhooks.
+func CopyDeploymentWebhooks(a []*DeploymentWebhookConfig) []*DeploymentWebhookConfig {
+	if len(a) == 0 {
+		return nil
+	}
+
+	ns := make([]*DeploymentWebhookConfig, len(a))
+	for idx, cfg := range a {
+		ns[idx] = cfg.Copy()
+	}
+
+	return ns
+}
+
+// MergeDeploymentWebhooks merges two sets of webhooks. Webhooks in b replace
+// the webhooks in a with the same name.
+func MergeDeploymentWebhooks(a, b []*DeploymentWebhookConfig) []*DeploymentWebhookConfig {
+	n := CopyDeploymentWebhooks(a)
+	seenKeys := make(map[string]int, len(a))
+
+	for i, config := range n {
+		seenKeys[config.Name] = i
+	}
+
+	for _, config := range b {
+		if idx, ok := seenKeys[config.Name]; ok {
+			n[idx] = config.Copy()
+			continue
+		}
+
+		seenKeys[config.Name] = len(n)
+		n = append(n, config.Copy())
+	}
+
+	return n
+}
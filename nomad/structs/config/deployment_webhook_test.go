@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDeploymentWebhookConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		config *DeploymentWebhookConfig
+		expErr string
+	}{
+		{
+			name: "valid",
+			config: &DeploymentWebhookConfig{
+				Name:   "ci",
+				URL:    "https://ci.example.com/hooks/nomad",
+				Events: []string{"failed", "successful"},
+			},
+		},
+		{
+			name:   "missing name",
+			config: &DeploymentWebhookConfig{URL: "https://ci.example.com"},
+			expErr: "name must not be empty",
+		},
+		{
+			name:   "bad scheme",
+			config: &DeploymentWebhookConfig{Name: "ci", URL: "ftp://ci.example.com"},
+			expErr: "must use the http or https scheme",
+		},
+		{
+			name: "unknown event",
+			config: &DeploymentWebhookConfig{
+				Name:   "ci",
+				URL:    "http://ci.example.com",
+				Events: []string{"paused"},
+			},
+			expErr: `unknown event "paused"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestMergeDeploymentWebhooks(t *testing.T) {
+	ci.Parallel(t)
+
+	a := []*DeploymentWebhookConfig{
+		{Name: "ci", URL: "http://ci.example.com"},
+		{Name: "chat", URL: "http://chat.example.com"},
+	}
+	b := []*DeploymentWebhookConfig{
+		{Name: "chat", URL: "https://chat.example.com", Events: []string{"failed"}},
+		{Name: "pager", URL: "https://pager.example.com"},
+	}
+
+	result := MergeDeploymentWebhooks(a, b)
+	must.Eq(t, []*DeploymentWebhookConfig{
+		{Name: "ci", URL: "http://ci.example.com"},
+		{Name: "chat", URL: "https://chat.example.com", Events: []string{"failed"}},
+		{Name: "pager", URL: "https://pager.example.com"},
+	}, result)
+
+	// The inputs are not modified
+	must.Eq(t, "http://chat.example.com", a[1].URL)
+}
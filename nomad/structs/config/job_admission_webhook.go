@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// JobAdmissionWebhookFailPolicyFail rejects job registrations when the
+	// webhook can't be reached or returns an invalid response.
+	JobAdmissionWebhookFailPolicyFail = "fail"
+
+	// JobAdmissionWebhookFailPolicyIgnore admits job registrations unchanged
+	// when the webhook can't be reached or returns an invalid response.
+	JobAdmissionWebhookFailPolicyIgnore = "ignore"
+
+	// DefaultJobAdmissionWebhookTimeout is the default timeout of a webhook
+	// request.
+	DefaultJobAdmissionWebhookTimeout = 10 * time.Second
+)
+
+// JobAdmissionWebhookConfig configures an external service that servers call
+// on job registration to mutate or reject the job before it's written to
+// state.
+type JobAdmissionWebhookConfig struct {
+	// URL is the http or https endpoint the jobs are POSTed to.
+	URL string `hcl:"url"`
+
+	// TLS configures the TLS connection to an https URL.
+	TLS *JobAdmissionWebhookTLSConfig `hcl:"tls"`
+
+	// Timeout is the timeout of a webhook request.
+	Timeout    time.Duration `hcl:"-"`
+	TimeoutHCL string        `hcl:"timeout" json:"-"`
+
+	// FailPolicy is the behavior when the webhook can't be reached or
+	// returns an invalid response, either "fail" or "ignore".
+	FailPolicy string `hcl:"fail_policy"`
+}
+
+// JobAdmissionWebhookTLSConfig configures the TLS connection to a job
+// admission webhook.
+type JobAdmissionWebhookTLSConfig struct {
+	// CAFile is the path to the CA certificate used to verify the webhook's
+	// certificate. The system CAs are used if empty.
+	CAFile string `hcl:"ca_file"`
+
+	// CertFile and KeyFile are the paths to the client certificate and key
+	// presented to the webhook.
+	CertFile string `hcl:"cert_file"`
+	KeyFile  string `hcl:"key_file"`
+
+	// InsecureSkipVerify disables the verification of the webhook's
+	// certificate.
+	InsecureSkipVerify bool `hcl:"insecure_skip_verify"`
+}
+
+// Copy returns a new copy of a JobAdmissionWebhookTLSConfig
+func (t *JobAdmissionWebhookTLSConfig) Copy() *JobAdmissionWebhookTLSConfig {
+	if t == nil {
+		return nil
+	}
+
+	nt := new(JobAdmissionWebhookTLSConfig)
+	*nt = *t
+	return nt
+}
+
+// Merge returns a new JobAdmissionWebhookTLSConfig with the values of o
+// taking precedence over those of t.
+func (t *JobAdmissionWebhookTLSConfig) Merge(o *JobAdmissionWebhookTLSConfig) *JobAdmissionWebhookTLSConfig {
+	switch {
+	case t == nil:
+		return o.Copy()
+	case o == nil:
+		return t.Copy()
+	default:
+		nt := t.Copy()
+		if o.CAFile != "" {
+			nt.CAFile = o.CAFile
+		}
+		if o.CertFile != "" {
+			nt.CertFile = o.CertFile
+		}
+		if o.KeyFile != "" {
+			nt.KeyFile = o.KeyFile
+		}
+		if o.InsecureSkipVerify {
+			nt.InsecureSkipVerify = true
+		}
+		return nt
+	}
+}
+
+// Copy returns a new copy of a JobAdmissionWebhookConfig
+func (j *JobAdmissionWebhookConfig) Copy() *JobAdmissionWebhookConfig {
+	if j == nil {
+		return nil
+	}
+
+	nj := new(JobAdmissionWebhookConfig)
+	*nj = *j
+	nj.TLS = j.TLS.Copy()
+	return nj
+}
+
+// Merge returns a new JobAdmissionWebhookConfig with the values of o taking
+// precedence over those of j.
+func (j *JobAdmissionWebhookConfig) Merge(o *JobAdmissionWebhookConfig) *JobAdmissionWebhookConfig {
+	switch {
+	case j == nil:
+		return o.Copy()
+	case o == nil:
+		return j.Copy()
+	default:
+		nj := j.Copy()
+		if o.URL != "" {
+			nj.URL = o.URL
+		}
+		nj.TLS = j.TLS.Merge(o.TLS)
+		if o.Timeout != 0 {
+			nj.Timeout = o.Timeout
+		}
+		if o.TimeoutHCL != "" {
+			nj.TimeoutHCL = o.TimeoutHCL
+		}
+		if o.FailPolicy != "" {
+			nj.FailPolicy = o.FailPolicy
+		}
+		return nj
+	}
+}
+
+// Canonicalize sets the defaults of the unset fields.
+func (j *JobAdmissionWebhookConfig) Canonicalize() {
+	if j == nil {
+		return
+	}
+	if j.Timeout == 0 {
+		j.Timeout = DefaultJobAdmissionWebhookTimeout
+	}
+	if j.FailPolicy == "" {
+		j.FailPolicy = JobAdmissionWebhookFailPolicyFail
+	}
+}
+
+// Validate returns an error if the job admission webhook is misconfigured.
+func (j *JobAdmissionWebhookConfig) Validate() error {
+	if j == nil {
+		return nil
+	}
+
+	u, err := url.Parse(j.URL)
+	if err != nil {
+		return fmt.Errorf("job admission webhook url is invalid: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("job admission webhook url must use the http or https scheme")
+	}
+	if j.Timeout < 0 {
+		return errors.New("job admission webhook timeout must not be negative")
+	}
+
+	switch j.FailPolicy {
+	case "", JobAdmissionWebhookFailPolicyFail, JobAdmissionWebhookFailPolicyIgnore:
+	default:
+		return fmt.Errorf("job admission webhook fail_policy must be %q or %q",
+			JobAdmissionWebhookFailPolicyFail, JobAdmissionWebhookFailPolicyIgnore)
+	}
+
+	if j.TLS != nil && (j.TLS.CertFile == "") != (j.TLS.KeyFile == "") {
+		return errors.New("job admission webhook tls cert_file and key_file must be set together")
+	}
+
+	return nil
+}
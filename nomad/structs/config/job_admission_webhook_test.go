@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobAdmissionWebhookConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *JobAdmissionWebhookConfig
+	a := &JobAdmissionWebhookConfig{
+		URL:     "https://a.example.com/admit",
+		TLS:     &JobAdmissionWebhookTLSConfig{CAFile: "ca.pem"},
+		Timeout: time.Second,
+	}
+	b := &JobAdmissionWebhookConfig{
+		URL:        "https://b.example.com/admit",
+		TLS:        &JobAdmissionWebhookTLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"},
+		FailPolicy: JobAdmissionWebhookFailPolicyIgnore,
+	}
+
+	must.Eq(t, a, nilConfig.Merge(a))
+	must.Eq(t, a, a.Merge(nilConfig))
+	must.Eq(t, &JobAdmissionWebhookConfig{
+		URL: "https://b.example.com/admit",
+		TLS: &JobAdmissionWebhookTLSConfig{
+			CAFile:   "ca.pem",
+			CertFile: "cert.pem",
+			KeyFile:  "key.pem",
+		},
+		Timeout:    time.Second,
+		FailPolicy: JobAdmissionWebhookFailPolicyIgnore,
+	}, a.Merge(b))
+}
+
+func TestJobAdmissionWebhookConfig_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &JobAdmissionWebhookConfig{URL: "https://example.com/admit"}
+	c.Canonicalize()
+	must.Eq(t, DefaultJobAdmissionWebhookTimeout, c.Timeout)
+	must.Eq(t, JobAdmissionWebhookFailPolicyFail, c.FailPolicy)
+}
+
+func TestJobAdmissionWebhookConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *JobAdmissionWebhookConfig
+	must.NoError(t, nilConfig.Validate())
+
+	valid := &JobAdmissionWebhookConfig{
+		URL:        "https://example.com/admit",
+		TLS:        &JobAdmissionWebhookTLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"},
+		Timeout:    time.Second,
+		FailPolicy: JobAdmissionWebhookFailPolicyIgnore,
+	}
+	must.NoError(t, valid.Validate())
+
+	invalid := valid.Copy()
+	invalid.URL = "example.com/admit"
+	must.ErrorContains(t, invalid.Validate(), "must use the http or https scheme")
+
+	invalid = valid.Copy()
+	invalid.Timeout = -time.Second
+	must.ErrorContains(t, invalid.Validate(), "timeout must not be negative")
+
+	invalid = valid.Copy()
+	invalid.FailPolicy = "allow"
+	must.ErrorContains(t, invalid.Validate(), "fail_policy must be")
+
+	invalid = valid.Copy()
+	invalid.TLS.KeyFile = ""
+	must.ErrorContains(t, invalid.Validate(), "must be set together")
+}
@@ -43,16 +43,27 @@ type Limits struct {
 	// RPCMaxConnsPerClient is the maximum number of concurrent RPC
 	// connections from a single IP address. nil/0 means no limit.
 	RPCMaxConnsPerClient *int `hcl:"rpc_max_conns_per_client"`
+
+	// BlockingQueriesPerToken is the maximum number of concurrent blocking
+	// queries a server serves for a single ACL token, workload identity or
+	// client node. nil/0 means no limit.
+	BlockingQueriesPerToken *int `hcl:"blocking_queries_per_token"`
+
+	// BlockingQueriesPerIP is the maximum number of concurrent blocking
+	// queries a server serves for a single IP address. nil/0 means no limit.
+	BlockingQueriesPerIP *int `hcl:"blocking_queries_per_ip"`
 }
 
 // DefaultLimits returns the default limits values. User settings should be
 // merged into these defaults.
 func DefaultLimits() Limits {
 	return Limits{
-		HTTPSHandshakeTimeout: "5s",
-		HTTPMaxConnsPerClient: pointer.Of(100),
-		RPCHandshakeTimeout:   "5s",
-		RPCMaxConnsPerClient:  pointer.Of(100),
+		HTTPSHandshakeTimeout:   "5s",
+		HTTPMaxConnsPerClient:   pointer.Of(100),
+		RPCHandshakeTimeout:     "5s",
+		RPCMaxConnsPerClient:    pointer.Of(100),
+		BlockingQueriesPerToken: pointer.Of(0),
+		BlockingQueriesPerIP:    pointer.Of(0),
 	}
 }
 
@@ -73,6 +84,12 @@ func (l *Limits) Merge(o Limits) Limits {
 	if o.RPCMaxConnsPerClient != nil {
 		m.RPCMaxConnsPerClient = pointer.Of(*o.RPCMaxConnsPerClient)
 	}
+	if o.BlockingQueriesPerToken != nil {
+		m.BlockingQueriesPerToken = pointer.Of(*o.BlockingQueriesPerToken)
+	}
+	if o.BlockingQueriesPerIP != nil {
+		m.BlockingQueriesPerIP = pointer.Of(*o.BlockingQueriesPerIP)
+	}
 
 	return m
 }
@@ -86,5 +103,11 @@ func (l *Limits) Copy() Limits {
 	if l.RPCMaxConnsPerClient != nil {
 		c.RPCMaxConnsPerClient = pointer.Of(*l.RPCMaxConnsPerClient)
 	}
+	if l.BlockingQueriesPerToken != nil {
+		c.BlockingQueriesPerToken = pointer.Of(*l.BlockingQueriesPerToken)
+	}
+	if l.BlockingQueriesPerIP != nil {
+		c.BlockingQueriesPerIP = pointer.Of(*l.BlockingQueriesPerIP)
+	}
 	return c
 }
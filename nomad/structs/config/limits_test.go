@@ -38,6 +38,7 @@ func TestLimits_Copy(t *testing.T) {
 	c.HTTPMaxConnsPerClient = pointer.Of(50)
 	c.RPCHandshakeTimeout = "1s"
 	c.RPCMaxConnsPerClient = pointer.Of(50)
+	c.BlockingQueriesPerToken = pointer.Of(50)
 
 	require.NotEqual(t, c.HTTPSHandshakeTimeout, o.HTTPSHandshakeTimeout)
 
@@ -51,6 +52,11 @@ func TestLimits_Copy(t *testing.T) {
 	require.True(t, c.RPCMaxConnsPerClient != o.RPCMaxConnsPerClient)
 
 	require.NotEqual(t, c.RPCMaxConnsPerClient, o.RPCMaxConnsPerClient)
+
+	// Pointers should be different
+	require.True(t, c.BlockingQueriesPerToken != o.BlockingQueriesPerToken)
+
+	require.NotEqual(t, c.BlockingQueriesPerToken, o.BlockingQueriesPerToken)
 }
 
 // TestLimits_Merge asserts non-zero fields from the method argument take
@@ -77,7 +83,7 @@ func TestLimits_Merge(t *testing.T) {
 
 	// Use short struct initialization style so it fails to compile if
 	// fields are added
-	expected := Limits{"10s", pointer.Of(100), "5s", pointer.Of(100)}
+	expected := Limits{"10s", pointer.Of(100), "5s", pointer.Of(100), pointer.Of(0), pointer.Of(0)}
 	require.Equal(t, expected, m2)
 
 	// Mergin in 0 values should not change anything
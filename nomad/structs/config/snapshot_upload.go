@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/snapshot"
+)
+
+// SnapshotUploadConfig configures the periodic upload of state snapshots by
+// the leader to object storage.
+type SnapshotUploadConfig struct {
+	// Storage is the s3://bucket/path URL snapshots are uploaded to.
+	Storage string `hcl:"storage"`
+
+	// Interval is the time between two snapshots.
+	Interval    time.Duration `hcl:"-"`
+	IntervalHCL string        `hcl:"interval" json:"-"`
+
+	// Retain is the number of snapshots to keep in storage. Older snapshots
+	// are deleted after each upload. All snapshots are kept when zero.
+	Retain int `hcl:"retain"`
+
+	// Region is the region of the bucket.
+	Region string `hcl:"region"`
+
+	// Endpoint is the address of an S3 compatible storage service, used
+	// instead of AWS S3.
+	Endpoint string `hcl:"endpoint"`
+
+	// SSE is the server-side encryption of uploaded snapshots, either
+	// "AES256" or "aws:kms".
+	SSE string `hcl:"sse"`
+
+	// KMSKeyID is the ID of the KMS key used when SSE is "aws:kms".
+	KMSKeyID string `hcl:"kms_key_id"`
+}
+
+// Copy returns a new copy of a SnapshotUploadConfig
+func (s *SnapshotUploadConfig) Copy() *SnapshotUploadConfig {
+	if s == nil {
+		return nil
+	}
+
+	ns := new(SnapshotUploadConfig)
+	*ns = *s
+	return ns
+}
+
+// Merge returns a new SnapshotUploadConfig with the values of o taking
+// precedence over those of s.
+func (s *SnapshotUploadConfig) Merge(o *SnapshotUploadConfig) *SnapshotUploadConfig {
+	switch {
+	case s == nil:
+		return o.Copy()
+	case o == nil:
+		return s.Copy()
+	default:
+		ns := s.Copy()
+		if o.Storage != "" {
+			ns.Storage = o.Storage
+		}
+		if o.Interval != 0 {
+			ns.Interval = o.Interval
+		}
+		if o.IntervalHCL != "" {
+			ns.IntervalHCL = o.IntervalHCL
+		}
+		if o.Retain != 0 {
+			ns.Retain = o.Retain
+		}
+		if o.Region != "" {
+			ns.Region = o.Region
+		}
+		if o.Endpoint != "" {
+			ns.Endpoint = o.Endpoint
+		}
+		if o.SSE != "" {
+			ns.SSE = o.SSE
+		}
+		if o.KMSKeyID != "" {
+			ns.KMSKeyID = o.KMSKeyID
+		}
+		return ns
+	}
+}
+
+// StorageOptions returns the options of the snapshot storage.
+func (s *SnapshotUploadConfig) StorageOptions() *snapshot.StorageOptions {
+	return &snapshot.StorageOptions{
+		Region:   s.Region,
+		Endpoint: s.Endpoint,
+		SSE:      s.SSE,
+		KMSKeyID: s.KMSKeyID,
+	}
+}
+
+// Validate returns an error if the snapshot upload is misconfigured.
+func (s *SnapshotUploadConfig) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if _, _, err := snapshot.ParseStorageURL(s.Storage); err != nil {
+		return fmt.Errorf("snapshot_upload storage is invalid: %w", err)
+	}
+	if s.Interval < time.Minute {
+		return errors.New("snapshot_upload interval must be at least 1m")
+	}
+	if s.Retain < 0 {
+		return errors.New("snapshot_upload retain must not be negative")
+	}
+	if err := s.StorageOptions().Validate(); err != nil {
+		return fmt.Errorf("snapshot_upload is invalid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSnapshotUploadConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *SnapshotUploadConfig
+	a := &SnapshotUploadConfig{Storage: "s3://a/nomad", Interval: time.Hour, Retain: 24}
+	b := &SnapshotUploadConfig{Storage: "s3://b/nomad", SSE: "AES256"}
+
+	must.Eq(t, a, nilConfig.Merge(a))
+	must.Eq(t, a, a.Merge(nilConfig))
+	must.Eq(t, &SnapshotUploadConfig{
+		Storage:  "s3://b/nomad",
+		Interval: time.Hour,
+		Retain:   24,
+		SSE:      "AES256",
+	}, a.Merge(b))
+}
+
+func TestSnapshotUploadConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *SnapshotUploadConfig
+	must.NoError(t, nilConfig.Validate())
+
+	valid := &SnapshotUploadConfig{
+		Storage:  "s3://backups/nomad",
+		Interval: time.Hour,
+		Retain:   24,
+		SSE:      "aws:kms",
+		KMSKeyID: "alias/nomad",
+	}
+	must.NoError(t, valid.Validate())
+
+	invalid := valid.Copy()
+	invalid.Storage = "backups/nomad"
+	must.ErrorContains(t, invalid.Validate(), "storage is invalid")
+
+	invalid = valid.Copy()
+	invalid.Interval = time.Second
+	must.ErrorContains(t, invalid.Validate(), "interval must be at least 1m")
+
+	invalid = valid.Copy()
+	invalid.Retain = -1
+	must.ErrorContains(t, invalid.Validate(), "retain must not be negative")
+
+	invalid = valid.Copy()
+	invalid.SSE = "AES256"
+	must.ErrorContains(t, invalid.Validate(), "KMS key ID requires")
+}
@@ -3,7 +3,11 @@
 
 package structs
 
-import "maps"
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
 
 // DeviceAccounter is used to account for device usage on a node. It can detect
 // when a node is oversubscribed and can be used for deciding what devices are
@@ -135,6 +139,68 @@ func (d *DeviceAccounter) AddAllocs(allocs []*Allocation) (collision bool) {
 	return
 }
 
+// DeviceCollision is a device instance assigned to more than one allocation.
+type DeviceCollision struct {
+	Device     DeviceIdTuple
+	InstanceID string
+
+	// Allocs are the allocations the instance is assigned to.
+	Allocs []*Allocation
+}
+
+// Collisions returns the device instances of the node that are assigned to
+// more than one of the passed non-terminal allocations, sorted by device and
+// instance ID. Unlike AddAllocs, the accounter is not modified, so usage
+// already marked in the accounter is not taken into account.
+func (d *DeviceAccounter) Collisions(allocs []*Allocation) []*DeviceCollision {
+	type instanceKey struct {
+		device   DeviceIdTuple
+		instance string
+	}
+	assigned := make(map[instanceKey][]*Allocation)
+
+	for _, a := range allocs {
+		if a.ClientTerminalStatus() || a.AllocatedResources == nil {
+			continue
+		}
+
+		for _, tr := range a.AllocatedResources.Tasks {
+			for _, device := range tr.Devices {
+				devID := device.ID()
+				devInst, ok := d.Devices[*devID]
+				if !ok {
+					continue
+				}
+				for _, instanceID := range device.DeviceIDs {
+					if _, ok := devInst.Instances[instanceID]; !ok {
+						continue
+					}
+					key := instanceKey{device: *devID, instance: instanceID}
+					assigned[key] = append(assigned[key], a)
+				}
+			}
+		}
+	}
+
+	var collisions []*DeviceCollision
+	for key, allocs := range assigned {
+		if len(allocs) > 1 {
+			collisions = append(collisions, &DeviceCollision{
+				Device:     key.device,
+				InstanceID: key.instance,
+				Allocs:     allocs,
+			})
+		}
+	}
+	slices.SortFunc(collisions, func(a, b *DeviceCollision) int {
+		return cmp.Or(
+			cmp.Compare(a.Device.String(), b.Device.String()),
+			cmp.Compare(a.InstanceID, b.InstanceID),
+		)
+	})
+	return collisions
+}
+
 // AddReserved marks the device instances in the passed device reservation as
 // used and returns if there is a collision.
 func (d *DeviceAccounter) AddReserved(res *AllocatedDeviceResource) (collision bool) {
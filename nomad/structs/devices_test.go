@@ -166,6 +166,33 @@ func TestDeviceAccounter_AddAllocs_Collision(t *testing.T) {
 	require.True(d.AddAllocs(allocs))
 }
 
+func TestDeviceAccounter_Collisions(t *testing.T) {
+	ci.Parallel(t)
+
+	n := devNode()
+	d := NewDeviceAccounter(n)
+
+	// a1 and a2 share an instance, a3 uses another one and a4 is terminal
+	a1, a2, a3, a4 := nvidiaAlloc(), nvidiaAlloc(), nvidiaAlloc(), nvidiaAlloc()
+	a4.ClientStatus = AllocClientStatusComplete
+
+	nvidiaDev0ID := n.NodeResources.Devices[0].Instances[0].ID
+	nvidiaDev1ID := n.NodeResources.Devices[0].Instances[1].ID
+	a1.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{nvidiaDev0ID}
+	a2.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{nvidiaDev0ID}
+	a3.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{nvidiaDev1ID}
+	a4.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{nvidiaDev1ID}
+
+	collisions := d.Collisions([]*Allocation{a1, a2, a3, a4})
+	must.Len(t, 1, collisions)
+	must.Eq(t, *n.NodeResources.Devices[0].ID(), collisions[0].Device)
+	must.Eq(t, nvidiaDev0ID, collisions[0].InstanceID)
+	must.Eq(t, []*Allocation{a1, a2}, collisions[0].Allocs)
+
+	// The accounter is not modified
+	must.Zero(t, d.Devices[*n.NodeResources.Devices[0].ID()].Instances[nvidiaDev0ID])
+}
+
 // Assert that devices are not freed when an alloc's ServerTerminalStatus is
 // true, but only when ClientTerminalStatus is true.
 func TestDeviceAccounter_AddAllocs_TerminalStatus(t *testing.T) {
@@ -74,7 +74,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	// See agent.ApiJobToStructJob Update is a default for TaskGroups
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
-	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
+	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "Frozen", "CreateIndex",
 		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "VaultToken"}
 
 	if j == nil && other == nil {
@@ -339,8 +339,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -359,6 +358,21 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, scDiff)
 	}
 
+	// ScaleWithNodes diff
+	if swnDiff := primitiveObjectDiff(tg.ScaleWithNodes, other.ScaleWithNodes, nil, "ScaleWithNodes", contextual); swnDiff != nil {
+		diff.Objects = append(diff.Objects, swnDiff)
+	}
+
+	// DisruptionBudget diff
+	if dbDiff := primitiveObjectDiff(tg.DisruptionBudget, other.DisruptionBudget, nil, "DisruptionBudget", contextual); dbDiff != nil {
+		diff.Objects = append(diff.Objects, dbDiff)
+	}
+
+	// PreemptionNotice diff
+	if pnDiff := primitiveObjectDiff(tg.PreemptionNotice, other.PreemptionNotice, nil, "PreemptionNotice", contextual); pnDiff != nil {
+		diff.Objects = append(diff.Objects, pnDiff)
+	}
+
 	// Services diff
 	if sDiffs := serviceDiffs(tg.Services, other.Services, contextual); sDiffs != nil {
 		diff.Objects = append(diff.Objects, sDiffs...)
@@ -2225,6 +2239,16 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 		diff.Objects = append(diff.Objects, requiredDiff)
 	}
 
+	// Meta schema diffs
+	if schemaDiffs := primitiveObjectSetDiff(
+		interfaceSlice(old.MetaSchema),
+		interfaceSlice(new.MetaSchema),
+		nil,
+		"MetaSchema",
+		contextual); schemaDiffs != nil {
+		diff.Objects = append(diff.Objects, schemaDiffs...)
+	}
+
 	return diff
 }
 
@@ -2747,6 +2771,32 @@ func disconectStrategyDiffs(old, new *DisconnectStrategy, contextual bool) *Obje
 	return diff
 }
 
+// updateStrategyDiff returns the diff of an update strategy and its nested
+// rollback metric. If contextual diff is enabled, non-changed fields will still
+// be returned.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	diff := primitiveObjectDiff(old, new, []string{"Stagger"}, "Update", contextual)
+
+	var oldRollbackOn, newRollbackOn *RollbackOn
+	if old != nil {
+		oldRollbackOn = old.RollbackOn
+	}
+	if new != nil {
+		newRollbackOn = new.RollbackOn
+	}
+
+	rDiff := primitiveObjectDiff(oldRollbackOn, newRollbackOn, nil, "RollbackOn", contextual)
+	if rDiff == nil {
+		return diff
+	}
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+	}
+	diff.Objects = append(diff.Objects, rDiff)
+	return diff
+}
+
 // networkResourceDiffs diffs a set of NetworkResources. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func networkResourceDiffs(old, new []*NetworkResource, contextual bool) []*ObjectDiff {
@@ -3047,6 +3097,11 @@ func idDiff(oldWI, newWI *WorkloadIdentity, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, audDiff)
 	}
 
+	metaDiff := stringSetDiff(oldWI.MetaClaims, newWI.MetaClaims, "MetaClaims", contextual)
+	if metaDiff != nil {
+		diff.Objects = append(diff.Objects, metaDiff)
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
 
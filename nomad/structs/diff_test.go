@@ -1554,6 +1554,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  DispatchPayloadRequired,
 								New:  DispatchPayloadOptional,
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "PayloadSchema",
+								Old:  "",
+								New:  "",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
@@ -2815,6 +2821,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "AutoPromoteMinHealthyTime",
+								Old:  "0",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "AutoPromoteThreshold",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "AutoRevert",
@@ -2877,6 +2895,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "AutoPromoteMinHealthyTime",
+								Old:  "",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "AutoPromoteThreshold",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "AutoRevert",
@@ -2918,6 +2948,66 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "Update strategy rollback metric added",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 5,
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 5,
+					RollbackOn: &RollbackOn{
+						Query:      "up",
+						Threshold:  1,
+						Comparison: RollbackOnComparisonBelow,
+						Interval:   30 * time.Second,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "RollbackOn",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Comparison",
+										Old:  "",
+										New:  "below",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Interval",
+										Old:  "",
+										New:  "30000000000",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Query",
+										Old:  "",
+										New:  "up",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Threshold",
+										Old:  "",
+										New:  "1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase: "Update strategy edited",
 			Old: &TaskGroup{
@@ -3044,6 +3134,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "true",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "AutoPromoteMinHealthyTime",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "AutoPromoteThreshold",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "AutoRevert",
@@ -4531,6 +4633,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "PreserveIdentity",
+								Old:  "",
+								New:  "false",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
@@ -4587,6 +4695,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "PreserveIdentity",
+								Old:  "false",
+								New:  "",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
@@ -5044,6 +5158,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Scaling",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Cooldown",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
@@ -5056,6 +5176,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "10",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxStep",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Min",
@@ -5108,6 +5234,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "Scaling",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Cooldown",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Enabled",
@@ -5120,6 +5252,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "10",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxStep",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Min",
@@ -5240,6 +5378,14 @@ func TestTaskGroupDiff(t *testing.T) {
 					{
 						Type: DiffTypeAdded,
 						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "PreserveIdentity",
+								Old:  "",
+								New:  "false",
+							},
+						},
 						Objects: []*ObjectDiff{
 
 							{
@@ -5281,6 +5427,14 @@ func TestTaskGroupDiff(t *testing.T) {
 					{
 						Type: DiffTypeDeleted,
 						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "PreserveIdentity",
+								Old:  "false",
+								New:  "",
+							},
+						},
 						Objects: []*ObjectDiff{
 
 							{
@@ -5330,6 +5484,14 @@ func TestTaskGroupDiff(t *testing.T) {
 					{
 						Type: DiffTypeAdded,
 						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "PreserveIdentity",
+								Old:  "",
+								New:  "false",
+							},
+						},
 						Objects: []*ObjectDiff{
 
 							{
@@ -5349,6 +5511,14 @@ func TestTaskGroupDiff(t *testing.T) {
 					{
 						Type: DiffTypeDeleted,
 						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "PreserveIdentity",
+								Old:  "false",
+								New:  "",
+							},
+						},
 						Objects: []*ObjectDiff{
 
 							{
@@ -5401,6 +5571,14 @@ func TestTaskGroupDiff(t *testing.T) {
 					{
 						Type: DiffTypeAdded,
 						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "PreserveIdentity",
+								Old:  "",
+								New:  "false",
+							},
+						},
 						Objects: []*ObjectDiff{
 
 							{
@@ -5444,6 +5622,14 @@ func TestTaskGroupDiff(t *testing.T) {
 					{
 						Type: DiffTypeDeleted,
 						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "PreserveIdentity",
+								Old:  "false",
+								New:  "",
+							},
+						},
 						Objects: []*ObjectDiff{
 
 							{
@@ -5873,12 +6059,24 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Compress",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Disabled",
 								Old:  "",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Journald",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "MaxFileSizeMB",
@@ -5891,6 +6089,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxTotalSizeMB",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -5913,12 +6117,24 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Compress",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Disabled",
 								Old:  "true",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Journald",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "MaxFileSizeMB",
@@ -5931,6 +6147,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -5947,9 +6169,11 @@ func TestTaskDiff(t *testing.T) {
 			},
 			New: &Task{
 				LogConfig: &LogConfig{
-					MaxFiles:      2,
-					MaxFileSizeMB: 20,
-					Disabled:      true,
+					MaxFiles:       2,
+					MaxFileSizeMB:  20,
+					Disabled:       true,
+					Compress:       true,
+					MaxTotalSizeMB: 30,
 				},
 			},
 			Expected: &TaskDiff{
@@ -5959,6 +6183,12 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Compress",
+								Old:  "false",
+								New:  "true",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Disabled",
@@ -5977,6 +6207,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "2",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "30",
+							},
 						},
 					},
 				},
@@ -6006,12 +6242,24 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Compress",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Disabled",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Journald",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "MaxFileSizeMB",
@@ -6024,6 +6272,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -6491,6 +6745,14 @@ func TestTaskDiff(t *testing.T) {
 							{
 								Type: DiffTypeAdded,
 								Name: "Network",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "PreserveIdentity",
+										Old:  "",
+										New:  "false",
+									},
+								},
 								Objects: []*ObjectDiff{
 									{
 										Type: DiffTypeAdded,
@@ -6545,6 +6807,14 @@ func TestTaskDiff(t *testing.T) {
 							{
 								Type: DiffTypeDeleted,
 								Name: "Network",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeDeleted,
+										Name: "PreserveIdentity",
+										Old:  "false",
+										New:  "",
+									},
+								},
 								Objects: []*ObjectDiff{
 									{
 										Type: DiffTypeDeleted,
@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 )
@@ -26,6 +27,7 @@ const (
 	errMissingAllocID             = "Missing allocation ID"
 	errIncompatibleFiltering      = "Filter expression cannot be used with other filter parameters"
 	errMalformedChooseParameter   = "Parameter for choose must be in form '<number>|<key>'"
+	errTooManyBlockingQueries     = "Too many concurrent blocking queries"
 
 	// Prefix based errors that are used to check if the error is of a given
 	// type. These errors should be created with the associated constructor.
@@ -64,6 +66,7 @@ var (
 	ErrMissingAllocID             = errors.New(errMissingAllocID)
 	ErrIncompatibleFiltering      = errors.New(errIncompatibleFiltering)
 	ErrMalformedChooseParameter   = errors.New(errMalformedChooseParameter)
+	ErrTooManyBlockingQueries     = errors.New(errTooManyBlockingQueries)
 
 	ErrUnknownNode = errors.New(ErrUnknownNodePrefix)
 
@@ -231,3 +234,99 @@ func CodeFromRPCCodedErr(err error) (code int, msg string, ok bool) {
 
 	return code, parts[1], true
 }
+
+// Error codes are stable identifiers for classes of errors returned by the
+// HTTP API in the ErrorCodeHeader header, so that API consumers can branch on
+// the error class without matching error messages, which may change between
+// releases. Error codes must never be reused or renumbered.
+const (
+	ErrorCodeHeader = "X-Nomad-Error-Code"
+
+	// Generic error codes, derived from the HTTP status code of the response
+	// when the error doesn't match a more specific error code.
+	ErrCodeInternal         = "NOMAD-E1000"
+	ErrCodeBadRequest       = "NOMAD-E1001"
+	ErrCodePermissionDenied = "NOMAD-E1002"
+	ErrCodeNotFound         = "NOMAD-E1003"
+	ErrCodeConflict         = "NOMAD-E1004"
+	ErrCodeMethodNotAllowed = "NOMAD-E1005"
+	ErrCodeTooManyRequests  = "NOMAD-E1006"
+	ErrCodeUnavailable      = "NOMAD-E1007"
+
+	// Specific error codes.
+	ErrCodeTokenNotFound           = "NOMAD-E2001"
+	ErrCodeTokenExpired            = "NOMAD-E2002"
+	ErrCodeTokenInvalid            = "NOMAD-E2003"
+	ErrCodeNoLeader                = "NOMAD-E2004"
+	ErrCodeNoRegionPath            = "NOMAD-E2005"
+	ErrCodeNoNodeConn              = "NOMAD-E2006"
+	ErrCodeJobRegistrationDisabled = "NOMAD-E2007"
+	ErrCodeIncompatibleFiltering   = "NOMAD-E2008"
+	ErrCodeUnknownJob              = "NOMAD-E2009"
+	ErrCodeUnknownAllocation       = "NOMAD-E2010"
+	ErrCodeUnknownNode             = "NOMAD-E2011"
+	ErrCodeUnknownEvaluation       = "NOMAD-E2012"
+	ErrCodeUnknownDeployment       = "NOMAD-E2013"
+	ErrCodeDeploymentTerminal      = "NOMAD-E2014"
+)
+
+// errorCodeMessages maps the messages of well-known errors to their error
+// code. Errors are often wrapped or converted to strings when crossing RPC
+// boundaries, so they are matched by message. More specific messages must be
+// listed first.
+var errorCodeMessages = []struct {
+	msg  string
+	code string
+}{
+	{errTokenNotFound, ErrCodeTokenNotFound},
+	{errTokenExpired, ErrCodeTokenExpired},
+	{errTokenInvalid, ErrCodeTokenInvalid},
+	{errPermissionDenied, ErrCodePermissionDenied},
+	{errNoLeader, ErrCodeNoLeader},
+	{errNoRegionPath, ErrCodeNoRegionPath},
+	{errNoNodeConn, ErrCodeNoNodeConn},
+	{errJobRegistrationDisabled, ErrCodeJobRegistrationDisabled},
+	{errIncompatibleFiltering, ErrCodeIncompatibleFiltering},
+	{ErrUnknownJobPrefix, ErrCodeUnknownJob},
+	{ErrUnknownAllocationPrefix, ErrCodeUnknownAllocation},
+	{ErrUnknownNodePrefix, ErrCodeUnknownNode},
+	{ErrUnknownEvaluationPrefix, ErrCodeUnknownEvaluation},
+	{ErrUnknownDeploymentPrefix, ErrCodeUnknownDeployment},
+	{"terminal deployment", ErrCodeDeploymentTerminal},
+}
+
+// ErrorCode returns the error code of an error returned by the HTTP API with
+// the given status code, or an empty string if err is nil.
+func ErrorCode(status int, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	msg := err.Error()
+	for _, m := range errorCodeMessages {
+		if strings.Contains(msg, m.msg) {
+			return m.code
+		}
+	}
+
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrCodePermissionDenied
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrCodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	}
+	if status >= 400 && status < 500 {
+		return ErrCodeBadRequest
+	}
+	return ErrCodeInternal
+}
@@ -5,9 +5,11 @@ package structs
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,3 +55,31 @@ func TestRPCCodedErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorCode(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		status int
+		err    error
+		code   string
+	}{
+		{0, nil, ""},
+		{403, ErrPermissionDenied, ErrCodePermissionDenied},
+		{500, fmt.Errorf("rpc error: %w", ErrPermissionDenied), ErrCodePermissionDenied},
+		{403, ErrTokenNotFound, ErrCodeTokenNotFound},
+		{500, ErrNoLeader, ErrCodeNoLeader},
+		{500, NewErrUnknownJob("example"), ErrCodeUnknownJob},
+		{500, ErrDeploymentTerminalNoPromote, ErrCodeDeploymentTerminal},
+		{400, NewErrRPCCoded(400, "invalid"), ErrCodeBadRequest},
+		{404, NewErrRPCCoded(404, "not found"), ErrCodeNotFound},
+		{409, errors.New("conflict"), ErrCodeConflict},
+		{422, errors.New("unprocessable"), ErrCodeBadRequest},
+		{429, errors.New("slow down"), ErrCodeTooManyRequests},
+		{500, errors.New("unexpected"), ErrCodeInternal},
+	}
+
+	for _, c := range cases {
+		must.Eq(t, c.code, ErrorCode(c.status, c.err), must.Sprintf("%d %v", c.status, c.err))
+	}
+}
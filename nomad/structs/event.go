@@ -3,11 +3,27 @@
 
 package structs
 
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // EventStreamRequest is used to stream events from a servers EventBroker
 type EventStreamRequest struct {
 	Topics map[Topic][]string
 	Index  int
 
+	// Durable requests a durable subscription, whose events are sent along
+	// with a resume token.
+	Durable bool
+
+	// ResumeToken resumes a durable subscription right after the events the
+	// token was sent with. Index is ignored if set.
+	ResumeToken string
+
 	QueryOptions
 }
 
@@ -53,6 +69,7 @@ const (
 	TypeJobRegistered                 = "JobRegistered"
 	TypeJobDeregistered               = "JobDeregistered"
 	TypeJobBatchDeregistered          = "JobBatchDeregistered"
+	TypeJobFreezeUpdated              = "JobFreezeUpdated"
 	TypePlanResult                    = "PlanResult"
 	TypeACLTokenDeleted               = "ACLTokenDeleted"
 	TypeACLTokenUpserted              = "ACLTokenUpserted"
@@ -105,6 +122,42 @@ type Events struct {
 	Events []Event
 }
 
+// DurableEvents is a set of events sent to a durable subscription, along with
+// the token to resume the subscription after them.
+type DurableEvents struct {
+	Index       uint64
+	Events      []Event
+	ResumeToken string
+}
+
+// eventResumeTokenPrefix versions the format of the event resume tokens.
+const eventResumeTokenPrefix = "v1:"
+
+// NewEventResumeToken returns the opaque token used to resume a durable event
+// stream subscription after the events at the given index.
+func NewEventResumeToken(index uint64) string {
+	raw := eventResumeTokenPrefix + strconv.FormatUint(index, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseEventResumeToken returns the index of the events an event resume token
+// was issued for.
+func ParseEventResumeToken(token string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resume token: %w", err)
+	}
+	indexStr, ok := strings.CutPrefix(string(raw), eventResumeTokenPrefix)
+	if !ok {
+		return 0, fmt.Errorf("invalid resume token: unknown format")
+	}
+	index, err := strconv.ParseUint(indexStr, 10, 64)
+	if err != nil || index == 0 {
+		return 0, fmt.Errorf("invalid resume token: invalid index %q", indexStr)
+	}
+	return index, nil
+}
+
 // EventJson is a wrapper for a JSON object
 type EventJson struct {
 	Data []byte
@@ -123,9 +176,58 @@ type JobEvent struct {
 	Job *Job
 }
 
-// EvaluationEvent holds a newly updated Eval.
+// EvaluationEvent holds a newly updated Eval and the change of status that
+// triggered the event.
 type EvaluationEvent struct {
 	Evaluation *Evaluation
+	Transition *EvaluationTransition
+}
+
+// EvaluationTransition describes the change of status of an evaluation. The
+// time an evaluation spends dequeued by a scheduler worker is not written to
+// Raft, so it is reported by the transition ending the processing instead.
+type EvaluationTransition struct {
+	// PreviousStatus is empty for newly created evaluations.
+	PreviousStatus    string
+	Status            string
+	StatusDescription string
+	TriggeredBy       string
+
+	// WorkerID is the ID of the scheduler worker that processed the
+	// evaluation, if any.
+	WorkerID string
+
+	// QueueDuration is the time between the creation of the evaluation and
+	// its dequeue by WorkerID, and ProcessDuration the time between its
+	// dequeue and this transition.
+	QueueDuration   time.Duration
+	ProcessDuration time.Duration
+}
+
+// NewEvaluationEvent returns the event for the change of an evaluation from
+// before to after. The before evaluation is nil for new evaluations.
+func NewEvaluationEvent(before, after *Evaluation) *EvaluationEvent {
+	transition := &EvaluationTransition{
+		Status:            after.Status,
+		StatusDescription: after.StatusDescription,
+		TriggeredBy:       after.TriggeredBy,
+	}
+	if before != nil {
+		transition.PreviousStatus = before.Status
+	}
+
+	// Only report the processing of the evaluation for the update that
+	// recorded it, and not for later updates such as a cancelation
+	if after.DequeueTime != 0 && (before == nil || before.DequeueTime != after.DequeueTime) {
+		transition.WorkerID = after.WorkerID
+		transition.QueueDuration = max(time.Duration(after.DequeueTime-after.CreateTime), 0)
+		transition.ProcessDuration = max(time.Duration(after.ModifyTime-after.DequeueTime), 0)
+	}
+
+	return &EvaluationEvent{
+		Evaluation: after,
+		Transition: transition,
+	}
 }
 
 // AllocationEvent holds a newly updated Allocation. The
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventResumeToken(t *testing.T) {
+	ci.Parallel(t)
+
+	token := NewEventResumeToken(1234)
+	index, err := ParseEventResumeToken(token)
+	must.NoError(t, err)
+	must.Eq(t, 1234, index)
+
+	for _, invalid := range []string{
+		"",
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("1234")),
+		base64.RawURLEncoding.EncodeToString([]byte("v1:abc")),
+		base64.RawURLEncoding.EncodeToString([]byte("v1:0")),
+	} {
+		_, err := ParseEventResumeToken(invalid)
+		must.ErrorContains(t, err, "invalid resume token")
+	}
+}
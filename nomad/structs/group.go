@@ -6,10 +6,12 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/ryanuber/go-glob"
 )
 
 const (
@@ -137,3 +139,288 @@ func (ds *DisconnectStrategy) ReconcileStrategy() string {
 
 	return ds.Reconcile
 }
+
+var (
+	// Scale with nodes validation errors
+	errScaleWithNodesNonService = errors.New("scale_with_nodes can only be used with service jobs")
+	errScaleWithNodesPerNode    = errors.New("scale_with_nodes per_node must be greater than zero")
+	errScaleWithNodesNegative   = errors.New("scale_with_nodes min, max and scale_down_delay cannot be negative")
+	errScaleWithNodesMinMax     = errors.New("scale_with_nodes min cannot be greater than max")
+	errScaleWithNodesScaling    = errors.New("scale_with_nodes cannot be used with an enabled scaling policy")
+)
+
+// ScaleWithNodes keeps the count of a task group proportional to the number
+// of nodes that match its selectors. The count is recomputed by the leader
+// whenever the node population changes, which lets a service job behave
+// somewhere between a regular service job and a system job.
+type ScaleWithNodes struct {
+	// NodePool restricts the nodes that are counted to the given node pool.
+	// If empty, the node pool of the job is used.
+	NodePool string
+
+	// NodeClass is a glob pattern matched against the node class of each
+	// node. If empty, nodes of any class are counted.
+	NodeClass string
+
+	// PerNode is the number of allocations desired for each matching node.
+	PerNode int
+
+	// Min is the lower bound of the computed count.
+	Min int
+
+	// Max is the upper bound of the computed count. Zero means unbounded.
+	Max int
+
+	// ScaleDownDelay is how long the computed count must stay below the
+	// group count before the group is scaled down.
+	ScaleDownDelay time.Duration
+}
+
+func (s *ScaleWithNodes) Copy() *ScaleWithNodes {
+	if s == nil {
+		return nil
+	}
+
+	ns := new(ScaleWithNodes)
+	*ns = *s
+	return ns
+}
+
+func (s *ScaleWithNodes) Validate(job *Job, tg *TaskGroup) error {
+	if s == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+
+	if job.Type != JobTypeService {
+		mErr = multierror.Append(mErr, errScaleWithNodesNonService)
+	}
+
+	if s.PerNode <= 0 {
+		mErr = multierror.Append(mErr, errScaleWithNodesPerNode)
+	}
+
+	if s.Min < 0 || s.Max < 0 || s.ScaleDownDelay < 0 {
+		mErr = multierror.Append(mErr, errScaleWithNodesNegative)
+	} else if s.Max > 0 && s.Min > s.Max {
+		mErr = multierror.Append(mErr, errScaleWithNodesMinMax)
+	}
+
+	if tg.Scaling != nil && tg.Scaling.Enabled {
+		mErr = multierror.Append(mErr, errScaleWithNodesScaling)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Matches returns true if the node should be counted towards the group count
+// of the job. Only nodes that can take placements are counted, so nodes that
+// are down, ineligible, or draining are not.
+func (s *ScaleWithNodes) Matches(job *Job, node *Node) bool {
+	if s == nil || node == nil || node.Status != NodeStatusReady {
+		return false
+	}
+	if node.SchedulingEligibility != NodeSchedulingEligible || node.DrainStrategy != nil {
+		return false
+	}
+
+	pool := s.NodePool
+	if pool == "" {
+		pool = job.NodePool
+	}
+	if !node.IsInPool(pool) {
+		return false
+	}
+
+	if !node.IsInAnyDC(job.Datacenters) {
+		return false
+	}
+
+	return s.NodeClass == "" || glob.Glob(s.NodeClass, node.NodeClass)
+}
+
+// DesiredCount returns the group count for the given number of matching
+// nodes, bounded by Min and Max.
+func (s *ScaleWithNodes) DesiredCount(nodes int) int {
+	count := nodes * s.PerNode
+	if count < s.Min {
+		count = s.Min
+	}
+	if s.Max > 0 && count > s.Max {
+		count = s.Max
+	}
+	return count
+}
+
+var (
+	// Disruption budget validation errors
+	errDisruptionBudgetNonService = errors.New("disruption_budget can only be used with service jobs")
+	errDisruptionBudgetNegative   = errors.New("disruption_budget min_healthy cannot be negative")
+	errDisruptionBudgetPercent    = errors.New("disruption_budget min_healthy_percent must be between 0 and 100")
+	errDisruptionBudgetBoth       = errors.New("disruption_budget cannot set both min_healthy and min_healthy_percent")
+)
+
+// DisruptionBudget is the minimum number of healthy allocations of a service
+// task group that voluntary disruptions, such as node drains and preemption,
+// must leave running.
+type DisruptionBudget struct {
+	// MinHealthy is the minimum number of healthy allocations.
+	MinHealthy int
+
+	// MinHealthyPercent is the minimum number of healthy allocations as a
+	// percentage of the group count, rounded up.
+	MinHealthyPercent int
+}
+
+func (b *DisruptionBudget) Copy() *DisruptionBudget {
+	if b == nil {
+		return nil
+	}
+
+	nb := new(DisruptionBudget)
+	*nb = *b
+	return nb
+}
+
+func (b *DisruptionBudget) Validate(job *Job) error {
+	if b == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+
+	if job.Type != JobTypeService {
+		mErr = multierror.Append(mErr, errDisruptionBudgetNonService)
+	}
+
+	if b.MinHealthy < 0 {
+		mErr = multierror.Append(mErr, errDisruptionBudgetNegative)
+	}
+
+	if b.MinHealthyPercent < 0 || b.MinHealthyPercent > 100 {
+		mErr = multierror.Append(mErr, errDisruptionBudgetPercent)
+	}
+
+	if b.MinHealthy > 0 && b.MinHealthyPercent > 0 {
+		mErr = multierror.Append(mErr, errDisruptionBudgetBoth)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// MinHealthyCount returns the minimum number of healthy allocations of a task
+// group with the given count.
+func (b *DisruptionBudget) MinHealthyCount(count int) int {
+	if b == nil {
+		return 0
+	}
+	if b.MinHealthyPercent > 0 {
+		return (count*b.MinHealthyPercent + 99) / 100
+	}
+	return b.MinHealthy
+}
+
+// Allowed returns how many of the healthy allocations of a task group with
+// the given count may be disrupted without going below the budget.
+func (b *DisruptionBudget) Allowed(count, healthy int) int {
+	return max(healthy-b.MinHealthyCount(count), 0)
+}
+
+// DisruptionBudgetHealthy returns true if the allocation counts towards the
+// healthy allocations of the disruption budget of its task group: it is
+// running and, if it is part of a deployment, healthy.
+func (a *Allocation) DisruptionBudgetHealthy() bool {
+	if a.TerminalStatus() || a.ClientStatus != AllocClientStatusRunning {
+		return false
+	}
+	return a.DeploymentStatus == nil || a.DeploymentStatus.IsHealthy()
+}
+
+var (
+	// Preemption notice validation errors
+	errPreemptionNoticeGrace = errors.New("preemption_notice grace cannot be negative")
+)
+
+// PreemptionNotice configures how the allocations of a task group are notified
+// when they are preempted, so that the workload can checkpoint its work before
+// it is evicted.
+type PreemptionNotice struct {
+	// Grace is how long a preempted allocation keeps running before its tasks
+	// are killed.
+	Grace time.Duration
+
+	// Signal is sent to the tasks of a preempted allocation at the start of
+	// the grace period. No signal is sent if it is empty.
+	Signal string
+}
+
+func (n *PreemptionNotice) Copy() *PreemptionNotice {
+	if n == nil {
+		return nil
+	}
+
+	nn := new(PreemptionNotice)
+	*nn = *n
+	return nn
+}
+
+func (n *PreemptionNotice) Validate() error {
+	if n == nil {
+		return nil
+	}
+	if n.Grace < 0 {
+		return errPreemptionNoticeGrace
+	}
+	return nil
+}
+
+// PreemptionDeadlineAt returns the time in Unix nanoseconds at which the
+// allocation is evicted if it is preempted at the given time, or zero if its
+// task group has no preemption grace period.
+func (a *Allocation) PreemptionDeadlineAt(now int64) int64 {
+	if a.Job == nil {
+		return 0
+	}
+	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	if tg == nil || tg.PreemptionNotice == nil || tg.PreemptionNotice.Grace <= 0 {
+		return 0
+	}
+	return now + tg.PreemptionNotice.Grace.Nanoseconds()
+}
+
+var (
+	// Gang validation errors
+	errGangJobType = errors.New("gang can only be used with service or batch jobs")
+	errGangName    = errors.New("gang name contains null character")
+)
+
+// validateGang validates the gang of the task group, if any.
+func (tg *TaskGroup) validateGang(j *Job) error {
+	if tg.Gang == "" {
+		return nil
+	}
+
+	var mErr *multierror.Error
+
+	switch j.Type {
+	case JobTypeService, JobTypeBatch:
+	default:
+		mErr = multierror.Append(mErr, errGangJobType)
+	}
+
+	if strings.Contains(tg.Gang, "\000") {
+		mErr = multierror.Append(mErr, errGangName)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// LookupGang returns the gang of the named task group, or an empty string if
+// the task group doesn't exist or isn't part of a gang.
+func (j *Job) LookupGang(taskGroup string) string {
+	if tg := j.LookupTaskGroup(taskGroup); tg != nil {
+		return tg.Gang
+	}
+	return ""
+}
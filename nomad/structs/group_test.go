@@ -271,3 +271,344 @@ func TestJobConfig_Validate_MaxClientDisconnect(t *testing.T) {
 	err = job.Validate()
 	must.NoError(t, err)
 }
+
+func TestScaleWithNodes_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		swn     *ScaleWithNodes
+		jobType string
+		scaling *ScalingPolicy
+		err     error
+	}{
+		{
+			name:    "valid",
+			swn:     &ScaleWithNodes{PerNode: 2, Min: 1, Max: 10},
+			jobType: JobTypeService,
+		},
+		{
+			name:    "non-service",
+			swn:     &ScaleWithNodes{PerNode: 1},
+			jobType: JobTypeBatch,
+			err:     errScaleWithNodesNonService,
+		},
+		{
+			name:    "zero-per-node",
+			swn:     &ScaleWithNodes{},
+			jobType: JobTypeService,
+			err:     errScaleWithNodesPerNode,
+		},
+		{
+			name:    "negative-min",
+			swn:     &ScaleWithNodes{PerNode: 1, Min: -1},
+			jobType: JobTypeService,
+			err:     errScaleWithNodesNegative,
+		},
+		{
+			name:    "negative-scale-down-delay",
+			swn:     &ScaleWithNodes{PerNode: 1, ScaleDownDelay: -time.Second},
+			jobType: JobTypeService,
+			err:     errScaleWithNodesNegative,
+		},
+		{
+			name:    "min-greater-than-max",
+			swn:     &ScaleWithNodes{PerNode: 1, Min: 5, Max: 2},
+			jobType: JobTypeService,
+			err:     errScaleWithNodesMinMax,
+		},
+		{
+			name:    "enabled-scaling-policy",
+			swn:     &ScaleWithNodes{PerNode: 1},
+			jobType: JobTypeService,
+			scaling: &ScalingPolicy{Enabled: true},
+			err:     errScaleWithNodesScaling,
+		},
+		{
+			name:    "disabled-scaling-policy",
+			swn:     &ScaleWithNodes{PerNode: 1},
+			jobType: JobTypeService,
+			scaling: &ScalingPolicy{Enabled: false},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			job := testJob()
+			job.Type = c.jobType
+			tg := job.TaskGroups[0]
+			tg.Scaling = c.scaling
+
+			err := c.swn.Validate(job, tg)
+			if c.err == nil {
+				must.NoError(t, err)
+			} else {
+				must.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_validateGang(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		gang    string
+		jobType string
+		err     error
+	}{
+		{
+			name:    "no gang",
+			jobType: JobTypeSystem,
+		},
+		{
+			name:    "service",
+			gang:    "app",
+			jobType: JobTypeService,
+		},
+		{
+			name:    "batch",
+			gang:    "app",
+			jobType: JobTypeBatch,
+		},
+		{
+			name:    "system",
+			gang:    "app",
+			jobType: JobTypeSystem,
+			err:     errGangJobType,
+		},
+		{
+			name:    "null character",
+			gang:    "app\000",
+			jobType: JobTypeService,
+			err:     errGangName,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			job := testJob()
+			job.Type = c.jobType
+			tg := job.TaskGroups[0]
+			tg.Gang = c.gang
+
+			err := tg.validateGang(job)
+			if c.err == nil {
+				must.NoError(t, err)
+			} else {
+				must.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestPlan_RemoveAlloc(t *testing.T) {
+	ci.Parallel(t)
+
+	plan := &Plan{
+		NodeUpdate:      make(map[string][]*Allocation),
+		NodeAllocation:  make(map[string][]*Allocation),
+		NodePreemptions: make(map[string][]*Allocation),
+	}
+
+	prev := &Allocation{ID: "prev", NodeID: "node-1"}
+	plan.AppendStoppedAlloc(prev, "replaced", "", "")
+
+	alloc := &Allocation{ID: "alloc", NodeID: "node-1"}
+	other := &Allocation{ID: "other", NodeID: "node-1"}
+	plan.AppendAlloc(alloc, nil)
+	plan.AppendAlloc(other, nil)
+	plan.AppendPreemptedAlloc(&Allocation{ID: "preempted", NodeID: "node-1"}, alloc.ID)
+
+	plan.RemoveAlloc(alloc)
+	must.Eq(t, []*Allocation{other}, plan.NodeAllocation["node-1"])
+	must.MapEmpty(t, plan.NodePreemptions)
+
+	plan.RemoveStoppedAlloc(prev)
+	must.MapEmpty(t, plan.NodeUpdate)
+}
+
+func TestScaleWithNodes_DesiredCount(t *testing.T) {
+	ci.Parallel(t)
+
+	swn := &ScaleWithNodes{PerNode: 2, Min: 3, Max: 8}
+	must.Eq(t, 3, swn.DesiredCount(0))
+	must.Eq(t, 4, swn.DesiredCount(2))
+	must.Eq(t, 8, swn.DesiredCount(10))
+
+	swn.Max = 0
+	must.Eq(t, 20, swn.DesiredCount(10))
+}
+
+func TestScaleWithNodes_Matches(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Datacenters = []string{"dc*"}
+	job.NodePool = "web"
+
+	node := &Node{
+		Datacenter:            "dc1",
+		NodePool:              "web",
+		NodeClass:             "web-large",
+		Status:                NodeStatusReady,
+		SchedulingEligibility: NodeSchedulingEligible,
+	}
+
+	swn := &ScaleWithNodes{PerNode: 1}
+	must.True(t, swn.Matches(job, node))
+
+	swn.NodeClass = "web-*"
+	must.True(t, swn.Matches(job, node))
+
+	swn.NodeClass = "db-*"
+	must.False(t, swn.Matches(job, node))
+
+	swn.NodeClass = ""
+	swn.NodePool = "other"
+	must.False(t, swn.Matches(job, node))
+
+	swn.NodePool = NodePoolAll
+	must.True(t, swn.Matches(job, node))
+
+	node.SchedulingEligibility = NodeSchedulingIneligible
+	must.False(t, swn.Matches(job, node))
+
+	// Draining nodes are not counted
+	node.SchedulingEligibility = NodeSchedulingEligible
+	node.DrainStrategy = &DrainStrategy{}
+	must.False(t, swn.Matches(job, node))
+
+	node.DrainStrategy = nil
+	node.Status = NodeStatusDown
+	must.False(t, swn.Matches(job, node))
+}
+
+func TestDisruptionBudget_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		budget  *DisruptionBudget
+		jobType string
+		err     error
+	}{
+		{
+			name:    "valid",
+			budget:  &DisruptionBudget{MinHealthy: 2},
+			jobType: JobTypeService,
+		},
+		{
+			name:    "valid-percent",
+			budget:  &DisruptionBudget{MinHealthyPercent: 50},
+			jobType: JobTypeService,
+		},
+		{
+			name:    "non-service",
+			budget:  &DisruptionBudget{MinHealthy: 1},
+			jobType: JobTypeBatch,
+			err:     errDisruptionBudgetNonService,
+		},
+		{
+			name:    "negative-min-healthy",
+			budget:  &DisruptionBudget{MinHealthy: -1},
+			jobType: JobTypeService,
+			err:     errDisruptionBudgetNegative,
+		},
+		{
+			name:    "invalid-percent",
+			budget:  &DisruptionBudget{MinHealthyPercent: 101},
+			jobType: JobTypeService,
+			err:     errDisruptionBudgetPercent,
+		},
+		{
+			name:    "both",
+			budget:  &DisruptionBudget{MinHealthy: 1, MinHealthyPercent: 50},
+			jobType: JobTypeService,
+			err:     errDisruptionBudgetBoth,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			job := testJob()
+			job.Type = c.jobType
+
+			err := c.budget.Validate(job)
+			if c.err == nil {
+				must.NoError(t, err)
+			} else {
+				must.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestDisruptionBudget_Allowed(t *testing.T) {
+	ci.Parallel(t)
+
+	var budget *DisruptionBudget
+	must.Eq(t, 3, budget.Allowed(3, 3))
+
+	budget = &DisruptionBudget{MinHealthy: 2}
+	must.Eq(t, 2, budget.MinHealthyCount(5))
+	must.Eq(t, 1, budget.Allowed(5, 3))
+	must.Eq(t, 0, budget.Allowed(5, 1))
+
+	budget = &DisruptionBudget{MinHealthyPercent: 50}
+	must.Eq(t, 3, budget.MinHealthyCount(5))
+	must.Eq(t, 2, budget.Allowed(5, 5))
+	must.Eq(t, 0, budget.Allowed(5, 3))
+}
+
+func TestAllocation_DisruptionBudgetHealthy(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		DesiredStatus: AllocDesiredStatusRun,
+		ClientStatus:  AllocClientStatusRunning,
+	}
+	must.True(t, alloc.DisruptionBudgetHealthy())
+
+	alloc.DeploymentStatus = &AllocDeploymentStatus{}
+	must.False(t, alloc.DisruptionBudgetHealthy())
+
+	alloc.DeploymentStatus.Healthy = pointer.Of(true)
+	must.True(t, alloc.DisruptionBudgetHealthy())
+
+	alloc.DesiredStatus = AllocDesiredStatusStop
+	must.False(t, alloc.DisruptionBudgetHealthy())
+
+	alloc.DesiredStatus = AllocDesiredStatusRun
+	alloc.ClientStatus = AllocClientStatusPending
+	must.False(t, alloc.DisruptionBudgetHealthy())
+}
+
+func TestPreemptionNotice_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var notice *PreemptionNotice
+	must.NoError(t, notice.Validate())
+	must.NoError(t, (&PreemptionNotice{Grace: time.Minute, Signal: "SIGUSR1"}).Validate())
+	must.ErrorIs(t, (&PreemptionNotice{Grace: -time.Second}).Validate(), errPreemptionNoticeGrace)
+}
+
+func TestAllocation_PreemptionDeadlineAt(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		TaskGroup: "web",
+		Job: &Job{
+			TaskGroups: []*TaskGroup{{Name: "web"}},
+		},
+	}
+	now := time.Now().UnixNano()
+	must.Zero(t, alloc.PreemptionDeadlineAt(now))
+
+	alloc.Job.TaskGroups[0].PreemptionNotice = &PreemptionNotice{Grace: 30 * time.Second}
+	must.Eq(t, now+(30*time.Second).Nanoseconds(), alloc.PreemptionDeadlineAt(now))
+
+	alloc.Job = nil
+	must.Zero(t, alloc.PreemptionDeadlineAt(now))
+}
@@ -0,0 +1,355 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// JobLintSeverityInfo is the severity of findings that are only
+	// informative.
+	JobLintSeverityInfo = "info"
+
+	// JobLintSeverityWarning is the severity of findings that likely make the
+	// job behave unexpectedly.
+	JobLintSeverityWarning = "warning"
+
+	// JobLintSeverityError is the severity of findings that must be fixed.
+	JobLintSeverityError = "error"
+)
+
+const (
+	// JobLintScopeJob evaluates a lint rule filter against the job.
+	JobLintScopeJob = "job"
+
+	// JobLintScopeGroup evaluates a lint rule filter against each task group.
+	JobLintScopeGroup = "group"
+
+	// JobLintScopeTask evaluates a lint rule filter against each task.
+	JobLintScopeTask = "task"
+
+	// JobLintScopeService evaluates a lint rule filter against each group and
+	// task service.
+	JobLintScopeService = "service"
+)
+
+// JobLintSeverityRank returns the rank of a lint severity, higher ranks being
+// more severe. It returns -1 for unknown severities.
+func JobLintSeverityRank(severity string) int {
+	return slices.Index([]string{
+		JobLintSeverityInfo,
+		JobLintSeverityWarning,
+		JobLintSeverityError,
+	}, severity)
+}
+
+// JobLintRule is a user-supplied lint rule. A finding is reported for each
+// object of the rule scope matched by the rule filter.
+type JobLintRule struct {
+	// Name uniquely identifies the rule.
+	Name string
+
+	// Description describes what the rule checks for. It is used as the
+	// message of findings when Message is empty.
+	Description string
+
+	// Severity is the severity of findings, defaulting to "warning".
+	Severity string
+
+	// Scope is the kind of object the filter is evaluated against, one of
+	// "job", "group", "task" or "service". Defaults to "job".
+	Scope string
+
+	// Filter is the go-bexpr expression matching the objects that violate
+	// the rule.
+	Filter string
+
+	// Message is the message of findings.
+	Message string
+}
+
+// Canonicalize sets the default severity and scope of the rule.
+func (r *JobLintRule) Canonicalize() {
+	if r.Severity == "" {
+		r.Severity = JobLintSeverityWarning
+	}
+	if r.Scope == "" {
+		r.Scope = JobLintScopeJob
+	}
+}
+
+// Validate returns an error if the rule is invalid.
+func (r *JobLintRule) Validate() error {
+	var mErr multierror.Error
+	if r.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing rule name"))
+	}
+	if JobLintSeverityRank(r.Severity) < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid severity %q", r.Severity))
+	}
+	switch r.Scope {
+	case JobLintScopeJob, JobLintScopeGroup, JobLintScopeTask, JobLintScopeService:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid scope %q", r.Scope))
+	}
+	if r.Filter == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing filter"))
+	} else if _, err := bexpr.CreateEvaluator(r.Filter); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid filter: %v", err))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// JobLintFinding is a violation of a lint rule by a job.
+type JobLintFinding struct {
+	// Rule is the name of the violated rule.
+	Rule string
+
+	// Severity is the severity of the violated rule.
+	Severity string
+
+	// Message describes the violation.
+	Message string
+
+	// Group and Task are the names of the task group and task violating the
+	// rule, if any.
+	Group string
+	Task  string
+}
+
+// jobLinter is a built-in lint rule.
+type jobLinter struct {
+	name     string
+	severity string
+	lint     func(job *Job) []*JobLintFinding
+}
+
+// builtinJobLinters are the lint rules applied to every linted job.
+var builtinJobLinters = []jobLinter{
+	{"missing-health-checks", JobLintSeverityWarning, lintMissingHealthChecks},
+	{"no-resources", JobLintSeverityWarning, lintNoResources},
+	{"latest-image-tag", JobLintSeverityWarning, lintLatestImageTag},
+	{"no-restart-policy", JobLintSeverityInfo, lintNoRestartPolicy},
+}
+
+// Lint returns the findings of the built-in lint rules and the given
+// user-supplied rules for a canonicalized job. An error is returned if a rule
+// is invalid or cannot be evaluated.
+func (j *Job) Lint(rules []*JobLintRule) ([]*JobLintFinding, error) {
+	var findings []*JobLintFinding
+	for _, linter := range builtinJobLinters {
+		for _, finding := range linter.lint(j) {
+			finding.Rule = linter.name
+			finding.Severity = linter.severity
+			findings = append(findings, finding)
+		}
+	}
+
+	ruleFindings, err := j.LintRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return append(findings, ruleFindings...), nil
+}
+
+// LintRules returns the findings of the given user-supplied rules only for a
+// canonicalized job. An error is returned if a rule is invalid or cannot be
+// evaluated.
+func (j *Job) LintRules(rules []*JobLintRule) ([]*JobLintFinding, error) {
+	var findings []*JobLintFinding
+	for _, rule := range rules {
+		rule.Canonicalize()
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("lint rule %q is invalid: %w", rule.Name, err)
+		}
+
+		ruleFindings, err := j.lintRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate lint rule %q: %w", rule.Name, err)
+		}
+		findings = append(findings, ruleFindings...)
+	}
+	return findings, nil
+}
+
+// lintRule evaluates a user-supplied rule against each object of its scope.
+func (j *Job) lintRule(rule *JobLintRule) ([]*JobLintFinding, error) {
+	eval, err := bexpr.CreateEvaluator(rule.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	message := rule.Message
+	if message == "" {
+		message = rule.Description
+	}
+
+	var findings []*JobLintFinding
+	match := func(obj any, group, task string) error {
+		matched, err := eval.Evaluate(obj)
+		if err != nil {
+			return err
+		}
+		if matched {
+			findings = append(findings, &JobLintFinding{
+				Rule:     rule.Name,
+				Severity: rule.Severity,
+				Message:  message,
+				Group:    group,
+				Task:     task,
+			})
+		}
+		return nil
+	}
+
+	switch rule.Scope {
+	case JobLintScopeJob:
+		if err := match(j, "", ""); err != nil {
+			return nil, err
+		}
+	case JobLintScopeGroup:
+		for _, tg := range j.TaskGroups {
+			if err := match(tg, tg.Name, ""); err != nil {
+				return nil, err
+			}
+		}
+	case JobLintScopeTask:
+		for _, tg := range j.TaskGroups {
+			for _, task := range tg.Tasks {
+				if err := match(task, tg.Name, task.Name); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case JobLintScopeService:
+		for _, tg := range j.TaskGroups {
+			for _, service := range tg.Services {
+				if err := match(service, tg.Name, ""); err != nil {
+					return nil, err
+				}
+			}
+			for _, task := range tg.Tasks {
+				for _, service := range task.Services {
+					if err := match(service, tg.Name, task.Name); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	return findings, nil
+}
+
+// lintMissingHealthChecks reports services without health checks, which are
+// considered healthy as soon as they are registered.
+func lintMissingHealthChecks(job *Job) []*JobLintFinding {
+	var findings []*JobLintFinding
+	for _, tg := range job.TaskGroups {
+		for _, service := range tg.Services {
+			if len(service.Checks) == 0 {
+				findings = append(findings, &JobLintFinding{
+					Message: fmt.Sprintf("service %q has no health checks", service.Name),
+					Group:   tg.Name,
+				})
+			}
+		}
+		for _, task := range tg.Tasks {
+			for _, service := range task.Services {
+				if len(service.Checks) == 0 {
+					findings = append(findings, &JobLintFinding{
+						Message: fmt.Sprintf("service %q has no health checks", service.Name),
+						Group:   tg.Name,
+						Task:    task.Name,
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// lintNoResources reports tasks whose resources were left to the defaults,
+// which are unlikely to match the needs of the task.
+func lintNoResources(job *Job) []*JobLintFinding {
+	defaults := DefaultResources()
+
+	var findings []*JobLintFinding
+	for _, tg := range job.TaskGroups {
+		for _, task := range tg.Tasks {
+			r := task.Resources
+			if r != nil && (r.CPU != defaults.CPU || r.Cores != 0 || r.MemoryMB != defaults.MemoryMB) {
+				continue
+			}
+			findings = append(findings, &JobLintFinding{
+				Message: "task does not set its cpu and memory resources",
+				Group:   tg.Name,
+				Task:    task.Name,
+			})
+		}
+	}
+	return findings
+}
+
+// lintLatestImageTag reports tasks running an image with the "latest" tag or
+// without tag, which may change between placements.
+func lintLatestImageTag(job *Job) []*JobLintFinding {
+	var findings []*JobLintFinding
+	for _, tg := range job.TaskGroups {
+		for _, task := range tg.Tasks {
+			image, ok := task.Config["image"].(string)
+			if !ok || image == "" || !imageHasLatestTag(image) {
+				continue
+			}
+			findings = append(findings, &JobLintFinding{
+				Message: fmt.Sprintf("image %q does not have a pinned tag", image),
+				Group:   tg.Name,
+				Task:    task.Name,
+			})
+		}
+	}
+	return findings
+}
+
+// imageHasLatestTag returns true if an image reference has the "latest" tag
+// or no tag nor digest.
+func imageHasLatestTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i < 0 || name[i+1:] == "latest"
+}
+
+// lintNoRestartPolicy reports task groups whose restart policy was left to
+// the default of the job type.
+func lintNoRestartPolicy(job *Job) []*JobLintFinding {
+	defaults := NewRestartPolicy(job.Type)
+	if defaults == nil {
+		return nil
+	}
+
+	var findings []*JobLintFinding
+	for _, tg := range job.TaskGroups {
+		rp := tg.RestartPolicy
+		if rp != nil && (rp.Attempts != defaults.Attempts ||
+			rp.Interval != defaults.Interval ||
+			rp.Delay != defaults.Delay ||
+			rp.Mode != defaults.Mode) {
+			continue
+		}
+		findings = append(findings, &JobLintFinding{
+			Message: "group does not set a restart policy",
+			Group:   tg.Name,
+		})
+	}
+	return findings
+}
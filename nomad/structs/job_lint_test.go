@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJob_Lint_Builtin(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	findings, err := job.Lint(nil)
+	must.NoError(t, err)
+	must.Eq(t, []*JobLintFinding{{
+		Rule:     "missing-health-checks",
+		Severity: JobLintSeverityWarning,
+		Message:  `service "web-admin" has no health checks`,
+		Group:    "web",
+		Task:     "web",
+	}}, findings)
+
+	tg := job.TaskGroups[0]
+	tg.RestartPolicy = NewRestartPolicy(job.Type)
+	tg.Tasks[0].Services = nil
+	tg.Tasks[0].Resources = DefaultResources()
+	tg.Tasks[0].Driver = "docker"
+	tg.Tasks[0].Config = map[string]any{"image": "registry.example.com:5000/web"}
+
+	findings, err = job.Lint(nil)
+	must.NoError(t, err)
+	must.Len(t, 3, findings)
+	must.Eq(t, "no-resources", findings[0].Rule)
+	must.Eq(t, "latest-image-tag", findings[1].Rule)
+	must.Eq(t, "no-restart-policy", findings[2].Rule)
+	must.Eq(t, JobLintSeverityInfo, findings[2].Severity)
+}
+
+func TestJob_Lint_Rules(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	job.TaskGroups[0].Tasks[0].Config["privileged"] = true
+
+	findings, err := job.Lint([]*JobLintRule{
+		{
+			Name:     "no-privileged",
+			Severity: JobLintSeverityError,
+			Scope:    JobLintScopeTask,
+			Filter:   "Config.privileged == true",
+			Message:  "task must not be privileged",
+		},
+		{
+			Name:        "owner",
+			Description: "job must have an owner",
+			Filter:      `"owner" not in Meta`,
+		},
+		{
+			Name:   "tagged-services",
+			Scope:  JobLintScopeService,
+			Filter: "Tags is empty",
+		},
+	})
+	must.NoError(t, err)
+	must.Len(t, 3, findings)
+	must.Eq(t, &JobLintFinding{
+		Rule:     "no-privileged",
+		Severity: JobLintSeverityError,
+		Message:  "task must not be privileged",
+		Group:    "web",
+		Task:     "web",
+	}, findings[1])
+	must.Eq(t, "tagged-services", findings[2].Rule)
+	must.Eq(t, JobLintSeverityWarning, findings[2].Severity)
+
+	_, err = job.Lint([]*JobLintRule{{Name: "bad", Scope: "node", Filter: "=="}})
+	must.ErrorContains(t, err, `lint rule "bad" is invalid`)
+}
+
+func TestJob_Lint_imageHasLatestTag(t *testing.T) {
+	ci.Parallel(t)
+
+	must.True(t, imageHasLatestTag("redis"))
+	must.True(t, imageHasLatestTag("redis:latest"))
+	must.True(t, imageHasLatestTag("registry.example.com:5000/redis"))
+	must.False(t, imageHasLatestTag("redis:7.2"))
+	must.False(t, imageHasLatestTag("registry.example.com:5000/redis:7.2"))
+	must.False(t, imageHasLatestTag("redis@sha256:0123456789abcdef"))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"github.com/hashicorp/nomad/helper/uuid"
+)
+
+// JobPurgeRecord is the audit record written when a job is purged with the
+// Job.PurgeWithAudit RPC. It keeps a compact summary of the job so that it can
+// still be established who purged a job, when, and what it looked like after
+// the job and its versions are gone.
+type JobPurgeRecord struct {
+	// ID is a generated UUID identifying the record.
+	ID string
+
+	Namespace string
+	JobID     string
+	Name      string
+	Type      string
+
+	// PurgedBy is the identity that purged the job, such as the accessor ID
+	// of the ACL token used.
+	PurgedBy string
+
+	// PurgedAt is the Unix nanosecond timestamp at which the job was purged.
+	PurgedAt int64
+
+	// Versions summarizes the versions of the job known at the time it was
+	// purged, from the most recent to the oldest.
+	Versions []*JobPurgeVersionSummary
+
+	// Raft index
+	CreateIndex uint64
+}
+
+// JobPurgeVersionSummary is the summary of a job version kept in a
+// JobPurgeRecord.
+type JobPurgeVersionSummary struct {
+	Version    uint64
+	SubmitTime int64
+	Stable     bool
+	Stopped    bool
+
+	// Tag is the name of the version tag, if the version was tagged.
+	Tag string
+}
+
+// NewJobPurgeRecord returns the audit record for purging the job, given the
+// versions of the job sorted from the most recent to the oldest.
+func NewJobPurgeRecord(job *Job, versions []*Job, purgedBy string, purgedAt int64) *JobPurgeRecord {
+	record := &JobPurgeRecord{
+		ID:        uuid.Generate(),
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Name:      job.Name,
+		Type:      job.Type,
+		PurgedBy:  purgedBy,
+		PurgedAt:  purgedAt,
+		Versions:  make([]*JobPurgeVersionSummary, 0, len(versions)),
+	}
+	for _, version := range versions {
+		summary := &JobPurgeVersionSummary{
+			Version:    version.Version,
+			SubmitTime: version.SubmitTime,
+			Stable:     version.Stable,
+			Stopped:    version.Stop,
+		}
+		if version.VersionTag != nil {
+			summary.Tag = version.VersionTag.Name
+		}
+		record.Versions = append(record.Versions, summary)
+	}
+	return record
+}
+
+// JobPurgeHistoryRequest is used to list the audit records of purged jobs.
+type JobPurgeHistoryRequest struct {
+	// JobID restricts the records to those of the job with this exact ID. All
+	// the records of the namespace are returned if empty.
+	JobID string
+
+	QueryOptions
+}
+
+// JobPurgeHistoryResponse is used to respond to a JobPurgeHistoryRequest.
+type JobPurgeHistoryResponse struct {
+	Records []*JobPurgeRecord
+	QueryMeta
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"slices"
+)
+
+const (
+	// JobRestartStatusRunning is the status of a restart that accepts new
+	// batches.
+	JobRestartStatusRunning = "running"
+
+	// JobRestartStatusPaused is the status of a restart halted because one
+	// of its batches failed. Paused restarts reject new batches.
+	JobRestartStatusPaused = "paused"
+
+	// JobRestartStatusComplete is the status of a restart that finished.
+	JobRestartStatusComplete = "complete"
+
+	// JobRestartPausedError is the error returned when a batch is submitted
+	// to a paused restart.
+	JobRestartPausedError = "job restart is paused"
+)
+
+// JobRestart tracks a batched restart of the allocations of a job that was
+// started with `nomad job restart -on-error=pause`. The restart itself is
+// driven by the CLI, but it is persisted so that the leader can halt it when
+// a batch fails even if the CLI is no longer connected. There is at most one
+// restart per job, and starting a new restart replaces the previous one.
+type JobRestart struct {
+	// ID is a generated UUID identifying the restart.
+	ID string
+
+	Namespace string
+	JobID     string
+
+	// Status is one of the JobRestartStatus constants, and
+	// StatusDescription is a human readable description of it, such as the
+	// reason the restart was paused.
+	Status            string
+	StatusDescription string
+
+	// Reschedule is set if the allocations are stopped and replaced rather
+	// than restarted in-place.
+	Reschedule bool
+
+	// Batch is the IDs of the allocations restarted by the latest batch,
+	// and BatchStartTime the Unix nanosecond timestamp at which the batch
+	// was submitted.
+	Batch          []string
+	BatchStartTime int64
+
+	// BatchCount is the number of batches submitted so far.
+	BatchCount int
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// Copy returns a deep copy of the restart.
+func (r *JobRestart) Copy() *JobRestart {
+	if r == nil {
+		return nil
+	}
+	nr := new(JobRestart)
+	*nr = *r
+	nr.Batch = slices.Clone(r.Batch)
+	return nr
+}
+
+// Terminal returns true if the restart will not accept new batches.
+func (r *JobRestart) Terminal() bool {
+	return r.Status == JobRestartStatusComplete
+}
+
+// JobRestartRequest is used to start a job restart, submit a batch to it, or
+// update its status.
+type JobRestartRequest struct {
+	JobID string
+
+	// RestartID is the ID of the restart being updated. A new restart is
+	// started if empty.
+	RestartID string
+
+	// Reschedule is only used when starting a new restart.
+	Reschedule bool
+
+	// Batch is the IDs of the allocations about to be restarted. It is
+	// rejected if the restart is paused or if the previous batch failed.
+	Batch []string
+
+	// Status, if set, transitions the restart to the given status instead
+	// of submitting a batch.
+	Status            string
+	StatusDescription string
+
+	WriteRequest
+}
+
+// JobRestartResponse is used to respond to a JobRestartRequest.
+type JobRestartResponse struct {
+	Restart *JobRestart
+	WriteMeta
+}
+
+// JobRestartUpsertRequest is the Raft request used to write a job restart.
+type JobRestartUpsertRequest struct {
+	Restart *JobRestart
+	WriteRequest
+}
+
+// SingleJobRestartResponse is used to return the restart of a job.
+type SingleJobRestartResponse struct {
+	Restart *JobRestart
+	QueryMeta
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// JobSubmissionRedacted replaces the variable values removed from a job
+	// submission by Redact.
+	JobSubmissionRedacted = "<redacted>"
+)
+
+// Redact returns a copy of js with the default values of the variables
+// declared in an hcl2 source, and the values of the variables submitted with
+// the job, replaced by JobSubmissionRedacted. It is used to serve the
+// submission to users who may read the job but not the secrets it was
+// submitted with.
+func (js *JobSubmission) Redact() *JobSubmission {
+	if js == nil {
+		return nil
+	}
+
+	out := js.Copy()
+	if out.Format == "hcl2" {
+		out.Source = redactHCLVariables(out.Source, false)
+	}
+	for k := range out.VariableFlags {
+		out.VariableFlags[k] = JobSubmissionRedacted
+	}
+	out.Variables = redactHCLVariables(out.Variables, true)
+	return out
+}
+
+// redactHCLVariables replaces the default values of the variable blocks, and
+// the attributes of the variables blocks, of the HCL document src. If
+// attrs is true the top level attributes are redacted as well, as in a
+// variables file. Documents that cannot be parsed are redacted entirely.
+func redactHCLVariables(src string, attrs bool) string {
+	if src == "" {
+		return src
+	}
+
+	f, diags := hclwrite.ParseConfig([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return JobSubmissionRedacted
+	}
+
+	redacted := cty.StringVal(JobSubmissionRedacted)
+	body := f.Body()
+	if attrs {
+		for name := range body.Attributes() {
+			body.SetAttributeValue(name, redacted)
+		}
+	}
+	for _, block := range body.Blocks() {
+		switch block.Type() {
+		case "variable":
+			if block.Body().GetAttribute("default") != nil {
+				block.Body().SetAttributeValue("default", redacted)
+			}
+		case "variables":
+			for name := range block.Body().Attributes() {
+				block.Body().SetAttributeValue(name, redacted)
+			}
+		}
+	}
+	return string(f.Bytes())
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobSubmission_Redact(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, (*JobSubmission)(nil).Redact())
+
+	source := `variable "password" {
+  type    = string
+  default = "hunter2"
+}
+
+variable "image" {
+  type = string
+}
+
+variables {
+  region = "us-east-1"
+}
+
+job "example" {
+  meta {
+    default = "kept"
+  }
+}
+`
+	sub := &JobSubmission{
+		Source:        source,
+		Format:        "hcl2",
+		VariableFlags: map[string]string{"image": "redis:7"},
+		Variables:     "password = \"p4ss\"\ncount = 3\n",
+		Namespace:     "default",
+		JobID:         "example",
+	}
+
+	out := sub.Redact()
+	must.Eq(t, `variable "password" {
+  type    = string
+  default = "<redacted>"
+}
+
+variable "image" {
+  type = string
+}
+
+variables {
+  region = "<redacted>"
+}
+
+job "example" {
+  meta {
+    default = "kept"
+  }
+}
+`, out.Source)
+	must.Eq(t, map[string]string{"image": JobSubmissionRedacted}, out.VariableFlags)
+	must.Eq(t, "password = \"<redacted>\"\ncount    = \"<redacted>\"\n", out.Variables)
+	must.Eq(t, "example", out.JobID)
+
+	// The submission is not modified
+	must.Eq(t, source, sub.Source)
+	must.Eq(t, "redis:7", sub.VariableFlags["image"])
+
+	// JSON sources have no variables, and unparsable variables are removed
+	sub = &JobSubmission{
+		Source:    `{"Job": {"ID": "example"}}`,
+		Format:    "json",
+		Variables: `{"password": "p4ss"`,
+	}
+	out = sub.Redact()
+	must.Eq(t, sub.Source, out.Source)
+	must.Eq(t, JobSubmissionRedacted, out.Variables)
+}
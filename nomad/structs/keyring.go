@@ -508,6 +508,54 @@ type KeyringDeleteRootKeyResponse struct {
 	WriteMeta
 }
 
+// KEKProviderHealth is the health of a KEK provider as last observed by a
+// server when wrapping or unwrapping root keys with it.
+type KEKProviderHealth struct {
+	// ID is the ID of the provider configuration, as in KEKProviderConfig.ID
+	ID       string
+	Provider string
+	Active   bool
+
+	// Healthy is false if the last operation with the provider failed, and
+	// Error is the error it returned.
+	Healthy bool
+	Error   string
+
+	// LastCheck is the time of the last operation with the provider, and is
+	// zero if the provider has not been used yet.
+	LastCheck time.Time
+}
+
+func (h *KEKProviderHealth) Copy() *KEKProviderHealth {
+	if h == nil {
+		return nil
+	}
+	nh := *h
+	return &nh
+}
+
+// KeyringHealthResponse is the response for Keyring.Health RPCs. The health is
+// the one of the keyring of the server that answered the request.
+type KeyringHealthResponse struct {
+	// Ready is true when all the root keys have been decrypted, and
+	// PendingKeys lists the IDs of the root keys waiting to be decrypted.
+	Ready       bool
+	PendingKeys []string
+
+	Providers []*KEKProviderHealth
+	QueryMeta
+}
+
+// KeyringReadyResponse is the response for Keyring.Ready RPCs. Ready is true
+// when the keyring of the server that answered the request has an active key
+// and all the root keys have been decrypted. Otherwise Error explains what the
+// keyring is waiting for.
+type KeyringReadyResponse struct {
+	Ready bool
+	Error string
+	QueryMeta
+}
+
 // KeyringListPublicResponse lists public key components of signing keys. Used
 // to build a JWKS endpoint.
 type KeyringListPublicResponse struct {
@@ -3,6 +3,11 @@
 
 package structs
 
+import (
+	"errors"
+	"time"
+)
+
 // NamespaceVaultConfiguration stores configuration about permissions to Vault
 // clusters for a namespace, for use with Nomad Enterprise.
 type NamespaceVaultConfiguration struct {
@@ -46,3 +51,61 @@ type NamespaceConsulConfiguration struct {
 	// This field cannot be used with Allowed.
 	Denied []string
 }
+
+// NamespacePreemptionConfiguration stores configuration about whether the
+// allocations of a namespace may be preempted to make room for allocations of
+// higher priority jobs.
+type NamespacePreemptionConfiguration struct {
+	// Protected prevents any allocation in this namespace from being
+	// preempted, regardless of the priority of its job.
+	Protected bool
+
+	// PriorityFloor prevents allocations of jobs with a priority at or above
+	// the floor from being preempted, while allocations of jobs with a lower
+	// priority remain preemptible. Zero disables the floor.
+	PriorityFloor int
+}
+
+func (n *NamespacePreemptionConfiguration) Validate() error {
+	if n == nil {
+		return nil
+	}
+	if n.PriorityFloor < 0 {
+		return errors.New("priority floor must not be negative")
+	}
+	return nil
+}
+
+// AllowsPreemption returns whether an allocation of a job with the given
+// priority may be preempted. A nil configuration allows all preemptions.
+func (n *NamespacePreemptionConfiguration) AllowsPreemption(priority int) bool {
+	if n == nil {
+		return true
+	}
+	if n.Protected {
+		return false
+	}
+	return n.PriorityFloor == 0 || priority < n.PriorityFloor
+}
+
+// NamespaceGCConfiguration stores the garbage collection thresholds of a
+// namespace, overriding the thresholds of the server configuration so that
+// the objects of high churn namespaces can be collected sooner, or kept
+// longer. Zero thresholds use the server configuration.
+type NamespaceGCConfiguration struct {
+	JobGCThreshold        time.Duration
+	EvalGCThreshold       time.Duration
+	BatchEvalGCThreshold  time.Duration
+	DeploymentGCThreshold time.Duration
+}
+
+func (n *NamespaceGCConfiguration) Validate() error {
+	if n == nil {
+		return nil
+	}
+	if n.JobGCThreshold < 0 || n.EvalGCThreshold < 0 ||
+		n.BatchEvalGCThreshold < 0 || n.DeploymentGCThreshold < 0 {
+		return errors.New("thresholds must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// NodeLifecycleStatusRunning is the status of a node lifecycle run that
+	// is cycling nodes.
+	NodeLifecycleStatusRunning = "running"
+
+	// NodeLifecycleStatusComplete is the status of a node lifecycle run whose
+	// nodes all completed.
+	NodeLifecycleStatusComplete = "complete"
+
+	// NodeLifecycleStatusFailed is the status of a node lifecycle run that
+	// stopped after one of its nodes failed.
+	NodeLifecycleStatusFailed = "failed"
+
+	// NodeLifecycleStatusAborted is the status of a node lifecycle run that
+	// was aborted by an operator.
+	NodeLifecycleStatusAborted = "aborted"
+)
+
+const (
+	// NodeLifecyclePhasePending is the phase of a node waiting for its batch.
+	NodeLifecyclePhasePending = "pending"
+
+	// NodeLifecyclePhaseCordon is the phase of a node being marked as
+	// ineligible for scheduling.
+	NodeLifecyclePhaseCordon = "cordon"
+
+	// NodeLifecyclePhaseDrain is the phase of a node being drained.
+	NodeLifecyclePhaseDrain = "drain"
+
+	// NodeLifecyclePhaseAction is the phase of a node being handed over to
+	// the custom action webhook.
+	NodeLifecyclePhaseAction = "action"
+
+	// NodeLifecyclePhaseReregister is the phase of a node that the custom
+	// action webhook was called for, until it registers again.
+	NodeLifecyclePhaseReregister = "reregister"
+
+	// NodeLifecyclePhaseUncordon is the phase of a node being marked as
+	// eligible for scheduling again.
+	NodeLifecyclePhaseUncordon = "uncordon"
+
+	// NodeLifecyclePhaseComplete is the phase of a node that completed.
+	NodeLifecyclePhaseComplete = "complete"
+
+	// NodeLifecyclePhaseFailed is the phase of a node that failed.
+	NodeLifecyclePhaseFailed = "failed"
+)
+
+const (
+	// NodeLifecycleDefaultDrainDeadline is the drain deadline of the nodes of
+	// a node lifecycle run if none is given.
+	NodeLifecycleDefaultDrainDeadline = time.Hour
+
+	// NodeLifecycleDefaultReregisterTimeout is the amount of time a node
+	// lifecycle run waits for a node to register again after calling the
+	// custom action webhook if none is given.
+	NodeLifecycleDefaultReregisterTimeout = 30 * time.Minute
+)
+
+// NodeLifecycleRun cycles the nodes of a node pool through maintenance, such
+// as an OS upgrade, in batches. Each node is cordoned, drained, handed over to
+// a custom action webhook, waited on until it registers again and uncordoned.
+// Runs are driven by the leader, and there is at most one run per node pool.
+// Starting a new run replaces the previous one once it's no longer running.
+type NodeLifecycleRun struct {
+	// ID is a generated UUID identifying the run.
+	ID string
+
+	NodePool string
+
+	// BatchSize is the number of nodes cycled at the same time.
+	BatchSize int
+
+	// DrainSpec is the drain applied to each node.
+	DrainSpec *DrainSpec
+
+	// WebhookURL is the URL of the custom action webhook called for each
+	// drained node. Nodes are uncordoned right after being drained if it's
+	// empty.
+	WebhookURL string
+
+	// ReregisterTimeout is the maximum amount of time to wait for a node to
+	// register again after the webhook was called.
+	ReregisterTimeout time.Duration
+
+	// Status is one of the NodeLifecycleStatus constants, and
+	// StatusDescription is a human readable description of it, such as the
+	// reason the run failed.
+	Status            string
+	StatusDescription string
+
+	// Nodes are the nodes of the run, in the order they are cycled.
+	Nodes []*NodeLifecycleNode
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// NodeLifecycleNode is the progress of a node in a node lifecycle run.
+type NodeLifecycleNode struct {
+	// NodeID is the ID of the node. It's updated if the node registers again
+	// with a new ID, for example after being reimaged.
+	NodeID string
+
+	// NodeName is the name of the node, used to recognize it once it
+	// registers again.
+	NodeName string
+
+	// NodeCreateIndex is the index at which the node was created, used to
+	// recognize a node that registered again with a new ID.
+	NodeCreateIndex uint64
+
+	// Phase is one of the NodeLifecyclePhase constants, and PhaseStartTime
+	// the Unix nanosecond timestamp at which the node entered it.
+	Phase          string
+	PhaseStartTime int64
+
+	// Error is the reason the node failed.
+	Error string
+}
+
+// Copy returns a deep copy of the run.
+func (r *NodeLifecycleRun) Copy() *NodeLifecycleRun {
+	if r == nil {
+		return nil
+	}
+	nr := new(NodeLifecycleRun)
+	*nr = *r
+	if r.DrainSpec != nil {
+		ds := *r.DrainSpec
+		nr.DrainSpec = &ds
+	}
+	if r.Nodes != nil {
+		nr.Nodes = make([]*NodeLifecycleNode, len(r.Nodes))
+		for i, n := range r.Nodes {
+			nn := *n
+			nr.Nodes[i] = &nn
+		}
+	}
+	return nr
+}
+
+// Terminal returns true if the run no longer cycles nodes.
+func (r *NodeLifecycleRun) Terminal() bool {
+	return r.Status != NodeLifecycleStatusRunning
+}
+
+// Active returns true if the node is being cycled.
+func (n *NodeLifecycleNode) Active() bool {
+	switch n.Phase {
+	case NodeLifecyclePhasePending, NodeLifecyclePhaseComplete, NodeLifecyclePhaseFailed:
+		return false
+	default:
+		return true
+	}
+}
+
+// NodeLifecycleStartRequest is used to start a node lifecycle run.
+type NodeLifecycleStartRequest struct {
+	NodePool string
+
+	// BatchSize is the number of nodes cycled at the same time. It defaults
+	// to 1.
+	BatchSize int
+
+	// DrainSpec is the drain applied to each node. It defaults to a drain
+	// with the default deadline.
+	DrainSpec *DrainSpec
+
+	// WebhookURL is the URL of the custom action webhook.
+	WebhookURL string
+
+	// ReregisterTimeout defaults to NodeLifecycleDefaultReregisterTimeout.
+	ReregisterTimeout time.Duration
+
+	WriteRequest
+}
+
+// Canonicalize sets the defaults of the request.
+func (r *NodeLifecycleStartRequest) Canonicalize() {
+	if r.BatchSize == 0 {
+		r.BatchSize = 1
+	}
+	if r.DrainSpec == nil {
+		r.DrainSpec = &DrainSpec{Deadline: NodeLifecycleDefaultDrainDeadline}
+	}
+	if r.ReregisterTimeout == 0 {
+		r.ReregisterTimeout = NodeLifecycleDefaultReregisterTimeout
+	}
+}
+
+// Validate returns an error if the request is invalid.
+func (r *NodeLifecycleStartRequest) Validate() error {
+	var mErr *multierror.Error
+	if r.NodePool == "" {
+		mErr = multierror.Append(mErr, errors.New("missing node pool"))
+	} else if r.NodePool == NodePoolAll {
+		mErr = multierror.Append(mErr, fmt.Errorf("node pool %q can't be cycled", NodePoolAll))
+	}
+	if r.BatchSize < 1 {
+		mErr = multierror.Append(mErr, errors.New("batch size must be greater than zero"))
+	}
+	if r.DrainSpec != nil && r.DrainSpec.Deadline < 0 {
+		mErr = multierror.Append(mErr, errors.New("drain deadline must not be negative"))
+	}
+	if r.ReregisterTimeout < 0 {
+		mErr = multierror.Append(mErr, errors.New("reregister timeout must not be negative"))
+	}
+	if r.WebhookURL != "" {
+		u, err := url.Parse(r.WebhookURL)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid webhook URL: %v", err))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			mErr = multierror.Append(mErr, errors.New("webhook URL must use http or https"))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NodeLifecycleAbortRequest is used to abort the running node lifecycle run
+// of a node pool.
+type NodeLifecycleAbortRequest struct {
+	NodePool string
+	WriteRequest
+}
+
+// NodeLifecycleRunResponse is used to respond to a request starting or
+// aborting a node lifecycle run.
+type NodeLifecycleRunResponse struct {
+	Run *NodeLifecycleRun
+	WriteMeta
+}
+
+// NodeLifecycleRunSpecificRequest is used to get the node lifecycle run of a
+// node pool.
+type NodeLifecycleRunSpecificRequest struct {
+	NodePool string
+	QueryOptions
+}
+
+// SingleNodeLifecycleRunResponse is used to return the node lifecycle run of
+// a node pool.
+type SingleNodeLifecycleRunResponse struct {
+	Run *NodeLifecycleRun
+	QueryMeta
+}
+
+// NodeLifecycleRunUpsertRequest is the Raft request used to write a node
+// lifecycle run.
+type NodeLifecycleRunUpsertRequest struct {
+	Run *NodeLifecycleRun
+	WriteRequest
+}
+
+// NodeLifecycleWebhookRequest is the body of the requests sent to the custom
+// action webhook of a node lifecycle run.
+type NodeLifecycleWebhookRequest struct {
+	RunID      string
+	NodeID     string
+	NodeName   string
+	NodePool   string
+	Datacenter string
+	Address    string
+}
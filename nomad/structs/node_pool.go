@@ -8,8 +8,11 @@ import (
 	"maps"
 	"regexp"
 	"sort"
+	"strconv"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"golang.org/x/crypto/blake2b"
 )
@@ -58,6 +61,20 @@ type NodePool struct {
 	// node pool.
 	SchedulerConfiguration *NodePoolSchedulerConfiguration
 
+	// MaxJobFeatureLevel is the most recent Nomad version whose job
+	// specification features may be required by the jobs of the node pool.
+	// Jobs with a more recent min_client_version are rejected. If empty, all
+	// versions are allowed.
+	MaxJobFeatureLevel string
+
+	// ReadinessGates are the jobs that must have a healthy allocation on a
+	// node of the pool before allocations of other jobs are placed on it.
+	ReadinessGates []*NodePoolReadinessGate
+
+	// PortConfiguration is the port configuration applied to all the nodes
+	// of the pool, overriding their client configuration.
+	PortConfiguration *NodePoolPortConfiguration
+
 	// Hash is the hash of the node pool which is used to efficiently diff when
 	// we replicate pools across regions.
 	Hash []byte
@@ -82,8 +99,31 @@ func (n *NodePool) Validate() error {
 		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxNodePoolDescriptionLength))
 	}
 
+	if n.MaxJobFeatureLevel != "" {
+		if _, err := version.NewVersion(n.MaxJobFeatureLevel); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid max job feature level %q: %v", n.MaxJobFeatureLevel, err))
+		}
+	}
+
+	seenGates := make(map[NamespacedID]struct{}, len(n.ReadinessGates))
+	for i, gate := range n.ReadinessGates {
+		if gate == nil || gate.JobID == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("readiness gate %d is missing a job ID", i+1))
+			continue
+		}
+		id := gate.NamespacedID()
+		if _, ok := seenGates[id]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("duplicate readiness gate for job %q in namespace %q", id.ID, id.Namespace))
+		}
+		seenGates[id] = struct{}{}
+	}
+
 	mErr = multierror.Append(mErr, n.SchedulerConfiguration.Validate())
 
+	if err := n.PortConfiguration.Validate(); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid port configuration: %v", err))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -97,6 +137,8 @@ func (n *NodePool) Copy() *NodePool {
 	*nc = *n
 	nc.Meta = maps.Clone(nc.Meta)
 	nc.SchedulerConfiguration = nc.SchedulerConfiguration.Copy()
+	nc.ReadinessGates = helper.CopySlice(n.ReadinessGates)
+	nc.PortConfiguration = nc.PortConfiguration.Copy()
 
 	nc.Hash = make([]byte, len(n.Hash))
 	copy(nc.Hash, n.Hash)
@@ -135,6 +177,42 @@ func (n *NodePool) MemoryOversubscriptionEnabled(global *SchedulerConfiguration)
 	return memOversubEnabled
 }
 
+// AllowsJobFeatureLevel returns an error if jobs of the node pool may not
+// require clients of the given version.
+func (n *NodePool) AllowsJobFeatureLevel(minClientVersion string) error {
+	if n == nil || n.MaxJobFeatureLevel == "" || minClientVersion == "" {
+		return nil
+	}
+
+	maxVersion, err := version.NewVersion(n.MaxJobFeatureLevel)
+	if err != nil {
+		return fmt.Errorf("invalid max job feature level %q: %v", n.MaxJobFeatureLevel, err)
+	}
+	minVersion, err := version.NewVersion(minClientVersion)
+	if err != nil {
+		return fmt.Errorf("invalid min client version %q: %v", minClientVersion, err)
+	}
+	if minVersion.GreaterThan(maxVersion) {
+		return fmt.Errorf("min client version %s is more recent than the max job feature level %s of node pool %q",
+			minClientVersion, n.MaxJobFeatureLevel, n.Name)
+	}
+	return nil
+}
+
+// ReadinessGate returns the readiness gate of the node pool for the job, or nil
+// if the job is not a readiness gate.
+func (n *NodePool) ReadinessGate(namespace, jobID string) *NodePoolReadinessGate {
+	if n == nil {
+		return nil
+	}
+	for _, gate := range n.ReadinessGates {
+		if gate.NamespacedID() == NewNamespacedID(jobID, namespace) {
+			return gate
+		}
+	}
+	return nil
+}
+
 // SetHash is used to compute and set the hash of node pool
 func (n *NodePool) SetHash() []byte {
 	// Initialize a 256bit Blake2 hash (32 bytes)
@@ -146,6 +224,16 @@ func (n *NodePool) SetHash() []byte {
 	// Write all the user set fields
 	_, _ = hash.Write([]byte(n.Name))
 	_, _ = hash.Write([]byte(n.Description))
+	_, _ = hash.Write([]byte(n.MaxJobFeatureLevel))
+	for _, gate := range n.ReadinessGates {
+		_, _ = hash.Write([]byte(gate.Namespace))
+		_, _ = hash.Write([]byte(gate.JobID))
+	}
+	if n.PortConfiguration != nil {
+		_, _ = hash.Write([]byte(strconv.Itoa(n.PortConfiguration.MinDynamicPort)))
+		_, _ = hash.Write([]byte(strconv.Itoa(n.PortConfiguration.MaxDynamicPort)))
+		_, _ = hash.Write([]byte(n.PortConfiguration.ReservedPorts))
+	}
 	if n.SchedulerConfiguration != nil {
 		_, _ = hash.Write([]byte(n.SchedulerConfiguration.SchedulerAlgorithm))
 
@@ -179,6 +267,140 @@ func (n *NodePool) SetHash() []byte {
 	return hashVal
 }
 
+// ApplyPortConfiguration overrides the dynamic port range and reserved ports
+// of the node with the port configuration of the node pool. It returns an
+// error if the resulting port configuration of the node is invalid.
+func (n *NodePool) ApplyPortConfiguration(node *Node) error {
+	if n == nil || n.PortConfiguration == nil {
+		return nil
+	}
+	config := n.PortConfiguration
+
+	if node.NodeResources != nil {
+		if config.MinDynamicPort > 0 {
+			node.NodeResources.MinDynamicPort = config.MinDynamicPort
+		}
+		if config.MaxDynamicPort > 0 {
+			node.NodeResources.MaxDynamicPort = config.MaxDynamicPort
+		}
+		// Unset ports use the same defaults as the network index.
+		minPort, maxPort := node.NodeResources.MinDynamicPort, node.NodeResources.MaxDynamicPort
+		if minPort == 0 {
+			minPort = DefaultMinDynamicPort
+		}
+		if maxPort == 0 {
+			maxPort = DefaultMaxDynamicPort
+		}
+		if minPort > maxPort {
+			return fmt.Errorf("invalid dynamic port range %d-%d after applying the port configuration of node pool %q",
+				minPort, maxPort, n.Name)
+		}
+	}
+
+	if config.ReservedPorts != "" {
+		if node.ReservedResources == nil {
+			node.ReservedResources = &NodeReservedResources{}
+		}
+		node.ReservedResources.Networks.ReservedHostPorts = config.ReservedPorts
+	}
+	return nil
+}
+
+// NodePoolPortConfiguration is the port configuration applied to the nodes of
+// a node pool. Zero values keep the client configuration of the nodes.
+type NodePoolPortConfiguration struct {
+	// MinDynamicPort and MaxDynamicPort override the dynamic port range of
+	// the nodes.
+	MinDynamicPort int
+	MaxDynamicPort int
+
+	// ReservedPorts overrides the reserved host ports of the nodes, in the
+	// same format as the reserved_ports client configuration.
+	ReservedPorts string
+}
+
+// Copy returns a copy of the port configuration.
+func (c *NodePoolPortConfiguration) Copy() *NodePoolPortConfiguration {
+	if c == nil {
+		return nil
+	}
+	nc := *c
+	return &nc
+}
+
+// Validate returns an error if the port configuration is invalid.
+func (c *NodePoolPortConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if c.MinDynamicPort < 0 || c.MinDynamicPort > MaxValidPort {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid min dynamic port %d", c.MinDynamicPort))
+	}
+	if c.MaxDynamicPort < 0 || c.MaxDynamicPort > MaxValidPort {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid max dynamic port %d", c.MaxDynamicPort))
+	}
+	if c.MinDynamicPort > 0 && c.MaxDynamicPort > 0 && c.MinDynamicPort > c.MaxDynamicPort {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid dynamic port range %d-%d", c.MinDynamicPort, c.MaxDynamicPort))
+	}
+	if c.ReservedPorts != "" {
+		if _, err := ParsePortRanges(c.ReservedPorts); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid reserved ports %q: %v", c.ReservedPorts, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NodePoolReadinessGate is a job, usually a system job installing node-level
+// dependencies such as CNI plugins or daemons, that must have a healthy
+// allocation on a node of the pool before allocations of other jobs are placed
+// on the node.
+type NodePoolReadinessGate struct {
+	// Namespace is the namespace of the job. If empty, the default namespace
+	// is used.
+	Namespace string
+
+	// JobID is the ID of the job.
+	JobID string
+}
+
+// Copy returns a copy of the readiness gate.
+func (g *NodePoolReadinessGate) Copy() *NodePoolReadinessGate {
+	if g == nil {
+		return nil
+	}
+	gc := *g
+	return &gc
+}
+
+// NamespacedID returns the namespaced ID of the readiness gate job.
+func (g *NodePoolReadinessGate) NamespacedID() NamespacedID {
+	namespace := g.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return NewNamespacedID(g.JobID, namespace)
+}
+
+// Ready returns true if one of the allocations is a running allocation of the
+// readiness gate job that is not waiting for or failing its health checks.
+func (g *NodePoolReadinessGate) Ready(allocs []*Allocation) bool {
+	id := g.NamespacedID()
+	for _, alloc := range allocs {
+		if alloc.JobNamespacedID() != id ||
+			alloc.ClientStatus != AllocClientStatusRunning ||
+			alloc.ServerTerminalStatus() {
+			continue
+		}
+		if alloc.DeploymentStatus != nil && !alloc.DeploymentStatus.IsHealthy() {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 // NodePoolSchedulerConfiguration is the scheduler confinguration applied to a
 // node pool.
 //
@@ -211,6 +433,21 @@ func (n *NodePoolSchedulerConfiguration) Copy() *NodePoolSchedulerConfiguration
 	return nc
 }
 
+// Validate returns an error if the node pool scheduler configuration is
+// invalid.
+func (n *NodePoolSchedulerConfiguration) Validate() error {
+	if n == nil {
+		return nil
+	}
+
+	switch n.SchedulerAlgorithm {
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	default:
+		return fmt.Errorf("invalid scheduler algorithm %q", n.SchedulerAlgorithm)
+	}
+	return nil
+}
+
 // NodePoolListRequest is used to list node pools.
 type NodePoolListRequest struct {
 	QueryOptions
@@ -23,6 +23,8 @@ func TestNodePool_Copy(t *testing.T) {
 			SchedulerAlgorithm:            SchedulerAlgorithmSpread,
 			MemoryOversubscriptionEnabled: pointer.Of(false),
 		},
+		ReadinessGates:    []*NodePoolReadinessGate{{JobID: "cni"}},
+		PortConfiguration: &NodePoolPortConfiguration{ReservedPorts: "22"},
 	}
 	poolCopy := pool.Copy()
 	poolCopy.Name = "copy"
@@ -31,10 +33,14 @@ func TestNodePool_Copy(t *testing.T) {
 	poolCopy.Meta["new_key"] = "true"
 	poolCopy.SchedulerConfiguration.SchedulerAlgorithm = SchedulerAlgorithmBinpack
 	poolCopy.SchedulerConfiguration.MemoryOversubscriptionEnabled = pointer.Of(true)
+	poolCopy.ReadinessGates[0].JobID = "copy"
+	poolCopy.PortConfiguration.ReservedPorts = "22,80"
 
 	must.NotEq(t, pool, poolCopy)
 	must.NotEq(t, pool.Meta, poolCopy.Meta)
 	must.NotEq(t, pool.SchedulerConfiguration, poolCopy.SchedulerConfiguration)
+	must.NotEq(t, pool.ReadinessGates, poolCopy.ReadinessGates)
+	must.NotEq(t, pool.PortConfiguration, poolCopy.PortConfiguration)
 }
 
 func TestNodePool_Validate(t *testing.T) {
@@ -74,6 +80,85 @@ func TestNodePool_Validate(t *testing.T) {
 			},
 			expectedErr: "description longer",
 		},
+		{
+			name: "invalid max job feature level",
+			pool: &NodePool{
+				Name:               "valid",
+				MaxJobFeatureLevel: "latest",
+			},
+			expectedErr: "invalid max job feature level",
+		},
+		{
+			name: "readiness gate missing job ID",
+			pool: &NodePool{
+				Name:           "valid",
+				ReadinessGates: []*NodePoolReadinessGate{{Namespace: "infra"}},
+			},
+			expectedErr: "readiness gate 1 is missing a job ID",
+		},
+		{
+			name: "duplicate readiness gate",
+			pool: &NodePool{
+				Name: "valid",
+				ReadinessGates: []*NodePoolReadinessGate{
+					{JobID: "cni"},
+					{Namespace: DefaultNamespace, JobID: "cni"},
+				},
+			},
+			expectedErr: `duplicate readiness gate for job "cni" in namespace "default"`,
+		},
+		{
+			name: "valid port configuration",
+			pool: &NodePool{
+				Name: "valid",
+				PortConfiguration: &NodePoolPortConfiguration{
+					MinDynamicPort: 20000,
+					MaxDynamicPort: 32000,
+					ReservedPorts:  "22,80,8000-8100",
+				},
+			},
+		},
+		{
+			name: "invalid dynamic port range",
+			pool: &NodePool{
+				Name: "valid",
+				PortConfiguration: &NodePoolPortConfiguration{
+					MinDynamicPort: 32000,
+					MaxDynamicPort: 20000,
+				},
+			},
+			expectedErr: "invalid dynamic port range 32000-20000",
+		},
+		{
+			name: "invalid reserved ports",
+			pool: &NodePool{
+				Name: "valid",
+				PortConfiguration: &NodePoolPortConfiguration{
+					ReservedPorts: "80-22",
+				},
+			},
+			expectedErr: "invalid reserved ports",
+		},
+		{
+			name: "valid scheduler configuration",
+			pool: &NodePool{
+				Name: "valid",
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+					SchedulerAlgorithm:            SchedulerAlgorithmSpread,
+					MemoryOversubscriptionEnabled: pointer.Of(true),
+				},
+			},
+		},
+		{
+			name: "invalid scheduler algorithm",
+			pool: &NodePool{
+				Name: "valid",
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+					SchedulerAlgorithm: "random",
+				},
+			},
+			expectedErr: `invalid scheduler algorithm "random"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -128,6 +213,198 @@ func TestNodePool_IsBuiltIn(t *testing.T) {
 	}
 }
 
+func TestNodePool_AllowsJobFeatureLevel(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name             string
+		maxLevel         string
+		minClientVersion string
+		expectedErr      string
+	}{
+		{
+			name:             "no max level",
+			minClientVersion: "1.9.0",
+		},
+		{
+			name:     "no min client version",
+			maxLevel: "1.7.0",
+		},
+		{
+			name:             "older version",
+			maxLevel:         "1.7.0",
+			minClientVersion: "1.6.2",
+		},
+		{
+			name:             "same version",
+			maxLevel:         "1.7.0",
+			minClientVersion: "1.7.0",
+		},
+		{
+			name:             "more recent version",
+			maxLevel:         "1.7.0",
+			minClientVersion: "1.9.0",
+			expectedErr:      `min client version 1.9.0 is more recent than the max job feature level 1.7.0 of node pool "legacy"`,
+		},
+		{
+			name:             "invalid min client version",
+			maxLevel:         "1.7.0",
+			minClientVersion: "latest",
+			expectedErr:      "invalid min client version",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &NodePool{Name: "legacy", MaxJobFeatureLevel: tc.maxLevel}
+			err := pool.AllowsJobFeatureLevel(tc.minClientVersion)
+
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodePool_ReadinessGate(t *testing.T) {
+	ci.Parallel(t)
+
+	pool := &NodePool{
+		Name: "gated",
+		ReadinessGates: []*NodePoolReadinessGate{
+			{JobID: "cni"},
+			{Namespace: "infra", JobID: "agent"},
+		},
+	}
+	must.Eq(t, pool.ReadinessGates[0], pool.ReadinessGate(DefaultNamespace, "cni"))
+	must.Eq(t, pool.ReadinessGates[1], pool.ReadinessGate("infra", "agent"))
+	must.Nil(t, pool.ReadinessGate(DefaultNamespace, "agent"))
+	must.Nil(t, pool.ReadinessGate("infra", "web"))
+
+	var nilPool *NodePool
+	must.Nil(t, nilPool.ReadinessGate(DefaultNamespace, "cni"))
+}
+
+func TestNodePoolReadinessGate_Ready(t *testing.T) {
+	ci.Parallel(t)
+
+	gate := &NodePoolReadinessGate{JobID: "cni"}
+	alloc := func(fn func(*Allocation)) *Allocation {
+		a := &Allocation{
+			Namespace:     DefaultNamespace,
+			JobID:         "cni",
+			ClientStatus:  AllocClientStatusRunning,
+			DesiredStatus: AllocDesiredStatusRun,
+		}
+		if fn != nil {
+			fn(a)
+		}
+		return a
+	}
+
+	testCases := []struct {
+		name   string
+		allocs []*Allocation
+		ready  bool
+	}{
+		{
+			name: "no allocs",
+		},
+		{
+			name:   "running",
+			allocs: []*Allocation{alloc(nil)},
+			ready:  true,
+		},
+		{
+			name: "other job",
+			allocs: []*Allocation{alloc(func(a *Allocation) {
+				a.JobID = "web"
+			})},
+		},
+		{
+			name: "pending",
+			allocs: []*Allocation{alloc(func(a *Allocation) {
+				a.ClientStatus = AllocClientStatusPending
+			})},
+		},
+		{
+			name: "stopping",
+			allocs: []*Allocation{alloc(func(a *Allocation) {
+				a.DesiredStatus = AllocDesiredStatusStop
+			})},
+		},
+		{
+			name: "waiting for health",
+			allocs: []*Allocation{alloc(func(a *Allocation) {
+				a.DeploymentStatus = &AllocDeploymentStatus{}
+			})},
+		},
+		{
+			name: "healthy",
+			allocs: []*Allocation{alloc(func(a *Allocation) {
+				a.DeploymentStatus = &AllocDeploymentStatus{Healthy: pointer.Of(true)}
+			})},
+			ready: true,
+		},
+		{
+			name: "one of many running",
+			allocs: []*Allocation{
+				alloc(func(a *Allocation) { a.ClientStatus = AllocClientStatusFailed }),
+				alloc(nil),
+			},
+			ready: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.ready, gate.Ready(tc.allocs))
+		})
+	}
+}
+
+func TestNodePool_ApplyPortConfiguration(t *testing.T) {
+	ci.Parallel(t)
+
+	newNode := func() *Node {
+		return &Node{
+			NodeResources: &NodeResources{
+				MinDynamicPort: 20000,
+				MaxDynamicPort: 32000,
+			},
+			ReservedResources: &NodeReservedResources{
+				Networks: NodeReservedNetworkResources{ReservedHostPorts: "22"},
+			},
+		}
+	}
+
+	// Pools without port configuration leave the node untouched
+	node := newNode()
+	must.NoError(t, (*NodePool)(nil).ApplyPortConfiguration(node))
+	must.NoError(t, (&NodePool{Name: "dev"}).ApplyPortConfiguration(node))
+	must.Eq(t, newNode(), node)
+
+	// Set fields override the node configuration
+	pool := &NodePool{
+		Name: "dev",
+		PortConfiguration: &NodePoolPortConfiguration{
+			MinDynamicPort: 25000,
+			ReservedPorts:  "22,80",
+		},
+	}
+	must.NoError(t, pool.ApplyPortConfiguration(node))
+	must.Eq(t, 25000, node.NodeResources.MinDynamicPort)
+	must.Eq(t, 32000, node.NodeResources.MaxDynamicPort)
+	must.Eq(t, "22,80", node.ReservedResources.Networks.ReservedHostPorts)
+
+	// The resulting range must be valid
+	pool.PortConfiguration.MinDynamicPort = 40000
+	err := pool.ApplyPortConfiguration(newNode())
+	must.ErrorContains(t, err, `invalid dynamic port range 40000-32000 after applying the port configuration of node pool "dev"`)
+}
+
 func TestNodePool_MemoryOversubscriptionEnabled(t *testing.T) {
 	ci.Parallel(t)
 
@@ -211,6 +211,28 @@ const (
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
 )
 
+// EvalBrokerDequeueMode is an enum string that encapsulates the valid options
+// for the order in which the eval broker hands evaluations to the scheduler
+// workers.
+type EvalBrokerDequeueMode string
+
+const (
+	// EvalBrokerDequeueModePriority indicates that the eval broker should
+	// dequeue the highest priority evaluation first, and evaluations of the
+	// same priority in the order they were created. This is the default.
+	EvalBrokerDequeueModePriority EvalBrokerDequeueMode = "priority"
+
+	// EvalBrokerDequeueModeNamespaceFair indicates that the eval broker should
+	// share the scheduler workers evenly between the namespaces with ready
+	// evaluations.
+	EvalBrokerDequeueModeNamespaceFair EvalBrokerDequeueMode = "namespace_fair"
+
+	// EvalBrokerDequeueModePriorityFair indicates that the eval broker should
+	// share the scheduler workers between the job priorities with ready
+	// evaluations in proportion to the priority.
+	EvalBrokerDequeueModePriorityFair EvalBrokerDequeueMode = "priority_fair"
+)
+
 // SchedulerConfiguration is the config for controlling scheduler behavior
 type SchedulerConfiguration struct {
 	// SchedulerAlgorithm lets you select between available scheduling algorithms.
@@ -233,6 +255,17 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// PlanEvaluatePoolMin and PlanEvaluatePoolMax bound the number of workers
+	// used by the leader to evaluate plans. Zero values fall back to the
+	// plan_evaluate_pool_min and plan_evaluate_pool_max server options.
+	PlanEvaluatePoolMin int `hcl:"plan_evaluate_pool_min"`
+	PlanEvaluatePoolMax int `hcl:"plan_evaluate_pool_max"`
+
+	// EvalBrokerDequeueMode controls the order in which the eval broker hands
+	// evaluations to the scheduler workers. An empty value is equivalent to
+	// EvalBrokerDequeueModePriority.
+	EvalBrokerDequeueMode EvalBrokerDequeueMode `hcl:"eval_broker_dequeue_mode"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -292,6 +325,21 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	if s.PlanEvaluatePoolMin < 0 || s.PlanEvaluatePoolMax < 0 {
+		return fmt.Errorf("plan evaluate pool bounds must be greater than or equal to 0")
+	}
+	if s.PlanEvaluatePoolMax > 0 && s.PlanEvaluatePoolMin > s.PlanEvaluatePoolMax {
+		return fmt.Errorf("plan evaluate pool min %d must be less than or equal to max %d",
+			s.PlanEvaluatePoolMin, s.PlanEvaluatePoolMax)
+	}
+
+	switch s.EvalBrokerDequeueMode {
+	case "", EvalBrokerDequeueModePriority, EvalBrokerDequeueModeNamespaceFair,
+		EvalBrokerDequeueModePriorityFair:
+	default:
+		return fmt.Errorf("invalid eval broker dequeue mode: %v", s.EvalBrokerDequeueMode)
+	}
+
 	return nil
 }
 
@@ -385,3 +433,256 @@ type UpgradeCheckVaultWorkloadIdentityResponse struct {
 
 	QueryMeta
 }
+
+// PlanRejectionNode is a node tracked by the plan rejection tracker.
+type PlanRejectionNode struct {
+	NodeID string
+
+	// Score is the number of plan rejections for the node within the
+	// tracker time window.
+	Score int
+
+	// LastRejection is the time of the most recent plan rejection recorded
+	// for the node.
+	LastRejection time.Time
+}
+
+// PlanRejectionTrackerResponse is used to return the state of the plan
+// rejection tracker of the leader.
+type PlanRejectionTrackerResponse struct {
+	// Enabled is true if the tracker marks nodes as ineligible.
+	Enabled bool
+
+	// Threshold is the number of rejections within the time window after
+	// which a node is marked as ineligible.
+	Threshold int
+
+	// Window is the time window used to compute node scores.
+	Window time.Duration
+
+	Nodes []*PlanRejectionNode
+
+	QueryMeta
+}
+
+// PlanRejectionTrackerResetRequest is used to clear the plan rejection
+// history of a node, or of all nodes if NodeID is empty.
+type PlanRejectionTrackerResetRequest struct {
+	NodeID string
+
+	WriteRequest
+}
+
+// PlanRejectionTrackerResetResponse is used to return the number of nodes
+// removed from the plan rejection tracker.
+type PlanRejectionTrackerResetResponse struct {
+	Removed int
+
+	WriteMeta
+}
+
+const (
+	// ReplicationConflictNamespace is the type of replication conflicts of
+	// namespaces.
+	ReplicationConflictNamespace = "namespace"
+
+	// ReplicationConflictNodePool is the type of replication conflicts of
+	// node pools.
+	ReplicationConflictNodePool = "node_pool"
+)
+
+// ReplicationConflict is an object of the authoritative region that the
+// leader of a federated region can't replicate, such as a namespace deleted in
+// the authoritative region that still has jobs in the federated region.
+type ReplicationConflict struct {
+	// Type is the type of the object, either namespace or node_pool.
+	Type string
+
+	// Name is the name of the object.
+	Name string
+
+	// Reason describes why the object can't be replicated.
+	Reason string
+
+	// Since is the time the conflict was first detected by the leader.
+	Since time.Time
+}
+
+// ReplicationConflictsResponse is used to return the replication conflicts
+// detected by the leader of a region.
+type ReplicationConflictsResponse struct {
+	Conflicts []*ReplicationConflict
+
+	QueryMeta
+}
+
+// PendingPlanStub is used to describe a plan waiting in the plan queue.
+type PendingPlanStub struct {
+	EvalID    string
+	Namespace string
+	JobID     string
+	Priority  int
+
+	// EffectivePriority is the priority the plan is ordered by in the queue,
+	// which is boosted above Priority the longer the plan waits when plan
+	// queue aging is enabled.
+	EffectivePriority int
+
+	// EnqueueTime is the time the plan was submitted to the queue and
+	// WaitTime is how long it has been waiting so far.
+	EnqueueTime time.Time
+	WaitTime    time.Duration
+}
+
+// PlanQueueWaitTime is the distribution of the time recently dequeued plans
+// spent waiting in the plan queue.
+type PlanQueueWaitTime struct {
+	// Samples is the number of wait times used to compute the distribution.
+	Samples int
+
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// PlanQueueStatus is a snapshot of the plan queue of the leader.
+type PlanQueueStatus struct {
+	// Enabled is true if the queue accepts plans, which is only the case on
+	// the leader.
+	Enabled bool
+
+	Depth           int
+	DepthByPriority map[int]int
+
+	// Pending are the plans waiting in the queue, in dequeue order.
+	Pending []*PendingPlanStub
+
+	WaitTime PlanQueueWaitTime
+}
+
+// PlanQueueStatusResponse is used to return the status of the plan queue.
+type PlanQueueStatusResponse struct {
+	PlanQueue *PlanQueueStatus
+	QueryMeta
+}
+
+// SchedulerSimulateRequest is used to simulate scheduling a job against the
+// current state of the cluster without committing the results.
+type SchedulerSimulateRequest struct {
+	Job *Job
+	WriteRequest
+}
+
+// SimulatedAllocation describes an allocation placed, stopped or preempted by
+// a scheduler simulation.
+type SimulatedAllocation struct {
+	ID        string
+	Name      string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+
+	// Metrics are the scheduling metrics of placed allocations, which
+	// describe the nodes that were evaluated and why some were filtered.
+	Metrics *AllocMetric
+}
+
+// SchedulerSimulation is the outcome of running the scheduler for a job and
+// evaluating the resulting plan, without committing it.
+type SchedulerSimulation struct {
+	// Placements are the allocations that would be placed or updated, Stops
+	// the allocations that would be stopped and Preemptions the allocations
+	// of other jobs that would be preempted.
+	Placements  []*SimulatedAllocation
+	Stops       []*SimulatedAllocation
+	Preemptions []*SimulatedAllocation
+
+	// FailedTGAllocs are the metrics of the task groups that could not be
+	// placed, keyed by task group name.
+	FailedTGAllocs map[string]*AllocMetric
+
+	// NodeRejections maps the ID of each node whose part of the plan was
+	// rejected by the plan applier to the reason it was rejected.
+	NodeRejections map[string]string
+
+	// Warnings contains any warnings about the job specification.
+	Warnings string
+}
+
+// SchedulerSimulateResponse is used to return the result of a scheduler
+// simulation.
+type SchedulerSimulateResponse struct {
+	Simulation *SchedulerSimulation
+	QueryMeta
+}
+
+// EvalBrokerSchedulerStatus is the number of evaluations of one scheduler
+// type in each state of the eval broker.
+type EvalBrokerSchedulerStatus struct {
+	Ready   int
+	Unacked int
+	Pending int
+	Waiting int
+
+	// OldestReadyAge and OldestPendingAge are the time since the oldest ready
+	// and the oldest pending evaluation were created.
+	OldestReadyAge   time.Duration
+	OldestPendingAge time.Duration
+}
+
+// EvalBrokerStatus is a snapshot of the eval broker of the leader.
+type EvalBrokerStatus struct {
+	// Enabled is true if the broker hands out evaluations, which is only the
+	// case on the leader while the broker is not paused.
+	Enabled     bool
+	DequeueMode EvalBrokerDequeueMode
+
+	// Ready evaluations wait for a scheduler worker, Unacked evaluations are
+	// being processed, Pending evaluations wait for another evaluation of the
+	// same job to complete, and Waiting evaluations wait for a delay to pass.
+	TotalReady      int
+	TotalUnacked    int
+	TotalPending    int
+	TotalWaiting    int
+	TotalCancelable int
+	ByScheduler     map[string]*EvalBrokerSchedulerStatus
+
+	OldestReadyAge   time.Duration
+	OldestPendingAge time.Duration
+
+	// The per minute rates are over the last minute and the totals since
+	// the broker was enabled. Requeues are evaluations reblocked by a
+	// scheduler and enqueued again once the scheduler completed.
+	DequeuesPerMinute int
+	NacksPerMinute    int
+	RequeuesPerMinute int
+	TotalDequeues     uint64
+	TotalNacks        uint64
+	TotalRequeues     uint64
+}
+
+// EvalBrokerStatusResponse is used to return the status of the eval broker.
+type EvalBrokerStatusResponse struct {
+	EvalBroker *EvalBrokerStatus
+	QueryMeta
+}
+
+// StateTableUsage is the number of objects and their approximate size in a
+// table of the state store.
+type StateTableUsage struct {
+	Name    string
+	Objects int
+
+	// SizeBytes is the size of the msgpack encoding of the objects, which
+	// approximates the memory they use.
+	SizeBytes int64
+}
+
+// StateUsageResponse is used to return the usage of the state store tables.
+type StateUsageResponse struct {
+	Tables []*StateTableUsage
+	QueryMeta
+}
@@ -106,3 +106,25 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate_PlanEvaluatePool(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (&SchedulerConfiguration{PlanEvaluatePoolMin: 4}).Validate())
+	must.NoError(t, (&SchedulerConfiguration{PlanEvaluatePoolMin: 2, PlanEvaluatePoolMax: 4}).Validate())
+	must.ErrorContains(t, (&SchedulerConfiguration{PlanEvaluatePoolMax: -1}).Validate(),
+		"must be greater than or equal to 0")
+	must.ErrorContains(t, (&SchedulerConfiguration{PlanEvaluatePoolMin: 8, PlanEvaluatePoolMax: 4}).Validate(),
+		"plan evaluate pool min 8 must be less than or equal to max 4")
+}
+
+func TestSchedulerConfiguration_Validate_EvalBrokerDequeueMode(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (&SchedulerConfiguration{}).Validate())
+	must.NoError(t, (&SchedulerConfiguration{EvalBrokerDequeueMode: EvalBrokerDequeueModePriority}).Validate())
+	must.NoError(t, (&SchedulerConfiguration{EvalBrokerDequeueMode: EvalBrokerDequeueModeNamespaceFair}).Validate())
+	must.NoError(t, (&SchedulerConfiguration{EvalBrokerDequeueMode: EvalBrokerDequeueModePriorityFair}).Validate())
+	must.ErrorContains(t, (&SchedulerConfiguration{EvalBrokerDequeueMode: "round_robin"}).Validate(),
+		"invalid eval broker dequeue mode: round_robin")
+}
@@ -36,6 +36,19 @@ const (
 	All Context = "all"
 )
 
+// FuzzyRanking defines the order of the matches of each Context in a fuzzy
+// search response.
+type FuzzyRanking string
+
+const (
+	// FuzzyRankingScore orders matches by the position of the search text in
+	// the name, then by the length of the name. This is the default.
+	FuzzyRankingScore FuzzyRanking = "score"
+
+	// FuzzyRankingName orders matches alphabetically by name.
+	FuzzyRankingName FuzzyRanking = "name"
+)
+
 // SearchConfig is used in servers to configure search API options.
 type SearchConfig struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -141,5 +154,18 @@ type FuzzySearchRequest struct {
 	// all Contexts types are queried for matching.
 	Context Context
 
+	// Contexts restricts the response to matches of the given types, and
+	// takes precedence over Context when set. The subtypes of jobs may be
+	// given to match against jobs without returning the jobs themselves.
+	Contexts []Context
+
+	// Ranking controls the order of the matches of each Context. Defaults to
+	// FuzzyRankingScore.
+	Ranking FuzzyRanking
+
+	// Limit caps the number of matches returned for each Context, within the
+	// results limit configured on the servers.
+	Limit int
+
 	QueryOptions
 }
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// ServiceGrantUpsertRPCMethod is the RPC method for creating or updating
+	// service grants.
+	//
+	// Args: ServiceGrantUpsertRequest
+	// Reply: ServiceGrantUpsertResponse
+	ServiceGrantUpsertRPCMethod = "ServiceGrant.Upsert"
+
+	// ServiceGrantDeleteRPCMethod is the RPC method for deleting service
+	// grants by their ID.
+	//
+	// Args: ServiceGrantDeleteRequest
+	// Reply: ServiceGrantDeleteResponse
+	ServiceGrantDeleteRPCMethod = "ServiceGrant.Delete"
+
+	// ServiceGrantListRPCMethod is the RPC method for listing service grants.
+	//
+	// Args: ServiceGrantListRequest
+	// Reply: ServiceGrantListResponse
+	ServiceGrantListRPCMethod = "ServiceGrant.List"
+
+	// ServiceGrantAllServices is the service name of grants that expose all
+	// the services of their namespace.
+	ServiceGrantAllServices = "*"
+
+	// maxServiceGrantDescriptionLength is the maximum length allowed for a
+	// service grant description.
+	maxServiceGrantDescriptionLength = 256
+)
+
+// ServiceGrant allows the workloads of a namespace to look up the Nomad
+// services registered in another namespace. Without a grant, workloads can
+// only look up the services of their own namespace.
+type ServiceGrant struct {
+	// ID is the unique identifier of the grant.
+	ID string
+
+	// Namespace is the namespace of the services exposed by the grant.
+	Namespace string
+
+	// ServiceName is the name of the service exposed by the grant, or "*" to
+	// expose all the services of the namespace.
+	ServiceName string
+
+	// GrantedNamespace is the namespace whose workloads are allowed to look
+	// up the services.
+	GrantedNamespace string
+
+	// Description is the human-friendly description of the grant.
+	Description string
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the service grant.
+func (g *ServiceGrant) Copy() *ServiceGrant {
+	if g == nil {
+		return nil
+	}
+	ng := *g
+	return &ng
+}
+
+// Validate returns an error if the service grant is invalid.
+func (g *ServiceGrant) Validate() error {
+	var mErr *multierror.Error
+
+	if g.Namespace == "" {
+		mErr = multierror.Append(mErr, errors.New("missing namespace"))
+	}
+	if g.ServiceName == "" {
+		mErr = multierror.Append(mErr, errors.New("missing service name"))
+	}
+	if g.GrantedNamespace == "" {
+		mErr = multierror.Append(mErr, errors.New("missing granted namespace"))
+	}
+	if g.Namespace == AllNamespacesSentinel || g.GrantedNamespace == AllNamespacesSentinel {
+		mErr = multierror.Append(mErr, errors.New("wildcard namespaces are not allowed"))
+	}
+	if g.Namespace != "" && g.Namespace == g.GrantedNamespace {
+		mErr = multierror.Append(mErr, errors.New("granted namespace must differ from the service namespace"))
+	}
+	if len(g.Description) > maxServiceGrantDescriptionLength {
+		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxServiceGrantDescriptionLength))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Matches returns true if the grant allows the workloads of the granted
+// namespace to look up the service.
+func (g *ServiceGrant) Matches(service, grantedNamespace string) bool {
+	if g.GrantedNamespace != grantedNamespace {
+		return false
+	}
+	return g.ServiceName == ServiceGrantAllServices || g.ServiceName == service
+}
+
+// SameTarget returns true if both grants expose the same services to the
+// same namespace.
+func (g *ServiceGrant) SameTarget(o *ServiceGrant) bool {
+	return g.Namespace == o.Namespace &&
+		g.ServiceName == o.ServiceName &&
+		g.GrantedNamespace == o.GrantedNamespace
+}
+
+// ServiceGrantUpsertRequest is the request object to create or update service
+// grants. Grants without an ID that expose the same services to the same
+// namespace as an existing grant update it.
+type ServiceGrantUpsertRequest struct {
+	Grants []*ServiceGrant
+	WriteRequest
+}
+
+// ServiceGrantUpsertResponse is the response object when one or more service
+// grants have been successfully upserted into state.
+type ServiceGrantUpsertResponse struct {
+	Grants []*ServiceGrant
+	WriteMeta
+}
+
+// ServiceGrantDeleteRequest is the request object to delete service grants by
+// their ID.
+type ServiceGrantDeleteRequest struct {
+	IDs []string
+	WriteRequest
+}
+
+// ServiceGrantDeleteResponse is the response object when one or more service
+// grants have been successfully deleted.
+type ServiceGrantDeleteResponse struct {
+	WriteMeta
+}
+
+// ServiceGrantListRequest is the request object to list the service grants
+// exposing the services of a namespace, or of all namespaces when using the
+// wildcard namespace.
+type ServiceGrantListRequest struct {
+	QueryOptions
+}
+
+// ServiceGrantListResponse is the response object when listing service grants.
+type ServiceGrantListResponse struct {
+	Grants []*ServiceGrant
+	QueryMeta
+}
@@ -13,6 +13,7 @@ import (
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"hash"
@@ -20,6 +21,7 @@ import (
 	"maps"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
@@ -43,6 +45,7 @@ import (
 	"github.com/hashicorp/nomad/helper/args"
 	"github.com/hashicorp/nomad/helper/constraints/semver"
 	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/helper/jsonschema"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/lib/kheap"
@@ -136,6 +139,13 @@ const (
 	HostVolumeRegisterRequestType             MessageType = 75
 	HostVolumeDeleteRequestType               MessageType = 76
 	TaskGroupHostVolumeClaimDeleteRequestType MessageType = 77
+	AllocIdentitiesUpdateRequestType          MessageType = 78
+	AllocTaskResultRegisterRequestType        MessageType = 79
+	JobFreezeRequestType                      MessageType = 80
+	ServiceGrantUpsertRequestType             MessageType = 81
+	ServiceGrantDeleteRequestType             MessageType = 82
+	JobRestartUpsertRequestType               MessageType = 83
+	NodeLifecycleRunUpsertRequestType         MessageType = 84
 
 	// NOTE: MessageTypes are shared between CE and ENT. If you need to add a
 	// new type, check that ENT is not already using that value.
@@ -211,6 +221,10 @@ const (
 	// if no time is specified. Previously we would wait the MaxBlockingRPCQueryTime.
 	DefaultBlockingRPCQueryTime = 300 * time.Second
 
+	// BlockingQueryRetryAfter is the delay after which callers should retry
+	// blocking queries rejected because of the blocking query limits
+	BlockingQueryRetryAfter = 5 * time.Second
+
 	// RateMetric constants are used as labels in RPC rate metrics
 	RateMetricRead  = "read"
 	RateMetricList  = "list"
@@ -421,6 +435,71 @@ type AgentPprofResponse struct {
 	HTTPHeaders map[string]string
 }
 
+// SchedulerTrace is the payload of a scheduler trace profile. It holds the
+// timing of the evaluations processed by the scheduler workers of a server
+// during the trace, along with a CPU profile of the server.
+type SchedulerTrace struct {
+	// ServerID is the name of the server that ran the trace.
+	ServerID string
+
+	// StartTime and Duration are the time window of the trace.
+	StartTime time.Time
+	Duration  time.Duration
+
+	// Profile is the CPU profile captured during the trace. Scheduler
+	// invocations are labeled with the eval_id, job_id, scheduler and
+	// worker_id pprof labels. ProfileError is set if the profile could not
+	// be captured, for example because another CPU profile was running.
+	Profile      []byte
+	ProfileError string
+
+	// Evals is the timing of the evaluations processed during the trace.
+	// Dropped is the number of evaluations that were not recorded because
+	// the trace reached its limit.
+	Evals   []*SchedulerEvalTrace
+	Dropped int
+}
+
+// SchedulerEvalTrace is the timing of the processing of an evaluation by a
+// scheduler worker.
+type SchedulerEvalTrace struct {
+	EvalID      string
+	Namespace   string
+	JobID       string
+	Type        string
+	TriggeredBy string
+	WorkerID    string
+
+	// DequeueTime is the time at which the worker dequeued the evaluation.
+	DequeueTime time.Time
+
+	// SnapshotDuration is the time spent waiting for the state store to
+	// catch up with the evaluation and acquiring a snapshot.
+	SnapshotDuration time.Duration
+
+	// ScheduleDuration is the time spent running the scheduler, excluding
+	// the time spent submitting plans and waiting for their results.
+	ScheduleDuration time.Duration
+
+	// PlanSubmitDuration is the time spent submitting plans to the leader,
+	// until they were evaluated and applied by the plan applier.
+	PlanSubmitDuration time.Duration
+
+	// PlanWaitDuration is the time spent waiting for the state store to
+	// catch up with the results of plans applied against stale state.
+	PlanWaitDuration time.Duration
+
+	// Plans is the number of plans submitted.
+	Plans int
+
+	// TotalDuration is the time between the dequeue of the evaluation and
+	// its acknowledgement.
+	TotalDuration time.Duration
+
+	// Acked is false if the evaluation was nacked.
+	Acked bool
+}
+
 type WriteRequest struct {
 	// The target region for this write
 	Region string
@@ -706,12 +785,29 @@ type NodeUpdateEligibilityRequest struct {
 	// NodeEvent is the event added to the node
 	NodeEvent *NodeEvent
 
+	// AllocDirBudget is set when the eligibility is updated because the
+	// client crossed the disk budget watermarks of its allocation
+	// directories.
+	AllocDirBudget bool
+
 	// UpdatedAt represents server time of receiving request
 	UpdatedAt int64
 
 	WriteRequest
 }
 
+// NodeAllocDirBudgetRequest is used by clients to report that the disk usage
+// of their allocation directories crossed the budget watermarks.
+type NodeAllocDirBudgetRequest struct {
+	NodeID string
+
+	// Exceeded is true if the usage is over the high watermark, and false if
+	// it is back under the low watermark.
+	Exceeded bool
+
+	WriteRequest
+}
+
 // NodeEvaluateRequest is used to re-evaluate the node
 type NodeEvaluateRequest struct {
 	NodeID string
@@ -762,6 +858,13 @@ type JobRegisterRequest struct {
 	// there is an active deployment for the job it will be canceled.
 	Deployment *Deployment
 
+	// ForwardedFromRegion is the region that forwarded the registration to
+	// the region of the job, and ForwardedTime is the time at which it was
+	// forwarded stored as UnixNano. They are set by the forwarding server and
+	// recorded on the evaluation.
+	ForwardedFromRegion string
+	ForwardedTime       int64
+
 	WriteRequest
 }
 
@@ -797,6 +900,10 @@ type JobDeregisterRequest struct {
 	// SubmitTime is the time at which the job was requested to be stopped
 	SubmitTime int64
 
+	// PurgeRecord is the audit record stored when the job is purged. It is
+	// set by the Job.PurgeWithAudit RPC and ignored unless Purge is set.
+	PurgeRecord *JobPurgeRecord
+
 	WriteRequest
 }
 
@@ -857,6 +964,13 @@ type JobPlanRequest struct {
 	WriteRequest
 }
 
+// JobDriftRequest is used for the Job.Drift endpoint to compare a job
+// specification against the registered version of the job.
+type JobDriftRequest struct {
+	Job *Job
+	WriteRequest
+}
+
 // JobScaleRequest is used for the Job.Scale endpoint to scale one of the
 // scaling targets in a job
 type JobScaleRequest struct {
@@ -867,6 +981,12 @@ type JobScaleRequest struct {
 	Error   bool
 	Meta    map[string]interface{}
 
+	// Source, MetricSnapshot and DryRun annotate the recorded scaling event.
+	// A dry run records the event without changing the group count.
+	Source         string
+	MetricSnapshot map[string]float64
+	DryRun         bool
+
 	// PolicyOverride is set when the user is attempting to override any policies
 	PolicyOverride bool
 
@@ -939,6 +1059,12 @@ type JobDispatchRequest struct {
 // JobValidateRequest is used to validate a job
 type JobValidateRequest struct {
 	Job *Job
+
+	// Lint requests the job to be linted with the built-in lint rules and
+	// LintRules in addition to being validated.
+	Lint      bool
+	LintRules []*JobLintRule
+
 	WriteRequest
 }
 
@@ -979,6 +1105,29 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobFreezeRequest is used to freeze or unfreeze the scheduling of a job.
+type JobFreezeRequest struct {
+	// Job to set the freeze on
+	JobID string
+
+	// Frozen is the desired freeze state of the job
+	Frozen bool
+
+	// Eval is the evaluation created when unfreezing a job so that any
+	// placements held back while frozen are made.
+	Eval *Evaluation
+
+	WriteRequest
+}
+
+// JobFreezeResponse is the response when freezing or unfreezing a job.
+type JobFreezeResponse struct {
+	EvalID          string
+	EvalCreateIndex uint64
+	JobModifyIndex  uint64
+	WriteMeta
+}
+
 // NodeListRequest is used to parameterize a list request
 type NodeListRequest struct {
 	QueryOptions
@@ -1068,6 +1217,13 @@ type PlanRequest struct {
 	WriteRequest
 }
 
+// PlanDryRunRequest is used to evaluate a plan against the latest state
+// without committing it.
+type PlanDryRunRequest struct {
+	Plan *Plan
+	QueryOptions
+}
+
 // ApplyPlanResultsRequest is used by the planner to apply a Raft transaction
 // committing the result of a plan.
 type ApplyPlanResultsRequest struct {
@@ -1092,6 +1248,10 @@ type ApplyPlanResultsRequest struct {
 	// the evaluation itself being updated.
 	EvalID string
 
+	// PlanID is the ID of the plan being applied. The results of a plan are
+	// ignored if a plan with the same ID was already applied.
+	PlanID string
+
 	// COMPAT 0.11
 	// NodePreemptions is a slice of allocations from other lower priority jobs
 	// that are preempted. Preempted allocations are marked as evicted.
@@ -1155,6 +1315,41 @@ type AllocUpdateDesiredTransitionRequest struct {
 	WriteRequest
 }
 
+// AllocIdentitiesUpdateRequest is used by the leader to replace the default
+// workload identities of allocations before they expire.
+type AllocIdentitiesUpdateRequest struct {
+	Allocs []*AllocSignedIdentities
+
+	WriteRequest
+}
+
+// AllocSignedIdentities are the re-signed default workload identities of an
+// allocation.
+type AllocSignedIdentities struct {
+	AllocID string
+
+	// SignedIdentities is the mapping of task names to their re-signed
+	// default identity. Tasks not in the map keep their current identity.
+	SignedIdentities map[string]string
+
+	// SigningKeyID is the ID of the key used to sign the identities.
+	SigningKeyID string
+}
+
+// AllocRegisterResultRequest is used by a task to register its result on the
+// allocation before it exits.
+type AllocRegisterResultRequest struct {
+	AllocID string
+
+	// TaskName is the name of the task registering the result. It defaults
+	// to the task of the workload identity making the request.
+	TaskName string
+
+	Result *TaskResult
+
+	WriteRequest
+}
+
 // AllocStopRequest is used to stop and reschedule a running Allocation.
 type AllocStopRequest struct {
 	AllocID         string
@@ -1171,6 +1366,109 @@ type AllocStopResponse struct {
 	WriteMeta
 }
 
+const (
+	AllocBatchActionStop    = "stop"
+	AllocBatchActionSignal  = "signal"
+	AllocBatchActionRestart = "restart"
+
+	// AllocBatchDefaultParallelism is the number of allocations signaled or
+	// restarted concurrently when a batch request doesn't set it.
+	AllocBatchDefaultParallelism = 8
+
+	// AllocBatchMaxParallelism is the maximum number of allocations that can
+	// be signaled or restarted concurrently by a single batch request.
+	AllocBatchMaxParallelism = 64
+)
+
+// AllocBatchRequest is used to stop, signal, or restart all the allocations
+// matching a filter with a single request.
+type AllocBatchRequest struct {
+	// Action is the action to apply to the allocations. It must be one of
+	// "stop", "signal" or "restart".
+	Action string
+
+	// JobID, TaskGroup and NodeID filter the allocations the action is
+	// applied to. At least one of JobID or NodeID must be set, and TaskGroup
+	// can only be set along with JobID.
+	JobID     string
+	TaskGroup string
+	NodeID    string
+
+	// Task is the task to signal or restart. If empty, the signal is sent to
+	// all tasks and the restart applies to the running tasks, unless AllTasks
+	// is set.
+	Task string
+
+	// Signal is the signal to send for the signal action.
+	Signal string
+
+	// AllTasks restarts all tasks of the allocation for the restart action.
+	AllTasks bool
+
+	// NoShutdownDelay skips the shutdown delay for the stop action.
+	NoShutdownDelay bool
+
+	// Parallelism is the number of allocations signaled or restarted
+	// concurrently.
+	Parallelism int
+
+	WriteRequest
+}
+
+// Validate checks the action and filter of the batch request are valid.
+func (r *AllocBatchRequest) Validate() error {
+	var mErr multierror.Error
+
+	switch r.Action {
+	case AllocBatchActionStop, AllocBatchActionSignal, AllocBatchActionRestart:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("invalid action %q", r.Action))
+	}
+
+	if r.JobID == "" && r.NodeID == "" {
+		_ = multierror.Append(&mErr, errors.New("must filter by job or node"))
+	}
+	if r.TaskGroup != "" && r.JobID == "" {
+		_ = multierror.Append(&mErr, errors.New("task group filter requires a job"))
+	}
+
+	if r.Action != AllocBatchActionSignal && r.Signal != "" {
+		_ = multierror.Append(&mErr, errors.New("signal can only be set for the signal action"))
+	}
+	if r.Action != AllocBatchActionRestart && r.AllTasks {
+		_ = multierror.Append(&mErr, errors.New("all tasks can only be set for the restart action"))
+	}
+	if r.AllTasks && r.Task != "" {
+		_ = multierror.Append(&mErr, errors.New("task and all tasks can not both be set"))
+	}
+
+	if r.Parallelism < 0 || r.Parallelism > AllocBatchMaxParallelism {
+		_ = multierror.Append(&mErr, fmt.Errorf("parallelism must be between 0 and %d", AllocBatchMaxParallelism))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// AllocBatchResult is the result of a batch action for a single allocation.
+type AllocBatchResult struct {
+	AllocID string
+	NodeID  string
+
+	// Error is set if the action failed for the allocation.
+	Error string
+}
+
+// AllocBatchResponse is the response to an AllocBatchRequest.
+type AllocBatchResponse struct {
+	// Results holds the outcome of the action for each matching allocation.
+	Results []*AllocBatchResult
+
+	// EvalIDs are the IDs of the evaluations created by the stop action.
+	EvalIDs []string
+
+	WriteMeta
+}
+
 // AllocListRequest is used to request a list of allocations
 type AllocListRequest struct {
 	QueryOptions
@@ -1230,6 +1528,13 @@ type AllocRestartRequest struct {
 // PeriodicForceRequest is used to force a specific periodic job.
 type PeriodicForceRequest struct {
 	JobID string
+
+	// Meta is merged into the metadata of the forced child job and Env is
+	// merged into the environment of each of its tasks, allowing ad hoc runs
+	// with altered inputs without editing the periodic job.
+	Meta map[string]string
+	Env  map[string]string
+
 	WriteRequest
 }
 
@@ -1353,16 +1658,49 @@ type DeploymentPromoteRequest struct {
 	// Groups is used to set the promotion status per task group
 	Groups []string
 
+	// CanaryCount is the number of healthy canaries to promote in each task
+	// group. The task groups stay unpromoted while their other canaries are
+	// still being observed. Zero promotes the task groups.
+	CanaryCount int
+
+	// CanaryPercent is the percentage of the placed canaries to promote in
+	// each task group, as an alternative to CanaryCount.
+	CanaryPercent int
+
 	// PromotedAt is the timestamp stored as Unix nano
 	PromotedAt int64
 
 	WriteRequest
 }
 
+// IsPartial returns whether the request only promotes a subset of the
+// canaries of the task groups.
+func (r *DeploymentPromoteRequest) IsPartial() bool {
+	return r.CanaryCount > 0 || r.CanaryPercent > 0
+}
+
+// CanariesToPromote returns the number of canaries to promote out of the
+// placed canaries of a task group.
+func (r *DeploymentPromoteRequest) CanariesToPromote(placed int) int {
+	switch {
+	case r.CanaryCount > 0:
+		return min(r.CanaryCount, placed)
+	case r.CanaryPercent > 0:
+		// Round up so at least one canary is promoted
+		return (placed*r.CanaryPercent + 99) / 100
+	default:
+		return placed
+	}
+}
+
 // ApplyDeploymentPromoteRequest is used to apply a promotion request via Raft
 type ApplyDeploymentPromoteRequest struct {
 	DeploymentPromoteRequest
 
+	// AutoPromote is set when the leader auto-promotes the deployment, in
+	// which case the auto promote threshold of the task groups applies.
+	AutoPromote bool
+
 	// An optional evaluation to create after promoting the canaries
 	Eval *Evaluation
 }
@@ -1439,12 +1777,56 @@ type ScalingPolicyListResponse struct {
 	QueryMeta
 }
 
+// ScalingEventListRequest is used to list the scaling events of all jobs
+type ScalingEventListRequest struct {
+	// Start and End bound the time of the returned events, as Unix
+	// nanosecond timestamps. Zero leaves the range unbounded on that side.
+	Start int64
+	End   int64
+
+	// Source filters the events by the system that requested them
+	Source string
+
+	QueryOptions
+}
+
+// ScalingEventListResponse is used for a scaling event list request
+type ScalingEventListResponse struct {
+	Events []*ScalingEventListStub
+	QueryMeta
+}
+
 // SingleDeploymentResponse is used to respond with a single deployment
 type SingleDeploymentResponse struct {
 	Deployment *Deployment
 	QueryMeta
 }
 
+// DeploymentMultiregionResponse is used to respond with the deployments paired
+// with a multiregion deployment in each region of the job.
+type DeploymentMultiregionResponse struct {
+	Regions []*MultiregionDeploymentRegion
+	QueryMeta
+}
+
+// MultiregionDeploymentRegion is the state of a multiregion deployment in one
+// region.
+type MultiregionDeploymentRegion struct {
+	// Region is the name of the region.
+	Region string
+
+	// Deployment is the deployment of the job version in the region. It is
+	// nil if the deployment couldn't be found.
+	Deployment *Deployment
+
+	// Blocking is true if the region hasn't finished its part of the
+	// deployment, which keeps the peer regions blocked.
+	Blocking bool
+
+	// Error describes why the deployment couldn't be retrieved.
+	Error string
+}
+
 // GenericResponse is used to respond to a request where no
 // specific response information is needed.
 type GenericResponse struct {
@@ -1471,6 +1853,35 @@ type JobRegisterResponse struct {
 	QueryMeta
 }
 
+// JobAdmissionRequest is the body POSTed to the job admission webhook when a
+// job is registered.
+type JobAdmissionRequest struct {
+	// Region and Namespace are the region and namespace the job is
+	// registered in.
+	Region    string
+	Namespace string
+
+	// Job is the job being registered, before it's canonicalized and
+	// validated.
+	Job *Job
+}
+
+// JobAdmissionResponse is the response of the job admission webhook.
+type JobAdmissionResponse struct {
+	// Allowed is whether the job can be registered.
+	Allowed bool
+
+	// Message is the reason the job is rejected, returned to the user.
+	Message string
+
+	// Warnings are returned to the user when the job is allowed.
+	Warnings []string
+
+	// Job is the mutated job to register instead of the submitted one. The
+	// submitted job is registered unchanged if nil.
+	Job *Job
+}
+
 // JobDeregisterResponse is used to respond to a job deregistration
 type JobDeregisterResponse struct {
 	EvalID          string
@@ -1496,6 +1907,10 @@ type JobValidateResponse struct {
 	// Warnings contains any warnings about the given job. These may include
 	// deprecation warnings.
 	Warnings string
+
+	// LintFindings are the findings of the lint rules when linting was
+	// requested.
+	LintFindings []*JobLintFinding
 }
 
 // NodeUpdateResponse is used to respond to a node update
@@ -1672,6 +2087,30 @@ type JobPlanResponse struct {
 	WriteMeta
 }
 
+// JobDriftResponse is used to respond to a job drift request
+type JobDriftResponse struct {
+	// Job is the submitted job in the normalized form it would be registered
+	// with, and Hash is its SpecHash.
+	Job  *Job
+	Hash string
+
+	// RegisteredHash is the SpecHash of the currently registered version of
+	// the job, RegisteredVersion is its version. Both are empty if the job is
+	// not registered.
+	RegisteredHash    string
+	RegisteredVersion uint64
+
+	// Drifted is true if the submitted job differs from the registered job,
+	// or if the job is not registered.
+	Drifted bool
+
+	// Warnings contains any warnings about the given job. These may include
+	// deprecation warnings.
+	Warnings string
+
+	QueryMeta
+}
+
 // SingleAllocResponse is used to return a single allocation
 type SingleAllocResponse struct {
 	Alloc *Allocation
@@ -1695,12 +2134,37 @@ type JobAllocationsResponse struct {
 	QueryMeta
 }
 
+// JobResultsResponse is used to return the results registered by the tasks
+// of a job
+type JobResultsResponse struct {
+	Results []*AllocTaskResult
+	QueryMeta
+}
+
 // JobEvaluationsResponse is used to return the evaluations for a job
 type JobEvaluationsResponse struct {
 	Evaluations []*Evaluation
 	QueryMeta
 }
 
+// JobSnapshotResponse is used to return a job along with its evaluations,
+// deployments and allocations as of a single Raft index.
+type JobSnapshotResponse struct {
+	Snapshot *JobSnapshot
+	QueryMeta
+}
+
+// JobSnapshot is a job along with its evaluations, deployments and
+// allocations, all read at the same Raft index.
+type JobSnapshot struct {
+	// Job is the job, or nil if it doesn't exist.
+	Job *Job
+
+	Evaluations []*Evaluation
+	Deployments []*Deployment
+	Allocations []*AllocListStub
+}
+
 // SingleEvalResponse is used to return a single evaluation
 type SingleEvalResponse struct {
 	Eval *Evaluation
@@ -1737,9 +2201,28 @@ func (e *EvalDequeueResponse) GetWaitIndex() uint64 {
 // PlanResponse is used to return from a PlanRequest
 type PlanResponse struct {
 	Result *PlanResult
+
+	// PlanQueueDepth is the number of plans waiting in the leader's plan
+	// queue when this plan was applied. Workers use it as a backpressure
+	// signal to slow down scheduling while the plan applier is behind.
+	PlanQueueDepth int
+
 	WriteMeta
 }
 
+// PlanDryRunResponse is used to return the result of evaluating a plan
+// without committing it.
+type PlanDryRunResponse struct {
+	// Result is the plan result that would have been committed.
+	Result *PlanResult
+
+	// NodeRejections maps the ID of each node whose part of the plan was
+	// rejected to the reason it was rejected.
+	NodeRejections map[string]string
+
+	QueryMeta
+}
+
 // AllocListResponse is used for a list request
 type AllocListResponse struct {
 	Allocations []*AllocListStub
@@ -1948,6 +2431,12 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// BatchGrace is the duration past the deadline during which the remaining
+	// batch allocations on the node are allowed to complete before they are
+	// told to stop. This prevents nearly finished batch allocations from being
+	// killed and rescheduled.
+	BatchGrace time.Duration
 }
 
 // DrainStrategy describes a Node's drain behavior.
@@ -1995,6 +2484,21 @@ func (d *DrainStrategy) DeadlineTime() (infinite bool, deadline time.Time) {
 	}
 }
 
+// BatchDeadlineTime returns the time at which the remaining batch allocations
+// are told to stop, which is the deadline extended by the batch grace period.
+// It returns the zero time if the drain strategy has no deadline or no batch
+// grace period.
+func (d *DrainStrategy) BatchDeadlineTime() time.Time {
+	if d == nil || d.BatchGrace <= 0 {
+		return time.Time{}
+	}
+	inf, deadline := d.DeadlineTime()
+	if inf || deadline.IsZero() {
+		return time.Time{}
+	}
+	return deadline.Add(d.BatchGrace)
+}
+
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d == nil && o == nil {
 		return true
@@ -2011,6 +2515,8 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 		return false
 	} else if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
+	} else if d.BatchGrace != o.BatchGrace {
+		return false
 	}
 
 	return true
@@ -2168,6 +2674,12 @@ type Node struct {
 	// LastDrain contains metadata about the most recent drain operation
 	LastDrain *DrainMetadata
 
+	// AllocDirBudgetExceeded is true while the node is ineligible because
+	// its client exceeded the disk budget of its allocation directories. It
+	// is cleared by any other change of the node's eligibility, so that the
+	// client only restores the eligibility it removed itself.
+	AllocDirBudgetExceeded bool
+
 	// LastMissedHeartbeatIndex stores the Raft index when the node last missed
 	// a heartbeat. It resets to zero once the node is marked as ready again.
 	LastMissedHeartbeatIndex uint64
@@ -2923,11 +3435,16 @@ type NetworkResource struct {
 	ReservedPorts []Port     // Host Reserved ports
 	DynamicPorts  []Port     // Host Dynamically assigned ports
 	CNI           *CNIConfig // CNIConfig Configuration
+
+	// PreserveIdentity requests that an allocation replacing a previous
+	// allocation on the same node keeps its bridge address and dynamic
+	// ports where possible.
+	PreserveIdentity bool `json:",omitempty"`
 }
 
 func (n *NetworkResource) Hash() uint32 {
 	var data []byte
-	data = append(data, []byte(fmt.Sprintf("%s%s%s%s%s%d", n.Mode, n.Device, n.CIDR, n.IP, n.Hostname, n.MBits))...)
+	data = append(data, []byte(fmt.Sprintf("%s%s%s%s%s%d%t", n.Mode, n.Device, n.CIDR, n.IP, n.Hostname, n.MBits, n.PreserveIdentity))...)
 
 	for i, port := range n.ReservedPorts {
 		data = append(data, []byte(fmt.Sprintf("r%d%s%d%d", i, port.Label, port.Value, port.To))...)
@@ -4474,6 +4991,12 @@ type Job struct {
 	// that will happen in the admission mutators.
 	NodePool string
 
+	// MinClientVersion is the oldest Nomad client version allowed to run the
+	// job. It is enforced by an implicit constraint on each task group, so
+	// jobs relying on new fields are not placed on clients that would ignore
+	// them.
+	MinClientVersion string
+
 	// Constraints can be specified at a job level and apply to
 	// all the task groups and tasks.
 	Constraints []*Constraint
@@ -4548,6 +5071,12 @@ type Job struct {
 	// update block.
 	Stable bool
 
+	// Frozen suspends the scheduling of the job. While frozen, the scheduler
+	// does not place, replace, reschedule or update allocations of the job,
+	// leaving running allocations as they are. Unlike Stable, this field is
+	// not tied to a job version and is carried over on job updates.
+	Frozen bool
+
 	// Version is a monotonically increasing version number that is incremented
 	// on each job register.
 	Version uint64
@@ -4812,6 +5341,12 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if j.MinClientVersion != "" {
+		if _, err := version.NewVersion(j.MinClientVersion); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid min_client_version %q: %v", j.MinClientVersion, err))
+		}
+	}
+
 	if len(j.TaskGroups) == 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job task groups"))
 	}
@@ -5232,6 +5767,7 @@ func (j *Job) SpecChanged(new *Job) bool {
 	c.Status = j.Status
 	c.StatusDescription = j.StatusDescription
 	c.Stable = j.Stable
+	c.Frozen = j.Frozen
 	c.Version = j.Version
 	c.CreateIndex = j.CreateIndex
 	c.ModifyIndex = j.ModifyIndex
@@ -5244,6 +5780,42 @@ func (j *Job) SpecChanged(new *Job) bool {
 	return !reflect.DeepEqual(j, c)
 }
 
+// SpecHash returns a hash of the functional specification of the job. Fields
+// that are set by Nomad when the job is registered are ignored, so a job
+// submitted by a user and the registered version of the same specification
+// hash the same, as long as both went through the same normalization.
+func (j *Job) SpecHash() (string, error) {
+	c := j.Copy()
+	c.Status = ""
+	c.StatusDescription = ""
+	c.Stable = false
+	c.Frozen = false
+	c.Version = 0
+	c.SubmitTime = 0
+	c.CreateIndex = 0
+	c.ModifyIndex = 0
+	c.JobModifyIndex = 0
+	c.VersionTag = nil
+	c.NomadTokenID = ""
+	c.ConsulToken = ""
+
+	// Scaling policies are assigned an ID when the job is first registered.
+	for _, p := range c.GetScalingPolicies() {
+		p.ID = ""
+		p.CreateIndex = 0
+		p.ModifyIndex = 0
+	}
+
+	// JSON has a deterministic map ordering and encodes numbers the same way
+	// regardless of the type they were decoded into.
+	buf, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job: %w", err)
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func (j *Job) SetSubmitTime() {
 	j.SubmitTime = time.Now().UTC().UnixNano()
 }
@@ -5404,9 +5976,22 @@ type UpdateStrategy struct {
 	// healthy
 	AutoPromote bool
 
+	// AutoPromoteThreshold is the percentage of canaries that must be healthy
+	// for the deployment to be auto-promoted. Zero requires all the canaries
+	// to be healthy.
+	AutoPromoteThreshold int
+
+	// AutoPromoteMinHealthyTime is the minimum time the auto promote
+	// threshold must be met before the deployment is auto-promoted.
+	AutoPromoteMinHealthyTime time.Duration
+
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// RollbackOn is an external metric that fails and reverts the deployment
+	// if it breaches its threshold while the deployment is in progress.
+	RollbackOn *RollbackOn
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5416,6 +6001,7 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	c := new(UpdateStrategy)
 	*c = *u
+	c.RollbackOn = u.RollbackOn.Copy()
 	return c
 }
 
@@ -5440,6 +6026,15 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary == 0 && u.AutoPromote {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	if u.AutoPromoteThreshold < 0 || u.AutoPromoteThreshold > 100 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Auto promote threshold must be between 0 and 100: %d", u.AutoPromoteThreshold))
+	}
+	if u.AutoPromoteMinHealthyTime < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Auto promote minimum healthy time may not be less than zero: %v", u.AutoPromoteMinHealthyTime))
+	}
+	if !u.AutoPromote && (u.AutoPromoteThreshold != 0 || u.AutoPromoteMinHealthyTime != 0) {
+		_ = multierror.Append(&mErr, fmt.Errorf("Auto promote threshold and minimum healthy time require Auto Promote"))
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -5458,6 +6053,9 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if err := u.RollbackOn.Validate(); err != nil {
+		_ = multierror.Append(&mErr, multierror.Prefix(err, "Rollback On:"))
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5476,6 +6074,73 @@ func (u *UpdateStrategy) IsEmpty() bool {
 	return u.MaxParallel == 0
 }
 
+const (
+	// RollbackOnComparisonAbove breaches when the metric is greater than the
+	// threshold.
+	RollbackOnComparisonAbove = "above"
+
+	// RollbackOnComparisonBelow breaches when the metric is less than the
+	// threshold.
+	RollbackOnComparisonBelow = "below"
+)
+
+// RollbackOn is a metric query evaluated by the deployment watcher while a
+// deployment is in progress. If the query breaches the threshold the
+// deployment is failed and the job is reverted to its latest stable version.
+type RollbackOn struct {
+	// Query is the query sent to the metric provider. Its result must be a
+	// scalar or a vector of samples.
+	Query string
+
+	// Threshold is the value the query result is compared against.
+	Threshold float64
+
+	// Comparison is either "above" or "below" and determines in which
+	// direction the threshold is breached.
+	Comparison string
+
+	// Interval is how often the query is evaluated.
+	Interval time.Duration
+}
+
+func (r *RollbackOn) Copy() *RollbackOn {
+	if r == nil {
+		return nil
+	}
+
+	c := new(RollbackOn)
+	*c = *r
+	return c
+}
+
+func (r *RollbackOn) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if r.Query == "" {
+		_ = multierror.Append(&mErr, errors.New("Query must not be empty"))
+	}
+	switch r.Comparison {
+	case RollbackOnComparisonAbove, RollbackOnComparisonBelow:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid comparison given: %q", r.Comparison))
+	}
+	if r.Interval <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval must be greater than zero: %v", r.Interval))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Breached returns whether the value breaches the threshold.
+func (r *RollbackOn) Breached(value float64) bool {
+	if r.Comparison == RollbackOnComparisonBelow {
+		return value < r.Threshold
+	}
+	return value > r.Threshold
+}
+
 // Rolling returns if a rolling strategy should be used.
 // TODO(alexdadgar): Remove once no longer used by the scheduler.
 func (u *UpdateStrategy) Rolling() bool {
@@ -5564,6 +6229,14 @@ type Namespace struct {
 	VaultConfiguration  *NamespaceVaultConfiguration
 	ConsulConfiguration *NamespaceConsulConfiguration
 
+	// PreemptionConfiguration is the namespace configuration for allowing
+	// its allocations to be preempted.
+	PreemptionConfiguration *NamespacePreemptionConfiguration
+
+	// GCConfiguration is the namespace configuration overriding the garbage
+	// collection thresholds of the servers.
+	GCConfiguration *NamespaceGCConfiguration
+
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
@@ -5650,6 +6323,14 @@ func (n *Namespace) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid consul configuration: %v", e))
 	}
 
+	if err := n.PreemptionConfiguration.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid preemption configuration: %v", err))
+	}
+
+	if err := n.GCConfiguration.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid gc configuration: %v", err))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5709,6 +6390,18 @@ func (n *Namespace) SetHash() []byte {
 		}
 	}
 
+	if n.PreemptionConfiguration != nil {
+		_, _ = hash.Write([]byte(strconv.FormatBool(n.PreemptionConfiguration.Protected)))
+		_, _ = hash.Write([]byte(strconv.Itoa(n.PreemptionConfiguration.PriorityFloor)))
+	}
+
+	if n.GCConfiguration != nil {
+		_, _ = hash.Write([]byte(n.GCConfiguration.JobGCThreshold.String()))
+		_, _ = hash.Write([]byte(n.GCConfiguration.EvalGCThreshold.String()))
+		_, _ = hash.Write([]byte(n.GCConfiguration.BatchEvalGCThreshold.String()))
+		_, _ = hash.Write([]byte(n.GCConfiguration.DeploymentGCThreshold.String()))
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -5760,6 +6453,16 @@ func (n *Namespace) Copy() *Namespace {
 		nc.Allowed = slices.Clone(n.ConsulConfiguration.Allowed)
 		nc.Denied = slices.Clone(n.ConsulConfiguration.Denied)
 	}
+	if n.PreemptionConfiguration != nil {
+		npc := new(NamespacePreemptionConfiguration)
+		*npc = *n.PreemptionConfiguration
+		nc.PreemptionConfiguration = npc
+	}
+	if n.GCConfiguration != nil {
+		ngc := new(NamespaceGCConfiguration)
+		*ngc = *n.GCConfiguration
+		nc.GCConfiguration = ngc
+	}
 
 	if n.Meta != nil {
 		nc.Meta = make(map[string]string, len(n.Meta))
@@ -6037,6 +6740,14 @@ type ParameterizedJobConfig struct {
 
 	// MetaOptional is metadata keys that may be specified by the dispatcher
 	MetaOptional []string
+
+	// MetaSchema declares the type and allowed values of dispatch metadata
+	// keys. Keys without a schema accept any value.
+	MetaSchema []*DispatchMetaSchema
+
+	// PayloadSchema is a JSON Schema document the dispatch payload must
+	// match. If set, the payload must be a JSON document.
+	PayloadSchema string
 }
 
 func (d *ParameterizedJobConfig) Validate() error {
@@ -6053,6 +6764,31 @@ func (d *ParameterizedJobConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
 	}
 
+	seen := make(map[string]struct{}, len(d.MetaSchema))
+	for _, schema := range d.MetaSchema {
+		if _, ok := seen[schema.Name]; ok {
+			_ = multierror.Append(&mErr, fmt.Errorf("Meta schema for key %q is declared more than once", schema.Name))
+			continue
+		}
+		seen[schema.Name] = struct{}{}
+
+		if !slices.Contains(d.MetaRequired, schema.Name) && !slices.Contains(d.MetaOptional, schema.Name) {
+			_ = multierror.Append(&mErr, fmt.Errorf("Meta schema for key %q must be a required or optional meta key", schema.Name))
+		}
+		if err := schema.Validate(); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Meta schema for key %q is invalid: %v", schema.Name, err))
+		}
+	}
+
+	if d.PayloadSchema != "" {
+		if d.Payload == DispatchPayloadForbidden {
+			_ = multierror.Append(&mErr, errors.New("Payload schema can't be set when the payload is forbidden"))
+		}
+		if _, err := jsonschema.Parse([]byte(d.PayloadSchema)); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Invalid payload schema: %v", err))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -6060,6 +6796,9 @@ func (d *ParameterizedJobConfig) Canonicalize() {
 	if d.Payload == "" {
 		d.Payload = DispatchPayloadOptional
 	}
+	for _, schema := range d.MetaSchema {
+		schema.Canonicalize()
+	}
 }
 
 func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
@@ -6070,9 +6809,106 @@ func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
 	*nd = *d
 	nd.MetaOptional = slices.Clone(nd.MetaOptional)
 	nd.MetaRequired = slices.Clone(nd.MetaRequired)
+	nd.MetaSchema = helper.CopySlice(nd.MetaSchema)
 	return nd
 }
 
+const (
+	DispatchMetaTypeString  = "string"
+	DispatchMetaTypeNumber  = "number"
+	DispatchMetaTypeInteger = "integer"
+	DispatchMetaTypeBool    = "bool"
+)
+
+// DispatchMetaSchema declares the values a dispatch metadata key accepts.
+type DispatchMetaSchema struct {
+	// Name is the metadata key
+	Name string
+
+	// Type is the type the value must parse as. Defaults to string.
+	Type string
+
+	// Pattern is a regular expression the value must match
+	Pattern string
+
+	// Enum is the set of allowed values
+	Enum []string
+}
+
+func (m *DispatchMetaSchema) Copy() *DispatchMetaSchema {
+	if m == nil {
+		return nil
+	}
+	nm := new(DispatchMetaSchema)
+	*nm = *m
+	nm.Enum = slices.Clone(m.Enum)
+	return nm
+}
+
+func (m *DispatchMetaSchema) Canonicalize() {
+	if m.Type == "" {
+		m.Type = DispatchMetaTypeString
+	}
+}
+
+func (m *DispatchMetaSchema) Validate() error {
+	switch m.Type {
+	case DispatchMetaTypeString, DispatchMetaTypeNumber, DispatchMetaTypeInteger, DispatchMetaTypeBool:
+	default:
+		return fmt.Errorf("unknown type %q", m.Type)
+	}
+
+	if m.Pattern != "" {
+		if _, err := regexp.Compile(m.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+
+	for _, v := range m.Enum {
+		if err := m.validateType(v); err != nil {
+			return fmt.Errorf("invalid enum value %q: %v", v, err)
+		}
+	}
+	return nil
+}
+
+// ValidateValue returns an error if the dispatch metadata value doesn't match
+// the schema.
+func (m *DispatchMetaSchema) ValidateValue(v string) error {
+	if err := m.validateType(v); err != nil {
+		return err
+	}
+	if len(m.Enum) != 0 && !slices.Contains(m.Enum, v) {
+		return fmt.Errorf("value %q is not one of %v", v, m.Enum)
+	}
+	if m.Pattern != "" {
+		re, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("value %q does not match pattern %q", v, m.Pattern)
+		}
+	}
+	return nil
+}
+
+func (m *DispatchMetaSchema) validateType(v string) error {
+	var err error
+	switch m.Type {
+	case DispatchMetaTypeNumber:
+		_, err = strconv.ParseFloat(v, 64)
+	case DispatchMetaTypeInteger:
+		_, err = strconv.ParseInt(v, 10, 64)
+	case DispatchMetaTypeBool:
+		_, err = strconv.ParseBool(v)
+	}
+	if err != nil {
+		return fmt.Errorf("value %q is not a valid %s", v, m.Type)
+	}
+	return nil
+}
+
 // DispatchedID returns an ID appropriate for a job dispatched against a
 // particular parameterized job
 func DispatchedID(templateID, idPrefixTemplate string, t time.Time) string {
@@ -6262,6 +7098,18 @@ type ScalingEvent struct {
 	// Meta is a map of metadata returned during a scaling event
 	Meta map[string]interface{}
 
+	// Source identifies the system that requested the scaling event, such as
+	// an autoscaler instance
+	Source string
+
+	// MetricSnapshot holds the values of the metrics that led to the scaling
+	// event, keyed by metric name
+	MetricSnapshot map[string]float64
+
+	// DryRun indicates the scaling event was recorded without changing the
+	// count of the task group
+	DryRun bool
+
 	// EvalID is the ID for an evaluation if one was created as part of a scaling event
 	EvalID *string
 
@@ -6278,6 +7126,7 @@ func (e *ScalingEvent) Copy() *ScalingEvent {
 
 	ne.Count = pointer.Copy(e.Count)
 	ne.Meta = maps.Clone(e.Meta)
+	ne.MetricSnapshot = maps.Clone(e.MetricSnapshot)
 	ne.EvalID = pointer.Copy(e.EvalID)
 	return ne
 }
@@ -6292,6 +7141,15 @@ type ScalingEventRequest struct {
 	ScalingEvent *ScalingEvent
 }
 
+// ScalingEventListStub is a scaling event along with the task group it
+// belongs to, as returned by the Scaling.ListEvents endpoint
+type ScalingEventListStub struct {
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Event     *ScalingEvent
+}
+
 // ScalingPolicy specifies the scaling policy for a scaling target
 type ScalingPolicy struct {
 	// ID is a generated UUID used for looking up the scaling policy
@@ -6312,6 +7170,14 @@ type ScalingPolicy struct {
 	// Max is the maximum allowable scaling count for this target
 	Max int64
 
+	// MaxStep is the maximum change of the count allowed by a single scaling
+	// request for this target. Zero means no limit.
+	MaxStep int64
+
+	// Cooldown is the minimum time between two scaling requests changing the
+	// count of this target. Zero means no limit.
+	Cooldown time.Duration
+
 	// Enabled indicates whether this policy has been enabled/disabled
 	Enabled bool
 
@@ -6374,6 +7240,8 @@ func (p *ScalingPolicy) Copy() *ScalingPolicy {
 		Type:        p.Type,
 		Min:         p.Min,
 		Max:         p.Max,
+		MaxStep:     p.MaxStep,
+		Cooldown:    p.Cooldown,
 		CreateIndex: p.CreateIndex,
 		ModifyIndex: p.ModifyIndex,
 	}
@@ -6412,6 +7280,13 @@ func (p *ScalingPolicy) Validate() error {
 			fmt.Errorf("minimum count must be specified and non-negative"))
 	}
 
+	if p.MaxStep < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max step must be non-negative"))
+	}
+	if p.Cooldown < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("cooldown must be non-negative"))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -6852,6 +7727,23 @@ type TaskGroup struct {
 	// Scaling is the list of autoscaling policies for the TaskGroup
 	Scaling *ScalingPolicy
 
+	// ScaleWithNodes keeps Count proportional to the number of matching
+	// nodes in the cluster
+	ScaleWithNodes *ScaleWithNodes
+
+	// DisruptionBudget is the minimum number of healthy allocations that
+	// voluntary disruptions must leave running
+	DisruptionBudget *DisruptionBudget
+
+	// PreemptionNotice configures the grace period and signal given to
+	// allocations of this group before they are evicted by preemption
+	PreemptionNotice *PreemptionNotice
+
+	// Gang is the name of the set of task groups this task group is
+	// co-scheduled with. The scheduler places all the task groups of a gang
+	// in the same plan or none of them.
+	Gang string
+
 	// RestartPolicy of a TaskGroup
 	RestartPolicy *RestartPolicy
 
@@ -6930,6 +7822,9 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
+	ntg.ScaleWithNodes = ntg.ScaleWithNodes.Copy()
+	ntg.DisruptionBudget = ntg.DisruptionBudget.Copy()
+	ntg.PreemptionNotice = ntg.PreemptionNotice.Copy()
 	ntg.Consul = ntg.Consul.Copy()
 
 	// Copy the network objects
@@ -7125,6 +8020,26 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if tg.ScaleWithNodes != nil {
+		if err := tg.ScaleWithNodes.Validate(j, tg); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
+	if tg.DisruptionBudget != nil {
+		if err := tg.DisruptionBudget.Validate(j); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
+	if err := tg.PreemptionNotice.Validate(); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
+	if err := tg.validateGang(j); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -7803,6 +8718,17 @@ type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// Compress enables gzip compression of rotated log files.
+	Compress bool
+
+	// MaxTotalSizeMB caps the size of all the log files of each stream, in
+	// addition to MaxFiles. Zero means no cap.
+	MaxTotalSizeMB int
+
+	// Journald sends the task logs to the journal of the client host instead
+	// of log files in the allocation directory.
+	Journald bool
 }
 
 func (l *LogConfig) Equal(o *LogConfig) bool {
@@ -7822,6 +8748,18 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
+	if l.Compress != o.Compress {
+		return false
+	}
+
+	if l.MaxTotalSizeMB != o.MaxTotalSizeMB {
+		return false
+	}
+
+	if l.Journald != o.Journald {
+		return false
+	}
+
 	return true
 }
 
@@ -7830,9 +8768,12 @@ func (l *LogConfig) Copy() *LogConfig {
 		return nil
 	}
 	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
-		Disabled:      l.Disabled,
+		MaxFiles:       l.MaxFiles,
+		MaxFileSizeMB:  l.MaxFileSizeMB,
+		Disabled:       l.Disabled,
+		Compress:       l.Compress,
+		MaxTotalSizeMB: l.MaxTotalSizeMB,
+		Journald:       l.Journald,
 	}
 }
 
@@ -7856,8 +8797,17 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 	if l.MaxFileSizeMB < 1 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum file size is 1MB; got %d", l.MaxFileSizeMB))
 	}
+	if l.MaxTotalSizeMB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max total size must be non-negative; got %d", l.MaxTotalSizeMB))
+	} else if l.MaxTotalSizeMB > 0 && l.MaxTotalSizeMB < l.MaxFileSizeMB {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max total size (%d MB) must be at least the max file size (%d MB)",
+			l.MaxTotalSizeMB, l.MaxFileSizeMB))
+	}
+	if l.Journald && l.Disabled {
+		mErr.Errors = append(mErr.Errors, errors.New("journald cannot be enabled when log collection is disabled"))
+	}
 	if disk != nil {
-		logUsage := (l.MaxFiles * l.MaxFileSizeMB)
+		logUsage := l.StorageMB()
 		if disk.SizeMB <= logUsage {
 			mErr.Errors = append(mErr.Errors,
 				fmt.Errorf("log storage (%d MB) must be less than requested disk capacity (%d MB)",
@@ -7867,6 +8817,19 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 	return mErr.ErrorOrNil()
 }
 
+// StorageMB returns the maximum disk space used by the logs of each stream
+// in the allocation directory.
+func (l *LogConfig) StorageMB() int {
+	if l.Journald {
+		return 0
+	}
+	logUsage := l.MaxFiles * l.MaxFileSizeMB
+	if l.MaxTotalSizeMB > 0 && l.MaxTotalSizeMB < logUsage {
+		return l.MaxTotalSizeMB
+	}
+	return logUsage
+}
+
 // Task is a single process typically that is executed as part of a task group.
 type Task struct {
 	// Name of the task
@@ -8229,6 +9192,9 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing Log Config"))
 	} else if err := t.LogConfig.Validate(tg.EphemeralDisk); err != nil {
 		mErr.Errors = append(mErr.Errors, err)
+	} else if t.LogConfig.Journald && t.Driver != "exec" && t.Driver != "raw_exec" {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("journald logging is only supported by the exec and raw_exec drivers; got %q", t.Driver))
 	}
 
 	// Validate constraints and affinities.
@@ -9080,6 +10046,72 @@ type AllocState struct {
 	Time  time.Time
 }
 
+// MaxTaskResultSize is the maximum size in bytes of the summary, output URI
+// and metadata of a TaskResult combined.
+const MaxTaskResultSize = 4096
+
+// TaskResult is a small result registered by a task before it exits, so that
+// other systems can find the outcome of the task without reading its logs.
+type TaskResult struct {
+	// Summary is a short human readable description of the outcome.
+	Summary string
+
+	// OutputURI is the location of the output of the task.
+	OutputURI string
+
+	// Meta is arbitrary metadata about the result.
+	Meta map[string]string
+
+	// RegisterTime is the time the result was registered, stored as
+	// UnixNano.
+	RegisterTime int64
+}
+
+func (r *TaskResult) Copy() *TaskResult {
+	if r == nil {
+		return nil
+	}
+	nr := new(TaskResult)
+	*nr = *r
+	nr.Meta = maps.Clone(r.Meta)
+	return nr
+}
+
+// Validate returns an error if the result is empty, too large, or has an
+// invalid output URI.
+func (r *TaskResult) Validate() error {
+	if r == nil || (r.Summary == "" && r.OutputURI == "" && len(r.Meta) == 0) {
+		return errors.New("result must have a summary, output URI or meta")
+	}
+
+	size := len(r.Summary) + len(r.OutputURI)
+	for k, v := range r.Meta {
+		size += len(k) + len(v)
+	}
+	if size > MaxTaskResultSize {
+		return fmt.Errorf("result size of %d bytes exceeds maximum of %d bytes", size, MaxTaskResultSize)
+	}
+
+	if r.OutputURI != "" {
+		u, err := url.Parse(r.OutputURI)
+		if err != nil {
+			return fmt.Errorf("invalid output URI: %v", err)
+		}
+		if u.Scheme == "" {
+			return fmt.Errorf("invalid output URI %q: missing scheme", r.OutputURI)
+		}
+	}
+	return nil
+}
+
+// AllocTaskResult is the result registered by a task of an allocation.
+type AllocTaskResult struct {
+	AllocID   string
+	AllocName string
+	TaskName  string
+	Result    *TaskResult
+}
+
 // Set of possible states for a task.
 const (
 	TaskStatePending = "pending" // The task is waiting to be run.
@@ -9238,6 +10270,10 @@ const (
 	// TaskSignaling indicates that the task is being signalled.
 	TaskSignaling = "Signaling"
 
+	// TaskPreemptionNotice indicates that the allocation has been preempted
+	// and that the task will be killed once its grace period has passed.
+	TaskPreemptionNotice = "Preemption Notice"
+
 	// TaskDownloadingArtifacts means the task is downloading the artifacts
 	// specified in the task.
 	TaskDownloadingArtifacts = "Downloading Artifacts"
@@ -9253,6 +10289,10 @@ const (
 	// TaskSetup indicates the task runner is setting up the task environment
 	TaskSetup = "Task Setup"
 
+	// TaskStartQueued indicates that the client delays the start of the
+	// allocation because of its allocation start limits.
+	TaskStartQueued = "Start Queued"
+
 	// TaskDiskExceeded indicates that one of the tasks in a taskgroup has
 	// exceeded the requested disk resources.
 	TaskDiskExceeded = "Disk Resources Exceeded"
@@ -10151,6 +11191,13 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// Topology is an ordered list of node attributes describing nested
+	// failure domains, from the broadest to the narrowest (e.g. availability
+	// zone then rack). Allocations are spread evenly across the values found
+	// on nodes at each level, within their parent domain. It is mutually
+	// exclusive with Attribute and SpreadTarget.
+	Topology []string
+
 	// Memoized string representation
 	str string
 }
@@ -10166,6 +11213,8 @@ func (s *Spread) Equal(o *Spread) bool {
 		return false
 	case !slices.EqualFunc(s.SpreadTarget, o.SpreadTarget, func(a, b *SpreadTarget) bool { return a.Equal(b) }):
 		return false
+	case !slices.Equal(s.Topology, o.Topology):
+		return false
 	}
 	return true
 }
@@ -10203,6 +11252,7 @@ func (s *Spread) Copy() *Spread {
 	*ns = *s
 
 	ns.SpreadTarget = CopySliceSpreadTarget(s.SpreadTarget)
+	ns.Topology = slices.Clone(s.Topology)
 	return ns
 }
 
@@ -10210,13 +11260,35 @@ func (s *Spread) String() string {
 	if s.str != "" {
 		return s.str
 	}
+	if len(s.Topology) > 0 {
+		s.str = fmt.Sprintf("%s %v", strings.Join(s.Topology, " > "), s.Weight)
+		return s.str
+	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
 	return s.str
 }
 
 func (s *Spread) Validate() error {
 	var mErr multierror.Error
-	if s.Attribute == "" {
+	if len(s.Topology) > 0 {
+		if s.Attribute != "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread topology and attribute are mutually exclusive"))
+		}
+		if len(s.SpreadTarget) > 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread topology does not support targets"))
+		}
+		seenLevels := make(map[string]struct{}, len(s.Topology))
+		for _, level := range s.Topology {
+			if level == "" {
+				mErr.Errors = append(mErr.Errors, errors.New("Spread topology level must not be empty"))
+				continue
+			}
+			if _, ok := seenLevels[level]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread topology level %q already defined", level))
+			}
+			seenLevels[level] = struct{}{}
+		}
+	} else if s.Attribute == "" {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing spread attribute"))
 	}
 	if s.Weight <= 0 || s.Weight > 100 {
@@ -10489,6 +11561,7 @@ const (
 	DeploymentStatusDescriptionNewerJob              = "Cancelled due to newer version of job"
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
+	DeploymentStatusDescriptionRollbackMetric        = "Failed due to rollback metric breach"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
 
 	// used only in multiregion deployments
@@ -10564,6 +11637,11 @@ type Deployment struct {
 	// is not guaranteed to be that of the job priority parameter.
 	EvalPriority int
 
+	// PendingFailure is set once the deployment watcher has decided to fail
+	// the deployment, before the failure is applied. A leader that starts
+	// watching a deployment with a pending failure applies it as recorded.
+	PendingFailure *DeploymentFailure
+
 	CreateIndex uint64
 	ModifyIndex uint64
 
@@ -10606,6 +11684,7 @@ func (d *Deployment) Copy() *Deployment {
 			c.TaskGroups[tg] = s.Copy()
 		}
 	}
+	c.PendingFailure = d.PendingFailure.Copy()
 
 	return c
 }
@@ -10704,6 +11783,15 @@ type DeploymentState struct {
 	// copied from TaskGroup UpdateStrategy in scheduler.reconcile
 	AutoPromote bool
 
+	// AutoPromoteThreshold is the percentage of canaries that must be healthy
+	// for the task group to be auto-promoted, copied from the TaskGroup
+	// UpdateStrategy in scheduler.reconcile. Zero requires all the canaries.
+	AutoPromoteThreshold int
+
+	// AutoPromoteMinHealthyTime is the minimum time the auto promote
+	// threshold must be met before the task group is auto-promoted.
+	AutoPromoteMinHealthyTime time.Duration
+
 	// ProgressDeadline is the deadline by which an allocation must transition
 	// to healthy before the deployment is considered failed. This value is set
 	// by the jobspec `update.progress_deadline` field.
@@ -10720,6 +11808,11 @@ type DeploymentState struct {
 	// PlacedCanaries is the set of placed canary allocations
 	PlacedCanaries []string
 
+	// PromotedCanaries is the set of canary allocations that were promoted
+	// before the task group was promoted. They are no longer canaries, but
+	// count towards the desired canaries.
+	PromotedCanaries []string
+
 	// DesiredCanaries is the number of canaries that should be created.
 	DesiredCanaries int
 
@@ -10741,12 +11834,15 @@ func (d *DeploymentState) GoString() string {
 	base := fmt.Sprintf("\tDesired Total: %d", d.DesiredTotal)
 	base += fmt.Sprintf("\n\tDesired Canaries: %d", d.DesiredCanaries)
 	base += fmt.Sprintf("\n\tPlaced Canaries: %#v", d.PlacedCanaries)
+	base += fmt.Sprintf("\n\tPromoted Canaries: %#v", d.PromotedCanaries)
 	base += fmt.Sprintf("\n\tPromoted: %v", d.Promoted)
 	base += fmt.Sprintf("\n\tPlaced: %d", d.PlacedAllocs)
 	base += fmt.Sprintf("\n\tHealthy: %d", d.HealthyAllocs)
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
 	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
+	base += fmt.Sprintf("\n\tAutoPromoteThreshold: %d", d.AutoPromoteThreshold)
+	base += fmt.Sprintf("\n\tAutoPromoteMinHealthyTime: %v", d.AutoPromoteMinHealthyTime)
 	return base
 }
 
@@ -10754,9 +11850,25 @@ func (d *DeploymentState) Copy() *DeploymentState {
 	c := &DeploymentState{}
 	*c = *d
 	c.PlacedCanaries = slices.Clone(d.PlacedCanaries)
+	c.PromotedCanaries = slices.Clone(d.PromotedCanaries)
 	return c
 }
 
+// HealthyCanariesRequired returns the number of healthy canaries required to
+// promote the task group. All the desired canaries are required, unless the
+// task group is auto-promoted with a threshold and auto is set, which is the
+// case for promotions made by the leader rather than the user. Canaries that
+// were already promoted count towards the required canaries.
+func (d *DeploymentState) HealthyCanariesRequired(auto bool) int {
+	required := d.DesiredCanaries
+	if auto && d.AutoPromote && d.AutoPromoteThreshold != 0 && d.DesiredCanaries != 0 {
+		// Round up so the threshold is always met, and require at least one
+		// healthy canary
+		required = max(1, (d.DesiredCanaries*d.AutoPromoteThreshold+99)/100)
+	}
+	return max(0, required-len(d.PromotedCanaries))
+}
+
 // DeploymentStatusUpdate is used to update the status of a given deployment
 type DeploymentStatusUpdate struct {
 	// DeploymentID is the ID of the deployment to update
@@ -10770,6 +11882,37 @@ type DeploymentStatusUpdate struct {
 
 	// UpdatedAt is the time of the update, stored as UnixNano
 	UpdatedAt int64
+
+	// PendingFailure, if set, is recorded on the deployment. It is never
+	// cleared by updates that don't set it.
+	PendingFailure *DeploymentFailure
+}
+
+// DeploymentFailure records the decision of the deployment watcher to fail a
+// deployment. It is persisted before the failure is applied so that the
+// failure, and any rollback, is completed as decided after a leader election
+// instead of being decided again from conditions that may no longer hold.
+type DeploymentFailure struct {
+	// StatusDescription is the description the deployment is failed with.
+	StatusDescription string
+
+	// RollbackJobVersion is the version of the job to roll back to, or nil
+	// if the job is not rolled back.
+	RollbackJobVersion *uint64
+
+	// DecidedAt is the time the failure was decided, stored as UnixNano.
+	DecidedAt int64
+}
+
+func (f *DeploymentFailure) Copy() *DeploymentFailure {
+	if f == nil {
+		return nil
+	}
+
+	c := new(DeploymentFailure)
+	*c = *f
+	c.RollbackJobVersion = pointer.Copy(f.RollbackJobVersion)
+	return c
 }
 
 // RescheduleTracker encapsulates previous reschedule events
@@ -11047,6 +12190,10 @@ type Allocation struct {
 	// TaskStates stores the state of each task,
 	TaskStates map[string]*TaskState
 
+	// TaskResults stores the results registered by the tasks, keyed by task
+	// name.
+	TaskResults map[string]*TaskResult
+
 	// AllocStates track meta data associated with changes to the state of the whole allocation, like becoming lost
 	AllocStates []*AllocState
 
@@ -11070,6 +12217,11 @@ type Allocation struct {
 	// NetworkStatus captures networking details of an allocation known at runtime
 	NetworkStatus *AllocNetworkStatus
 
+	// PreservedNetworkAddress is the bridge address of the previous
+	// allocation that the client should request again for this allocation,
+	// set by the scheduler when the group network preserves its identity.
+	PreservedNetworkAddress string `json:",omitempty"`
+
 	// FollowupEvalID captures a follow up evaluation created to handle a failed allocation
 	// that can be rescheduled in the future
 	FollowupEvalID string
@@ -11082,6 +12234,12 @@ type Allocation struct {
 	// to stop running because it got preempted
 	PreemptedByAllocation string
 
+	// PreemptionDeadline is the time in Unix nanoseconds at which the tasks
+	// of a preempted allocation are killed, once the grace period of its
+	// task group's preemption notice has passed. It is zero if the
+	// allocation is evicted immediately.
+	PreemptionDeadline int64
+
 	// SignedIdentities is a map of task names to signed identity/capability
 	// claim tokens for those tasks. If needed, it is populated in the plan
 	// applier.
@@ -11104,6 +12262,10 @@ type Allocation struct {
 
 	// ModifyTime is the time the allocation was last updated stored as UnixNano.
 	ModifyTime int64
+
+	// Timestamps records when the allocation went through the steps of its
+	// placement and startup.
+	Timestamps *AllocTimestamps
 }
 
 // GetID implements the IDGetter interface, required for pagination.
@@ -11280,8 +12442,17 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 		na.TaskStates = ts
 	}
 
+	if a.TaskResults != nil {
+		tr := make(map[string]*TaskResult, len(na.TaskResults))
+		for task, result := range na.TaskResults {
+			tr[task] = result.Copy()
+		}
+		na.TaskResults = tr
+	}
+
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = slices.Clone(a.PreemptedAllocations)
+	na.Timestamps = a.Timestamps.Copy()
 	return na
 }
 
@@ -11967,6 +13138,15 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// PlanRejections maps the ID of each node the plan applier rejected
+	// placements for to the reason it was rejected.
+	PlanRejections map[string]string
+
+	// FailedGang is the name of the task group's gang if its placements
+	// were dropped because another task group of the gang could not be
+	// placed.
+	FailedGang string
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -11983,9 +13163,19 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.QuotaExhausted = slices.Clone(na.QuotaExhausted)
 	na.Scores = maps.Clone(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
+	na.PlanRejections = maps.Clone(na.PlanRejections)
 	return na
 }
 
+// RejectPlan records that the plan applier rejected placements for the node
+// for the given reason.
+func (a *AllocMetric) RejectPlan(nodeID, reason string) {
+	if a.PlanRejections == nil {
+		a.PlanRejections = make(map[string]string)
+	}
+	a.PlanRejections[nodeID] = reason
+}
+
 func (a *AllocMetric) EvaluateNode() {
 	a.NodesEvaluated += 1
 }
@@ -12320,6 +13510,7 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerJobUnfreeze          = "job-unfreeze"
 )
 
 const (
@@ -12501,6 +13692,29 @@ type Evaluation struct {
 	// the SnapshotIndex being less than the CreateIndex.
 	SnapshotIndex uint64
 
+	// WorkerID is the ID of the scheduler worker that processed the
+	// evaluation, and DequeueTime is the time at which the worker dequeued it
+	// stored as UnixNano. Both are set when the worker updates or reblocks the
+	// evaluation.
+	WorkerID    string
+	DequeueTime int64
+
+	// SnapshotWaitTime is the time the worker spent waiting for the state
+	// store to catch up with the evaluation and with the results of its
+	// plans. PlanTime is the time its plans spent queued and evaluated by the
+	// plan applier, and RaftApplyTime is the time spent committing the plan
+	// results to Raft. They are set when the worker updates or reblocks the
+	// evaluation.
+	SnapshotWaitTime time.Duration
+	PlanTime         time.Duration
+	RaftApplyTime    time.Duration
+
+	// ForwardedFromRegion is the region that forwarded the request creating
+	// the evaluation, when it was submitted to a server of another region, and
+	// ForwardedTime is the time at which it was forwarded stored as UnixNano.
+	ForwardedFromRegion string
+	ForwardedTime       int64
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -12683,6 +13897,7 @@ func (e *Evaluation) ShouldBlock() bool {
 func (e *Evaluation) MakePlan(j *Job) *Plan {
 	p := &Plan{
 		EvalID:          e.ID,
+		EvalCreateTime:  e.CreateTime,
 		Priority:        e.Priority,
 		Job:             j,
 		NodeUpdate:      make(map[string][]*Allocation),
@@ -12781,6 +13996,11 @@ type Plan struct {
 	// msgpack omit empty fields during serialization
 	_struct bool `codec:",omitempty"` // nolint: structcheck
 
+	// ID uniquely identifies a plan submission. A plan that is resubmitted
+	// after an error keeps its ID, so that its results are applied at most
+	// once.
+	ID string
+
 	// EvalID is the evaluation ID this plan is associated with
 	EvalID string
 
@@ -12835,6 +14055,12 @@ type Plan struct {
 	// Plan. The leader will wait to evaluate the plan until its StateStore
 	// has reached at least this index.
 	SnapshotIndex uint64
+
+	// EvalCreateTime is the time the evaluation of the plan was created, and
+	// SubmitTime the time the plan was first submitted, stored as UnixNano.
+	// They are recorded on the allocations placed by the plan.
+	EvalCreateTime int64
+	SubmitTime     int64
 }
 
 func (p *Plan) GoString() string {
@@ -12999,6 +14225,40 @@ func (p *Plan) AppendAlloc(alloc *Allocation, job *Job) {
 	p.NodeAllocation[node] = append(existing, alloc)
 }
 
+// RemoveAlloc removes an allocation added with AppendAlloc from the plan,
+// along with the allocations it preempts.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	removeNodeAllocs(p.NodeAllocation, alloc.NodeID, func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	removeNodeAllocs(p.NodePreemptions, alloc.NodeID, func(a *Allocation) bool {
+		return a.PreemptedByAllocation == alloc.ID
+	})
+}
+
+// RemoveStoppedAlloc removes an allocation added with AppendStoppedAlloc from
+// the plan.
+func (p *Plan) RemoveStoppedAlloc(alloc *Allocation) {
+	removeNodeAllocs(p.NodeUpdate, alloc.NodeID, func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+}
+
+// removeNodeAllocs removes the allocations of the node matching the remove
+// function, deleting the node entry if no allocations remain.
+func removeNodeAllocs(nodeAllocs map[string][]*Allocation, nodeID string, remove func(*Allocation) bool) {
+	existing, ok := nodeAllocs[nodeID]
+	if !ok {
+		return
+	}
+	existing = slices.DeleteFunc(existing, remove)
+	if len(existing) > 0 {
+		nodeAllocs[nodeID] = existing
+	} else {
+		delete(nodeAllocs, nodeID)
+	}
+}
+
 // IsNoOp checks if this plan would do nothing
 func (p *Plan) IsNoOp() bool {
 	return len(p.NodeUpdate) == 0 &&
@@ -13055,6 +14315,10 @@ type PlanResult struct {
 	// retrying them repeatedly.
 	RejectedNodes []string
 
+	// NodeRejections maps the ID of each node whose part of the plan was
+	// rejected by the plan applier to the reason it was rejected.
+	NodeRejections map[string]string
+
 	// IneligibleNodes are nodes the plan applier has repeatedly rejected
 	// placements for and should therefore be considered ineligible by workers
 	// to avoid retrying them repeatedly.
@@ -13069,6 +14333,9 @@ type PlanResult struct {
 	// AllocIndex is the Raft index in which the evictions and
 	// allocations took place. This is used for the write index.
 	AllocIndex uint64
+
+	// RaftApplyTime is the time spent committing the plan result to Raft.
+	RaftApplyTime time.Duration
 }
 
 // IsNoOp checks if this plan result would do nothing
@@ -13100,6 +14367,24 @@ type PlanAnnotations struct {
 
 	// PreemptedAllocs is the set of allocations to be preempted to make the placement successful.
 	PreemptedAllocs []*AllocListStub
+
+	// PortConflicts is the set of nodes excluded because some of the static
+	// ports of a task group are already in use, keyed by task group name.
+	// Only the task groups with conflicts are included.
+	PortConflicts map[string]*PortConflicts
+}
+
+// PortConflicts describes the candidate nodes of a task group on which some of
+// its static ports are already in use. Candidate nodes are the ready nodes of
+// the job's datacenters and node pool with the host networks of these ports,
+// so these nodes are excluded solely because of the port conflicts.
+type PortConflicts struct {
+	// CandidateNodes is the number of candidate nodes the static ports of the
+	// task group were checked on.
+	CandidateNodes int
+
+	// Nodes maps the ID of each node with a conflict to the conflicting ports.
+	Nodes map[string][]int
 }
 
 // DesiredUpdates is the set of changes the scheduler would like to make given
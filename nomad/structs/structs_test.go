@@ -61,6 +61,26 @@ func TestNamespace_Validate(t *testing.T) {
 			},
 			Expected: "description longer than",
 		},
+		{
+			Test: "negative preemption priority floor",
+			Namespace: &Namespace{
+				Name: "foo",
+				PreemptionConfiguration: &NamespacePreemptionConfiguration{
+					PriorityFloor: -1,
+				},
+			},
+			Expected: "invalid preemption configuration",
+		},
+		{
+			Test: "negative gc threshold",
+			Namespace: &Namespace{
+				Name: "foo",
+				GCConfiguration: &NamespaceGCConfiguration{
+					EvalGCThreshold: -time.Hour,
+				},
+			},
+			Expected: "invalid gc configuration",
+		},
 		{
 			Test: "valid",
 			Namespace: &Namespace{
@@ -178,6 +198,35 @@ func TestNamespace_SetHash(t *testing.T) {
 	must.NotNil(t, ns.Hash)
 	must.Eq(t, out8, ns.Hash)
 	must.NotEq(t, out7, out8)
+
+	ns.PreemptionConfiguration = &NamespacePreemptionConfiguration{PriorityFloor: 50}
+	out9 := ns.SetHash()
+	must.NotNil(t, out9)
+	must.NotNil(t, ns.Hash)
+	must.Eq(t, out9, ns.Hash)
+	must.NotEq(t, out8, out9)
+
+	ns.GCConfiguration = &NamespaceGCConfiguration{JobGCThreshold: time.Hour}
+	out10 := ns.SetHash()
+	must.NotNil(t, out10)
+	must.NotNil(t, ns.Hash)
+	must.Eq(t, out10, ns.Hash)
+	must.NotEq(t, out9, out10)
+}
+
+func TestNamespacePreemptionConfiguration_AllowsPreemption(t *testing.T) {
+	ci.Parallel(t)
+
+	var unset *NamespacePreemptionConfiguration
+	must.True(t, unset.AllowsPreemption(100))
+
+	protected := &NamespacePreemptionConfiguration{Protected: true, PriorityFloor: 50}
+	must.False(t, protected.AllowsPreemption(10))
+
+	floor := &NamespacePreemptionConfiguration{PriorityFloor: 50}
+	must.True(t, floor.AllowsPreemption(49))
+	must.False(t, floor.AllowsPreemption(50))
+	must.False(t, floor.AllowsPreemption(80))
 }
 
 func TestNamespace_Copy(t *testing.T) {
@@ -203,6 +252,9 @@ func TestNamespace_Copy(t *testing.T) {
 			Default: "default",
 			Allowed: []string{"default"},
 		},
+		GCConfiguration: &NamespaceGCConfiguration{
+			JobGCThreshold: time.Hour,
+		},
 		Meta: map[string]string{
 			"a": "b",
 			"c": "d",
@@ -225,6 +277,7 @@ func TestNamespace_Copy(t *testing.T) {
 	nsCopy.ConsulConfiguration.Default = "infra"
 	nsCopy.ConsulConfiguration.Allowed = []string{}
 	nsCopy.ConsulConfiguration.Denied = []string{"dev"}
+	nsCopy.GCConfiguration.JobGCThreshold = 2 * time.Hour
 	nsCopy.Meta["a"] = "z"
 	must.NotEq(t, ns, nsCopy)
 
@@ -318,6 +371,15 @@ func TestJob_Validate(t *testing.T) {
 				`Invalid job type: "invalid-job-type"`,
 			},
 		},
+		{
+			name: "job min client version is invalid",
+			job: &Job{
+				MinClientVersion: "latest",
+			},
+			expErr: []string{
+				`Invalid min_client_version "latest"`,
+			},
+		},
 		{
 			name: "job periodic specification type is missing",
 			job: &Job{
@@ -627,6 +689,47 @@ func TestJob_Warnings(t *testing.T) {
 	}
 }
 
+func TestJob_SpecHash(t *testing.T) {
+	ci.Parallel(t)
+
+	base := testJob()
+	base.TaskGroups[0].Scaling = &ScalingPolicy{Min: 1, Max: 10, Enabled: true}
+	baseHash, err := base.SpecHash()
+	must.NoError(t, err)
+
+	// Fields set on registration do not change the hash
+	registered := base.Copy()
+	registered.Status = JobStatusRunning
+	registered.Version = 3
+	registered.Stable = true
+	registered.SubmitTime = 1
+	registered.CreateIndex = 10
+	registered.ModifyIndex = 20
+	registered.JobModifyIndex = 20
+	registered.NomadTokenID = "token"
+	registered.TaskGroups[0].Scaling.ID = "policy"
+	registered.TaskGroups[0].Scaling.CreateIndex = 10
+	hash, err := registered.SpecHash()
+	must.NoError(t, err)
+	must.Eq(t, baseHash, hash)
+
+	// The job itself is not modified
+	must.Eq(t, "policy", registered.TaskGroups[0].Scaling.ID)
+
+	// Changes to the specification do
+	changed := base.Copy()
+	changed.TaskGroups[0].Count++
+	hash, err = changed.SpecHash()
+	must.NoError(t, err)
+	must.NotEq(t, baseHash, hash)
+
+	changed = base.Copy()
+	changed.Meta["owner"] = "ops"
+	hash, err = changed.SpecHash()
+	must.NoError(t, err)
+	must.NotEq(t, baseHash, hash)
+}
+
 func TestJob_SpecChanged(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3160,6 +3263,57 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	require.Error(t, err, "log storage")
 }
 
+func TestLogConfig_Validate_MaxTotalSize(t *testing.T) {
+	ci.Parallel(t)
+
+	disk := &EphemeralDisk{SizeMB: 60}
+
+	// The total size caps the storage used by MaxFiles
+	l := &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, MaxTotalSizeMB: 50}
+	require.NoError(t, l.Validate(disk))
+	require.Equal(t, 50, l.StorageMB())
+
+	l.MaxTotalSizeMB = 5
+	require.ErrorContains(t, l.Validate(disk), "must be at least the max file size")
+
+	l.MaxTotalSizeMB = -1
+	require.ErrorContains(t, l.Validate(disk), "max total size must be non-negative")
+
+	l.MaxTotalSizeMB = 0
+	require.ErrorContains(t, l.Validate(disk), "log storage (100 MB)")
+}
+
+func TestLogConfig_Validate_Journald(t *testing.T) {
+	ci.Parallel(t)
+
+	// Logs sent to journald don't use the ephemeral disk
+	l := &LogConfig{MaxFiles: 10, MaxFileSizeMB: 10, Journald: true}
+	require.NoError(t, l.Validate(&EphemeralDisk{SizeMB: 10}))
+	require.Zero(t, l.StorageMB())
+
+	l.Disabled = true
+	require.ErrorContains(t, l.Validate(nil), "journald cannot be enabled when log collection is disabled")
+
+	task := &Task{
+		Name:   "web",
+		Driver: "docker",
+		Resources: &Resources{
+			CPU:      100,
+			MemoryMB: 100,
+		},
+		LogConfig: DefaultLogConfig(),
+	}
+	task.LogConfig.Journald = true
+	tg := &TaskGroup{EphemeralDisk: DefaultEphemeralDisk()}
+	require.ErrorContains(t, task.Validate(JobTypeService, tg),
+		`journald logging is only supported by the exec and raw_exec drivers; got "docker"`)
+
+	for _, driver := range []string{"exec", "raw_exec"} {
+		task.Driver = driver
+		require.NoError(t, task.Validate(JobTypeService, tg))
+	}
+}
+
 func TestLogConfig_Equals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3175,6 +3329,13 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equal(b))
 	})
 
+	t.Run("journald", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Journald: true}
+		require.False(t, a.Equal(b))
+		require.True(t, b.Equal(b.Copy()))
+	})
+
 	t.Run("max files", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 2, MaxFileSizeMB: 200}
@@ -3187,6 +3348,18 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equal(b))
 	})
 
+	t.Run("compress", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Compress: true}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("max total size", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, MaxTotalSizeMB: 400}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, MaxTotalSizeMB: 300}
+		require.False(t, a.Equal(b))
+	})
+
 	t.Run("same", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
@@ -3785,6 +3958,11 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 		ProgressDeadline: -25,
 		AutoRevert:       false,
 		Canary:           -1,
+
+		AutoPromoteThreshold:      101,
+		AutoPromoteMinHealthyTime: -5,
+
+		RollbackOn: &RollbackOn{Comparison: "equal"},
 	}
 
 	err := u.Validate()
@@ -3797,9 +3975,145 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 		"Progress deadline must be zero or greater",
 		"Minimum healthy time must be less than healthy deadline",
 		"Healthy deadline must be less than progress deadline",
+		"Auto promote threshold must be between 0 and 100",
+		"Auto promote minimum healthy time may not be less than zero",
+		"Auto promote threshold and minimum healthy time require Auto Promote",
+		"Query must not be empty",
+		"Invalid comparison given",
+		"Interval must be greater than zero",
 	)
 }
 
+func TestRollbackOn_Breached(t *testing.T) {
+	ci.Parallel(t)
+
+	r := &RollbackOn{Threshold: 0.5, Comparison: RollbackOnComparisonAbove}
+	must.True(t, r.Breached(0.6))
+	must.False(t, r.Breached(0.5))
+
+	r.Comparison = RollbackOnComparisonBelow
+	must.True(t, r.Breached(0.4))
+	must.False(t, r.Breached(0.5))
+}
+
+func TestDeploymentState_HealthyCanariesRequired(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		dstate      *DeploymentState
+		manual      bool
+		expRequired int
+	}{
+		{
+			name:        "no canaries",
+			dstate:      &DeploymentState{AutoPromote: true, AutoPromoteThreshold: 80},
+			expRequired: 0,
+		},
+		{
+			name:        "no threshold",
+			dstate:      &DeploymentState{AutoPromote: true, DesiredCanaries: 5},
+			expRequired: 5,
+		},
+		{
+			name:        "threshold without auto promote",
+			dstate:      &DeploymentState{DesiredCanaries: 5, AutoPromoteThreshold: 60},
+			expRequired: 5,
+		},
+		{
+			name:        "threshold",
+			dstate:      &DeploymentState{AutoPromote: true, DesiredCanaries: 5, AutoPromoteThreshold: 60},
+			expRequired: 3,
+		},
+		{
+			name:        "threshold rounds up",
+			dstate:      &DeploymentState{AutoPromote: true, DesiredCanaries: 3, AutoPromoteThreshold: 50},
+			expRequired: 2,
+		},
+		{
+			name:        "at least one canary",
+			dstate:      &DeploymentState{AutoPromote: true, DesiredCanaries: 3, AutoPromoteThreshold: 1},
+			expRequired: 1,
+		},
+		{
+			name:        "threshold with manual promotion",
+			dstate:      &DeploymentState{AutoPromote: true, DesiredCanaries: 5, AutoPromoteThreshold: 60},
+			manual:      true,
+			expRequired: 5,
+		},
+		{
+			name:        "promoted canaries",
+			dstate:      &DeploymentState{DesiredCanaries: 5, PromotedCanaries: []string{"a", "b"}},
+			expRequired: 3,
+		},
+		{
+			name: "promoted canaries meet threshold",
+			dstate: &DeploymentState{AutoPromote: true, DesiredCanaries: 5, AutoPromoteThreshold: 40,
+				PromotedCanaries: []string{"a", "b", "c"}},
+			expRequired: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expRequired, tc.dstate.HealthyCanariesRequired(!tc.manual))
+		})
+	}
+}
+
+func TestDeploymentPromoteRequest_CanariesToPromote(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name       string
+		req        *DeploymentPromoteRequest
+		placed     int
+		expPartial bool
+		expPromote int
+	}{
+		{
+			name:       "all",
+			req:        &DeploymentPromoteRequest{All: true},
+			placed:     4,
+			expPromote: 4,
+		},
+		{
+			name:       "count",
+			req:        &DeploymentPromoteRequest{CanaryCount: 3},
+			placed:     4,
+			expPartial: true,
+			expPromote: 3,
+		},
+		{
+			name:       "count exceeds placed",
+			req:        &DeploymentPromoteRequest{CanaryCount: 6},
+			placed:     4,
+			expPartial: true,
+			expPromote: 4,
+		},
+		{
+			name:       "percent rounds up",
+			req:        &DeploymentPromoteRequest{CanaryPercent: 30},
+			placed:     4,
+			expPartial: true,
+			expPromote: 2,
+		},
+		{
+			name:       "no placed canaries",
+			req:        &DeploymentPromoteRequest{CanaryPercent: 50},
+			expPartial: true,
+			expPromote: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expPartial, tc.req.IsPartial())
+			must.Eq(t, tc.expPromote, tc.req.CanariesToPromote(tc.placed))
+		})
+	}
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4926,6 +5240,55 @@ func TestAllocation_ReservedCores(t *testing.T) {
 
 }
 
+func TestTaskResult_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		result    *TaskResult
+		expectErr string
+	}{
+		{
+			name:      "nil",
+			expectErr: "result must have a summary, output URI or meta",
+		},
+		{
+			name:      "empty",
+			result:    &TaskResult{},
+			expectErr: "result must have a summary, output URI or meta",
+		},
+		{
+			name: "valid",
+			result: &TaskResult{
+				Summary:   "processed 42 rows",
+				OutputURI: "s3://bucket/output.csv",
+				Meta:      map[string]string{"rows": "42"},
+			},
+		},
+		{
+			name:      "missing scheme",
+			result:    &TaskResult{OutputURI: "bucket/output.csv"},
+			expectErr: "missing scheme",
+		},
+		{
+			name:      "too large",
+			result:    &TaskResult{Meta: map[string]string{"output": strings.Repeat("x", MaxTaskResultSize)}},
+			expectErr: "exceeds maximum",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.result.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
 func TestAllocation_Index(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6454,6 +6817,49 @@ func TestParameterizedJobConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestParameterizedJobConfig_Validate_Schema(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &ParameterizedJobConfig{
+		Payload:      DispatchPayloadOptional,
+		MetaRequired: []string{"count"},
+		MetaSchema: []*DispatchMetaSchema{
+			{Name: "count", Type: DispatchMetaTypeInteger, Enum: []string{"1", "2"}},
+		},
+		PayloadSchema: `{"type": "object"}`,
+	}
+	must.NoError(t, d.Validate())
+
+	d.MetaSchema = append(d.MetaSchema,
+		&DispatchMetaSchema{Name: "other", Type: DispatchMetaTypeString},
+		&DispatchMetaSchema{Name: "count", Type: "float"},
+	)
+	d.PayloadSchema = `{"type": "thing"}`
+	d.Payload = DispatchPayloadForbidden
+
+	err := d.Validate()
+	must.ErrorContains(t, err, `key "other" must be a required or optional meta key`)
+	must.ErrorContains(t, err, `key "count" is declared more than once`)
+	must.ErrorContains(t, err, "Payload schema can't be set when the payload is forbidden")
+	must.ErrorContains(t, err, `Invalid payload schema: $: unknown type "thing"`)
+
+	d.MetaSchema = []*DispatchMetaSchema{{Name: "count", Type: DispatchMetaTypeBool, Enum: []string{"yes"}}}
+	must.ErrorContains(t, d.Validate(), `invalid enum value "yes"`)
+}
+
+func TestDispatchMetaSchema_ValidateValue(t *testing.T) {
+	ci.Parallel(t)
+
+	m := &DispatchMetaSchema{Name: "count", Type: DispatchMetaTypeInteger, Pattern: "^[0-9]$"}
+	must.NoError(t, m.ValidateValue("7"))
+	must.ErrorContains(t, m.ValidateValue("1.5"), `value "1.5" is not a valid integer`)
+	must.ErrorContains(t, m.ValidateValue("42"), `does not match pattern`)
+
+	m = &DispatchMetaSchema{Name: "mode", Type: DispatchMetaTypeString, Enum: []string{"fast", "slow"}}
+	must.NoError(t, m.ValidateValue("fast"))
+	must.ErrorContains(t, m.ValidateValue("medium"), "is not one of")
+}
+
 func TestParameterizedJobConfig_Validate_NonBatch(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7243,6 +7649,45 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				Topology:  []string{"${attr.zone}", "${meta.rack}"},
+			},
+			err:  fmt.Errorf("Spread topology and attribute are mutually exclusive"),
+			name: "Topology with attribute",
+		},
+		{
+			spread: &Spread{
+				Weight:   50,
+				Topology: []string{"${attr.zone}", "${meta.rack}"},
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 25,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread topology does not support targets"),
+			name: "Topology with targets",
+		},
+		{
+			spread: &Spread{
+				Weight:   50,
+				Topology: []string{"${attr.zone}", "${attr.zone}"},
+			},
+			err:  fmt.Errorf("Spread topology level \"${attr.zone}\" already defined"),
+			name: "Duplicate topology level",
+		},
+		{
+			spread: &Spread{
+				Weight:   50,
+				Topology: []string{"${attr.zone}", "${meta.rack}"},
+			},
+			err:  nil,
+			name: "Valid topology spread",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8254,6 +8699,9 @@ func TestSpread_Equal(t *testing.T) {
 	}, {
 		Field: "SpreadTarget",
 		Apply: func(s *Spread) { s.SpreadTarget = nil },
+	}, {
+		Field: "Topology",
+		Apply: func(s *Spread) { s.Topology = []string{"${meta.rack}"} },
 	}})
 }
 
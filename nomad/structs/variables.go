@@ -134,6 +134,12 @@ type VariableLock struct {
 	// before another client may acquire the lock. This helps protect against
 	// split-brains.
 	LockDelay time.Duration
+
+	// FencingToken is the Raft index at which the current holder acquired the
+	// lock. It is set by Nomad and increases every time the lock changes
+	// holder, so resources protected by the lock can reject requests carrying
+	// a lower token than the last one they have seen.
+	FencingToken uint64
 }
 
 // Equal performs an equality check on the two variable lock objects. It
@@ -151,6 +157,9 @@ func (vl *VariableLock) Equal(vl2 *VariableLock) bool {
 	if vl.LockDelay != vl2.LockDelay {
 		return false
 	}
+	if vl.FencingToken != vl2.FencingToken {
+		return false
+	}
 	return true
 }
 
@@ -541,6 +550,10 @@ const (
 	// VarOpLockRelease is the variable operation used when attempting to
 	// release a held variable lock.
 	VarOpLockRelease VarOp = "lock-release"
+
+	// VarOpLockSteal is the variable operation used when acquiring a variable
+	// lock regardless of its current holder.
+	VarOpLockSteal VarOp = "lock-steal"
 )
 
 // VarOpResult constants give possible operations results from a transaction.
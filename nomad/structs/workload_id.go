@@ -5,6 +5,7 @@ package structs
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strings"
@@ -80,6 +81,10 @@ type IdentityClaims struct {
 	// WorkloadIdentityConfiguration, controlled by server configuration
 	ExtraClaims map[string]string `json:"extra_claims,omitempty"`
 
+	// Meta are the values of the job, group and task meta keys requested by
+	// this identity's MetaClaims.
+	Meta map[string]string `json:"nomad_meta,omitempty"`
+
 	jwt.Claims
 }
 
@@ -210,6 +215,7 @@ func (b *IdentityClaimsBuilder) Build(now time.Time) *IdentityClaims {
 		claims.VaultNamespace = b.vault.Namespace
 		claims.VaultRole = b.vault.Role
 	}
+	claims.Meta = b.metaClaims()
 
 	claims.Audience = slices.Clone(b.wid.Audience)
 	claims.setSubject(b.job, b.alloc.TaskGroup, b.wihandle.WorkloadIdentifier, b.wid.Name)
@@ -220,6 +226,35 @@ func (b *IdentityClaimsBuilder) Build(now time.Time) *IdentityClaims {
 	return claims
 }
 
+// metaClaims returns the values of the meta keys requested by the identity,
+// with task meta taking precedence over group meta and group meta over job
+// meta. Keys missing from the meta are omitted.
+func (b *IdentityClaimsBuilder) metaClaims() map[string]string {
+	if len(b.wid.MetaClaims) == 0 {
+		return nil
+	}
+
+	meta := maps.Clone(b.job.Meta)
+	if meta == nil {
+		meta = map[string]string{}
+	}
+	maps.Copy(meta, b.tg.Meta)
+	if b.task != nil {
+		maps.Copy(meta, b.task.Meta)
+	}
+
+	claims := map[string]string{}
+	for _, key := range b.wid.MetaClaims {
+		if v, ok := meta[key]; ok {
+			claims[key] = v
+		}
+	}
+	if len(claims) == 0 {
+		return nil
+	}
+	return claims
+}
+
 func strAttrGet[T any](x *T, fn func(x *T) string) string {
 	if x != nil {
 		return fn(x)
@@ -318,6 +353,11 @@ type WorkloadIdentity struct {
 	// this identity (eg the JWT "exp" claim).
 	TTL time.Duration
 
+	// MetaClaims are the keys of the job, group or task meta added to the
+	// "nomad_meta" claim. Only keys allowed by the server identity_meta_claims
+	// configuration may be requested.
+	MetaClaims []string
+
 	// Note: ExtraClaims is available on config/WorkloadIdentity but not
 	// available here on jobspecs because that might allow a job author to
 	// escalate their privileges if they know what claim mappings to expect.
@@ -363,6 +403,7 @@ func (wi *WorkloadIdentity) Copy() *WorkloadIdentity {
 		Filepath:     wi.Filepath,
 		ServiceName:  wi.ServiceName,
 		TTL:          wi.TTL,
+		MetaClaims:   slices.Clone(wi.MetaClaims),
 	}
 }
 
@@ -407,6 +448,10 @@ func (wi *WorkloadIdentity) Equal(other *WorkloadIdentity) bool {
 		return false
 	}
 
+	if !slices.Equal(wi.MetaClaims, other.MetaClaims) {
+		return false
+	}
+
 	return true
 }
 
@@ -447,6 +492,12 @@ func (wi *WorkloadIdentity) Validate() error {
 		}
 	}
 
+	for i, key := range wi.MetaClaims {
+		if key == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("an empty string is an invalid meta claim (%d)", i+1))
+		}
+	}
+
 	switch wi.ChangeMode {
 	case "", WIChangeModeNoop, WIChangeModeRestart:
 		// Treat "" as noop. Make sure signal isn't set.
@@ -463,10 +514,6 @@ func (wi *WorkloadIdentity) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid change_mode: %s", wi.ChangeMode))
 	}
 
-	if wi.TTL > 0 && (wi.Name == "" || wi.Name == WorkloadIdentityDefaultName) {
-		mErr.Errors = append(mErr.Errors, fmt.Errorf("ttl for default identity not yet supported"))
-	}
-
 	if wi.TTL < 0 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("ttl must be >= 0"))
 	}
@@ -537,6 +537,39 @@ func TestNewIdentityClaims(t *testing.T) {
 	}
 }
 
+func TestIdentityClaimsBuilder_MetaClaims(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	job.Meta = map[string]string{"owner": "job-owner", "team": "platform"}
+	tg := job.TaskGroups[0]
+	tg.Meta = map[string]string{"team": "web", "secret": "hunter2"}
+	task := tg.Tasks[0]
+	task.Meta = map[string]string{"owner": "task-owner"}
+
+	alloc := &Allocation{
+		ID:        uuid.Generate(),
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		TaskGroup: tg.Name,
+	}
+	wid := &WorkloadIdentity{
+		Name:       WorkloadIdentityDefaultName,
+		MetaClaims: []string{"owner", "team", "missing"},
+	}
+
+	claims := NewIdentityClaimsBuilder(job, alloc, task.IdentityHandle(wid), wid).
+		WithTask(task).
+		Build(time.Now())
+	must.Eq(t, map[string]string{"owner": "task-owner", "team": "web"}, claims.Meta)
+
+	// Identities without meta claims do not get any meta
+	claims = NewIdentityClaimsBuilder(job, alloc, task.IdentityHandle(task.Identity), nil).
+		WithTask(task).
+		Build(time.Now())
+	must.Nil(t, claims.Meta)
+}
+
 func TestWorkloadIdentity_Equal(t *testing.T) {
 	ci.Parallel(t)
 
@@ -595,6 +628,10 @@ func TestWorkloadIdentity_Equal(t *testing.T) {
 
 	newWI.TTL = 123 * time.Hour
 	must.NotEqual(t, orig, newWI)
+
+	newWI.TTL = orig.TTL
+	newWI.MetaClaims = []string{"owner"}
+	must.NotEqual(t, orig, newWI)
 }
 
 // TestWorkloadIdentity_Validate asserts that canonicalized workload identities
@@ -681,6 +718,15 @@ func TestWorkloadIdentity_Validate(t *testing.T) {
 			},
 			Warn: `using env=true without change_mode="restart" may result in task not getting updated identity`,
 		},
+		{
+			Desc: "Empty meta claim",
+			In: WorkloadIdentity{
+				Name:       "foo-id",
+				Audience:   []string{"http://nomadproject.io/"},
+				MetaClaims: []string{"owner", ""},
+			},
+			Err: `an empty string is an invalid meta claim (2)`,
+		},
 		{
 			Desc: "Signal without signal",
 			In: WorkloadIdentity{
@@ -763,6 +809,19 @@ func TestWorkloadIdentity_Validate(t *testing.T) {
 			},
 			Err: "ttl must be >= 0",
 		},
+		{
+			Desc: "Default TTL",
+			In: WorkloadIdentity{
+				Name:     WorkloadIdentityDefaultName,
+				Audience: []string{WorkloadIdentityDefaultAud},
+				TTL:      24 * time.Hour,
+			},
+			Exp: WorkloadIdentity{
+				Name:     WorkloadIdentityDefaultName,
+				Audience: []string{WorkloadIdentityDefaultAud},
+				TTL:      24 * time.Hour,
+			},
+		},
 		{
 			Desc: "No TTL",
 			In: WorkloadIdentity{
@@ -96,7 +96,8 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 	if err != nil {
 		return err
 	}
-	err = hasOperationPermissions(aclObj, args.Var.Namespace, args.Var.Path, args.Op)
+	claim := auth.IdentityToACLClaim(args.GetIdentity(), sv.srv.State())
+	err = hasOperationPermissions(aclObj, args.Var.Namespace, args.Var.Path, args.Op, claim)
 	if err != nil {
 		return err
 	}
@@ -110,7 +111,7 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 
 	switch args.Op {
 	case structs.VarOpSet, structs.VarOpCAS, structs.VarOpLockAcquire,
-		structs.VarOpLockRelease:
+		structs.VarOpLockRelease, structs.VarOpLockSteal:
 		ev, err = sv.encrypt(args.Var)
 		if err != nil {
 			return fmt.Errorf("variable error: encrypt: %w", err)
@@ -129,6 +130,16 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 		}
 	}
 
+	// Look up the lock being stolen, so its TTL timer can be removed once the
+	// lock has a new holder.
+	var stolen *structs.VariableEncrypted
+	if args.Op == structs.VarOpLockSteal {
+		stolen, err = sv.srv.State().GetVariable(nil, args.Var.Namespace, args.Var.Path)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Make a SVEArgs
 	sveArgs := structs.VarApplyStateRequest{
 		Op:           args.Op,
@@ -159,6 +170,11 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 			sv.timers.CreateVariableLockTTLTimer(ev.Copy())
 		case structs.VarOpLockRelease:
 			sv.timers.RemoveVariableLockTTLTimer(ev.Copy())
+		case structs.VarOpLockSteal:
+			if stolen != nil && stolen.Lock != nil {
+				sv.timers.RemoveVariableLockTTLTimer(stolen.Copy())
+			}
+			sv.timers.CreateVariableLockTTLTimer(ev.Copy())
 		}
 	}
 
@@ -170,11 +186,11 @@ func hasReadPermission(aclObj *acl.ACL, namespace, path string) bool {
 		path, acl.VariablesCapabilityRead, nil)
 }
 
-func hasOperationPermissions(aclObj *acl.ACL, namespace, path string, op structs.VarOp) error {
+func hasOperationPermissions(aclObj *acl.ACL, namespace, path string, op structs.VarOp, claim *acl.ACLClaim) error {
 
 	hasPerm := func(perm string) bool {
 		return aclObj.AllowVariableOperation(namespace,
-			path, perm, nil)
+			path, perm, claim)
 	}
 
 	switch op {
@@ -188,6 +204,13 @@ func hasOperationPermissions(aclObj *acl.ACL, namespace, path string, op structs
 		if !hasPerm(acl.VariablesCapabilityDestroy) {
 			return structs.ErrPermissionDenied
 		}
+
+	case structs.VarOpLockSteal:
+		// Stealing a lock revokes the lock of its current holder, so it
+		// requires the same permissions as deleting the variable.
+		if !hasPerm(acl.VariablesCapabilityWrite) || !hasPerm(acl.VariablesCapabilityDestroy) {
+			return structs.ErrPermissionDenied
+		}
 	default:
 		return fmt.Errorf("svPreApply: unexpected VarOp received: %q", op)
 	}
@@ -198,12 +221,18 @@ func hasOperationPermissions(aclObj *acl.ACL, namespace, path string, op structs
 func canonicalizeAndValidate(args *structs.VariablesApplyRequest) error {
 
 	switch args.Op {
-	case structs.VarOpLockAcquire:
+	case structs.VarOpLockAcquire, structs.VarOpLockSteal:
 		// In case the user wants to use the default values so no lock data was provided.
 		if args.Var.VariableMetadata.Lock == nil {
 			args.Var.VariableMetadata.Lock = &structs.VariableLock{}
 		}
 
+		// A stolen lock always gets a new ID, so the previous holder can't
+		// renew or release it.
+		if args.Op == structs.VarOpLockSteal {
+			args.Var.VariableMetadata.Lock.ID = ""
+		}
+
 		args.Var.Canonicalize()
 
 		err := args.Var.ValidateForLock()
@@ -626,7 +655,7 @@ func (sv *Variables) RenewLock(args *structs.VariablesRenewLockRequest, reply *s
 		return err
 	}
 	if !aclObj.AllowVariableOperation(args.WriteRequest.Namespace, args.Path,
-		acl.VariablesCapabilityWrite, nil) {
+		acl.VariablesCapabilityWrite, auth.IdentityToACLClaim(args.GetIdentity(), sv.srv.State())) {
 		return structs.ErrPermissionDenied
 	}
 
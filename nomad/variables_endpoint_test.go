@@ -1578,6 +1578,211 @@ func TestVariablesEndpoint_Read_Lock_ACL(t *testing.T) {
 	})
 }
 
+func TestVariablesEndpoint_Apply_LockSteal(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+	state := srv.fsm.State()
+
+	writePol := mock.NamespacePolicyWithVariables(
+		structs.DefaultNamespace, "", []string{"list-jobs"},
+		map[string][]string{
+			"dropbox/*": {"list", "read", "write"},
+		})
+	writeToken := mock.CreatePolicyAndToken(t, state, 1003, "test-write", writePol)
+
+	destroyPol := mock.NamespacePolicyWithVariables(
+		structs.DefaultNamespace, "", []string{"list-jobs"},
+		map[string][]string{
+			"dropbox/*": {"list", "read", "write", "destroy"},
+		})
+	destroyToken := mock.CreatePolicyAndToken(t, state, 1005, "test-destroy", destroyPol)
+
+	sv := mock.Variable()
+	sv.Path = "dropbox/a"
+
+	latest := sv.Copy()
+	t.Run("successfully acquire lock on new variable", func(t *testing.T) {
+		sv := sv.Copy()
+		sv.VariableMetadata.Lock = &structs.VariableLock{
+			TTL:       24 * time.Hour,
+			LockDelay: 15 * time.Second,
+		}
+
+		applyReq := structs.VariablesApplyRequest{
+			Op:  structs.VarOpLockAcquire,
+			Var: &sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: rootToken.SecretID,
+			},
+		}
+
+		applyResp := new(structs.VariablesApplyResponse)
+		err := msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, &applyReq, applyResp)
+
+		must.NoError(t, err)
+		must.Eq(t, structs.VarOpResultOk, applyResp.Result)
+		must.NotNil(t, applyResp.Output.VariableMetadata.Lock)
+		must.NonZero(t, applyResp.Output.VariableMetadata.Lock.FencingToken)
+		latest = *applyResp.Output
+	})
+
+	t.Run("steal lock without destroy permission", func(t *testing.T) {
+		sv := sv.Copy()
+		sv.VariableMetadata.Lock = &structs.VariableLock{
+			TTL:       24 * time.Hour,
+			LockDelay: 15 * time.Second,
+		}
+
+		applyReq := structs.VariablesApplyRequest{
+			Op:  structs.VarOpLockSteal,
+			Var: &sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: writeToken.SecretID,
+			},
+		}
+
+		applyResp := new(structs.VariablesApplyResponse)
+		err := msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, &applyReq, applyResp)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	})
+
+	t.Run("successfully steal lock", func(t *testing.T) {
+		sv := sv.Copy()
+		sv.VariableMetadata.Lock = &structs.VariableLock{
+			ID:        latest.LockID(),
+			TTL:       24 * time.Hour,
+			LockDelay: 15 * time.Second,
+		}
+
+		applyReq := structs.VariablesApplyRequest{
+			Op:  structs.VarOpLockSteal,
+			Var: &sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: destroyToken.SecretID,
+			},
+		}
+
+		runningTimers := srv.lockTTLTimer.TimerNum()
+
+		applyResp := new(structs.VariablesApplyResponse)
+		err := msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, &applyReq, applyResp)
+
+		must.NoError(t, err)
+		must.Eq(t, structs.VarOpResultOk, applyResp.Result)
+		must.NotNil(t, applyResp.Output.VariableMetadata.Lock)
+
+		// The stolen lock gets a new ID and fencing token, and replaces the
+		// TTL timer of the previous holder.
+		stolen := applyResp.Output.VariableMetadata.Lock
+		must.NotEq(t, latest.LockID(), stolen.ID)
+		must.Greater(t, latest.Lock.FencingToken, stolen.FencingToken)
+		must.Nil(t, srv.lockTTLTimer.Get(latest.LockID()))
+		must.NotNil(t, srv.lockTTLTimer.Get(stolen.ID))
+		must.Eq(t, runningTimers, srv.lockTTLTimer.TimerNum())
+
+		// The previous holder can no longer release the lock.
+		release := latest.Copy()
+		release.Items = nil
+		applyReq = structs.VariablesApplyRequest{
+			Op:  structs.VarOpLockRelease,
+			Var: &release,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: rootToken.SecretID,
+			},
+		}
+
+		applyResp = new(structs.VariablesApplyResponse)
+		err = msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, &applyReq, applyResp)
+		must.NoError(t, err)
+		must.Eq(t, structs.VarOpResultConflict, applyResp.Result)
+	})
+}
+
+func TestVariablesEndpoint_Apply_LeadershipLock(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, srv.fsm.State().UpsertAllocs(
+		structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	task := alloc.LookupTask("web")
+	claims := structs.NewIdentityClaimsBuilder(alloc.Job, alloc,
+		&structs.WIHandle{
+			WorkloadIdentifier: "web",
+			WorkloadType:       structs.WorkloadTypeTask,
+		},
+		task.Identity).
+		WithTask(task).
+		Build(time.Now())
+	idToken, _, err := srv.encrypter.SignClaims(claims)
+	must.NoError(t, err)
+
+	apply := func(op structs.VarOp, path string) (*structs.VariablesApplyResponse, error) {
+		applyReq := structs.VariablesApplyRequest{
+			Op: op,
+			Var: &structs.VariableDecrypted{
+				VariableMetadata: structs.VariableMetadata{
+					Namespace: alloc.Namespace,
+					Path:      path,
+					Lock: &structs.VariableLock{
+						TTL:       15 * time.Second,
+						LockDelay: 15 * time.Second,
+					},
+				},
+			},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: alloc.Namespace,
+				AuthToken: idToken,
+			},
+		}
+		applyResp := new(structs.VariablesApplyResponse)
+		err := msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, &applyReq, applyResp)
+		return applyResp, err
+	}
+
+	// Workloads can hold the locks of the leader elections of their job
+	path := "nomad/jobs/" + alloc.JobID + "/leadership/primary"
+	applyResp, err := apply(structs.VarOpLockAcquire, path)
+	must.NoError(t, err)
+	must.Eq(t, structs.VarOpResultOk, applyResp.Result)
+
+	renewReq := structs.VariablesRenewLockRequest{
+		Path:   path,
+		LockID: applyResp.Output.LockID(),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: alloc.Namespace,
+			AuthToken: idToken,
+		},
+	}
+	renewResp := new(structs.VariablesRenewLockResponse)
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesRenewLockRPCMethod, &renewReq, renewResp))
+
+	// But not the locks of other jobs, nor the variables of their own job
+	_, err = apply(structs.VarOpLockAcquire, "nomad/jobs/other/leadership/primary")
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	_, err = apply(structs.VarOpLockAcquire, "nomad/jobs/"+alloc.JobID)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+}
+
 func TestVariablesEndpoint_RenewLock(t *testing.T) {
 	ci.Parallel(t)
 	srv, shutdown := TestServer(t, func(c *Config) {
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"runtime/pprof"
 	"strings"
 	"sync"
 	"time"
@@ -52,6 +53,20 @@ const (
 	// dequeue errors after start. This is to improve the user experience
 	// in dev mode where the leader isn't elected for a few seconds.
 	dequeueErrGrace = 10 * time.Second
+
+	// planQueueBackpressureDepth is the depth of the plan queue above which
+	// workers delay dequeuing their next evaluation. Plans submitted while
+	// the queue is this deep are likely to be evaluated against a stale
+	// snapshot and partially rejected.
+	planQueueBackpressureDepth = 8
+
+	// planQueueBackpressureStep is the delay added for each plan queued
+	// above planQueueBackpressureDepth.
+	planQueueBackpressureStep = 10 * time.Millisecond
+
+	// planQueueBackpressureLimit is the maximum delay before dequeuing the
+	// next evaluation while the plan queue is backed up.
+	planQueueBackpressureLimit = 1 * time.Second
 )
 
 type WorkerStatus int
@@ -121,6 +136,28 @@ type Worker struct {
 	// first invoked. It is used to mark the SnapshotIndex of evaluations
 	// Created, Updated or Reblocked.
 	snapshotIndex uint64
+
+	// dequeueTime is the time at which the evaluation being processed was
+	// dequeued. It is used to mark the DequeueTime of evaluations Updated or
+	// Reblocked.
+	dequeueTime time.Time
+
+	// snapshotWaitTime, planTime and raftApplyTime accumulate the time spent
+	// processing the evaluation waiting for state snapshots, at the plan
+	// applier, and waiting for plan results to be committed to Raft. They
+	// are used to mark the evaluations Updated or Reblocked.
+	snapshotWaitTime time.Duration
+	planTime         time.Duration
+	raftApplyTime    time.Duration
+
+	// planQueueDepth is the depth of the leader's plan queue as reported
+	// when the last plan was submitted. It is used to slow down dequeuing
+	// evaluations while the plan applier is behind.
+	planQueueDepth int
+
+	// trace is the timing of the evaluation being processed, recorded while
+	// a scheduler trace is running on the server.
+	trace *structs.SchedulerEvalTrace
 }
 
 // NewWorker starts a new scheduler worker associated with the given server
@@ -407,6 +444,13 @@ func (w *Worker) run(raftSyncLimit time.Duration) {
 		if w.workerShuttingDown() {
 			return
 		}
+
+		// Slow down if the plan queue was backed up when we last submitted
+		// a plan
+		if w.planQueueBackpressure() {
+			return
+		}
+
 		// Dequeue a pending evaluation
 		eval, token, waitIndex, shutdown := w.dequeueEvaluation(dequeueTimeout)
 		if shutdown {
@@ -423,9 +467,16 @@ func (w *Worker) run(raftSyncLimit time.Duration) {
 			return
 		}
 
+		w.trace = w.srv.schedulerTracer.evalTrace(eval, w.id, w.dequeueTime)
+
 		// Wait for the raft log to catchup to the evaluation
 		w.setWorkloadStatus(WorkloadWaitingForRaft)
+		snapStart := time.Now()
 		snap, err := w.snapshotMinIndex(waitIndex, raftSyncLimit)
+		w.snapshotWaitTime += time.Since(snapStart)
+		if w.trace != nil {
+			w.trace.SnapshotDuration = w.snapshotWaitTime
+		}
 		if err != nil {
 			var timeoutErr ErrMinIndexDeadlineExceeded
 			if errors.As(err, &timeoutErr) {
@@ -522,6 +573,8 @@ REQ:
 	// Check if we got a response
 	if resp.Eval != nil {
 		w.logger.Debug("dequeued evaluation", "eval_id", resp.Eval.ID, "type", resp.Eval.Type, "namespace", resp.Eval.Namespace, "job_id", resp.Eval.JobID, "node_id", resp.Eval.NodeID, "triggered_by", resp.Eval.TriggeredBy)
+		w.dequeueTime = time.Now()
+		w.snapshotWaitTime, w.planTime, w.raftApplyTime = 0, 0, 0
 		return resp.Eval, resp.Token, resp.GetWaitIndex(), false
 	}
 
@@ -558,6 +611,13 @@ func (w *Worker) sendAcknowledgement(eval *structs.Evaluation, token string, ack
 	} else {
 		w.logger.Debug(fmt.Sprintf("%s evaluation", verb), "eval_id", eval.ID, "type", eval.Type, "namespace", eval.Namespace, "job_id", eval.JobID, "node_id", eval.NodeID, "triggered_by", eval.TriggeredBy)
 	}
+
+	if w.trace != nil {
+		w.trace.TotalDuration = time.Since(w.trace.DequeueTime)
+		w.trace.Acked = ack && err == nil
+		w.srv.schedulerTracer.record(w.trace)
+		w.trace = nil
+	}
 }
 
 // sendNack makes a best effort to nack the evaluation.
@@ -630,8 +690,20 @@ func (w *Worker) invokeScheduler(snap *state.StateSnapshot, eval *structs.Evalua
 		}
 	}
 
-	// Process the evaluation
-	err = sched.Process(eval)
+	// Process the evaluation. While a scheduler trace is running the
+	// invocation is labeled so it can be found in the CPU profile.
+	if w.trace != nil {
+		start := time.Now()
+		labels := pprof.Labels("eval_id", eval.ID, "job_id", eval.JobID,
+			"scheduler", eval.Type, "worker_id", w.id)
+		pprof.Do(w.ctx, labels, func(context.Context) {
+			err = sched.Process(eval)
+		})
+		w.trace.ScheduleDuration = time.Since(start) -
+			w.trace.PlanSubmitDuration - w.trace.PlanWaitDuration
+	} else {
+		err = sched.Process(eval)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to process evaluation: %v", err)
 	}
@@ -658,6 +730,10 @@ func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.
 	// Add the evaluation token to the plan
 	plan.EvalToken = w.evalToken
 
+	// Identify the submission, so that the plan isn't applied twice if it is
+	// resubmitted below after an error that left its outcome unknown.
+	plan.ID = uuid.Generate()
+
 	// Add SnapshotIndex to ensure leader's StateStore processes the Plan
 	// at or after the index it was created.
 	plan.SnapshotIndex = w.snapshotIndex
@@ -668,6 +744,8 @@ func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.
 		plan.NormalizeAllocations()
 	}
 
+	plan.SubmitTime = time.Now().UnixNano()
+
 	// Setup the request
 	req := structs.PlanRequest{
 		Plan: plan,
@@ -679,7 +757,14 @@ func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.
 
 SUBMIT:
 	// Make the RPC call
-	if err := w.srv.RPC("Plan.Submit", &req, &resp); err != nil {
+	submitStart := time.Now()
+	err := w.srv.RPC("Plan.Submit", &req, &resp)
+	submitTime := time.Since(submitStart)
+	if w.trace != nil {
+		w.trace.PlanSubmitDuration += submitTime
+		w.trace.Plans++
+	}
+	if err != nil {
 		w.logger.Error("failed to submit plan for evaluation", "eval_id", plan.EvalID, "error", err)
 		if w.shouldResubmit(err) && !w.backoffErr(backoffBaselineSlow, backoffLimitSlow) {
 			goto SUBMIT
@@ -688,6 +773,7 @@ SUBMIT:
 	} else {
 		w.logger.Debug("submitted plan for evaluation", "eval_id", plan.EvalID)
 		w.backoffReset()
+		w.planQueueDepth = resp.PlanQueueDepth
 	}
 
 	// Look for a result
@@ -695,6 +781,8 @@ SUBMIT:
 	if result == nil {
 		return nil, nil, fmt.Errorf("missing result")
 	}
+	w.raftApplyTime += result.RaftApplyTime
+	w.planTime += max(submitTime-result.RaftApplyTime, 0)
 
 	// Check if a state update is required. This could be required if we
 	// planned based on stale data, which is causing issues. For example, a
@@ -705,8 +793,13 @@ SUBMIT:
 		// Wait for the raft log to catchup to the evaluation
 		w.logger.Debug("refreshing state", "refresh_index", result.RefreshIndex, "eval_id", plan.EvalID)
 
-		var err error
+		waitStart := time.Now()
 		state, err = w.snapshotMinIndex(result.RefreshIndex, raftSyncLimit)
+		waitTime := time.Since(waitStart)
+		w.snapshotWaitTime += waitTime
+		if w.trace != nil {
+			w.trace.PlanWaitDuration += waitTime
+		}
 		if err != nil {
 			return nil, nil, err
 		}
@@ -726,8 +819,9 @@ func (w *Worker) UpdateEval(eval *structs.Evaluation) error {
 	}
 	defer metrics.MeasureSince([]string{"nomad", "worker", "update_eval"}, time.Now())
 
-	// Store the snapshot index in the eval
+	// Store the snapshot index and the worker processing the eval
 	eval.SnapshotIndex = w.snapshotIndex
+	w.markProcessed(eval)
 	eval.UpdateModifyTime()
 
 	// Setup the request
@@ -831,8 +925,9 @@ func (w *Worker) ReblockEval(eval *structs.Evaluation) error {
 		}
 	}
 
-	// Store the snapshot index in the eval
+	// Store the snapshot index and the worker processing the eval
 	eval.SnapshotIndex = w.snapshotIndex
+	w.markProcessed(eval)
 	eval.UpdateModifyTime()
 
 	// Setup the request
@@ -860,6 +955,21 @@ SUBMIT:
 	return nil
 }
 
+// markProcessed records the worker and the time at which it dequeued the
+// evaluation being processed, so the event stream can report how long the
+// evaluation waited in the eval broker and how long it took to process. It
+// also records the time spent waiting for snapshots and applying plans, so
+// slow placements can be attributed to scheduling or to consensus.
+func (w *Worker) markProcessed(eval *structs.Evaluation) {
+	eval.WorkerID = w.id
+	if !w.dequeueTime.IsZero() {
+		eval.DequeueTime = w.dequeueTime.UnixNano()
+	}
+	eval.SnapshotWaitTime = w.snapshotWaitTime
+	eval.PlanTime = w.planTime
+	eval.RaftApplyTime = w.raftApplyTime
+}
+
 // shouldResubmit checks if a given error should be swallowed and the plan
 // resubmitted after a backoff. Usually these are transient errors that
 // the cluster should heal from quickly.
@@ -894,6 +1004,41 @@ func (w *Worker) backoffErr(base, limit time.Duration) bool {
 	}
 }
 
+// planQueueBackpressure delays the worker while the plan queue reported by
+// the last plan submission is backed up. Plans generated while the plan
+// applier is behind are likely to be rejected and retried, so waiting both
+// reduces the load on the leader and lets the eval broker coalesce more
+// evaluations per job, resulting in fewer but larger plans. Returns true if
+// the worker was shutdown while waiting.
+func (w *Worker) planQueueBackpressure() bool {
+	delay := planQueueBackpressureDelay(w.planQueueDepth)
+	w.planQueueDepth = 0
+	if delay == 0 {
+		return false
+	}
+
+	w.setWorkloadStatus(WorkloadBackoff)
+	metrics.IncrCounter([]string{"nomad", "worker", "plan_queue_backpressure"}, 1)
+	w.logger.Trace("plan queue is backed up, delaying dequeue", "delay", delay)
+
+	select {
+	case <-time.After(delay):
+		return false
+	case <-w.ctx.Done():
+		return true
+	}
+}
+
+// planQueueBackpressureDelay returns how long a worker should wait before
+// dequeuing its next evaluation given the depth of the plan queue.
+func planQueueBackpressureDelay(depth int) time.Duration {
+	if depth <= planQueueBackpressureDepth {
+		return 0
+	}
+	delay := time.Duration(depth-planQueueBackpressureDepth) * planQueueBackpressureStep
+	return min(delay, planQueueBackpressureLimit)
+}
+
 // backoffReset is used to reset the failure count for
 // exponential backoff
 func (w *Worker) backoffReset() {
@@ -65,7 +65,7 @@ func TestWorker_dequeueEvaluation(t *testing.T) {
 		c.EnabledSchedulers = []string{structs.JobTypeService}
 	})
 	defer cleanupS1()
-	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
 
 	// Create the evaluation
 	eval1 := mock.Eval()
@@ -103,7 +103,7 @@ func TestWorker_dequeueEvaluation_SerialJobs(t *testing.T) {
 		c.EnabledSchedulers = []string{structs.JobTypeService}
 	})
 	defer cleanupS1()
-	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
 
 	// Create the evaluation
 	eval1 := mock.Eval()
@@ -158,7 +158,7 @@ func TestWorker_dequeueEvaluation_paused(t *testing.T) {
 		c.EnabledSchedulers = []string{structs.JobTypeService}
 	})
 	defer cleanupS1()
-	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
 
 	// Create the evaluation
 	eval1 := mock.Eval()
@@ -289,7 +289,7 @@ func TestWorker_sendAck(t *testing.T) {
 		c.EnabledSchedulers = []string{structs.JobTypeService}
 	})
 	defer cleanupS1()
-	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
 
 	// Create the evaluation
 	eval1 := mock.Eval()
@@ -379,6 +379,53 @@ func TestWorker_runBackoff(t *testing.T) {
 	must.Eq(t, 0, srv.evalBroker.Stats().TotalUnacked)
 }
 
+func TestWorker_planQueueBackpressureDelay(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, 0, planQueueBackpressureDelay(0))
+	must.Eq(t, 0, planQueueBackpressureDelay(planQueueBackpressureDepth))
+	must.Eq(t, planQueueBackpressureStep,
+		planQueueBackpressureDelay(planQueueBackpressureDepth+1))
+	must.Eq(t, 4*planQueueBackpressureStep,
+		planQueueBackpressureDelay(planQueueBackpressureDepth+4))
+	must.Eq(t, planQueueBackpressureLimit,
+		planQueueBackpressureDelay(planQueueBackpressureDepth+10_000))
+}
+
+func TestWorker_planQueueBackpressure(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.EnabledSchedulers = []string{structs.JobTypeService}
+	})
+	defer cleanupSrv()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	workerCtx, workerCancel := context.WithCancel(srv.shutdownCtx)
+	defer workerCancel()
+
+	poolArgs := getSchedulerWorkerPoolArgsFromConfigLocked(srv.config).Copy()
+	w := newWorker(workerCtx, srv, poolArgs)
+
+	// No delay below the threshold
+	w.planQueueDepth = planQueueBackpressureDepth
+	must.False(t, w.planQueueBackpressure())
+	must.Zero(t, w.planQueueDepth)
+
+	// Delay and reset the depth above the threshold
+	w.planQueueDepth = planQueueBackpressureDepth + 2
+	start := time.Now()
+	must.False(t, w.planQueueBackpressure())
+	must.GreaterEq(t, 2*planQueueBackpressureStep, time.Since(start))
+	must.Zero(t, w.planQueueDepth)
+
+	// Shutting down the worker interrupts the delay
+	w.planQueueDepth = planQueueBackpressureDepth + 10_000
+	workerCancel()
+	must.True(t, w.planQueueBackpressure())
+}
+
 func TestWorker_waitForIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -508,6 +555,11 @@ func TestWorker_SubmitPlan(t *testing.T) {
 	if len(result.NodeAllocation) != 1 {
 		t.Fatalf("Bad: %#v", result)
 	}
+
+	// The plan timing should be accumulated for the evaluation
+	must.Positive(t, result.RaftApplyTime)
+	must.Eq(t, result.RaftApplyTime, w.raftApplyTime)
+	must.Positive(t, w.planTime)
 }
 
 func TestWorker_SubmitPlanNormalizedAllocations(t *testing.T) {
@@ -669,6 +721,10 @@ func TestWorker_UpdateEval(t *testing.T) {
 	poolArgs := getSchedulerWorkerPoolArgsFromConfigLocked(s1.config).Copy()
 	w := newWorker(s1.shutdownCtx, s1, poolArgs)
 	w.evalToken = token
+	w.dequeueTime = time.Now()
+	w.snapshotWaitTime = time.Millisecond
+	w.planTime = 2 * time.Millisecond
+	w.raftApplyTime = 3 * time.Millisecond
 
 	err = w.UpdateEval(eval2)
 	if err != nil {
@@ -686,6 +742,12 @@ func TestWorker_UpdateEval(t *testing.T) {
 	if out.SnapshotIndex != w.snapshotIndex {
 		t.Fatalf("bad: %v", out)
 	}
+	if out.WorkerID != w.id || out.DequeueTime != w.dequeueTime.UnixNano() {
+		t.Fatalf("bad: %v", out)
+	}
+	must.Eq(t, time.Millisecond, out.SnapshotWaitTime)
+	must.Eq(t, 2*time.Millisecond, out.PlanTime)
+	must.Eq(t, 3*time.Millisecond, out.RaftApplyTime)
 }
 
 func TestWorker_CreateEval(t *testing.T) {
@@ -54,6 +54,112 @@ func Annotate(diff *structs.JobDiff, annotations *structs.PlanAnnotations) error
 	return nil
 }
 
+// AnnotatePortConflicts checks the static ports of the task groups of the job
+// against the ports already in use on the candidate nodes of the job, and adds
+// the nodes excluded because of a conflict to the plan annotations. The ports
+// used by the allocations of the job itself are ignored, since these
+// allocations are replaced by the planned version of the job.
+func AnnotatePortConflicts(state State, job *structs.Job, annotations *structs.PlanAnnotations) error {
+	if annotations == nil {
+		return nil
+	}
+
+	asks := make(map[string][]structs.Port)
+	for _, tg := range job.TaskGroups {
+		for _, network := range tg.Networks {
+			for _, port := range network.ReservedPorts {
+				if !port.IgnoreCollision {
+					asks[tg.Name] = append(asks[tg.Name], port)
+				}
+			}
+		}
+	}
+	if len(asks) == 0 {
+		return nil
+	}
+
+	nodes, _, _, err := readyNodesInDCsAndPool(state, job.Datacenters, job.NodePool)
+	if err != nil {
+		return err
+	}
+
+	conflicts := make(map[string]*structs.PortConflicts)
+	for _, node := range nodes {
+		allocs, err := state.AllocsByNodeTerminal(nil, node.ID, false)
+		if err != nil {
+			return err
+		}
+		others := make([]*structs.Allocation, 0, len(allocs))
+		for _, alloc := range allocs {
+			if alloc.Namespace != job.Namespace || alloc.JobID != job.ID {
+				others = append(others, alloc)
+			}
+		}
+
+		idx := structs.NewNetworkIndex()
+		if err := idx.SetNode(node); err != nil {
+			idx.Release()
+			continue
+		}
+		idx.AddAllocs(others)
+
+		for tg, ports := range asks {
+			nodeConflicts, ok := portConflicts(idx, ports)
+			if !ok {
+				continue
+			}
+
+			tgConflicts := conflicts[tg]
+			if tgConflicts == nil {
+				tgConflicts = &structs.PortConflicts{Nodes: make(map[string][]int)}
+				conflicts[tg] = tgConflicts
+			}
+			tgConflicts.CandidateNodes++
+			if len(nodeConflicts) > 0 {
+				tgConflicts.Nodes[node.ID] = nodeConflicts
+			}
+		}
+		idx.Release()
+	}
+
+	for tg, tgConflicts := range conflicts {
+		if len(tgConflicts.Nodes) == 0 {
+			continue
+		}
+		if annotations.PortConflicts == nil {
+			annotations.PortConflicts = make(map[string]*structs.PortConflicts)
+		}
+		annotations.PortConflicts[tg] = tgConflicts
+	}
+	return nil
+}
+
+// portConflicts returns the static ports already in use on all the addresses
+// of their host network on the indexed node. It returns false if the node
+// doesn't have the host network of one of the ports.
+func portConflicts(idx *structs.NetworkIndex, ports []structs.Port) ([]int, bool) {
+	var conflicts []int
+	for _, port := range ports {
+		addrs := idx.HostNetworks[port.HostNetwork]
+		if len(addrs) == 0 {
+			return nil, false
+		}
+
+		available := false
+		for _, addr := range addrs {
+			used := idx.UsedPorts[addr.Address]
+			if used == nil || !used.Check(uint(port.Value)) {
+				available = true
+				break
+			}
+		}
+		if !available {
+			conflicts = append(conflicts, port.Value)
+		}
+	}
+	return conflicts, true
+}
+
 // annotateTaskGroup takes a task group diff and annotates it.
 func annotateTaskGroup(diff *structs.TaskGroupDiff, annotations *structs.PlanAnnotations) error {
 	// Annotate the updates
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 )
 
 func TestAnnotateTaskGroup_Updates(t *testing.T) {
@@ -508,3 +510,53 @@ func TestAnnotateTask(t *testing.T) {
 		}
 	}
 }
+
+func TestAnnotatePortConflicts(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Networks = []*structs.NetworkResource{{
+		ReservedPorts: []structs.Port{{Label: "admin", Value: 5000, HostNetwork: "default"}},
+	}}
+	noPorts := job.TaskGroups[0].Copy()
+	noPorts.Name = "no-ports"
+	noPorts.Networks = nil
+	job.TaskGroups = append(job.TaskGroups, noPorts)
+
+	// conflicting is running an allocation of another job using the port,
+	// sameJob is running an allocation of the planned job using it, free is
+	// not using it and down is not a candidate node.
+	conflicting, sameJob, free, down := mock.Node(), mock.Node(), mock.Node(), mock.Node()
+	down.Status = structs.NodeStatusDown
+	for _, node := range []*structs.Node{conflicting, sameJob, free, down} {
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	other := mock.Alloc()
+	other.NodeID = conflicting.ID
+	own := mock.Alloc()
+	own.NodeID = sameJob.ID
+	own.Job = job
+	own.JobID = job.ID
+	blocked := mock.Alloc()
+	blocked.NodeID = down.ID
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{other, own, blocked}))
+
+	annotations := &structs.PlanAnnotations{}
+	must.NoError(t, AnnotatePortConflicts(h.State, job, annotations))
+	must.Eq(t, map[string]*structs.PortConflicts{
+		"web": {
+			CandidateNodes: 3,
+			Nodes:          map[string][]int{conflicting.ID: {5000}},
+		},
+	}, annotations.PortConflicts)
+
+	// No annotations without conflicts
+	job.TaskGroups[0].Networks[0].ReservedPorts[0].Value = 5001
+	annotations = &structs.PlanAnnotations{}
+	must.NoError(t, AnnotatePortConflicts(h.State, job, annotations))
+	must.Nil(t, annotations.PortConflicts)
+}
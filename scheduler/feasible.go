@@ -34,6 +34,8 @@ const (
 	FilterConstraintDrivers                        = "missing drivers"
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
+	FilterConstraintReadinessGateLookupFailed      = "readiness gate lookup failed"
+	FilterConstraintReadinessGateTemplate          = "readiness gate job %s is not healthy"
 )
 
 var (
@@ -499,6 +501,71 @@ func (c *CSIVolumeChecker) isFeasible(n *structs.Node) (bool, string) {
 	return true, ""
 }
 
+// ReadinessGateChecker is a FeasibilityChecker which returns whether the
+// readiness gate jobs of the node pool of a node have a healthy allocation on
+// the node. The readiness gate jobs themselves are not gated.
+type ReadinessGateChecker struct {
+	ctx       Context
+	namespace string
+	jobID     string
+
+	// pools caches the node pools looked up for the job.
+	pools map[string]*structs.NodePool
+}
+
+// NewReadinessGateChecker creates a ReadinessGateChecker.
+func NewReadinessGateChecker(ctx Context) *ReadinessGateChecker {
+	return &ReadinessGateChecker{
+		ctx:   ctx,
+		pools: make(map[string]*structs.NodePool),
+	}
+}
+
+func (c *ReadinessGateChecker) SetJob(job *structs.Job) {
+	c.namespace = job.Namespace
+	c.jobID = job.ID
+	clear(c.pools)
+}
+
+func (c *ReadinessGateChecker) Feasible(n *structs.Node) bool {
+	ok, failReason := c.isFeasible(n)
+	if ok {
+		return true
+	}
+
+	c.ctx.Metrics().FilterNode(n, failReason)
+	return false
+}
+
+func (c *ReadinessGateChecker) isFeasible(n *structs.Node) (bool, string) {
+	pool, ok := c.pools[n.NodePool]
+	if !ok {
+		var err error
+		pool, err = c.ctx.State().NodePoolByName(nil, n.NodePool)
+		if err != nil {
+			return false, FilterConstraintReadinessGateLookupFailed
+		}
+		c.pools[n.NodePool] = pool
+	}
+
+	// Fast path: no readiness gates, or the job is one of them.
+	if pool == nil || len(pool.ReadinessGates) == 0 ||
+		pool.ReadinessGate(c.namespace, c.jobID) != nil {
+		return true, ""
+	}
+
+	allocs, err := c.ctx.State().AllocsByNode(nil, n.ID)
+	if err != nil {
+		return false, FilterConstraintReadinessGateLookupFailed
+	}
+	for _, gate := range pool.ReadinessGates {
+		if !gate.Ready(allocs) {
+			return false, fmt.Sprintf(FilterConstraintReadinessGateTemplate, gate.JobID)
+		}
+	}
+	return true, ""
+}
+
 // NetworkChecker is a FeasibilityChecker which returns whether a node has the
 // network resources necessary to schedule the task group
 type NetworkChecker struct {
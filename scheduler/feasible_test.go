@@ -935,6 +935,71 @@ func TestCSIVolumeChecker(t *testing.T) {
 
 }
 
+func TestReadinessGateChecker(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := testContext(t)
+
+	pool := mock.NodePool()
+	pool.ReadinessGates = []*structs.NodePoolReadinessGate{{JobID: "cni"}}
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	// node0 runs the readiness gate job, node1 doesn't, and node2 is in a pool
+	// without readiness gates
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node()}
+	nodes[0].NodePool = pool.Name
+	nodes[1].NodePool = pool.Name
+	for _, node := range nodes {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	}
+
+	gateJob := mock.SystemJob()
+	gateJob.ID = "cni"
+	gateJob.NodePool = pool.Name
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, gateJob))
+
+	gateAlloc := mock.AllocForNode(nodes[0])
+	gateAlloc.Job = gateJob
+	gateAlloc.JobID = gateJob.ID
+	gateAlloc.ClientStatus = structs.AllocClientStatusRunning
+	pendingAlloc := mock.AllocForNode(nodes[1])
+	pendingAlloc.Job = gateJob
+	pendingAlloc.JobID = gateJob.ID
+	pendingAlloc.ClientStatus = structs.AllocClientStatusPending
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Allocation{gateAlloc, pendingAlloc}))
+
+	checker := NewReadinessGateChecker(ctx)
+
+	testCases := []struct {
+		name   string
+		job    *structs.Job
+		result []bool
+	}{
+		{
+			name:   "gated job",
+			job:    mock.Job(),
+			result: []bool{true, false, true},
+		},
+		{
+			name:   "readiness gate job",
+			job:    gateJob,
+			result: []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker.SetJob(tc.job)
+			for i, node := range nodes {
+				must.Eq(t, tc.result[i], checker.Feasible(node), must.Sprintf("node %d", i))
+			}
+		})
+	}
+
+	must.Eq(t, 1, ctx.Metrics().ConstraintFiltered[`readiness gate job cni is not healthy`])
+}
+
 func TestNetworkChecker(t *testing.T) {
 	ci.Parallel(t)
 
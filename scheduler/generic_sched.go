@@ -170,7 +170,8 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerJobUnfreeze:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -189,6 +190,7 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		if statusErr, ok := err.(*SetStatusError); ok {
 			// Scheduling was tried but made no forward progress so create a
 			// blocked eval to retry once resources become available.
+			s.failedTGAllocs = addPlanRejections(s.failedTGAllocs, s.plan, s.planResult)
 			var mErr multierror.Error
 			if err := s.createBlockedEval(true); err != nil {
 				mErr.Errors = append(mErr.Errors, err)
@@ -389,6 +391,12 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	results := reconciler.Compute()
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", results))
 
+	// A frozen job keeps its allocations as they are until it is unfrozen
+	if !s.job.Stopped() && s.job.Frozen {
+		s.logger.Debug("job is frozen, skipping placements and updates")
+		freezeResults(results, s.deployment)
+	}
+
 	if s.eval.AnnotatePlan {
 		s.plan.Annotations = &structs.PlanAnnotations{
 			DesiredTGUpdates: results.desiredTGUpdates,
@@ -469,6 +477,31 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	return s.computePlacements(destructive, place, results.taskGroupAllocNameIndexes)
 }
 
+// freezeResults drops the parts of the reconciler results that would place,
+// replace or update allocations of a frozen job. Failed and migrating
+// allocations are not stopped either, so that they can be rescheduled or
+// migrated once the job is unfrozen.
+func freezeResults(results *reconcileResults, existing *structs.Deployment) {
+	results.place = nil
+	results.destructiveUpdate = nil
+	results.inplaceUpdate = nil
+
+	stop := results.stop[:0]
+	for _, s := range results.stop {
+		switch s.statusDescription {
+		case allocRescheduled, allocMigrating:
+			continue
+		}
+		stop = append(stop, s)
+	}
+	results.stop = stop
+
+	// Don't start a new deployment that couldn't make any progress
+	if results.deployment != nil && results.deployment.ID != existing.GetID() {
+		results.deployment = nil
+	}
+}
+
 // downgradedJobForPlacement returns the previous stable version of the job for
 // downgrading a placement for non-canaries
 func (s *GenericScheduler) downgradedJobForPlacement(p placementResult) (string, *structs.Job, error) {
@@ -525,6 +558,11 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements and the allocations they stop so they can be
+	// backed out if their gang fails to place
+	var placed []*structs.Allocation
+	stopped := make(map[string]*structs.Allocation)
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -688,6 +726,8 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 					alloc.PreviousAllocation = prevAllocation.ID
 					if missing.IsRescheduling() {
 						updateRescheduleTracker(alloc, prevAllocation, now)
+					} else if err := preserveNetworkIdentity(s.ctx, option.Node, tg, alloc, prevAllocation); err != nil {
+						return err
 					}
 				}
 
@@ -703,6 +743,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
+				placed = append(placed, alloc)
+				if stopPrevAlloc {
+					stopped[alloc.ID] = prevAllocation
+				}
 
 			} else {
 				// Lazy initialize the failed map
@@ -736,9 +780,44 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	s.rollbackFailedGangs(placed, stopped)
 	return nil
 }
 
+// rollbackFailedGangs removes the placements of every gang with a task group
+// that failed to place, so that the task groups of a gang are placed together
+// or not at all. The task groups whose placements are removed are marked as
+// failed so they are retried by the blocked evaluation.
+func (s *GenericScheduler) rollbackFailedGangs(placed []*structs.Allocation, stopped map[string]*structs.Allocation) {
+	failedGangs := make(map[string]struct{})
+	for tgName := range s.failedTGAllocs {
+		if gang := s.job.LookupGang(tgName); gang != "" {
+			failedGangs[gang] = struct{}{}
+		}
+	}
+	if len(failedGangs) == 0 {
+		return
+	}
+
+	for _, alloc := range placed {
+		gang := s.job.LookupGang(alloc.TaskGroup)
+		if _, ok := failedGangs[gang]; !ok {
+			continue
+		}
+
+		s.plan.RemoveAlloc(alloc)
+		if prev, ok := stopped[alloc.ID]; ok {
+			s.plan.RemoveStoppedAlloc(prev)
+		}
+
+		if metric, ok := s.failedTGAllocs[alloc.TaskGroup]; ok {
+			metric.CoalescedFailures += 1
+		} else {
+			s.failedTGAllocs[alloc.TaskGroup] = &structs.AllocMetric{FailedGang: gang}
+		}
+	}
+}
+
 // setJob updates the stack with the given job and job's node pool scheduler
 // configuration.
 func (s *GenericScheduler) setJob(job *structs.Job) error {
@@ -855,7 +934,9 @@ func (s *GenericScheduler) findPreferredNode(place placementResult) (*structs.No
 	if prev == nil {
 		return nil, nil
 	}
-	if place.TaskGroup().EphemeralDisk.Sticky || place.TaskGroup().EphemeralDisk.Migrate {
+	tg := place.TaskGroup()
+	preserveNetwork := len(tg.Networks) > 0 && tg.Networks[0].PreserveIdentity && !place.IsRescheduling()
+	if tg.EphemeralDisk.Sticky || tg.EphemeralDisk.Migrate || preserveNetwork {
 		var preferredNode *structs.Node
 		ws := memdb.NewWatchSet()
 		preferredNode, err := s.state.NodeByID(ws, prev.NodeID)
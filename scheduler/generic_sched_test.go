@@ -1380,6 +1380,74 @@ func TestServiceSched_JobRegister_AllocFail(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_GangFail(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a job where the "web" and "db" groups form a gang and "db" can
+	// never be placed, and a "cache" group that isn't part of the gang
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Gang = "app"
+
+	db := job.TaskGroups[0].Copy()
+	db.Name = "db"
+	db.Count = 1
+	db.Tasks[0].Resources.CPU = 1_000_000
+
+	cache := job.TaskGroups[0].Copy()
+	cache.Name = "cache"
+	cache.Count = 1
+	cache.Gang = ""
+
+	job.TaskGroups = append(job.TaskGroups, db, cache)
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure only the group outside of the gang was placed
+	must.Len(t, 1, h.Plans)
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 1, planned)
+	must.Eq(t, "cache", planned[0].TaskGroup)
+
+	// Ensure both groups of the gang failed and the group that could be
+	// placed reports the gang
+	must.Len(t, 1, h.Evals)
+	outEval := h.Evals[0]
+	must.MapLen(t, 2, outEval.FailedTGAllocs)
+	must.Eq(t, "", outEval.FailedTGAllocs["db"].FailedGang)
+	must.Eq(t, "app", outEval.FailedTGAllocs["web"].FailedGang)
+	must.Eq(t, 1, outEval.FailedTGAllocs["web"].CoalescedFailures)
+
+	must.Eq(t, 2, outEval.QueuedAllocations["web"])
+	must.Eq(t, 1, outEval.QueuedAllocations["db"])
+	must.Eq(t, 0, outEval.QueuedAllocations["cache"])
+
+	// Ensure there is a blocked eval to retry the gang
+	must.Len(t, 1, h.CreateEvals)
+	must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+}
+
 func TestServiceSched_JobRegister_CreateBlockedEval(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2760,6 +2828,103 @@ func TestServiceSched_JobModify_Rolling(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobModify_PreserveNetworkIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		node.NodeResources.NodeNetworks = append(node.NodeResources.NodeNetworks,
+			&structs.NodeNetworkResource{Mode: "bridge"})
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Networks[0].Mode = "bridge"
+	job.TaskGroups[0].Networks[0].PreserveIdentity = true
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[i].ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+			{Label: "http", Value: 25000, HostIP: "192.168.0.100"},
+			{Label: "admin", Value: 25001, HostIP: "192.168.0.100"},
+		}
+		alloc.NetworkStatus = &structs.AllocNetworkStatus{
+			InterfaceName: "eth0",
+			Address:       fmt.Sprintf("172.26.64.%d", 10+i),
+		}
+		allocs = append(allocs, alloc)
+	}
+
+	// Another job holds the previous http port on the first node
+	other := mock.Alloc()
+	other.NodeID = nodes[0].ID
+	other.ClientStatus = structs.AllocClientStatusRunning
+	other.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "main", Value: 25000, HostIP: "192.168.0.100"},
+	}
+	allocs = append(allocs, other)
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the task, such that it cannot be done in-place
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 3, planned)
+
+	for _, alloc := range planned {
+		prev := allocs[slices.IndexFunc(allocs, func(a *structs.Allocation) bool {
+			return a.ID == alloc.PreviousAllocation
+		})]
+		must.Eq(t, prev.NodeID, alloc.NodeID)
+		must.Eq(t, prev.NetworkStatus.Address, alloc.PreservedNetworkAddress)
+
+		ports := alloc.AllocatedResources.Shared.Ports
+		http, _ := ports.Get("http")
+		admin, _ := ports.Get("admin")
+		must.Eq(t, 25001, admin.Value)
+		if alloc.NodeID == nodes[0].ID {
+			must.NotEq(t, 25000, http.Value)
+		} else {
+			must.Eq(t, 25000, http.Value)
+		}
+
+		for _, port := range alloc.AllocatedResources.Shared.Networks[0].DynamicPorts {
+			p, _ := ports.Get(port.Label)
+			must.Eq(t, p.Value, port.Value)
+		}
+	}
+}
+
 // This tests that the old allocation is stopped before placing.
 // It is critical to test that the updated job attempts to place more
 // allocations as this allows us to assert that destructive changes are done
@@ -4310,6 +4475,65 @@ func TestServiceSched_RetryLimit(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusFailed)
 }
 
+// rejectNodesPlan is a planner that rejects the placements for every node of
+// a plan with the same reason, as the plan applier would.
+type rejectNodesPlan struct {
+	*RejectPlan
+	reason string
+}
+
+func (r *rejectNodesPlan) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State, error) {
+	result, state, err := r.RejectPlan.SubmitPlan(plan)
+	for nodeID := range plan.NodeAllocation {
+		if result.NodeRejections == nil {
+			result.NodeRejections = make(map[string]string)
+		}
+		result.NodeRejections[nodeID] = r.reason
+		result.RejectedNodes = append(result.RejectedNodes, nodeID)
+	}
+	return result, state, err
+}
+
+func TestServiceSched_RetryLimit_PlanRejections(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	h.Planner = &rejectNodesPlan{RejectPlan: &RejectPlan{h}, reason: "node is draining"}
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The eval failed and both it and the blocked eval report why the
+	// placements were rejected
+	h.AssertEvalStatus(t, structs.EvalStatusFailed)
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, structs.EvalTriggerMaxPlans, blocked.TriggeredBy)
+
+	for _, e := range []*structs.Evaluation{h.Evals[0], blocked} {
+		metric := e.FailedTGAllocs[job.TaskGroups[0].Name]
+		must.NotNil(t, metric)
+		must.Eq(t, map[string]string{node.ID: "node is draining"}, metric.PlanRejections)
+		must.Eq(t, 1, metric.CoalescedFailures)
+	}
+}
+
 func TestServiceSched_Reschedule_OnceNow(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4424,6 +4648,100 @@ func TestServiceSched_Reschedule_OnceNow(t *testing.T) {
 }
 
 // Tests that alloc reschedulable at a future time creates a follow up eval
+func TestServiceSched_JobFrozen(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	var nodes []*structs.Node
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Generate a frozen job missing an allocation and with a failed one that
+	// is eligible for rescheduling
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{
+		Attempts:      1,
+		Interval:      15 * time.Minute,
+		Delay:         5 * time.Second,
+		MaxDelay:      1 * time.Minute,
+		DelayFunction: "constant",
+	}
+	tgName := job.TaskGroups[0].Name
+	now := time.Now()
+
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	must.NoError(t, h.State.UpdateJobFreeze(structs.MsgTypeTestSetup, h.NextIndex(), job.Namespace, job.ID, true))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[i].ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	allocs[1].ClientStatus = structs.AllocClientStatusFailed
+	allocs[1].TaskStates = map[string]*structs.TaskState{tgName: {State: "dead",
+		StartedAt:  now.Add(-1 * time.Hour),
+		FinishedAt: now.Add(-10 * time.Second)}}
+	failedAllocID := allocs[1].ID
+
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Nothing was placed, and the failed allocation was left as it is
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 2, out)
+
+	failed, err := h.State.AllocByID(nil, failedAllocID)
+	must.NoError(t, err)
+	must.Eq(t, structs.AllocDesiredStatusRun, failed.DesiredStatus)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+	must.Len(t, 0, h.CreateEvals)
+
+	// Unfreeze the job and process the unfreeze evaluation
+	must.NoError(t, h.State.UpdateJobFreeze(structs.MsgTypeTestSetup, h.NextIndex(), job.Namespace, job.ID, false))
+
+	eval = &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobUnfreeze,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The missing allocation was placed and the failed one rescheduled
+	out, err = h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 4, out)
+
+	failed, err = h.State.AllocByID(nil, failedAllocID)
+	must.NoError(t, err)
+	must.Eq(t, structs.AllocDesiredStatusStop, failed.DesiredStatus)
+}
+
 func TestServiceSched_Reschedule_Later(t *testing.T) {
 	ci.Parallel(t)
 
@@ -25,12 +25,17 @@ type groupedAllocs struct {
 type allocInfo struct {
 	maxParallel int
 	resources   *structs.ComparableResources
+
+	// protected is set when the namespace of the allocation doesn't allow it
+	// to be preempted
+	protected bool
 }
 
 func (ai *allocInfo) Copy() *allocInfo {
 	return &allocInfo{
 		maxParallel: ai.maxParallel,
 		resources:   ai.resources.Copy(),
+		protected:   ai.protected,
 	}
 }
 
@@ -129,17 +134,28 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// namespacePreemption caches the preemption configuration of the
+	// namespaces of the candidate allocations
+	namespacePreemption map[string]*structs.NamespacePreemptionConfiguration
+
+	// disruptionsAllowed caches the number of healthy allocations per
+	// job/taskgroup that may be disrupted without violating the disruption
+	// budget of the task group
+	disruptionsAllowed map[structs.NamespacedID]map[string]int
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
 
 func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Preemptor {
 	return &Preemptor{
-		currentPreemptions: make(map[structs.NamespacedID]map[string]int),
-		jobPriority:        jobPriority,
-		jobID:              jobID,
-		allocDetails:       make(map[string]*allocInfo),
-		ctx:                ctx,
+		currentPreemptions:  make(map[structs.NamespacedID]map[string]int),
+		jobPriority:         jobPriority,
+		jobID:               jobID,
+		allocDetails:        make(map[string]*allocInfo),
+		namespacePreemption: make(map[string]*structs.NamespacePreemptionConfiguration),
+		disruptionsAllowed:  make(map[structs.NamespacedID]map[string]int),
+		ctx:                 ctx,
 	}
 }
 
@@ -148,6 +164,10 @@ func (p *Preemptor) Copy() *Preemptor {
 	for k, v := range p.currentPreemptions {
 		currentPreemptions[k] = maps.Clone(v)
 	}
+	disruptionsAllowed := make(map[structs.NamespacedID]map[string]int)
+	for k, v := range p.disruptionsAllowed {
+		disruptionsAllowed[k] = maps.Clone(v)
+	}
 
 	return &Preemptor{
 		currentPreemptions:     currentPreemptions,
@@ -156,6 +176,8 @@ func (p *Preemptor) Copy() *Preemptor {
 		jobID:                  p.jobID,
 		nodeRemainingResources: p.nodeRemainingResources.Copy(),
 		currentAllocs:          helper.CopySlice(p.currentAllocs),
+		namespacePreemption:    maps.Clone(p.namespacePreemption),
+		disruptionsAllowed:     disruptionsAllowed,
 		ctx:                    p.ctx,
 	}
 }
@@ -187,11 +209,93 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 		if tg != nil && tg.Migrate != nil {
 			maxParallel = tg.Migrate.MaxParallel
 		}
-		p.allocDetails[alloc.ID] = &allocInfo{maxParallel: maxParallel, resources: alloc.AllocatedResources.Comparable()}
+		p.allocDetails[alloc.ID] = &allocInfo{
+			maxParallel: maxParallel,
+			resources:   alloc.AllocatedResources.Comparable(),
+			protected:   !p.namespaceAllowsPreemption(alloc),
+		}
 		p.currentAllocs = append(p.currentAllocs, alloc)
 	}
 }
 
+// namespaceAllowsPreemption returns whether the preemption configuration of
+// the allocation's namespace allows it to be preempted
+func (p *Preemptor) namespaceAllowsPreemption(alloc *structs.Allocation) bool {
+	if alloc.Job == nil {
+		return false
+	}
+
+	config, ok := p.namespacePreemption[alloc.Namespace]
+	if !ok {
+		ns, err := p.ctx.State().NamespaceByName(nil, alloc.Namespace)
+		if err != nil {
+			p.ctx.Logger().Named("preemption").Error("failed to lookup namespace",
+				"namespace", alloc.Namespace, "error", err)
+			return false
+		}
+		if ns != nil {
+			config = ns.PreemptionConfiguration
+		}
+		p.namespacePreemption[alloc.Namespace] = config
+	}
+	return config.AllowsPreemption(alloc.Job.Priority)
+}
+
+// preemptible returns whether the allocation may be preempted by the job being
+// placed, based on the priority of both jobs and the preemption configuration
+// of the allocation's namespace
+func (p *Preemptor) preemptible(alloc *structs.Allocation) bool {
+	// Skip allocs whose priority is within a delta of 10
+	// This also skips any allocs of the current job
+	// for which we are attempting preemption
+	if p.jobPriority-alloc.Job.Priority < 10 {
+		return false
+	}
+	if details := p.allocDetails[alloc.ID]; details != nil && details.protected {
+		return false
+	}
+	return true
+}
+
+// getDisruptionsAllowed returns the number of healthy allocations of the job
+// and task group of the alloc that may be disrupted without going below the
+// minimum number of healthy allocations of the disruption budget of the task
+// group, if any.
+func (p *Preemptor) getDisruptionsAllowed(alloc *structs.Allocation) int {
+	id := alloc.JobNamespacedID()
+	if allowed, ok := p.disruptionsAllowed[id][alloc.TaskGroup]; ok {
+		return allowed
+	}
+
+	allowed := math.MaxInt
+	job := alloc.Job
+	if current, err := p.ctx.State().JobByID(nil, alloc.Namespace, alloc.JobID); err == nil && current != nil {
+		job = current
+	}
+	if tg := job.LookupTaskGroup(alloc.TaskGroup); tg != nil && tg.DisruptionBudget != nil {
+		allocs, err := p.ctx.State().AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+		if err != nil {
+			p.ctx.Logger().Named("preemption").Error("failed to lookup job allocations",
+				"job_id", alloc.JobID, "namespace", alloc.Namespace, "error", err)
+			allowed = 0
+		} else {
+			healthy := 0
+			for _, a := range allocs {
+				if a.TaskGroup == alloc.TaskGroup && a.DisruptionBudgetHealthy() {
+					healthy++
+				}
+			}
+			allowed = tg.DisruptionBudget.Allowed(tg.Count, healthy)
+		}
+	}
+
+	if _, ok := p.disruptionsAllowed[id]; !ok {
+		p.disruptionsAllowed[id] = make(map[string]int)
+	}
+	p.disruptionsAllowed[id][alloc.TaskGroup] = allowed
+	return allowed
+}
+
 // SetPreemptions initializes a map tracking existing counts of preempted allocations
 // per job/task group. This is used while scoring preemption options
 func (p *Preemptor) SetPreemptions(allocs []*structs.Allocation) {
@@ -234,7 +338,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	}
 
 	// Group candidates by priority, filter out ineligible allocs
-	allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.currentAllocs)
+	allocsByPriority := p.filterAndGroupPreemptibleAllocs(p.currentAllocs)
 
 	var bestAllocs []*structs.Allocation
 	allRequirementsMet := false
@@ -335,8 +439,8 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 		// We only check first network - TODO: why?!?!
 		net := networks[0]
 
-		// Filter out alloc that's ineligible due to priority
-		if p.jobPriority-alloc.Job.Priority < 10 {
+		// Filter out alloc that's ineligible due to priority or its namespace
+		if !p.preemptible(alloc) {
 			// Populate any reserved ports used by
 			// this allocation that cannot be preempted
 			for _, port := range net.ReservedPorts {
@@ -429,7 +533,7 @@ OUTER:
 		}
 
 		// Split by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, currentAllocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(currentAllocs)
 
 		for _, allocsGrp := range allocsByPriority {
 			allocs := allocsGrp.allocs
@@ -546,7 +650,7 @@ func (p *Preemptor) PreemptForDevice(ask *structs.RequestedDevice, devAlloc *dev
 OUTER:
 	for deviceIDTuple, allocsGrp := range deviceToAllocs {
 		// First group and sort allocations using this device by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, allocsGrp.allocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(allocsGrp.allocs)
 
 		// Reset preempted count for this device
 		preemptedCount := 0
@@ -688,20 +792,29 @@ func scoreForNetwork(resourceUsed *structs.NetworkResource, resourceNeeded *stru
 }
 
 // filterAndGroupPreemptibleAllocs groups allocations by priority after filtering allocs
-// that are not preemptible based on the jobPriority arg
-func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocation) []*groupedAllocs {
+// that are not preemptible by the job being placed. Healthy allocations are
+// only kept as long as preempting all of them doesn't violate the disruption
+// budget of their task group.
+func (p *Preemptor) filterAndGroupPreemptibleAllocs(current []*structs.Allocation) []*groupedAllocs {
 	allocsByPriority := make(map[int][]*structs.Allocation)
+	disruptions := make(map[structs.NamespacedID]map[string]int)
 	for _, alloc := range current {
 		if alloc.Job == nil {
 			continue
 		}
-
-		// Skip allocs whose priority is within a delta of 10
-		// This also skips any allocs of the current job
-		// for which we are attempting preemption
-		if jobPriority-alloc.Job.Priority < 10 {
+		if !p.preemptible(alloc) {
 			continue
 		}
+		if alloc.DisruptionBudgetHealthy() {
+			id := alloc.JobNamespacedID()
+			if _, ok := disruptions[id]; !ok {
+				disruptions[id] = make(map[string]int)
+			}
+			if p.getNumPreemptions(alloc)+disruptions[id][alloc.TaskGroup] >= p.getDisruptionsAllowed(alloc) {
+				continue
+			}
+			disruptions[id][alloc.TaskGroup]++
+		}
 		grpAllocs, ok := allocsByPriority[alloc.Job.Priority]
 		if !ok {
 			grpAllocs = make([]*structs.Allocation, 0)
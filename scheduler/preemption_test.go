@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -1569,6 +1570,128 @@ func TestPreemptionMultiple(t *testing.T) {
 	require.Equal(t, allocIDs, preempted)
 }
 
+func TestPreemption_NamespacePreemptionConfiguration(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	// A namespace allowing all preemptions, a protected namespace and a
+	// namespace only allowing preemption of low priority jobs
+	batchNs := mock.Namespace()
+	prodNs := mock.Namespace()
+	prodNs.PreemptionConfiguration = &structs.NamespacePreemptionConfiguration{
+		Protected: true,
+	}
+	mixedNs := mock.Namespace()
+	mixedNs.PreemptionConfiguration = &structs.NamespacePreemptionConfiguration{
+		PriorityFloor: 30,
+	}
+	require.NoError(t, state.UpsertNamespaces(1000,
+		[]*structs.Namespace{batchNs, prodNs, mixedNs}))
+
+	node := mock.Node()
+	node.ReservedResources = nil
+
+	// Fill the node with four allocs of low priority jobs
+	newAlloc := func(namespace string, priority int) *structs.Allocation {
+		job := mock.Job()
+		job.Namespace = namespace
+		job.Priority = priority
+		alloc := createAlloc(uuid.Generate(), job, &structs.Resources{
+			CPU:      1000,
+			MemoryMB: 2048,
+		})
+		alloc.Namespace = namespace
+		alloc.NodeID = node.ID
+		return alloc
+	}
+	batchAlloc := newAlloc(batchNs.Name, 20)
+	prodAlloc := newAlloc(prodNs.Name, 20)
+	mixedLowAlloc := newAlloc(mixedNs.Name, 20)
+	mixedHighAlloc := newAlloc(mixedNs.Name, 40)
+
+	preemptor := NewPreemptor(100, ctx, &structs.NamespacedID{
+		ID:        "high-priority",
+		Namespace: structs.DefaultNamespace,
+	})
+	preemptor.SetNode(node)
+	preemptor.SetCandidates([]*structs.Allocation{
+		batchAlloc, prodAlloc, mixedLowAlloc, mixedHighAlloc,
+	})
+
+	// Only the allocs allowed by their namespace can be preempted
+	preempted := preemptor.PreemptForTaskGroup(&structs.AllocatedResources{
+		Tasks: map[string]*structs.AllocatedTaskResources{
+			"web": {
+				Cpu:    structs.AllocatedCpuResources{CpuShares: 2000},
+				Memory: structs.AllocatedMemoryResources{MemoryMB: 4096},
+			},
+		},
+	})
+	require.ElementsMatch(t, []string{batchAlloc.ID, mixedLowAlloc.ID},
+		helper.ConvertSlice(preempted, func(a *structs.Allocation) string { return a.ID }))
+}
+
+func TestPreemption_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	node := mock.Node()
+	node.ReservedResources = nil
+
+	// A job with three healthy allocs, two of them on the node, that must
+	// keep two of them healthy, and another job preempted only when needed
+	budgetJob := mock.Job()
+	budgetJob.Priority = 20
+	budgetJob.TaskGroups[0].Name = "web"
+	budgetJob.TaskGroups[0].Count = 3
+	budgetJob.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MinHealthy: 2}
+	otherJob := mock.Job()
+	otherJob.Priority = 30
+	otherJob.TaskGroups[0].Name = "web"
+	for _, job := range []*structs.Job{budgetJob, otherJob} {
+		require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	}
+
+	resources := &structs.Resources{CPU: 1000, MemoryMB: 2048}
+	var budgetAllocs []*structs.Allocation
+	for i := 0; i < 3; i++ {
+		alloc := createAlloc(uuid.Generate(), budgetJob, resources)
+		alloc.NodeID = node.ID
+		if i == 2 {
+			alloc.NodeID = uuid.Generate()
+		}
+		budgetAllocs = append(budgetAllocs, alloc)
+	}
+	otherAlloc := createAlloc(uuid.Generate(), otherJob, resources)
+	otherAlloc.NodeID = node.ID
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		append(budgetAllocs, otherAlloc)))
+
+	preemptor := NewPreemptor(100, ctx, &structs.NamespacedID{
+		ID:        "high-priority",
+		Namespace: structs.DefaultNamespace,
+	})
+	preemptor.SetNode(node)
+	preemptor.SetCandidates([]*structs.Allocation{
+		budgetAllocs[0], budgetAllocs[1], otherAlloc,
+	})
+
+	// Only one of the allocs of the job with the disruption budget can be
+	// preempted
+	preempted := preemptor.PreemptForTaskGroup(&structs.AllocatedResources{
+		Tasks: map[string]*structs.AllocatedTaskResources{
+			"web": {
+				Cpu:    structs.AllocatedCpuResources{CpuShares: 3000},
+				Memory: structs.AllocatedMemoryResources{MemoryMB: 6144},
+			},
+		},
+	})
+	require.Len(t, preempted, 2)
+	require.Contains(t, preempted, otherAlloc)
+}
+
 // helper method to create allocations with given jobs and resources
 func createAlloc(id string, job *structs.Job, resource *structs.Resources) *structs.Allocation {
 	return createAllocInner(id, job, resource, nil, nil)
@@ -6,6 +6,7 @@ package scheduler
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
@@ -33,6 +34,11 @@ type propertySet struct {
 	// targetAttribute is the attribute this property set is checking
 	targetAttribute string
 
+	// topology is optionally set to the ordered attributes of a topology
+	// spread. The property value of a node is then the path of its values at
+	// every level, joined by topologyDelimiter.
+	topology []string
+
 	// targetValues are the set of attribute values that are explicitly expected,
 	// so we can combine the count of values that belong to any implicit targets.
 	targetValues *set.Set[string]
@@ -135,6 +141,13 @@ func (p *propertySet) setTargetAttributeWithCount(targetAttribute string, allowe
 	p.PopulateProposed()
 }
 
+// SetTargetTopology is used to populate this property set for a topology
+// spread, tracking the full path of attribute values of each node
+func (p *propertySet) SetTargetTopology(topology []string, taskGroup string) {
+	p.topology = topology
+	p.setTargetAttributeWithCount(topologyAttribute(topology), 0, taskGroup)
+}
+
 func (p *propertySet) SetTargetValues(values []string) {
 	p.targetValues = set.From(values)
 }
@@ -247,7 +260,7 @@ func (p *propertySet) UsedCount(option *structs.Node, _ string) (string, string,
 	}
 
 	// Get the nodes property value
-	nValue, ok := getProperty(option, p.targetAttribute)
+	nValue, ok := p.getProperty(option)
 	targetPropertyValue := p.targetedPropertyValue(nValue)
 	if !ok {
 		return nValue, fmt.Sprintf("missing property %q", p.targetAttribute), 0
@@ -342,7 +355,7 @@ func (p *propertySet) populateProperties(allocs []*structs.Allocation, nodes map
 	properties map[string]uint64) {
 
 	for _, alloc := range allocs {
-		nProperty, ok := getProperty(nodes[alloc.NodeID], p.targetAttribute)
+		nProperty, ok := p.getProperty(nodes[alloc.NodeID])
 		if !ok {
 			continue
 		}
@@ -352,6 +365,24 @@ func (p *propertySet) populateProperties(allocs []*structs.Allocation, nodes map
 	}
 }
 
+// getProperty is used to lookup the value of the tracked property on the node.
+// For a topology the values of all levels must be set on the node.
+func (p *propertySet) getProperty(n *structs.Node) (string, bool) {
+	if p.topology == nil {
+		return getProperty(n, p.targetAttribute)
+	}
+
+	values := make([]string, 0, len(p.topology))
+	for _, level := range p.topology {
+		value, ok := getProperty(n, level)
+		if !ok {
+			return "", false
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, topologyDelimiter), true
+}
+
 // getProperty is used to lookup the property value on the node
 func getProperty(n *structs.Node, property string) (string, bool) {
 	if n == nil || property == "" {
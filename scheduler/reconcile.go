@@ -554,7 +554,8 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	// include stopped allocations.
 	isCanarying := dstate != nil && dstate.DesiredCanaries != 0 && !dstate.Promoted
 
-	stop := a.computeStop(tg, nameIndex, untainted, migrate, lost, canaries, isCanarying, lostLaterEvals)
+	promotedCanaries := untainted.fromKeys(dstate.PromotedCanaries)
+	stop := a.computeStop(tg, nameIndex, untainted, migrate, lost, canaries, promotedCanaries, isCanarying, lostLaterEvals)
 
 	desiredChanges.Stop += uint64(len(stop))
 	untainted = untainted.difference(stop)
@@ -637,6 +638,8 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 		if !tg.Update.IsEmpty() {
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
+			dstate.AutoPromoteThreshold = tg.Update.AutoPromoteThreshold
+			dstate.AutoPromoteMinHealthyTime = tg.Update.AutoPromoteMinHealthyTime
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
 		}
 	}
@@ -647,9 +650,13 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 // If we have destructive updates, and have fewer canaries than is desired, we need to create canaries.
 func (a *allocReconciler) requiresCanaries(tg *structs.TaskGroup, dstate *structs.DeploymentState, destructive, canaries allocSet) bool {
 	canariesPromoted := dstate != nil && dstate.Promoted
+	var promotedCanaries int
+	if dstate != nil {
+		promotedCanaries = len(dstate.PromotedCanaries)
+	}
 	return tg.Update != nil &&
 		len(destructive) != 0 &&
-		len(canaries) < tg.Update.Canary &&
+		len(canaries)+promotedCanaries < tg.Update.Canary &&
 		!canariesPromoted
 }
 
@@ -658,7 +665,7 @@ func (a *allocReconciler) computeCanaries(tg *structs.TaskGroup, dstate *structs
 	dstate.DesiredCanaries = tg.Update.Canary
 
 	if !a.deploymentPaused && !a.deploymentFailed {
-		desiredChanges.Canary += uint64(tg.Update.Canary - len(canaries))
+		desiredChanges.Canary += uint64(tg.Update.Canary - len(canaries) - len(dstate.PromotedCanaries))
 		for _, name := range nameIndex.NextCanaries(uint(desiredChanges.Canary), canaries, destructive) {
 			a.result.place = append(a.result.place, allocPlaceResult{
 				name:      name,
@@ -1028,7 +1035,7 @@ func (a *allocReconciler) isDeploymentComplete(groupName string, destructive, in
 // the group definition, the set of allocations in various states and whether we
 // are canarying.
 func (a *allocReconciler) computeStop(group *structs.TaskGroup, nameIndex *allocNameIndex,
-	untainted, migrate, lost, canaries allocSet, isCanarying bool, followupEvals map[string]string) allocSet {
+	untainted, migrate, lost, canaries, promotedCanaries allocSet, isCanarying bool, followupEvals map[string]string) allocSet {
 
 	// Mark all lost allocations for stop.
 	var stop allocSet
@@ -1062,10 +1069,14 @@ func (a *allocReconciler) computeStop(group *structs.TaskGroup, nameIndex *alloc
 	untainted = filterByTerminal(untainted)
 
 	// Prefer stopping any alloc that has the same name as the canaries if we
-	// are promoted
-	if !isCanarying && len(canaries) != 0 {
-		canaryNames := canaries.nameSet()
-		for id, alloc := range untainted.difference(canaries) {
+	// are promoted, or as the partially promoted canaries if we are not
+	replaced := promotedCanaries
+	if !isCanarying {
+		replaced = replaced.union(canaries)
+	}
+	if len(replaced) != 0 {
+		canaryNames := replaced.nameSet()
+		for id, alloc := range untainted.difference(replaced) {
 			if _, match := canaryNames[alloc.Name]; match {
 				stop[id] = alloc
 				a.result.stop = append(a.result.stop, allocStopResult{
@@ -4025,6 +4025,82 @@ func TestReconciler_PromoteCanaries_Unblock(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), stopResultsToNames(r.stop))
 }
 
+// Tests the reconciler stops the allocs replaced by partially promoted canaries,
+// without placing new canaries or unblocking the rolling update
+func TestReconciler_PromoteCanaries_Partial(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate
+
+	// Create an existing deployment that has placed two canaries, of which
+	// the first was promoted
+	d := structs.NewDeployment(job, 50, time.Now().UnixNano())
+	s := &structs.DeploymentState{
+		DesiredTotal:    10,
+		DesiredCanaries: 2,
+		PlacedAllocs:    2,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the canaries
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 2; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+			Canary:  i != 0,
+		}
+		if i == 0 {
+			s.PromotedCanaries = append(s.PromotedCanaries, canary.ID)
+		} else {
+			s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		}
+		allocs = append(allocs, canary)
+		handled[canary.ID] = allocUpdateFnIgnore
+	}
+
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		stop:              1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 11,
+			},
+		},
+	})
+
+	assertNoCanariesStopped(t, d, r.stop)
+	assertNamesHaveIndexes(t, intRange(0, 0), stopResultsToNames(r.stop))
+	must.NotEq(t, s.PromotedCanaries[0], r.stop[0].alloc.ID)
+}
+
 // Tests the reconciler handles canary promotion when the canary count equals
 // the total correctly
 func TestReconciler_PromoteCanaries_CanariesEqualCount(t *testing.T) {
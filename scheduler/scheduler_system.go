@@ -107,6 +107,7 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) (err error) {
 	progress := func() bool { return progressMade(s.planResult) }
 	if err := retryMax(limit, s.process, progress); err != nil {
 		if statusErr, ok := err.(*SetStatusError); ok {
+			s.failedTGAllocs = addPlanRejections(s.failedTGAllocs, s.plan, s.planResult)
 			return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, statusErr.EvalStatus, err.Error(),
 				s.queuedAllocs, "")
 		}
@@ -259,6 +260,14 @@ func (s *SystemScheduler) computeJobAllocs() error {
 		s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true))
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", diff))
 
+	// A frozen job keeps its allocations as they are until it is unfrozen
+	if !s.job.Stopped() && s.job.Frozen {
+		s.logger.Debug("job is frozen, skipping placements and updates")
+		diff.place = nil
+		diff.update = nil
+		diff.migrate = nil
+	}
+
 	// Add all the allocs to stop
 	for _, e := range diff.stop {
 		s.plan.AppendStoppedAlloc(e.Alloc, allocNotNeeded, "", "")
@@ -544,6 +553,7 @@ func (s *SystemScheduler) canHandle(trigger string) bool {
 	case structs.EvalTriggerQueuedAllocs:
 	case structs.EvalTriggerScaling:
 	case structs.EvalTriggerReconnect:
+	case structs.EvalTriggerJobUnfreeze:
 	default:
 		switch s.sysbatch {
 		case true:
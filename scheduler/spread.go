@@ -4,6 +4,9 @@
 package scheduler
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -12,6 +15,11 @@ const (
 	// implicitTarget is used to represent any remaining attribute values
 	// when target percentages don't add up to 100
 	implicitTarget = "*"
+
+	// topologyDelimiter separates the values of each level in the property
+	// value tracked for a topology spread. It is not expected to appear in
+	// node attribute values.
+	topologyDelimiter = "\x00"
 )
 
 // SpreadIterator is used to spread allocations across a specified attribute
@@ -94,19 +102,13 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 	if _, ok := iter.groupPropertySets[tg.Name]; !ok {
 		// First add property sets that are at the job level for this task group
 		for _, spread := range iter.jobSpreads {
-			pset := NewPropertySet(iter.ctx, iter.job)
-			pset.SetTargetAttribute(spread.Attribute, tg.Name)
-			pset.SetTargetValues(helper.ConvertSlice(spread.SpreadTarget,
-				func(t *structs.SpreadTarget) string { return t.Value }))
+			pset := iter.newSpreadPropertySet(spread, tg.Name)
 			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
 		}
 
 		// Include property sets at the task group level
 		for _, spread := range tg.Spreads {
-			pset := NewPropertySet(iter.ctx, iter.job)
-			pset.SetTargetAttribute(spread.Attribute, tg.Name)
-			pset.SetTargetValues(helper.ConvertSlice(spread.SpreadTarget,
-				func(t *structs.SpreadTarget) string { return t.Value }))
+			pset := iter.newSpreadPropertySet(spread, tg.Name)
 			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
 		}
 	}
@@ -121,6 +123,20 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 
 }
 
+// newSpreadPropertySet builds the property set tracking the attribute values
+// used by the task group for the given spread
+func (iter *SpreadIterator) newSpreadPropertySet(spread *structs.Spread, tgName string) *propertySet {
+	pset := NewPropertySet(iter.ctx, iter.job)
+	if len(spread.Topology) > 0 {
+		pset.SetTargetTopology(spread.Topology, tgName)
+		return pset
+	}
+	pset.SetTargetAttribute(spread.Attribute, tgName)
+	pset.SetTargetValues(helper.ConvertSlice(spread.SpreadTarget,
+		func(t *structs.SpreadTarget) string { return t.Value }))
+	return pset
+}
+
 func (iter *SpreadIterator) hasSpreads() bool {
 	return iter.hasSpread
 }
@@ -162,7 +178,12 @@ func (iter *SpreadIterator) Next() *RankedNode {
 				continue
 			}
 
-			if len(spreadDetails.desiredCounts) == 0 {
+			if pset.topology != nil {
+				// Topology spreads have no targets, the domains at each
+				// level are discovered from the nodes and spread evenly
+				scoreBoost := topologySpreadScoreBoost(pset, option.Node)
+				totalSpreadScore += scoreBoost
+			} else if len(spreadDetails.desiredCounts) == 0 {
 				// When desired counts map is empty the user didn't specify any targets
 				// Use even spreading scoring algorithm for this scenario
 				scoreBoost := evenSpreadScoreBoost(pset, option.Node)
@@ -224,6 +245,52 @@ func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
 	if !ok {
 		return -1.0
 	}
+	return evenSpreadBoost(combinedUseMap, nValue)
+}
+
+// topologySpreadScoreBoost is a scoring helper that calculates the score for
+// the option when spreading across a topology of failure domains. Each level
+// is scored as an even spread among the domains sharing the option's parent
+// domain. Every level weighs twice as much as the level below it, so that
+// balancing zones takes precedence over balancing the racks within a zone.
+func topologySpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
+	nValue, ok := pset.getProperty(option)
+
+	// Maximum possible penalty when any level isn't set on the node
+	if !ok {
+		return -1.0
+	}
+	path := strings.Split(nValue, topologyDelimiter)
+	combinedUseMap := pset.GetCombinedUseMap()
+
+	totalBoost, sumWeights := 0.0, 0.0
+	weight := 1.0
+	for level := len(path) - 1; level >= 0; level-- {
+		// Count the allocations in each domain of this level that shares
+		// the option's parent domain
+		levelUseMap := make(map[string]uint64)
+		for value, count := range combinedUseMap {
+			valuePath := strings.Split(value, topologyDelimiter)
+			if len(valuePath) != len(path) || !slices.Equal(valuePath[:level], path[:level]) {
+				continue
+			}
+			levelUseMap[valuePath[level]] += count
+		}
+
+		totalBoost += weight * evenSpreadBoost(levelUseMap, path[level])
+		sumWeights += weight
+		weight *= 2
+	}
+	return totalBoost / sumWeights
+}
+
+// evenSpreadBoost calculates the even spread score of placing on the
+// attribute value nValue, given the use count of each attribute value
+func evenSpreadBoost(combinedUseMap map[string]uint64, nValue string) float64 {
+	if len(combinedUseMap) == 0 {
+		// Nothing placed yet, so return 0 as the score
+		return 0.0
+	}
 	currentAttributeCount := combinedUseMap[nValue]
 	minCount := uint64(0)
 	maxCount := uint64(0)
@@ -286,8 +353,22 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 			remainingCount := float64(totalCount) - sumDesiredCounts
 			si.desiredCounts[implicitTarget] = remainingCount
 		}
-		spreadInfos[spread.Attribute] = si
+		spreadInfos[spreadAttribute(spread)] = si
 		iter.sumSpreadWeights += int32(spread.Weight)
 	}
 	iter.tgSpreadInfo[tg.Name] = spreadInfos
 }
+
+// spreadAttribute returns the attribute tracked by the property set of the
+// spread, which is used to look up its spread info
+func spreadAttribute(spread *structs.Spread) string {
+	if len(spread.Topology) > 0 {
+		return topologyAttribute(spread.Topology)
+	}
+	return spread.Attribute
+}
+
+// topologyAttribute returns the attribute describing a topology spread
+func topologyAttribute(topology []string) string {
+	return strings.Join(topology, " > ")
+}
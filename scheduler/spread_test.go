@@ -476,6 +476,92 @@ func TestSpreadIterator_EvenSpread(t *testing.T) {
 }
 
 // Test scenarios where the spread iterator sets maximum penalty (-1.0)
+func TestSpreadIterator_Topology(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	domains := [][]string{
+		{"z1", "r1"}, {"z1", "r2"}, {"z2", "r1"}, {"z2", "r2"},
+		{"z1", "r1"}, {"z1", "r2"}, {"z2", "r1"}, {"z2", "r2"},
+		{"z1", ""},
+	}
+	var nodes []*RankedNode
+	for i, domain := range domains {
+		node := mock.Node()
+		node.Attributes["platform.aws.placement.availability-zone"] = domain[0]
+		if domain[1] != "" {
+			node.Meta["rack"] = domain[1]
+		}
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, &RankedNode{Node: node})
+	}
+	nodeDomain := func(n *structs.Node) string {
+		return n.Attributes["platform.aws.placement.availability-zone"] + "/" + n.Meta["rack"]
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Count = 8
+	tg.Spreads = []*structs.Spread{{
+		Weight: 100,
+		Topology: []string{
+			"${attr.platform.aws.placement.availability-zone}",
+			"${meta.rack}",
+		},
+	}}
+
+	placeOn := func(node *RankedNode) {
+		ctx.plan.NodeAllocation[node.Node.ID] = append(ctx.plan.NodeAllocation[node.Node.ID],
+			&structs.Allocation{
+				Namespace: structs.DefaultNamespace,
+				TaskGroup: tg.Name,
+				JobID:     job.ID,
+				Job:       job,
+				ID:        uuid.Generate(),
+				NodeID:    node.Node.ID,
+			})
+	}
+	scoreNodes := func() map[string]string {
+		for _, node := range nodes {
+			node.Scores = nil
+			node.FinalScore = 0
+		}
+		static := NewStaticRankIterator(ctx, nodes)
+		spreadIter := NewSpreadIterator(ctx, static)
+		spreadIter.SetJob(job)
+		spreadIter.SetTaskGroup(tg)
+		scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+
+		scores := map[string]string{}
+		for _, rn := range collectRanked(scoreNorm) {
+			scores[nodeDomain(rn.Node)] = fmt.Sprintf("%.3f", rn.FinalScore)
+		}
+		return scores
+	}
+
+	// Place in z1/r1, so the other zone is preferred, followed by the other
+	// rack of the same zone
+	placeOn(nodes[0])
+	must.Eq(t, map[string]string{
+		"z1/r1": "-1.000",
+		"z1/r2": "-0.333",
+		"z2/r1": "0.667",
+		"z2/r2": "0.667",
+		"z1/":   "-1.000",
+	}, scoreNodes())
+
+	// Place in z2/r1, so both zones are balanced and the unused rack of
+	// each zone is preferred
+	placeOn(nodes[2])
+	must.Eq(t, map[string]string{
+		"z1/r1": "-1.000",
+		"z1/r2": "-0.333",
+		"z2/r1": "-1.000",
+		"z2/r2": "-0.333",
+		"z1/":   "-1.000",
+	}, scoreNodes())
+}
+
 func TestSpreadIterator_MaxPenalty(t *testing.T) {
 	ci.Parallel(t)
 
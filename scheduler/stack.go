@@ -61,6 +61,7 @@ type GenericStack struct {
 	taskGroupHostVolumes *HostVolumeChecker
 	taskGroupCSIVolumes  *CSIVolumeChecker
 	taskGroupNetwork     *NetworkChecker
+	readinessGates       *ReadinessGateChecker
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
@@ -117,6 +118,7 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
+	s.readinessGates.SetJob(job)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetJob(job)
@@ -218,6 +220,7 @@ type SystemStack struct {
 	taskGroupHostVolumes *HostVolumeChecker
 	taskGroupCSIVolumes  *CSIVolumeChecker
 	taskGroupNetwork     *NetworkChecker
+	readinessGates       *ReadinessGateChecker
 
 	distinctPropertyConstraint *DistinctPropertyIterator
 	binPack                    *BinPackIterator
@@ -258,6 +261,9 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Filter on available client networks
 	s.taskGroupNetwork = NewNetworkChecker(ctx)
 
+	// Filter on nodes whose node pool readiness gates are healthy
+	s.readinessGates = NewReadinessGateChecker(ctx)
+
 	// Create the feasibility wrapper which wraps all feasibility checks in
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
@@ -272,6 +278,7 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	avail := []FeasibilityChecker{
 		s.taskGroupHostVolumes,
 		s.taskGroupCSIVolumes,
+		s.readinessGates,
 	}
 	s.wrappedChecks = NewFeasibilityWrapper(ctx, s.source, jobs, tgs, avail)
 
@@ -328,6 +335,7 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
+	s.readinessGates.SetJob(job)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetJob(job)
@@ -409,6 +417,9 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on available client networks
 	s.taskGroupNetwork = NewNetworkChecker(ctx)
 
+	// Filter on nodes whose node pool readiness gates are healthy
+	s.readinessGates = NewReadinessGateChecker(ctx)
+
 	// Create the feasibility wrapper which wraps all feasibility checks in
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
@@ -423,6 +434,7 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	avail := []FeasibilityChecker{
 		s.taskGroupHostVolumes,
 		s.taskGroupCSIVolumes,
+		s.readinessGates,
 	}
 	s.wrappedChecks = NewFeasibilityWrapper(ctx, s.source, jobs, tgs, avail)
 
@@ -123,6 +123,34 @@ func progressMade(result *structs.PlanResult) bool {
 		len(result.DeploymentUpdates) != 0)
 }
 
+// addPlanRejections records the reasons the plan applier rejected the
+// placements of the plan in the failed allocation metrics of their task
+// groups, so that they are surfaced in the evaluation's failed placements
+// instead of only being logged by the leader.
+func addPlanRejections(failed map[string]*structs.AllocMetric, plan *structs.Plan, result *structs.PlanResult) map[string]*structs.AllocMetric {
+	if plan == nil || result == nil {
+		return failed
+	}
+
+	for nodeID, reason := range result.NodeRejections {
+		for _, alloc := range plan.NodeAllocation[nodeID] {
+			if failed == nil {
+				failed = make(map[string]*structs.AllocMetric)
+			}
+
+			metric, ok := failed[alloc.TaskGroup]
+			if ok {
+				metric.CoalescedFailures++
+			} else {
+				metric = &structs.AllocMetric{}
+				failed[alloc.TaskGroup] = metric
+			}
+			metric.RejectPlan(nodeID, reason)
+		}
+	}
+	return failed
+}
+
 // taintedNodes is used to scan the allocations and then check if the
 // underlying nodes are tainted, and should force a migration of the allocation,
 // or if the underlying nodes are disconnected, and should be used to calculate
@@ -570,6 +598,88 @@ func networkPortMap(n *structs.NetworkResource) structs.AllocatedPorts {
 	return m
 }
 
+// preserveNetworkIdentity carries the network identity of a previous
+// allocation over to its replacement when the task group network sets
+// preserve_identity and the replacement was placed on the same node. The
+// bridge address is handed to the client to request again, and the dynamic
+// ports of the replacement are swapped for the previous values that are still
+// free on the node.
+func preserveNetworkIdentity(ctx Context, node *structs.Node, tg *structs.TaskGroup, alloc, prev *structs.Allocation) error {
+	if len(tg.Networks) == 0 || !tg.Networks[0].PreserveIdentity || prev.NodeID != node.ID {
+		return nil
+	}
+	ask := tg.Networks[0]
+
+	if ask.Mode == "bridge" && prev.NetworkStatus != nil {
+		alloc.PreservedNetworkAddress = prev.NetworkStatus.Address
+	}
+
+	if len(ask.DynamicPorts) == 0 || prev.AllocatedResources == nil || alloc.AllocatedResources == nil {
+		return nil
+	}
+	prevPorts := prev.AllocatedResources.Shared.Ports
+	shared := &alloc.AllocatedResources.Shared
+	if len(prevPorts) == 0 || len(shared.Ports) == 0 {
+		return nil
+	}
+
+	proposed, err := ctx.ProposedAllocs(node.ID)
+	if err != nil {
+		return err
+	}
+	netIdx := structs.NewNetworkIndex()
+	defer netIdx.Release()
+	if err := netIdx.SetNode(node); err != nil {
+		return nil
+	}
+	netIdx.AddAllocs(proposed)
+
+	inOffer := make(map[int]struct{}, len(shared.Ports))
+	for _, port := range shared.Ports {
+		inOffer[port.Value] = struct{}{}
+	}
+
+	for _, askPort := range ask.DynamicPorts {
+		i := slices.IndexFunc(shared.Ports, func(p structs.AllocatedPortMapping) bool {
+			return p.Label == askPort.Label
+		})
+		prevPort, ok := prevPorts.Get(askPort.Label)
+		if i < 0 || !ok {
+			continue
+		}
+		port := shared.Ports[i]
+		if prevPort.HostIP != port.HostIP || prevPort.Value == port.Value {
+			continue
+		}
+		if prevPort.Value < netIdx.MinDynamicPort || prevPort.Value > netIdx.MaxDynamicPort {
+			continue
+		}
+		if _, ok := inOffer[prevPort.Value]; ok {
+			continue
+		}
+		if used := netIdx.UsedPorts[port.HostIP]; used != nil && used.Check(uint(prevPort.Value)) {
+			continue
+		}
+
+		delete(inOffer, port.Value)
+		inOffer[prevPort.Value] = struct{}{}
+
+		shared.Ports[i].Value = prevPort.Value
+		if askPort.To == -1 {
+			shared.Ports[i].To = prevPort.Value
+		}
+		for _, nw := range shared.Networks {
+			for j, p := range nw.DynamicPorts {
+				if p.Label == askPort.Label {
+					nw.DynamicPorts[j].Value = shared.Ports[i].Value
+					nw.DynamicPorts[j].To = shared.Ports[i].To
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // renderTemplatesUpdated returns the difference in the RestartPolicy's
 // render_templates field, if set
 func renderTemplatesUpdated(a, b *structs.RestartPolicy, msg string) comparison {
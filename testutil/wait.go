@@ -4,6 +4,7 @@
 package testutil
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -181,12 +182,38 @@ func WaitForKeyring(t testing.TB, rpc rpcFn, region string) {
 	reply := structs.KeyringListPublicResponse{}
 	WaitForResult(func() (bool, error) {
 		err := rpc("Keyring.ListPublic", &args, &reply)
-		return len(reply.PublicKeys) > 0, err
+		if err != nil {
+			return false, err
+		}
+		if len(reply.PublicKeys) == 0 {
+			return false, keyringHealthError(rpc, region)
+		}
+		return true, nil
 	}, func(err error) {
 		t.Fatalf("timed out waiting for keyring to initialize: %v", err)
 	})
 }
 
+// keyringHealthError returns the error reported while the keyring has no keys,
+// naming the failing KEK providers if the leader can report them.
+func keyringHealthError(rpc rpcFn, region string) error {
+	args := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: region,
+		},
+	}
+	reply := structs.KeyringHealthResponse{}
+	if err := rpc("Keyring.Health", &args, &reply); err == nil {
+		for _, provider := range reply.Providers {
+			if !provider.Healthy {
+				return fmt.Errorf("keyring has not been initialized yet: KEK provider %q is failing: %s",
+					provider.ID, provider.Error)
+			}
+		}
+	}
+	return errors.New("keyring has not been initialized yet")
+}
+
 // WaitForClient blocks until the client can be found
 func WaitForClient(t testing.TB, rpc rpcFn, nodeID string, region string) {
 	t.Helper()